@@ -0,0 +1,158 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/output"
+	"github.com/okteto/okteto/pkg/stream"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	errAuditNotAllowed = oktetoErrors.UserError{
+		E:    errors.New("you are not allowed to read the audit log of this Okteto instance"),
+		Hint: "Only the administrators of your Okteto instance can read the audit log",
+	}
+)
+
+// auditFlags are the flags of the audit command
+type auditFlags struct {
+	user      string
+	namespace string
+	action    string
+	output    string
+	since     time.Duration
+}
+
+type auditCommand struct {
+	okClient types.OktetoInterface
+	now      func() time.Time
+	flags    *auditFlags
+}
+
+// Audit shows the audit events of an Okteto instance
+func Audit(ctx context.Context) *cobra.Command {
+	flags := &auditFlags{}
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Show who deployed, destroyed, slept or woke resources of your Okteto instance",
+		Args:  utils.NoArgsAccepted(""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.Validate(flags.output); err != nil {
+				return err
+			}
+
+			ctxOptions := &contextCMD.ContextOptions{
+				Show: flags.output == "",
+			}
+			if err := contextCMD.NewContextCommand().Run(ctx, ctxOptions); err != nil {
+				return err
+			}
+
+			if !okteto.IsOkteto() {
+				return oktetoErrors.ErrContextIsNotOktetoCluster
+			}
+
+			okClient, err := okteto.NewOktetoClient()
+			if err != nil {
+				return err
+			}
+			c := &auditCommand{
+				okClient: okClient,
+				flags:    flags,
+				now:      time.Now,
+			}
+			return c.run(ctx, os.Stdout)
+		},
+	}
+	cmd.Flags().DurationVar(&flags.since, "since", 24*time.Hour, "show the events newer than a relative duration like 30m or 24h")
+	cmd.Flags().StringVar(&flags.user, "user", "", "only show the events of this user")
+	cmd.Flags().StringVarP(&flags.namespace, "namespace", "n", "", "only show the events of this namespace")
+	cmd.Flags().StringVar(&flags.action, "action", "", "only show the events of this action, like deploy, destroy, sleep or wake")
+	output.AddFlag(cmd, &flags.output)
+	return cmd
+}
+
+func (c *auditCommand) run(ctx context.Context, w io.Writer) error {
+	if c.flags.since <= 0 {
+		return fmt.Errorf("invalid value for --since: it must be a positive duration")
+	}
+
+	events := []types.AuditEvent{}
+	since := c.now().Add(-c.flags.since)
+	err := c.okClient.Stream().AuditEvents(ctx, since, func(e types.AuditEvent) {
+		if c.flags.matches(e) {
+			events = append(events, e)
+		}
+	})
+	if err != nil {
+		var statusErr stream.StatusError
+		if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusForbidden {
+			return errAuditNotAllowed
+		}
+		return fmt.Errorf("failed to get the audit events: %w", err)
+	}
+
+	return displayAuditEvents(w, events, c.flags.output)
+}
+
+// matches returns if the event passes the user, namespace and action filters
+func (f *auditFlags) matches(e types.AuditEvent) bool {
+	if f.user != "" && !strings.EqualFold(f.user, e.User) {
+		return false
+	}
+	if f.namespace != "" && f.namespace != e.Namespace {
+		return false
+	}
+	if f.action != "" && !strings.EqualFold(f.action, e.Action) {
+		return false
+	}
+	return true
+}
+
+// displayAuditEvents prints the audit events
+func displayAuditEvents(w io.Writer, events []types.AuditEvent, outputFormat string) error {
+	return output.Print(w, outputFormat, events, func(w io.Writer) error {
+		if len(events) == 0 {
+			fmt.Fprintln(w, "There are no audit events")
+			return nil
+		}
+		table := oktetoLog.NewTable(
+			oktetoLog.Column{Header: "Time"},
+			oktetoLog.Column{Header: "User"},
+			oktetoLog.Column{Header: "Action"},
+			oktetoLog.Column{Header: "Namespace"},
+			oktetoLog.Column{Header: "Resource"},
+		)
+		for _, e := range events {
+			table.AddRow(e.Time.Local().Format(time.DateTime), e.User, e.Action, e.Namespace, e.Resource)
+		}
+		return table.Render(w, "")
+	})
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	client "github.com/okteto/okteto/pkg/okteto/fake"
+	"github.com/okteto/okteto/pkg/stream"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var auditEvents = []types.AuditEvent{
+	{User: "cindy", Namespace: "cindy", Action: "deploy", Resource: "movies"},
+	{User: "john", Namespace: "john", Action: "destroy", Resource: "movies"},
+	{User: "cindy", Namespace: "preview-1", Action: "sleep", Resource: "preview-1"},
+}
+
+func Test_auditCommandRun(t *testing.T) {
+	var tests = []struct {
+		streamErr   error
+		expectedErr error
+		flags       *auditFlags
+		name        string
+		expected    []types.AuditEvent
+	}{
+		{
+			name:     "all events",
+			flags:    &auditFlags{since: time.Hour},
+			expected: auditEvents,
+		},
+		{
+			name:     "filter by user",
+			flags:    &auditFlags{since: time.Hour, user: "Cindy"},
+			expected: []types.AuditEvent{auditEvents[0], auditEvents[2]},
+		},
+		{
+			name:     "filter by namespace and action",
+			flags:    &auditFlags{since: time.Hour, namespace: "john", action: "destroy"},
+			expected: []types.AuditEvent{auditEvents[1]},
+		},
+		{
+			name:        "not admin",
+			flags:       &auditFlags{since: time.Hour},
+			streamErr:   stream.StatusError{Status: "403 Forbidden", StatusCode: http.StatusForbidden},
+			expectedErr: errAuditNotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.flags.output = "json"
+			c := &auditCommand{
+				okClient: &client.FakeOktetoClient{
+					StreamClient: client.NewFakeStreamClient(&client.FakeStreamResponse{
+						AuditEvents: auditEvents,
+						StreamErr:   tt.streamErr,
+					}),
+				},
+				flags: tt.flags,
+				now:   time.Now,
+			}
+			var buf bytes.Buffer
+			err := c.run(context.Background(), &buf)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			result := []types.AuditEvent{}
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_displayAuditEvents(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, displayAuditEvents(&buf, nil, ""))
+	assert.Equal(t, "There are no audit events\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, displayAuditEvents(&buf, auditEvents, ""))
+	assert.Contains(t, buf.String(), "preview-1")
+}
@@ -14,9 +14,15 @@
 package cmd
 
 import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/analytics"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/output"
 	"github.com/spf13/cobra"
 )
 
@@ -36,9 +42,58 @@ func Analytics() *cobra.Command {
 		},
 	}
 	cmd.Flags().BoolVarP(&disable, "disable", "d", false, "disable analytics")
+
+	cmd.AddCommand(analyticsStatus())
+	cmd.AddCommand(&cobra.Command{
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#analytics"),
+		Use:   "enable",
+		Short: "Enable analytics, including anonymized command metrics",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return enableAnalytics()
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#analytics"),
+		Use:   "disable",
+		Short: "Disable analytics",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return disableAnalytics()
+		},
+	})
+	return cmd
+}
+
+func analyticsStatus() *cobra.Command {
+	var outputFormat string
+	cmd := &cobra.Command{
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#analytics"),
+		Use:   "status",
+		Short: "Show the analytics configuration",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return printAnalyticsStatus(analytics.GetStatus(), outputFormat, os.Stdout)
+		},
+	}
+	output.AddFlag(cmd, &outputFormat)
 	return cmd
 }
 
+func printAnalyticsStatus(status analytics.Status, outputFormat string, w io.Writer) error {
+	return output.Print(w, outputFormat, status, func(w io.Writer) error {
+		tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+		fmt.Fprintf(tw, "Analytics:\t%s\n", enabledString(status.Enabled))
+		fmt.Fprintf(tw, "Command metrics:\t%s\n", enabledString(status.CommandMetrics))
+		fmt.Fprintf(tw, "Pending events:\t%d\n", status.SpooledEvents)
+		return tw.Flush()
+	})
+}
+
+func enabledString(enabled bool) string {
+	if enabled {
+		return "enabled"
+	}
+	return "disabled"
+}
+
 func disableAnalytics() error {
 	if err := analytics.Disable(); err != nil {
 		return err
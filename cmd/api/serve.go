@@ -0,0 +1,63 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/cmd/api"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/spf13/cobra"
+)
+
+// Serve serves the okteto local API on a unix socket until the command is interrupted
+func Serve(ctx context.Context) *cobra.Command {
+	var socket string
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve the okteto local API on a unix socket",
+		Long: `Serve the okteto local API on a unix socket.
+
+The API is versioned under the '/` + api.Version + `' prefix and returns JSON documents:
+  GET  /` + api.Version + `/version                            the version of the API and the CLI
+  GET  /` + api.Version + `/contexts                           the okteto contexts
+  GET  /` + api.Version + `/status?name=<dev>&namespace=<ns>   the state of a development container
+  POST /` + api.Version + `/up                                 run 'okteto up' in the background
+  POST /` + api.Version + `/up/stop                            stop the 'okteto up' running in the background
+  POST /` + api.Version + `/down                               deactivate a development container
+  GET  /` + api.Version + `/logs?name=<dev>&service=<svc>      stream the logs of a development environment`,
+		Args: utils.NoArgsAccepted(""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if socket == "" {
+				socket = api.GetSocketPath()
+			}
+			server, err := api.NewServer(socket)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+			defer cancel()
+
+			oktetoLog.Information("Serving the okteto API on %s", socket)
+			return server.Serve(ctx)
+		},
+	}
+	cmd.Flags().StringVarP(&socket, "socket", "", "", "path of the unix socket (defaults to $OKTETO_HOME/okteto-api.sock)")
+	return cmd
+}
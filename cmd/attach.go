@@ -0,0 +1,147 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/cmd/daemon"
+	"github.com/okteto/okteto/pkg/config"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+// attachFlags is the input of the user to attach command
+type attachFlags struct {
+	manifestPath string
+	namespace    string
+	k8sContext   string
+	noTTY        bool
+}
+
+// Attach opens a terminal in a development container activated by 'okteto up --detach'
+func Attach() *cobra.Command {
+	flags := &attachFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "attach [service]",
+		Short: "Open a terminal in a development container running in the background",
+		Args:  utils.MaximumNArgsAccepted(1, "https://okteto.com/docs/reference/cli/#attach"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			manifestOpts := contextCMD.ManifestOptions{Filename: flags.manifestPath, Namespace: flags.namespace, K8sContext: flags.k8sContext}
+			manifest, err := contextCMD.LoadManifestWithContext(ctx, manifestOpts)
+			if err != nil {
+				return err
+			}
+
+			dev, err := getDetachedDev(manifest, args)
+			if err != nil {
+				return err
+			}
+
+			s, err := daemon.Read(dev.Namespace, dev.Name)
+			if err != nil {
+				if errors.Is(err, daemon.ErrNotFound) {
+					return oktetoErrors.UserError{
+						E:    fmt.Errorf("development container '%s' is not running in the background", dev.Name),
+						Hint: fmt.Sprintf("Run 'okteto up %s --detach' to launch it in the background", dev.Name),
+					}
+				}
+				return err
+			}
+
+			state, err := s.CheckHealth()
+			if err != nil {
+				if errors.Is(err, daemon.ErrNotRunning) {
+					if err := daemon.Delete(dev.Namespace, dev.Name); err != nil {
+						oktetoLog.Infof("failed to delete stale daemon state file: %s", err)
+					}
+					return oktetoErrors.UserError{
+						E:    fmt.Errorf("the background 'okteto up' for '%s' is no longer running", dev.Name),
+						Hint: fmt.Sprintf("Find the daemon logs at %s and run 'okteto up %s --detach' to launch it again", s.LogFile, dev.Name),
+					}
+				}
+				return err
+			}
+			if state != config.Ready {
+				oktetoLog.Information("Development container '%s' is %s, waiting until it's ready...", dev.Name, state)
+			}
+
+			oktetoLog.Information("Attached to '%s'. Exit the terminal to detach, the background 'okteto up' keeps running", dev.Name)
+			tty := shouldAllocateTTY(flags.noTTY, os.Stdin)
+
+			t := time.NewTicker(1 * time.Second)
+			defer t.Stop()
+			err = executeExec(ctx, dev, dev.Command.Values, tty)
+			for oktetoErrors.IsTransient(err) {
+				oktetoLog.Yellow("Connection lost to your development container, reconnecting...")
+				<-t.C
+				err = executeExec(ctx, dev, dev.Command.Values, tty)
+			}
+			return err
+		},
+	}
+
+	cmd.Flags().StringVarP(&flags.manifestPath, "file", "f", utils.DefaultManifest, "path to the manifest file")
+	cmd.Flags().StringVarP(&flags.namespace, "namespace", "n", "", "namespace where the attach command is executed")
+	cmd.Flags().StringVarP(&flags.k8sContext, "context", "c", "", "context where the attach command is executed")
+	cmd.Flags().BoolVar(&flags.noTTY, "no-tty", false, "run the command without allocating a TTY. It's disabled automatically when stdin is not a terminal")
+
+	return cmd
+}
+
+// getDetachedDev returns the development container to attach to, selecting it among the ones running in the background when not given
+func getDetachedDev(manifest *model.Manifest, args []string) (*model.Dev, error) {
+	devName := ""
+	if len(args) == 1 {
+		devName = args[0]
+	}
+	dev, err := utils.GetDevFromManifest(manifest, devName)
+	if err == nil {
+		return dev, nil
+	}
+	if !errors.Is(err, utils.ErrNoDevSelected) {
+		return nil, err
+	}
+
+	detached := []string{}
+	for _, name := range manifest.Dev.GetDevs() {
+		d := manifest.Dev[name]
+		if _, err := daemon.Read(d.Namespace, d.Name); err == nil {
+			detached = append(detached, name)
+		}
+	}
+	switch len(detached) {
+	case 0:
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("there are no development containers running in the background"),
+			Hint: "Run 'okteto up --detach' to launch your development container in the background",
+		}
+	case 1:
+		return manifest.Dev[detached[0]], nil
+	}
+	selector := utils.NewOktetoSelector("Select which development container to attach to:", "Development container")
+	return utils.SelectDevFromManifest(manifest, selector, detached)
+}
@@ -31,12 +31,15 @@ import (
 	buildCmd "github.com/okteto/okteto/pkg/cmd/build"
 	"github.com/okteto/okteto/pkg/discovery"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/log/io"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/registry"
+	"github.com/okteto/okteto/pkg/scan"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 type outputFormat string
@@ -94,11 +97,25 @@ const (
 // Build build and optionally push a Docker image
 func Build(ctx context.Context, ioCtrl *io.IOController, at analyticsTrackerInterface) *cobra.Command {
 	options := &types.BuildOptions{}
+	var output string
 	cmd := &cobra.Command{
 		Use:   "build [service...]",
 		Short: "Build and push the images defined in the 'build' section of your okteto manifest",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			options.CommandArgs = args
+			if output != "" {
+				parsed, err := buildCmd.ParseOutput(output)
+				if err != nil {
+					return err
+				}
+				options.Output = parsed
+			}
+			if options.ScanFailOn != "" {
+				if _, err := scan.ParseSeverity(options.ScanFailOn); err != nil {
+					return err
+				}
+				options.Scan = true
+			}
 			// The context must be loaded before reading manifest. Otherwise,
 			// secrets will not be resolved when GetManifest is called and
 			// the manifest will load empty values.
@@ -126,7 +143,10 @@ func Build(ctx context.Context, ioCtrl *io.IOController, at analyticsTrackerInte
 				}
 			}
 
-			return builder.Build(ctx, options)
+			// the values of the build secrets are masked from the build output
+			oktetoLog.EnableMasking()
+			defer oktetoLog.DisableMasking()
+			return oktetoErrors.WithExitCode(builder.Build(ctx, options), oktetoErrors.ExitCodeBuild)
 		},
 	}
 
@@ -137,15 +157,29 @@ func Build(ctx context.Context, ioCtrl *io.IOController, at analyticsTrackerInte
 	cmd.Flags().BoolVarP(&options.NoCache, "no-cache", "", false, "do not use cache when building the image")
 	cmd.Flags().StringArrayVar(&options.CacheFrom, "cache-from", nil, "cache source images")
 	cmd.Flags().StringArrayVar(&options.ExportCache, "export-cache", nil, "export cache images")
-	cmd.Flags().StringVarP(&options.OutputMode, "progress", "", string(TTYFormat), "show plain/tty build output")
+	cmd.Flags().StringVarP(&options.OutputMode, "progress", "", string(TTYFormat), "show plain/tty/json build output")
 	cmd.Flags().StringArrayVar(&options.BuildArgs, "build-arg", nil, "set build-time variables")
-	cmd.Flags().StringArrayVar(&options.Secrets, "secret", nil, "secret files exposed to the build. Format: id=mysecret,src=/local/secret")
-	cmd.Flags().StringVar(&options.Platform, "platform", "", "set platform if server is multi-platform capable")
+	cmd.Flags().StringArrayVar(&options.Secrets, "build-secret", nil, "secret files or env vars exposed to the build. Format: id=mysecret,src=/local/secret or id=mysecret,env=MY_SECRET")
+	cmd.Flags().SetNormalizeFunc(normalizeSecretFlag)
+	cmd.Flags().StringVar(&options.Platform, "platform", "", "set the target platform(s) of the image. A comma separated list like 'linux/amd64,linux/arm64' builds a multi-platform image")
 	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "namespace against which the image will be consumed. Default is the one defined at okteto context or okteto manifest")
 	cmd.Flags().BoolVarP(&options.BuildToGlobal, "global", "", false, "push the image to the global registry")
+	cmd.Flags().StringVar(&output, "output", "", "export the image to a local docker archive (type=docker,dest=image.tar) or OCI layout directory (type=oci,dest=./image) instead of pushing it")
+	cmd.Flags().BoolVarP(&options.Scan, "scan", "", false, "scan the built images for vulnerabilities with trivy")
+	cmd.Flags().StringVarP(&options.ScanFailOn, "fail-on", "", "", "fail the build if the scan finds vulnerabilities of this severity or higher (low, medium, high, critical). Implies --scan")
+	cmd.Flags().BoolVarP(&options.Lock, "lock", "", false, "write the digests of the built images to okteto.lock.yml, next to the okteto manifest. 'okteto deploy' and 'okteto up' use the locked images while their sources don't change")
+	cmd.Flags().BoolVarP(&options.Explain, "explain", "", false, "print the inputs hashed by smart builds and why each image is built or skipped")
 	return cmd
 }
 
+// normalizeSecretFlag keeps '--secret' working as an alias of '--build-secret'
+func normalizeSecretFlag(_ *pflag.FlagSet, name string) pflag.NormalizedName {
+	if name == "secret" {
+		name = "build-secret"
+	}
+	return pflag.NormalizedName(name)
+}
+
 func (bc *Command) getBuilder(options *types.BuildOptions, okCtx *okteto.OktetoContextStateless) (Builder, error) {
 	var builder Builder
 
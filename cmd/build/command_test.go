@@ -14,6 +14,7 @@
 package build
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
@@ -467,3 +468,16 @@ func Test_defaultOktetoClientCfg(t *testing.T) {
 		})
 	}
 }
+
+func Test_BuildSecretFlags(t *testing.T) {
+	cmd := Build(context.Background(), io.NewIOController(), fakeAnalyticsTracker{})
+	err := cmd.Flags().Parse([]string{
+		"--build-secret", "id=npmrc,src=/tmp/npmrc",
+		"--secret", "id=token,env=MY_TOKEN",
+	})
+	require.NoError(t, err)
+
+	secrets, err := cmd.Flags().GetStringArray("build-secret")
+	require.NoError(t, err)
+	require.Equal(t, []string{"id=npmrc,src=/tmp/npmrc", "id=token,env=MY_TOKEN"}, secrets)
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/okteto/okteto/pkg/log/io"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/registry"
+	"github.com/okteto/okteto/pkg/scan"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/spf13/afero"
 )
@@ -46,6 +47,7 @@ type oktetoRegistryInterface interface {
 type OktetoBuilder struct {
 	Builder  OktetoBuilderInterface
 	Registry oktetoRegistryInterface
+	Scanner  scan.Scanner
 	IoCtrl   *io.IOController
 }
 
@@ -54,6 +56,7 @@ func NewBuilder(builder OktetoBuilderInterface, registry oktetoRegistryInterface
 	return &OktetoBuilder{
 		Builder:  builder,
 		Registry: registry,
+		Scanner:  scan.NewTrivy(),
 		IoCtrl:   ioCtrl,
 	}
 }
@@ -116,7 +119,9 @@ func (ob *OktetoBuilder) Build(ctx context.Context, options *types.BuildOptions)
 		return err
 	}
 
-	if options.Tag == "" {
+	if options.Output != nil {
+		ob.IoCtrl.Out().Success("Image exported to '%s'", options.Output.Dest)
+	} else if options.Tag == "" {
 		ob.IoCtrl.Out().Success("Build succeeded")
 		ob.IoCtrl.Out().Infof("Your image won't be pushed. To push your image specify the flag '-t'.")
 	} else {
@@ -128,5 +133,63 @@ func (ob *OktetoBuilder) Build(ctx context.Context, options *types.BuildOptions)
 	}
 
 	analytics.TrackBuild(true)
+	return ob.ScanImage(ctx, options)
+}
+
+// ScanImage scans the built image for vulnerabilities and fails if any of them has the severity of the '--fail-on' option or higher
+func (ob *OktetoBuilder) ScanImage(ctx context.Context, options *types.BuildOptions) error {
+	if !options.Scan {
+		return nil
+	}
+
+	threshold := scan.SeverityCritical
+	if options.ScanFailOn != "" {
+		var err error
+		threshold, err = scan.ParseSeverity(options.ScanFailOn)
+		if err != nil {
+			return err
+		}
+	}
+
+	target := scan.Target{Image: options.Tag}
+	if options.DevTag != "" {
+		target.Image = options.DevTag
+	}
+	if options.Output != nil {
+		target.Archive = options.Output.Dest
+	}
+	if target.String() == "" {
+		ob.IoCtrl.Out().Warning("Skipping the vulnerability scan because the image is not pushed. Specify the flag '-t' to scan it")
+		return nil
+	}
+
+	ob.IoCtrl.Out().Infof("Scanning '%s' for vulnerabilities...", target)
+	report, err := ob.Scanner.Scan(ctx, target)
+	if err != nil {
+		return err
+	}
+	ob.IoCtrl.Logger().Infof("vulnerabilities of '%s': %s", target, report.Summary())
+
+	vulnerabilities := report.AtLeast(threshold)
+	if len(vulnerabilities) == 0 {
+		ob.IoCtrl.Out().Success("No vulnerabilities with severity %s or higher found in '%s'", threshold, target)
+		return nil
+	}
+
+	ob.IoCtrl.Out().Warning("Found %d vulnerabilities with severity %s or higher in '%s'", len(vulnerabilities), threshold, target)
+	for _, v := range vulnerabilities {
+		fixed := "no fix available"
+		if v.FixedVersion != "" {
+			fixed = fmt.Sprintf("fixed in %s", v.FixedVersion)
+		}
+		ob.IoCtrl.Out().Println(fmt.Sprintf("  %s %s %s %s (%s)", v.Severity, v.ID, v.Package, v.InstalledVersion, fixed))
+	}
+
+	if options.ScanFailOn != "" {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("the image '%s' has %d vulnerabilities with severity %s or higher", target, len(vulnerabilities), threshold),
+			Hint: "Update the affected packages of your image or change the severity of the '--fail-on' option",
+		}
+	}
 	return nil
 }
@@ -24,6 +24,7 @@ import (
 	"github.com/okteto/okteto/pkg/env"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/log/io"
+	"github.com/okteto/okteto/pkg/scan"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -180,3 +181,85 @@ func createDockerfile(t *testing.T) (string, error) {
 	}
 	return dir, nil
 }
+
+type fakeScanner struct {
+	report *scan.Report
+	target scan.Target
+}
+
+func (fs *fakeScanner) Scan(_ context.Context, target scan.Target) (*scan.Report, error) {
+	fs.target = target
+	return fs.report, nil
+}
+
+func TestBuildWithScan(t *testing.T) {
+	report := &scan.Report{
+		Vulnerabilities: []scan.Vulnerability{
+			{ID: "CVE-1", Package: "openssl", Severity: scan.SeverityCritical},
+			{ID: "CVE-2", Package: "curl", Severity: scan.SeverityHigh},
+			{ID: "CVE-3", Package: "zlib", Severity: scan.SeverityLow},
+		},
+	}
+	tests := []struct {
+		name    string
+		failOn  string
+		wantErr bool
+	}{
+		{
+			name: "report only",
+		},
+		{
+			name:    "fail on high",
+			failOn:  "high",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := newFakeRegistry()
+			scanner := &fakeScanner{report: report}
+			bc := &OktetoBuilder{
+				Builder:  test.NewFakeOktetoBuilder(registry),
+				Registry: registry,
+				Scanner:  scanner,
+				IoCtrl:   io.NewIOController(),
+			}
+			dir, err := createDockerfile(t)
+			assert.NoError(t, err)
+
+			options := &types.BuildOptions{
+				CommandArgs: []string{dir},
+				Tag:         "okteto.dev/test",
+				Scan:        true,
+				ScanFailOn:  tt.failOn,
+			}
+			err = bc.Build(context.Background(), options)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, scan.Target{Image: "okteto.dev/test"}, scanner.target)
+		})
+	}
+}
+
+func TestBuildWithScanWithoutTag(t *testing.T) {
+	registry := newFakeRegistry()
+	scanner := &fakeScanner{report: &scan.Report{}}
+	bc := &OktetoBuilder{
+		Builder:  test.NewFakeOktetoBuilder(registry),
+		Registry: registry,
+		Scanner:  scanner,
+		IoCtrl:   io.NewIOController(),
+	}
+	dir, err := createDockerfile(t)
+	assert.NoError(t, err)
+
+	options := &types.BuildOptions{
+		CommandArgs: []string{dir},
+		Scan:        true,
+	}
+	assert.NoError(t, bc.Build(context.Background(), options))
+	assert.Empty(t, scanner.target)
+}
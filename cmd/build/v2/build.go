@@ -15,6 +15,7 @@ package v2
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -28,15 +29,16 @@ import (
 	"github.com/okteto/okteto/pkg/analytics"
 	"github.com/okteto/okteto/pkg/build"
 	buildCmd "github.com/okteto/okteto/pkg/cmd/build"
-	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/devenvironment"
-	"github.com/okteto/okteto/pkg/env"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/filesystem"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/log/io"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/registry"
+	"github.com/okteto/okteto/pkg/registry/login"
 	"github.com/okteto/okteto/pkg/repository"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/spf13/afero"
@@ -69,6 +71,11 @@ type oktetoBuilderConfigInterface interface {
 	GetAnonymizedRepo() string
 }
 
+// registryLoginInterface gets the credentials of the private external registries defined in the manifest
+type registryLoginInterface interface {
+	LoginAll(ctx context.Context, registries model.ManifestRegistries) error
+}
+
 type analyticsTrackerInterface interface {
 	TrackImageBuild(meta ...*analytics.ImageBuildMetadata)
 }
@@ -84,6 +91,11 @@ type OktetoBuilder struct {
 
 	smartBuildCtrl *smartbuild.SmartBuildCtrl
 
+	registryLogin registryLoginInterface
+
+	// fs is the filesystem of the lock file
+	fs afero.Fs
+
 	// buildEnvironments are the environment variables created by the build steps
 	buildEnvironments map[string]string
 
@@ -113,7 +125,9 @@ func NewBuilder(builder OktetoBuilderInterface, registry oktetoRegistryInterface
 		analyticsTracker:  analyticsTracker,
 		ioCtrl:            ioCtrl,
 		smartBuildCtrl:    smartbuild.NewSmartBuildCtrl(gitRepo, registry, config.fs, ioCtrl),
+		fs:                config.fs,
 		oktetoContext:     okCtx,
+		registryLogin:     login.NewManager(),
 	}
 }
 
@@ -152,9 +166,11 @@ func NewBuilderFromScratch(analyticsTracker analyticsTrackerInterface, ioCtrl *i
 		analyticsTracker:  analyticsTracker,
 		ioCtrl:            ioCtrl,
 		smartBuildCtrl:    smartbuild.NewSmartBuildCtrl(gitRepo, reg, config.fs, ioCtrl),
+		fs:                config.fs,
 		oktetoContext: &okteto.OktetoContextStateless{
 			Store: okteto.ContextStore(),
 		},
+		registryLogin: login.NewManager(),
 	}
 }
 
@@ -167,11 +183,13 @@ func (*OktetoBuilder) IsV1() bool {
 // TODO: Function with cyclomatic complexity higher than threshold. Refactor function in order to reduce its complexity
 // skipcq: GO-R1005
 func (ob *OktetoBuilder) Build(ctx context.Context, options *types.BuildOptions) error {
-	if env.LoadBoolean(constants.OktetoDeployRemote) {
+	if config.EnvOktetoDeployRemote.IsTrue() {
 		// Since the local build has already been built,
 		// we have the environment variables set and we can skip this code
 		return nil
 	}
+	// the lock path is resolved before moving to the manifest workdir
+	lockPath := getLockPath(options.Manifest)
 	if options.File != "" {
 		workdir := model.GetWorkdirFromManifestPath(options.File)
 		if err := os.Chdir(workdir); err != nil {
@@ -200,6 +218,12 @@ func (ob *OktetoBuilder) Build(ctx context.Context, options *types.BuildOptions)
 		return err
 	}
 
+	if len(options.Manifest.Registries) > 0 {
+		if err := ob.registryLogin.LoginAll(ctx, options.Manifest.Registries); err != nil {
+			return err
+		}
+	}
+
 	buildManifest := options.Manifest.Build
 
 	// builtImagesControl represents the controller for the built services
@@ -256,12 +280,13 @@ func (ob *OktetoBuilder) Build(ctx context.Context, options *types.BuildOptions)
 			meta.BuildContextHash = serviceHash
 			meta.BuildContextHashDuration = time.Since(buildContextHashDurationStart)
 
-			// We only check that the image is built in the global registry if the noCache option is not set
-			if !options.NoCache && ob.smartBuildCtrl.IsEnabled() {
+			// We only check that the image is built in the global registry if the noCache option is not set.
+			// Exported images are always built because the registry image can't be written to the local output
+			if !options.NoCache && options.Output == nil && ob.smartBuildCtrl.IsEnabled() {
 				imageChecker := getImageChecker(buildSvcInfo, ob.Config, ob.Registry, ob.smartBuildCtrl, ob.ioCtrl.Logger())
 				cacheHitDurationStart := time.Now()
 
-				buildHash, err := ob.smartBuildCtrl.GetBuildHash(buildSvcInfo)
+				buildHash, err := ob.smartBuildCtrl.GetBuildHash(buildSvcInfo, options.Platform)
 				if err != nil {
 					ob.ioCtrl.Logger().Infof("error getting build hash: %s", err)
 				}
@@ -270,6 +295,16 @@ func (ob *OktetoBuilder) Build(ctx context.Context, options *types.BuildOptions)
 				meta.CacheHit = isBuilt
 				meta.CacheHitDuration = time.Since(cacheHitDurationStart)
 
+				if options.Explain {
+					explanation := ob.smartBuildCtrl.ExplainBuildHash(svcToBuild, buildHash)
+					if isBuilt {
+						explanation.SetCacheHit(imageWithDigest)
+					} else {
+						explanation.SetCacheMiss()
+					}
+					ob.printExplanation(explanation)
+				}
+
 				if isBuilt {
 					ob.ioCtrl.Out().Infof("Skipping build of '%s' image because it's already built for commit %s", svcToBuild, ob.smartBuildCtrl.GetBuildCommit(buildSvcInfo))
 
@@ -277,6 +312,9 @@ func (ob *OktetoBuilder) Build(ctx context.Context, options *types.BuildOptions)
 					if err != nil {
 						return err
 					}
+					if err := ob.scanReusedImage(ctx, buildSvcInfo, options, imageWithDigest); err != nil {
+						return err
+					}
 					ob.SetServiceEnvVars(svcToBuild, imageWithDigest)
 					builtImagesControl[svcToBuild] = true
 					meta.Success = true
@@ -302,7 +340,41 @@ func (ob *OktetoBuilder) Build(ctx context.Context, options *types.BuildOptions)
 	if options.EnableStages {
 		ob.ioCtrl.SetStage("")
 	}
-	return options.Manifest.ExpandEnvVars()
+	if err := options.Manifest.ExpandEnvVars(); err != nil {
+		return err
+	}
+	ob.pinManifestImages(options.Manifest)
+	if options.Lock {
+		return ob.writeLock(options.Manifest, lockPath, options.Platform)
+	}
+	return nil
+}
+
+// scanReusedImage scans the image reused by smart builds, so the scan of the service applies on cache hits too
+func (ob *OktetoBuilder) scanReusedImage(ctx context.Context, buildSvcInfo *build.Info, options *types.BuildOptions, image string) error {
+	scanOptions := &types.BuildOptions{
+		Tag:        image,
+		Scan:       options.Scan || buildSvcInfo.Scan.IsEnabled(),
+		ScanFailOn: options.ScanFailOn,
+	}
+	if scanOptions.ScanFailOn == "" && buildSvcInfo.Scan.IsEnabled() {
+		scanOptions.ScanFailOn = buildSvcInfo.Scan.FailOn
+	}
+	return ob.V1Builder.ScanImage(ctx, scanOptions)
+}
+
+// printExplanation prints the smart build decision of a service. On json output the explanation is printed as a json document
+func (ob *OktetoBuilder) printExplanation(explanation *smartbuild.Explanation) {
+	if oktetoLog.GetOutputFormat() == oktetoLog.JSONFormat {
+		bytes, err := json.Marshal(explanation)
+		if err != nil {
+			ob.ioCtrl.Logger().Infof("error marshalling smart build explanation: %s", err)
+			return
+		}
+		ob.ioCtrl.Out().Println(string(bytes))
+		return
+	}
+	ob.ioCtrl.Out().Println(explanation.String())
 }
 
 // areServicesBuilt compares the list of services with the built control
@@ -329,6 +401,11 @@ func (bc *OktetoBuilder) buildServiceImages(ctx context.Context, manifest *model
 	buildSvcInfo := manifest.Build[svcName]
 
 	switch {
+	case serviceHasVolumesToInclude(buildSvcInfo) && options.Output != nil:
+		return "", oktetoErrors.UserError{
+			E:    fmt.Errorf("the flag '--output' is not supported for services with volume mounts"),
+			Hint: "Remove the volume mounts of the service or build it without the '--output' flag",
+		}
 	case serviceHasVolumesToInclude(buildSvcInfo) && !bc.oktetoContext.IsOkteto():
 		return "", oktetoErrors.UserError{
 			E:    fmt.Errorf("Build with volume mounts is not supported on vanilla contexts"),
@@ -361,7 +438,7 @@ func (bc *OktetoBuilder) buildSvcFromDockerfile(ctx context.Context, manifest *m
 	var err error
 	var buildHash string
 	if bc.smartBuildCtrl.IsEnabled() {
-		buildHash, err = bc.smartBuildCtrl.GetBuildHash(buildSvcInfo)
+		buildHash, err = bc.smartBuildCtrl.GetBuildHash(buildSvcInfo, options.Platform)
 		if err != nil {
 			bc.ioCtrl.Logger().Infof("error getting build hash: %s", err)
 		}
@@ -377,6 +454,10 @@ func (bc *OktetoBuilder) buildSvcFromDockerfile(ctx context.Context, manifest *m
 	if err := bc.V1Builder.Build(ctx, buildOptions); err != nil {
 		return "", err
 	}
+	if options.Output != nil {
+		// the image is not pushed, so there is no digest to resolve
+		return buildOptions.Tag, nil
+	}
 	// check if the image is pushed to the dev registry if DevTag is set
 	reference := buildOptions.Tag
 	if buildOptions.DevTag != "" {
@@ -403,7 +484,7 @@ func (bc *OktetoBuilder) addVolumeMounts(ctx context.Context, manifest *model.Ma
 	var err error
 	var buildHash string
 	if bc.smartBuildCtrl.IsEnabled() {
-		buildHash, err = bc.smartBuildCtrl.GetBuildHash(buildInfoCopy)
+		buildHash, err = bc.smartBuildCtrl.GetBuildHash(buildInfoCopy, options.Platform)
 		if err != nil {
 			bc.ioCtrl.Logger().Infof("error getting build hash: %s", err)
 		}
@@ -489,10 +570,14 @@ func validateOptions(manifest *model.Manifest, svcsToBuild []string, options *ty
 		return err
 	}
 
-	if len(svcsToBuild) != 1 && (options.Tag != "" || options.Target != "" || options.CacheFrom != nil || options.Secrets != nil) {
+	if len(svcsToBuild) != 1 && (options.Tag != "" || options.Target != "" || options.CacheFrom != nil || options.Secrets != nil || options.Output != nil) {
 		return oktetoErrors.ErrNoFlagAllowedOnSingleImageBuild
 	}
 
+	if options.Output != nil && options.Lock {
+		return fmt.Errorf("the flags '--output' and '--lock' can't be used together: exported images are not pushed to the registry")
+	}
+
 	return nil
 }
 
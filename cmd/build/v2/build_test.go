@@ -31,6 +31,7 @@ import (
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/registry"
+	"github.com/okteto/okteto/pkg/scan"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
@@ -172,6 +173,7 @@ func NewFakeBuilder(builder OktetoBuilderInterface, registry oktetoRegistryInter
 		ioCtrl:           io.NewIOController(),
 		analyticsTracker: analyticsTracker,
 		smartBuildCtrl:   smartbuild.NewSmartBuildCtrl(fakeConfigRepo{}, registry, afero.NewMemMapFs(), io.NewIOController()),
+		fs:               afero.NewMemMapFs(),
 		oktetoContext: &okteto.OktetoContextStateless{
 			Store: &okteto.OktetoContextStore{
 				Contexts: map[string]*okteto.OktetoContext{
@@ -221,6 +223,30 @@ func TestValidateOptions(t *testing.T) {
 			},
 			expectedErr: true,
 		},
+		{
+			name: "several services with output",
+			buildSection: build.ManifestBuild{
+				"test":   &build.Info{},
+				"test-2": &build.Info{},
+			},
+			svcsToBuild: []string{"test", "test-2"},
+			options: types.BuildOptions{
+				Output: &types.BuildOutput{Type: "docker", Dest: "image.tar"},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "output with lock",
+			buildSection: build.ManifestBuild{
+				"test": &build.Info{},
+			},
+			svcsToBuild: []string{"test"},
+			options: types.BuildOptions{
+				Output: &types.BuildOutput{Type: "docker", Dest: "image.tar"},
+				Lock:   true,
+			},
+			expectedErr: true,
+		},
 		{
 			name: "only one service without flags",
 			buildSection: build.ManifestBuild{
@@ -531,6 +557,55 @@ func TestBuildWithDependsOn(t *testing.T) {
 
 }
 
+type fakeRegistryLogin struct {
+	err        error
+	registries model.ManifestRegistries
+}
+
+func (f *fakeRegistryLogin) LoginAll(_ context.Context, registries model.ManifestRegistries) error {
+	f.registries = append(f.registries, registries...)
+	return f.err
+}
+
+func TestBuildLogsInToManifestRegistries(t *testing.T) {
+	ctx := context.Background()
+	dir, err := createDockerfile(t)
+	require.NoError(t, err)
+
+	registries := model.ManifestRegistries{{Server: "123456789012.dkr.ecr.us-east-1.amazonaws.com"}}
+	manifest := &model.Manifest{
+		Name: "test",
+		Build: build.ManifestBuild{
+			"a": &build.Info{
+				Context:    dir,
+				Dockerfile: filepath.Join(dir, "Dockerfile"),
+				Image:      "okteto/a:test",
+			},
+		},
+		Registries: registries,
+	}
+
+	t.Run("login before building", func(t *testing.T) {
+		registry := newFakeRegistry()
+		bc := NewFakeBuilder(test.NewFakeOktetoBuilder(registry), registry, fakeConfig{isOkteto: true}, &fakeAnalyticsTracker{})
+		rl := &fakeRegistryLogin{}
+		bc.registryLogin = rl
+		require.NoError(t, bc.Build(ctx, &types.BuildOptions{Manifest: manifest}))
+		assert.Equal(t, registries, rl.registries)
+		_, err = registry.GetImageTagWithDigest("okteto/a:test")
+		assert.NoError(t, err)
+	})
+
+	t.Run("login fails", func(t *testing.T) {
+		registry := newFakeRegistry()
+		bc := NewFakeBuilder(test.NewFakeOktetoBuilder(registry), registry, fakeConfig{isOkteto: true}, &fakeAnalyticsTracker{})
+		bc.registryLogin = &fakeRegistryLogin{err: assert.AnError}
+		require.ErrorIs(t, bc.Build(ctx, &types.BuildOptions{Manifest: manifest}), assert.AnError)
+		_, err = registry.GetImageTagWithDigest("okteto/a:test")
+		assert.Error(t, err)
+	})
+}
+
 func Test_areAllServicesBuilt(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -642,3 +717,39 @@ func Test_skipServiceBuild(t *testing.T) {
 
 	}
 }
+
+type fakeScanner struct {
+	targets []scan.Target
+	report  *scan.Report
+}
+
+func (fs *fakeScanner) Scan(_ context.Context, target scan.Target) (*scan.Report, error) {
+	fs.targets = append(fs.targets, target)
+	return fs.report, nil
+}
+
+func TestScanReusedImage(t *testing.T) {
+	registry := newFakeRegistry()
+	builder := test.NewFakeOktetoBuilder(registry)
+	bc := NewFakeBuilder(builder, registry, fakeConfig{isOkteto: true}, &fakeAnalyticsTracker{})
+	scanner := &fakeScanner{
+		report: &scan.Report{
+			Vulnerabilities: []scan.Vulnerability{{ID: "CVE-1", Severity: scan.SeverityHigh}},
+		},
+	}
+	bc.V1Builder.Scanner = scanner
+	image := "okteto.dev/test-api@sha256:1234"
+
+	// the scan is disabled
+	require.NoError(t, bc.scanReusedImage(context.Background(), &build.Info{}, &types.BuildOptions{}, image))
+	assert.Empty(t, scanner.targets)
+
+	// the scan of the service fails the build on cache hits too
+	err := bc.scanReusedImage(context.Background(), &build.Info{Scan: build.NewScanInfo("high")}, &types.BuildOptions{}, image)
+	assert.ErrorContains(t, err, "vulnerabilities with severity HIGH or higher")
+	assert.Equal(t, []scan.Target{{Image: image}}, scanner.targets)
+
+	// the --fail-on option takes precedence over the scan of the service
+	err = bc.scanReusedImage(context.Background(), &build.Info{Scan: build.NewScanInfo("high")}, &types.BuildOptions{Scan: true, ScanFailOn: "critical"}, image)
+	assert.NoError(t, err)
+}
@@ -14,9 +14,7 @@
 package v2
 
 import (
-	"os"
-	"strconv"
-
+	oktetoConfig "github.com/okteto/okteto/pkg/config"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/spf13/afero"
@@ -94,18 +92,11 @@ func getConfigStateless(registry configRegistryInterface, gitRepo configReposito
 }
 
 func getIsSmartBuildEnabled() bool {
-	enableSmartBuilds := true
-	enableSmartBuildsStr := os.Getenv(OktetoEnableSmartBuildEnvVar)
-	if enableSmartBuildsStr != "" {
-		smartBuildEnabledBool, err := strconv.ParseBool(enableSmartBuildsStr)
-		if err != nil {
-			oktetoLog.Warning("feature flag %s received an invalid value; expected boolean. Smart builds will remain enabled by default", OktetoEnableSmartBuildEnvVar)
-		} else {
-			enableSmartBuilds = smartBuildEnabledBool
-		}
-
+	enableSmartBuilds, err := oktetoConfig.EnvOktetoSmartBuildsEnabled.Bool()
+	if err != nil {
+		oktetoLog.Warning("feature flag %s received an invalid value; expected boolean. Smart builds will remain enabled by default", OktetoEnableSmartBuildEnvVar)
+		return true
 	}
-
 	return enableSmartBuilds
 }
 
@@ -0,0 +1,163 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/env"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/afero"
+)
+
+func (bc *OktetoBuilder) getFs() afero.Fs {
+	if bc.fs == nil {
+		return afero.NewOsFs()
+	}
+	return bc.fs
+}
+
+// getBuiltImages returns the images referenced by digest of the services built or reused by the builder
+func (bc *OktetoBuilder) getBuiltImages(manifest *model.Manifest) map[string]string {
+	images := map[string]string{}
+	bc.lock.RLock()
+	defer bc.lock.RUnlock()
+	for svc := range manifest.Build {
+		image := bc.buildEnvironments[fmt.Sprintf("OKTETO_BUILD_%s_IMAGE", strings.ToUpper(strings.ReplaceAll(svc, "-", "_")))]
+		if strings.Contains(image, "@sha256:") {
+			images[svc] = image
+		}
+	}
+	return images
+}
+
+// pinManifestImages replaces the references by tag to the built images in the dev and compose sections of the manifest with their digests,
+// so the manifest keeps pointing to the same images even if their tags are pushed again by another build
+func (bc *OktetoBuilder) pinManifestImages(manifest *model.Manifest) {
+	for svc, image := range bc.getBuiltImages(manifest) {
+		info := manifest.Build[svc]
+		if info == nil || info.Image == "" {
+			continue
+		}
+		tag, err := env.ExpandEnv(info.Image)
+		if err != nil || tag == "" {
+			continue
+		}
+		for _, dev := range manifest.Dev {
+			if dev.Image != nil && dev.Image.Name == tag {
+				dev.Image.Name = image
+			}
+		}
+		if stack := manifest.GetStack(); stack != nil {
+			for _, stackSvc := range stack.Services {
+				if stackSvc.Image == tag {
+					stackSvc.Image = image
+				}
+			}
+		}
+	}
+}
+
+// getLockPath returns the path of the lock file, next to the okteto manifest
+func getLockPath(manifest *model.Manifest) string {
+	if manifest == nil || manifest.ManifestPath == "" {
+		return build.LockFile
+	}
+	manifestPath, err := filepath.Abs(manifest.ManifestPath)
+	if err != nil {
+		return build.LockFile
+	}
+	return filepath.Join(model.GetWorkdirFromManifestPath(manifestPath), build.LockFile)
+}
+
+// getLockHash returns the build hash of a service stored in the lock file
+func (bc *OktetoBuilder) getLockHash(manifest *model.Manifest, svc, platform string) (string, error) {
+	if !bc.smartBuildCtrl.IsEnabled() {
+		return "", errors.New("smart builds are disabled")
+	}
+	info := manifest.Build[svc].Copy()
+	image, err := env.ExpandEnv(info.Image)
+	if err != nil {
+		return "", err
+	}
+	info.Image = image
+	return bc.smartBuildCtrl.GetBuildHash(info, platform)
+}
+
+// writeLock stores the images built for the manifest in the lock file, replacing the previous entries of the services.
+// Entries of services no longer in the build section are removed
+func (bc *OktetoBuilder) writeLock(manifest *model.Manifest, lockPath, platform string) error {
+	lock, err := build.ReadLock(bc.getFs(), lockPath)
+	if err != nil {
+		return err
+	}
+	if lock == nil {
+		lock = &build.Lock{Images: map[string]build.LockedImage{}}
+	}
+	for _, svc := range lock.GetServices() {
+		if _, ok := manifest.Build[svc]; !ok {
+			delete(lock.Images, svc)
+		}
+	}
+	for svc, image := range bc.getBuiltImages(manifest) {
+		hash, err := bc.getLockHash(manifest, svc, platform)
+		if err != nil {
+			bc.ioCtrl.Logger().Infof("could not get the build hash of service '%s': %s", svc, err)
+		}
+		lock.Images[svc] = build.LockedImage{
+			Image:    image,
+			Hash:     hash,
+			Platform: platform,
+		}
+	}
+	if err := lock.Write(bc.getFs(), lockPath); err != nil {
+		return err
+	}
+	bc.ioCtrl.Out().Success("Image digests written to '%s'", lockPath)
+	return nil
+}
+
+// useLockedImages sets the images of the lock file for the services to deploy. It returns the services with a locked image.
+// Images are only used if they were built from the current sources of the service
+func (bc *OktetoBuilder) useLockedImages(manifest *model.Manifest, svcToDeployMap map[string]bool) (map[string]bool, error) {
+	locked := map[string]bool{}
+	lockPath := getLockPath(manifest)
+	lock, err := build.ReadLock(bc.getFs(), lockPath)
+	if err != nil || lock == nil {
+		return locked, err
+	}
+	for _, svc := range lock.GetServices() {
+		if _, ok := manifest.Build[svc]; !ok || !svcToDeployMap[svc] {
+			continue
+		}
+		entry := lock.Images[svc]
+		hash, err := bc.getLockHash(manifest, svc, entry.Platform)
+		if err != nil {
+			bc.ioCtrl.Logger().Infof("could not verify the locked image of service '%s': %s", svc, err)
+			continue
+		}
+		if entry.Hash == "" || entry.Hash != hash {
+			bc.ioCtrl.Logger().Infof("ignoring the locked image of service '%s': it was built from different sources", svc)
+			continue
+		}
+		bc.ioCtrl.Out().Warning("Using image '%s' of service '%s' from '%s'", entry.Image, svc, lockPath)
+		bc.setServiceImage(svc, manifest, entry.Image)
+		locked[svc] = true
+	}
+	return locked, nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	fakeDigestAPI      = "okteto.dev/api@sha256:7075f1094117e418764bb9b47a5dfc093466e714ec385223fb582d78220c7252"
+	fakeDigestFrontend = "okteto.dev/frontend@sha256:2a1e0f4f63c1da2a8ac5dbd3b0aaf37b4b4e2dc7d7bb1fd1b1e5a6c9d1d2e3f4"
+)
+
+func TestPinManifestImages(t *testing.T) {
+	bc := NewFakeBuilder(nil, newFakeRegistry(), fakeConfig{}, &fakeAnalyticsTracker{})
+	manifest := &model.Manifest{
+		Build: build.ManifestBuild{
+			"api": &build.Info{Image: "okteto.dev/api:okteto"},
+		},
+		Dev: model.ManifestDevs{
+			"api":    &model.Dev{Image: &build.Info{Name: "okteto.dev/api:okteto"}},
+			"worker": &model.Dev{Image: &build.Info{Name: "busybox"}},
+		},
+		Deploy: &model.DeployInfo{
+			ComposeSection: &model.ComposeSectionInfo{
+				Stack: &model.Stack{
+					Services: map[string]*model.Service{
+						"api": {Image: "okteto.dev/api:okteto"},
+					},
+				},
+			},
+		},
+	}
+	bc.SetServiceEnvVars("api", fakeDigestAPI)
+
+	bc.pinManifestImages(manifest)
+
+	assert.Equal(t, fakeDigestAPI, manifest.Dev["api"].Image.Name)
+	assert.Equal(t, "busybox", manifest.Dev["worker"].Image.Name)
+	assert.Equal(t, fakeDigestAPI, manifest.Deploy.ComposeSection.Stack.Services["api"].Image)
+	assert.Equal(t, "okteto.dev/api:okteto", manifest.Build["api"].Image)
+}
+
+func TestGetLockPath(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+
+	assert.Equal(t, build.LockFile, getLockPath(nil))
+	assert.Equal(t, build.LockFile, getLockPath(&model.Manifest{}))
+	assert.Equal(t, filepath.Join(wd, "api", build.LockFile), getLockPath(&model.Manifest{ManifestPath: filepath.Join("api", "okteto.yml")}))
+	assert.Equal(t, filepath.Join(wd, "api", build.LockFile), getLockPath(&model.Manifest{ManifestPath: filepath.Join("api", ".okteto", "okteto.yml")}))
+}
+
+func TestWriteLock(t *testing.T) {
+	bc := NewFakeBuilder(nil, newFakeRegistry(), fakeConfig{}, &fakeAnalyticsTracker{})
+	require.NoError(t, (&build.Lock{Images: map[string]build.LockedImage{
+		"frontend": {Image: fakeDigestFrontend, Hash: "hash"},
+		"api":      {Image: "okteto.dev/api@sha256:old", Hash: "old-hash"},
+	}}).Write(bc.fs, build.LockFile))
+	manifest := &model.Manifest{
+		Build: build.ManifestBuild{
+			"api":    &build.Info{Image: "okteto.dev/api:okteto"},
+			"worker": &build.Info{Image: "okteto.dev/worker:okteto"},
+		},
+	}
+	bc.SetServiceEnvVars("api", fakeDigestAPI)
+	bc.SetServiceEnvVars("worker", "okteto.dev/worker:okteto")
+
+	require.NoError(t, bc.writeLock(manifest, build.LockFile, ""))
+
+	lock, err := build.ReadLock(bc.fs, build.LockFile)
+	require.NoError(t, err)
+	hash, err := bc.getLockHash(manifest, "api", "")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]build.LockedImage{"api": {Image: fakeDigestAPI, Hash: hash}}, lock.Images)
+}
+
+func TestGetServicesToBuildWithLock(t *testing.T) {
+	bc := NewFakeBuilder(nil, newFakeRegistry(), fakeConfig{isOkteto: true}, &fakeAnalyticsTracker{})
+	hash, err := bc.getLockHash(fakeManifest, "test-1", "")
+	require.NoError(t, err)
+	require.NoError(t, (&build.Lock{Images: map[string]build.LockedImage{
+		"test-1": {Image: fakeDigestAPI, Hash: hash},
+		"test-3": {Image: fakeDigestFrontend, Hash: "outdated-hash"},
+	}}).Write(bc.fs, build.LockFile))
+
+	toBuild, err := bc.GetServicesToBuild(context.Background(), fakeManifest, []string{"test-1", "test-3"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"test-3"}, toBuild)
+	assert.Equal(t, fakeDigestAPI, bc.buildEnvironments["OKTETO_BUILD_TEST_1_IMAGE"])
+}
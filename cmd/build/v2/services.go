@@ -48,6 +48,12 @@ func (bc *OktetoBuilder) GetServicesToBuild(ctx context.Context, manifest *model
 			svcToDeployMap[svcToDeploy] = true
 		}
 	}
+	// services with an image in the lock file are never built
+	locked, err := bc.useLockedImages(manifest, svcToDeployMap)
+	if err != nil {
+		return nil, err
+	}
+
 	// check if images are at registry (global or dev) and set envs or send to build
 	toBuildCh := make(chan string, len(svcToDeployMap))
 	g, _ := errgroup.WithContext(ctx)
@@ -56,6 +62,9 @@ func (bc *OktetoBuilder) GetServicesToBuild(ctx context.Context, manifest *model
 			bc.ioCtrl.Logger().Debugf("Skipping service '%s' because it is not in the list of services to deploy", service)
 			continue
 		}
+		if locked[service] {
+			continue
+		}
 		svc := service
 
 		g.Go(func() error {
@@ -73,6 +82,7 @@ func (bc *OktetoBuilder) GetServicesToBuild(ctx context.Context, manifest *model
 		if err := manifest.ExpandEnvVars(); err != nil {
 			return nil, err
 		}
+		bc.pinManifestImages(manifest)
 		return nil, nil
 	}
 
@@ -97,7 +107,8 @@ func (bc *OktetoBuilder) checkServiceToBuild(service string, manifest *model.Man
 	var err error
 	var buildHash string
 	if bc.smartBuildCtrl.IsEnabled() {
-		buildHash, err = bc.smartBuildCtrl.GetBuildHash(buildInfo)
+		// services are checked for the default platform of the builder, the one used by deploy
+		buildHash, err = bc.smartBuildCtrl.GetBuildHash(buildInfo, "")
 		if err != nil {
 			bc.ioCtrl.Logger().Infof("error getting build hash: %s", err)
 		}
@@ -114,6 +125,12 @@ func (bc *OktetoBuilder) checkServiceToBuild(service string, manifest *model.Man
 	}
 	bc.ioCtrl.Logger().Debugf("Skipping build for image for service: %s", service)
 
+	bc.setServiceImage(service, manifest, imageWithDigest)
+	return nil
+}
+
+// setServiceImage sets the image of a service that doesn't need to be built
+func (bc *OktetoBuilder) setServiceImage(service string, manifest *model.Manifest, imageWithDigest string) {
 	bc.SetServiceEnvVars(service, imageWithDigest)
 
 	if manifest.Deploy != nil && manifest.Deploy.ComposeSection != nil && manifest.Deploy.ComposeSection.Stack != nil {
@@ -122,5 +139,4 @@ func (bc *OktetoBuilder) checkServiceToBuild(service string, manifest *model.Man
 			stack.Services[service].Image = fmt.Sprintf("${OKTETO_BUILD_%s_IMAGE}", strings.ToUpper(strings.ReplaceAll(service, "-", "_")))
 		}
 	}
-	return nil
 }
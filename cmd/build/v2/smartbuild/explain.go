@@ -0,0 +1,109 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package smartbuild
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// ProjectCommitStrategy is the strategy used when the hash is computed from the repository commit
+	ProjectCommitStrategy = "project-commit"
+
+	// BuildContextStrategy is the strategy used when the hash is computed from the build context tree
+	BuildContextStrategy = "build-context"
+)
+
+// HashInputs represents the values that were hashed to compute a build hash
+type HashInputs struct {
+	Commit            string   `json:"commit"`
+	Target            string   `json:"target,omitempty"`
+	Context           string   `json:"context,omitempty"`
+	Dockerfile        string   `json:"dockerfile,omitempty"`
+	DockerfileContent string   `json:"dockerfileContent,omitempty"`
+	Diff              string   `json:"diff,omitempty"`
+	Image             string   `json:"image,omitempty"`
+	Platforms         []string `json:"platforms,omitempty"`
+	BuildArgs         []string `json:"buildArgs,omitempty"`
+	// Secrets only contains the ids of the secrets, never its values
+	Secrets []string `json:"secrets,omitempty"`
+}
+
+// Explanation describes why the build of a service was skipped or executed
+type Explanation struct {
+	Service  string     `json:"service"`
+	Strategy string     `json:"strategy"`
+	Hash     string     `json:"hash"`
+	Image    string     `json:"image,omitempty"`
+	Reason   string     `json:"reason"`
+	Inputs   HashInputs `json:"inputs"`
+	CacheHit bool       `json:"cacheHit"`
+}
+
+// ExplainBuildHash returns the explanation of the given build hash for a service.
+// The hash must have been computed previously by the controller
+func (s *SmartBuildCtrl) ExplainBuildHash(svcName, buildHash string) *Explanation {
+	strategy := ProjectCommitStrategy
+	if s.isUsingBuildContext {
+		strategy = BuildContextStrategy
+	}
+	explanation := &Explanation{
+		Service:  svcName,
+		Strategy: strategy,
+		Hash:     buildHash,
+	}
+	inputs, ok := s.hasher.getHashInputsInCache(buildHash)
+	if !ok {
+		s.ioCtrl.Logger().Debugf("inputs for build hash '%s' not found in cache", buildHash)
+	}
+	explanation.Inputs = inputs
+	return explanation
+}
+
+// SetCacheHit records the result of looking up the build hash in the registry
+func (e *Explanation) SetCacheHit(image string) {
+	e.CacheHit = true
+	e.Image = image
+	e.Reason = fmt.Sprintf("an image built with hash '%s' already exists", e.Hash)
+}
+
+// SetCacheMiss records that no image was found for the build hash
+func (e *Explanation) SetCacheMiss() {
+	e.CacheHit = false
+	e.Reason = fmt.Sprintf("no image found for hash '%s'", e.Hash)
+}
+
+// String returns a human readable version of the explanation
+func (e *Explanation) String() string {
+	var b strings.Builder
+	decision := "built"
+	if e.CacheHit {
+		decision = "skipped"
+	}
+	fmt.Fprintf(&b, "Build of '%s' %s: %s\n", e.Service, decision, e.Reason)
+	fmt.Fprintf(&b, "  strategy: %s\n", e.Strategy)
+	fmt.Fprintf(&b, "  commit: %s\n", e.Inputs.Commit)
+	fmt.Fprintf(&b, "  target: %s\n", e.Inputs.Target)
+	fmt.Fprintf(&b, "  build args: %s\n", strings.Join(e.Inputs.BuildArgs, ", "))
+	fmt.Fprintf(&b, "  secrets: %s\n", strings.Join(e.Inputs.Secrets, ", "))
+	fmt.Fprintf(&b, "  context: %s\n", e.Inputs.Context)
+	fmt.Fprintf(&b, "  dockerfile: %s (sha256 %s)\n", e.Inputs.Dockerfile, e.Inputs.DockerfileContent)
+	fmt.Fprintf(&b, "  diff: %s\n", e.Inputs.Diff)
+	if len(e.Inputs.Platforms) > 0 {
+		fmt.Fprintf(&b, "  platforms: %s\n", strings.Join(e.Inputs.Platforms, ", "))
+	}
+	fmt.Fprintf(&b, "  image: %s", e.Inputs.Image)
+	return b.String()
+}
@@ -0,0 +1,106 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package smartbuild
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/log/io"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainBuildHash(t *testing.T) {
+	sh := newServiceHasher(fakeConfigRepo{sha: "testsha"}, afero.NewMemMapFs())
+	sbc := SmartBuildCtrl{
+		ioCtrl: io.NewIOController(),
+		hasher: sh,
+	}
+	buildInfo := &build.Info{
+		Target:  "dev",
+		Context: "api",
+		Args: build.Args{
+			{Name: "KEY", Value: "value"},
+		},
+		Secrets: build.Secrets{
+			"npmrc": "/home/.npmrc",
+		},
+	}
+
+	hash, err := sbc.GetBuildHash(buildInfo, "")
+	require.NoError(t, err)
+
+	explanation := sbc.ExplainBuildHash("api", hash)
+	assert.Equal(t, "api", explanation.Service)
+	assert.Equal(t, ProjectCommitStrategy, explanation.Strategy)
+	assert.Equal(t, hash, explanation.Hash)
+	assert.Equal(t, HashInputs{
+		Commit:            "testsha",
+		Target:            "dev",
+		Context:           "api",
+		BuildArgs:         []string{"KEY=value"},
+		Secrets:           []string{"npmrc"},
+		DockerfileContent: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+	}, explanation.Inputs)
+	assert.NotContains(t, explanation.String(), "/home/.npmrc")
+}
+
+func TestExplainBuildHashCacheDecision(t *testing.T) {
+	sbc := SmartBuildCtrl{
+		ioCtrl:              io.NewIOController(),
+		hasher:              fakeHasher{},
+		isUsingBuildContext: true,
+	}
+
+	explanation := sbc.ExplainBuildHash("api", "unknown")
+	assert.Equal(t, BuildContextStrategy, explanation.Strategy)
+	assert.Equal(t, HashInputs{}, explanation.Inputs)
+
+	explanation.SetCacheMiss()
+	assert.False(t, explanation.CacheHit)
+	assert.Equal(t, "no image found for hash 'unknown'", explanation.Reason)
+
+	explanation.SetCacheHit("okteto.dev/api@sha256:123")
+	assert.True(t, explanation.CacheHit)
+	assert.Equal(t, "okteto.dev/api@sha256:123", explanation.Image)
+	assert.Contains(t, explanation.String(), "Build of 'api' skipped")
+}
+
+func TestGetBuildHashWithPlatforms(t *testing.T) {
+	sh := newServiceHasher(fakeConfigRepo{sha: "testsha"}, afero.NewMemMapFs())
+	sbc := SmartBuildCtrl{
+		ioCtrl: io.NewIOController(),
+		hasher: sh,
+	}
+	buildInfo := &build.Info{Context: "api"}
+
+	defaultHash, err := sbc.GetBuildHash(buildInfo, "")
+	require.NoError(t, err)
+	multiHash, err := sbc.GetBuildHash(buildInfo, "linux/arm64, linux/amd64")
+	require.NoError(t, err)
+	sameMultiHash, err := sbc.GetBuildHash(buildInfo, "LINUX/AMD64,linux/arm64,linux/amd64")
+	require.NoError(t, err)
+	amdHash, err := sbc.GetBuildHash(buildInfo, "linux/amd64")
+	require.NoError(t, err)
+
+	assert.NotEqual(t, defaultHash, multiHash)
+	assert.NotEqual(t, multiHash, amdHash)
+	assert.Equal(t, multiHash, sameMultiHash)
+
+	explanation := sbc.ExplainBuildHash("api", multiHash)
+	assert.Equal(t, []string{"linux/amd64", "linux/arm64"}, explanation.Inputs.Platforms)
+	assert.Contains(t, explanation.String(), "platforms: linux/amd64, linux/arm64")
+}
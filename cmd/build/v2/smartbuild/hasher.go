@@ -20,10 +20,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 
 	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/filesystem"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/spf13/afero"
 )
@@ -36,11 +38,14 @@ type repositoryCommitRetriever interface {
 
 type serviceHasher struct {
 	gitRepoCtrl repositoryCommitRetriever
-	fs          afero.Fs
+	fs          *filesystem.CrossPlatformFs
 
 	buildContextCache map[string]string
 	projectCommit     string
 
+	// hashInputsCache stores the inputs used to compute each hash so the decision can be explained later
+	hashInputsCache map[string]HashInputs
+
 	// lock is a mutex to provide thread safety
 	lock sync.RWMutex
 }
@@ -49,7 +54,8 @@ func newServiceHasher(gitRepoCtrl repositoryCommitRetriever, fs afero.Fs) *servi
 	return &serviceHasher{
 		gitRepoCtrl:       gitRepoCtrl,
 		buildContextCache: map[string]string{},
-		fs:                fs,
+		hashInputsCache:   map[string]HashInputs{},
+		fs:                filesystem.NewCrossPlatformFs(fs, "."),
 	}
 }
 
@@ -95,11 +101,8 @@ func (sh *serviceHasher) hashBuildContext(buildInfo *build.Info) (string, error)
 }
 
 func (sh *serviceHasher) hash(buildInfo *build.Info, commitHash string, diff string) string {
-	args := []string{}
-	for _, arg := range buildInfo.Args {
-		args = append(args, arg.String())
-	}
-	argsText := strings.Join(args, ";")
+	inputs := sh.getHashInputs(buildInfo, commitHash, diff)
+	argsText := strings.Join(inputs.BuildArgs, ";")
 
 	secrets := []string{}
 	for key, value := range buildInfo.Secrets {
@@ -110,28 +113,77 @@ func (sh *serviceHasher) hash(buildInfo *build.Info, commitHash string, diff str
 	// We use a builder to avoid allocations when building the string
 	var b strings.Builder
 
-	fmt.Fprintf(&b, "commit:%s;", commitHash)
-	fmt.Fprintf(&b, "target:%s;", buildInfo.Target)
+	fmt.Fprintf(&b, "commit:%s;", inputs.Commit)
+	fmt.Fprintf(&b, "target:%s;", inputs.Target)
 	fmt.Fprintf(&b, "build_args:%s;", argsText)
 	fmt.Fprintf(&b, "secrets:%s;", secretsText)
-	fmt.Fprintf(&b, "context:%s;", buildInfo.Context)
-	fmt.Fprintf(&b, "dockerfile:%s;", buildInfo.Dockerfile)
-	fmt.Fprintf(&b, "dockerfile_content:%s;", sh.getDockerfileContent(buildInfo.Context, buildInfo.Dockerfile))
-	fmt.Fprintf(&b, "diff:%s;", diff)
-	fmt.Fprintf(&b, "image:%s;", buildInfo.Image)
+	fmt.Fprintf(&b, "context:%s;", inputs.Context)
+	fmt.Fprintf(&b, "dockerfile:%s;", inputs.Dockerfile)
+	fmt.Fprintf(&b, "dockerfile_content:%s;", inputs.DockerfileContent)
+	fmt.Fprintf(&b, "diff:%s;", inputs.Diff)
+	fmt.Fprintf(&b, "image:%s;", inputs.Image)
 
 	oktetoBuildHash := sha256.Sum256([]byte(b.String()))
-	return hex.EncodeToString(oktetoBuildHash[:])
+	hash := hex.EncodeToString(oktetoBuildHash[:])
+
+	sh.lock.Lock()
+	sh.hashInputsCache[hash] = inputs
+	sh.lock.Unlock()
+	return hash
+}
+
+// hashWithPlatforms returns the hash of a build for a list of target platforms.
+// The hash is not modified when the build doesn't target specific platforms
+func (sh *serviceHasher) hashWithPlatforms(hash string, platforms []string) string {
+	if len(platforms) == 0 {
+		return hash
+	}
+	platformsHash := sha256.Sum256([]byte(fmt.Sprintf("hash:%s;platforms:%s;", hash, strings.Join(platforms, ","))))
+	result := hex.EncodeToString(platformsHash[:])
+
+	sh.lock.Lock()
+	defer sh.lock.Unlock()
+	if inputs, ok := sh.hashInputsCache[hash]; ok {
+		inputs.Platforms = platforms
+		sh.hashInputsCache[result] = inputs
+	}
+	return result
+}
+
+// getHashInputs returns the values that are part of the hash of a service. Secret values are never included
+func (sh *serviceHasher) getHashInputs(buildInfo *build.Info, commitHash, diff string) HashInputs {
+	args := []string{}
+	for _, arg := range buildInfo.Args {
+		args = append(args, arg.String())
+	}
+
+	secrets := []string{}
+	for key := range buildInfo.Secrets {
+		secrets = append(secrets, key)
+	}
+	sort.Strings(secrets)
+
+	return HashInputs{
+		Commit:            commitHash,
+		Target:            buildInfo.Target,
+		BuildArgs:         args,
+		Secrets:           secrets,
+		Context:           buildInfo.Context,
+		Dockerfile:        buildInfo.Dockerfile,
+		DockerfileContent: sh.getDockerfileContent(buildInfo.Context, buildInfo.Dockerfile),
+		Diff:              diff,
+		Image:             buildInfo.Image,
+	}
 }
 
 // getDockerfileContent returns the content of the Dockerfile
 func (sh *serviceHasher) getDockerfileContent(dockerfileContext, dockerfilePath string) string {
-	content, err := afero.ReadFile(sh.fs, dockerfilePath)
+	content, err := sh.fs.ReadFile(dockerfilePath)
 	if err != nil {
 		oktetoLog.Infof("error trying to read Dockerfile on path '%s': %s", dockerfilePath, err)
 		if errors.Is(err, os.ErrNotExist) {
 			dockerfilePath = filepath.Join(dockerfileContext, dockerfilePath)
-			content, err = afero.ReadFile(sh.fs, dockerfilePath)
+			content, err = sh.fs.ReadFile(dockerfilePath)
 			if err != nil {
 				oktetoLog.Infof("error trying to read Dockerfile: %s", err)
 				return ""
@@ -157,3 +209,10 @@ func (sh *serviceHasher) getProjectCommitHashInCache() string {
 	defer sh.lock.RUnlock()
 	return sh.projectCommit
 }
+
+func (sh *serviceHasher) getHashInputsInCache(hash string) (HashInputs, bool) {
+	sh.lock.RLock()
+	defer sh.lock.RUnlock()
+	v, ok := sh.hashInputsCache[hash]
+	return v, ok
+}
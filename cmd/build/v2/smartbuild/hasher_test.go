@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/filesystem"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 )
@@ -134,3 +135,13 @@ func TestGetProjectCommitHashInCache(t *testing.T) {
 	result = sh.getProjectCommitHashInCache()
 	assert.Equal(t, "hash123", result)
 }
+
+func TestGetDockerfileContentNormalizesLineEndings(t *testing.T) {
+	t.Setenv(filesystem.NormalizeLineEndingsEnvVar, "true")
+	fs := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(fs, "Dockerfile.crlf", []byte("FROM alpine\r\nRUN echo hi\r\n"), 0600))
+	assert.NoError(t, afero.WriteFile(fs, "Dockerfile.lf", []byte("FROM alpine\nRUN echo hi\n"), 0600))
+
+	sh := newServiceHasher(nil, fs)
+	assert.Equal(t, sh.getDockerfileContent(".", "Dockerfile.lf"), sh.getDockerfileContent(".", "Dockerfile.crlf"))
+}
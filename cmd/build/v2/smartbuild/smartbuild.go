@@ -15,9 +15,11 @@ package smartbuild
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/okteto/okteto/pkg/build"
-	"github.com/okteto/okteto/pkg/env"
+	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/log/io"
 	"github.com/spf13/afero"
 )
@@ -46,8 +48,10 @@ type repositoryInterface interface {
 type hasherController interface {
 	hashProjectCommit(*build.Info) (string, error)
 	hashBuildContext(*build.Info) (string, error)
+	hashWithPlatforms(string, []string) string
 	getBuildContextHashInCache(string) string
 	getProjectCommitHashInCache() string
+	getHashInputsInCache(string) (HashInputs, bool)
 }
 
 // SmartBuildCtrl is the controller for smart builds
@@ -64,8 +68,8 @@ type SmartBuildCtrl struct {
 
 // NewSmartBuildCtrl creates a new smart build controller
 func NewSmartBuildCtrl(repo repositoryInterface, registry registryController, fs afero.Fs, ioCtrl *io.IOController) *SmartBuildCtrl {
-	isEnabled := env.LoadBooleanOrDefault(OktetoEnableSmartBuildEnvVar, true)
-	isUsingBuildCtx := env.LoadBoolean(OktetoSmartBuildUsingContextEnvVar)
+	isEnabled := config.EnvOktetoSmartBuildsEnabled.IsTrue()
+	isUsingBuildCtx := config.EnvOktetoSmartBuildsUsingBuildContext.IsTrue()
 
 	return &SmartBuildCtrl{
 		gitRepo:             repo,
@@ -94,15 +98,40 @@ func (s *SmartBuildCtrl) GetServiceHash(buildInfo *build.Info) (string, error) {
 	return s.hasher.hashBuildContext(buildInfo)
 }
 
-// GetBuildHash returns the hash of the build based on the env vars
-func (s *SmartBuildCtrl) GetBuildHash(buildInfo *build.Info) (string, error) {
+// GetBuildHash returns the hash of the build based on the env vars and the platforms the image is built for.
+// platform is a comma separated list of platforms like 'linux/amd64,linux/arm64', empty for the default platform of the builder
+func (s *SmartBuildCtrl) GetBuildHash(buildInfo *build.Info, platform string) (string, error) {
 	s.ioCtrl.Logger().Debugf("getting hash based on the buildContext env var")
+	var hash string
+	var err error
 	if s.isUsingBuildContext {
 		s.ioCtrl.Logger().Info("getting hash using build context due to env var")
-		return s.hasher.hashBuildContext(buildInfo)
+		hash, err = s.hasher.hashBuildContext(buildInfo)
+	} else {
+		s.ioCtrl.Logger().Info("getting hash using project commit")
+		hash, err = s.hasher.hashProjectCommit(buildInfo)
 	}
-	s.ioCtrl.Logger().Info("getting hash using project commit")
-	return s.hasher.hashProjectCommit(buildInfo)
+	if err != nil {
+		return "", err
+	}
+	return s.hasher.hashWithPlatforms(hash, normalizePlatforms(platform)), nil
+}
+
+// normalizePlatforms returns the sorted list of unique platforms of a comma separated list,
+// so the same platforms always produce the same build hash
+func normalizePlatforms(platform string) []string {
+	platforms := []string{}
+	seen := map[string]bool{}
+	for _, p := range strings.Split(platform, ",") {
+		p = strings.ToLower(strings.TrimSpace(p))
+		if p == "" || seen[p] {
+			continue
+		}
+		seen[p] = true
+		platforms = append(platforms, p)
+	}
+	sort.Strings(platforms)
+	return platforms
 }
 
 // GetBuildCommit returns the commit that generated the smart build
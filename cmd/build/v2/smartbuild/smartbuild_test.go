@@ -45,14 +45,19 @@ func (frc fakeRegistryController) CloneGlobalImageToDev(image string, _ string)
 func (frc fakeRegistryController) IsGlobalRegistry(string) bool { return frc.isGlobalRegistry }
 
 type fakeHasher struct {
-	err  error
-	hash string
+	err    error
+	hash   string
+	inputs HashInputs
 }
 
-func (fh fakeHasher) hashProjectCommit(*build.Info) (string, error) { return fh.hash, fh.err }
-func (fh fakeHasher) hashBuildContext(*build.Info) (string, error)  { return fh.hash, fh.err }
-func (fh fakeHasher) getBuildContextHashInCache(string) string      { return fh.hash }
-func (fh fakeHasher) getProjectCommitHashInCache() string           { return fh.hash }
+func (fh fakeHasher) hashProjectCommit(*build.Info) (string, error)    { return fh.hash, fh.err }
+func (fh fakeHasher) hashBuildContext(*build.Info) (string, error)     { return fh.hash, fh.err }
+func (fh fakeHasher) hashWithPlatforms(hash string, _ []string) string { return hash }
+func (fh fakeHasher) getBuildContextHashInCache(string) string         { return fh.hash }
+func (fh fakeHasher) getProjectCommitHashInCache() string              { return fh.hash }
+func (fh fakeHasher) getHashInputsInCache(string) (HashInputs, bool) {
+	return fh.inputs, fh.hash != ""
+}
 
 func TestNewSmartBuildCtrl(t *testing.T) {
 	type input struct {
@@ -300,7 +305,7 @@ func TestGetBuildHash(t *testing.T) {
 				},
 				isUsingBuildContext: tt.input.isUsingBuildContext,
 			}
-			out, err := sbc.GetBuildHash(&build.Info{})
+			out, err := sbc.GetBuildHash(&build.Info{}, "")
 			assert.Equal(t, tt.output.hash, out)
 			assert.ErrorIs(t, err, tt.output.err)
 		})
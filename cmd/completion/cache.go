@@ -0,0 +1,81 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package completion
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// cacheTTL is the time the cached values are used without refreshing them
+const cacheTTL = 5 * time.Minute
+
+// cacheEntry stores the values of a context
+type cacheEntry struct {
+	UpdatedAt  time.Time `json:"updatedAt"`
+	Namespaces []string  `json:"namespaces"`
+}
+
+// cache stores the values fetched from the API so completion is fast and works offline
+type cache struct {
+	Entries map[string]cacheEntry `json:"entries"`
+	path    string
+}
+
+func loadCache(path string) *cache {
+	c := &cache{Entries: map[string]cacheEntry{}, path: path}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return c
+	}
+	if err := json.Unmarshal(b, c); err != nil {
+		oktetoLog.Infof("failed to read completion cache: %s", err)
+	}
+	if c.Entries == nil {
+		c.Entries = map[string]cacheEntry{}
+	}
+	return c
+}
+
+func (c *cache) save() {
+	b, err := json.Marshal(c)
+	if err != nil {
+		oktetoLog.Infof("failed to generate completion cache: %s", err)
+		return
+	}
+	if err := os.WriteFile(c.path, b, 0600); err != nil {
+		oktetoLog.Infof("failed to write completion cache: %s", err)
+	}
+}
+
+// getNamespaces returns the namespaces of a context, refreshing them with list when the cache is expired.
+// If list fails, the expired values are returned
+func (c *cache) getNamespaces(contextName string, now time.Time, list func() ([]string, error)) []string {
+	entry, ok := c.Entries[contextName]
+	if ok && now.Sub(entry.UpdatedAt) < cacheTTL {
+		return entry.Namespaces
+	}
+
+	namespaces, err := list()
+	if err != nil {
+		oktetoLog.Infof("failed to list namespaces for completion: %s", err)
+		return entry.Namespaces
+	}
+	c.Entries[contextName] = cacheEntry{UpdatedAt: now, Namespaces: namespaces}
+	c.save()
+	return namespaces
+}
@@ -0,0 +1,159 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package completion adds dynamic values to the shell completion of the okteto commands
+package completion
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/okteto/okteto/pkg/config"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// apiTimeout is the maximum time waiting for the API while completing
+const apiTimeout = 2 * time.Second
+
+// devCommands are the commands whose first argument is the name of a dev container
+var devCommands = map[string]bool{
+	"up":      true,
+	"down":    true,
+	"exec":    true,
+	"restart": true,
+	"doctor":  true,
+}
+
+// Register adds the dynamic completion of namespaces, contexts and dev containers to the command tree
+// and the 'completion install' command
+func Register(root *cobra.Command) {
+	root.InitDefaultCompletionCmd()
+	for _, c := range root.Commands() {
+		if c.Name() == "completion" {
+			c.AddCommand(Install())
+		}
+	}
+
+	registered := map[*pflag.Flag]bool{}
+	register(root, registered)
+}
+
+func register(cmd *cobra.Command, registered map[*pflag.Flag]bool) {
+	registerFlag(cmd, "namespace", completeNamespaces, registered)
+	registerFlag(cmd, "context", completeContexts, registered)
+
+	if cmd.HasParent() && cmd.Parent() == cmd.Root() && devCommands[cmd.Name()] && cmd.ValidArgsFunction == nil {
+		cmd.ValidArgsFunction = completeDevs
+	}
+
+	for _, c := range cmd.Commands() {
+		register(c, registered)
+	}
+}
+
+func registerFlag(cmd *cobra.Command, name string, f func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective), registered map[*pflag.Flag]bool) {
+	flag := cmd.Flag(name)
+	if flag == nil || registered[flag] {
+		return
+	}
+	registered[flag] = true
+	if err := cmd.RegisterFlagCompletionFunc(name, f); err != nil {
+		oktetoLog.Infof("failed to register completion for flag '%s' of '%s': %s", name, cmd.CommandPath(), err)
+	}
+}
+
+func completeNamespaces(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	oktetoLog.SetOutputFormat(oktetoLog.SilentFormat)
+
+	store := okteto.ContextStore()
+	octx, ok := store.Contexts[store.CurrentContext]
+	if !ok || !octx.IsOkteto {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	c := loadCache(config.GetCompletionCachePath())
+	namespaces := c.getNamespaces(store.CurrentContext, time.Now(), listNamespaces)
+	return filterPrefix(namespaces, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+func listNamespaces() ([]string, error) {
+	oc, err := okteto.NewOktetoClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), apiTimeout)
+	defer cancel()
+	namespaces, err := oc.Namespaces().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]string, 0, len(namespaces))
+	for _, ns := range namespaces {
+		result = append(result, ns.ID)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+func completeContexts(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	oktetoLog.SetOutputFormat(oktetoLog.SilentFormat)
+
+	contexts := []string{}
+	for name := range okteto.ContextStore().Contexts {
+		contexts = append(contexts, name)
+	}
+	sort.Strings(contexts)
+	return filterPrefix(contexts, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeDevs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+	oktetoLog.SetOutputFormat(oktetoLog.SilentFormat)
+
+	manifestPath := ""
+	if f := cmd.Flags().Lookup("file"); f != nil && f.Changed {
+		manifestPath = f.Value.String()
+	}
+	manifest, err := model.GetManifestV2(manifestPath)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return filterPrefix(getDevNames(manifest), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+func getDevNames(manifest *model.Manifest) []string {
+	result := make([]string, 0, len(manifest.Dev))
+	for name := range manifest.Dev {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func filterPrefix(values []string, prefix string) []string {
+	result := []string{}
+	for _, v := range values {
+		if strings.HasPrefix(v, prefix) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
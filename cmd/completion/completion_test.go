@@ -0,0 +1,86 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package completion
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+	root := &cobra.Command{Use: "okteto"}
+	up := &cobra.Command{Use: "up"}
+	up.Flags().StringP("namespace", "n", "", "")
+	ns := &cobra.Command{Use: "namespace"}
+	ns.PersistentFlags().StringP("context", "c", "", "")
+	use := &cobra.Command{Use: "use"}
+	ns.AddCommand(use)
+	root.AddCommand(up, ns)
+
+	Register(root)
+
+	assert.NotNil(t, up.ValidArgsFunction)
+	assert.Nil(t, use.ValidArgsFunction)
+
+	install, _, err := root.Find([]string{"completion", "install"})
+	assert.NoError(t, err)
+	assert.Equal(t, "install", install.Name())
+
+	// registering a flag twice fails, so the flags have already been registered
+	assert.Error(t, up.RegisterFlagCompletionFunc("namespace", completeNamespaces))
+	assert.Error(t, use.RegisterFlagCompletionFunc("context", completeContexts))
+}
+
+func TestCacheGetNamespaces(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "completion-cache.json")
+	now := time.Now()
+	calls := 0
+	list := func() ([]string, error) {
+		calls++
+		return []string{"cindy", "movies"}, nil
+	}
+	offline := func() ([]string, error) {
+		calls++
+		return nil, assert.AnError
+	}
+
+	c := loadCache(path)
+	assert.Equal(t, []string{"cindy", "movies"}, c.getNamespaces("okteto.example.com", now, list))
+	assert.Equal(t, 1, calls)
+
+	c = loadCache(path)
+	assert.Equal(t, []string{"cindy", "movies"}, c.getNamespaces("okteto.example.com", now.Add(time.Minute), offline))
+	assert.Equal(t, 1, calls)
+
+	assert.Equal(t, []string{"cindy", "movies"}, c.getNamespaces("okteto.example.com", now.Add(time.Hour), offline))
+	assert.Equal(t, 2, calls)
+
+	assert.Empty(t, c.getNamespaces("other", now, offline))
+}
+
+func Test_getDevNames(t *testing.T) {
+	m := &model.Manifest{
+		Dev: model.ManifestDevs{
+			"worker": &model.Dev{},
+			"api":    &model.Dev{},
+		},
+	}
+	assert.Equal(t, []string{"api", "worker"}, getDevNames(m))
+	assert.Equal(t, []string{"worker"}, filterPrefix(getDevNames(m), "w"))
+}
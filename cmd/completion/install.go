@@ -0,0 +1,388 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package completion
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/config"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/spf13/cobra"
+)
+
+const (
+	bashShell       = "bash"
+	zshShell        = "zsh"
+	fishShell       = "fish"
+	powershellShell = "powershell"
+
+	// installMetadataFile stores the completion scripts installed by 'okteto completion install'
+	installMetadataFile = "install.json"
+)
+
+var supportedShells = []string{bashShell, zshShell, fishShell, powershellShell}
+
+// installation represents the completion of a shell installed by 'okteto completion install'
+type installation struct {
+	InstalledAt time.Time `json:"installedAt"`
+	Shell       string    `json:"shell"`
+	Script      string    `json:"script"`
+	RCFile      string    `json:"rcFile,omitempty"`
+}
+
+// installMetadata is stored in the okteto folder so the completion can be uninstalled
+// no matter how the okteto cli was installed
+type installMetadata struct {
+	Installations map[string]installation `json:"installations"`
+}
+
+// installer knows where the completion scripts and the rc files of every shell are
+type installer struct {
+	env           func(string) string
+	home          string
+	completionDir string
+	binary        string
+	goos          string
+}
+
+func newInstaller() *installer {
+	return &installer{
+		env:           os.Getenv,
+		home:          config.GetUserHomeDir(),
+		completionDir: config.GetCompletionDir(),
+		binary:        strings.TrimSuffix(config.GetBinaryName(), ".exe"),
+		goos:          runtime.GOOS,
+	}
+}
+
+// Install installs the completion script of the okteto cli for the given shell
+func Install() *cobra.Command {
+	var uninstall bool
+	cmd := &cobra.Command{
+		Use:   "install [bash|zsh|fish|powershell]",
+		Short: "Install the shell completion of the okteto cli",
+		Long: `Install the shell completion of the okteto cli.
+
+The shell is detected from the environment when it is not specified.
+The completion script is written in the okteto folder and loaded from the rc file of the shell. Running this command several times is safe.`,
+		Args:      utils.MaximumNArgsAccepted(1, ""),
+		ValidArgs: supportedShells,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			i := newInstaller()
+			shell := ""
+			if len(args) > 0 {
+				shell = args[0]
+			} else {
+				detected, err := i.detectShell()
+				if err != nil {
+					return err
+				}
+				shell = detected
+			}
+			if uninstall {
+				return i.uninstall(shell)
+			}
+			return i.install(cmd.Root(), shell)
+		},
+	}
+	cmd.Flags().BoolVar(&uninstall, "uninstall", false, "remove the completion script and the changes made to the rc file of the shell")
+	return cmd
+}
+
+// detectShell returns the shell of the user
+func (i *installer) detectShell() (string, error) {
+	if shell := i.env("SHELL"); shell != "" {
+		name := strings.TrimSuffix(filepath.Base(shell), ".exe")
+		if isSupportedShell(name) {
+			return name, nil
+		}
+		return "", oktetoErrors.UserError{
+			E:    fmt.Errorf("shell '%s' is not supported", name),
+			Hint: fmt.Sprintf("Supported shells are: %s", strings.Join(supportedShells, ", ")),
+		}
+	}
+	if i.goos == "windows" {
+		return powershellShell, nil
+	}
+	return "", oktetoErrors.UserError{
+		E:    errors.New("could not detect your shell"),
+		Hint: fmt.Sprintf("Run '%s completion install <shell>' with one of: %s", i.binary, strings.Join(supportedShells, ", ")),
+	}
+}
+
+func isSupportedShell(shell string) bool {
+	for _, s := range supportedShells {
+		if s == shell {
+			return true
+		}
+	}
+	return false
+}
+
+func (i *installer) validateShell(shell string) error {
+	if isSupportedShell(shell) {
+		return nil
+	}
+	return oktetoErrors.UserError{
+		E:    fmt.Errorf("shell '%s' is not supported", shell),
+		Hint: fmt.Sprintf("Supported shells are: %s", strings.Join(supportedShells, ", ")),
+	}
+}
+
+// getInstallation returns where the completion of a shell is installed
+func (i *installer) getInstallation(shell string) installation {
+	result := installation{Shell: shell}
+	switch shell {
+	case bashShell:
+		result.Script = filepath.Join(i.completionDir, fmt.Sprintf("%s.bash", i.binary))
+		result.RCFile = filepath.Join(i.home, ".bashrc")
+		if i.goos == "darwin" {
+			// the terminal of macOS starts login shells, which don't read .bashrc
+			result.RCFile = filepath.Join(i.home, ".bash_profile")
+		}
+	case zshShell:
+		result.Script = filepath.Join(i.completionDir, fmt.Sprintf("%s.zsh", i.binary))
+		dir := i.env("ZDOTDIR")
+		if dir == "" {
+			dir = i.home
+		}
+		result.RCFile = filepath.Join(dir, ".zshrc")
+	case fishShell:
+		// fish loads the completion scripts of this folder automatically
+		result.Script = filepath.Join(i.configDir(), "fish", "completions", fmt.Sprintf("%s.fish", i.binary))
+	case powershellShell:
+		result.Script = filepath.Join(i.completionDir, fmt.Sprintf("%s.ps1", i.binary))
+		if i.goos == "windows" {
+			result.RCFile = filepath.Join(i.home, "Documents", "PowerShell", "Microsoft.PowerShell_profile.ps1")
+		} else {
+			result.RCFile = filepath.Join(i.configDir(), "powershell", "Microsoft.PowerShell_profile.ps1")
+		}
+	}
+	return result
+}
+
+func (i *installer) configDir() string {
+	if dir := i.env("XDG_CONFIG_HOME"); dir != "" {
+		return dir
+	}
+	return filepath.Join(i.home, ".config")
+}
+
+// install writes the completion script of a shell and loads it from the rc file of the shell
+func (i *installer) install(root *cobra.Command, shell string) error {
+	if err := i.validateShell(shell); err != nil {
+		return err
+	}
+	inst := i.getInstallation(shell)
+
+	var script bytes.Buffer
+	if err := generateScript(root, shell, &script); err != nil {
+		return fmt.Errorf("failed to generate the %s completion script: %w", shell, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(inst.Script), 0700); err != nil {
+		return err
+	}
+	if err := os.WriteFile(inst.Script, script.Bytes(), 0600); err != nil {
+		return fmt.Errorf("failed to write the %s completion script: %w", shell, err)
+	}
+
+	if inst.RCFile != "" {
+		if err := updateRCFile(inst.RCFile, i.blockStart(), i.blockEnd(), i.rcBlock(inst)); err != nil {
+			return fmt.Errorf("failed to update '%s': %w", inst.RCFile, err)
+		}
+	}
+
+	inst.InstalledAt = time.Now()
+	meta := i.loadMetadata()
+	meta.Installations[shell] = inst
+	if err := i.saveMetadata(meta); err != nil {
+		oktetoLog.Infof("failed to save the completion install metadata: %s", err)
+	}
+
+	oktetoLog.Success("Completion for %s installed in %s", shell, inst.Script)
+	if inst.RCFile != "" {
+		oktetoLog.Information("Open a new terminal or reload '%s' to enable it", inst.RCFile)
+	} else {
+		oktetoLog.Information("Open a new terminal to enable it")
+	}
+	return nil
+}
+
+// uninstall removes the completion script of a shell and the lines added to its rc file
+func (i *installer) uninstall(shell string) error {
+	if err := i.validateShell(shell); err != nil {
+		return err
+	}
+	meta := i.loadMetadata()
+	inst, ok := meta.Installations[shell]
+	if !ok {
+		inst = i.getInstallation(shell)
+	}
+
+	if inst.RCFile != "" {
+		if err := removeRCBlock(inst.RCFile, i.blockStart(), i.blockEnd()); err != nil {
+			return fmt.Errorf("failed to update '%s': %w", inst.RCFile, err)
+		}
+	}
+	if err := os.Remove(inst.Script); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete the %s completion script: %w", shell, err)
+	}
+
+	delete(meta.Installations, shell)
+	if err := i.saveMetadata(meta); err != nil {
+		oktetoLog.Infof("failed to save the completion install metadata: %s", err)
+	}
+	oktetoLog.Success("Completion for %s uninstalled", shell)
+	return nil
+}
+
+func generateScript(root *cobra.Command, shell string, w io.Writer) error {
+	switch shell {
+	case bashShell:
+		return root.GenBashCompletionV2(w, true)
+	case zshShell:
+		return root.GenZshCompletion(w)
+	case fishShell:
+		return root.GenFishCompletion(w, true)
+	case powershellShell:
+		return root.GenPowerShellCompletionWithDesc(w)
+	}
+	return fmt.Errorf("shell '%s' is not supported", shell)
+}
+
+func (i *installer) blockStart() string {
+	return fmt.Sprintf("# >>> %s completion >>>", i.binary)
+}
+
+func (i *installer) blockEnd() string {
+	return fmt.Sprintf("# <<< %s completion <<<", i.binary)
+}
+
+// rcBlock returns the lines added to the rc file to load the completion script
+func (i *installer) rcBlock(inst installation) string {
+	lines := []string{i.blockStart()}
+	switch inst.Shell {
+	case bashShell:
+		lines = append(lines, fmt.Sprintf("[ -f '%s' ] && source '%s'", inst.Script, inst.Script))
+	case zshShell:
+		lines = append(lines,
+			"(( $+functions[compdef] )) || { autoload -U compinit && compinit }",
+			fmt.Sprintf("[ -f '%s' ] && source '%s'", inst.Script, inst.Script),
+		)
+	case powershellShell:
+		lines = append(lines, fmt.Sprintf("if (Test-Path '%s') { . '%s' }", inst.Script, inst.Script))
+	}
+	lines = append(lines, i.blockEnd())
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// updateRCFile adds the block to the rc file, replacing the block added by a previous installation
+func updateRCFile(path, start, end, block string) error {
+	content, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	updated, found := replaceBlock(string(content), start, end, block)
+	if !found {
+		updated = string(content)
+		if updated != "" && !strings.HasSuffix(updated, "\n") {
+			updated += "\n"
+		}
+		updated += block
+	}
+	if updated == string(content) {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return writeKeepingMode(path, []byte(updated))
+}
+
+// removeRCBlock removes the block added by 'okteto completion install' from the rc file
+func removeRCBlock(path, start, end string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	updated, found := replaceBlock(string(content), start, end, "")
+	if !found {
+		return nil
+	}
+	return writeKeepingMode(path, []byte(updated))
+}
+
+// replaceBlock replaces the lines between start and end, both included, with block
+func replaceBlock(content, start, end, block string) (string, bool) {
+	from := strings.Index(content, start+"\n")
+	if from < 0 {
+		return content, false
+	}
+	to := strings.Index(content[from:], end)
+	if to < 0 {
+		return content, false
+	}
+	to += from + len(end)
+	if to < len(content) && content[to] == '\n' {
+		to++
+	}
+	return content[:from] + block + content[to:], true
+}
+
+func writeKeepingMode(path string, content []byte) error {
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	return os.WriteFile(path, content, mode)
+}
+
+func (i *installer) loadMetadata() *installMetadata {
+	meta := &installMetadata{}
+	b, err := os.ReadFile(filepath.Join(i.completionDir, installMetadataFile))
+	if err == nil {
+		if err := json.Unmarshal(b, meta); err != nil {
+			oktetoLog.Infof("failed to read the completion install metadata: %s", err)
+		}
+	}
+	if meta.Installations == nil {
+		meta.Installations = map[string]installation{}
+	}
+	return meta
+}
+
+func (i *installer) saveMetadata(meta *installMetadata) error {
+	b, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(i.completionDir, 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(i.completionDir, installMetadataFile), b, 0600)
+}
@@ -0,0 +1,114 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package completion
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestInstaller(t *testing.T, env map[string]string, goos string) *installer {
+	home := t.TempDir()
+	return &installer{
+		env:           func(k string) string { return env[k] },
+		home:          home,
+		completionDir: filepath.Join(home, ".okteto", "completion"),
+		binary:        "okteto",
+		goos:          goos,
+	}
+}
+
+func TestDetectShell(t *testing.T) {
+	tests := []struct {
+		env      map[string]string
+		name     string
+		goos     string
+		expected string
+		wantErr  bool
+	}{
+		{name: "zsh", env: map[string]string{"SHELL": "/bin/zsh"}, goos: "darwin", expected: zshShell},
+		{name: "bash", env: map[string]string{"SHELL": "/usr/bin/bash"}, goos: "linux", expected: bashShell},
+		{name: "git bash on windows", env: map[string]string{"SHELL": "C:/Program Files/Git/usr/bin/bash.exe"}, goos: "windows", expected: bashShell},
+		{name: "powershell on windows", goos: "windows", expected: powershellShell},
+		{name: "unsupported", env: map[string]string{"SHELL": "/bin/tcsh"}, goos: "linux", wantErr: true},
+		{name: "unknown", goos: "linux", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shell, err := newTestInstaller(t, tt.env, tt.goos).detectShell()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, shell)
+		})
+	}
+}
+
+func TestGetInstallation(t *testing.T) {
+	i := newTestInstaller(t, map[string]string{"ZDOTDIR": "/zdot", "XDG_CONFIG_HOME": "/xdg"}, "linux")
+	assert.Equal(t, filepath.Join(i.home, ".bashrc"), i.getInstallation(bashShell).RCFile)
+	assert.Equal(t, filepath.Join("/zdot", ".zshrc"), i.getInstallation(zshShell).RCFile)
+	assert.Equal(t, filepath.Join("/xdg", "fish", "completions", "okteto.fish"), i.getInstallation(fishShell).Script)
+	assert.Empty(t, i.getInstallation(fishShell).RCFile)
+
+	i.goos = "darwin"
+	assert.Equal(t, filepath.Join(i.home, ".bash_profile"), i.getInstallation(bashShell).RCFile)
+}
+
+func TestInstallAndUninstall(t *testing.T) {
+	root := &cobra.Command{Use: "okteto"}
+	root.AddCommand(&cobra.Command{Use: "up"})
+	i := newTestInstaller(t, nil, "linux")
+	rcFile := filepath.Join(i.home, ".bashrc")
+	require.NoError(t, os.WriteFile(rcFile, []byte("export PATH=$PATH:/opt/bin"), 0640))
+
+	require.NoError(t, i.install(root, bashShell))
+	require.NoError(t, i.install(root, bashShell))
+
+	script := filepath.Join(i.completionDir, "okteto.bash")
+	assert.FileExists(t, script)
+	content, err := os.ReadFile(rcFile)
+	require.NoError(t, err)
+	assert.Equal(t, "export PATH=$PATH:/opt/bin\n# >>> okteto completion >>>\n[ -f '"+script+"' ] && source '"+script+"'\n# <<< okteto completion <<<\n", string(content))
+	assert.Contains(t, i.loadMetadata().Installations, bashShell)
+
+	require.NoError(t, i.uninstall(bashShell))
+	assert.NoFileExists(t, script)
+	content, err = os.ReadFile(rcFile)
+	require.NoError(t, err)
+	assert.Equal(t, "export PATH=$PATH:/opt/bin\n", string(content))
+	info, err := os.Stat(rcFile)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0640), info.Mode().Perm())
+	assert.Empty(t, i.loadMetadata().Installations)
+
+	assert.Error(t, i.install(root, "tcsh"))
+}
+
+func TestReplaceBlock(t *testing.T) {
+	content := "a\n# start\nold\n# end\nb\n"
+	updated, found := replaceBlock(content, "# start", "# end", "# start\nnew\n# end\n")
+	assert.True(t, found)
+	assert.Equal(t, "a\n# start\nnew\n# end\nb\n", updated)
+
+	_, found = replaceBlock("a\n", "# start", "# end", "")
+	assert.False(t, found)
+}
@@ -0,0 +1,34 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// Config groups the commands to manage the defaults of the okteto cli
+func Config() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage the defaults of the okteto cli stored in $OKTETO_HOME/config.yaml",
+		Long: `Manage the defaults of the okteto cli stored in $OKTETO_HOME/config.yaml.
+
+Flags and environment variables always take precedence over the values of the config file.`,
+	}
+	cmd.AddCommand(Get())
+	cmd.AddCommand(Set())
+	cmd.AddCommand(List())
+	cmd.AddCommand(EnvVars())
+	return cmd
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	oktetoConfig "github.com/okteto/okteto/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetGetList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+
+	require.NoError(t, executeSet(path, "default-context", "https://okteto.example.com"))
+	assert.Error(t, executeSet(path, "log-output", "xml"))
+
+	c, err := oktetoConfig.LoadCLIConfig(path)
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+	require.NoError(t, executeGet(c, "default-context", &b))
+	assert.Equal(t, "https://okteto.example.com\n", b.String())
+
+	b.Reset()
+	require.NoError(t, executeList(c, "json", &b))
+	values := []oktetoConfig.CLIConfigValue{}
+	require.NoError(t, json.Unmarshal(b.Bytes(), &values))
+	assert.Contains(t, values, oktetoConfig.CLIConfigValue{Key: "default-context", Value: "https://okteto.example.com", Description: "okteto context used when no context has been selected"})
+
+	b.Reset()
+	require.NoError(t, executeList(c, "", &b))
+	assert.Contains(t, b.String(), "log-output")
+
+	assert.Error(t, executeList(c, "xml", &b))
+
+	require.NoError(t, executeSet(path, "default-context", ""))
+	c, err = oktetoConfig.LoadCLIConfig(path)
+	require.NoError(t, err)
+	assert.Empty(t, c.DefaultContext)
+}
+
+func TestEnvVars(t *testing.T) {
+	values := []oktetoConfig.EnvVarValue{
+		{Name: "OKTETO_TIMEOUT", Type: "duration", Value: "5m", Source: oktetoConfig.EnvSourceEnvironment, Description: "timeout"},
+		{Name: "OKTETO_OLD", Type: "bool", Source: oktetoConfig.EnvSourceUnset, Description: "old", Deprecated: "use OKTETO_NEW instead"},
+	}
+
+	var b bytes.Buffer
+	require.NoError(t, executeEnvVars(values, "", &b))
+	assert.Contains(t, b.String(), "OKTETO_TIMEOUT  duration  5m     environment  timeout")
+	assert.Contains(t, b.String(), "OKTETO_OLD      bool      -      unset        old (deprecated: use OKTETO_NEW instead)")
+
+	b.Reset()
+	require.NoError(t, executeEnvVars(values, "json", &b))
+	result := []oktetoConfig.EnvVarValue{}
+	require.NoError(t, json.Unmarshal(b.Bytes(), &result))
+	assert.Equal(t, values, result)
+
+	assert.Error(t, executeEnvVars(values, "xml", &b))
+}
@@ -0,0 +1,49 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoConfig "github.com/okteto/okteto/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// Get prints the value of a config key
+func Get() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <key>",
+		Short: "Print the value of a config key",
+		Args:  utils.ExactArgsAccepted(1, ""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := oktetoConfig.LoadCLIConfig(oktetoConfig.GetCLIConfigPath())
+			if err != nil {
+				return err
+			}
+			return executeGet(c, args[0], os.Stdout)
+		},
+	}
+}
+
+func executeGet(c *oktetoConfig.CLIConfig, key string, w io.Writer) error {
+	value, err := c.Get(key)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(w, value)
+	return nil
+}
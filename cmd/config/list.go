@@ -0,0 +1,61 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoConfig "github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// List prints all the config keys and their values
+func List() *cobra.Command {
+	var outputFormat string
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the config keys and their values",
+		Args:  utils.NoArgsAccepted(""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := oktetoConfig.LoadCLIConfig(oktetoConfig.GetCLIConfigPath())
+			if err != nil {
+				return err
+			}
+			return executeList(c, outputFormat, os.Stdout)
+		},
+	}
+	output.AddFlag(cmd, &outputFormat)
+	return cmd
+}
+
+func executeList(c *oktetoConfig.CLIConfig, outputFormat string, w io.Writer) error {
+	values := c.List()
+	return output.Print(w, outputFormat, values, func(w io.Writer) error {
+		tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+		fmt.Fprintln(tw, "Key\tValue\tDescription")
+		for _, v := range values {
+			value := v.Value
+			if value == "" {
+				value = "-"
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", v.Key, value, v.Description)
+		}
+		return tw.Flush()
+	})
+}
@@ -0,0 +1,62 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoConfig "github.com/okteto/okteto/pkg/config"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/spf13/cobra"
+)
+
+// Set persists the value of a config key
+func Set() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <key> [value]",
+		Short: "Set the value of a config key. Omit the value to unset it",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := utils.MinimumNArgsAccepted(1, "")(cmd, args); err != nil {
+				return err
+			}
+			return utils.MaximumNArgsAccepted(2, "")(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			value := ""
+			if len(args) == 2 {
+				value = args[1]
+			}
+			return executeSet(oktetoConfig.GetCLIConfigPath(), args[0], value)
+		},
+	}
+}
+
+func executeSet(path, key, value string) error {
+	c, err := oktetoConfig.LoadCLIConfig(path)
+	if err != nil {
+		return err
+	}
+	if err := c.Set(key, value); err != nil {
+		return err
+	}
+	if err := c.Save(path); err != nil {
+		return err
+	}
+
+	if value == "" {
+		oktetoLog.Success("'%s' has been unset", key)
+		return nil
+	}
+	oktetoLog.Success("'%s' has been set to '%s'", key, value)
+	return nil
+}
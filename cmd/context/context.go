@@ -51,9 +51,11 @@ This will prompt you to select one of your existing contexts or to create a new
 		RunE: Use().RunE,
 	}
 	cmd.AddCommand(Show())
+	cmd.AddCommand(ShowCertificate())
 	cmd.AddCommand(Use())
 	cmd.AddCommand(List())
 	cmd.AddCommand(DeleteCMD())
+	cmd.AddCommand(Logout())
 
 	// deprecated
 	cmd.AddCommand(CreateCMD())
@@ -21,19 +21,20 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/compose-spec/godotenv"
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/analytics"
 	"github.com/okteto/okteto/pkg/cmd/login"
 	"github.com/okteto/okteto/pkg/config"
-	"github.com/okteto/okteto/pkg/env"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/filesystem"
 	"github.com/okteto/okteto/pkg/k8s/kubeconfig"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/retry"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/spf13/cobra"
 )
@@ -47,11 +48,25 @@ type kubeconfigTokenController interface {
 	updateOktetoContextToken(*types.UserContext) error
 }
 
+// apiCircuitBreaker tracks the consecutive failures of the okteto API to short-circuit its calls
+type apiCircuitBreaker interface {
+	Allow(name string) bool
+	RecordSuccess(name string)
+	RecordFailure(name string)
+}
+
+// apiCapabilitiesCache caches the capabilities advertised by the okteto API
+type apiCapabilitiesCache interface {
+	Update(contextName string, metadata types.ClusterMetadata)
+}
+
 // ContextCommand has the dependencies to run a ctxCommand
 type ContextCommand struct {
 	K8sClientProvider    okteto.K8sClientProvider
 	LoginController      login.LoginInterface
 	OktetoClientProvider oktetoClientProvider
+	APICircuitBreaker    apiCircuitBreaker
+	APICapabilities      apiCapabilitiesCache
 
 	kubetokenController kubeconfigTokenController
 	OktetoContextWriter okteto.ContextConfigWriterInterface
@@ -72,8 +87,10 @@ func NewContextCommand(ctxCmdOption ...ctxCmdOption) *ContextCommand {
 		LoginController:      login.NewLoginController(),
 		OktetoClientProvider: okteto.NewOktetoClientProvider(),
 		OktetoContextWriter:  okteto.NewContextConfigWriter(),
+		APICircuitBreaker:    okteto.NewCircuitBreaker(),
+		APICapabilities:      okteto.NewCapabilityChecker(nil, ""),
 	}
-	if env.LoadBoolean(OktetoUseStaticKubetokenEnvVar) {
+	if config.EnvOktetoUseStaticKubetoken.IsTrue() {
 		cfg.kubetokenController = newStaticKubetokenController()
 	} else {
 		cfg.kubetokenController = newDynamicKubetokenController(cfg.OktetoClientProvider)
@@ -196,6 +213,13 @@ func (c *ContextCommand) UseContext(ctx context.Context, ctxOptions *ContextOpti
 		}
 	}
 
+	if err := setContextLabelsPolicy(ctxStore.Contexts[ctxOptions.Context], ctxOptions); err != nil {
+		return err
+	}
+	if okCtx := ctxStore.Contexts[ctxOptions.Context]; okCtx != nil && ctxOptions.DevCatalog != "" {
+		okCtx.DevCatalog = ctxOptions.DevCatalog
+	}
+
 	if ctxOptions.Save {
 		hasAccess, err := hasAccessToNamespace(ctx, c, ctxOptions)
 		if err != nil {
@@ -283,6 +307,34 @@ func hasAccessToNamespace(ctx context.Context, c *ContextCommand, ctxOptions *Co
 }
 
 func (c *ContextCommand) initOktetoContext(ctx context.Context, ctxOptions *ContextOptions) error {
+	if c.APICircuitBreaker == nil {
+		return c.initOktetoContextFromAPI(ctx, ctxOptions)
+	}
+
+	if !c.APICircuitBreaker.Allow(ctxOptions.Context) {
+		oktetoLog.Infof("skipping the okteto API of '%s' after consecutive failures", ctxOptions.Context)
+		return c.initOfflineOktetoContext(ctxOptions, oktetoErrors.ErrAPIUnavailable)
+	}
+
+	apiCtx := ctx
+	if ctxOptions.Token != "" && !ctxOptions.IsCtxCommand {
+		// a hanging API must count as a failure too. Interactive logins are not bounded
+		var cancel context.CancelFunc
+		apiCtx, cancel = context.WithTimeout(ctx, apiPreflightTimeout)
+		defer cancel()
+	}
+	err := c.initOktetoContextFromAPI(apiCtx, ctxOptions)
+	switch {
+	case err == nil:
+		c.APICircuitBreaker.RecordSuccess(ctxOptions.Context)
+	case isAPIUnavailableErr(err):
+		c.APICircuitBreaker.RecordFailure(ctxOptions.Context)
+		return c.initOfflineOktetoContext(ctxOptions, err)
+	}
+	return err
+}
+
+func (c *ContextCommand) initOktetoContextFromAPI(ctx context.Context, ctxOptions *ContextOptions) error {
 	var userContext *types.UserContext
 	userContext, err := getLoggedUserContext(ctx, c, ctxOptions)
 	if err != nil {
@@ -315,6 +367,9 @@ func (c *ContextCommand) initOktetoContext(ctx context.Context, ctxOptions *Cont
 		oktetoLog.Infof("error getting cluster metadata: %v", err)
 		return err
 	}
+	if c.APICapabilities != nil {
+		c.APICapabilities.Update(ctxOptions.Context, clusterMetadata)
+	}
 
 	// once we have namespace and user identify we are able to retrieve the dynamic token for the namespace
 	err = c.kubetokenController.updateOktetoContextToken(userContext)
@@ -347,7 +402,13 @@ func (c *ContextCommand) initOktetoContext(ctx context.Context, ctxOptions *Cont
 }
 
 func getLoggedUserContext(ctx context.Context, c *ContextCommand, ctxOptions *ContextOptions) (*types.UserContext, error) {
-	user, err := c.LoginController.AuthenticateToOktetoCluster(ctx, ctxOptions.Context, ctxOptions.Token)
+	var user *types.User
+	var err error
+	if ctxOptions.Token == "" && ctxOptions.DeviceCode {
+		user, err = c.LoginController.AuthenticateWithDeviceCode(ctx, ctxOptions.Context)
+	} else {
+		user, err = c.LoginController.AuthenticateToOktetoCluster(ctx, ctxOptions.Context, ctxOptions.Token)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -397,6 +458,9 @@ func (*ContextCommand) initKubernetesContext(ctxOptions *ContextOptions) error {
 	return nil
 }
 
+// errRetryUserContext signals a transient failure retrieving the user context
+var errRetryUserContext = errors.New("error retrieving the user context")
+
 func (c ContextCommand) getUserContext(ctx context.Context, ctxName, ns, token string) (*types.UserContext, error) {
 	client, err := c.OktetoClientProvider.Provide(
 		okteto.WithCtxName(ctxName),
@@ -406,62 +470,69 @@ func (c ContextCommand) getUserContext(ctx context.Context, ctxName, ns, token s
 		return nil, err
 	}
 
-	retries := 0
-	for retries <= 3 {
-		userContext, err := client.User().GetContext(ctx, ns)
+	var userContext *types.UserContext
+	policy := retry.Constant("okteto user context", 200*time.Millisecond, 4).WithJitter(retry.DefaultJitter)
+	err = retry.Do(ctx, policy, func(ctx context.Context) error {
+		uc, err := client.User().GetContext(ctx, ns)
 
 		if err != nil {
 			if errors.Is(err, oktetoErrors.ErrTokenExpired) {
-				return nil, err
+				return retry.Permanent(err)
 			}
 
 			if oktetoErrors.IsForbidden(err) {
 				if err := c.OktetoContextWriter.Write(); err != nil {
 					oktetoLog.Infof("error updating okteto contexts: %v", err)
-					return nil, fmt.Errorf(oktetoErrors.ErrCorruptedOktetoContexts, config.GetOktetoContextsStorePath())
+					return retry.Permanent(fmt.Errorf(oktetoErrors.ErrCorruptedOktetoContexts, config.GetOktetoContextsStorePath()))
 				}
-				return nil, oktetoErrors.NotLoggedError{
+				return retry.Permanent(oktetoErrors.NotLoggedError{
 					Context: okteto.Context().Name,
-				}
+				})
 			}
 
-			// If there is a TLS error, don't continue the loop and return the raw error
+			// If there is a TLS error, don't retry and return the raw error
 			if oktetoErrors.IsX509(err) {
-				return nil, err
+				return retry.Permanent(err)
 			}
 
 			if errors.Is(err, oktetoErrors.ErrInvalidLicense) {
-				return nil, err
+				return retry.Permanent(err)
 			}
 
 			if oktetoErrors.IsNotFound(err) {
 				// fallback to personal namespace using empty string as param
-				userContext, err = client.User().GetContext(ctx, "")
+				uc, err = client.User().GetContext(ctx, "")
 				if err != nil {
-					return nil, err
+					return retry.Permanent(err)
 				}
 			}
 		}
 
 		if err != nil {
 			oktetoLog.Info(err)
-			retries++
-			continue
+			return errRetryUserContext
 		}
-
-		// If userID is not on context config file we add it and save it.
-		// this prevents from relogin to actual users
-		if okteto.Context().UserID == "" && okteto.Context().IsOkteto {
-			okteto.Context().UserID = userContext.User.ID
-			if err := c.OktetoContextWriter.Write(); err != nil {
-				oktetoLog.Infof("error updating okteto contexts: %v", err)
-				return nil, fmt.Errorf(oktetoErrors.ErrCorruptedOktetoContexts, config.GetOktetoContextsStorePath())
-			}
+		userContext = uc
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, errRetryUserContext) {
+			return nil, oktetoErrors.ErrInternalServerError
 		}
+		return nil, err
+	}
 
-		return userContext, nil
+	// If userID is not on context config file we add it and save it.
+	// this prevents from relogin to actual users
+	if okteto.Context().UserID == "" && okteto.Context().IsOkteto {
+		okteto.Context().UserID = userContext.User.ID
+		if err := c.OktetoContextWriter.Write(); err != nil {
+			oktetoLog.Infof("error updating okteto contexts: %v", err)
+			return nil, fmt.Errorf(oktetoErrors.ErrCorruptedOktetoContexts, config.GetOktetoContextsStorePath())
+		}
 	}
-	return nil, oktetoErrors.ErrInternalServerError
+
+	return userContext, nil
 }
 
 func (*ContextCommand) initEnvVars() {
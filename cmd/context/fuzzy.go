@@ -0,0 +1,136 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package context
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/agext/levenshtein"
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+)
+
+// contextMatchThreshold is the maximum edit distance between a context and the name given by the user to suggest it
+const contextMatchThreshold = 3
+
+type contextMatch struct {
+	name     string
+	distance int
+}
+
+// normalizeContextName removes the differences that don't change the context a name refers to, like the schema or a trailing slash
+func normalizeContextName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.TrimPrefix(name, "https://")
+	name = strings.TrimPrefix(name, "http://")
+	return strings.TrimSuffix(name, "/")
+}
+
+// getContextMatches returns the contexts similar to name, sorted from the best to the worst match
+func getContextMatches(name string, contexts []string) []contextMatch {
+	target := normalizeContextName(name)
+	matches := []contextMatch{}
+	for _, c := range contexts {
+		normalized := normalizeContextName(c)
+		distance := levenshtein.Distance(target, normalized, nil)
+		if distance > contextMatchThreshold && !strings.Contains(normalized, target) {
+			continue
+		}
+		matches = append(matches, contextMatch{name: c, distance: distance})
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+		return matches[i].name < matches[j].name
+	})
+	return matches
+}
+
+// getKnownContexts returns the okteto contexts and the kubernetes contexts of the kubeconfig
+func getKnownContexts() []string {
+	known := map[string]bool{}
+	result := []string{}
+	for name := range okteto.ContextStore().Contexts {
+		known[name] = true
+		result = append(result, name)
+	}
+	for _, name := range getKubernetesContextList(false) {
+		if !known[name] {
+			known[name] = true
+			result = append(result, name)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// resolveContextName returns the context the user refers to with name.
+// If name doesn't match any context exactly, the similar ones are offered in a selector.
+// With yes, only a context equivalent to name once normalized is picked, otherwise the similar ones are returned as an error
+func resolveContextName(name string, contexts []string, yes, interactive bool, selector utils.OktetoSelectorInterface) (string, error) {
+	for _, c := range contexts {
+		if c == name {
+			return name, nil
+		}
+	}
+
+	matches := getContextMatches(name, contexts)
+	if len(matches) == 0 {
+		return name, nil
+	}
+
+	exactMatch := matches[0].distance == 0 && (len(matches) == 1 || matches[1].distance > 0)
+	if exactMatch {
+		oktetoLog.Information("Using context '%s', the closest match to '%s'", matches[0].name, name)
+		return matches[0].name, nil
+	}
+
+	if yes {
+		suggestions := make([]string, 0, len(matches))
+		for _, m := range matches {
+			suggestions = append(suggestions, fmt.Sprintf("'%s'", m.name))
+		}
+		return "", oktetoErrors.UserError{
+			E:    fmt.Errorf("context '%s' not found", name),
+			Hint: fmt.Sprintf("Did you mean %s?", strings.Join(suggestions, " or ")),
+		}
+	}
+
+	if !interactive {
+		return name, nil
+	}
+
+	useName := fmt.Sprintf("Use '%s'", name)
+	items := make([]utils.SelectorItem, 0, len(matches)+2)
+	for _, m := range matches {
+		items = append(items, utils.SelectorItem{Name: m.name, Label: m.name, Enable: true})
+	}
+	items = append(items,
+		utils.SelectorItem{Label: "", Enable: false},
+		utils.SelectorItem{Name: useName, Label: useName, Enable: true},
+	)
+	selected, err := selector.AskForOptionsOkteto(items, 0)
+	if err != nil {
+		return "", err
+	}
+	if selected == useName {
+		return name, nil
+	}
+	return selected, nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package context
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeContextSelector struct {
+	err      error
+	selected string
+	options  []utils.SelectorItem
+}
+
+func (s *fakeContextSelector) AskForOptionsOkteto(options []utils.SelectorItem, _ int) (string, error) {
+	s.options = options
+	return s.selected, s.err
+}
+
+func Test_getContextMatches(t *testing.T) {
+	contexts := []string{
+		"https://okteto.example.com",
+		"https://okteto.staging.example.com",
+		"minikube",
+		"kind-okteto",
+	}
+	matches := getContextMatches("okteto.example.com/", contexts)
+	assert.Equal(t, []contextMatch{{name: "https://okteto.example.com", distance: 0}}, matches)
+
+	// contexts containing the name are offered even if they are not similar
+	assert.Equal(t, []contextMatch{
+		{name: "kind-okteto", distance: 5},
+		{name: "https://okteto.example.com", distance: 12},
+		{name: "https://okteto.staging.example.com", distance: 20},
+	}, getContextMatches("okteto", contexts))
+
+	assert.Equal(t, []contextMatch{{name: "minikube", distance: 1}}, getContextMatches("minikub", contexts))
+	assert.Empty(t, getContextMatches("docker-desktop", contexts))
+}
+
+func Test_resolveContextName(t *testing.T) {
+	contexts := []string{
+		"https://okteto.example.com",
+		"https://okteto.exemple.com",
+		"minikube",
+	}
+	var tests = []struct {
+		name            string
+		input           string
+		selected        string
+		expected        string
+		yes             bool
+		interactive     bool
+		expectedOptions int
+	}{
+		{
+			name:     "exact match",
+			input:    "minikube",
+			expected: "minikube",
+		},
+		{
+			name:     "missing schema",
+			input:    "http://OKTETO.example.com",
+			expected: "https://okteto.example.com",
+		},
+		{
+			name:     "no similar contexts",
+			input:    "https://cloud.okteto.com",
+			expected: "https://cloud.okteto.com",
+		},
+		{
+			name:     "normalized match with yes",
+			input:    "okteto.example.com/",
+			yes:      true,
+			expected: "https://okteto.example.com",
+		},
+		{
+			name:     "not interactive",
+			input:    "https://okteto.exampl.com",
+			expected: "https://okteto.exampl.com",
+		},
+		{
+			name:            "selected a similar context",
+			input:           "https://okteto.exampl.com",
+			interactive:     true,
+			selected:        "https://okteto.exemple.com",
+			expected:        "https://okteto.exemple.com",
+			expectedOptions: 4,
+		},
+		{
+			name:            "selected the given name",
+			input:           "minikub",
+			interactive:     true,
+			selected:        "Use 'minikub'",
+			expected:        "minikub",
+			expectedOptions: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector := &fakeContextSelector{selected: tt.selected}
+			result, err := resolveContextName(tt.input, contexts, tt.yes, tt.interactive, selector)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+			assert.Len(t, selector.options, tt.expectedOptions)
+		})
+	}
+}
+
+func Test_resolveContextNameWithYesRequiresEquivalentName(t *testing.T) {
+	contexts := []string{"staging", "production"}
+	_, err := resolveContextName("staging2", contexts, true, true, &fakeContextSelector{})
+	var uErr oktetoErrors.UserError
+	assert.ErrorAs(t, err, &uErr)
+	assert.EqualError(t, err, "context 'staging2' not found")
+	assert.Equal(t, "Did you mean 'staging'?", uErr.Hint)
+}
@@ -15,39 +15,42 @@ package context
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"text/tabwriter"
 
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/output"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 )
 
-var output string
-
 // List returns all contexts managed by okteto
 func List() *cobra.Command {
+	var outputFormat string
 	cmd := &cobra.Command{
 		Use:     "list",
 		Aliases: []string{"ls"},
 		Args:    utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#list"),
 		Short:   "List available contexts",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.Validate(outputFormat); err != nil {
+				return err
+			}
 			ctx := context.Background()
 			if err := NewContextCommand().Run(ctx, &ContextOptions{raiseNotCtxError: true}); err != nil {
 				return err
 			}
-			return executeListContext()
+			return executeListContext(outputFormat)
 		},
 	}
-	cmd.Flags().StringVarP(&output, "output", "o", "", "Output format. One of: json|yaml")
+	output.AddFlag(cmd, &outputFormat)
 	return cmd
 }
 
-func executeListContext() error {
+func executeListContext(outputFormat string) error {
 	contexts := getOktetoClusters(false)
 	contexts = append(contexts, getK8sClusters(getKubernetesContextList(true))...)
 
@@ -79,31 +82,15 @@ func executeListContext() error {
 		ctxs = append(ctxs, ctxViewer)
 	}
 
-	if output == "" {
-		w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
-		fmt.Fprintf(w, "Name\tNamespace\tBuilder\tRegistry\n")
+	return output.Print(os.Stdout, outputFormat, ctxs, func(w io.Writer) error {
+		tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+		fmt.Fprintf(tw, "Name\tNamespace\tBuilder\tRegistry\n")
 		for _, ctx := range ctxs {
 			if ctx.Name == ctxStore.CurrentContext {
 				ctx.Name += " *"
 			}
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", ctx.Name, ctx.Namespace, ctx.Builder, ctx.Registry)
-		}
-		w.Flush()
-	} else if output == "json" {
-		ctxRaw, err := json.MarshalIndent(ctxs, "", "\t")
-		if err != nil {
-			return err
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", ctx.Name, ctx.Namespace, ctx.Builder, ctx.Registry)
 		}
-		fmt.Println(string(ctxRaw))
-	} else if output == "yaml" {
-		ctxRaw, err := yaml.Marshal(&ctxs)
-		if err != nil {
-			return err
-		}
-		fmt.Print(string(ctxRaw))
-	} else {
-		return fmt.Errorf("unable to match a printer suitable for the output format \"%s\", allowed formats are: json, yaml", output)
-	}
-
-	return nil
+		return tw.Flush()
+	}, output.WithJSONIndent("\t"), output.WithYAMLMarshal(yaml.Marshal))
 }
@@ -0,0 +1,240 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package context
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/config"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/kubeconfig"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/cobra"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// tokenRevoker invalidates the token of an okteto context in the okteto API
+type tokenRevoker func(ctx context.Context, contextName, token string) error
+
+// logoutCommand removes the sessions of okteto contexts
+type logoutCommand struct {
+	revoke          tokenRevoker
+	writer          okteto.ContextConfigWriterInterface
+	kubeconfigPaths []string
+}
+
+// Logout removes the session of an okteto context
+func Logout() *cobra.Command {
+	var all bool
+	cmd := &cobra.Command{
+		Use:   "logout [<url>]",
+		Args:  utils.MaximumNArgsAccepted(1, "https://okteto.com/docs/reference/cli/#logout"),
+		Short: "Log out from an okteto context",
+		Long: `Log out from an okteto context
+
+The token of the context is revoked in your Okteto instance and removed from your machine, together with the credentials of the context in your kubeconfig file.
+If no context is given, it logs out from the current context. Use --all to log out from every okteto context.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if all && len(args) > 0 {
+				return oktetoErrors.UserError{
+					E:    fmt.Errorf("the flag '--all' can't be used with a context name"),
+					Hint: "Run 'okteto context logout <url>' to log out from a single context",
+				}
+			}
+			lc := &logoutCommand{
+				revoke:          revokeToken,
+				writer:          okteto.NewContextConfigWriter(),
+				kubeconfigPaths: config.GetKubeconfigPath(),
+			}
+			return lc.run(context.Background(), args, all)
+		},
+	}
+	cmd.Flags().BoolVarP(&all, "all", "", false, "log out from every okteto context")
+	return cmd
+}
+
+func (lc *logoutCommand) run(ctx context.Context, args []string, all bool) error {
+	ctxStore := okteto.ContextStore()
+	names, err := getLogoutContexts(ctxStore, args, all)
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		oktetoLog.Information("There are no okteto contexts to log out from")
+		return nil
+	}
+
+	for _, name := range names {
+		okCtx := ctxStore.Contexts[name]
+		if okCtx.Token == "" {
+			oktetoLog.Infof("context '%s' has no token", name)
+			continue
+		}
+		if err := lc.revoke(ctx, name, okCtx.Token); err != nil {
+			oktetoLog.Warning("Could not revoke the token of '%s': %s", name, err)
+		}
+		okCtx.Token = ""
+		// a context without a token can't be used, so it can't stay as the current context
+		if name == ctxStore.CurrentContext {
+			ctxStore.CurrentContext = ""
+		}
+	}
+	if err := lc.writer.Write(); err != nil {
+		return err
+	}
+
+	if err := lc.cleanKubeconfig(ctxStore, names); err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		oktetoLog.Success("Logged out from '%s'", name)
+	}
+	return nil
+}
+
+// getLogoutContexts returns the names of the okteto contexts to log out from
+func getLogoutContexts(ctxStore *okteto.OktetoContextStore, args []string, all bool) ([]string, error) {
+	if all {
+		names := []string{}
+		for name, okCtx := range ctxStore.Contexts {
+			if okCtx.IsOkteto {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		return names, nil
+	}
+
+	name := ctxStore.CurrentContext
+	if len(args) == 1 {
+		name = strings.TrimSuffix(okteto.AddSchema(args[0]), "/")
+	}
+	if name == "" {
+		return nil, oktetoErrors.ErrCtxNotSet
+	}
+	okCtx, ok := ctxStore.Contexts[name]
+	if !ok {
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("'%s' context doesn't exist", name),
+			Hint: "Run 'okteto context list' to see your contexts",
+		}
+	}
+	if !okCtx.IsOkteto {
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("'%s' is not an okteto context", name),
+			Hint: "To delete a Kubernetes context run 'kubectl config delete-context <k8s-context-name>'",
+		}
+	}
+	return []string{name}, nil
+}
+
+// cleanKubeconfig removes the kubernetes contexts of the okteto contexts from the kubeconfig file
+func (lc *logoutCommand) cleanKubeconfig(ctxStore *okteto.OktetoContextStore, names []string) error {
+	if len(lc.kubeconfigPaths) == 0 {
+		return nil
+	}
+	cfg := kubeconfig.Get(lc.kubeconfigPaths)
+	if cfg == nil {
+		return nil
+	}
+
+	changed := false
+	for _, name := range names {
+		if removeKubeconfigEntries(cfg, name, ctxStore.Contexts[name].UserID) {
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return kubeconfig.Write(cfg, lc.kubeconfigPaths[0])
+}
+
+// removeKubeconfigEntries removes the kubernetes context of an okteto context and the cluster and user entries
+// that are not used by any other kubernetes context, including the 'okteto kubetoken' exec entries of the context.
+// It returns true if the kubeconfig has changed
+func removeKubeconfigEntries(cfg *clientcmdapi.Config, contextName, userID string) bool {
+	changed := false
+	k8sContext := okteto.UrlToKubernetesContext(contextName)
+	clusters := map[string]bool{}
+	authInfos := map[string]bool{}
+	if kubeCtx, ok := cfg.Contexts[k8sContext]; ok {
+		delete(cfg.Contexts, k8sContext)
+		clusters[kubeCtx.Cluster] = true
+		authInfos[kubeCtx.AuthInfo] = true
+		changed = true
+	}
+	if cfg.CurrentContext == k8sContext {
+		cfg.CurrentContext = ""
+		changed = true
+	}
+	if userID != "" {
+		authInfos[userID] = true
+	}
+	for name, authInfo := range cfg.AuthInfos {
+		if isKubetokenExecFor(authInfo, contextName) {
+			authInfos[name] = true
+		}
+	}
+
+	for _, kubeCtx := range cfg.Contexts {
+		delete(clusters, kubeCtx.Cluster)
+		delete(authInfos, kubeCtx.AuthInfo)
+	}
+	for name := range clusters {
+		if _, ok := cfg.Clusters[name]; ok {
+			delete(cfg.Clusters, name)
+			changed = true
+		}
+	}
+	for name := range authInfos {
+		if _, ok := cfg.AuthInfos[name]; ok {
+			delete(cfg.AuthInfos, name)
+			changed = true
+		}
+	}
+	return changed
+}
+
+// isKubetokenExecFor returns true if the user entry gets its token from 'okteto kubetoken' for the given context
+func isKubetokenExecFor(authInfo *clientcmdapi.AuthInfo, contextName string) bool {
+	if authInfo == nil || authInfo.Exec == nil || authInfo.Exec.Command != "okteto" {
+		return false
+	}
+	args := authInfo.Exec.Args
+	if len(args) == 0 || args[0] != "kubetoken" {
+		return false
+	}
+	for i := 1; i < len(args)-1; i++ {
+		if args[i] == "--context" && args[i+1] == contextName {
+			return true
+		}
+	}
+	return false
+}
+
+func revokeToken(ctx context.Context, contextName, token string) error {
+	c, err := okteto.NewOktetoClientFromUrlAndToken(contextName, token)
+	if err != nil {
+		return err
+	}
+	return c.Session().RevokeToken(ctx, contextName)
+}
@@ -0,0 +1,173 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package context
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/k8s/kubeconfig"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+type fakeContextWriter struct {
+	calls int
+}
+
+func (f *fakeContextWriter) Write() error {
+	f.calls++
+	return nil
+}
+
+func newLogoutStore() *okteto.OktetoContextStore {
+	return &okteto.OktetoContextStore{
+		CurrentContext: "https://okteto.example.com",
+		Contexts: map[string]*okteto.OktetoContext{
+			"https://okteto.example.com": {Name: "https://okteto.example.com", Token: "token-a", UserID: "user-a", IsOkteto: true},
+			"https://okteto.other.com":   {Name: "https://okteto.other.com", Token: "token-b", UserID: "user-b", IsOkteto: true},
+			"minikube":                   {Name: "minikube"},
+		},
+	}
+}
+
+func newLogoutKubeconfig() *clientcmdapi.Config {
+	cfg := clientcmdapi.NewConfig()
+	cfg.CurrentContext = "okteto_example_com"
+	cfg.Contexts["okteto_example_com"] = &clientcmdapi.Context{Cluster: "okteto_example_com", AuthInfo: "user-a"}
+	cfg.Contexts["okteto_other_com"] = &clientcmdapi.Context{Cluster: "okteto_other_com", AuthInfo: "user-b"}
+	cfg.Contexts["minikube"] = &clientcmdapi.Context{Cluster: "minikube", AuthInfo: "minikube"}
+	cfg.Clusters["okteto_example_com"] = &clientcmdapi.Cluster{Server: "https://kubernetes.okteto.example.com"}
+	cfg.Clusters["okteto_other_com"] = &clientcmdapi.Cluster{Server: "https://kubernetes.okteto.other.com"}
+	cfg.Clusters["minikube"] = &clientcmdapi.Cluster{Server: "https://127.0.0.1:8443"}
+	cfg.AuthInfos["user-a"] = &clientcmdapi.AuthInfo{
+		Exec: &clientcmdapi.ExecConfig{Command: "okteto", Args: []string{"kubetoken", "--context", "https://okteto.example.com", "--namespace", "a"}},
+	}
+	cfg.AuthInfos["user-b"] = &clientcmdapi.AuthInfo{Token: "static"}
+	cfg.AuthInfos["minikube"] = &clientcmdapi.AuthInfo{Token: "minikube"}
+	cfg.AuthInfos["dangling"] = &clientcmdapi.AuthInfo{
+		Exec: &clientcmdapi.ExecConfig{Command: "okteto", Args: []string{"kubetoken", "--context", "https://okteto.example.com", "--namespace", "b"}},
+	}
+	return cfg
+}
+
+func Test_logoutCommand(t *testing.T) {
+	tests := []struct {
+		expectedErr      error
+		name             string
+		expectedCurrent  string
+		args             []string
+		expectedRevoked  []string
+		expectedContexts []string
+		all              bool
+	}{
+		{
+			name:             "current context",
+			expectedRevoked:  []string{"token-a"},
+			expectedContexts: []string{"minikube", "okteto_other_com"},
+		},
+		{
+			name:             "context by name",
+			args:             []string{"okteto.other.com/"},
+			expectedRevoked:  []string{"token-b"},
+			expectedCurrent:  "https://okteto.example.com",
+			expectedContexts: []string{"minikube", "okteto_example_com"},
+		},
+		{
+			name:             "all contexts",
+			all:              true,
+			expectedRevoked:  []string{"token-a", "token-b"},
+			expectedContexts: []string{"minikube"},
+		},
+		{
+			name:        "unknown context",
+			args:        []string{"https://okteto.unknown.com"},
+			expectedErr: errors.New("'https://okteto.unknown.com' context doesn't exist"),
+		},
+		{
+			name:        "kubernetes context",
+			args:        []string{"minikube"},
+			expectedErr: errors.New("'https://minikube' context doesn't exist"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kubeconfigPath := filepath.Join(t.TempDir(), "config")
+			require.NoError(t, kubeconfig.Write(newLogoutKubeconfig(), kubeconfigPath))
+			okteto.CurrentStore = newLogoutStore()
+
+			revoked := []string{}
+			writer := &fakeContextWriter{}
+			lc := &logoutCommand{
+				revoke: func(_ context.Context, _, token string) error {
+					revoked = append(revoked, token)
+					return errors.New("revoke error")
+				},
+				writer:          writer,
+				kubeconfigPaths: []string{kubeconfigPath},
+			}
+
+			err := lc.run(context.Background(), tt.args, tt.all)
+			if tt.expectedErr != nil {
+				require.ErrorContains(t, err, tt.expectedErr.Error())
+				assert.Equal(t, 0, writer.calls)
+				return
+			}
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.expectedRevoked, revoked)
+			assert.Equal(t, 1, writer.calls)
+			assert.Equal(t, tt.expectedCurrent, okteto.ContextStore().CurrentContext)
+
+			for _, okCtx := range okteto.ContextStore().Contexts {
+				if okCtx.Token == "" {
+					continue
+				}
+				assert.NotContains(t, tt.expectedRevoked, okCtx.Token)
+			}
+
+			cfg := kubeconfig.Get([]string{kubeconfigPath})
+			contexts := []string{}
+			for name := range cfg.Contexts {
+				contexts = append(contexts, name)
+			}
+			assert.ElementsMatch(t, tt.expectedContexts, contexts)
+			// the exec entries of a context are only removed when logging out from it
+			_, exampleKept := cfg.Contexts["okteto_example_com"]
+			_, danglingKept := cfg.AuthInfos["dangling"]
+			assert.Equal(t, exampleKept, danglingKept)
+			assert.Contains(t, cfg.AuthInfos, "minikube")
+			assert.Contains(t, cfg.Clusters, "minikube")
+		})
+	}
+}
+
+func Test_removeKubeconfigEntriesKeepsSharedEntries(t *testing.T) {
+	cfg := clientcmdapi.NewConfig()
+	cfg.Contexts["okteto_example_com"] = &clientcmdapi.Context{Cluster: "shared", AuthInfo: "user"}
+	cfg.Contexts["other"] = &clientcmdapi.Context{Cluster: "shared", AuthInfo: "user"}
+	cfg.Clusters["shared"] = &clientcmdapi.Cluster{}
+	cfg.AuthInfos["user"] = &clientcmdapi.AuthInfo{}
+
+	assert.True(t, removeKubeconfigEntries(cfg, "https://okteto.example.com", "user"))
+	assert.NotContains(t, cfg.Contexts, "okteto_example_com")
+	assert.Contains(t, cfg.Clusters, "shared")
+	assert.Contains(t, cfg.AuthInfos, "user")
+
+	assert.False(t, removeKubeconfigEntries(cfg, "https://okteto.unknown.com", ""))
+}
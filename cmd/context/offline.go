@@ -0,0 +1,75 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package context
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/okteto/okteto/pkg/config"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/kubeconfig"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+)
+
+// apiPreflightTimeout is the maximum time to wait for the okteto API when initializing a context with a token
+const apiPreflightTimeout = 30 * time.Second
+
+// isAPIUnavailableErr returns if the error means the okteto API is not responding
+func isAPIUnavailableErr(err error) bool {
+	return errors.Is(err, oktetoErrors.ErrInternalServerError) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// initOfflineOktetoContext initializes the okteto context with its last known configuration when the okteto API is not responding.
+// It's only allowed for the commands that don't need the okteto API
+func (*ContextCommand) initOfflineOktetoContext(ctxOptions *ContextOptions, apiErr error) error {
+	if errors.Is(apiErr, oktetoErrors.ErrAPIUnavailable) {
+		apiErr = oktetoErrors.UserError{
+			E:    apiErr,
+			Hint: "Okteto stopped calling the API after consecutive failures. It will try again in a few minutes",
+		}
+	}
+
+	okCtx, ok := okteto.ContextStore().Contexts[ctxOptions.Context]
+	if !ctxOptions.AllowOffline || !ok || okCtx.UserID == "" {
+		return apiErr
+	}
+	cfg := kubeconfig.Get(config.GetKubeconfigPath())
+	if cfg == nil {
+		return apiErr
+	}
+	kubeCtxName := okteto.UrlToKubernetesContext(ctxOptions.Context)
+	kubeCtx, ok := cfg.Contexts[kubeCtxName]
+	if !ok {
+		return apiErr
+	}
+
+	oktetoLog.Warning("The okteto API of '%s' is not responding, using the last known configuration of the context", okteto.RemoveSchema(ctxOptions.Context))
+	if ctxOptions.Namespace != "" {
+		okCtx.Namespace = ctxOptions.Namespace
+	}
+	kubeCtx.Namespace = okCtx.Namespace
+	cfg.CurrentContext = kubeCtxName
+
+	okCtx.Cfg = cfg
+	okCtx.IsOkteto = true
+	okCtx.IsInsecure = okteto.IsInsecureSkipTLSVerifyPolicy()
+	okCtx.IsOffline = true
+
+	// saving the context checks the namespace access against the okteto API
+	ctxOptions.Save = false
+	return nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package context
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/okteto/okteto/internal/test"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/okteto"
+	client "github.com/okteto/okteto/pkg/okteto/fake"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCircuitBreaker struct {
+	failures  int
+	successes int
+	open      bool
+}
+
+func (f *fakeCircuitBreaker) Allow(_ string) bool { return !f.open }
+
+func (f *fakeCircuitBreaker) RecordSuccess(_ string) { f.successes++ }
+
+func (f *fakeCircuitBreaker) RecordFailure(_ string) { f.failures++ }
+
+func Test_initOktetoContextWithOpenCircuit(t *testing.T) {
+	file, err := test.CreateKubeconfig(test.KubeconfigFields{
+		Name:           []string{"okteto_example_com"},
+		Namespace:      []string{"test"},
+		CurrentContext: "okteto_example_com",
+	})
+	require.NoError(t, err)
+	defer os.Remove(file)
+
+	tests := []struct {
+		expectedErr error
+		name        string
+		userID      string
+		allow       bool
+	}{
+		{
+			name:        "offline not allowed",
+			userID:      "user-id",
+			expectedErr: oktetoErrors.ErrAPIUnavailable,
+		},
+		{
+			name:        "context never initialized",
+			allow:       true,
+			expectedErr: oktetoErrors.ErrAPIUnavailable,
+		},
+		{
+			name:   "offline allowed",
+			userID: "user-id",
+			allow:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			okteto.CurrentStore = &okteto.OktetoContextStore{
+				Contexts: map[string]*okteto.OktetoContext{
+					"https://okteto.example.com": {
+						Name:      "https://okteto.example.com",
+						UserID:    tt.userID,
+						Namespace: "test",
+					},
+				},
+				CurrentContext: "https://okteto.example.com",
+			}
+			breaker := &fakeCircuitBreaker{open: true}
+			ctxController := newFakeContextCommand(&client.FakeOktetoClient{}, &types.User{}, nil)
+			ctxController.APICircuitBreaker = breaker
+			ctxOptions := &ContextOptions{
+				Context:      "https://okteto.example.com",
+				Namespace:    "test",
+				IsOkteto:     true,
+				Save:         true,
+				AllowOffline: tt.allow,
+			}
+
+			err := ctxController.initOktetoContext(context.Background(), ctxOptions)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, okteto.Context().IsOffline)
+			assert.True(t, okteto.Context().IsOkteto)
+			assert.Equal(t, "okteto_example_com", okteto.Context().Cfg.CurrentContext)
+			assert.False(t, ctxOptions.Save)
+		})
+	}
+}
+
+func Test_initOktetoContextRecordsAPIFailures(t *testing.T) {
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		Contexts: map[string]*okteto.OktetoContext{
+			"https://okteto.example.com": {Name: "https://okteto.example.com"},
+		},
+		CurrentContext: "https://okteto.example.com",
+	}
+	user := &types.User{Token: "token"}
+	errRefused := errors.New("connection refused")
+	fakeOktetoClient := &client.FakeOktetoClient{
+		Users: client.NewFakeUsersClient(user, errRefused, errRefused, errRefused, errRefused, errRefused),
+	}
+	breaker := &fakeCircuitBreaker{}
+	ctxController := newFakeContextCommand(fakeOktetoClient, user, nil)
+	ctxController.APICircuitBreaker = breaker
+
+	err := ctxController.initOktetoContext(context.Background(), &ContextOptions{
+		Context:  "https://okteto.example.com",
+		Token:    "token",
+		IsOkteto: true,
+	})
+	assert.ErrorIs(t, err, oktetoErrors.ErrInternalServerError)
+	assert.Equal(t, 1, breaker.failures)
+	assert.Equal(t, 0, breaker.successes)
+}
+
+func Test_isAPIUnavailableErr(t *testing.T) {
+	assert.True(t, isAPIUnavailableErr(oktetoErrors.ErrInternalServerError))
+	assert.True(t, isAPIUnavailableErr(context.DeadlineExceeded))
+	assert.False(t, isAPIUnavailableErr(oktetoErrors.ErrTokenExpired))
+}
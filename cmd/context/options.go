@@ -14,19 +14,22 @@
 package context
 
 import (
-	"os"
 	"strings"
 
+	"github.com/okteto/okteto/pkg/config"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
 )
 
 type ContextOptions struct {
+	Labels                []string
+	Annotations           []string
 	Token                 string
 	Context               string
 	Namespace             string
 	Builder               string
+	DevCatalog            string
 	OnlyOkteto            bool
 	Show                  bool
 	Save                  bool
@@ -37,6 +40,10 @@ type ContextOptions struct {
 	raiseNotCtxError      bool
 	InsecureSkipTlsVerify bool
 	InferredToken         bool
+	// DeviceCode authenticates with a code entered in a browser of another device instead of opening a local browser
+	DeviceCode bool
+	// AllowOffline uses the last known configuration of the context when the okteto API is not responding
+	AllowOffline bool
 }
 
 func (o *ContextOptions) InitFromContext() {
@@ -63,18 +70,18 @@ func (o *ContextOptions) InitFromContext() {
 func (o *ContextOptions) InitFromEnvVars() {
 	usedEnvVars := []string{}
 
-	if o.Context == "" && os.Getenv(model.OktetoURLEnvVar) != "" {
-		o.Context = os.Getenv(model.OktetoURLEnvVar)
+	if o.Context == "" && config.EnvOktetoURL.Value() != "" {
+		o.Context = config.EnvOktetoURL.Value()
 		o.IsOkteto = true
 		usedEnvVars = append(usedEnvVars, model.OktetoURLEnvVar)
 	}
 
-	if o.Context == "" && os.Getenv(model.OktetoContextEnvVar) != "" {
-		o.Context = os.Getenv(model.OktetoContextEnvVar)
+	if o.Context == "" && config.EnvOktetoContext.Value() != "" {
+		o.Context = config.EnvOktetoContext.Value()
 		usedEnvVars = append(usedEnvVars, model.OktetoContextEnvVar)
 	}
 
-	envToken := os.Getenv(model.OktetoTokenEnvVar)
+	envToken := config.EnvOktetoToken.Value()
 	if o.Token != "" || envToken != "" {
 		o.IsOkteto = true
 		if o.Context == "" {
@@ -90,8 +97,12 @@ func (o *ContextOptions) InitFromEnvVars() {
 		o.InferredToken = true
 	}
 
-	if o.Namespace == "" && os.Getenv(model.OktetoNamespaceEnvVar) != "" {
-		o.Namespace = os.Getenv(model.OktetoNamespaceEnvVar)
+	if o.Context == "" && !o.IsCtxCommand {
+		o.Context = config.GetCLIConfig().DefaultContext
+	}
+
+	if o.Namespace == "" && config.EnvOktetoNamespace.Value() != "" {
+		o.Namespace = config.EnvOktetoNamespace.Value()
 		usedEnvVars = append(usedEnvVars, model.OktetoNamespaceEnvVar)
 	}
 
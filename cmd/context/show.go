@@ -15,25 +15,32 @@ package context
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
+	"os"
 
 	"github.com/okteto/okteto/cmd/utils"
-	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/output"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v2"
 )
 
 // Show current context
 func Show() *cobra.Command {
-	var output string
+	var outputFormat string
 	var includeToken bool
 	cmd := &cobra.Command{
 		Use:   "show",
 		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#show"),
 		Short: "Print the current context",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			// the context is always printed in a structured output format
+			if outputFormat == "" {
+				outputFormat = output.JSON
+			}
+			formatter, err := output.NewFormatter(outputFormat, output.WithJSONIndent("  "))
+			if err != nil {
+				return err
+			}
+
 			ctx := context.Background()
 
 			if err := NewContextCommand().Run(ctx, &ContextOptions{raiseNotCtxError: true}); err != nil {
@@ -41,40 +48,16 @@ func Show() *cobra.Command {
 			}
 			ctxStore := okteto.ContextStore()
 			current := ctxStore.Contexts[ctxStore.CurrentContext]
-			if err := validateOutput(output); err != nil {
-				return err
-			}
 
 			if !includeToken {
 				current.Token = ""
 			}
 
 			current.Certificate = ""
-			switch output {
-			case "json":
-				bytes, err := json.MarshalIndent(current, "", "  ")
-				if err != nil {
-					return err
-				}
-				oktetoLog.Println(string(bytes))
-			case "yaml":
-				bytes, err := yaml.Marshal(current)
-				if err != nil {
-					return err
-				}
-				oktetoLog.Println(string(bytes))
-			}
-			return nil
+			return formatter.Format(os.Stdout, current)
 		},
 	}
-	cmd.Flags().StringVarP(&output, "output", "o", "json", "output format. One of: ['json', 'yaml']")
+	output.AddFlagWithDefault(cmd, &outputFormat, output.JSON)
 	cmd.Flags().BoolVar(&includeToken, "include-token", false, "include the token in the output")
 	return cmd
 }
-
-func validateOutput(output string) error {
-	if output != "json" && output != "yaml" {
-		return fmt.Errorf("output format is not accepted. Value must be one of: ['json', 'yaml']")
-	}
-	return nil
-}
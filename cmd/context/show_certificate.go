@@ -0,0 +1,200 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package context
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// certificateExpirationWarning is how long before its expiration a certificate is reported as expiring soon
+	certificateExpirationWarning = 30 * 24 * time.Hour
+)
+
+// certificateInfo represents the details of a certificate stored in a context
+type certificateInfo struct {
+	NotBefore    time.Time `json:"notBefore" yaml:"notBefore"`
+	NotAfter     time.Time `json:"notAfter" yaml:"notAfter"`
+	Subject      string    `json:"subject" yaml:"subject"`
+	Issuer       string    `json:"issuer" yaml:"issuer"`
+	SerialNumber string    `json:"serialNumber" yaml:"serialNumber"`
+	DNSNames     []string  `json:"dnsNames,omitempty" yaml:"dnsNames,omitempty"`
+	IPAddresses  []string  `json:"ipAddresses,omitempty" yaml:"ipAddresses,omitempty"`
+	SelfSigned   bool      `json:"selfSigned" yaml:"selfSigned"`
+	Trusted      bool      `json:"trusted" yaml:"trusted"`
+}
+
+// OutputName returns the subject of the certificate for the 'name' output format
+func (c certificateInfo) OutputName() string {
+	return c.Subject
+}
+
+// ShowCertificate prints the certificate stored for a context
+func ShowCertificate() *cobra.Command {
+	var contextName string
+	var outputFormat string
+	cmd := &cobra.Command{
+		Use:   "show-certificate",
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#show-certificate"),
+		Short: "Print the details of the cluster certificate stored for a context",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.Validate(outputFormat); err != nil {
+				return err
+			}
+			okCtx, err := getContextForCertificate(contextName)
+			if err != nil {
+				return err
+			}
+			if okCtx.Certificate == "" {
+				return fmt.Errorf("context '%s' doesn't store a certificate. Its server certificate is verified with the certificates of your system", okCtx.Name)
+			}
+			certs, err := parseContextCertificates(okCtx.Certificate)
+			if err != nil {
+				return fmt.Errorf("the certificate stored for context '%s' is not valid: %w", okCtx.Name, err)
+			}
+			return printCertificates(os.Stdout, certs, outputFormat, time.Now())
+		},
+	}
+	cmd.Flags().StringVarP(&contextName, "context", "c", "", "context to inspect. Default is the current context")
+	output.AddFlag(cmd, &outputFormat)
+	return cmd
+}
+
+func getContextForCertificate(name string) (*okteto.OktetoContext, error) {
+	ctxStore := okteto.ContextStore()
+	if name == "" {
+		name = ctxStore.CurrentContext
+	}
+	if name == "" {
+		return nil, fmt.Errorf("there isn't a current context. Run 'okteto context' to configure your first okteto context")
+	}
+	if okCtx, ok := ctxStore.Contexts[name]; ok {
+		return okCtx, nil
+	}
+	if okCtx, ok := ctxStore.Contexts[okteto.AddSchema(name)]; ok {
+		return okCtx, nil
+	}
+	return nil, fmt.Errorf("context '%s' doesn't exist", name)
+}
+
+// parseContextCertificates returns the certificates of the base64 encoded PEM stored in a context
+func parseContextCertificates(certB64 string) ([]*x509.Certificate, error) {
+	certPEM, err := base64.StdEncoding.DecodeString(certB64)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't decode base64: %w", err)
+	}
+
+	certs := []*x509.Certificate{}
+	for {
+		var block *pem.Block
+		block, certPEM = pem.Decode(certPEM)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("couldn't decode pem")
+	}
+	return certs, nil
+}
+
+func getCertificateInfo(cert *x509.Certificate) certificateInfo {
+	info := certificateInfo{
+		Subject:      cert.Subject.String(),
+		Issuer:       cert.Issuer.String(),
+		SerialNumber: cert.SerialNumber.String(),
+		NotBefore:    cert.NotBefore,
+		NotAfter:     cert.NotAfter,
+		DNSNames:     cert.DNSNames,
+		SelfSigned:   cert.Subject.String() == cert.Issuer.String(),
+	}
+	for _, ip := range cert.IPAddresses {
+		info.IPAddresses = append(info.IPAddresses, ip.String())
+	}
+	if _, err := cert.Verify(x509.VerifyOptions{}); err == nil { // skipcq: GO-S1031
+		info.Trusted = true
+	}
+	return info
+}
+
+func printCertificates(w io.Writer, certs []*x509.Certificate, outputFormat string, now time.Time) error {
+	infos := []certificateInfo{}
+	for _, cert := range certs {
+		infos = append(infos, getCertificateInfo(cert))
+	}
+
+	err := output.Print(w, outputFormat, infos, func(w io.Writer) error {
+		for i, info := range infos {
+			if i > 0 {
+				fmt.Fprintln(w)
+			}
+			tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+			fmt.Fprintf(tw, "Subject:\t%s\n", info.Subject)
+			fmt.Fprintf(tw, "Issuer:\t%s\n", info.Issuer)
+			fmt.Fprintf(tw, "Serial number:\t%s\n", info.SerialNumber)
+			fmt.Fprintf(tw, "DNS names:\t%s\n", formatCertificateList(info.DNSNames))
+			fmt.Fprintf(tw, "IP addresses:\t%s\n", formatCertificateList(info.IPAddresses))
+			fmt.Fprintf(tw, "Valid from:\t%s\n", info.NotBefore.UTC().Format(time.RFC3339))
+			fmt.Fprintf(tw, "Valid until:\t%s\n", info.NotAfter.UTC().Format(time.RFC3339))
+			fmt.Fprintf(tw, "Self-signed:\t%t\n", info.SelfSigned)
+			fmt.Fprintf(tw, "Trusted by your system:\t%t\n", info.Trusted)
+			if err := tw.Flush(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, info := range infos {
+		switch {
+		case now.After(info.NotAfter):
+			oktetoLog.Warning("The certificate '%s' expired on %s", info.Subject, info.NotAfter.UTC().Format(time.RFC3339))
+		case info.NotAfter.Sub(now) < certificateExpirationWarning:
+			oktetoLog.Warning("The certificate '%s' expires in %d days", info.Subject, int(info.NotAfter.Sub(now).Hours()/24))
+		}
+	}
+	return nil
+}
+
+func formatCertificateList(values []string) string {
+	if len(values) == 0 {
+		return "-"
+	}
+	return strings.Join(values, ", ")
+}
@@ -0,0 +1,106 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package context
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCertificate(t *testing.T, notAfter time.Time) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(42),
+		Subject:      pkix.Name{CommonName: "okteto-wildcard-ca"},
+		DNSNames:     []string{"*.okteto.example.com"},
+		IPAddresses:  []net.IP{net.ParseIP("10.0.0.1")},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func Test_parseContextCertificates(t *testing.T) {
+	certs, err := parseContextCertificates(newTestCertificate(t, time.Now().Add(time.Hour)))
+	require.NoError(t, err)
+	require.Len(t, certs, 1)
+
+	_, err = parseContextCertificates("not base64")
+	assert.Error(t, err)
+
+	_, err = parseContextCertificates(base64.StdEncoding.EncodeToString([]byte("not pem")))
+	assert.Error(t, err)
+}
+
+func Test_printCertificates(t *testing.T) {
+	notAfter := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	certs, err := parseContextCertificates(newTestCertificate(t, notAfter))
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+	require.NoError(t, printCertificates(&b, certs, "", notAfter.Add(-10*24*time.Hour)))
+	assert.Contains(t, b.String(), "Subject:                 CN=okteto-wildcard-ca")
+	assert.Contains(t, b.String(), "DNS names:               *.okteto.example.com")
+	assert.Contains(t, b.String(), "IP addresses:            10.0.0.1")
+	assert.Contains(t, b.String(), "Valid until:             2030-01-01T00:00:00Z")
+	assert.Contains(t, b.String(), "Self-signed:             true")
+
+	b.Reset()
+	require.NoError(t, printCertificates(&b, certs, "json", notAfter))
+	infos := []certificateInfo{}
+	require.NoError(t, json.Unmarshal(b.Bytes(), &infos))
+	require.Len(t, infos, 1)
+	assert.Equal(t, "42", infos[0].SerialNumber)
+	assert.False(t, infos[0].Trusted)
+}
+
+func Test_getContextForCertificate(t *testing.T) {
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		Contexts: map[string]*okteto.OktetoContext{
+			"https://okteto.example.com": {Name: "https://okteto.example.com"},
+		},
+		CurrentContext: "https://okteto.example.com",
+	}
+	defer func() { okteto.CurrentStore = nil }()
+
+	okCtx, err := getContextForCertificate("")
+	require.NoError(t, err)
+	assert.Equal(t, "https://okteto.example.com", okCtx.Name)
+
+	okCtx, err = getContextForCertificate("okteto.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "https://okteto.example.com", okCtx.Name)
+
+	_, err = getContextForCertificate("minikube")
+	assert.Error(t, err)
+}
@@ -17,15 +17,18 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/config"
-	"github.com/okteto/okteto/pkg/env"
 	"github.com/okteto/okteto/pkg/k8s/kubeconfig"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
 // kubeconfigController has all the functions that the context command needs to update the kubeconfig stored in the okteto context
@@ -43,7 +46,7 @@ type KubeconfigCMD struct {
 // newKubeconfigController creates a new command to update the kubeconfig stored in the okteto context
 func newKubeconfigController(okClientProvider oktetoClientProvider) *KubeconfigCMD {
 	var kubetokenController kubeconfigController
-	if env.LoadBoolean(OktetoUseStaticKubetokenEnvVar) {
+	if config.EnvOktetoUseStaticKubetoken.IsTrue() {
 		kubetokenController = newStaticKubetokenController()
 	} else {
 		kubetokenController = newDynamicKubetokenController(okClientProvider)
@@ -62,26 +65,38 @@ func UpdateKubeconfigCMD(okClientProvider oktetoClientProvider) *cobra.Command {
 		Args:   utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#kubeconfig"),
 		Short:  "Download credentials for the Kubernetes cluster selected via 'okteto context'",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			ctx := context.Background()
-
-			// Run context command to get the Cfg into Okteto Context
-			if err := NewContextCommand(withKubeTokenController(kc.kubetokenController)).Run(ctx, &ContextOptions{}); err != nil {
-				return err
-			}
-
-			return kc.execute(okteto.Context(), config.GetKubeconfigPath())
+			return kc.Run(context.Background(), false, os.Stdout)
 		},
 	}
 
 	return cmd
 }
 
-func (k *KubeconfigCMD) execute(okCtx *okteto.OktetoContext, kubeconfigPaths []string) error {
+// ExecuteKubeconfig merges the credentials of the current context into the kubeconfig file, or prints them if printOnly is set
+func ExecuteKubeconfig(ctx context.Context, okClientProvider oktetoClientProvider, printOnly bool, w io.Writer) error {
+	return newKubeconfigController(okClientProvider).Run(ctx, printOnly, w)
+}
+
+// Run initializes the current context and merges its credentials into the kubeconfig file, or prints them if printOnly is set
+func (k *KubeconfigCMD) Run(ctx context.Context, printOnly bool, w io.Writer) error {
+	// Run context command to get the Cfg into Okteto Context
+	if err := NewContextCommand(withKubeTokenController(k.kubetokenController)).Run(ctx, &ContextOptions{}); err != nil {
+		return err
+	}
+
+	if printOnly {
+		return k.print(okteto.Context(), w)
+	}
+	return k.execute(okteto.Context(), config.GetKubeconfigPath())
+}
+
+// prepareCfg configures the okteto credentials of the context and returns the name of its kubernetes context
+func (k *KubeconfigCMD) prepareCfg(okCtx *okteto.OktetoContext) (string, error) {
 	contextName := okCtx.Name
 	if okCtx.IsOkteto {
 		contextName = okteto.UrlToKubernetesContext(contextName)
 		if err := updateCfgClusterCertificate(contextName, okCtx); err != nil {
-			return err
+			return "", err
 		}
 
 		err := k.kubetokenController.updateOktetoContextExec(okCtx)
@@ -89,6 +104,14 @@ func (k *KubeconfigCMD) execute(okCtx *okteto.OktetoContext, kubeconfigPaths []s
 			oktetoLog.Infof("failed to update okteto kubeconfig: %s", err)
 		}
 	}
+	return contextName, nil
+}
+
+func (k *KubeconfigCMD) execute(okCtx *okteto.OktetoContext, kubeconfigPaths []string) error {
+	contextName, err := k.prepareCfg(okCtx)
+	if err != nil {
+		return err
+	}
 
 	if err := kubeconfig.Write(okCtx.Cfg, kubeconfigPaths[0]); err != nil {
 		return err
@@ -98,6 +121,47 @@ func (k *KubeconfigCMD) execute(okCtx *okteto.OktetoContext, kubeconfigPaths []s
 	return nil
 }
 
+// print writes a kubeconfig with only the cluster, user and context of the current context
+func (k *KubeconfigCMD) print(okCtx *okteto.OktetoContext, w io.Writer) error {
+	contextName, err := k.prepareCfg(okCtx)
+	if err != nil {
+		return err
+	}
+
+	fragment, err := getKubeconfigFragment(okCtx.Cfg, contextName)
+	if err != nil {
+		return err
+	}
+	b, err := clientcmd.Write(*fragment)
+	if err != nil {
+		return fmt.Errorf("failed to generate the kubeconfig: %w", err)
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// getKubeconfigFragment returns a kubeconfig with the given context and the cluster and user it references
+func getKubeconfigFragment(cfg *clientcmdapi.Config, contextName string) (*clientcmdapi.Config, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("kubernetes context '%s' not found", contextName)
+	}
+	kubeCtx, ok := cfg.Contexts[contextName]
+	if !ok {
+		return nil, fmt.Errorf("kubernetes context '%s' not found", contextName)
+	}
+
+	fragment := clientcmdapi.NewConfig()
+	fragment.Contexts[contextName] = kubeCtx
+	if cluster, ok := cfg.Clusters[kubeCtx.Cluster]; ok {
+		fragment.Clusters[kubeCtx.Cluster] = cluster
+	}
+	if authInfo, ok := cfg.AuthInfos[kubeCtx.AuthInfo]; ok {
+		fragment.AuthInfos[kubeCtx.AuthInfo] = authInfo
+	}
+	fragment.CurrentContext = contextName
+	return fragment, nil
+}
+
 func updateCfgClusterCertificate(contextName string, okContext *okteto.OktetoContext) error {
 	if !okContext.IsStoredAsInsecure {
 		return nil
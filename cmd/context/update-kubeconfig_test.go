@@ -14,16 +14,18 @@
 package context
 
 import (
+	"bytes"
 	"encoding/base64"
 	"os"
 	"testing"
 
 	"github.com/okteto/okteto/internal/test"
-	"github.com/okteto/okteto/internal/test/client"
 	"github.com/okteto/okteto/pkg/k8s/kubeconfig"
 	"github.com/okteto/okteto/pkg/okteto"
+	client "github.com/okteto/okteto/pkg/okteto/fake"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/clientcmd/api"
 )
 
@@ -578,3 +580,50 @@ func Test_ExecuteUpdateKubeconfig_WithWrongOktetoCertificate(t *testing.T) {
 	err = newKubeconfigController(okClientProvider).execute(okContext, kubeconfigPaths)
 	assert.Error(t, err, "should fail as the okteto certificate is not a valid base64 value")
 }
+
+func Test_PrintKubeconfig(t *testing.T) {
+	t.Setenv(OktetoUseStaticKubetokenEnvVar, "true")
+	okCtx := &okteto.OktetoContext{
+		Name:      "https://okteto.example.com",
+		UserID:    "user-id",
+		Namespace: "ns-test",
+		IsOkteto:  true,
+		Cfg: &api.Config{
+			CurrentContext: "okteto_example_com",
+			Contexts: map[string]*api.Context{
+				"okteto_example_com": {Cluster: "okteto_example_com", AuthInfo: "user-id", Namespace: "ns-test"},
+				"other":              {Cluster: "other", AuthInfo: "other-user"},
+			},
+			Clusters: map[string]*api.Cluster{
+				"okteto_example_com": {Server: "https://kubernetes.okteto.example.com"},
+				"other":              {Server: "https://other.example.com"},
+			},
+			AuthInfos: map[string]*api.AuthInfo{
+				"user-id":    {Token: "static-token"},
+				"other-user": {Token: "other-token"},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	err := newKubeconfigController(nil).print(okCtx, &buf)
+	require.NoError(t, err)
+
+	cfg, err := clientcmd.Load(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, "okteto_example_com", cfg.CurrentContext)
+	assert.Len(t, cfg.Contexts, 1)
+	assert.Equal(t, "ns-test", cfg.Contexts["okteto_example_com"].Namespace)
+	assert.Len(t, cfg.Clusters, 1)
+	assert.Equal(t, "https://kubernetes.okteto.example.com", cfg.Clusters["okteto_example_com"].Server)
+	assert.Len(t, cfg.AuthInfos, 1)
+	assert.Equal(t, "static-token", cfg.AuthInfos["user-id"].Token)
+}
+
+func Test_getKubeconfigFragment(t *testing.T) {
+	_, err := getKubeconfigFragment(nil, "okteto_example_com")
+	assert.Error(t, err)
+
+	_, err = getKubeconfigFragment(api.NewConfig(), "okteto_example_com")
+	assert.Error(t, err)
+}
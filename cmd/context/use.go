@@ -23,6 +23,7 @@ import (
 	"github.com/okteto/okteto/pkg/analytics"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/k8s/kubeconfig"
+	"github.com/okteto/okteto/pkg/k8s/labels"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
@@ -39,6 +40,7 @@ const (
 // Use context points okteto to a cluster.
 func Use() *cobra.Command {
 	ctxOptions := &ContextOptions{}
+	var yes bool
 	cmd := &cobra.Command{
 		Use:   "use [<url>|Kubernetes context]",
 		Args:  utils.MaximumNArgsAccepted(1, "https://okteto.com/docs/reference/cli/#use"),
@@ -58,14 +60,34 @@ You can also specify an Okteto URL:
 
     $ okteto context use https://cloud.okteto.com
 
+If a browser can't be opened in your machine, for example in a remote SSH session, authenticate with a code from any other device:
+
+    $ okteto context use https://cloud.okteto.com --device-code
+
 Or a Kubernetes context:
 
     $ okteto context use kubernetes_context_name
+
+If the name doesn't match any of your contexts, you will be prompted to select one of the similar ones.
+Use --yes to pick the most similar context without prompting.
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
 			if len(args) == 1 {
 				ctxOptions.Context = strings.TrimSuffix(args[0], "/")
+				selector := utils.NewOktetoSelector(fmt.Sprintf("Context '%s' not found. Select the context you want to use:", ctxOptions.Context), "Context")
+				resolved, err := resolveContextName(ctxOptions.Context, getKnownContexts(), yes, oktetoLog.IsInteractive(), selector)
+				if err != nil {
+					return err
+				}
+				ctxOptions.Context = resolved
+			}
+
+			if ctxOptions.DeviceCode && ctxOptions.Token != "" {
+				return oktetoErrors.UserError{
+					E:    fmt.Errorf("flags '--device-code' and '--token' can't be used together"),
+					Hint: "The flag '--token' authenticates without any login process",
+				}
 			}
 
 			ctxOptions.IsCtxCommand = true
@@ -86,6 +108,11 @@ Or a Kubernetes context:
 	cmd.Flags().StringVarP(&ctxOptions.Token, "token", "t", "", "API token for authentication")
 	cmd.Flags().StringVarP(&ctxOptions.Namespace, "namespace", "n", "", "namespace of your okteto context")
 	cmd.Flags().StringVarP(&ctxOptions.Builder, "builder", "b", "", "url of the builder service")
+	cmd.Flags().StringArrayVarP(&ctxOptions.Labels, "label", "", []string{}, "label added to every object created by okteto in this context (KEY=VALUE)")
+	cmd.Flags().StringArrayVarP(&ctxOptions.Annotations, "annotation", "", []string{}, "annotation added to every object created by okteto in this context (KEY=VALUE)")
+	cmd.Flags().StringVarP(&ctxOptions.DevCatalog, "dev-catalog", "", "", "git repository or url of the catalog of dev templates used by 'okteto init'")
+	cmd.Flags().BoolVarP(&ctxOptions.DeviceCode, "device-code", "", false, "authenticate with a code entered in a browser of another device, for sessions where a browser can't be opened")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "use the most similar context if the name doesn't match any context")
 	cmd.Flags().BoolVarP(&ctxOptions.OnlyOkteto, "okteto", "", false, "only shows okteto context options")
 	if err := cmd.Flags().MarkHidden("okteto"); err != nil {
 		oktetoLog.Infof("failed to mark 'okteto' flag as hidden: %s", err)
@@ -139,6 +166,7 @@ func (c *ContextCommand) Run(ctx context.Context, ctxOptions *ContextOptions) er
 	if err := c.UseContext(ctx, ctxOptions); err != nil {
 		return err
 	}
+	labels.AddPolicy(okteto.Context().Labels, okteto.Context().Annotations)
 
 	os.Setenv(model.OktetoNamespaceEnvVar, okteto.Context().Namespace)
 
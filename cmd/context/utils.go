@@ -29,6 +29,7 @@ import (
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
+	"k8s.io/apimachinery/pkg/util/validation"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
@@ -42,6 +43,8 @@ type ManifestOptions struct {
 	Namespace  string
 	Filename   string
 	K8sContext string
+	// AllowOffline loads the context even if the okteto API is not responding
+	AllowOffline bool
 }
 
 func getKubernetesContextList(filterOkteto bool) []string {
@@ -154,9 +157,10 @@ func LoadManifestWithContext(ctx context.Context, opts ManifestOptions) (*model.
 	}
 
 	ctxOptions := &ContextOptions{
-		Context:   ctxResource.Context,
-		Namespace: ctxResource.Namespace,
-		Show:      true,
+		Context:      ctxResource.Context,
+		Namespace:    ctxResource.Namespace,
+		Show:         true,
+		AllowOffline: opts.AllowOffline,
 	}
 
 	if err := NewContextCommand().Run(ctx, ctxOptions); err != nil {
@@ -176,6 +180,7 @@ func LoadManifestWithContext(ctx context.Context, opts ManifestOptions) (*model.
 
 	manifest.Namespace = okteto.Context().Namespace
 	manifest.Context = okteto.Context().Name
+	utils.AddManifestLabelsPolicy(manifest)
 
 	for _, dev := range manifest.Dev {
 		if err := utils.LoadManifestRc(dev); err != nil {
@@ -246,3 +251,57 @@ func LoadContextFromPath(ctx context.Context, namespace, k8sContext, path string
 
 	return NewContextCommand().Run(ctx, ctxOptions)
 }
+
+// setContextLabelsPolicy stores the labels and annotations given by flags in the okteto context
+func setContextLabelsPolicy(okCtx *okteto.OktetoContext, ctxOptions *ContextOptions) error {
+	if okCtx == nil {
+		return nil
+	}
+	contextLabels, err := parsePolicyValues(ctxOptions.Labels, "label", true)
+	if err != nil {
+		return err
+	}
+	contextAnnotations, err := parsePolicyValues(ctxOptions.Annotations, "annotation", false)
+	if err != nil {
+		return err
+	}
+	if len(contextLabels) > 0 {
+		if okCtx.Labels == nil {
+			okCtx.Labels = map[string]string{}
+		}
+		for k, v := range contextLabels {
+			okCtx.Labels[k] = v
+		}
+	}
+	if len(contextAnnotations) > 0 {
+		if okCtx.Annotations == nil {
+			okCtx.Annotations = map[string]string{}
+		}
+		for k, v := range contextAnnotations {
+			okCtx.Annotations[k] = v
+		}
+	}
+	return nil
+}
+
+// parsePolicyValues parses a list of KEY=VALUE values into a map, validating them as kubernetes metadata
+func parsePolicyValues(values []string, kind string, isLabel bool) (map[string]string, error) {
+	result := map[string]string{}
+	for _, v := range values {
+		kvFormatParts := 2
+		kv := strings.SplitN(v, "=", kvFormatParts)
+		if len(kv) != kvFormatParts {
+			return nil, fmt.Errorf("invalid %s '%s': must follow KEY=VALUE format", kind, v)
+		}
+		if errs := validation.IsQualifiedName(kv[0]); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid %s key '%s': %s", kind, kv[0], errs[0])
+		}
+		if isLabel {
+			if errs := validation.IsValidLabelValue(kv[1]); len(errs) > 0 {
+				return nil, fmt.Errorf("invalid %s value '%s': %s", kind, kv[1], errs[0])
+			}
+		}
+		result[kv[0]] = kv[1]
+	}
+	return result, nil
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
@@ -275,3 +276,66 @@ context: manifest-context
 		})
 	}
 }
+
+func Test_setContextLabelsPolicy(t *testing.T) {
+	tests := []struct {
+		okCtx               *okteto.OktetoContext
+		expectedLabels      map[string]string
+		expectedAnnotations map[string]string
+		name                string
+		labels              []string
+		annotations         []string
+		expectErr           bool
+	}{
+		{
+			name:  "no flags keeps the stored policy",
+			okCtx: &okteto.OktetoContext{Labels: map[string]string{"team": "a"}},
+			expectedLabels: map[string]string{
+				"team": "a",
+			},
+		},
+		{
+			name:        "flags are merged with the stored policy",
+			okCtx:       &okteto.OktetoContext{Labels: map[string]string{"team": "a"}},
+			labels:      []string{"team=b", "cost-center=dev"},
+			annotations: []string{"okteto.com/owner=user@okteto.com"},
+			expectedLabels: map[string]string{
+				"team":        "b",
+				"cost-center": "dev",
+			},
+			expectedAnnotations: map[string]string{
+				"okteto.com/owner": "user@okteto.com",
+			},
+		},
+		{
+			name:      "label without value separator",
+			okCtx:     &okteto.OktetoContext{},
+			labels:    []string{"team"},
+			expectErr: true,
+		},
+		{
+			name:      "invalid label value",
+			okCtx:     &okteto.OktetoContext{},
+			labels:    []string{"team=not valid"},
+			expectErr: true,
+		},
+		{
+			name:        "invalid annotation key",
+			okCtx:       &okteto.OktetoContext{},
+			annotations: []string{"not valid=value"},
+			expectErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := setContextLabelsPolicy(tt.okCtx, &ContextOptions{Labels: tt.labels, Annotations: tt.annotations})
+			if tt.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedLabels, tt.okCtx.Labels)
+			assert.Equal(t, tt.expectedAnnotations, tt.okCtx.Annotations)
+		})
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debug
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/moby/term"
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/apps"
+	"github.com/okteto/okteto/pkg/k8s/exec"
+	k8sforward "github.com/okteto/okteto/pkg/k8s/forward"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// attachFlags is the input of the user to the attach command
+type attachFlags struct {
+	manifestPath string
+	namespace    string
+	k8sContext   string
+	language     string
+	port         int
+	localPort    int
+	configOnly   bool
+}
+
+// Attach starts a debug server in a development container and prints the IDE configuration to attach to it
+func Attach(ctx context.Context) *cobra.Command {
+	flags := &attachFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "attach [devContainer] [-- <program>]",
+		Short: "Start a debug server in your development container and attach your IDE to it",
+		Long: `Start a debug server in your development container and attach your IDE to it.
+
+The debug server depends on the language of your development container: delve for go, debugpy for python and 'node --inspect' for node.
+It is installed in the development container if it is not available.
+The debug port is forwarded to your local machine and the command prints the VS Code launch configuration to attach to it.`,
+		Example: `  okteto debug attach api
+  okteto debug attach api --lang python -- main.py
+  okteto debug attach api --config-only`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			devArgs, program := splitArgs(args, cmd.ArgsLenAtDash())
+			if len(devArgs) > 1 {
+				return fmt.Errorf("only one development container can be debugged at a time")
+			}
+
+			manifestOpts := contextCMD.ManifestOptions{Filename: flags.manifestPath, Namespace: flags.namespace, K8sContext: flags.k8sContext}
+			manifest, err := contextCMD.LoadManifestWithContext(ctx, manifestOpts)
+			if err != nil {
+				return err
+			}
+
+			devName := ""
+			if len(devArgs) == 1 {
+				devName = devArgs[0]
+			}
+			dev, err := utils.GetDevFromManifest(manifest, devName)
+			if err != nil {
+				if !errors.Is(err, utils.ErrNoDevSelected) {
+					return err
+				}
+				selector := utils.NewOktetoSelector("Select the development container to debug:", "Development container")
+				dev, err = utils.SelectDevFromManifest(manifest, selector, manifest.Dev.GetDevs())
+				if err != nil {
+					return err
+				}
+			}
+
+			return runAttach(ctx, dev, flags, program)
+		},
+	}
+
+	cmd.Flags().StringVarP(&flags.manifestPath, "file", "f", utils.DefaultManifest, "path to the manifest file")
+	cmd.Flags().StringVarP(&flags.namespace, "namespace", "n", "", "namespace where the development container is running")
+	cmd.Flags().StringVarP(&flags.k8sContext, "context", "c", "", "context where the development container is running")
+	cmd.Flags().StringVarP(&flags.language, "lang", "l", "", "language of the development container (go, python or node). Detected from the files of the repository by default")
+	cmd.Flags().IntVarP(&flags.port, "port", "p", 0, "port of the debug server in the development container. Defaults to the standard port of the debugger")
+	cmd.Flags().IntVarP(&flags.localPort, "local-port", "", 0, "local port to forward the debug server to. Defaults to the port of the debug server")
+	cmd.Flags().BoolVarP(&flags.configOnly, "config-only", "", false, "print the IDE launch configuration without starting the debug server")
+	return cmd
+}
+
+// splitArgs returns the arguments before '--' and the program to debug after it
+func splitArgs(args []string, dash int) ([]string, []string) {
+	if dash < 0 {
+		return args, nil
+	}
+	return args[:dash], args[dash:]
+}
+
+func runAttach(ctx context.Context, dev *model.Dev, flags *attachFlags, program []string) error {
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	language, d, err := getDebugger(flags.language, wd, afero.NewOsFs())
+	if err != nil {
+		return err
+	}
+
+	port := d.port
+	if flags.port != 0 {
+		port = flags.port
+	}
+	localPort, forwardedByUp := getForwardedPort(dev, port)
+	if flags.localPort != 0 {
+		localPort = flags.localPort
+		forwardedByUp = false
+	} else if !forwardedByUp {
+		localPort = port
+	}
+
+	command, err := getDebugCommand(language, d, port, program, getRemoteRoot(dev))
+	if err != nil {
+		return err
+	}
+	launch, err := getLaunchConfiguration(dev, d, localPort)
+	if err != nil {
+		return err
+	}
+
+	if flags.configOnly {
+		oktetoLog.Information("Add the following configuration to the 'configurations' list of your .vscode/launch.json file:")
+		oktetoLog.Println(launch)
+		return nil
+	}
+
+	c, cfg, err := okteto.GetK8sClient()
+	if err != nil {
+		return err
+	}
+	pod, err := getDevPod(ctx, dev, c)
+	if err != nil {
+		return err
+	}
+	container := dev.Container
+	if container == "" {
+		container = pod.Spec.Containers[0].Name
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if !forwardedByUp {
+		pf := k8sforward.NewPortForwardManager(ctx, dev.Interface, cfg, c, dev.Namespace)
+		if err := pf.Add(forward.Forward{Local: localPort, Remote: port}); err != nil {
+			return err
+		}
+		if err := pf.Start(pod.Name, dev.Namespace); err != nil {
+			return err
+		}
+		defer pf.Stop()
+	}
+
+	oktetoLog.Success("Debug server of '%s' available at localhost:%d", dev.Name, localPort)
+	oktetoLog.Information("Add the following configuration to the 'configurations' list of your .vscode/launch.json file:")
+	oktetoLog.Println(launch)
+	oktetoLog.Information("Starting the %s debug server. Press CTRL+C to stop it", language)
+
+	_, tty := term.GetFdInfo(os.Stdin)
+	return exec.Exec(ctx, c, cfg, dev.Namespace, pod.Name, container, tty, os.Stdin, os.Stdout, os.Stderr, []string{"sh", "-c", command})
+}
+
+// getDevPod returns the running pod of the development container
+func getDevPod(ctx context.Context, dev *model.Dev, c kubernetes.Interface) (*apiv1.Pod, error) {
+	notRunning := oktetoErrors.UserError{
+		E:    fmt.Errorf("development container '%s' is not running in namespace '%s'", dev.Name, dev.Namespace),
+		Hint: "Run 'okteto up' to launch your development container and try again",
+	}
+
+	d := *dev
+	if d.Autocreate {
+		d.Name = model.DevCloneName(d.Name)
+	}
+	app, err := apps.Get(ctx, &d, d.Namespace, c)
+	if err != nil {
+		if oktetoErrors.IsNotFound(err) {
+			return nil, notRunning
+		}
+		return nil, err
+	}
+	if !d.Autocreate {
+		if !apps.IsDevModeOn(app) {
+			return nil, notRunning
+		}
+		app = app.DevClone()
+	}
+	if err := app.Refresh(ctx, c); err != nil {
+		if oktetoErrors.IsNotFound(err) {
+			return nil, notRunning
+		}
+		return nil, err
+	}
+	return app.GetRunningPod(ctx, c)
+}
@@ -0,0 +1,196 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debug
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/alessio/shellescape"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/filesystem"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/afero"
+)
+
+const (
+	goLanguage     = "go"
+	pythonLanguage = "python"
+	nodeLanguage   = "node"
+)
+
+// debugger is the debug server of a language
+type debugger struct {
+	// markers are the files that identify the language in the root of the repository
+	markers []string
+	// install is the shell command that installs the debug server if it is not available in the container
+	install string
+	// server returns the shell command that starts the debug server listening on port
+	server func(port int, program []string) string
+	// launch returns the VS Code launch configuration to attach to the debug server
+	launch func(name string, port int, remoteRoot string) map[string]interface{}
+	// defaultProgram is the program to debug when none is given
+	defaultProgram string
+	port           int
+}
+
+var debuggers = map[string]debugger{
+	goLanguage: {
+		markers:        []string{"go.mod"},
+		port:           2345,
+		defaultProgram: ".",
+		install:        "command -v dlv >/dev/null 2>&1 || go install github.com/go-delve/delve/cmd/dlv@latest",
+		server: func(port int, program []string) string {
+			return fmt.Sprintf("dlv debug --headless --listen=:%d --api-version=2 --accept-multiclient %s", port, shellescape.QuoteCommand(program))
+		},
+		launch: func(name string, port int, remoteRoot string) map[string]interface{} {
+			return map[string]interface{}{
+				"name":    name,
+				"type":    "go",
+				"request": "attach",
+				"mode":    "remote",
+				"host":    "127.0.0.1",
+				"port":    port,
+				"substitutePath": []map[string]string{
+					{"from": "${workspaceFolder}", "to": remoteRoot},
+				},
+			}
+		},
+	},
+	pythonLanguage: {
+		markers: []string{"requirements.txt", "pyproject.toml", "setup.py", "Pipfile"},
+		port:    5678,
+		install: "python -c 'import debugpy' >/dev/null 2>&1 || python -m pip install debugpy",
+		server: func(port int, program []string) string {
+			return fmt.Sprintf("python -m debugpy --listen 0.0.0.0:%d %s", port, shellescape.QuoteCommand(program))
+		},
+		launch: func(name string, port int, remoteRoot string) map[string]interface{} {
+			return map[string]interface{}{
+				"name":    name,
+				"type":    "debugpy",
+				"request": "attach",
+				"connect": map[string]interface{}{"host": "127.0.0.1", "port": port},
+				"pathMappings": []map[string]string{
+					{"localRoot": "${workspaceFolder}", "remoteRoot": remoteRoot},
+				},
+			}
+		},
+	},
+	nodeLanguage: {
+		markers: []string{"package.json"},
+		port:    9229,
+		server: func(port int, program []string) string {
+			return fmt.Sprintf("node --inspect=0.0.0.0:%d %s", port, shellescape.QuoteCommand(program))
+		},
+		launch: func(name string, port int, remoteRoot string) map[string]interface{} {
+			return map[string]interface{}{
+				"name":       name,
+				"type":       "node",
+				"request":    "attach",
+				"address":    "127.0.0.1",
+				"port":       port,
+				"localRoot":  "${workspaceFolder}",
+				"remoteRoot": remoteRoot,
+			}
+		},
+	},
+}
+
+// getLanguages returns the supported languages sorted by name
+func getLanguages() []string {
+	result := []string{}
+	for name := range debuggers {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// getDebugger returns the debugger of a language, detecting it from the files of the repository if it is empty
+func getDebugger(language, cwd string, fs afero.Fs) (string, debugger, error) {
+	if language == "" {
+		for _, name := range getLanguages() {
+			for _, marker := range debuggers[name].markers {
+				if filesystem.FileExistsWithFilesystem(filepath.Join(cwd, marker), fs) {
+					return name, debuggers[name], nil
+				}
+			}
+		}
+		return "", debugger{}, oktetoErrors.UserError{
+			E:    fmt.Errorf("the language of your development container could not be detected"),
+			Hint: fmt.Sprintf("Use the '--lang' flag to set it. Supported languages: %s", strings.Join(getLanguages(), ", ")),
+		}
+	}
+	d, ok := debuggers[language]
+	if !ok {
+		return "", debugger{}, oktetoErrors.UserError{
+			E:    fmt.Errorf("language '%s' is not supported", language),
+			Hint: fmt.Sprintf("Supported languages: %s", strings.Join(getLanguages(), ", ")),
+		}
+	}
+	return language, d, nil
+}
+
+// getDebugCommand returns the shell command that installs, if needed, and starts the debug server in the dir of the development container
+func getDebugCommand(language string, d debugger, port int, program []string, dir string) (string, error) {
+	if len(program) == 0 {
+		if d.defaultProgram == "" {
+			return "", oktetoErrors.UserError{
+				E:    fmt.Errorf("the program to debug is required for %s", language),
+				Hint: "Add it after '--', for example: 'okteto debug attach -- main.py'",
+			}
+		}
+		program = []string{d.defaultProgram}
+	}
+	command := d.server(port, program)
+	if d.install != "" {
+		command = fmt.Sprintf("(%s) && %s", d.install, command)
+	}
+	return fmt.Sprintf("cd %s && %s", shellescape.Quote(dir), command), nil
+}
+
+// getRemoteRoot returns the folder of the development container where the local sources are synchronized
+func getRemoteRoot(dev *model.Dev) string {
+	if dev.Workdir != "" {
+		return dev.Workdir
+	}
+	if len(dev.Sync.Folders) > 0 {
+		return dev.Sync.Folders[0].RemotePath
+	}
+	return "/"
+}
+
+// getLaunchConfiguration returns the VS Code launch configuration to attach to the debug server
+func getLaunchConfiguration(dev *model.Dev, d debugger, localPort int) (string, error) {
+	config := d.launch(fmt.Sprintf("okteto: %s", dev.Name), localPort, getRemoteRoot(dev))
+	b, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// getForwardedPort returns the local port of the forward of the manifest for the remote port, if any.
+// These forwards are already started by 'okteto up'
+func getForwardedPort(dev *model.Dev, remote int) (int, bool) {
+	for _, f := range dev.Forward {
+		if f.Remote == remote && !f.Service && f.Local != 0 {
+			return f.Local, true
+		}
+	}
+	return 0, false
+}
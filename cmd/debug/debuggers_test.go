@@ -0,0 +1,108 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debug
+
+import (
+	"encoding/json"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDebugger(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		file     string
+		expected string
+		wantErr  bool
+	}{
+		{name: "go detected", file: "go.mod", expected: goLanguage},
+		{name: "python detected", file: "pyproject.toml", expected: pythonLanguage},
+		{name: "node detected", file: "package.json", expected: nodeLanguage},
+		{name: "flag wins over detection", language: pythonLanguage, file: "go.mod", expected: pythonLanguage},
+		{name: "not detected", wantErr: true},
+		{name: "unsupported", language: "ruby", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			if tt.file != "" {
+				require.NoError(t, afero.WriteFile(fs, "/app/"+tt.file, []byte(""), 0600))
+			}
+			language, _, err := getDebugger(tt.language, "/app", fs)
+			if tt.wantErr {
+				var uErr oktetoErrors.UserError
+				assert.ErrorAs(t, err, &uErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, language)
+		})
+	}
+}
+
+func TestGetDebugCommand(t *testing.T) {
+	command, err := getDebugCommand(goLanguage, debuggers[goLanguage], 2345, nil, "/app")
+	require.NoError(t, err)
+	assert.Equal(t, "cd /app && (command -v dlv >/dev/null 2>&1 || go install github.com/go-delve/delve/cmd/dlv@latest) && dlv debug --headless --listen=:2345 --api-version=2 --accept-multiclient .", command)
+
+	command, err = getDebugCommand(nodeLanguage, debuggers[nodeLanguage], 9230, []string{"server.js", "--name", "my app; rm -rf /"}, "/usr/src/my app")
+	require.NoError(t, err)
+	assert.Equal(t, "cd '/usr/src/my app' && node --inspect=0.0.0.0:9230 server.js --name 'my app; rm -rf /'", command)
+
+	_, err = getDebugCommand(pythonLanguage, debuggers[pythonLanguage], 5678, nil, "/")
+	var uErr oktetoErrors.UserError
+	assert.ErrorAs(t, err, &uErr)
+}
+
+func TestGetLaunchConfiguration(t *testing.T) {
+	dev := &model.Dev{Name: "api", Workdir: "/usr/src/app"}
+	launch, err := getLaunchConfiguration(dev, debuggers[pythonLanguage], 5679)
+	require.NoError(t, err)
+
+	config := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal([]byte(launch), &config))
+	assert.Equal(t, "okteto: api", config["name"])
+	assert.Equal(t, "attach", config["request"])
+	assert.Equal(t, map[string]interface{}{"host": "127.0.0.1", "port": float64(5679)}, config["connect"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"localRoot": "${workspaceFolder}", "remoteRoot": "/usr/src/app"}}, config["pathMappings"])
+}
+
+func TestGetRemoteRoot(t *testing.T) {
+	assert.Equal(t, "/app", getRemoteRoot(&model.Dev{Workdir: "/app"}))
+	assert.Equal(t, "/src", getRemoteRoot(&model.Dev{Sync: model.Sync{Folders: []model.SyncFolder{{LocalPath: ".", RemotePath: "/src"}}}}))
+	assert.Equal(t, "/", getRemoteRoot(&model.Dev{}))
+}
+
+func TestGetForwardedPort(t *testing.T) {
+	dev := &model.Dev{
+		Forward: []forward.Forward{
+			{Local: 8080, Remote: 8080},
+			{Local: 2346, Remote: 2345},
+			{Local: 5678, Remote: 5678, ServiceName: "worker", Service: true},
+		},
+	}
+	port, ok := getForwardedPort(dev, 2345)
+	assert.True(t, ok)
+	assert.Equal(t, 2346, port)
+
+	_, ok = getForwardedPort(dev, 5678)
+	assert.False(t, ok)
+}
@@ -0,0 +1,103 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/pkg/filesystem"
+	"github.com/okteto/okteto/pkg/format"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/registry"
+	"github.com/spf13/afero"
+)
+
+type manifestArtifactPuller interface {
+	PullManifestArtifact(reference string) (*registry.ManifestArtifact, error)
+}
+
+// loadManifestArtifact pulls the okteto manifest artifact of the reference into a temporary folder and sets it as the manifest to deploy.
+// It returns a function to remove the temporary folder
+func loadManifestArtifact(ctx context.Context, options *Options, reference string) (func(), error) {
+	if options.ManifestPath != "" {
+		return nil, fmt.Errorf("the flag '--file' can't be used when deploying an okteto manifest from an OCI artifact")
+	}
+
+	ctxOptions := &contextCMD.ContextOptions{
+		Context:   options.K8sContext,
+		Namespace: options.Namespace,
+	}
+	if err := contextCMD.NewContextCommand().Run(ctx, ctxOptions); err != nil {
+		return nil, err
+	}
+
+	dir, err := os.MkdirTemp("", "okteto-manifest-")
+	if err != nil {
+		return nil, err
+	}
+	cleanUp := func() {
+		if err := os.RemoveAll(dir); err != nil {
+			oktetoLog.Infof("failed to remove the manifest folder '%s': %s", dir, err)
+		}
+	}
+
+	if err := extractManifestArtifact(registry.NewOktetoRegistry(okteto.Config{}), afero.NewOsFs(), reference, dir, options); err != nil {
+		cleanUp()
+		return nil, err
+	}
+	return cleanUp, nil
+}
+
+// extractManifestArtifact extracts the content of the artifact into dir and updates the manifest path and the name of the options
+func extractManifestArtifact(puller manifestArtifactPuller, fs afero.Fs, reference, dir string, options *Options) error {
+	oktetoLog.Information("Pulling okteto manifest from '%s'", reference)
+	artifact, err := puller.PullManifestArtifact(reference)
+	if err != nil {
+		return err
+	}
+	if artifact.ManifestPath == "" {
+		return fmt.Errorf("'%s' doesn't define the path of its okteto manifest", reference)
+	}
+	if err := filesystem.ExtractArchive(fs, artifact.Content, dir); err != nil {
+		return err
+	}
+
+	options.ManifestPath = filepath.Join(dir, filepath.FromSlash(artifact.ManifestPath))
+	if !filesystem.FileExistsWithFilesystem(options.ManifestPath, fs) {
+		return fmt.Errorf("'%s' doesn't contain the okteto manifest '%s'", reference, artifact.ManifestPath)
+	}
+	if options.Name == "" {
+		options.Name = getManifestArtifactName(reference)
+	}
+	return nil
+}
+
+// getManifestArtifactName returns the name of the repository of the reference, used as the name of the development environment
+func getManifestArtifactName(reference string) string {
+	reference = strings.TrimPrefix(reference, registry.ManifestArtifactScheme)
+	if i := strings.Index(reference, "@"); i >= 0 {
+		reference = reference[:i]
+	}
+	name := reference[strings.LastIndex(reference, "/")+1:]
+	if i := strings.Index(name, ":"); i >= 0 {
+		name = name[:i]
+	}
+	return format.ResourceK8sMetaString(name)
+}
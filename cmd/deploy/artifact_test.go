@@ -0,0 +1,76 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/filesystem"
+	"github.com/okteto/okteto/pkg/registry"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeManifestArtifactPuller struct {
+	artifact *registry.ManifestArtifact
+	err      error
+}
+
+func (f fakeManifestArtifactPuller) PullManifestArtifact(_ string) (*registry.ManifestArtifact, error) {
+	return f.artifact, f.err
+}
+
+func TestExtractManifestArtifact(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	src := filepath.Join("/", "src")
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(src, "okteto.yml"), []byte("deploy: []"), 0600))
+	content, err := filesystem.ArchiveDirectory(fs, src, nil)
+	require.NoError(t, err)
+
+	dir := filepath.Join("/", "artifact")
+	options := &Options{}
+	puller := fakeManifestArtifactPuller{artifact: &registry.ManifestArtifact{ManifestPath: "okteto.yml", Content: content}}
+	require.NoError(t, extractManifestArtifact(puller, fs, "oci://okteto.dev/my-app:1.0", dir, options))
+	assert.Equal(t, filepath.Join(dir, "okteto.yml"), options.ManifestPath)
+	assert.Equal(t, "my-app", options.Name)
+
+	options = &Options{Name: "custom"}
+	require.NoError(t, extractManifestArtifact(puller, fs, "oci://okteto.dev/my-app:1.0", dir, options))
+	assert.Equal(t, "custom", options.Name)
+
+	puller = fakeManifestArtifactPuller{artifact: &registry.ManifestArtifact{ManifestPath: "missing.yml", Content: content}}
+	assert.Error(t, extractManifestArtifact(puller, fs, "oci://okteto.dev/my-app:1.0", dir, &Options{}))
+
+	puller = fakeManifestArtifactPuller{err: errors.New("not found")}
+	assert.Error(t, extractManifestArtifact(puller, fs, "oci://okteto.dev/my-app:1.0", dir, &Options{}))
+}
+
+func TestGetManifestArtifactName(t *testing.T) {
+	tests := []struct {
+		reference string
+		expected  string
+	}{
+		{reference: "oci://okteto.dev/my-app:1.0", expected: "my-app"},
+		{reference: "oci://registry.example.com:5000/team/My_App", expected: "my-app"},
+		{reference: "oci://okteto.dev/my-app@sha256:abc", expected: "my-app"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.reference, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getManifestArtifactName(tt.reference))
+		})
+	}
+}
@@ -17,8 +17,7 @@ import (
 	"context"
 
 	"github.com/okteto/okteto/pkg/cmd/pipeline"
-	"github.com/okteto/okteto/pkg/constants"
-	"github.com/okteto/okteto/pkg/env"
+	"github.com/okteto/okteto/pkg/config"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/okteto"
 	apiv1 "k8s.io/api/core/v1"
@@ -58,7 +57,7 @@ func newDefaultConfigMapHandler(provider okteto.K8sClientProvider) *defaultConfi
 }
 
 func NewConfigmapHandler(provider okteto.K8sClientProvider) configMapHandler {
-	if env.LoadBoolean(constants.OktetoDeployRemote) {
+	if config.EnvOktetoDeployRemote.IsTrue() {
 		return newDeployInsideDeployConfigMapHandler(provider)
 	}
 	return newDefaultConfigMapHandler(provider)
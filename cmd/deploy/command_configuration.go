@@ -21,6 +21,7 @@ import (
 
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/cmd/stack"
+	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/constants"
 	"github.com/okteto/okteto/pkg/devenvironment"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
@@ -120,7 +121,7 @@ func mergeServicesToDeployFromOptionsAndManifest(deployOptions *Options) {
 }
 
 func (dc *DeployCommand) addEnvVars(cwd string) {
-	if os.Getenv(constants.OktetoGitBranchEnvVar) == "" {
+	if config.EnvOktetoGitBranch.Value() == "" {
 		branch, err := utils.GetBranch(cwd)
 		if err != nil {
 			oktetoLog.Infof("could not retrieve branch name: %s", err)
@@ -128,7 +129,7 @@ func (dc *DeployCommand) addEnvVars(cwd string) {
 		os.Setenv(constants.OktetoGitBranchEnvVar, branch)
 	}
 
-	if os.Getenv(model.GithubRepositoryEnvVar) == "" {
+	if config.EnvGithubRepository.Value() == "" {
 		repo, err := modelUtils.GetRepositoryURL(cwd)
 		if err != nil {
 			oktetoLog.Infof("could not retrieve repo name: %s", err)
@@ -146,7 +147,7 @@ func (dc *DeployCommand) addEnvVars(cwd string) {
 		os.Setenv(model.GithubRepositoryEnvVar, repo)
 	}
 
-	if os.Getenv(constants.OktetoGitCommitEnvVar) == "" {
+	if config.EnvOktetoGitCommit.Value() == "" {
 		sha, err := repository.NewRepository(cwd).GetSHA()
 		if err != nil {
 			oktetoLog.Infof("could not retrieve sha: %s", err)
@@ -163,16 +164,16 @@ func (dc *DeployCommand) addEnvVars(cwd string) {
 		}
 		os.Setenv(constants.OktetoGitCommitEnvVar, sha)
 	}
-	if os.Getenv(model.OktetoRegistryURLEnvVar) == "" {
+	if config.EnvOktetoRegistryURL.Value() == "" {
 		os.Setenv(model.OktetoRegistryURLEnvVar, okteto.Context().Registry)
 	}
-	if os.Getenv(model.OktetoBuildkitHostURLEnvVar) == "" {
+	if config.EnvBuildkitHost.Value() == "" {
 		os.Setenv(model.OktetoBuildkitHostURLEnvVar, okteto.Context().Builder)
 	}
-	if os.Getenv(model.OktetoTokenEnvVar) == "" {
+	if config.EnvOktetoToken.Value() == "" {
 		os.Setenv(model.OktetoTokenEnvVar, okteto.Context().Token)
 	}
-	oktetoLog.AddMaskedWord(os.Getenv(model.OktetoTokenEnvVar))
+	oktetoLog.AddMaskedWord(config.EnvOktetoToken.Value())
 }
 
 func switchRepoSchemaToHTTPS(repo string) *url.URL {
@@ -30,16 +30,20 @@ import (
 	"github.com/okteto/okteto/pkg/cmd/pipeline"
 	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/deps"
 	"github.com/okteto/okteto/pkg/divert"
 	"github.com/okteto/okteto/pkg/env"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/externalresource"
 	"github.com/okteto/okteto/pkg/format"
+	"github.com/okteto/okteto/pkg/k8s/rbac"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/log/io"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/output"
 	oktetoPath "github.com/okteto/okteto/pkg/path"
+	"github.com/okteto/okteto/pkg/registry"
 	"github.com/okteto/okteto/pkg/repository"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/spf13/afero"
@@ -65,12 +69,14 @@ type Options struct {
 	ManifestPathFlag string
 	// ManifestPath is the path to the manifest used though the command execution.
 	// This might change its value during execution
-	ManifestPath     string
-	Name             string
-	Namespace        string
-	K8sContext       string
-	Repository       string
-	Branch           string
+	ManifestPath string
+	Name         string
+	Namespace    string
+	K8sContext   string
+	Repository   string
+	Branch       string
+	// PlanOutput is the output format of the plan
+	PlanOutput       string
 	Variables        []string
 	servicesToDeploy []string
 	Timeout          time.Duration
@@ -80,6 +86,8 @@ type Options struct {
 	RunInRemote      bool
 	Wait             bool
 	ShowCTA          bool
+	// Plan prints what the deploy would do without executing it
+	Plan bool
 }
 
 type builderInterface interface {
@@ -148,7 +156,7 @@ func Deploy(ctx context.Context, at analyticsTrackerInterface, ioCtrl *io.IOCont
 		Fs: afero.NewOsFs(),
 	}
 	cmd := &cobra.Command{
-		Use:   "deploy [service...]",
+		Use:   "deploy [oci://REFERENCE] [service...]",
 		Short: "Execute the list of commands specified in the 'deploy' section of your okteto manifest",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// validate cmd options
@@ -164,6 +172,22 @@ func Deploy(ctx context.Context, at analyticsTrackerInterface, ioCtrl *io.IOCont
 			// deploy command. If not, we could be proxying a proxy and we would be applying the incorrect deployed-by label
 			os.Setenv(constants.OktetoSkipConfigCredentialsUpdate, "false")
 
+			if len(args) > 0 && registry.IsManifestArtifact(args[0]) {
+				cleanUp, err := loadManifestArtifact(ctx, options, args[0])
+				if err != nil {
+					return err
+				}
+				defer cleanUp()
+				args = args[1:]
+			}
+
+			if options.ManifestPath == "" {
+				manifestPath, err := utils.DiscoverManifestPath()
+				if err != nil {
+					return err
+				}
+				options.ManifestPath = manifestPath
+			}
 			err := checkOktetoManifestPathFlag(options, fs.Fs)
 			if err != nil {
 				return err
@@ -179,7 +203,7 @@ func Deploy(ctx context.Context, at analyticsTrackerInterface, ioCtrl *io.IOCont
 				}
 			}
 
-			if okteto.IsOkteto() {
+			if okteto.IsOkteto() && !options.Plan {
 				create, err := utils.ShouldCreateNamespace(ctx, okteto.Context().Namespace)
 				if err != nil {
 					return err
@@ -212,7 +236,7 @@ func Deploy(ctx context.Context, at analyticsTrackerInterface, ioCtrl *io.IOCont
 				Builder:            buildv2.NewBuilderFromScratch(at, ioCtrl),
 				DeployWaiter:       NewDeployWaiter(k8sClientProvider),
 				EndpointGetter:     NewEndpointGetter,
-				isRemote:           env.LoadBoolean(constants.OktetoDeployRemote),
+				isRemote:           config.EnvOktetoDeployRemote.IsTrue(),
 				CfgMapHandler:      NewConfigmapHandler(k8sClientProvider),
 				Fs:                 afero.NewOsFs(),
 				PipelineCMD:        pc,
@@ -233,19 +257,28 @@ func Deploy(ctx context.Context, at analyticsTrackerInterface, ioCtrl *io.IOCont
 				exit <- err
 			}()
 
-			select {
-			case <-stop:
-				oktetoLog.Infof("CTRL+C received, starting shutdown sequence")
+			shutdown := func(reason error) error {
 				oktetoLog.Spinner("Shutting down...")
 				oktetoLog.StartSpinner()
 				defer oktetoLog.StopSpinner()
 
-				deployer, err := c.GetDeployer(ctx, options, c.Builder, c.CfgMapHandler, k8sClientProvider, NewKubeConfig(), model.GetAvailablePort, ioCtrl)
+				// the command context might be cancelled, the clean up needs its own context
+				cleanUpCtx := context.Background()
+				deployer, err := c.GetDeployer(cleanUpCtx, options, c.Builder, c.CfgMapHandler, k8sClientProvider, NewKubeConfig(), model.GetAvailablePort, ioCtrl)
 				if err != nil {
 					return err
 				}
-				deployer.cleanUp(ctx, oktetoErrors.ErrIntSig)
-				return oktetoErrors.ErrIntSig
+				deployer.cleanUp(cleanUpCtx, reason)
+				return reason
+			}
+
+			select {
+			case <-stop:
+				oktetoLog.Infof("CTRL+C received, starting shutdown sequence")
+				return shutdown(oktetoErrors.ErrIntSig)
+			case <-ctx.Done():
+				oktetoLog.Infof("context cancelled, starting shutdown sequence")
+				return shutdown(ctx.Err())
 			case err := <-exit:
 				return err
 			}
@@ -263,6 +296,8 @@ func Deploy(ctx context.Context, at analyticsTrackerInterface, ioCtrl *io.IOCont
 	cmd.Flags().BoolVarP(&options.RunInRemote, "remote", "", false, "force run deploy commands in remote")
 
 	cmd.Flags().BoolVarP(&options.Wait, "wait", "w", false, "wait until the development environment is deployed (defaults to false)")
+	cmd.Flags().BoolVarP(&options.Plan, "plan", "", false, "print the images, commands, resources, variables and external resources involved in the deploy without executing it")
+	output.AddFlag(cmd, &options.PlanOutput)
 	cmd.Flags().DurationVarP(&options.Timeout, "timeout", "t", getDefaultTimeout(), "the length of time to wait for completion, zero means never. Any other values should contain a corresponding time unit e.g. 1s, 2m, 3h ")
 
 	return cmd
@@ -277,6 +312,7 @@ func (dc *DeployCommand) RunDeploy(ctx context.Context, deployOptions *Options)
 	}
 	deployOptions.Manifest = manifest
 	oktetoLog.Debug("found okteto manifest")
+	utils.AddManifestLabelsPolicy(manifest)
 	dc.PipelineType = deployOptions.Manifest.Type
 
 	if deployOptions.Manifest.Deploy == nil && !deployOptions.Manifest.HasDependencies() {
@@ -327,11 +363,23 @@ func (dc *DeployCommand) RunDeploy(ctx context.Context, deployOptions *Options)
 		}
 	}
 
+	if deployOptions.Plan {
+		plan, err := dc.getPlan(ctx, deployOptions, c)
+		if err != nil {
+			return err
+		}
+		return printPlan(plan, deployOptions.PlanOutput, os.Stdout)
+	}
+
+	if err := rbac.Check(ctx, c, deployOptions.Manifest.Namespace, getDeployPermissions(deployOptions.Manifest)); err != nil {
+		return err
+	}
+
 	data := &pipeline.CfgData{
 		Name:       deployOptions.Name,
 		Namespace:  deployOptions.Manifest.Namespace,
-		Repository: os.Getenv(model.GithubRepositoryEnvVar),
-		Branch:     os.Getenv(constants.OktetoGitBranchEnvVar),
+		Repository: config.EnvGithubRepository.Value(),
+		Branch:     config.EnvOktetoGitBranch.Value(),
 		Filename:   deployOptions.ManifestPathFlag,
 		Status:     pipeline.ProgressingStatus,
 		Manifest:   deployOptions.Manifest.Manifest,
@@ -339,6 +387,11 @@ func (dc *DeployCommand) RunDeploy(ctx context.Context, deployOptions *Options)
 		Variables:  deployOptions.Variables,
 	}
 
+	// the installer deploys a clean checkout of the repository, so its commit can be deployed again
+	if dc.runningInInstaller {
+		data.Commit = config.EnvOktetoGitCommit.Value()
+	}
+
 	if !deployOptions.Manifest.IsV2 && deployOptions.Manifest.Type == model.StackType && deployOptions.Manifest.Deploy != nil {
 		data.Manifest = deployOptions.Manifest.Deploy.ComposeSection.Stack.Manifest
 	}
@@ -365,7 +418,7 @@ func (dc *DeployCommand) RunDeploy(ctx context.Context, deployOptions *Options)
 		if errStatus := dc.CfgMapHandler.updateConfigMap(ctx, cfg, data, err); errStatus != nil {
 			return errStatus
 		}
-		return err
+		return oktetoErrors.WithExitCode(err, oktetoErrors.ExitCodeBuild)
 	}
 
 	if err := dc.recreateFailedPods(ctx, deployOptions.Name); err != nil {
@@ -382,7 +435,7 @@ func (dc *DeployCommand) RunDeploy(ctx context.Context, deployOptions *Options)
 		if err == oktetoErrors.ErrIntSig {
 			return nil
 		}
-		err = oktetoErrors.UserError{E: err}
+		err = oktetoErrors.UserError{E: oktetoErrors.WithExitCode(err, oktetoErrors.ExitCodeDeploy)}
 		data.Status = pipeline.ErrorStatus
 	} else {
 		oktetoLog.SetStage("")
@@ -396,7 +449,7 @@ func (dc *DeployCommand) RunDeploy(ctx context.Context, deployOptions *Options)
 					return err
 				}
 			}
-			if !env.LoadBoolean(constants.OktetoWithinDeployCommandContextEnvVar) {
+			if !config.EnvOktetoWithinDeployCommandContext.IsTrue() {
 				eg, err := dc.EndpointGetter()
 				if err != nil {
 					oktetoLog.Infof("could not create endpoint getter: %s", err)
@@ -413,6 +466,7 @@ func (dc *DeployCommand) RunDeploy(ctx context.Context, deployOptions *Options)
 			}
 			pipeline.AddDevAnnotations(ctx, deployOptions.Manifest, c)
 		}
+		data.HelmReleases = getHelmReleases(ctx, deployOptions.Name, deployOptions.Manifest, c)
 		data.Status = pipeline.DeployedStatus
 	}
 
@@ -424,20 +478,7 @@ func (dc *DeployCommand) RunDeploy(ctx context.Context, deployOptions *Options)
 }
 
 func buildImages(ctx context.Context, builder builderInterface, deployOptions *Options) error {
-	var stackServicesWithBuild map[string]bool
-
-	if stack := deployOptions.Manifest.GetStack(); stack != nil {
-		stackServicesWithBuild = stack.GetServicesWithBuildSection()
-	}
-
-	allServicesWithBuildSection := deployOptions.Manifest.GetBuildServices()
-	oktetoManifestServicesWithBuild := setDifference(allServicesWithBuildSection, stackServicesWithBuild) // Warning: this way of getting the oktetoManifestServicesWithBuild is highly dependent on the manifest struct as it is now. We are assuming that: *okteto* manifest build = manifest build - stack build section
-	servicesToDeployWithBuild := setIntersection(allServicesWithBuildSection, sliceToSet(deployOptions.servicesToDeploy))
-	// We need to build:
-	// - All the services that have a build section defined in the *okteto* manifest
-	// - Services from *deployOptions.servicesToDeploy* that have a build section
-
-	servicesToBuildSet := setUnion(oktetoManifestServicesWithBuild, servicesToDeployWithBuild)
+	servicesToBuildSet := getServicesToBuildSet(deployOptions)
 
 	if deployOptions.Build {
 		buildOptions := &types.BuildOptions{
@@ -471,6 +512,24 @@ func buildImages(ctx context.Context, builder builderInterface, deployOptions *O
 	return nil
 }
 
+// getServicesToBuildSet returns the services with a build section that are candidates to be built by the deploy
+func getServicesToBuildSet(deployOptions *Options) map[string]bool {
+	var stackServicesWithBuild map[string]bool
+
+	if stack := deployOptions.Manifest.GetStack(); stack != nil {
+		stackServicesWithBuild = stack.GetServicesWithBuildSection()
+	}
+
+	allServicesWithBuildSection := deployOptions.Manifest.GetBuildServices()
+	oktetoManifestServicesWithBuild := setDifference(allServicesWithBuildSection, stackServicesWithBuild) // Warning: this way of getting the oktetoManifestServicesWithBuild is highly dependent on the manifest struct as it is now. We are assuming that: *okteto* manifest build = manifest build - stack build section
+	servicesToDeployWithBuild := setIntersection(allServicesWithBuildSection, sliceToSet(deployOptions.servicesToDeploy))
+	// We need to build:
+	// - All the services that have a build section defined in the *okteto* manifest
+	// - Services from *deployOptions.servicesToDeploy* that have a build section
+
+	return setUnion(oktetoManifestServicesWithBuild, servicesToDeployWithBuild)
+}
+
 func sliceToSet[T comparable](slice []T) map[T]bool {
 	set := make(map[T]bool)
 	for _, value := range slice {
@@ -520,14 +579,13 @@ func setDifference[T comparable](set1, set2 map[T]bool) map[T]bool {
 
 func getDefaultTimeout() time.Duration {
 	defaultTimeout := 5 * time.Minute
-	t := os.Getenv(model.OktetoTimeoutEnvVar)
-	if t == "" {
+	if config.EnvOktetoTimeout.Value() == "" {
 		return defaultTimeout
 	}
 
-	parsed, err := time.ParseDuration(t)
+	parsed, err := config.EnvOktetoTimeout.Duration()
 	if err != nil {
-		oktetoLog.Infof("OKTETO_TIMEOUT value is not a valid duration: %s", t)
+		oktetoLog.Infof("%s", err)
 		oktetoLog.Infof("timeout fallback to defaultTimeout")
 		return defaultTimeout
 	}
@@ -537,7 +595,7 @@ func getDefaultTimeout() time.Duration {
 
 func shouldRunInRemote(opts *Options) bool {
 	// already in remote so we need to deploy locally
-	if env.LoadBoolean(constants.OktetoDeployRemote) {
+	if config.EnvOktetoDeployRemote.IsTrue() {
 		return false
 	}
 
@@ -548,12 +606,12 @@ func shouldRunInRemote(opts *Options) bool {
 
 	// remote option set in the manifest via a remote deployer image or the remote option enabled
 	if opts.Manifest != nil && opts.Manifest.Deploy != nil {
-		if opts.Manifest.Deploy.Image != "" || opts.Manifest.Deploy.Remote {
+		if opts.Manifest.Deploy.Image != "" || opts.Manifest.Deploy.Remote.IsEnabled() {
 			return true
 		}
 	}
 
-	if env.LoadBoolean(constants.OktetoForceRemote) {
+	if config.EnvOktetoForceRemote.IsTrue() {
 		return true
 	}
 
@@ -597,7 +655,7 @@ func GetDeployer(ctx context.Context,
 func isRemoteDeployer(runInRemoteFlag bool, deployImage string) bool {
 	// isDeployRemote represents whether the process is coming from a remote deploy
 	// if true it should get the local deployer
-	isDeployRemote := env.LoadBoolean(constants.OktetoDeployRemote)
+	isDeployRemote := config.EnvOktetoDeployRemote.IsTrue()
 
 	// remote deployment should be done when flag RunInRemote is active OR deploy.image is fulfilled
 	return !isDeployRemote && (runInRemoteFlag || deployImage != "")
@@ -609,7 +667,15 @@ func (dc *DeployCommand) deployDependencies(ctx context.Context, deployOptions *
 		return errDepenNotAvailableInVanilla
 	}
 
-	for depName, dep := range deployOptions.Manifest.Dependencies {
+	order, err := deployOptions.Manifest.Dependencies.GetDeployOrder()
+	if err != nil {
+		return err
+	}
+	referenced := getReferencedDependencies(deployOptions.Manifest)
+	outputs := deps.Outputs{}
+
+	for _, depName := range order {
+		dep := deployOptions.Manifest.Dependencies[depName]
 		oktetoLog.Information("Deploying dependency '%s'", depName)
 		oktetoLog.SetStage(fmt.Sprintf("Deploying dependency %s", depName))
 		dep.Variables = append(dep.Variables, env.Var{
@@ -625,13 +691,18 @@ func (dc *DeployCommand) deployDependencies(ctx context.Context, deployOptions *
 		if err != nil {
 			return fmt.Errorf("could not expand variables in dependencies: %w", err)
 		}
+		if err := dep.ExpandOutputs(outputs); err != nil {
+			return fmt.Errorf("could not expand variables in dependency '%s': %w", depName, err)
+		}
+		// the outputs of a dependency are only available once its deployment finishes
+		_, isReferenced := referenced[depName]
 		pipOpts := &pipelineCMD.DeployOptions{
 			Name:         depName,
 			Repository:   dep.Repository,
 			Branch:       dep.Branch,
 			File:         dep.ManifestPath,
 			Variables:    model.SerializeEnvironmentVars(dep.Variables),
-			Wait:         dep.Wait,
+			Wait:         dep.Wait || isReferenced,
 			Timeout:      dep.GetTimeout(deployOptions.Timeout),
 			SkipIfExists: !deployOptions.Dependencies,
 			Namespace:    namespace,
@@ -640,11 +711,51 @@ func (dc *DeployCommand) deployDependencies(ctx context.Context, deployOptions *
 		if err := dc.PipelineCMD.ExecuteDeployPipeline(ctx, pipOpts); err != nil {
 			return err
 		}
+
+		if isReferenced {
+			c, _, err := dc.K8sClientProvider.Provide(okteto.Context().Cfg)
+			if err != nil {
+				return fmt.Errorf("could not get kubernetes client: %w", err)
+			}
+			envs, err := pipeline.GetDependencyEnvs(ctx, depName, namespace, c)
+			if err != nil {
+				return fmt.Errorf("could not get the variables of dependency '%s': %w", depName, err)
+			}
+			outputs[depName] = envs
+		}
 	}
 	oktetoLog.SetStage("")
+
+	if deployOptions.Manifest.Deploy != nil {
+		for i, command := range deployOptions.Manifest.Deploy.Commands {
+			expanded, err := outputs.Expand(command.Command)
+			if err != nil {
+				return fmt.Errorf("could not expand dependency variables in command '%s': %w", command.Name, err)
+			}
+			deployOptions.Manifest.Deploy.Commands[i].Command = expanded
+		}
+	}
 	return nil
 }
 
+// getReferencedDependencies returns the dependencies whose variables are referenced by other dependencies or by the deploy commands
+func getReferencedDependencies(manifest *model.Manifest) map[string]struct{} {
+	result := map[string]struct{}{}
+	for _, dep := range manifest.Dependencies {
+		for _, name := range dep.GetReferencedDependencies() {
+			result[name] = struct{}{}
+		}
+	}
+	if manifest.Deploy != nil {
+		for _, command := range manifest.Deploy.Commands {
+			for _, name := range deps.GetReferencedDependencies(command.Command) {
+				result[name] = struct{}{}
+			}
+		}
+	}
+	return result
+}
+
 func (dc *DeployCommand) recreateFailedPods(ctx context.Context, name string) error {
 	c, _, err := dc.K8sClientProvider.Provide(okteto.Context().Cfg)
 	if err != nil {
@@ -691,7 +802,7 @@ func (dc *DeployCommand) trackDeploy(manifest *model.Manifest, runInRemoteFlag b
 		Duration:               time.Since(startTime),
 		PipelineType:           dc.PipelineType,
 		DeployType:             deployType,
-		IsPreview:              os.Getenv(model.OktetoCurrentDeployBelongsToPreview) == "true",
+		IsPreview:              config.EnvOktetoCurrentDeployBelongsToPreview.IsTrue(),
 		HasDependenciesSection: hasDependencySection,
 		HasBuildSection:        hasBuildSection,
 		IsRemote:               isRunningOnRemoteDeployer,
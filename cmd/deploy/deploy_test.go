@@ -33,6 +33,7 @@ import (
 	"github.com/okteto/okteto/pkg/constants"
 	"github.com/okteto/okteto/pkg/deps"
 	"github.com/okteto/okteto/pkg/divert"
+	"github.com/okteto/okteto/pkg/env"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/externalresource"
 	"github.com/okteto/okteto/pkg/format"
@@ -1124,6 +1125,71 @@ func TestDeployDependencies(t *testing.T) {
 	}
 }
 
+type recordingPipelineDeployer struct {
+	deployed *[]*pipelineCMD.DeployOptions
+}
+
+func (rd recordingPipelineDeployer) ExecuteDeployPipeline(_ context.Context, opts *pipelineCMD.DeployOptions) error {
+	*rd.deployed = append(*rd.deployed, opts)
+	return nil
+}
+
+func TestDeployDependenciesWithOutputs(t *testing.T) {
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {
+				Namespace: "test",
+				IsOkteto:  true,
+			},
+		},
+		CurrentContext: "test",
+	}
+	fakeK8sClientProvider := test.NewFakeK8sProvider(&apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pipeline.TranslatePipelineName("api"),
+			Namespace: "test",
+		},
+		Data: map[string]string{
+			// {"ENDPOINT":"https://api-test.okteto.dev"}
+			constants.OktetoDependencyEnvsKey: "eyJFTkRQT0lOVCI6Imh0dHBzOi8vYXBpLXRlc3Qub2t0ZXRvLmRldiJ9",
+		},
+	})
+	manifest := &model.Manifest{
+		Dependencies: deps.ManifestSection{
+			"frontend": &deps.Dependency{
+				Repository: "https://github.com/okteto/frontend",
+				Variables: env.Environment{
+					{Name: "API_URL", Value: "${dependencies.api.endpoint}"},
+				},
+			},
+			"api": &deps.Dependency{
+				Repository: "https://github.com/okteto/api",
+			},
+		},
+		Deploy: &model.DeployInfo{
+			Commands: []model.DeployCommand{
+				{Name: "smoke test", Command: "curl ${dependencies.api.endpoint}/healthz"},
+			},
+		},
+	}
+	deployed := []*pipelineCMD.DeployOptions{}
+	dc := &DeployCommand{
+		PipelineCMD:       recordingPipelineDeployer{deployed: &deployed},
+		K8sClientProvider: fakeK8sClientProvider,
+	}
+
+	err := dc.deployDependencies(context.Background(), &Options{Manifest: manifest})
+	require.NoError(t, err)
+
+	require.Len(t, deployed, 2)
+	assert.Equal(t, "api", deployed[0].Name)
+	assert.True(t, deployed[0].Wait)
+	assert.Equal(t, "frontend", deployed[1].Name)
+	assert.False(t, deployed[1].Wait)
+	assert.Contains(t, deployed[1].Variables, "API_URL=https://api-test.okteto.dev")
+	assert.Equal(t, "curl https://api-test.okteto.dev/healthz", manifest.Deploy.Commands[0].Command)
+}
+
 func TestDeployOnlyDependencies(t *testing.T) {
 	fakeOs := afero.NewMemMapFs()
 	fakeK8sClientProvider := test.NewFakeK8sProvider(&v1.Deployment{
@@ -1256,7 +1322,7 @@ func TestTrackDeploy(t *testing.T) {
 func TestShouldRunInRemoteDeploy(t *testing.T) {
 	var tempManifest *model.Manifest = &model.Manifest{
 		Deploy: &model.DeployInfo{
-			Remote: true,
+			Remote: &model.RemoteDeploy{Enabled: true},
 			Image:  "some-image",
 		},
 	}
@@ -1300,7 +1366,7 @@ func TestShouldRunInRemoteDeploy(t *testing.T) {
 				Manifest: &model.Manifest{
 					Deploy: &model.DeployInfo{
 						Image:  "",
-						Remote: true,
+						Remote: &model.RemoteDeploy{Enabled: true},
 					},
 				},
 			},
@@ -1314,7 +1380,7 @@ func TestShouldRunInRemoteDeploy(t *testing.T) {
 				Manifest: &model.Manifest{
 					Deploy: &model.DeployInfo{
 						Image:  "",
-						Remote: false,
+						Remote: &model.RemoteDeploy{Enabled: false},
 					},
 				},
 			},
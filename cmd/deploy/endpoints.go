@@ -15,8 +15,9 @@ package deploy
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"sort"
 	"strings"
@@ -31,9 +32,13 @@ import (
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/output"
 	"github.com/spf13/cobra"
 )
 
+// mdOutput is the output format that lists the endpoints as markdown links, used to comment them in pull requests
+const mdOutput = "md"
+
 // EndpointsOptions defines the options to get the endpoints
 type EndpointsOptions struct {
 	Name         string
@@ -41,6 +46,7 @@ type EndpointsOptions struct {
 	Output       string
 	Namespace    string
 	K8sContext   string
+	Probe        bool
 }
 
 type endpointGetterInterface interface {
@@ -58,6 +64,7 @@ type k8sIngressClientProvider interface {
 type EndpointGetter struct {
 	GetManifest     func(path string) (*model.Manifest, error)
 	endpointControl endpointControlInterface
+	probeClient     *http.Client
 }
 
 func NewEndpointGetter() (EndpointGetter, error) {
@@ -75,6 +82,7 @@ func NewEndpointGetter() (EndpointGetter, error) {
 	return EndpointGetter{
 		GetManifest:     model.GetManifestV2,
 		endpointControl: endpointControl,
+		probeClient:     newEndpointProbeClient(),
 	}, nil
 
 }
@@ -163,18 +171,18 @@ func Endpoints(ctx context.Context) *cobra.Command {
 	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "overwrites the namespace where the development environment is deployed")
 	cmd.Flags().StringVarP(&options.K8sContext, "context", "c", "", "context where the development environment is deployed")
 
-	cmd.Flags().StringVarP(&options.Output, "output", "o", "", "output format. One of: ['json', 'md']")
+	output.AddFlag(cmd, &options.Output)
+	cmd.Flags().Lookup("output").Usage += ", or 'md' to list the endpoints as markdown links"
+	cmd.Flags().BoolVarP(&options.Probe, "probe", "", false, "check if each endpoint responds with HTTP 200")
 
 	return cmd
 }
 
-func validateOutput(output string) error {
-	switch output {
-	case "", "json", "md":
+func validateOutput(outputFormat string) error {
+	if outputFormat == mdOutput {
 		return nil
-	default:
-		return fmt.Errorf("output format is not accepted. Value must be one of: ['json', 'md']")
 	}
+	return output.Validate(outputFormat)
 }
 
 func (eg *EndpointGetter) getEndpoints(ctx context.Context, opts *EndpointsOptions) ([]string, error) {
@@ -254,14 +262,11 @@ func (dc *EndpointGetter) showEndpoints(ctx context.Context, opts *EndpointsOpti
 		return err
 	}
 
-	switch opts.Output {
-	case "json":
-		bytes, err := json.MarshalIndent(eps, "", "  ")
-		if err != nil {
-			return err
-		}
-		oktetoLog.Println(string(bytes))
-	case "md":
+	if opts.Probe {
+		return dc.showEndpointsStatus(ctx, opts, eps)
+	}
+
+	if opts.Output == mdOutput {
 		if len(eps) == 0 {
 			oktetoLog.Printf("There are no available endpoints for '%s'\n", opts.Name)
 		} else {
@@ -270,13 +275,51 @@ func (dc *EndpointGetter) showEndpoints(ctx context.Context, opts *EndpointsOpti
 				oktetoLog.Printf("\n - [%s](%s)\n", e, e)
 			}
 		}
-	default:
+		return nil
+	}
+	return output.Print(os.Stdout, opts.Output, eps, func(io.Writer) error {
 		if len(eps) == 0 {
 			oktetoLog.Information("There are no available endpoints for '%s'.\n    Follow this link to know more about how to create public endpoints for your application:\n    https://www.okteto.com/docs/cloud/ssl/", opts.Name)
 		} else {
 			oktetoLog.Information("Endpoints available:")
 			oktetoLog.Printf("  - %s\n", strings.Join(eps, "\n  - "))
 		}
+		return nil
+	}, output.WithJSONIndent("  "))
+}
+
+func (dc *EndpointGetter) showEndpointsStatus(ctx context.Context, opts *EndpointsOptions, eps []string) error {
+	if opts.Output == "" && len(eps) > 0 {
+		oktetoLog.Spinner("Probing endpoints...")
+		oktetoLog.StartSpinner()
+	}
+	statuses := probeEndpoints(ctx, dc.probeClient, eps)
+	oktetoLog.StopSpinner()
+
+	if opts.Output == mdOutput {
+		if len(statuses) == 0 {
+			oktetoLog.Printf("There are no available endpoints for '%s'\n", opts.Name)
+		} else {
+			oktetoLog.Printf("Available endpoints:\n")
+			for _, s := range statuses {
+				oktetoLog.Printf("\n - [%s](%s) %s\n", s.URL, s.URL, s.Summary())
+			}
+		}
+		return nil
 	}
-	return nil
+	return output.Print(os.Stdout, opts.Output, statuses, func(w io.Writer) error {
+		if len(statuses) == 0 {
+			oktetoLog.Information("There are no available endpoints for '%s'.\n    Follow this link to know more about how to create public endpoints for your application:\n    https://www.okteto.com/docs/cloud/ssl/", opts.Name)
+		} else {
+			table := oktetoLog.NewTable(
+				oktetoLog.Column{Header: "Endpoint", NoTruncate: true},
+				oktetoLog.Column{Header: "Status"},
+			)
+			for _, s := range statuses {
+				table.AddRow(s.URL, strings.Trim(s.Summary(), "()"))
+			}
+			return table.Render(w, "")
+		}
+		return nil
+	}, output.WithJSONIndent("  "))
 }
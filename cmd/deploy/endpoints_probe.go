@@ -0,0 +1,97 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	okHttp "github.com/okteto/okteto/pkg/http"
+	"github.com/okteto/okteto/pkg/okteto"
+)
+
+const endpointProbeTimeout = 5 * time.Second
+
+// EndpointStatus represents the result of probing an endpoint
+type EndpointStatus struct {
+	URL        string `json:"url" yaml:"url"`
+	Error      string `json:"error,omitempty" yaml:"error,omitempty"`
+	StatusCode int    `json:"statusCode,omitempty" yaml:"statusCode,omitempty"`
+	Healthy    bool   `json:"healthy" yaml:"healthy"`
+}
+
+// OutputName returns the url of the endpoint for the 'name' output format
+func (s EndpointStatus) OutputName() string {
+	return s.URL
+}
+
+// newEndpointProbeClient returns the http client used to probe the endpoints
+func newEndpointProbeClient() *http.Client {
+	transport := okHttp.DefaultTransport()
+	if okteto.IsInsecureSkipTLSVerifyPolicy() {
+		transport = okHttp.InsecureTransport()
+	}
+	return &http.Client{
+		Transport: transport,
+		Timeout:   endpointProbeTimeout,
+	}
+}
+
+// probeEndpoints sends a GET request to each endpoint. An endpoint is healthy if it responds with HTTP 200
+func probeEndpoints(ctx context.Context, c *http.Client, eps []string) []EndpointStatus {
+	result := make([]EndpointStatus, len(eps))
+	var wg sync.WaitGroup
+	for i, ep := range eps {
+		wg.Add(1)
+		go func(i int, ep string) {
+			defer wg.Done()
+			result[i] = probeEndpoint(ctx, c, ep)
+		}(i, ep)
+	}
+	wg.Wait()
+	return result
+}
+
+func probeEndpoint(ctx context.Context, c *http.Client, ep string) EndpointStatus {
+	status := EndpointStatus{URL: ep}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ep, nil)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	defer resp.Body.Close()
+	status.StatusCode = resp.StatusCode
+	status.Healthy = resp.StatusCode == http.StatusOK
+	return status
+}
+
+// Summary returns a short description of the result of the probe
+func (s EndpointStatus) Summary() string {
+	switch {
+	case s.Healthy:
+		return "(healthy)"
+	case s.StatusCode != 0:
+		return fmt.Sprintf("(unhealthy: %d %s)", s.StatusCode, http.StatusText(s.StatusCode))
+	default:
+		return "(unreachable)"
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProbeEndpoints(t *testing.T) {
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer healthy.Close()
+	unhealthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer unhealthy.Close()
+
+	statuses := probeEndpoints(context.Background(), healthy.Client(), []string{healthy.URL, unhealthy.URL, "://invalid"})
+	require.Len(t, statuses, 3)
+
+	assert.Equal(t, EndpointStatus{URL: healthy.URL, StatusCode: http.StatusOK, Healthy: true}, statuses[0])
+	assert.Equal(t, "(healthy)", statuses[0].Summary())
+
+	assert.Equal(t, EndpointStatus{URL: unhealthy.URL, StatusCode: http.StatusBadGateway}, statuses[1])
+	assert.Equal(t, "(unhealthy: 502 Bad Gateway)", statuses[1].Summary())
+
+	assert.False(t, statuses[2].Healthy)
+	assert.NotEmpty(t, statuses[2].Error)
+	assert.Equal(t, "(unreachable)", statuses[2].Summary())
+}
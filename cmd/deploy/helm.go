@@ -0,0 +1,47 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"k8s.io/client-go/kubernetes"
+)
+
+// getHelmReleases returns the helm releases installed by the development environment, in install order, so 'okteto destroy' can uninstall them.
+// It returns nil if they can't be detected so the releases already tracked are kept
+func getHelmReleases(ctx context.Context, name string, manifest *model.Manifest, c kubernetes.Interface) []model.HelmRelease {
+	detected, err := pipeline.DetectHelmReleases(ctx, name, manifest.Namespace, c)
+	if err != nil {
+		oktetoLog.Infof("could not detect the helm releases of '%s': %s", name, err)
+		return nil
+	}
+	tracked, err := pipeline.GetHelmReleases(ctx, name, manifest.Namespace, c)
+	if err != nil {
+		oktetoLog.Infof("could not get the helm releases of '%s': %s", name, err)
+		return nil
+	}
+	var declared []model.HelmRelease
+	if manifest.Deploy != nil {
+		declared = manifest.Deploy.HelmReleases
+	}
+	releases := pipeline.MergeHelmReleases(tracked, detected, declared)
+	for _, r := range releases {
+		oktetoLog.Debugf("tracking helm release '%s'", r.Name)
+	}
+	return releases
+}
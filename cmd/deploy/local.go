@@ -24,6 +24,7 @@ import (
 	stackCMD "github.com/okteto/okteto/cmd/stack"
 	"github.com/okteto/okteto/cmd/utils/executor"
 	"github.com/okteto/okteto/pkg/cmd/stack"
+	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/constants"
 	"github.com/okteto/okteto/pkg/devenvironment"
 	"github.com/okteto/okteto/pkg/divert"
@@ -159,7 +160,7 @@ func (ld *localDeployer) deploy(ctx context.Context, deployOptions *Options) err
 		// should not overwrite the server and the credentials in the kubeconfig
 		fmt.Sprintf("%s=true", constants.OktetoSkipConfigCredentialsUpdate),
 		// Set OKTETO_DISABLE_SPINNER=true env variable, so all the Okteto commands disable spinner which leads to errors
-		fmt.Sprintf("%s=true", oktetoLog.OktetoDisableSpinnerEnvVar),
+		fmt.Sprintf("%s=true", config.EnvOktetoDisableSpinner.Name),
 		// Set OKTETO_NAMESPACE=namespace-name env variable, so all the commandsruns on the same namespace
 		fmt.Sprintf("%s=%s", model.OktetoNamespaceEnvVar, okteto.Context().Namespace),
 		// Set OKTETO_AUTODISCOVERY_RELEASE_NAME=sanitized name, so the release name in case of autodiscovery of helm is valid
@@ -173,7 +174,13 @@ func (ld *localDeployer) deploy(ctx context.Context, deployOptions *Options) err
 		)
 	}
 	oktetoLog.EnableMasking()
-	err = ld.runDeploySection(ctx, deployOptions)
+	err = executor.RunHook(ld.Executor, model.PreDeployHook, deployOptions.Manifest.Hooks, deployOptions.Variables)
+	if err == nil {
+		err = ld.runDeploySection(ctx, deployOptions)
+	}
+	if err == nil {
+		err = executor.RunHook(ld.Executor, model.PostDeployHook, deployOptions.Manifest.Hooks, deployOptions.Variables)
+	}
 	oktetoLog.DisableMasking()
 	oktetoLog.SetStage("done")
 	oktetoLog.AddToBuffer(oktetoLog.InfoLevel, "EOF")
@@ -0,0 +1,38 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"github.com/okteto/okteto/pkg/k8s/rbac"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+// getDeployPermissions returns the permissions required to deploy the manifest.
+// The resources created by the deploy commands are unknown, only the ones created by okteto are checked
+func getDeployPermissions(manifest *model.Manifest) []rbac.Permission {
+	readWrite := []string{"get", "create", "update"}
+	result := []rbac.Permission{
+		{Resource: "configmaps", Verbs: readWrite},
+	}
+	if manifest.Deploy != nil && manifest.Deploy.ComposeSection != nil {
+		result = append(result,
+			rbac.Permission{Group: "apps", Resource: "deployments", Verbs: readWrite},
+			rbac.Permission{Group: "apps", Resource: "statefulsets", Verbs: readWrite},
+			rbac.Permission{Group: "batch", Resource: "jobs", Verbs: readWrite},
+			rbac.Permission{Resource: "services", Verbs: readWrite},
+			rbac.Permission{Resource: "persistentvolumeclaims", Verbs: readWrite},
+		)
+	}
+	return result
+}
@@ -0,0 +1,33 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDeployPermissions(t *testing.T) {
+	manifest := &model.Manifest{Deploy: &model.DeployInfo{}}
+	assert.Len(t, getDeployPermissions(manifest), 1)
+
+	manifest.Deploy.ComposeSection = &model.ComposeSectionInfo{}
+	resources := []string{}
+	for _, p := range getDeployPermissions(manifest) {
+		resources = append(resources, p.Resource)
+	}
+	assert.Equal(t, []string{"configmaps", "deployments", "statefulsets", "jobs", "services", "persistentvolumeclaims"}, resources)
+}
@@ -0,0 +1,212 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	"github.com/okteto/okteto/pkg/cmd/stack"
+	"github.com/okteto/okteto/pkg/output"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// planActionExisting is used for resources already deployed that might be modified by the deploy commands
+	planActionExisting = "existing"
+)
+
+// Plan represents what a deploy would do without executing it
+type Plan struct {
+	Name         string         `json:"name" yaml:"name"`
+	Namespace    string         `json:"namespace" yaml:"namespace"`
+	Images       []string       `json:"images" yaml:"images"`
+	Commands     []PlanCommand  `json:"commands" yaml:"commands"`
+	Resources    []PlanResource `json:"resources" yaml:"resources"`
+	Variables    []string       `json:"variables" yaml:"variables"`
+	Dependencies []string       `json:"dependencies" yaml:"dependencies"`
+	External     []string       `json:"external" yaml:"external"`
+	Remote       bool           `json:"remote" yaml:"remote"`
+}
+
+// PlanCommand represents a deploy command that would be executed
+type PlanCommand struct {
+	Name    string `json:"name" yaml:"name"`
+	Command string `json:"command" yaml:"command"`
+}
+
+// PlanResource represents a k8s resource affected by the deploy
+type PlanResource struct {
+	Kind    string   `json:"kind" yaml:"kind"`
+	Name    string   `json:"name" yaml:"name"`
+	Action  string   `json:"action" yaml:"action"`
+	Changes []string `json:"changes,omitempty" yaml:"changes,omitempty"`
+}
+
+// getPlan computes the plan of the deploy without building images or running any command
+func (dc *DeployCommand) getPlan(ctx context.Context, opts *Options, c kubernetes.Interface) (*Plan, error) {
+	manifest := opts.Manifest
+	plan := &Plan{
+		Name:         opts.Name,
+		Namespace:    manifest.Namespace,
+		Images:       []string{},
+		Commands:     []PlanCommand{},
+		Resources:    []PlanResource{},
+		Variables:    []string{},
+		Dependencies: []string{},
+		External:     []string{},
+		Remote:       shouldRunInRemote(opts),
+	}
+
+	if manifest.Deploy != nil {
+		images := setToSlice(getServicesToBuildSet(opts))
+		if !opts.Build {
+			var err error
+			images, err = dc.Builder.GetServicesToBuild(ctx, manifest, images)
+			if err != nil {
+				return nil, err
+			}
+		}
+		plan.Images = append(plan.Images, images...)
+
+		for _, cmd := range manifest.Deploy.Commands {
+			plan.Commands = append(plan.Commands, PlanCommand{Name: cmd.Name, Command: cmd.Command})
+		}
+	}
+
+	resources, err := getPlanResources(ctx, opts, c)
+	if err != nil {
+		return nil, err
+	}
+	plan.Resources = resources
+
+	// only the names of the variables are included: values might contain secrets
+	for _, v := range opts.Variables {
+		name, _, _ := strings.Cut(v, "=")
+		plan.Variables = append(plan.Variables, name)
+	}
+	for name := range manifest.Dependencies {
+		plan.Dependencies = append(plan.Dependencies, name)
+	}
+	for name := range manifest.External {
+		plan.External = append(plan.External, name)
+	}
+
+	sort.Strings(plan.Images)
+	sort.Strings(plan.Variables)
+	sort.Strings(plan.Dependencies)
+	sort.Strings(plan.External)
+	return plan, nil
+}
+
+// getPlanResources returns the result of the server-side dry run of the compose services and the workloads already deployed by the dev environment
+func getPlanResources(ctx context.Context, opts *Options, c kubernetes.Interface) ([]PlanResource, error) {
+	result := []PlanResource{}
+	planned := map[string]bool{}
+	ns := opts.Manifest.Namespace
+
+	if manifestStack := opts.Manifest.GetStack(); manifestStack != nil {
+		s := *manifestStack
+		if s.Namespace == "" {
+			s.Namespace = ns
+		}
+		for _, svcName := range opts.servicesToDeploy {
+			if _, ok := s.Services[svcName]; !ok {
+				continue
+			}
+			resources, err := stack.DryRunService(ctx, svcName, &s, c)
+			if err != nil {
+				return nil, err
+			}
+			for _, r := range resources {
+				planned[r.Kind+"/"+r.Name] = true
+				result = append(result, PlanResource{Kind: r.Kind, Name: r.Name, Action: r.Action, Changes: r.Changes})
+			}
+		}
+	}
+
+	dList, err := pipeline.ListDeployments(ctx, opts.Name, ns, c)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range dList {
+		if !planned["Deployment/"+d.Name] {
+			result = append(result, PlanResource{Kind: "Deployment", Name: d.Name, Action: planActionExisting})
+		}
+	}
+
+	sfsList, err := pipeline.ListStatefulsets(ctx, opts.Name, ns, c)
+	if err != nil {
+		return nil, err
+	}
+	for _, sfs := range sfsList {
+		if !planned["StatefulSet/"+sfs.Name] {
+			result = append(result, PlanResource{Kind: "StatefulSet", Name: sfs.Name, Action: planActionExisting})
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Kind != result[j].Kind {
+			return result[i].Kind < result[j].Kind
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// printPlan writes the plan in the given output format
+func printPlan(plan *Plan, outputFormat string, w io.Writer) error {
+	return output.Print(w, outputFormat, plan, func(w io.Writer) error {
+		fmt.Fprintf(w, "Deploy plan for '%s' in namespace '%s'\n", plan.Name, plan.Namespace)
+		if plan.Remote {
+			fmt.Fprintln(w, "Deploy commands run in remote")
+		}
+		fmt.Fprintf(w, "\nImages to build: %s\n", joinOrDash(plan.Images))
+		fmt.Fprintf(w, "Dependencies: %s\n", joinOrDash(plan.Dependencies))
+		fmt.Fprintf(w, "Variables: %s\n", joinOrDash(plan.Variables))
+		fmt.Fprintf(w, "External resources: %s\n", joinOrDash(plan.External))
+
+		if len(plan.Commands) > 0 {
+			fmt.Fprintln(w, "\nCommands:")
+			for _, cmd := range plan.Commands {
+				fmt.Fprintf(w, "  - %s\n", cmd.Name)
+			}
+		}
+
+		if len(plan.Resources) > 0 {
+			fmt.Fprintln(w, "")
+			tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+			fmt.Fprintln(tw, "Kind\tName\tAction\tChanges")
+			for _, r := range plan.Resources {
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", r.Kind, r.Name, r.Action, joinOrDash(r.Changes))
+			}
+			if err := tw.Flush(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func joinOrDash(values []string) string {
+	if len(values) == 0 {
+		return "-"
+	}
+	return strings.Join(values, ", ")
+}
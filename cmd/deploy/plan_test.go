@@ -0,0 +1,148 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/cmd/stack"
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/deps"
+	"github.com/okteto/okteto/pkg/externalresource"
+	"github.com/okteto/okteto/pkg/format"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetPlan(t *testing.T) {
+	t.Setenv(constants.OktetoForceRemote, "false")
+	c := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "api",
+				Namespace: "test",
+			},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "worker",
+				Namespace: "test",
+				Labels: map[string]string{
+					model.DeployedByLabel: format.ResourceK8sMetaString("movies"),
+				},
+			},
+		},
+	)
+	opts := &Options{
+		Name:      "movies",
+		Variables: []string{"TOKEN=secret", "REGION=eu"},
+		Manifest: &model.Manifest{
+			Namespace: "test",
+			Build: build.ManifestBuild{
+				"api": &build.Info{},
+			},
+			Deploy: &model.DeployInfo{
+				Commands: []model.DeployCommand{
+					{Name: "helm", Command: "helm upgrade --install movies chart"},
+				},
+				ComposeSection: &model.ComposeSectionInfo{
+					Stack: &model.Stack{
+						Services: map[string]*model.Service{
+							"api": {
+								RestartPolicy: apiv1.RestartPolicyAlways,
+								Resources:     &model.StackResources{},
+							},
+							"db": {
+								RestartPolicy: apiv1.RestartPolicyAlways,
+								Volumes:       []build.VolumeMounts{{RemotePath: "/data"}},
+								Resources:     &model.StackResources{},
+							},
+							"migrate": {
+								RestartPolicy: apiv1.RestartPolicyNever,
+								Resources:     &model.StackResources{},
+							},
+						},
+					},
+				},
+			},
+			Dependencies: deps.ManifestSection{
+				"frontend": &deps.Dependency{},
+			},
+			External: externalresource.ExternalResourceSection{
+				"docs": &externalresource.ExternalResource{},
+			},
+		},
+		servicesToDeploy: []string{"api", "db", "migrate"},
+	}
+	dc := &DeployCommand{
+		Builder: &fakeV2Builder{},
+	}
+
+	plan, err := dc.getPlan(context.Background(), opts, c)
+	require.NoError(t, err)
+
+	expected := &Plan{
+		Name:      "movies",
+		Namespace: "test",
+		Images:    []string{"api"},
+		Commands: []PlanCommand{
+			{Name: "helm", Command: "helm upgrade --install movies chart"},
+		},
+		Resources: []PlanResource{
+			{
+				Kind:   "Deployment",
+				Name:   "api",
+				Action: stack.PlanActionUpdate,
+				Changes: []string{
+					"metadata.labels",
+					"spec.replicas",
+					"spec.selector",
+					"spec.strategy.type",
+					"spec.template.metadata.labels",
+					"spec.template.spec.containers",
+					"spec.template.spec.terminationGracePeriodSeconds",
+				},
+			},
+			{Kind: "Deployment", Name: "worker", Action: planActionExisting},
+			{Kind: "Job", Name: "migrate", Action: stack.PlanActionCreate},
+			{Kind: "StatefulSet", Name: "db", Action: stack.PlanActionCreate},
+		},
+		Variables:    []string{"REGION", "TOKEN"},
+		Dependencies: []string{"frontend"},
+		External:     []string{"docs"},
+	}
+	assert.Equal(t, expected, plan)
+
+	var b bytes.Buffer
+	require.NoError(t, printPlan(plan, "json", &b))
+	assert.NotContains(t, b.String(), "secret")
+	result := &Plan{}
+	require.NoError(t, json.Unmarshal(b.Bytes(), result))
+	assert.Equal(t, expected, result)
+
+	b.Reset()
+	require.NoError(t, printPlan(plan, "", &b))
+	assert.Contains(t, b.String(), "Images to build: api")
+
+	assert.Error(t, printPlan(plan, "xml", &b))
+}
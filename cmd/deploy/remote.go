@@ -182,6 +182,9 @@ func (rd *remoteDeployCommand) deploy(ctx context.Context, deployOptions *Option
 
 	buildOptions := buildCmd.OptsFromBuildInfoForRemoteDeploy(buildInfo, &types.BuildOptions{OutputMode: "deploy"})
 	buildOptions.Manifest = deployOptions.Manifest
+	if deployOptions.Manifest != nil && deployOptions.Manifest.Deploy != nil {
+		buildOptions.RemoteDeploy = deployOptions.Manifest.Deploy.Remote
+	}
 	buildOptions.BuildArgs = append(
 		buildOptions.BuildArgs,
 		fmt.Sprintf("%s=%s", model.OktetoContextEnvVar, okteto.Context().Name),
@@ -189,9 +192,9 @@ func (rd *remoteDeployCommand) deploy(ctx context.Context, deployOptions *Option
 		fmt.Sprintf("%s=%s", model.OktetoTokenEnvVar, okteto.Context().Token),
 		fmt.Sprintf("%s=%s", constants.OktetoTlsCertBase64EnvVar, base64.StdEncoding.EncodeToString(sc.Certificate)),
 		fmt.Sprintf("%s=%s", constants.OktetoInternalServerNameEnvVar, sc.ServerName),
-		fmt.Sprintf("%s=%s", model.OktetoActionNameEnvVar, os.Getenv(model.OktetoActionNameEnvVar)),
-		fmt.Sprintf("%s=%s", constants.OktetoGitCommitEnvVar, os.Getenv(constants.OktetoGitCommitEnvVar)),
-		fmt.Sprintf("%s=%s", constants.OktetoGitBranchEnvVar, os.Getenv(constants.OktetoGitBranchEnvVar)),
+		fmt.Sprintf("%s=%s", model.OktetoActionNameEnvVar, config.EnvOktetoActionName.Value()),
+		fmt.Sprintf("%s=%s", constants.OktetoGitCommitEnvVar, config.EnvOktetoGitCommit.Value()),
+		fmt.Sprintf("%s=%s", constants.OktetoGitBranchEnvVar, config.EnvOktetoGitBranch.Value()),
 		fmt.Sprintf("%s=%d", constants.OktetoInvalidateCacheEnvVar, int(randomNumber.Int64())),
 	)
 
@@ -368,7 +371,7 @@ func getOktetoCLIVersion(versionString string) string {
 		version = fmt.Sprintf(constants.OktetoCLIImageForRemoteTemplate, versionString)
 	} else {
 		oktetoLog.Infof("invalid version string: %s, using latest: %s", versionString, err)
-		remoteOktetoImage := os.Getenv(constants.OktetoDeployRemoteImage)
+		remoteOktetoImage := config.EnvOktetoRemoteCLIImage.Value()
 		if remoteOktetoImage != "" {
 			version = remoteOktetoImage
 		} else {
@@ -31,6 +31,7 @@ import (
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
 )
 
 type fakeV1Builder struct {
@@ -609,3 +610,36 @@ func TestGetExtraHosts(t *testing.T) {
 		})
 	}
 }
+
+func TestRemoteDeployRunnerSettings(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	remoteDeploy := &model.RemoteDeploy{
+		Enabled:        true,
+		ServiceAccount: "deployer",
+		Tolerations:    []apiv1.Toleration{{Key: "pool", Operator: apiv1.TolerationOpEqual, Value: "ci", Effect: apiv1.TaintEffectNoSchedule}},
+	}
+	rdc := remoteDeployCommand{
+		getBuildEnvVars: func() map[string]string { return nil },
+		builderV1: fakeV1Builder{
+			assertOptions: func(o *types.BuildOptions) {
+				assert.Equal(t, remoteDeploy, o.RemoteDeploy)
+			},
+		},
+		fs:                   fs,
+		workingDirectoryCtrl: filesystem.NewFakeWorkingDirectoryCtrl(filepath.Clean("/")),
+		temporalCtrl:         filesystem.NewTemporalDirectoryCtrl(fs),
+		clusterMetadata: func(context.Context) (*types.ClusterMetadata, error) {
+			return &types.ClusterMetadata{}, nil
+		},
+	}
+
+	err := rdc.deploy(context.Background(), &Options{
+		Manifest: &model.Manifest{
+			Deploy: &model.DeployInfo{
+				Image:  "test-image",
+				Remote: remoteDeploy,
+			},
+		},
+	})
+	assert.NoError(t, err)
+}
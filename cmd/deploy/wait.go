@@ -15,6 +15,7 @@ package deploy
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
@@ -26,6 +27,7 @@ import (
 	"github.com/okteto/okteto/pkg/k8s/statefulsets"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/wait"
 )
 
 type DeployWaiter struct {
@@ -39,10 +41,6 @@ func NewDeployWaiter(k8sClientProvider okteto.K8sClientProvider) DeployWaiter {
 }
 
 func (dw *DeployWaiter) wait(ctx context.Context, opts *Options) error {
-	oktetoLog.Spinner(fmt.Sprintf("Waiting for %s to be deployed...", opts.Name))
-	oktetoLog.StartSpinner()
-	defer oktetoLog.StopSpinner()
-
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt)
 	exit := make(chan error, 1)
@@ -52,6 +50,7 @@ func (dw *DeployWaiter) wait(ctx context.Context, opts *Options) error {
 	select {
 	case <-stop:
 		oktetoLog.Infof("CTRL+C received, starting shutdown sequence")
+		oktetoLog.StopSpinner()
 		return oktetoErrors.ErrIntSig
 	case err := <-exit:
 		if err != nil {
@@ -64,46 +63,44 @@ func (dw *DeployWaiter) wait(ctx context.Context, opts *Options) error {
 }
 
 func (dw *DeployWaiter) waitForResourcesToBeRunning(ctx context.Context, opts *Options) error {
-	ticker := time.NewTicker(5 * time.Second)
-	to := time.NewTicker(opts.Timeout)
 	c, _, err := dw.K8sClientProvider.Provide(okteto.Context().Cfg)
 	if err != nil {
 		return err
 	}
 
-	for {
-		select {
-		case <-to.C:
-			return fmt.Errorf("'%s' deploy didn't finish after %s", opts.Manifest.Name, opts.Timeout.String())
-		case <-ticker.C:
-			dList, err := pipeline.ListDeployments(ctx, opts.Manifest.Name, opts.Manifest.Namespace, c)
-			if err != nil {
-				return err
-			}
-			areAllRunning := true
-			for i := range dList {
-				d := &dList[i]
-				if !deployments.IsRunning(ctx, opts.Manifest.Namespace, d.Name, c) {
-					areAllRunning = false
-				}
-			}
-			if !areAllRunning {
-				continue
-			}
-			sfsList, err := pipeline.ListStatefulsets(ctx, opts.Manifest.Name, opts.Manifest.Namespace, c)
-			if err != nil {
-				return err
-			}
-			for i := range sfsList {
-				ss := &sfsList[i]
-				if !statefulsets.IsRunning(ctx, opts.Manifest.Namespace, ss.Name, c) {
-					areAllRunning = false
-				}
+	err = wait.WaitFor(ctx, func(ctx context.Context) (bool, wait.Status, error) {
+		status := wait.Status{Message: "resources running"}
+		dList, err := pipeline.ListDeployments(ctx, opts.Manifest.Name, opts.Manifest.Namespace, c)
+		if err != nil {
+			return false, status, err
+		}
+		for i := range dList {
+			status.Total++
+			if deployments.IsRunning(ctx, opts.Manifest.Namespace, dList[i].Name, c) {
+				status.Completed++
 			}
-			if !areAllRunning {
-				continue
+		}
+		sfsList, err := pipeline.ListStatefulsets(ctx, opts.Manifest.Name, opts.Manifest.Namespace, c)
+		if err != nil {
+			return false, status, err
+		}
+		for i := range sfsList {
+			status.Total++
+			if statefulsets.IsRunning(ctx, opts.Manifest.Namespace, sfsList[i].Name, c) {
+				status.Completed++
 			}
-			return nil
 		}
+		return status.Completed == status.Total, status, nil
+	}, wait.Options{
+		Interval: 5 * time.Second,
+		Timeout:  opts.Timeout,
+		Progress: wait.NewMultiProgress(
+			wait.NewPercentageProgress(fmt.Sprintf("Waiting for %s to be deployed", opts.Name)),
+			wait.NewEventsProgress(c, opts.Manifest.Namespace),
+		),
+	})
+	if errors.Is(err, wait.ErrTimeout) {
+		return fmt.Errorf("'%s' deploy didn't finish: %w", opts.Manifest.Name, err)
 	}
+	return err
 }
@@ -17,10 +17,10 @@ import (
 	"context"
 
 	"github.com/okteto/okteto/pkg/cmd/pipeline"
-	"github.com/okteto/okteto/pkg/constants"
-	"github.com/okteto/okteto/pkg/env"
+	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/k8s/configmaps"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
 )
@@ -32,6 +32,7 @@ type configMapHandler interface {
 	destroyConfigMap(context.Context, *apiv1.ConfigMap, string) error
 	setErrorStatus(context.Context, *apiv1.ConfigMap, *pipeline.CfgData, error) error
 	getConfigmapVariablesEncoded(ctx context.Context, name, namespace string) (string, error)
+	getHelmReleases(ctx context.Context, name, namespace string) ([]model.HelmRelease, error)
 }
 
 // destroyInsideDeployConfigMapHandler is the runner used when the okteto is executed
@@ -55,7 +56,7 @@ func newDefaultConfigMapHandler(c kubernetes.Interface) *defaultConfigMapHandler
 }
 
 func NewConfigmapHandler(c kubernetes.Interface) configMapHandler {
-	if env.LoadBoolean(constants.OktetoWithinDeployCommandContextEnvVar) {
+	if config.EnvOktetoWithinDeployCommandContext.IsTrue() {
 		return newDestroyInsideDeployConfigMapHandler()
 	}
 	return newDefaultConfigMapHandler(c)
@@ -69,6 +70,10 @@ func (ch *defaultConfigMapHandler) getConfigmapVariablesEncoded(ctx context.Cont
 	return pipeline.GetConfigmapVariablesEncoded(ctx, name, namespace, ch.k8sClient)
 }
 
+func (ch *defaultConfigMapHandler) getHelmReleases(ctx context.Context, name, namespace string) ([]model.HelmRelease, error) {
+	return pipeline.GetHelmReleases(ctx, name, namespace, ch.k8sClient)
+}
+
 func (ch *defaultConfigMapHandler) destroyConfigMap(ctx context.Context, cfg *apiv1.ConfigMap, namespace string) error {
 	return configmaps.Destroy(ctx, cfg.Name, namespace, ch.k8sClient)
 }
@@ -87,6 +92,10 @@ func (ch *destroyInsideDeployConfigMapHandler) getConfigmapVariablesEncoded(_ co
 	return "", nil
 }
 
+func (*destroyInsideDeployConfigMapHandler) getHelmReleases(_ context.Context, _, _ string) ([]model.HelmRelease, error) {
+	return nil, nil
+}
+
 func (*destroyInsideDeployConfigMapHandler) destroyConfigMap(_ context.Context, _ *apiv1.ConfigMap, _ string) error {
 	return nil
 }
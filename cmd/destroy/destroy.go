@@ -25,7 +25,6 @@ import (
 	"github.com/okteto/okteto/cmd/utils/executor"
 	"github.com/okteto/okteto/pkg/analytics"
 	"github.com/okteto/okteto/pkg/config"
-	"github.com/okteto/okteto/pkg/constants"
 	"github.com/okteto/okteto/pkg/devenvironment"
 	"github.com/okteto/okteto/pkg/env"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
@@ -36,6 +35,7 @@ import (
 	"github.com/okteto/okteto/pkg/log/io"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/output"
 	oktetoPath "github.com/okteto/okteto/pkg/path"
 	"github.com/spf13/cobra"
 	v1 "k8s.io/api/core/v1"
@@ -51,6 +51,8 @@ const (
 type destroyer interface {
 	DestroyWithLabel(ctx context.Context, ns string, opts namespaces.DeleteAllOptions) error
 	DestroySFSVolumes(ctx context.Context, ns string, opts namespaces.DeleteAllOptions) error
+	ListWithLabel(ctx context.Context, ns string, opts namespaces.DeleteAllOptions) ([]namespaces.Resource, error)
+	ListSFSVolumes(ctx context.Context, ns string, opts namespaces.DeleteAllOptions) ([]string, error)
 }
 
 type secretHandler interface {
@@ -76,6 +78,10 @@ type Options struct {
 	RunWithoutBash      bool
 	DestroyAll          bool
 	RunInRemote         bool
+	// DryRun lists the resources that would be destroyed without destroying them
+	DryRun bool
+	// Output is the format of the resources listed by DryRun
+	Output string
 }
 
 type destroyInterface interface {
@@ -112,6 +118,23 @@ func Destroy(ctx context.Context, at analyticsTrackerInterface, ioCtrl *io.IOCon
 		Long:  `Destroy everything created by the 'okteto deploy' command. You can also include a 'destroy' section in your okteto manifest with a list of custom commands to be executed on destroy`,
 		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#destroy"),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if options.Output != "" && !options.DryRun {
+				return oktetoErrors.UserError{
+					E:    fmt.Errorf("the flag '--output' is only supported with '--dry-run'"),
+					Hint: "Run 'okteto destroy --dry-run --output json'",
+				}
+			}
+			if options.Output != "" {
+				// logs would break the json output
+				oktetoLog.SetOutputFormat(oktetoLog.SilentFormat)
+			}
+			if options.ManifestPath == "" {
+				manifestPath, err := utils.DiscoverManifestPath()
+				if err != nil {
+					return err
+				}
+				options.ManifestPath = manifestPath
+			}
 			if options.ManifestPath != "" {
 				// if path is absolute, its transformed to rel from root
 				initialCWD, err := os.Getwd()
@@ -195,6 +218,10 @@ func Destroy(ctx context.Context, at analyticsTrackerInterface, ioCtrl *io.IOCon
 				ioCtrl:            ioCtrl,
 			}
 
+			if options.DryRun {
+				return c.dryRun(ctx, options, os.Stdout)
+			}
+
 			kubeconfigPath := getTempKubeConfigFile(options.Name)
 			if err := kubeconfig.Write(okteto.Context().Cfg, kubeconfigPath); err != nil {
 				return err
@@ -232,6 +259,8 @@ func Destroy(ctx context.Context, at analyticsTrackerInterface, ioCtrl *io.IOCon
 	cmd.Flags().BoolVarP(&options.RunWithoutBash, "no-bash", "", false, "execute commands without bash")
 	cmd.Flags().BoolVarP(&options.DestroyAll, "all", "", false, "destroy everything in the namespace")
 	cmd.Flags().BoolVarP(&options.RunInRemote, "remote", "", false, "force run destroy commands in remote")
+	cmd.Flags().BoolVar(&options.DryRun, "dry-run", false, "list the resources that would be destroyed without destroying them")
+	output.AddFlag(cmd, &options.Output)
 
 	return cmd
 }
@@ -243,7 +272,7 @@ func getTempKubeConfigFile(name string) string {
 
 func shouldRunInRemote(opts *Options) bool {
 	// already in remote so we need to deploy locally
-	if env.LoadBoolean(constants.OktetoDeployRemote) {
+	if config.EnvOktetoDeployRemote.IsTrue() {
 		return false
 	}
 
@@ -259,7 +288,7 @@ func shouldRunInRemote(opts *Options) bool {
 		}
 	}
 
-	if env.LoadBoolean(constants.OktetoForceRemote) {
+	if config.EnvOktetoForceRemote.IsTrue() {
 		return true
 	}
 
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/okteto/okteto/internal/test"
 	"github.com/okteto/okteto/pkg/cmd/pipeline"
@@ -55,6 +56,8 @@ var fakeManifest *model.Manifest = &model.Manifest{
 type fakeDestroyer struct {
 	err              error
 	errOnVolumes     error
+	resources        []namespaces.Resource
+	volumes          []string
 	destroyed        bool
 	destroyedVolumes bool
 }
@@ -87,6 +90,17 @@ func (fd *fakeDestroyer) DestroySFSVolumes(_ context.Context, _ string, _ namesp
 	return nil
 }
 
+func (fd *fakeDestroyer) ListWithLabel(_ context.Context, _ string, _ namespaces.DeleteAllOptions) ([]namespaces.Resource, error) {
+	return fd.resources, fd.err
+}
+
+func (fd *fakeDestroyer) ListSFSVolumes(_ context.Context, _ string, opts namespaces.DeleteAllOptions) ([]string, error) {
+	if !opts.IncludeVolumes {
+		return []string{}, fd.errOnVolumes
+	}
+	return fd.volumes, fd.errOnVolumes
+}
+
 func (fd *fakeSecretHandler) List(_ context.Context, _, _ string) ([]v1.Secret, error) {
 	if fd.err != nil {
 		return nil, fd.err
@@ -1083,3 +1097,56 @@ func TestShouldRunInRemoteDestroy(t *testing.T) {
 		})
 	}
 }
+
+func TestGetHelmReleasesUninstallOrder(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	helmSecret := func(release string, created time.Time) v1.Secret {
+		return v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				CreationTimestamp: metav1.NewTime(created),
+				Labels: map[string]string{
+					ownerLabel: helmOwner,
+					nameLabel:  release,
+				},
+			},
+			Type: model.HelmSecretType,
+		}
+	}
+	k8sClientProvider := test.NewFakeK8sProvider()
+	fakeClient, _, err := k8sClientProvider.Provide(api.NewConfig())
+	assert.NoError(t, err)
+	_, err = pipeline.TranslateConfigMapAndDeploy(ctx, &pipeline.CfgData{
+		Name:         "test-app",
+		Namespace:    "test",
+		Status:       pipeline.DeployedStatus,
+		HelmReleases: []model.HelmRelease{{Name: "db"}, {Name: "api"}},
+	}, fakeClient)
+	assert.NoError(t, err)
+
+	secrets := &fakeSecretHandler{
+		secrets: []v1.Secret{
+			helmSecret("api", now),
+			helmSecret("db", now.Add(time.Minute)),
+			helmSecret("frontend", now.Add(-time.Minute)),
+		},
+	}
+	manifest := &model.Manifest{
+		Deploy: &model.DeployInfo{
+			HelmReleases: []model.HelmRelease{{Name: "db", Timeout: 10 * time.Minute}},
+		},
+	}
+
+	releases, err := getHelmReleases(ctx, NewConfigmapHandler(fakeClient), secrets, manifest, "test-app", "test", "")
+	assert.NoError(t, err)
+	assert.Equal(t, []model.HelmRelease{
+		{Name: "frontend"},
+		{Name: "api"},
+		{Name: "db", Timeout: 10 * time.Minute},
+	}, releases)
+}
+
+func TestGetHelmUninstallCommand(t *testing.T) {
+	assert.Equal(t, "helm uninstall db", getHelmUninstallCommand(model.HelmRelease{Name: "db"}))
+	assert.Equal(t, "helm uninstall db --namespace data --timeout 10m0s", getHelmUninstallCommand(model.HelmRelease{Name: "db", Namespace: "data", Timeout: 10 * time.Minute}))
+}
@@ -0,0 +1,184 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destroy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	externalresourceK8s "github.com/okteto/okteto/pkg/externalresource/k8s"
+	"github.com/okteto/okteto/pkg/k8s/namespaces"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/output"
+)
+
+const pvcKind = "PersistentVolumeClaim"
+
+// destroyPlan represents everything 'okteto destroy' would destroy with the given options
+type destroyPlan struct {
+	Name              string                `json:"name,omitempty" yaml:"name,omitempty"`
+	Namespace         string                `json:"namespace" yaml:"namespace"`
+	Commands          []string              `json:"commands" yaml:"commands"`
+	Dependencies      []string              `json:"dependencies" yaml:"dependencies"`
+	HelmReleases      []string              `json:"helmReleases" yaml:"helmReleases"`
+	Resources         []namespaces.Resource `json:"resources" yaml:"resources"`
+	ExternalResources []string              `json:"externalResources" yaml:"externalResources"`
+	Volumes           []string              `json:"volumes" yaml:"volumes"`
+	All               bool                  `json:"all" yaml:"all"`
+	IncludeVolumes    bool                  `json:"includeVolumes" yaml:"includeVolumes"`
+}
+
+// dryRun prints what would be destroyed without destroying anything
+func (dc *destroyCommand) dryRun(ctx context.Context, opts *Options, w io.Writer) error {
+	plan, err := dc.getDestroyPlan(ctx, opts)
+	if err != nil {
+		return err
+	}
+	return printDestroyPlan(plan, opts.Output, w)
+}
+
+// getDestroyPlan queries the same resources the destroyers delete
+func (dc *destroyCommand) getDestroyPlan(ctx context.Context, opts *Options) (*destroyPlan, error) {
+	plan := &destroyPlan{
+		Name:              opts.Name,
+		Namespace:         opts.Namespace,
+		All:               opts.DestroyAll,
+		IncludeVolumes:    opts.DestroyVolumes,
+		Commands:          []string{},
+		Dependencies:      []string{},
+		HelmReleases:      []string{},
+		Resources:         []namespaces.Resource{},
+		ExternalResources: []string{},
+		Volumes:           []string{},
+	}
+	deleteOpts := namespaces.DeleteAllOptions{
+		IncludeVolumes: opts.DestroyVolumes,
+	}
+
+	if opts.DestroyAll && !okteto.Context().IsOkteto {
+		return nil, oktetoErrors.ErrContextIsNotOktetoCluster
+	}
+
+	if !opts.DestroyAll {
+		manifest, err := dc.getManifest(opts.ManifestPath)
+		if err != nil {
+			oktetoLog.Infof("could not find manifest file to be executed: %s", err)
+			manifest = &model.Manifest{}
+		}
+		for _, command := range manifest.Hooks.GetCommands(model.PreDestroyHook) {
+			plan.Commands = append(plan.Commands, command.Name)
+		}
+		if manifest.Destroy != nil {
+			for _, command := range manifest.Destroy.Commands {
+				plan.Commands = append(plan.Commands, command.Name)
+			}
+		}
+		if opts.DestroyDependencies {
+			for name := range manifest.Dependencies {
+				plan.Dependencies = append(plan.Dependencies, name)
+			}
+			sort.Strings(plan.Dependencies)
+		}
+
+		deleteOpts.LabelSelector, err = getDeployedBySelector(opts.Name)
+		if err != nil {
+			return nil, err
+		}
+		releases, err := getHelmReleases(ctx, dc.ConfigMapHandler, dc.secrets, manifest, opts.Name, opts.Namespace, deleteOpts.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the helm releases: %w", err)
+		}
+		for _, r := range releases {
+			plan.HelmReleases = append(plan.HelmReleases, r.Name)
+		}
+		plan.Volumes, err = dc.nsDestroyer.ListSFSVolumes(ctx, opts.Namespace, deleteOpts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resources, err := dc.nsDestroyer.ListWithLabel(ctx, opts.Namespace, deleteOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the resources of namespace '%s': %w", opts.Namespace, err)
+	}
+	for _, r := range resources {
+		switch r.Kind {
+		case externalresourceK8s.ExternalResourceKind:
+			plan.ExternalResources = append(plan.ExternalResources, r.Name)
+		case pvcKind:
+			plan.Volumes = append(plan.Volumes, r.Name)
+		default:
+			plan.Resources = append(plan.Resources, r)
+		}
+	}
+	sort.Strings(plan.Volumes)
+	return plan, nil
+}
+
+func printDestroyPlan(plan *destroyPlan, outputFormat string, w io.Writer) error {
+	return output.Print(w, outputFormat, plan, func(w io.Writer) error {
+		printDestroyPlanSummary(w, plan)
+		return nil
+	}, output.WithJSONIndent("  "))
+}
+
+// printDestroyPlanSummary prints the sections of the destroy plan that are not empty
+func printDestroyPlanSummary(w io.Writer, plan *destroyPlan) {
+	switch {
+	case plan.All:
+		fmt.Fprintf(w, "Dry run: 'okteto destroy --all' would destroy everything in namespace '%s'\n", plan.Namespace)
+	case plan.Name != "":
+		fmt.Fprintf(w, "Dry run: 'okteto destroy' would destroy the development environment '%s' in namespace '%s'\n", plan.Name, plan.Namespace)
+	default:
+		fmt.Fprintf(w, "Dry run: 'okteto destroy' would destroy the development environment in namespace '%s'\n", plan.Namespace)
+	}
+
+	resources := make([]string, 0, len(plan.Resources))
+	for _, r := range plan.Resources {
+		resources = append(resources, fmt.Sprintf("%s/%s", r.Kind, r.Name))
+	}
+	sections := []struct {
+		title  string
+		values []string
+	}{
+		{title: "Commands", values: plan.Commands},
+		{title: "Dependencies", values: plan.Dependencies},
+		{title: "Helm releases", values: plan.HelmReleases},
+		{title: "Resources", values: resources},
+		{title: "External resources", values: plan.ExternalResources},
+		{title: "Volumes", values: plan.Volumes},
+	}
+	empty := true
+	for _, section := range sections {
+		if len(section.values) == 0 {
+			continue
+		}
+		empty = false
+		fmt.Fprintf(w, "\n%s:\n", section.title)
+		for _, v := range section.values {
+			fmt.Fprintf(w, "  - %s\n", v)
+		}
+	}
+	if empty {
+		fmt.Fprintln(w, "\nNothing to destroy")
+	}
+	if !plan.IncludeVolumes {
+		fmt.Fprintln(w, "\nVolumes are kept. Run with '--volumes' to destroy them")
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destroy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/deps"
+	"github.com/okteto/okteto/pkg/k8s/namespaces"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetDestroyPlan(t *testing.T) {
+	manifest := &model.Manifest{
+		Hooks: &model.Hooks{
+			PreDestroy: []model.DeployCommand{{Name: "backup", Command: "backup.sh"}},
+		},
+		Destroy: &model.DestroyInfo{
+			Commands: []model.DeployCommand{{Name: "cleanup", Command: "cleanup.sh"}},
+		},
+		Dependencies: deps.ManifestSection{
+			"db":  &deps.Dependency{},
+			"api": &deps.Dependency{},
+		},
+	}
+	dc := &destroyCommand{
+		ConfigMapHandler: NewConfigmapHandler(fake.NewSimpleClientset()),
+		getManifest:      func(string) (*model.Manifest, error) { return manifest, nil },
+		nsDestroyer: &fakeDestroyer{
+			resources: []namespaces.Resource{
+				{Kind: "Deployment", Name: "api"},
+				{Kind: "External", Name: "docs"},
+				{Kind: "PersistentVolumeClaim", Name: "data"},
+			},
+			volumes: []string{"cache-db-0"},
+		},
+		secrets: &fakeSecretHandler{
+			secrets: []v1.Secret{
+				{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{ownerLabel: helmOwner, nameLabel: "movies"}},
+					Type:       model.HelmSecretType,
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{ownerLabel: helmOwner, nameLabel: "movies"}},
+					Type:       model.HelmSecretType,
+				},
+			},
+		},
+	}
+
+	plan, err := dc.getDestroyPlan(context.Background(), &Options{
+		Name:                "movies",
+		Namespace:           "cindy",
+		DestroyVolumes:      true,
+		DestroyDependencies: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &destroyPlan{
+		Name:              "movies",
+		Namespace:         "cindy",
+		IncludeVolumes:    true,
+		Commands:          []string{"backup", "cleanup"},
+		Dependencies:      []string{"api", "db"},
+		HelmReleases:      []string{"movies"},
+		Resources:         []namespaces.Resource{{Kind: "Deployment", Name: "api"}},
+		ExternalResources: []string{"docs"},
+		Volumes:           []string{"cache-db-0", "data"},
+	}, plan)
+}
+
+func TestGetDestroyPlanAllRequiresOktetoContext(t *testing.T) {
+	dc := &destroyCommand{nsDestroyer: &fakeDestroyer{}}
+	current := okteto.CurrentStore
+	t.Cleanup(func() {
+		okteto.CurrentStore = current
+	})
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		CurrentContext: "test",
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {Name: "test", Namespace: "namespace", IsOkteto: false},
+		},
+	}
+
+	_, err := dc.getDestroyPlan(context.Background(), &Options{Namespace: "namespace", DestroyAll: true})
+	assert.Error(t, err)
+}
+
+func TestPrintDestroyPlan(t *testing.T) {
+	plan := &destroyPlan{
+		Name:              "movies",
+		Namespace:         "cindy",
+		Commands:          []string{"cleanup"},
+		Dependencies:      []string{},
+		HelmReleases:      []string{"movies"},
+		Resources:         []namespaces.Resource{{Kind: "Deployment", Name: "api"}},
+		ExternalResources: []string{},
+		Volumes:           []string{},
+	}
+
+	var b bytes.Buffer
+	require.NoError(t, printDestroyPlan(plan, "", &b))
+	assert.Equal(t, `Dry run: 'okteto destroy' would destroy the development environment 'movies' in namespace 'cindy'
+
+Commands:
+  - cleanup
+
+Helm releases:
+  - movies
+
+Resources:
+  - Deployment/api
+
+Volumes are kept. Run with '--volumes' to destroy them
+`, b.String())
+
+	b.Reset()
+	require.NoError(t, printDestroyPlan(plan, "json", &b))
+	result := &destroyPlan{}
+	require.NoError(t, json.Unmarshal(b.Bytes(), result))
+	assert.Equal(t, plan, result)
+
+	b.Reset()
+	require.NoError(t, printDestroyPlan(&destroyPlan{Namespace: "cindy", All: true, IncludeVolumes: true}, "", &b))
+	assert.Equal(t, "Dry run: 'okteto destroy --all' would destroy everything in namespace 'cindy'\n\nNothing to destroy\n", b.String())
+
+	b.Reset()
+	require.NoError(t, printDestroyPlan(plan, "yaml", &b))
+	assert.Contains(t, b.String(), "namespace: cindy")
+
+	assert.Error(t, printDestroyPlan(plan, "xml", &b))
+}
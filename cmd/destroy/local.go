@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	pipelineCMD "github.com/okteto/okteto/cmd/pipeline"
+	"github.com/okteto/okteto/cmd/utils/executor"
 	"github.com/okteto/okteto/pkg/cmd/pipeline"
 	"github.com/okteto/okteto/pkg/constants"
 	"github.com/okteto/okteto/pkg/divert"
@@ -165,6 +166,19 @@ func (ld *localDestroyCommand) runDestroy(ctx context.Context, opts *Options) er
 	}
 
 	go func() {
+		if err := executor.RunHook(ld.executor, model.PreDestroyHook, ld.manifest.Hooks, opts.Variables); err != nil {
+			if !opts.ForceDestroy {
+				if err := ld.ConfigMapHandler.setErrorStatus(ctx, cfg, data, err); err != nil {
+					exit <- err
+					return
+				}
+				exit <- err
+				return
+			}
+
+			// Store the error to return if the force destroy option is set
+			commandErr = err
+		}
 		if ld.manifest.Destroy == nil {
 			exit <- nil
 			return
@@ -211,18 +225,13 @@ func (ld *localDestroyCommand) runDestroy(ctx context.Context, opts *Options) er
 	oktetoLog.StartSpinner()
 	defer oktetoLog.StopSpinner()
 
-	deployedByLs, err := labels.NewRequirement(
-		model.DeployedByLabel,
-		selection.Equals,
-		[]string{format.ResourceK8sMetaString(opts.Name)},
-	)
+	deployedBySelector, err := getDeployedBySelector(opts.Name)
 	if err != nil {
 		if err := ld.ConfigMapHandler.setErrorStatus(ctx, cfg, data, err); err != nil {
 			return err
 		}
 		return err
 	}
-	deployedBySelector := labels.NewSelector().Add(*deployedByLs).String()
 	deleteOpts := namespaces.DeleteAllOptions{
 		LabelSelector:  deployedBySelector,
 		IncludeVolumes: opts.DestroyVolumes,
@@ -265,33 +274,71 @@ func (ld *localDestroyCommand) runDestroy(ctx context.Context, opts *Options) er
 	return commandErr
 }
 
-func (dc *localDestroyCommand) destroyHelmReleasesIfPresent(ctx context.Context, opts *Options, labelSelector string) error {
-	sList, err := dc.secrets.List(ctx, opts.Namespace, labelSelector)
+// getDeployedBySelector returns the label selector of the resources deployed by a development environment
+func getDeployedBySelector(name string) (string, error) {
+	deployedByLs, err := labels.NewRequirement(
+		model.DeployedByLabel,
+		selection.Equals,
+		[]string{format.ResourceK8sMetaString(name)},
+	)
 	if err != nil {
-		return err
+		return "", err
 	}
+	return labels.NewSelector().Add(*deployedByLs).String(), nil
+}
 
+// getHelmReleases returns the helm releases of a development environment in the order they must be uninstalled.
+// The releases tracked by 'okteto deploy' are merged with the ones detected in the namespace and the ones declared in the manifest,
+// and uninstalled in reverse install order
+func getHelmReleases(ctx context.Context, cmh configMapHandler, secrets secretHandler, manifest *model.Manifest, name, namespace, labelSelector string) ([]model.HelmRelease, error) {
 	oktetoLog.Debugf("checking if application installed something with helm")
-	helmReleases := map[string]bool{}
-	for _, s := range sList {
-		if s.Type == model.HelmSecretType && s.Labels[ownerLabel] == helmOwner {
-			helmReleaseName, ok := s.Labels[nameLabel]
-			if !ok {
-				continue
-			}
+	sList, err := secrets.List(ctx, namespace, labelSelector)
+	if err != nil {
+		return nil, err
+	}
+	tracked, err := cmh.getHelmReleases(ctx, name, namespace)
+	if err != nil {
+		oktetoLog.Infof("could not get the helm releases tracked by '%s': %s", name, err)
+	}
+	var declared []model.HelmRelease
+	if manifest != nil && manifest.Deploy != nil {
+		declared = manifest.Deploy.HelmReleases
+	}
 
-			helmReleases[helmReleaseName] = true
-		}
+	releases := pipeline.MergeHelmReleases(tracked, pipeline.HelmReleasesFromSecrets(sList), declared)
+	for i, j := 0, len(releases)-1; i < j; i, j = i+1, j-1 {
+		releases[i], releases[j] = releases[j], releases[i]
+	}
+	return releases, nil
+}
+
+// getHelmUninstallCommand returns the command to uninstall a helm release
+func getHelmUninstallCommand(release model.HelmRelease) string {
+	cmd := fmt.Sprintf(helmUninstallCommand, release.Name)
+	if release.Namespace != "" {
+		cmd = fmt.Sprintf("%s --namespace %s", cmd, release.Namespace)
+	}
+	if release.Timeout != 0 {
+		cmd = fmt.Sprintf("%s --timeout %s", cmd, release.Timeout)
+	}
+	return cmd
+}
+
+func (ld *localDestroyCommand) destroyHelmReleasesIfPresent(ctx context.Context, opts *Options, labelSelector string) error {
+	helmReleases, err := getHelmReleases(ctx, ld.ConfigMapHandler, ld.secrets, ld.manifest, opts.Name, opts.Namespace, labelSelector)
+	if err != nil {
+		return err
 	}
 
 	// If the application to be destroyed was deployed with helm, we try to uninstall it to avoid to leave orphan release resources
-	for releaseName := range helmReleases {
-		oktetoLog.Debugf("uninstalling helm release '%s'", releaseName)
-		cmd := fmt.Sprintf(helmUninstallCommand, releaseName)
+	for _, release := range helmReleases {
+		oktetoLog.SetStage(fmt.Sprintf("Uninstalling helm release '%s'", release.Name))
+		oktetoLog.Debugf("uninstalling helm release '%s'", release.Name)
+		cmd := getHelmUninstallCommand(release)
 		cmdInfo := model.DeployCommand{Command: cmd, Name: cmd}
 		oktetoLog.Information("Running '%s'", cmdInfo.Name)
-		if err := dc.executor.Execute(cmdInfo, opts.Variables); err != nil {
-			oktetoLog.Infof("could not uninstall helm release '%s': %s", releaseName, err)
+		if err := ld.executor.Execute(cmdInfo, opts.Variables); err != nil {
+			oktetoLog.Infof("could not uninstall helm release '%s': %s", release.Name, err)
 			if !opts.ForceDestroy {
 				return err
 			}
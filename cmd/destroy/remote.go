@@ -192,9 +192,9 @@ func (rd *remoteDestroyCommand) destroy(ctx context.Context, opts *Options) erro
 		fmt.Sprintf("%s=%s", model.OktetoTokenEnvVar, okteto.Context().Token),
 		fmt.Sprintf("%s=%s", constants.OktetoTlsCertBase64EnvVar, base64.StdEncoding.EncodeToString(sc.Certificate)),
 		fmt.Sprintf("%s=%s", constants.OktetoInternalServerNameEnvVar, sc.ServerName),
-		fmt.Sprintf("%s=%s", model.OktetoActionNameEnvVar, os.Getenv(model.OktetoActionNameEnvVar)),
-		fmt.Sprintf("%s=%s", constants.OktetoGitCommitEnvVar, os.Getenv(constants.OktetoGitCommitEnvVar)),
-		fmt.Sprintf("%s=%s", constants.OktetoGitBranchEnvVar, os.Getenv(constants.OktetoGitBranchEnvVar)),
+		fmt.Sprintf("%s=%s", model.OktetoActionNameEnvVar, config.EnvOktetoActionName.Value()),
+		fmt.Sprintf("%s=%s", constants.OktetoGitCommitEnvVar, config.EnvOktetoGitCommit.Value()),
+		fmt.Sprintf("%s=%s", constants.OktetoGitBranchEnvVar, config.EnvOktetoGitBranch.Value()),
 		fmt.Sprintf("%s=%d", constants.OktetoInvalidateCacheEnvVar, int(randomNumber.Int64())),
 	)
 
@@ -354,7 +354,7 @@ func getOktetoCLIVersion(versionString string) string {
 	} else {
 		oktetoLog.Infof("invalid okteto CLI version %s: %s", versionString, err)
 		oktetoLog.Info("using latest okteto CLI image")
-		remoteOktetoImage := os.Getenv(constants.OktetoDeployRemoteImage)
+		remoteOktetoImage := config.EnvOktetoRemoteCLIImage.Value()
 		if remoteOktetoImage != "" {
 			version = remoteOktetoImage
 		} else {
@@ -0,0 +1,99 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package divert
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/divert"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+// CreateOptions represents the options of the divert create command
+type CreateOptions struct {
+	Name            string
+	Namespace       string
+	From            string
+	Driver          string
+	VirtualServices []string
+}
+
+// Create diverts a shared namespace into the current namespace
+func Create(ctx context.Context) *cobra.Command {
+	options := &CreateOptions{}
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Divert the traffic of a shared namespace into your namespace",
+		Args:  utils.ExactArgsAccepted(1, ""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.Name = args[0]
+			dc, err := newCommand(ctx, options.Namespace)
+			if err != nil {
+				return err
+			}
+			return dc.ExecuteCreate(ctx, options)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "namespace that receives the diverted traffic (defaults to the current namespace)")
+	cmd.Flags().StringVarP(&options.From, "from", "", "", "shared namespace to divert")
+	cmd.Flags().StringVarP(&options.Driver, "driver", "", constants.OktetoDivertWeaverDriver, "divert driver. One of: ['weaver', 'istio']")
+	cmd.Flags().StringArrayVarP(&options.VirtualServices, "virtual-service", "", nil, "virtual service to divert when using the istio driver (can be set more than once)")
+	return cmd
+}
+
+// ExecuteCreate creates the divert
+func (dc *Command) ExecuteCreate(ctx context.Context, opts *CreateOptions) error {
+	if opts.From == "" {
+		return fmt.Errorf("%w: use the '--from' flag", divert.ErrDivertNamespaceRequired)
+	}
+	if opts.Driver != constants.OktetoDivertWeaverDriver && opts.Driver != constants.OktetoDivertIstioDriver {
+		return fmt.Errorf("invalid divert driver '%s'. Supported values are: ['%s', '%s']", opts.Driver, constants.OktetoDivertWeaverDriver, constants.OktetoDivertIstioDriver)
+	}
+	if len(opts.VirtualServices) > 0 && opts.Driver != constants.OktetoDivertIstioDriver {
+		return fmt.Errorf("'--virtual-service' is only supported by the '%s' driver", constants.OktetoDivertIstioDriver)
+	}
+
+	d := &divert.Divert{
+		Name:      opts.Name,
+		Namespace: dc.namespace,
+		Spec: model.DivertDeploy{
+			Driver:    opts.Driver,
+			Namespace: opts.From,
+		},
+	}
+	for _, vs := range opts.VirtualServices {
+		d.Spec.VirtualServices = append(d.Spec.VirtualServices, parseVirtualService(vs, opts.From))
+	}
+
+	if err := dc.client.Create(ctx, d); err != nil {
+		return err
+	}
+	oktetoLog.Success("Divert '%s' from namespace '%s' created", opts.Name, opts.From)
+	return nil
+}
+
+// parseVirtualService parses virtual services with the format [namespace/]name
+func parseVirtualService(value, defaultNamespace string) model.DivertVirtualService {
+	ns, name, found := strings.Cut(value, "/")
+	if !found {
+		return model.DivertVirtualService{Name: value, Namespace: defaultNamespace}
+	}
+	return model.DivertVirtualService{Name: name, Namespace: ns}
+}
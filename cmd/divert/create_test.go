@@ -0,0 +1,57 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package divert
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/divert"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteCreate(t *testing.T) {
+	fc := &fakeDivertClient{}
+	dc := &Command{namespace: "cindy", client: fc}
+
+	err := dc.ExecuteCreate(context.Background(), &CreateOptions{
+		Name:            "movies",
+		From:            "staging",
+		Driver:          constants.OktetoDivertIstioDriver,
+		VirtualServices: []string{"frontend", "shared/api"},
+	})
+	require.NoError(t, err)
+	require.Len(t, fc.diverts, 1)
+	assert.Equal(t, "cindy", fc.diverts[0].Namespace)
+	assert.Equal(t, []model.DivertVirtualService{
+		{Name: "frontend", Namespace: "staging"},
+		{Name: "api", Namespace: "shared"},
+	}, fc.diverts[0].Spec.VirtualServices)
+}
+
+func TestExecuteCreateErrors(t *testing.T) {
+	dc := &Command{namespace: "cindy", client: &fakeDivertClient{}}
+
+	err := dc.ExecuteCreate(context.Background(), &CreateOptions{Name: "movies", Driver: constants.OktetoDivertWeaverDriver})
+	assert.ErrorIs(t, err, divert.ErrDivertNamespaceRequired)
+
+	err = dc.ExecuteCreate(context.Background(), &CreateOptions{Name: "movies", From: "staging", Driver: "linkerd"})
+	assert.Error(t, err)
+
+	err = dc.ExecuteCreate(context.Background(), &CreateOptions{Name: "movies", From: "staging", Driver: constants.OktetoDivertWeaverDriver, VirtualServices: []string{"api"}})
+	assert.Error(t, err)
+}
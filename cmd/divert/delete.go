@@ -0,0 +1,50 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package divert
+
+import (
+	"context"
+
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/spf13/cobra"
+)
+
+// Delete deletes a divert of the current namespace
+func Delete(ctx context.Context) *cobra.Command {
+	var namespace string
+	cmd := &cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete a divert and restore the diverted resources",
+		Args:  utils.ExactArgsAccepted(1, ""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dc, err := newCommand(ctx, namespace)
+			if err != nil {
+				return err
+			}
+			return dc.ExecuteDelete(ctx, args[0])
+		},
+	}
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "namespace of the divert (defaults to the current namespace)")
+	return cmd
+}
+
+// ExecuteDelete deletes the divert
+func (dc *Command) ExecuteDelete(ctx context.Context, name string) error {
+	if err := dc.client.Delete(ctx, name, dc.namespace); err != nil {
+		return err
+	}
+	oktetoLog.Success("Divert '%s' deleted", name)
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package divert
+
+import (
+	"context"
+	"fmt"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/pkg/divert"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/cobra"
+)
+
+// divertClient manages the diverts of a namespace
+type divertClient interface {
+	Create(ctx context.Context, d *divert.Divert) error
+	List(ctx context.Context, namespace string) ([]divert.Divert, error)
+	Delete(ctx context.Context, name, namespace string) error
+}
+
+// Command has all the divert subcommands
+type Command struct {
+	client    divertClient
+	namespace string
+}
+
+// newCommand loads the okteto context and creates a divert command for the given namespace
+func newCommand(ctx context.Context, namespace string) (*Command, error) {
+	if err := contextCMD.NewContextCommand().Run(ctx, &contextCMD.ContextOptions{Namespace: namespace}); err != nil {
+		return nil, err
+	}
+
+	if !okteto.IsOkteto() {
+		return nil, oktetoErrors.ErrContextIsNotOktetoCluster
+	}
+
+	if err := okteto.CheckCapability(ctx, okteto.DivertCapability); err != nil {
+		return nil, err
+	}
+
+	c, _, err := okteto.NewK8sClientProvider().Provide(okteto.Context().Cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load okteto context '%s': %w", okteto.Context().Name, err)
+	}
+
+	return &Command{
+		client:    divert.NewClient(c),
+		namespace: okteto.Context().Namespace,
+	}, nil
+}
+
+// Divert manages the diverts of the current namespace
+func Divert(ctx context.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "divert",
+		Short: "Manage the diverts of your namespace",
+		Long:  "Manage the diverts of your namespace. Diverts route the traffic of a shared namespace to the services running in your namespace",
+	}
+	cmd.AddCommand(Create(ctx))
+	cmd.AddCommand(List(ctx))
+	cmd.AddCommand(Delete(ctx))
+	return cmd
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package divert
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+type listFlags struct {
+	namespace string
+	output    string
+}
+
+// List lists the diverts of the current namespace
+func List(ctx context.Context) *cobra.Command {
+	flags := &listFlags{}
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   "List the diverts of your namespace",
+		Aliases: []string{"ls"},
+		Args:    utils.NoArgsAccepted(""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.Validate(flags.output); err != nil {
+				return err
+			}
+			dc, err := newCommand(ctx, flags.namespace)
+			if err != nil {
+				return err
+			}
+			return dc.ExecuteList(ctx, flags.output, os.Stdout)
+		},
+	}
+	cmd.Flags().StringVarP(&flags.namespace, "namespace", "n", "", "namespace where the diverts are listed (defaults to the current namespace)")
+	output.AddFlag(cmd, &flags.output)
+	return cmd
+}
+
+// ExecuteList prints the diverts of the namespace
+func (dc *Command) ExecuteList(ctx context.Context, outputFormat string, w io.Writer) error {
+	diverts, err := dc.client.List(ctx, dc.namespace)
+	if err != nil {
+		return fmt.Errorf("failed to list diverts: %w", err)
+	}
+
+	return output.Print(w, outputFormat, diverts, func(w io.Writer) error {
+		if len(diverts) == 0 {
+			fmt.Fprintf(w, "There are no diverts in namespace '%s'\n", dc.namespace)
+			return nil
+		}
+		tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+		fmt.Fprintln(tw, "Name\tFrom\tDriver")
+		for _, d := range diverts {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", d.Name, d.Spec.Namespace, d.Spec.Driver)
+		}
+		return tw.Flush()
+	})
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package divert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/divert"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDivertClient struct {
+	diverts []divert.Divert
+	err     error
+}
+
+func (fc *fakeDivertClient) Create(_ context.Context, d *divert.Divert) error {
+	if fc.err != nil {
+		return fc.err
+	}
+	fc.diverts = append(fc.diverts, *d)
+	return nil
+}
+
+func (fc *fakeDivertClient) List(_ context.Context, _ string) ([]divert.Divert, error) {
+	return fc.diverts, fc.err
+}
+
+func (fc *fakeDivertClient) Delete(_ context.Context, _, _ string) error {
+	return fc.err
+}
+
+func TestExecuteList(t *testing.T) {
+	dc := &Command{
+		namespace: "cindy",
+		client: &fakeDivertClient{
+			diverts: []divert.Divert{
+				{
+					Name:      "movies",
+					Namespace: "cindy",
+					Spec:      model.DivertDeploy{Namespace: "staging", Driver: constants.OktetoDivertWeaverDriver},
+				},
+			},
+		},
+	}
+
+	var b bytes.Buffer
+	require.NoError(t, dc.ExecuteList(context.Background(), "", &b))
+	assert.Contains(t, b.String(), "movies")
+	assert.Contains(t, b.String(), "staging")
+
+	b.Reset()
+	require.NoError(t, dc.ExecuteList(context.Background(), "json", &b))
+	var result []divert.Divert
+	require.NoError(t, json.Unmarshal(b.Bytes(), &result))
+	assert.Equal(t, "movies", result[0].Name)
+
+	assert.Error(t, dc.ExecuteList(context.Background(), "xml", &b))
+}
@@ -21,8 +21,10 @@ import (
 	"os/signal"
 
 	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/up"
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/analytics"
+	"github.com/okteto/okteto/pkg/cmd/daemon"
 	"github.com/okteto/okteto/pkg/cmd/down"
 	"github.com/okteto/okteto/pkg/config"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
@@ -36,13 +38,98 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// downOptions are the flags of the down command that decide what is torn down
+type downOptions struct {
+	rm          bool
+	keepVolumes bool
+	detachOnly  bool
+	localOnly   bool
+}
+
+// validate returns an error if the options can't be used together
+func (o *downOptions) validate() error {
+	if o.detachOnly && o.rm {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("flags '--detach-only' and '--volumes' can't be used together"),
+			Hint: "Run 'okteto down --volumes' to deactivate your development container and remove its persistent volume",
+		}
+	}
+	if o.localOnly && o.rm {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("flags '--local-only' and '--volumes' can't be used together"),
+			Hint: "Run 'okteto down --volumes' to deactivate your development container and remove its persistent volume",
+		}
+	}
+	if o.localOnly && o.detachOnly {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("flags '--local-only' and '--detach-only' can't be used together"),
+			Hint: "The flag '--local-only' also stops the 'okteto up' running in the background",
+		}
+	}
+	if o.keepVolumes && o.rm {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("flags '--keep-volumes' and '--volumes' can't be used together"),
+			Hint: "Use '--keep-volumes' to preserve the persistent volumes or '--volumes' to delete them",
+		}
+	}
+	return nil
+}
+
+// askFunc asks a yes/no question to the user
+type askFunc func(q string, d utils.YesNoDefault) (bool, error)
+
+// shouldRemoveVolumes returns if the persistent volumes of the development containers must be deleted.
+// When no flag decides it, the user is asked only if the terminal is interactive and some persistent volume exists
+func (o *downOptions) shouldRemoveVolumes(devs []*model.Dev, interactive bool, ask askFunc) (bool, error) {
+	if o.rm {
+		return true, nil
+	}
+	if o.keepVolumes || !interactive {
+		return false, nil
+	}
+	names := []string{}
+	for _, dev := range devs {
+		if dev.PersistentVolumeEnabled() {
+			names = append(names, dev.Name)
+		}
+	}
+	if len(names) == 0 {
+		return false, nil
+	}
+	q := fmt.Sprintf("Do you want to delete the persistent volume of '%s'?", names[0])
+	if len(names) > 1 {
+		q = fmt.Sprintf("Do you want to delete the persistent volumes of %d development containers?", len(names))
+	}
+	return ask(q, utils.YesNoDefault_No)
+}
+
+// downSummary is the list of what was torn down by the down command
+type downSummary struct {
+	items []string
+}
+
+func (s *downSummary) add(format string, args ...interface{}) {
+	s.items = append(s.items, fmt.Sprintf(format, args...))
+}
+
+// print displays the summary when more than one thing was torn down
+func (s *downSummary) print() {
+	if len(s.items) < 2 {
+		return
+	}
+	oktetoLog.Information("Summary:")
+	for _, item := range s.items {
+		oktetoLog.Println(fmt.Sprintf("  - %s", item))
+	}
+}
+
 // Down deactivates the development container
 func Down() *cobra.Command {
 	var devPath string
 	var namespace string
 	var k8sContext string
-	var rm bool
 	var all bool
+	opts := &downOptions{}
 
 	cmd := &cobra.Command{
 		Use:   "down [svc]",
@@ -51,6 +138,10 @@ func Down() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
 
+			if err := opts.validate(); err != nil {
+				return err
+			}
+
 			manifestOpts := contextCMD.ManifestOptions{Filename: devPath, Namespace: namespace, K8sContext: k8sContext}
 			if devPath != "" {
 				workdir := model.GetWorkdirFromManifestPath(devPath)
@@ -69,13 +160,42 @@ func Down() *cobra.Command {
 				return err
 			}
 
+			if all && opts.detachOnly {
+				for _, dev := range manifest.Dev {
+					if err := detachDown(dev); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+
+			if all && opts.localOnly {
+				summary := &downSummary{}
+				for _, dev := range manifest.Dev {
+					if err := localDown(dev, summary); err != nil {
+						return err
+					}
+				}
+				summary.print()
+				return nil
+			}
+
 			if all {
-				err := allDown(ctx, manifest, rm)
+				devs := []*model.Dev{}
+				for _, dev := range manifest.Dev {
+					devs = append(devs, dev)
+				}
+				rm, err := opts.shouldRemoveVolumes(devs, oktetoLog.IsInteractive(), utils.AskYesNo)
 				if err != nil {
 					return err
 				}
+				summary := &downSummary{}
+				if err := allDown(ctx, manifest, rm, summary); err != nil {
+					return err
+				}
 
 				oktetoLog.Success("All development containers are deactivated")
+				summary.print()
 				return nil
 			} else {
 				devName := ""
@@ -105,16 +225,30 @@ func Down() *cobra.Command {
 					}
 				}
 
+				if opts.detachOnly {
+					return detachDown(dev)
+				}
+
+				if opts.localOnly {
+					return localDown(dev, &downSummary{})
+				}
+
 				app, _, err := utils.GetApp(ctx, dev, c, false)
 				if err != nil {
 					return err
 				}
 
 				if apps.IsDevModeOn(app) {
-					if err := runDown(ctx, dev, rm); err != nil {
+					rm, err := opts.shouldRemoveVolumes([]*model.Dev{dev}, oktetoLog.IsInteractive(), utils.AskYesNo)
+					if err != nil {
+						return err
+					}
+					summary := &downSummary{}
+					if err := runDown(ctx, dev, rm, summary); err != nil {
 						analytics.TrackDown(false)
-						return fmt.Errorf("%w\n    Find additional logs at: %s/okteto.log", err, config.GetAppHome(dev.Namespace, dev.Name))
+						return utils.WithLogsHint(err)
 					}
+					summary.print()
 				} else {
 					oktetoLog.Success(fmt.Sprintf("Development container '%s' deactivated", dev.Name))
 				}
@@ -126,14 +260,17 @@ func Down() *cobra.Command {
 	}
 
 	cmd.Flags().StringVarP(&devPath, "file", "f", utils.DefaultManifest, "path to the manifest file")
-	cmd.Flags().BoolVarP(&rm, "volumes", "v", false, "remove persistent volume")
+	cmd.Flags().BoolVarP(&opts.rm, "volumes", "v", false, "remove persistent volumes without asking")
+	cmd.Flags().BoolVarP(&opts.keepVolumes, "keep-volumes", "", false, "keep persistent volumes without asking")
 	cmd.Flags().BoolVarP(&all, "all", "A", false, "deactivate all running dev containers")
+	cmd.Flags().BoolVarP(&opts.localOnly, "local-only", "", false, "stop the file synchronization and port forwards of 'okteto up' without deactivating the development container")
+	cmd.Flags().BoolVarP(&opts.detachOnly, "detach-only", "", false, "stop the 'okteto up' running in the background without deactivating the development container")
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "namespace where the down command is executed")
 	cmd.Flags().StringVarP(&k8sContext, "context", "c", "", "context where the down command is executed")
 	return cmd
 }
 
-func allDown(ctx context.Context, manifest *model.Manifest, rm bool) error {
+func allDown(ctx context.Context, manifest *model.Manifest, rm bool, summary *downSummary) error {
 	oktetoLog.Spinner("Deactivating your development containers...")
 	oktetoLog.StartSpinner()
 	defer oktetoLog.StopSpinner()
@@ -155,9 +292,9 @@ func allDown(ctx context.Context, manifest *model.Manifest, rm bool) error {
 
 		if apps.IsDevModeOn(app) {
 			oktetoLog.StopSpinner()
-			if err := runDown(ctx, dev, rm); err != nil {
+			if err := runDown(ctx, dev, rm, summary); err != nil {
 				analytics.TrackDown(false)
-				return fmt.Errorf("%w\n    Find additional logs at: %s/okteto.log", err, config.GetAppHome(dev.Namespace, dev.Name))
+				return utils.WithLogsHint(err)
 			}
 		}
 	}
@@ -166,7 +303,61 @@ func allDown(ctx context.Context, manifest *model.Manifest, rm bool) error {
 	return nil
 }
 
-func runDown(ctx context.Context, dev *model.Dev, rm bool) error {
+// detachDown stops the 'okteto up' running in the background for a development container, keeping it active
+func detachDown(dev *model.Dev) error {
+	stopped, err := stopDaemon(dev)
+	if err != nil {
+		return err
+	}
+	if !stopped {
+		oktetoLog.Information("'okteto up' is not running in the background for '%s'", dev.Name)
+		return nil
+	}
+	oktetoLog.Success("Stopped the background 'okteto up' for '%s'. Your development container is still active", dev.Name)
+	return nil
+}
+
+// localDown stops the file synchronization and port forwards of a development container, keeping it active
+func localDown(dev *model.Dev, summary *downSummary) error {
+	if _, err := stopDaemon(dev); err != nil {
+		return err
+	}
+	stopped, err := up.StopSession(dev.Namespace, dev.Name)
+	if err != nil {
+		return fmt.Errorf("failed to stop 'okteto up' for '%s': %w", dev.Name, err)
+	}
+	down.StopSyncthing(dev)
+	if !stopped {
+		oktetoLog.Information("'okteto up' is not running for '%s'", dev.Name)
+		return nil
+	}
+	summary.add("file synchronization of '%s' stopped", dev.Name)
+	summary.add("port forwards of '%s' stopped", dev.Name)
+	oktetoLog.Success("Stopped the file synchronization and port forwards of '%s'. Your development container is still active", dev.Name)
+	return nil
+}
+
+// stopDaemon stops the 'okteto up' running in the background for a development container, if any
+func stopDaemon(dev *model.Dev) (bool, error) {
+	s, err := daemon.Read(dev.Namespace, dev.Name)
+	if err != nil {
+		if errors.Is(err, daemon.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	if err := s.Stop(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func runDown(ctx context.Context, dev *model.Dev, rm bool, summary *downSummary) error {
+	// the daemon would activate the development container again after it's deactivated
+	if _, err := stopDaemon(dev); err != nil {
+		oktetoLog.Infof("failed to stop 'okteto up' running in the background: %s", err)
+	}
+
 	oktetoLog.Spinner(fmt.Sprintf("Deactivating '%s' development container...", dev.Name))
 	oktetoLog.StartSpinner()
 	defer oktetoLog.StopSpinner()
@@ -206,8 +397,13 @@ func runDown(ctx context.Context, dev *model.Dev, rm bool) error {
 		}
 
 		oktetoLog.Success(fmt.Sprintf("Development container '%s' deactivated", dev.Name))
+		summary.add("development container '%s' deactivated", dev.Name)
+		summary.add("file synchronization and port forwards of '%s' stopped", dev.Name)
 
 		if !rm {
+			if dev.PersistentVolumeEnabled() {
+				summary.add("persistent volume '%s' kept", dev.GetVolumeName())
+			}
 			exit <- nil
 			return
 		}
@@ -219,8 +415,9 @@ func runDown(ctx context.Context, dev *model.Dev, rm bool) error {
 			return
 		}
 		oktetoLog.Success(fmt.Sprintf("Persistent volume '%s' removed", dev.Name))
+		summary.add("persistent volume '%s' removed", dev.GetVolumeName())
 
-		if os.Getenv(model.OktetoSkipCleanupEnvVar) == "" {
+		if config.EnvOktetoSkipCleanup.Value() == "" {
 			if err := syncthing.RemoveFolder(dev); err != nil {
 				oktetoLog.Infof("failed to delete existing syncthing folder")
 			}
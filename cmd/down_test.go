@@ -0,0 +1,118 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownOptionsValidate(t *testing.T) {
+	tests := []struct {
+		opts    *downOptions
+		name    string
+		wantErr bool
+	}{
+		{name: "default", opts: &downOptions{}},
+		{name: "volumes", opts: &downOptions{rm: true}},
+		{name: "local only", opts: &downOptions{localOnly: true, keepVolumes: true}},
+		{name: "detach only and volumes", opts: &downOptions{detachOnly: true, rm: true}, wantErr: true},
+		{name: "local only and volumes", opts: &downOptions{localOnly: true, rm: true}, wantErr: true},
+		{name: "local only and detach only", opts: &downOptions{localOnly: true, detachOnly: true}, wantErr: true},
+		{name: "keep volumes and volumes", opts: &downOptions{keepVolumes: true, rm: true}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestShouldRemoveVolumes(t *testing.T) {
+	withVolume := &model.Dev{Name: "api"}
+	withoutVolume := &model.Dev{Name: "frontend", PersistentVolumeInfo: &model.PersistentVolumeInfo{Enabled: false}}
+
+	tests := []struct {
+		opts        *downOptions
+		name        string
+		question    string
+		devs        []*model.Dev
+		interactive bool
+		answer      bool
+		expected    bool
+	}{
+		{
+			name:        "volumes flag",
+			opts:        &downOptions{rm: true},
+			devs:        []*model.Dev{withVolume},
+			interactive: true,
+			expected:    true,
+		},
+		{
+			name:        "keep volumes flag",
+			opts:        &downOptions{keepVolumes: true},
+			devs:        []*model.Dev{withVolume},
+			interactive: true,
+		},
+		{
+			name: "not interactive",
+			opts: &downOptions{},
+			devs: []*model.Dev{withVolume},
+		},
+		{
+			name:        "no persistent volumes",
+			opts:        &downOptions{},
+			devs:        []*model.Dev{withoutVolume},
+			interactive: true,
+		},
+		{
+			name:        "user deletes the volume",
+			opts:        &downOptions{},
+			devs:        []*model.Dev{withVolume, withoutVolume},
+			interactive: true,
+			answer:      true,
+			question:    "Do you want to delete the persistent volume of 'api'?",
+			expected:    true,
+		},
+		{
+			name:        "user keeps the volumes",
+			opts:        &downOptions{},
+			devs:        []*model.Dev{withVolume, {Name: "worker"}},
+			interactive: true,
+			question:    "Do you want to delete the persistent volumes of 2 development containers?",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			asked := ""
+			ask := func(q string, _ utils.YesNoDefault) (bool, error) {
+				asked = q
+				return tt.answer, nil
+			}
+			result, err := tt.opts.shouldRemoveVolumes(tt.devs, tt.interactive, ask)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+			assert.Equal(t, tt.question, asked)
+		})
+	}
+}
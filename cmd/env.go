@@ -0,0 +1,142 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/cobra"
+)
+
+const (
+	bashShell       = "bash"
+	zshShell        = "zsh"
+	fishShell       = "fish"
+	powershellShell = "powershell"
+)
+
+var supportedShells = []string{bashShell, zshShell, fishShell, powershellShell}
+
+type envOptions struct {
+	shell        string
+	includeToken bool
+}
+
+type envVar struct {
+	name  string
+	value string
+}
+
+// Env prints the environment variables of the current context as statements of a shell
+func Env() *cobra.Command {
+	options := &envOptions{}
+	cmd := &cobra.Command{
+		Use:   "env",
+		Short: "Print the environment variables of the current context",
+		Long: `Print the environment variables of the current context as export statements of your shell.
+
+Run 'eval $(okteto env)' to configure your shell, or 'okteto env --shell fish | source' in fish.`,
+		Args: utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#env"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			shell := options.shell
+			if shell == "" {
+				shell = detectShell()
+			}
+			if err := validateShell(shell); err != nil {
+				return err
+			}
+
+			// stdout is evaluated by the shell, the context picker and its messages must go to stderr
+			oktetoLog.SetOutput(os.Stderr)
+			defer oktetoLog.SetOutput(os.Stdout)
+
+			ctx := context.Background()
+			if err := contextCMD.NewContextCommand().Run(ctx, &contextCMD.ContextOptions{}); err != nil {
+				return err
+			}
+			return printEnv(os.Stdout, shell, getContextEnvVars(okteto.Context(), options.includeToken))
+		},
+	}
+	cmd.Flags().StringVarP(&options.shell, "shell", "", "", fmt.Sprintf("shell syntax of the statements. One of: ['%s']. Detected from the environment by default", strings.Join(supportedShells, "', '")))
+	cmd.Flags().BoolVarP(&options.includeToken, "include-token", "", false, "include the token of the context in OKTETO_TOKEN")
+	return cmd
+}
+
+// detectShell returns the shell of the user, bash if it can't be detected
+func detectShell() string {
+	if runtime.GOOS == "windows" {
+		return powershellShell
+	}
+	shell := filepath.Base(os.Getenv("SHELL"))
+	if validateShell(shell) == nil {
+		return shell
+	}
+	return bashShell
+}
+
+func validateShell(shell string) error {
+	for _, s := range supportedShells {
+		if shell == s {
+			return nil
+		}
+	}
+	return fmt.Errorf("shell '%s' is not supported. Value must be one of: ['%s']", shell, strings.Join(supportedShells, "', '"))
+}
+
+func getContextEnvVars(okCtx *okteto.OktetoContext, includeToken bool) []envVar {
+	vars := []envVar{
+		{name: model.OktetoContextEnvVar, value: okCtx.Name},
+		{name: model.OktetoNamespaceEnvVar, value: okCtx.Namespace},
+	}
+	if okCtx.IsOkteto && okCtx.Registry != "" {
+		vars = append(vars, envVar{name: model.OktetoRegistryURLEnvVar, value: okCtx.Registry})
+	}
+	if includeToken && okCtx.Token != "" {
+		vars = append(vars, envVar{name: model.OktetoTokenEnvVar, value: okCtx.Token})
+	}
+	return vars
+}
+
+func printEnv(w io.Writer, shell string, vars []envVar) error {
+	for _, v := range vars {
+		if _, err := fmt.Fprintln(w, formatEnvVar(shell, v)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatEnvVar returns the statement setting the env var in the shell, quoting the value so it is never interpreted by the shell
+func formatEnvVar(shell string, v envVar) string {
+	switch shell {
+	case fishShell:
+		value := strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(v.value)
+		return fmt.Sprintf("set -gx %s '%s';", v.name, value)
+	case powershellShell:
+		return fmt.Sprintf("$Env:%s = '%s'", v.name, strings.ReplaceAll(v.value, "'", "''"))
+	default:
+		return fmt.Sprintf("export %s='%s'", v.name, strings.ReplaceAll(v.value, "'", `'\''`))
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"runtime"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_formatEnvVar(t *testing.T) {
+	v := envVar{name: "OKTETO_NAMESPACE", value: "it's $HOME\\"}
+	tests := []struct {
+		shell    string
+		expected string
+	}{
+		{shell: bashShell, expected: `export OKTETO_NAMESPACE='it'\''s $HOME\'`},
+		{shell: zshShell, expected: `export OKTETO_NAMESPACE='it'\''s $HOME\'`},
+		{shell: fishShell, expected: `set -gx OKTETO_NAMESPACE 'it\'s $HOME\\';`},
+		{shell: powershellShell, expected: `$Env:OKTETO_NAMESPACE = 'it''s $HOME\'`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.shell, func(t *testing.T) {
+			assert.Equal(t, tt.expected, formatEnvVar(tt.shell, v))
+		})
+	}
+}
+
+func Test_getContextEnvVars(t *testing.T) {
+	okCtx := &okteto.OktetoContext{
+		Name:      "https://okteto.example.com",
+		Namespace: "cindy",
+		Registry:  "registry.okteto.example.com",
+		Token:     "secret",
+		IsOkteto:  true,
+	}
+
+	var b bytes.Buffer
+	require.NoError(t, printEnv(&b, bashShell, getContextEnvVars(okCtx, false)))
+	assert.Equal(t, `export OKTETO_CONTEXT='https://okteto.example.com'
+export OKTETO_NAMESPACE='cindy'
+export OKTETO_REGISTRY_URL='registry.okteto.example.com'
+`, b.String())
+
+	vars := getContextEnvVars(okCtx, true)
+	assert.Contains(t, vars, envVar{name: "OKTETO_TOKEN", value: "secret"})
+
+	vars = getContextEnvVars(&okteto.OktetoContext{Name: "minikube", Namespace: "default", Token: "secret"}, false)
+	assert.Equal(t, []envVar{{name: "OKTETO_CONTEXT", value: "minikube"}, {name: "OKTETO_NAMESPACE", value: "default"}}, vars)
+}
+
+func Test_detectShell(t *testing.T) {
+	t.Setenv("SHELL", "/usr/local/bin/fish")
+	expected := fishShell
+	if runtime.GOOS == "windows" {
+		expected = powershellShell
+	}
+	assert.Equal(t, expected, detectShell())
+
+	assert.Error(t, validateShell("tcsh"))
+}
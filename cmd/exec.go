@@ -17,9 +17,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"time"
 
+	"github.com/moby/term"
 	contextCMD "github.com/okteto/okteto/cmd/context"
 	"github.com/okteto/okteto/cmd/up"
 	"github.com/okteto/okteto/cmd/utils"
@@ -42,6 +44,7 @@ type execFlags struct {
 	namespace        string
 	k8sContext       string
 	commandToExecute []string
+	noTTY            bool
 }
 
 // Exec executes a command on the CND container
@@ -49,7 +52,7 @@ func Exec() *cobra.Command {
 	execFlags := &execFlags{}
 
 	cmd := &cobra.Command{
-		Use:   "exec [service] <command>",
+		Use:   "exec [service] [--] <command>",
 		Short: "Execute a command in your development container",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx, cancel := context.WithCancel(context.Background())
@@ -79,10 +82,11 @@ func Exec() *cobra.Command {
 				return err
 			}
 			execFlags.commandToExecute = getCommandToRunFromArgs(manifest, args)
+			tty := shouldAllocateTTY(execFlags.noTTY, os.Stdin)
 
 			t := time.NewTicker(1 * time.Second)
 			iter := 0
-			err = executeExec(ctx, dev, execFlags.commandToExecute)
+			err = executeExec(ctx, dev, execFlags.commandToExecute, tty)
 			for oktetoErrors.IsTransient(err) {
 				if iter == 0 {
 					oktetoLog.Yellow("Connection lost to your development container, reconnecting...")
@@ -90,7 +94,7 @@ func Exec() *cobra.Command {
 				iter++
 				iter = iter % 10
 				<-t.C
-				err = executeExec(ctx, dev, execFlags.commandToExecute)
+				err = executeExec(ctx, dev, execFlags.commandToExecute, tty)
 			}
 
 			analytics.TrackExec(&analytics.TrackExecMetadata{
@@ -98,7 +102,7 @@ func Exec() *cobra.Command {
 				Success:                err == nil,
 				Mode:                   dev.Mode,
 				IsOktetoRepository:     utils.IsOktetoRepo(),
-				IsInteractive:          dev.IsInteractive(),
+				IsInteractive:          dev.IsInteractive() && tty,
 				HasDependenciesSection: manifest.HasDependenciesSection(),
 				HasBuildSection:        manifest.HasBuildSection(),
 				HasDeploySection:       manifest.HasDeploySection(),
@@ -119,11 +123,21 @@ func Exec() *cobra.Command {
 	cmd.Flags().StringVarP(&execFlags.manifestPath, "file", "f", utils.DefaultManifest, "path to the manifest file")
 	cmd.Flags().StringVarP(&execFlags.namespace, "namespace", "n", "", "namespace where the exec command is executed")
 	cmd.Flags().StringVarP(&execFlags.k8sContext, "context", "c", "", "context where the exec command is executed")
+	cmd.Flags().BoolVar(&execFlags.noTTY, "no-tty", false, "run the command without allocating a TTY. It's disabled automatically when stdin is not a terminal")
 
 	return cmd
 }
 
-func executeExec(ctx context.Context, dev *model.Dev, args []string) error {
+// shouldAllocateTTY returns if the command should run in an interactive terminal: scripts and pipes run without TTY
+func shouldAllocateTTY(noTTY bool, stdin io.Reader) bool {
+	if noTTY {
+		return false
+	}
+	_, isTerm := term.GetFdInfo(stdin)
+	return isTerm
+}
+
+func executeExec(ctx context.Context, dev *model.Dev, args []string, tty bool) error {
 	oktetoLog.Spinner("Preparing your container")
 	oktetoLog.StartSpinner()
 	defer oktetoLog.StopSpinner()
@@ -227,10 +241,10 @@ func executeExec(ctx context.Context, dev *model.Dev, args []string) error {
 			return executor.RunCommand(cmd)
 		}
 
-		return ssh.Exec(ctx, dev.Interface, dev.RemotePort, true, os.Stdin, os.Stdout, os.Stderr, wrapped)
+		return ssh.Exec(ctx, dev.Interface, dev.RemotePort, tty, os.Stdin, os.Stdout, os.Stderr, wrapped)
 	}
 	oktetoLog.StopSpinner()
-	return exec.Exec(ctx, c, cfg, dev.Namespace, pod.Name, dev.Container, true, os.Stdin, os.Stdout, os.Stderr, wrapped)
+	return exec.Exec(ctx, c, cfg, dev.Namespace, pod.Name, dev.Container, tty, os.Stdin, os.Stdout, os.Stderr, wrapped)
 }
 
 func getDevFromArgs(manifest *model.Manifest, args, activeDevMode []string) (*model.Dev, error) {
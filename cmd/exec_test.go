@@ -14,7 +14,9 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
+	"os"
 	"testing"
 
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
@@ -144,3 +146,8 @@ func TestGetCommandFromArgs(t *testing.T) {
 		})
 	}
 }
+
+func TestShouldAllocateTTY(t *testing.T) {
+	assert.False(t, shouldAllocateTTY(true, os.Stdin))
+	assert.False(t, shouldAllocateTTY(false, &bytes.Buffer{}))
+}
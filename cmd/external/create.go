@@ -0,0 +1,94 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/externalresource"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/spf13/cobra"
+)
+
+// createOptions are the flags of the create command
+type createOptions struct {
+	icon      string
+	notes     string
+	endpoints []string
+}
+
+// Create creates an external resource
+func Create(ctx context.Context, opts *options) *cobra.Command {
+	createOpts := &createOptions{}
+	cmd := &cobra.Command{
+		Use:     "create <name>",
+		Short:   "Create an external resource",
+		Args:    utils.ExactArgsAccepted(1, ""),
+		Example: `okteto external create functions --icon function --endpoint api=https://my-lambda.aws.com --notes docs/lambda.md`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			control, namespace, err := newExternalControl(ctx, opts, true)
+			if err != nil {
+				return err
+			}
+			if err := runCreate(ctx, control, namespace, args[0], createOpts); err != nil {
+				return err
+			}
+			oktetoLog.Success("External resource '%s' created", args[0])
+			return nil
+		},
+	}
+	cmd.Flags().StringArrayVarP(&createOpts.endpoints, "endpoint", "e", []string{}, "endpoint of the external resource with the format 'name=url' (multiple --endpoint flags accepted)")
+	cmd.Flags().StringVarP(&createOpts.icon, "icon", "", "", "icon displayed for the external resource")
+	cmd.Flags().StringVarP(&createOpts.notes, "notes", "", "", "path to a markdown file with the notes of the external resource")
+	return cmd
+}
+
+func runCreate(ctx context.Context, control externalControl, namespace, name string, opts *createOptions) error {
+	if len(opts.endpoints) == 0 {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("there must be at least one endpoint available for the external resource"),
+			Hint: "Use the '--endpoint name=url' flag to define its endpoints",
+		}
+	}
+
+	_, err := control.Get(ctx, name, namespace)
+	if err == nil {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("external resource '%s' already exists in namespace '%s'", name, namespace),
+			Hint: "Run 'okteto external update' to modify it",
+		}
+	}
+	if !errors.Is(err, oktetoErrors.ErrNotFound) {
+		return err
+	}
+
+	er := &externalresource.ExternalResource{
+		Icon: opts.icon,
+	}
+	er.Endpoints, err = parseEndpoints(opts.endpoints)
+	if err != nil {
+		return err
+	}
+	if opts.notes != "" {
+		er.Notes, err = loadNotes(opts.notes)
+		if err != nil {
+			return err
+		}
+	}
+	return control.Deploy(ctx, name, namespace, er)
+}
@@ -0,0 +1,124 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/externalresource"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/cobra"
+)
+
+// externalControl manages the external resources of a namespace
+type externalControl interface {
+	Deploy(ctx context.Context, name, ns string, er *externalresource.ExternalResource) error
+	Get(ctx context.Context, name, ns string) (*externalresource.ExternalResource, error)
+	List(ctx context.Context, ns, labelSelector string) ([]externalresource.ExternalResource, error)
+	Delete(ctx context.Context, name, ns string) error
+}
+
+// options are the flags shared by the external subcommands
+type options struct {
+	namespace  string
+	k8sContext string
+}
+
+// External groups the commands to manage the external resources of a namespace
+func External(ctx context.Context) *cobra.Command {
+	opts := &options{}
+	cmd := &cobra.Command{
+		Use:   "external",
+		Short: "Manage the external resources displayed in the Okteto UI for your namespace",
+	}
+	cmd.PersistentFlags().StringVarP(&opts.namespace, "namespace", "n", "", "overwrites the namespace where the external resources are managed")
+	cmd.PersistentFlags().StringVarP(&opts.k8sContext, "context", "c", "", "overwrites the context where the external resources are managed")
+
+	cmd.AddCommand(Create(ctx, opts))
+	cmd.AddCommand(Update(ctx, opts))
+	cmd.AddCommand(List(ctx, opts))
+	cmd.AddCommand(Delete(ctx, opts))
+	return cmd
+}
+
+// newExternalControl initializes the okteto context and returns the control for its namespace
+func newExternalControl(ctx context.Context, opts *options, show bool) (externalControl, string, error) {
+	ctxOptions := &contextCMD.ContextOptions{
+		Context:   opts.k8sContext,
+		Namespace: opts.namespace,
+		Show:      show,
+	}
+	if err := contextCMD.NewContextCommand().Run(ctx, ctxOptions); err != nil {
+		return nil, "", err
+	}
+
+	if !okteto.IsOkteto() {
+		return nil, "", oktetoErrors.ErrContextIsNotOktetoCluster
+	}
+
+	if err := okteto.CheckCapability(ctx, okteto.ExternalResourcesCapability); err != nil {
+		return nil, "", err
+	}
+
+	_, cfg, err := okteto.GetK8sClient()
+	if err != nil {
+		return nil, "", fmt.Errorf("error getting kubernetes client: %w", err)
+	}
+	return externalresource.NewExternalK8sControl(cfg), okteto.Context().Namespace, nil
+}
+
+// parseEndpoints returns the endpoints defined with the format 'name=url'
+func parseEndpoints(values []string) ([]*externalresource.ExternalEndpoint, error) {
+	result := []*externalresource.ExternalEndpoint{}
+	names := map[string]bool{}
+	for _, value := range values {
+		name, endpointURL, found := strings.Cut(value, "=")
+		name = strings.TrimSpace(name)
+		endpointURL = strings.TrimSpace(endpointURL)
+		if !found || name == "" || endpointURL == "" {
+			return nil, fmt.Errorf("invalid endpoint '%s': the format must be 'name=url'", value)
+		}
+		if u, err := url.ParseRequestURI(endpointURL); err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("invalid endpoint '%s': '%s' is not a valid url", value, endpointURL)
+		}
+		if names[name] {
+			return nil, fmt.Errorf("invalid endpoint '%s': the endpoint '%s' is duplicated", value, name)
+		}
+		names[name] = true
+		result = append(result, &externalresource.ExternalEndpoint{
+			Name: name,
+			Url:  endpointURL,
+		})
+	}
+	return result, nil
+}
+
+// loadNotes returns the notes of an external resource from a markdown file
+func loadNotes(path string) (*externalresource.Notes, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading notes file '%s': %w", path, err)
+	}
+	return &externalresource.Notes{
+		Path:     path,
+		Markdown: base64.StdEncoding.EncodeToString(b),
+	}, nil
+}
@@ -0,0 +1,222 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/externalresource"
+	"github.com/okteto/okteto/pkg/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeExternalControl struct {
+	externals map[string]*externalresource.ExternalResource
+}
+
+func (f *fakeExternalControl) Deploy(_ context.Context, name, _ string, er *externalresource.ExternalResource) error {
+	f.externals[name] = er
+	return nil
+}
+
+func (f *fakeExternalControl) Get(_ context.Context, name, _ string) (*externalresource.ExternalResource, error) {
+	er, ok := f.externals[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: external resource '%s'", oktetoErrors.ErrNotFound, name)
+	}
+	return er, nil
+}
+
+func (f *fakeExternalControl) List(_ context.Context, _, _ string) ([]externalresource.ExternalResource, error) {
+	result := []externalresource.ExternalResource{}
+	for _, er := range f.externals {
+		result = append(result, *er)
+	}
+	return result, nil
+}
+
+func (f *fakeExternalControl) Delete(_ context.Context, name, _ string) error {
+	delete(f.externals, name)
+	return nil
+}
+
+func Test_parseEndpoints(t *testing.T) {
+	tests := []struct {
+		name        string
+		values      []string
+		expected    []*externalresource.ExternalEndpoint
+		expectedErr bool
+	}{
+		{
+			name:   "valid",
+			values: []string{"api=https://api.okteto.dev", " docs = https://docs.okteto.dev/path?a=b"},
+			expected: []*externalresource.ExternalEndpoint{
+				{Name: "api", Url: "https://api.okteto.dev"},
+				{Name: "docs", Url: "https://docs.okteto.dev/path?a=b"},
+			},
+		},
+		{
+			name:        "missing url",
+			values:      []string{"api"},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid url",
+			values:      []string{"api=okteto"},
+			expectedErr: true,
+		},
+		{
+			name:        "duplicated",
+			values:      []string{"api=https://a.okteto.dev", "api=https://b.okteto.dev"},
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseEndpoints(tt.values)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_runCreate(t *testing.T) {
+	ctx := context.Background()
+	notes := filepath.Join(t.TempDir(), "notes.md")
+	require.NoError(t, os.WriteFile(notes, []byte("# Lambda"), 0600))
+
+	control := &fakeExternalControl{externals: map[string]*externalresource.ExternalResource{}}
+	err := runCreate(ctx, control, "ns", "functions", &createOptions{
+		icon:      "function",
+		notes:     notes,
+		endpoints: []string{"api=https://lambda.aws.com"},
+	})
+	require.NoError(t, err)
+
+	er := control.externals["functions"]
+	require.NotNil(t, er)
+	assert.Equal(t, "function", er.Icon)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("# Lambda")), er.Notes.Markdown)
+	assert.Equal(t, []*externalresource.ExternalEndpoint{{Name: "api", Url: "https://lambda.aws.com"}}, er.Endpoints)
+
+	err = runCreate(ctx, control, "ns", "functions", &createOptions{endpoints: []string{"api=https://lambda.aws.com"}})
+	assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+
+	err = runCreate(ctx, control, "ns", "other", &createOptions{})
+	assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+}
+
+func Test_runUpdate(t *testing.T) {
+	ctx := context.Background()
+	control := &fakeExternalControl{externals: map[string]*externalresource.ExternalResource{
+		"functions": {
+			Icon: "function",
+			Endpoints: []*externalresource.ExternalEndpoint{
+				{Name: "api", Url: "https://old.aws.com"},
+				{Name: "legacy", Url: "https://legacy.aws.com"},
+			},
+		},
+	}}
+	icon := "database"
+	err := runUpdate(ctx, control, "ns", "functions", &updateOptions{
+		icon:            &icon,
+		endpoints:       []string{"api=https://new.aws.com", "admin=https://admin.aws.com"},
+		removeEndpoints: []string{"legacy"},
+	})
+	require.NoError(t, err)
+
+	er := control.externals["functions"]
+	assert.Equal(t, "database", er.Icon)
+	assert.Equal(t, []*externalresource.ExternalEndpoint{
+		{Name: "api", Url: "https://new.aws.com"},
+		{Name: "admin", Url: "https://admin.aws.com"},
+	}, er.Endpoints)
+
+	err = runUpdate(ctx, control, "ns", "functions", &updateOptions{removeEndpoints: []string{"api", "admin"}})
+	assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+
+	err = runUpdate(ctx, control, "ns", "not-found", &updateOptions{})
+	assert.ErrorIs(t, err, oktetoErrors.ErrNotFound)
+}
+
+func Test_printExternals(t *testing.T) {
+	externals := getExternalsOutput([]externalresource.ExternalResource{
+		{
+			Name: "functions",
+			Endpoints: []*externalresource.ExternalEndpoint{
+				{Name: "api", Url: "https://lambda.aws.com"},
+			},
+		},
+		{
+			Name: "bucket",
+			Icon: "storage",
+			Endpoints: []*externalresource.ExternalEndpoint{
+				{Name: "console", Url: "https://s3.aws.com"},
+				{Name: "cdn", Url: "https://cdn.aws.com"},
+			},
+		},
+	})
+
+	tests := []struct {
+		name     string
+		output   string
+		expected string
+	}{
+		{
+			name:   "default",
+			output: "",
+			expected: `Name       Endpoints
+bucket     console: https://s3.aws.com, cdn: https://cdn.aws.com
+functions  api: https://lambda.aws.com
+`,
+		},
+		{
+			name:   "yaml",
+			output: "yaml",
+			expected: `- name: bucket
+  icon: storage
+  endpoints:
+  - name: console
+    url: https://s3.aws.com
+  - name: cdn
+    url: https://cdn.aws.com
+- name: functions
+  endpoints:
+  - name: api
+    url: https://lambda.aws.com
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			require.NoError(t, printExternals(&b, externals, tt.output))
+			assert.Equal(t, tt.expected, b.String())
+		})
+	}
+
+	assert.ErrorIs(t, output.Validate("xml"), output.ErrInvalidFormat)
+}
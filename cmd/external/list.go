@@ -0,0 +1,107 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/externalresource"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+type externalOutput struct {
+	Name      string           `json:"name" yaml:"name"`
+	Icon      string           `json:"icon,omitempty" yaml:"icon,omitempty"`
+	Endpoints []endpointOutput `json:"endpoints" yaml:"endpoints"`
+}
+
+type endpointOutput struct {
+	Name string `json:"name" yaml:"name"`
+	URL  string `json:"url" yaml:"url"`
+}
+
+// List lists the external resources of a namespace
+func List(ctx context.Context, opts *options) *cobra.Command {
+	var outputFormat string
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   "List the external resources of your namespace",
+		Aliases: []string{"ls"},
+		Args:    utils.NoArgsAccepted(""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.Validate(outputFormat); err != nil {
+				return err
+			}
+			control, namespace, err := newExternalControl(ctx, opts, outputFormat == "")
+			if err != nil {
+				return err
+			}
+			externals, err := control.List(ctx, namespace, "")
+			if err != nil {
+				return err
+			}
+			return printExternals(os.Stdout, getExternalsOutput(externals), outputFormat)
+		},
+	}
+	output.AddFlag(cmd, &outputFormat)
+	return cmd
+}
+
+func getExternalsOutput(externals []externalresource.ExternalResource) []externalOutput {
+	result := []externalOutput{}
+	for _, er := range externals {
+		item := externalOutput{
+			Name:      er.Name,
+			Icon:      er.Icon,
+			Endpoints: []endpointOutput{},
+		}
+		for _, endpoint := range er.Endpoints {
+			item.Endpoints = append(item.Endpoints, endpointOutput{Name: endpoint.Name, URL: endpoint.Url})
+		}
+		result = append(result, item)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+func printExternals(w io.Writer, externals []externalOutput, outputFormat string) error {
+	return output.Print(w, outputFormat, externals, func(w io.Writer) error {
+		if len(externals) == 0 {
+			fmt.Fprintln(w, "There are no external resources")
+			return nil
+		}
+		table := oktetoLog.NewTable(
+			oktetoLog.Column{Header: "Name"},
+			oktetoLog.Column{Header: "Endpoints"},
+		)
+		for _, external := range externals {
+			endpoints := []string{}
+			for _, endpoint := range external.Endpoints {
+				endpoints = append(endpoints, fmt.Sprintf("%s: %s", endpoint.Name, endpoint.URL))
+			}
+			table.AddRow(external.Name, strings.Join(endpoints, ", "))
+		}
+		return table.Render(w, "")
+	})
+}
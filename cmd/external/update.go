@@ -0,0 +1,124 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/externalresource"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/spf13/cobra"
+)
+
+// updateOptions are the flags of the update command
+type updateOptions struct {
+	icon            *string
+	notes           string
+	endpoints       []string
+	removeEndpoints []string
+}
+
+// Update updates an existing external resource
+func Update(ctx context.Context, opts *options) *cobra.Command {
+	updateOpts := &updateOptions{}
+	var icon string
+	cmd := &cobra.Command{
+		Use:     "update <name>",
+		Short:   "Update an external resource",
+		Args:    utils.ExactArgsAccepted(1, ""),
+		Example: `okteto external update functions --endpoint api=https://my-new-lambda.aws.com --remove-endpoint legacy`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cmd.Flags().Changed("icon") {
+				updateOpts.icon = &icon
+			}
+			control, namespace, err := newExternalControl(ctx, opts, true)
+			if err != nil {
+				return err
+			}
+			if err := runUpdate(ctx, control, namespace, args[0], updateOpts); err != nil {
+				return err
+			}
+			oktetoLog.Success("External resource '%s' updated", args[0])
+			return nil
+		},
+	}
+	cmd.Flags().StringArrayVarP(&updateOpts.endpoints, "endpoint", "e", []string{}, "adds or replaces an endpoint of the external resource with the format 'name=url' (multiple --endpoint flags accepted)")
+	cmd.Flags().StringArrayVarP(&updateOpts.removeEndpoints, "remove-endpoint", "", []string{}, "name of an endpoint to remove from the external resource (multiple --remove-endpoint flags accepted)")
+	cmd.Flags().StringVarP(&icon, "icon", "", "", "icon displayed for the external resource")
+	cmd.Flags().StringVarP(&updateOpts.notes, "notes", "", "", "path to a markdown file with the notes of the external resource")
+	return cmd
+}
+
+func runUpdate(ctx context.Context, control externalControl, namespace, name string, opts *updateOptions) error {
+	er, err := control.Get(ctx, name, namespace)
+	if err != nil {
+		return err
+	}
+
+	if opts.icon != nil {
+		er.Icon = *opts.icon
+	}
+	if opts.notes != "" {
+		er.Notes, err = loadNotes(opts.notes)
+		if err != nil {
+			return err
+		}
+	}
+
+	endpoints, err := parseEndpoints(opts.endpoints)
+	if err != nil {
+		return err
+	}
+	er.Endpoints = mergeEndpoints(er.Endpoints, endpoints, opts.removeEndpoints)
+	if len(er.Endpoints) == 0 {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("there must be at least one endpoint available for the external resource"),
+			Hint: "Run 'okteto external delete' to delete it",
+		}
+	}
+	return control.Deploy(ctx, name, namespace, er)
+}
+
+// mergeEndpoints replaces the endpoints with the same name, appends the new ones and drops the removed ones
+func mergeEndpoints(current, updated []*externalresource.ExternalEndpoint, removed []string) []*externalresource.ExternalEndpoint {
+	toRemove := map[string]bool{}
+	for _, name := range removed {
+		toRemove[name] = true
+	}
+	updatedByName := map[string]*externalresource.ExternalEndpoint{}
+	for _, endpoint := range updated {
+		updatedByName[endpoint.Name] = endpoint
+	}
+
+	result := []*externalresource.ExternalEndpoint{}
+	for _, endpoint := range current {
+		if toRemove[endpoint.Name] {
+			continue
+		}
+		if e, ok := updatedByName[endpoint.Name]; ok {
+			endpoint = e
+			delete(updatedByName, endpoint.Name)
+		}
+		result = append(result, endpoint)
+	}
+	for _, endpoint := range updated {
+		if _, ok := updatedByName[endpoint.Name]; ok && !toRemove[endpoint.Name] {
+			result = append(result, endpoint)
+		}
+	}
+	return result
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forwards
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/config"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+// Enable starts the forwards of a profile in the running 'okteto up' session of a development container
+func Enable(ctx context.Context) *cobra.Command {
+	var namespace string
+	var k8sContext string
+	var devPath string
+
+	cmd := &cobra.Command{
+		Use:   "enable <profile> [devContainer]",
+		Short: "Enable a forward profile of your development container while 'okteto up' is running",
+		Args:  cobra.MatchAll(utils.MinimumNArgsAccepted(1, ""), utils.MaximumNArgsAccepted(2, "")),
+		RunE: func(_ *cobra.Command, args []string) error {
+			manifestOpts := contextCMD.ManifestOptions{Filename: devPath, Namespace: namespace, K8sContext: k8sContext}
+			manifest, err := contextCMD.LoadManifestWithContext(ctx, manifestOpts)
+			if err != nil {
+				return err
+			}
+
+			devName := ""
+			if len(args) == 2 {
+				devName = args[1]
+			}
+			dev, err := utils.GetDevFromManifest(manifest, devName)
+			if err != nil {
+				if !errors.Is(err, utils.ErrNoDevSelected) {
+					return err
+				}
+				selector := utils.NewOktetoSelector("Select the development container:", "Development container")
+				dev, err = utils.SelectDevFromManifest(manifest, selector, manifest.Dev.GetDevs())
+				if err != nil {
+					return err
+				}
+			}
+
+			if err := enableForwardProfile(dev, args[0]); err != nil {
+				return err
+			}
+			oktetoLog.Success("Forward profile '%s' enabled for '%s'", args[0], dev.Name)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&devPath, "file", "f", utils.DefaultManifest, "path to the manifest file")
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "namespace where the development container is running")
+	cmd.Flags().StringVarP(&k8sContext, "context", "c", "", "context where the development container is running")
+	return cmd
+}
+
+// enableForwardProfile requests the running 'okteto up' session of dev to start the forwards of the profile
+func enableForwardProfile(dev *model.Dev, profile string) error {
+	if _, err := dev.ForwardProfiles.Get(profile); err != nil {
+		hint := "Define it in the 'forwardProfiles' field of your development container"
+		if names := dev.ForwardProfiles.Names(); len(names) > 0 {
+			hint = fmt.Sprintf("Available forward profiles: %s", strings.Join(names, ", "))
+		}
+		return oktetoErrors.UserError{E: err, Hint: hint}
+	}
+
+	if _, err := config.GetState(dev.Name, dev.Namespace); err != nil {
+		return err
+	}
+
+	return config.EnableForwardProfile(dev.Name, dev.Namespace, profile)
+}
@@ -0,0 +1,53 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forwards
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/constants"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnableForwardProfile(t *testing.T) {
+	t.Setenv(constants.OktetoHomeEnvVar, t.TempDir())
+	dev := &model.Dev{
+		Name:      "dev",
+		Namespace: "ns",
+		ForwardProfiles: model.ForwardProfiles{
+			"debug": []forward.Forward{{Local: 2345, Remote: 2345}},
+			"db":    []forward.Forward{{Local: 5432, Remote: 5432}},
+		},
+	}
+
+	err := enableForwardProfile(dev, "cache")
+	var uErr oktetoErrors.UserError
+	require.ErrorAs(t, err, &uErr)
+	assert.Equal(t, "Available forward profiles: db, debug", uErr.Hint)
+
+	// okteto up is not running
+	assert.Error(t, enableForwardProfile(dev, "debug"))
+
+	require.NoError(t, config.UpdateStateFile(dev.Name, dev.Namespace, config.Ready))
+	require.NoError(t, enableForwardProfile(dev, "debug"))
+
+	profiles, err := config.GetEnabledForwardProfiles(dev.Name, dev.Namespace)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"debug"}, profiles)
+}
@@ -0,0 +1,329 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/format"
+	"github.com/okteto/okteto/pkg/k8s/deployments"
+	"github.com/okteto/okteto/pkg/k8s/ingressesv1"
+	"github.com/okteto/okteto/pkg/k8s/jobs"
+	"github.com/okteto/okteto/pkg/k8s/services"
+	"github.com/okteto/okteto/pkg/k8s/statefulsets"
+	"github.com/okteto/okteto/pkg/model"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// helmReleaseAnnotation is set by helm in every resource of a release
+	helmReleaseAnnotation = "meta.helm.sh/release-name"
+
+	notDeployedStatus = "not deployed"
+)
+
+// workload represents a deployed resource that runs pods
+type workload struct {
+	id        string
+	podLabels map[string]string
+	claims    []string
+}
+
+// getGraph computes the graph of a development environment from its manifest and the resources deployed in the cluster
+func getGraph(ctx context.Context, name string, manifest *model.Manifest, c kubernetes.Interface) (*graph, error) {
+	g := newGraph()
+	status, err := getStatus(ctx, name, manifest.Namespace, c)
+	if err != nil {
+		return nil, err
+	}
+	g.root = g.addNode(kindEnvironment, name, fmt.Sprintf("namespace: %s, %s", manifest.Namespace, status))
+
+	if err := addDependencies(ctx, g, manifest, c); err != nil {
+		return nil, err
+	}
+
+	parent := g.root
+	if manifest.Deploy != nil {
+		parent = g.addNode(kindDeploy, "", getDeployDetail(manifest.Deploy))
+		g.addEdge(g.root, parent, "deploys")
+	}
+	addImages(g, parent, manifest.Build)
+
+	if err := addHelmReleases(ctx, g, parent, name, manifest, c); err != nil {
+		return nil, err
+	}
+	if err := addResources(ctx, g, parent, name, manifest.Namespace, c); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// getStatus returns the status of the last deploy of a development environment
+func getStatus(ctx context.Context, name, namespace string, c kubernetes.Interface) (string, error) {
+	cfg, err := pipeline.GetConfigmapData(ctx, name, namespace, c)
+	if err != nil {
+		if oktetoErrors.IsNotFound(err) {
+			return notDeployedStatus, nil
+		}
+		return "", fmt.Errorf("could not get the status of '%s': %w", name, err)
+	}
+	return cfg.Status, nil
+}
+
+func getDeployDetail(deploy *model.DeployInfo) string {
+	details := []string{}
+	if deploy.ComposeSection != nil {
+		files := []string{}
+		for _, info := range deploy.ComposeSection.ComposesInfo {
+			files = append(files, info.File)
+		}
+		details = append(details, fmt.Sprintf("compose: %s", strings.Join(files, ", ")))
+	}
+	switch len(deploy.Commands) {
+	case 0:
+	case 1:
+		details = append(details, "1 command")
+	default:
+		details = append(details, fmt.Sprintf("%d commands", len(deploy.Commands)))
+	}
+	return strings.Join(details, ", ")
+}
+
+// addDependencies adds the dependencies of the manifest with the status of their last deploy
+func addDependencies(ctx context.Context, g *graph, manifest *model.Manifest, c kubernetes.Interface) error {
+	names := []string{}
+	for name := range manifest.Dependencies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		dep := manifest.Dependencies[name]
+		namespace := dep.Namespace
+		if namespace == "" {
+			namespace = manifest.Namespace
+		}
+		status, err := getStatus(ctx, name, namespace, c)
+		if err != nil {
+			return err
+		}
+		repository := dep.Repository
+		if dep.Branch != "" {
+			repository = fmt.Sprintf("%s@%s", repository, dep.Branch)
+		}
+		id := g.addNode(kindDependency, name, fmt.Sprintf("%s, %s", repository, status))
+		g.addEdge(g.root, id, "depends on")
+	}
+	return nil
+}
+
+// addImages adds the images of the build section. Images that other images depend on are added as their children
+func addImages(g *graph, parent string, manifestBuild build.ManifestBuild) {
+	names := []string{}
+	required := map[string]bool{}
+	for name, info := range manifestBuild {
+		names = append(names, name)
+		for _, dep := range info.DependsOn {
+			required[dep] = true
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		g.addNode(kindImage, name, manifestBuild[name].Image)
+	}
+	for _, name := range names {
+		id := nodeID(kindImage, name)
+		if !required[name] {
+			g.addEdge(parent, id, "builds")
+		}
+		for _, dep := range manifestBuild[name].DependsOn {
+			if g.hasNode(kindImage, dep) {
+				g.addEdge(id, nodeID(kindImage, dep), "depends on")
+			}
+		}
+	}
+}
+
+// addHelmReleases adds the helm releases installed by the development environment in install order
+func addHelmReleases(ctx context.Context, g *graph, parent, name string, manifest *model.Manifest, c kubernetes.Interface) error {
+	detected, err := pipeline.DetectHelmReleases(ctx, name, manifest.Namespace, c)
+	if err != nil {
+		return fmt.Errorf("could not get the helm releases of '%s': %w", name, err)
+	}
+	tracked, err := pipeline.GetHelmReleases(ctx, name, manifest.Namespace, c)
+	if err != nil {
+		return fmt.Errorf("could not get the helm releases of '%s': %w", name, err)
+	}
+	var declared []model.HelmRelease
+	if manifest.Deploy != nil {
+		declared = manifest.Deploy.HelmReleases
+	}
+	for _, r := range pipeline.MergeHelmReleases(tracked, detected, declared) {
+		id := g.addNode(kindHelmRelease, r.Name, r.Namespace)
+		g.addEdge(parent, id, "installs")
+	}
+	return nil
+}
+
+// addResources adds the resources deployed by the development environment.
+// Services are linked to the workloads they select, ingresses to the services they route to and volumes to the workloads that mount them.
+// Any other resource is linked to its helm release or to the given parent
+func addResources(ctx context.Context, g *graph, parent, name, namespace string, c kubernetes.Interface) error {
+	selector := fmt.Sprintf("%s=%s", model.DeployedByLabel, format.ResourceK8sMetaString(name))
+	owner := func(m metav1.ObjectMeta) string {
+		if release := m.Annotations[helmReleaseAnnotation]; release != "" && g.hasNode(kindHelmRelease, release) {
+			return nodeID(kindHelmRelease, release)
+		}
+		return parent
+	}
+
+	workloads := []workload{}
+	addWorkload := func(kind string, m metav1.ObjectMeta, spec apiv1.PodTemplateSpec) *workload {
+		id := g.addNode(kind, m.Name, "")
+		g.addEdge(owner(m), id, "deploys")
+		w := workload{id: id, podLabels: spec.Labels, claims: []string{}}
+		for _, v := range spec.Spec.Volumes {
+			if v.PersistentVolumeClaim != nil {
+				w.claims = append(w.claims, v.PersistentVolumeClaim.ClaimName)
+			}
+		}
+		workloads = append(workloads, w)
+		return &workloads[len(workloads)-1]
+	}
+
+	dList, err := deployments.List(ctx, namespace, selector, c)
+	if err != nil {
+		return fmt.Errorf("could not list the deployments of '%s': %w", name, err)
+	}
+	sort.Slice(dList, func(i, j int) bool { return dList[i].Name < dList[j].Name })
+	for _, d := range dList {
+		addWorkload("Deployment", d.ObjectMeta, d.Spec.Template)
+	}
+
+	sfsList, err := statefulsets.List(ctx, namespace, selector, c)
+	if err != nil {
+		return fmt.Errorf("could not list the statefulsets of '%s': %w", name, err)
+	}
+	sort.Slice(sfsList, func(i, j int) bool { return sfsList[i].Name < sfsList[j].Name })
+	for _, sfs := range sfsList {
+		w := addWorkload("StatefulSet", sfs.ObjectMeta, sfs.Spec.Template)
+		for _, tmpl := range sfs.Spec.VolumeClaimTemplates {
+			// the claims of a statefulset are named after the template, the statefulset and the ordinal of the pod
+			w.claims = append(w.claims, fmt.Sprintf("%s-%s-", tmpl.Name, sfs.Name))
+		}
+	}
+
+	jobList, err := jobs.List(ctx, namespace, selector, c)
+	if err != nil {
+		return fmt.Errorf("could not list the jobs of '%s': %w", name, err)
+	}
+	sort.Slice(jobList, func(i, j int) bool { return jobList[i].Name < jobList[j].Name })
+	for _, job := range jobList {
+		addWorkload("Job", job.ObjectMeta, job.Spec.Template)
+	}
+
+	svcList, err := services.List(ctx, namespace, selector, c)
+	if err != nil {
+		return fmt.Errorf("could not list the services of '%s': %w", name, err)
+	}
+	sort.Slice(svcList, func(i, j int) bool { return svcList[i].Name < svcList[j].Name })
+	for _, svc := range svcList {
+		id := g.addNode("Service", svc.Name, string(svc.Spec.Type))
+		linked := false
+		if len(svc.Spec.Selector) > 0 {
+			for _, w := range workloads {
+				if labels.SelectorFromSet(svc.Spec.Selector).Matches(labels.Set(w.podLabels)) {
+					g.addEdge(w.id, id, "exposed by")
+					linked = true
+				}
+			}
+		}
+		if !linked {
+			g.addEdge(owner(svc.ObjectMeta), id, "deploys")
+		}
+	}
+
+	iList, err := ingressesv1.List(ctx, namespace, selector, c)
+	if err != nil {
+		return fmt.Errorf("could not list the ingresses of '%s': %w", name, err)
+	}
+	sort.Slice(iList, func(i, j int) bool { return iList[i].Name < iList[j].Name })
+	for _, i := range iList {
+		id := g.addNode("Ingress", i.Name, "")
+		backends := []string{}
+		if i.Spec.DefaultBackend != nil && i.Spec.DefaultBackend.Service != nil {
+			backends = append(backends, i.Spec.DefaultBackend.Service.Name)
+		}
+		for _, rule := range i.Spec.Rules {
+			if rule.HTTP == nil {
+				continue
+			}
+			for _, path := range rule.HTTP.Paths {
+				if path.Backend.Service != nil {
+					backends = append(backends, path.Backend.Service.Name)
+				}
+			}
+		}
+		linked := false
+		for _, svcName := range backends {
+			if g.hasNode("Service", svcName) {
+				g.addEdge(nodeID("Service", svcName), id, "routed by")
+				linked = true
+			}
+		}
+		if !linked {
+			g.addEdge(owner(i.ObjectMeta), id, "deploys")
+		}
+	}
+
+	pvcList, err := pipeline.ListVolumes(ctx, name, namespace, c)
+	if err != nil {
+		return fmt.Errorf("could not list the volumes of '%s': %w", name, err)
+	}
+	sort.Slice(pvcList, func(i, j int) bool { return pvcList[i].Name < pvcList[j].Name })
+	for _, pvc := range pvcList {
+		id := g.addNode("PersistentVolumeClaim", pvc.Name, "")
+		linked := false
+		for _, w := range workloads {
+			if mountsClaim(w, pvc.Name) {
+				g.addEdge(w.id, id, "mounts")
+				linked = true
+			}
+		}
+		if !linked {
+			g.addEdge(owner(pvc.ObjectMeta), id, "deploys")
+		}
+	}
+	return nil
+}
+
+// mountsClaim returns if a workload mounts a persistent volume claim. Claims ending with '-' are the prefix of the claims of a statefulset
+func mountsClaim(w workload, pvcName string) bool {
+	for _, claim := range w.claims {
+		if claim == pvcName || (strings.HasSuffix(claim, "-") && strings.HasPrefix(pvcName, claim)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,200 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	"github.com/okteto/okteto/pkg/deps"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetGraph(t *testing.T) {
+	ctx := context.Background()
+	deployedBy := map[string]string{model.DeployedByLabel: "app"}
+	c := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "test", Labels: deployedBy},
+			Spec: appsv1.DeploymentSpec{
+				Template: apiv1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "api"}},
+					Spec: apiv1.PodSpec{
+						Volumes: []apiv1.Volume{
+							{
+								Name: "data",
+								VolumeSource: apiv1.VolumeSource{
+									PersistentVolumeClaim: &apiv1.PersistentVolumeClaimVolumeSource{ClaimName: "data"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "redis",
+				Namespace:   "test",
+				Labels:      deployedBy,
+				Annotations: map[string]string{helmReleaseAnnotation: "redis"},
+			},
+			Spec: appsv1.StatefulSetSpec{
+				Template: apiv1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "redis"}},
+				},
+				VolumeClaimTemplates: []apiv1.PersistentVolumeClaim{
+					{ObjectMeta: metav1.ObjectMeta{Name: "data"}},
+				},
+			},
+		},
+		&apiv1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "test", Labels: deployedBy},
+			Spec: apiv1.ServiceSpec{
+				Type:     apiv1.ServiceTypeClusterIP,
+				Selector: map[string]string{"app": "api"},
+			},
+		},
+		&networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "test", Labels: deployedBy},
+			Spec: networkingv1.IngressSpec{
+				DefaultBackend: &networkingv1.IngressBackend{
+					Service: &networkingv1.IngressServiceBackend{Name: "api"},
+				},
+			},
+		},
+		&apiv1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "test", Labels: deployedBy},
+		},
+		&apiv1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "data-redis-0", Namespace: "test"},
+		},
+		&apiv1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "test"},
+		},
+	)
+	_, err := pipeline.TranslateConfigMapAndDeploy(ctx, &pipeline.CfgData{
+		Name:      "app",
+		Namespace: "test",
+		Status:    pipeline.DeployedStatus,
+	}, c)
+	assert.NoError(t, err)
+
+	manifest := &model.Manifest{
+		Namespace: "test",
+		Dependencies: deps.ManifestSection{
+			"db": &deps.Dependency{Repository: "https://github.com/okteto/db", Branch: "main"},
+		},
+		Build: build.ManifestBuild{
+			"api":  &build.Info{Image: "okteto.dev/api", DependsOn: build.DependsOn{"base"}},
+			"base": &build.Info{},
+		},
+		Deploy: &model.DeployInfo{
+			Commands:     []model.DeployCommand{{Name: "deploy", Command: "helm upgrade --install redis chart"}},
+			HelmReleases: []model.HelmRelease{{Name: "redis"}},
+		},
+	}
+
+	g, err := getGraph(ctx, "app", manifest, c)
+	assert.NoError(t, err)
+
+	var b bytes.Buffer
+	assert.NoError(t, render(g, outputTree, &b))
+	expected := `Environment app (namespace: test, deployed)
+├── Dependency db (https://github.com/okteto/db@main, not deployed)
+└── Deploy (1 command)
+    ├── Image api (okteto.dev/api)
+    │   └── Image base
+    ├── HelmRelease redis
+    │   └── StatefulSet redis
+    │       └── PersistentVolumeClaim data-redis-0
+    └── Deployment api
+        ├── Service api (ClusterIP)
+        │   └── Ingress api
+        └── PersistentVolumeClaim data
+`
+	assert.Equal(t, expected, b.String())
+}
+
+func TestGetGraphWithoutDeploy(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset(
+		&apiv1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "db",
+				Namespace: "test",
+				Labels:    map[string]string{model.DeployedByLabel: "app"},
+			},
+		},
+	)
+	manifest := &model.Manifest{
+		Namespace: "test",
+		Build: build.ManifestBuild{
+			"api": &build.Info{},
+		},
+	}
+
+	g, err := getGraph(ctx, "app", manifest, c)
+	assert.NoError(t, err)
+	assert.Equal(t, []edge{
+		{from: "Environment/app", to: "Image/api", label: "builds"},
+		{from: "Environment/app", to: "Service/db", label: "deploys"},
+	}, g.edges)
+	assert.Equal(t, "Environment app (namespace: test, not deployed)", g.index[g.root].label())
+}
+
+func TestGetDeployDetail(t *testing.T) {
+	tests := []struct {
+		deploy   *model.DeployInfo
+		name     string
+		expected string
+	}{
+		{
+			name:     "empty",
+			deploy:   &model.DeployInfo{},
+			expected: "",
+		},
+		{
+			name: "commands",
+			deploy: &model.DeployInfo{
+				Commands: []model.DeployCommand{{Name: "a"}, {Name: "b"}},
+			},
+			expected: "2 commands",
+		},
+		{
+			name: "compose and commands",
+			deploy: &model.DeployInfo{
+				ComposeSection: &model.ComposeSectionInfo{
+					ComposesInfo: model.ComposeInfoList{{File: "docker-compose.yml"}},
+				},
+				Commands: []model.DeployCommand{{Name: "a"}},
+			},
+			expected: "compose: docker-compose.yml, 1 command",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getDeployDetail(tt.deploy))
+		})
+	}
+}
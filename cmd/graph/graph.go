@@ -0,0 +1,150 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/devenvironment"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/cobra"
+)
+
+// Options represents the options of the graph command
+type Options struct {
+	Name         string
+	ManifestPath string
+	Namespace    string
+	K8sContext   string
+	Output       string
+}
+
+// Command has the dependencies to run the graph command
+type Command struct {
+	k8sClientProvider okteto.K8sClientProvider
+	getManifest       func(path string) (*model.Manifest, error)
+}
+
+// NewCommand creates a graph command
+func NewCommand() *Command {
+	return &Command{
+		k8sClientProvider: okteto.NewK8sClientProvider(),
+		getManifest:       model.GetManifestV2,
+	}
+}
+
+// Graph shows the dependency and resource graph of a development environment
+func Graph(ctx context.Context) *cobra.Command {
+	options := &Options{}
+	cmd := &cobra.Command{
+		Use:   "graph",
+		Short: "Show the dependency and resource graph of your development environment",
+		Long: `Show the dependency and resource graph of your development environment.
+
+The graph includes the dependencies and the images defined in your okteto manifest, the helm releases installed by the deploy and the kubernetes resources deployed in the namespace.
+Use '--output dot' or '--output mermaid' to render it with Graphviz or Mermaid.`,
+		Args: utils.NoArgsAccepted("https://www.okteto.com/docs/reference/cli/#graph"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutput(options.Output); err != nil {
+				return err
+			}
+			if options.ManifestPath != "" {
+				workdir := model.GetWorkdirFromManifestPath(options.ManifestPath)
+				if err := os.Chdir(workdir); err != nil {
+					return err
+				}
+				options.ManifestPath = model.GetManifestPathFromWorkdir(options.ManifestPath, workdir)
+			}
+
+			ctxResource, err := utils.LoadManifestContext(options.ManifestPath)
+			if err != nil {
+				if !oktetoErrors.IsNotExist(err) {
+					return err
+				}
+				ctxResource = &model.ContextResource{}
+			}
+			if err := ctxResource.UpdateNamespace(options.Namespace); err != nil {
+				return err
+			}
+			if err := ctxResource.UpdateContext(options.K8sContext); err != nil {
+				return err
+			}
+			ctxOptions := &contextCMD.ContextOptions{
+				Context:   ctxResource.Context,
+				Namespace: ctxResource.Namespace,
+				Show:      options.Output == "",
+			}
+			if err := contextCMD.NewContextCommand().Run(ctx, ctxOptions); err != nil {
+				return err
+			}
+			return NewCommand().Run(ctx, options)
+		},
+	}
+	cmd.Flags().StringVar(&options.Name, "name", "", "development environment name")
+	cmd.Flags().StringVarP(&options.ManifestPath, "file", "f", "", "path to the okteto manifest file")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "overwrites the namespace where the development environment is deployed")
+	cmd.Flags().StringVarP(&options.K8sContext, "context", "c", "", "context where the development environment is deployed")
+	cmd.Flags().StringVarP(&options.Output, "output", "o", "", "output format. One of: ['tree', 'dot', 'mermaid']")
+	return cmd
+}
+
+// Run computes the graph of the development environment and writes it in the output format
+func (gc *Command) Run(ctx context.Context, opts *Options) error {
+	manifest, err := gc.getManifest(opts.ManifestPath)
+	if err != nil {
+		return err
+	}
+	manifest.Namespace = okteto.Context().Namespace
+
+	c, _, err := gc.k8sClientProvider.Provide(okteto.Context().Cfg)
+	if err != nil {
+		return err
+	}
+
+	name := opts.Name
+	if name == "" {
+		name = manifest.Name
+	}
+	if name == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get the current working directory: %w", err)
+		}
+		name = devenvironment.NewNameInferer(c).InferName(ctx, cwd, manifest.Namespace, opts.ManifestPath)
+	}
+
+	g, err := getGraph(ctx, name, manifest, c)
+	if err != nil {
+		return err
+	}
+	return render(g, opts.Output, os.Stdout)
+}
+
+func validateOutput(output string) error {
+	switch output {
+	case "", outputTree, outputDOT, outputMermaid:
+		return nil
+	default:
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("output format '%s' is not supported", output),
+			Hint: "Supported values are: ['tree', 'dot', 'mermaid']",
+		}
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import "fmt"
+
+const (
+	kindEnvironment = "Environment"
+	kindDependency  = "Dependency"
+	kindImage       = "Image"
+	kindDeploy      = "Deploy"
+	kindHelmRelease = "HelmRelease"
+)
+
+// node represents an element of a development environment
+type node struct {
+	id     string
+	kind   string
+	name   string
+	detail string
+}
+
+func (n *node) label() string {
+	result := n.kind
+	if n.name != "" {
+		result = fmt.Sprintf("%s %s", result, n.name)
+	}
+	if n.detail != "" {
+		result = fmt.Sprintf("%s (%s)", result, n.detail)
+	}
+	return result
+}
+
+// edge represents a relation between two nodes, from the parent to the child
+type edge struct {
+	from  string
+	to    string
+	label string
+}
+
+// graph is a directed graph of the elements of a development environment.
+// Nodes and edges keep their insertion order so the output is deterministic
+type graph struct {
+	index map[string]*node
+	root  string
+	nodes []*node
+	edges []edge
+}
+
+func newGraph() *graph {
+	return &graph{
+		index: map[string]*node{},
+		nodes: []*node{},
+		edges: []edge{},
+	}
+}
+
+func nodeID(kind, name string) string {
+	return fmt.Sprintf("%s/%s", kind, name)
+}
+
+// addNode adds a node to the graph if it doesn't exist and returns its id
+func (g *graph) addNode(kind, name, detail string) string {
+	id := nodeID(kind, name)
+	if _, ok := g.index[id]; ok {
+		return id
+	}
+	n := &node{id: id, kind: kind, name: name, detail: detail}
+	g.index[id] = n
+	g.nodes = append(g.nodes, n)
+	return id
+}
+
+// hasNode returns if the graph has a node with the given kind and name
+func (g *graph) hasNode(kind, name string) bool {
+	_, ok := g.index[nodeID(kind, name)]
+	return ok
+}
+
+// addEdge adds an edge between two nodes of the graph if it doesn't exist
+func (g *graph) addEdge(from, to, label string) {
+	for _, e := range g.edges {
+		if e.from == from && e.to == to {
+			return
+		}
+	}
+	g.edges = append(g.edges, edge{from: from, to: to, label: label})
+}
+
+// children returns the edges that start in the given node
+func (g *graph) children(id string) []edge {
+	result := []edge{}
+	for _, e := range g.edges {
+		if e.from == id {
+			result = append(result, e)
+		}
+	}
+	return result
+}
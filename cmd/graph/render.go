@@ -0,0 +1,101 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	outputTree    = "tree"
+	outputDOT     = "dot"
+	outputMermaid = "mermaid"
+)
+
+// render writes the graph in the given output format
+func render(g *graph, output string, w io.Writer) error {
+	var b strings.Builder
+	switch output {
+	case "", outputTree:
+		renderTree(g, &b)
+	case outputDOT:
+		renderDOT(g, &b)
+	case outputMermaid:
+		renderMermaid(g, &b)
+	default:
+		return fmt.Errorf("output format '%s' is not supported. Supported values are: ['tree', 'dot', 'mermaid']", output)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// renderTree writes the graph as a tree from its root.
+// Nodes with several parents are expanded only the first time they are written
+func renderTree(g *graph, b *strings.Builder) {
+	fmt.Fprintln(b, g.index[g.root].label())
+	visited := map[string]bool{g.root: true}
+	renderTreeChildren(g, g.root, "", visited, b)
+}
+
+func renderTreeChildren(g *graph, id, prefix string, visited map[string]bool, b *strings.Builder) {
+	children := g.children(id)
+	for i, e := range children {
+		connector, childPrefix := "├── ", "│   "
+		if i == len(children)-1 {
+			connector, childPrefix = "└── ", "    "
+		}
+		n := g.index[e.to]
+		if visited[e.to] {
+			fmt.Fprintf(b, "%s%s%s (see above)\n", prefix, connector, n.label())
+			continue
+		}
+		visited[e.to] = true
+		fmt.Fprintf(b, "%s%s%s\n", prefix, connector, n.label())
+		renderTreeChildren(g, e.to, prefix+childPrefix, visited, b)
+	}
+}
+
+// renderDOT writes the graph in the Graphviz DOT language
+func renderDOT(g *graph, b *strings.Builder) {
+	fmt.Fprintf(b, "digraph %s {\n", dotQuote(g.index[g.root].name))
+	fmt.Fprintln(b, "  rankdir=LR;")
+	fmt.Fprintln(b, "  node [shape=box];")
+	for _, n := range g.nodes {
+		fmt.Fprintf(b, "  %s [label=%s];\n", dotQuote(n.id), dotQuote(n.label()))
+	}
+	for _, e := range g.edges {
+		fmt.Fprintf(b, "  %s -> %s [label=%s];\n", dotQuote(e.from), dotQuote(e.to), dotQuote(e.label))
+	}
+	fmt.Fprintln(b, "}")
+}
+
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(s, `"`, `\"`))
+}
+
+// renderMermaid writes the graph as a Mermaid flowchart
+func renderMermaid(g *graph, b *strings.Builder) {
+	ids := map[string]string{}
+	fmt.Fprintln(b, "graph LR")
+	for i, n := range g.nodes {
+		ids[n.id] = fmt.Sprintf("n%d", i)
+		fmt.Fprintf(b, "  %s[\"%s\"]\n", ids[n.id], strings.ReplaceAll(n.label(), `"`, "#quot;"))
+	}
+	for _, e := range g.edges {
+		fmt.Fprintf(b, "  %s -->|%s| %s\n", ids[e.from], e.label, ids[e.to])
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestGraph() *graph {
+	g := newGraph()
+	g.root = g.addNode(kindEnvironment, "app", "namespace: test")
+	deploy := g.addNode(kindDeploy, "", "")
+	g.addEdge(g.root, deploy, "deploys")
+	api := g.addNode("Deployment", "api", "")
+	worker := g.addNode("Deployment", "worker", "")
+	svc := g.addNode("Service", "api", `say "hi"`)
+	g.addEdge(deploy, api, "deploys")
+	g.addEdge(deploy, worker, "deploys")
+	g.addEdge(api, svc, "exposed by")
+	g.addEdge(worker, svc, "exposed by")
+	g.addEdge(worker, svc, "exposed by")
+	return g
+}
+
+func TestRenderTree(t *testing.T) {
+	var b bytes.Buffer
+	assert.NoError(t, render(newTestGraph(), "", &b))
+	expected := `Environment app (namespace: test)
+└── Deploy
+    ├── Deployment api
+    │   └── Service api (say "hi")
+    └── Deployment worker
+        └── Service api (say "hi") (see above)
+`
+	assert.Equal(t, expected, b.String())
+}
+
+func TestRenderDOT(t *testing.T) {
+	var b bytes.Buffer
+	assert.NoError(t, render(newTestGraph(), outputDOT, &b))
+	expected := `digraph "app" {
+  rankdir=LR;
+  node [shape=box];
+  "Environment/app" [label="Environment app (namespace: test)"];
+  "Deploy/" [label="Deploy"];
+  "Deployment/api" [label="Deployment api"];
+  "Deployment/worker" [label="Deployment worker"];
+  "Service/api" [label="Service api (say \"hi\")"];
+  "Environment/app" -> "Deploy/" [label="deploys"];
+  "Deploy/" -> "Deployment/api" [label="deploys"];
+  "Deploy/" -> "Deployment/worker" [label="deploys"];
+  "Deployment/api" -> "Service/api" [label="exposed by"];
+  "Deployment/worker" -> "Service/api" [label="exposed by"];
+}
+`
+	assert.Equal(t, expected, b.String())
+}
+
+func TestRenderMermaid(t *testing.T) {
+	var b bytes.Buffer
+	assert.NoError(t, render(newTestGraph(), outputMermaid, &b))
+	expected := `graph LR
+  n0["Environment app (namespace: test)"]
+  n1["Deploy"]
+  n2["Deployment api"]
+  n3["Deployment worker"]
+  n4["Service api (say #quot;hi#quot;)"]
+  n0 -->|deploys| n1
+  n1 -->|deploys| n2
+  n1 -->|deploys| n3
+  n2 -->|exposed by| n4
+  n3 -->|exposed by| n4
+`
+	assert.Equal(t, expected, b.String())
+}
+
+func TestRenderWrongOutput(t *testing.T) {
+	var b bytes.Buffer
+	assert.Error(t, render(newTestGraph(), "svg", &b))
+	assert.Error(t, validateOutput("svg"))
+	assert.NoError(t, validateOutput(outputMermaid))
+}
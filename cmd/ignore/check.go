@@ -0,0 +1,79 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ignore
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/ignore"
+	"github.com/spf13/cobra"
+)
+
+// Check explains if a file is excluded by the .oktetoignore file
+func Check() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check <path>",
+		Short: "Explain why a file is excluded by the .oktetoignore file",
+		Long: `Explain why a file is excluded by the .oktetoignore file.
+
+The rules of the .oktetoignore file apply to the file synchronization of "okteto up", the build context hashing and the context uploaded by remote deploys`,
+		Args: utils.ExactArgsAccepted(1, ""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			wd, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("failed to get the current working directory: %w", err)
+			}
+			oktetoIgnore, err := ignore.NewFromFile(filepath.Join(wd, ignore.Filename))
+			if err != nil {
+				return err
+			}
+			return check(cmd.OutOrStdout(), oktetoIgnore, wd, args[0])
+		},
+	}
+	return cmd
+}
+
+func check(w io.Writer, oktetoIgnore *ignore.Ignore, projectDir, file string) error {
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(projectDir, file)
+	}
+	rel, err := filepath.Rel(projectDir, file)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("'%s' is outside of the project folder '%s'", file, projectDir),
+			Hint: "Run 'okteto ignore check' from the folder containing the .oktetoignore file",
+		}
+	}
+	rel = filepath.ToSlash(rel)
+
+	rule, err := oktetoIgnore.Explain(rel)
+	if err != nil {
+		return fmt.Errorf("failed to check '%s': %w", rel, err)
+	}
+	switch {
+	case rule == nil:
+		fmt.Fprintf(w, "'%s' is not ignored: no rule of %s matches it\n", rel, ignore.Filename)
+	case rule.Negated:
+		fmt.Fprintf(w, "'%s' is not ignored: included by '%s' (%s:%d)\n", rel, rule.String(), ignore.Filename, rule.Line)
+	default:
+		fmt.Fprintf(w, "'%s' is ignored by '%s' (%s:%d)\n", rel, rule.String(), ignore.Filename, rule.Line)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ignore
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/ignore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_check(t *testing.T) {
+	oktetoIgnore, err := ignore.Parse([]byte("# comments are skipped\nnode_modules\n*.log\n!important.log\n"))
+	require.NoError(t, err)
+	projectDir := t.TempDir()
+
+	tests := []struct {
+		name     string
+		file     string
+		expected string
+	}{
+		{
+			name:     "ignored",
+			file:     "node_modules/lib/index.js",
+			expected: "'node_modules/lib/index.js' is ignored by 'node_modules' (.oktetoignore:2)\n",
+		},
+		{
+			name:     "included by a negated rule",
+			file:     "important.log",
+			expected: "'important.log' is not ignored: included by '!important.log' (.oktetoignore:4)\n",
+		},
+		{
+			name:     "no rule",
+			file:     "main.go",
+			expected: "'main.go' is not ignored: no rule of .oktetoignore matches it\n",
+		},
+		{
+			name:     "absolute path",
+			file:     filepath.Join(projectDir, "debug.log"),
+			expected: "'debug.log' is ignored by '*.log' (.oktetoignore:3)\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			require.NoError(t, check(out, oktetoIgnore, projectDir, tt.file))
+			assert.Equal(t, tt.expected, out.String())
+		})
+	}
+}
+
+func Test_checkOutsideProject(t *testing.T) {
+	oktetoIgnore, err := ignore.Parse([]byte("*.log\n"))
+	require.NoError(t, err)
+	err = check(&bytes.Buffer{}, oktetoIgnore, t.TempDir(), "../other/debug.log")
+	assert.ErrorContains(t, err, "outside of the project folder")
+}
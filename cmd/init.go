@@ -42,6 +42,16 @@ func Init(at analyticsTrackerInterface, ioCtrl *io.IOController) *cobra.Command
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
 
+			if opts.Template != "" {
+				cwd, err := os.Getwd()
+				if err != nil {
+					return err
+				}
+				opts.Workdir = cwd
+				mc := &manifest.ManifestCommand{}
+				return mc.RunInitFromTemplate(ctx, opts)
+			}
+
 			ctxResource := &model.ContextResource{}
 			if err := ctxResource.UpdateNamespace(opts.Namespace); err != nil {
 				return err
@@ -90,5 +100,6 @@ func Init(at analyticsTrackerInterface, ioCtrl *io.IOController) *cobra.Command
 	cmd.Flags().BoolVarP(&opts.Version1, "v1", "", false, "create a v1 okteto manifest: www.okteto.com/docs/0.10/reference/manifest/")
 	cmd.Flags().BoolVarP(&opts.AutoDeploy, "deploy", "", false, "deploy the application after generate the okteto manifest")
 	cmd.Flags().BoolVarP(&opts.AutoConfigureDev, "configure-devs", "", false, "configure devs after deploying the application")
+	cmd.Flags().StringVarP(&opts.Template, "template", "", "", "generate the okteto manifest from a template without asking any question. One of: ['compose', 'node', 'golang', 'java', 'dockerfile']")
 	return cmd
 }
@@ -14,6 +14,9 @@
 package cmd
 
 import (
+	"errors"
+	"os"
+
 	"github.com/okteto/okteto/cmd/context"
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/okteto"
@@ -21,24 +24,41 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var errKubeconfigMergeAndPrint = errors.New("flags '--merge' and '--print' can't be used together")
+
 // oktetoClientProvider provides an okteto client ready to use or fail
 type oktetoClientProvider interface {
 	Provide(...okteto.Option) (types.OktetoInterface, error)
 }
 
+type kubeconfigOptions struct {
+	merge bool
+	print bool
+}
+
 // Kubeconfig fetch credentials for a cluster namespace
 func Kubeconfig(okClientProvider oktetoClientProvider) *cobra.Command {
+	options := &kubeconfigOptions{}
 	cmd := &cobra.Command{
 		Use:   "kubeconfig",
 		Short: "Download credentials for the Kubernetes cluster selected via 'okteto context'",
 		Long: `Download credentials for the Kubernetes cluster selected via 'okteto context'.
 
 Generated kubeconfig file uses a credential plugin to get the cluster credentials via Okteto backend that requires the Okteto CLI to be in the PATH. Learn more about how to use the Kubernetes credentials at https://www.okteto.com/docs/cloud/credentials/#using-your-kubernetes-credentials.
+
+By default, the credentials are merged into your kubeconfig file. Use '--print' to write a kubeconfig with only the current context to the standard output, e.g. to use it in tools like Lens or k9s:
+
+    okteto kubeconfig --print > okteto.kubeconfig
 `,
 		Args: utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#kubeconfig"),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return context.UpdateKubeconfigCMD(okClientProvider).RunE(cmd, args)
+			if options.merge && options.print {
+				return errKubeconfigMergeAndPrint
+			}
+			return context.ExecuteKubeconfig(cmd.Context(), okClientProvider, options.print, os.Stdout)
 		},
 	}
+	cmd.Flags().BoolVarP(&options.merge, "merge", "", false, "merge the credentials of the current context into your kubeconfig file (default behavior)")
+	cmd.Flags().BoolVarP(&options.print, "print", "", false, "print a kubeconfig with the credentials of the current context instead of updating your kubeconfig file")
 	return cmd
 }
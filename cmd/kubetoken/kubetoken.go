@@ -170,6 +170,10 @@ func (kc *KubetokenCmd) Run(ctx context.Context, flags KubetokenFlags) error {
 
 	out, err := c.Kubetoken().GetKubeToken(ctxResource.Context, ctxResource.Namespace)
 	if err != nil {
+		// older okteto versions fail with cryptic errors, explain it if the version is the reason
+		if capErr := okteto.NewCapabilityChecker(c.User(), ctxResource.Namespace).Check(ctx, ctxResource.Context, okteto.KubetokenCapability); capErr != nil {
+			return capErr
+		}
 		return fmt.Errorf("failed to get the kubetoken: %w", err)
 	}
 
@@ -18,8 +18,8 @@ import (
 	"testing"
 
 	contextCMD "github.com/okteto/okteto/cmd/context"
-	"github.com/okteto/okteto/internal/test/client"
 	"github.com/okteto/okteto/pkg/okteto"
+	client "github.com/okteto/okteto/pkg/okteto/fake"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/stretchr/testify/assert"
 	"k8s.io/client-go/kubernetes"
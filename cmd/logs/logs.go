@@ -47,10 +47,14 @@ type LogsOptions struct {
 	exclude      string
 	Include      string
 	Name         string
+	Container    string
+	Output       string
 	Since        time.Duration
 	Tail         int64
 	Timestamps   bool
 	All          bool
+	Self         bool
+	Clean        bool
 }
 
 func Logs(ctx context.Context) *cobra.Command {
@@ -60,6 +64,16 @@ func Logs(ctx context.Context) *cobra.Command {
 		Use:   "logs",
 		Short: "Fetch the logs of your development environment",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if options.Clean && !options.Self {
+				return errors.UserError{
+					E:    fmt.Errorf("the flag 'clean' can only be used with the flag 'self'"),
+					Hint: "Run 'okteto logs --self --clean' to remove the logs of the okteto commands",
+				}
+			}
+			if options.Self {
+				return runSelfLogs(os.Stdout, config.GetInvocationLogsDir(), args, options)
+			}
+
 			ctx, cancel := context.WithCancel(ctx)
 			defer cancel()
 
@@ -129,10 +143,14 @@ func Logs(ctx context.Context) *cobra.Command {
 	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "the namespace to use to fetch the logs (defaults to the current okteto namespace)")
 	cmd.Flags().StringVarP(&options.Context, "context", "c", "", "the context to use to fetch the logs")
 	cmd.Flags().StringVarP(&options.exclude, "exclude", "e", "", "exclude by service name (regular expression)")
+	cmd.Flags().StringVar(&options.Container, "container", "", "filter by container name (regular expression)")
+	cmd.Flags().StringVarP(&options.Output, "output", "o", "", "output format. One of: ['json']")
 	cmd.Flags().DurationVarP(&options.Since, "since", "s", defaultSinceOptionHoursValue*time.Hour, "return logs newer than a relative duration like 5s, 2m, or 3h")
 	cmd.Flags().Int64Var(&options.Tail, "tail", defaultTailOptionValue, "the number of lines from the end of the logs to show")
 	cmd.Flags().BoolVarP(&options.Timestamps, "timestamps", "t", false, "print timestamps")
 	cmd.Flags().StringVar(&options.Name, "name", "", "development environment name")
+	cmd.Flags().BoolVar(&options.Self, "self", false, "list the logs of the okteto commands run in this machine, or show the log of a command run by its id ('last' for the most recent one)")
+	cmd.Flags().BoolVar(&options.Clean, "clean", false, "remove the logs of the okteto commands, used with the flag 'self'")
 
 	return cmd
 }
@@ -0,0 +1,93 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/output"
+)
+
+// runSelfLogs lists, shows or cleans the logs of the okteto commands run in this machine
+func runSelfLogs(w io.Writer, dir string, args []string, options *LogsOptions) error {
+	if options.Clean {
+		if err := oktetoLog.CleanInvocationLogs(dir); err != nil {
+			return fmt.Errorf("failed to clean the command logs: %w", err)
+		}
+		oktetoLog.Success("Command logs successfully cleaned")
+		return nil
+	}
+
+	if len(args) > 0 {
+		invocation, err := oktetoLog.GetInvocation(dir, args[0])
+		if err != nil {
+			return err
+		}
+		return printLogFile(w, invocation.LogFile, options.Tail)
+	}
+
+	invocations, err := oktetoLog.ListInvocations(dir)
+	if err != nil {
+		return fmt.Errorf("failed to list the command logs: %w", err)
+	}
+	return printInvocations(w, invocations, options.Output)
+}
+
+func printInvocations(w io.Writer, invocations []oktetoLog.Invocation, outputFormat string) error {
+	return output.Print(w, outputFormat, invocations, func(w io.Writer) error {
+		if len(invocations) == 0 {
+			fmt.Fprintln(w, "There are no command logs")
+			return nil
+		}
+		tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+		fmt.Fprintf(tw, "ID\tSTARTED\tDURATION\tEXIT CODE\tCOMMAND\n")
+		for _, i := range invocations {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\n", i.ID, i.StartedAt.Format(time.DateTime), i.Duration.Round(time.Millisecond), i.ExitCode, strings.Join(append([]string{i.Command}, i.Args...), " "))
+		}
+		return tw.Flush()
+	}, output.WithJSONIndent("  "))
+}
+
+// printLogFile prints the last lines of a log file. It prints the whole file when tail is lower than 1
+func printLogFile(w io.Writer, path string, tail int64) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	lines := []string{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+		if tail > 0 && int64(len(lines)) > tail {
+			lines = lines[1:]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	for _, l := range lines {
+		fmt.Fprintln(w, l)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunSelfLogs(t *testing.T) {
+	dir := t.TempDir()
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, runSelfLogs(buf, dir, nil, &LogsOptions{}))
+	assert.Equal(t, "There are no command logs\n", buf.String())
+
+	require.NoError(t, oktetoLog.StartInvocationLog(dir, "1.0.0", "okteto deploy", []string{"deploy"}))
+	logPath := oktetoLog.GetInvocationLogPath()
+	oktetoLog.FinishInvocationLog(0, nil)
+	require.NoError(t, os.WriteFile(logPath, []byte("line 1\nline 2\nline 3\n"), 0600))
+
+	buf.Reset()
+	require.NoError(t, runSelfLogs(buf, dir, nil, &LogsOptions{}))
+	assert.Contains(t, buf.String(), "okteto deploy deploy")
+	assert.Contains(t, buf.String(), filepath.Base(logPath[:len(logPath)-len(".log")]))
+
+	buf.Reset()
+	require.NoError(t, runSelfLogs(buf, dir, []string{"last"}, &LogsOptions{Tail: 2}))
+	assert.Equal(t, "line 2\nline 3\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, runSelfLogs(buf, dir, nil, &LogsOptions{Output: "json"}))
+	assert.Contains(t, buf.String(), "\"command\": \"okteto deploy\"")
+
+	buf.Reset()
+	require.NoError(t, runSelfLogs(buf, dir, nil, &LogsOptions{Output: "yaml"}))
+	assert.Contains(t, buf.String(), "command: okteto deploy")
+
+	assert.Error(t, runSelfLogs(buf, dir, nil, &LogsOptions{Output: "xml"}))
+
+	require.NoError(t, runSelfLogs(buf, dir, nil, &LogsOptions{Clean: true}))
+	assert.NoFileExists(t, logPath)
+}
@@ -14,6 +14,7 @@
 package logs
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"regexp"
@@ -63,18 +64,16 @@ func getSternConfig(manifest *model.Manifest, o *LogsOptions, kubeconfigFile str
 	}
 	labelSelector = labelSelector.Add(*req)
 
-	funs := map[string]interface{}{
-		"color": func(color color.Color, text string) string {
-			return color.SprintFunc()(text)
-		},
-	}
-	t := "{{color .PodColor .PodName}} {{color .ContainerColor .ContainerName}} {{.Message}}\n"
-	tmpl, err := template.New("logs").Funcs(funs).Parse(t)
+	tmpl, err := getLogTemplate(o.Output)
 	if err != nil {
 		return nil, err
 	}
 
-	containerQuery, err := regexp.Compile(".*")
+	containerQueryValue := ".*"
+	if o.Container != "" {
+		containerQueryValue = o.Container
+	}
+	containerQuery, err := regexp.Compile(containerQueryValue)
 	if err != nil {
 		return nil, fmt.Errorf("failed to compile regular expression for container query: %w", err)
 	}
@@ -109,3 +108,30 @@ func getSternConfig(manifest *model.Manifest, o *LogsOptions, kubeconfigFile str
 		Out:                 os.Stdout,
 	}, nil
 }
+
+// getLogTemplate returns the template used to print each log line: a colored prefix with the pod and container names or a json object per line
+func getLogTemplate(output string) (*template.Template, error) {
+	funs := map[string]interface{}{
+		"color": func(color color.Color, text string) string {
+			return color.SprintFunc()(text)
+		},
+		"json": func(l stern.Log) (string, error) {
+			bytes, err := json.Marshal(l)
+			if err != nil {
+				return "", err
+			}
+			return string(bytes), nil
+		},
+	}
+
+	var t string
+	switch output {
+	case "":
+		t = "{{color .PodColor .PodName}} {{color .ContainerColor .ContainerName}} {{.Message}}\n"
+	case "json":
+		t = "{{json .}}\n"
+	default:
+		return nil, fmt.Errorf("output format '%s' is not supported. Supported values are: ['json']", output)
+	}
+	return template.New("logs").Funcs(funs).Parse(t)
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/stern/stern/stern"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLogTemplate(t *testing.T) {
+	l := stern.Log{
+		Message:       "listening on port 8080",
+		Namespace:     "test",
+		PodName:       "api-123",
+		ContainerName: "api",
+	}
+
+	tmpl, err := getLogTemplate("json")
+	require.NoError(t, err)
+	var b bytes.Buffer
+	require.NoError(t, tmpl.Execute(&b, l))
+	assert.Equal(t, `{"message":"listening on port 8080","nodeName":"","namespace":"test","podName":"api-123","containerName":"api"}`+"\n", b.String())
+
+	_, err = getLogTemplate("")
+	assert.NoError(t, err)
+
+	_, err = getLogTemplate("yaml")
+	assert.Error(t, err)
+}
+
+func TestGetSternConfigContainer(t *testing.T) {
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		CurrentContext: "test",
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {Name: "test"},
+		},
+	}
+	manifest := &model.Manifest{Name: "movies", Namespace: "test"}
+
+	c, err := getSternConfig(manifest, &LogsOptions{Include: ".*", Container: "^api$"}, "kubeconfig")
+	require.NoError(t, err)
+	assert.True(t, c.ContainerQuery.MatchString("api"))
+	assert.False(t, c.ContainerQuery.MatchString("api-sidecar"))
+
+	c, err = getSternConfig(manifest, &LogsOptions{Include: ".*"}, "kubeconfig")
+	require.NoError(t, err)
+	assert.True(t, c.ContainerQuery.MatchString("api-sidecar"))
+
+	_, err = getSternConfig(manifest, &LogsOptions{Include: ".*", Container: "("}, "kubeconfig")
+	assert.Error(t, err)
+}
@@ -0,0 +1,217 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	okHttp "github.com/okteto/okteto/pkg/http"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	yaml3 "gopkg.in/yaml.v3"
+)
+
+const (
+	// catalogFile is the file that defines the templates of a dev catalog
+	catalogFile = "catalog.yml"
+
+	catalogTimeout = 30 * time.Second
+)
+
+// catalogClient downloads the catalog files served over http
+var catalogClient = &http.Client{Transport: okHttp.SharedDefaultTransport()}
+
+// devCatalog represents the dev templates shared by the platform team of an organization
+type devCatalog struct {
+	Templates []catalogTemplate `yaml:"templates"`
+}
+
+type catalogTemplate struct {
+	Dev         yaml3.Node `yaml:"dev"`
+	Name        string     `yaml:"name"`
+	Description string     `yaml:"description"`
+	Marker      string     `yaml:"marker"`
+}
+
+// catalogFetcher returns the content of the catalog file of a catalog url
+type catalogFetcher func(ctx context.Context, url, token string) ([]byte, error)
+
+// getAvailableTemplates returns the templates of the dev catalog of the current context followed by the builtin ones.
+// Catalog templates replace the builtin templates with the same name
+func getAvailableTemplates(ctx context.Context, fetch catalogFetcher) []*manifestTemplate {
+	templates := []*manifestTemplate{}
+	url, token := getCurrentDevCatalog()
+	if url != "" {
+		catalogTemplates, err := loadDevCatalog(ctx, url, token, fetch)
+		if err != nil {
+			oktetoLog.Warning("Could not load the dev catalog '%s': %s", url, err)
+		}
+		templates = append(templates, catalogTemplates...)
+	}
+
+	for i := range manifestTemplates {
+		overridden := false
+		for _, tmpl := range templates {
+			if tmpl.name == manifestTemplates[i].name {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			templates = append(templates, &manifestTemplates[i])
+		}
+	}
+	return templates
+}
+
+// getCurrentDevCatalog returns the dev catalog configured in the current context, if any,
+// and the token to authenticate against it
+func getCurrentDevCatalog() (string, string) {
+	store := okteto.ContextStore()
+	okCtx, ok := store.Contexts[store.CurrentContext]
+	if !ok || okCtx == nil {
+		return "", ""
+	}
+	if !isContextCatalog(okCtx.DevCatalog, okCtx.Name) {
+		return okCtx.DevCatalog, ""
+	}
+	return okCtx.DevCatalog, okCtx.Token
+}
+
+// isContextCatalog returns if the catalog is served over https by the okteto instance of the context.
+// The token of the context is not sent to any other catalog
+func isContextCatalog(catalogURL, contextURL string) bool {
+	catalog, err := neturl.Parse(catalogURL)
+	if err != nil || catalog.Scheme != "https" {
+		return false
+	}
+	okCtx, err := neturl.Parse(contextURL)
+	if err != nil || okCtx.Hostname() == "" {
+		return false
+	}
+	return strings.EqualFold(catalog.Hostname(), okCtx.Hostname()) && catalog.Port() == okCtx.Port()
+}
+
+func loadDevCatalog(ctx context.Context, url, token string, fetch catalogFetcher) ([]*manifestTemplate, error) {
+	ctx, cancel := context.WithTimeout(ctx, catalogTimeout)
+	defer cancel()
+	b, err := fetch(ctx, url, token)
+	if err != nil {
+		return nil, err
+	}
+	return parseDevCatalog(b)
+}
+
+// parseDevCatalog translates the templates of a catalog file into manifest templates
+func parseDevCatalog(b []byte) ([]*manifestTemplate, error) {
+	catalog := &devCatalog{}
+	if err := yaml3.Unmarshal(b, catalog); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", catalogFile, err)
+	}
+	result := []*manifestTemplate{}
+	for _, t := range catalog.Templates {
+		if t.Name == "" {
+			return nil, fmt.Errorf("invalid %s: every template must have a name", catalogFile)
+		}
+		if t.Dev.Kind != yaml3.MappingNode {
+			return nil, fmt.Errorf("invalid %s: the dev section of the template '%s' must be an object", catalogFile, t.Name)
+		}
+		dev, err := yaml3.Marshal(&t.Dev)
+		if err != nil {
+			return nil, err
+		}
+		description := t.Description
+		if description == "" {
+			description = t.Name
+		}
+		result = append(result, &manifestTemplate{
+			name:        t.Name,
+			description: description,
+			marker:      t.Marker,
+			catalogDev:  indent(string(dev), "    "),
+		})
+	}
+	return result, nil
+}
+
+func indent(text, prefix string) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// fetchDevCatalog downloads the catalog file from a git repository or from an http endpoint
+func fetchDevCatalog(ctx context.Context, url, token string) ([]byte, error) {
+	if isGitCatalog(url) {
+		return fetchGitCatalog(ctx, url)
+	}
+	return fetchHTTPCatalog(ctx, url, token)
+}
+
+func isGitCatalog(url string) bool {
+	return strings.HasSuffix(url, ".git") || strings.HasPrefix(url, "git@") || strings.HasPrefix(url, "ssh://")
+}
+
+func fetchGitCatalog(ctx context.Context, url string) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "okteto-catalog-")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			oktetoLog.Infof("could not remove the dev catalog folder: %s", err)
+		}
+	}()
+
+	if _, err := git.PlainCloneContext(ctx, dir, false, &git.CloneOptions{URL: url, Depth: 1}); err != nil {
+		return nil, fmt.Errorf("could not clone the catalog repository: %w", err)
+	}
+	b, err := os.ReadFile(filepath.Join(dir, catalogFile))
+	if err != nil {
+		return nil, fmt.Errorf("could not read %s from the catalog repository: %w", catalogFile, err)
+	}
+	return b, nil
+}
+
+func fetchHTTPCatalog(ctx context.Context, url, token string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
+	resp, err := catalogClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response from the catalog endpoint: %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
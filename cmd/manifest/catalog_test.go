@@ -0,0 +1,212 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+var testCatalog = []byte(`templates:
+  - name: node
+    description: Node.js services of the platform team
+    marker: package.json
+    dev:
+      image: myorg/node-dev:20
+      command: bash
+      resources:
+        limits:
+          cpu: "2"
+          memory: 4Gi
+      forward:
+        - 9229:9229
+  - name: python
+    dev:
+      image: myorg/python-dev:3
+`)
+
+func setDevCatalogContext(t *testing.T, catalog, token string) {
+	t.Helper()
+	previous := okteto.CurrentStore
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		Contexts: map[string]*okteto.OktetoContext{
+			"https://catalog.okteto.dev": {
+				Name:       "https://catalog.okteto.dev",
+				Token:      token,
+				DevCatalog: catalog,
+			},
+		},
+		CurrentContext: "https://catalog.okteto.dev",
+	}
+	t.Cleanup(func() {
+		okteto.CurrentStore = previous
+	})
+}
+
+func TestParseDevCatalog(t *testing.T) {
+	templates, err := parseDevCatalog(testCatalog)
+	require.NoError(t, err)
+	require.Len(t, templates, 2)
+	assert.Equal(t, "node", templates[0].name)
+	assert.Equal(t, "package.json", templates[0].marker)
+	assert.Equal(t, "python", templates[1].description)
+
+	content, err := renderManifestTemplate(templates[0], templateData{Name: "api", DeployCommand: model.FakeCommand})
+	require.NoError(t, err)
+	m, err := model.Read(content)
+	require.NoError(t, err)
+	require.Contains(t, m.Dev, "api")
+	assert.Equal(t, "myorg/node-dev:20", m.Dev["api"].Image.Name)
+	memory := m.Dev["api"].Resources.Limits[apiv1.ResourceMemory]
+	assert.Equal(t, "4Gi", memory.String())
+	assert.Len(t, m.Dev["api"].Forward, 1)
+}
+
+func TestParseDevCatalogErrors(t *testing.T) {
+	var tests = []struct {
+		name    string
+		catalog string
+	}{
+		{
+			name:    "invalid yaml",
+			catalog: "templates: [",
+		},
+		{
+			name:    "missing name",
+			catalog: "templates:\n  - dev:\n      image: node\n",
+		},
+		{
+			name:    "missing dev",
+			catalog: "templates:\n  - name: node\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseDevCatalog([]byte(tt.catalog))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestGetAvailableTemplates(t *testing.T) {
+	setDevCatalogContext(t, "https://catalog.okteto.dev/catalog.yml", "token")
+	fetch := func(_ context.Context, url, token string) ([]byte, error) {
+		assert.Equal(t, "https://catalog.okteto.dev/catalog.yml", url)
+		assert.Equal(t, "token", token)
+		return testCatalog, nil
+	}
+
+	templates := getAvailableTemplates(context.Background(), fetch)
+	assert.Equal(t, []string{"node", "python", composeTemplate, golangTemplate, javaTemplate, dockerfileTemplate}, getTemplateNames(templates))
+
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/app/package.json", []byte(""), 0600))
+	detected := detectTemplates(templates, "/app", fs)
+	assert.Equal(t, []string{"node", "python"}, getTemplateNames(detected))
+	assert.NotEmpty(t, detected[0].catalogDev)
+}
+
+func TestGetAvailableTemplatesExternalCatalog(t *testing.T) {
+	setDevCatalogContext(t, "https://templates.example.com/catalog.yml", "token")
+	fetch := func(_ context.Context, _, token string) ([]byte, error) {
+		assert.Empty(t, token)
+		return testCatalog, nil
+	}
+	templates := getAvailableTemplates(context.Background(), fetch)
+	assert.Equal(t, "node", templates[0].name)
+}
+
+func TestGetAvailableTemplatesCatalogError(t *testing.T) {
+	setDevCatalogContext(t, "https://catalog.okteto.dev/catalog.yml", "")
+	fetch := func(_ context.Context, _, _ string) ([]byte, error) {
+		return nil, errors.New("connection refused")
+	}
+	templates := getAvailableTemplates(context.Background(), fetch)
+	assert.Equal(t, getTemplateNames(builtinTemplates()), getTemplateNames(templates))
+}
+
+func TestFetchHTTPCatalog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write(testCatalog)
+	}))
+	defer server.Close()
+
+	b, err := fetchDevCatalog(context.Background(), server.URL, "token")
+	require.NoError(t, err)
+	assert.Equal(t, testCatalog, b)
+
+	_, err = fetchDevCatalog(context.Background(), server.URL, "")
+	assert.ErrorContains(t, err, "401")
+}
+
+func TestIsGitCatalog(t *testing.T) {
+	assert.True(t, isGitCatalog("https://github.com/okteto/catalog.git"))
+	assert.True(t, isGitCatalog("git@github.com:okteto/catalog.git"))
+	assert.False(t, isGitCatalog("https://okteto.example.com/api/catalog"))
+}
+
+func TestIsContextCatalog(t *testing.T) {
+	tests := []struct {
+		name       string
+		catalogURL string
+		contextURL string
+		expected   bool
+	}{
+		{
+			name:       "same host over https",
+			catalogURL: "https://okteto.example.com/api/catalog",
+			contextURL: "https://okteto.example.com",
+			expected:   true,
+		},
+		{
+			name:       "same host over http",
+			catalogURL: "http://okteto.example.com/api/catalog",
+			contextURL: "https://okteto.example.com",
+		},
+		{
+			name:       "other host",
+			catalogURL: "https://catalog.example.com/catalog.yml",
+			contextURL: "https://okteto.example.com",
+		},
+		{
+			name:       "other port",
+			catalogURL: "https://okteto.example.com:8443/api/catalog",
+			contextURL: "https://okteto.example.com",
+		},
+		{
+			name:       "context without url",
+			catalogURL: "https://okteto.example.com/api/catalog",
+			contextURL: "okteto_example",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isContextCatalog(tt.catalogURL, tt.contextURL))
+		})
+	}
+}
@@ -30,9 +30,11 @@ import (
 	"github.com/okteto/okteto/pkg/build"
 	initCMD "github.com/okteto/okteto/pkg/cmd/init"
 	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/constants"
 	"github.com/okteto/okteto/pkg/devenvironment"
 	"github.com/okteto/okteto/pkg/discovery"
+	"github.com/okteto/okteto/pkg/filesystem"
 	"github.com/okteto/okteto/pkg/k8s/apps"
 	"github.com/okteto/okteto/pkg/linguist"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
@@ -63,6 +65,7 @@ type InitOpts struct {
 	Context   string
 	Language  string
 	Workdir   string
+	Template  string
 
 	Overwrite bool
 	ShowCTA   bool
@@ -91,6 +94,15 @@ func (mc *ManifestCommand) RunInitV2(ctx context.Context, opts *InitOpts) (*mode
 	}
 
 	if manifest == nil || len(manifest.Build) == 0 || manifest.Deploy == nil {
+		if oktetoLog.IsInteractive() {
+			tmpl, err := askForTemplate(getAvailableTemplates(ctx, fetchDevCatalog), opts.Workdir, afero.NewOsFs())
+			if err != nil {
+				return nil, err
+			}
+			if tmpl != nil {
+				return mc.initFromSelectedTemplate(tmpl, opts)
+			}
+		}
 		manifest, err = mc.configureManifestDeployAndBuild(opts.Workdir)
 		if err != nil {
 			return nil, err
@@ -99,7 +111,7 @@ func (mc *ManifestCommand) RunInitV2(ctx context.Context, opts *InitOpts) (*mode
 
 	if manifest != nil {
 		mc.manifest = manifest
-		manifest.Name = os.Getenv(constants.OktetoNameEnvVar)
+		manifest.Name = config.EnvOktetoName.Value()
 		if opts.Namespace == "" {
 			manifest.Namespace = ""
 		}
@@ -180,6 +192,37 @@ func (mc *ManifestCommand) RunInitV2(ctx context.Context, opts *InitOpts) (*mode
 	return manifest, nil
 }
 
+// initFromSelectedTemplate writes the manifest of the template selected by the user and loads it
+func (mc *ManifestCommand) initFromSelectedTemplate(tmpl *manifestTemplate, opts *InitOpts) (*model.Manifest, error) {
+	fs := afero.NewOsFs()
+	templateOpts := *opts
+	overwrite, err := confirmManifestOverwrite(opts, fs, utils.AskYesNo)
+	if err != nil {
+		return nil, err
+	}
+	templateOpts.Overwrite = overwrite
+	if err := writeManifestTemplate(tmpl, &templateOpts, fs); err != nil {
+		return nil, err
+	}
+	manifest, err := model.GetManifestV2(opts.DevPath)
+	if err != nil {
+		return nil, err
+	}
+	mc.manifest = manifest
+	if opts.ShowCTA {
+		oktetoLog.Information("Review the generated manifest and run 'okteto up' to activate your development container")
+	}
+	return manifest, nil
+}
+
+// confirmManifestOverwrite asks the user before replacing an existing manifest, unless the overwrite flag is set
+func confirmManifestOverwrite(opts *InitOpts, fs afero.Fs, ask func(string, utils.YesNoDefault) (bool, error)) (bool, error) {
+	if opts.Overwrite || !filesystem.FileExistsWithFilesystem(getManifestPath(opts), fs) {
+		return opts.Overwrite, nil
+	}
+	return ask(fmt.Sprintf("%s already exists. Do you want to overwrite it?", opts.DevPath), utils.YesNoDefault_No)
+}
+
 func (*ManifestCommand) configureManifestDeployAndBuild(cwd string) (*model.Manifest, error) {
 
 	composeFiles := utils.GetStackFiles(cwd)
@@ -0,0 +1,156 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/discovery"
+	"github.com/okteto/okteto/pkg/filesystem"
+	"github.com/okteto/okteto/pkg/ignore"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/registry"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+// defaultPushIgnorePatterns are the files never pushed unless an ignore file includes them back
+var defaultPushIgnorePatterns = []string{"**/.env", "**/node_modules"}
+
+// PushOptions are the options of the push command
+type PushOptions struct {
+	ManifestPath string
+	Namespace    string
+	K8sContext   string
+}
+
+// Push packages the okteto manifest as an OCI artifact and pushes it to a registry
+func Push() *cobra.Command {
+	options := &PushOptions{}
+	cmd := &cobra.Command{
+		Use:   "push REFERENCE",
+		Short: "Push the okteto manifest to a registry as an OCI artifact",
+		Long: `Push the okteto manifest to a registry as an OCI artifact.
+
+The artifact includes the okteto manifest and the files of its folder, like compose files, helm charts or kubernetes manifests.
+Version control folders, '.env' files, 'node_modules' folders and the files excluded by the '.dockerignore' and '.oktetoignore' files of the folder are not included.
+Run 'okteto deploy oci://REFERENCE' to deploy it without cloning the repository.
+
+Okteto doesn't sign the artifact. Sign the digest printed by this command with your own tooling, and deploy the digest to use an immutable version of it.`,
+		Example: "okteto manifest push okteto.dev/my-app:1.0.0",
+		Args:    utils.ExactArgsAccepted(1, ""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			ctxOptions := &contextCMD.ContextOptions{
+				Context:   options.K8sContext,
+				Namespace: options.Namespace,
+			}
+			if err := contextCMD.NewContextCommand().Run(ctx, ctxOptions); err != nil {
+				return err
+			}
+
+			manifestPath, err := getManifestPathToPush(options.ManifestPath, afero.NewOsFs())
+			if err != nil {
+				return err
+			}
+			if _, err := model.GetManifestV2(manifestPath); err != nil {
+				return err
+			}
+			artifact, err := packageManifest(manifestPath, afero.NewOsFs())
+			if err != nil {
+				return err
+			}
+
+			reference := strings.TrimPrefix(args[0], registry.ManifestArtifactScheme)
+			pushed, err := registry.NewOktetoRegistry(okteto.Config{}).PushManifestArtifact(reference, artifact)
+			if err != nil {
+				return err
+			}
+			oktetoLog.Success("Okteto manifest pushed to '%s'", pushed)
+			oktetoLog.Information("Run 'okteto deploy %s%s' to deploy it", registry.ManifestArtifactScheme, reference)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&options.ManifestPath, "file", "f", "", "path to the okteto manifest file")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "namespace used to resolve okteto.dev references")
+	cmd.Flags().StringVarP(&options.K8sContext, "context", "c", "", "context where the manifest is pushed")
+	return cmd
+}
+
+// getManifestPathToPush returns the path of the manifest of the flag or the one of the current folder
+func getManifestPathToPush(manifestPath string, fs afero.Fs) (string, error) {
+	if manifestPath != "" {
+		if !filesystem.FileExistsWithFilesystem(manifestPath, fs) {
+			return "", fmt.Errorf("%s file doesn't exist", manifestPath)
+		}
+		return manifestPath, nil
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get the current working directory: %w", err)
+	}
+	return discovery.GetOktetoManifestPathWithFilesystem(wd, fs)
+}
+
+// packageManifest returns the artifact with the folder of the manifest
+func packageManifest(manifestPath string, fs afero.Fs) (*registry.ManifestArtifact, error) {
+	abs, err := filepath.Abs(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Dir(abs)
+	rules, err := getPushIgnore(dir, fs)
+	if err != nil {
+		return nil, err
+	}
+	content, err := filesystem.ArchiveDirectory(fs, dir, rules.Ignores)
+	if err != nil {
+		return nil, err
+	}
+	return &registry.ManifestArtifact{
+		ManifestPath: filepath.Base(abs),
+		Content:      content,
+	}, nil
+}
+
+// getPushIgnore returns the rules of the files excluded from the artifact: the default ones, which usually hold secrets or dependencies,
+// followed by the rules of the .dockerignore and .oktetoignore files of the folder, which can include them back
+func getPushIgnore(dir string, fs afero.Fs) (*ignore.Ignore, error) {
+	content := []byte(strings.Join(defaultPushIgnorePatterns, "\n") + "\n")
+	for _, name := range []string{".dockerignore", ignore.Filename} {
+		b, err := afero.ReadFile(fs, filepath.Join(dir, name))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read '%s': %w", name, err)
+		}
+		content = append(content, b...)
+		content = append(content, '\n')
+	}
+	rules, err := ignore.Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ignore rules: %w", err)
+	}
+	return rules, nil
+}
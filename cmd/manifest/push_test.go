@@ -0,0 +1,81 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/filesystem"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageManifest(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	dir := filepath.Join("/", "app")
+	manifestPath := filepath.Join(dir, "okteto.prod.yml")
+	require.NoError(t, afero.WriteFile(fs, manifestPath, []byte("deploy: []"), 0600))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "docker-compose.yml"), []byte("services: {}"), 0600))
+
+	artifact, err := packageManifest(manifestPath, fs)
+	require.NoError(t, err)
+	assert.Equal(t, "okteto.prod.yml", artifact.ManifestPath)
+
+	dst := filepath.Join("/", "dst")
+	require.NoError(t, filesystem.ExtractArchive(fs, artifact.Content, dst))
+	assert.True(t, filesystem.FileExistsWithFilesystem(filepath.Join(dst, "okteto.prod.yml"), fs))
+	assert.True(t, filesystem.FileExistsWithFilesystem(filepath.Join(dst, "docker-compose.yml"), fs))
+}
+
+func TestPackageManifestIgnoredFiles(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	dir := filepath.Join("/", "app")
+	manifestPath := filepath.Join(dir, "okteto.yml")
+	require.NoError(t, afero.WriteFile(fs, manifestPath, []byte("deploy: []"), 0600))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, ".env"), []byte("TOKEN=secret"), 0600))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "api", ".env"), []byte("TOKEN=secret"), 0600))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "api", "node_modules", "index.js"), []byte(""), 0600))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "secrets", "key.pem"), []byte("key"), 0600))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "build.log"), []byte("log"), 0600))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "chart", "values.yaml"), []byte("replicas: 1"), 0600))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, ".dockerignore"), []byte("secrets\n"), 0600))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, ".oktetoignore"), []byte("*.log\n!api/.env\n"), 0600))
+
+	artifact, err := packageManifest(manifestPath, fs)
+	require.NoError(t, err)
+
+	dst := filepath.Join("/", "dst")
+	require.NoError(t, filesystem.ExtractArchive(fs, artifact.Content, dst))
+	assert.True(t, filesystem.FileExistsWithFilesystem(filepath.Join(dst, "okteto.yml"), fs))
+	assert.True(t, filesystem.FileExistsWithFilesystem(filepath.Join(dst, "chart", "values.yaml"), fs))
+	assert.True(t, filesystem.FileExistsWithFilesystem(filepath.Join(dst, "api", ".env"), fs))
+	assert.False(t, filesystem.FileExistsWithFilesystem(filepath.Join(dst, ".env"), fs))
+	assert.False(t, filesystem.FileExistsWithFilesystem(filepath.Join(dst, "api", "node_modules"), fs))
+	assert.False(t, filesystem.FileExistsWithFilesystem(filepath.Join(dst, "secrets"), fs))
+	assert.False(t, filesystem.FileExistsWithFilesystem(filepath.Join(dst, "build.log"), fs))
+}
+
+func TestGetManifestPathToPush(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "okteto.yml", []byte("deploy: []"), 0600))
+
+	path, err := getManifestPathToPush("okteto.yml", fs)
+	require.NoError(t, err)
+	assert.Equal(t, "okteto.yml", path)
+
+	_, err = getManifestPathToPush("missing.yml", fs)
+	assert.Error(t, err)
+}
@@ -0,0 +1,166 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/discovery"
+	"github.com/okteto/okteto/pkg/env"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v2"
+	yaml3 "gopkg.in/yaml.v3"
+)
+
+const redactedValue = "***"
+
+// RenderOptions are the options of the render command
+type RenderOptions struct {
+	ManifestPath string
+	Output       string
+	Redact       bool
+}
+
+// Render prints the manifest as okteto sees it
+func Render() *cobra.Command {
+	options := &RenderOptions{}
+	cmd := &cobra.Command{
+		Use:   "render",
+		Short: "Print the fully resolved okteto manifest",
+		Long: `Print the fully resolved okteto manifest.
+
+The manifest is printed after expanding the environment variables, applying the default values and processing the docker compose files and their extends.
+The personal okteto.override.yml file is merged over the manifest unless the --no-override flag is set.`,
+		Args: utils.NoArgsAccepted(""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateRenderOutput(options.Output); err != nil {
+				return err
+			}
+			manifest, err := getRenderedManifest(options.ManifestPath)
+			if err != nil {
+				return err
+			}
+			if options.Redact {
+				redactManifest(manifest)
+			}
+			return printManifest(os.Stdout, manifest, options.Output)
+		},
+	}
+	cmd.Flags().StringVarP(&options.ManifestPath, "file", "f", "", "path to the okteto manifest file")
+	cmd.Flags().StringVarP(&options.Output, "output", "o", "yaml", "output format. One of: ['yaml', 'json']")
+	cmd.Flags().BoolVarP(&options.Redact, "redact", "", false, "hide the values of variables, environment variables and build args")
+	return cmd
+}
+
+func validateRenderOutput(output string) error {
+	switch output {
+	case "yaml", "json":
+		return nil
+	}
+	return fmt.Errorf("output format '%s' is not supported. Supported values are: ['yaml', 'json']", output)
+}
+
+// getRenderedManifest loads the manifest the same way the rest of commands do and expands its variables
+func getRenderedManifest(manifestPath string) (*model.Manifest, error) {
+	manifest, err := model.GetManifestV1(manifestPath)
+	if err != nil {
+		if !errors.Is(err, discovery.ErrOktetoManifestNotFound) {
+			return nil, err
+		}
+		manifest, err = model.GetManifestV2(manifestPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := manifest.ExpandEnvVars(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// redactManifest hides the values that might contain credentials
+func redactManifest(manifest *model.Manifest) {
+	redactEnvironment(manifest.Variables)
+	for _, b := range manifest.Build {
+		redactBuildArgs(b)
+	}
+	for _, dev := range manifest.Dev {
+		redactDev(dev)
+	}
+	if manifest.Deploy != nil && manifest.Deploy.ComposeSection != nil && manifest.Deploy.ComposeSection.Stack != nil {
+		for _, svc := range manifest.Deploy.ComposeSection.Stack.Services {
+			for i := range svc.Environment {
+				svc.Environment[i].Value = redactedValue
+			}
+			redactBuildArgs(svc.Build)
+		}
+	}
+}
+
+func redactDev(dev *model.Dev) {
+	if dev == nil {
+		return
+	}
+	redactEnvironment(dev.Environment)
+	redactBuildArgs(dev.Image)
+	for _, svc := range dev.Services {
+		redactDev(svc)
+	}
+}
+
+func redactEnvironment(environment env.Environment) {
+	for i := range environment {
+		environment[i].Value = redactedValue
+	}
+}
+
+func redactBuildArgs(info *build.Info) {
+	if info == nil {
+		return
+	}
+	for i := range info.Args {
+		info.Args[i].Value = redactedValue
+	}
+}
+
+func printManifest(w io.Writer, manifest *model.Manifest, output string) error {
+	bytes, err := yaml.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to render the manifest: %w", err)
+	}
+	if output == "yaml" {
+		fmt.Fprint(w, string(bytes))
+		return nil
+	}
+
+	// the manifest is converted from its yaml representation to keep the same fields in both formats
+	var content interface{}
+	if err := yaml3.Unmarshal(bytes, &content); err != nil {
+		return fmt.Errorf("failed to render the manifest: %w", err)
+	}
+	bytes, err = json.MarshalIndent(content, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to render the manifest: %w", err)
+	}
+	fmt.Fprintln(w, string(bytes))
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/env"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const renderManifest = `name: movies
+build:
+  api:
+    context: api
+    args:
+      TOKEN: abc
+deploy:
+  - helm upgrade --install movies chart
+dev:
+  api:
+    image: ${API_IMAGE}
+    command: bash
+    environment:
+      PASSWORD: secret
+    sync:
+      - .:/usr/src/app
+`
+
+func Test_getRenderedManifest(t *testing.T) {
+	t.Setenv("API_IMAGE", "okteto/api:dev")
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "okteto.yml")
+	require.NoError(t, os.WriteFile(manifestPath, []byte(renderManifest), 0600))
+
+	manifest, err := getRenderedManifest(manifestPath)
+	require.NoError(t, err)
+	assert.Equal(t, "movies", manifest.Name)
+	require.Contains(t, manifest.Dev, "api")
+	assert.Equal(t, "okteto/api:dev", manifest.Dev["api"].Image.Name)
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, printManifest(buf, manifest, "yaml"))
+	assert.Contains(t, buf.String(), "name: okteto/api:dev")
+	assert.Contains(t, buf.String(), "PASSWORD=secret")
+
+	buf.Reset()
+	require.NoError(t, printManifest(buf, manifest, "json"))
+	var content map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &content))
+	assert.Equal(t, "movies", content["name"])
+	assert.Contains(t, content, "dev")
+}
+
+func Test_redactManifest(t *testing.T) {
+	manifest := &model.Manifest{
+		Variables: env.Environment{{Name: "API_KEY", Value: "secret"}},
+		Build: build.ManifestBuild{
+			"api": {Args: build.Args{{Name: "TOKEN", Value: "abc"}}},
+		},
+		Dev: model.ManifestDevs{
+			"api": {
+				Environment: env.Environment{{Name: "PASSWORD", Value: "secret"}},
+				Services: []*model.Dev{
+					{Environment: env.Environment{{Name: "WORKER_PASSWORD", Value: "secret"}}},
+				},
+			},
+		},
+	}
+
+	redactManifest(manifest)
+
+	assert.Equal(t, redactedValue, manifest.Variables[0].Value)
+	assert.Equal(t, redactedValue, manifest.Build["api"].Args[0].Value)
+	assert.Equal(t, redactedValue, manifest.Dev["api"].Environment[0].Value)
+	assert.Equal(t, redactedValue, manifest.Dev["api"].Services[0].Environment[0].Value)
+	assert.Equal(t, "API_KEY", manifest.Variables[0].Name)
+}
+
+func Test_validateRenderOutput(t *testing.T) {
+	assert.NoError(t, validateRenderOutput("yaml"))
+	assert.NoError(t, validateRenderOutput("json"))
+	assert.Error(t, validateRenderOutput("table"))
+}
@@ -0,0 +1,333 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/discovery"
+	"github.com/okteto/okteto/pkg/filesystem"
+	"github.com/okteto/okteto/pkg/format"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/afero"
+)
+
+const (
+	nodeTemplate       = "node"
+	golangTemplate     = "golang"
+	javaTemplate       = "java"
+	composeTemplate    = "compose"
+	dockerfileTemplate = "dockerfile"
+
+	noTemplateOption = "No, continue without a template"
+)
+
+// manifestTemplate represents a commented okteto manifest for a given framework
+type manifestTemplate struct {
+	name        string
+	description string
+	// marker is the file that identifies the framework in the root of the repository
+	marker string
+	dev    string
+	// catalogDev is the dev section of a template of the dev catalog, used as is
+	catalogDev string
+}
+
+// templateData are the values inferred from the repository to render a template
+type templateData struct {
+	Name          string
+	Dockerfile    string
+	Compose       string
+	DeployCommand string
+	Dev           string
+}
+
+// manifestTemplates are the available templates, sorted by detection priority
+var manifestTemplates = []manifestTemplate{
+	{
+		name:        composeTemplate,
+		description: "Docker Compose",
+		dev: `  # Run 'okteto up <service>' to develop any of the services defined in your compose file.
+  # Add a dev section here to override the default development container of a service.
+`,
+	},
+	{
+		name:        nodeTemplate,
+		description: "Node.js",
+		marker:      "package.json",
+		dev: `  {{ .Name }}:
+    image: okteto/node:14
+    command: bash
+    workdir: /usr/src/app
+    sync:
+      - .:/usr/src/app
+    forward:
+      # debugger port
+      - 9229:9229
+      - 3000:3000
+`,
+	},
+	{
+		name:        golangTemplate,
+		description: "Go",
+		marker:      "go.mod",
+		dev: `  {{ .Name }}:
+    image: okteto/golang:1
+    command: bash
+    workdir: /usr/src/app
+    sync:
+      - .:/usr/src/app
+    volumes:
+      # persist the go module and build caches between 'okteto up' sessions
+      - /go/pkg/
+      - /root/.cache/go-build/
+    securityContext:
+      capabilities:
+        add:
+          - SYS_PTRACE
+    forward:
+      # debugger port
+      - 2345:2345
+      - 8080:8080
+`,
+	},
+	{
+		name:        javaTemplate,
+		description: "Java (Maven)",
+		marker:      "pom.xml",
+		dev: `  {{ .Name }}:
+    image: okteto/maven:3
+    command: bash
+    workdir: /usr/src/app
+    sync:
+      - .:/usr/src/app
+    volumes:
+      # persist the maven repository between 'okteto up' sessions
+      - /root/.m2
+    forward:
+      # debugger port
+      - 5005:5005
+      - 8080:8080
+`,
+	},
+	{
+		name:        dockerfileTemplate,
+		description: "Dockerfile",
+		marker:      dockerfileName,
+		dev: `  {{ .Name }}:
+    image: {{ if .Dockerfile }}${OKTETO_BUILD_{{ .Name | upper }}_IMAGE}{{ else }}okteto/dev:latest{{ end }}
+    command: sh
+    sync:
+      - .:/usr/src/app
+`,
+	},
+}
+
+const manifestTemplateContent = `# Okteto manifest generated by 'okteto init'
+# Reference: https://www.okteto.com/docs/reference/manifest/
+
+# The build section defines how to build the images of your development environment
+{{- if .Dockerfile }}
+build:
+  {{ .Name }}:
+    context: .
+    dockerfile: {{ .Dockerfile }}
+{{- else }}
+# build:
+#   {{ .Name }}:
+#     context: .
+#     dockerfile: Dockerfile
+{{- end }}
+
+# The deploy section defines how to deploy your development environment
+deploy:
+{{- if .Compose }}
+  compose: {{ .Compose }}
+{{- else }}
+  commands:
+    - name: Deploy
+      command: {{ printf "%q" .DeployCommand }}
+{{- end }}
+
+# The dev section defines how to activate a development container
+dev:
+{{ .Dev -}}
+`
+
+// getManifestTemplate returns the template for a given name
+func getManifestTemplate(templates []*manifestTemplate, name string) (*manifestTemplate, error) {
+	names := []string{}
+	for _, tmpl := range templates {
+		if tmpl.name == name {
+			return tmpl, nil
+		}
+		names = append(names, tmpl.name)
+	}
+	return nil, fmt.Errorf("template '%s' not found. Available templates: %s", name, strings.Join(names, ", "))
+}
+
+// detectTemplates returns the templates that match the files of the repository.
+// Templates of the dev catalog without marker are always suggested
+func detectTemplates(templates []*manifestTemplate, cwd string, fs afero.Fs) []*manifestTemplate {
+	result := []*manifestTemplate{}
+	for _, tmpl := range templates {
+		switch {
+		case tmpl.name == composeTemplate:
+			if _, err := discovery.GetComposePathWithFilesystem(cwd, fs); err == nil {
+				result = append(result, tmpl)
+			}
+		case tmpl.marker == "":
+			if tmpl.catalogDev != "" {
+				result = append(result, tmpl)
+			}
+		default:
+			if filesystem.FileExistsWithFilesystem(filepath.Join(cwd, tmpl.marker), fs) {
+				result = append(result, tmpl)
+			}
+		}
+	}
+	return result
+}
+
+// getTemplateData infers the values to render a template from the files of the repository
+func getTemplateData(tmpl *manifestTemplate, cwd string, fs afero.Fs) templateData {
+	data := templateData{
+		Name:          format.ResourceK8sMetaString(filepath.Base(cwd)),
+		DeployCommand: model.FakeCommand,
+	}
+	if filesystem.FileExistsWithFilesystem(filepath.Join(cwd, dockerfileName), fs) {
+		data.Dockerfile = dockerfileName
+	}
+
+	if tmpl.name == composeTemplate {
+		if path, err := discovery.GetComposePathWithFilesystem(cwd, fs); err == nil {
+			data.Compose = relativeTo(cwd, path)
+			return data
+		}
+	}
+	if path, err := discovery.GetHelmChartPathWithFilesystem(cwd, fs); err == nil {
+		data.DeployCommand = fmt.Sprintf("helm upgrade --install %s %s", data.Name, relativeTo(cwd, path))
+	} else if path, err := discovery.GetK8sManifestPathWithFilesystem(cwd, fs); err == nil {
+		data.DeployCommand = fmt.Sprintf("kubectl apply -f %s", relativeTo(cwd, path))
+	}
+	return data
+}
+
+func relativeTo(cwd, path string) string {
+	rel, err := filepath.Rel(cwd, path)
+	if err != nil {
+		oktetoLog.Infof("could not get relative path: %s", err)
+		return path
+	}
+	return rel
+}
+
+// renderManifestTemplate returns the commented okteto manifest of a template
+func renderManifestTemplate(tmpl *manifestTemplate, data templateData) ([]byte, error) {
+	funcs := template.FuncMap{
+		"upper": func(s string) string {
+			return strings.ToUpper(strings.ReplaceAll(s, "-", "_"))
+		},
+	}
+	if tmpl.catalogDev != "" {
+		data.Dev = fmt.Sprintf("  %s:\n%s", data.Name, tmpl.catalogDev)
+	} else {
+		dev, err := executeTemplate(tmpl.dev, data, funcs)
+		if err != nil {
+			return nil, err
+		}
+		data.Dev = dev
+	}
+	content, err := executeTemplate(manifestTemplateContent, data, funcs)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(content), nil
+}
+
+func executeTemplate(text string, data templateData, funcs template.FuncMap) (string, error) {
+	t, err := template.New("manifest").Funcs(funcs).Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// askForTemplate suggests the templates detected in the repository. It returns nil if none is selected
+func askForTemplate(templates []*manifestTemplate, cwd string, fs afero.Fs) (*manifestTemplate, error) {
+	detected := detectTemplates(templates, cwd, fs)
+	if len(detected) == 0 {
+		return nil, nil
+	}
+	options := []string{}
+	for _, tmpl := range detected {
+		options = append(options, fmt.Sprintf("%s (%s)", tmpl.description, tmpl.name))
+	}
+	options = append(options, noTemplateOption)
+	selection, err := utils.AskForOptions(options, "We detected the following frameworks in your repository. Do you want to generate your okteto manifest from one of them?")
+	if err != nil {
+		return nil, err
+	}
+	for i, option := range options {
+		if option == selection && i < len(detected) {
+			return detected[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// writeManifestTemplate renders a template with the values of the repository and writes it into the manifest path
+func writeManifestTemplate(tmpl *manifestTemplate, opts *InitOpts, fs afero.Fs) error {
+	path := getManifestPath(opts)
+	if !opts.Overwrite && filesystem.FileExistsWithFilesystem(path, fs) {
+		return fmt.Errorf("%s already exists. Run this command again with the '--replace' flag to overwrite it", opts.DevPath)
+	}
+	content, err := renderManifestTemplate(tmpl, getTemplateData(tmpl, opts.Workdir, fs))
+	if err != nil {
+		return err
+	}
+	if err := afero.WriteFile(fs, path, content, 0600); err != nil {
+		return err
+	}
+	oktetoLog.Success("Okteto manifest (%s) generated from the '%s' template", opts.DevPath, tmpl.name)
+	return nil
+}
+
+// getManifestPath returns the path of the manifest to initialize, relative paths are relative to the workdir
+func getManifestPath(opts *InitOpts) string {
+	if filepath.IsAbs(opts.DevPath) {
+		return opts.DevPath
+	}
+	return filepath.Join(opts.Workdir, opts.DevPath)
+}
+
+// RunInitFromTemplate writes the okteto manifest of a template without asking any question
+func (*ManifestCommand) RunInitFromTemplate(ctx context.Context, opts *InitOpts) error {
+	tmpl, err := getManifestTemplate(getAvailableTemplates(ctx, fetchDevCatalog), opts.Template)
+	if err != nil {
+		return err
+	}
+	return writeManifestTemplate(tmpl, opts, afero.NewOsFs())
+}
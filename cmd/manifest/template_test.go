@@ -0,0 +1,183 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func builtinTemplates() []*manifestTemplate {
+	templates := []*manifestTemplate{}
+	for i := range manifestTemplates {
+		templates = append(templates, &manifestTemplates[i])
+	}
+	return templates
+}
+
+func getTemplateNames(templates []*manifestTemplate) []string {
+	names := []string{}
+	for _, tmpl := range templates {
+		names = append(names, tmpl.name)
+	}
+	return names
+}
+
+func TestDetectTemplates(t *testing.T) {
+	var tests = []struct {
+		name     string
+		files    []string
+		expected []string
+	}{
+		{
+			name:     "empty repository",
+			expected: []string{},
+		},
+		{
+			name:     "node with dockerfile",
+			files:    []string{"package.json", "Dockerfile"},
+			expected: []string{nodeTemplate, dockerfileTemplate},
+		},
+		{
+			name:     "golang",
+			files:    []string{"go.mod"},
+			expected: []string{golangTemplate},
+		},
+		{
+			name:     "java with compose",
+			files:    []string{"pom.xml", "docker-compose.yml"},
+			expected: []string{composeTemplate, javaTemplate},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			cwd := "/app"
+			for _, f := range tt.files {
+				require.NoError(t, afero.WriteFile(fs, filepath.Join(cwd, f), []byte(""), 0600))
+			}
+			assert.Equal(t, tt.expected, getTemplateNames(detectTemplates(builtinTemplates(), cwd, fs)))
+		})
+	}
+}
+
+func TestGetManifestTemplateNotFound(t *testing.T) {
+	_, err := getManifestTemplate(builtinTemplates(), "rails")
+	assert.ErrorContains(t, err, "Available templates: compose, node, golang, java, dockerfile")
+}
+
+func TestRenderManifestTemplates(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	cwd := "/my-app"
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(cwd, "Dockerfile"), []byte(""), 0600))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(cwd, "k8s.yml"), []byte(""), 0600))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(cwd, "docker-compose.yml"), []byte(""), 0600))
+
+	for i := range manifestTemplates {
+		tmpl := &manifestTemplates[i]
+		t.Run(tmpl.name, func(t *testing.T) {
+			data := getTemplateData(tmpl, cwd, fs)
+			content, err := renderManifestTemplate(tmpl, data)
+			require.NoError(t, err)
+			assert.Contains(t, string(content), "# The dev section defines how to activate a development container")
+
+			m, err := model.Read(content)
+			require.NoError(t, err)
+			require.NotNil(t, m.Deploy)
+			require.Contains(t, m.Build, "my-app")
+			assert.Equal(t, "Dockerfile", m.Build["my-app"].Dockerfile)
+			if tmpl.name == composeTemplate {
+				require.NotNil(t, m.Deploy.ComposeSection)
+				assert.Equal(t, "docker-compose.yml", m.Deploy.ComposeSection.ComposesInfo[0].File)
+				return
+			}
+			require.Len(t, m.Deploy.Commands, 1)
+			assert.Equal(t, "kubectl apply -f k8s.yml", m.Deploy.Commands[0].Command)
+			assert.Contains(t, m.Dev, "my-app")
+		})
+	}
+}
+
+func TestRenderManifestTemplateWithoutDockerfile(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	tmpl, err := getManifestTemplate(builtinTemplates(), golangTemplate)
+	require.NoError(t, err)
+
+	content, err := renderManifestTemplate(tmpl, getTemplateData(tmpl, "/api", fs))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "# build:")
+
+	m, err := model.Read(content)
+	require.NoError(t, err)
+	assert.Empty(t, m.Build)
+	assert.Equal(t, model.FakeCommand, m.Deploy.Commands[0].Command)
+	assert.Equal(t, "okteto/golang:1", m.Dev["api"].Image.Name)
+}
+
+func TestWriteManifestTemplate(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	tmpl, err := getManifestTemplate(builtinTemplates(), nodeTemplate)
+	require.NoError(t, err)
+	opts := &InitOpts{DevPath: "okteto.yml", Workdir: "/app"}
+
+	require.NoError(t, writeManifestTemplate(tmpl, opts, fs))
+	exists, err := afero.Exists(fs, "/app/okteto.yml")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	assert.ErrorContains(t, writeManifestTemplate(tmpl, opts, fs), "already exists")
+
+	opts.Overwrite = true
+	assert.NoError(t, writeManifestTemplate(tmpl, opts, fs))
+}
+
+func TestConfirmManifestOverwrite(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	opts := &InitOpts{DevPath: "okteto.yml", Workdir: "/app"}
+	asked := false
+	answer := false
+	ask := func(string, utils.YesNoDefault) (bool, error) {
+		asked = true
+		return answer, nil
+	}
+
+	overwrite, err := confirmManifestOverwrite(opts, fs, ask)
+	require.NoError(t, err)
+	assert.False(t, overwrite)
+	assert.False(t, asked)
+
+	require.NoError(t, afero.WriteFile(fs, "/app/okteto.yml", []byte("dev: {}"), 0600))
+	overwrite, err = confirmManifestOverwrite(opts, fs, ask)
+	require.NoError(t, err)
+	assert.False(t, overwrite)
+	assert.True(t, asked)
+
+	answer = true
+	overwrite, err = confirmManifestOverwrite(opts, fs, ask)
+	require.NoError(t, err)
+	assert.True(t, overwrite)
+
+	asked = false
+	opts.Overwrite = true
+	overwrite, err = confirmManifestOverwrite(opts, fs, ask)
+	require.NoError(t, err)
+	assert.True(t, overwrite)
+	assert.False(t, asked)
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"errors"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/lint"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/spf13/cobra"
+)
+
+// errInvalidManifest is returned when the manifest doesn't pass a lint rule with error severity
+var errInvalidManifest = errors.New("the okteto manifest has errors")
+
+// ValidateOptions are the options of the validate command
+type ValidateOptions struct {
+	ManifestPath string
+	RulesPath    string
+}
+
+// Validate checks the manifest with the builtin lint rules and the rules of a rules file
+func Validate() *cobra.Command {
+	options := &ValidateOptions{}
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Check the okteto manifest against the lint rules",
+		Long: `Check the okteto manifest against the lint rules.
+
+The manifest is checked with the builtin rules and, if the --rules flag is set, with the rules declared in a rules file:
+
+  rules:
+    - name: corp-registry
+      description: images must come from the corporate registry
+      severity: error
+      images:
+        allowedRegistries:
+          - registry.corp
+        deniedTags:
+          - latest
+
+Rules with 'error' severity make the command fail.`,
+		Example: `  okteto validate
+  okteto validate -f okteto.yml --rules rules.yaml`,
+		Args: utils.NoArgsAccepted(""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rules, err := getLintRules(options.RulesPath)
+			if err != nil {
+				return err
+			}
+			manifest, err := getRenderedManifest(options.ManifestPath)
+			if err != nil {
+				return err
+			}
+			return reportIssues(lint.Run(manifest, rules))
+		},
+	}
+	cmd.Flags().StringVarP(&options.ManifestPath, "file", "f", "", "path to the okteto manifest file")
+	cmd.Flags().StringVarP(&options.RulesPath, "rules", "", "", "path to a file with custom lint rules")
+	return cmd
+}
+
+// getLintRules returns the rules of the default registry followed by the ones of the rules file
+func getLintRules(rulesPath string) ([]lint.Rule, error) {
+	rules := lint.DefaultRules()
+	if rulesPath == "" {
+		return rules, nil
+	}
+	custom, err := lint.LoadRulesFile(rulesPath)
+	if err != nil {
+		return nil, err
+	}
+	return append(rules, custom...), nil
+}
+
+// reportIssues prints the issues and fails if any of them is an error
+func reportIssues(issues []lint.Issue) error {
+	for _, issue := range issues {
+		if issue.Severity == lint.SeverityError {
+			oktetoLog.Fail("%s: %s (%s)", issue.Path, issue.Message, issue.Rule)
+			continue
+		}
+		oktetoLog.Warning("%s: %s (%s)", issue.Path, issue.Message, issue.Rule)
+	}
+	if lint.HasErrors(issues) {
+		return errInvalidManifest
+	}
+	oktetoLog.Success("The okteto manifest is valid")
+	return nil
+}
@@ -0,0 +1,48 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/lint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_getLintRules(t *testing.T) {
+	rules, err := getLintRules("")
+	require.NoError(t, err)
+	assert.Len(t, rules, len(lint.DefaultRules()))
+
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("rules:\n  - name: corp\n    images:\n      allowedRegistries: [registry.corp]\n"), 0600))
+	rules, err = getLintRules(path)
+	require.NoError(t, err)
+	assert.Equal(t, "corp", rules[len(rules)-1].Name())
+
+	_, err = getLintRules(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func Test_reportIssues(t *testing.T) {
+	warning := lint.Issue{Rule: "latest-tag", Path: "dev.api.image", Message: "latest", Severity: lint.SeverityWarning}
+	assert.NoError(t, reportIssues(nil))
+	assert.NoError(t, reportIssues([]lint.Issue{warning}))
+
+	failure := lint.Issue{Rule: "corp", Path: "build.api.image", Message: "registry", Severity: lint.SeverityError}
+	assert.ErrorIs(t, reportIssues([]lint.Issue{warning, failure}), errInvalidManifest)
+}
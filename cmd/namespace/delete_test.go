@@ -17,9 +17,9 @@ import (
 	"context"
 	"testing"
 
-	"github.com/okteto/okteto/internal/test/client"
 	"github.com/okteto/okteto/pkg/constants"
 	"github.com/okteto/okteto/pkg/okteto"
+	client "github.com/okteto/okteto/pkg/okteto/fake"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
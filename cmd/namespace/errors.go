@@ -24,3 +24,5 @@ var errDeleteNamespaceTimeout = errors.New("namespace delete didn't finish")
 var errFailedSleepNamespace = errors.New("failed to sleep namespace")
 
 var errFailedWakeNamespace = errors.New("failed to wake namespace")
+
+var errNamespaceStatusTimeout = errors.New("namespace didn't reach the expected status")
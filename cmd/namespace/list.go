@@ -16,23 +16,40 @@ package namespace
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
-	"text/tabwriter"
 
 	contextCMD "github.com/okteto/okteto/cmd/context"
 	"github.com/okteto/okteto/cmd/utils"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/output"
 	"github.com/spf13/cobra"
 )
 
+// namespaceOutput is a namespace in the structured output formats
+type namespaceOutput struct {
+	Namespace string `json:"namespace" yaml:"namespace"`
+	Status    string `json:"status" yaml:"status"`
+}
+
+// OutputName returns the name of the namespace for the 'name' output format
+func (n namespaceOutput) OutputName() string {
+	return n.Namespace
+}
+
 // List all namespace in current context
 func List(ctx context.Context) *cobra.Command {
-	return &cobra.Command{
+	var outputFormat string
+	cmd := &cobra.Command{
 		Use:     "list",
 		Short:   "List namespaces managed by Okteto in your current context",
 		Aliases: []string{"ls"},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.Validate(outputFormat); err != nil {
+				return err
+			}
 
 			if err := contextCMD.NewContextCommand().Run(ctx, &contextCMD.ContextOptions{}); err != nil {
 				return err
@@ -46,27 +63,35 @@ func List(ctx context.Context) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			err = nsCmd.executeListNamespaces(ctx)
+			err = nsCmd.executeListNamespaces(ctx, outputFormat)
 			return err
 		},
 		Args: utils.NoArgsAccepted(""),
 	}
+	output.AddFlag(cmd, &outputFormat)
+	return cmd
 }
 
-func (nc *NamespaceCommand) executeListNamespaces(ctx context.Context) error {
+func (nc *NamespaceCommand) executeListNamespaces(ctx context.Context, outputFormat string) error {
 	spaces, err := nc.okClient.Namespaces().List(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get namespaces: %w", err)
 	}
-	w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
-	fmt.Fprintf(w, "Namespace\tStatus\n")
+	items := []namespaceOutput{}
 	for _, space := range spaces {
-		if space.ID == okteto.Context().Namespace {
-			space.ID += " *"
-		}
-		fmt.Fprintf(w, "%s\t%v\n", space.ID, space.Status)
+		items = append(items, namespaceOutput{Namespace: space.ID, Status: space.Status})
 	}
-
-	w.Flush()
-	return nil
+	return output.Print(os.Stdout, outputFormat, items, func(w io.Writer) error {
+		table := oktetoLog.NewTable(
+			oktetoLog.Column{Header: "Namespace"},
+			oktetoLog.Column{Header: "Status"},
+		)
+		for _, item := range items {
+			if item.Namespace == okteto.Context().Namespace {
+				item.Namespace += " *"
+			}
+			table.AddRow(item.Namespace, item.Status)
+		}
+		return table.Render(w, "")
+	})
 }
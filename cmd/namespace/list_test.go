@@ -18,8 +18,8 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/okteto/okteto/internal/test/client"
 	"github.com/okteto/okteto/pkg/okteto"
+	client "github.com/okteto/okteto/pkg/okteto/fake"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -73,7 +73,7 @@ func Test_listNamespace(t *testing.T) {
 				okClient: fakeOktetoClient,
 				ctxCmd:   newFakeContextCommand(fakeOktetoClient, usr),
 			}
-			err := nsCmd.executeListNamespaces(ctx)
+			err := nsCmd.executeListNamespaces(ctx, "")
 			if tt.err != nil {
 				assert.Error(t, err)
 			} else {
@@ -71,5 +71,7 @@ func Namespace(ctx context.Context) *cobra.Command {
 	cmd.AddCommand(Delete(ctx))
 	cmd.AddCommand(Sleep(ctx))
 	cmd.AddCommand(Wake(ctx))
+	cmd.AddCommand(Usage(ctx))
+	cmd.AddCommand(TTL(ctx))
 	return cmd
 }
@@ -16,6 +16,7 @@ package namespace
 import (
 	"context"
 	"fmt"
+	"time"
 
 	contextCMD "github.com/okteto/okteto/cmd/context"
 	"github.com/okteto/okteto/cmd/utils"
@@ -25,8 +26,15 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// SleepOptions represents the options of the namespace sleep command
+type SleepOptions struct {
+	wait    bool
+	timeout time.Duration
+}
+
 // Sleep sleeps a namespace
 func Sleep(ctx context.Context) *cobra.Command {
+	options := &SleepOptions{}
 	cmd := &cobra.Command{
 		Use:   "sleep <name>",
 		Short: "Sleeps a namespace",
@@ -50,18 +58,22 @@ func Sleep(ctx context.Context) *cobra.Command {
 				return err
 			}
 
-			err = nsCmd.ExecuteSleepNamespace(ctx, nsToSleep)
+			err = nsCmd.ExecuteSleepNamespace(ctx, nsToSleep, options)
 			return err
 		},
 	}
+	cmd.Flags().BoolVarP(&options.wait, "wait", "w", false, "wait until all the workloads of the namespace have scaled down")
+	cmd.Flags().DurationVarP(&options.timeout, "timeout", "t", defaultStatusTimeout, "the length of time to wait for the namespace, zero means never. Any other values should contain a corresponding time unit e.g. 1s, 2m, 3h ")
 	return cmd
 }
 
-func (nc *NamespaceCommand) ExecuteSleepNamespace(ctx context.Context, namespace string) error {
+func (nc *NamespaceCommand) ExecuteSleepNamespace(ctx context.Context, namespace string, options *SleepOptions) error {
 	// Spinner to be loaded before sleeping a namespace
 	oktetoLog.Spinner(fmt.Sprintf("Sleeping %s namespace", namespace))
 	oktetoLog.StartSpinner()
 	defer oktetoLog.StopSpinner()
+	oktetoLog.SetStage(fmt.Sprintf("Sleeping %s namespace", namespace))
+	defer oktetoLog.SetStage("")
 
 	// trigger namespace to sleep
 	if err := nc.okClient.Namespaces().Sleep(ctx, namespace); err != nil {
@@ -69,5 +81,14 @@ func (nc *NamespaceCommand) ExecuteSleepNamespace(ctx context.Context, namespace
 	}
 
 	oktetoLog.Success("Namespace '%s' is sleeping", namespace)
+	if !options.wait {
+		return nil
+	}
+
+	oktetoLog.SetStage(fmt.Sprintf("Waiting for %s namespace", namespace))
+	if err := nc.waitForNamespaceAsleep(ctx, namespace, options.timeout); err != nil {
+		return fmt.Errorf("%w: %w", errFailedSleepNamespace, err)
+	}
+	oktetoLog.Success("All the workloads of namespace '%s' have scaled down", namespace)
 	return nil
 }
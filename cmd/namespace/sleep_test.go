@@ -17,8 +17,8 @@ import (
 	"context"
 	"testing"
 
-	"github.com/okteto/okteto/internal/test/client"
 	"github.com/okteto/okteto/pkg/okteto"
+	client "github.com/okteto/okteto/pkg/okteto/fake"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/stretchr/testify/assert"
 	"k8s.io/client-go/kubernetes/fake"
@@ -99,7 +99,7 @@ func Test_SleepNamespace(t *testing.T) {
 				CurrentContext: "test-context",
 			}
 			nsFakeCommand := NewFakeNamespaceCommand(tt.fakeOkClient, tt.fakeK8sClient, usr)
-			err := nsFakeCommand.ExecuteSleepNamespace(ctx, tt.toSleepNs)
+			err := nsFakeCommand.ExecuteSleepNamespace(ctx, tt.toSleepNs, &SleepOptions{})
 			if tt.err != nil {
 				assert.ErrorIs(t, err, tt.err)
 			} else {
@@ -0,0 +1,304 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+var (
+	errTTLOrSchedule = errors.New("either a TTL or the '--schedule' flag is required, but not both")
+
+	cronDescriptors = map[string]bool{
+		"@yearly":   true,
+		"@annually": true,
+		"@monthly":  true,
+		"@weekly":   true,
+		"@daily":    true,
+		"@midnight": true,
+		"@hourly":   true,
+	}
+
+	// cronFields are the fields of a cron expression in order, with their ranges and the names accepted as values
+	cronFields = []cronField{
+		{name: "minute", min: 0, max: 59},
+		{name: "hour", min: 0, max: 23},
+		{name: "day of month", min: 1, max: 31},
+		{name: "month", min: 1, max: 12, names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+		{name: "day of week", min: 0, max: 7, names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}},
+	}
+)
+
+// cronField is a field of a cron expression. names are the values from min, like 'JAN' or 'SUN'
+type cronField struct {
+	name  string
+	names []string
+	min   int
+	max   int
+}
+
+// TTL shows and configures when the garbage collector of Okteto destroys a namespace
+func TTL(ctx context.Context) *cobra.Command {
+	var namespace string
+	cmd := &cobra.Command{
+		Use:   "ttl",
+		Short: "Show or configure when a namespace is destroyed automatically",
+		Long: `Show or configure when a namespace is destroyed automatically.
+
+A namespace can be destroyed once its TTL has elapsed, or periodically following a cron schedule.
+The namespace is destroyed by the garbage collector of Okteto. Preview environments are namespaces too, use their name with the '--namespace' flag.`,
+		Example: `  # destroy the current namespace in three days
+  okteto namespace ttl set 72h
+
+  # destroy a namespace every Friday at 20:00
+  okteto namespace ttl set --schedule "0 20 * * FRI" --namespace my-namespace
+
+  # show the time remaining until the current namespace is destroyed
+  okteto namespace ttl`,
+		Args: utils.NoArgsAccepted(""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			nsCmd, ns, err := newTTLCommand(ctx, namespace)
+			if err != nil {
+				return err
+			}
+			return nsCmd.ExecuteShowDestroySchedule(ctx, ns, os.Stdout, time.Now())
+		},
+	}
+	cmd.PersistentFlags().StringVarP(&namespace, "namespace", "n", "", "namespace to configure, defaults to the namespace of the okteto context")
+
+	cmd.AddCommand(ttlSet(ctx, &namespace))
+	cmd.AddCommand(ttlUnset(ctx, &namespace))
+	return cmd
+}
+
+func ttlSet(ctx context.Context, namespace *string) *cobra.Command {
+	var schedule string
+	cmd := &cobra.Command{
+		Use:   "set [<duration>]",
+		Short: "Destroy a namespace once the TTL has elapsed or following a cron schedule",
+		Args:  utils.MaximumNArgsAccepted(1, ""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ttl := ""
+			if len(args) > 0 {
+				ttl = args[0]
+			}
+			destroySchedule, err := getDestroySchedule(ttl, schedule)
+			if err != nil {
+				return err
+			}
+
+			nsCmd, ns, err := newTTLCommand(ctx, *namespace)
+			if err != nil {
+				return err
+			}
+			return nsCmd.ExecuteSetDestroySchedule(ctx, ns, destroySchedule)
+		},
+	}
+	cmd.Flags().StringVarP(&schedule, "schedule", "", "", "cron expression to destroy the namespace periodically, e.g. '0 20 * * FRI'")
+	return cmd
+}
+
+func ttlUnset(ctx context.Context, namespace *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "unset",
+		Short: "Stop destroying a namespace automatically",
+		Args:  utils.NoArgsAccepted(""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			nsCmd, ns, err := newTTLCommand(ctx, *namespace)
+			if err != nil {
+				return err
+			}
+			return nsCmd.ExecuteSetDestroySchedule(ctx, ns, types.DestroySchedule{})
+		},
+	}
+}
+
+// newTTLCommand loads the okteto context and returns the namespace command and the namespace to configure
+func newTTLCommand(ctx context.Context, namespace string) (*NamespaceCommand, string, error) {
+	if err := contextCMD.NewContextCommand().Run(ctx, &contextCMD.ContextOptions{}); err != nil {
+		return nil, "", err
+	}
+	if !okteto.IsOkteto() {
+		return nil, "", oktetoErrors.ErrContextIsNotOktetoCluster
+	}
+	if namespace == "" {
+		namespace = okteto.Context().Namespace
+	}
+	nsCmd, err := NewCommand()
+	if err != nil {
+		return nil, "", err
+	}
+	return nsCmd, namespace, nil
+}
+
+// getDestroySchedule validates the TTL and the cron schedule given by the user
+func getDestroySchedule(ttl, schedule string) (types.DestroySchedule, error) {
+	if (ttl == "") == (schedule == "") {
+		return types.DestroySchedule{}, errTTLOrSchedule
+	}
+	if schedule != "" {
+		if err := validateCronSchedule(schedule); err != nil {
+			return types.DestroySchedule{}, err
+		}
+		return types.DestroySchedule{Schedule: schedule}, nil
+	}
+
+	d, err := time.ParseDuration(ttl)
+	if err != nil {
+		return types.DestroySchedule{}, oktetoErrors.UserError{
+			E:    fmt.Errorf("invalid TTL '%s': %w", ttl, err),
+			Hint: "Use a duration with a time unit, e.g. 30m, 12h or 72h",
+		}
+	}
+	if d < time.Minute {
+		return types.DestroySchedule{}, fmt.Errorf("invalid TTL '%s': it must be at least one minute", ttl)
+	}
+	return types.DestroySchedule{TTL: d}, nil
+}
+
+// validateCronSchedule checks that a schedule is a descriptor like '@daily' or has the five fields of a cron expression
+func validateCronSchedule(schedule string) error {
+	if strings.HasPrefix(schedule, "@") {
+		if cronDescriptors[schedule] {
+			return nil
+		}
+		return fmt.Errorf("invalid schedule '%s': unknown descriptor", schedule)
+	}
+	fields := strings.Fields(schedule)
+	if len(fields) != 5 {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("invalid schedule '%s': a cron expression must have 5 fields", schedule),
+			Hint: "The fields are: minute, hour, day of month, month and day of week, e.g. '0 20 * * FRI'",
+		}
+	}
+	for i, field := range fields {
+		if !cronFields[i].isValid(field) {
+			return fmt.Errorf("invalid schedule '%s': invalid field '%s', the %s must be between %d and %d", schedule, field, cronFields[i].name, cronFields[i].min, cronFields[i].max)
+		}
+	}
+	return nil
+}
+
+// isValid returns if value is a list of values, ranges or '*' of the field, optionally with steps, like '*/15', '1-5' or 'MON,FRI'
+func (f cronField) isValid(value string) bool {
+	for _, part := range strings.Split(value, ",") {
+		rangeValue, step, hasStep := strings.Cut(part, "/")
+		if hasStep {
+			n, err := strconv.Atoi(step)
+			if err != nil || n < 1 {
+				return false
+			}
+		}
+		if rangeValue == "*" || rangeValue == "?" {
+			continue
+		}
+		start, end, isRange := strings.Cut(rangeValue, "-")
+		first, ok := f.parse(start)
+		if !ok {
+			return false
+		}
+		if isRange {
+			last, ok := f.parse(end)
+			if !ok || last < first {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// parse returns the number of a value of the field, which can be a number or a name
+func (f cronField) parse(value string) (int, bool) {
+	for i, name := range f.names {
+		if strings.EqualFold(name, value) {
+			return f.min + i, true
+		}
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < f.min || n > f.max {
+		return 0, false
+	}
+	return n, true
+}
+
+// ExecuteSetDestroySchedule sets when the garbage collector of Okteto destroys a namespace. An empty schedule removes it
+func (nc *NamespaceCommand) ExecuteSetDestroySchedule(ctx context.Context, namespace string, schedule types.DestroySchedule) error {
+	if err := nc.okClient.Namespaces().SetDestroySchedule(ctx, namespace, schedule); err != nil {
+		if uErr, ok := err.(oktetoErrors.UserError); ok {
+			return uErr
+		}
+		return fmt.Errorf("failed to set the destroy schedule of namespace '%s': %w", namespace, err)
+	}
+
+	switch {
+	case schedule.Schedule != "":
+		oktetoLog.Success("Namespace '%s' will be destroyed following the schedule '%s'", namespace, schedule.Schedule)
+	case schedule.TTL > 0:
+		oktetoLog.Success("Namespace '%s' will be destroyed in %s", namespace, duration.HumanDuration(schedule.TTL))
+	default:
+		oktetoLog.Success("Namespace '%s' won't be destroyed automatically", namespace)
+	}
+	return nil
+}
+
+// ExecuteShowDestroySchedule prints when the garbage collector of Okteto destroys a namespace and the time remaining
+func (nc *NamespaceCommand) ExecuteShowDestroySchedule(ctx context.Context, namespace string, w io.Writer, now time.Time) error {
+	schedule, err := nc.okClient.Namespaces().GetDestroySchedule(ctx, namespace)
+	if err != nil {
+		if uErr, ok := err.(oktetoErrors.UserError); ok {
+			return uErr
+		}
+		return fmt.Errorf("failed to get the destroy schedule of namespace '%s': %w", namespace, err)
+	}
+	fmt.Fprintln(w, getDestroyScheduleStatus(namespace, schedule, now))
+	return nil
+}
+
+// getDestroyScheduleStatus returns a description of the destroy schedule of a namespace
+func getDestroyScheduleStatus(namespace string, schedule *types.DestroySchedule, now time.Time) string {
+	var status string
+	switch {
+	case schedule.Schedule != "":
+		status = fmt.Sprintf("Namespace '%s' is destroyed following the schedule '%s'", namespace, schedule.Schedule)
+	case schedule.TTL > 0:
+		status = fmt.Sprintf("Namespace '%s' has a TTL of %s", namespace, duration.HumanDuration(schedule.TTL))
+	default:
+		return fmt.Sprintf("Namespace '%s' is not destroyed automatically", namespace)
+	}
+
+	if schedule.DestroyAt == nil {
+		return status
+	}
+	remaining := schedule.DestroyAt.Sub(now)
+	if remaining <= 0 {
+		return fmt.Sprintf("%s. It is being destroyed", status)
+	}
+	return fmt.Sprintf("%s. It will be destroyed in %s (%s)", status, duration.HumanDuration(remaining), schedule.DestroyAt.Format(time.RFC3339))
+}
@@ -0,0 +1,205 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	client "github.com/okteto/okteto/pkg/okteto/fake"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_getDestroySchedule(t *testing.T) {
+	tests := []struct {
+		expectedErr error
+		name        string
+		ttl         string
+		schedule    string
+		expected    types.DestroySchedule
+	}{
+		{
+			name:     "ttl",
+			ttl:      "72h",
+			expected: types.DestroySchedule{TTL: 72 * time.Hour},
+		},
+		{
+			name:     "schedule",
+			schedule: "0 20 * * FRI",
+			expected: types.DestroySchedule{Schedule: "0 20 * * FRI"},
+		},
+		{
+			name:     "schedule with ranges, lists and steps",
+			schedule: "*/15 9-17 1,15 JAN-mar mon-FRI",
+			expected: types.DestroySchedule{Schedule: "*/15 9-17 1,15 JAN-mar mon-FRI"},
+		},
+		{
+			name:     "descriptor",
+			schedule: "@daily",
+			expected: types.DestroySchedule{Schedule: "@daily"},
+		},
+		{
+			name:        "none",
+			expectedErr: errTTLOrSchedule,
+		},
+		{
+			name:        "both",
+			ttl:         "72h",
+			schedule:    "@daily",
+			expectedErr: errTTLOrSchedule,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getDestroySchedule(tt.ttl, tt.schedule)
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func Test_getDestroyScheduleInvalidValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		ttl      string
+		schedule string
+		err      string
+	}{
+		{
+			name: "invalid ttl",
+			ttl:  "3 days",
+			err:  "invalid TTL '3 days'",
+		},
+		{
+			name: "ttl too short",
+			ttl:  "10s",
+			err:  "it must be at least one minute",
+		},
+		{
+			name:     "unknown descriptor",
+			schedule: "@sometimes",
+			err:      "unknown descriptor",
+		},
+		{
+			name:     "wrong number of fields",
+			schedule: "0 20 * *",
+			err:      "a cron expression must have 5 fields",
+		},
+		{
+			name:     "invalid field",
+			schedule: "0 20 * * $",
+			err:      "invalid field '$'",
+		},
+		{
+			name:     "field out of range",
+			schedule: "99 99 * * foo",
+			err:      "invalid field '99', the minute must be between 0 and 59",
+		},
+		{
+			name:     "unknown name",
+			schedule: "0 20 * * foo",
+			err:      "invalid field 'foo', the day of week must be between 0 and 7",
+		},
+		{
+			name:     "inverted range",
+			schedule: "0 20 * 12-1 *",
+			err:      "invalid field '12-1'",
+		},
+		{
+			name:     "zero step",
+			schedule: "*/0 20 * * *",
+			err:      "invalid field '*/0'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := getDestroySchedule(tt.ttl, tt.schedule)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func Test_getDestroyScheduleStatus(t *testing.T) {
+	now := time.Date(2023, 10, 15, 10, 0, 0, 0, time.UTC)
+	destroyAt := now.Add(50 * time.Hour)
+	past := now.Add(-time.Minute)
+	tests := []struct {
+		schedule *types.DestroySchedule
+		name     string
+		expected string
+	}{
+		{
+			name:     "not destroyed",
+			schedule: &types.DestroySchedule{},
+			expected: "Namespace 'test' is not destroyed automatically",
+		},
+		{
+			name:     "ttl",
+			schedule: &types.DestroySchedule{TTL: 72 * time.Hour, DestroyAt: &destroyAt},
+			expected: "Namespace 'test' has a TTL of 3d. It will be destroyed in 2d2h (2023-10-17T12:00:00Z)",
+		},
+		{
+			name:     "schedule without destroy time",
+			schedule: &types.DestroySchedule{Schedule: "@daily"},
+			expected: "Namespace 'test' is destroyed following the schedule '@daily'",
+		},
+		{
+			name:     "being destroyed",
+			schedule: &types.DestroySchedule{TTL: time.Hour, DestroyAt: &past},
+			expected: "Namespace 'test' has a TTL of 60m. It is being destroyed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getDestroyScheduleStatus("test", tt.schedule, now))
+		})
+	}
+}
+
+func Test_ExecuteDestroySchedule(t *testing.T) {
+	ctx := context.Background()
+	nsClient := client.NewFakeNamespaceClient([]types.Namespace{{ID: "test"}}, nil)
+	nsCmd := &NamespaceCommand{
+		okClient: &client.FakeOktetoClient{
+			Namespace: nsClient,
+		},
+	}
+
+	require.NoError(t, nsCmd.ExecuteSetDestroySchedule(ctx, "test", types.DestroySchedule{Schedule: "@weekly"}))
+	assert.Equal(t, types.DestroySchedule{Schedule: "@weekly"}, nsClient.DestroySchedules["test"])
+
+	var out bytes.Buffer
+	require.NoError(t, nsCmd.ExecuteShowDestroySchedule(ctx, "test", &out, time.Now()))
+	assert.Equal(t, "Namespace 'test' is destroyed following the schedule '@weekly'\n", out.String())
+
+	require.NoError(t, nsCmd.ExecuteSetDestroySchedule(ctx, "test", types.DestroySchedule{}))
+	assert.Equal(t, types.DestroySchedule{}, nsClient.DestroySchedules["test"])
+}
+
+func Test_ExecuteDestroyScheduleError(t *testing.T) {
+	nsCmd := &NamespaceCommand{
+		okClient: &client.FakeOktetoClient{
+			Namespace: client.NewFakeNamespaceClient(nil, assert.AnError),
+		},
+	}
+	err := nsCmd.ExecuteSetDestroySchedule(context.Background(), "test", types.DestroySchedule{TTL: time.Hour})
+	assert.ErrorIs(t, err, assert.AnError)
+
+	err = nsCmd.ExecuteShowDestroySchedule(context.Background(), "test", &bytes.Buffer{}, time.Now())
+	assert.ErrorIs(t, err, assert.AnError)
+}
@@ -0,0 +1,315 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/output"
+	"github.com/spf13/cobra"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// namespaceUsage is the resource usage of a namespace compared to its quotas
+type namespaceUsage struct {
+	Namespace   string          `json:"namespace" yaml:"namespace"`
+	Resources   []resourceUsage `json:"resources" yaml:"resources"`
+	Volumes     []volumeUsage   `json:"volumes" yaml:"volumes"`
+	PendingPods []pendingPod    `json:"pendingPods" yaml:"pendingPods"`
+	Pods        podCounts       `json:"pods" yaml:"pods"`
+}
+
+// resourceUsage is the usage of a resource. Quota and Used are empty if the namespace has no quota for the resource
+type resourceUsage struct {
+	Resource string `json:"resource" yaml:"resource"`
+	Requests string `json:"requests" yaml:"requests"`
+	Limits   string `json:"limits,omitempty" yaml:"limits,omitempty"`
+	Quota    string `json:"quota,omitempty" yaml:"quota,omitempty"`
+	Used     string `json:"used,omitempty" yaml:"used,omitempty"`
+}
+
+// volumeUsage is a persistent volume claim of the namespace
+type volumeUsage struct {
+	Name         string `json:"name" yaml:"name"`
+	Status       string `json:"status" yaml:"status"`
+	StorageClass string `json:"storageClass" yaml:"storageClass"`
+	Requested    string `json:"requested" yaml:"requested"`
+	Capacity     string `json:"capacity" yaml:"capacity"`
+}
+
+// pendingPod is a pod that hasn't been scheduled, with the reason reported by the scheduler
+type pendingPod struct {
+	Name   string `json:"name" yaml:"name"`
+	Reason string `json:"reason" yaml:"reason"`
+}
+
+// podCounts is the number of pods of the namespace by phase
+type podCounts struct {
+	Running   int `json:"running" yaml:"running"`
+	Pending   int `json:"pending" yaml:"pending"`
+	Succeeded int `json:"succeeded" yaml:"succeeded"`
+	Failed    int `json:"failed" yaml:"failed"`
+}
+
+// Usage shows the resource usage of a namespace
+func Usage(ctx context.Context) *cobra.Command {
+	var outputFormat string
+	cmd := &cobra.Command{
+		Use:   "usage [name]",
+		Short: "Show the resource requests, quotas, volumes and pods of a namespace",
+		Long: `Show the resource requests, quotas, volumes and pods of a namespace.
+
+Use it to find out why new pods of your namespace are not scheduled`,
+		Args: utils.MaximumNArgsAccepted(1, ""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.Validate(outputFormat); err != nil {
+				return err
+			}
+			if err := contextCMD.NewContextCommand().Run(ctx, &contextCMD.ContextOptions{}); err != nil {
+				return err
+			}
+
+			ns := okteto.Context().Namespace
+			if len(args) > 0 {
+				ns = args[0]
+			}
+
+			c, _, err := okteto.NewK8sClientProvider().Provide(okteto.Context().Cfg)
+			if err != nil {
+				return err
+			}
+			usage, err := getNamespaceUsage(ctx, c, ns)
+			if err != nil {
+				return err
+			}
+			return renderNamespaceUsage(os.Stdout, usage, outputFormat)
+		},
+	}
+	output.AddFlag(cmd, &outputFormat)
+	return cmd
+}
+
+// getNamespaceUsage aggregates the requests and limits of the active pods, the quotas and the volumes of a namespace
+func getNamespaceUsage(ctx context.Context, c kubernetes.Interface, namespace string) (*namespaceUsage, error) {
+	pods, err := c.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the pods of namespace '%s': %w", namespace, err)
+	}
+	quotas, err := c.CoreV1().ResourceQuotas(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the resource quotas of namespace '%s': %w", namespace, err)
+	}
+	pvcs, err := c.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the volumes of namespace '%s': %w", namespace, err)
+	}
+
+	usage := &namespaceUsage{
+		Namespace:   namespace,
+		Resources:   []resourceUsage{},
+		Volumes:     []volumeUsage{},
+		PendingPods: []pendingPod{},
+	}
+
+	requests := apiv1.ResourceList{}
+	limits := apiv1.ResourceList{}
+	activePods := 0
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		switch pod.Status.Phase {
+		case apiv1.PodRunning:
+			usage.Pods.Running++
+		case apiv1.PodPending:
+			usage.Pods.Pending++
+			if reason := getUnschedulableReason(pod); reason != "" {
+				usage.PendingPods = append(usage.PendingPods, pendingPod{Name: pod.Name, Reason: reason})
+			}
+		case apiv1.PodSucceeded:
+			usage.Pods.Succeeded++
+			continue
+		case apiv1.PodFailed:
+			usage.Pods.Failed++
+			continue
+		}
+		// quotas only count the pods that are not terminated
+		activePods++
+		for _, container := range pod.Spec.Containers {
+			addResources(requests, container.Resources.Requests)
+			addResources(limits, container.Resources.Limits)
+		}
+	}
+
+	storage := resource.Quantity{}
+	for _, pvc := range pvcs.Items {
+		requested := pvc.Spec.Resources.Requests[apiv1.ResourceStorage]
+		storage.Add(requested)
+		capacity := pvc.Status.Capacity[apiv1.ResourceStorage]
+		storageClass := ""
+		if pvc.Spec.StorageClassName != nil {
+			storageClass = *pvc.Spec.StorageClassName
+		}
+		usage.Volumes = append(usage.Volumes, volumeUsage{
+			Name:         pvc.Name,
+			Status:       string(pvc.Status.Phase),
+			StorageClass: storageClass,
+			Requested:    requested.String(),
+			Capacity:     capacity.String(),
+		})
+	}
+
+	hard, used := getQuotaLimits(quotas.Items)
+	cpuRequests := requests[apiv1.ResourceCPU]
+	cpuLimits := limits[apiv1.ResourceCPU]
+	memoryRequests := requests[apiv1.ResourceMemory]
+	memoryLimits := limits[apiv1.ResourceMemory]
+	usage.Resources = append(usage.Resources,
+		newResourceUsage("cpu", cpuRequests.String(), cpuLimits.String(), hard, used, apiv1.ResourceRequestsCPU, apiv1.ResourceCPU),
+		newResourceUsage("memory", memoryRequests.String(), memoryLimits.String(), hard, used, apiv1.ResourceRequestsMemory, apiv1.ResourceMemory),
+		newResourceUsage("storage", storage.String(), "", hard, used, apiv1.ResourceRequestsStorage),
+		newResourceUsage("pods", fmt.Sprintf("%d", activePods), "", hard, used, apiv1.ResourcePods),
+		newResourceUsage("volumes", fmt.Sprintf("%d", len(pvcs.Items)), "", hard, used, apiv1.ResourcePersistentVolumeClaims),
+	)
+	return usage, nil
+}
+
+// addResources adds the quantities of src to dst
+func addResources(dst, src apiv1.ResourceList) {
+	for name, q := range src {
+		total := dst[name]
+		total.Add(q)
+		dst[name] = total
+	}
+}
+
+// getQuotaLimits returns the most restrictive hard limit of each resource and its usage, as several quotas can apply to a namespace
+func getQuotaLimits(quotas []apiv1.ResourceQuota) (apiv1.ResourceList, apiv1.ResourceList) {
+	hard := apiv1.ResourceList{}
+	used := apiv1.ResourceList{}
+	for _, quota := range quotas {
+		for name, q := range quota.Status.Hard {
+			if current, ok := hard[name]; ok && current.Cmp(q) <= 0 {
+				continue
+			}
+			hard[name] = q
+			used[name] = quota.Status.Used[name]
+		}
+	}
+	return hard, used
+}
+
+// newResourceUsage returns the usage of a resource, with the quota of the first name defined in the quotas
+func newResourceUsage(resourceName, requests, limits string, hard, used apiv1.ResourceList, names ...apiv1.ResourceName) resourceUsage {
+	result := resourceUsage{
+		Resource: resourceName,
+		Requests: requests,
+		Limits:   limits,
+	}
+	for _, name := range names {
+		q, ok := hard[name]
+		if !ok {
+			continue
+		}
+		u := used[name]
+		result.Quota = q.String()
+		result.Used = u.String()
+		if q.MilliValue() > 0 {
+			result.Used = fmt.Sprintf("%s (%d%%)", u.String(), u.MilliValue()*100/q.MilliValue())
+		}
+		break
+	}
+	return result
+}
+
+// getUnschedulableReason returns the message of the scheduler for a pod that couldn't be scheduled
+func getUnschedulableReason(pod *apiv1.Pod) string {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == apiv1.PodScheduled && condition.Status == apiv1.ConditionFalse {
+			if condition.Message != "" {
+				return condition.Message
+			}
+			return condition.Reason
+		}
+	}
+	return ""
+}
+
+func renderNamespaceUsage(w io.Writer, usage *namespaceUsage, outputFormat string) error {
+	return output.Print(w, outputFormat, usage, func(w io.Writer) error {
+		return renderNamespaceUsageTables(w, usage)
+	})
+}
+
+// renderNamespaceUsageTables prints the usage of a namespace as tables
+func renderNamespaceUsageTables(w io.Writer, usage *namespaceUsage) error {
+	fmt.Fprintf(w, "Namespace: %s\n\n", usage.Namespace)
+	resources := oktetoLog.NewTable(
+		oktetoLog.Column{Header: "Resource"},
+		oktetoLog.Column{Header: "Requests", Align: oktetoLog.AlignRight},
+		oktetoLog.Column{Header: "Limits", Align: oktetoLog.AlignRight},
+		oktetoLog.Column{Header: "Quota", Align: oktetoLog.AlignRight},
+		oktetoLog.Column{Header: "Used"},
+	)
+	for _, r := range usage.Resources {
+		resources.AddRow(r.Resource, r.Requests, valueOrDash(r.Limits), valueOrDash(r.Quota), valueOrDash(r.Used))
+	}
+	if err := resources.Render(w, ""); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "\nPods: %d running, %d pending, %d succeeded, %d failed\n", usage.Pods.Running, usage.Pods.Pending, usage.Pods.Succeeded, usage.Pods.Failed)
+	if len(usage.PendingPods) > 0 {
+		fmt.Fprintln(w, "\nPods not scheduled:")
+		sort.Slice(usage.PendingPods, func(i, j int) bool {
+			return usage.PendingPods[i].Name < usage.PendingPods[j].Name
+		})
+		for _, p := range usage.PendingPods {
+			fmt.Fprintf(w, "  %s: %s\n", p.Name, strings.TrimSpace(p.Reason))
+		}
+	}
+
+	if len(usage.Volumes) > 0 {
+		fmt.Fprintln(w)
+		volumes := oktetoLog.NewTable(
+			oktetoLog.Column{Header: "Volume"},
+			oktetoLog.Column{Header: "Status"},
+			oktetoLog.Column{Header: "Storage Class"},
+			oktetoLog.Column{Header: "Requested", Align: oktetoLog.AlignRight},
+			oktetoLog.Column{Header: "Capacity", Align: oktetoLog.AlignRight},
+		)
+		for _, v := range usage.Volumes {
+			volumes.AddRow(v.Name, v.Status, valueOrDash(v.StorageClass), v.Requested, v.Capacity)
+		}
+		return volumes.Render(w, "")
+	}
+	return nil
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
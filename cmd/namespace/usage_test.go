@@ -0,0 +1,137 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newUsagePod(name string, phase apiv1.PodPhase, cpu, memory string) *apiv1.Pod {
+	return &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test"},
+		Spec: apiv1.PodSpec{
+			Containers: []apiv1.Container{
+				{
+					Resources: apiv1.ResourceRequirements{
+						Requests: apiv1.ResourceList{
+							apiv1.ResourceCPU:    resource.MustParse(cpu),
+							apiv1.ResourceMemory: resource.MustParse(memory),
+						},
+					},
+				},
+			},
+		},
+		Status: apiv1.PodStatus{Phase: phase},
+	}
+}
+
+func Test_getNamespaceUsage(t *testing.T) {
+	pending := newUsagePod("pending", apiv1.PodPending, "500m", "1Gi")
+	pending.Status.Conditions = []apiv1.PodCondition{
+		{
+			Type:    apiv1.PodScheduled,
+			Status:  apiv1.ConditionFalse,
+			Reason:  "Unschedulable",
+			Message: "0/3 nodes are available: 3 Insufficient cpu.",
+		},
+	}
+	storageClass := "standard"
+	c := fake.NewSimpleClientset(
+		newUsagePod("api", apiv1.PodRunning, "250m", "512Mi"),
+		newUsagePod("worker", apiv1.PodRunning, "250m", "512Mi"),
+		newUsagePod("job", apiv1.PodSucceeded, "1", "1Gi"),
+		pending,
+		&apiv1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: "loose", Namespace: "test"},
+			Status: apiv1.ResourceQuotaStatus{
+				Hard: apiv1.ResourceList{apiv1.ResourceRequestsCPU: resource.MustParse("4")},
+				Used: apiv1.ResourceList{apiv1.ResourceRequestsCPU: resource.MustParse("1")},
+			},
+		},
+		&apiv1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: "strict", Namespace: "test"},
+			Status: apiv1.ResourceQuotaStatus{
+				Hard: apiv1.ResourceList{
+					apiv1.ResourceRequestsCPU: resource.MustParse("1"),
+					apiv1.ResourcePods:        resource.MustParse("10"),
+				},
+				Used: apiv1.ResourceList{
+					apiv1.ResourceRequestsCPU: resource.MustParse("1"),
+					apiv1.ResourcePods:        resource.MustParse("3"),
+				},
+			},
+		},
+		&apiv1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "test"},
+			Spec: apiv1.PersistentVolumeClaimSpec{
+				StorageClassName: &storageClass,
+				Resources: apiv1.ResourceRequirements{
+					Requests: apiv1.ResourceList{apiv1.ResourceStorage: resource.MustParse("5Gi")},
+				},
+			},
+			Status: apiv1.PersistentVolumeClaimStatus{
+				Phase:    apiv1.ClaimBound,
+				Capacity: apiv1.ResourceList{apiv1.ResourceStorage: resource.MustParse("5Gi")},
+			},
+		},
+	)
+
+	usage, err := getNamespaceUsage(context.Background(), c, "test")
+	require.NoError(t, err)
+
+	assert.Equal(t, podCounts{Running: 2, Pending: 1, Succeeded: 1}, usage.Pods)
+	assert.Equal(t, []pendingPod{{Name: "pending", Reason: "0/3 nodes are available: 3 Insufficient cpu."}}, usage.PendingPods)
+	assert.Equal(t, []volumeUsage{{Name: "data", Status: "Bound", StorageClass: "standard", Requested: "5Gi", Capacity: "5Gi"}}, usage.Volumes)
+	assert.Equal(t, []resourceUsage{
+		{Resource: "cpu", Requests: "1", Limits: "0", Quota: "1", Used: "1 (100%)"},
+		{Resource: "memory", Requests: "2Gi", Limits: "0"},
+		{Resource: "storage", Requests: "5Gi"},
+		{Resource: "pods", Requests: "3", Quota: "10", Used: "3 (30%)"},
+		{Resource: "volumes", Requests: "1"},
+	}, usage.Resources)
+}
+
+func Test_renderNamespaceUsage(t *testing.T) {
+	usage := &namespaceUsage{
+		Namespace: "test",
+		Resources: []resourceUsage{{Resource: "cpu", Requests: "1", Limits: "2", Quota: "4", Used: "1 (25%)"}},
+		Volumes:   []volumeUsage{},
+		PendingPods: []pendingPod{
+			{Name: "api", Reason: "0/3 nodes are available: 3 Insufficient memory."},
+		},
+		Pods: podCounts{Running: 1, Pending: 1},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, renderNamespaceUsage(&buf, usage, ""))
+	assert.Contains(t, buf.String(), "Namespace: test")
+	assert.Contains(t, buf.String(), "Pods: 1 running, 1 pending, 0 succeeded, 0 failed")
+	assert.Contains(t, buf.String(), "api: 0/3 nodes are available: 3 Insufficient memory.")
+
+	buf.Reset()
+	require.NoError(t, renderNamespaceUsage(&buf, usage, "json"))
+	result := &namespaceUsage{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), result))
+	assert.Equal(t, usage, result)
+}
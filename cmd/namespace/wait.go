@@ -0,0 +1,118 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/wait"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const defaultStatusTimeout = 5 * time.Minute
+
+// workloadsStatus summarizes the replicas of the deployments and statefulsets of a namespace
+type workloadsStatus struct {
+	// total is the number of workloads
+	total int
+	// awake is the number of workloads with all their desired replicas ready
+	awake int
+	// asleep is the number of workloads without ready replicas
+	asleep int
+}
+
+// waitForNamespaceAwake waits until the namespace is not sleeping and all its workloads have scaled back up
+func (nc *NamespaceCommand) waitForNamespaceAwake(ctx context.Context, namespace string, timeout time.Duration) error {
+	return nc.waitForNamespaceStatus(ctx, namespace, timeout, func(isSleeping bool, s workloadsStatus) (bool, wait.Status) {
+		return !isSleeping && s.awake == s.total, wait.Status{Message: "workloads ready", Completed: s.awake, Total: s.total}
+	})
+}
+
+// waitForNamespaceAsleep waits until the namespace is sleeping and all its workloads have scaled down
+func (nc *NamespaceCommand) waitForNamespaceAsleep(ctx context.Context, namespace string, timeout time.Duration) error {
+	return nc.waitForNamespaceStatus(ctx, namespace, timeout, func(isSleeping bool, s workloadsStatus) (bool, wait.Status) {
+		return isSleeping && s.asleep == s.total, wait.Status{Message: "workloads scaled down", Completed: s.asleep, Total: s.total}
+	})
+}
+
+func (nc *NamespaceCommand) waitForNamespaceStatus(ctx context.Context, namespace string, timeout time.Duration, isDone func(bool, workloadsStatus) (bool, wait.Status)) error {
+	c, _, err := nc.k8sClientProvider.Provide(okteto.Context().Cfg)
+	if err != nil {
+		return err
+	}
+
+	err = wait.WaitFor(ctx, func(ctx context.Context) (bool, wait.Status, error) {
+		ns, err := c.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+		if err != nil {
+			return false, wait.Status{}, err
+		}
+		status, err := getWorkloadsStatus(ctx, c, namespace)
+		if err != nil {
+			return false, wait.Status{}, err
+		}
+		isSleeping := ns.Labels[constants.NamespaceStatusLabel] == constants.NamespaceStatusSleeping
+		done, s := isDone(isSleeping, status)
+		return done, s, nil
+	}, wait.Options{
+		Interval:  1 * time.Second,
+		Timeout:   timeout,
+		Immediate: true,
+		Progress:  wait.NewMultiProgress(wait.NewSpinnerProgress(fmt.Sprintf("Waiting for namespace '%s'", namespace)), wait.NewEventsProgress(c, namespace)),
+	})
+	if errors.Is(err, wait.ErrTimeout) {
+		return fmt.Errorf("%w: namespace %s: %w", errNamespaceStatusTimeout, namespace, err)
+	}
+	return err
+}
+
+func getWorkloadsStatus(ctx context.Context, c kubernetes.Interface, namespace string) (workloadsStatus, error) {
+	result := workloadsStatus{}
+	add := func(replicas *int32, readyReplicas int32) {
+		desired := int32(1)
+		if replicas != nil {
+			desired = *replicas
+		}
+		result.total++
+		// workloads scaled to zero by the user are ready without replicas
+		if readyReplicas >= desired {
+			result.awake++
+		}
+		if readyReplicas == 0 {
+			result.asleep++
+		}
+	}
+
+	deployments, err := c.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return result, err
+	}
+	for i := range deployments.Items {
+		add(deployments.Items[i].Spec.Replicas, deployments.Items[i].Status.ReadyReplicas)
+	}
+
+	statefulsets, err := c.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return result, err
+	}
+	for i := range statefulsets.Items {
+		add(statefulsets.Items[i].Spec.Replicas, statefulsets.Items[i].Status.ReadyReplicas)
+	}
+	return result, nil
+}
@@ -0,0 +1,171 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/okteto"
+	client "github.com/okteto/okteto/pkg/okteto/fake"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestNamespace(sleeping bool) *v1.Namespace {
+	ns := &v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "test",
+			Labels: map[string]string{},
+		},
+	}
+	if sleeping {
+		ns.Labels[constants.NamespaceStatusLabel] = constants.NamespaceStatusSleeping
+	}
+	return ns
+}
+
+func newTestDeployment(name string, replicas, ready int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+		},
+		Status: appsv1.DeploymentStatus{
+			ReadyReplicas: ready,
+		},
+	}
+}
+
+func newTestStatefulSet(name string, replicas, ready int32) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test",
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: &replicas,
+		},
+		Status: appsv1.StatefulSetStatus{
+			ReadyReplicas: ready,
+		},
+	}
+}
+
+func Test_getWorkloadsStatus(t *testing.T) {
+	c := fake.NewSimpleClientset(
+		newTestDeployment("api", 2, 2),
+		newTestDeployment("frontend", 1, 0),
+		newTestStatefulSet("db", 1, 1),
+		newTestStatefulSet("cache", 0, 0),
+	)
+
+	status, err := getWorkloadsStatus(context.Background(), c, "test")
+	assert.NoError(t, err)
+	assert.Equal(t, workloadsStatus{total: 4, awake: 3, asleep: 2}, status)
+}
+
+func Test_waitForNamespaceStatus(t *testing.T) {
+	usr := &types.User{
+		Token: "test-token",
+	}
+	var tests = []struct {
+		expectedErr error
+		wait        func(*NamespaceCommand) error
+		name        string
+		objects     []runtime.Object
+	}{
+		{
+			name: "namespace is awake",
+			objects: []runtime.Object{
+				newTestNamespace(false),
+				newTestDeployment("api", 2, 2),
+				newTestStatefulSet("db", 1, 1),
+			},
+			wait: func(nc *NamespaceCommand) error {
+				return nc.waitForNamespaceAwake(context.Background(), "test", time.Minute)
+			},
+		},
+		{
+			name: "workloads are not ready",
+			objects: []runtime.Object{
+				newTestNamespace(false),
+				newTestDeployment("api", 2, 1),
+			},
+			wait: func(nc *NamespaceCommand) error {
+				return nc.waitForNamespaceAwake(context.Background(), "test", time.Millisecond)
+			},
+			expectedErr: errNamespaceStatusTimeout,
+		},
+		{
+			name: "namespace is asleep",
+			objects: []runtime.Object{
+				newTestNamespace(true),
+				newTestDeployment("api", 0, 0),
+			},
+			wait: func(nc *NamespaceCommand) error {
+				return nc.waitForNamespaceAsleep(context.Background(), "test", time.Minute)
+			},
+		},
+		{
+			name: "namespace is not sleeping yet",
+			objects: []runtime.Object{
+				newTestNamespace(false),
+				newTestDeployment("api", 0, 0),
+			},
+			wait: func(nc *NamespaceCommand) error {
+				return nc.waitForNamespaceAsleep(context.Background(), "test", time.Millisecond)
+			},
+			expectedErr: errNamespaceStatusTimeout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			okteto.CurrentStore = &okteto.OktetoContextStore{
+				Contexts: map[string]*okteto.OktetoContext{
+					"test-context": {
+						Name:      "test-context",
+						Token:     "test-token",
+						IsOkteto:  true,
+						Namespace: "test",
+					},
+				},
+				CurrentContext: "test-context",
+			}
+			okClient := &client.FakeOktetoClient{
+				Namespace: client.NewFakeNamespaceClient(nil, nil),
+				Users:     client.NewFakeUsersClient(usr),
+			}
+			nc := NewFakeNamespaceCommand(okClient, fake.NewSimpleClientset(tt.objects...), usr)
+
+			err := tt.wait(nc)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
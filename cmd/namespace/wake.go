@@ -16,6 +16,7 @@ package namespace
 import (
 	"context"
 	"fmt"
+	"time"
 
 	contextCMD "github.com/okteto/okteto/cmd/context"
 	"github.com/okteto/okteto/cmd/utils"
@@ -25,7 +26,14 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// WakeOptions represents the options of the namespace wake command
+type WakeOptions struct {
+	wait    bool
+	timeout time.Duration
+}
+
 func Wake(ctx context.Context) *cobra.Command {
+	options := &WakeOptions{}
 	cmd := &cobra.Command{
 		Use:   "wake <name>",
 		Short: "Wakes a namespace",
@@ -46,18 +54,22 @@ func Wake(ctx context.Context) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			err = nsCmd.ExecuteWakeNamespace(ctx, nsToWake)
+			err = nsCmd.ExecuteWakeNamespace(ctx, nsToWake, options)
 			return err
 		},
 	}
+	cmd.Flags().BoolVarP(&options.wait, "wait", "w", false, "wait until all the workloads of the namespace are ready")
+	cmd.Flags().DurationVarP(&options.timeout, "timeout", "t", defaultStatusTimeout, "the length of time to wait for the namespace, zero means never. Any other values should contain a corresponding time unit e.g. 1s, 2m, 3h ")
 	return cmd
 }
 
-func (nc *NamespaceCommand) ExecuteWakeNamespace(ctx context.Context, namespace string) error {
+func (nc *NamespaceCommand) ExecuteWakeNamespace(ctx context.Context, namespace string, options *WakeOptions) error {
 	// Spinner to be loaded before waking a namespace
 	oktetoLog.Spinner(fmt.Sprintf("Waking %s namespace", namespace))
 	oktetoLog.StartSpinner()
 	defer oktetoLog.StopSpinner()
+	oktetoLog.SetStage(fmt.Sprintf("Waking %s namespace", namespace))
+	defer oktetoLog.SetStage("")
 
 	// trigger namespace to sleep
 	if err := nc.okClient.Namespaces().Wake(ctx, namespace); err != nil {
@@ -65,5 +77,14 @@ func (nc *NamespaceCommand) ExecuteWakeNamespace(ctx context.Context, namespace
 	}
 
 	oktetoLog.Success("Namespace '%s' is awake now", namespace)
+	if !options.wait {
+		return nil
+	}
+
+	oktetoLog.SetStage(fmt.Sprintf("Waiting for %s namespace", namespace))
+	if err := nc.waitForNamespaceAwake(ctx, namespace, options.timeout); err != nil {
+		return fmt.Errorf("%w: %w", errFailedWakeNamespace, err)
+	}
+	oktetoLog.Success("All the workloads of namespace '%s' are ready", namespace)
 	return nil
 }
@@ -58,8 +58,10 @@ type deployFlags struct {
 	namespace    string
 	file         string
 	filename     string //Deprecated field
+	varFile      string
 	variables    []string
 	labels       []string
+	matrix       []string
 	timeout      time.Duration
 	wait         bool
 	skipIfExists bool
@@ -79,6 +81,8 @@ type DeployOptions struct {
 	Wait         bool
 	SkipIfExists bool
 	ReuseParams  bool
+	// Commit is the metadata of the local commit when it is the one being deployed
+	Commit *repository.CommitInfo
 }
 
 func deploy(ctx context.Context) *cobra.Command {
@@ -110,6 +114,19 @@ func deploy(ctx context.Context) *cobra.Command {
 				return err
 			}
 			opts := flags.toOptions()
+			if flags.varFile != "" {
+				fileVariables, err := loadVariablesFile(flags.varFile)
+				if err != nil {
+					return err
+				}
+				opts.Variables, err = mergeVariables(fileVariables, opts.Variables)
+				if err != nil {
+					return err
+				}
+			}
+			if len(flags.matrix) > 0 {
+				return pipelineCmd.executeDeployMatrix(ctx, opts, flags.matrix)
+			}
 			err = pipelineCmd.ExecuteDeployPipeline(ctx, opts)
 			if err != nil {
 				return fmt.Errorf("pipeline deploy failed: %w", err)
@@ -126,6 +143,8 @@ func deploy(ctx context.Context) *cobra.Command {
 	cmd.Flags().BoolVarP(&flags.skipIfExists, "skip-if-exists", "", false, "skip the pipeline deployment if the pipeline already exists in the namespace (defaults to false)")
 	cmd.Flags().DurationVarP(&flags.timeout, "timeout", "t", (5 * time.Minute), "the length of time to wait for completion, zero means never. Any other values should contain a corresponding time unit e.g. 1s, 2m, 3h ")
 	cmd.Flags().StringArrayVarP(&flags.variables, "var", "v", []string{}, "set a pipeline variable (can be set more than once)")
+	cmd.Flags().StringVar(&flags.varFile, "var-file", "", "path to a yaml file with the pipeline variables. Variables set with --var take precedence")
+	cmd.Flags().StringArrayVar(&flags.matrix, "matrix", []string{}, "run the pipeline once per value of a variable, in the format KEY=VALUE1,VALUE2 (can be set more than once to run every combination)")
 	cmd.Flags().StringVarP(&flags.file, "file", "f", "", "relative path within the repository to the manifest file (default to okteto-pipeline.yaml or .okteto/okteto-pipeline.yaml)")
 	cmd.Flags().StringVarP(&flags.filename, "filename", "", "", "relative path within the repository to the manifest file (default to okteto-pipeline.yaml or .okteto/okteto-pipeline.yaml)")
 	if err := cmd.Flags().MarkHidden("filename"); err != nil {
@@ -251,27 +270,12 @@ type envSetter func(name, value string) error
 
 // setEnvsFromDependency sets the environment variables found at configmap.Data[dependencyEnvs]
 func setEnvsFromDependency(cmap *v1.ConfigMap, envSetter envSetter) error {
-	if cmap == nil || cmap.Data == nil {
-		return nil
-	}
-
-	dependencyEnvsEncoded, ok := cmap.Data[constants.OktetoDependencyEnvsKey]
-	if !ok {
-		return nil
-	}
-
-	name := cmap.Name
-
-	decodedEnvs, err := base64.StdEncoding.DecodeString(dependencyEnvsEncoded)
+	envsToSet, err := pipeline.DecodeDependencyEnvs(cmap)
 	if err != nil {
 		return err
 	}
-	envsToSet := make(map[string]string)
-	if err = json.Unmarshal(decodedEnvs, &envsToSet); err != nil {
-		return err
-	}
 	for envKey, envValue := range envsToSet {
-		envName := fmt.Sprintf(dependencyEnvTemplate, strings.ToUpper(name), envKey)
+		envName := fmt.Sprintf(dependencyEnvTemplate, strings.ToUpper(cmap.Name), envKey)
 		if err := envSetter(envName, envValue); err != nil {
 			return err
 		}
@@ -493,6 +497,8 @@ func (o *DeployOptions) setDefaults() error {
 		o.Branch = b
 	}
 
+	o.Commit = utils.GetDeployedCommitInfo(cwd, o.Repository, o.Branch, "")
+
 	if o.Namespace == "" {
 		o.Namespace = okteto.Context().Namespace
 	}
@@ -520,6 +526,7 @@ func (o *DeployOptions) toPipelineDeployClientOptions() (types.PipelineDeployOpt
 		Variables:  varList,
 		Namespace:  o.Namespace,
 		Labels:     o.Labels,
+		Commit:     o.Commit,
 	}, nil
 }
 
@@ -20,7 +20,7 @@ import (
 	"testing"
 	"time"
 
-	"github.com/okteto/okteto/internal/test/client"
+	client "github.com/okteto/okteto/pkg/okteto/fake"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/stretchr/testify/assert"
 )
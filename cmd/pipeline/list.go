@@ -15,7 +15,6 @@ package pipeline
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -30,9 +29,9 @@ import (
 	"github.com/okteto/okteto/pkg/k8s/configmaps"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/output"
 	"github.com/okteto/okteto/pkg/repository"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v2"
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation"
@@ -69,12 +68,16 @@ func list(ctx context.Context) *cobra.Command {
 	cmd.Flags().StringVarP(&flags.context, "context", "c", "", "context where the pipelines are deployed (defaults to the current context)")
 	cmd.Flags().StringArrayVarP(&flags.labels, "label", "", []string{}, "tag and organize dev environments using labels (multiple --label flags accepted)")
 	cmd.Flags().StringVarP(&flags.namespace, "namespace", "n", "", "namespace where the pipelines are deployed (defaults to the current namespace)")
-	cmd.Flags().StringVarP(&flags.output, "output", "o", "", "output format. One of: ['json', 'yaml']")
+	output.AddFlag(cmd, &flags.output)
 	return cmd
 }
 
 // pipelineListCommandHandler prepares the right okteto context depending on the provided flags and then calls the actual function that lists pipelines
 func pipelineListCommandHandler(ctx context.Context, flags *listFlags, initOkCtx initOkCtxFn) error {
+	if err := output.Validate(flags.output); err != nil {
+		return err
+	}
+
 	ctxResource := &model.ContextResource{}
 	ctxOptions := &contextCMD.ContextOptions{
 		Show: false,
@@ -139,24 +142,7 @@ func executeListPipelines(ctx context.Context, opts listFlags, listPipelines lis
 	if err != nil {
 		return err
 	}
-	switch opts.output {
-	case "json":
-		bytes, err := json.MarshalIndent(pipelineListOutput, "", " ")
-		if err != nil {
-			return err
-		}
-		jsonOutput := string(bytes)
-		if jsonOutput == "null" {
-			jsonOutput = "[]"
-		}
-		fmt.Fprint(w, jsonOutput)
-	case "yaml":
-		bytes, err := yaml.Marshal(pipelineListOutput)
-		if err != nil {
-			return err
-		}
-		fmt.Fprint(w, string(bytes))
-	default:
+	return output.Print(w, opts.output, pipelineListOutput, func(w io.Writer) error {
 		tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
 		cols := []string{"Name", "Status", "Repository", "Branch", "Labels"}
 		header := strings.Join(cols, "\t")
@@ -166,12 +152,11 @@ func executeListPipelines(ctx context.Context, opts listFlags, listPipelines lis
 			if len(pipeline.Labels) > 0 {
 				labels = strings.Join(pipeline.Labels, ", ")
 			}
-			output := fmt.Sprintf("%s\t%s\t%s\t%s\t%s", pipeline.Name, pipeline.Status, pipeline.Repository, pipeline.Branch, labels)
-			fmt.Fprintln(tw, output)
+			line := fmt.Sprintf("%s\t%s\t%s\t%s\t%s", pipeline.Name, pipeline.Status, pipeline.Repository, pipeline.Branch, labels)
+			fmt.Fprintln(tw, line)
 		}
-		tw.Flush()
-	}
-	return nil
+		return tw.Flush()
+	})
 }
 
 func getLabelSelector(labels []string) (string, error) {
@@ -316,7 +316,8 @@ dev2  dev2-status  https://dev2-repository  dev2-branch  fake-label-2
    "fake-label-3"
   ]
  }
-]`,
+]
+`,
 		},
 		{
 			name: "success - empty JSON output",
@@ -339,7 +340,7 @@ dev2  dev2-status  https://dev2-repository  dev2-branch  fake-label-2
 				),
 			},
 			expectedError:         nil,
-			expectedPrintedOutput: `[]`,
+			expectedPrintedOutput: "[]\n",
 		},
 		{
 			name: "success - YAML output",
@@ -0,0 +1,185 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"gopkg.in/yaml.v2"
+)
+
+// matrixRun is the result of one of the pipeline runs of a matrix deploy
+type matrixRun struct {
+	err       error
+	name      string
+	variables []string
+}
+
+// loadVariablesFile returns the variables defined in a yaml file as KEY: VALUE pairs, sorted by name
+func loadVariablesFile(path string) ([]string, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read variables file '%s': %w", path, err)
+	}
+
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal(bytes, &values); err != nil {
+		return nil, fmt.Errorf("invalid variables file '%s': it must be a map of variable names to values: %w", path, err)
+	}
+
+	variables := make([]string, 0, len(values))
+	for name, value := range values {
+		switch value.(type) {
+		case map[interface{}]interface{}, []interface{}:
+			return nil, fmt.Errorf("invalid variables file '%s': the value of '%s' must be a string, a number or a boolean", path, name)
+		case nil:
+			value = ""
+		}
+		variables = append(variables, fmt.Sprintf("%s=%v", name, value))
+	}
+	sort.Strings(variables)
+	return variables, nil
+}
+
+// mergeVariables merges lists of KEY=VALUE variables. Variables of later lists override the ones of previous lists
+func mergeVariables(lists ...[]string) ([]string, error) {
+	result := []string{}
+	indexes := map[string]int{}
+	for _, list := range lists {
+		for _, v := range list {
+			name, _, found := strings.Cut(v, "=")
+			if !found {
+				return nil, fmt.Errorf("invalid variable value '%s': must follow KEY=VALUE format", v)
+			}
+			if i, ok := indexes[name]; ok {
+				result[i] = v
+				continue
+			}
+			indexes[name] = len(result)
+			result = append(result, v)
+		}
+	}
+	return result, nil
+}
+
+// getMatrixCombinations returns every combination of the values of the matrix variables, defined as KEY=VALUE1,VALUE2
+func getMatrixCombinations(matrix []string) ([][]string, error) {
+	combinations := [][]string{{}}
+	seen := map[string]bool{}
+	for _, m := range matrix {
+		name, list, found := strings.Cut(m, "=")
+		if !found || name == "" || list == "" {
+			return nil, fmt.Errorf("invalid matrix value '%s': must follow KEY=VALUE1,VALUE2 format", m)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("invalid matrix value '%s': variable '%s' is defined more than once", m, name)
+		}
+		seen[name] = true
+
+		values := strings.Split(list, ",")
+		next := make([][]string, 0, len(combinations)*len(values))
+		for _, combination := range combinations {
+			for _, value := range values {
+				if value == "" {
+					return nil, fmt.Errorf("invalid matrix value '%s': values can't be empty", m)
+				}
+				variables := make([]string, len(combination), len(combination)+1)
+				copy(variables, combination)
+				next = append(next, append(variables, fmt.Sprintf("%s=%s", name, value)))
+			}
+		}
+		combinations = next
+	}
+	return combinations, nil
+}
+
+// getMatrixRunName returns the name of the pipeline of a matrix run, suffixing the name with the values of the run
+func getMatrixRunName(name string, combination []string) string {
+	parts := []string{name}
+	for _, v := range combination {
+		_, value, _ := strings.Cut(v, "=")
+		parts = append(parts, value)
+	}
+	return strings.Join(parts, "-")
+}
+
+// executeDeployMatrix deploys one pipeline for each combination of the matrix variables and reports the status of every run
+func (pc *Command) executeDeployMatrix(ctx context.Context, opts *DeployOptions, matrix []string) error {
+	combinations, err := getMatrixCombinations(matrix)
+	if err != nil {
+		return err
+	}
+
+	if err := opts.setDefaults(); err != nil {
+		return fmt.Errorf("could not set default values for options: %w", err)
+	}
+
+	runs := make([]matrixRun, 0, len(combinations))
+	for _, combination := range combinations {
+		variables, err := mergeVariables(opts.Variables, combination)
+		if err != nil {
+			return err
+		}
+		runOpts := *opts
+		runOpts.Name = getMatrixRunName(opts.Name, combination)
+		runOpts.Variables = variables
+
+		oktetoLog.SetStage(runOpts.Name)
+		oktetoLog.Information("Deploying '%s' with %s", runOpts.Name, strings.Join(combination, " "))
+		err = pc.ExecuteDeployPipeline(ctx, &runOpts)
+		if err != nil {
+			oktetoLog.Fail("Pipeline '%s' failed: %s", runOpts.Name, err)
+		}
+		runs = append(runs, matrixRun{name: runOpts.Name, variables: combination, err: err})
+	}
+	oktetoLog.SetStage("")
+
+	if err := renderMatrixRuns(os.Stdout, runs); err != nil {
+		oktetoLog.Infof("failed to render the pipeline runs: %s", err)
+	}
+
+	failed := 0
+	for _, run := range runs {
+		if run.err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d pipeline runs failed", failed, len(runs))
+	}
+	return nil
+}
+
+func renderMatrixRuns(w io.Writer, runs []matrixRun) error {
+	table := oktetoLog.NewTable(
+		oktetoLog.Column{Header: "Pipeline"},
+		oktetoLog.Column{Header: "Variables"},
+		oktetoLog.Column{Header: "Status"},
+	)
+	for _, run := range runs {
+		status := "success"
+		if run.err != nil {
+			status = "failed"
+		}
+		table.AddRow(run.name, strings.Join(run.variables, " "), status)
+	}
+	fmt.Fprintln(w)
+	return table.Render(w, "")
+}
@@ -0,0 +1,197 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/internal/test"
+	"github.com/okteto/okteto/pkg/okteto"
+	client "github.com/okteto/okteto/pkg/okteto/fake"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_loadVariablesFile(t *testing.T) {
+	var tests = []struct {
+		name        string
+		content     string
+		expected    []string
+		expectedErr bool
+	}{
+		{
+			name:     "scalar values",
+			content:  "REGION: us-east-1\nREPLICAS: 3\nDEBUG: true\nEMPTY:\n",
+			expected: []string{"DEBUG=true", "EMPTY=", "REGION=us-east-1", "REPLICAS=3"},
+		},
+		{
+			name:        "nested values",
+			content:     "REGIONS:\n  - us-east-1\n",
+			expectedErr: true,
+		},
+		{
+			name:        "not a map",
+			content:     "- REGION=us-east-1\n",
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "vars.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0600))
+
+			result, err := loadVariablesFile(path)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_mergeVariables(t *testing.T) {
+	result, err := mergeVariables([]string{"A=1", "B=2"}, []string{"B=3", "C=4"}, []string{"A=5"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A=5", "B=3", "C=4"}, result)
+
+	_, err = mergeVariables([]string{"A"})
+	assert.Error(t, err)
+}
+
+func Test_getMatrixCombinations(t *testing.T) {
+	var tests = []struct {
+		name        string
+		matrix      []string
+		expected    [][]string
+		expectedErr bool
+	}{
+		{
+			name:     "single variable",
+			matrix:   []string{"REGION=us,eu"},
+			expected: [][]string{{"REGION=us"}, {"REGION=eu"}},
+		},
+		{
+			name:   "several variables",
+			matrix: []string{"REGION=us,eu", "SIZE=small,large"},
+			expected: [][]string{
+				{"REGION=us", "SIZE=small"},
+				{"REGION=us", "SIZE=large"},
+				{"REGION=eu", "SIZE=small"},
+				{"REGION=eu", "SIZE=large"},
+			},
+		},
+		{
+			name:        "invalid format",
+			matrix:      []string{"REGION"},
+			expectedErr: true,
+		},
+		{
+			name:        "empty value",
+			matrix:      []string{"REGION=us,"},
+			expectedErr: true,
+		},
+		{
+			name:        "duplicated variable",
+			matrix:      []string{"REGION=us", "REGION=eu"},
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := getMatrixCombinations(tt.matrix)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_getMatrixRunName(t *testing.T) {
+	assert.Equal(t, "api-us-small", getMatrixRunName("api", []string{"REGION=us", "SIZE=small"}))
+}
+
+func Test_executeDeployMatrix(t *testing.T) {
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		CurrentContext: "test",
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {},
+		},
+	}
+	var tests = []struct {
+		deployErr   error
+		name        string
+		expectedErr bool
+	}{
+		{
+			name: "all runs succeed",
+		},
+		{
+			name:        "runs fail",
+			deployErr:   errors.New("deploy failed"),
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := &client.FakePipelineResponses{
+				DeployResponse: &types.GitDeployResponse{
+					Action: &types.Action{ID: "test", Name: "test"},
+				},
+				DeployErr: tt.deployErr,
+			}
+			pc := &Command{
+				okClient: &client.FakeOktetoClient{
+					PipelineClient: client.NewFakePipelineClient(response),
+				},
+				k8sClientProvider: test.NewFakeK8sProvider(),
+			}
+			opts := &DeployOptions{
+				Repository: "test",
+				Name:       "test",
+				Variables:  []string{"REGION=local", "DEBUG=true"},
+			}
+			err := pc.executeDeployMatrix(context.Background(), opts, []string{"REGION=us,eu"})
+			if tt.expectedErr {
+				assert.EqualError(t, err, "2 of 2 pipeline runs failed")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "test-eu", response.DeployOpts.Name)
+			assert.Equal(t, []types.Variable{{Name: "REGION", Value: "eu"}, {Name: "DEBUG", Value: "true"}}, response.DeployOpts.Variables)
+		})
+	}
+}
+
+func Test_renderMatrixRuns(t *testing.T) {
+	var buf bytes.Buffer
+	runs := []matrixRun{
+		{name: "api-us", variables: []string{"REGION=us"}},
+		{name: "api-eu", variables: []string{"REGION=eu"}, err: errors.New("failed")},
+	}
+	require.NoError(t, renderMatrixRuns(&buf, runs))
+	assert.Contains(t, buf.String(), "api-us")
+	assert.Contains(t, buf.String(), "success")
+	assert.Contains(t, buf.String(), "failed")
+}
@@ -61,5 +61,6 @@ func Pipeline(ctx context.Context) *cobra.Command {
 	cmd.AddCommand(deploy(ctx))
 	cmd.AddCommand(destroy(ctx))
 	cmd.AddCommand(list(ctx))
+	cmd.AddCommand(wait(ctx))
 	return cmd
 }
@@ -0,0 +1,236 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	modelUtils "github.com/okteto/okteto/pkg/model/utils"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// WaitFailureReason represents why a pipeline didn't become healthy
+type WaitFailureReason string
+
+const (
+	// WaitReasonNotFound means the pipeline doesn't exist in the namespace
+	WaitReasonNotFound WaitFailureReason = "not-found"
+
+	// WaitReasonDeployFailed means the deploy of the pipeline failed
+	WaitReasonDeployFailed WaitFailureReason = "deploy-failed"
+
+	// WaitReasonResourcesFailed means the pipeline was deployed but some of its resources have errors
+	WaitReasonResourcesFailed WaitFailureReason = "resources-failed"
+
+	// WaitReasonTimeout means the pipeline didn't become healthy before the timeout
+	WaitReasonTimeout WaitFailureReason = "timeout"
+)
+
+// waitInterval is the time between two checks of the pipeline status
+var waitInterval = 1 * time.Second
+
+// WaitError is returned when a pipeline doesn't become healthy
+type WaitError struct {
+	Name   string
+	Reason WaitFailureReason
+	// Status is the last status of the pipeline
+	Status string
+	// Resources are the resources that aren't running, with their last status
+	Resources map[string]string
+}
+
+// Error returns the error message
+func (e WaitError) Error() string {
+	msg := fmt.Sprintf("pipeline '%s' is not healthy (reason: %s", e.Name, e.Reason)
+	if e.Status != "" {
+		msg = fmt.Sprintf("%s, status: %s", msg, e.Status)
+	}
+	msg = fmt.Sprintf("%s)", msg)
+
+	if len(e.Resources) > 0 {
+		resources := make([]string, 0, len(e.Resources))
+		for name, status := range e.Resources {
+			resources = append(resources, fmt.Sprintf("%s is %s", name, status))
+		}
+		sort.Strings(resources)
+		msg = fmt.Sprintf("%s: %s", msg, strings.Join(resources, ", "))
+	}
+	return msg
+}
+
+// Unwrap classifies timeouts so they return the timeout exit code
+func (e WaitError) Unwrap() error {
+	if e.Reason == WaitReasonTimeout {
+		return oktetoErrors.ErrTimeout
+	}
+	return nil
+}
+
+type waitFlags struct {
+	namespace string
+	timeout   time.Duration
+}
+
+func wait(ctx context.Context) *cobra.Command {
+	flags := &waitFlags{}
+	cmd := &cobra.Command{
+		Use:   "wait <name|repository>",
+		Short: "Wait until an okteto pipeline is deployed and all its resources are healthy",
+		Args:  utils.ExactArgsAccepted(1, "https://www.okteto.com/docs/reference/cli/#pipeline"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctxResource := &model.ContextResource{}
+			if err := ctxResource.UpdateNamespace(flags.namespace); err != nil {
+				return err
+			}
+
+			ctxOptions := &contextCMD.ContextOptions{
+				Namespace: ctxResource.Namespace,
+				Show:      true,
+			}
+			if err := contextCMD.NewContextCommand().Run(ctx, ctxOptions); err != nil {
+				return err
+			}
+
+			if !okteto.IsOkteto() {
+				return oktetoErrors.ErrContextIsNotOktetoCluster
+			}
+
+			namespace := flags.namespace
+			if namespace == "" {
+				namespace = okteto.Context().Namespace
+			}
+
+			pipelineCmd, err := NewCommand()
+			if err != nil {
+				return err
+			}
+
+			name := getPipelineName(args[0])
+			oktetoLog.Spinner(fmt.Sprintf("Waiting for pipeline '%s' to be healthy...", name))
+			oktetoLog.StartSpinner()
+			defer oktetoLog.StopSpinner()
+
+			if err := pipelineCmd.WaitForPipeline(ctx, name, namespace, flags.timeout); err != nil {
+				return err
+			}
+			oktetoLog.Success("Pipeline '%s' is healthy", name)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&flags.namespace, "namespace", "n", "", "namespace where the pipeline is deployed (defaults to the current namespace)")
+	cmd.Flags().DurationVarP(&flags.timeout, "timeout", "t", (5 * time.Minute), "the length of time to wait for the pipeline to be healthy, zero means never. Any other values should contain a corresponding time unit e.g. 1s, 2m, 3h ")
+	return cmd
+}
+
+// getPipelineName returns the name of the pipeline from its name or its repository url
+func getPipelineName(nameOrRepository string) string {
+	if strings.Contains(nameOrRepository, "/") || strings.Contains(nameOrRepository, ":") {
+		return modelUtils.TranslateURLToName(nameOrRepository)
+	}
+	return nameOrRepository
+}
+
+// WaitForPipeline blocks until the pipeline is deployed and all its resources are running
+func (pc *Command) WaitForPipeline(ctx context.Context, name, namespace string, timeout time.Duration) error {
+	ticker := time.NewTicker(waitInterval)
+	defer ticker.Stop()
+
+	var timeoutCh <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		timeoutCh = timer.C
+	}
+
+	lastErr := WaitError{Name: name, Reason: WaitReasonNotFound}
+	for {
+		status, err := pc.okClient.Pipeline().GetStatus(ctx, name, namespace)
+		switch {
+		case oktetoErrors.IsNotFound(err):
+			// the pipeline might not have been created yet
+			lastErr = WaitError{Name: name, Reason: WaitReasonNotFound}
+		case err != nil:
+			return err
+		default:
+			healthy, waitErr := checkPipelineHealthy(status)
+			if healthy {
+				return nil
+			}
+			if waitErr.Reason != WaitReasonTimeout {
+				return waitErr
+			}
+			lastErr = waitErr
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeoutCh:
+			if lastErr.Reason == WaitReasonNotFound {
+				return lastErr
+			}
+			lastErr.Reason = WaitReasonTimeout
+			return lastErr
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkPipelineHealthy returns if the pipeline is healthy. If it isn't, the error explains why: errors with the timeout reason are still in progress
+func checkPipelineHealthy(status *types.PipelineStatus) (bool, WaitError) {
+	waitErr := WaitError{Name: status.Name, Status: status.Status, Reason: WaitReasonTimeout}
+	switch status.Status {
+	case pipeline.ErrorStatus:
+		waitErr.Reason = WaitReasonDeployFailed
+		return false, waitErr
+	case pipeline.DeployedStatus:
+	default:
+		return false, waitErr
+	}
+
+	failed := map[string]string{}
+	pending := map[string]string{}
+	for resource, resourceStatus := range status.Resources {
+		oktetoLog.Infof("resource %s is %s", resource, resourceStatus)
+		if resourceStatus == okteto.ErrorStatus {
+			failed[resource] = resourceStatus
+		} else if okteto.TransitionStatus[resourceStatus] {
+			pending[resource] = resourceStatus
+		}
+	}
+
+	if len(failed) > 0 {
+		waitErr.Reason = WaitReasonResourcesFailed
+		waitErr.Resources = failed
+		return false, waitErr
+	}
+	if len(pending) > 0 {
+		waitErr.Resources = pending
+		return false, waitErr
+	}
+	return true, WaitError{}
+}
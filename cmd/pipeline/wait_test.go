@@ -0,0 +1,111 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	client "github.com/okteto/okteto/pkg/okteto/fake"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitForPipeline(t *testing.T) {
+	waitInterval = 10 * time.Millisecond
+	defer func() {
+		waitInterval = 1 * time.Second
+	}()
+
+	tests := []struct {
+		expectedErr *WaitError
+		statusErr   error
+		name        string
+		statuses    []*types.PipelineStatus
+	}{
+		{
+			name: "healthy after progressing",
+			statuses: []*types.PipelineStatus{
+				{Name: "api", Status: "progressing"},
+				{Name: "api", Status: "deployed", Resources: map[string]string{"deployment/api": "booting"}},
+				{Name: "api", Status: "deployed", Resources: map[string]string{"deployment/api": "running", "job/migrate": "completed"}},
+			},
+		},
+		{
+			name: "deploy failed",
+			statuses: []*types.PipelineStatus{
+				{Name: "api", Status: "error"},
+			},
+			expectedErr: &WaitError{Name: "api", Status: "error", Reason: WaitReasonDeployFailed},
+		},
+		{
+			name: "resources failed",
+			statuses: []*types.PipelineStatus{
+				{Name: "api", Status: "deployed", Resources: map[string]string{"deployment/api": "running", "statefulset/db": "error"}},
+			},
+			expectedErr: &WaitError{Name: "api", Status: "deployed", Reason: WaitReasonResourcesFailed, Resources: map[string]string{"statefulset/db": "error"}},
+		},
+		{
+			name: "timeout with pending resources",
+			statuses: []*types.PipelineStatus{
+				{Name: "api", Status: "deployed", Resources: map[string]string{"deployment/api": "pulling"}},
+			},
+			expectedErr: &WaitError{Name: "api", Status: "deployed", Reason: WaitReasonTimeout, Resources: map[string]string{"deployment/api": "pulling"}},
+		},
+		{
+			name:        "not found",
+			statusErr:   oktetoErrors.ErrNotFound,
+			expectedErr: &WaitError{Name: "api", Reason: WaitReasonNotFound},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pc := &Command{
+				okClient: &client.FakeOktetoClient{
+					PipelineClient: client.NewFakePipelineClient(&client.FakePipelineResponses{
+						StatusResponses: tt.statuses,
+						StatusErr:       tt.statusErr,
+					}),
+				},
+			}
+
+			err := pc.WaitForPipeline(context.Background(), "api", "test", 100*time.Millisecond)
+			if tt.expectedErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.Equal(t, *tt.expectedErr, err)
+		})
+	}
+}
+
+func TestWaitErrorExitCode(t *testing.T) {
+	err := WaitError{Name: "api", Reason: WaitReasonTimeout, Resources: map[string]string{"deployment/api": "pulling", "deployment/web": "booting"}}
+	assert.Equal(t, oktetoErrors.ExitCodeTimeout, oktetoErrors.GetExitCode(err))
+	assert.Equal(t, "pipeline 'api' is not healthy (reason: timeout): deployment/api is pulling, deployment/web is booting", err.Error())
+
+	err = WaitError{Name: "api", Reason: WaitReasonDeployFailed, Status: "error"}
+	assert.Equal(t, oktetoErrors.ExitCodeGeneric, oktetoErrors.GetExitCode(err))
+	assert.Equal(t, "pipeline 'api' is not healthy (reason: deploy-failed, status: error)", err.Error())
+}
+
+func TestGetPipelineName(t *testing.T) {
+	assert.Equal(t, "movies", getPipelineName("movies"))
+	assert.Equal(t, "movies", getPipelineName("https://github.com/okteto/movies"))
+	assert.Equal(t, "movies", getPipelineName("git@github.com:okteto/movies.git"))
+}
@@ -30,7 +30,9 @@ import (
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/repository"
 	"github.com/okteto/okteto/pkg/types"
+	"github.com/okteto/okteto/pkg/vcs"
 	"github.com/spf13/cobra"
 )
 
@@ -39,6 +41,7 @@ var (
 )
 
 type DeployOptions struct {
+	publisher          *vcsPublisher
 	branch             string
 	deprecatedFilename string
 	file               string
@@ -46,10 +49,16 @@ type DeployOptions struct {
 	repository         string
 	scope              string
 	sourceUrl          string
+	sha                string
+	commit             *repository.CommitInfo
+	vcsProvider        string
 	variables          []string
 	labels             []string
 	timeout            time.Duration
+	prNumber           int
 	wait               bool
+	commitStatus       bool
+	comment            bool
 }
 
 // Deploy Deploy a preview environment
@@ -94,6 +103,11 @@ func Deploy(ctx context.Context) *cobra.Command {
 	cmd.Flags().BoolVarP(&opts.wait, "wait", "w", false, "wait until the preview environment deployment finishes (defaults to false)")
 	cmd.Flags().StringVarP(&opts.file, "file", "f", "", "relative path within the repository to the okteto manifest (default to okteto.yaml or .okteto/okteto.yaml)")
 	cmd.Flags().StringArrayVarP(&opts.labels, "label", "", []string{}, "set a preview environment label (can be set more than once)")
+	cmd.Flags().IntVarP(&opts.prNumber, "pr-number", "", 0, "the number of the pull/merge request the preview environment is deployed for")
+	cmd.Flags().StringVarP(&opts.sha, "sha", "", "", "the commit SHA of the pull/merge request (defaults to the current commit)")
+	cmd.Flags().StringVarP(&opts.vcsProvider, "vcs-provider", "", "", "the VCS provider of the repository (defaults to the provider inferred from the repository URL). Accepted values are ['github', 'gitlab', 'bitbucket']")
+	cmd.Flags().BoolVarP(&opts.commitStatus, "commit-status", "", false, "publish the preview environment status as a commit status, waiting until the deployment finishes (defaults to false)")
+	cmd.Flags().BoolVarP(&opts.comment, "comment", "", false, "comment the preview environment endpoints on the pull/merge request (defaults to false)")
 
 	cmd.Flags().StringVarP(&opts.deprecatedFilename, "filename", "", "", "relative path within the repository to the manifest file (default to okteto-pipeline.yaml or .okteto/okteto-pipeline.yaml)")
 	if err := cmd.Flags().MarkHidden("filename"); err != nil {
@@ -103,21 +117,40 @@ func Deploy(ctx context.Context) *cobra.Command {
 }
 
 func (pw *Command) ExecuteDeployPreview(ctx context.Context, opts *DeployOptions) error {
+	previewURL := getPreviewURL(opts.name)
 	resp, err := pw.deployPreview(ctx, opts)
 	analytics.TrackPreviewDeploy(err == nil, opts.scope)
 	if err != nil {
+		opts.publisher.publishStatus(ctx, vcs.StateFailure, "Preview environment failed to deploy", previewURL)
 		return err
 	}
 
-	oktetoLog.Information("Preview URL: %s", getPreviewURL(opts.name))
-	if !opts.wait {
+	oktetoLog.Information("Preview URL: %s", previewURL)
+	opts.publisher.publishStatus(ctx, vcs.StatePending, "Preview environment is being deployed", previewURL)
+	wait := opts.wait
+	if !wait && opts.publisher.publishesStatus() {
+		// the pending commit status is only resolved when the deployment finishes
+		oktetoLog.Information("Waiting for the preview environment to be deployed to publish its commit status")
+		wait = true
+	}
+	if !wait {
+		opts.publisher.publishComment(ctx, getPreviewComment(opts.name, previewURL, false, nil))
 		oktetoLog.Success("Preview environment '%s' scheduled for deployment", opts.name)
 		return nil
 	}
 
 	if err := pw.waitUntilRunning(ctx, opts.name, opts.name, resp.Action, opts.timeout); err != nil {
+		opts.publisher.publishStatus(ctx, vcs.StateFailure, "Preview environment failed to deploy", previewURL)
 		return err
 	}
+	opts.publisher.publishStatus(ctx, vcs.StateSuccess, "Preview environment successfully deployed", previewURL)
+	if opts.publisher != nil && opts.publisher.comment {
+		endpoints, err := pw.okClient.Previews().ListEndpoints(ctx, opts.name)
+		if err != nil {
+			oktetoLog.Infof("failed to get preview environment endpoints: %s", err)
+		}
+		opts.publisher.publishComment(ctx, getPreviewComment(opts.name, previewURL, true, endpoints))
+	}
 	oktetoLog.Success("Preview environment '%s' successfully deployed", opts.name)
 	return nil
 }
@@ -140,7 +173,7 @@ func (pw *Command) deployPreview(ctx context.Context, opts *DeployOptions) (*typ
 		})
 	}
 
-	return pw.okClient.Previews().DeployPreview(ctx, opts.name, opts.scope, opts.repository, opts.branch, opts.sourceUrl, opts.file, varList, opts.labels)
+	return pw.okClient.Previews().DeployPreview(ctx, opts.name, opts.scope, opts.repository, opts.branch, opts.sourceUrl, opts.file, varList, opts.labels, opts.commit)
 }
 
 func (pw *Command) waitUntilRunning(ctx context.Context, name, namespace string, a *types.Action, timeout time.Duration) error {
@@ -24,6 +24,7 @@ import (
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	modelUtils "github.com/okteto/okteto/pkg/model/utils"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/repository"
 )
 
 var (
@@ -51,6 +52,24 @@ func optionsSetup(cwd string, opts *DeployOptions, args []string) error {
 		return err
 	}
 
+	if opts.commitStatus && opts.sha == "" {
+		oktetoLog.Info("inferring git commit SHA")
+		opts.sha, err = repository.NewRepository(cwd).GetSHA()
+		if err != nil {
+			return fmt.Errorf("failed to get the commit SHA: %w", err)
+		}
+	}
+
+	opts.commit = utils.GetDeployedCommitInfo(cwd, opts.repository, opts.branch, opts.sha)
+
+	opts.publisher, err = newVCSPublisher(opts)
+	if err != nil {
+		return err
+	}
+	if opts.sourceUrl == "" {
+		opts.sourceUrl = opts.publisher.pullRequestURL()
+	}
+
 	if opts.deprecatedFilename != "" {
 		oktetoLog.Warning("the 'filename' flag is deprecated and will be removed in a future version. Please consider using 'file' flag'")
 		if opts.file == "" {
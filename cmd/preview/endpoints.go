@@ -16,8 +16,8 @@ package preview
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"sort"
 	"strings"
@@ -28,12 +28,16 @@ import (
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/output"
 	"github.com/spf13/cobra"
 )
 
+// mdOutput is the output format that lists the endpoints as markdown links, used to comment them in pull requests
+const mdOutput = "md"
+
 // Endpoints show all the endpoints of a preview environment
 func Endpoints(ctx context.Context) *cobra.Command {
-	var output string
+	var outputFormat string
 
 	cmd := &cobra.Command{
 		Use:   "endpoints <name>",
@@ -43,20 +47,25 @@ func Endpoints(ctx context.Context) *cobra.Command {
 
 			previewName := args[0]
 
+			if err := validateOutput(outputFormat); err != nil {
+				return err
+			}
+			structured := outputFormat != "" && outputFormat != mdOutput
+
 			ctxResource := &model.ContextResource{}
 			if err := ctxResource.UpdateNamespace(previewName); err != nil {
 				return err
 			}
 
 			jsonContextBuffer := bytes.NewBuffer([]byte{})
-			if output == "json" {
+			if structured {
 				oktetoLog.SetOutput(jsonContextBuffer)
 			}
 
 			if err := contextCMD.NewContextCommand().Run(ctx, &contextCMD.ContextOptions{}); err != nil {
 				return err
 			}
-			if output != "json" {
+			if !structured {
 				oktetoLog.Information("Using %s @ %s as context", previewName, okteto.RemoveSchema(okteto.Context().Name))
 			} else {
 				oktetoLog.Info(jsonContextBuffer.String())
@@ -67,28 +76,24 @@ func Endpoints(ctx context.Context) *cobra.Command {
 				return oktetoErrors.ErrContextIsNotOktetoCluster
 			}
 
-			if err := validateOutput(output); err != nil {
-				return err
-			}
-			err := executeListPreviewEndpoints(ctx, previewName, output)
+			err := executeListPreviewEndpoints(ctx, previewName, outputFormat)
 			return err
 		},
 	}
-	cmd.Flags().StringVarP(&output, "output", "o", "", "output format. One of: ['json', 'md']")
+	output.AddFlag(cmd, &outputFormat)
+	cmd.Flags().Lookup("output").Usage += ", or 'md' to list the endpoints as markdown links"
 
 	return cmd
 }
 
-func validateOutput(output string) error {
-	switch output {
-	case "", "json", "md":
+func validateOutput(outputFormat string) error {
+	if outputFormat == mdOutput {
 		return nil
-	default:
-		return fmt.Errorf("output format is not accepted. Value must be one of: ['json', 'md']")
 	}
+	return output.Validate(outputFormat)
 }
 
-func executeListPreviewEndpoints(ctx context.Context, name, output string) error {
+func executeListPreviewEndpoints(ctx context.Context, name, outputFormat string) error {
 	oktetoClient, err := okteto.NewOktetoClient()
 	if err != nil {
 		return err
@@ -98,14 +103,7 @@ func executeListPreviewEndpoints(ctx context.Context, name, output string) error
 		return fmt.Errorf("failed to get preview environments: %w", err)
 	}
 
-	switch output {
-	case "json":
-		bytes, err := json.MarshalIndent(endpointList, "", "  ")
-		if err != nil {
-			return err
-		}
-		oktetoLog.Println(string(bytes))
-	case "md":
+	if outputFormat == mdOutput {
 		if len(endpointList) == 0 {
 			oktetoLog.Printf("There are no available endpoints for preview '%s'\n", name)
 		} else {
@@ -121,7 +119,9 @@ func executeListPreviewEndpoints(ctx context.Context, name, output string) error
 				oktetoLog.Printf("\n - [%s](%s)\n", e, e)
 			}
 		}
-	default:
+		return nil
+	}
+	return output.Print(os.Stdout, outputFormat, endpointList, func(io.Writer) error {
 		if len(endpointList) == 0 {
 			oktetoLog.Printf("There are no available endpoints for preview '%s'\n", name)
 		} else {
@@ -134,6 +134,6 @@ func executeListPreviewEndpoints(ctx context.Context, name, output string) error
 			})
 			oktetoLog.Printf("Available endpoints for preview '%s':\n  - %s\n", name, strings.Join(endpoints, "\n  - "))
 		}
-	}
-	return nil
+		return nil
+	}, output.WithJSONIndent("  "))
 }
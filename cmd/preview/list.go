@@ -15,33 +15,51 @@ package preview
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"strconv"
 	"strings"
-	"text/tabwriter"
 
 	contextCMD "github.com/okteto/okteto/cmd/context"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/output"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v2"
 )
 
 var (
-	errInvalidOutput = fmt.Errorf("output format is not accepted. Value must be one of: ['json', 'yaml']")
+	// previewSortFields are the fields accepted by the '--sort' flag
+	previewSortFields = []string{"name", "owner", "branch", "created"}
+
+	// previewStatuses are the statuses accepted by the '--status' flag
+	previewStatuses = []string{"Success", "Failed", "Progressing", "Queued"}
+
+	errMineAndOwner = errors.New("the flags '--mine' and '--owner' can't be used together")
+	errNoUsername   = errors.New("the username of the current context is unknown")
 )
 
 // listFlags are the flags available for list commands
 type listFlags struct {
 	output string
+	owner  string
+	branch string
+	status string
+	sort   string
 	labels []string
+	limit  int
+	mine   bool
 }
 
 type previewOutput struct {
 	Name     string   `json:"name" yaml:"name"`
 	Scope    string   `json:"scope" yaml:"scope"`
+	Owner    string   `json:"owner,omitempty" yaml:"owner,omitempty"`
+	Branch   string   `json:"branch,omitempty" yaml:"branch,omitempty"`
+	Status   string   `json:"status,omitempty" yaml:"status,omitempty"`
 	Labels   []string `json:"labels" yaml:"labels"`
 	Sleeping bool     `json:"sleeping" yaml:"sleeping"`
 }
@@ -63,6 +81,15 @@ func List(ctx context.Context) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all preview environments",
+		Long: `List all preview environments.
+
+Preview environments can be filtered by owner, branch, label and status, and sorted by name, owner, branch or creation date.
+The filters and the sorting are applied by Okteto, and the results are requested page by page.`,
+		Example: `  # list your preview environments
+  okteto preview list --mine
+
+  # list the five most recent preview environments of the main branch
+  okteto preview list --branch main --sort created --limit 5`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctxOptions := &contextCMD.ContextOptions{}
 
@@ -87,18 +114,33 @@ func List(ctx context.Context) *cobra.Command {
 		},
 	}
 	cmd.Flags().StringArrayVarP(&flags.labels, "label", "", []string{}, "tag and organize preview environments using labels (multiple --label flags accepted)")
-	cmd.Flags().StringVarP(&flags.output, "output", "o", "", "output format. One of: ['json', 'yaml']")
+	cmd.Flags().StringVarP(&flags.owner, "owner", "", "", "only list the preview environments created by this user")
+	cmd.Flags().BoolVarP(&flags.mine, "mine", "", false, "only list the preview environments created by the current user")
+	cmd.Flags().StringVarP(&flags.branch, "branch", "", "", "only list the preview environments deployed from this branch")
+	cmd.Flags().StringVarP(&flags.status, "status", "", "", fmt.Sprintf("only list the preview environments with one of these statuses: %s", strings.Join(previewStatuses, ", ")))
+	cmd.Flags().StringVarP(&flags.sort, "sort", "", "", fmt.Sprintf("sort the preview environments by one of these fields: %s", strings.Join(previewSortFields, ", ")))
+	cmd.Flags().IntVarP(&flags.limit, "limit", "", 0, "maximum number of preview environments to list (0 means all of them)")
+	output.AddFlag(cmd, &flags.output)
 
 	return cmd
 }
 
 func (cmd *listPreviewCommand) run(ctx context.Context) error {
 
-	if err := validatePreviewListOutput(cmd.flags.output); err != nil {
+	if err := output.Validate(cmd.flags.output); err != nil {
+		return err
+	}
+
+	username := ""
+	if cmd.flags.mine {
+		username = okteto.Context().Username
+	}
+	opts, err := cmd.flags.getListOptions(username)
+	if err != nil {
 		return err
 	}
 
-	previewList, err := cmd.okClient.Previews().List(ctx, cmd.flags.labels)
+	previewList, err := cmd.okClient.Previews().List(ctx, opts)
 	if err != nil {
 		if uErr, ok := err.(oktetoErrors.UserError); ok {
 			return uErr
@@ -110,49 +152,91 @@ func (cmd *listPreviewCommand) run(ctx context.Context) error {
 	return displayListPreviews(previewOutput, cmd.flags.output)
 }
 
-// displayListPreviews prints the list of previews
-func displayListPreviews(previews []previewOutput, outputFormat string) error {
-	switch outputFormat {
-	case "json":
-		// json marshal return null for empty objects, returning the empty list if no previews are retrieved
-		if len(previews) == 0 {
-			fmt.Println(previews)
-			return nil
+// getListOptions returns the options to list the preview environments from the flags.
+// username is the user of the current context, used by the '--mine' flag
+func (f *listFlags) getListOptions(username string) (types.PreviewListOptions, error) {
+	opts := types.PreviewListOptions{
+		Labels: f.labels,
+		Owner:  f.owner,
+		Branch: f.branch,
+		Status: f.status,
+		SortBy: f.sort,
+		Limit:  f.limit,
+	}
+	if f.mine {
+		if f.owner != "" {
+			return opts, errMineAndOwner
 		}
-		bytes, err := json.MarshalIndent(previews, "", " ")
-		if err != nil {
-			return err
+		if username == "" {
+			return opts, oktetoErrors.UserError{
+				E:    errNoUsername,
+				Hint: "Run 'okteto context use' to log in again, or use the '--owner' flag instead",
+			}
 		}
-		fmt.Println(string(bytes))
-	case "yaml":
-		bytes, err := yaml.Marshal(previews)
-		if err != nil {
-			return err
+		opts.Owner = username
+	}
+	if f.sort != "" && !isPreviewSortField(f.sort) {
+		return opts, fmt.Errorf("invalid value '%s' for the '--sort' flag. Accepted values: %s", f.sort, strings.Join(previewSortFields, ", "))
+	}
+	if f.status != "" {
+		status, ok := getPreviewStatus(f.status)
+		if !ok {
+			return opts, fmt.Errorf("invalid value '%s' for the '--status' flag. Accepted values: %s", f.status, strings.Join(previewStatuses, ", "))
 		}
-		fmt.Println(string(bytes))
-	default:
+		opts.Status = status
+	}
+	if f.limit < 0 {
+		return opts, fmt.Errorf("invalid value '%d' for the '--limit' flag. It must be a positive number", f.limit)
+	}
+	return opts, nil
+}
+
+func isPreviewSortField(field string) bool {
+	for _, f := range previewSortFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// getPreviewStatus returns the status accepted by the server for a '--status' value, which is case insensitive
+func getPreviewStatus(value string) (string, bool) {
+	for _, s := range previewStatuses {
+		if strings.EqualFold(s, value) {
+			return s, true
+		}
+	}
+	return "", false
+}
+
+// displayListPreviews prints the list of previews
+func displayListPreviews(previews []previewOutput, outputFormat string) error {
+	return output.Print(os.Stdout, outputFormat, previews, func(w io.Writer) error {
 		if len(previews) == 0 {
-			fmt.Println("There are no previews")
+			fmt.Fprintln(w, "There are no previews")
 			return nil
 		}
-		w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
-		fmt.Fprint(w, "Name\tScope\tSleeping\tLabels\n")
+		table := oktetoLog.NewTable(
+			oktetoLog.Column{Header: "Name"},
+			oktetoLog.Column{Header: "Scope"},
+			oktetoLog.Column{Header: "Sleeping"},
+			oktetoLog.Column{Header: "Labels"},
+		)
 		for _, preview := range previews {
-			output := getPreviewDefaultOutput(preview)
-			fmt.Fprint(w, output)
+			table.AddRow(getPreviewDefaultRow(preview)...)
 		}
-		w.Flush()
-	}
-	return nil
+		return table.Render(w, "")
+	})
 }
 
-// getPreviewDefaultOutput returns the rows for the default list output format
-func getPreviewDefaultOutput(preview previewOutput) string {
+// getPreviewDefaultRow returns the cells of a preview for the default list output format
+func getPreviewDefaultRow(preview previewOutput) []string {
 	previewLabels := "-"
 	if len(preview.Labels) > 0 {
 		previewLabels = strings.Join(preview.Labels, ", ")
 	}
-	return fmt.Sprintf("%s\t%s\t%v\t%s\n", preview.Name, preview.Scope, preview.Sleeping, previewLabels)
+	return []string{preview.Name, preview.Scope, strconv.FormatBool(preview.Sleeping), previewLabels}
 }
 
 // getPreviewOutput transforms type.Preview into previewOutput type
@@ -162,6 +246,9 @@ func getPreviewOutput(previews []types.Preview) []previewOutput {
 		previewOutput := previewOutput{
 			Name:     p.ID,
 			Scope:    p.Scope,
+			Owner:    p.Owner,
+			Branch:   p.Branch,
+			Status:   p.Status,
 			Sleeping: p.Sleeping,
 			Labels:   p.PreviewLabels,
 		}
@@ -169,13 +256,3 @@ func getPreviewOutput(previews []types.Preview) []previewOutput {
 	}
 	return previewSlice
 }
-
-// validatePreviewListOutput returns error if output flag is not valid
-func validatePreviewListOutput(output string) error {
-	switch output {
-	case "", "json", "yaml":
-		return nil
-	default:
-		return errInvalidOutput
-	}
-}
@@ -19,10 +19,12 @@ import (
 	"os"
 	"testing"
 
-	"github.com/okteto/okteto/internal/test/client"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	client "github.com/okteto/okteto/pkg/okteto/fake"
+	"github.com/okteto/okteto/pkg/output"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_getPreviewOutput(t *testing.T) {
@@ -79,40 +81,10 @@ func Test_getPreviewOutput(t *testing.T) {
 	}
 }
 
-func Test_validatePreviewListOutput(t *testing.T) {
-	var tests = []struct {
-		expectedErr error
-		name        string
-		output      string
-	}{
-		{
-			name:        "output format is yaml",
-			output:      "yaml",
-			expectedErr: nil,
-		},
-		{
-			name:        "output format is json",
-			output:      "json",
-			expectedErr: nil,
-		},
-		{
-			name:        "output format is not valid",
-			output:      "xml",
-			expectedErr: errInvalidOutput,
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			err := validatePreviewListOutput(tt.output)
-			assert.Equal(t, tt.expectedErr, err)
-		})
-	}
-}
-
-func Test_getPreviewDefaultOutput(t *testing.T) {
+func Test_getPreviewDefaultRow(t *testing.T) {
 	tests := []struct {
 		name     string
-		expected string
+		expected []string
 		input    previewOutput
 	}{
 		{
@@ -122,7 +94,7 @@ func Test_getPreviewDefaultOutput(t *testing.T) {
 				Scope:    "personal",
 				Sleeping: false,
 			},
-			expected: "my-preview\tpersonal\tfalse\t-\n",
+			expected: []string{"my-preview", "personal", "false", "-"},
 		},
 		{
 			name: "preview with labels",
@@ -132,13 +104,13 @@ func Test_getPreviewDefaultOutput(t *testing.T) {
 				Sleeping: false,
 				Labels:   []string{"one", "two"},
 			},
-			expected: "my-preview\tpersonal\tfalse\tone, two\n",
+			expected: []string{"my-preview", "personal", "false", "one, two"},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := getPreviewDefaultOutput(tt.input)
+			got := getPreviewDefaultRow(tt.input)
 			assert.Equal(t, tt.expected, got)
 		})
 	}
@@ -221,7 +193,7 @@ test2  global    true      -
 					Sleeping: true,
 				},
 			},
-			expectedOutput: "- name: test\n  scope: personal\n  labels:\n  - test\n  - okteto\n  sleeping: true\n- name: test2\n  scope: global\n  labels: []\n  sleeping: true\n\n",
+			expectedOutput: "- name: test\n  scope: personal\n  labels:\n  - test\n  - okteto\n  sleeping: true\n- name: test2\n  scope: global\n  labels: []\n  sleeping: true\n",
 		},
 	}
 
@@ -301,7 +273,7 @@ func Test_run(t *testing.T) {
 					output: "xml",
 				},
 			},
-			expectErr: errInvalidOutput,
+			expectErr: output.ErrInvalidFormat,
 		},
 		{
 			name: "okClient Previews list returns error",
@@ -417,3 +389,77 @@ func Test_run(t *testing.T) {
 	}
 
 }
+
+func Test_getListOptions(t *testing.T) {
+	tests := []struct {
+		expectedErr error
+		flags       *listFlags
+		name        string
+		username    string
+		expected    types.PreviewListOptions
+	}{
+		{
+			name: "filters and sorting",
+			flags: &listFlags{
+				labels: []string{"test"},
+				owner:  "cindy",
+				branch: "main",
+				status: "Success",
+				sort:   "created",
+				limit:  10,
+			},
+			expected: types.PreviewListOptions{
+				Labels: []string{"test"},
+				Owner:  "cindy",
+				Branch: "main",
+				Status: "Success",
+				SortBy: "created",
+				Limit:  10,
+			},
+		},
+		{
+			name:     "mine",
+			flags:    &listFlags{mine: true},
+			username: "cindy",
+			expected: types.PreviewListOptions{
+				Owner: "cindy",
+			},
+		},
+		{
+			name:        "mine and owner",
+			flags:       &listFlags{mine: true, owner: "john"},
+			username:    "cindy",
+			expectedErr: errMineAndOwner,
+		},
+		{
+			name:        "mine without username",
+			flags:       &listFlags{mine: true},
+			expectedErr: errNoUsername,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.flags.getListOptions(tt.username)
+			assert.ErrorIs(t, err, tt.expectedErr)
+			if tt.expectedErr == nil {
+				assert.Equal(t, tt.expected, got)
+			}
+		})
+	}
+}
+
+func Test_getListOptionsInvalidValues(t *testing.T) {
+	_, err := (&listFlags{sort: "size"}).getListOptions("")
+	assert.ErrorContains(t, err, "invalid value 'size' for the '--sort' flag")
+
+	_, err = (&listFlags{status: "deployed"}).getListOptions("")
+	assert.ErrorContains(t, err, "invalid value 'deployed' for the '--status' flag")
+
+	opts, err := (&listFlags{status: "failed"}).getListOptions("")
+	require.NoError(t, err)
+	assert.Equal(t, "Failed", opts.Status)
+
+	_, err = (&listFlags{limit: -1}).getListOptions("")
+	assert.ErrorContains(t, err, "invalid value '-1' for the '--limit' flag")
+}
@@ -0,0 +1,166 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/okteto/okteto/pkg/vcs"
+)
+
+var (
+	// ErrPRNumberRequired is raised when a comment is requested without the number of the pull request
+	ErrPRNumberRequired = errors.New("the flag 'pr-number' is required to comment on the pull request")
+
+	// ErrSHARequired is raised when a commit status is requested without the SHA of the commit
+	ErrSHARequired = errors.New("the flag 'sha' is required to publish the commit status")
+
+	newVCSProvider = vcs.NewProvider
+)
+
+// vcsPublisher publishes the preview environment status to the pull request it's deployed for
+type vcsPublisher struct {
+	provider     vcs.Provider
+	pr           vcs.PullRequest
+	commitStatus bool
+	comment      bool
+}
+
+// newVCSPublisher returns the publisher for the pull request metadata. It returns nil if there is nothing to publish
+func newVCSPublisher(opts *DeployOptions) (*vcsPublisher, error) {
+	if opts.prNumber == 0 && !opts.commitStatus && !opts.comment {
+		return nil, nil
+	}
+	if opts.comment && opts.prNumber == 0 {
+		return nil, ErrPRNumberRequired
+	}
+	if opts.commitStatus && opts.sha == "" {
+		return nil, ErrSHARequired
+	}
+
+	publish := opts.commitStatus || opts.comment
+	providerName := opts.vcsProvider
+	if providerName == "" {
+		var err error
+		providerName, err = vcs.DetectProvider(opts.repository)
+		if err != nil {
+			if !publish {
+				oktetoLog.Infof("failed to infer the VCS provider: %s", err)
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to infer the VCS provider, use the 'vcs-provider' flag: %w", err)
+		}
+	}
+
+	token := ""
+	if publish {
+		var err error
+		token, err = vcs.GetToken(providerName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	provider, err := newVCSProvider(providerName, opts.repository, token, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &vcsPublisher{
+		provider: provider,
+		pr: vcs.PullRequest{
+			Repository: opts.repository,
+			Number:     opts.prNumber,
+			SHA:        opts.sha,
+		},
+		commitStatus: opts.commitStatus,
+		comment:      opts.comment,
+	}, nil
+}
+
+// pullRequestURL returns the URL of the pull request, or an empty string if it's unknown
+func (p *vcsPublisher) pullRequestURL() string {
+	if p == nil || p.pr.Number == 0 {
+		return ""
+	}
+	u, err := p.provider.PullRequestURL(p.pr)
+	if err != nil {
+		oktetoLog.Infof("failed to get pull request URL: %s", err)
+		return ""
+	}
+	return u
+}
+
+// publishesStatus returns if the publisher sets the commit status
+func (p *vcsPublisher) publishesStatus() bool {
+	return p != nil && p.commitStatus
+}
+
+// publishStatus sets the commit status. Errors are not fatal for the preview deployment.
+// The status is published even if the command is canceled, so it's not left pending
+func (p *vcsPublisher) publishStatus(ctx context.Context, state vcs.State, description, previewURL string) {
+	if !p.publishesStatus() {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	status := vcs.Status{
+		State:       state,
+		Description: description,
+		TargetURL:   previewURL,
+	}
+	if err := p.provider.PublishStatus(ctx, p.pr, status); err != nil {
+		oktetoLog.Warning("failed to publish the commit status to %s: %s", p.provider.Name(), err)
+	}
+}
+
+// publishComment comments on the pull request. Errors are not fatal for the preview deployment
+func (p *vcsPublisher) publishComment(ctx context.Context, body string) {
+	if p == nil || !p.comment {
+		return
+	}
+	if err := p.provider.PublishComment(ctx, p.pr, body); err != nil {
+		oktetoLog.Warning("failed to comment on the pull request in %s: %s", p.provider.Name(), err)
+	}
+}
+
+// getPreviewComment returns the markdown comment with the endpoints of a preview environment
+func getPreviewComment(name, previewURL string, deployed bool, endpointList []types.Endpoint) string {
+	if !deployed {
+		return fmt.Sprintf("Preview environment [%s](%s) scheduled for deployment", name, previewURL)
+	}
+
+	endpoints := make([]string, 0, len(endpointList))
+	for _, endpoint := range endpointList {
+		endpoints = append(endpoints, endpoint.URL)
+	}
+	if len(endpoints) == 0 {
+		return fmt.Sprintf("Preview environment [%s](%s) successfully deployed", name, previewURL)
+	}
+	sort.Slice(endpoints, func(i, j int) bool {
+		return len(endpoints[i]) < len(endpoints[j])
+	})
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Preview environment [%s](%s) successfully deployed. Available endpoints:\n", name, previewURL))
+	for _, e := range endpoints {
+		sb.WriteString(fmt.Sprintf("\n - [%s](%s)", e, e))
+	}
+	return sb.String()
+}
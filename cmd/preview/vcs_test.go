@@ -0,0 +1,194 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preview
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/okteto"
+	client "github.com/okteto/okteto/pkg/okteto/fake"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/okteto/okteto/pkg/vcs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeVCSProvider struct {
+	statuses []vcs.State
+	comments []string
+}
+
+func (*fakeVCSProvider) Name() string {
+	return "fake"
+}
+
+func (*fakeVCSProvider) PullRequestURL(_ vcs.PullRequest) (string, error) {
+	return "https://github.com/okteto/movies/pull/7", nil
+}
+
+func (f *fakeVCSProvider) PublishStatus(_ context.Context, _ vcs.PullRequest, status vcs.Status) error {
+	f.statuses = append(f.statuses, status.State)
+	return nil
+}
+
+func (f *fakeVCSProvider) PublishComment(_ context.Context, _ vcs.PullRequest, body string) error {
+	f.comments = append(f.comments, body)
+	return nil
+}
+
+func Test_newVCSPublisher(t *testing.T) {
+	t.Setenv(vcs.TokenEnvVar, "")
+	t.Setenv("GITHUB_TOKEN", "")
+
+	tests := []struct {
+		expectedErr error
+		opts        *DeployOptions
+		name        string
+		expectNil   bool
+	}{
+		{
+			name:      "no metadata",
+			opts:      &DeployOptions{repository: "https://github.com/okteto/movies"},
+			expectNil: true,
+		},
+		{
+			name:        "comment without pr number",
+			opts:        &DeployOptions{repository: "https://github.com/okteto/movies", comment: true},
+			expectedErr: ErrPRNumberRequired,
+		},
+		{
+			name:        "commit status without sha",
+			opts:        &DeployOptions{repository: "https://github.com/okteto/movies", commitStatus: true},
+			expectedErr: ErrSHARequired,
+		},
+		{
+			name:        "missing token",
+			opts:        &DeployOptions{repository: "https://github.com/okteto/movies", comment: true, prNumber: 7},
+			expectedErr: vcs.ErrMissingToken,
+		},
+		{
+			name:      "pr number with unknown provider",
+			opts:      &DeployOptions{repository: "https://git.example.com/okteto/movies", prNumber: 7},
+			expectNil: true,
+		},
+		{
+			name: "pr number without publishing",
+			opts: &DeployOptions{repository: "https://github.com/okteto/movies", prNumber: 7},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := newVCSPublisher(tt.opts)
+			assert.ErrorIs(t, err, tt.expectedErr)
+			if tt.expectedErr != nil {
+				return
+			}
+			if tt.expectNil {
+				assert.Nil(t, p)
+				return
+			}
+			require.NotNil(t, p)
+			assert.Equal(t, "https://github.com/okteto/movies/pull/7", p.pullRequestURL())
+		})
+	}
+}
+
+func Test_ExecuteDeployPreviewPublishes(t *testing.T) {
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {
+				Name:     "test",
+				Username: "test-username",
+			},
+		},
+		CurrentContext: "test",
+	}
+	provider := &fakeVCSProvider{}
+	opts := &DeployOptions{
+		name:       "movies-pr-7",
+		scope:      "personal",
+		repository: "https://github.com/okteto/movies",
+		publisher: &vcsPublisher{
+			provider: provider,
+			comment:  true,
+		},
+	}
+	pw := Command{
+		okClient: &client.FakeOktetoClient{
+			Preview: client.NewFakePreviewClient(&client.FakePreviewResponse{
+				Preview: &types.PreviewResponse{
+					Action: &types.Action{Name: "action-name"},
+				},
+			}),
+		},
+	}
+
+	err := pw.ExecuteDeployPreview(context.Background(), opts)
+	assert.NoError(t, err)
+	assert.Empty(t, provider.statuses)
+	assert.Equal(t, []string{"Preview environment [movies-pr-7](test/previews/movies-pr-7) scheduled for deployment"}, provider.comments)
+}
+
+func Test_ExecuteDeployPreviewResolvesCommitStatus(t *testing.T) {
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {
+				Name:     "test",
+				Username: "test-username",
+			},
+		},
+		CurrentContext: "test",
+	}
+	provider := &fakeVCSProvider{}
+	opts := &DeployOptions{
+		name:       "movies-pr-7",
+		scope:      "personal",
+		repository: "https://github.com/okteto/movies",
+		timeout:    time.Minute,
+		publisher: &vcsPublisher{
+			provider:     provider,
+			commitStatus: true,
+		},
+	}
+	pw := Command{
+		okClient: &client.FakeOktetoClient{
+			Preview: client.NewFakePreviewClient(&client.FakePreviewResponse{
+				Preview: &types.PreviewResponse{
+					Action: &types.Action{Name: "action-name"},
+				},
+				ResourceStatus: map[string]string{},
+			}),
+			PipelineClient: client.NewFakePipelineClient(&client.FakePipelineResponses{}),
+			StreamClient:   client.NewFakeStreamClient(&client.FakeStreamResponse{}),
+		},
+	}
+
+	err := pw.ExecuteDeployPreview(context.Background(), opts)
+	assert.NoError(t, err)
+	assert.Equal(t, []vcs.State{vcs.StatePending, vcs.StateSuccess}, provider.statuses)
+}
+
+func Test_getPreviewComment(t *testing.T) {
+	endpoints := []types.Endpoint{
+		{URL: "https://movies-api.okteto.example.com"},
+		{URL: "https://movies.okteto.example.com"},
+	}
+	expected := "Preview environment [pr-7](https://okteto.example.com/previews/pr-7) successfully deployed. Available endpoints:\n" +
+		"\n - [https://movies.okteto.example.com](https://movies.okteto.example.com)" +
+		"\n - [https://movies-api.okteto.example.com](https://movies-api.okteto.example.com)"
+	assert.Equal(t, expected, getPreviewComment("pr-7", "https://okteto.example.com/previews/pr-7", true, endpoints))
+	assert.Equal(t, "Preview environment [pr-7](url) successfully deployed", getPreviewComment("pr-7", "url", true, nil))
+}
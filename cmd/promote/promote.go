@@ -0,0 +1,297 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package promote
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/docker/go-units"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/registry"
+	"github.com/spf13/cobra"
+)
+
+// Options represents the options of the promote command
+type Options struct {
+	Image         string
+	Name          string
+	FromContext   string
+	FromNamespace string
+	ToContext     string
+	ToNamespace   string
+	ToImage       string
+	LimitRate     string
+	DryRun        bool
+}
+
+type imagePromoter interface {
+	GetDestinationImage(sourceImage string) (string, error)
+	Plan(ctx context.Context, sourceImage, destinationImage string) (*registry.PromotePlan, error)
+	Promote(ctx context.Context, plan *registry.PromotePlan, progress chan<- v1.Update) error
+}
+
+// Command has the dependencies to run the promote command
+type Command struct {
+	k8sClientProvider okteto.K8sClientProvider
+	newPromoter       func(source, destination *okteto.OktetoContext, bytesPerSecond int64) imagePromoter
+}
+
+// NewCommand creates a promote command
+func NewCommand() *Command {
+	return &Command{
+		k8sClientProvider: okteto.NewK8sClientProvider(),
+		newPromoter: func(source, destination *okteto.OktetoContext, bytesPerSecond int64) imagePromoter {
+			return registry.NewPromoter(getRegistryConfig(source), getRegistryConfig(destination), bytesPerSecond)
+		},
+	}
+}
+
+// Promote copies an image and the variables of its development environment from one okteto context or namespace to another
+func Promote(ctx context.Context) *cobra.Command {
+	opts := &Options{}
+	cmd := &cobra.Command{
+		Use:   "promote <image>",
+		Short: "Copy an image and its deploy variables to another okteto context or namespace",
+		Long: `Copy an image and its deploy variables to another okteto context or namespace.
+
+The image is copied by digest from the okteto registry of the source namespace to the okteto registry of the destination namespace, for example to promote the build of a preview environment to staging.
+Layers already present in the destination registry are not copied again, so running the same command after an interruption resumes the copy.
+Use '--name' to also copy the deploy variables of a development environment to the development environment with the same name in the destination namespace.`,
+		Example: `okteto promote okteto.dev/api:1.0 --from-namespace preview-42 --to-namespace staging --name movies`,
+		Args:    utils.ExactArgsAccepted(1, ""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Image = args[0]
+			if opts.ToContext == "" && opts.ToNamespace == "" {
+				return oktetoErrors.UserError{
+					E:    fmt.Errorf("the destination of the promotion is not defined"),
+					Hint: "Use the '--to-namespace' or '--to-context' flags to define it",
+				}
+			}
+			bytesPerSecond, err := parseLimitRate(opts.LimitRate)
+			if err != nil {
+				return err
+			}
+
+			source, err := loadContext(ctx, opts.FromContext, opts.FromNamespace)
+			if err != nil {
+				return err
+			}
+			toContext := opts.ToContext
+			if toContext == "" {
+				toContext = source.Name
+			}
+			destination, err := loadContext(ctx, toContext, opts.ToNamespace)
+			if err != nil {
+				return err
+			}
+			return NewCommand().Run(ctx, opts, source, destination, bytesPerSecond)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Name, "name", "", "", "development environment whose deploy variables are copied to the destination namespace")
+	cmd.Flags().StringVarP(&opts.FromContext, "from-context", "", "", "okteto context of the image (defaults to the current context)")
+	cmd.Flags().StringVarP(&opts.FromNamespace, "from-namespace", "", "", "namespace of the image (defaults to the current namespace)")
+	cmd.Flags().StringVarP(&opts.ToContext, "to-context", "", "", "okteto context the image is promoted to (defaults to the source context)")
+	cmd.Flags().StringVarP(&opts.ToNamespace, "to-namespace", "", "", "namespace the image is promoted to (defaults to the namespace of the destination context)")
+	cmd.Flags().StringVarP(&opts.ToImage, "to-image", "", "", "image the source image is promoted to (defaults to the same repository and tag in the destination namespace)")
+	cmd.Flags().StringVarP(&opts.LimitRate, "limit-rate", "", "", "maximum transfer rate, for example '10MB' for 10 megabytes per second (defaults to unlimited)")
+	cmd.Flags().BoolVarP(&opts.DryRun, "dry-run", "", false, "show the layers and variables that would be copied without copying them")
+	return cmd
+}
+
+// loadContext switches to an okteto context and returns a copy of it, so several contexts can be used by the same command
+func loadContext(ctx context.Context, name, namespace string) (*okteto.OktetoContext, error) {
+	ctxResource := &model.ContextResource{Context: name}
+	if err := ctxResource.UpdateNamespace(namespace); err != nil {
+		return nil, err
+	}
+	ctxOptions := &contextCMD.ContextOptions{
+		Context:   ctxResource.Context,
+		Namespace: ctxResource.Namespace,
+		Show:      true,
+	}
+	if err := contextCMD.NewContextCommand().Run(ctx, ctxOptions); err != nil {
+		return nil, err
+	}
+	if !okteto.IsOkteto() {
+		return nil, oktetoErrors.ErrContextIsNotOktetoCluster
+	}
+	okCtx := *okteto.Context()
+	if namespace != "" {
+		okCtx.Namespace = namespace
+	}
+	return &okCtx, nil
+}
+
+func getRegistryConfig(okCtx *okteto.OktetoContext) *okteto.ConfigStateless {
+	return &okteto.ConfigStateless{
+		Cert:                        okCtx.Certificate,
+		IsOkteto:                    okCtx.IsOkteto,
+		ContextName:                 okCtx.Name,
+		Namespace:                   okCtx.Namespace,
+		RegistryUrl:                 okCtx.Registry,
+		UserId:                      okCtx.UserID,
+		Token:                       okCtx.Token,
+		GlobalNamespace:             okCtx.GlobalNamespace,
+		InsecureSkipTLSVerifyPolicy: okCtx.IsInsecure,
+	}
+}
+
+func parseLimitRate(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	bytesPerSecond, err := units.RAMInBytes(value)
+	if err != nil || bytesPerSecond <= 0 {
+		return 0, fmt.Errorf("invalid value for '--limit-rate': '%s' must be a size like '512KB' or '10MB'", value)
+	}
+	return bytesPerSecond, nil
+}
+
+// Run promotes the image and the variables from the source context to the destination context
+func (pc *Command) Run(ctx context.Context, opts *Options, source, destination *okteto.OktetoContext, bytesPerSecond int64) error {
+	if source.Name == destination.Name && source.Namespace == destination.Namespace {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("the source and the destination of the promotion are the same namespace '%s'", source.Namespace),
+			Hint: "Use the '--to-namespace' or '--to-context' flags to choose a different destination",
+		}
+	}
+
+	variables := []string{}
+	if opts.Name != "" {
+		c, _, err := pc.k8sClientProvider.Provide(source.Cfg)
+		if err != nil {
+			return fmt.Errorf("failed to load okteto context '%s': %w", source.Name, err)
+		}
+		data, err := pipeline.GetConfigmapData(ctx, opts.Name, source.Namespace, c)
+		if err != nil {
+			if oktetoErrors.IsNotFound(err) {
+				return oktetoErrors.UserError{
+					E:    fmt.Errorf("development environment '%s' not found in namespace '%s'", opts.Name, source.Namespace),
+					Hint: "Use the '--name' flag with the name of the development environment that built the image",
+				}
+			}
+			return err
+		}
+		variables = data.Variables
+	}
+
+	promoter := pc.newPromoter(source, destination, bytesPerSecond)
+	destinationImage := opts.ToImage
+	if destinationImage == "" {
+		var err error
+		destinationImage, err = promoter.GetDestinationImage(opts.Image)
+		if err != nil {
+			return oktetoErrors.UserError{
+				E:    err,
+				Hint: "Use the '--to-image' flag to define the image it is promoted to",
+			}
+		}
+	}
+
+	oktetoLog.Spinner(fmt.Sprintf("Planning the promotion of '%s'...", opts.Image))
+	oktetoLog.StartSpinner()
+	plan, err := promoter.Plan(ctx, opts.Image, destinationImage)
+	oktetoLog.StopSpinner()
+	if err != nil {
+		return err
+	}
+
+	if opts.DryRun {
+		return printPlan(plan, opts.Name, destination.Namespace, variables, os.Stdout)
+	}
+
+	if err := pc.copyImage(ctx, promoter, plan); err != nil {
+		return err
+	}
+	oktetoLog.Success("Image '%s' promoted to '%s'", plan.Source, plan.Destination)
+
+	if len(variables) == 0 {
+		return nil
+	}
+	c, _, err := pc.k8sClientProvider.Provide(destination.Cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load okteto context '%s': %w", destination.Name, err)
+	}
+	deployed, err := pipeline.MergeVariables(ctx, opts.Name, destination.Namespace, variables, c)
+	if err != nil {
+		return fmt.Errorf("failed to promote the variables of '%s': %w", opts.Name, err)
+	}
+	if !deployed {
+		oktetoLog.Warning("The variables of '%s' were not promoted: '%s' is not deployed in namespace '%s'", opts.Name, opts.Name, destination.Namespace)
+		return nil
+	}
+	oktetoLog.Success("%d variables of '%s' promoted to namespace '%s'", len(variables), opts.Name, destination.Namespace)
+	return nil
+}
+
+func (*Command) copyImage(ctx context.Context, promoter imagePromoter, plan *registry.PromotePlan) error {
+	pending, size := plan.Pending()
+	message := fmt.Sprintf("Copying %d layers (%s) to '%s'...", pending, units.HumanSize(float64(size)), plan.Destination)
+	oktetoLog.Spinner(message)
+	oktetoLog.StartSpinner()
+	defer oktetoLog.StopSpinner()
+
+	progress := make(chan v1.Update, 100)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for update := range progress {
+			if update.Total > 0 {
+				oktetoLog.Spinner(fmt.Sprintf("%s %d%%", message, update.Complete*100/update.Total))
+			}
+		}
+	}()
+	if err := promoter.Promote(ctx, plan, progress); err != nil {
+		// the progress channel might not be closed when the promotion fails
+		return err
+	}
+	<-done
+	return nil
+}
+
+func printPlan(plan *registry.PromotePlan, name, namespace string, variables []string, w io.Writer) error {
+	pending, size := plan.Pending()
+	fmt.Fprintf(w, "Promotion plan of '%s'\n\n", plan.Source)
+	tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+	fmt.Fprintf(tw, "Destination:\t%s\n", plan.Destination)
+	fmt.Fprintf(tw, "Layers to copy:\t%d (%s)\n", pending, units.HumanSize(float64(size)))
+	fmt.Fprintf(tw, "Layers already copied:\t%d\n", len(plan.Blobs)-pending)
+	if name != "" {
+		names := []string{}
+		for _, v := range variables {
+			names = append(names, strings.SplitN(v, "=", 2)[0])
+		}
+		sort.Strings(names)
+		value := "-"
+		if len(names) > 0 {
+			value = fmt.Sprintf("%s (to '%s' in namespace '%s')", strings.Join(names, ", "), name, namespace)
+		}
+		fmt.Fprintf(tw, "Variables:\t%s\n", value)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,176 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package promote
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/okteto/okteto/internal/test"
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePromoter struct {
+	err        error
+	promoteErr error
+	plan       *registry.PromotePlan
+	promoted   *registry.PromotePlan
+}
+
+func (fp *fakePromoter) GetDestinationImage(sourceImage string) (string, error) {
+	if fp.err != nil {
+		return "", fp.err
+	}
+	return "okteto.dev/api@sha256:123", nil
+}
+
+func (fp *fakePromoter) Plan(_ context.Context, _, destinationImage string) (*registry.PromotePlan, error) {
+	fp.plan.Destination = destinationImage
+	return fp.plan, nil
+}
+
+func (fp *fakePromoter) Promote(_ context.Context, plan *registry.PromotePlan, progress chan<- v1.Update) error {
+	if fp.promoteErr != nil {
+		// like a failure before writing, the progress channel is left open
+		return fp.promoteErr
+	}
+	defer close(progress)
+	fp.promoted = plan
+	progress <- v1.Update{Complete: 1, Total: 2}
+	return nil
+}
+
+func newCommand(fp *fakePromoter, provider *test.FakeK8sProvider) *Command {
+	return &Command{
+		k8sClientProvider: provider,
+		newPromoter: func(_, _ *okteto.OktetoContext, _ int64) imagePromoter {
+			return fp
+		},
+	}
+}
+
+func newPlan() *registry.PromotePlan {
+	return &registry.PromotePlan{
+		Source: "registry.okteto.dev/preview/api@sha256:123",
+		Blobs: []registry.PromoteBlob{
+			{Digest: "sha256:1", Size: 1000},
+			{Digest: "sha256:2", Size: 2000, Exists: true},
+		},
+	}
+}
+
+func TestRunPromotesImageAndVariables(t *testing.T) {
+	ctx := context.Background()
+	provider := test.NewFakeK8sProvider()
+	c, _, err := provider.Provide(nil)
+	require.NoError(t, err)
+	for ns, variables := range map[string][]string{"preview": {"A=1", "B=2"}, "staging": {"B=1", "C=3"}} {
+		_, err := pipeline.TranslateConfigMapAndDeploy(ctx, &pipeline.CfgData{
+			Name:      "movies",
+			Namespace: ns,
+			Status:    pipeline.DeployedStatus,
+			Variables: variables,
+		}, c)
+		require.NoError(t, err)
+	}
+
+	fp := &fakePromoter{plan: newPlan()}
+	opts := &Options{Image: "okteto.dev/api:1.0", Name: "movies"}
+	source := &okteto.OktetoContext{Name: "https://okteto.example.com", Namespace: "preview"}
+	destination := &okteto.OktetoContext{Name: "https://okteto.example.com", Namespace: "staging"}
+	require.NoError(t, newCommand(fp, provider).Run(ctx, opts, source, destination, 0))
+
+	require.NotNil(t, fp.promoted)
+	assert.Equal(t, "okteto.dev/api@sha256:123", fp.promoted.Destination)
+	data, err := pipeline.GetConfigmapData(ctx, "movies", "staging", c)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"A=1", "B=2", "C=3"}, data.Variables)
+}
+
+func TestRunDryRun(t *testing.T) {
+	fp := &fakePromoter{plan: newPlan()}
+	opts := &Options{Image: "okteto.dev/api:1.0", ToImage: "registry.okteto.dev/staging/api:1.0", DryRun: true}
+	source := &okteto.OktetoContext{Name: "https://okteto.example.com", Namespace: "preview"}
+	destination := &okteto.OktetoContext{Name: "https://okteto.example.com", Namespace: "staging"}
+	require.NoError(t, newCommand(fp, test.NewFakeK8sProvider()).Run(context.Background(), opts, source, destination, 0))
+	assert.Nil(t, fp.promoted)
+	assert.Equal(t, "registry.okteto.dev/staging/api:1.0", fp.plan.Destination)
+}
+
+func TestRunSameNamespace(t *testing.T) {
+	fp := &fakePromoter{plan: newPlan()}
+	okCtx := &okteto.OktetoContext{Name: "https://okteto.example.com", Namespace: "preview"}
+	err := newCommand(fp, test.NewFakeK8sProvider()).Run(context.Background(), &Options{Image: "okteto.dev/api"}, okCtx, okCtx, 0)
+	assert.Error(t, err)
+	assert.Nil(t, fp.promoted)
+}
+
+func TestRunDestinationImageError(t *testing.T) {
+	fp := &fakePromoter{plan: newPlan(), err: fmt.Errorf("not an okteto registry image")}
+	source := &okteto.OktetoContext{Name: "https://okteto.example.com", Namespace: "preview"}
+	destination := &okteto.OktetoContext{Name: "https://okteto.example.com", Namespace: "staging"}
+	err := newCommand(fp, test.NewFakeK8sProvider()).Run(context.Background(), &Options{Image: "docker.io/api"}, source, destination, 0)
+	assert.Error(t, err)
+	assert.Nil(t, fp.promoted)
+}
+
+func TestRunPromoteError(t *testing.T) {
+	fp := &fakePromoter{plan: newPlan(), promoteErr: fmt.Errorf("unauthorized")}
+	source := &okteto.OktetoContext{Name: "https://okteto.example.com", Namespace: "preview"}
+	destination := &okteto.OktetoContext{Name: "https://okteto.example.com", Namespace: "staging"}
+
+	result := make(chan error, 1)
+	go func() {
+		result <- newCommand(fp, test.NewFakeK8sProvider()).Run(context.Background(), &Options{Image: "okteto.dev/api:1.0"}, source, destination, 0)
+	}()
+	select {
+	case err := <-result:
+		assert.ErrorContains(t, err, "unauthorized")
+	case <-time.After(5 * time.Second):
+		t.Fatal("the promotion didn't return after the promoter failed")
+	}
+}
+
+func TestPrintPlan(t *testing.T) {
+	plan := newPlan()
+	plan.Destination = "registry.okteto.dev/staging/api@sha256:123"
+	var buf bytes.Buffer
+	require.NoError(t, printPlan(plan, "movies", "staging", []string{"SECRET=value", "A=1"}, &buf))
+	out := buf.String()
+	assert.Contains(t, out, "registry.okteto.dev/staging/api@sha256:123")
+	assert.Contains(t, out, "1 (1kB)")
+	assert.Contains(t, out, "A, SECRET")
+	assert.NotContains(t, out, "value")
+}
+
+func TestParseLimitRate(t *testing.T) {
+	bps, err := parseLimitRate("")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), bps)
+
+	bps, err = parseLimitRate("10MB")
+	require.NoError(t, err)
+	assert.Equal(t, int64(10*1024*1024), bps)
+
+	_, err = parseLimitRate("fast")
+	assert.Error(t, err)
+}
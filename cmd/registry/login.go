@@ -0,0 +1,117 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/registry/login"
+	"github.com/spf13/cobra"
+)
+
+// LoginOptions represents the options of the registry login command
+type LoginOptions struct {
+	ManifestPath string
+	Server       string
+	Provider     string
+	Helper       string
+	Force        bool
+}
+
+type registryLoginer interface {
+	Login(ctx context.Context, r model.RegistryLogin, force bool) (*login.Credentials, error)
+}
+
+// Login logs in to private external registries
+func Login(ctx context.Context) *cobra.Command {
+	opts := &LoginOptions{}
+	cmd := &cobra.Command{
+		Use:   "login [server]",
+		Short: "Get short-lived credentials of private external registries for your builds",
+		Long: `Get short-lived credentials of private external registries for your builds.
+
+The credentials are exchanged with the CLI of the registry provider (aws for ECR, gcloud for GCR and Artifact Registry, az for ACR) or with a docker credential helper.
+They are stored in your okteto home folder and used by 'okteto build' and 'okteto deploy' until they expire.
+Without arguments, it logs in to every registry defined in the 'registries' section of your okteto manifest.`,
+		Args: utils.MaximumNArgsAccepted(1, ""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Server = args[0]
+			}
+			registries, err := getRegistries(opts)
+			if err != nil {
+				return err
+			}
+			return runLogin(ctx, registries, opts.Force, login.NewManager())
+		},
+	}
+	cmd.Flags().StringVarP(&opts.ManifestPath, "file", "f", "", "path to the okteto manifest file")
+	cmd.Flags().StringVarP(&opts.Provider, "provider", "", "", fmt.Sprintf("provider of the registry (%s, %s or %s). Inferred from the server when possible", model.RegistryProviderECR, model.RegistryProviderGCR, model.RegistryProviderACR))
+	cmd.Flags().StringVarP(&opts.Helper, "helper", "", "", "name of the docker credential helper to get the credentials from (for example 'ecr-login' for docker-credential-ecr-login)")
+	cmd.Flags().BoolVarP(&opts.Force, "force", "", false, "get new credentials even if the stored ones are still valid")
+	return cmd
+}
+
+// getRegistries returns the registries to log in: the one given as argument or the ones of the manifest
+func getRegistries(opts *LoginOptions) (model.ManifestRegistries, error) {
+	if opts.Server != "" {
+		r := model.RegistryLogin{Server: opts.Server, Provider: opts.Provider, Helper: opts.Helper}
+		if opts.Provider == "" && opts.Helper == "" {
+			if manifest, err := model.GetManifestV2(opts.ManifestPath); err == nil {
+				if fromManifest, ok := manifest.Registries.Get(opts.Server); ok {
+					r = fromManifest
+				}
+			}
+		}
+		registries := model.ManifestRegistries{r}
+		if err := registries.Validate(); err != nil {
+			return nil, oktetoErrors.UserError{
+				E:    err,
+				Hint: "Use the '--provider' or '--helper' flags to choose how to get the credentials",
+			}
+		}
+		return registries, nil
+	}
+
+	if opts.Provider != "" || opts.Helper != "" {
+		return nil, fmt.Errorf("the flags '--provider' and '--helper' require a server as argument")
+	}
+	manifest, err := model.GetManifestV2(opts.ManifestPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(manifest.Registries) == 0 {
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("your okteto manifest doesn't define any registry"),
+			Hint: "Run 'okteto registry login <server>' or add the 'registries' section to your okteto manifest",
+		}
+	}
+	return manifest.Registries, nil
+}
+
+func runLogin(ctx context.Context, registries model.ManifestRegistries, force bool, loginer registryLoginer) error {
+	for _, r := range registries {
+		creds, err := loginer.Login(ctx, r, force)
+		if err != nil {
+			return err
+		}
+		oktetoLog.Success("Logged in to '%s' until %s", r.Server, creds.ExpiresAt.Local().Format("15:04:05"))
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/registry/login"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeLoginer struct {
+	err    error
+	logins []model.RegistryLogin
+	forced bool
+}
+
+func (f *fakeLoginer) Login(_ context.Context, r model.RegistryLogin, force bool) (*login.Credentials, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.logins = append(f.logins, r)
+	f.forced = force
+	return &login.Credentials{Server: r.Server, ExpiresAt: time.Now().Add(time.Hour)}, nil
+}
+
+func writeManifest(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "okteto.yml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestGetRegistries(t *testing.T) {
+	manifestPath := writeManifest(t, `deploy:
+  - echo deploy
+registries:
+  - server: registry.example.com
+    helper: pass
+  - server: gcr.io
+`)
+
+	tests := []struct {
+		name      string
+		opts      *LoginOptions
+		expected  model.ManifestRegistries
+		expectErr bool
+	}{
+		{
+			name:     "server inferred",
+			opts:     &LoginOptions{Server: "myregistry.azurecr.io"},
+			expected: model.ManifestRegistries{{Server: "myregistry.azurecr.io"}},
+		},
+		{
+			name:     "server with flags",
+			opts:     &LoginOptions{Server: "registry.acme.com", Provider: model.RegistryProviderECR},
+			expected: model.ManifestRegistries{{Server: "registry.acme.com", Provider: model.RegistryProviderECR}},
+		},
+		{
+			name:     "server from manifest",
+			opts:     &LoginOptions{Server: "registry.example.com", ManifestPath: manifestPath},
+			expected: model.ManifestRegistries{{Server: "registry.example.com", Helper: "pass"}},
+		},
+		{
+			name:      "server without provider",
+			opts:      &LoginOptions{Server: "registry.acme.com"},
+			expectErr: true,
+		},
+		{
+			name:     "all registries of the manifest",
+			opts:     &LoginOptions{ManifestPath: manifestPath},
+			expected: model.ManifestRegistries{{Server: "registry.example.com", Helper: "pass"}, {Server: "gcr.io"}},
+		},
+		{
+			name:      "flags without server",
+			opts:      &LoginOptions{ManifestPath: manifestPath, Helper: "pass"},
+			expectErr: true,
+		},
+		{
+			name:      "manifest without registries",
+			opts:      &LoginOptions{ManifestPath: writeManifest(t, "deploy:\n  - echo deploy\n")},
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registries, err := getRegistries(tt.opts)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, registries)
+		})
+	}
+}
+
+func TestRunLogin(t *testing.T) {
+	registries := model.ManifestRegistries{{Server: "gcr.io"}, {Server: "myregistry.azurecr.io"}}
+
+	loginer := &fakeLoginer{}
+	require.NoError(t, runLogin(context.Background(), registries, true, loginer))
+	assert.Equal(t, []model.RegistryLogin(registries), loginer.logins)
+	assert.True(t, loginer.forced)
+
+	loginer = &fakeLoginer{err: assert.AnError}
+	assert.ErrorIs(t, runLogin(context.Background(), registries, false, loginer), assert.AnError)
+}
@@ -0,0 +1,43 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/registry/login"
+	"github.com/spf13/cobra"
+)
+
+// Logout removes the stored credentials of a private external registry
+func Logout() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "logout <server>",
+		Short: "Remove the stored credentials of a private external registry",
+		Args:  utils.ExactArgsAccepted(1, ""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			deleted, err := login.NewManager().Logout(args[0])
+			if err != nil {
+				return err
+			}
+			if !deleted {
+				oktetoLog.Information("There are no stored credentials for '%s'", args[0])
+				return nil
+			}
+			oktetoLog.Success("Logged out from '%s'", args[0])
+			return nil
+		},
+	}
+	return cmd
+}
@@ -19,31 +19,38 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"strings"
+	"time"
 
 	contextCMD "github.com/okteto/okteto/cmd/context"
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/analytics"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
-	"github.com/okteto/okteto/pkg/k8s/pods"
+	"github.com/okteto/okteto/pkg/k8s/apps"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
 )
 
-// Restart restarts the pods of a given dev mode deployment
+const defaultRestartTimeout = 5 * time.Minute
+
+// Restart restarts the deployments and statefulsets of the services of a development container
 func Restart() *cobra.Command {
 	var namespace string
 	var k8sContext string
 	var devPath string
+	var services []string
+	var timeout time.Duration
+	var watch bool
 
 	cmd := &cobra.Command{
-		Use:    "restart [svc]",
-		Short:  "Restart the deployments listed in the services field of a development container",
-		Args:   utils.MaximumNArgsAccepted(1, "https://okteto.com/docs/reference/cli/#restart"),
-		Hidden: true,
+		Use:   "restart [devContainer]",
+		Short: "Restart the deployments and statefulsets listed in the services field of a development container",
+		Args:  utils.MaximumNArgsAccepted(1, "https://okteto.com/docs/reference/cli/#restart"),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			ctx := context.Background()
+			ctx := cmd.Context()
 
 			manifestOpts := contextCMD.ManifestOptions{Filename: devPath, Namespace: namespace, K8sContext: k8sContext}
 			manifest, err := contextCMD.LoadManifestWithContext(ctx, manifestOpts)
@@ -67,22 +74,31 @@ func Restart() *cobra.Command {
 				}
 			}
 
+			if watch {
+				return runAutorestart(ctx, dev)
+			}
+
 			if len(dev.Services) == 0 {
 				return oktetoErrors.ErrNoServicesinOktetoManifest
 			}
 
-			serviceName := ""
-			if len(args) > 0 {
-				serviceName = args[0]
+			toRestart, err := getServicesToRestart(dev, services)
+			if err != nil {
+				return err
 			}
-			err = executeRestart(ctx, dev, serviceName)
+
+			client, _, err := okteto.GetK8sClient()
 			if err != nil {
-				return fmt.Errorf("failed to restart your deployments: %w", err)
+				return err
 			}
-			analytics.TrackRestart(err == nil)
 
-			oktetoLog.Success("Deployments restarted")
+			err = executeRestart(ctx, dev, toRestart, client, timeout)
+			analytics.TrackRestart(err == nil)
+			if err != nil {
+				return fmt.Errorf("failed to restart your services: %w", err)
+			}
 
+			oktetoLog.Success("Services restarted")
 			return nil
 		},
 	}
@@ -90,27 +106,70 @@ func Restart() *cobra.Command {
 	cmd.Flags().StringVarP(&devPath, "file", "f", utils.DefaultManifest, "path to the manifest file")
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "namespace where the restart command is executed")
 	cmd.Flags().StringVarP(&k8sContext, "context", "c", "", "context where the restart command is executed")
+	cmd.Flags().StringArrayVarP(&services, "service", "s", []string{}, "name of the service to restart, all the services of the development container are restarted if empty (multiple --service flags accepted)")
+	cmd.Flags().DurationVarP(&timeout, "timeout", "t", defaultRestartTimeout, "the maximum time to wait for each service to be ready")
+	cmd.Flags().BoolVarP(&watch, "watch", "w", false, "run the autorestart command of the development container every time 'okteto up' synchronizes your changes")
 
 	return cmd
 }
 
-func executeRestart(ctx context.Context, dev *model.Dev, sn string) error {
-	oktetoLog.Infof("restarting services")
-	client, _, err := okteto.GetK8sClient()
-	if err != nil {
-		return err
+// getServicesToRestart returns the services of the development container matching the given names
+func getServicesToRestart(dev *model.Dev, names []string) ([]*model.Dev, error) {
+	if len(names) == 0 {
+		return dev.Services, nil
 	}
 
-	oktetoLog.Spinner("Restarting deployments...")
-	oktetoLog.StartSpinner()
-	defer oktetoLog.StopSpinner()
+	byName := map[string]*model.Dev{}
+	available := []string{}
+	for _, svc := range dev.Services {
+		byName[svc.Name] = svc
+		available = append(available, svc.Name)
+	}
+
+	result := []*model.Dev{}
+	for _, name := range names {
+		svc, ok := byName[name]
+		if !ok {
+			return nil, oktetoErrors.UserError{
+				E:    fmt.Errorf("service '%s' is not defined in the services of the development container '%s'", name, dev.Name),
+				Hint: fmt.Sprintf("Available services are: %s", strings.Join(available, ", ")),
+			}
+		}
+		result = append(result, svc)
+	}
+	return result, nil
+}
+
+func executeRestart(ctx context.Context, dev *model.Dev, services []*model.Dev, c kubernetes.Interface, timeout time.Duration) error {
+	oktetoLog.Infof("restarting services")
+	defer oktetoLog.SetStage("")
 
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt)
+	defer signal.Stop(stop)
 	exit := make(chan error, 1)
 
 	go func() {
-		exit <- pods.Restart(ctx, dev, client, sn)
+		restarted := map[string]bool{}
+		for _, svc := range services {
+			oktetoLog.SetStage(fmt.Sprintf("Restarting %s", svc.Name))
+			app, err := apps.Get(ctx, svc, dev.Namespace, c)
+			if err != nil {
+				exit <- fmt.Errorf("service '%s': %w", svc.Name, err)
+				return
+			}
+			if restarted[app.ObjectMeta().Name] {
+				oktetoLog.Success("Service '%s' restarted", svc.Name)
+				continue
+			}
+			if err := restartService(ctx, svc, app, c, timeout); err != nil {
+				exit <- fmt.Errorf("service '%s': %w", svc.Name, err)
+				return
+			}
+			restarted[app.ObjectMeta().Name] = true
+			oktetoLog.Success("Service '%s' restarted", svc.Name)
+		}
+		exit <- nil
 	}()
 
 	select {
@@ -126,3 +185,17 @@ func executeRestart(ctx context.Context, dev *model.Dev, sn string) error {
 	}
 	return nil
 }
+
+// restartService restarts the pods of the app of a service and waits for its rollout
+func restartService(ctx context.Context, svc *model.Dev, app apps.App, c kubernetes.Interface, timeout time.Duration) error {
+	oktetoLog.Spinner(fmt.Sprintf("Restarting service '%s'...", svc.Name))
+	oktetoLog.StartSpinner()
+	defer oktetoLog.StopSpinner()
+
+	if err := apps.Restart(ctx, app, c, time.Now()); err != nil {
+		return err
+	}
+
+	oktetoLog.Spinner(fmt.Sprintf("Waiting for service '%s' to be ready...", svc.Name))
+	return apps.WaitForRollout(ctx, svc, app, c, timeout)
+}
@@ -0,0 +1,89 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
+)
+
+func Test_getServicesToRestart(t *testing.T) {
+	api := &model.Dev{Name: "api"}
+	worker := &model.Dev{Name: "worker"}
+	dev := &model.Dev{Name: "dev", Services: []*model.Dev{api, worker}}
+
+	result, err := getServicesToRestart(dev, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []*model.Dev{api, worker}, result)
+
+	result, err = getServicesToRestart(dev, []string{"worker"})
+	require.NoError(t, err)
+	assert.Equal(t, []*model.Dev{worker}, result)
+
+	_, err = getServicesToRestart(dev, []string{"db"})
+	assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+}
+
+func Test_executeRestart(t *testing.T) {
+	ctx := context.Background()
+	newDeployment := func(name string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "test",
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: pointer.Int32(1),
+			},
+			Status: appsv1.DeploymentStatus{
+				Replicas:          1,
+				UpdatedReplicas:   1,
+				AvailableReplicas: 1,
+			},
+		}
+	}
+	c := fake.NewSimpleClientset(newDeployment("api"), newDeployment("worker"))
+	dev := &model.Dev{
+		Name:      "dev",
+		Namespace: "test",
+		Services: []*model.Dev{
+			{Name: "api", Namespace: "test"},
+			{Name: "worker", Namespace: "test"},
+		},
+	}
+
+	err := executeRestart(ctx, dev, dev.Services[:1], c, time.Second)
+	require.NoError(t, err)
+
+	api, err := c.AppsV1().Deployments("test").Get(ctx, "api", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.NotEmpty(t, api.Spec.Template.Annotations["kubectl.kubernetes.io/restartedAt"])
+
+	worker, err := c.AppsV1().Deployments("test").Get(ctx, "worker", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, worker.Spec.Template.Annotations)
+
+	err = executeRestart(ctx, dev, []*model.Dev{{Name: "db", Namespace: "test"}}, c, time.Second)
+	assert.ErrorContains(t, err, "service 'db'")
+}
@@ -0,0 +1,335 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/apps"
+	"github.com/okteto/okteto/pkg/k8s/exec"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/syncthing"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// syncEventsTimeout is the maximum time to wait for new synchronization events on every request
+	syncEventsTimeout = 30 * time.Second
+
+	// autorestartStopTimeout is the maximum time to wait for the autorestart command to be stopped on exit
+	autorestartStopTimeout = 10 * time.Second
+
+	// autorestartPIDFile keeps the process group of the autorestart command running in the development container
+	autorestartPIDFile = "/tmp/okteto-autorestart.pid"
+)
+
+var (
+	// autorestartStopScript stops every process of the previous run of the autorestart command
+	autorestartStopScript = fmt.Sprintf(`if [ -f %[1]s ]; then pid=$(cat %[1]s); kill -TERM -- -$pid 2>/dev/null || kill -TERM $pid 2>/dev/null; rm -f %[1]s; fi`, autorestartPIDFile)
+
+	// autorestartRunScript runs the autorestart command in its own process group, so the next run can stop all its processes
+	autorestartRunScript = autorestartStopScript + fmt.Sprintf(`
+if command -v setsid >/dev/null 2>&1; then setsid "$@" & else "$@" & fi
+echo $! > %[1]s
+wait $!`, autorestartPIDFile)
+)
+
+// syncEventsGetter returns the synchronization events of an 'okteto up' session
+type syncEventsGetter interface {
+	GetSyncEvents(ctx context.Context, since int, timeout time.Duration) ([]syncthing.SyncEvent, error)
+}
+
+// commandRunner runs a command in the development container
+type commandRunner func(ctx context.Context, command []string, stdout, stderr io.Writer) error
+
+// runAutorestart runs the autorestart command of a development container every time 'okteto up' synchronizes the local changes
+func runAutorestart(ctx context.Context, dev *model.Dev) error {
+	if dev.Autorestart == nil {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("the development container '%s' doesn't define the 'autorestart' section", dev.Name),
+			Hint: "Add the command to run after every synchronization to the 'autorestart' section of your development container",
+		}
+	}
+
+	sy, err := syncthing.Load(dev)
+	if err != nil {
+		oktetoLog.Infof("error loading syncthing info: %s", err)
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("'okteto up' is not running for the development container '%s'", dev.Name),
+			Hint: "Run 'okteto up' in another terminal and try again",
+		}
+	}
+
+	c, cfg, err := okteto.GetK8sClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt)
+	defer signal.Stop(stop)
+	go func() {
+		select {
+		case <-stop:
+			oktetoLog.Infof("CTRL+C received, starting shutdown sequence")
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	oktetoLog.Information("Running '%s' every time your changes are synchronized. Press Ctrl+C to stop", strings.Join(dev.Autorestart.Command.Values, " "))
+	a := newAutorestarter(dev, newDevContainerRunner(dev, c, cfg), os.Stdout)
+	if err := a.watch(ctx, sy); err != nil {
+		if errors.Is(err, oktetoErrors.ErrLostSyncthing) {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("connection lost to the synchronization service of '%s'", dev.Name),
+				Hint: "Check that 'okteto up' is still running and try again",
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+// watchSyncCompletion notifies every time the local changes are completely synchronized to the development container
+func watchSyncCompletion(ctx context.Context, sy syncEventsGetter, synced chan<- struct{}) error {
+	// the events before starting the watch are already synchronized
+	events, err := sy.GetSyncEvents(ctx, 0, 0)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return err
+	}
+	last := 0
+	if len(events) > 0 {
+		last = events[len(events)-1].ID
+	}
+
+	pending := false
+	for {
+		events, err := sy.GetSyncEvents(ctx, last, syncEventsTimeout)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		for _, e := range events {
+			last = e.ID
+			switch e.Type {
+			case "LocalIndexUpdated":
+				pending = true
+			case "FolderCompletion":
+				if !pending || e.Data.Device != syncthing.DefaultRemoteDeviceID || e.Data.Completion < 100 {
+					continue
+				}
+				pending = false
+				select {
+				case synced <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}
+}
+
+// autorestarter runs the autorestart command, stopping the previous run if it's still running
+type autorestarter struct {
+	run      commandRunner
+	stdout   io.Writer
+	stderr   io.Writer
+	out      io.Writer
+	cancel   context.CancelFunc
+	done     chan struct{}
+	command  []string
+	debounce time.Duration
+}
+
+func newAutorestarter(dev *model.Dev, run commandRunner, out io.Writer) *autorestarter {
+	mu := &sync.Mutex{}
+	return &autorestarter{
+		run:      run,
+		out:      out,
+		stdout:   newColoredWriter(out, mu, oktetoLog.BlueString("%s |", dev.Name)),
+		stderr:   newColoredWriter(out, mu, oktetoLog.RedString("%s |", dev.Name)),
+		command:  dev.Autorestart.Command.Values,
+		debounce: dev.Autorestart.GetDebounce(),
+	}
+}
+
+// watch runs the command when it starts and after every synchronization, waiting for the debounce time without new synchronizations
+func (a *autorestarter) watch(ctx context.Context, sy syncEventsGetter) error {
+	synced := make(chan struct{}, 1)
+	exit := make(chan error, 1)
+	go func() {
+		exit <- watchSyncCompletion(ctx, sy, synced)
+	}()
+
+	a.restart(ctx)
+	var debounce <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			a.shutdown()
+			return nil
+		case err := <-exit:
+			a.shutdown()
+			return err
+		case <-synced:
+			debounce = time.After(a.debounce)
+		case <-debounce:
+			debounce = nil
+			oktetoLog.Infof("changes synchronized, restarting the autorestart command")
+			a.restart(ctx)
+		}
+	}
+}
+
+// restart stops the current run of the command and runs it again
+func (a *autorestarter) restart(ctx context.Context) {
+	a.stop()
+
+	runCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	a.cancel, a.done = cancel, done
+	fmt.Fprintln(a.out, oktetoLog.BlueString("Running '%s'...", strings.Join(a.command, " ")))
+	go func() {
+		defer close(done)
+		err := a.run(runCtx, getAutorestartCommand(a.command), a.stdout, a.stderr)
+		if runCtx.Err() != nil {
+			return
+		}
+		if err != nil {
+			fmt.Fprintln(a.out, oktetoLog.RedString("Command '%s' failed: %s", strings.Join(a.command, " "), err))
+			return
+		}
+		fmt.Fprintln(a.out, oktetoLog.GreenString("Command '%s' finished", strings.Join(a.command, " ")))
+	}()
+}
+
+// stop cancels the current run of the command. The processes in the development container are stopped by the next run
+func (a *autorestarter) stop() {
+	if a.cancel == nil {
+		return
+	}
+	a.cancel()
+	<-a.done
+	a.cancel, a.done = nil, nil
+}
+
+// shutdown stops the current run and its processes in the development container
+func (a *autorestarter) shutdown() {
+	a.stop()
+	ctx, cancel := context.WithTimeout(context.Background(), autorestartStopTimeout)
+	defer cancel()
+	if err := a.run(ctx, []string{"sh", "-c", autorestartStopScript}, io.Discard, io.Discard); err != nil {
+		oktetoLog.Infof("failed to stop the autorestart command: %s", err)
+	}
+}
+
+// getAutorestartCommand wraps the autorestart command to stop the previous run before starting it
+func getAutorestartCommand(command []string) []string {
+	return append([]string{"sh", "-c", autorestartRunScript, "okteto-autorestart"}, command...)
+}
+
+// newDevContainerRunner returns a runner executing the commands in the running pod of the development container
+func newDevContainerRunner(dev *model.Dev, c kubernetes.Interface, cfg *rest.Config) commandRunner {
+	return func(ctx context.Context, command []string, stdout, stderr io.Writer) error {
+		pod, err := getDevContainerPod(ctx, dev, c)
+		if err != nil {
+			return err
+		}
+		container := dev.Container
+		if container == "" {
+			container = pod.Spec.Containers[0].Name
+		}
+		return exec.Exec(ctx, c, cfg, dev.Namespace, pod.Name, container, false, strings.NewReader(""), stdout, stderr, command)
+	}
+}
+
+// getDevContainerPod returns the running pod of a development container
+func getDevContainerPod(ctx context.Context, dev *model.Dev, c kubernetes.Interface) (*apiv1.Pod, error) {
+	var devApp apps.App
+	if dev.Autocreate {
+		app, err := apps.Get(ctx, &model.Dev{Name: model.DevCloneName(dev.Name)}, dev.Namespace, c)
+		if err != nil {
+			return nil, err
+		}
+		devApp = app
+	} else {
+		app, err := apps.Get(ctx, dev, dev.Namespace, c)
+		if err != nil {
+			return nil, err
+		}
+		if !apps.IsDevModeOn(app) {
+			return nil, oktetoErrors.UserError{
+				E:    fmt.Errorf("development mode is not enabled"),
+				Hint: "Run 'okteto up' to enable it and try again",
+			}
+		}
+		devApp = app.DevClone()
+	}
+
+	if err := devApp.Refresh(ctx, c); err != nil {
+		return nil, err
+	}
+	return devApp.GetRunningPod(ctx, c)
+}
+
+// coloredWriter writes every line of the output of the autorestart command with a colored prefix
+type coloredWriter struct {
+	out    io.Writer
+	mu     *sync.Mutex
+	prefix string
+	buf    []byte
+}
+
+func newColoredWriter(out io.Writer, mu *sync.Mutex, prefix string) *coloredWriter {
+	return &coloredWriter{out: out, mu: mu, prefix: prefix}
+}
+
+// Write writes the complete lines of p and keeps the last partial line until it is completed
+func (w *coloredWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf = append(w.buf, p...)
+	for {
+		idx := bytes.IndexByte(w.buf, '\n')
+		if idx < 0 {
+			break
+		}
+		if _, err := fmt.Fprintf(w.out, "%s %s", w.prefix, w.buf[:idx+1]); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[idx+1:]
+	}
+	return len(p), nil
+}
@@ -0,0 +1,158 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/syncthing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSyncEventsGetter struct {
+	err     error
+	block   chan struct{}
+	batches [][]syncthing.SyncEvent
+	since   []int
+}
+
+func (f *fakeSyncEventsGetter) GetSyncEvents(ctx context.Context, since int, _ time.Duration) ([]syncthing.SyncEvent, error) {
+	f.since = append(f.since, since)
+	if len(f.batches) == 0 {
+		if f.block != nil {
+			<-f.block
+		}
+		if f.err != nil {
+			return nil, f.err
+		}
+		<-ctx.Done()
+		return nil, oktetoErrors.ErrLostSyncthing
+	}
+	batch := f.batches[0]
+	f.batches = f.batches[1:]
+	return batch, nil
+}
+
+func localIndexUpdated(id int) syncthing.SyncEvent {
+	return syncthing.SyncEvent{ID: id, Type: "LocalIndexUpdated", Data: syncthing.DataSyncEvent{Folder: "okteto-1"}}
+}
+
+func folderCompletion(id int, completion float64) syncthing.SyncEvent {
+	return syncthing.SyncEvent{
+		ID:   id,
+		Type: "FolderCompletion",
+		Data: syncthing.DataSyncEvent{Folder: "okteto-1", Device: syncthing.DefaultRemoteDeviceID, Completion: completion},
+	}
+}
+
+func Test_watchSyncCompletion(t *testing.T) {
+	sy := &fakeSyncEventsGetter{
+		err: oktetoErrors.ErrLostSyncthing,
+		batches: [][]syncthing.SyncEvent{
+			{localIndexUpdated(1), folderCompletion(2, 100)},
+			{folderCompletion(3, 100)},
+			{localIndexUpdated(4), folderCompletion(5, 50)},
+			{folderCompletion(6, 100)},
+		},
+	}
+	synced := make(chan struct{}, 10)
+
+	err := watchSyncCompletion(context.Background(), sy, synced)
+	assert.ErrorIs(t, err, oktetoErrors.ErrLostSyncthing)
+	assert.Len(t, synced, 1)
+	assert.Equal(t, []int{0, 2, 3, 5, 6}, sy.since)
+}
+
+func Test_watchSyncCompletionCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	sy := &fakeSyncEventsGetter{}
+	exit := make(chan error, 1)
+	go func() {
+		exit <- watchSyncCompletion(ctx, sy, make(chan struct{}, 1))
+	}()
+	cancel()
+	assert.NoError(t, <-exit)
+}
+
+type fakeCommandRunner struct {
+	done     chan struct{}
+	commands [][]string
+	mu       sync.Mutex
+}
+
+func (f *fakeCommandRunner) run(_ context.Context, command []string, stdout, _ io.Writer) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.commands = append(f.commands, command)
+	if len(f.commands) == 2 && f.done != nil {
+		close(f.done)
+	}
+	_, err := stdout.Write([]byte("output\n"))
+	return err
+}
+
+func Test_autorestarterWatch(t *testing.T) {
+	dev := &model.Dev{
+		Name: "api",
+		Autorestart: &model.Autorestart{
+			Command:  model.Command{Values: []string{"sh", "-c", "go build && ./app"}},
+			Debounce: 10 * time.Millisecond,
+		},
+	}
+	runner := &fakeCommandRunner{done: make(chan struct{})}
+	sy := &fakeSyncEventsGetter{
+		err:   oktetoErrors.ErrLostSyncthing,
+		block: runner.done,
+		batches: [][]syncthing.SyncEvent{
+			{},
+			{localIndexUpdated(1), folderCompletion(2, 100)},
+		},
+	}
+	var out bytes.Buffer
+	a := newAutorestarter(dev, runner.run, &out)
+	a.stdout = newColoredWriter(&out, &sync.Mutex{}, "api |")
+
+	err := a.watch(context.Background(), sy)
+	assert.ErrorIs(t, err, oktetoErrors.ErrLostSyncthing)
+
+	command := getAutorestartCommand(dev.Autorestart.Command.Values)
+	assert.Equal(t, [][]string{command, command, {"sh", "-c", autorestartStopScript}}, runner.commands)
+	assert.Contains(t, out.String(), "api | output\n")
+}
+
+func Test_getAutorestartCommand(t *testing.T) {
+	result := getAutorestartCommand([]string{"go", "run", "main.go"})
+	assert.Equal(t, []string{"sh", "-c", autorestartRunScript, "okteto-autorestart", "go", "run", "main.go"}, result)
+}
+
+func Test_coloredWriter(t *testing.T) {
+	var out bytes.Buffer
+	w := newColoredWriter(&out, &sync.Mutex{}, "api |")
+
+	_, err := w.Write([]byte("building"))
+	require.NoError(t, err)
+	assert.Empty(t, out.String())
+
+	_, err = w.Write([]byte("...\nlistening on :8080\npartial"))
+	require.NoError(t, err)
+	assert.Equal(t, "api | building...\napi | listening on :8080\n", out.String())
+}
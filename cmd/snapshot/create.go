@@ -0,0 +1,98 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/format"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/snapshot"
+	"github.com/spf13/cobra"
+)
+
+type createFlags struct {
+	namespace string
+	file      string
+}
+
+func create(ctx context.Context) *cobra.Command {
+	flags := &createFlags{}
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Save the state of a development environment into a file",
+		Long: `Save the state of a development environment into a file.
+
+The snapshot includes the repository, branch and manifest used to deploy the development environment, its variables, the resource versions of its deployments and statefulsets and the digests of the images they run.
+Use 'okteto snapshot restore' to redeploy that exact state later, for example to reproduce a bug reported against a preview environment.`,
+		Args: utils.ExactArgsAccepted(1, ""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctxResource := &model.ContextResource{}
+			if err := ctxResource.UpdateNamespace(flags.namespace); err != nil {
+				return err
+			}
+
+			ctxOptions := &contextCMD.ContextOptions{
+				Namespace: ctxResource.Namespace,
+				Show:      true,
+			}
+			if err := contextCMD.NewContextCommand().Run(ctx, ctxOptions); err != nil {
+				return err
+			}
+
+			namespace := flags.namespace
+			if namespace == "" {
+				namespace = okteto.Context().Namespace
+			}
+
+			file := flags.file
+			if file == "" {
+				file = fmt.Sprintf("%s.snapshot.yml", format.ResourceK8sMetaString(args[0]))
+			}
+
+			if err := NewCommand().Create(ctx, args[0], namespace, file); err != nil {
+				return err
+			}
+			oktetoLog.Success("Snapshot of '%s' saved to '%s'", args[0], file)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&flags.namespace, "namespace", "n", "", "namespace where the development environment is deployed (defaults to the current namespace)")
+	cmd.Flags().StringVarP(&flags.file, "file", "f", "", "path of the snapshot file (defaults to <name>.snapshot.yml)")
+	return cmd
+}
+
+// Create saves the state of the dev environment into the given path
+func (sc *Command) Create(ctx context.Context, name, namespace, path string) error {
+	c, _, err := sc.k8sClientProvider.Provide(okteto.Context().Cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load okteto context '%s': %w", okteto.Context().Name, err)
+	}
+
+	oktetoLog.Spinner(fmt.Sprintf("Taking snapshot of '%s'...", name))
+	oktetoLog.StartSpinner()
+	defer oktetoLog.StopSpinner()
+
+	s, err := snapshot.Take(ctx, name, namespace, c)
+	if err != nil {
+		return err
+	}
+	return snapshot.Write(s, path)
+}
@@ -0,0 +1,151 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	pipelineCMD "github.com/okteto/okteto/cmd/pipeline"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/snapshot"
+	"github.com/spf13/cobra"
+)
+
+// RestoreOptions represents the options to restore a snapshot
+type RestoreOptions struct {
+	Name      string
+	Namespace string
+	Timeout   time.Duration
+}
+
+func restore(ctx context.Context) *cobra.Command {
+	opts := &RestoreOptions{}
+	cmd := &cobra.Command{
+		Use:   "restore <file>",
+		Short: "Redeploy the state of a development environment saved with 'okteto snapshot create'",
+		Args:  utils.ExactArgsAccepted(1, ""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, err := snapshot.Read(args[0])
+			if err != nil {
+				return err
+			}
+
+			if opts.Namespace == "" {
+				opts.Namespace = s.Namespace
+			}
+
+			ctxResource := &model.ContextResource{}
+			if err := ctxResource.UpdateNamespace(opts.Namespace); err != nil {
+				return err
+			}
+
+			ctxOptions := &contextCMD.ContextOptions{
+				Namespace: ctxResource.Namespace,
+				Show:      true,
+			}
+			if err := contextCMD.NewContextCommand().Run(ctx, ctxOptions); err != nil {
+				return err
+			}
+
+			if !okteto.IsOkteto() {
+				return oktetoErrors.ErrContextIsNotOktetoCluster
+			}
+
+			pipelineCmd, err := pipelineCMD.NewCommand()
+			if err != nil {
+				return err
+			}
+
+			if err := NewCommand().Restore(ctx, s, opts, pipelineCmd); err != nil {
+				return err
+			}
+			oktetoLog.Success("Snapshot of '%s' successfully restored", s.Name)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Name, "name", "", "", "name of the restored development environment (defaults to the name in the snapshot)")
+	cmd.Flags().StringVarP(&opts.Namespace, "namespace", "n", "", "namespace where the snapshot is restored (defaults to the namespace in the snapshot)")
+	cmd.Flags().DurationVarP(&opts.Timeout, "timeout", "t", (5 * time.Minute), "the length of time to wait for the deployment, zero means never. Any other values should contain a corresponding time unit e.g. 1s, 2m, 3h ")
+	return cmd
+}
+
+// Restore redeploys the dev environment of the snapshot and pins its workloads to the images stored in it
+func (sc *Command) Restore(ctx context.Context, s *snapshot.Snapshot, opts *RestoreOptions, deployer pipelineDeployer) error {
+	if s.Repository == "" {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("the snapshot of '%s' can't be restored because it wasn't deployed from a git repository", s.Name),
+			Hint: "Only development environments deployed from the Okteto UI or with 'okteto pipeline deploy' can be restored",
+		}
+	}
+
+	name := opts.Name
+	if name == "" {
+		name = s.Name
+	}
+	namespace := opts.Namespace
+	if namespace == "" {
+		namespace = s.Namespace
+	}
+
+	// the pipeline checks out any git ref, so the recorded commit is deployed instead of the head of the branch
+	ref := s.Commit
+	if ref == "" {
+		oktetoLog.Warning("The snapshot of '%s' doesn't record its commit: restoring the head of branch '%s'", s.Name, s.Branch)
+		ref = s.Branch
+	}
+
+	deployOpts := &pipelineCMD.DeployOptions{
+		Repository: s.Repository,
+		Branch:     ref,
+		File:       s.Filename,
+		Name:       name,
+		Namespace:  namespace,
+		Variables:  s.Variables,
+		Wait:       true,
+		Timeout:    opts.Timeout,
+	}
+	if err := deployer.ExecuteDeployPipeline(ctx, deployOpts); err != nil {
+		return err
+	}
+
+	c, _, err := sc.k8sClientProvider.Provide(okteto.Context().Cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load okteto context '%s': %w", okteto.Context().Name, err)
+	}
+
+	data, err := pipeline.GetConfigmapData(ctx, name, namespace, c)
+	if err != nil {
+		return fmt.Errorf("failed to get dev environment '%s': %w", name, err)
+	}
+	if string(data.Manifest) != s.Manifest {
+		oktetoLog.Warning("The manifest of '%s' changed since the snapshot was taken", ref)
+	}
+
+	oktetoLog.Spinner("Pinning images to the snapshot digests...")
+	oktetoLog.StartSpinner()
+	defer oktetoLog.StopSpinner()
+
+	restored := *s
+	restored.Namespace = namespace
+	return snapshot.PinImages(ctx, &restored, c)
+}
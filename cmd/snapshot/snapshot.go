@@ -0,0 +1,49 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"context"
+
+	pipelineCMD "github.com/okteto/okteto/cmd/pipeline"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/cobra"
+)
+
+type pipelineDeployer interface {
+	ExecuteDeployPipeline(ctx context.Context, opts *pipelineCMD.DeployOptions) error
+}
+
+// Command has the dependencies to run the snapshot commands
+type Command struct {
+	k8sClientProvider okteto.K8sClientProvider
+}
+
+// NewCommand creates a snapshot command
+func NewCommand() *Command {
+	return &Command{
+		k8sClientProvider: okteto.NewK8sClientProvider(),
+	}
+}
+
+// Snapshot groups the commands to snapshot and restore dev environments
+func Snapshot(ctx context.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Save and restore the state of your development environments",
+	}
+	cmd.AddCommand(create(ctx))
+	cmd.AddCommand(restore(ctx))
+	return cmd
+}
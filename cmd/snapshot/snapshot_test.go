@@ -0,0 +1,159 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pipelineCMD "github.com/okteto/okteto/cmd/pipeline"
+	"github.com/okteto/okteto/internal/test"
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/snapshot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type fakeDeployer struct {
+	err  error
+	opts *pipelineCMD.DeployOptions
+}
+
+func (fd *fakeDeployer) ExecuteDeployPipeline(_ context.Context, opts *pipelineCMD.DeployOptions) error {
+	fd.opts = opts
+	return fd.err
+}
+
+func TestMain(m *testing.M) {
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		CurrentContext: "test",
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {Namespace: "test"},
+		},
+	}
+	os.Exit(m.Run())
+}
+
+func newConfigmap(ctx context.Context, t *testing.T, name, namespace string) *apiv1.ConfigMap {
+	cmap, err := pipeline.TranslateConfigMapAndDeploy(ctx, &pipeline.CfgData{
+		Name:       name,
+		Namespace:  namespace,
+		Status:     pipeline.DeployedStatus,
+		Repository: "https://github.com/okteto/movies",
+		Branch:     "main",
+		Manifest:   []byte("deploy:\n  - kubectl apply -f k8s"),
+		Variables:  []string{"A=1"},
+	}, fake.NewSimpleClientset())
+	require.NoError(t, err)
+	return cmap
+}
+
+func newDeployment(namespace, image string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "api",
+			Namespace: namespace,
+			Labels:    map[string]string{model.DeployedByLabel: "movies"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}},
+			Template: apiv1.PodTemplateSpec{
+				Spec: apiv1.PodSpec{
+					Containers: []apiv1.Container{{Name: "api", Image: image}},
+				},
+			},
+		},
+	}
+}
+
+func TestCreate(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "movies.snapshot.yml")
+	sc := &Command{
+		k8sClientProvider: test.NewFakeK8sProvider(newConfigmap(ctx, t, "movies", "test"), newDeployment("test", "okteto.dev/api:1.0")),
+	}
+
+	require.NoError(t, sc.Create(ctx, "movies", "test", path))
+
+	s, err := snapshot.Read(path)
+	require.NoError(t, err)
+	assert.Equal(t, "movies", s.Name)
+	assert.Equal(t, "https://github.com/okteto/movies", s.Repository)
+	assert.Len(t, s.Resources, 1)
+}
+
+func TestRestore(t *testing.T) {
+	ctx := context.Background()
+	provider := test.NewFakeK8sProvider(newConfigmap(ctx, t, "bug-123", "other"), newDeployment("other", "okteto.dev/api:2.0"))
+	sc := &Command{k8sClientProvider: provider}
+	s := &snapshot.Snapshot{
+		Name:       "movies",
+		Namespace:  "test",
+		Repository: "https://github.com/okteto/movies",
+		Branch:     "main",
+		Commit:     "1a2b3c4",
+		Manifest:   "deploy:\n  - kubectl apply -f k8s",
+		Variables:  []string{"A=1"},
+		Resources: []snapshot.Resource{
+			{Kind: snapshot.DeploymentKind, Name: "api", Images: map[string]string{"api": "okteto.dev/api@sha256:abc"}},
+		},
+	}
+	deployer := &fakeDeployer{}
+
+	err := sc.Restore(ctx, s, &RestoreOptions{Name: "bug-123", Namespace: "other"}, deployer)
+	require.NoError(t, err)
+
+	assert.Equal(t, &pipelineCMD.DeployOptions{
+		Repository: "https://github.com/okteto/movies",
+		Branch:     "1a2b3c4",
+		Name:       "bug-123",
+		Namespace:  "other",
+		Variables:  []string{"A=1"},
+		Wait:       true,
+	}, deployer.opts)
+
+	c, _, err := provider.Provide(nil)
+	require.NoError(t, err)
+	d, err := c.AppsV1().Deployments("other").Get(ctx, "api", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "okteto.dev/api@sha256:abc", d.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, "test", s.Namespace)
+}
+
+func TestRestoreWithoutRepository(t *testing.T) {
+	deployer := &fakeDeployer{}
+	sc := &Command{k8sClientProvider: test.NewFakeK8sProvider()}
+
+	err := sc.Restore(context.Background(), &snapshot.Snapshot{Name: "movies"}, &RestoreOptions{}, deployer)
+	assert.Error(t, err)
+	assert.Nil(t, deployer.opts)
+}
+
+func TestRestoreDeployError(t *testing.T) {
+	deployErr := errors.New("deploy failed")
+	sc := &Command{k8sClientProvider: test.NewFakeK8sProvider()}
+	s := &snapshot.Snapshot{Name: "movies", Repository: "https://github.com/okteto/movies"}
+
+	err := sc.Restore(context.Background(), s, &RestoreOptions{}, &fakeDeployer{err: deployErr})
+	assert.ErrorIs(t, err, deployErr)
+}
@@ -25,8 +25,7 @@ import (
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/analytics"
 	"github.com/okteto/okteto/pkg/cmd/stack"
-	"github.com/okteto/okteto/pkg/constants"
-	"github.com/okteto/okteto/pkg/env"
+	"github.com/okteto/okteto/pkg/config"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/log/io"
 	"github.com/okteto/okteto/pkg/model"
@@ -135,8 +134,8 @@ func (c *DeployCommand) RunDeploy(ctx context.Context, s *model.Stack, options *
 	}
 	oktetoLog.Success("Compose '%s' successfully deployed", s.Name)
 
-	if !(!env.LoadBoolean(constants.OktetoWithinDeployCommandContextEnvVar) || !c.IsInsideDeploy) {
-		if err := stack.ListEndpoints(ctx, s); err != nil {
+	if !(!config.EnvOktetoWithinDeployCommandContext.IsTrue() || !c.IsInsideDeploy) {
+		if err := stack.ListEndpoints(ctx, s, ""); err != nil {
 			return err
 		}
 	}
@@ -151,7 +150,7 @@ func splitComposeFileEnv(value string) []string {
 }
 
 func loadComposePaths(paths []string) []string {
-	composeEnv, present := os.LookupEnv(model.ComposeFileEnvVar)
+	composeEnv, present := config.EnvComposeFile.Lookup()
 	if len(paths) == 0 && present {
 		paths = splitComposeFileEnv(composeEnv)
 	}
@@ -15,28 +15,35 @@ package stack
 
 import (
 	"context"
-	"fmt"
 
 	contextCMD "github.com/okteto/okteto/cmd/context"
 	"github.com/okteto/okteto/pkg/cmd/stack"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/output"
 	"github.com/spf13/cobra"
 )
 
 // Endpoints show all the endpoints of a stack
 func Endpoints(ctx context.Context) *cobra.Command {
 	var (
-		output    string
-		name      string
-		namespace string
-		stackPath []string
+		outputFormat string
+		name         string
+		namespace    string
+		stackPath    []string
 	)
 	cmd := &cobra.Command{
 		Use:   "endpoints [service...]",
 		Short: "Show endpoints for a stack",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.Validate(outputFormat); err != nil {
+				return err
+			}
+			if outputFormat != "" {
+				// logs would break the structured output
+				oktetoLog.SetOutputFormat(oktetoLog.SilentFormat)
+			}
 			oktetoLog.Warning("'okteto stack endpoints' is deprecated and will be removed in a future version")
 			s, err := contextCMD.LoadStackWithContext(ctx, name, namespace, stackPath)
 			if err != nil {
@@ -46,26 +53,15 @@ func Endpoints(ctx context.Context) *cobra.Command {
 				return oktetoErrors.ErrContextIsNotOktetoCluster
 			}
 
-			if err := validateOutput(output); err != nil {
-				return err
-			}
-
-			if err := stack.ListEndpoints(ctx, s); err != nil {
+			if err := stack.ListEndpoints(ctx, s, outputFormat); err != nil {
 				oktetoLog.Success("Compose '%s' successfully deployed", s.Name)
 			}
 			return nil
 		},
 	}
-	cmd.Flags().StringVarP(&output, "output", "o", "", "output format. One of: ['json']")
+	output.AddFlag(cmd, &outputFormat)
 	cmd.Flags().StringArrayVarP(&stackPath, "file", "f", []string{}, "path to the compose manifest files. If more than one is passed the latest will overwrite the fields from the previous")
 	cmd.Flags().StringVarP(&name, "name", "", "", "overwrites the compose name")
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "overwrites the compose namespace where the compose is deployed")
 	return cmd
 }
-
-func validateOutput(output string) error {
-	if output != "" && output != "json" {
-		return fmt.Errorf("output format is not accepted. Value must be one of: ['json']")
-	}
-	return nil
-}
@@ -16,6 +16,8 @@ package cmd
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"time"
@@ -23,17 +25,27 @@ import (
 	contextCMD "github.com/okteto/okteto/cmd/context"
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/analytics"
+	"github.com/okteto/okteto/pkg/cmd/daemon"
 	"github.com/okteto/okteto/pkg/cmd/status"
 	"github.com/okteto/okteto/pkg/config"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/ssh"
 	"github.com/okteto/okteto/pkg/syncthing"
+	"github.com/okteto/okteto/pkg/types"
 	"github.com/spf13/cobra"
 )
 
 const (
 	completedProgress = 100
+
+	// forwardsHealthStaleness is the time after which the health of the forwards is considered outdated
+	forwardsHealthStaleness = 10 * time.Second
+
+	// deployStatusTimeout is the maximum time to get the deploy status, so the sync status is shown when the okteto API is not responding
+	deployStatusTimeout = 5 * time.Second
 )
 
 // Status returns the status of the synchronization process
@@ -43,27 +55,76 @@ func Status() *cobra.Command {
 	var k8sContext string
 	var showInfo bool
 	var watch bool
+	var forwards bool
+	var historyMinutes int
 	cmd := &cobra.Command{
-		Use:   "status",
+		Use:   "status [service...]",
 		Short: "Status of the synchronization process",
-		Args:  utils.MaximumNArgsAccepted(1, "https://okteto.com/docs/reference/cli/#status"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 
 			if okteto.InDevContainer() {
 				return oktetoErrors.ErrNotInDevContainer
 			}
 
+			historyWindow, err := getHistoryWindow(cmd.Flags().Changed("history"), historyMinutes)
+			if err != nil {
+				return err
+			}
+
 			ctx := context.Background()
 
-			manifestOpts := contextCMD.ManifestOptions{Filename: devPath, Namespace: namespace, K8sContext: k8sContext}
+			// the sync status is local, so it's available even if the okteto API is not responding
+			manifestOpts := contextCMD.ManifestOptions{Filename: devPath, Namespace: namespace, K8sContext: k8sContext, AllowOffline: true}
 			manifest, err := contextCMD.LoadManifestWithContext(ctx, manifestOpts)
 			if err != nil {
 				return err
 			}
 
+			services := args
+			if len(services) == 0 {
+				if session, err := daemon.ReadSession(manifest.Namespace, manifest.Name); err == nil {
+					services = session.Services
+				}
+			}
+			if len(services) > 1 {
+				if err := validateAggregateFlags(watch, showInfo); err != nil {
+					return err
+				}
+				devs := []*model.Dev{}
+				for _, name := range services {
+					dev, err := utils.GetDevFromManifest(manifest, name)
+					if err != nil {
+						return err
+					}
+					devs = append(devs, dev)
+				}
+				if forwards {
+					for _, dev := range devs {
+						oktetoLog.Information("Forwards of '%s':", dev.Name)
+						if err := runForwardsStatus(os.Stdout, ssh.GetForwardsHealthPath(dev.Namespace, dev.Name)); err != nil {
+							return err
+						}
+					}
+					return nil
+				}
+				if historyWindow > 0 {
+					for _, dev := range devs {
+						oktetoLog.Information("History of '%s':", dev.Name)
+						if err := runHistory(dev, historyWindow); err != nil {
+							return err
+						}
+					}
+					return nil
+				}
+				showDeployStatus(ctx, manifest)
+				err := runAggregateStatus(ctx, os.Stdout, devs, loadDevStatus)
+				analytics.TrackStatus(err == nil, showInfo)
+				return err
+			}
+
 			devName := ""
-			if len(args) == 1 {
-				devName = args[0]
+			if len(services) == 1 {
+				devName = services[0]
 			}
 			dev, err := utils.GetDevFromManifest(manifest, devName)
 			if err != nil {
@@ -77,6 +138,15 @@ func Status() *cobra.Command {
 				}
 			}
 
+			if forwards {
+				return runForwardsStatus(os.Stdout, ssh.GetForwardsHealthPath(dev.Namespace, dev.Name))
+			}
+
+			if historyWindow > 0 {
+				return runHistory(dev, historyWindow)
+			}
+
+			showDeployStatus(ctx, manifest)
 			waitForStates := []config.UpState{config.Synchronizing, config.Ready}
 			if err := status.Wait(dev, waitForStates); err != nil {
 				return err
@@ -92,6 +162,12 @@ func Status() *cobra.Command {
 				oktetoLog.Information("Remote syncthing url: http://%s", sy.RemoteGUIAddress)
 				oktetoLog.Information("Syncthing username: okteto")
 				oktetoLog.Information("Syncthing password: %s", sy.GUIPassword)
+				if info, err := config.GetStateInfo(dev.Name, dev.Namespace); err == nil {
+					oktetoLog.Information("Development container state: %s (retries: %d)", info.State, info.Retries)
+					if info.LastError != "" {
+						oktetoLog.Information("Last error: %s", info.LastError)
+					}
+				}
 			}
 
 			if watch {
@@ -109,9 +185,29 @@ func Status() *cobra.Command {
 	cmd.Flags().StringVarP(&k8sContext, "context", "c", "", "context where the up command is executing")
 	cmd.Flags().BoolVarP(&showInfo, "info", "i", false, "show syncthing links for troubleshooting the synchronization service")
 	cmd.Flags().BoolVarP(&watch, "watch", "w", false, "watch for changes")
+	cmd.Flags().BoolVar(&forwards, "forwards", false, "show the health of the forwards and reverse forwards of the development container")
+	cmd.Flags().IntVar(&historyMinutes, "history", 0, "show the synchronization status samples of the last N minutes (--history=N, defaults to 10)")
+	cmd.Flags().Lookup("history").NoOptDefVal = "10"
 	return cmd
 }
 
+// validateAggregateFlags rejects the flags that only apply to the status of a single development container
+func validateAggregateFlags(watch, showInfo bool) error {
+	if watch {
+		return oktetoErrors.UserError{
+			E:    errors.New("the flag '--watch' is not supported with several development containers"),
+			Hint: "Run 'okteto status <service> --watch' to watch a single development container",
+		}
+	}
+	if showInfo {
+		return oktetoErrors.UserError{
+			E:    errors.New("the flag '--info' is not supported with several development containers"),
+			Hint: "Run 'okteto status <service> --info' to show the troubleshooting information of a single development container",
+		}
+	}
+	return nil
+}
+
 func runWithWatch(ctx context.Context, sy *syncthing.Syncthing) error {
 	textSpinner := "Synchronizing your files..."
 	oktetoLog.Spinner(textSpinner)
@@ -156,6 +252,183 @@ func runWithWatch(ctx context.Context, sy *syncthing.Syncthing) error {
 	return nil
 }
 
+// getHistoryWindow returns the time window of the --history flag, 0 if it isn't set
+func getHistoryWindow(isSet bool, minutes int) (time.Duration, error) {
+	if !isSet {
+		return 0, nil
+	}
+	window := time.Duration(minutes) * time.Minute
+	if minutes <= 0 || window > syncthing.HistoryRetention {
+		return 0, oktetoErrors.UserError{
+			E:    fmt.Errorf("invalid value for --history: %d", minutes),
+			Hint: fmt.Sprintf("The synchronization history keeps the last %d minutes. Use '--history=N' with N between 1 and %d", int(syncthing.HistoryRetention.Minutes()), int(syncthing.HistoryRetention.Minutes())),
+		}
+	}
+	return window, nil
+}
+
+func runHistory(dev *model.Dev, window time.Duration) error {
+	samples, err := syncthing.LoadHistory(syncthing.GetHistoryFile(dev.Namespace, dev.Name))
+	if err != nil {
+		return fmt.Errorf("failed to read the synchronization history: %w", err)
+	}
+	samples = status.FilterHistory(samples, time.Now().Add(-window))
+	if len(samples) == 0 {
+		oktetoLog.Information("No synchronization status samples in the last %s. Samples are taken while 'okteto up' is running", window)
+		return nil
+	}
+	oktetoLog.Information("Synchronization status of the last %s:", window)
+	return status.RenderHistory(os.Stdout, samples)
+}
+
+// showDeployStatus shows the deploy status of the development environment when the current context is an okteto context
+func showDeployStatus(ctx context.Context, manifest *model.Manifest) {
+	if !okteto.IsOkteto() || manifest.Name == "" {
+		return
+	}
+	okClient, err := okteto.NewOktetoClient()
+	if err != nil {
+		oktetoLog.Infof("error creating okteto client: %s", err)
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deployStatusTimeout)
+	defer cancel()
+	runDeployStatus(ctx, os.Stdout, okClient.Pipeline(), manifest.Name, manifest.Namespace)
+}
+
+// runDeployStatus shows the deploy status of a development environment and its last action as stored in the okteto backend.
+// Errors are only logged, the deploy status is shown along with the sync status and must not block it
+func runDeployStatus(ctx context.Context, w io.Writer, pc types.PipelineInterface, name, namespace string) {
+	status, err := pc.GetStatus(ctx, name, namespace)
+	if err != nil {
+		if oktetoErrors.IsNotFound(err) {
+			fmt.Fprintf(w, "Deploy status of '%s': not deployed\n", name)
+			return
+		}
+		oktetoLog.Infof("error getting the deploy status of '%s': %s", name, err)
+		return
+	}
+	fmt.Fprintf(w, "Deploy status of '%s': %s\n", name, status.Status)
+
+	action, err := pc.GetLastAction(ctx, name, namespace)
+	if err != nil {
+		oktetoLog.Infof("error getting the last action of '%s': %s", name, err)
+		return
+	}
+	if action == nil {
+		return
+	}
+	if action.Actor == "" {
+		fmt.Fprintf(w, "Last action: %s (%s)\n", action.Name, action.Status)
+		return
+	}
+	fmt.Fprintf(w, "Last action: %s (%s) by %s\n", action.Name, action.Status, action.Actor)
+}
+
+// devStatus is the state of a development container of an 'okteto up' session
+type devStatus struct {
+	name     string
+	state    config.UpState
+	progress float64
+	synced   bool
+}
+
+// loadDevStatus returns the state and the synchronization progress of a development container
+func loadDevStatus(ctx context.Context, dev *model.Dev) devStatus {
+	result := devStatus{name: dev.Name}
+	info, err := config.GetStateInfo(dev.Name, dev.Namespace)
+	if err != nil {
+		oktetoLog.Infof("error accessing the state of '%s': %s", dev.Name, err)
+		return result
+	}
+	result.state = info.State
+	if info.State != config.Synchronizing && info.State != config.Ready {
+		return result
+	}
+	sy, err := syncthing.Load(dev)
+	if err != nil {
+		oktetoLog.Infof("error accessing the syncthing info file of '%s': %s", dev.Name, err)
+		return result
+	}
+	progress, err := status.Run(ctx, sy)
+	if err != nil {
+		oktetoLog.Infof("error accessing the synchronization status of '%s': %s", dev.Name, err)
+		return result
+	}
+	result.progress = progress
+	result.synced = true
+	return result
+}
+
+// runAggregateStatus shows the state of several development containers and how many of them are ready
+func runAggregateStatus(ctx context.Context, w io.Writer, devs []*model.Dev, getStatus func(context.Context, *model.Dev) devStatus) error {
+	table := oktetoLog.NewTable(
+		oktetoLog.Column{Header: "Development container"},
+		oktetoLog.Column{Header: "State"},
+		oktetoLog.Column{Header: "Synchronization", Align: oktetoLog.AlignRight},
+	)
+	ready := 0
+	for _, dev := range devs {
+		s := getStatus(ctx, dev)
+		state := string(s.state)
+		if state == "" {
+			state = "not running"
+		}
+		progress := "-"
+		if s.synced {
+			progress = fmt.Sprintf("%.2f%%", s.progress)
+		}
+		if s.state == config.Ready {
+			ready++
+		}
+		table.AddRow(s.name, state, progress)
+	}
+	if err := table.Render(w, ""); err != nil {
+		return err
+	}
+	if ready == len(devs) {
+		oktetoLog.Success("%d of %d development containers are ready", ready, len(devs))
+	} else {
+		oktetoLog.Yellow("%d of %d development containers are ready", ready, len(devs))
+	}
+	return nil
+}
+
+// runForwardsStatus shows the health of the forwards written by 'okteto up' into path
+func runForwardsStatus(w io.Writer, path string) error {
+	health, err := ssh.ReadForwardsHealth(path)
+	if err != nil {
+		oktetoLog.Infof("error reading the forwards health: %s", err)
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("the health of the forwards is not available"),
+			Hint: "Run 'okteto up' to activate your development container",
+		}
+	}
+	if time.Since(health.UpdatedAt) > forwardsHealthStaleness {
+		oktetoLog.Warning("The health of the forwards was last updated %s ago. Is 'okteto up' still running?", time.Since(health.UpdatedAt).Round(time.Second))
+	}
+
+	table := oktetoLog.NewTable(
+		oktetoLog.Column{Header: "Type"},
+		oktetoLog.Column{Header: "Local"},
+		oktetoLog.Column{Header: "Remote"},
+		oktetoLog.Column{Header: "Status"},
+		oktetoLog.Column{Header: "Last error"},
+	)
+	for _, f := range health.Forwards {
+		state := "disconnected"
+		if f.Connected {
+			state = "connected"
+		}
+		lastError := f.LastError
+		if lastError == "" {
+			lastError = "-"
+		}
+		table.AddRow(f.Kind, f.Local, f.Remote, state, lastError)
+	}
+	return table.Render(w, "")
+}
+
 func runWithoutWatch(ctx context.Context, sy *syncthing.Syncthing) error {
 	progress, err := status.Run(ctx, sy)
 	if err != nil {
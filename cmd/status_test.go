@@ -0,0 +1,154 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/config"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto/fake"
+	"github.com/okteto/okteto/pkg/ssh"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunAggregateStatus(t *testing.T) {
+	statuses := map[string]devStatus{
+		"api":      {name: "api", state: config.Ready, progress: 100, synced: true},
+		"frontend": {name: "frontend", state: config.Synchronizing, progress: 45.5, synced: true},
+		"worker":   {name: "worker"},
+	}
+	getStatus := func(_ context.Context, dev *model.Dev) devStatus {
+		return statuses[dev.Name]
+	}
+
+	var out bytes.Buffer
+	devs := []*model.Dev{{Name: "api"}, {Name: "frontend"}, {Name: "worker"}}
+	require.NoError(t, runAggregateStatus(context.Background(), &out, devs, getStatus))
+
+	result := out.String()
+	assert.Contains(t, result, "Development container")
+	assert.Regexp(t, `api\s+ready\s+100.00%`, result)
+	assert.Regexp(t, `frontend\s+synchronizing\s+45.50%`, result)
+	assert.Regexp(t, `worker\s+not running\s+-`, result)
+}
+
+func TestRunDeployStatus(t *testing.T) {
+	tests := []struct {
+		responses *fake.FakePipelineResponses
+		name      string
+		expected  string
+	}{
+		{
+			name: "deployed with last action",
+			responses: &fake.FakePipelineResponses{
+				StatusResponses: []*types.PipelineStatus{{Name: "movies", Status: "deployed"}},
+				LastAction:      &types.Action{Name: "cli", Status: "success", Actor: "cindy"},
+			},
+			expected: "Deploy status of 'movies': deployed\nLast action: cli (success) by cindy\n",
+		},
+		{
+			name: "progressing without actor",
+			responses: &fake.FakePipelineResponses{
+				StatusResponses: []*types.PipelineStatus{{Name: "movies", Status: "progressing"}},
+				LastAction:      &types.Action{Name: "github-1234", Status: "progressing"},
+			},
+			expected: "Deploy status of 'movies': progressing\nLast action: github-1234 (progressing)\n",
+		},
+		{
+			name: "last action not available",
+			responses: &fake.FakePipelineResponses{
+				StatusResponses: []*types.PipelineStatus{{Name: "movies", Status: "error"}},
+				ActionErr:       assert.AnError,
+			},
+			expected: "Deploy status of 'movies': error\n",
+		},
+		{
+			name:      "not deployed",
+			responses: &fake.FakePipelineResponses{StatusErr: oktetoErrors.ErrNotFound},
+			expected:  "Deploy status of 'movies': not deployed\n",
+		},
+		{
+			name:      "api not available",
+			responses: &fake.FakePipelineResponses{StatusErr: assert.AnError},
+			expected:  "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			runDeployStatus(context.Background(), &out, fake.NewFakePipelineClient(tt.responses), "movies", "test")
+			assert.Equal(t, tt.expected, out.String())
+		})
+	}
+}
+
+func TestRunForwardsStatus(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "okteto.forwards")
+	health := ssh.ForwardsHealth{
+		UpdatedAt: time.Now(),
+		Forwards: []ssh.ForwardStatus{
+			{Kind: ssh.ForwardKind, Local: "localhost:8080", Remote: "0.0.0.0:8080", Connected: true},
+			{Kind: ssh.ReverseKind, Local: "localhost:9229", Remote: "0.0.0.0:9229", LastError: "failed to accept connection"},
+		},
+	}
+	b, err := json.Marshal(health)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, b, 0600))
+
+	var out bytes.Buffer
+	require.NoError(t, runForwardsStatus(&out, path))
+	assert.Contains(t, out.String(), "localhost:8080")
+	assert.Contains(t, out.String(), "connected")
+	assert.Contains(t, out.String(), "localhost:9229")
+	assert.Contains(t, out.String(), "disconnected")
+	assert.Contains(t, out.String(), "failed to accept connection")
+}
+
+func TestRunForwardsStatusNotAvailable(t *testing.T) {
+	var out bytes.Buffer
+	err := runForwardsStatus(&out, filepath.Join(t.TempDir(), "okteto.forwards"))
+	assert.Error(t, err)
+}
+
+func TestGetHistoryWindow(t *testing.T) {
+	window, err := getHistoryWindow(false, 0)
+	require.NoError(t, err)
+	assert.Zero(t, window)
+
+	window, err = getHistoryWindow(true, 30)
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Minute, window)
+
+	_, err = getHistoryWindow(true, 0)
+	assert.Error(t, err)
+
+	_, err = getHistoryWindow(true, 121)
+	assert.Error(t, err)
+}
+
+func TestValidateAggregateFlags(t *testing.T) {
+	assert.NoError(t, validateAggregateFlags(false, false))
+	assert.ErrorContains(t, validateAggregateFlags(true, false), "--watch")
+	assert.ErrorContains(t, validateAggregateFlags(false, true), "--info")
+}
@@ -0,0 +1,359 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	builder "github.com/okteto/okteto/cmd/build"
+	remoteBuild "github.com/okteto/okteto/cmd/build/remote"
+	"github.com/okteto/okteto/pkg/build"
+	buildCmd "github.com/okteto/okteto/pkg/cmd/build"
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/constants"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/filesystem"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/log/io"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/remote"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/afero"
+)
+
+const (
+	templateName           = "test-dockerfile"
+	dockerfileTemporalName = "Dockerfile.test"
+
+	// exitCodeFile is the file of the build output storing the exit code of the test commands
+	exitCodeFile = "exit-code"
+
+	// artifactsFolder is the folder of the build output storing the artifacts of the test suite
+	artifactsFolder = "artifacts"
+
+	dockerfileTemplate = `
+FROM {{ .OktetoCLIImage }} as okteto-cli
+
+FROM {{ .UserTestImage }} as runner
+
+ENV PATH="${PATH}:/okteto/bin"
+COPY --from=okteto-cli /usr/local/bin/* /okteto/bin/
+
+ARG {{ .NamespaceArgName }}
+ARG {{ .ContextArgName }}
+ARG {{ .TokenArgName }}
+ARG {{ .TlsCertBase64ArgName }}
+ARG {{ .InternalServerName }}
+RUN mkdir -p /etc/ssl/certs/
+RUN echo "${{ .TlsCertBase64ArgName }}" | base64 -d > /etc/ssl/certs/okteto.crt
+
+COPY . /okteto/src
+WORKDIR {{ .Workdir }}
+
+ARG {{ .GitCommitArgName }}
+ARG {{ .GitBranchArgName }}
+ARG {{ .InvalidateCacheArgName }}
+
+RUN {{ range .Caches }}--mount=type=cache,target={{ . }} {{ end }}<<'OKTETO_TEST'
+set +e
+(
+set -e
+{{ range .Commands }}{{ .Command }}
+{{ end }})
+status=$?
+mkdir -p /okteto/output/{{ .ArtifactsFolder }}
+{{ range .Artifacts }}mkdir -p "$(dirname "/okteto/output/{{ $.ArtifactsFolder }}/{{ .Destination }}")" && cp -r "{{ .Path }}" "/okteto/output/{{ $.ArtifactsFolder }}/{{ .Destination }}" || echo "artifact '{{ .Path }}' was not generated"
+{{ end }}echo $status > /okteto/output/{{ .ExitCodeFile }}
+OKTETO_TEST
+
+FROM scratch
+COPY --from=runner /okteto/output /
+`
+)
+
+type dockerfileTemplateProperties struct {
+	OktetoCLIImage         string
+	UserTestImage          string
+	ContextArgName         string
+	NamespaceArgName       string
+	TokenArgName           string
+	TlsCertBase64ArgName   string
+	InternalServerName     string
+	GitCommitArgName       string
+	GitBranchArgName       string
+	InvalidateCacheArgName string
+	Workdir                string
+	ArtifactsFolder        string
+	ExitCodeFile           string
+	Caches                 []string
+	Commands               []model.DeployCommand
+	Artifacts              []model.Artifact
+}
+
+type remoteTestRunner struct {
+	builder              builder.Builder
+	fs                   afero.Fs
+	workingDirectoryCtrl filesystem.WorkingDirectoryInterface
+	temporalCtrl         filesystem.TemporalDirectoryInterface
+	clusterMetadata      func(context.Context) (*types.ClusterMetadata, error)
+}
+
+func newRemoteTestRunner(ioCtrl *io.IOController) *remoteTestRunner {
+	fs := afero.NewOsFs()
+	return &remoteTestRunner{
+		builder:              remoteBuild.NewBuilderFromScratch(ioCtrl),
+		fs:                   fs,
+		workingDirectoryCtrl: filesystem.NewOsWorkingDirectoryCtrl(),
+		temporalCtrl:         filesystem.NewTemporalDirectoryCtrl(fs),
+		clusterMetadata:      fetchClusterMetadata,
+	}
+}
+
+// run executes the commands of a test suite in the okteto builder and copies its artifacts to the current folder
+func (rt *remoteTestRunner) run(ctx context.Context, manifestPath string, test *model.Test) error {
+	sc, err := rt.clusterMetadata(ctx)
+	if err != nil {
+		return err
+	}
+
+	image := test.Image
+	if image == "" {
+		image = sc.PipelineRunnerImage
+	}
+
+	cwd, err := rt.workingDirectoryCtrl.Get()
+	if err != nil {
+		return err
+	}
+
+	tmpDir, err := rt.temporalCtrl.Create()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := rt.fs.RemoveAll(tmpDir); err != nil {
+			oktetoLog.Infof("error removing temporal folder: %s", err)
+		}
+	}()
+
+	dockerfile, err := rt.createDockerfile(cwd, tmpDir, manifestPath, image, test)
+	if err != nil {
+		return err
+	}
+
+	randomNumber, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return err
+	}
+
+	outputDir := filepath.Join(tmpDir, "output")
+	buildOptions := buildCmd.OptsFromBuildInfoForRemoteDeploy(&build.Info{Dockerfile: dockerfile}, &types.BuildOptions{Path: cwd, OutputMode: getOutputMode()})
+	buildOptions.LocalOutputPath = outputDir
+	buildOptions.BuildArgs = append(
+		buildOptions.BuildArgs,
+		fmt.Sprintf("%s=%s", model.OktetoContextEnvVar, okteto.Context().Name),
+		fmt.Sprintf("%s=%s", model.OktetoNamespaceEnvVar, okteto.Context().Namespace),
+		fmt.Sprintf("%s=%s", model.OktetoTokenEnvVar, okteto.Context().Token),
+		fmt.Sprintf("%s=%s", constants.OktetoTlsCertBase64EnvVar, base64.StdEncoding.EncodeToString(sc.Certificate)),
+		fmt.Sprintf("%s=%s", constants.OktetoInternalServerNameEnvVar, sc.ServerName),
+		fmt.Sprintf("%s=%s", constants.OktetoGitCommitEnvVar, config.EnvOktetoGitCommit.Value()),
+		fmt.Sprintf("%s=%s", constants.OktetoGitBranchEnvVar, config.EnvOktetoGitBranch.Value()),
+		fmt.Sprintf("%s=%d", constants.OktetoInvalidateCacheEnvVar, int(randomNumber.Int64())),
+	)
+
+	if sc.ServerName != "" {
+		registryUrl := okteto.Context().Registry
+		subdomain := strings.TrimPrefix(registryUrl, "registry.")
+		ip, _, err := net.SplitHostPort(sc.ServerName)
+		if err != nil {
+			return fmt.Errorf("failed to parse server name network address: %w", err)
+		}
+		buildOptions.ExtraHosts = getExtraHosts(registryUrl, subdomain, ip, *sc)
+	}
+
+	if err := rt.builder.Build(ctx, buildOptions); err != nil {
+		var userErr oktetoErrors.UserError
+		if errors.As(err, &userErr) {
+			return userErr
+		}
+		return oktetoErrors.UserError{
+			E: fmt.Errorf("error running the test suite: %w", err),
+		}
+	}
+
+	if err := rt.copyArtifacts(filepath.Join(outputDir, artifactsFolder), cwd); err != nil {
+		return fmt.Errorf("failed to copy the test artifacts: %w", err)
+	}
+
+	return rt.getCommandsError(filepath.Join(outputDir, exitCodeFile))
+}
+
+func (rt *remoteTestRunner) createDockerfile(cwd, tmpDir, manifestPath, image string, test *model.Test) (string, error) {
+	tmpl := template.Must(template.New(templateName).Parse(dockerfileTemplate))
+	dockerfileSyntax := dockerfileTemplateProperties{
+		OktetoCLIImage:         getOktetoCLIVersion(config.VersionString),
+		UserTestImage:          image,
+		ContextArgName:         model.OktetoContextEnvVar,
+		NamespaceArgName:       model.OktetoNamespaceEnvVar,
+		TokenArgName:           model.OktetoTokenEnvVar,
+		TlsCertBase64ArgName:   constants.OktetoTlsCertBase64EnvVar,
+		InternalServerName:     constants.OktetoInternalServerNameEnvVar,
+		GitCommitArgName:       constants.OktetoGitCommitEnvVar,
+		GitBranchArgName:       constants.OktetoGitBranchEnvVar,
+		InvalidateCacheArgName: constants.OktetoInvalidateCacheEnvVar,
+		Workdir:                path.Join("/okteto/src", filepath.ToSlash(test.Context)),
+		ArtifactsFolder:        artifactsFolder,
+		ExitCodeFile:           exitCodeFile,
+		Caches:                 test.Caches,
+		Commands:               test.Commands,
+		Artifacts:              test.Artifacts,
+	}
+
+	dockerfile, err := rt.fs.Create(filepath.Join(tmpDir, dockerfileTemporalName))
+	if err != nil {
+		return "", err
+	}
+	defer dockerfile.Close()
+
+	if err := remote.CreateDockerignoreFileWithFilesystem(cwd, tmpDir, manifestPath, rt.fs); err != nil {
+		return "", err
+	}
+
+	if err := tmpl.Execute(dockerfile, dockerfileSyntax); err != nil {
+		return "", err
+	}
+	return dockerfile.Name(), nil
+}
+
+// copyArtifacts copies the artifacts exported by the builder into the local folder
+func (rt *remoteTestRunner) copyArtifacts(from, to string) error {
+	if _, err := rt.fs.Stat(from); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	return afero.Walk(rt.fs, from, func(src string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(from, src)
+		if err != nil {
+			return err
+		}
+		dst := filepath.Join(to, rel)
+		if info.IsDir() {
+			return rt.fs.MkdirAll(dst, 0700)
+		}
+		content, err := afero.ReadFile(rt.fs, src)
+		if err != nil {
+			return err
+		}
+		oktetoLog.Infof("copying test artifact to '%s'", dst)
+		return afero.WriteFile(rt.fs, dst, content, info.Mode().Perm())
+	})
+}
+
+// getCommandsError returns an error if the commands of the test suite didn't succeed
+func (rt *remoteTestRunner) getCommandsError(exitCodePath string) error {
+	content, err := afero.ReadFile(rt.fs, exitCodePath)
+	if err != nil {
+		return fmt.Errorf("failed to read the result of the test suite: %w", err)
+	}
+	exitCode, err := strconv.Atoi(strings.TrimSpace(string(content)))
+	if err != nil {
+		return fmt.Errorf("failed to parse the result of the test suite: %w", err)
+	}
+	if exitCode != 0 {
+		return fmt.Errorf("test commands exited with code %d", exitCode)
+	}
+	return nil
+}
+
+func getOutputMode() string {
+	if oktetoLog.GetOutputFormat() == oktetoLog.TTYFormat {
+		return oktetoLog.TTYFormat
+	}
+	return oktetoLog.PlainFormat
+}
+
+func getExtraHosts(registryURL, subdomain, ip string, metadata types.ClusterMetadata) []types.HostMap {
+	extraHosts := []types.HostMap{
+		{Hostname: registryURL, IP: ip},
+		{Hostname: fmt.Sprintf("kubernetes.%s", subdomain), IP: ip},
+	}
+
+	if metadata.BuildKitInternalIP != "" {
+		extraHosts = append(extraHosts, types.HostMap{Hostname: fmt.Sprintf("buildkit.%s", subdomain), IP: metadata.BuildKitInternalIP})
+	}
+
+	if metadata.PublicDomain != "" {
+		extraHosts = append(extraHosts, types.HostMap{Hostname: metadata.PublicDomain, IP: ip})
+	}
+
+	return extraHosts
+}
+
+func getOktetoCLIVersion(versionString string) string {
+	var version string
+	if match, err := regexp.MatchString(`\d+\.\d+\.\d+`, versionString); match {
+		version = fmt.Sprintf(constants.OktetoCLIImageForRemoteTemplate, versionString)
+	} else {
+		oktetoLog.Infof("invalid okteto CLI version %s: %s", versionString, err)
+		oktetoLog.Info("using latest okteto CLI image")
+		remoteOktetoImage := config.EnvOktetoRemoteCLIImage.Value()
+		if remoteOktetoImage != "" {
+			version = remoteOktetoImage
+		} else {
+			version = fmt.Sprintf(constants.OktetoCLIImageForRemoteTemplate, "latest")
+		}
+	}
+
+	return version
+}
+
+func fetchClusterMetadata(ctx context.Context) (*types.ClusterMetadata, error) {
+	cp := okteto.NewOktetoClientProvider()
+	c, err := cp.Provide()
+	if err != nil {
+		return nil, fmt.Errorf("failed to provide okteto client for fetching certs: %w", err)
+	}
+	uc := c.User()
+
+	metadata, err := uc.GetClusterMetadata(ctx, okteto.Context().Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	if metadata.Certificate == nil {
+		metadata.Certificate, err = uc.GetClusterCertificate(ctx, okteto.Context().Name, okteto.Context().Namespace)
+	}
+
+	return &metadata, err
+}
@@ -0,0 +1,139 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	filesystem "github.com/okteto/okteto/pkg/filesystem/fake"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeBuilder struct {
+	err   error
+	build func(o *types.BuildOptions)
+}
+
+func (f fakeBuilder) Build(_ context.Context, opts *types.BuildOptions) error {
+	if f.build != nil {
+		f.build(opts)
+	}
+	return f.err
+}
+
+func (fakeBuilder) IsV1() bool { return true }
+
+func newFakeRemoteTestRunner(fs afero.Fs, b fakeBuilder) *remoteTestRunner {
+	return &remoteTestRunner{
+		builder:              b,
+		fs:                   fs,
+		workingDirectoryCtrl: filesystem.NewFakeWorkingDirectoryCtrl(filepath.Clean("/src")),
+		temporalCtrl:         filesystem.NewTemporalDirectoryCtrl(fs),
+		clusterMetadata: func(ctx context.Context) (*types.ClusterMetadata, error) {
+			return &types.ClusterMetadata{
+				Certificate:         []byte("cert"),
+				ServerName:          "1.2.3.4:443",
+				PipelineRunnerImage: "okteto/pipeline-runner",
+			}, nil
+		},
+	}
+}
+
+func TestRemoteRun(t *testing.T) {
+	test := &model.Test{
+		Commands:  []model.DeployCommand{{Name: "make test", Command: "make test"}},
+		Artifacts: []model.Artifact{{Path: "report.xml", Destination: "reports/junit.xml"}},
+	}
+
+	tests := []struct {
+		builderErr  error
+		name        string
+		exitCode    string
+		expectedErr bool
+	}{
+		{
+			name:     "commands succeed",
+			exitCode: "0\n",
+		},
+		{
+			name:        "commands fail",
+			exitCode:    "2\n",
+			expectedErr: true,
+		},
+		{
+			name:        "build fails",
+			builderErr:  assert.AnError,
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			b := fakeBuilder{
+				err: tt.builderErr,
+				build: func(o *types.BuildOptions) {
+					require.NotEmpty(t, o.LocalOutputPath)
+					require.NoError(t, afero.WriteFile(fs, filepath.Join(o.LocalOutputPath, exitCodeFile), []byte(tt.exitCode), 0600))
+					require.NoError(t, afero.WriteFile(fs, filepath.Join(o.LocalOutputPath, artifactsFolder, "reports", "junit.xml"), []byte("<testsuites/>"), 0600))
+				},
+			}
+
+			err := newFakeRemoteTestRunner(fs, b).run(context.Background(), "", test)
+			if tt.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			content, err := afero.ReadFile(fs, filepath.Join("/src", "reports", "junit.xml"))
+			require.NoError(t, err)
+			require.Equal(t, "<testsuites/>", string(content))
+		})
+	}
+}
+
+func TestCreateDockerfile(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	rt := newFakeRemoteTestRunner(fs, fakeBuilder{})
+	test := &model.Test{
+		Context: "api",
+		Commands: []model.DeployCommand{
+			{Name: "go test", Command: "go test ./..."},
+		},
+		Caches:    []string{"/root/.cache/go-build"},
+		Artifacts: []model.Artifact{{Path: "coverage.xml", Destination: "reports/coverage.xml"}},
+	}
+
+	dockerfile, err := rt.createDockerfile("/src", "/tmp", "", "golang:1.21", test)
+	require.NoError(t, err)
+
+	content, err := afero.ReadFile(fs, dockerfile)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "FROM golang:1.21 as runner")
+	assert.Contains(t, string(content), "WORKDIR /okteto/src/api")
+	assert.Contains(t, string(content), "RUN --mount=type=cache,target=/root/.cache/go-build <<'OKTETO_TEST'")
+	assert.Contains(t, string(content), "go test ./...\n")
+	assert.Contains(t, string(content), `cp -r "coverage.xml" "/okteto/output/artifacts/reports/coverage.xml"`)
+	assert.Contains(t, string(content), "echo $status > /okteto/output/exit-code")
+
+	_, err = fs.Stat("/tmp/.dockerignore")
+	require.NoError(t, err)
+}
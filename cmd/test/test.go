@@ -0,0 +1,155 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/log/io"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+// Options represents the options of the test command
+type Options struct {
+	ManifestPath string
+	Namespace    string
+	K8sContext   string
+}
+
+type testRunner interface {
+	run(ctx context.Context, manifestPath string, test *model.Test) error
+}
+
+// Command runs the test suites defined in the okteto manifest
+type Command struct {
+	GetManifest func(path string) (*model.Manifest, error)
+	runner      testRunner
+}
+
+// Test runs the test suites defined in the okteto manifest
+func Test(ctx context.Context, ioCtrl *io.IOController) *cobra.Command {
+	options := &Options{}
+	cmd := &cobra.Command{
+		Use:   "test [suite...]",
+		Short: "Run the test suites defined in the 'test' section of your okteto manifest",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if options.ManifestPath == "" {
+				manifestPath, err := utils.DiscoverManifestPath()
+				if err != nil {
+					return err
+				}
+				options.ManifestPath = manifestPath
+			}
+			if options.ManifestPath != "" {
+				workdir := model.GetWorkdirFromManifestPath(options.ManifestPath)
+				if err := os.Chdir(workdir); err != nil {
+					return err
+				}
+				options.ManifestPath = model.GetManifestPathFromWorkdir(options.ManifestPath, workdir)
+			}
+
+			ctxResource, err := utils.LoadManifestContext(options.ManifestPath)
+			if err != nil {
+				if oktetoErrors.IsNotExist(err) {
+					ctxResource = &model.ContextResource{}
+				}
+			}
+
+			if err := ctxResource.UpdateNamespace(options.Namespace); err != nil {
+				return err
+			}
+
+			if err := ctxResource.UpdateContext(options.K8sContext); err != nil {
+				return err
+			}
+
+			ctxOptions := &contextCMD.ContextOptions{
+				Context:   ctxResource.Context,
+				Namespace: ctxResource.Namespace,
+				Show:      true,
+			}
+			if err := contextCMD.NewContextCommand().Run(ctx, ctxOptions); err != nil {
+				return err
+			}
+
+			c := &Command{
+				GetManifest: model.GetManifestV2,
+				runner:      newRemoteTestRunner(ioCtrl),
+			}
+			return c.Run(ctx, options, args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.ManifestPath, "file", "f", "", "path to the okteto manifest file")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "overwrites the namespace where the tests are executed")
+	cmd.Flags().StringVarP(&options.K8sContext, "context", "c", "", "overwrites the context where the tests are executed")
+	return cmd
+}
+
+// Run runs the given test suites, or all of them if none is given
+func (c *Command) Run(ctx context.Context, options *Options, suites []string) error {
+	manifest, err := c.GetManifest(options.ManifestPath)
+	if err != nil {
+		return err
+	}
+	utils.AddManifestLabelsPolicy(manifest)
+
+	if len(manifest.Test) == 0 {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("your okteto manifest doesn't define any test suite"),
+			Hint: "Add a 'test' section to your okteto manifest and try again",
+		}
+	}
+
+	if len(suites) == 0 {
+		suites = manifest.Test.Names()
+	}
+	for _, suite := range suites {
+		if _, ok := manifest.Test[suite]; !ok {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("test suite '%s' is not defined in your okteto manifest", suite),
+				Hint: fmt.Sprintf("Available test suites: %s", strings.Join(manifest.Test.Names(), ", ")),
+			}
+		}
+	}
+
+	failed := []string{}
+	for _, suite := range suites {
+		oktetoLog.SetStage(suite)
+		oktetoLog.Information("Running test suite '%s'", suite)
+		if err := c.runner.run(ctx, options.ManifestPath, manifest.Test[suite]); err != nil {
+			oktetoLog.Fail("Test suite '%s' failed: %s", suite, err)
+			failed = append(failed, suite)
+			continue
+		}
+		oktetoLog.Success("Test suite '%s' passed", suite)
+	}
+	oktetoLog.SetStage("")
+
+	if len(failed) > 0 {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("%d of %d test suites failed: %s", len(failed), len(suites), strings.Join(failed, ", ")),
+			Hint: "Check the logs of the failed test suites and try again",
+		}
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTestRunner struct {
+	errs map[string]error
+	ran  []string
+}
+
+func (f *fakeTestRunner) run(_ context.Context, _ string, test *model.Test) error {
+	f.ran = append(f.ran, test.Image)
+	return f.errs[test.Image]
+}
+
+func TestMain(m *testing.M) {
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		CurrentContext: "test",
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {
+				Name:      "test",
+				Namespace: "namespace",
+				UserID:    "user-id",
+				Registry:  "registry.okteto.dev",
+			},
+		},
+	}
+	os.Exit(m.Run())
+}
+
+func newFakeManifest() *model.Manifest {
+	return &model.Manifest{
+		Test: model.ManifestTests{
+			"unit": &model.Test{
+				Image:    "unit",
+				Commands: []model.DeployCommand{{Name: "go test", Command: "go test"}},
+			},
+			"e2e": &model.Test{
+				Image:    "e2e",
+				Commands: []model.DeployCommand{{Name: "make e2e", Command: "make e2e"}},
+			},
+		},
+	}
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		manifest    *model.Manifest
+		errs        map[string]error
+		name        string
+		suites      []string
+		expectedRan []string
+		expectedErr bool
+	}{
+		{
+			name:        "all suites",
+			manifest:    newFakeManifest(),
+			expectedRan: []string{"e2e", "unit"},
+		},
+		{
+			name:        "selected suite",
+			manifest:    newFakeManifest(),
+			suites:      []string{"unit"},
+			expectedRan: []string{"unit"},
+		},
+		{
+			name:        "suite not defined",
+			manifest:    newFakeManifest(),
+			suites:      []string{"integration"},
+			expectedErr: true,
+		},
+		{
+			name:        "no test section",
+			manifest:    &model.Manifest{},
+			expectedErr: true,
+		},
+		{
+			name:        "failed suite does not stop the rest",
+			manifest:    newFakeManifest(),
+			errs:        map[string]error{"e2e": assert.AnError},
+			expectedRan: []string{"e2e", "unit"},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &fakeTestRunner{errs: tt.errs}
+			c := &Command{
+				GetManifest: func(string) (*model.Manifest, error) {
+					return tt.manifest, nil
+				},
+				runner: runner,
+			}
+			err := c.Run(context.Background(), &Options{}, tt.suites)
+			if tt.expectedErr {
+				require.Error(t, err)
+				require.ErrorAs(t, err, &oktetoErrors.UserError{})
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.expectedRan, runner.ran)
+		})
+	}
+}
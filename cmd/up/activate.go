@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/cmd/daemon"
 	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/constants"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
@@ -148,6 +149,13 @@ func (up *upContext) activate() error {
 		return fmt.Errorf("couldn't activate your development container\n    %w", err)
 	}
 
+	if up.Options != nil && up.Options.CheckImage && !up.imageChecked {
+		up.imageChecked = true
+		if err := up.checkImageDrift(up.Pod); err != nil {
+			return err
+		}
+	}
+
 	if up.isRetry {
 		if lastPodUID != up.Pod.UID {
 			up.analyticsMeta.ReconnectDevPodRecreated()
@@ -172,6 +180,10 @@ func (up *upContext) activate() error {
 	}
 	go up.cleanCommand(ctx)
 
+	if err := up.waitForReadinessGate(ctx); err != nil {
+		return err
+	}
+
 	if err := up.sync(ctx); err != nil {
 		if up.shouldRetry(ctx, err) {
 			return oktetoErrors.ErrLostSyncthing
@@ -181,6 +193,16 @@ func (up *upContext) activate() error {
 
 	// success means all context is ready to run the activation
 	up.success = true
+	if up.reconnectBackoff != nil {
+		up.reconnectBackoff.reset()
+	}
+
+	if !up.postUpHookExecuted {
+		up.postUpHookExecuted = true
+		if err := up.runHook(model.PostUpHook); err != nil {
+			return err
+		}
+	}
 
 	go func() {
 		output := <-up.cleaned
@@ -212,15 +234,30 @@ func (up *upContext) activate() error {
 
 		}
 		printDisplayContext(up)
+		if shouldOpen(up.Dev, up.Options) && !up.openStarted && !daemon.IsDaemon() {
+			// only once per session, reconnections don't open the browser again
+			up.openStarted = true
+			go openWhenReady(ctx, up.Dev)
+		}
 		durationActivateUp := time.Since(up.StartTime)
 		up.analyticsMeta.ActivateDuration(durationActivateUp)
 
+		if daemon.IsDaemon() {
+			// in the background the command is run by 'okteto attach', the daemon only keeps sync and forwards alive
+			if err := config.UpdateStateFile(up.Dev.Name, up.Dev.Namespace, config.Ready); err != nil {
+				up.CommandResult <- err
+			}
+			return
+		}
+
 		startRunCommand := time.Now()
 		up.CommandResult <- up.RunCommand(ctx, up.Dev.Command.Values)
 		up.analyticsMeta.ExecDuration(time.Since(startRunCommand))
 
 	}()
 
+	go up.watchManifest(ctx)
+
 	prevError := up.waitUntilExitOrInterruptOrApply(ctx)
 
 	if up.shouldRetry(ctx, prevError) {
@@ -0,0 +1,166 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"github.com/okteto/okteto/pkg/cmd/daemon"
+	"github.com/okteto/okteto/pkg/config"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+// runDetached starts 'okteto up' as a background daemon and waits until the development container is ready
+func runDetached(ctx context.Context, dev *model.Dev, upOptions *UpOptions) error {
+	if err := cleanDaemonState(dev); err != nil {
+		return err
+	}
+
+	bin, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to get the okteto binary path: %w", err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	logPath := daemon.GetLogPath(dev.Namespace, dev.Name)
+	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create daemon log file: %w", err)
+	}
+	defer logFile.Close()
+
+	cmd := exec.Command(bin, getDaemonArgs(dev, upOptions)...)
+	cmd.Dir = wd
+	cmd.Env = append(os.Environ(), fmt.Sprintf("%s=true", daemon.EnvVar))
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	// the daemon runs in its own session so it survives the terminal that started it
+	detachProcess(cmd)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start 'okteto up' in the background: %w", err)
+	}
+	oktetoLog.Infof("started daemon process %d for '%s'", cmd.Process.Pid, dev.Name)
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+
+	oktetoLog.Spinner("Activating your development container in the background...")
+	oktetoLog.StartSpinner()
+	defer oktetoLog.StopSpinner()
+
+	if err := waitUntilDaemonIsReady(ctx, dev, exited); err != nil {
+		return fmt.Errorf("%w\n    Find the daemon logs at: %s", err, logPath)
+	}
+
+	oktetoLog.StopSpinner()
+	oktetoLog.Success("Development container '%s' is running in the background", dev.Name)
+	oktetoLog.Information("Run 'okteto attach %s' to open a terminal, or 'okteto down %s --detach-only' to stop it", dev.Name, dev.Name)
+	if shouldOpen(dev, upOptions) {
+		ctx, cancel := context.WithTimeout(ctx, detachedOpenTimeout)
+		defer cancel()
+		openWhenReady(ctx, dev)
+	}
+	return nil
+}
+
+// cleanDaemonState fails if the development container is already running in the background and removes its stale state otherwise
+func cleanDaemonState(dev *model.Dev) error {
+	if s, err := daemon.Read(dev.Namespace, dev.Name); err == nil {
+		if s.IsRunning() {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("development container '%s' is already running in the background", dev.Name),
+				Hint: fmt.Sprintf("Run 'okteto attach %s' to open a terminal in your development container", dev.Name),
+			}
+		}
+		if err := daemon.Delete(dev.Namespace, dev.Name); err != nil {
+			oktetoLog.Infof("failed to delete stale daemon state file: %s", err)
+		}
+	}
+
+	if err := config.DeleteStateFile(dev.Name, dev.Namespace); err != nil && !os.IsNotExist(err) {
+		oktetoLog.Infof("failed to delete state file: %s", err)
+	}
+	return nil
+}
+
+func waitUntilDaemonIsReady(ctx context.Context, dev *model.Dev, exited chan error) error {
+	ticker := time.NewTicker(1 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-exited:
+			if err != nil {
+				return fmt.Errorf("'okteto up' exited in the background: %w", err)
+			}
+			return errors.New("'okteto up' exited in the background")
+		case <-ticker.C:
+			info, err := config.GetStateInfo(dev.Name, dev.Namespace)
+			if err != nil {
+				// the state file is not created until the daemon starts the activation
+				continue
+			}
+			switch info.State {
+			case config.Ready:
+				return nil
+			case config.Failed:
+				return fmt.Errorf("'okteto up' failed in the background: %s", info.LastError)
+			}
+		}
+	}
+}
+
+// getDaemonArgs returns the arguments to run 'okteto up' in the background for a given development container
+func getDaemonArgs(dev *model.Dev, upOptions *UpOptions) []string {
+	args := []string{"up", dev.Name, "--namespace", dev.Namespace, "--context", dev.Context, "--log-output", oktetoLog.PlainFormat}
+	if upOptions.ManifestPath != "" {
+		args = append(args, "--file", upOptions.ManifestPath)
+	}
+	for _, e := range upOptions.Envs {
+		args = append(args, "--env", e)
+	}
+	if upOptions.Remote > 0 {
+		args = append(args, "--remote", strconv.Itoa(upOptions.Remote))
+	}
+	if upOptions.Reset {
+		args = append(args, "--reset")
+	}
+	return args
+}
+
+// writeDaemonState stores the state of the current process when it runs as a background daemon
+func (up *upContext) writeDaemonState() error {
+	return daemon.Write(&daemon.State{
+		Name:       up.Dev.Name,
+		Namespace:  up.Dev.Namespace,
+		PID:        os.Getpid(),
+		Executable: daemon.GetExecutable(),
+		LogFile:    daemon.GetLogPath(up.Dev.Namespace, up.Dev.Name),
+		StartedAt:  time.Now(),
+	})
+}
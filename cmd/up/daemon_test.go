@@ -0,0 +1,52 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_getDaemonArgs(t *testing.T) {
+	dev := &model.Dev{Name: "api", Namespace: "ns", Context: "https://okteto.example.com"}
+	tests := []struct {
+		options  *UpOptions
+		name     string
+		expected []string
+	}{
+		{
+			name:     "default options",
+			options:  &UpOptions{},
+			expected: []string{"up", "api", "--namespace", "ns", "--context", "https://okteto.example.com", "--log-output", "plain"},
+		},
+		{
+			name: "all options",
+			options: &UpOptions{
+				ManifestPath: "okteto.yml",
+				Envs:         []string{"A=1", "B=2"},
+				Remote:       2222,
+				Reset:        true,
+				Deploy:       true,
+			},
+			expected: []string{"up", "api", "--namespace", "ns", "--context", "https://okteto.example.com", "--log-output", "plain", "--file", "okteto.yml", "--env", "A=1", "--env", "B=2", "--remote", "2222", "--reset"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getDaemonArgs(dev, tt.options))
+		})
+	}
+}
@@ -0,0 +1,13 @@
+//go:build !windows
+// +build !windows
+
+package up
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+func detachProcess(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+}
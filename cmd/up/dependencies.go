@@ -14,32 +14,26 @@
 package up
 
 import (
+	"context"
 	"time"
 
 	"github.com/okteto/okteto/cmd/utils"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/retry"
 	"github.com/okteto/okteto/pkg/ssh"
 	"github.com/okteto/okteto/pkg/syncthing"
 )
 
 func downloadSyncthing() error {
-	maxRetries := 2
-	t := time.NewTicker(1 * time.Second)
-	var err error
-	for i := 0; i < 3; i++ {
+	policy := retry.Constant("download syncthing", 1*time.Second, 3)
+	return retry.Do(context.Background(), policy, func(_ context.Context) error {
 		p := &utils.ProgressBar{}
-		err = syncthing.Install(p)
-		if err == nil {
-			return nil
-		}
-
-		if i < maxRetries {
-			oktetoLog.Infof("failed to download syncthing, retrying: %s", err)
-			<-t.C
+		if err := syncthing.Install(p); err != nil {
+			oktetoLog.Infof("failed to download syncthing: %s", err)
+			return err
 		}
-	}
-
-	return err
+		return nil
+	})
 }
 
 func sshKeys() error {
@@ -0,0 +1,85 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"time"
+
+	"github.com/okteto/okteto/pkg/config"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model/forward"
+)
+
+const forwardProfilesPollInterval = 2 * time.Second
+
+// watchForwardProfiles starts the forwards of the profiles enabled with 'okteto forwards enable' until ctx is done
+func (up *upContext) watchForwardProfiles(ctx context.Context, f forwarder) {
+	if len(up.Dev.ForwardProfiles) == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(forwardProfilesPollInterval)
+	defer ticker.Stop()
+
+	started := map[string]bool{}
+	for {
+		up.startEnabledForwardProfiles(f, started)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (up *upContext) startEnabledForwardProfiles(f forwarder, started map[string]bool) {
+	profiles, err := config.GetEnabledForwardProfiles(up.Dev.Name, up.Dev.Namespace)
+	if err != nil {
+		oktetoLog.Infof("failed to read enabled forward profiles: %s", err)
+		return
+	}
+
+	for _, name := range profiles {
+		if started[name] {
+			continue
+		}
+		// failed profiles are retried on the next poll
+		if err := up.startForwardProfile(f, name); err != nil {
+			oktetoLog.Infof("failed to enable forward profile '%s': %s", name, err)
+			continue
+		}
+		started[name] = true
+		oktetoLog.Infof("forward profile '%s' enabled", name)
+	}
+}
+
+func (up *upContext) startForwardProfile(f forwarder, name string) error {
+	forwards, err := up.Dev.ForwardProfiles.Get(name)
+	if err != nil {
+		return err
+	}
+
+	resolved := make([]forward.Forward, 0, len(forwards))
+	for _, fwd := range forwards {
+		if fwd.Labels != nil {
+			fwd, err = f.TransformLabelsToServiceName(fwd)
+			if err != nil {
+				return err
+			}
+		}
+		resolved = append(resolved, fwd)
+	}
+	return f.StartForwards(resolved)
+}
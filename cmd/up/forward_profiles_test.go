@@ -0,0 +1,101 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeProfileForwarder struct {
+	forwarder
+	err     error
+	started [][]forward.Forward
+}
+
+func (f *fakeProfileForwarder) StartForwards(forwards []forward.Forward) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.started = append(f.started, forwards)
+	return nil
+}
+
+func (*fakeProfileForwarder) TransformLabelsToServiceName(f forward.Forward) (forward.Forward, error) {
+	f.ServiceName = "api"
+	return f, nil
+}
+
+func TestStartEnabledForwardProfiles(t *testing.T) {
+	t.Setenv(constants.OktetoHomeEnvVar, t.TempDir())
+
+	up := &upContext{
+		Dev: &model.Dev{
+			Name:      "dev",
+			Namespace: "ns",
+			ForwardProfiles: model.ForwardProfiles{
+				"debug": []forward.Forward{{Local: 2345, Remote: 2345}},
+				"api":   []forward.Forward{{Local: 8081, Remote: 8080, Service: true, Labels: map[string]string{"app": "api"}}},
+			},
+		},
+	}
+	f := &fakeProfileForwarder{}
+	started := map[string]bool{}
+
+	up.startEnabledForwardProfiles(f, started)
+	assert.Empty(t, f.started)
+
+	require.NoError(t, config.EnableForwardProfile("dev", "ns", "debug"))
+	up.startEnabledForwardProfiles(f, started)
+	up.startEnabledForwardProfiles(f, started)
+	require.Len(t, f.started, 1)
+	assert.Equal(t, []forward.Forward{{Local: 2345, Remote: 2345}}, f.started[0])
+
+	require.NoError(t, config.EnableForwardProfile("dev", "ns", "api"))
+	up.startEnabledForwardProfiles(f, started)
+	require.Len(t, f.started, 2)
+	assert.Equal(t, "api", f.started[1][0].ServiceName)
+}
+
+func TestStartEnabledForwardProfilesRetriesFailures(t *testing.T) {
+	t.Setenv(constants.OktetoHomeEnvVar, t.TempDir())
+
+	up := &upContext{
+		Dev: &model.Dev{
+			Name:      "dev",
+			Namespace: "ns",
+			ForwardProfiles: model.ForwardProfiles{
+				"debug": []forward.Forward{{Local: 2345, Remote: 2345}},
+			},
+		},
+	}
+	f := &fakeProfileForwarder{err: assert.AnError}
+	started := map[string]bool{}
+	require.NoError(t, config.EnableForwardProfile("dev", "ns", "debug"))
+
+	up.startEnabledForwardProfiles(f, started)
+	assert.Empty(t, f.started)
+	assert.False(t, started["debug"])
+
+	f.err = nil
+	up.startEnabledForwardProfiles(f, started)
+	require.Len(t, f.started, 1)
+	assert.True(t, started["debug"])
+}
@@ -28,6 +28,9 @@ import (
 	"github.com/okteto/okteto/pkg/syncthing"
 )
 
+// forwardsHealthInterval is the frequency to write the health of the forwards for 'okteto status --forwards'
+const forwardsHealthInterval = 2 * time.Second
+
 func (up *upContext) forwards(ctx context.Context) error {
 	msg := "Configuring SSH tunnel to your development container..."
 	if up.Dev.IsHybridModeEnabled() {
@@ -81,6 +84,8 @@ func (up *upContext) forwards(ctx context.Context) error {
 		go up.setGlobalForwardsIfRequiredLoop(ctx)
 	}
 
+	go up.watchForwardProfiles(ctx, up.Forwarder)
+
 	return nil
 }
 
@@ -96,7 +101,8 @@ func (up *upContext) sshForwards(ctx context.Context) error {
 		return err
 	}
 
-	up.Forwarder = ssh.NewForwardManager(ctx, fmt.Sprintf(":%d", up.Dev.RemotePort), up.Dev.Interface, "0.0.0.0", f, up.Dev.Namespace)
+	fm := ssh.NewForwardManager(ctx, fmt.Sprintf(":%d", up.Dev.RemotePort), up.Dev.Interface, "0.0.0.0", f, up.Dev.Namespace)
+	up.Forwarder = fm
 	if err := up.Forwarder.Add(forward.Forward{Local: up.Sy.RemotePort, Remote: syncthing.ClusterPort}); err != nil {
 		return err
 	}
@@ -119,11 +125,15 @@ func (up *upContext) sshForwards(ctx context.Context) error {
 		return err
 	}
 
+	go fm.Supervise(ctx, ssh.GetForwardsHealthPath(up.Dev.Namespace, up.Dev.Name), forwardsHealthInterval)
+
 	if isNeededGlobalForwarder(up.Manifest.GlobalForward) {
 		up.GlobalForwarderStatus = make(chan error, 1)
 		go up.setGlobalForwardsIfRequiredLoop(ctx)
 	}
 
+	go up.watchForwardProfiles(ctx, up.Forwarder)
+
 	return nil
 }
 
@@ -0,0 +1,34 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"fmt"
+
+	"github.com/okteto/okteto/cmd/utils/executor"
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+// runHook executes the commands defined for the given hook in the manifest
+func (up *upContext) runHook(hook string) error {
+	if up.Manifest == nil || up.hookExecutor == nil {
+		return nil
+	}
+	env := []string{
+		fmt.Sprintf("%s=%s", model.OktetoNamespaceEnvVar, up.Dev.Namespace),
+		fmt.Sprintf("%s=%s", constants.OktetoNameEnvVar, up.Manifest.Name),
+	}
+	return executor.RunHook(up.hookExecutor, hook, up.Manifest.Hooks, env)
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+// errDevImageChanged is returned to redeploy the development container with the latest version of its image
+var errDevImageChanged = errors.New("the image of the development container has changed")
+
+// imageDrift represents a difference between the image running in the development container and the image in the registry
+type imageDrift struct {
+	image   string
+	running string
+	latest  string
+}
+
+// getImageDrift compares the digest of the image running in the development container with the digest in the registry
+func (up *upContext) getImageDrift(pod *apiv1.Pod) (*imageDrift, error) {
+	if pod == nil || up.Dev.Image == nil || up.Dev.Image.Name == "" {
+		return nil, nil
+	}
+
+	running := ""
+	for _, cs := range pod.Status.ContainerStatuses {
+		if up.Dev.Container == "" || cs.Name == up.Dev.Container {
+			running = getDigest(cs.ImageID)
+			break
+		}
+	}
+	if running == "" {
+		oktetoLog.Infof("digest of the running image of '%s' not found", up.Dev.Name)
+		return nil, nil
+	}
+
+	imageWithDigest, err := up.Registry.GetImageTagWithDigest(up.Dev.Image.Name)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the digest of image '%s': %w", up.Dev.Image.Name, err)
+	}
+	latest := getDigest(imageWithDigest)
+	if latest == "" || latest == running {
+		return nil, nil
+	}
+	return &imageDrift{
+		image:   up.Dev.Image.Name,
+		running: running,
+		latest:  latest,
+	}, nil
+}
+
+// checkImageDrift asks the user to redeploy the development container if its image has changed
+func (up *upContext) checkImageDrift(pod *apiv1.Pod) error {
+	drift, err := up.getImageDrift(pod)
+	if err != nil {
+		oktetoLog.Warning("Could not check if the image of your development container has changed: %s", err)
+		return nil
+	}
+	if drift == nil {
+		oktetoLog.Infof("image of the development container is up to date")
+		return nil
+	}
+
+	oktetoLog.Warning("The image '%s' has changed since your development container was deployed.\n    running: %s\n    latest:  %s", drift.image, drift.running, drift.latest)
+	redeploy, err := utils.AskYesNo("Do you want to redeploy your development container with the latest image?", utils.YesNoDefault_Yes)
+	if err != nil {
+		return err
+	}
+	if !redeploy {
+		return nil
+	}
+	up.Dev.LoadForcePull()
+	return errDevImageChanged
+}
+
+// getDigest returns the digest of an image reference or a container image id
+func getDigest(image string) string {
+	if i := strings.LastIndex(image, "@"); i != -1 {
+		return image[i+1:]
+	}
+	if strings.HasPrefix(image, "sha256:") {
+		return image
+	}
+	return ""
+}
@@ -0,0 +1,78 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+type fakeDigestRegistry struct {
+	err    error
+	digest string
+}
+
+func (fr fakeDigestRegistry) GetImageTagWithDigest(image string) (string, error) {
+	if fr.err != nil {
+		return "", fr.err
+	}
+	return image + "@" + fr.digest, nil
+}
+
+func (fakeDigestRegistry) GetImageTag(image, _, _ string) string { return image }
+
+func TestGetDigest(t *testing.T) {
+	assert.Equal(t, "sha256:123", getDigest("docker-pullable://okteto/golang@sha256:123"))
+	assert.Equal(t, "sha256:123", getDigest("sha256:123"))
+	assert.Equal(t, "", getDigest("okteto/golang:1"))
+}
+
+func TestGetImageDrift(t *testing.T) {
+	pod := &apiv1.Pod{
+		Status: apiv1.PodStatus{
+			ContainerStatuses: []apiv1.ContainerStatus{
+				{Name: "sidecar", ImageID: "docker-pullable://busybox@sha256:000"},
+				{Name: "api", ImageID: "docker-pullable://okteto/golang@sha256:123"},
+			},
+		},
+	}
+	dev := &model.Dev{
+		Name:      "api",
+		Container: "api",
+		Image:     &build.Info{Name: "okteto/golang:1"},
+	}
+
+	up := &upContext{Dev: dev, Registry: fakeDigestRegistry{digest: "sha256:123"}}
+	drift, err := up.getImageDrift(pod)
+	require.NoError(t, err)
+	assert.Nil(t, drift)
+
+	up.Registry = fakeDigestRegistry{digest: "sha256:456"}
+	drift, err = up.getImageDrift(pod)
+	require.NoError(t, err)
+	assert.Equal(t, &imageDrift{image: "okteto/golang:1", running: "sha256:123", latest: "sha256:456"}, drift)
+
+	up.Registry = fakeDigestRegistry{err: assert.AnError}
+	_, err = up.getImageDrift(pod)
+	assert.ErrorIs(t, err, assert.AnError)
+
+	drift, err = up.getImageDrift(nil)
+	require.NoError(t, err)
+	assert.Nil(t, drift)
+}
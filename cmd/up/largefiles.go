@@ -0,0 +1,170 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/moby/patternmatcher"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/afero"
+)
+
+const (
+	// largeFilesWalkTimeout bounds the time spent looking for large files before the synchronization starts
+	largeFilesWalkTimeout = 5 * time.Second
+)
+
+var (
+	// largeFilesWalkLimit bounds the number of entries visited looking for large files
+	largeFilesWalkLimit = 100000
+
+	errLargeFilesWalkLimit = errors.New("large files check limit reached")
+)
+
+// largeFile is a file of a synchronized folder above the large files threshold
+type largeFile struct {
+	path        string
+	category    string
+	size        int64
+	autoIgnored bool
+}
+
+// checkLargeFiles warns about the files of a synchronized folder above the large files threshold that aren't excluded by the ignore lines,
+// since they are the main cause of slow initial synchronizations.
+// It returns the .stignore lines excluding the large files of the categories set in 'sync.largeFiles.autoIgnore'
+func checkLargeFiles(fs afero.Fs, localPath string, lines []string, config *model.SyncLargeFiles) []string {
+	files, err := findLargeFiles(fs, localPath, lines, config)
+	if err != nil {
+		oktetoLog.Infof("failed to check large files in '%s': %s", localPath, err)
+		return nil
+	}
+	if len(files) == 0 {
+		return nil
+	}
+
+	result := []string{}
+	warnings := []string{}
+	for _, f := range files {
+		if f.autoIgnored {
+			oktetoLog.Information("'%s' (%s) won't be synchronized: '%s' files are ignored by 'sync.largeFiles.autoIgnore'", f.path, units.BytesSize(float64(f.size)), f.category)
+			result = append(result, "/"+f.path)
+			continue
+		}
+		warnings = append(warnings, fmt.Sprintf("    - %s (%s)", f.path, units.BytesSize(float64(f.size))))
+	}
+	if len(warnings) > 0 {
+		oktetoLog.Warning(`The following files of '%s' are larger than %s and will slow down the file synchronization:
+%s
+    Add them to your '.stignore' file or set 'sync.largeFiles.autoIgnore' in your okteto manifest`, localPath, units.BytesSize(float64(config.GetThreshold())), strings.Join(warnings, "\n"))
+	}
+	return result
+}
+
+// findLargeFiles returns the files of localPath above the threshold that aren't excluded by the .stignore lines, sorted by size
+func findLargeFiles(fs afero.Fs, localPath string, lines []string, config *model.SyncLargeFiles) ([]largeFile, error) {
+	matcher, err := patternmatcher.New(getStignoreMatcherPatterns(lines))
+	if err != nil {
+		return nil, err
+	}
+	threshold := config.GetThreshold()
+
+	result := []largeFile{}
+	deadline := time.Now().Add(largeFilesWalkTimeout)
+	visited := 0
+	err = afero.Walk(fs, localPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		visited++
+		if visited > largeFilesWalkLimit || time.Now().After(deadline) {
+			return errLargeFilesWalkLimit
+		}
+		rel, err := filepath.Rel(localPath, path)
+		if err != nil || rel == "." {
+			return err
+		}
+		if info.IsDir() && info.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		ignored, err := matcher.MatchesOrParentMatches(rel)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			// ignored folders are skipped unless some of their files could be included back
+			if ignored && !matcher.Exclusions() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if ignored || !info.Mode().IsRegular() || info.Size() <= threshold {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		result = append(result, largeFile{
+			path:        rel,
+			size:        info.Size(),
+			category:    model.GetLargeFileCategory(rel),
+			autoIgnored: config.IsAutoIgnored(rel),
+		})
+		return nil
+	})
+	if errors.Is(err, errLargeFilesWalkLimit) {
+		// the check is best effort, the large files found so far are still reported
+		oktetoLog.Infof("stopped checking large files in '%s' after %d entries", localPath, visited-1)
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].size > result[j].size
+	})
+	return result, nil
+}
+
+// getStignoreMatcherPatterns translates .stignore lines to the .dockerignore syntax. Patterns not anchored to the folder root match at any depth
+func getStignoreMatcherPatterns(lines []string) []string {
+	result := []string{}
+	for _, line := range lines {
+		prefix := ""
+		if strings.HasPrefix(line, "!") {
+			prefix = "!"
+			line = strings.TrimPrefix(line, "!")
+		}
+		for strings.HasPrefix(line, "(?d)") || strings.HasPrefix(line, "(?i)") {
+			line = line[len("(?d)"):]
+		}
+		line = filepath.ToSlash(strings.TrimSpace(line))
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(line, "/"):
+			line = strings.TrimPrefix(line, "/")
+		case !strings.HasPrefix(line, "**/"):
+			line = "**/" + line
+		}
+		result = append(result, prefix+line)
+	}
+	return result
+}
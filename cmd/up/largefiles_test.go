@@ -0,0 +1,95 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func Test_getStignoreMatcherPatterns(t *testing.T) {
+	lines := []string{"(?d)node_modules", "/build", "!(?i)build/keep.tar", "**/*.log", "# comment"}
+	expected := []string{"**/node_modules", "build", "!**/build/keep.tar", "**/*.log"}
+	assert.Equal(t, expected, getStignoreMatcherPatterns(lines))
+}
+
+func Test_findLargeFiles(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	localPath := filepath.Join("/", "project")
+	files := map[string]int{
+		"main.go":                    10,
+		"dist/app.tar":               200,
+		"data/dump.sql":              150,
+		"node_modules/pkg/big.zip":   500,
+		"build/keep.tar":             300,
+		"build/ignored.bin":          400,
+		"assets/logo.png":            120,
+		"assets/under-threshold.gz":  100,
+		".git/objects/pack/big.pack": 600,
+	}
+	for name, size := range files {
+		require.NoError(t, afero.WriteFile(fs, filepath.Join(localPath, name), make([]byte, size), 0600))
+	}
+
+	config := &model.SyncLargeFiles{
+		Threshold:  &model.Quantity{Value: resource.MustParse("100")},
+		AutoIgnore: []string{"databases"},
+	}
+	result, err := findLargeFiles(fs, localPath, []string{"node_modules", "/build", "!/build/keep.tar"}, config)
+	require.NoError(t, err)
+
+	expected := []largeFile{
+		{path: "build/keep.tar", size: 300, category: "archives"},
+		{path: "dist/app.tar", size: 200, category: "archives"},
+		{path: "data/dump.sql", size: 150, category: "databases", autoIgnored: true},
+		{path: "assets/logo.png", size: 120},
+	}
+	assert.Equal(t, expected, result)
+}
+
+func Test_findLargeFilesWalkLimit(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	localPath := filepath.Join("/", "project")
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(localPath, "a.tar"), make([]byte, 200), 0600))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(localPath, "b.tar"), make([]byte, 300), 0600))
+
+	defaultLimit := largeFilesWalkLimit
+	largeFilesWalkLimit = 2
+	defer func() { largeFilesWalkLimit = defaultLimit }()
+
+	config := &model.SyncLargeFiles{Threshold: &model.Quantity{Value: resource.MustParse("100")}}
+	result, err := findLargeFiles(fs, localPath, nil, config)
+	require.NoError(t, err)
+	assert.Equal(t, []largeFile{{path: "a.tar", size: 200, category: "archives"}}, result)
+}
+
+func Test_checkLargeFiles(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	localPath := filepath.Join("/", "project")
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(localPath, "data", "dump.sql"), make([]byte, 200), 0600))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(localPath, "app.tar"), make([]byte, 200), 0600))
+
+	config := &model.SyncLargeFiles{
+		Threshold:  &model.Quantity{Value: resource.MustParse("100")},
+		AutoIgnore: []string{"databases"},
+	}
+	assert.Equal(t, []string{"/data/dump.sql"}, checkLargeFiles(fs, localPath, nil, config))
+	assert.Empty(t, checkLargeFiles(fs, localPath, nil, nil))
+}
@@ -0,0 +1,173 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/cmd/daemon"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+const manifestWatchInterval = 3 * time.Second
+
+// errDevManifestChanged is returned to reactivate the development container with the changes of the okteto manifest
+var errDevManifestChanged = errors.New("the okteto manifest has changed")
+
+// getManifestChanges returns the fields of the development container that changed and require to reactivate it
+func getManifestChanges(current, updated *model.Dev) []string {
+	changes := []string{}
+	if getDevImageName(current) != getDevImageName(updated) {
+		changes = append(changes, "image")
+	}
+	if !reflect.DeepEqual(current.Sync.Folders, updated.Sync.Folders) {
+		changes = append(changes, "sync")
+	}
+	if !reflect.DeepEqual(current.Forward, updated.Forward) {
+		changes = append(changes, "forward")
+	}
+	if !reflect.DeepEqual(current.Reverse, updated.Reverse) {
+		changes = append(changes, "reverse")
+	}
+	return changes
+}
+
+func getDevImageName(dev *model.Dev) string {
+	if dev.Image == nil {
+		return ""
+	}
+	return dev.Image.Name
+}
+
+// loadDevFromManifest reads the current definition of the development container from the manifest file
+func (up *upContext) loadDevFromManifest() (*model.Dev, error) {
+	manifest, err := model.GetManifestV2(up.Options.ManifestPath)
+	if err != nil {
+		return nil, err
+	}
+	for name, dev := range manifest.Dev {
+		if name != up.Dev.Name && dev.Name != up.Dev.Name {
+			continue
+		}
+		dev.Name = up.Dev.Name
+		dev.Namespace = up.Dev.Namespace
+		dev.Context = up.Dev.Context
+		if len(up.Options.commandToExecute) > 0 {
+			dev.Command.Values = up.Options.commandToExecute
+		}
+		if err := dev.PreparePathsAndExpandEnvFiles(manifest.ManifestPath); err != nil {
+			return nil, err
+		}
+		return dev, nil
+	}
+	return nil, fmt.Errorf("'%s' is not defined in your okteto manifest", up.Dev.Name)
+}
+
+// watchManifest reactivates the development container when its definition changes in the manifest file
+func (up *upContext) watchManifest(ctx context.Context) {
+	if up.Options == nil || up.Options.ManifestPath == "" {
+		return
+	}
+	if info, err := os.Stat(up.Options.ManifestPath); err != nil || info.IsDir() {
+		return
+	}
+
+	lastHash := getFileHash(up.Options.ManifestPath)
+	baseline, err := up.loadDevFromManifest()
+	if err != nil {
+		oktetoLog.Infof("manifest watcher disabled: %s", err)
+		return
+	}
+
+	ticker := time.NewTicker(manifestWatchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			hash := getFileHash(up.Options.ManifestPath)
+			if hash == "" || hash == lastHash {
+				continue
+			}
+			lastHash = hash
+
+			updated, err := up.loadDevFromManifest()
+			if err != nil {
+				oktetoLog.Infof("error reading the updated okteto manifest: %s", err)
+				continue
+			}
+			changes := getManifestChanges(baseline, updated)
+			if len(changes) == 0 {
+				oktetoLog.Infof("okteto manifest changed without changes in the development container")
+				continue
+			}
+
+			oktetoLog.Infof("okteto manifest changed: %s", strings.Join(changes, ", "))
+			up.pendingDev = updated
+			up.pendingDevChanges = changes
+			select {
+			case up.Disconnect <- errDevManifestChanged:
+			case <-ctx.Done():
+			}
+			return
+		}
+	}
+}
+
+// applyManifestChanges asks the user to apply the changes of the okteto manifest detected by watchManifest
+func (up *upContext) applyManifestChanges() {
+	dev, changes := up.pendingDev, up.pendingDevChanges
+	up.pendingDev, up.pendingDevChanges = nil, nil
+	if dev == nil {
+		return
+	}
+
+	oktetoLog.Information("The '%s' fields of your okteto manifest have changed", strings.Join(changes, "', '"))
+	if up.isTerm && !daemon.IsDaemon() {
+		apply, err := utils.AskYesNo("Do you want to apply the changes to your development container?", utils.YesNoDefault_Yes)
+		if err != nil {
+			oktetoLog.Infof("error asking to apply the manifest changes: %s", err)
+			return
+		}
+		if !apply {
+			oktetoLog.Information("Keeping the current configuration of your development container. Run 'okteto up' again to apply the changes")
+			return
+		}
+	}
+
+	if err := prepareDev(dev, up.Options, up.getSyncTempDir); err != nil {
+		oktetoLog.Warning("Could not apply the changes of your okteto manifest: %s", err)
+		return
+	}
+	up.Dev = dev
+}
+
+// getFileHash returns the hash of the content of a file, or an empty string if it can't be read
+func getFileHash(path string) string {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(b))
+}
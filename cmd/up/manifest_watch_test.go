@@ -0,0 +1,109 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_getManifestChanges(t *testing.T) {
+	newDev := func() *model.Dev {
+		return &model.Dev{
+			Image:   &build.Info{Name: "okteto/golang:1"},
+			Sync:    model.Sync{Folders: []model.SyncFolder{{LocalPath: "/src", RemotePath: "/app"}}},
+			Forward: []forward.Forward{{Local: 8080, Remote: 8080}},
+		}
+	}
+
+	tests := []struct {
+		update   func(*model.Dev)
+		name     string
+		expected []string
+	}{
+		{
+			name:     "no changes",
+			update:   func(*model.Dev) {},
+			expected: []string{},
+		},
+		{
+			name: "image and forwards",
+			update: func(d *model.Dev) {
+				d.Image.Name = "okteto/golang:2"
+				d.Forward = append(d.Forward, forward.Forward{Local: 9229, Remote: 9229})
+			},
+			expected: []string{"image", "forward"},
+		},
+		{
+			name: "sync and reverse",
+			update: func(d *model.Dev) {
+				d.Sync.Folders[0].RemotePath = "/usr/src/app"
+				d.Reverse = []model.Reverse{{Local: 5000, Remote: 5000}}
+			},
+			expected: []string{"sync", "reverse"},
+		},
+		{
+			name: "image removed",
+			update: func(d *model.Dev) {
+				d.Image = nil
+			},
+			expected: []string{"image"},
+		},
+		{
+			name: "other fields are ignored",
+			update: func(d *model.Dev) {
+				d.Workdir = "/other"
+			},
+			expected: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated := newDev()
+			tt.update(updated)
+			assert.Equal(t, tt.expected, getManifestChanges(newDev(), updated))
+		})
+	}
+}
+
+func Test_getFileHash(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "okteto.yml")
+	assert.Empty(t, getFileHash(path))
+
+	require.NoError(t, os.WriteFile(path, []byte("dev: {}"), 0600))
+	hash := getFileHash(path)
+	assert.NotEmpty(t, hash)
+	assert.Equal(t, hash, getFileHash(path))
+
+	require.NoError(t, os.WriteFile(path, []byte("dev: {api: {}}"), 0600))
+	assert.NotEqual(t, hash, getFileHash(path))
+}
+
+func Test_applyManifestChangesWithoutPendingChanges(t *testing.T) {
+	dev := &model.Dev{Name: "api"}
+	up := &upContext{Dev: dev}
+	up.applyManifestChanges()
+	assert.Same(t, dev, up.Dev)
+}
+
+func Test_getReconnectReasonManifest(t *testing.T) {
+	assert.Equal(t, reconnectReasonManifest, getReconnectReason(errDevManifestChanged))
+}
@@ -0,0 +1,340 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/cmd/daemon"
+	"github.com/okteto/okteto/pkg/config"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+const (
+	// sessionLogName is the prefix of the messages of the process running several development containers
+	sessionLogName = "okteto"
+
+	readinessInterval = 1 * time.Second
+)
+
+// getDevsFromManifest returns the development containers of the manifest for the given names
+func getDevsFromManifest(manifest *model.Manifest, names []string) ([]*model.Dev, error) {
+	devs := []*model.Dev{}
+	for _, name := range names {
+		dev, err := utils.GetDevFromManifest(manifest, name)
+		if err != nil {
+			return nil, err
+		}
+		if err := dev.PreparePathsAndExpandEnvFiles(manifest.ManifestPath); err != nil {
+			return nil, fmt.Errorf("error in 'dev' section of your manifest: %w", err)
+		}
+		devs = append(devs, dev)
+	}
+	return devs, nil
+}
+
+// validateMultipleOptions returns an error if the options can't be applied to several development containers
+func validateMultipleOptions(upOptions *UpOptions) error {
+	if len(upOptions.commandToExecute) > 0 {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("the flag '--command' is not supported when activating several development containers"),
+			Hint: "Run 'okteto exec <service>' to run commands in each development container",
+		}
+	}
+	if upOptions.Remote > 0 {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("the flag '--remote' is not supported when activating several development containers"),
+			Hint: "Set the 'remote' field in the dev section of each development container instead",
+		}
+	}
+	return nil
+}
+
+// upMultiple builds the images of the manifest and activates several development containers
+func upMultiple(ctx context.Context, manifest *model.Manifest, upOptions *UpOptions, builder builderInterface) error {
+	devs, err := getDevsFromManifest(manifest, upOptions.DevNames)
+	if err != nil {
+		return err
+	}
+
+	if err := buildServicesAndSetBuildEnvs(ctx, manifest, builder); err != nil {
+		return err
+	}
+
+	if err := installSyncthing(); err != nil {
+		return err
+	}
+
+	if upOptions.Detach && !daemon.IsDaemon() {
+		for _, dev := range devs {
+			if err := runDetached(ctx, dev, upOptions); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return runMultiple(ctx, manifest, devs, upOptions)
+}
+
+// prefixedWriter writes every line of the output of a development container with its name as prefix
+type prefixedWriter struct {
+	out    io.Writer
+	mu     *sync.Mutex
+	prefix string
+	buf    []byte
+}
+
+func newPrefixedWriter(out io.Writer, mu *sync.Mutex, name string, width int) *prefixedWriter {
+	return &prefixedWriter{
+		out:    out,
+		mu:     mu,
+		prefix: fmt.Sprintf("%-*s | ", width, name),
+	}
+}
+
+// Write writes the complete lines of p and keeps the last partial line until it is completed
+func (w *prefixedWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		idx := bytes.IndexByte(w.buf, '\n')
+		if idx < 0 {
+			break
+		}
+		if err := w.writeLine(w.buf[:idx+1]); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[idx+1:]
+	}
+	return len(p), nil
+}
+
+// Flush writes the pending partial line
+func (w *prefixedWriter) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	line := append(w.buf, '\n')
+	w.buf = nil
+	return w.writeLine(line)
+}
+
+// Printf writes a message of the process running several development containers
+func (w *prefixedWriter) Printf(format string, args ...interface{}) {
+	if err := w.writeLine([]byte(fmt.Sprintf(format, args...) + "\n")); err != nil {
+		oktetoLog.Infof("failed to write message: %s", err)
+	}
+}
+
+func (w *prefixedWriter) writeLine(line []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, err := fmt.Fprintf(w.out, "%s%s", w.prefix, line)
+	return err
+}
+
+// stateChange represents a new state of a development container
+type stateChange struct {
+	name      string
+	state     config.UpState
+	lastError string
+}
+
+// processExit represents the end of the 'okteto up' process of a development container
+type processExit struct {
+	err  error
+	name string
+}
+
+// readinessTracker keeps the last known state of every development container of the session
+type readinessTracker struct {
+	getStateInfo func(devName, devNamespace string) (*config.StateInfo, error)
+	states       map[string]config.UpState
+	devs         []*model.Dev
+}
+
+func newReadinessTracker(devs []*model.Dev, getStateInfo func(devName, devNamespace string) (*config.StateInfo, error)) *readinessTracker {
+	return &readinessTracker{
+		devs:         devs,
+		states:       map[string]config.UpState{},
+		getStateInfo: getStateInfo,
+	}
+}
+
+// refresh reads the state of every development container and returns the ones that changed
+func (t *readinessTracker) refresh() []stateChange {
+	changes := []stateChange{}
+	for _, dev := range t.devs {
+		info, err := t.getStateInfo(dev.Name, dev.Namespace)
+		if err != nil {
+			// the state file is not created until the development container starts the activation
+			continue
+		}
+		if t.states[dev.Name] == info.State {
+			continue
+		}
+		t.states[dev.Name] = info.State
+		changes = append(changes, stateChange{name: dev.Name, state: info.State, lastError: info.LastError})
+	}
+	return changes
+}
+
+// ready returns the number of development containers that are ready
+func (t *readinessTracker) ready() int {
+	result := 0
+	for _, dev := range t.devs {
+		if t.states[dev.Name] == config.Ready {
+			result++
+		}
+	}
+	return result
+}
+
+// runMultiple activates several development containers in the same 'okteto up' session.
+// Each development container is run by an 'okteto up' process without terminal whose output is multiplexed with its name as prefix
+func runMultiple(ctx context.Context, manifest *model.Manifest, devs []*model.Dev, upOptions *UpOptions) error {
+	for _, dev := range devs {
+		if err := cleanDaemonState(dev); err != nil {
+			return err
+		}
+	}
+
+	bin, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to get the okteto binary path: %w", err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	names := []string{}
+	width := len(sessionLogName)
+	for _, dev := range devs {
+		names = append(names, dev.Name)
+		if len(dev.Name) > width {
+			width = len(dev.Name)
+		}
+	}
+
+	session := &daemon.Session{
+		Name:      manifest.Name,
+		Namespace: manifest.Namespace,
+		Services:  names,
+		PID:       os.Getpid(),
+		StartedAt: time.Now(),
+	}
+	if err := daemon.WriteSession(session); err != nil {
+		return err
+	}
+	defer func() {
+		if err := daemon.DeleteSession(session.Namespace, session.Name); err != nil {
+			oktetoLog.Infof("failed to delete session file: %s", err)
+		}
+	}()
+
+	mu := &sync.Mutex{}
+	sessionWriter := newPrefixedWriter(os.Stdout, mu, sessionLogName, width)
+	exited := make(chan processExit, len(devs))
+	processes := []*daemon.State{}
+	for _, dev := range devs {
+		w := newPrefixedWriter(os.Stdout, mu, dev.Name, width)
+		cmd := exec.Command(bin, getDaemonArgs(dev, upOptions)...)
+		cmd.Dir = wd
+		cmd.Env = append(os.Environ(), fmt.Sprintf("%s=true", daemon.EnvVar))
+		cmd.Stdout = w
+		cmd.Stderr = w
+		if err := cmd.Start(); err != nil {
+			stopProcesses(processes)
+			return fmt.Errorf("failed to start 'okteto up' for '%s': %w", dev.Name, err)
+		}
+		oktetoLog.Infof("started process %d for '%s'", cmd.Process.Pid, dev.Name)
+		processes = append(processes, &daemon.State{Name: dev.Name, Namespace: dev.Namespace, PID: cmd.Process.Pid, Executable: daemon.GetExecutable()})
+
+		go func(name string) {
+			err := cmd.Wait()
+			if err := w.Flush(); err != nil {
+				oktetoLog.Infof("failed to flush the output of '%s': %s", name, err)
+			}
+			exited <- processExit{name: name, err: err}
+		}(dev.Name)
+	}
+	sessionWriter.Printf("Activating %d development containers: %v", len(devs), names)
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(stop)
+
+	tracker := newReadinessTracker(devs, config.GetStateInfo)
+	ticker := time.NewTicker(readinessInterval)
+	defer ticker.Stop()
+	allReady := false
+	for {
+		select {
+		case <-ctx.Done():
+			stopProcesses(processes)
+			return ctx.Err()
+		case <-stop:
+			oktetoLog.Infof("CTRL+C received, starting shutdown sequence")
+			sessionWriter.Printf("Stopping the development containers...")
+			stopProcesses(processes)
+			return nil
+		case e := <-exited:
+			stopProcesses(processes)
+			if e.err != nil {
+				return fmt.Errorf("development container '%s' exited: %w", e.name, e.err)
+			}
+			return fmt.Errorf("development container '%s' exited", e.name)
+		case <-ticker.C:
+			for _, change := range tracker.refresh() {
+				if change.state == config.Failed && change.lastError != "" {
+					sessionWriter.Printf("'%s' is %s: %s", change.name, change.state, change.lastError)
+					continue
+				}
+				sessionWriter.Printf("'%s' is %s", change.name, change.state)
+			}
+			ready := tracker.ready()
+			if ready == len(devs) && !allReady {
+				sessionWriter.Printf("All development containers are ready (%d/%d). Run 'okteto exec <service>' to run commands in them", ready, len(devs))
+			}
+			allReady = ready == len(devs)
+		}
+	}
+}
+
+// stopProcesses stops the 'okteto up' processes of the session and waits for them to exit
+func stopProcesses(processes []*daemon.State) {
+	wg := sync.WaitGroup{}
+	for _, p := range processes {
+		wg.Add(1)
+		go func(p *daemon.State) {
+			defer wg.Done()
+			if err := p.Stop(); err != nil {
+				oktetoLog.Infof("failed to stop 'okteto up' for '%s': %s", p.Name, err)
+			}
+		}(p)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,155 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrefixedWriter(t *testing.T) {
+	var out bytes.Buffer
+	mu := &sync.Mutex{}
+	api := newPrefixedWriter(&out, mu, "api", 8)
+	frontend := newPrefixedWriter(&out, mu, "frontend", 8)
+
+	_, err := api.Write([]byte("Activating your development"))
+	require.NoError(t, err)
+	_, err = frontend.Write([]byte("Synchronizing files\nFiles synchronized\n"))
+	require.NoError(t, err)
+	_, err = api.Write([]byte(" container...\nPulling image"))
+	require.NoError(t, err)
+	require.NoError(t, api.Flush())
+	require.NoError(t, frontend.Flush())
+
+	expected := "frontend | Synchronizing files\n" +
+		"frontend | Files synchronized\n" +
+		"api      | Activating your development container...\n" +
+		"api      | Pulling image\n"
+	assert.Equal(t, expected, out.String())
+}
+
+func TestReadinessTracker(t *testing.T) {
+	states := map[string]*config.StateInfo{}
+	getStateInfo := func(devName, _ string) (*config.StateInfo, error) {
+		info, ok := states[devName]
+		if !ok {
+			return nil, errors.New("not found")
+		}
+		return info, nil
+	}
+	tracker := newReadinessTracker([]*model.Dev{{Name: "api"}, {Name: "frontend"}}, getStateInfo)
+
+	assert.Empty(t, tracker.refresh())
+	assert.Equal(t, 0, tracker.ready())
+
+	states["api"] = &config.StateInfo{State: config.Ready}
+	states["frontend"] = &config.StateInfo{State: config.Synchronizing}
+	assert.Equal(t, []stateChange{
+		{name: "api", state: config.Ready},
+		{name: "frontend", state: config.Synchronizing},
+	}, tracker.refresh())
+	assert.Equal(t, 1, tracker.ready())
+
+	states["frontend"] = &config.StateInfo{State: config.Failed, LastError: "pod evicted"}
+	assert.Equal(t, []stateChange{{name: "frontend", state: config.Failed, lastError: "pod evicted"}}, tracker.refresh())
+	assert.Empty(t, tracker.refresh())
+
+	states["frontend"] = &config.StateInfo{State: config.Ready}
+	tracker.refresh()
+	assert.Equal(t, 2, tracker.ready())
+}
+
+func TestAddArgs(t *testing.T) {
+	var tests = []struct {
+		name             string
+		opts             *UpOptions
+		args             []string
+		expectedDevName  string
+		expectedDevNames []string
+		expectErr        bool
+	}{
+		{
+			name: "no args",
+			opts: &UpOptions{},
+		},
+		{
+			name:            "one service",
+			opts:            &UpOptions{},
+			args:            []string{"api"},
+			expectedDevName: "api",
+		},
+		{
+			name:            "repeated service",
+			opts:            &UpOptions{},
+			args:            []string{"api", "api"},
+			expectedDevName: "api",
+		},
+		{
+			name:             "several services",
+			opts:             &UpOptions{},
+			args:             []string{"api", "frontend", "api"},
+			expectedDevNames: []string{"api", "frontend"},
+		},
+		{
+			name:      "several services with command",
+			opts:      &UpOptions{commandToExecute: []string{"bash"}},
+			args:      []string{"api", "frontend"},
+			expectErr: true,
+		},
+		{
+			name:      "several services with remote",
+			opts:      &UpOptions{Remote: 2222},
+			args:      []string{"api", "frontend"},
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.AddArgs(&cobra.Command{}, tt.args)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedDevName, tt.opts.DevName)
+			assert.Equal(t, tt.expectedDevNames, tt.opts.DevNames)
+		})
+	}
+}
+
+func TestGetDevsFromManifest(t *testing.T) {
+	manifest := &model.Manifest{
+		Dev: model.ManifestDevs{
+			"api":      &model.Dev{Name: "api"},
+			"frontend": &model.Dev{Name: "frontend"},
+		},
+	}
+	devs, err := getDevsFromManifest(manifest, []string{"frontend", "api"})
+	require.NoError(t, err)
+	require.Len(t, devs, 2)
+	assert.Equal(t, "frontend", devs[0].Name)
+	assert.Equal(t, "api", devs[1].Name)
+
+	_, err = getDevsFromManifest(manifest, []string{"api", "worker"})
+	assert.Error(t, err)
+}
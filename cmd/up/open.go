@@ -0,0 +1,137 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"runtime"
+	"time"
+
+	okHttp "github.com/okteto/okteto/pkg/http"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/skratchdot/open-golang/open"
+)
+
+const (
+	// openTimeout is the time to wait for an endpoint of the development container before giving up on opening it
+	openTimeout = 5 * time.Minute
+
+	// detachedOpenTimeout is the openTimeout of 'okteto up --detach', which keeps the terminal busy while it waits
+	detachedOpenTimeout = 30 * time.Second
+
+	openProbeTimeout = 5 * time.Second
+)
+
+// urlOpener opens a url in the default browser
+type urlOpener func(url string) error
+
+// getOpenCandidates returns the local urls of the forwarded ports of the development container, in manifest order
+func getOpenCandidates(dev *model.Dev) []string {
+	result := []string{}
+	for _, f := range dev.Forward {
+		if f.Local == 0 {
+			continue
+		}
+		result = append(result, fmt.Sprintf("http://localhost:%d", f.Local))
+	}
+	return result
+}
+
+// waitForEndpoint returns the first url that answers an HTTP request without a server error.
+// It retries every interval until the context is done
+func waitForEndpoint(ctx context.Context, c *http.Client, urls []string, interval time.Duration) (string, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		for _, url := range urls {
+			if isEndpointReady(ctx, c, url) {
+				return url, nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func isEndpointReady(ctx context.Context, c *http.Client, url string) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		oktetoLog.Debugf("endpoint %s is not ready: %s", url, err)
+		return false
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode < http.StatusInternalServerError
+}
+
+// hasDisplay returns if there is a graphical session where a browser can be opened
+func hasDisplay(goos string, getenv func(string) string) bool {
+	switch goos {
+	case "darwin", "windows":
+		return true
+	default:
+		return getenv("DISPLAY") != "" || getenv("WAYLAND_DISPLAY") != ""
+	}
+}
+
+// openURL opens the url in the browser. If there is no display or the browser can't be opened, the url is printed instead
+func openURL(url string, display bool, start urlOpener) bool {
+	if display {
+		err := start(url)
+		if err == nil {
+			oktetoLog.Success("Opened %s in your browser", url)
+			return true
+		}
+		oktetoLog.Infof("failed to open the browser: %s", err)
+	}
+	oktetoLog.Information("Your development container is ready at %s", url)
+	return false
+}
+
+// shouldOpen returns if the endpoint of the development container has to be opened in the browser
+func shouldOpen(dev *model.Dev, upOptions *UpOptions) bool {
+	return upOptions.Open || dev.Open
+}
+
+// openWhenReady waits for the first forwarded endpoint of the development container and opens it in the browser
+func openWhenReady(ctx context.Context, dev *model.Dev) {
+	urls := getOpenCandidates(dev)
+	if len(urls) == 0 {
+		oktetoLog.Warning("There are no forwarded ports to open in the browser. Add a 'forward' section to the dev section of your okteto manifest")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, openTimeout)
+	defer cancel()
+	c := &http.Client{
+		Transport: okHttp.SharedDefaultTransport(),
+		Timeout:   openProbeTimeout,
+	}
+	url, err := waitForEndpoint(ctx, c, urls, readinessInterval)
+	if err != nil {
+		oktetoLog.Infof("no endpoint of the development container was ready: %s", err)
+		return
+	}
+	openURL(url, hasDisplay(runtime.GOOS, os.Getenv), open.Start)
+}
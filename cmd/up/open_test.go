@@ -0,0 +1,126 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetOpenCandidates(t *testing.T) {
+	dev := &model.Dev{
+		Forward: []forward.Forward{
+			{Local: 8080, Remote: 8080},
+			{Local: 0, Remote: 5432, ServiceName: "db"},
+			{Local: 3000, Remote: 80},
+		},
+	}
+	assert.Equal(t, []string{"http://localhost:8080", "http://localhost:3000"}, getOpenCandidates(dev))
+	assert.Empty(t, getOpenCandidates(&model.Dev{}))
+}
+
+func TestWaitForEndpoint(t *testing.T) {
+	calls := 0
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+	starting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer starting.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	url, err := waitForEndpoint(ctx, http.DefaultClient, []string{failing.URL, starting.URL}, 10*time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, starting.URL, url)
+	assert.Equal(t, 2, calls)
+}
+
+func TestWaitForEndpointTimeout(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := waitForEndpoint(ctx, http.DefaultClient, []string{failing.URL}, 10*time.Millisecond)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestHasDisplay(t *testing.T) {
+	env := func(values map[string]string) func(string) string {
+		return func(key string) string {
+			return values[key]
+		}
+	}
+	tests := []struct {
+		env      map[string]string
+		name     string
+		goos     string
+		expected bool
+	}{
+		{name: "darwin", goos: "darwin", expected: true},
+		{name: "windows", goos: "windows", expected: true},
+		{name: "linux without display", goos: "linux"},
+		{name: "linux with x11", goos: "linux", env: map[string]string{"DISPLAY": ":0"}, expected: true},
+		{name: "linux with wayland", goos: "linux", env: map[string]string{"WAYLAND_DISPLAY": "wayland-0"}, expected: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, hasDisplay(tt.goos, env(tt.env)))
+		})
+	}
+}
+
+func TestOpenURL(t *testing.T) {
+	opened := ""
+	start := func(url string) error {
+		opened = url
+		return nil
+	}
+	assert.True(t, openURL("http://localhost:8080", true, start))
+	assert.Equal(t, "http://localhost:8080", opened)
+
+	opened = ""
+	assert.False(t, openURL("http://localhost:8080", false, start))
+	assert.Empty(t, opened)
+
+	failing := func(string) error {
+		return errors.New("no browser")
+	}
+	assert.False(t, openURL("http://localhost:8080", true, failing))
+}
+
+func TestShouldOpen(t *testing.T) {
+	assert.False(t, shouldOpen(&model.Dev{}, &UpOptions{}))
+	assert.True(t, shouldOpen(&model.Dev{}, &UpOptions{Open: true}))
+	assert.True(t, shouldOpen(&model.Dev{Open: true}, &UpOptions{}))
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+
+	"github.com/okteto/okteto/pkg/k8s/deployments"
+	"github.com/okteto/okteto/pkg/k8s/rbac"
+	"github.com/okteto/okteto/pkg/k8s/statefulsets"
+	"github.com/okteto/okteto/pkg/model"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	deploymentsResource  = "deployments"
+	statefulsetsResource = "statefulsets"
+)
+
+// getWorkloadResource returns the resource of the workload of the development container.
+// Like apps.Get, deployments take precedence and they are also the default when no workload exists yet
+func getWorkloadResource(ctx context.Context, dev *model.Dev, c kubernetes.Interface) string {
+	if _, err := deployments.GetByDev(ctx, dev, dev.Namespace, c); err == nil {
+		return deploymentsResource
+	}
+	if _, err := statefulsets.GetByDev(ctx, dev, dev.Namespace, c); err == nil {
+		return statefulsetsResource
+	}
+	return deploymentsResource
+}
+
+// getUpPermissions returns the permissions required to activate the development container of the workload resource
+func getUpPermissions(dev *model.Dev, workload string) []rbac.Permission {
+	readWrite := []string{"get", "create", "update"}
+	result := []rbac.Permission{
+		{Group: "apps", Resource: workload, Verbs: []string{"get", "update"}},
+		{Resource: "pods", Verbs: []string{"get", "list", "watch"}},
+		{Resource: "pods/portforward", Verbs: []string{"create"}},
+		{Resource: "secrets", Verbs: readWrite},
+	}
+	if dev.Autocreate {
+		result[0].Verbs = readWrite
+		result = append(result, rbac.Permission{Resource: "services", Verbs: readWrite})
+	}
+	if dev.PersistentVolumeEnabled() {
+		result = append(result, rbac.Permission{Resource: "persistentvolumeclaims", Verbs: readWrite})
+	}
+	return result
+}
@@ -0,0 +1,59 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/k8s/rbac"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetUpPermissions(t *testing.T) {
+	dev := &model.Dev{PersistentVolumeInfo: &model.PersistentVolumeInfo{Enabled: false}}
+	assert.Equal(t, []rbac.Permission{
+		{Group: "apps", Resource: "deployments", Verbs: []string{"get", "update"}},
+		{Resource: "pods", Verbs: []string{"get", "list", "watch"}},
+		{Resource: "pods/portforward", Verbs: []string{"create"}},
+		{Resource: "secrets", Verbs: []string{"get", "create", "update"}},
+	}, getUpPermissions(dev, deploymentsResource))
+
+	dev = &model.Dev{Autocreate: true}
+	permissions := getUpPermissions(dev, deploymentsResource)
+	assert.Equal(t, []string{"get", "create", "update"}, permissions[0].Verbs)
+	assert.Contains(t, permissions, rbac.Permission{Resource: "services", Verbs: []string{"get", "create", "update"}})
+	assert.Contains(t, permissions, rbac.Permission{Resource: "persistentvolumeclaims", Verbs: []string{"get", "create", "update"}})
+
+	permissions = getUpPermissions(&model.Dev{}, statefulsetsResource)
+	assert.Equal(t, rbac.Permission{Group: "apps", Resource: "statefulsets", Verbs: []string{"get", "update"}}, permissions[0])
+}
+
+func TestGetWorkloadResource(t *testing.T) {
+	dev := &model.Dev{Name: "db", Namespace: "test"}
+	meta := metav1.ObjectMeta{Name: "db", Namespace: "test"}
+
+	c := fake.NewSimpleClientset(&appsv1.StatefulSet{ObjectMeta: meta})
+	assert.Equal(t, statefulsetsResource, getWorkloadResource(context.Background(), dev, c))
+
+	c = fake.NewSimpleClientset(&appsv1.Deployment{ObjectMeta: meta}, &appsv1.StatefulSet{ObjectMeta: meta})
+	assert.Equal(t, deploymentsResource, getWorkloadResource(context.Background(), dev, c))
+
+	c = fake.NewSimpleClientset()
+	assert.Equal(t, deploymentsResource, getWorkloadResource(context.Background(), dev, c))
+}
@@ -14,12 +14,15 @@
 package up
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/fsnotify/fsnotify"
+	"github.com/okteto/okteto/pkg/cmd/daemon"
 	"github.com/okteto/okteto/pkg/config"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
@@ -70,6 +73,32 @@ func newPIDController(ns, dpName string) pidController {
 	}
 }
 
+// StopSession terminates the 'okteto up' process of a development container, if any.
+// The development container is kept active
+func StopSession(ns, dpName string) (bool, error) {
+	pc := newPIDController(ns, dpName)
+	content, err := pc.get()
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(content))
+	if err != nil {
+		return false, fmt.Errorf("invalid PID file '%s': %w", pc.pidFilePath, err)
+	}
+	// the PID file could be stale and its PID reused by another process
+	s := &daemon.State{Name: dpName, Namespace: ns, PID: pid, Executable: daemon.GetExecutable()}
+	if !s.IsRunning() {
+		return false, nil
+	}
+	if err := s.Stop(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // create creates the PID file containing the okteto PID
 func (pc *pidController) create() error {
 	file, err := pc.filesystem.Create(pc.pidFilePath)
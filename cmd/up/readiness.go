@@ -0,0 +1,158 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	k8sExec "github.com/okteto/okteto/pkg/k8s/exec"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	defaultReadinessGateTimeout = 5 * time.Minute
+	readinessGatePollInterval   = 2 * time.Second
+)
+
+// readinessCheck returns nil when a condition of the readiness gate is met
+type readinessCheck func(ctx context.Context) error
+
+// waitForReadinessGate waits until the development container meets the conditions of its readiness gate
+func (up *upContext) waitForReadinessGate(ctx context.Context) error {
+	gate := up.Dev.ReadinessGate
+	if gate == nil {
+		return nil
+	}
+
+	oktetoLog.Spinner("Waiting for your development container to be ready...")
+	oktetoLog.StartSpinner()
+	defer oktetoLog.StopSpinner()
+
+	checks := []readinessCheck{}
+	if gate.Probes {
+		checks = append(checks, up.checkDevContainerProbes)
+	}
+	if len(gate.Command.Values) > 0 {
+		checks = append(checks, up.checkReadinessCommand)
+	}
+
+	timeout := gate.Timeout
+	if timeout == 0 {
+		timeout = defaultReadinessGateTimeout
+	}
+
+	if err := waitForReadinessChecks(ctx, checks, timeout, readinessGatePollInterval); err != nil {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("your development container is not ready: %w", err),
+			Hint: "Check the 'readinessGate' field of your okteto manifest and the status of the services your development container depends on",
+		}
+	}
+	oktetoLog.Success("Development container is ready")
+	return nil
+}
+
+// waitForReadinessChecks runs the checks every interval until all of them pass at the same time
+func waitForReadinessChecks(ctx context.Context, checks []readinessCheck, timeout, interval time.Duration) error {
+	to := time.NewTimer(timeout)
+	defer to.Stop()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		var lastErr error
+		for _, check := range checks {
+			if err := check(ctx); err != nil {
+				lastErr = err
+				break
+			}
+		}
+		if lastErr == nil {
+			return nil
+		}
+		oktetoLog.Infof("development container is not ready yet: %s", lastErr)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-to.C:
+			return fmt.Errorf("timeout after %s: %w", timeout.String(), lastErr)
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkDevContainerProbes checks that the development container passes its readiness and startup probes
+func (up *upContext) checkDevContainerProbes(ctx context.Context) error {
+	k8sClient, _, err := up.K8sClientProvider.Provide(okteto.Context().Cfg)
+	if err != nil {
+		return err
+	}
+	pod, err := k8sClient.CoreV1().Pods(up.Dev.Namespace).Get(ctx, up.Pod.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	return isContainerReady(pod, up.Dev.Container)
+}
+
+func isContainerReady(pod *apiv1.Pod, container string) error {
+	for _, status := range pod.Status.ContainerStatuses {
+		if container != "" && status.Name != container {
+			continue
+		}
+		if status.Started != nil && !*status.Started {
+			return fmt.Errorf("container '%s' has not passed its startup probe", status.Name)
+		}
+		if !status.Ready {
+			return fmt.Errorf("container '%s' has not passed its readiness probe", status.Name)
+		}
+		return nil
+	}
+	return fmt.Errorf("container '%s' not found in pod '%s'", container, pod.Name)
+}
+
+// checkReadinessCommand runs the command of the readiness gate in the development container
+func (up *upContext) checkReadinessCommand(ctx context.Context) error {
+	k8sClient, restConfig, err := up.K8sClientProvider.Provide(okteto.Context().Cfg)
+	if err != nil {
+		return err
+	}
+
+	var out bytes.Buffer
+	err = k8sExec.Exec(
+		ctx,
+		k8sClient,
+		restConfig,
+		up.Dev.Namespace,
+		up.Pod.Name,
+		up.Dev.Container,
+		false,
+		strings.NewReader(""),
+		&out,
+		&out,
+		up.Dev.ReadinessGate.Command.Values,
+	)
+	if err != nil {
+		oktetoLog.Infof("readiness command output: %s", out.String())
+		return fmt.Errorf("command '%s' failed: %w", strings.Join(up.Dev.ReadinessGate.Command.Values, " "), err)
+	}
+	return nil
+}
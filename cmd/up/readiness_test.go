@@ -0,0 +1,117 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+func TestWaitForReadinessChecks(t *testing.T) {
+	attempts := 0
+	eventuallyReady := func(_ context.Context) error {
+		attempts++
+		if attempts < 3 {
+			return assert.AnError
+		}
+		return nil
+	}
+	ready := func(_ context.Context) error {
+		return nil
+	}
+
+	err := waitForReadinessChecks(context.Background(), []readinessCheck{ready, eventuallyReady}, time.Minute, time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+
+	notReady := func(_ context.Context) error {
+		return assert.AnError
+	}
+	err = waitForReadinessChecks(context.Background(), []readinessCheck{ready, notReady}, 10*time.Millisecond, time.Millisecond)
+	assert.ErrorIs(t, err, assert.AnError)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = waitForReadinessChecks(ctx, []readinessCheck{notReady}, time.Minute, time.Minute)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestIsContainerReady(t *testing.T) {
+	started := true
+	notStarted := false
+	tests := []struct {
+		name      string
+		container string
+		statuses  []apiv1.ContainerStatus
+		expectErr bool
+	}{
+		{
+			name:      "ready",
+			container: "dev",
+			statuses: []apiv1.ContainerStatus{
+				{Name: "sidecar", Ready: false},
+				{Name: "dev", Ready: true, Started: &started},
+			},
+		},
+		{
+			name:      "not ready",
+			container: "dev",
+			statuses: []apiv1.ContainerStatus{
+				{Name: "dev", Ready: false, Started: &started},
+			},
+			expectErr: true,
+		},
+		{
+			name:      "not started",
+			container: "dev",
+			statuses: []apiv1.ContainerStatus{
+				{Name: "dev", Ready: true, Started: &notStarted},
+			},
+			expectErr: true,
+		},
+		{
+			name:      "container not found",
+			container: "dev",
+			statuses: []apiv1.ContainerStatus{
+				{Name: "sidecar", Ready: true},
+			},
+			expectErr: true,
+		},
+		{
+			name: "first container when container is empty",
+			statuses: []apiv1.ContainerStatus{
+				{Name: "dev", Ready: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &apiv1.Pod{
+				Status: apiv1.PodStatus{
+					ContainerStatuses: tt.statuses,
+				},
+			}
+			err := isContainerReady(pod, tt.container)
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
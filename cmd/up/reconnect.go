@@ -0,0 +1,118 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"errors"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/retry"
+)
+
+const (
+	initialReconnectWait = 1 * time.Second
+	maxReconnectWait     = 30 * time.Second
+
+	// shutdownWaitTimeout bounds the wait for the shutdown sequence: activate might fail before starting it
+	shutdownWaitTimeout = 10 * time.Second
+
+	reconnectReasonSyncthing = "syncthing"
+	reconnectReasonSSH       = "ssh"
+	reconnectReasonAuth      = "auth"
+	reconnectReasonAPI       = "api"
+	reconnectReasonImage     = "image"
+	reconnectReasonManifest  = "manifest"
+)
+
+// reconnectBackoff computes the time to wait between reconnection attempts to the development container
+type reconnectBackoff struct {
+	policy  retry.Policy
+	attempt int
+}
+
+func newReconnectBackoff() *reconnectBackoff {
+	return &reconnectBackoff{
+		policy: retry.Exponential("reconnect development container", initialReconnectWait, maxReconnectWait).WithJitter(retry.DefaultJitter),
+	}
+}
+
+// next returns the time to wait before the next attempt, doubling it on each attempt up to the maximum
+func (b *reconnectBackoff) next() time.Duration {
+	wait := b.policy.Interval(b.attempt)
+	b.attempt++
+	return wait
+}
+
+// waitForShutdown waits until the shutdown sequence completes or the timeout expires. It returns false on timeout
+func waitForShutdown(completed <-chan bool, timeout time.Duration) bool {
+	t := time.NewTimer(timeout)
+	defer t.Stop()
+	select {
+	case <-completed:
+		return true
+	case <-t.C:
+		return false
+	}
+}
+
+// reset is called once the development container is reconnected
+func (b *reconnectBackoff) reset() {
+	b.attempt = 0
+}
+
+// reconnectEvent represents a reconnection attempt to the development container
+type reconnectEvent struct {
+	reason  string
+	err     error
+	wait    time.Duration
+	attempt int
+}
+
+// getReconnectReason returns the connection that was dropped
+func getReconnectReason(err error) string {
+	switch {
+	case errors.Is(err, errDevImageChanged):
+		return reconnectReasonImage
+	case errors.Is(err, errDevManifestChanged):
+		return reconnectReasonManifest
+	case errors.Is(err, oktetoErrors.ErrLostSyncthing):
+		return reconnectReasonSyncthing
+	case errors.Is(err, oktetoErrors.ErrSSHConnectError):
+		return reconnectReasonSSH
+	case errors.Is(err, okteto.ErrK8sUnauthorised):
+		return reconnectReasonAuth
+	default:
+		return reconnectReasonAPI
+	}
+}
+
+// logReconnectEvent logs the reconnection attempt. Only the first attempt is displayed to the user
+func logReconnectEvent(e reconnectEvent) {
+	oktetoLog.Infof("reconnect event: reason=%s attempt=%d wait=%s error=%v", e.reason, e.attempt, e.wait, e.err)
+	oktetoLog.AddToBuffer(oktetoLog.WarningLevel, "connection lost (%s), reconnecting in %s (attempt %d)", e.reason, e.wait, e.attempt)
+	if e.reason == reconnectReasonImage {
+		oktetoLog.Information("Redeploying your development container with the latest image...")
+		return
+	}
+	if e.reason == reconnectReasonManifest {
+		oktetoLog.Information("Reactivating your development container...")
+		return
+	}
+	if e.attempt == 1 {
+		oktetoLog.Yellow("Connection lost to your development container, reconnecting...")
+	}
+}
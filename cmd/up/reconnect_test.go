@@ -0,0 +1,60 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/retry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconnectBackoff(t *testing.T) {
+	b := newReconnectBackoff()
+	for _, expected := range []time.Duration{1 * time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second, 30 * time.Second, 30 * time.Second} {
+		assert.InEpsilon(t, float64(expected), float64(b.next()), retry.DefaultJitter)
+	}
+	assert.Equal(t, 7, b.attempt)
+
+	b.reset()
+	assert.InEpsilon(t, float64(time.Second), float64(b.next()), retry.DefaultJitter)
+}
+
+func TestGetReconnectReason(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected string
+	}{
+		{err: oktetoErrors.ErrLostSyncthing, expected: reconnectReasonSyncthing},
+		{err: fmt.Errorf("wrapped: %w", oktetoErrors.ErrSSHConnectError), expected: reconnectReasonSSH},
+		{err: okteto.ErrK8sUnauthorised, expected: reconnectReasonAuth},
+		{err: assert.AnError, expected: reconnectReasonAPI},
+		{err: nil, expected: reconnectReasonAPI},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, getReconnectReason(tt.err))
+	}
+}
+
+func TestWaitForShutdown(t *testing.T) {
+	completed := make(chan bool, 1)
+	assert.False(t, waitForShutdown(completed, 10*time.Millisecond))
+
+	completed <- true
+	assert.True(t, waitForShutdown(completed, time.Second))
+}
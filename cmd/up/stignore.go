@@ -27,34 +27,45 @@ import (
 	"github.com/okteto/okteto/cmd/manifest"
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/config"
-	"github.com/okteto/okteto/pkg/env"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/filesystem"
+	"github.com/okteto/okteto/pkg/ignore"
 	"github.com/okteto/okteto/pkg/linguist"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 )
 
-func addStignoreSecrets(dev *model.Dev) error {
+// addStignoreSecrets adds the .stignore of every synchronized folder as a secret of the development container.
+// projectDir is the directory of the okteto manifest, where the .oktetoignore file is read from
+func addStignoreSecrets(dev *model.Dev, projectDir string, fs *filesystem.CrossPlatformFs) error {
+	projectDir, err := filepath.Abs(projectDir)
+	if err != nil {
+		return err
+	}
+	// the rules of the .oktetoignore file at the project root are added to the .stignore of every synchronized folder
+	oktetoIgnore, err := ignore.NewFromFileWithFilesystem(filepath.Join(projectDir, ignore.Filename), fs)
+	if err != nil {
+		return oktetoErrors.UserError{
+			E:    err,
+			Hint: fmt.Sprintf("Fix the syntax of your '%s' file", ignore.Filename),
+		}
+	}
+
 	output := ""
 	for i, folder := range dev.Sync.Folders {
 		stignorePath := filepath.Join(folder.LocalPath, ".stignore")
-		if !filesystem.FileExists(stignorePath) {
-			continue
-		}
-		infile, err := os.Open(stignorePath)
-		if err != nil {
-			return oktetoErrors.UserError{
-				E:    err,
-				Hint: "Update the 'sync' field of your okteto manifest to point to a valid directory path",
+		lines := []string{}
+		if filesystem.FileExists(stignorePath) {
+			lines, err = readStignoreLines(stignorePath)
+			if err != nil {
+				return err
 			}
 		}
-		defer func() {
-			if err := infile.Close(); err != nil {
-				oktetoLog.Debugf("Error closing file %s: %s", stignorePath, err)
-			}
-		}()
-		reader := bufio.NewReader(infile)
+		lines = append(lines, getOktetoIgnoreLines(oktetoIgnore, projectDir, folder.LocalPath)...)
+		lines = append(lines, checkLargeFiles(fs, folder.LocalPath, lines, dev.Sync.LargeFiles)...)
+		if !filesystem.FileExists(stignorePath) && len(lines) == 0 {
+			continue
+		}
 
 		stignoreName := fmt.Sprintf(".stignore-%d", i+1)
 		transformedStignorePath := filepath.Join(config.GetAppHome(dev.Namespace, dev.Name), stignoreName)
@@ -71,21 +82,8 @@ func addStignoreSecrets(dev *model.Dev) error {
 		writer := bufio.NewWriter(outfile)
 		defer writer.Flush()
 
-		for {
-			bytes, _, err := reader.ReadLine()
-			if err != nil {
-				if err == io.EOF {
-					break
-				}
-				return err
-			}
-
-			line := strings.TrimSpace(string(bytes))
-			// ignore local lines that are empty, comments or includes more files
-			// TODO: support remote #include https://github.com/okteto/okteto/issues/2832
-			if strings.Compare(line, "") == 0 || strings.HasPrefix(line, "//") || strings.HasPrefix(line, "#") {
-				continue
-			}
+		for _, line := range lines {
+			line = normalizeStignoreLine(line, fs)
 
 			// transform line by adding (?d) unless the line starts with ! or already has (?d)
 			if !strings.HasPrefix(line, "!") && !strings.Contains(line, "(?d)") {
@@ -113,6 +111,71 @@ func addStignoreSecrets(dev *model.Dev) error {
 	return nil
 }
 
+// readStignoreLines returns the patterns of a local .stignore file
+func readStignoreLines(stignorePath string) ([]string, error) {
+	infile, err := os.Open(stignorePath)
+	if err != nil {
+		return nil, oktetoErrors.UserError{
+			E:    err,
+			Hint: "Update the 'sync' field of your okteto manifest to point to a valid directory path",
+		}
+	}
+	defer func() {
+		if err := infile.Close(); err != nil {
+			oktetoLog.Debugf("Error closing file %s: %s", stignorePath, err)
+		}
+	}()
+	reader := bufio.NewReader(infile)
+
+	lines := []string{}
+	for {
+		bytes, _, err := reader.ReadLine()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+
+		line := strings.TrimSpace(string(bytes))
+		// ignore local lines that are empty, comments or includes more files
+		// TODO: support remote #include https://github.com/okteto/okteto/issues/2832
+		if strings.Compare(line, "") == 0 || strings.HasPrefix(line, "//") || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+// getOktetoIgnoreLines returns the .oktetoignore rules that apply to a synchronized folder
+func getOktetoIgnoreLines(oktetoIgnore *ignore.Ignore, projectDir, localPath string) []string {
+	localPath, err := filepath.Abs(localPath)
+	if err != nil {
+		return nil
+	}
+	folder, err := filepath.Rel(projectDir, localPath)
+	if err != nil || folder == ".." || strings.HasPrefix(folder, ".."+string(filepath.Separator)) {
+		return nil
+	}
+	return oktetoIgnore.SyncthingPatterns(folder)
+}
+
+// normalizeStignoreLine translates a local .stignore pattern so it behaves the same in the remote syncthing, which runs on linux:
+// windows path separators are converted to forward slashes and patterns are marked as case-insensitive if the local filesystem is
+func normalizeStignoreLine(line string, fs *filesystem.CrossPlatformFs) string {
+	prefix := ""
+	if strings.HasPrefix(line, "!") {
+		prefix = "!"
+		line = strings.TrimPrefix(line, "!")
+	}
+	line = filepath.ToSlash(line)
+	if fs.CaseInsensitive && !strings.Contains(line, "(?i)") {
+		line = fmt.Sprintf("(?i)%s", line)
+	}
+	return prefix + line
+}
+
 func addSyncFieldHash(dev *model.Dev) error {
 	output, err := json.Marshal(dev.Sync)
 	if err != nil {
@@ -150,7 +213,7 @@ func checkStignoreConfiguration(dev *model.Dev) error {
 }
 
 func askIfCreateStignoreDefaults(folder, stignorePath string) error {
-	autogenerateStignore := env.LoadBoolean(model.OktetoAutogenerateStignoreEnvVar)
+	autogenerateStignore := config.EnvOktetoAutogenerateStignore.IsTrue()
 
 	oktetoLog.Information("'.stignore' doesn't exist in folder '%s'.", folder)
 
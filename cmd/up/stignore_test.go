@@ -21,7 +21,10 @@ import (
 	"testing"
 
 	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/filesystem"
+	"github.com/okteto/okteto/pkg/ignore"
 	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -85,7 +88,7 @@ func Test_addStignoreSecrets(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			err := addStignoreSecrets(tt.dev)
+			err := addStignoreSecrets(tt.dev, t.TempDir(), &filesystem.CrossPlatformFs{Fs: afero.NewOsFs()})
 			if err == nil && tt.expectedError {
 				t.Fatal("expected Error, but no error")
 			}
@@ -106,3 +109,79 @@ func Test_addStignoreSecrets(t *testing.T) {
 		})
 	}
 }
+
+func Test_normalizeStignoreLine(t *testing.T) {
+	tests := []struct {
+		name            string
+		line            string
+		expected        string
+		caseInsensitive bool
+	}{
+		{
+			name:     "case-sensitive filesystem",
+			line:     "node_modules",
+			expected: "node_modules",
+		},
+		{
+			name:            "case-insensitive filesystem",
+			line:            "Build",
+			caseInsensitive: true,
+			expected:        "(?i)Build",
+		},
+		{
+			name:            "negated pattern in case-insensitive filesystem",
+			line:            "!Build/keep",
+			caseInsensitive: true,
+			expected:        "!(?i)Build/keep",
+		},
+		{
+			name:            "pattern already case-insensitive",
+			line:            "(?i)build",
+			caseInsensitive: true,
+			expected:        "(?i)build",
+		},
+		{
+			name:     "path separators",
+			line:     filepath.Join("build", "out"),
+			expected: "build/out",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := &filesystem.CrossPlatformFs{Fs: afero.NewMemMapFs(), CaseInsensitive: tt.caseInsensitive}
+			assert.Equal(t, tt.expected, normalizeStignoreLine(tt.line, fs))
+		})
+	}
+}
+
+func Test_getOktetoIgnoreLines(t *testing.T) {
+	oktetoIgnore, err := ignore.Parse([]byte("node_modules\napi/dist\n**/.cache\n"))
+	assert.NoError(t, err)
+
+	projectDir := t.TempDir()
+	assert.Equal(t, []string{"/node_modules", "/api/dist", "**/.cache"}, getOktetoIgnoreLines(oktetoIgnore, projectDir, projectDir))
+	assert.Equal(t, []string{"/dist", "**/.cache"}, getOktetoIgnoreLines(oktetoIgnore, projectDir, filepath.Join(projectDir, "api")))
+	assert.Nil(t, getOktetoIgnoreLines(oktetoIgnore, projectDir, filepath.Dir(projectDir)))
+}
+
+func Test_addStignoreSecretsReadsOktetoIgnoreFromProjectDir(t *testing.T) {
+	projectDir := t.TempDir()
+	apiDir := filepath.Join(projectDir, "api")
+	assert.NoError(t, os.Mkdir(apiDir, 0700))
+	assert.NoError(t, os.WriteFile(filepath.Join(projectDir, ignore.Filename), []byte("api/dist\n"), 0600))
+
+	dev := &model.Dev{
+		Name:      "oktetoignore",
+		Namespace: "test-namespace",
+		Sync: model.Sync{
+			Folders: []model.SyncFolder{{LocalPath: apiDir}},
+		},
+		Metadata: &model.Metadata{Annotations: model.Annotations{}},
+	}
+	assert.NoError(t, addStignoreSecrets(dev, projectDir, &filesystem.CrossPlatformFs{Fs: afero.NewOsFs()}))
+
+	file, err := os.ReadFile(filepath.Join(config.GetAppHome(dev.Namespace, dev.Name), ".stignore-1"))
+	assert.NoError(t, err)
+	assert.Equal(t, "(?d)/dist\n", string(file))
+}
@@ -19,6 +19,7 @@ import (
 	"time"
 
 	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/cmd/status"
 	"github.com/okteto/okteto/pkg/config"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
@@ -91,6 +92,7 @@ func (up *upContext) sync(ctx context.Context) error {
 
 	go up.Sy.Monitor(ctx, up.Disconnect)
 	go up.Sy.MonitorStatus(ctx, up.Disconnect)
+	go status.MonitorHistory(ctx, up.Sy, syncthing.GetHistoryFile(up.Dev.Namespace, up.Dev.Name))
 	oktetoLog.Infof("restarting syncthing to update sync mode to sendreceive")
 	return up.Sy.Restart(ctx)
 }
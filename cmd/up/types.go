@@ -19,6 +19,7 @@ import (
 	"time"
 
 	"github.com/moby/term"
+	"github.com/okteto/okteto/cmd/utils/executor"
 	"github.com/okteto/okteto/pkg/analytics"
 	"github.com/okteto/okteto/pkg/k8s/apps"
 	"github.com/okteto/okteto/pkg/model"
@@ -69,18 +70,25 @@ type upContext struct {
 	Manifest              *model.Manifest
 	analyticsMeta         *analytics.UpMetricsMetadata
 	Dev                   *model.Dev
+	pendingDev            *model.Dev
 	GlobalForwarderStatus chan error
 	ShutdownCompleted     chan bool
 	Options               *UpOptions
 	Pod                   *apiv1.Pod
+	pendingDevChanges     []string
 	Cancel                context.CancelFunc
 	pidController         pidController
+	hookExecutor          executor.ManifestExecutor
+	reconnectBackoff      *reconnectBackoff
 	inFd                  uintptr
 	isRetry               bool
 	success               bool
+	postUpHookExecuted    bool
+	imageChecked          bool
 	resetSyncthing        bool
 	isTerm                bool
 	interruptReceived     bool
+	openStarted           bool
 }
 
 // Forwarder is an interface for the port-forwarding features
@@ -89,6 +97,7 @@ type forwarder interface {
 	AddReverse(model.Reverse) error
 	Start(string, string) error
 	StartGlobalForwarding() error
+	StartForwards([]forward.Forward) error
 	Stop()
 	TransformLabelsToServiceName(forward.Forward) (forward.Forward, error)
 }
@@ -35,8 +35,10 @@ import (
 	"github.com/okteto/okteto/cmd/namespace"
 	pipelineCMD "github.com/okteto/okteto/cmd/pipeline"
 	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/cmd/utils/executor"
 	"github.com/okteto/okteto/pkg/analytics"
 	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/cmd/daemon"
 	"github.com/okteto/okteto/pkg/cmd/pipeline"
 	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/constants"
@@ -44,7 +46,9 @@ import (
 	"github.com/okteto/okteto/pkg/discovery"
 	"github.com/okteto/okteto/pkg/env"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/filesystem"
 	"github.com/okteto/okteto/pkg/k8s/apps"
+	"github.com/okteto/okteto/pkg/k8s/rbac"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/log/io"
 	"github.com/okteto/okteto/pkg/model"
@@ -78,25 +82,30 @@ type UpOptions struct {
 	ManifestPathFlag string
 	// ManifestPath is the path to the manifest used though the command execution.
 	// This might change its value during execution
-	ManifestPath     string
-	Namespace        string
-	K8sContext       string
-	DevName          string
+	ManifestPath string
+	Namespace    string
+	K8sContext   string
+	DevName      string
+	// DevNames are the development containers to activate when several are given
+	DevNames         []string
 	Envs             []string
 	commandToExecute []string
 	Remote           int
 	Deploy           bool
 	ForcePull        bool
 	Reset            bool
+	Fresh            bool
+	CheckImage       bool
+	Detach           bool
+	Open             bool
 }
 
 // Up starts a development container
 func Up(at analyticsTrackerInterface, ioCtrl *io.IOController) *cobra.Command {
 	upOptions := &UpOptions{}
 	cmd := &cobra.Command{
-		Use:   "up [service]",
+		Use:   "up [service...]",
 		Short: "Launch your development environment",
-		Args:  utils.MaximumNArgsAccepted(1, "https://okteto.com/docs/reference/cli/#up"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if okteto.InDevContainer() {
 				return oktetoErrors.ErrNotInDevContainer
@@ -129,6 +138,13 @@ func Up(at analyticsTrackerInterface, ioCtrl *io.IOController) *cobra.Command {
 			defer at.TrackUp(upMeta)
 
 			startOkContextConfig := time.Now()
+			if upOptions.ManifestPath == "" {
+				manifestPath, err := utils.DiscoverManifestPath()
+				if err != nil {
+					return err
+				}
+				upOptions.ManifestPath = manifestPath
+			}
 			if upOptions.ManifestPath != "" {
 				// if path is absolute, its transformed to rel from root
 				initialCWD, err := os.Getwd()
@@ -266,6 +282,7 @@ func Up(at analyticsTrackerInterface, ioCtrl *io.IOController) *cobra.Command {
 				K8sClientProvider: okteto.NewK8sClientProvider(),
 				tokenUpdater:      newTokenUpdaterController(),
 				builder:           buildv2.NewBuilderFromScratch(at, ioCtrl),
+				hookExecutor:      executor.NewExecutor(oktetoLog.GetOutputFormat(), false, ""),
 			}
 			up.inFd, up.isTerm = term.GetFdInfo(os.Stdin)
 			if up.isTerm {
@@ -301,6 +318,10 @@ func Up(at analyticsTrackerInterface, ioCtrl *io.IOController) *cobra.Command {
 				oktetoLog.Information("'%s' was already deployed. To redeploy run 'okteto deploy' or 'okteto up --deploy'", up.Manifest.Name)
 			}
 
+			if len(upOptions.DevNames) > 0 {
+				return upMultiple(ctx, oktetoManifest, upOptions, up.builder)
+			}
+
 			dev, err := utils.GetDevFromManifest(oktetoManifest, upOptions.DevName)
 			if err != nil {
 				if !errors.Is(err, utils.ErrNoDevSelected) {
@@ -327,6 +348,10 @@ func Up(at analyticsTrackerInterface, ioCtrl *io.IOController) *cobra.Command {
 				up.Dev.Autocreate = true
 			}
 
+			if err := rbac.Check(ctx, k8sClient, up.Dev.Namespace, getUpPermissions(up.Dev, getWorkloadResource(ctx, up.Dev, k8sClient))); err != nil {
+				return err
+			}
+
 			// only if the context is an okteto one, we should verify if the namespace has to be woken up
 			if okteto.Context().IsOkteto {
 				// We execute it in a goroutine to not impact the command performance
@@ -348,45 +373,19 @@ func Up(at analyticsTrackerInterface, ioCtrl *io.IOController) *cobra.Command {
 				return err
 			}
 
-			if err := loadManifestOverrides(dev, upOptions); err != nil {
+			if err := installSyncthing(); err != nil {
 				return err
 			}
 
-			if syncthing.ShouldUpgrade() {
-				oktetoLog.Println("Installing dependencies...")
-				if err := downloadSyncthing(); err != nil {
-					oktetoLog.Infof("failed to upgrade syncthing: %s", err)
-
-					if !syncthing.IsInstalled() {
-						return fmt.Errorf("couldn't download syncthing, please try again")
-					}
-
-					oktetoLog.Yellow("couldn't upgrade syncthing, will try again later")
-					oktetoLog.Println()
-				} else {
-					oktetoLog.Success("Dependencies successfully installed")
-				}
-			}
-
-			oktetoLog.ConfigureFileLogger(config.GetAppHome(dev.Namespace, dev.Name), config.VersionString)
-
 			if err := checkStignoreConfiguration(dev); err != nil {
 				oktetoLog.Infof("failed to check '.stignore' configuration: %s", err.Error())
 			}
 
-			if err := addStignoreSecrets(dev); err != nil {
+			if err := prepareDev(dev, upOptions, up.getSyncTempDir); err != nil {
 				return err
 			}
 
-			if err := addSyncFieldHash(dev); err != nil {
-				return err
-			}
-
-			if err := setSyncDefaultsByDevMode(dev, up.getSyncTempDir); err != nil {
-				return err
-			}
-
-			if _, ok := os.LookupEnv(model.OktetoAutoDeployEnvVar); ok {
+			if _, ok := config.EnvOktetoAutoDeploy.Lookup(); ok {
 				upOptions.Deploy = true
 			}
 
@@ -396,10 +395,14 @@ func Up(at analyticsTrackerInterface, ioCtrl *io.IOController) *cobra.Command {
     https://www.okteto.com/docs/reference/manifest-migration/`))
 			}
 
+			if upOptions.Detach && !daemon.IsDaemon() {
+				return runDetached(ctx, dev, upOptions)
+			}
+
 			if err = up.start(); err != nil {
 				switch err.(type) {
 				default:
-					return fmt.Errorf("%w\n    Find additional logs at: %s/okteto.log", err, config.GetAppHome(dev.Namespace, dev.Name))
+					return utils.WithLogsHint(err)
 				case oktetoErrors.CommandError:
 					oktetoLog.Infof("CommandError: %v", err)
 					return err
@@ -424,28 +427,54 @@ func Up(at analyticsTrackerInterface, ioCtrl *io.IOController) *cobra.Command {
 		oktetoLog.Infof("failed to mark 'pull' flag as hidden: %s", err)
 	}
 	cmd.Flags().BoolVarP(&upOptions.Reset, "reset", "", false, "reset the file synchronization database")
+	cmd.Flags().BoolVarP(&upOptions.Fresh, "fresh", "", false, "start the development container with an empty shell history")
+	cmd.Flags().BoolVarP(&upOptions.CheckImage, "check-image", "", false, "check if the image of the development container has changed and ask to redeploy it")
+	cmd.Flags().BoolVarP(&upOptions.Detach, "detach", "", false, "run the file synchronization and port forwarding in the background. Use 'okteto attach' to open a terminal")
+	cmd.Flags().BoolVarP(&upOptions.Open, "open", "", false, "open the first forwarded endpoint of the development container in the browser when it's ready")
 	cmd.Flags().StringArrayVarP(&upOptions.commandToExecute, "command", "", []string{}, "external commands to be supplied to 'okteto up'")
 	return cmd
 }
 
-// AddArgs sets the args as options and return err if it's not compatible
-func (o *UpOptions) AddArgs(cmd *cobra.Command, args []string) error {
+// installSyncthing downloads syncthing if it is not installed or there is a new version
+func installSyncthing() error {
+	if !syncthing.ShouldUpgrade() {
+		return nil
+	}
+	oktetoLog.Println("Installing dependencies...")
+	if err := downloadSyncthing(); err != nil {
+		oktetoLog.Infof("failed to upgrade syncthing: %s", err)
 
-	maxV1Args := 1
-	docsURL := "https://okteto.com/docs/reference/cli/#up"
-	if len(args) > maxV1Args {
-		if err := cmd.Help(); err != nil {
-			oktetoLog.Infof("could not show help: %s", err)
+		if !syncthing.IsInstalled() {
+			return err
 		}
 
-		return oktetoErrors.UserError{
-			E:    fmt.Errorf("%q accepts at most %d arg(s), but received %d", cmd.CommandPath(), maxV1Args, len(args)),
-			Hint: fmt.Sprintf("Visit %s for more information.", docsURL),
+		oktetoLog.Yellow("couldn't upgrade syncthing, will try again later")
+		oktetoLog.Println()
+		return nil
+	}
+	oktetoLog.Success("Dependencies successfully installed")
+	return nil
+}
+
+// AddArgs sets the args as options. Several args activate several development containers in the same session
+func (o *UpOptions) AddArgs(_ *cobra.Command, args []string) error {
+	names := []string{}
+	seen := map[string]bool{}
+	for _, arg := range args {
+		if !seen[arg] {
+			seen[arg] = true
+			names = append(names, arg)
 		}
-	} else if len(args) == 1 {
-		o.DevName = args[0]
 	}
 
+	switch len(names) {
+	case 0:
+	case 1:
+		o.DevName = names[0]
+	default:
+		o.DevNames = names
+		return validateMultipleOptions(o)
+	}
 	return nil
 }
 
@@ -490,6 +519,29 @@ func LoadManifestWithInit(ctx context.Context, k8sContext, namespace, devPath st
 	return manifest, nil
 }
 
+// prepareDev applies the command options to the development container and generates its synchronization settings
+func prepareDev(dev *model.Dev, upOptions *UpOptions, getSyncTempDir func() (string, error)) error {
+	if err := loadManifestOverrides(dev, upOptions); err != nil {
+		return err
+	}
+
+	dev.FreshHistory = upOptions.Fresh
+
+	projectDir := "."
+	if upOptions.ManifestPath != "" {
+		projectDir = model.GetWorkdirFromManifestPath(upOptions.ManifestPath)
+	}
+	if err := addStignoreSecrets(dev, projectDir, filesystem.NewCrossPlatformFs(afero.NewOsFs(), ".")); err != nil {
+		return err
+	}
+
+	if err := addSyncFieldHash(dev); err != nil {
+		return err
+	}
+
+	return setSyncDefaultsByDevMode(dev, getSyncTempDir)
+}
+
 func loadManifestOverrides(dev *model.Dev, upOptions *UpOptions) error {
 	if upOptions.Remote > 0 {
 		dev.RemotePort = upOptions.Remote
@@ -621,7 +673,7 @@ func (up *upContext) deployApp(ctx context.Context) error {
 		Duration:               time.Since(startTime),
 		PipelineType:           up.Manifest.Type,
 		DeployType:             "automatic",
-		IsPreview:              os.Getenv(model.OktetoCurrentDeployBelongsToPreview) == "true",
+		IsPreview:              config.EnvOktetoCurrentDeployBelongsToPreview.IsTrue(),
 		HasDependenciesSection: up.Manifest.HasDependenciesSection(),
 		HasBuildSection:        up.Manifest.HasBuildSection(),
 		Err:                    err,
@@ -654,6 +706,19 @@ func (up *upContext) start() error {
 
 	defer up.pidController.delete()
 
+	if daemon.IsDaemon() {
+		// the daemon must survive the terminal that started it
+		signal.Ignore(syscall.SIGHUP)
+		if err := up.writeDaemonState(); err != nil {
+			return err
+		}
+		defer func() {
+			if err := daemon.Delete(up.Dev.Namespace, up.Dev.Name); err != nil {
+				oktetoLog.Infof("failed to delete daemon state file: %s", err)
+			}
+		}()
+	}
+
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
 
@@ -663,6 +728,10 @@ func (up *upContext) start() error {
 	up.analyticsMeta.DevProps(up.Dev)
 	up.analyticsMeta.RepositoryProps(utils.IsOktetoRepo())
 
+	if err := up.runHook(model.PreUpHook); err != nil {
+		return err
+	}
+
 	go up.activateLoop()
 
 	go up.pidController.notifyIfPIDFileChange(pidFileCh)
@@ -693,20 +762,28 @@ func (up *upContext) start() error {
 
 // activateLoop activates the development container in a retry loop
 func (up *upContext) activateLoop() {
-	isTransientError := false
-	t := time.NewTicker(1 * time.Second)
-	iter := 0
-	defer t.Stop()
+	if up.reconnectBackoff == nil {
+		up.reconnectBackoff = newReconnectBackoff()
+	}
+	var lastErr error
 
+	if err := config.DeleteForwardProfilesFile(up.Dev.Name, up.Dev.Namespace); err != nil {
+		oktetoLog.Infof("failed to delete forward profiles file: %s", err)
+	}
 	defer func() {
 		if err := config.DeleteStateFile(up.Dev.Name, up.Dev.Namespace); err != nil {
 			oktetoLog.Infof("failed to delete state file: %s", err)
 		}
+		if err := config.DeleteForwardProfilesFile(up.Dev.Name, up.Dev.Namespace); err != nil {
+			oktetoLog.Infof("failed to delete forward profiles file: %s", err)
+		}
 	}()
 	for {
-		if up.isRetry || isTransientError {
+		if up.isRetry || lastErr != nil {
 			oktetoLog.Infof("waiting for shutdown sequence to finish")
-			<-up.ShutdownCompleted
+			if !waitForShutdown(up.ShutdownCompleted, shutdownWaitTimeout) {
+				oktetoLog.Infof("shutdown sequence didn't finish after %s", shutdownWaitTimeout)
+			}
 			pidFromFile, err := up.pidController.get()
 			if err != nil {
 				oktetoLog.Infof("error getting pid: %w")
@@ -721,23 +798,40 @@ func (up *upContext) activateLoop() {
 				}
 				return
 			}
-			if iter == 0 {
-				oktetoLog.Yellow("Connection lost to your development container, reconnecting...")
+
+			if lastErr != nil {
+				if err := config.RecordStateError(up.Dev.Name, up.Dev.Namespace, lastErr); err != nil {
+					oktetoLog.Infof("failed to record error in state file: %s", err)
+				}
 			}
-			iter++
-			iter = iter % 10
-			if isTransientError {
-				<-t.C
+
+			// only transient errors wait before reconnecting, the rest of disconnections reconnect immediately
+			var wait time.Duration
+			if oktetoErrors.IsTransient(lastErr) {
+				wait = up.reconnectBackoff.next()
+			} else {
+				up.reconnectBackoff.attempt++
 			}
+			logReconnectEvent(reconnectEvent{
+				reason:  getReconnectReason(lastErr),
+				err:     lastErr,
+				wait:    wait,
+				attempt: up.reconnectBackoff.attempt,
+			})
+			time.Sleep(wait)
 		}
 
 		err := up.activate()
+		lastErr = err
 		if err != nil {
 			oktetoLog.Infof("activate failed with: %s", err)
 
-			if err == oktetoErrors.ErrLostSyncthing {
-				isTransientError = false
-				iter = 0
+			if err == oktetoErrors.ErrLostSyncthing || err == errDevImageChanged {
+				continue
+			}
+
+			if errors.Is(err, errDevManifestChanged) {
+				up.applyManifestChanges()
 				continue
 			}
 
@@ -751,10 +845,15 @@ func (up *upContext) activateLoop() {
 			}
 
 			if oktetoErrors.IsTransient(err) {
-				isTransientError = true
 				continue
 			}
 
+			if err := config.RecordStateError(up.Dev.Name, up.Dev.Namespace, err); err != nil {
+				oktetoLog.Infof("failed to record error in state file: %s", err)
+			}
+			if err := config.UpdateStateFile(up.Dev.Name, up.Dev.Namespace, config.Failed); err != nil {
+				oktetoLog.Infof("failed to update state file: %s", err)
+			}
 			up.Exit <- err
 			return
 		}
@@ -14,13 +14,15 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
-	"runtime"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/config"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/selfupdate"
 	"github.com/spf13/cobra"
 )
 
@@ -29,72 +31,79 @@ const (
 	INSTALL_PATH = "/usr/local/bin/okteto"
 )
 
+// updateOptions are the options of the update commands
+type updateOptions struct {
+	channel string
+	check   bool
+}
+
+func (o *updateOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&o.channel, "channel", "", "", "release channel to update from. One of: ['stable', 'beta']. Defaults to the value of OKTETO_UPDATE_CHANNEL or 'stable'")
+	cmd.Flags().BoolVarP(&o.check, "check", "", false, "only check if a new version is available")
+}
+
 // UpdateDeprecated checks if there is a new version available and updates it
 func UpdateDeprecated() *cobra.Command {
-	return &cobra.Command{
+	opts := &updateOptions{}
+	cmd := &cobra.Command{
 		Use:   "update",
 		Short: "Update Okteto CLI version",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			oktetoLog.Warning("'okteto update' is deprecated in favor of 'okteto version update', and will be removed in a future version")
-			currentVersion, err := semver.NewVersion(config.VersionString)
-			if err != nil {
-				return fmt.Errorf("could not retrieve version")
-			}
-
-			if isUpdateAvailable(currentVersion) {
-				displayUpdateSteps()
-			} else {
-				oktetoLog.Success("The latest okteto version is already installed")
-			}
-
-			return nil
+			return runUpdate(cmd.Context(), opts)
 		},
 	}
+	opts.addFlags(cmd)
+	return cmd
 }
 
-// isUpdateAvailable checks if there is a new version available
-func isUpdateAvailable(currentVersion *semver.Version) bool {
-	v, err := utils.GetLatestVersionFromGithub()
+// runUpdate replaces the okteto binary with the latest release of the channel
+func runUpdate(ctx context.Context, opts *updateOptions) error {
+	channel := opts.channel
+	if channel == "" {
+		channel = selfupdate.GetChannel()
+	}
+	if err := selfupdate.ValidateChannel(channel); err != nil {
+		return err
+	}
+
+	currentVersion, err := semver.NewVersion(config.VersionString)
 	if err != nil {
-		oktetoLog.Infof("failed to get latest version from github: %s", err)
-		return false
+		return fmt.Errorf("could not retrieve version")
 	}
 
-	if len(v) > 0 {
-		latest, err := semver.NewVersion(v)
-		if err != nil {
-			oktetoLog.Infof("failed to parse latest version '%s': %s", v, err)
-			return false
-		}
+	latest, err := selfupdate.GetLatestRelease(ctx, channel)
+	if err != nil {
+		return err
+	}
+	if !latest.Version.GreaterThan(currentVersion) {
+		oktetoLog.Success("The latest okteto version is already installed")
+		return nil
+	}
+	oktetoLog.Infof("new version available: %s -> %s", currentVersion.String(), latest.Version)
 
-		if latest.GreaterThan(currentVersion) {
-			oktetoLog.Infof("new version available: %s -> %s", currentVersion.String(), latest)
-			return true
-		}
+	if opts.check {
+		oktetoLog.Information("Okteto %s is available in the '%s' channel. Run 'okteto version update' to install it", latest.Version, channel)
+		return nil
 	}
 
-	return false
-}
+	binPath, err := selfupdate.GetBinaryPath()
+	if err != nil {
+		return err
+	}
+	selfupdate.CleanupOldBinary(binPath)
 
-func displayUpdateSteps() {
-	oktetoLog.Println("You can update okteto with the following:")
-	switch {
-	case runtime.GOOS == "darwin" || runtime.GOOS == "linux":
-		oktetoLog.Print(`
-# Using installation script:
-curl https://get.okteto.com -sSfL | sh`)
-		if runtime.GOOS == "darwin" {
-			oktetoLog.Print(`
+	oktetoLog.Spinner(fmt.Sprintf("Updating okteto to %s...", latest.Version))
+	oktetoLog.StartSpinner()
+	defer oktetoLog.StopSpinner()
 
-# Using brew:
-brew upgrade okteto`)
+	if err := selfupdate.NewUpdater().Update(ctx, latest, binPath); err != nil {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("failed to update okteto: %w", err),
+			Hint: fmt.Sprintf("You can update okteto manually running: %s", utils.GetUpgradeCommand()),
 		}
-	case runtime.GOOS == "windows":
-		oktetoLog.Print(`# Using manual installation:
-1.- Download https://downloads.okteto.com/cli/okteto.exe
-2.- Add downloaded file to your $PATH
-
-# Using scoop:
-scoop update okteto`)
 	}
+
+	oktetoLog.Success("Okteto has been updated to %s", latest.Version)
+	return nil
 }
@@ -17,9 +17,10 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"runtime"
+	"sync"
 
-	"github.com/okteto/okteto/pkg/constants"
-	"github.com/okteto/okteto/pkg/env"
+	"github.com/okteto/okteto/pkg/config"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 )
@@ -33,9 +34,11 @@ type ManifestExecutor interface {
 // Executor implements ManifestExecutor with a executor displayer
 type Executor struct {
 	displayer      executorDisplayer
+	running        *exec.Cmd
 	outputMode     string
 	shell, dir     string
 	runWithoutBash bool
+	mu             sync.Mutex
 }
 
 type executorDisplayer interface {
@@ -60,7 +63,7 @@ func NewExecutor(output string, runWithoutBash bool, dir string) *Executor {
 	}
 
 	shell := "bash"
-	if env.LoadBoolean(constants.OktetoDeployRemote) {
+	if config.EnvOktetoDeployRemote.IsTrue() {
 		shell = "sh"
 	}
 
@@ -95,21 +98,47 @@ func (e *Executor) Execute(cmdInfo model.DeployCommand, env []string) error {
 		return err
 	}
 
+	e.setRunning(cmd)
 	e.displayer.display(cmdInfo.Name)
 
 	err := cmd.Wait()
+	e.setRunning(nil)
 
 	e.CleanUp(err)
 	return err
 }
 
-// CleanUp cleans the execution lines
+func (e *Executor) setRunning(cmd *exec.Cmd) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.running = cmd
+}
+
+// CleanUp cleans the execution lines and stops the running command, if any
 func (e *Executor) CleanUp(err error) {
+	e.stopRunning()
 	if e.displayer != nil {
 		e.displayer.cleanUp(err)
 	}
 }
 
+// stopRunning stops the running command when the deploy is cancelled
+func (e *Executor) stopRunning() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.running == nil || e.running.Process == nil {
+		return
+	}
+	oktetoLog.Infof("stopping running command")
+	sig := os.Interrupt
+	if runtime.GOOS == "windows" {
+		sig = os.Kill
+	}
+	if err := e.running.Process.Signal(sig); err != nil {
+		oktetoLog.Infof("failed to stop running command: %s", err)
+	}
+}
+
 func startCommand(cmd *exec.Cmd) error {
 	return cmd.Start()
 }
@@ -0,0 +1,38 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"fmt"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+// RunHook executes the commands of a manifest hook with the same stage logging used by the deploy commands
+func RunHook(e ManifestExecutor, hook string, hooks *model.Hooks, env []string) error {
+	for _, command := range hooks.GetCommands(hook) {
+		oktetoLog.Information("Running %s hook '%s'", hook, command.Name)
+		oktetoLog.SetStage(fmt.Sprintf("%s: %s", hook, command.Name))
+		oktetoLog.AddToBuffer(oktetoLog.InfoLevel, "Executing %s hook '%s'...", hook, command.Name)
+
+		if err := e.Execute(command, env); err != nil {
+			oktetoLog.AddToBuffer(oktetoLog.ErrorLevel, "error executing %s hook '%s': %s", hook, command.Name, err.Error())
+			return fmt.Errorf("error executing %s hook '%s': %w", hook, command.Name, err)
+		}
+		oktetoLog.AddToBuffer(oktetoLog.InfoLevel, "%s hook '%s' successfully executed", hook, command.Name)
+		oktetoLog.SetStage("")
+	}
+	return nil
+}
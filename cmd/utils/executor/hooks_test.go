@@ -0,0 +1,65 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeExecutor struct {
+	failOn   string
+	executed []string
+}
+
+func (fe *fakeExecutor) Execute(command model.DeployCommand, _ []string) error {
+	fe.executed = append(fe.executed, command.Name)
+	if command.Name == fe.failOn {
+		return assert.AnError
+	}
+	return nil
+}
+
+func (*fakeExecutor) CleanUp(_ error) {}
+
+func TestRunHook(t *testing.T) {
+	hooks := &model.Hooks{
+		PreDeploy: []model.DeployCommand{
+			{Name: "migrate", Command: "make migrate"},
+			{Name: "seed", Command: "make seed"},
+		},
+		PostDeploy: []model.DeployCommand{
+			{Name: "smoke", Command: "make smoke"},
+		},
+	}
+
+	e := &fakeExecutor{}
+	assert.NoError(t, RunHook(e, model.PreDeployHook, hooks, nil))
+	assert.Equal(t, []string{"migrate", "seed"}, e.executed)
+
+	e = &fakeExecutor{}
+	assert.NoError(t, RunHook(e, model.PreUpHook, hooks, nil))
+	assert.Empty(t, e.executed)
+
+	e = &fakeExecutor{}
+	assert.NoError(t, RunHook(e, model.PreDeployHook, nil, nil))
+	assert.Empty(t, e.executed)
+
+	e = &fakeExecutor{failOn: "migrate"}
+	err := RunHook(e, model.PreDeployHook, hooks, nil)
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Equal(t, []string{"migrate"}, e.executed)
+}
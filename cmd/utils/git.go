@@ -23,9 +23,11 @@ import (
 	"sync/atomic"
 
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/transport"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model/utils"
+	"github.com/okteto/okteto/pkg/repository"
 )
 
 var (
@@ -54,6 +56,66 @@ func GetBranch(path string) (string, error) {
 	return name, nil
 }
 
+// GetDeployedCommitInfo returns the metadata of the commit checked out in path when it's the one being deployed:
+// path must be a checkout of the repository and branch being deployed, and its HEAD must be sha or,
+// if sha is empty, the commit of the branch in the remote as of the last fetch. It returns nil otherwise
+func GetDeployedCommitInfo(path, repositoryURL, branch, sha string) *repository.CommitInfo {
+	currentURL, err := utils.GetRepositoryURL(path)
+	if err != nil {
+		oktetoLog.Infof("commit metadata not available: %s", err)
+		return nil
+	}
+	if !repository.NewRepository(currentURL).IsEqual(repository.NewRepository(repositoryURL)) {
+		return nil
+	}
+	currentBranch, err := GetBranch(path)
+	if err != nil || currentBranch != branch {
+		return nil
+	}
+
+	info, err := repository.NewRepository(path).GetCommitInfo()
+	if err != nil {
+		oktetoLog.Infof("commit metadata not available: %s", err)
+		return nil
+	}
+	if sha == "" {
+		sha, err = getRemoteBranchSHA(path, repositoryURL, branch)
+		if err != nil {
+			oktetoLog.Infof("commit metadata not available: %s", err)
+			return nil
+		}
+	}
+	if info.Sha != sha {
+		oktetoLog.Infof("commit metadata not available: the local commit '%s' is not the deployed commit '%s'", info.Sha, sha)
+		return nil
+	}
+	return &info
+}
+
+// getRemoteBranchSHA returns the commit of the branch in the remote of the repository, as of the last fetch
+func getRemoteBranchSHA(path, repositoryURL, branch string) (string, error) {
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to analyze git repo: %w", err)
+	}
+	remotes, err := repo.Remotes()
+	if err != nil {
+		return "", fmt.Errorf("failed to get git repo's remote information: %w", err)
+	}
+	for _, remote := range remotes {
+		cfg := remote.Config()
+		if len(cfg.URLs) == 0 || !repository.NewRepository(cfg.URLs[0]).IsEqual(repository.NewRepository(repositoryURL)) {
+			continue
+		}
+		ref, err := repo.Reference(plumbing.NewRemoteReferenceName(cfg.Name, branch), true)
+		if err != nil {
+			continue
+		}
+		return ref.Hash().String(), nil
+	}
+	return "", fmt.Errorf("branch '%s' of '%s' not found in the local repository", branch, repositoryURL)
+}
+
 // GetRandomSHA returns a random sha generated in the fly
 func GetRandomSHA() string {
 	var letters = []rune("0123456789abcdef")
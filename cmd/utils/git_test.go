@@ -21,9 +21,11 @@ import (
 	"time"
 
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_getBranch(t *testing.T) {
@@ -103,6 +105,52 @@ func Test_getBranch(t *testing.T) {
 	}
 }
 
+func TestGetDeployedCommitInfo(t *testing.T) {
+	dir := t.TempDir()
+	repoURL := "https://github.com/okteto/movies"
+
+	r, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	_, err = r.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{repoURL}})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "okteto.yml"), []byte("deploy: []"), 0600))
+	w, err := r.Worktree()
+	require.NoError(t, err)
+	_, err = w.Add("okteto.yml")
+	require.NoError(t, err)
+	when := time.Date(2023, time.October, 10, 12, 0, 0, 0, time.UTC)
+	first, err := w.Commit("Add manifest", &git.CommitOptions{Author: &object.Signature{Name: "Cindy Lopez", Email: "cindy@okteto.com", When: when}})
+	require.NoError(t, err)
+	second, err := w.Commit("Empty commit", &git.CommitOptions{Author: &object.Signature{Name: "Cindy Lopez", Email: "cindy@okteto.com", When: when}, AllowEmptyCommits: true})
+	require.NoError(t, err)
+	head, err := r.Head()
+	require.NoError(t, err)
+	branch := head.Name().Short()
+
+	// the branch is not in the remote
+	assert.Nil(t, GetDeployedCommitInfo(dir, repoURL, branch, ""))
+
+	// HEAD is the commit of the branch in the remote
+	require.NoError(t, r.Storer.SetReference(plumbing.NewHashReference(plumbing.NewRemoteReferenceName("origin", branch), second)))
+	info := GetDeployedCommitInfo(dir, repoURL, branch, "")
+	require.NotNil(t, info)
+	assert.Equal(t, second.String(), info.Sha)
+	assert.Equal(t, "Cindy Lopez", info.Author)
+	assert.Equal(t, "Empty commit", info.Message)
+
+	// HEAD is ahead of the branch in the remote
+	require.NoError(t, r.Storer.SetReference(plumbing.NewHashReference(plumbing.NewRemoteReferenceName("origin", branch), first)))
+	assert.Nil(t, GetDeployedCommitInfo(dir, repoURL, branch, ""))
+
+	// the deployed sha is given
+	assert.NotNil(t, GetDeployedCommitInfo(dir, repoURL, branch, second.String()))
+	assert.Nil(t, GetDeployedCommitInfo(dir, repoURL, branch, first.String()))
+
+	// other repository or branch
+	assert.Nil(t, GetDeployedCommitInfo(dir, "https://github.com/okteto/other", branch, second.String()))
+	assert.Nil(t, GetDeployedCommitInfo(dir, repoURL, "other", second.String()))
+}
+
 func Test_isOktetoRepoFromURL(t *testing.T) {
 	var tests = []struct {
 		name     string
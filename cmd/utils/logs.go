@@ -0,0 +1,29 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"fmt"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// WithLogsHint adds the path of the log file of the current command run to an error
+func WithLogsHint(err error) error {
+	logPath := oktetoLog.GetInvocationLogPath()
+	if logPath == "" {
+		return err
+	}
+	return fmt.Errorf("%w\n    Find additional logs at: %s", err, logPath)
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/okteto/okteto/pkg/discovery"
+	"github.com/okteto/okteto/pkg/k8s/labels"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/afero"
+)
+
+// ManifestDiscovery looks for okteto manifests around the working directory when no manifest is given
+type ManifestDiscovery struct {
+	Fs          afero.Fs
+	Selector    OktetoSelectorInterface
+	Interactive bool
+}
+
+// NewManifestDiscovery returns a manifest discovery for the current terminal
+func NewManifestDiscovery() *ManifestDiscovery {
+	return &ManifestDiscovery{
+		Fs:          afero.NewOsFs(),
+		Selector:    NewOktetoSelector("Select the okteto manifest to use:", "Manifest"),
+		Interactive: oktetoLog.IsInteractive(),
+	}
+}
+
+// DiscoverManifestPath returns the okteto manifest to use from the current working directory when the flag '--file' isn't set
+func DiscoverManifestPath() (string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get the current working directory: %w", err)
+	}
+	return NewManifestDiscovery().Discover(cwd)
+}
+
+// Discover returns the path, relative to cwd, of the okteto manifest to use when the working directory doesn't have any manifest.
+// If several manifests are found the user is prompted to choose one, or the closest one is picked in non-interactive mode.
+// It returns an empty path when the working directory has a manifest or when no manifest is found around it
+func (md *ManifestDiscovery) Discover(cwd string) (string, error) {
+	if discovery.FindManifestNameWithFilesystem(cwd, md.Fs) != "" {
+		return "", nil
+	}
+
+	candidates := []string{}
+	for _, c := range discovery.FindOktetoManifests(cwd, md.Fs) {
+		rel, err := filepath.Rel(cwd, c)
+		if err != nil {
+			return "", err
+		}
+		candidates = append(candidates, rel)
+	}
+
+	switch {
+	case len(candidates) == 0:
+		return "", nil
+	case len(candidates) == 1:
+		oktetoLog.Information("Using okteto manifest '%s'", candidates[0])
+		return candidates[0], nil
+	case !md.Interactive:
+		oktetoLog.Information("Found %d okteto manifests, using '%s'. Use the flag '--file' to select a different one", len(candidates), candidates[0])
+		return candidates[0], nil
+	}
+
+	items := make([]SelectorItem, 0, len(candidates))
+	for _, c := range candidates {
+		items = append(items, SelectorItem{
+			Name:   c,
+			Label:  c,
+			Enable: true,
+		})
+	}
+	selected, err := md.Selector.AskForOptionsOkteto(items, 0)
+	if err != nil {
+		return "", fmt.Errorf("failed to select the okteto manifest: %w", err)
+	}
+	return selected, nil
+}
+
+// AddManifestLabelsPolicy adds the labels and annotations of the manifest metadata section to every object created by okteto
+func AddManifestLabelsPolicy(manifest *model.Manifest) {
+	if manifest == nil || manifest.Metadata == nil {
+		return
+	}
+	labels.AddPolicy(manifest.Metadata.Labels, manifest.Metadata.Annotations)
+}
@@ -0,0 +1,98 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManifestDiscovery(t *testing.T) {
+	cwd := filepath.Join("/", "repo")
+	var tests = []struct {
+		selector    *FakeOktetoSelector
+		name        string
+		expected    string
+		files       []string
+		interactive bool
+		expectedErr bool
+	}{
+		{
+			name:     "manifest in the working directory",
+			files:    []string{"okteto.yml", "api/okteto.yml"},
+			expected: "",
+		},
+		{
+			name:     "compose file in the working directory",
+			files:    []string{"docker-compose.yml", "api/okteto.yml"},
+			expected: "",
+		},
+		{
+			name:     "no manifests",
+			files:    []string{"README.md"},
+			expected: "",
+		},
+		{
+			name:     "single manifest in a subdirectory",
+			files:    []string{"api/okteto.yml"},
+			expected: filepath.Join("api", "okteto.yml"),
+		},
+		{
+			name:     "several manifests in non-interactive mode picks the closest",
+			files:    []string{"services/web/okteto.yml", "api/okteto.yml"},
+			expected: filepath.Join("api", "okteto.yml"),
+		},
+		{
+			name:        "several manifests in interactive mode prompts",
+			files:       []string{"services/web/okteto.yml", "api/okteto.yml"},
+			interactive: true,
+			selector:    &FakeOktetoSelector{dev: filepath.Join("services", "web", "okteto.yml")},
+			expected:    filepath.Join("services", "web", "okteto.yml"),
+		},
+		{
+			name:        "selector error",
+			files:       []string{"services/web/okteto.yml", "api/okteto.yml"},
+			interactive: true,
+			selector:    &FakeOktetoSelector{err: errors.New("interrupted")},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			for _, f := range tt.files {
+				require.NoError(t, afero.WriteFile(fs, filepath.Join(cwd, f), []byte(""), 0600))
+			}
+			md := &ManifestDiscovery{
+				Fs:          fs,
+				Selector:    tt.selector,
+				Interactive: tt.interactive,
+			}
+
+			result, err := md.Discover(cwd)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -17,8 +17,7 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/okteto/okteto/pkg/constants"
-	"github.com/okteto/okteto/pkg/env"
+	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/types"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -51,7 +50,7 @@ func HasAccessToOktetoClusterNamespace(ctx context.Context, namespace string, ok
 
 	// added possibility to point a context to a preview environment (namespace)
 	// https://github.com/okteto/okteto/pull/2018
-	previewList, err := oktetoClient.Previews().List(ctx, []string{})
+	previewList, err := oktetoClient.Previews().List(ctx, types.PreviewListOptions{})
 	if err != nil {
 		return false, err
 	}
@@ -84,7 +83,7 @@ func ShouldCreateNamespaceStateless(ctx context.Context, ns string, c *okteto.Ok
 		return false, err
 	}
 	if !hasAccess {
-		if env.LoadBoolean(constants.OktetoWithinDeployCommandContextEnvVar) {
+		if config.EnvOktetoWithinDeployCommandContext.IsTrue() {
 			return false, fmt.Errorf("cannot deploy on a namespace that doesn't exist. Please create %s and try again", ns)
 		}
 		create, err := AskYesNo(fmt.Sprintf("The namespace %s doesn't exist. Do you want to create it?", ns), YesNoDefault_Yes)
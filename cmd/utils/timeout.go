@@ -0,0 +1,91 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// timeoutGracePeriod is the time given to a command to tear down after its context is cancelled
+const timeoutGracePeriod = 30 * time.Second
+
+// CommandTimeout cancels the context of a command when the global --timeout expires.
+// If the command doesn't finish after a grace period, the process exits
+type CommandTimeout struct {
+	cancel  context.CancelFunc
+	exit    func(code int)
+	timer   *time.Timer
+	timeout time.Duration
+	grace   time.Duration
+	expired atomic.Bool
+}
+
+// NewCommandTimeout returns a timeout that cancels the context with cancel
+func NewCommandTimeout(cancel context.CancelFunc) *CommandTimeout {
+	return &CommandTimeout{
+		cancel: cancel,
+		exit:   os.Exit,
+		grace:  timeoutGracePeriod,
+	}
+}
+
+// Start starts the timer of the timeout. Zero means no timeout
+func (t *CommandTimeout) Start(timeout time.Duration) {
+	if timeout <= 0 {
+		return
+	}
+	t.timeout = timeout
+	t.timer = time.AfterFunc(timeout, t.expire)
+}
+
+// Stop stops the timer of the timeout
+func (t *CommandTimeout) Stop() {
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+}
+
+// Expired returns if the timeout expired
+func (t *CommandTimeout) Expired() bool {
+	return t.expired.Load()
+}
+
+// WrapError sets the timeout exit code to the error returned by the command if the timeout expired
+func (t *CommandTimeout) WrapError(err error) error {
+	if err == nil || !t.Expired() {
+		return err
+	}
+	return oktetoErrors.ExitCodeError{
+		E:    fmt.Errorf("command timed out after %s: %w", t.timeout, err),
+		Code: oktetoErrors.ExitCodeTimeout,
+	}
+}
+
+func (t *CommandTimeout) expire() {
+	t.expired.Store(true)
+	oktetoLog.Warning("The command timed out after %s, cancelling the running operations...", t.timeout)
+	t.cancel()
+
+	time.AfterFunc(t.grace, func() {
+		oktetoLog.Fail("The command didn't finish %s after being cancelled", t.grace)
+		t.exit(oktetoErrors.ExitCodeTimeout)
+	})
+}
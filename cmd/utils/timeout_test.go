@@ -0,0 +1,69 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	exitCode := make(chan int, 1)
+	timeout := NewCommandTimeout(cancel)
+	timeout.grace = 10 * time.Millisecond
+	timeout.exit = func(code int) {
+		exitCode <- code
+	}
+
+	err := errors.New("deploy failed")
+	assert.Equal(t, err, timeout.WrapError(err))
+
+	timeout.Start(10 * time.Millisecond)
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("context not cancelled")
+	}
+	assert.True(t, timeout.Expired())
+
+	wrapped := timeout.WrapError(err)
+	require.Error(t, wrapped)
+	assert.ErrorIs(t, wrapped, err)
+	assert.Equal(t, oktetoErrors.ExitCodeTimeout, oktetoErrors.GetExitCode(wrapped))
+	assert.NoError(t, timeout.WrapError(nil))
+
+	select {
+	case code := <-exitCode:
+		assert.Equal(t, oktetoErrors.ExitCodeTimeout, code)
+	case <-time.After(5 * time.Second):
+		t.Fatal("process didn't exit after the grace period")
+	}
+}
+
+func TestCommandTimeoutDisabled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	timeout := NewCommandTimeout(cancel)
+	timeout.Start(0)
+	timeout.Stop()
+	assert.NoError(t, ctx.Err())
+	assert.False(t, timeout.Expired())
+}
@@ -0,0 +1,44 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/okteto/okteto/pkg/cmd/login"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// RefreshTokenWithBrowser asks the user to log in again when the token of an okteto context is no longer valid
+func RefreshTokenWithBrowser(ctx context.Context, contextName, _ string) (string, error) {
+	oktetoLog.StopSpinner()
+
+	oktetoLog.Warning("Your session in '%s' has expired", contextName)
+	relogin, err := AskYesNo("Do you want to log in again?", YesNoDefault_Yes)
+	if err != nil {
+		return "", err
+	}
+	if !relogin {
+		return "", fmt.Errorf(oktetoErrors.ErrNotLogged, contextName)
+	}
+
+	user, err := login.WithBrowser(ctx, contextName)
+	if err != nil {
+		return "", err
+	}
+	oktetoLog.Success("Logged in as %s", user.ExternalID)
+	return user.Token, nil
+}
@@ -17,14 +17,20 @@ import (
 	"context"
 	"fmt"
 	"runtime"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/google/go-github/github"
 	"github.com/okteto/okteto/pkg/config"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/selfupdate"
 )
 
 func UpgradeAvailable() string {
+	if !selfupdate.ShouldCheck(time.Now()) {
+		return ""
+	}
+
 	current, err := semver.NewVersion(config.VersionString)
 	if err != nil {
 		return ""
@@ -0,0 +1,139 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vars
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/compose-spec/godotenv"
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/env"
+	"github.com/okteto/okteto/pkg/filesystem"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/output"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+const dotEnvFile = ".env"
+
+type resolveFlags struct {
+	manifestPath string
+	namespace    string
+	k8sContext   string
+	output       string
+	variables    []string
+	showValues   bool
+}
+
+// Resolve shows the value of each variable and the source it is taken from
+func Resolve(ctx context.Context) *cobra.Command {
+	flags := &resolveFlags{}
+	cmd := &cobra.Command{
+		Use:   "resolve",
+		Short: "Show the variables of your development environment and where their values come from",
+		Long: `Show the variables of your development environment and where their values come from.
+
+Variables are resolved with the following precedence: flags > environment > .env file > manifest defaults > okteto platform variables`,
+		Args: utils.NoArgsAccepted(""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manifest, err := contextCMD.LoadManifestWithContext(ctx, contextCMD.ManifestOptions{Filename: flags.manifestPath, Namespace: flags.namespace, K8sContext: flags.k8sContext})
+			if err != nil {
+				return err
+			}
+
+			dotEnv := map[string]string{}
+			if filesystem.FileExists(dotEnvFile) {
+				dotEnv, err = godotenv.Read(dotEnvFile)
+				if err != nil {
+					return fmt.Errorf("error reading %s file: %w", dotEnvFile, err)
+				}
+			}
+
+			var secrets []types.Secret
+			if okteto.IsOkteto() {
+				c, err := okteto.NewOktetoClient()
+				if err != nil {
+					return err
+				}
+				secrets, err = c.User().GetUserSecrets(ctx)
+				if err != nil {
+					return fmt.Errorf("error getting okteto platform variables: %w", err)
+				}
+			}
+
+			r, err := getResolver(manifest, flags.variables, dotEnv, secrets)
+			if err != nil {
+				return err
+			}
+			return printVars(r.Resolve(), flags.output, flags.showValues, os.Stdout)
+		},
+	}
+	cmd.Flags().StringVarP(&flags.manifestPath, "file", "f", "", "path to the okteto manifest file")
+	cmd.Flags().StringVarP(&flags.namespace, "namespace", "n", "", "overwrites the namespace where the development environment is deployed")
+	cmd.Flags().StringVarP(&flags.k8sContext, "context", "c", "", "context where the development environment is deployed")
+	cmd.Flags().StringArrayVarP(&flags.variables, "var", "v", []string{}, "set a variable (can be set more than once)")
+	output.AddFlag(cmd, &flags.output)
+	cmd.Flags().BoolVar(&flags.showValues, "show-values", false, "show the value of the variables")
+	return cmd
+}
+
+func getResolver(manifest *model.Manifest, variables []string, dotEnv map[string]string, secrets []types.Secret) (*env.Resolver, error) {
+	r := env.NewResolver()
+	r.DotEnv = dotEnv
+	r.Manifest = manifest.Variables
+	for _, v := range variables {
+		name, value, found := strings.Cut(v, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid variable value '%s': must follow KEY=VALUE format", v)
+		}
+		r.Flags = append(r.Flags, env.Var{Name: name, Value: value})
+	}
+	for _, s := range secrets {
+		r.Platform = append(r.Platform, env.Var{Name: s.Name, Value: s.Value})
+	}
+	return r, nil
+}
+
+func printVars(vars []env.ResolvedVar, outputFormat string, showValues bool, w io.Writer) error {
+	if !showValues {
+		for i := range vars {
+			vars[i].Value = ""
+		}
+	}
+
+	return output.Print(w, outputFormat, vars, func(w io.Writer) error {
+		tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+		if showValues {
+			fmt.Fprintln(tw, "Name\tSource\tValue")
+		} else {
+			fmt.Fprintln(tw, "Name\tSource")
+		}
+		for _, v := range vars {
+			if showValues {
+				fmt.Fprintf(tw, "%s\t%s\t%s\n", v.Name, v.Source, v.Value)
+			} else {
+				fmt.Fprintf(tw, "%s\t%s\n", v.Name, v.Source)
+			}
+		}
+		return tw.Flush()
+	})
+}
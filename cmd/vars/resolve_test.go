@@ -0,0 +1,64 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vars
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/env"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetResolver(t *testing.T) {
+	manifest := &model.Manifest{
+		Variables: env.Environment{
+			{Name: "REGION", Value: "eu"},
+		},
+	}
+	r, err := getResolver(manifest, []string{"TOKEN=abc"}, map[string]string{"DEBUG": "true"}, []types.Secret{{Name: "API_KEY", Value: "secret"}})
+	require.NoError(t, err)
+	assert.Equal(t, []env.Var{{Name: "TOKEN", Value: "abc"}}, r.Flags)
+	assert.Equal(t, map[string]string{"DEBUG": "true"}, r.DotEnv)
+	assert.Equal(t, manifest.Variables, r.Manifest)
+	assert.Equal(t, []env.Var{{Name: "API_KEY", Value: "secret"}}, r.Platform)
+
+	_, err = getResolver(manifest, []string{"TOKEN"}, nil, nil)
+	assert.Error(t, err)
+}
+
+func TestPrintVars(t *testing.T) {
+	vars := func() []env.ResolvedVar {
+		return []env.ResolvedVar{
+			{Name: "API_KEY", Value: "secret", Source: env.SourcePlatform},
+		}
+	}
+
+	var b bytes.Buffer
+	require.NoError(t, printVars(vars(), "json", false, &b))
+	assert.NotContains(t, b.String(), "secret")
+	var result []env.ResolvedVar
+	require.NoError(t, json.Unmarshal(b.Bytes(), &result))
+	assert.Equal(t, []env.ResolvedVar{{Name: "API_KEY", Source: env.SourcePlatform}}, result)
+
+	b.Reset()
+	require.NoError(t, printVars(vars(), "", true, &b))
+	assert.Contains(t, b.String(), "secret")
+
+	assert.Error(t, printVars(vars(), "xml", false, &b))
+}
@@ -14,23 +14,32 @@
 package cmd
 
 import (
-	"fmt"
+	"os"
 
-	"github.com/Masterminds/semver/v3"
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/config"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/output"
 	"github.com/spf13/cobra"
 )
 
 // Version returns information about the binary
 func Version() *cobra.Command {
+	opts := &versionCheckOptions{}
 	cmd := &cobra.Command{
 		Use:   "version",
 		Short: "View the version of the okteto binary",
 		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#version"),
-		RunE:  Show().RunE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.check {
+				return runVersionCheck(cmd.Context(), opts, os.Stdout)
+			}
+			return Show().RunE(cmd, args)
+		},
 	}
+	cmd.Flags().BoolVarP(&opts.check, "check", "", false, "check the compatibility with the okteto server and the deprecated features used by your manifest")
+	output.AddFlag(cmd, &opts.output)
+	cmd.Flags().StringVarP(&opts.manifestPath, "file", "f", "", "path to the okteto manifest file")
 	cmd.AddCommand(Update())
 	cmd.AddCommand(Show())
 	return cmd
@@ -38,24 +47,16 @@ func Version() *cobra.Command {
 
 // Update checks if there is a new version available and updates it
 func Update() *cobra.Command {
-	return &cobra.Command{
+	opts := &updateOptions{}
+	cmd := &cobra.Command{
 		Use:   "update",
 		Short: "Update Okteto CLI version",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			currentVersion, err := semver.NewVersion(config.VersionString)
-			if err != nil {
-				return fmt.Errorf("could not retrieve version")
-			}
-
-			if isUpdateAvailable(currentVersion) {
-				displayUpdateSteps()
-			} else {
-				oktetoLog.Success("The latest okteto version is already installed")
-			}
-
-			return nil
+			return runUpdate(cmd.Context(), opts)
 		},
 	}
+	opts.addFlags(cmd)
+	return cmd
 }
 
 // Show shows the current Okteto CLI version
@@ -0,0 +1,148 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/Masterminds/semver/v3"
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/pkg/config"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/output"
+	"github.com/okteto/okteto/pkg/types"
+)
+
+var errIncompatibleVersion = errors.New("the okteto cli version is not supported by the okteto server")
+
+type versionCheckOptions struct {
+	output       string
+	manifestPath string
+	check        bool
+}
+
+// versionCheck represents the compatibility of the cli with the server and the manifest
+type versionCheck struct {
+	Client        string              `json:"client" yaml:"client"`
+	Server        string              `json:"server,omitempty" yaml:"server,omitempty"`
+	MinimumClient string              `json:"minimumClient,omitempty" yaml:"minimumClient,omitempty"`
+	Deprecations  []model.Deprecation `json:"deprecations" yaml:"deprecations"`
+	Compatible    bool                `json:"compatible" yaml:"compatible"`
+}
+
+func runVersionCheck(ctx context.Context, opts *versionCheckOptions, w io.Writer) error {
+	if opts.output != "" {
+		// logs would break the json and yaml outputs
+		prev := oktetoLog.GetOutputFormat()
+		oktetoLog.SetOutputFormat(oktetoLog.SilentFormat)
+		defer oktetoLog.SetOutputFormat(prev)
+	}
+
+	if err := contextCMD.NewContextCommand().Run(ctx, &contextCMD.ContextOptions{AllowOffline: true}); err != nil {
+		return err
+	}
+
+	metadata := types.ClusterMetadata{}
+	switch {
+	case okteto.IsOkteto() && okteto.Context().IsOffline:
+		// the server version is unknown, only the manifest deprecations are checked
+		oktetoLog.Infof("skipping the okteto server version check: the okteto API is not responding")
+	case okteto.IsOkteto():
+		c, err := okteto.NewOktetoClient()
+		if err != nil {
+			return err
+		}
+		metadata, err = c.User().GetClusterMetadata(ctx, okteto.Context().Namespace)
+		if err != nil {
+			return fmt.Errorf("failed to get the okteto server version: %w", err)
+		}
+	}
+
+	deprecations := []model.Deprecation{}
+	manifest, err := model.GetManifestV2(opts.manifestPath)
+	switch {
+	case err == nil:
+		deprecations = manifest.GetDeprecations()
+	case opts.manifestPath != "":
+		return err
+	default:
+		oktetoLog.Infof("skipping manifest deprecations: %s", err)
+	}
+
+	check := newVersionCheck(config.VersionString, metadata, deprecations)
+	if err := printVersionCheck(check, opts.output, w); err != nil {
+		return err
+	}
+	if !check.Compatible {
+		return oktetoErrors.UserError{
+			E:    errIncompatibleVersion,
+			Hint: fmt.Sprintf("Run 'okteto version update' to update to a version equal or greater than %s", check.MinimumClient),
+		}
+	}
+	return nil
+}
+
+func newVersionCheck(clientVersion string, metadata types.ClusterMetadata, deprecations []model.Deprecation) *versionCheck {
+	check := &versionCheck{
+		Client:        clientVersion,
+		Server:        metadata.ServerVersion,
+		MinimumClient: metadata.MinimumCLIVersion,
+		Deprecations:  deprecations,
+		Compatible:    true,
+	}
+	if check.MinimumClient == "" {
+		return check
+	}
+
+	minimum, err := semver.NewVersion(check.MinimumClient)
+	if err != nil {
+		oktetoLog.Infof("invalid minimum cli version '%s': %s", check.MinimumClient, err)
+		return check
+	}
+	current, err := semver.NewVersion(clientVersion)
+	if err != nil {
+		// development builds don't have a valid version
+		oktetoLog.Infof("invalid cli version '%s': %s", clientVersion, err)
+		return check
+	}
+	check.Compatible = !current.LessThan(minimum)
+	return check
+}
+
+func printVersionCheck(check *versionCheck, outputFormat string, w io.Writer) error {
+	return output.Print(w, outputFormat, check, func(w io.Writer) error {
+		tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+		fmt.Fprintf(tw, "Client version:\t%s\n", check.Client)
+		if check.Server != "" {
+			fmt.Fprintf(tw, "Server version:\t%s\n", check.Server)
+		}
+		if check.MinimumClient != "" {
+			fmt.Fprintf(tw, "Minimum client version:\t%s\n", check.MinimumClient)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+		for _, d := range check.Deprecations {
+			fmt.Fprintf(w, "Warning: the field '%s' is deprecated and will be removed in a future version. Use '%s' instead\n", d.Field, d.Replacement)
+		}
+		return nil
+	})
+}
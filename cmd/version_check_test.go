@@ -0,0 +1,66 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_newVersionCheck(t *testing.T) {
+	tests := []struct {
+		name       string
+		client     string
+		minimum    string
+		compatible bool
+	}{
+		{name: "no-minimum", client: "2.14.0", compatible: true},
+		{name: "greater", client: "2.15.1", minimum: "2.14.0", compatible: true},
+		{name: "equal", client: "2.14.0", minimum: "2.14.0", compatible: true},
+		{name: "lower", client: "2.13.2", minimum: "2.14.0", compatible: false},
+		{name: "dev-build", client: "", minimum: "2.14.0", compatible: true},
+		{name: "invalid-minimum", client: "2.13.2", minimum: "latest", compatible: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := newVersionCheck(tt.client, types.ClusterMetadata{MinimumCLIVersion: tt.minimum}, nil)
+			assert.Equal(t, tt.compatible, check.Compatible)
+		})
+	}
+}
+
+func Test_printVersionCheck(t *testing.T) {
+	check := newVersionCheck("2.13.2", types.ClusterMetadata{ServerVersion: "1.12.0", MinimumCLIVersion: "2.14.0"}, []model.Deprecation{
+		{Field: "dev.api.healthchecks", Replacement: "probes"},
+	})
+
+	var b bytes.Buffer
+	require.NoError(t, printVersionCheck(check, "json", &b))
+	result := &versionCheck{}
+	require.NoError(t, json.Unmarshal(b.Bytes(), result))
+	assert.Equal(t, check, result)
+
+	b.Reset()
+	require.NoError(t, printVersionCheck(check, "", &b))
+	assert.Contains(t, b.String(), "Server version:")
+	assert.Contains(t, b.String(), "'dev.api.healthchecks' is deprecated")
+
+	assert.Error(t, printVersionCheck(check, "xml", &b))
+}
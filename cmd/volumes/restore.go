@@ -0,0 +1,182 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package volumes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/volumes"
+	"github.com/okteto/okteto/pkg/k8s/volumesnapshots"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/cobra"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+)
+
+// RestoreOptions represents the options to restore a snapshot of persistent volumes
+type RestoreOptions struct {
+	Snapshot        string
+	Namespace       string
+	TargetNamespace string
+	Timeout         time.Duration
+}
+
+func restore(ctx context.Context) *cobra.Command {
+	opts := &RestoreOptions{}
+	cmd := &cobra.Command{
+		Use:   "restore <snapshot>",
+		Short: "Recreate the persistent volumes of a development environment from a snapshot",
+		Long: `Recreate the persistent volumes of a development environment from a snapshot.
+
+The persistent volume claims are created with the content of the snapshot taken with 'okteto volumes snapshot'. They must not exist: run 'okteto destroy --volumes' first to restore them into the same namespace.
+Use the '--target-namespace' flag to clone the snapshot into a fresh development environment in another namespace. Cloning a snapshot requires permissions to create volume snapshot contents in the cluster.
+Run 'okteto deploy' afterwards: the development environment will use the restored persistent volume claims.`,
+		Args: utils.ExactArgsAccepted(1, ""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctxResource := &model.ContextResource{}
+			if err := ctxResource.UpdateNamespace(opts.Namespace); err != nil {
+				return err
+			}
+
+			ctxOptions := &contextCMD.ContextOptions{
+				Namespace: ctxResource.Namespace,
+				Show:      true,
+			}
+			if err := contextCMD.NewContextCommand().Run(ctx, ctxOptions); err != nil {
+				return err
+			}
+
+			opts.Snapshot = args[0]
+			if opts.Namespace == "" {
+				opts.Namespace = okteto.Context().Namespace
+			}
+			if opts.TargetNamespace == "" {
+				opts.TargetNamespace = opts.Namespace
+			}
+
+			restored, err := NewCommand().Restore(ctx, opts)
+			if err != nil {
+				return err
+			}
+			oktetoLog.Success("%d persistent volumes restored from snapshot '%s' into namespace '%s'", restored, opts.Snapshot, opts.TargetNamespace)
+			oktetoLog.Information("Run 'okteto deploy -n %s' to deploy your development environment with the restored volumes", opts.TargetNamespace)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Namespace, "namespace", "n", "", "namespace where the snapshot was taken (defaults to the current namespace)")
+	cmd.Flags().StringVarP(&opts.TargetNamespace, "target-namespace", "", "", "namespace where the persistent volumes are restored (defaults to the namespace of the snapshot)")
+	cmd.Flags().DurationVarP(&opts.Timeout, "timeout", "t", (5 * time.Minute), "the length of time to wait for the snapshot to be cloned into the target namespace")
+	return cmd
+}
+
+// Restore creates the persistent volume claims of a snapshot and returns how many were created.
+// The snapshot is cloned first when the persistent volume claims are restored into another namespace
+func (vc *Command) Restore(ctx context.Context, opts *RestoreOptions) (int, error) {
+	target := opts.TargetNamespace
+	if target == "" {
+		target = opts.Namespace
+	}
+
+	c, cfg, err := vc.k8sClientProvider.Provide(okteto.Context().Cfg)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load okteto context '%s': %w", okteto.Context().Name, err)
+	}
+	dc, err := vc.dynamicClientProvider(cfg)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load okteto context '%s': %w", okteto.Context().Name, err)
+	}
+
+	vsList, err := volumesnapshots.List(ctx, opts.Snapshot, opts.Namespace, dc)
+	if err != nil {
+		return 0, err
+	}
+	if len(vsList) == 0 {
+		return 0, oktetoErrors.UserError{
+			E:    fmt.Errorf("snapshot '%s' not found in namespace '%s'", opts.Snapshot, opts.Namespace),
+			Hint: "Run 'okteto volumes snapshot' to take a snapshot of the persistent volumes of a development environment",
+		}
+	}
+
+	if target != opts.Namespace {
+		if _, err := c.CoreV1().Namespaces().Get(ctx, target, metav1.GetOptions{}); err != nil {
+			if k8sErrors.IsNotFound(err) {
+				return 0, oktetoErrors.UserError{
+					E:    fmt.Errorf("namespace '%s' not found", target),
+					Hint: fmt.Sprintf("Run 'okteto namespace create %s' to create it", target),
+				}
+			}
+			return 0, err
+		}
+	}
+
+	for _, vs := range vsList {
+		if !vs.ReadyToUse {
+			return 0, fmt.Errorf("the volume snapshot of '%s' is not ready to be restored", vs.PVC.Name)
+		}
+		_, err := c.CoreV1().PersistentVolumeClaims(target).Get(ctx, vs.PVC.Name, metav1.GetOptions{})
+		if err == nil {
+			return 0, oktetoErrors.UserError{
+				E:    fmt.Errorf("persistent volume claim '%s' already exists in namespace '%s'", vs.PVC.Name, target),
+				Hint: "Run 'okteto destroy --volumes' or use the '--target-namespace' flag to restore the snapshot into a fresh development environment",
+			}
+		}
+		if !k8sErrors.IsNotFound(err) {
+			return 0, err
+		}
+	}
+
+	oktetoLog.Spinner(fmt.Sprintf("Restoring snapshot '%s'...", opts.Snapshot))
+	oktetoLog.StartSpinner()
+	defer oktetoLog.StopSpinner()
+
+	if target != opts.Namespace {
+		vsList, err = cloneSnapshot(ctx, opts.Snapshot, vsList, target, opts.Timeout, dc)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	for _, vs := range vsList {
+		if err := volumes.Create(ctx, vs.RestorePVC(), c); err != nil {
+			return 0, fmt.Errorf("failed to restore '%s': %w", vs.PVC.Name, err)
+		}
+		oktetoLog.Infof("persistent volume claim '%s' restored from '%s'", vs.PVC.Name, vs.Name)
+	}
+	return len(vsList), nil
+}
+
+// cloneSnapshot copies the volume snapshots of a snapshot into another namespace and waits until they are ready to be restored
+func cloneSnapshot(ctx context.Context, snapshot string, vsList []*volumesnapshots.VolumeSnapshot, namespace string, timeout time.Duration, dc dynamic.Interface) ([]*volumesnapshots.VolumeSnapshot, error) {
+	result := []*volumesnapshots.VolumeSnapshot{}
+	for _, vs := range vsList {
+		clone, err := volumesnapshots.Clone(ctx, vs, namespace, dc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to clone the volume snapshot of '%s' into namespace '%s': %w", vs.PVC.Name, namespace, err)
+		}
+		result = append(result, clone)
+	}
+	if err := volumesnapshots.WaitUntilReady(ctx, snapshot, namespace, timeout, dc); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
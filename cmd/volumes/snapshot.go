@@ -0,0 +1,142 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package volumes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/format"
+	"github.com/okteto/okteto/pkg/k8s/volumesnapshots"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/cobra"
+)
+
+// SnapshotOptions represents the options to snapshot the volumes of a dev environment
+type SnapshotOptions struct {
+	Name      string
+	Snapshot  string
+	Namespace string
+	Class     string
+	Timeout   time.Duration
+}
+
+func snapshot(ctx context.Context) *cobra.Command {
+	opts := &SnapshotOptions{}
+	cmd := &cobra.Command{
+		Use:   "snapshot <name>",
+		Short: "Take a snapshot of the persistent volumes of a development environment",
+		Long: `Take a snapshot of the persistent volumes of a development environment.
+
+A volume snapshot is created for every persistent volume claim deployed by the development environment, including the ones of its statefulsets and development containers.
+Use 'okteto volumes restore' to recreate the persistent volume claims from the snapshot before deploying a fresh development environment.
+Volume snapshots require the volume snapshot CRDs and a CSI driver that supports them.`,
+		Args: utils.ExactArgsAccepted(1, ""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctxResource := &model.ContextResource{}
+			if err := ctxResource.UpdateNamespace(opts.Namespace); err != nil {
+				return err
+			}
+
+			ctxOptions := &contextCMD.ContextOptions{
+				Namespace: ctxResource.Namespace,
+				Show:      true,
+			}
+			if err := contextCMD.NewContextCommand().Run(ctx, ctxOptions); err != nil {
+				return err
+			}
+
+			opts.Name = args[0]
+			if opts.Namespace == "" {
+				opts.Namespace = okteto.Context().Namespace
+			}
+			if opts.Snapshot == "" {
+				opts.Snapshot = fmt.Sprintf("%s-%s", format.ResourceK8sMetaString(opts.Name), time.Now().UTC().Format("20060102150405"))
+			}
+
+			vsList, err := NewCommand().Snapshot(ctx, opts)
+			if err != nil {
+				return err
+			}
+			oktetoLog.Success("Snapshot '%s' of %d persistent volumes of '%s' created", opts.Snapshot, len(vsList), opts.Name)
+			oktetoLog.Information("Run 'okteto volumes restore %s' to restore them into a fresh development environment", opts.Snapshot)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Snapshot, "snapshot-name", "", "", "name of the snapshot (defaults to <name>-<timestamp>)")
+	cmd.Flags().StringVarP(&opts.Namespace, "namespace", "n", "", "namespace where the development environment is deployed (defaults to the current namespace)")
+	cmd.Flags().StringVarP(&opts.Class, "class", "", "", "volume snapshot class used to take the snapshots (defaults to the default class of the cluster)")
+	cmd.Flags().DurationVarP(&opts.Timeout, "timeout", "t", (5 * time.Minute), "the length of time to wait for the snapshots to be ready")
+	return cmd
+}
+
+// Snapshot takes a volume snapshot of every persistent volume claim of a dev environment and waits until they are ready
+func (vc *Command) Snapshot(ctx context.Context, opts *SnapshotOptions) ([]*volumesnapshots.VolumeSnapshot, error) {
+	c, cfg, err := vc.k8sClientProvider.Provide(okteto.Context().Cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load okteto context '%s': %w", okteto.Context().Name, err)
+	}
+	dc, err := vc.dynamicClientProvider(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load okteto context '%s': %w", okteto.Context().Name, err)
+	}
+
+	pvcs, err := pipeline.ListVolumes(ctx, opts.Name, opts.Namespace, c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list persistent volumes of '%s': %w", opts.Name, err)
+	}
+	if len(pvcs) == 0 {
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("development environment '%s' has no persistent volumes in namespace '%s'", opts.Name, opts.Namespace),
+			Hint: "Run 'okteto deploy' to deploy your development environment before taking a snapshot of its volumes",
+		}
+	}
+
+	existing, err := volumesnapshots.List(ctx, opts.Snapshot, opts.Namespace, dc)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) > 0 {
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("snapshot '%s' already exists", opts.Snapshot),
+			Hint: "Use the '--snapshot-name' flag to choose a different name",
+		}
+	}
+
+	oktetoLog.Spinner(fmt.Sprintf("Taking snapshot of the persistent volumes of '%s'...", opts.Name))
+	oktetoLog.StartSpinner()
+	defer oktetoLog.StopSpinner()
+
+	result := []*volumesnapshots.VolumeSnapshot{}
+	for i := range pvcs {
+		vs, err := volumesnapshots.Create(ctx, opts.Snapshot, &pvcs[i], opts.Class, dc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to take snapshot of '%s': %w", pvcs[i].Name, err)
+		}
+		result = append(result, vs)
+	}
+
+	if err := volumesnapshots.WaitUntilReady(ctx, opts.Snapshot, opts.Namespace, opts.Timeout, dc); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
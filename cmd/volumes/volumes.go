@@ -0,0 +1,50 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package volumes
+
+import (
+	"context"
+
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+)
+
+// Command has the dependencies to run the volumes commands
+type Command struct {
+	k8sClientProvider     okteto.K8sClientProvider
+	dynamicClientProvider func(*rest.Config) (dynamic.Interface, error)
+}
+
+// NewCommand creates a volumes command
+func NewCommand() *Command {
+	return &Command{
+		k8sClientProvider: okteto.NewK8sClientProvider(),
+		dynamicClientProvider: func(cfg *rest.Config) (dynamic.Interface, error) {
+			return dynamic.NewForConfig(cfg)
+		},
+	}
+}
+
+// Volumes groups the commands to snapshot and restore the persistent volumes of dev environments
+func Volumes(ctx context.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "volumes",
+		Short: "Snapshot and restore the persistent volumes of your development environments",
+	}
+	cmd.AddCommand(snapshot(ctx))
+	cmd.AddCommand(restore(ctx))
+	return cmd
+}
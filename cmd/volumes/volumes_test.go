@@ -0,0 +1,181 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package volumes
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/internal/test"
+	"github.com/okteto/okteto/pkg/k8s/volumesnapshots"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestMain(m *testing.M) {
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		CurrentContext: "test",
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {Namespace: "test"},
+		},
+	}
+	os.Exit(m.Run())
+}
+
+func newFakeDynamicClient() *dynamicfake.FakeDynamicClient {
+	dc := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		volumesnapshots.GVR:        "VolumeSnapshotList",
+		volumesnapshots.ContentGVR: "VolumeSnapshotContentList",
+	})
+	// the snapshot controller is not running in the fake cluster
+	dc.PrependReactor("create", "volumesnapshots", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		obj := action.(k8stesting.CreateAction).GetObject().(*unstructured.Unstructured)
+		return false, nil, unstructured.SetNestedField(obj.Object, true, "status", "readyToUse")
+	})
+	return dc
+}
+
+func newCommand(dc dynamic.Interface, objects ...runtime.Object) *Command {
+	return &Command{
+		k8sClientProvider: test.NewFakeK8sProvider(objects...),
+		dynamicClientProvider: func(*rest.Config) (dynamic.Interface, error) {
+			return dc, nil
+		},
+	}
+}
+
+func newPVC(name string, labels map[string]string) *apiv1.PersistentVolumeClaim {
+	return &apiv1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test",
+			Labels:    labels,
+		},
+		Spec: apiv1.PersistentVolumeClaimSpec{
+			AccessModes: []apiv1.PersistentVolumeAccessMode{apiv1.ReadWriteOnce},
+			Resources: apiv1.ResourceRequirements{
+				Requests: apiv1.ResourceList{apiv1.ResourceStorage: resource.MustParse("1Gi")},
+			},
+		},
+	}
+}
+
+func TestSnapshotAndRestore(t *testing.T) {
+	ctx := context.Background()
+	dc := newFakeDynamicClient()
+	pvc := newPVC("postgres", map[string]string{model.DeployedByLabel: "movies"})
+	other := newPVC("other", nil)
+
+	vsList, err := newCommand(dc, pvc, other).Snapshot(ctx, &SnapshotOptions{
+		Name:      "movies",
+		Snapshot:  "movies-1",
+		Namespace: "test",
+		Timeout:   time.Second,
+	})
+	require.NoError(t, err)
+	require.Len(t, vsList, 1)
+	assert.Equal(t, "postgres", vsList[0].PVC.Name)
+
+	_, err = newCommand(dc, pvc).Snapshot(ctx, &SnapshotOptions{Name: "movies", Snapshot: "movies-1", Namespace: "test", Timeout: time.Second})
+	assert.ErrorContains(t, err, "already exists")
+
+	_, err = newCommand(dc, pvc).Restore(ctx, &RestoreOptions{Snapshot: "movies-1", Namespace: "test"})
+	assert.ErrorContains(t, err, "already exists")
+
+	cmd := newCommand(dc, other)
+	restored, err := cmd.Restore(ctx, &RestoreOptions{Snapshot: "movies-1", Namespace: "test"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, restored)
+
+	c, _, err := cmd.k8sClientProvider.Provide(nil)
+	require.NoError(t, err)
+	result, err := c.CoreV1().PersistentVolumeClaims("test").Get(ctx, "postgres", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NotNil(t, result.Spec.DataSource)
+	assert.Equal(t, "movies-1-postgres", result.Spec.DataSource.Name)
+	assert.Equal(t, "movies", result.Labels[model.DeployedByLabel])
+}
+
+func TestRestoreIntoAnotherNamespace(t *testing.T) {
+	ctx := context.Background()
+	dc := newFakeDynamicClient()
+	pvc := newPVC("postgres", map[string]string{model.DeployedByLabel: "movies"})
+
+	vsList, err := newCommand(dc, pvc).Snapshot(ctx, &SnapshotOptions{Name: "movies", Snapshot: "movies-1", Namespace: "test", Timeout: time.Second})
+	require.NoError(t, err)
+	require.Len(t, vsList, 1)
+
+	// the snapshot controller binds the volume snapshot to the content of the storage backend
+	obj, err := dc.Resource(volumesnapshots.GVR).Namespace("test").Get(ctx, vsList[0].Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NoError(t, unstructured.SetNestedField(obj.Object, "snapcontent-1", "status", "boundVolumeSnapshotContentName"))
+	_, err = dc.Resource(volumesnapshots.GVR).Namespace("test").Update(ctx, obj, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	content := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": volumesnapshots.ContentGVR.GroupVersion().String(),
+			"kind":       volumesnapshots.ContentKind,
+			"metadata":   map[string]interface{}{"name": "snapcontent-1"},
+			"spec":       map[string]interface{}{"driver": "pd.csi.storage.gke.io"},
+			"status":     map[string]interface{}{"snapshotHandle": "snapshot-1234"},
+		},
+	}
+	_, err = dc.Resource(volumesnapshots.ContentGVR).Create(ctx, content, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	opts := &RestoreOptions{Snapshot: "movies-1", Namespace: "test", TargetNamespace: "copy", Timeout: time.Second}
+	_, err = newCommand(dc, pvc).Restore(ctx, opts)
+	assert.ErrorContains(t, err, "namespace 'copy' not found")
+
+	ns := &apiv1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "copy"}}
+	cmd := newCommand(dc, pvc, ns)
+	restored, err := cmd.Restore(ctx, opts)
+	require.NoError(t, err)
+	assert.Equal(t, 1, restored)
+
+	c, _, err := cmd.k8sClientProvider.Provide(nil)
+	require.NoError(t, err)
+	result, err := c.CoreV1().PersistentVolumeClaims("copy").Get(ctx, "postgres", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NotNil(t, result.Spec.DataSource)
+	assert.Equal(t, "movies-1-postgres", result.Spec.DataSource.Name)
+
+	clones, err := volumesnapshots.List(ctx, "movies-1", "copy", dc)
+	require.NoError(t, err)
+	assert.Len(t, clones, 1)
+}
+
+func TestSnapshotWithoutVolumes(t *testing.T) {
+	_, err := newCommand(newFakeDynamicClient()).Snapshot(context.Background(), &SnapshotOptions{Name: "movies", Snapshot: "movies-1", Namespace: "test"})
+	assert.ErrorContains(t, err, "has no persistent volumes")
+}
+
+func TestRestoreNotFound(t *testing.T) {
+	_, err := newCommand(newFakeDynamicClient()).Restore(context.Background(), &RestoreOptions{Snapshot: "movies-1", Namespace: "test"})
+	assert.ErrorContains(t, err, "not found")
+}
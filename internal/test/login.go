@@ -31,3 +31,7 @@ func NewFakeLoginController(user *types.User, err error) *FakeLoginController {
 func (fakeController FakeLoginController) AuthenticateToOktetoCluster(_ context.Context, _, _ string) (*types.User, error) {
 	return fakeController.User, fakeController.Err
 }
+
+func (fakeController FakeLoginController) AuthenticateWithDeviceCode(_ context.Context, _ string) (*types.User, error) {
+	return fakeController.User, fakeController.Err
+}
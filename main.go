@@ -17,6 +17,7 @@ import (
 	"context"
 	cryptoRand "crypto/rand"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"math/rand"
 	"os"
@@ -24,22 +25,44 @@ import (
 	"time"
 	"unicode"
 
+	"github.com/fatih/color"
 	"github.com/okteto/okteto/cmd"
+	"github.com/okteto/okteto/cmd/admin"
+	"github.com/okteto/okteto/cmd/api"
 	"github.com/okteto/okteto/cmd/build"
+	"github.com/okteto/okteto/cmd/completion"
+	configCMD "github.com/okteto/okteto/cmd/config"
 	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/debug"
 	"github.com/okteto/okteto/cmd/deploy"
 	"github.com/okteto/okteto/cmd/destroy"
+	"github.com/okteto/okteto/cmd/divert"
+	"github.com/okteto/okteto/cmd/external"
+	"github.com/okteto/okteto/cmd/forwards"
+	"github.com/okteto/okteto/cmd/graph"
+	ignoreCMD "github.com/okteto/okteto/cmd/ignore"
 	"github.com/okteto/okteto/cmd/kubetoken"
 	"github.com/okteto/okteto/cmd/logs"
+	"github.com/okteto/okteto/cmd/manifest"
 	"github.com/okteto/okteto/cmd/namespace"
 	"github.com/okteto/okteto/cmd/pipeline"
 	"github.com/okteto/okteto/cmd/preview"
+	"github.com/okteto/okteto/cmd/promote"
+	"github.com/okteto/okteto/cmd/registry"
 	"github.com/okteto/okteto/cmd/registrytoken"
+	"github.com/okteto/okteto/cmd/snapshot"
 	"github.com/okteto/okteto/cmd/stack"
+	"github.com/okteto/okteto/cmd/test"
 	"github.com/okteto/okteto/cmd/up"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/cmd/vars"
+	"github.com/okteto/okteto/cmd/volumes"
 	"github.com/okteto/okteto/pkg/analytics"
 	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/crash"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoHttp "github.com/okteto/okteto/pkg/http"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/log/io"
 	"github.com/okteto/okteto/pkg/model"
@@ -76,21 +99,29 @@ func init() {
 
 	utilRuntime.ErrorHandlers = errorHandlers
 
-	if bin := os.Getenv(model.OktetoBinEnvVar); bin != "" {
+	if bin := config.EnvOktetoBin.Value(); bin != "" {
 		model.OktetoBinImageTag = bin
 		oktetoLog.Infof("using %s as the bin image", bin)
 	}
 }
 
 func main() {
-	ctx := context.Background()
+	defer crash.Recover()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	commandTimeout := utils.NewCommandTimeout(cancel)
+	disableSpinner := config.EnvOktetoDisableSpinner.IsTrue()
+	oktetoLog.SetSpinnerDisabled(disableSpinner) // TODO: Remove when we fully move to ioController
 	ioController := io.NewIOController()
 	ioController.Logger().SetLevel(io.WarnLevel)
 	oktetoLog.Init(logrus.WarnLevel) // TODO: Remove when we fully move to ioController
 	if registrytoken.IsRegistryCredentialHelperCommand(os.Args) {
-		oktetoLog.SetOutput(os.Stderr)                  // TODO: Remove when we fully move to ioController
-		oktetoLog.SetLevel(oktetoLog.InfoLevel)         // TODO: Remove when we fully move to ioController
-		oktetoLog.SetOutputFormat(oktetoLog.JSONFormat) // TODO: Remove when we fully move to ioController
+		// TODO: Remove when we fully move to ioController
+		oktetoLog.SetLogger(oktetoLog.NewLogger(
+			oktetoLog.WithOutput(os.Stderr),
+			oktetoLog.WithLevel(logrus.InfoLevel),
+			oktetoLog.WithFormat(oktetoLog.JSONFormat),
+		))
 
 		ioController.Logger().SetLevel(io.InfoLevel)
 		ioController.SetOutputFormat(io.JSONFormat)
@@ -98,12 +129,15 @@ func main() {
 	var logLevel string
 	var outputMode string
 	var serverNameOverride string
+	var noOverride bool
+	var timeout time.Duration
 
 	if err := analytics.Init(); err != nil {
 		oktetoLog.Infof("error initializing okteto analytics: %s", err)
 	}
 
 	okteto.InitContextWithDeprecatedToken()
+	okteto.SetInteractiveTokenRefresher(utils.RefreshTokenWithBrowser)
 
 	root := &cobra.Command{
 		Use:           fmt.Sprintf("%s COMMAND [ARG...]", config.GetBinaryName()),
@@ -113,13 +147,37 @@ func main() {
 		PersistentPreRun: func(ccmd *cobra.Command, args []string) {
 			ccmd.SilenceUsage = true
 			if !registrytoken.IsRegistryCredentialHelperCommand(os.Args) {
+				cliConfig := config.GetCLIConfig()
+				if !ccmd.Flags().Changed("log-output") && cliConfig.LogOutput != "" {
+					outputMode = cliConfig.LogOutput
+				}
+				if cliConfig.IsNoColor() {
+					color.NoColor = true
+				}
+
 				oktetoLog.SetLevel(logLevel)          // TODO: Remove when we fully move to ioController
 				oktetoLog.SetOutputFormat(outputMode) // TODO: Remove when we fully move to ioController
 
 				ioController.Logger().SetLevel(logLevel)
 				ioController.SetOutputFormat(outputMode)
+
+				for _, warning := range config.ValidateEnvVars() {
+					oktetoLog.Warning(warning)
+				}
 			}
 			okteto.SetServerNameOverride(serverNameOverride)
+			if noOverride {
+				// set as env var so the commands run by okteto ignore the override file too
+				if err := os.Setenv(constants.OktetoDisableManifestOverrideEnvVar, "true"); err != nil {
+					ioController.Logger().Infof("error disabling the manifest override: %s", err)
+				}
+			}
+			if !registrytoken.IsRegistryCredentialHelperCommand(os.Args) {
+				if err := oktetoLog.StartInvocationLog(config.GetInvocationLogsDir(), config.VersionString, ccmd.CommandPath(), os.Args[1:]); err != nil {
+					ioController.Logger().Infof("error creating the command log: %s", err)
+				}
+			}
+			commandTimeout.Start(timeout)
 			ioController.Logger().Infof("started %s", strings.Join(os.Args, " "))
 		},
 		PersistentPostRun: func(ccmd *cobra.Command, args []string) {
@@ -130,6 +188,10 @@ func main() {
 	root.PersistentFlags().StringVarP(&logLevel, "log-level", "l", "warn", "amount of information outputted (debug, info, warn, error)")
 	root.PersistentFlags().StringVar(&outputMode, "log-output", oktetoLog.TTYFormat, "output format for logs (tty, plain, json)")
 
+	root.PersistentFlags().DurationVar(&timeout, "timeout", 0, "maximum duration of the command, zero means no timeout. Commands with their own --timeout flag use it instead")
+
+	root.PersistentFlags().BoolVar(&noOverride, "no-override", false, "don't merge the okteto.override.yml file over the okteto manifest")
+
 	root.PersistentFlags().StringVarP(&serverNameOverride, "server-name", "", "", "The address and port of the Okteto Ingress server")
 	err := root.PersistentFlags().MarkHidden("server-name")
 	if err != nil {
@@ -140,31 +202,50 @@ func main() {
 	at := analytics.NewAnalyticsTracker()
 
 	root.AddCommand(cmd.Analytics())
+	root.AddCommand(configCMD.Config())
 	root.AddCommand(cmd.Version())
 	root.AddCommand(cmd.Login())
 
 	root.AddCommand(contextCMD.Context(okClientProvider))
 	root.AddCommand(cmd.Kubeconfig(okClientProvider))
+	root.AddCommand(cmd.Env())
 
 	root.AddCommand(kubetoken.NewKubetokenCmd().Cmd())
 	root.AddCommand(registrytoken.RegistryToken(ctx))
+	root.AddCommand(registry.Registry(ctx))
 
 	root.AddCommand(build.Build(ctx, ioController, at))
 
 	root.AddCommand(namespace.Namespace(ctx))
+	root.AddCommand(divert.Divert(ctx))
+	root.AddCommand(vars.Vars(ctx))
 	root.AddCommand(cmd.Init(at, ioController))
 	root.AddCommand(up.Up(at, ioController))
 	root.AddCommand(cmd.Down())
 	root.AddCommand(cmd.Status())
 	root.AddCommand(cmd.Doctor())
 	root.AddCommand(cmd.Exec())
+	root.AddCommand(cmd.Attach())
+	root.AddCommand(debug.Debug(ctx))
 	root.AddCommand(preview.Preview(ctx))
 	root.AddCommand(cmd.Restart())
+	root.AddCommand(forwards.Forwards(ctx))
 	root.AddCommand(cmd.UpdateDeprecated())
 	root.AddCommand(deploy.Deploy(ctx, at, ioController))
 	root.AddCommand(destroy.Destroy(ctx, at, ioController))
 	root.AddCommand(deploy.Endpoints(ctx))
+	root.AddCommand(graph.Graph(ctx))
+	root.AddCommand(test.Test(ctx, ioController))
+	root.AddCommand(snapshot.Snapshot(ctx))
+	root.AddCommand(volumes.Volumes(ctx))
+	root.AddCommand(promote.Promote(ctx))
+	root.AddCommand(external.External(ctx))
 	root.AddCommand(logs.Logs(ctx))
+	root.AddCommand(manifest.Manifest())
+	root.AddCommand(manifest.Validate())
+	root.AddCommand(ignoreCMD.Ignore())
+	root.AddCommand(api.API(ctx))
+	root.AddCommand(admin.Admin(ctx))
 	root.AddCommand(generateFigSpec.NewCmdGenFigSpec())
 
 	// deprecated
@@ -175,7 +256,19 @@ func main() {
 	root.AddCommand(cmd.Push(ctx))
 	root.AddCommand(pipeline.Pipeline(ctx))
 
-	err = root.Execute()
+	completion.Register(root)
+
+	start := time.Now()
+	executedCmd, err := root.ExecuteContextC(ctx)
+	commandTimeout.Stop()
+	err = commandTimeout.WrapError(err)
+	if executedCmd != nil {
+		analytics.TrackCommand(executedCmd.CommandPath(), time.Since(start), err)
+	}
+	if httpStats := oktetoHttp.GetStats(); httpStats.Requests > 0 {
+		oktetoLog.Debugf("http connections: %s", httpStats)
+	}
+	oktetoLog.FinishInvocationLog(oktetoErrors.GetExitCode(err), err)
 
 	if err != nil {
 		message := err.Error()
@@ -185,11 +278,12 @@ func main() {
 			message = string(tmp)
 		}
 		oktetoLog.Fail(message) // TODO: Change to use ioController  when we fully move to ioController
-		if uErr, ok := err.(oktetoErrors.UserError); ok {
+		var uErr oktetoErrors.UserError
+		if errors.As(err, &uErr) {
 			if len(uErr.Hint) > 0 {
 				oktetoLog.Hint("    %s", uErr.Hint)
 			}
 		}
-		os.Exit(1)
+		os.Exit(oktetoErrors.GetExitCode(err))
 	}
 }
@@ -0,0 +1,71 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analytics
+
+import (
+	"errors"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+)
+
+const (
+	commandEvent = "Command"
+	crashEvent   = "Crash"
+
+	userErrorClass      = "user"
+	transientErrorClass = "transient"
+	commandErrorClass   = "command"
+	internalErrorClass  = "internal"
+)
+
+// TrackCommand sends the anonymized metrics of a command execution. Only the class of the error is sent, never its message
+func TrackCommand(command string, duration time.Duration, err error) {
+	if !get().CommandMetrics {
+		return
+	}
+	props := map[string]interface{}{
+		"command":    command,
+		"duration":   duration.Seconds(),
+		"errorClass": getErrorClass(err),
+	}
+	track(commandEvent, err == nil, props)
+}
+
+// getErrorClass returns the class of an error without exposing any detail of it
+func getErrorClass(err error) string {
+	if err == nil {
+		return ""
+	}
+	var userErr oktetoErrors.UserError
+	var cmdErr oktetoErrors.CommandError
+	switch {
+	case oktetoErrors.IsTransient(err):
+		return transientErrorClass
+	case errors.As(err, &userErr):
+		return userErrorClass
+	case errors.As(err, &cmdErr):
+		return commandErrorClass
+	default:
+		return internalErrorClass
+	}
+}
+
+// TrackCrash sends the function where the cli panicked. As the command metrics, it requires the user to opt in
+func TrackCrash(frame string) {
+	if !get().CommandMetrics {
+		return
+	}
+	track(crashEvent, false, map[string]interface{}{"frame": frame})
+}
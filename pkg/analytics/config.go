@@ -36,6 +36,15 @@ var (
 type Analytics struct {
 	MachineID string `json:"machineID"`
 	Enabled   bool   `json:"enabled"`
+	// CommandMetrics is only enabled when the user explicitly opts in running 'okteto analytics enable'
+	CommandMetrics bool `json:"commandMetrics"`
+}
+
+// Status represents the current analytics configuration
+type Status struct {
+	Enabled        bool `json:"enabled"`
+	CommandMetrics bool `json:"commandMetrics"`
+	SpooledEvents  int  `json:"spooledEvents"`
 }
 
 func getContextType(oktetoContext string) string {
@@ -147,17 +156,30 @@ func (a *Analytics) save() error {
 func Disable() error {
 	a := get()
 	a.Enabled = false
+	a.CommandMetrics = false
 	trackDisable(true)
 	return a.save()
 }
 
-// Enable enables analytics
+// Enable enables analytics and opts in to the command metrics
 func Enable() error {
 	a := get()
 	a.Enabled = true
+	a.CommandMetrics = true
 	return a.save()
 }
 
+// GetStatus returns the analytics configuration and the number of events waiting to be sent
+func GetStatus() Status {
+	a := get()
+	enabled := a.Enabled && config.GetCLIConfig().IsTelemetryEnabled()
+	return Status{
+		Enabled:        enabled,
+		CommandMetrics: enabled && a.CommandMetrics,
+		SpooledEvents:  newSpool().size(),
+	}
+}
+
 func getTrackID() string {
 	if okteto.Context().UserID != "" {
 		return okteto.Context().UserID
@@ -0,0 +1,121 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analytics
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/dukex/mixpanel"
+	"github.com/okteto/okteto/pkg/config"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	// maxSpooledEvents is the maximum number of events stored while offline. Older events are discarded
+	maxSpooledEvents = 100
+
+	// spoolBatchSize is the maximum number of spooled events sent on each command execution
+	spoolBatchSize = 20
+)
+
+// spooledEvent represents an event that couldn't be sent to mixpanel
+type spooledEvent struct {
+	Timestamp  time.Time              `json:"timestamp"`
+	Properties map[string]interface{} `json:"properties"`
+	TrackID    string                 `json:"trackID"`
+	Event      string                 `json:"event"`
+}
+
+// spool stores the events that couldn't be sent so they are sent on a later execution
+type spool struct {
+	path string
+}
+
+func newSpool() *spool {
+	return &spool{path: config.GetAnalyticsSpoolPath()}
+}
+
+func (s *spool) read() []spooledEvent {
+	b, err := os.ReadFile(s.path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			oktetoLog.Infof("error reading analytics spool: %s", err)
+		}
+		return nil
+	}
+	events := []spooledEvent{}
+	if err := json.Unmarshal(b, &events); err != nil {
+		oktetoLog.Infof("error unmarshaling analytics spool: %s", err)
+		return nil
+	}
+	return events
+}
+
+func (s *spool) write(events []spooledEvent) error {
+	if len(events) == 0 {
+		if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	if len(events) > maxSpooledEvents {
+		events = events[len(events)-maxSpooledEvents:]
+	}
+	b, err := json.Marshal(events)
+	if err != nil {
+		return fmt.Errorf("failed to generate analytics spool: %w", err)
+	}
+	return os.WriteFile(s.path, b, 0600)
+}
+
+// add stores an event in the spool
+func (s *spool) add(e spooledEvent) {
+	if err := s.write(append(s.read(), e)); err != nil {
+		oktetoLog.Infof("error writing analytics spool: %s", err)
+	}
+}
+
+// flush sends a batch of the spooled events. It stops on the first failure to keep the rest of the events for later
+func (s *spool) flush(c mixpanel.Mixpanel) {
+	events := s.read()
+	if len(events) == 0 {
+		return
+	}
+
+	sent := 0
+	for sent < len(events) && sent < spoolBatchSize {
+		e := events[sent]
+		timestamp := e.Timestamp
+		if err := c.Track(e.TrackID, e.Event, &mixpanel.Event{Timestamp: &timestamp, Properties: e.Properties}); err != nil {
+			oktetoLog.Infof("failed to send spooled analytics: %s", err)
+			break
+		}
+		sent++
+	}
+
+	if sent == 0 {
+		return
+	}
+	if err := s.write(events[sent:]); err != nil {
+		oktetoLog.Infof("error writing analytics spool: %s", err)
+	}
+}
+
+// size returns the number of events waiting to be sent
+func (s *spool) size() int {
+	return len(s.read())
+}
@@ -0,0 +1,91 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analytics
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dukex/mixpanel"
+	"github.com/okteto/okteto/pkg/constants"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeMixpanel struct {
+	err    error
+	events []string
+}
+
+func (f *fakeMixpanel) Track(_, eventName string, _ *mixpanel.Event) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.events = append(f.events, eventName)
+	return nil
+}
+
+func (*fakeMixpanel) Update(string, *mixpanel.Update) error { return nil }
+
+func (*fakeMixpanel) Alias(string, string) error { return nil }
+
+func TestSpool(t *testing.T) {
+	t.Setenv(constants.OktetoHomeEnvVar, t.TempDir())
+	s := newSpool()
+	assert.Equal(t, 0, s.size())
+
+	for i := 0; i < maxSpooledEvents+5; i++ {
+		s.add(spooledEvent{Event: fmt.Sprintf("event-%d", i), Timestamp: time.Now()})
+	}
+	require.Equal(t, maxSpooledEvents, s.size())
+	assert.Equal(t, "event-5", s.read()[0].Event)
+
+	failing := &fakeMixpanel{err: errors.New("offline")}
+	s.flush(failing)
+	assert.Equal(t, maxSpooledEvents, s.size())
+
+	c := &fakeMixpanel{}
+	s.flush(c)
+	assert.Len(t, c.events, spoolBatchSize)
+	assert.Equal(t, "event-5", c.events[0])
+	assert.Equal(t, maxSpooledEvents-spoolBatchSize, s.size())
+
+	for s.size() > 0 {
+		s.flush(c)
+	}
+	assert.Len(t, c.events, maxSpooledEvents)
+	assert.NoFileExists(t, s.path)
+}
+
+func Test_getErrorClass(t *testing.T) {
+	tests := []struct {
+		err      error
+		name     string
+		expected string
+	}{
+		{name: "no-error"},
+		{name: "user", err: oktetoErrors.UserError{E: errors.New("wrong manifest")}, expected: userErrorClass},
+		{name: "transient", err: errors.New("unexpected EOF"), expected: transientErrorClass},
+		{name: "command", err: oktetoErrors.CommandError{E: errors.New("exit 1"), Reason: errors.New("command failed")}, expected: commandErrorClass},
+		{name: "internal", err: errors.New("boom"), expected: internalErrorClass},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getErrorClass(tt.err))
+		})
+	}
+}
@@ -14,7 +14,6 @@
 package analytics
 
 import (
-	"net"
 	"net/http"
 	"os"
 	"regexp"
@@ -24,6 +23,7 @@ import (
 
 	"github.com/dukex/mixpanel"
 	"github.com/okteto/okteto/pkg/config"
+	okHttp "github.com/okteto/okteto/pkg/http"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
@@ -71,13 +71,8 @@ var (
 
 func init() {
 	c := &http.Client{
-		Timeout: time.Second * 5,
-		Transport: &http.Transport{
-			Dial: (&net.Dialer{
-				Timeout: 5 * time.Second,
-			}).Dial,
-			TLSHandshakeTimeout: 5 * time.Second,
-		},
+		Timeout:   time.Second * 5,
+		Transport: okHttp.SharedDefaultTransport(),
 	}
 
 	mixpanelClient = mixpanel.NewFromClient(c, mixpanelToken, "")
@@ -289,6 +284,11 @@ func track(event string, success bool, props map[string]interface{}) {
 		return
 	}
 
+	if !config.GetCLIConfig().IsTelemetryEnabled() {
+		oktetoLog.Info("failed to send analytics: telemetry has been disabled in the okteto config")
+		return
+	}
+
 	if !okteto.IsContextInitialized() {
 		oktetoLog.Info("failed to send analytics: okteto context not initialized")
 		return
@@ -339,10 +339,19 @@ func track(event string, success bool, props map[string]interface{}) {
 	props["context"] = okteto.Context().CompanyName
 	props["isTrial"] = okteto.Context().IsTrial
 
+	trackID := getTrackID()
 	e := &mixpanel.Event{Properties: props}
-	if err := mixpanelClient.Track(getTrackID(), event, e); err != nil {
+	if err := mixpanelClient.Track(trackID, event, e); err != nil {
 		oktetoLog.Infof("Failed to send analytics: %s", err)
+		newSpool().add(spooledEvent{
+			Timestamp:  time.Now(),
+			Properties: props,
+			TrackID:    trackID,
+			Event:      event,
+		})
+		return
 	}
+	newSpool().flush(mixpanelClient)
 }
 
 func disabledByOktetoAdmin() bool {
@@ -29,6 +29,7 @@ import (
 // Info represents the build info to generate an image
 type Info struct {
 	Secrets          Secrets           `yaml:"secrets,omitempty"`
+	Scan             *ScanInfo         `yaml:"scan,omitempty"`
 	Name             string            `yaml:"name,omitempty"`
 	Context          string            `yaml:"context,omitempty"`
 	Dockerfile       string            `yaml:"dockerfile,omitempty"`
@@ -47,6 +48,7 @@ type Secrets map[string]string
 // infoRaw represents the build info for serialization
 type infoRaw struct {
 	Secrets          Secrets           `yaml:"secrets,omitempty"`
+	Scan             *ScanInfo         `yaml:"scan,omitempty"`
 	Name             string            `yaml:"name,omitempty"`
 	Context          string            `yaml:"context,omitempty"`
 	Dockerfile       string            `yaml:"dockerfile,omitempty"`
@@ -139,6 +141,7 @@ func (i *Info) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	i.ExportCache = rawBuildInfo.ExportCache
 	i.DependsOn = rawBuildInfo.DependsOn
 	i.Secrets = rawBuildInfo.Secrets
+	i.Scan = rawBuildInfo.Scan
 	return nil
 }
 
@@ -156,6 +159,9 @@ func (i *Info) MarshalYAML() (interface{}, error) {
 	if i.Args != nil && len(i.Args) != 0 {
 		return infoRaw(*i), nil
 	}
+	if i.Scan.IsEnabled() {
+		return infoRaw(*i), nil
+	}
 	return i.Name, nil
 }
 
@@ -193,6 +199,11 @@ func (i *Info) Copy() *Info {
 	dependsOn = append(dependsOn, i.DependsOn...)
 	result.DependsOn = dependsOn
 
+	if i.Scan != nil {
+		scan := *i.Scan
+		result.Scan = &scan
+	}
+
 	return result
 }
 
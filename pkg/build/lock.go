@@ -0,0 +1,84 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// LockFile is the file storing the digests of the images built for the services of the manifest
+	LockFile = "okteto.lock.yml"
+)
+
+// Lock represents the images built for the services of the manifest, referenced by digest
+type Lock struct {
+	Images map[string]LockedImage `yaml:"images"`
+}
+
+// LockedImage is the image built for a service and the build hash of the sources it was built from
+type LockedImage struct {
+	Image string `yaml:"image"`
+	// Hash is the smart build hash of the service when the image was built. The image is only used while the hash matches
+	Hash string `yaml:"hash,omitempty"`
+	// Platform is the comma separated list of platforms the image was built for
+	Platform string `yaml:"platform,omitempty"`
+}
+
+// ReadLock reads the lock file in 'path'. It returns nil if the file doesn't exist
+func ReadLock(fs afero.Fs, path string) (*Lock, error) {
+	b, err := afero.ReadFile(fs, path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading '%s': %w", path, err)
+	}
+	lock := &Lock{}
+	if err := yaml.UnmarshalStrict(b, lock); err != nil {
+		return nil, fmt.Errorf("error parsing '%s': %w", path, err)
+	}
+	if lock.Images == nil {
+		lock.Images = map[string]LockedImage{}
+	}
+	return lock, nil
+}
+
+// Write writes the lock file in 'path'
+func (l *Lock) Write(fs afero.Fs, path string) error {
+	b, err := yaml.Marshal(l)
+	if err != nil {
+		return err
+	}
+	if err := afero.WriteFile(fs, path, b, 0600); err != nil {
+		return fmt.Errorf("error writing '%s': %w", path, err)
+	}
+	return nil
+}
+
+// GetServices returns the sorted services of the lock file
+func (l *Lock) GetServices() []string {
+	services := make([]string, 0, len(l.Images))
+	for svc := range l.Images {
+		services = append(services, svc)
+	}
+	sort.Strings(services)
+	return services
+}
@@ -0,0 +1,47 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLock(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	lock, err := ReadLock(fs, LockFile)
+	require.NoError(t, err)
+	assert.Nil(t, lock)
+
+	lock = &Lock{
+		Images: map[string]LockedImage{
+			"frontend": {Image: "okteto.dev/frontend@sha256:1", Hash: "hash-1"},
+			"api":      {Image: "okteto.dev/api@sha256:2", Hash: "hash-2", Platform: "linux/amd64,linux/arm64"},
+		},
+	}
+	require.NoError(t, lock.Write(fs, LockFile))
+
+	result, err := ReadLock(fs, LockFile)
+	require.NoError(t, err)
+	assert.Equal(t, lock, result)
+	assert.Equal(t, []string{"api", "frontend"}, result.GetServices())
+
+	require.NoError(t, afero.WriteFile(fs, LockFile, []byte("unknown: value"), 0600))
+	_, err = ReadLock(fs, LockFile)
+	assert.Error(t, err)
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+// ScanInfo represents the vulnerability scan of an image after it is built
+type ScanInfo struct {
+	// FailOn is the minimum severity of the vulnerabilities that fail the build
+	FailOn  string `yaml:"failOn,omitempty"`
+	enabled bool
+}
+
+// NewScanInfo returns an enabled scan that fails the build on vulnerabilities of the given severity
+func NewScanInfo(failOn string) *ScanInfo {
+	return &ScanInfo{FailOn: failOn, enabled: true}
+}
+
+// IsEnabled returns true if the image must be scanned after it is built
+func (s *ScanInfo) IsEnabled() bool {
+	return s != nil && s.enabled
+}
+
+type scanInfoRaw struct {
+	FailOn string `yaml:"failOn,omitempty"`
+}
+
+// UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
+// 'scan: true' enables the scan without failing the build
+func (s *ScanInfo) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var enabled bool
+	if err := unmarshal(&enabled); err == nil {
+		*s = ScanInfo{enabled: enabled}
+		return nil
+	}
+
+	var raw scanInfoRaw
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+	*s = ScanInfo{FailOn: raw.FailOn, enabled: true}
+	return nil
+}
+
+// MarshalYAML Implements the marshaler interface of the yaml pkg.
+func (s *ScanInfo) MarshalYAML() (interface{}, error) {
+	if !s.enabled || s.FailOn == "" {
+		return s.enabled, nil
+	}
+	return scanInfoRaw{FailOn: s.FailOn}, nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestScanInfoUnmarshalYAML(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          string
+		expectedFailOn string
+		enabled        bool
+	}{
+		{
+			name:  "not defined",
+			input: "context: .",
+		},
+		{
+			name:    "enabled",
+			input:   "scan: true",
+			enabled: true,
+		},
+		{
+			name:  "disabled",
+			input: "scan: false",
+		},
+		{
+			name:           "fail on",
+			input:          "scan:\n  failOn: high",
+			enabled:        true,
+			expectedFailOn: "high",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := &Info{}
+			require.NoError(t, yaml.Unmarshal([]byte(tt.input), info))
+			assert.Equal(t, tt.enabled, info.Scan.IsEnabled())
+			if tt.enabled {
+				assert.Equal(t, tt.expectedFailOn, info.Scan.FailOn)
+			}
+		})
+	}
+}
+
+func TestScanInfoMarshalYAML(t *testing.T) {
+	b, err := yaml.Marshal(&Info{Context: "api", Scan: NewScanInfo("critical")})
+	require.NoError(t, err)
+	info := &Info{}
+	require.NoError(t, yaml.Unmarshal(b, info))
+	assert.True(t, info.Scan.IsEnabled())
+	assert.Equal(t, "critical", info.Scan.FailOn)
+}
@@ -0,0 +1,253 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/okteto/okteto/pkg/cmd/daemon"
+	"github.com/okteto/okteto/pkg/config"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+)
+
+// VersionResponse is the response of the version endpoint
+type VersionResponse struct {
+	APIVersion string `json:"apiVersion"`
+	CLIVersion string `json:"cliVersion"`
+}
+
+// StatusResponse is the response of the status endpoint
+type StatusResponse struct {
+	Timestamp *time.Time     `json:"timestamp,omitempty"`
+	State     config.UpState `json:"state"`
+	LastError string         `json:"lastError,omitempty"`
+	Retries   int            `json:"retries"`
+	PID       int            `json:"pid,omitempty"`
+	Detached  bool           `json:"detached"`
+}
+
+// DevRequest identifies the development container of an operation
+type DevRequest struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Context   string `json:"context"`
+	File      string `json:"file"`
+}
+
+// CommandResponse is the response of the endpoints running okteto commands
+type CommandResponse struct {
+	Output string `json:"output"`
+}
+
+// ErrorResponse is returned by all the endpoints on failure
+type ErrorResponse struct {
+	Error  string `json:"error"`
+	Output string `json:"output,omitempty"`
+}
+
+func (*Server) handleVersion(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+	writeJSON(w, http.StatusOK, VersionResponse{
+		APIVersion: Version,
+		CLIVersion: config.VersionString,
+	})
+}
+
+func (s *Server) handleContexts(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+	ctxStore := s.contextStore()
+	contexts := []okteto.OktetoContextViewer{}
+	for name, okCtx := range ctxStore.Contexts {
+		viewer := okteto.OktetoContextViewer{
+			Name:      name,
+			Namespace: okCtx.Namespace,
+			Builder:   okCtx.Builder,
+			Registry:  okCtx.Registry,
+			Current:   name == ctxStore.CurrentContext,
+		}
+		contexts = append(contexts, viewer)
+	}
+	sort.Slice(contexts, func(i, j int) bool {
+		return contexts[i].Name < contexts[j].Name
+	})
+	writeJSON(w, http.StatusOK, contexts)
+}
+
+func (*Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+	name := r.URL.Query().Get("name")
+	namespace := r.URL.Query().Get("namespace")
+	if name == "" || namespace == "" {
+		writeError(w, http.StatusBadRequest, errors.New("the parameters 'name' and 'namespace' are required"), "")
+		return
+	}
+
+	response := StatusResponse{}
+	info, err := config.GetStateInfo(name, namespace)
+	if err != nil {
+		writeError(w, http.StatusNotFound, fmt.Errorf("development container '%s' is not running: %w", name, err), "")
+		return
+	}
+	response.State = info.State
+	response.LastError = info.LastError
+	response.Retries = info.Retries
+	if !info.Timestamp.IsZero() {
+		response.Timestamp = &info.Timestamp
+	}
+
+	if state, err := daemon.Read(namespace, name); err == nil && state.IsRunning() {
+		response.Detached = true
+		response.PID = state.PID
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) handleUp(w http.ResponseWriter, r *http.Request) {
+	s.runDevCommand(w, r, func(req DevRequest) []string {
+		return append([]string{"up", req.Name, "--detach"}, req.flags()...)
+	})
+}
+
+func (s *Server) handleUpStop(w http.ResponseWriter, r *http.Request) {
+	s.runDevCommand(w, r, func(req DevRequest) []string {
+		return append([]string{"down", req.Name, "--detach-only"}, req.flags()...)
+	})
+}
+
+func (s *Server) handleDown(w http.ResponseWriter, r *http.Request) {
+	s.runDevCommand(w, r, func(req DevRequest) []string {
+		return append([]string{"down", req.Name}, req.flags()...)
+	})
+}
+
+// runDevCommand runs an okteto command for the development container in the body of the request
+func (s *Server) runDevCommand(w http.ResponseWriter, r *http.Request, getArgs func(DevRequest) []string) {
+	if !allowMethod(w, r, http.MethodPost) {
+		return
+	}
+	var req DevRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request: %w", err), "")
+		return
+	}
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, errMissingName, "")
+		return
+	}
+
+	output := &strings.Builder{}
+	if err := s.runner.Run(r.Context(), getArgs(req), output); err != nil {
+		writeError(w, http.StatusInternalServerError, err, output.String())
+		return
+	}
+	writeJSON(w, http.StatusOK, CommandResponse{Output: output.String()})
+}
+
+// handleLogs streams the logs of a development environment as newline-delimited JSON
+func (s *Server) handleLogs(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+	query := r.URL.Query()
+	req := DevRequest{
+		Name:      query.Get("name"),
+		Namespace: query.Get("namespace"),
+		Context:   query.Get("context"),
+		File:      query.Get("file"),
+	}
+	args := []string{"logs"}
+	if service := query.Get("service"); service != "" {
+		args = append(args, service)
+	}
+	if req.Name != "" {
+		args = append(args, "--name", req.Name)
+	}
+	args = append(args, req.flags()...)
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(s.runner.Run(r.Context(), args, pw))
+	}()
+
+	enc := json.NewEncoder(w)
+	flusher, _ := w.(http.Flusher)
+	scanner := bufio.NewScanner(pr)
+	for scanner.Scan() {
+		if err := enc.Encode(map[string]string{"line": scanner.Text()}); err != nil {
+			oktetoLog.Infof("failed to stream logs: %s", err)
+			pr.Close()
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+	if err := scanner.Err(); err != nil && r.Context().Err() == nil {
+		_ = enc.Encode(ErrorResponse{Error: err.Error()})
+	}
+}
+
+// flags returns the flags shared by the okteto commands for a development container
+func (req DevRequest) flags() []string {
+	flags := []string{"--log-output", "plain"}
+	if req.Namespace != "" {
+		flags = append(flags, "--namespace", req.Namespace)
+	}
+	if req.Context != "" {
+		flags = append(flags, "--context", req.Context)
+	}
+	if req.File != "" {
+		flags = append(flags, "--file", req.File)
+	}
+	return flags
+}
+
+func allowMethod(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method == method {
+		return true
+	}
+	w.Header().Set("Allow", method)
+	writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method), "")
+	return false
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		oktetoLog.Infof("failed to write API response: %s", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error, output string) {
+	writeJSON(w, status, ErrorResponse{Error: err.Error(), Output: output})
+}
@@ -0,0 +1,204 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/okteto/okteto/pkg/config"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+)
+
+const (
+	// Version is the version of the local API. Breaking changes are released under a new version prefix
+	Version = "v1"
+
+	socketFile = "okteto-api.sock"
+
+	shutdownTimeout = 5 * time.Second
+)
+
+var (
+	// ErrAlreadyRunning is raised when another process is serving the API on the same socket
+	ErrAlreadyRunning = errors.New("the okteto API is already running")
+
+	errMissingName = errors.New("the parameter 'name' is required")
+)
+
+// GetSocketPath returns the default path of the unix socket of the local API
+func GetSocketPath() string {
+	return filepath.Join(config.GetOktetoHome(), socketFile)
+}
+
+// CommandRunner runs okteto commands on behalf of the API
+type CommandRunner interface {
+	Run(ctx context.Context, args []string, stdout io.Writer) error
+}
+
+// binaryRunner runs the commands with the current okteto binary
+type binaryRunner struct {
+	path string
+}
+
+// Run runs an okteto command writing its combined output to stdout
+func (r binaryRunner) Run(ctx context.Context, args []string, stdout io.Writer) error {
+	// #nosec G204 the binary is the current okteto executable
+	cmd := exec.CommandContext(ctx, r.path, args...)
+	cmd.Stdout = stdout
+	cmd.Stderr = stdout
+	return cmd.Run()
+}
+
+// Server exposes the core operations of the CLI on a unix socket
+type Server struct {
+	runner       CommandRunner
+	contextStore func() *okteto.OktetoContextStore
+	mux          *http.ServeMux
+	socket       string
+}
+
+// NewServer returns a server listening on the given unix socket
+func NewServer(socket string) (*Server, error) {
+	binary, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the okteto binary: %w", err)
+	}
+	return newServer(socket, binaryRunner{path: binary}, readContextStore), nil
+}
+
+func newServer(socket string, runner CommandRunner, contextStore func() *okteto.OktetoContextStore) *Server {
+	s := &Server{
+		socket:       socket,
+		runner:       runner,
+		contextStore: contextStore,
+		mux:          http.NewServeMux(),
+	}
+	s.mux.HandleFunc(route("version"), s.handleVersion)
+	s.mux.HandleFunc(route("contexts"), s.handleContexts)
+	s.mux.HandleFunc(route("status"), s.handleStatus)
+	s.mux.HandleFunc(route("up"), s.handleUp)
+	s.mux.HandleFunc(route("up/stop"), s.handleUpStop)
+	s.mux.HandleFunc(route("down"), s.handleDown)
+	s.mux.HandleFunc(route("logs"), s.handleLogs)
+	return s
+}
+
+func route(path string) string {
+	return fmt.Sprintf("/%s/%s", Version, path)
+}
+
+// readContextStore reads the contexts from disk on each request, they might be changed by other okteto commands
+func readContextStore() *okteto.OktetoContextStore {
+	if !okteto.ContextExists() {
+		return &okteto.OktetoContextStore{Contexts: map[string]*okteto.OktetoContext{}}
+	}
+	return okteto.GetContextStoreFromStorePath()
+}
+
+// Serve serves the API until the context is canceled
+func (s *Server) Serve(ctx context.Context) error {
+	l, err := s.listen()
+	if err != nil {
+		return err
+	}
+	defer os.Remove(s.socket)
+
+	oktetoLog.Infof("okteto API %s listening on %s", Version, s.socket)
+	return s.serve(ctx, l)
+}
+
+// serve serves the API on a listener until the context is canceled or the server fails
+func (s *Server) serve(ctx context.Context, l net.Listener) error {
+	srv := &http.Server{
+		Handler:           s.mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	stopped := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		select {
+		case <-ctx.Done():
+		case <-stopped:
+			return
+		}
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			oktetoLog.Infof("failed to shutdown the okteto API: %s", err)
+		}
+	}()
+
+	err := srv.Serve(l)
+	close(stopped)
+	wg.Wait()
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// listen creates the unix socket, replacing the socket of a previous server that is no longer running
+func (s *Server) listen() (net.Listener, error) {
+	if _, err := os.Stat(s.socket); err == nil {
+		conn, err := net.DialTimeout("unix", s.socket, time.Second)
+		if err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("%w on %s", ErrAlreadyRunning, s.socket)
+		}
+		if err := os.Remove(s.socket); err != nil {
+			return nil, fmt.Errorf("failed to remove stale socket %s: %w", s.socket, err)
+		}
+	}
+
+	// the socket is created in a private directory and moved once its permissions are restricted,
+	// so other users can't connect to it in the meantime
+	tmpDir, err := os.MkdirTemp(filepath.Dir(s.socket), ".okteto-api-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the socket directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	tmpSocket := filepath.Join(tmpDir, filepath.Base(s.socket))
+	l, err := net.Listen("unix", tmpSocket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", s.socket, err)
+	}
+	// the socket is removed by Serve from its final path
+	if ul, ok := l.(*net.UnixListener); ok {
+		ul.SetUnlinkOnClose(false)
+	}
+	if err := os.Chmod(tmpSocket, 0600); err != nil {
+		l.Close()
+		return nil, fmt.Errorf("failed to set the permissions of %s: %w", s.socket, err)
+	}
+	if err := os.Rename(tmpSocket, s.socket); err != nil {
+		l.Close()
+		return nil, fmt.Errorf("failed to create socket %s: %w", s.socket, err)
+	}
+	return l, nil
+}
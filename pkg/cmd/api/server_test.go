@@ -0,0 +1,267 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRunner struct {
+	err    error
+	output string
+	args   [][]string
+}
+
+func (f *fakeRunner) Run(_ context.Context, args []string, stdout io.Writer) error {
+	f.args = append(f.args, args)
+	fmt.Fprint(stdout, f.output)
+	return f.err
+}
+
+func newTestServer(runner CommandRunner) *Server {
+	store := &okteto.OktetoContextStore{
+		CurrentContext: "https://okteto.example.com",
+		Contexts: map[string]*okteto.OktetoContext{
+			"https://okteto.example.com": {Namespace: "cindy", Builder: "https://buildkit.example.com"},
+			"minikube":                   {Namespace: "default"},
+		},
+	}
+	return newServer("", runner, func() *okteto.OktetoContextStore { return store })
+}
+
+func doRequest(s *Server, method, path, body string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	s.mux.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestVersion(t *testing.T) {
+	s := newTestServer(&fakeRunner{})
+	rec := doRequest(s, http.MethodGet, "/v1/version", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var response VersionResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+	assert.Equal(t, Version, response.APIVersion)
+
+	rec = doRequest(s, http.MethodPost, "/v1/version", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestContexts(t *testing.T) {
+	s := newTestServer(&fakeRunner{})
+	rec := doRequest(s, http.MethodGet, "/v1/contexts", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var contexts []okteto.OktetoContextViewer
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&contexts))
+	expected := []okteto.OktetoContextViewer{
+		{Name: "https://okteto.example.com", Namespace: "cindy", Builder: "https://buildkit.example.com", Current: true},
+		{Name: "minikube", Namespace: "default"},
+	}
+	assert.Equal(t, expected, contexts)
+}
+
+func TestStatus(t *testing.T) {
+	t.Setenv(constants.OktetoFolderEnvVar, t.TempDir())
+	s := newTestServer(&fakeRunner{})
+
+	rec := doRequest(s, http.MethodGet, "/v1/status?name=api", "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = doRequest(s, http.MethodGet, "/v1/status?name=api&namespace=cindy", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	require.NoError(t, config.UpdateStateFile("api", "cindy", config.Synchronizing))
+	rec = doRequest(s, http.MethodGet, "/v1/status?name=api&namespace=cindy", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var response StatusResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+	assert.Equal(t, config.Synchronizing, response.State)
+	assert.False(t, response.Detached)
+}
+
+func TestDevCommands(t *testing.T) {
+	tests := []struct {
+		name         string
+		path         string
+		body         string
+		expectedArgs []string
+		expectedCode int
+	}{
+		{
+			name:         "up",
+			path:         "/v1/up",
+			body:         `{"name": "api", "namespace": "cindy", "file": "okteto.yml"}`,
+			expectedArgs: []string{"up", "api", "--detach", "--log-output", "plain", "--namespace", "cindy", "--file", "okteto.yml"},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "up stop",
+			path:         "/v1/up/stop",
+			body:         `{"name": "api", "context": "minikube"}`,
+			expectedArgs: []string{"down", "api", "--detach-only", "--log-output", "plain", "--context", "minikube"},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "down",
+			path:         "/v1/down",
+			body:         `{"name": "api"}`,
+			expectedArgs: []string{"down", "api", "--log-output", "plain"},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "missing name",
+			path:         "/v1/up",
+			body:         `{"namespace": "cindy"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid body",
+			path:         "/v1/down",
+			body:         `{`,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &fakeRunner{output: "done"}
+			s := newTestServer(runner)
+			rec := doRequest(s, http.MethodPost, tt.path, tt.body)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			if tt.expectedArgs == nil {
+				assert.Empty(t, runner.args)
+				return
+			}
+			require.Len(t, runner.args, 1)
+			assert.Equal(t, tt.expectedArgs, runner.args[0])
+			var response CommandResponse
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+			assert.Equal(t, "done", response.Output)
+		})
+	}
+}
+
+func TestDevCommandError(t *testing.T) {
+	runner := &fakeRunner{output: "development container not found", err: errors.New("exit status 1")}
+	s := newTestServer(runner)
+	rec := doRequest(s, http.MethodPost, "/v1/down", `{"name": "api"}`)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	var response ErrorResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+	assert.Equal(t, "exit status 1", response.Error)
+	assert.Equal(t, "development container not found", response.Output)
+}
+
+func TestLogs(t *testing.T) {
+	runner := &fakeRunner{output: "api line 1\napi line 2\n"}
+	s := newTestServer(runner)
+	rec := doRequest(s, http.MethodGet, "/v1/logs?name=movies&service=api&namespace=cindy", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, []string{"logs", "api", "--name", "movies", "--log-output", "plain", "--namespace", "cindy"}, runner.args[0])
+
+	lines := []string{}
+	scanner := bufio.NewScanner(rec.Body)
+	for scanner.Scan() {
+		var line map[string]string
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		lines = append(lines, line["line"])
+	}
+	assert.Equal(t, []string{"api line 1", "api line 2"}, lines)
+}
+
+func TestServeReturnsWhenTheServerFails(t *testing.T) {
+	l, err := net.Listen("unix", filepath.Join(t.TempDir(), "api.sock"))
+	require.NoError(t, err)
+	require.NoError(t, l.Close())
+
+	s := newTestServer(&fakeRunner{})
+	done := make(chan error, 1)
+	go func() {
+		done <- s.serve(context.Background(), l)
+	}()
+
+	select {
+	case err := <-done:
+		assert.Error(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("serve didn't return after the server failed")
+	}
+}
+
+func TestServe(t *testing.T) {
+	dir, err := os.MkdirTemp("", "okteto-api")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "api.sock")
+
+	s := newTestServer(&fakeRunner{})
+	s.socket = socket
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Serve(ctx)
+	}()
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+			},
+		},
+	}
+	require.Eventually(t, func() bool {
+		resp, err := client.Get("http://okteto/v1/version")
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		return resp.StatusCode == http.StatusOK
+	}, 5*time.Second, 10*time.Millisecond)
+
+	info, err := os.Stat(socket)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	second := newTestServer(&fakeRunner{})
+	second.socket = socket
+	assert.ErrorIs(t, second.Serve(context.Background()), ErrAlreadyRunning)
+
+	cancel()
+	assert.NoError(t, <-done)
+	assert.NoFileExists(t, socket)
+}
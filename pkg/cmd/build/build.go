@@ -45,6 +45,9 @@ import (
 const (
 	warningDockerfilePath   string = "Build '%s': Dockerfile '%s' is not in a relative path to context '%s'"
 	doubleDockerfileWarning string = "Build '%s': Two Dockerfiles discovered in both the root and context path, defaulting to '%s/%s'"
+
+	// minMaskedSecretLength is the minimum length of the secret values masked from the logs
+	minMaskedSecretLength = 4
 )
 
 var (
@@ -77,7 +80,13 @@ func (ob *OktetoBuilder) GetBuilder() string {
 // Run runs the build sequence
 func (ob *OktetoBuilder) Run(ctx context.Context, buildOptions *types.BuildOptions, ioCtrl *io.IOController) error {
 	buildOptions.OutputMode = setOutputMode(buildOptions.OutputMode)
+	if err := validatePlatforms(buildOptions, ob.OktetoContext.GetCurrentBuilder() == ""); err != nil {
+		return err
+	}
 	if ob.OktetoContext.GetCurrentBuilder() == "" {
+		if buildOptions.Output != nil {
+			return errOutputDocker
+		}
 		if err := ob.buildWithDocker(ctx, buildOptions); err != nil {
 			return err
 		}
@@ -93,11 +102,11 @@ func setOutputMode(outputMode string) string {
 	if outputMode != "" {
 		return outputMode
 	}
-	switch os.Getenv(model.BuildkitProgressEnvVar) {
+	switch config.EnvBuildkitProgress.Value() {
 	case oktetoLog.PlainFormat:
 		return oktetoLog.PlainFormat
 	case oktetoLog.JSONFormat:
-		return oktetoLog.PlainFormat
+		return oktetoLog.JSONFormat
 	default:
 		return oktetoLog.TTYFormat
 	}
@@ -121,6 +130,11 @@ func GetRegistryConfigFromOktetoConfig(okCtx OktetoContextInterface) *okteto.Con
 
 func (ob *OktetoBuilder) buildWithOkteto(ctx context.Context, buildOptions *types.BuildOptions, ioCtrl *io.IOController) error {
 	oktetoLog.Infof("building your image on %s", ob.OktetoContext.GetCurrentBuilder())
+	if timeout := config.GetBuildkitTimeout(); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
 	buildkitClient, err := getBuildkitClient(ctx, ob.OktetoContext)
 	if err != nil {
 		return err
@@ -165,10 +179,10 @@ func (ob *OktetoBuilder) buildWithOkteto(ctx context.Context, buildOptions *type
 
 	// inject secrets to buildkit from temp folder
 	if err := replaceSecretsSourceEnvWithTempFile(afero.NewOsFs(), secretTempFolder, buildOptions); err != nil {
-		return fmt.Errorf("%w: secret should have the format 'id=mysecret,src=/local/secret'", err)
+		return fmt.Errorf("%w: secret should have the format 'id=mysecret,src=/local/secret' or 'id=mysecret,env=MY_SECRET'", err)
 	}
 
-	opt, err := getSolveOpt(buildOptions, ob.OktetoContext)
+	opt, err := getSolveOpt(buildOptions, ob.OktetoContext, ioCtrl)
 	if err != nil {
 		return errors.Wrap(err, "failed to create build solver")
 	}
@@ -192,7 +206,7 @@ func (ob *OktetoBuilder) buildWithOkteto(ctx context.Context, buildOptions *type
 		return err
 	}
 
-	if err == nil && buildOptions.Tag != "" {
+	if err == nil && buildOptions.Tag != "" && buildOptions.Output == nil {
 		if _, err := registry.NewOktetoRegistry(GetRegistryConfigFromOktetoConfig(ob.OktetoContext)).GetImageTagWithDigest(buildOptions.Tag); err != nil {
 			oktetoLog.Yellow(`Failed to push '%s' metadata to the registry:
 	  %s,
@@ -379,6 +393,12 @@ func OptsFromBuildInfo(manifestName, svcName string, b *build.Info, o *types.Bui
 		NoCache:     o.NoCache,
 		ExportCache: b.ExportCache,
 		Platform:    o.Platform,
+		Output:      o.Output,
+		Scan:        o.Scan || b.Scan.IsEnabled(),
+		ScanFailOn:  o.ScanFailOn,
+	}
+	if opts.ScanFailOn == "" && b.Scan.IsEnabled() {
+		opts.ScanFailOn = b.Scan.FailOn
 	}
 
 	// if secrets are present at the cmd flag, copy them to opts.Secrets
@@ -430,7 +450,8 @@ func extractFromContextAndDockerfile(context, dockerfile, svcName string) string
 	return joinPath
 }
 
-// replaceSecretsSourceEnvWithTempFile reads the content of the src of a secret and replaces the envs to mount into dockerfile
+// replaceSecretsSourceEnvWithTempFile reads the content of the src of a secret and replaces the envs to mount into dockerfile.
+// Secrets sourced from an env var are written to a temp file too, so buildkit always mounts them from a file
 func replaceSecretsSourceEnvWithTempFile(fs afero.Fs, secretTempFolder string, buildOptions *types.BuildOptions) error {
 	// for each secret at buildOptions extract the src
 	// read the content of the file
@@ -443,27 +464,90 @@ func replaceSecretsSourceEnvWithTempFile(fs afero.Fs, secretTempFolder string, b
 			return fmt.Errorf("error reading the csv secret, %w", err)
 		}
 
-		newFields := make([]string, len(fields))
-		for indx, field := range fields {
+		id := ""
+		isEnvSecret := false
+		for _, field := range fields {
 			key, value, found := strings.Cut(field, "=")
 			if !found {
 				return fmt.Errorf("secret format error")
 			}
+			switch strings.ToLower(key) {
+			case "id":
+				id = value
+			case "type":
+				isEnvSecret = value == "env"
+			}
+		}
 
-			if key == "src" || key == "source" {
-				tempFileName, err := createTempFileWithExpandedEnvsAtSource(fs, value, secretTempFolder)
+		hasSource := false
+		newFields := make([]string, 0, len(fields))
+		for _, field := range fields {
+			key, value, _ := strings.Cut(field, "=")
+
+			switch strings.ToLower(key) {
+			case "type":
+				if isEnvSecret {
+					// the env is replaced by a file, so the type no longer applies
+					continue
+				}
+			case "src", "source":
+				hasSource = true
+				var tempFileName string
+				if isEnvSecret {
+					tempFileName, err = createTempFileWithEnvValue(fs, value, secretTempFolder)
+				} else {
+					tempFileName, err = createTempFileWithExpandedEnvsAtSource(fs, value, secretTempFolder)
+				}
 				if err != nil {
 					return fmt.Errorf("error creating the temp file with expanded values: %w", err)
 				}
 				value = tempFileName
+			case "env":
+				hasSource = true
+				tempFileName, err := createTempFileWithEnvValue(fs, value, secretTempFolder)
+				if err != nil {
+					return fmt.Errorf("error creating the temp file with the env value: %w", err)
+				}
+				key = "src"
+				value = tempFileName
+			}
+			newFields = append(newFields, fmt.Sprintf("%s=%s", key, value))
+		}
+
+		// buildkit reads 'type=env' secrets without source from the env var named as the id
+		if isEnvSecret && !hasSource && id != "" {
+			tempFileName, err := createTempFileWithEnvValue(fs, id, secretTempFolder)
+			if err != nil {
+				return fmt.Errorf("error creating the temp file with the env value: %w", err)
 			}
-			newFields[indx] = fmt.Sprintf("%s=%s", key, value)
+			newFields = append(newFields, fmt.Sprintf("src=%s", tempFileName))
 		}
 		buildOptions.Secrets[indx] = strings.Join(newFields, ",")
 	}
 	return nil
 }
 
+// createTempFileWithEnvValue creates a temp file with the value of a local env var
+func createTempFileWithEnvValue(fs afero.Fs, envName, tempFolder string) (string, error) {
+	value, ok := os.LookupEnv(envName)
+	if !ok {
+		return "", fmt.Errorf("environment variable '%s' is not defined", envName)
+	}
+	maskSecretValue(value)
+
+	tmpfile, err := afero.TempFile(fs, tempFolder, "secret-")
+	if err != nil {
+		return "", err
+	}
+	if _, err := tmpfile.WriteString(value); err != nil {
+		return "", fmt.Errorf("unable to write to temp file: %w", err)
+	}
+	if err := tmpfile.Close(); err != nil {
+		return "", err
+	}
+	return tmpfile.Name(), nil
+}
+
 // createTempFileWithExpandedEnvsAtSource creates a temp file with the expanded values of envs in local secrets
 func createTempFileWithExpandedEnvsAtSource(fs afero.Fs, sourceFile, tempFolder string) (string, error) {
 	srcFile, err := fs.Open(sourceFile)
@@ -479,6 +563,7 @@ func createTempFileWithExpandedEnvsAtSource(fs afero.Fs, sourceFile, tempFolder
 
 	writer := bufio.NewWriter(tmpfile)
 
+	lines := []string{}
 	sc := bufio.NewScanner(srcFile)
 	for sc.Scan() {
 		// expand content
@@ -486,6 +571,7 @@ func createTempFileWithExpandedEnvsAtSource(fs afero.Fs, sourceFile, tempFolder
 		if err != nil {
 			return "", err
 		}
+		lines = append(lines, srcContent)
 
 		// save expanded to temp file
 		if _, err = writer.Write([]byte(fmt.Sprintf("%s\n", srcContent))); err != nil {
@@ -499,5 +585,15 @@ func createTempFileWithExpandedEnvsAtSource(fs afero.Fs, sourceFile, tempFolder
 	if err := srcFile.Close(); err != nil {
 		return "", err
 	}
+	maskSecretValue(strings.Join(lines, "\n"))
 	return tmpfile.Name(), sc.Err()
 }
+
+// maskSecretValue redacts the whole value of a secret from the logs.
+// Short values are not masked because they would redact unrelated output
+func maskSecretValue(value string) {
+	if len(strings.TrimSpace(value)) < minMaskedSecretLength {
+		return
+	}
+	oktetoLog.AddMaskedWord(value)
+}
@@ -50,6 +50,7 @@ import (
 	"github.com/moby/buildkit/util/progress/progresswriter"
 	"github.com/moby/term"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/registry/login"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
@@ -68,6 +69,7 @@ func buildWithDockerDaemonBuildkit(ctx context.Context, buildOptions *types.Buil
 	}
 
 	dockerCfg := config.LoadDefaultConfigFile(os.Stderr)
+	login.AddToDockerConfig(dockerCfg)
 	dockerAuthProvider := authprovider.NewDockerAuthProvider(dockerCfg)
 	s.Allow(dockerAuthProvider)
 	if len(buildOptions.Secrets) > 0 {
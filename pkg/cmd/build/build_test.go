@@ -32,6 +32,8 @@ import (
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 func Test_validateImage(t *testing.T) {
@@ -602,7 +604,7 @@ func removeFile(s string) error {
 }
 
 func Test_replaceSecretsSourceEnvWithTempFile(t *testing.T) {
-	t.Parallel()
+	t.Setenv("TEST_BUILD_SECRET_ENV", "my-secret-value")
 	fakeFs := afero.NewMemMapFs()
 	localSrcFile, err := afero.TempFile(fakeFs, t.TempDir(), "")
 	require.NoError(t, err)
@@ -664,6 +666,36 @@ func Test_replaceSecretsSourceEnvWithTempFile(t *testing.T) {
 			expectedErr:             true,
 			expectedReplacedSecrets: false,
 		},
+		{
+			name:             "valid secret from env",
+			fs:               fakeFs,
+			secretTempFolder: t.TempDir(),
+			buildOptions: &types.BuildOptions{
+				Secrets: []string{"id=mysecret,env=TEST_BUILD_SECRET_ENV"},
+			},
+			expectedErr:             false,
+			expectedReplacedSecrets: true,
+		},
+		{
+			name:             "valid secret from env with type",
+			fs:               fakeFs,
+			secretTempFolder: t.TempDir(),
+			buildOptions: &types.BuildOptions{
+				Secrets: []string{"id=TEST_BUILD_SECRET_ENV,type=env"},
+			},
+			expectedErr:             false,
+			expectedReplacedSecrets: true,
+		},
+		{
+			name:             "invalid secret, env is not defined",
+			fs:               fakeFs,
+			secretTempFolder: t.TempDir(),
+			buildOptions: &types.BuildOptions{
+				Secrets: []string{"id=mysecret,env=TEST_BUILD_SECRET_UNDEFINED_ENV"},
+			},
+			expectedErr:             true,
+			expectedReplacedSecrets: false,
+		},
 		{
 			name:             "invalid secret, no = found",
 			fs:               fakeFs,
@@ -678,8 +710,6 @@ func Test_replaceSecretsSourceEnvWithTempFile(t *testing.T) {
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-
 			initialSecrets := make([]string, len(tt.buildOptions.Secrets))
 			copy(initialSecrets, tt.buildOptions.Secrets)
 			err := replaceSecretsSourceEnvWithTempFile(tt.fs, tt.secretTempFolder, tt.buildOptions)
@@ -809,7 +839,7 @@ func Test_setOutputMode(t *testing.T) {
 			name:                     "empty input and json env BUILDKIT_PROGRESS  - default output",
 			input:                    "",
 			envBuildkitProgressValue: "json",
-			expected:                 "plain",
+			expected:                 "json",
 		},
 	}
 
@@ -821,3 +851,79 @@ func Test_setOutputMode(t *testing.T) {
 		})
 	}
 }
+
+func Test_createTempFileWithEnvValue(t *testing.T) {
+	t.Setenv("TEST_BUILD_SECRET_ENV", "my-secret-value")
+	fakeFs := afero.NewMemMapFs()
+
+	file, err := createTempFileWithEnvValue(fakeFs, "TEST_BUILD_SECRET_ENV", t.TempDir())
+	require.NoError(t, err)
+
+	content, err := afero.ReadFile(fakeFs, file)
+	require.NoError(t, err)
+	require.Equal(t, "my-secret-value", string(content))
+
+	_, err = createTempFileWithEnvValue(fakeFs, "TEST_BUILD_SECRET_UNDEFINED_ENV", t.TempDir())
+	require.Error(t, err)
+}
+
+func Test_createTempFileWithExpandedEnvsAtSourceMasksWholeValue(t *testing.T) {
+	fakeFs := afero.NewMemMapFs()
+	src := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, afero.WriteFile(fakeFs, src, []byte("first-secret-line\nsecond-secret-line\n"), 0600))
+
+	_, err := createTempFileWithExpandedEnvsAtSource(fakeFs, src, t.TempDir())
+	require.NoError(t, err)
+
+	require.Equal(t, "***", oktetoLog.Redact("first-secret-line\nsecond-secret-line"))
+	require.Equal(t, "first-secret-line", oktetoLog.Redact("first-secret-line"))
+}
+
+func Test_maskSecretValue(t *testing.T) {
+	maskSecretValue("abc")
+	require.Equal(t, "abc", oktetoLog.Redact("abc"))
+
+	maskSecretValue("masked-secret-value")
+	require.Equal(t, "***", oktetoLog.Redact("masked-secret-value"))
+}
+
+func TestSetRunnerAttrs(t *testing.T) {
+	tests := []struct {
+		remoteDeploy *model.RemoteDeploy
+		expected     map[string]string
+		name         string
+	}{
+		{
+			name:     "nil",
+			expected: map[string]string{},
+		},
+		{
+			name:         "no execution settings",
+			remoteDeploy: &model.RemoteDeploy{Enabled: true, Image: "okteto/runner:1.0"},
+			expected:     map[string]string{},
+		},
+		{
+			name: "execution settings",
+			remoteDeploy: &model.RemoteDeploy{
+				Enabled:        true,
+				ServiceAccount: "deployer",
+				Resources: model.ResourceRequirements{
+					Limits: model.ResourceList{apiv1.ResourceMemory: resource.MustParse("1Gi")},
+				},
+				Tolerations: []apiv1.Toleration{{Key: "pool", Operator: apiv1.TolerationOpExists}},
+			},
+			expected: map[string]string{
+				runnerResourcesAttr:      `{"limits":{"memory":"1Gi"}}`,
+				runnerTolerationsAttr:    `[{"key":"pool","operator":"Exists"}]`,
+				runnerServiceAccountAttr: "deployer",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attrs := map[string]string{}
+			require.NoError(t, setRunnerAttrs(attrs, tt.remoteDeploy))
+			assert.Equal(t, tt.expected, attrs)
+		})
+	}
+}
@@ -16,13 +16,13 @@ package build
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
 
-	"github.com/containerd/console"
 	dockerConfig "github.com/docker/cli/cli/config"
 	"github.com/moby/buildkit/client"
 	buildkit "github.com/moby/buildkit/cmd/buildctl/build"
@@ -33,23 +33,61 @@ import (
 	"github.com/okteto/okteto/pkg/config"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/log/io"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/registry/login"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/pkg/errors"
 	"golang.org/x/oauth2"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc/credentials/oauth"
+	apiv1 "k8s.io/api/core/v1"
 )
 
 const (
 	defaultFrontend = "dockerfile.v0"
+
+	// runnerResourcesAttr, runnerTolerationsAttr and runnerServiceAccountAttr are the frontend attributes
+	// the Okteto builder uses to configure the runner of a remote deploy
+	runnerResourcesAttr      = "okteto:runner-resources"
+	runnerTolerationsAttr    = "okteto:runner-tolerations"
+	runnerServiceAccountAttr = "okteto:runner-service-account"
 )
 
 type buildWriter struct{}
 
+// setRunnerAttrs adds the resources, tolerations and service account of the remote deploy runner to the frontend attributes
+func setRunnerAttrs(frontendAttrs map[string]string, remoteDeploy *model.RemoteDeploy) error {
+	if !remoteDeploy.HasExecutionSettings() {
+		return nil
+	}
+	if len(remoteDeploy.Resources.Limits) > 0 || len(remoteDeploy.Resources.Requests) > 0 {
+		resources, err := json.Marshal(apiv1.ResourceRequirements{
+			Limits:   apiv1.ResourceList(remoteDeploy.Resources.Limits),
+			Requests: apiv1.ResourceList(remoteDeploy.Resources.Requests),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to encode the resources of the remote deploy runner: %w", err)
+		}
+		frontendAttrs[runnerResourcesAttr] = string(resources)
+	}
+	if len(remoteDeploy.Tolerations) > 0 {
+		tolerations, err := json.Marshal(remoteDeploy.Tolerations)
+		if err != nil {
+			return fmt.Errorf("failed to encode the tolerations of the remote deploy runner: %w", err)
+		}
+		frontendAttrs[runnerTolerationsAttr] = string(tolerations)
+	}
+	if remoteDeploy.ServiceAccount != "" {
+		frontendAttrs[runnerServiceAccountAttr] = remoteDeploy.ServiceAccount
+	}
+	return nil
+}
+
 // getSolveOpt returns the buildkit solve options
-func getSolveOpt(buildOptions *types.BuildOptions, okctx OktetoContextInterface) (*client.SolveOpt, error) {
+func getSolveOpt(buildOptions *types.BuildOptions, okctx OktetoContextInterface, ioCtrl *io.IOController) (*client.SolveOpt, error) {
 	var localDirs map[string]string
 	var frontendAttrs map[string]string
+	var uploader *contextUploader
 
 	if uri, err := url.ParseRequestURI(buildOptions.Path); err != nil || (uri != nil && (uri.Scheme == "" || uri.Host == "")) {
 
@@ -66,6 +104,24 @@ func getSolveOpt(buildOptions *types.BuildOptions, okctx OktetoContextInterface)
 		frontendAttrs = map[string]string{
 			"filename": filepath.Base(buildOptions.File),
 		}
+		if isContextCompressionEnabled() {
+			cwd, err := os.Getwd()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get the current working directory: %w", err)
+			}
+			contextDir := buildOptions.Path
+			if contextDir == "" {
+				contextDir = "."
+			}
+			excludes, err := getContextExcludes(contextDir, buildOptions.File, cwd)
+			if err != nil {
+				return nil, err
+			}
+			uploader = newContextUploader(contextDir, excludes, buildOptions.OutputMode, ioCtrl)
+			delete(localDirs, "context")
+			frontendAttrs["context"] = uploader.URL()
+			frontendAttrs[keyDockerfileLocalName] = "dockerfile"
+		}
 	} else {
 		frontendAttrs = map[string]string{
 			"context": buildOptions.Path,
@@ -102,6 +158,9 @@ func getSolveOpt(buildOptions *types.BuildOptions, okctx OktetoContextInterface)
 		}
 		frontendAttrs["build-arg:"+kv[0]] = kv[1]
 	}
+	if err := setRunnerAttrs(frontendAttrs, buildOptions.RemoteDeploy); err != nil {
+		return nil, err
+	}
 	attachable := []session.Attachable{}
 	if okctx.IsOkteto() {
 		apCtx := &authProviderContext{
@@ -112,9 +171,11 @@ func getSolveOpt(buildOptions *types.BuildOptions, okctx OktetoContextInterface)
 		}
 
 		ap := newDockerAndOktetoAuthProvider(okctx.GetCurrentRegister(), okctx.GetCurrentUser(), okctx.GetCurrentToken(), apCtx, os.Stderr)
+		login.AddToDockerConfig(ap.config)
 		attachable = append(attachable, ap)
 	} else {
 		dockerCfg := dockerConfig.LoadDefaultConfigFile(os.Stderr)
+		login.AddToDockerConfig(dockerCfg)
 		attachable = append(attachable, authprovider.NewDockerAuthProvider(dockerCfg))
 	}
 
@@ -131,6 +192,10 @@ func getSolveOpt(buildOptions *types.BuildOptions, okctx OktetoContextInterface)
 		attachable = append(attachable, ssh)
 	}
 
+	if uploader != nil {
+		attachable = append(attachable, uploader)
+	}
+
 	if len(buildOptions.Secrets) > 0 {
 		secretProvider, err := buildkit.ParseSecret(buildOptions.Secrets)
 		if err != nil {
@@ -172,6 +237,19 @@ func getSolveOpt(buildOptions *types.BuildOptions, okctx OktetoContextInterface)
 		}
 
 	}
+
+	if buildOptions.Output != nil {
+		// the image is exported to the local output instead of being pushed
+		opt.Exports = []client.ExportEntry{getOutputExport(buildOptions.Output, buildOptions.Tag)}
+	}
+
+	if buildOptions.LocalOutputPath != "" {
+		opt.Exports = append(opt.Exports, client.ExportEntry{
+			Type:      client.ExporterLocal,
+			OutputDir: buildOptions.LocalOutputPath,
+		})
+	}
+
 	for _, cacheFromImage := range buildOptions.CacheFrom {
 		opt.CacheImports = append(
 			opt.CacheImports,
@@ -272,9 +350,11 @@ func solveBuild(ctx context.Context, c *client.Client, opt *client.SolveOpt, pro
 	}
 	logFilter := NewBuildKitLogsFilter(logFilterRules)
 	ch := make(chan *client.SolveStatus)
-	ttyChannel := make(chan *client.SolveStatus)
+	displayChannel := make(chan *client.SolveStatus)
 	plainChannel := make(chan *client.SolveStatus)
 	commandFailChannel := make(chan error, 1)
+	// tty and json progress are rendered from their own channel while the plain one feeds the buffer
+	hasDisplayChannel := progress == oktetoLog.TTYFormat || progress == oktetoLog.JSONFormat
 
 	eg, ctx := errgroup.WithContext(ctx)
 	eg.Go(func() error {
@@ -293,8 +373,8 @@ func solveBuild(ctx context.Context, c *client.Client, opt *client.SolveOpt, pro
 				if ok {
 					logFilter.Run(ss, progress)
 					plainChannel <- ss
-					if progress == oktetoLog.TTYFormat {
-						ttyChannel <- ss
+					if hasDisplayChannel {
+						displayChannel <- ss
 					}
 				} else {
 					done = true
@@ -302,8 +382,8 @@ func solveBuild(ctx context.Context, c *client.Client, opt *client.SolveOpt, pro
 			}
 			if done {
 				close(plainChannel)
-				if progress == oktetoLog.TTYFormat {
-					close(ttyChannel)
+				if hasDisplayChannel {
+					close(displayChannel)
 				}
 				break
 			}
@@ -314,25 +394,21 @@ func solveBuild(ctx context.Context, c *client.Client, opt *client.SolveOpt, pro
 	eg.Go(func() error {
 
 		w := &buildWriter{}
-		switch progress {
-		case oktetoLog.TTYFormat:
-			var c console.Console
-
-			if cn, err := console.ConsoleFromFile(os.Stdout); err == nil {
-				c = cn
-			} else {
-				oktetoLog.Debugf("could not create console from file: %s ", err)
-			}
+		if hasDisplayChannel {
 			go func() {
 				// We use the plain channel to store the logs into a buffer and then show them in the UI
 				if _, err := progressui.DisplaySolveStatus(context.TODO(), "", nil, w, plainChannel); err != nil {
 					oktetoLog.Infof("could not display solve status: %s", err)
 				}
 			}()
+		}
+		switch progress {
+		case oktetoLog.TTYFormat:
 			// not using shared context to not disrupt display but let it finish reporting errors
 			// We need to wait until the tty channel is closed to avoid writing to stdout while the tty is being used
-			_, err := progressui.DisplaySolveStatus(context.TODO(), "", c, ioCtrl.Out(), ttyChannel)
-			return err
+			return displayTTYProgress(context.TODO(), displayChannel, ioCtrl.Out())
+		case oktetoLog.JSONFormat:
+			return displayJSONProgress(context.TODO(), displayChannel, ioCtrl.Out())
 		case "deploy":
 			err := deployDisplayer(context.TODO(), plainChannel, &types.BuildOptions{OutputMode: "deploy"})
 			commandFailChannel <- err
@@ -0,0 +1,99 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/moby/buildkit/client"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/types"
+)
+
+const (
+	// OutputDocker exports the image as a docker archive that can be loaded with 'docker load'
+	OutputDocker = "docker"
+
+	// OutputOCI exports the image as an OCI image layout directory
+	OutputOCI = "oci"
+)
+
+var (
+	errOutputDocker = oktetoErrors.UserError{
+		E:    fmt.Errorf("the flag '--output' is not supported by the Docker Daemon"),
+		Hint: "Build the image with the Okteto builder or configure a builder endpoint with 'okteto context --builder BUILDKIT_URL'",
+	}
+)
+
+// ParseOutput returns the output of a value like 'type=docker,dest=image.tar' or 'type=oci,dest=./image'
+func ParseOutput(value string) (*types.BuildOutput, error) {
+	output := &types.BuildOutput{}
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		kv := strings.SplitN(field, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid output '%s': the format is 'type=docker|oci,dest=PATH'", value)
+		}
+		switch strings.ToLower(kv[0]) {
+		case "type":
+			output.Type = strings.ToLower(kv[1])
+		case "dest":
+			output.Dest = kv[1]
+		default:
+			return nil, fmt.Errorf("invalid output '%s': unknown field '%s'", value, kv[0])
+		}
+	}
+
+	if output.Type != OutputDocker && output.Type != OutputOCI {
+		return nil, fmt.Errorf("invalid output '%s': type must be '%s' or '%s'", value, OutputDocker, OutputOCI)
+	}
+	if output.Dest == "" {
+		return nil, fmt.Errorf("invalid output '%s': dest is required", value)
+	}
+	return output, nil
+}
+
+// getOutputExport returns the buildkit export of the image into the local output
+func getOutputExport(output *types.BuildOutput, tag string) client.ExportEntry {
+	attrs := map[string]string{}
+	if tag != "" {
+		attrs["name"] = tag
+	}
+	if output.Type == OutputOCI {
+		attrs["tar"] = "false"
+		return client.ExportEntry{
+			Type:      client.ExporterOCI,
+			Attrs:     attrs,
+			OutputDir: output.Dest,
+		}
+	}
+	dest := output.Dest
+	return client.ExportEntry{
+		Type:  client.ExporterDocker,
+		Attrs: attrs,
+		Output: func(map[string]string) (io.WriteCloser, error) {
+			if err := os.MkdirAll(filepath.Dir(dest), 0700); err != nil {
+				return nil, err
+			}
+			return os.Create(dest)
+		},
+	}
+}
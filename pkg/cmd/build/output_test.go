@@ -0,0 +1,92 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/moby/buildkit/client"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOutput(t *testing.T) {
+	tests := []struct {
+		expected *types.BuildOutput
+		name     string
+		value    string
+		wantErr  bool
+	}{
+		{
+			name:     "docker archive",
+			value:    "type=docker,dest=image.tar",
+			expected: &types.BuildOutput{Type: OutputDocker, Dest: "image.tar"},
+		},
+		{
+			name:     "oci layout",
+			value:    "type=OCI, dest=./image",
+			expected: &types.BuildOutput{Type: OutputOCI, Dest: "./image"},
+		},
+		{
+			name:    "unknown type",
+			value:   "type=registry,dest=image.tar",
+			wantErr: true,
+		},
+		{
+			name:    "missing dest",
+			value:   "type=docker",
+			wantErr: true,
+		},
+		{
+			name:    "unknown field",
+			value:   "type=docker,dest=image.tar,push=true",
+			wantErr: true,
+		},
+		{
+			name:    "invalid format",
+			value:   "image.tar",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := ParseOutput(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, output)
+		})
+	}
+}
+
+func Test_getOutputExport(t *testing.T) {
+	export := getOutputExport(&types.BuildOutput{Type: OutputOCI, Dest: "./image"}, "okteto.dev/api:1.0")
+	assert.Equal(t, client.ExporterOCI, export.Type)
+	assert.Equal(t, "./image", export.OutputDir)
+	assert.Equal(t, map[string]string{"name": "okteto.dev/api:1.0", "tar": "false"}, export.Attrs)
+
+	dest := filepath.Join(t.TempDir(), "out", "image.tar")
+	export = getOutputExport(&types.BuildOutput{Type: OutputDocker, Dest: dest}, "")
+	assert.Equal(t, client.ExporterDocker, export.Type)
+	assert.Empty(t, export.Attrs)
+	require.NotNil(t, export.Output)
+	w, err := export.Output(nil)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	assert.FileExists(t, dest)
+}
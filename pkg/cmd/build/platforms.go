@@ -0,0 +1,75 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"fmt"
+	"strings"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/types"
+)
+
+var (
+	errMultiPlatformDocker = oktetoErrors.UserError{
+		E:    fmt.Errorf("multi-platform builds are not supported by the Docker Daemon"),
+		Hint: "Build the image with the Okteto builder or configure a builder endpoint with 'okteto context --builder BUILDKIT_URL'",
+	}
+)
+
+// parsePlatforms returns the platforms of a comma separated list like 'linux/amd64,linux/arm64'
+func parsePlatforms(value string) ([]string, error) {
+	platforms := []string{}
+	seen := map[string]bool{}
+	for _, p := range strings.Split(value, ",") {
+		p = strings.ToLower(strings.TrimSpace(p))
+		if p == "" {
+			continue
+		}
+		parts := strings.Split(p, "/")
+		if len(parts) < 2 || len(parts) > 3 {
+			return nil, fmt.Errorf("invalid platform '%s': the format is 'os/arch[/variant]'", p)
+		}
+		for _, part := range parts {
+			if part == "" {
+				return nil, fmt.Errorf("invalid platform '%s': the format is 'os/arch[/variant]'", p)
+			}
+		}
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		platforms = append(platforms, p)
+	}
+	return platforms, nil
+}
+
+// validatePlatforms normalizes the platforms of the build and checks the builder supports them
+func validatePlatforms(buildOptions *types.BuildOptions, isDocker bool) error {
+	if buildOptions.Platform == "" {
+		return nil
+	}
+	platforms, err := parsePlatforms(buildOptions.Platform)
+	if err != nil {
+		return oktetoErrors.UserError{
+			E:    err,
+			Hint: "Use a comma separated list of platforms, for example: --platform linux/amd64,linux/arm64",
+		}
+	}
+	if len(platforms) > 1 && isDocker {
+		return errMultiPlatformDocker
+	}
+	buildOptions.Platform = strings.Join(platforms, ",")
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parsePlatforms(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected []string
+		wantErr  bool
+	}{
+		{
+			name:     "single",
+			value:    "linux/amd64",
+			expected: []string{"linux/amd64"},
+		},
+		{
+			name:     "multiple",
+			value:    "linux/amd64, linux/arm64,linux/arm/v7",
+			expected: []string{"linux/amd64", "linux/arm64", "linux/arm/v7"},
+		},
+		{
+			name:     "duplicated",
+			value:    "linux/amd64,LINUX/AMD64,",
+			expected: []string{"linux/amd64"},
+		},
+		{
+			name:    "missing-arch",
+			value:   "linux",
+			wantErr: true,
+		},
+		{
+			name:    "empty-part",
+			value:   "linux//v7",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			platforms, err := parsePlatforms(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, platforms)
+		})
+	}
+}
+
+func Test_validatePlatforms(t *testing.T) {
+	opts := &types.BuildOptions{Platform: "linux/amd64 ,linux/arm64"}
+	require.NoError(t, validatePlatforms(opts, false))
+	assert.Equal(t, "linux/amd64,linux/arm64", opts.Platform)
+
+	opts = &types.BuildOptions{Platform: "linux/amd64,linux/arm64"}
+	assert.ErrorIs(t, validatePlatforms(opts, true), errMultiPlatformDocker)
+
+	opts = &types.BuildOptions{Platform: "linux/arm64"}
+	require.NoError(t, validatePlatforms(opts, true))
+	assert.Equal(t, "linux/arm64", opts.Platform)
+
+	opts = &types.BuildOptions{Platform: "arm64"}
+	assert.Error(t, validatePlatforms(opts, false))
+}
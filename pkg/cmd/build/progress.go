@@ -0,0 +1,212 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/moby/buildkit/client"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	oktetoIO "github.com/okteto/okteto/pkg/log/io"
+)
+
+const (
+	vertexRunning = "running"
+	vertexCached  = "cached"
+	vertexDone    = "done"
+	vertexError   = "error"
+	vertexLog     = "log"
+
+	// maxVertexLogs is the number of log lines of a vertex shown when it fails
+	maxVertexLogs = 10
+)
+
+// vertexEvent is a change of status or a log line of a step of the build
+type vertexEvent struct {
+	Vertex string `json:"vertex"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	Log    string `json:"log,omitempty"`
+	// Duration is the time in seconds taken by the step when it's done
+	Duration float64 `json:"duration,omitempty"`
+}
+
+type vertexState struct {
+	name   string
+	status string
+	logs   []string
+}
+
+// vertexTracker translates the status stream of buildkit into vertex events
+type vertexTracker struct {
+	vertexes map[string]*vertexState
+}
+
+func newVertexTracker() *vertexTracker {
+	return &vertexTracker{
+		vertexes: map[string]*vertexState{},
+	}
+}
+
+// update returns the events of the vertexes that changed their status and of their new log lines
+func (t *vertexTracker) update(ss *client.SolveStatus) []vertexEvent {
+	events := []vertexEvent{}
+	for _, v := range ss.Vertexes {
+		digest := v.Digest.String()
+		state, ok := t.vertexes[digest]
+		if !ok {
+			state = &vertexState{name: v.Name}
+			t.vertexes[digest] = state
+		}
+		status := getVertexStatus(v)
+		if status == "" || status == state.status {
+			continue
+		}
+		state.status = status
+		event := vertexEvent{Vertex: digest, Name: v.Name, Status: status, Error: v.Error}
+		if status == vertexDone && v.Started != nil && v.Completed != nil {
+			event.Duration = v.Completed.Sub(*v.Started).Round(100 * time.Millisecond).Seconds()
+		}
+		events = append(events, event)
+	}
+
+	for _, l := range ss.Logs {
+		digest := l.Vertex.String()
+		state, ok := t.vertexes[digest]
+		if !ok {
+			continue
+		}
+		for _, line := range strings.Split(strings.TrimRight(string(l.Data), "\n"), "\n") {
+			state.logs = append(state.logs, line)
+			if len(state.logs) > maxVertexLogs {
+				state.logs = state.logs[1:]
+			}
+			events = append(events, vertexEvent{Vertex: digest, Name: state.name, Status: vertexLog, Log: line})
+		}
+	}
+	return events
+}
+
+// logs returns the last log lines of a vertex
+func (t *vertexTracker) logs(digest string) []string {
+	if state, ok := t.vertexes[digest]; ok {
+		return state.logs
+	}
+	return nil
+}
+
+// running returns the sorted names of the vertexes that are running
+func (t *vertexTracker) running() []string {
+	result := []string{}
+	for _, state := range t.vertexes {
+		if state.status == vertexRunning {
+			result = append(result, state.name)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+func getVertexStatus(v *client.Vertex) string {
+	switch {
+	case v.Error != "":
+		return vertexError
+	case v.Completed != nil && v.Cached:
+		return vertexCached
+	case v.Completed != nil:
+		return vertexDone
+	case v.Cached:
+		return vertexCached
+	case v.Started != nil:
+		return vertexRunning
+	default:
+		return ""
+	}
+}
+
+// displayJSONProgress writes every vertex event of the build as a json message of the output controller, so the
+// events follow the format of the rest of the output
+func displayJSONProgress(ctx context.Context, ch chan *client.SolveStatus, out *oktetoIO.OutputController) error {
+	tracker := newVertexTracker()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case ss, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			for _, event := range tracker.update(ss) {
+				b, err := json.Marshal(event)
+				if err != nil {
+					oktetoLog.Infof("could not write build event: %s", err)
+					continue
+				}
+				out.Println(string(b))
+			}
+		}
+	}
+}
+
+// displayTTYProgress shows the steps of the build as they finish, with their duration, and a spinner with the running ones.
+// The last log lines of a step are shown when it fails
+func displayTTYProgress(ctx context.Context, ch chan *client.SolveStatus, out *oktetoIO.OutputController) error {
+	tracker := newVertexTracker()
+	var spinner oktetoIO.OktetoSpinner
+	defer func() {
+		if spinner != nil {
+			spinner.Stop()
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case ss, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			for _, event := range tracker.update(ss) {
+				switch event.Status {
+				case vertexCached:
+					out.Success("%s %s", event.Name, oktetoLog.BlueString("CACHED"))
+				case vertexDone:
+					out.Success("%s %s", event.Name, oktetoLog.BlueString("%.1fs", event.Duration))
+				case vertexError:
+					for _, line := range tracker.logs(event.Vertex) {
+						out.Println(fmt.Sprintf("  %s", line))
+					}
+					out.Println(fmt.Sprintf(" x %s: %s", event.Name, event.Error))
+				}
+			}
+
+			running := tracker.running()
+			if len(running) == 0 {
+				if spinner != nil {
+					spinner.Stop()
+				}
+				continue
+			}
+			spinner = out.Spinner(fmt.Sprintf("Building: %s", strings.Join(running, ", ")))
+			spinner.Start()
+		}
+	}
+}
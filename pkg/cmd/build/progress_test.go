@@ -0,0 +1,160 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"context"
+	"encoding/json"
+	goio "io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moby/buildkit/client"
+	"github.com/okteto/okteto/pkg/log/io"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	runDigest  = "sha256:run"
+	fromDigest = "sha256:from"
+)
+
+func TestVertexTrackerUpdate(t *testing.T) {
+	started := time.Date(2023, 10, 15, 10, 0, 0, 0, time.UTC)
+	completed := started.Add(3200 * time.Millisecond)
+
+	tracker := newVertexTracker()
+	events := tracker.update(&client.SolveStatus{
+		Vertexes: []*client.Vertex{
+			{Digest: fromDigest, Name: "[1/2] FROM alpine", Started: &started, Completed: &started, Cached: true},
+			{Digest: runDigest, Name: "[2/2] RUN make", Started: &started},
+		},
+		Logs: []*client.VertexLog{
+			{Vertex: runDigest, Data: []byte("building\n")},
+		},
+	})
+	assert.Equal(t, []vertexEvent{
+		{Vertex: fromDigest, Name: "[1/2] FROM alpine", Status: vertexCached},
+		{Vertex: runDigest, Name: "[2/2] RUN make", Status: vertexRunning},
+		{Vertex: runDigest, Name: "[2/2] RUN make", Status: vertexLog, Log: "building"},
+	}, events)
+	assert.Equal(t, []string{"[2/2] RUN make"}, tracker.running())
+
+	// the same status is not reported twice
+	events = tracker.update(&client.SolveStatus{
+		Vertexes: []*client.Vertex{
+			{Digest: runDigest, Name: "[2/2] RUN make", Started: &started},
+		},
+	})
+	assert.Empty(t, events)
+
+	events = tracker.update(&client.SolveStatus{
+		Vertexes: []*client.Vertex{
+			{Digest: runDigest, Name: "[2/2] RUN make", Started: &started, Completed: &completed},
+		},
+	})
+	assert.Equal(t, []vertexEvent{
+		{Vertex: runDigest, Name: "[2/2] RUN make", Status: vertexDone, Duration: 3.2},
+	}, events)
+	assert.Empty(t, tracker.running())
+}
+
+func TestVertexTrackerKeepsTheLastLogs(t *testing.T) {
+	started := time.Now()
+	tracker := newVertexTracker()
+	tracker.update(&client.SolveStatus{
+		Vertexes: []*client.Vertex{
+			{Digest: runDigest, Name: "RUN make", Started: &started},
+		},
+	})
+
+	lines := []string{}
+	for i := 0; i < maxVertexLogs+5; i++ {
+		lines = append(lines, strings.Repeat("x", i+1))
+	}
+	tracker.update(&client.SolveStatus{
+		Logs: []*client.VertexLog{
+			{Vertex: runDigest, Data: []byte(strings.Join(lines, "\n"))},
+		},
+	})
+	assert.Equal(t, lines[5:], tracker.logs(runDigest))
+
+	events := tracker.update(&client.SolveStatus{
+		Vertexes: []*client.Vertex{
+			{Digest: runDigest, Name: "RUN make", Started: &started, Completed: &started, Error: "exit code: 2"},
+		},
+	})
+	assert.Equal(t, []vertexEvent{
+		{Vertex: runDigest, Name: "RUN make", Status: vertexError, Error: "exit code: 2"},
+	}, events)
+}
+
+func TestDisplayJSONProgress(t *testing.T) {
+	started := time.Now()
+	ch := make(chan *client.SolveStatus, 1)
+	ch <- &client.SolveStatus{
+		Vertexes: []*client.Vertex{
+			{Digest: runDigest, Name: "RUN make", Started: &started},
+		},
+	}
+	close(ch)
+
+	initialStdout := os.Stdout
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	// replace Stdout for tests
+	os.Stdout = w
+	ioCtrl := io.NewIOController()
+	os.Stdout = initialStdout
+	ioCtrl.SetOutputFormat("json")
+	ioCtrl.SetStage("Build")
+
+	require.NoError(t, displayJSONProgress(context.Background(), ch, ioCtrl.Out()))
+	w.Close()
+	out, err := goio.ReadAll(r)
+	require.NoError(t, err)
+
+	message := struct {
+		Stage   string `json:"stage"`
+		Message string `json:"message"`
+	}{}
+	require.NoError(t, json.Unmarshal(out, &message))
+	assert.Equal(t, "Build", message.Stage)
+	event := vertexEvent{}
+	require.NoError(t, json.Unmarshal([]byte(message.Message), &event))
+	assert.Equal(t, "RUN make", event.Name)
+	assert.Equal(t, vertexRunning, event.Status)
+}
+
+func TestDisplayTTYProgress(t *testing.T) {
+	started := time.Now()
+	completed := started.Add(time.Second)
+	ch := make(chan *client.SolveStatus, 2)
+	ch <- &client.SolveStatus{
+		Vertexes: []*client.Vertex{
+			{Digest: runDigest, Name: "RUN make", Started: &started},
+		},
+	}
+	ch <- &client.SolveStatus{
+		Vertexes: []*client.Vertex{
+			{Digest: runDigest, Name: "RUN make", Started: &started, Completed: &completed},
+		},
+	}
+	close(ch)
+
+	assert.NoError(t, displayTTYProgress(context.Background(), ch, io.NewIOController().Out()))
+}
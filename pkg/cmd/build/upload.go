@@ -0,0 +1,280 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	goio "io"
+	iofs "io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/idtools"
+	"github.com/moby/buildkit/frontend/dockerfile/dockerignore"
+	"github.com/moby/buildkit/identity"
+	"github.com/moby/buildkit/session/upload"
+	"github.com/moby/patternmatcher"
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/ignore"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/log/io"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// keyDockerfileLocalName makes the dockerfile frontend read the Dockerfile from a local dir when the build context is uploaded
+	keyDockerfileLocalName = "dockerfilekey"
+
+	// sessionUploadURL is the url used by buildkit to pull files from the build session
+	sessionUploadURL = "http://buildkit-session/"
+
+	// tarBlockSize is the size of the headers and the padding of the tar entries
+	tarBlockSize = 512
+
+	uploadChunkSize = 32 * 1024
+)
+
+// contextUploader serves the build context to buildkit as a compressed tarball through the session of the build.
+// The tarball is created every time buildkit pulls it, so the same solve options can be used to retry a build
+type contextUploader struct {
+	sizeErr  error
+	ioCtrl   *io.IOController
+	id       string
+	dir      string
+	progress string
+	excludes []string
+	size     int64
+	sizeOnce sync.Once
+}
+
+func newContextUploader(dir string, excludes []string, progress string, ioCtrl *io.IOController) *contextUploader {
+	return &contextUploader{
+		id:       identity.NewID(),
+		dir:      dir,
+		excludes: excludes,
+		progress: progress,
+		ioCtrl:   ioCtrl,
+	}
+}
+
+// URL returns the url of the build context in the session of the build
+func (u *contextUploader) URL() string {
+	return sessionUploadURL + u.id
+}
+
+// Register registers the upload service in the session of the build
+func (u *contextUploader) Register(server *grpc.Server) {
+	upload.RegisterUploadServer(server, u)
+}
+
+// Pull streams the compressed build context to buildkit
+func (u *contextUploader) Pull(stream upload.Upload_PullServer) error {
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	if paths := md.Get("urlpath"); len(paths) == 0 || path.Base(paths[0]) != u.id {
+		return fmt.Errorf("no build context in the session for %v", paths)
+	}
+
+	// the size is only computed once, the build context isn't walked again when buildkit retries the pull
+	u.sizeOnce.Do(func() {
+		u.size, u.sizeErr = getContextSize(u.dir, u.excludes)
+	})
+	if u.sizeErr != nil {
+		return fmt.Errorf("failed to read the build context: %w", u.sizeErr)
+	}
+	bar := u.progressBar()
+	bar.Start()
+	defer bar.Finish()
+
+	w := bufio.NewWriterSize(&uploadWriter{stream: stream}, uploadChunkSize)
+	if err := writeContext(w, u.dir, u.excludes, bar); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// progressBar returns the progress bar of the upload. The tty display of buildkit owns the terminal while
+// the build is solved, so in that case the progress is only logged instead of drawing a bar over it
+func (u *contextUploader) progressBar() io.OktetoProgressBar {
+	if u.progress == oktetoLog.TTYFormat {
+		return &uploadProgress{dir: u.dir, total: u.size}
+	}
+	return u.ioCtrl.Out().ProgressBar("uploading build context", u.size)
+}
+
+// uploadProgress logs the progress of the upload of the build context without writing to the terminal
+type uploadProgress struct {
+	dir     string
+	total   int64
+	current int64
+}
+
+// Start logs the size of the build context
+func (p *uploadProgress) Start() {
+	oktetoLog.Infof("uploading build context '%s' (%d bytes)", p.dir, p.total)
+}
+
+// Add increases the progress by n bytes
+func (p *uploadProgress) Add(n int) {
+	atomic.AddInt64(&p.current, int64(n))
+}
+
+// Finish logs the bytes uploaded
+func (p *uploadProgress) Finish() {
+	oktetoLog.Infof("uploaded build context '%s' (%d/%d bytes)", p.dir, atomic.LoadInt64(&p.current), p.total)
+}
+
+// uploadWriter sends the data written as messages of the upload stream
+type uploadWriter struct {
+	stream upload.Upload_PullServer
+}
+
+func (w *uploadWriter) Write(p []byte) (int, error) {
+	if err := w.stream.SendMsg(&upload.BytesMessage{Data: p}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// writeContext writes the build context as a gzip compressed tarball, without the files matching the excludes
+func writeContext(w goio.Writer, dir string, excludes []string, bar io.OktetoProgressBar) error {
+	tarball, err := archive.TarWithOptions(dir, &archive.TarOptions{
+		ExcludePatterns: excludes,
+		ChownOpts:       &idtools.Identity{UID: 0, GID: 0},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read the build context: %w", err)
+	}
+	defer tarball.Close()
+
+	gz := gzip.NewWriter(w)
+	if _, err := goio.Copy(gz, io.NewProgressReader(tarball, bar)); err != nil {
+		return fmt.Errorf("failed to upload the build context: %w", err)
+	}
+	return gz.Close()
+}
+
+// getContextSize estimates the size of the uncompressed tarball of the build context
+func getContextSize(dir string, excludes []string) (int64, error) {
+	pm, err := patternmatcher.New(excludes)
+	if err != nil {
+		return 0, err
+	}
+	// the two empty blocks at the end of the tarball
+	size := int64(2 * tarBlockSize)
+	err = filepath.WalkDir(dir, func(p string, d iofs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		excluded, err := pm.MatchesOrParentMatches(rel)
+		if err != nil {
+			return err
+		}
+		if excluded {
+			if d.IsDir() && !pm.Exclusions() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		size += tarBlockSize
+		if d.Type().IsRegular() {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			size += (info.Size() + tarBlockSize - 1) / tarBlockSize * tarBlockSize
+		}
+		return nil
+	})
+	return size, err
+}
+
+// getContextExcludes returns the patterns of the files of the build context that are not uploaded.
+// They are the rules of the .dockerignore file, looked up next to the Dockerfile as <Dockerfile>.dockerignore
+// first as docker does, or at the root of the build context, and the rules of the .oktetoignore file of the project for the files inside the build context
+func getContextExcludes(contextDir, dockerfile, projectDir string) ([]string, error) {
+	excludes := []string{}
+	candidates := []string{
+		dockerfile + ".dockerignore",
+		filepath.Join(contextDir, ".dockerignore"),
+	}
+	for _, candidate := range candidates {
+		patterns, err := readIgnoreFile(candidate)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		excludes = append(excludes, patterns...)
+		break
+	}
+
+	absContext, err := filepath.Abs(contextDir)
+	if err != nil {
+		return nil, err
+	}
+	absProject, err := filepath.Abs(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	folder, err := filepath.Rel(absProject, absContext)
+	if err != nil || folder == ".." || strings.HasPrefix(folder, ".."+string(filepath.Separator)) {
+		// the rules of the project don't apply to build contexts outside of it
+		return excludes, nil
+	}
+	oktetoIgnore, err := ignore.NewFromFile(filepath.Join(absProject, ignore.Filename))
+	if err != nil {
+		return nil, err
+	}
+	return append(excludes, oktetoIgnore.ContextPatterns(folder)...), nil
+}
+
+// isContextCompressionEnabled returns if the build context is uploaded as a compressed tarball instead of being synchronized file by file
+func isContextCompressionEnabled() bool {
+	enabled, err := config.EnvOktetoCompressBuildContext.Bool()
+	if err != nil {
+		oktetoLog.Infof("%s, the build context is compressed", err)
+		return true
+	}
+	return enabled
+}
+
+func readIgnoreFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			oktetoLog.Debugf("Error closing file %s: %s", path, err)
+		}
+	}()
+	return dockerignore.ReadAll(f)
+}
@@ -0,0 +1,195 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	goio "io"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/moby/buildkit/session/upload"
+	"github.com/okteto/okteto/pkg/config"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/log/io"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type fakeProgressBar struct {
+	current int64
+}
+
+func (*fakeProgressBar) Start()      {}
+func (p *fakeProgressBar) Add(n int) { p.current += int64(n) }
+func (*fakeProgressBar) Finish()     {}
+
+type fakePullServer struct {
+	grpc.ServerStream
+	ctx context.Context
+	buf bytes.Buffer
+}
+
+func (s *fakePullServer) Context() context.Context { return s.ctx }
+
+func (s *fakePullServer) SendMsg(m interface{}) error {
+	_, err := s.buf.Write(m.(*upload.BytesMessage).Data)
+	return err
+}
+
+func (*fakePullServer) Send(*upload.BytesMessage) error { return nil }
+
+func (*fakePullServer) Recv() (*upload.BytesMessage, error) { return nil, goio.EOF }
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	}
+}
+
+func readTarball(t *testing.T, r goio.Reader) []string {
+	t.Helper()
+	gz, err := gzip.NewReader(r)
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+	names := []string{}
+	for {
+		h, err := tr.Next()
+		if err == goio.EOF {
+			break
+		}
+		require.NoError(t, err)
+		names = append(names, filepath.Clean(h.Name))
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestGetContextExcludes(t *testing.T) {
+	project := t.TempDir()
+	writeFiles(t, project, map[string]string{
+		".oktetoignore":               "node_modules\napi/tmp\n**/.cache\n",
+		"api/.dockerignore":           "*.log\n",
+		"api/Dockerfile":              "FROM alpine",
+		"web/Dockerfile":              "FROM alpine",
+		"web/Dockerfile.dockerignore": "dist\n",
+		"web/.dockerignore":           "*.log\n",
+	})
+
+	excludes, err := getContextExcludes(filepath.Join(project, "api"), filepath.Join(project, "api", "Dockerfile"), project)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"*.log", "tmp", "**/.cache"}, excludes)
+
+	excludes, err = getContextExcludes(filepath.Join(project, "web"), filepath.Join(project, "web", "Dockerfile"), project)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dist", "**/.cache"}, excludes)
+
+	// the .dockerignore of the folder of the Dockerfile only applies when it is the build context
+	excludes, err = getContextExcludes(project, filepath.Join(project, "api", "Dockerfile"), project)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"node_modules", "api/tmp", "**/.cache"}, excludes)
+
+	excludes, err = getContextExcludes(project, filepath.Join(project, "web", "Dockerfile"), filepath.Join(project, "web"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dist"}, excludes)
+}
+
+func TestWriteContext(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"main.go":           "package main",
+		"app.log":           "log",
+		"src/index.js":      "console.log('hello')",
+		"node_modules/a.js": "module.exports = {}",
+	})
+	excludes := []string{"*.log", "node_modules"}
+
+	bar := &fakeProgressBar{}
+	var b bytes.Buffer
+	require.NoError(t, writeContext(&b, dir, excludes, bar))
+	assert.Equal(t, []string{"main.go", "src", "src/index.js"}, readTarball(t, &b))
+
+	size, err := getContextSize(dir, excludes)
+	require.NoError(t, err)
+	assert.Equal(t, size, bar.current)
+}
+
+func TestContextUploaderPull(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"main.go": "package main",
+		"app.log": "log",
+	})
+	ioCtrl := io.NewIOController()
+	ioCtrl.SetOutputFormat("plain")
+	u := newContextUploader(dir, []string{"*.log"}, "plain", ioCtrl)
+
+	// the build context is created every time it is pulled so builds can be retried
+	for i := 0; i < 2; i++ {
+		stream := &fakePullServer{
+			ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs("urlpath", "/"+u.id)),
+		}
+		require.NoError(t, u.Pull(stream))
+		assert.Equal(t, []string{"main.go"}, readTarball(t, &stream.buf))
+	}
+	size, err := getContextSize(dir, []string{"*.log"})
+	require.NoError(t, err)
+	assert.Equal(t, size, u.size)
+	assert.IsType(t, &uploadProgress{}, newContextUploader(dir, nil, oktetoLog.TTYFormat, ioCtrl).progressBar())
+
+	stream := &fakePullServer{
+		ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs("urlpath", "/unknown")),
+	}
+	require.Error(t, u.Pull(stream))
+}
+
+func TestGetSolveOptUploadsContext(t *testing.T) {
+	dir := t.TempDir()
+	dockerfile := filepath.Join(dir, "Dockerfile")
+	writeFiles(t, dir, map[string]string{"Dockerfile": "FROM alpine"})
+	okCtx := &okteto.OktetoContextStateless{
+		Store: &okteto.OktetoContextStore{
+			Contexts: map[string]*okteto.OktetoContext{
+				"test": {
+					Namespace: "test",
+				},
+			},
+			CurrentContext: "test",
+		},
+	}
+
+	opt, err := getSolveOpt(&types.BuildOptions{Path: dir, File: dockerfile}, okCtx, io.NewIOController())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"dockerfile": dir}, opt.LocalDirs)
+	assert.Regexp(t, "^http://buildkit-session/", opt.FrontendAttrs["context"])
+	assert.Equal(t, "dockerfile", opt.FrontendAttrs[keyDockerfileLocalName])
+
+	t.Setenv(config.EnvOktetoCompressBuildContext.Name, "false")
+	opt, err = getSolveOpt(&types.BuildOptions{Path: dir, File: dockerfile}, okCtx, io.NewIOController())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"context": dir, "dockerfile": dir}, opt.LocalDirs)
+	assert.NotContains(t, opt.FrontendAttrs, "context")
+}
@@ -0,0 +1,199 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	ps "github.com/mitchellh/go-ps"
+	"github.com/okteto/okteto/pkg/config"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	// EnvVar is set on the 'okteto up' processes running as a background daemon
+	EnvVar = "OKTETO_UP_DAEMON"
+
+	stateFile = "okteto.daemon"
+	logFile   = "okteto-daemon.log"
+
+	stopTimeout = 30 * time.Second
+
+	// maxExecutableNameLength is the length the kernel truncates process names to on linux and macOS
+	maxExecutableNameLength = 15
+)
+
+var (
+	// ErrNotFound is returned when there is no daemon for a development container
+	ErrNotFound = errors.New("'okteto up' is not running in the background")
+
+	// ErrNotRunning is returned when the daemon process of a development container is no longer alive
+	ErrNotRunning = errors.New("the background 'okteto up' process is not running")
+
+	// findProcess returns nil when there is no process with the given PID
+	findProcess = ps.FindProcess
+)
+
+// State is the information stored about an 'okteto up' running in the background
+type State struct {
+	StartedAt time.Time `json:"startedAt"`
+	Name      string    `json:"name"`
+	Namespace string    `json:"namespace"`
+	LogFile   string    `json:"logFile"`
+	// Executable is the binary name of the process, used to detect PIDs reused by other processes
+	Executable string `json:"executable,omitempty"`
+	PID        int    `json:"pid"`
+}
+
+// GetExecutable returns the binary name of the current process
+func GetExecutable() string {
+	bin, err := os.Executable()
+	if err != nil {
+		oktetoLog.Infof("failed to get the okteto binary path: %s", err)
+		return ""
+	}
+	return filepath.Base(bin)
+}
+
+// IsDaemon returns if the current process is an 'okteto up' running in the background
+func IsDaemon() bool {
+	return config.EnvOktetoUpDaemon.Value() == "true"
+}
+
+// GetLogPath returns the path of the file storing the output of the daemon of a development container
+func GetLogPath(namespace, devName string) string {
+	return filepath.Join(config.GetAppHome(namespace, devName), logFile)
+}
+
+func getStatePath(namespace, devName string) string {
+	return filepath.Join(config.GetAppHome(namespace, devName), stateFile)
+}
+
+// Write stores the state of the daemon of a development container
+func Write(s *State) error {
+	bytes, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(getStatePath(s.Namespace, s.Name), bytes, 0600); err != nil {
+		return fmt.Errorf("failed to write daemon state file: %w", err)
+	}
+	return nil
+}
+
+// Read returns the state of the daemon of a development container
+func Read(namespace, devName string) (*State, error) {
+	bytes, err := os.ReadFile(getStatePath(namespace, devName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	s := &State{}
+	if err := json.Unmarshal(bytes, s); err != nil {
+		return nil, fmt.Errorf("failed to read daemon state file: %w", err)
+	}
+	return s, nil
+}
+
+// Delete removes the state of the daemon of a development container
+func Delete(namespace, devName string) error {
+	if err := os.Remove(getStatePath(namespace, devName)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// IsRunning returns if the daemon process is alive
+func (s *State) IsRunning() bool {
+	p, err := findProcess(s.PID)
+	if err != nil {
+		oktetoLog.Infof("error finding daemon process %d: %s", s.PID, err)
+		return false
+	}
+	if p == nil {
+		return false
+	}
+	if s.Executable != "" && !isSameExecutable(s.Executable, p.Executable()) {
+		oktetoLog.Infof("process %d is '%s', not the daemon process '%s'", s.PID, p.Executable(), s.Executable)
+		return false
+	}
+	return true
+}
+
+// isSameExecutable returns if a process name matches the expected binary name, taking into account the process names truncated by the kernel
+func isSameExecutable(expected, actual string) bool {
+	if expected == actual {
+		return true
+	}
+	return len(actual) >= maxExecutableNameLength && strings.HasPrefix(expected, actual)
+}
+
+// CheckHealth returns the up state of a daemon, or an error if its process is no longer alive
+func (s *State) CheckHealth() (config.UpState, error) {
+	if !s.IsRunning() {
+		return config.Failed, ErrNotRunning
+	}
+	return config.GetState(s.Name, s.Namespace)
+}
+
+// Stop terminates the daemon process and waits for it to exit
+func (s *State) Stop() error {
+	defer func() {
+		if err := Delete(s.Namespace, s.Name); err != nil {
+			oktetoLog.Infof("failed to delete daemon state file: %s", err)
+		}
+	}()
+
+	if !s.IsRunning() {
+		return nil
+	}
+
+	p, err := os.FindProcess(s.PID)
+	if err != nil {
+		return err
+	}
+	if err := p.Signal(syscall.SIGTERM); err != nil {
+		if errors.Is(err, os.ErrProcessDone) {
+			return nil
+		}
+		// SIGTERM is not supported on every platform, kill the process instead
+		oktetoLog.Infof("failed to send SIGTERM to daemon process %d: %s", s.PID, err)
+		if err := p.Kill(); err != nil && !errors.Is(err, os.ErrProcessDone) {
+			return fmt.Errorf("failed to stop daemon process %d: %w", s.PID, err)
+		}
+	}
+
+	ticker := time.NewTicker(200 * time.Millisecond)
+	defer ticker.Stop()
+	timeout := time.After(stopTimeout)
+	for {
+		select {
+		case <-ticker.C:
+			if !s.IsRunning() {
+				return nil
+			}
+		case <-timeout:
+			return fmt.Errorf("daemon process %d didn't stop after %s", s.PID, stopTimeout.String())
+		}
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemon
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	ps "github.com/mitchellh/go-ps"
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteReadDelete(t *testing.T) {
+	t.Setenv(constants.OktetoFolderEnvVar, t.TempDir())
+
+	_, err := Read("ns", "api")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	s := &State{
+		Name:       "api",
+		Namespace:  "ns",
+		PID:        1234,
+		Executable: "okteto",
+		LogFile:    GetLogPath("ns", "api"),
+		StartedAt:  time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, Write(s))
+
+	result, err := Read("ns", "api")
+	require.NoError(t, err)
+	assert.Equal(t, s, result)
+
+	require.NoError(t, Delete("ns", "api"))
+	_, err = Read("ns", "api")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, Delete("ns", "api"))
+}
+
+type fakeProcess struct {
+	executable string
+	pid        int
+}
+
+func (p fakeProcess) Pid() int           { return p.pid }
+func (p fakeProcess) PPid() int          { return 0 }
+func (p fakeProcess) Executable() string { return p.executable }
+
+func TestIsRunning(t *testing.T) {
+	defer func() {
+		findProcess = ps.FindProcess
+	}()
+
+	tests := []struct {
+		name       string
+		executable string
+		process    ps.Process
+		expected   bool
+	}{
+		{
+			name:       "no process",
+			executable: "okteto",
+			expected:   false,
+		},
+		{
+			name:       "same executable",
+			executable: "okteto",
+			process:    fakeProcess{pid: 1234, executable: "okteto"},
+			expected:   true,
+		},
+		{
+			name:       "truncated executable",
+			executable: "okteto-linux-amd64",
+			process:    fakeProcess{pid: 1234, executable: "okteto-linux-am"},
+			expected:   true,
+		},
+		{
+			name:       "pid reused by another process",
+			executable: "okteto",
+			process:    fakeProcess{pid: 1234, executable: "bash"},
+			expected:   false,
+		},
+		{
+			name:     "executable not recorded",
+			process:  fakeProcess{pid: 1234, executable: "bash"},
+			expected: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findProcess = func(int) (ps.Process, error) {
+				return tt.process, nil
+			}
+			s := &State{Name: "api", Namespace: "ns", PID: 1234, Executable: tt.executable}
+			assert.Equal(t, tt.expected, s.IsRunning())
+		})
+	}
+}
+
+func TestCheckHealth(t *testing.T) {
+	t.Setenv(constants.OktetoFolderEnvVar, t.TempDir())
+	defer func() {
+		findProcess = ps.FindProcess
+	}()
+
+	s := &State{Name: "api", Namespace: "ns", PID: os.Getpid()}
+
+	findProcess = func(int) (ps.Process, error) {
+		return nil, nil
+	}
+	_, err := s.CheckHealth()
+	require.ErrorIs(t, err, ErrNotRunning)
+
+	findProcess = ps.FindProcess
+	require.NoError(t, config.UpdateStateFile("api", "ns", config.Synchronizing))
+	state, err := s.CheckHealth()
+	require.NoError(t, err)
+	assert.Equal(t, config.Synchronizing, state)
+}
+
+func TestStopNotRunning(t *testing.T) {
+	t.Setenv(constants.OktetoFolderEnvVar, t.TempDir())
+	defer func() {
+		findProcess = ps.FindProcess
+	}()
+	findProcess = func(int) (ps.Process, error) {
+		return nil, nil
+	}
+
+	s := &State{Name: "api", Namespace: "ns", PID: 1234}
+	require.NoError(t, Write(s))
+	require.NoError(t, s.Stop())
+
+	_, err := Read("ns", "api")
+	require.ErrorIs(t, err, ErrNotFound)
+}
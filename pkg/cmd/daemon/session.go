@@ -0,0 +1,96 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/okteto/okteto/pkg/config"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+const sessionFile = "okteto-up-%s.session"
+
+// ErrSessionNotFound is returned when there is no 'okteto up' running several development containers
+var ErrSessionNotFound = errors.New("'okteto up' is not running several development containers")
+
+// Session is the information stored about an 'okteto up' running several development containers
+type Session struct {
+	StartedAt time.Time `json:"startedAt"`
+	Name      string    `json:"name"`
+	Namespace string    `json:"namespace"`
+	Services  []string  `json:"services"`
+	PID       int       `json:"pid"`
+}
+
+func getSessionPath(namespace, name string) string {
+	return filepath.Join(config.GetNamespaceHome(namespace), fmt.Sprintf(sessionFile, name))
+}
+
+// WriteSession stores the session of an 'okteto up' running several development containers
+func WriteSession(s *Session) error {
+	bytes, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(getSessionPath(s.Namespace, s.Name), bytes, 0600); err != nil {
+		return fmt.Errorf("failed to write session file: %w", err)
+	}
+	return nil
+}
+
+// ReadSession returns the session of the development environment, if it is still running
+func ReadSession(namespace, name string) (*Session, error) {
+	bytes, err := os.ReadFile(getSessionPath(namespace, name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, err
+	}
+	s := &Session{}
+	if err := json.Unmarshal(bytes, s); err != nil {
+		return nil, fmt.Errorf("failed to read session file: %w", err)
+	}
+	if !s.IsRunning() {
+		if err := DeleteSession(namespace, name); err != nil {
+			oktetoLog.Infof("failed to delete stale session file: %s", err)
+		}
+		return nil, ErrSessionNotFound
+	}
+	return s, nil
+}
+
+// DeleteSession removes the session of the development environment
+func DeleteSession(namespace, name string) error {
+	if err := os.Remove(getSessionPath(namespace, name)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// IsRunning returns if the process running the session is alive
+func (s *Session) IsRunning() bool {
+	p, err := findProcess(s.PID)
+	if err != nil {
+		oktetoLog.Infof("error finding session process %d: %s", s.PID, err)
+		return false
+	}
+	return p != nil
+}
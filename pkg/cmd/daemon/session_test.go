@@ -0,0 +1,58 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemon
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	ps "github.com/mitchellh/go-ps"
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSession(t *testing.T) {
+	t.Setenv(constants.OktetoFolderEnvVar, t.TempDir())
+	defer func() {
+		findProcess = ps.FindProcess
+	}()
+
+	_, err := ReadSession("ns", "movies")
+	require.ErrorIs(t, err, ErrSessionNotFound)
+
+	s := &Session{
+		Name:      "movies",
+		Namespace: "ns",
+		Services:  []string{"api", "frontend"},
+		PID:       os.Getpid(),
+		StartedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, WriteSession(s))
+
+	result, err := ReadSession("ns", "movies")
+	require.NoError(t, err)
+	assert.Equal(t, s, result)
+
+	findProcess = func(int) (ps.Process, error) {
+		return nil, nil
+	}
+	_, err = ReadSession("ns", "movies")
+	require.ErrorIs(t, err, ErrSessionNotFound)
+
+	_, err = os.Stat(getSessionPath("ns", "movies"))
+	assert.True(t, os.IsNotExist(err))
+	require.NoError(t, DeleteSession("ns", "movies"))
+}
@@ -39,6 +39,9 @@ import (
 	"k8s.io/client-go/kubernetes"
 )
 
+// maxDoctorInvocationLogs is the number of command logs included in the doctor archive
+const maxDoctorInvocationLogs = 10
+
 // PodInfo info collected for pods
 type PodInfo struct {
 	CPU        string               `yaml:"cpu,omitempty"`
@@ -91,10 +94,7 @@ func Run(ctx context.Context, dev *model.Dev, devPath string, c *kubernetes.Clie
 	files := []string{summaryFilename}
 	files = append(files, stignoreFilenames...)
 
-	appLogsPath := filepath.Join(config.GetAppHome(dev.Namespace, dev.Name), "okteto.log")
-	if filesystem.FileExists(appLogsPath) {
-		files = append(files, appLogsPath)
-	}
+	files = append(files, getInvocationLogFiles()...)
 
 	if filesystem.FileExists(syncthing.GetLogFile(dev.Namespace, dev.Name)) {
 		files = append(files, syncthing.GetLogFile(dev.Namespace, dev.Name))
@@ -116,6 +116,23 @@ func Run(ctx context.Context, dev *model.Dev, devPath string, c *kubernetes.Clie
 	return archiveName, nil
 }
 
+// getInvocationLogFiles returns the logs of the most recent command runs
+func getInvocationLogFiles() []string {
+	invocations, err := oktetoLog.ListInvocations(config.GetInvocationLogsDir())
+	if err != nil {
+		oktetoLog.Infof("error listing the command logs: %s", err)
+		return nil
+	}
+	files := []string{}
+	for _, i := range invocations {
+		if len(files) == maxDoctorInvocationLogs {
+			break
+		}
+		files = append(files, i.LogFile)
+	}
+	return files
+}
+
 func generateSummaryFile() (string, error) {
 	tempdir, err := os.MkdirTemp("", "")
 	if err != nil {
@@ -70,7 +70,7 @@ func Run(dev *model.Dev, app apps.App, trMap map[string]*apps.Translation, wait
 		return err
 	}
 
-	stopSyncthing(dev)
+	StopSyncthing(dev)
 
 	if err := ssh.RemoveEntry(dev.Name); err != nil {
 		oktetoLog.Infof("failed to remove ssh entry: %s", err)
@@ -85,7 +85,8 @@ func Run(dev *model.Dev, app apps.App, trMap map[string]*apps.Translation, wait
 	return nil
 }
 
-func stopSyncthing(dev *model.Dev) {
+// StopSyncthing terminates the local syncthing process of a development container
+func StopSyncthing(dev *model.Dev) {
 	sy, err := syncthing.New(dev)
 	if err != nil {
 		oktetoLog.Infof("failed to create syncthing instance")
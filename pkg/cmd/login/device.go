@@ -0,0 +1,140 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package login
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+)
+
+const (
+	// defaultDeviceCodeInterval is the polling interval in seconds when the backend doesn't return one
+	defaultDeviceCodeInterval = 5
+
+	// slowDownIncrement is the number of seconds added to the polling interval when the backend asks to slow down
+	slowDownIncrement = 5
+
+	// defaultDeviceCodeExpiration is the lifetime in seconds of the codes when the backend doesn't return one
+	defaultDeviceCodeExpiration = 600
+)
+
+// authenticator exchanges an authorization code for the okteto user
+type authenticator func(ctx context.Context, code string) (*types.User, error)
+
+// deviceCodeFlow authenticates the user with the device authorization flow
+type deviceCodeFlow struct {
+	client       types.DeviceInterface
+	auth         authenticator
+	baseURL      string
+	intervalUnit time.Duration
+}
+
+// WithDeviceCode authenticates the user with a code entered in a browser of any other device
+func WithDeviceCode(ctx context.Context, oktetoURL string) (*types.User, error) {
+	u, err := url.Parse(oktetoURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "" {
+		u.Scheme = "https"
+	}
+
+	oktetoClient, err := okteto.NewOktetoClientFromUrl(u.String())
+	if err != nil {
+		return nil, err
+	}
+
+	flow := &deviceCodeFlow{
+		client:       oktetoClient.Device(),
+		auth:         oktetoClient.Auth,
+		baseURL:      u.String(),
+		intervalUnit: time.Second,
+	}
+	return flow.run(ctx)
+}
+
+func (f *deviceCodeFlow) run(ctx context.Context) (*types.User, error) {
+	code, err := f.client.RequestCode(ctx, f.baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't start the login process: %w", err)
+	}
+
+	verificationURI := code.VerificationURIComplete
+	if verificationURI == "" {
+		verificationURI = code.VerificationURI
+	}
+	oktetoLog.Println("To authenticate, open a browser in any device and navigate to the following address:")
+	oktetoLog.Println(verificationURI)
+	oktetoLog.Printf("Then enter the code: %s\n", oktetoLog.BlueString(code.UserCode))
+
+	authorizationCode, err := f.poll(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := f.auth(ctx, authorizationCode)
+	if err != nil {
+		return nil, okteto.TranslateAuthError(err)
+	}
+	return user, nil
+}
+
+// poll waits until the user completes the authorization and returns the authorization code
+func (f *deviceCodeFlow) poll(ctx context.Context, code *types.DeviceCode) (string, error) {
+	interval := code.Interval
+	if interval <= 0 {
+		interval = defaultDeviceCodeInterval
+	}
+	expiresIn := code.ExpiresIn
+	if expiresIn <= 0 {
+		expiresIn = defaultDeviceCodeExpiration
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(expiresIn)*f.intervalUnit)
+	defer cancel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return "", okteto.ErrDeviceCodeExpired
+			}
+			return "", ctx.Err()
+		case <-time.After(time.Duration(interval) * f.intervalUnit):
+		}
+
+		authorizationCode, err := f.client.PollCode(ctx, f.baseURL, code.DeviceCode)
+		switch {
+		case err == nil:
+			return authorizationCode, nil
+		case errors.Is(err, okteto.ErrAuthorizationPending):
+			oktetoLog.Infof("waiting for the device authorization")
+		case errors.Is(err, okteto.ErrSlowDown):
+			interval += slowDownIncrement
+			oktetoLog.Infof("polling interval increased to %d seconds", interval)
+		default:
+			if ctx.Err() != nil {
+				continue
+			}
+			return "", err
+		}
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package login
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDeviceClient struct {
+	requestErr error
+	code       *types.DeviceCode
+	polls      []error
+	calls      int
+	// pending keeps the authorization pending once the polls are consumed
+	pending bool
+}
+
+func (f *fakeDeviceClient) RequestCode(_ context.Context, _ string) (*types.DeviceCode, error) {
+	return f.code, f.requestErr
+}
+
+func (f *fakeDeviceClient) PollCode(_ context.Context, _, deviceCode string) (string, error) {
+	f.calls++
+	if f.calls <= len(f.polls) {
+		return "", f.polls[f.calls-1]
+	}
+	if f.pending {
+		return "", okteto.ErrAuthorizationPending
+	}
+	return "code-" + deviceCode, nil
+}
+
+func fakeAuth(_ context.Context, code string) (*types.User, error) {
+	return &types.User{ID: "user", Token: code}, nil
+}
+
+func Test_deviceCodeFlow(t *testing.T) {
+	tests := []struct {
+		client      *fakeDeviceClient
+		expectedErr error
+		name        string
+		expected    string
+		calls       int
+	}{
+		{
+			name: "authorized after pending polls",
+			client: &fakeDeviceClient{
+				code:  &types.DeviceCode{DeviceCode: "device", UserCode: "ABCD", Interval: 1, ExpiresIn: 1000},
+				polls: []error{okteto.ErrAuthorizationPending, okteto.ErrSlowDown, okteto.ErrAuthorizationPending},
+			},
+			expected: "code-device",
+			calls:    4,
+		},
+		{
+			name: "access denied",
+			client: &fakeDeviceClient{
+				code:  &types.DeviceCode{DeviceCode: "device", UserCode: "ABCD", Interval: 1, ExpiresIn: 1000},
+				polls: []error{okteto.ErrAuthorizationPending, okteto.ErrAccessDenied},
+			},
+			expectedErr: okteto.ErrAccessDenied,
+		},
+		{
+			name: "expired",
+			client: &fakeDeviceClient{
+				code:    &types.DeviceCode{DeviceCode: "device", UserCode: "ABCD", Interval: 1, ExpiresIn: 30},
+				pending: true,
+			},
+			expectedErr: okteto.ErrDeviceCodeExpired,
+		},
+		{
+			name: "request error",
+			client: &fakeDeviceClient{
+				requestErr: errors.New("request error"),
+			},
+			expectedErr: errors.New("couldn't start the login process: request error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flow := &deviceCodeFlow{
+				client:       tt.client,
+				auth:         fakeAuth,
+				baseURL:      "https://okteto.example.com",
+				intervalUnit: time.Millisecond,
+			}
+			user, err := flow.run(context.Background())
+			if tt.expectedErr != nil {
+				require.ErrorContains(t, err, tt.expectedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, user.Token)
+			assert.Equal(t, tt.calls, tt.client.calls)
+		})
+	}
+}
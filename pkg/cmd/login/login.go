@@ -33,6 +33,7 @@ import (
 
 type LoginInterface interface {
 	AuthenticateToOktetoCluster(context.Context, string, string) (*types.User, error)
+	AuthenticateWithDeviceCode(context.Context, string) (*types.User, error)
 }
 
 type LoginController struct {
@@ -46,27 +47,38 @@ func (*LoginController) AuthenticateToOktetoCluster(ctx context.Context, oktetoU
 	if token == "" {
 		oktetoLog.Infof("authenticating with browser code")
 		user, err := WithBrowser(ctx, oktetoURL)
-		// If there is a TLS error, return the raw error
-		if oktetoErrors.IsX509(err) {
-			return nil, oktetoErrors.UserError{
-				E:    err,
-				Hint: oktetoErrors.ErrX509Hint,
-			}
-		}
-		if err != nil {
-			return nil, oktetoErrors.UserError{
-				E:    fmt.Errorf("couldn't authenticate to okteto context: %w", err),
-				Hint: "Try to set the context using the 'token' flag: https://www.okteto.com/docs/reference/cli/#context",
-			}
+		return authenticatedUser(user, err)
+	}
+	return &types.User{Token: token}, nil
+}
+
+// AuthenticateWithDeviceCode authenticates the user with a code entered in a browser of any other device
+func (*LoginController) AuthenticateWithDeviceCode(ctx context.Context, oktetoURL string) (*types.User, error) {
+	oktetoLog.Infof("authenticating with device code")
+	user, err := WithDeviceCode(ctx, oktetoURL)
+	return authenticatedUser(user, err)
+}
+
+func authenticatedUser(user *types.User, err error) (*types.User, error) {
+	// If there is a TLS error, return the raw error
+	if oktetoErrors.IsX509(err) {
+		return nil, oktetoErrors.UserError{
+			E:    err,
+			Hint: oktetoErrors.ErrX509Hint,
 		}
-		if user.New {
-			analytics.TrackSignup(true, user.ID)
+	}
+	if err != nil {
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("couldn't authenticate to okteto context: %w", err),
+			Hint: "Try to set the context using the 'token' flag: https://www.okteto.com/docs/reference/cli/#context",
 		}
-		oktetoLog.Infof("authenticated user %s", user.ID)
-
-		return user, nil
 	}
-	return &types.User{Token: token}, nil
+	if user.New {
+		analytics.TrackSignup(true, user.ID)
+	}
+	oktetoLog.Infof("authenticated user %s", user.ID)
+
+	return user, nil
 }
 
 // WithBrowser authenticates the user with the browser
@@ -85,7 +97,7 @@ func WithBrowser(ctx context.Context, oktetoURL string) (*types.User, error) {
 		if strings.Contains(err.Error(), "executable file not found in $PATH") {
 			return nil, oktetoErrors.UserError{
 				E:    fmt.Errorf("no browser could be found"),
-				Hint: "Use the '--token' flag to run this command in server mode. More information can be found here: https://www.okteto.com/docs/reference/cli/#context",
+				Hint: "Use the '--device-code' flag to authenticate from another device, or the '--token' flag to run this command in server mode. More information can be found here: https://www.okteto.com/docs/reference/cli/#context",
 			}
 		}
 		oktetoLog.Errorf("Something went wrong opening your browser: %s\n", err)
@@ -16,13 +16,17 @@ package pipeline
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/okteto/okteto/pkg/constants"
 	"github.com/okteto/okteto/pkg/format"
 	"github.com/okteto/okteto/pkg/k8s/configmaps"
 	"github.com/okteto/okteto/pkg/k8s/deployments"
 	"github.com/okteto/okteto/pkg/k8s/statefulsets"
+	"github.com/okteto/okteto/pkg/k8s/volumes"
 	"github.com/okteto/okteto/pkg/model"
 	v1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/kubernetes"
 )
@@ -56,6 +60,52 @@ func ListStatefulsets(ctx context.Context, name, ns string, c kubernetes.Interfa
 	return sfsList, nil
 }
 
+// ListVolumes list the persistent volume claims created by the pipeline, including the ones of its statefulsets
+// and the ones of the development containers of its deployments and statefulsets
+func ListVolumes(ctx context.Context, name, ns string, c kubernetes.Interface) ([]apiv1.PersistentVolumeClaim, error) {
+	dList, err := ListDeployments(ctx, name, ns, c)
+	if err != nil {
+		return nil, err
+	}
+	sfsList, err := ListStatefulsets(ctx, name, ns, c)
+	if err != nil {
+		return nil, err
+	}
+	devVolumes := map[string]bool{}
+	for _, d := range dList {
+		devVolumes[fmt.Sprintf(model.OktetoVolumeNameTemplate, d.Name)] = true
+	}
+	for _, sfs := range sfsList {
+		devVolumes[fmt.Sprintf(model.OktetoVolumeNameTemplate, sfs.Name)] = true
+	}
+	vList, err := volumes.List(ctx, ns, "", c)
+	if err != nil {
+		return nil, err
+	}
+
+	deployedBy := format.ResourceK8sMetaString(name)
+	result := []apiv1.PersistentVolumeClaim{}
+	for _, pvc := range vList {
+		isDevVolume := pvc.Labels[constants.DevLabel] == "true" && devVolumes[pvc.Name]
+		if pvc.Labels[model.DeployedByLabel] == deployedBy || isStatefulsetVolume(pvc.Name, sfsList) || isDevVolume {
+			result = append(result, pvc)
+		}
+	}
+	return result, nil
+}
+
+// isStatefulsetVolume returns if a persistent volume claim was created from the volume claim templates of a statefulset
+func isStatefulsetVolume(pvcName string, sfsList []v1.StatefulSet) bool {
+	for _, sfs := range sfsList {
+		for _, tmpl := range sfs.Spec.VolumeClaimTemplates {
+			if strings.HasPrefix(pvcName, fmt.Sprintf("%s-%s-", tmpl.Name, sfs.Name)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // HasDeployedSomething checks if the pipeline has deployed any deployment/statefulset/job
 func HasDeployedSomething(ctx context.Context, name, ns string, c kubernetes.Interface) (bool, error) {
 	labels := fmt.Sprintf("%s=%s", model.DeployedByLabel, format.ResourceK8sMetaString(name))
@@ -17,7 +17,11 @@ import (
 	"context"
 	"testing"
 
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/model"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
@@ -81,3 +85,67 @@ func Test_IsDeployedPipeline(t *testing.T) {
 		})
 	}
 }
+
+func TestListVolumes(t *testing.T) {
+	ctx := context.Background()
+	namespace := "test"
+	sfs := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "db",
+			Namespace: namespace,
+			Labels:    map[string]string{model.DeployedByLabel: "movies"},
+		},
+		Spec: appsv1.StatefulSetSpec{
+			VolumeClaimTemplates: []apiv1.PersistentVolumeClaim{
+				{ObjectMeta: metav1.ObjectMeta{Name: "data"}},
+			},
+		},
+	}
+	deployed := &apiv1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cache",
+			Namespace: namespace,
+			Labels:    map[string]string{model.DeployedByLabel: "movies"},
+		},
+	}
+	fromStatefulset := &apiv1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "data-db-0", Namespace: namespace},
+	}
+	other := &apiv1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "other",
+			Namespace: namespace,
+			Labels:    map[string]string{model.DeployedByLabel: "other"},
+		},
+	}
+	d := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "api",
+			Namespace: namespace,
+			Labels:    map[string]string{model.DeployedByLabel: "movies"},
+		},
+	}
+	devVolume := &apiv1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "api-okteto",
+			Namespace: namespace,
+			Labels:    map[string]string{constants.DevLabel: "true"},
+		},
+	}
+	otherDevVolume := &apiv1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "frontend-okteto",
+			Namespace: namespace,
+			Labels:    map[string]string{constants.DevLabel: "true"},
+		},
+	}
+	c := fake.NewSimpleClientset(sfs, d, deployed, fromStatefulset, other, devVolume, otherDevVolume)
+
+	result, err := ListVolumes(ctx, "movies", namespace, c)
+	require.NoError(t, err)
+	names := []string{}
+	for _, pvc := range result {
+		names = append(names, pvc.Name)
+	}
+	assert.ElementsMatch(t, []string{"cache", "data-db-0", "api-okteto"}, names)
+}
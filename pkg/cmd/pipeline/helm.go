@@ -0,0 +1,163 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/format"
+	"github.com/okteto/okteto/pkg/k8s/configmaps"
+	"github.com/okteto/okteto/pkg/model"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	helmOwnerLabel = "owner"
+	helmNameLabel  = "name"
+	helmOwner      = "helm"
+)
+
+// DetectHelmReleases returns the helm releases installed by a development environment in its namespace, sorted by install time
+func DetectHelmReleases(ctx context.Context, name, namespace string, c kubernetes.Interface) ([]model.HelmRelease, error) {
+	sList, err := c.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", model.DeployedByLabel, format.ResourceK8sMetaString(name)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return HelmReleasesFromSecrets(sList.Items), nil
+}
+
+// HelmReleasesFromSecrets returns the helm releases stored in a list of secrets, sorted by install time.
+// The install time of a release is the creation time of its oldest revision
+func HelmReleasesFromSecrets(secrets []apiv1.Secret) []model.HelmRelease {
+	installedAt := map[string]time.Time{}
+	for _, s := range secrets {
+		if s.Type != model.HelmSecretType || s.Labels[helmOwnerLabel] != helmOwner {
+			continue
+		}
+		name, ok := s.Labels[helmNameLabel]
+		if !ok {
+			continue
+		}
+		created := s.CreationTimestamp.Time
+		if t, ok := installedAt[name]; !ok || created.Before(t) {
+			installedAt[name] = created
+		}
+	}
+
+	result := make([]model.HelmRelease, 0, len(installedAt))
+	for name := range installedAt {
+		result = append(result, model.HelmRelease{Name: name})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		ti, tj := installedAt[result[i].Name], installedAt[result[j].Name]
+		if !ti.Equal(tj) {
+			return ti.Before(tj)
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// MergeHelmReleases returns the helm releases of a development environment in install order.
+// Tracked releases keep their order and are only kept if they are still detected or declared in the manifest.
+// Releases detected for the first time go after them, followed by the declared releases that are not installed yet.
+// The namespace and timeout of the declared releases take precedence
+func MergeHelmReleases(tracked, detected, declared []model.HelmRelease) []model.HelmRelease {
+	current := map[string]bool{}
+	for _, r := range detected {
+		current[r.Name] = true
+	}
+	for _, r := range declared {
+		current[r.Name] = true
+	}
+
+	result := []model.HelmRelease{}
+	index := map[string]int{}
+	add := func(r model.HelmRelease) {
+		i, ok := index[r.Name]
+		if !ok {
+			index[r.Name] = len(result)
+			result = append(result, r)
+			return
+		}
+		if r.Namespace != "" {
+			result[i].Namespace = r.Namespace
+		}
+		if r.Timeout != 0 {
+			result[i].Timeout = r.Timeout
+		}
+	}
+	for _, r := range tracked {
+		if current[r.Name] {
+			add(r)
+		}
+	}
+	for _, r := range detected {
+		add(r)
+	}
+	for _, r := range declared {
+		add(r)
+	}
+	return result
+}
+
+// GetHelmReleases returns the helm releases tracked in the configmap of a pipeline, in install order
+func GetHelmReleases(ctx context.Context, name, namespace string, c kubernetes.Interface) ([]model.HelmRelease, error) {
+	cmap, err := configmaps.Get(ctx, TranslatePipelineName(name), namespace, c)
+	if err != nil {
+		if oktetoErrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return decodeHelmReleases(cmap.Data[helmReleasesField])
+}
+
+func setHelmReleases(cmap *apiv1.ConfigMap, releases []model.HelmRelease) error {
+	if len(releases) == 0 {
+		delete(cmap.Data, helmReleasesField)
+		return nil
+	}
+	encoded, err := json.Marshal(releases)
+	if err != nil {
+		return err
+	}
+	cmap.Data[helmReleasesField] = base64.StdEncoding.EncodeToString(encoded)
+	return nil
+}
+
+func decodeHelmReleases(encoded string) ([]model.HelmRelease, error) {
+	if encoded == "" {
+		return nil, nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	var result []model.HelmRelease
+	if err := json.Unmarshal(decoded, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func helmSecret(name, release string, created time.Time) *apiv1.Secret {
+	return &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "test",
+			CreationTimestamp: metav1.NewTime(created),
+			Labels: map[string]string{
+				model.DeployedByLabel: "movies",
+				helmOwnerLabel:        helmOwner,
+				helmNameLabel:         release,
+			},
+		},
+		Type: model.HelmSecretType,
+	}
+}
+
+func TestDetectHelmReleases(t *testing.T) {
+	now := time.Now()
+	c := fake.NewSimpleClientset(
+		helmSecret("sh.helm.release.v1.frontend.v2", "frontend", now.Add(time.Minute)),
+		helmSecret("sh.helm.release.v1.db.v1", "db", now),
+		helmSecret("sh.helm.release.v1.frontend.v1", "frontend", now.Add(-time.Minute)),
+		helmSecret("sh.helm.release.v1.api.v1", "api", now),
+		&apiv1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "other",
+				Namespace: "test",
+				Labels:    map[string]string{model.DeployedByLabel: "movies"},
+			},
+		},
+	)
+
+	releases, err := DetectHelmReleases(context.Background(), "movies", "test", c)
+	require.NoError(t, err)
+	assert.Equal(t, []model.HelmRelease{{Name: "frontend"}, {Name: "api"}, {Name: "db"}}, releases)
+}
+
+func TestMergeHelmReleases(t *testing.T) {
+	tracked := []model.HelmRelease{{Name: "db"}, {Name: "removed"}, {Name: "api"}}
+	detected := []model.HelmRelease{{Name: "api"}, {Name: "db"}, {Name: "frontend"}}
+	declared := []model.HelmRelease{{Name: "api", Timeout: time.Minute}, {Name: "monitoring", Namespace: "observability"}}
+
+	assert.Equal(t, []model.HelmRelease{
+		{Name: "db"},
+		{Name: "api", Timeout: time.Minute},
+		{Name: "frontend"},
+		{Name: "monitoring", Namespace: "observability"},
+	}, MergeHelmReleases(tracked, detected, declared))
+
+	assert.Empty(t, MergeHelmReleases(tracked, nil, nil))
+}
+
+func TestHelmReleasesConfigMap(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset()
+	releases := []model.HelmRelease{{Name: "db", Timeout: time.Minute}, {Name: "api"}}
+
+	_, err := TranslateConfigMapAndDeploy(ctx, &CfgData{Name: "movies", Namespace: "test", Status: DeployedStatus, HelmReleases: releases}, c)
+	require.NoError(t, err)
+	tracked, err := GetHelmReleases(ctx, "movies", "test", c)
+	require.NoError(t, err)
+	assert.Equal(t, releases, tracked)
+
+	// updates without helm releases keep the tracked ones
+	cmap, err := TranslateConfigMapAndDeploy(ctx, &CfgData{Name: "movies", Namespace: "test", Status: DestroyingStatus}, c)
+	require.NoError(t, err)
+	data, err := GetConfigmapData(ctx, "movies", "test", c)
+	require.NoError(t, err)
+	assert.Equal(t, releases, data.HelmReleases)
+
+	require.NoError(t, UpdateConfigMap(ctx, cmap, &CfgData{Name: "movies", Namespace: "test", Status: DeployedStatus, HelmReleases: []model.HelmRelease{}}, c))
+	tracked, err = GetHelmReleases(ctx, "movies", "test", c)
+	require.NoError(t, err)
+	assert.Empty(t, tracked)
+
+	tracked, err = GetHelmReleases(ctx, "other", "test", c)
+	require.NoError(t, err)
+	assert.Nil(t, tracked)
+}
@@ -14,17 +14,15 @@
 package pipeline
 
 import (
-	"bufio"
-	"bytes"
 	"context"
 	"encoding/base64"
 	"errors"
 	"fmt"
 	"math"
-	"os"
 	"strings"
 	"time"
 
+	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/constants"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/format"
@@ -42,17 +40,19 @@ import (
 )
 
 const (
-	nameField       = "name"
-	statusField     = "status"
-	outputField     = "output"
-	repoField       = "repository"
-	branchField     = "branch"
-	filenameField   = "filename"
-	yamlField       = "yaml"
-	iconField       = "icon"
-	actionLockField = "actionLock"
-	actionNameField = "actionName"
-	variablesField  = "variables"
+	nameField         = "name"
+	statusField       = "status"
+	outputField       = "output"
+	repoField         = "repository"
+	branchField       = "branch"
+	commitField       = "commit"
+	filenameField     = "filename"
+	yamlField         = "yaml"
+	iconField         = "icon"
+	actionLockField   = "actionLock"
+	actionNameField   = "actionName"
+	variablesField    = "variables"
+	helmReleasesField = "helmReleases"
 
 	actionDefaultName = "cli"
 
@@ -86,10 +86,14 @@ type CfgData struct {
 	Output     string
 	Repository string
 	Branch     string
-	Filename   string
-	Manifest   []byte
-	Icon       string
-	Variables  []string
+	// Commit is the SHA of the deployed commit of the repository
+	Commit    string
+	Filename  string
+	Manifest  []byte
+	Icon      string
+	Variables []string
+	// HelmReleases are the helm releases installed by the pipeline. The configmap keeps its releases when it is nil
+	HelmReleases []model.HelmRelease
 }
 
 // GetConfigmapVariablesEncoded returns Data["variables"] content from Configmap
@@ -106,6 +110,43 @@ func GetConfigmapVariablesEncoded(ctx context.Context, name, namespace string, c
 	return cmap.Data[variablesField], nil
 }
 
+// GetConfigmapData returns the data stored in the configmap of a pipeline, with the manifest and variables decoded
+func GetConfigmapData(ctx context.Context, name, namespace string, c kubernetes.Interface) (*CfgData, error) {
+	cmap, err := configmaps.Get(ctx, TranslatePipelineName(name), namespace, c)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := base64.StdEncoding.DecodeString(cmap.Data[yamlField])
+	if err != nil {
+		return nil, fmt.Errorf("error decoding manifest of '%s': %w", name, err)
+	}
+
+	variables, err := decodeVariables(cmap.Data[variablesField])
+	if err != nil {
+		return nil, fmt.Errorf("error decoding variables of '%s': %w", name, err)
+	}
+
+	helmReleases, err := decodeHelmReleases(cmap.Data[helmReleasesField])
+	if err != nil {
+		return nil, fmt.Errorf("error decoding helm releases of '%s': %w", name, err)
+	}
+
+	return &CfgData{
+		Name:         cmap.Data[nameField],
+		Namespace:    cmap.Namespace,
+		Status:       cmap.Data[statusField],
+		Repository:   cmap.Data[repoField],
+		Branch:       cmap.Data[branchField],
+		Commit:       cmap.Data[commitField],
+		Filename:     cmap.Data[filenameField],
+		Manifest:     manifest,
+		Icon:         cmap.Data[iconField],
+		Variables:    variables,
+		HelmReleases: helmReleases,
+	}, nil
+}
+
 // TranslateConfigMapAndDeploy translates the app into a configMap.
 // Name param is the pipeline sanitized name
 func TranslateConfigMapAndDeploy(ctx context.Context, data *CfgData, c kubernetes.Interface) (*apiv1.ConfigMap, error) {
@@ -186,44 +227,112 @@ func UpdateEnvs(ctx context.Context, name, namespace string, envs []string, c ku
 	return nil
 }
 
+// MergeVariables sets variables on a deployed pipeline, keeping its other variables.
+// It returns false if the pipeline is not deployed in the namespace
+func MergeVariables(ctx context.Context, name, namespace string, variables []string, c kubernetes.Interface) (bool, error) {
+	cmap, err := configmaps.Get(ctx, TranslatePipelineName(name), namespace, c)
+	if err != nil {
+		if oktetoErrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	current, err := decodeVariables(cmap.Data[variablesField])
+	if err != nil {
+		return false, fmt.Errorf("error decoding variables of '%s': %w", name, err)
+	}
+	merged := []string{}
+	overridden := map[string]bool{}
+	for _, v := range variables {
+		overridden[strings.SplitN(v, "=", 2)[0]] = true
+	}
+	for _, v := range current {
+		if !overridden[strings.SplitN(v, "=", 2)[0]] {
+			merged = append(merged, v)
+		}
+	}
+	merged = append(merged, variables...)
+	if len(merged) == 0 {
+		return true, nil
+	}
+	cmap.Data[variablesField] = translateVariables(merged)
+	return true, configmaps.Deploy(ctx, cmap, cmap.Namespace, c)
+}
+
+// GetDependencyEnvs returns the variables exported through $OKTETO_ENV by the pipeline
+func GetDependencyEnvs(ctx context.Context, name, namespace string, c kubernetes.Interface) (map[string]string, error) {
+	cmap, err := configmaps.Get(ctx, TranslatePipelineName(name), namespace, c)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeDependencyEnvs(cmap)
+}
+
+// DecodeDependencyEnvs returns the variables exported through $OKTETO_ENV stored in the pipeline configmap
+func DecodeDependencyEnvs(cmap *apiv1.ConfigMap) (map[string]string, error) {
+	result := map[string]string{}
+	if cmap == nil || cmap.Data == nil {
+		return result, nil
+	}
+	encodedEnvs, ok := cmap.Data[constants.OktetoDependencyEnvsKey]
+	if !ok {
+		return result, nil
+	}
+	decodedEnvs, err := base64.StdEncoding.DecodeString(encodedEnvs)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(decodedEnvs, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // TranslatePipelineName translate the name into the configmap name
 func TranslatePipelineName(name string) string {
 	return fmt.Sprintf("okteto-git-%s", format.ResourceK8sMetaString(name))
 }
 
-func translateOutput(output *bytes.Buffer) []byte {
+func translateOutput(messages []oktetoLog.JSONMessage) []byte {
 	// If the output is larger than the currentMaxLimit for the logs trim it.
-	// We can't really truncate the buffer since we would end up with an invalid json
+	// We can't really truncate the output since we would end up with an invalid json
 	// line for the last line, so we pick lines from the end while the line fits
-	var data []byte
-	if output.Len() > maxLogOutputRaw {
-		scanner := bufio.NewScanner(output)
-		var linesInReverse []string
-		for scanner.Scan() {
-			linesInReverse = append([]string{scanner.Text()}, linesInReverse...)
+	lines := []string{}
+	size := 0
+	for i := len(messages) - 1; i >= 0; i-- {
+		line, err := json.Marshal(messages[i])
+		if err != nil {
+			oktetoLog.Infof("could not marshal output message: %s", err)
+			continue
 		}
-		var cappedOutput string
-		var head string
-		for _, l := range linesInReverse {
-			head = l + "\n"
-			if len(cappedOutput)+len(head) > maxLogOutputRaw {
-				break
-			}
-			cappedOutput = head + cappedOutput
+		if size+len(line)+1 > maxLogOutputRaw {
+			break
 		}
-		cappedOutput = strings.TrimPrefix(cappedOutput, head)
-		data = []byte(cappedOutput)
-	} else {
-		data = output.Bytes()
+		size += len(line) + 1
+		lines = append([]string{string(line)}, lines...)
+	}
+	if len(lines) == 0 {
+		return []byte{}
 	}
-	return data
+	return []byte(strings.Join(lines, "\n") + "\n")
+}
+
+// getOutputData returns the messages buffered by the logger ready to be stored in the pipeline configmap
+func getOutputData() []byte {
+	messages, err := oktetoLog.GetBufferedMessages()
+	if err != nil {
+		oktetoLog.Infof("could not read the output buffer: %s", err)
+	}
+	return translateOutput(messages)
 }
 
 // translateConfigMapSandBox creates a configmap adding data from a config data
 func translateConfigMapSandBox(data *CfgData) *apiv1.ConfigMap {
-	// if repository is empty, force empty branch
+	// if repository is empty, force empty branch and commit
 	if data.Repository == "" {
 		data.Branch = ""
+		data.Commit = ""
 	}
 	cmap := &apiv1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
@@ -256,8 +365,17 @@ func translateConfigMapSandBox(data *CfgData) *apiv1.ConfigMap {
 		cmap.Data[filenameField] = data.Filename
 	}
 
-	output := oktetoLog.GetOutputBuffer()
-	outputData := translateOutput(output)
+	if data.Commit != "" {
+		cmap.Data[commitField] = data.Commit
+	}
+
+	if data.HelmReleases != nil {
+		if err := setHelmReleases(cmap, data.HelmReleases); err != nil {
+			oktetoLog.Infof("could not store the helm releases of '%s': %s", data.Name, err)
+		}
+	}
+
+	outputData := getOutputData()
 	cmap.Data[outputField] = base64.StdEncoding.EncodeToString(outputData)
 	return cmap
 }
@@ -268,7 +386,7 @@ func updateCmap(cmap *apiv1.ConfigMap, data *CfgData) error {
 	}
 	cmap.Annotations[constants.LastUpdatedAnnotation] = time.Now().UTC().Format(constants.TimeFormat)
 
-	actionName := os.Getenv(model.OktetoActionNameEnvVar)
+	actionName := config.EnvOktetoActionName.Value()
 	if actionName == "" {
 		actionName = actionDefaultName
 	}
@@ -289,15 +407,20 @@ func updateCmap(cmap *apiv1.ConfigMap, data *CfgData) error {
 		cmap.Data[repoField] = data.Repository
 	}
 
-	// if repository is empty, force empty branch
+	// if repository is empty, force empty branch and commit
 	if data.Repository == "" {
 		data.Branch = ""
+		data.Commit = ""
 	}
 
 	if data.Branch != "" {
 		cmap.Data[branchField] = data.Branch
 	}
 
+	if data.Commit != "" {
+		cmap.Data[commitField] = data.Commit
+	}
+
 	// only update field when variables exist
 	if len(data.Variables) > 0 {
 		cmap.Data[variablesField] = translateVariables(data.Variables)
@@ -306,15 +429,20 @@ func updateCmap(cmap *apiv1.ConfigMap, data *CfgData) error {
 		delete(cmap.Data, variablesField)
 	}
 
-	output := oktetoLog.GetOutputBuffer()
-	outputData := translateOutput(output)
+	if data.HelmReleases != nil {
+		if err := setHelmReleases(cmap, data.HelmReleases); err != nil {
+			return err
+		}
+	}
+
+	outputData := getOutputData()
 	cmap.Data[outputField] = base64.StdEncoding.EncodeToString(outputData)
 	return nil
 }
 
 // AddDevAnnotations add deploy labels to the deployments/sfs
 func AddDevAnnotations(ctx context.Context, manifest *model.Manifest, c kubernetes.Interface) {
-	repo := os.Getenv(model.GithubRepositoryEnvVar)
+	repo := config.EnvGithubRepository.Value()
 	for devName, dev := range manifest.Dev {
 		if dev.Autocreate {
 			continue
@@ -374,3 +502,23 @@ func translateVariables(variables []string) string {
 
 	return ""
 }
+
+// decodeVariables is the inverse of translateVariables
+func decodeVariables(encoded string) ([]string, error) {
+	if encoded == "" {
+		return nil, nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	var v []types.DeployVariable
+	if err := json.Unmarshal(decoded, &v); err != nil {
+		return nil, err
+	}
+	var result []string
+	for _, item := range v {
+		result = append(result, fmt.Sprintf("%s=%s", item.Name, item.Value))
+	}
+	return result, nil
+}
@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	"github.com/okteto/okteto/pkg/constants"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
@@ -258,3 +259,80 @@ func Test_translateVariables(t *testing.T) {
 
 	}
 }
+
+func Test_GetConfigmapData(t *testing.T) {
+	ctx := context.Background()
+	data := &CfgData{
+		Name:       "movies",
+		Namespace:  "test",
+		Status:     DeployedStatus,
+		Repository: "https://github.com/okteto/movies",
+		Branch:     "main",
+		Commit:     "1a2b3c4",
+		Filename:   "okteto.yml",
+		Manifest:   []byte("deploy:\n  - kubectl apply -f k8s"),
+		Variables:  []string{"A=1", "B=x=y"},
+	}
+	c := fake.NewSimpleClientset(translateConfigMapSandBox(data))
+
+	result, err := GetConfigmapData(ctx, "movies", "test", c)
+	assert.NoError(t, err)
+	assert.Equal(t, "movies", result.Name)
+	assert.Equal(t, "test", result.Namespace)
+	assert.Equal(t, DeployedStatus, result.Status)
+	assert.Equal(t, "https://github.com/okteto/movies", result.Repository)
+	assert.Equal(t, "main", result.Branch)
+	assert.Equal(t, "1a2b3c4", result.Commit)
+	assert.Equal(t, "okteto.yml", result.Filename)
+	assert.Equal(t, data.Manifest, result.Manifest)
+	assert.Equal(t, []string{"A=1", "B=x=y"}, result.Variables)
+
+	_, err = GetConfigmapData(ctx, "other", "test", c)
+	assert.Error(t, err)
+}
+
+func Test_MergeVariables(t *testing.T) {
+	ctx := context.Background()
+	data := &CfgData{
+		Name:      "movies",
+		Namespace: "staging",
+		Status:    DeployedStatus,
+		Variables: []string{"A=1", "B=2"},
+	}
+	c := fake.NewSimpleClientset(translateConfigMapSandBox(data))
+
+	deployed, err := MergeVariables(ctx, "movies", "staging", []string{"B=3", "C=4"}, c)
+	assert.NoError(t, err)
+	assert.True(t, deployed)
+
+	result, err := GetConfigmapData(ctx, "movies", "staging", c)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A=1", "B=3", "C=4"}, result.Variables)
+	assert.Equal(t, DeployedStatus, result.Status)
+
+	deployed, err = MergeVariables(ctx, "movies", "production", []string{"B=3"}, c)
+	assert.NoError(t, err)
+	assert.False(t, deployed)
+}
+
+func Test_translateOutput(t *testing.T) {
+	previous := maxLogOutputRaw
+	t.Cleanup(func() {
+		maxLogOutputRaw = previous
+	})
+
+	messages := []oktetoLog.JSONMessage{
+		{Level: "info", Stage: "build", Message: "building", Timestamp: 1},
+		{Level: "info", Stage: "deploy", Message: "deploying", Timestamp: 2},
+	}
+	expected := `{"level":"info","stage":"build","message":"building","timestamp":1}
+{"level":"info","stage":"deploy","message":"deploying","timestamp":2}
+`
+	assert.Equal(t, expected, string(translateOutput(messages)))
+
+	maxLogOutputRaw = 80
+	assert.Equal(t, `{"level":"info","stage":"deploy","message":"deploying","timestamp":2}
+`, string(translateOutput(messages)))
+
+	assert.Empty(t, translateOutput(nil))
+}
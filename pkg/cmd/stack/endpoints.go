@@ -16,6 +16,8 @@ package stack
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
 	"sort"
 	"strings"
 
@@ -23,9 +25,11 @@ import (
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/output"
 )
 
-func ListEndpoints(ctx context.Context, stack *model.Stack) error {
+// ListEndpoints prints the endpoints of a stack in an output format of the '--output' flag
+func ListEndpoints(ctx context.Context, stack *model.Stack, outputFormat string) error {
 	c, _, err := okteto.GetK8sClient()
 	if err != nil {
 		return fmt.Errorf("failed to load your local Kubeconfig: %w", err)
@@ -39,11 +43,13 @@ func ListEndpoints(ctx context.Context, stack *model.Stack) error {
 	if err != nil {
 		return err
 	}
-	if len(endpointList) > 0 {
-		sort.Slice(endpointList, func(i, j int) bool {
-			return len(endpointList[i]) < len(endpointList[j])
-		})
-		oktetoLog.Information("Endpoints available:\n  - %s\n", strings.Join(endpointList, "\n  - "))
-	}
-	return nil
+	sort.Slice(endpointList, func(i, j int) bool {
+		return len(endpointList[i]) < len(endpointList[j])
+	})
+	return output.Print(os.Stdout, outputFormat, endpointList, func(io.Writer) error {
+		if len(endpointList) > 0 {
+			oktetoLog.Information("Endpoints available:\n  - %s\n", strings.Join(endpointList, "\n  - "))
+		}
+		return nil
+	})
 }
@@ -0,0 +1,188 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/model"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	apiv1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// PlanActionCreate is the action of resources that don't exist yet
+	PlanActionCreate = "create"
+	// PlanActionUpdate is the action of resources whose live object would change
+	PlanActionUpdate = "update"
+	// PlanActionUnchanged is the action of resources whose live object would not change
+	PlanActionUnchanged = "unchanged"
+	// PlanActionRecreate is the action of resources that can't be updated in place
+	PlanActionRecreate = "recreate"
+)
+
+// ResourcePlan is the result of the server-side dry run of a resource of a service
+type ResourcePlan struct {
+	Kind    string
+	Name    string
+	Action  string
+	Changes []string
+}
+
+// dryRunClient is the subset of the typed k8s clients used by the dry run
+type dryRunClient[T metav1.Object] interface {
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (T, error)
+	Create(ctx context.Context, obj T, opts metav1.CreateOptions) (T, error)
+	Update(ctx context.Context, obj T, opts metav1.UpdateOptions) (T, error)
+}
+
+// DryRunService sends the resources of a service to the cluster with DryRun=All and compares the result with the live objects
+func DryRunService(ctx context.Context, svcName string, s *model.Stack, c kubernetes.Interface) ([]ResourcePlan, error) {
+	svc, ok := s.Services[svcName]
+	if !ok {
+		return nil, fmt.Errorf("service '%s' not found", svcName)
+	}
+	result := []ResourcePlan{}
+	var plan ResourcePlan
+	var err error
+	switch {
+	case svc.IsJob():
+		plan, err = dryRun[*batchv1.Job](ctx, "Job", c.BatchV1().Jobs(s.Namespace), translateJob(svcName, s))
+	case len(svc.Volumes) == 0:
+		plan, err = dryRun[*appsv1.Deployment](ctx, "Deployment", c.AppsV1().Deployments(s.Namespace), translateDeployment(svcName, s))
+	default:
+		plan, err = dryRun[*appsv1.StatefulSet](ctx, "StatefulSet", c.AppsV1().StatefulSets(s.Namespace), translateStatefulSet(svcName, s))
+		if k8sErrors.IsInvalid(err) {
+			// statefulsets with forbidden spec changes are destroyed and created again
+			plan, err = ResourcePlan{Kind: "StatefulSet", Name: svcName, Action: PlanActionRecreate}, nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	result = append(result, plan)
+
+	if len(svc.Ports) > 0 {
+		plan, err := dryRun[*apiv1.Service](ctx, "Service", c.CoreV1().Services(s.Namespace), translateService(svcName, s))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, plan)
+	}
+	return result, nil
+}
+
+func dryRun[T metav1.Object](ctx context.Context, kind string, client dryRunClient[T], desired T) (ResourcePlan, error) {
+	name := desired.GetName()
+	plan := ResourcePlan{Kind: kind, Name: name}
+	live, err := client.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if !k8sErrors.IsNotFound(err) {
+			return plan, fmt.Errorf("could not get %s '%s': %w", strings.ToLower(kind), name, err)
+		}
+		if _, err := client.Create(ctx, desired, metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}}); err != nil {
+			return plan, fmt.Errorf("dry run of %s '%s' failed: %w", strings.ToLower(kind), name, err)
+		}
+		plan.Action = PlanActionCreate
+		return plan, nil
+	}
+
+	// the same values the deploy keeps from the live object
+	if v, ok := live.GetLabels()[model.DeployedByLabel]; ok {
+		labels := desired.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[model.DeployedByLabel] = v
+		desired.SetLabels(labels)
+	}
+	desired.SetResourceVersion(live.GetResourceVersion())
+	updated, err := client.Update(ctx, desired, metav1.UpdateOptions{DryRun: []string{metav1.DryRunAll}})
+	if err != nil {
+		return plan, err
+	}
+	plan.Changes, err = diffObjects(live, updated)
+	if err != nil {
+		return plan, err
+	}
+	plan.Action = PlanActionUnchanged
+	if len(plan.Changes) > 0 {
+		plan.Action = PlanActionUpdate
+	}
+	return plan, nil
+}
+
+// diffObjects returns the paths of the labels, annotations and spec fields that differ between two objects
+func diffObjects(live, updated interface{}) ([]string, error) {
+	liveMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(live)
+	if err != nil {
+		return nil, err
+	}
+	updatedMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(updated)
+	if err != nil {
+		return nil, err
+	}
+	result := []string{}
+	for _, path := range [][]string{{"metadata", "labels"}, {"metadata", "annotations"}, {"spec"}} {
+		a := getNestedField(liveMap, path)
+		b := getNestedField(updatedMap, path)
+		result = append(result, diffValues(strings.Join(path, "."), a, b)...)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+func getNestedField(obj map[string]interface{}, path []string) interface{} {
+	var current interface{} = obj
+	for _, key := range path {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = m[key]
+	}
+	return current
+}
+
+func diffValues(path string, a, b interface{}) []string {
+	aMap, aOk := a.(map[string]interface{})
+	bMap, bOk := b.(map[string]interface{})
+	if !aOk || !bOk {
+		if reflect.DeepEqual(a, b) {
+			return nil
+		}
+		return []string{path}
+	}
+	result := []string{}
+	keys := map[string]bool{}
+	for k := range aMap {
+		keys[k] = true
+	}
+	for k := range bMap {
+		keys[k] = true
+	}
+	for k := range keys {
+		result = append(result, diffValues(path+"."+k, aMap[k], bMap[k])...)
+	}
+	return result
+}
@@ -0,0 +1,135 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes/fake"
+	k8sTesting "k8s.io/client-go/testing"
+)
+
+func newDryRunStack() *model.Stack {
+	return &model.Stack{
+		Name:      "movies",
+		Namespace: "test",
+		Services: map[string]*model.Service{
+			"api": {
+				Image:         "api",
+				RestartPolicy: apiv1.RestartPolicyAlways,
+				Resources:     &model.StackResources{},
+				Ports:         []model.Port{{ContainerPort: 8080, Protocol: apiv1.ProtocolTCP}},
+			},
+			"db": {
+				Image:         "postgres",
+				RestartPolicy: apiv1.RestartPolicyAlways,
+				Resources:     &model.StackResources{},
+				Volumes:       []build.VolumeMounts{{RemotePath: "/data"}},
+			},
+		},
+	}
+}
+
+// addDryRunReactors makes the fake client return the objects of create and update requests without storing them
+func addDryRunReactors(c *fake.Clientset, updateErr error) {
+	c.Fake.PrependReactor("create", "*", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		return true, action.(k8sTesting.CreateAction).GetObject(), nil
+	})
+	c.Fake.PrependReactor("update", "*", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		if updateErr != nil {
+			return true, nil, updateErr
+		}
+		return true, action.(k8sTesting.UpdateAction).GetObject(), nil
+	})
+}
+
+func TestDryRunService(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("create", func(t *testing.T) {
+		s := newDryRunStack()
+		c := fake.NewSimpleClientset()
+		addDryRunReactors(c, nil)
+
+		result, err := DryRunService(ctx, "api", s, c)
+		require.NoError(t, err)
+		assert.Equal(t, []ResourcePlan{
+			{Kind: "Deployment", Name: "api", Action: PlanActionCreate},
+			{Kind: "Service", Name: "api", Action: PlanActionCreate},
+		}, result)
+
+		_, err = c.AppsV1().Deployments("test").Get(ctx, "api", metav1.GetOptions{})
+		assert.True(t, k8sErrors.IsNotFound(err))
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		s := newDryRunStack()
+		c := fake.NewSimpleClientset(translateDeployment("api", s), translateService("api", s))
+		addDryRunReactors(c, nil)
+
+		result, err := DryRunService(ctx, "api", s, c)
+		require.NoError(t, err)
+		assert.Equal(t, []ResourcePlan{
+			{Kind: "Deployment", Name: "api", Action: PlanActionUnchanged, Changes: []string{}},
+			{Kind: "Service", Name: "api", Action: PlanActionUnchanged, Changes: []string{}},
+		}, result)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		s := newDryRunStack()
+		c := fake.NewSimpleClientset(translateDeployment("api", s), translateService("api", s))
+		addDryRunReactors(c, nil)
+		s.Services["api"].Image = "api:v2"
+
+		result, err := DryRunService(ctx, "api", s, c)
+		require.NoError(t, err)
+		assert.Equal(t, []ResourcePlan{
+			{Kind: "Deployment", Name: "api", Action: PlanActionUpdate, Changes: []string{"spec.template.spec.containers"}},
+			{Kind: "Service", Name: "api", Action: PlanActionUnchanged, Changes: []string{}},
+		}, result)
+	})
+
+	t.Run("recreate", func(t *testing.T) {
+		s := newDryRunStack()
+		c := fake.NewSimpleClientset(translateStatefulSet("db", s))
+		forbidden := k8sErrors.NewInvalid(
+			schema.GroupKind{Group: "apps", Kind: "StatefulSet"},
+			"db",
+			field.ErrorList{field.Forbidden(field.NewPath("spec"), "updates to statefulset spec are forbidden")},
+		)
+		addDryRunReactors(c, forbidden)
+
+		result, err := DryRunService(ctx, "db", s, c)
+		require.NoError(t, err)
+		assert.Equal(t, []ResourcePlan{
+			{Kind: "StatefulSet", Name: "db", Action: PlanActionRecreate},
+		}, result)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := DryRunService(ctx, "web", newDryRunStack(), fake.NewSimpleClientset())
+		assert.Error(t, err)
+	})
+}
@@ -17,7 +17,6 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
-	"os"
 	"sort"
 	"strconv"
 	"strings"
@@ -25,7 +24,7 @@ import (
 	buildv2 "github.com/okteto/okteto/cmd/build/v2"
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/build"
-	"github.com/okteto/okteto/pkg/env"
+	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/format"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/log/io"
@@ -53,9 +52,6 @@ const (
 	destroyingStatus  = "destroying"
 
 	pvcName = "pvc"
-
-	// oktetoComposeVolumeAffinityEnabledEnvVar represents whether the feature flag to enable volume affinity is enabled or not
-	oktetoComposeVolumeAffinityEnabledEnvVar = "OKTETO_COMPOSE_VOLUME_AFFINITY_ENABLED"
 )
 
 // +enum
@@ -466,7 +462,7 @@ func translateVolumeLabels(volumeName string, s *model.Stack) map[string]string
 }
 
 func translateAffinity(svc *model.Service) *apiv1.Affinity {
-	if !env.LoadBooleanOrDefault(oktetoComposeVolumeAffinityEnabledEnvVar, true) {
+	if !config.EnvOktetoComposeVolumeAffinityEnabled.IsTrue() {
 		return nil
 	}
 
@@ -808,7 +804,7 @@ func getUpdateStrategyByAnnotation(svc *model.Service) updateStrategy {
 }
 
 func getUpdateStrategyByEnvVar() updateStrategy {
-	if v := os.Getenv(model.OktetoComposeUpdateStrategyEnvVar); v != "" {
+	if v := config.EnvOktetoComposeUpdateStrategy.Value(); v != "" {
 		return updateStrategy(v)
 	}
 	return ""
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/env"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/stretchr/testify/assert"
@@ -1547,7 +1548,7 @@ func Test_translateAffinity(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.disableVolumeAffinity {
-				t.Setenv(oktetoComposeVolumeAffinityEnabledEnvVar, "false")
+				t.Setenv(config.EnvOktetoComposeVolumeAffinityEnabled.Name, "false")
 			}
 			aff := translateAffinity(tt.svc)
 			assert.Equal(t, tt.affinity, aff)
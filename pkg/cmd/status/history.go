@@ -0,0 +1,144 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/docker/go-units"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/syncthing"
+)
+
+var sparklineTicks = []rune("▁▂▃▄▅▆▇█")
+
+// historySampler takes samples of the synchronization status
+type historySampler struct {
+	sy           *syncthing.Syncthing
+	now          func() time.Time
+	last         time.Time
+	lastInBytes  int64
+	lastOutBytes int64
+}
+
+// MonitorHistory persists a sample of the synchronization status in path every syncthing.HistorySampleInterval until ctx is done
+func MonitorHistory(ctx context.Context, sy *syncthing.Syncthing, path string) {
+	sampler := &historySampler{sy: sy, now: time.Now}
+	ticker := time.NewTicker(syncthing.HistorySampleInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := syncthing.AppendHistorySample(path, sampler.sample(ctx)); err != nil {
+				oktetoLog.Infof("error saving sync status sample: %s", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (h *historySampler) sample(ctx context.Context) syncthing.HistorySample {
+	sample := syncthing.HistorySample{Time: h.now()}
+	progress, err := Run(ctx, h.sy)
+	if err != nil {
+		sample.Error = err.Error()
+		return sample
+	}
+	sample.Progress = progress
+
+	if pullErrors, err := h.sy.GetPullErrors(ctx, true); err == nil {
+		sample.PullErrors = pullErrors
+	}
+
+	inBytes, outBytes, err := h.sy.GetTransferredBytes(ctx, true)
+	if err != nil {
+		sample.Error = err.Error()
+		return sample
+	}
+	if !h.last.IsZero() {
+		elapsed := sample.Time.Sub(h.last).Seconds()
+		if elapsed > 0 && inBytes >= h.lastInBytes && outBytes >= h.lastOutBytes {
+			sample.InBytesPerSecond = float64(inBytes-h.lastInBytes) / elapsed
+			sample.OutBytesPerSecond = float64(outBytes-h.lastOutBytes) / elapsed
+		}
+	}
+	h.last = sample.Time
+	h.lastInBytes = inBytes
+	h.lastOutBytes = outBytes
+	return sample
+}
+
+// FilterHistory returns the samples taken after since
+func FilterHistory(samples []syncthing.HistorySample, since time.Time) []syncthing.HistorySample {
+	result := []syncthing.HistorySample{}
+	for _, s := range samples {
+		if s.Time.After(since) {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// RenderHistory writes the samples as sparklines followed by a table
+func RenderHistory(w io.Writer, samples []syncthing.HistorySample) error {
+	progress := make([]float64, 0, len(samples))
+	upload := make([]float64, 0, len(samples))
+	download := make([]float64, 0, len(samples))
+	for _, s := range samples {
+		progress = append(progress, s.Progress)
+		upload = append(upload, s.OutBytesPerSecond)
+		download = append(download, s.InBytesPerSecond)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Progress:\t%s\n", sparkline(progress, 100))
+	fmt.Fprintf(tw, "Upload:\t%s\n", sparkline(upload, 0))
+	fmt.Fprintf(tw, "Download:\t%s\n", sparkline(download, 0))
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "TIME\tPROGRESS\tUPLOAD\tDOWNLOAD\tERRORS")
+	for _, s := range samples {
+		errs := fmt.Sprintf("%d", s.PullErrors)
+		if s.Error != "" {
+			errs = s.Error
+		}
+		fmt.Fprintf(tw, "%s\t%.2f%%\t%s/s\t%s/s\t%s\n", s.Time.Local().Format(time.TimeOnly), s.Progress, units.BytesSize(s.OutBytesPerSecond), units.BytesSize(s.InBytesPerSecond), errs)
+	}
+	return tw.Flush()
+}
+
+// sparkline renders the values scaled to max. If max is 0, values are scaled to the largest one
+func sparkline(values []float64, max float64) string {
+	if max == 0 {
+		for _, v := range values {
+			max = math.Max(max, v)
+		}
+	}
+	var sb strings.Builder
+	for _, v := range values {
+		idx := 0
+		if max > 0 {
+			idx = int(math.Round(v / max * float64(len(sparklineTicks)-1)))
+		}
+		idx = int(math.Min(math.Max(float64(idx), 0), float64(len(sparklineTicks)-1)))
+		sb.WriteRune(sparklineTicks[idx])
+	}
+	return sb.String()
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/syncthing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_sparkline(t *testing.T) {
+	assert.Equal(t, "▁▅█", sparkline([]float64{0, 50, 100}, 100))
+	assert.Equal(t, "▁▂█", sparkline([]float64{0, 10, 70}, 0))
+	assert.Equal(t, "▁▁", sparkline([]float64{0, 0}, 0))
+	assert.Equal(t, "", sparkline(nil, 100))
+}
+
+func TestFilterHistory(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	samples := []syncthing.HistorySample{
+		{Time: now.Add(-20 * time.Minute)},
+		{Time: now.Add(-5 * time.Minute)},
+		{Time: now.Add(-1 * time.Minute)},
+	}
+	assert.Equal(t, samples[1:], FilterHistory(samples, now.Add(-10*time.Minute)))
+	assert.Empty(t, FilterHistory(samples, now))
+}
+
+func TestRenderHistory(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.Local)
+	samples := []syncthing.HistorySample{
+		{Time: now, Progress: 50, OutBytesPerSecond: 2048},
+		{Time: now.Add(10 * time.Second), Progress: 100, PullErrors: 1},
+		{Time: now.Add(20 * time.Second), Error: "lost"},
+	}
+	out := &bytes.Buffer{}
+	require.NoError(t, RenderHistory(out, samples))
+
+	expected := `Progress:  ▅█▁
+Upload:    █▁▁
+Download:  ▁▁▁
+
+TIME      PROGRESS  UPLOAD  DOWNLOAD  ERRORS
+12:00:00  50.00%    2KiB/s  0B/s      0
+12:00:10  100.00%   0B/s    0B/s      1
+12:00:20  0.00%     0B/s    0B/s      lost
+`
+	assert.Equal(t, expected, out.String())
+}
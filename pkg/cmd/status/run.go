@@ -91,17 +91,21 @@ func Wait(dev *model.Dev, okStatusList []config.UpState) error {
 
 		ticker := time.NewTicker(500 * time.Millisecond)
 		for {
-			status, err := config.GetState(dev.Name, dev.Namespace)
+			info, err := config.GetStateInfo(dev.Name, dev.Namespace)
 			if err != nil {
 				exit <- err
 				return
 			}
-			if status == config.Failed {
+			if info.State == config.Failed {
+				if info.LastError != "" {
+					exit <- fmt.Errorf("your development container has failed: %s", info.LastError)
+					return
+				}
 				exit <- fmt.Errorf("your development container has failed")
 				return
 			}
 			for _, okStatus := range okStatusList {
-				if status == okStatus {
+				if info.State == okStatus {
 					exit <- nil
 					return
 				}
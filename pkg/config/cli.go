@@ -0,0 +1,235 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"gopkg.in/yaml.v2"
+)
+
+// ErrUnknownCLIConfigKey is returned when a key is not part of the cli config
+var ErrUnknownCLIConfigKey = errors.New("unknown config key")
+
+// CLIConfig represents the defaults persisted by the user in $OKTETO_HOME/config.yaml.
+// Flags and environment variables take precedence over these values
+type CLIConfig struct {
+	LogOutput       string `yaml:"log-output,omitempty"`
+	Telemetry       *bool  `yaml:"telemetry,omitempty"`
+	DefaultContext  string `yaml:"default-context,omitempty"`
+	BuildkitTimeout string `yaml:"buildkit-timeout,omitempty"`
+	NoColor         *bool  `yaml:"no-color,omitempty"`
+}
+
+// CLIConfigValue represents a key of the cli config and its value
+type CLIConfigValue struct {
+	Key         string `json:"key" yaml:"key"`
+	Value       string `json:"value" yaml:"value"`
+	Description string `json:"description" yaml:"description"`
+}
+
+type cliConfigKey struct {
+	get         func(c *CLIConfig) string
+	set         func(c *CLIConfig, value string) error
+	name        string
+	description string
+}
+
+var cliConfigKeys = []cliConfigKey{
+	{
+		name:        "log-output",
+		description: "default output format for logs (tty, plain, json)",
+		get:         func(c *CLIConfig) string { return c.LogOutput },
+		set: func(c *CLIConfig, value string) error {
+			switch value {
+			case "", oktetoLog.TTYFormat, oktetoLog.PlainFormat, oktetoLog.JSONFormat:
+				c.LogOutput = value
+				return nil
+			}
+			return fmt.Errorf("invalid log output '%s': supported values are 'tty', 'plain' and 'json'", value)
+		},
+	},
+	{
+		name:        "telemetry",
+		description: "send anonymous usage analytics",
+		get:         func(c *CLIConfig) string { return formatBoolPtr(c.Telemetry) },
+		set: func(c *CLIConfig, value string) (err error) {
+			c.Telemetry, err = parseBoolPtr(value)
+			return err
+		},
+	},
+	{
+		name:        "default-context",
+		description: "okteto context used when no context has been selected",
+		get:         func(c *CLIConfig) string { return c.DefaultContext },
+		set: func(c *CLIConfig, value string) error {
+			c.DefaultContext = value
+			return nil
+		},
+	},
+	{
+		name:        "buildkit-timeout",
+		description: "maximum duration of a build in the okteto builder, e.g. 10m",
+		get:         func(c *CLIConfig) string { return c.BuildkitTimeout },
+		set: func(c *CLIConfig, value string) error {
+			if value != "" {
+				if _, err := time.ParseDuration(value); err != nil {
+					return fmt.Errorf("invalid buildkit timeout '%s': %w", value, err)
+				}
+			}
+			c.BuildkitTimeout = value
+			return nil
+		},
+	},
+	{
+		name:        "no-color",
+		description: "disable colors in the output",
+		get:         func(c *CLIConfig) string { return formatBoolPtr(c.NoColor) },
+		set: func(c *CLIConfig, value string) (err error) {
+			c.NoColor, err = parseBoolPtr(value)
+			return err
+		},
+	},
+}
+
+var currentCLIConfig *CLIConfig
+
+// GetCLIConfigPath returns the path of the cli config
+func GetCLIConfigPath() string {
+	return filepath.Join(GetOktetoHome(), cliConfigFile)
+}
+
+// GetCLIConfig returns the cli config of the user. Errors reading it are logged and the defaults are used
+func GetCLIConfig() *CLIConfig {
+	if currentCLIConfig != nil {
+		return currentCLIConfig
+	}
+	c, err := LoadCLIConfig(GetCLIConfigPath())
+	if err != nil {
+		oktetoLog.Infof("failed to load cli config: %s", err)
+		c = &CLIConfig{}
+	}
+	currentCLIConfig = c
+	return currentCLIConfig
+}
+
+// LoadCLIConfig reads the cli config from a path. A missing file returns an empty config
+func LoadCLIConfig(path string) (*CLIConfig, error) {
+	c := &CLIConfig{}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return c, nil
+		}
+		return nil, err
+	}
+	if err := yaml.UnmarshalStrict(b, c); err != nil {
+		return nil, fmt.Errorf("failed to parse '%s': %w", path, err)
+	}
+	return c, nil
+}
+
+// Save writes the cli config to a path
+func (c *CLIConfig) Save(path string) error {
+	b, err := yaml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to generate cli config: %w", err)
+	}
+	if err := os.WriteFile(path, b, 0600); err != nil {
+		return fmt.Errorf("failed to write '%s': %w", path, err)
+	}
+	currentCLIConfig = c
+	return nil
+}
+
+// Get returns the value of a key
+func (c *CLIConfig) Get(key string) (string, error) {
+	k, err := getCLIConfigKey(key)
+	if err != nil {
+		return "", err
+	}
+	return k.get(c), nil
+}
+
+// Set validates and sets the value of a key. An empty value unsets the key
+func (c *CLIConfig) Set(key, value string) error {
+	k, err := getCLIConfigKey(key)
+	if err != nil {
+		return err
+	}
+	return k.set(c, value)
+}
+
+// List returns all the keys of the cli config with their values
+func (c *CLIConfig) List() []CLIConfigValue {
+	result := make([]CLIConfigValue, 0, len(cliConfigKeys))
+	for _, k := range cliConfigKeys {
+		result = append(result, CLIConfigValue{Key: k.name, Value: k.get(c), Description: k.description})
+	}
+	return result
+}
+
+// IsTelemetryEnabled returns false only if the user disabled the telemetry in the cli config
+func (c *CLIConfig) IsTelemetryEnabled() bool {
+	return c.Telemetry == nil || *c.Telemetry
+}
+
+// IsNoColor returns if the user disabled colors in the cli config
+func (c *CLIConfig) IsNoColor() bool {
+	return c.NoColor != nil && *c.NoColor
+}
+
+// GetBuildkitTimeout returns the maximum duration of a build. The environment variable takes precedence over the cli config.
+// Zero means no timeout
+func GetBuildkitTimeout() time.Duration {
+	d, err := EnvOktetoBuildkitTimeout.Duration()
+	if err != nil {
+		oktetoLog.Infof("invalid buildkit timeout: %s", err)
+		return 0
+	}
+	return d
+}
+
+func getCLIConfigKey(key string) (*cliConfigKey, error) {
+	for i := range cliConfigKeys {
+		if cliConfigKeys[i].name == key {
+			return &cliConfigKeys[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w '%s'", ErrUnknownCLIConfigKey, key)
+}
+
+func formatBoolPtr(b *bool) string {
+	if b == nil {
+		return ""
+	}
+	return strconv.FormatBool(*b)
+}
+
+func parseBoolPtr(value string) (*bool, error) {
+	if value == "" {
+		return nil, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid boolean '%s'", value)
+	}
+	return &b, nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCLIConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	c, err := LoadCLIConfig(path)
+	require.NoError(t, err)
+	assert.True(t, c.IsTelemetryEnabled())
+	assert.False(t, c.IsNoColor())
+
+	require.NoError(t, c.Set("log-output", "plain"))
+	require.NoError(t, c.Set("telemetry", "false"))
+	require.NoError(t, c.Set("buildkit-timeout", "10m"))
+	require.NoError(t, c.Set("no-color", "true"))
+	require.NoError(t, c.Save(path))
+
+	c, err = LoadCLIConfig(path)
+	require.NoError(t, err)
+	value, err := c.Get("log-output")
+	require.NoError(t, err)
+	assert.Equal(t, "plain", value)
+	assert.False(t, c.IsTelemetryEnabled())
+	assert.True(t, c.IsNoColor())
+
+	require.NoError(t, c.Set("no-color", ""))
+	assert.Nil(t, c.NoColor)
+
+	assert.Error(t, c.Set("log-output", "xml"))
+	assert.Error(t, c.Set("telemetry", "maybe"))
+	assert.Error(t, c.Set("buildkit-timeout", "10"))
+	assert.ErrorIs(t, c.Set("unknown", "value"), ErrUnknownCLIConfigKey)
+	_, err = c.Get("unknown")
+	assert.ErrorIs(t, err, ErrUnknownCLIConfigKey)
+
+	assert.Len(t, c.List(), len(cliConfigKeys))
+}
+
+func TestGetBuildkitTimeout(t *testing.T) {
+	currentCLIConfig = &CLIConfig{BuildkitTimeout: "10m"}
+	t.Cleanup(func() {
+		currentCLIConfig = nil
+	})
+
+	assert.Equal(t, 10*time.Minute, GetBuildkitTimeout())
+
+	t.Setenv(constants.OktetoBuildkitTimeoutEnvVar, "1h")
+	assert.Equal(t, time.Hour, GetBuildkitTimeout())
+
+	t.Setenv(constants.OktetoBuildkitTimeoutEnvVar, "invalid")
+	assert.Equal(t, time.Duration(0), GetBuildkitTimeout())
+}
@@ -20,42 +20,32 @@ import (
 	"runtime"
 	"strings"
 
-	"github.com/okteto/okteto/pkg/constants"
-	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/filesystem"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"gopkg.in/yaml.v2"
 )
 
-// UpState represents the state of the up command
-type UpState string
-
 const (
 	deprecatedAnalyticsFile = ".noanalytics"
 	analyticsFile           = "analytics.json"
+	analyticsSpoolFile      = "analytics-spool.json"
+	crashReportsDir         = "crash-reports"
+	invocationLogsDir       = "logs"
+	updateCheckFile         = "update-check.json"
+	completionCacheFile     = "completion-cache.json"
+	completionDir           = "completion"
+	apiCircuitBreakerFile   = "api-circuit-breaker.json"
+	apiCapabilitiesFile     = "api-capabilities.json"
+	cliConfigFile           = "config.yaml"
+	registryCredentialsFile = "registry-credentials.json"
+	binDir                  = "bin"
 	tokenFile               = ".token.json"
 	contextDir              = "context"
 	contextsStoreFile       = "config.json"
 
 	oktetoFolderName = ".okteto"
-	// Activating up started
-	Activating UpState = "activating"
-	// Starting up started the dev pod creation
-	Starting = "starting"
-	// Attaching up attaching volume
-	Attaching = "attaching"
-	// Pulling  up pulling images
-	Pulling = "pulling"
-	// StartingSync up preparing syncthing
-	StartingSync = "startingSync"
-	// Synchronizing up is syncthing
-	Synchronizing = "synchronizing"
-	// Ready up finished
-	Ready = "ready"
-	// Failed up failed
-	Failed = "failed"
-
-	stateFile string = "okteto.state"
+
+	forwardProfilesFile string = "okteto.forwards"
 
 	// OktetoContextVariableName defines the kubeconfig context of okteto commands
 	OktetoContextVariableName = "OKTETO_CONTEXT"
@@ -81,7 +71,7 @@ func GetBinaryFullPath() string {
 
 // GetOktetoHome returns the path of the okteto folder
 func GetOktetoHome() string {
-	if v, ok := os.LookupEnv(constants.OktetoFolderEnvVar); ok {
+	if v, ok := EnvOktetoFolder.Lookup(); ok {
 		if !filesystem.FileExists(v) {
 			oktetoLog.Fatalf("OKTETO_FOLDER doesn't exist: %s", v)
 		}
@@ -123,72 +113,69 @@ func GetAppHome(namespace, name string) string {
 	return d
 }
 
-// UpdateStateFile updates the state file of a given dev environment
-func UpdateStateFile(devName, devNamespace string, state UpState) error {
+// EnableForwardProfile adds a forward profile to the ones enabled for a given dev environment
+func EnableForwardProfile(devName, devNamespace, profile string) error {
 	if devNamespace == "" {
-		return fmt.Errorf("can't update state file, namespace is empty")
+		return fmt.Errorf("can't enable forward profile, namespace is empty")
 	}
 
 	if devName == "" {
-		return fmt.Errorf("can't update state file, name is empty")
+		return fmt.Errorf("can't enable forward profile, name is empty")
 	}
 
-	s := filepath.Join(GetAppHome(devNamespace, devName), stateFile)
-
-	oktetoLog.Infof("updating file '%s'", s)
-	if err := os.WriteFile(s, []byte(state), 0600); err != nil {
-		return fmt.Errorf("failed to update state file: %w", err)
-	}
-	oktetoLog.Infof("file '%s' updated successfully", s)
-
-	return nil
-}
-
-// DeleteStateFile deletes the state file of a given dev environment
-func DeleteStateFile(devName, devNamespace string) error {
-	if devNamespace == "" {
-		return fmt.Errorf("can't delete state file, namespace is empty")
+	profiles, err := GetEnabledForwardProfiles(devName, devNamespace)
+	if err != nil {
+		return err
 	}
-
-	if devName == "" {
-		return fmt.Errorf("can't delete state file, name is empty")
+	for _, p := range profiles {
+		if p == profile {
+			return nil
+		}
 	}
+	profiles = append(profiles, profile)
 
-	s := filepath.Join(GetAppHome(devNamespace, devName), stateFile)
-	return os.Remove(s)
-}
-
-// GetState returns the state of a given dev environment
-func GetState(devName, devNamespace string) (UpState, error) {
-	var result UpState
-	if devNamespace == "" {
-		return Failed, fmt.Errorf("can't update state file, namespace is empty")
+	bytes, err := yaml.Marshal(profiles)
+	if err != nil {
+		return err
 	}
 
-	if devName == "" {
-		return Failed, fmt.Errorf("can't update state file, name is empty")
+	s := filepath.Join(GetAppHome(devNamespace, devName), forwardProfilesFile)
+	if err := os.WriteFile(s, bytes, 0600); err != nil {
+		return fmt.Errorf("failed to update forward profiles file: %w", err)
 	}
+	return nil
+}
 
-	statePath := filepath.Join(GetAppHome(devNamespace, devName), stateFile)
-	stateBytes, err := os.ReadFile(statePath)
+// GetEnabledForwardProfiles returns the forward profiles enabled for a given dev environment
+func GetEnabledForwardProfiles(devName, devNamespace string) ([]string, error) {
+	s := filepath.Join(GetAppHome(devNamespace, devName), forwardProfilesFile)
+	bytes, err := os.ReadFile(s)
 	if err != nil {
-		oktetoLog.Infof("error reading state file: %s", err.Error())
-		return Failed, oktetoErrors.UserError{
-			E:    fmt.Errorf("development mode isn't enabled on your deployment"),
-			Hint: "Run 'okteto up' to enable it and try again",
+		if os.IsNotExist(err) {
+			return []string{}, nil
 		}
+		return nil, err
 	}
 
-	if err := yaml.Unmarshal(stateBytes, &result); err != nil {
-		return Failed, err
+	profiles := []string{}
+	if err := yaml.Unmarshal(bytes, &profiles); err != nil {
+		return nil, err
 	}
+	return profiles, nil
+}
 
-	return result, nil
+// DeleteForwardProfilesFile disables all the forward profiles of a given dev environment
+func DeleteForwardProfilesFile(devName, devNamespace string) error {
+	s := filepath.Join(GetAppHome(devNamespace, devName), forwardProfilesFile)
+	if err := os.Remove(s); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
 }
 
 // GetUserHomeDir returns the OS home dir
 func GetUserHomeDir() string {
-	if v, ok := os.LookupEnv(constants.OktetoHomeEnvVar); ok {
+	if v, ok := EnvOktetoHome.Lookup(); ok {
 		if !filesystem.FileExists(v) {
 			oktetoLog.Fatalf("OKTETO_HOME points to a non-existing directory: %s", v)
 		}
@@ -232,7 +219,7 @@ func homedirWindows() (string, error) {
 func GetKubeconfigPath() []string {
 	home := GetUserHomeDir()
 	kubeconfig := []string{filepath.Join(home, ".kube", "config")}
-	kubeconfigEnv := os.Getenv(constants.KubeConfigEnvVar)
+	kubeconfigEnv := EnvKubeconfig.Value()
 	if len(kubeconfigEnv) > 0 {
 		kubeconfig = splitKubeConfigEnv(kubeconfigEnv)
 	}
@@ -258,6 +245,62 @@ func GetAnalyticsPath() string {
 	return filepath.Join(GetOktetoHome(), analyticsFile)
 }
 
+// GetAnalyticsSpoolPath returns the path of the file storing the analytics events that couldn't be sent
+func GetAnalyticsSpoolPath() string {
+	return filepath.Join(GetOktetoHome(), analyticsSpoolFile)
+}
+
+// GetCrashReportsDir returns the path of the folder storing the crash reports
+func GetCrashReportsDir() string {
+	d := filepath.Join(GetOktetoHome(), crashReportsDir)
+
+	if err := os.MkdirAll(d, 0700); err != nil {
+		oktetoLog.Fatalf("failed to create %s: %s", d, err)
+	}
+
+	return d
+}
+
+// GetInvocationLogsDir returns the path of the folder storing the logs of each command run
+func GetInvocationLogsDir() string {
+	return filepath.Join(GetOktetoHome(), invocationLogsDir)
+}
+
+// GetUpdateCheckPath returns the path of the file storing the last time the cli checked for new versions
+func GetUpdateCheckPath() string {
+	return filepath.Join(GetOktetoHome(), updateCheckFile)
+}
+
+// GetCompletionCachePath returns the path of the file caching the values used by the shell completion
+func GetCompletionCachePath() string {
+	return filepath.Join(GetOktetoHome(), completionCacheFile)
+}
+
+// GetCompletionDir returns the path of the folder storing the installed shell completion scripts
+func GetCompletionDir() string {
+	return filepath.Join(GetOktetoHome(), completionDir)
+}
+
+// GetAPICircuitBreakerPath returns the path of the file storing the consecutive failures of the okteto API
+func GetAPICircuitBreakerPath() string {
+	return filepath.Join(GetOktetoHome(), apiCircuitBreakerFile)
+}
+
+// GetAPICapabilitiesPath returns the path of the file caching the features supported by the okteto API of each context
+func GetAPICapabilitiesPath() string {
+	return filepath.Join(GetOktetoHome(), apiCapabilitiesFile)
+}
+
+// GetRegistryCredentialsPath returns the path of the file storing the short-lived credentials of external registries
+func GetRegistryCredentialsPath() string {
+	return filepath.Join(GetOktetoHome(), registryCredentialsFile)
+}
+
+// GetBinFolder returns the path of the folder caching the binaries installed by okteto
+func GetBinFolder() string {
+	return filepath.Join(GetOktetoHome(), binDir)
+}
+
 func GetOktetoContextFolder() string {
 	return filepath.Join(GetOktetoHome(), contextDir)
 }
@@ -274,18 +317,15 @@ func GetCertificatePath() string {
 // GetDeployOrigin gets the pipeline deploy origin. This is the initiator of the
 // deploy action: web, cli, github-action, etc
 func GetDeployOrigin() (src string) {
-	src = os.Getenv(oktetoOriginEnvVar)
-	if src == "" {
-		src = "cli"
-	}
+	src = EnvOktetoOrigin.Value()
 	// deploys within another okteto deploy take precedence as a deploy origin.
 	// This is running okteto pipeline deploy as a step of another okteto deploy
-	if os.Getenv(constants.OktetoWithinDeployCommandContextEnvVar) == "true" {
+	if EnvOktetoWithinDeployCommandContext.Value() == "true" {
 		src = "okteto-deploy"
 	}
 	return
 }
 
 func RunningInInstaller() bool {
-	return os.Getenv(oktetoInInstaller) == "true"
+	return EnvOktetoInInstaller.Value() == "true"
 }
@@ -16,6 +16,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 
 	"github.com/okteto/okteto/pkg/constants"
@@ -135,3 +136,43 @@ func TestGetAppHome(t *testing.T) {
 		t.Errorf("expected %s, got %s", expected, got)
 	}
 }
+
+func TestForwardProfiles(t *testing.T) {
+	t.Setenv(constants.OktetoHomeEnvVar, t.TempDir())
+
+	profiles, err := GetEnabledForwardProfiles("api", "ns")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(profiles) != 0 {
+		t.Fatalf("expected no profiles, got %v", profiles)
+	}
+
+	for _, p := range []string{"debug", "db", "debug"} {
+		if err := EnableForwardProfile("api", "ns", p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	profiles, err = GetEnabledForwardProfiles("api", "ns")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(profiles, []string{"debug", "db"}) {
+		t.Errorf("got %v, expected [debug db]", profiles)
+	}
+
+	if err := DeleteForwardProfilesFile("api", "ns"); err != nil {
+		t.Fatal(err)
+	}
+	if err := DeleteForwardProfilesFile("api", "ns"); err != nil {
+		t.Fatal(err)
+	}
+	profiles, err = GetEnabledForwardProfiles("api", "ns")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(profiles) != 0 {
+		t.Errorf("expected no profiles after delete, got %v", profiles)
+	}
+}
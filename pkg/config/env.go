@@ -0,0 +1,576 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/filesystem"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// EnvVarType is the type of the value of an environment variable
+type EnvVarType string
+
+const (
+	// StringEnvVar is an environment variable with a text value
+	StringEnvVar EnvVarType = "string"
+	// BoolEnvVar is an environment variable with a boolean value
+	BoolEnvVar EnvVarType = "bool"
+	// IntEnvVar is an environment variable with an integer value
+	IntEnvVar EnvVarType = "int"
+	// DurationEnvVar is an environment variable with a duration value, e.g. 10m
+	DurationEnvVar EnvVarType = "duration"
+
+	// EnvSourceEnvironment means the value is defined in the environment
+	EnvSourceEnvironment = "environment"
+	// EnvSourceCLIConfig means the value is defined in $OKTETO_HOME/config.yaml
+	EnvSourceCLIConfig = "config file"
+	// EnvSourceDefault means the default value is used
+	EnvSourceDefault = "default"
+	// EnvSourceUnset means the environment variable is not defined and has no default value
+	EnvSourceUnset = "unset"
+)
+
+// EnvVar represents an environment variable honored by the okteto cli
+type EnvVar struct {
+	// cliConfig returns the value of the cli config used when the environment variable is not defined
+	cliConfig   func(c *CLIConfig) string
+	Name        string
+	Type        EnvVarType
+	Default     string
+	Description string
+	// Deprecated is the message shown when a deprecated environment variable is used
+	Deprecated string
+	// Sensitive values are never shown
+	Sensitive bool
+}
+
+// EnvVarValue represents an environment variable, its current value and where the value comes from
+type EnvVarValue struct {
+	Name        string `json:"name" yaml:"name"`
+	Type        string `json:"type" yaml:"type"`
+	Value       string `json:"value" yaml:"value"`
+	Source      string `json:"source" yaml:"source"`
+	Description string `json:"description" yaml:"description"`
+	Deprecated  string `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+}
+
+// envVars are all the environment variables registered with newEnvVar
+var envVars []*EnvVar
+
+func newEnvVar(e *EnvVar) *EnvVar {
+	envVars = append(envVars, e)
+	return e
+}
+
+var (
+	// EnvOktetoURL is the okteto context used when no context is selected
+	EnvOktetoURL = newEnvVar(&EnvVar{
+		Name:        "OKTETO_URL",
+		Type:        StringEnvVar,
+		Description: "url of the okteto context used when no context is selected",
+	})
+	// EnvOktetoContext is the context used when no context is selected
+	EnvOktetoContext = newEnvVar(&EnvVar{
+		Name:        "OKTETO_CONTEXT",
+		Type:        StringEnvVar,
+		Description: "okteto or kubernetes context used when no context is selected",
+	})
+	// EnvOktetoToken is the token used to authenticate with the okteto context
+	EnvOktetoToken = newEnvVar(&EnvVar{
+		Name:        "OKTETO_TOKEN",
+		Type:        StringEnvVar,
+		Description: "token used to authenticate with the okteto context",
+		Sensitive:   true,
+	})
+	// EnvOktetoNamespace is the namespace used when no namespace is selected
+	EnvOktetoNamespace = newEnvVar(&EnvVar{
+		Name:        "OKTETO_NAMESPACE",
+		Type:        StringEnvVar,
+		Description: "namespace used when no namespace is selected",
+	})
+	// EnvOktetoName is the name of the development environment
+	EnvOktetoName = newEnvVar(&EnvVar{
+		Name:        constants.OktetoNameEnvVar,
+		Type:        StringEnvVar,
+		Description: "name of the development environment used when the manifest doesn't define it",
+	})
+	// EnvOktetoHome is the home directory of the user
+	EnvOktetoHome = newEnvVar(&EnvVar{
+		Name:        constants.OktetoHomeEnvVar,
+		Type:        StringEnvVar,
+		Description: "directory used as the home directory of the user, it must exist",
+	})
+	// EnvOktetoFolder is the path of the okteto folder
+	EnvOktetoFolder = newEnvVar(&EnvVar{
+		Name:        constants.OktetoFolderEnvVar,
+		Type:        StringEnvVar,
+		Description: "path of the okteto folder, it must exist (defaults to $HOME/.okteto)",
+	})
+	// EnvKubeconfig are the paths of the kubeconfig files
+	EnvKubeconfig = newEnvVar(&EnvVar{
+		Name:        constants.KubeConfigEnvVar,
+		Type:        StringEnvVar,
+		Description: "paths of the kubeconfig files (defaults to $HOME/.kube/config)",
+	})
+	// EnvOktetoBin is the image with the okteto binaries injected in the development containers
+	EnvOktetoBin = newEnvVar(&EnvVar{
+		Name:        "OKTETO_BIN",
+		Type:        StringEnvVar,
+		Description: "image with the okteto binaries injected in the development containers",
+	})
+	// EnvOktetoTimeout is the maximum time to wait for the development container and the deploy commands
+	EnvOktetoTimeout = newEnvVar(&EnvVar{
+		Name:        "OKTETO_TIMEOUT",
+		Type:        DurationEnvVar,
+		Description: "maximum time to wait for 'okteto up' (defaults to 60s) and 'okteto deploy --wait' (defaults to 5m)",
+	})
+	// EnvOktetoSSHTimeout is the timeout of the ssh connections to the development container
+	EnvOktetoSSHTimeout = newEnvVar(&EnvVar{
+		Name:        "OKTETO_SSH_TIMEOUT",
+		Type:        DurationEnvVar,
+		Default:     "10s",
+		Description: "timeout of the ssh connections to the development container",
+	})
+	// EnvOktetoKubernetesTimeout is the timeout of the requests to the kubernetes API
+	EnvOktetoKubernetesTimeout = newEnvVar(&EnvVar{
+		Name:        "OKTETO_KUBERNETES_TIMEOUT",
+		Type:        DurationEnvVar,
+		Default:     "0s",
+		Description: "timeout of the requests to the kubernetes API, zero means no timeout",
+	})
+	// EnvOktetoBuildkitTimeout is the maximum duration of a build in the okteto builder
+	EnvOktetoBuildkitTimeout = newEnvVar(&EnvVar{
+		Name:        constants.OktetoBuildkitTimeoutEnvVar,
+		Type:        DurationEnvVar,
+		Description: "maximum duration of a build in the okteto builder, zero means no timeout",
+		cliConfig:   func(c *CLIConfig) string { return c.BuildkitTimeout },
+	})
+	// EnvBuildkitProgress is the output format of the builds
+	EnvBuildkitProgress = newEnvVar(&EnvVar{
+		Name:        "BUILDKIT_PROGRESS",
+		Type:        StringEnvVar,
+		Default:     "tty",
+		Description: "output format of the builds (tty, plain)",
+	})
+	// EnvOktetoCompressBuildContext uploads the build context to the okteto builder as a compressed tarball
+	EnvOktetoCompressBuildContext = newEnvVar(&EnvVar{
+		Name:        "OKTETO_COMPRESS_BUILD_CONTEXT",
+		Type:        BoolEnvVar,
+		Default:     "true",
+		Description: "upload the build context to the builder as a compressed tarball without the files excluded by .dockerignore and .oktetoignore, false synchronizes it file by file",
+	})
+	// EnvOktetoSmartBuildsEnabled enables reusing the images of previous builds
+	EnvOktetoSmartBuildsEnabled = newEnvVar(&EnvVar{
+		Name:        "OKTETO_SMART_BUILDS_ENABLED",
+		Type:        BoolEnvVar,
+		Default:     "true",
+		Description: "reuse the images of previous builds when the build context didn't change",
+	})
+	// EnvOktetoSmartBuildsUsingBuildContext computes the smart builds hash from the build context
+	EnvOktetoSmartBuildsUsingBuildContext = newEnvVar(&EnvVar{
+		Name:        "OKTETO_SMART_BUILDS_USING_BUILD_CONTEXT",
+		Type:        BoolEnvVar,
+		Default:     "false",
+		Description: "reuse the images of previous builds based on the content of the build context instead of the commit of the repository",
+	})
+	// EnvOktetoRegistryURL is the url of the okteto registry
+	EnvOktetoRegistryURL = newEnvVar(&EnvVar{
+		Name:        "OKTETO_REGISTRY_URL",
+		Type:        StringEnvVar,
+		Description: "url of the okteto registry, set by okteto for the commands of 'okteto deploy'",
+	})
+	// EnvBuildkitHost is the url of the okteto builder
+	EnvBuildkitHost = newEnvVar(&EnvVar{
+		Name:        "BUILDKIT_HOST",
+		Type:        StringEnvVar,
+		Description: "url of the okteto builder, set by okteto for the commands of 'okteto deploy'",
+	})
+	// EnvOktetoRescanInterval is the interval between full scans of the synchronized folders
+	EnvOktetoRescanInterval = newEnvVar(&EnvVar{
+		Name:        "OKTETO_RESCAN_INTERVAL",
+		Type:        IntEnvVar,
+		Description: "seconds between full scans of the synchronized folders (defaults to 'sync.rescanInterval' of the manifest)",
+	})
+	// EnvOktetoExecuteSSH runs the command of the development container through ssh
+	EnvOktetoExecuteSSH = newEnvVar(&EnvVar{
+		Name:        "OKTETO_EXECUTE_SSH",
+		Type:        BoolEnvVar,
+		Default:     "true",
+		Description: "run the command of the development container through ssh",
+	})
+	// EnvOktetoAutoDeploy deploys the development environment on 'okteto up'
+	EnvOktetoAutoDeploy = newEnvVar(&EnvVar{
+		Name:        "OKTETO_AUTODEPLOY",
+		Type:        StringEnvVar,
+		Description: "deploy the development environment on 'okteto up' if it has any value",
+		Deprecated:  "use 'okteto up --deploy' instead",
+	})
+	// EnvOktetoAutogenerateStignore generates the .stignore files without asking
+	EnvOktetoAutogenerateStignore = newEnvVar(&EnvVar{
+		Name:        "OKTETO_AUTOGENERATE_STIGNORE",
+		Type:        BoolEnvVar,
+		Default:     "false",
+		Description: "generate the missing .stignore files on 'okteto up' without asking",
+	})
+	// EnvOktetoUpDaemon is set when 'okteto up' runs in the background
+	EnvOktetoUpDaemon = newEnvVar(&EnvVar{
+		Name:        "OKTETO_UP_DAEMON",
+		Type:        BoolEnvVar,
+		Default:     "false",
+		Description: "set by okteto when 'okteto up' runs in the background",
+	})
+	// EnvOktetoSyncthingVersion is the minimum version of syncthing
+	EnvOktetoSyncthingVersion = newEnvVar(&EnvVar{
+		Name:        "OKTETO_SYNCTHING_VERSION",
+		Type:        StringEnvVar,
+		Description: "minimum version of the syncthing binary installed by okteto",
+	})
+	// EnvOktetoSyncthingMirror is the base url to download the syncthing releases from
+	EnvOktetoSyncthingMirror = newEnvVar(&EnvVar{
+		Name:        "OKTETO_SYNCTHING_MIRROR",
+		Type:        StringEnvVar,
+		Default:     "https://github.com/syncthing/syncthing/releases/download",
+		Description: "base url of the mirror to download the syncthing releases from",
+	})
+	// EnvOktetoSyncthingSigningKey is the public key that must sign the checksums of the syncthing releases
+	EnvOktetoSyncthingSigningKey = newEnvVar(&EnvVar{
+		Name:        "OKTETO_SYNCTHING_SIGNING_KEY",
+		Type:        StringEnvVar,
+		Description: "path to an armored OpenPGP public key that must sign the checksums of the syncthing releases",
+	})
+	// EnvOktetoSkipCleanup keeps the local synchronization folder on 'okteto down -v'
+	EnvOktetoSkipCleanup = newEnvVar(&EnvVar{
+		Name:        "OKTETO_SKIP_CLEANUP",
+		Type:        StringEnvVar,
+		Description: "keep the local synchronization folder when running 'okteto down -v' if it has any value",
+	})
+	// EnvOktetoDisableManifestOverride ignores the okteto.override.yml files
+	EnvOktetoDisableManifestOverride = newEnvVar(&EnvVar{
+		Name:        constants.OktetoDisableManifestOverrideEnvVar,
+		Type:        BoolEnvVar,
+		Default:     "false",
+		Description: "ignore the okteto.override.yml files",
+	})
+	// EnvOktetoComposeUpdateStrategy is the update strategy of the services of a compose file
+	EnvOktetoComposeUpdateStrategy = newEnvVar(&EnvVar{
+		Name:        "OKTETO_COMPOSE_UPDATE_STRATEGY",
+		Type:        StringEnvVar,
+		Description: "update strategy of the services of a compose file (rolling, recreate, on-delete)",
+	})
+	// EnvOktetoComposeVolumeAffinityEnabled schedules the services of a compose file sharing a volume in the same node
+	EnvOktetoComposeVolumeAffinityEnabled = newEnvVar(&EnvVar{
+		Name:        "OKTETO_COMPOSE_VOLUME_AFFINITY_ENABLED",
+		Type:        BoolEnvVar,
+		Default:     "true",
+		Description: "schedule the services of a compose file that share a volume in the same node",
+	})
+	// EnvComposeFile are the paths of the compose files
+	EnvComposeFile = newEnvVar(&EnvVar{
+		Name:        "COMPOSE_FILE",
+		Type:        StringEnvVar,
+		Description: "paths of the compose files used when the manifest is not found",
+	})
+	// EnvComposeProfiles are the profiles of the compose services that are deployed
+	EnvComposeProfiles = newEnvVar(&EnvVar{
+		Name:        "COMPOSE_PROFILES",
+		Type:        StringEnvVar,
+		Description: "comma separated profiles of the compose services that are deployed",
+	})
+	// EnvOktetoSkipConfigCredentialsUpdate doesn't write the okteto credentials into the kubeconfig
+	EnvOktetoSkipConfigCredentialsUpdate = newEnvVar(&EnvVar{
+		Name:        "OKTETO_SKIP_CONFIG_CREDENTIALS_UPDATE",
+		Type:        BoolEnvVar,
+		Default:     "false",
+		Description: "don't write the okteto credentials into the kubeconfig",
+	})
+	// EnvOktetoUseStaticKubetoken writes a static token into the kubeconfig instead of the okteto credential helper
+	EnvOktetoUseStaticKubetoken = newEnvVar(&EnvVar{
+		Name:        "OKTETO_USE_STATIC_KUBETOKEN",
+		Type:        BoolEnvVar,
+		Default:     "false",
+		Description: "write a static token into the kubeconfig instead of using the okteto credential helper",
+	})
+	// EnvOktetoVCSToken is the token used to access the git repositories
+	EnvOktetoVCSToken = newEnvVar(&EnvVar{
+		Name:        "OKTETO_VCS_TOKEN",
+		Type:        StringEnvVar,
+		Description: "token used to access the private git repositories of any provider",
+		Sensitive:   true,
+	})
+	// EnvGithubToken is the token used to access the GitHub repositories
+	EnvGithubToken = newEnvVar(&EnvVar{
+		Name:        "GITHUB_TOKEN",
+		Type:        StringEnvVar,
+		Description: "token used to access the private GitHub repositories when OKTETO_VCS_TOKEN is not defined",
+		Sensitive:   true,
+	})
+	// EnvGitlabToken is the token used to access the GitLab repositories
+	EnvGitlabToken = newEnvVar(&EnvVar{
+		Name:        "GITLAB_TOKEN",
+		Type:        StringEnvVar,
+		Description: "token used to access the private GitLab repositories when OKTETO_VCS_TOKEN is not defined",
+		Sensitive:   true,
+	})
+	// EnvBitbucketToken is the token used to access the Bitbucket repositories
+	EnvBitbucketToken = newEnvVar(&EnvVar{
+		Name:        "BITBUCKET_TOKEN",
+		Type:        StringEnvVar,
+		Description: "token used to access the private Bitbucket repositories when OKTETO_VCS_TOKEN is not defined",
+		Sensitive:   true,
+	})
+	// EnvOktetoDeployRemote is set when the command runs in the okteto remote deployer
+	EnvOktetoDeployRemote = newEnvVar(&EnvVar{
+		Name:        constants.OktetoDeployRemote,
+		Type:        BoolEnvVar,
+		Default:     "false",
+		Description: "set by okteto when the command runs in the remote deployer",
+	})
+	// EnvOktetoForceRemote runs the deploy and destroy commands in the remote deployer
+	EnvOktetoForceRemote = newEnvVar(&EnvVar{
+		Name:        constants.OktetoForceRemote,
+		Type:        BoolEnvVar,
+		Default:     "false",
+		Description: "run 'okteto deploy' and 'okteto destroy' in the remote deployer",
+	})
+	// EnvOktetoRemoteCLIImage is the image of the okteto cli used by the remote deployer
+	EnvOktetoRemoteCLIImage = newEnvVar(&EnvVar{
+		Name:        constants.OktetoDeployRemoteImage,
+		Type:        StringEnvVar,
+		Description: "image of the okteto cli used by the remote deployer (defaults to the image of the current version)",
+	})
+	// EnvOktetoWithinDeployCommandContext is set for the commands run by 'okteto deploy'
+	EnvOktetoWithinDeployCommandContext = newEnvVar(&EnvVar{
+		Name:        constants.OktetoWithinDeployCommandContextEnvVar,
+		Type:        BoolEnvVar,
+		Default:     "false",
+		Description: "set by okteto for the commands run by 'okteto deploy'",
+	})
+	// EnvOktetoCurrentDeployBelongsToPreview is set for the commands run by the deploy of a preview environment
+	EnvOktetoCurrentDeployBelongsToPreview = newEnvVar(&EnvVar{
+		Name:        "OKTETO_CURRENT_DEPLOY_BELONGS_TO_PREVIEW",
+		Type:        BoolEnvVar,
+		Default:     "false",
+		Description: "set by okteto for the commands run by the deploy of a preview environment",
+	})
+	// EnvOktetoOrigin is the initiator of the deploy
+	EnvOktetoOrigin = newEnvVar(&EnvVar{
+		Name:        "OKTETO_ORIGIN",
+		Type:        StringEnvVar,
+		Default:     "cli",
+		Description: "initiator of the deploy: web, cli, github-action...",
+	})
+	// EnvOktetoInInstaller is set when the command runs in the okteto installer
+	EnvOktetoInInstaller = newEnvVar(&EnvVar{
+		Name:        "OKTETO_IN_INSTALLER",
+		Type:        BoolEnvVar,
+		Default:     "false",
+		Description: "set by okteto when the command runs in the installer of a pipeline",
+	})
+	// EnvOktetoActionName is the name of the okteto action running the command
+	EnvOktetoActionName = newEnvVar(&EnvVar{
+		Name:        "OKTETO_ACTION_NAME",
+		Type:        StringEnvVar,
+		Description: "name of the okteto action running the command, set by okteto pipelines",
+	})
+	// EnvOktetoGitCommit is the commit of the repository deployed by a pipeline
+	EnvOktetoGitCommit = newEnvVar(&EnvVar{
+		Name:        constants.OktetoGitCommitEnvVar,
+		Type:        StringEnvVar,
+		Description: "commit of the repository deployed, set by okteto pipelines",
+	})
+	// EnvOktetoGitBranch is the branch of the repository deployed by a pipeline
+	EnvOktetoGitBranch = newEnvVar(&EnvVar{
+		Name:        constants.OktetoGitBranchEnvVar,
+		Type:        StringEnvVar,
+		Description: "branch of the repository deployed, set by okteto pipelines",
+	})
+	// EnvGithubRepository is the url of the repository deployed by a pipeline
+	EnvGithubRepository = newEnvVar(&EnvVar{
+		Name:        "GITHUB_REPOSITORY",
+		Type:        StringEnvVar,
+		Description: "url of the repository deployed, set by okteto pipelines",
+	})
+	// EnvOktetoUpdateChannel is the release channel used to update the cli
+	EnvOktetoUpdateChannel = newEnvVar(&EnvVar{
+		Name:        constants.OktetoUpdateChannelEnvVar,
+		Type:        StringEnvVar,
+		Default:     "stable",
+		Description: "release channel used to update the cli (stable, beta)",
+	})
+	// EnvOktetoUpdateCheckInterval is how often the cli checks for new versions
+	EnvOktetoUpdateCheckInterval = newEnvVar(&EnvVar{
+		Name:        constants.OktetoUpdateCheckIntervalEnvVar,
+		Type:        DurationEnvVar,
+		Default:     "24h",
+		Description: "how often the cli checks for new versions, zero disables the check",
+	})
+	// EnvOktetoDisableSpinner disables the spinner of the tty output
+	EnvOktetoDisableSpinner = newEnvVar(&EnvVar{
+		Name:        "OKTETO_DISABLE_SPINNER",
+		Type:        BoolEnvVar,
+		Default:     "false",
+		Description: "disable the spinner of the tty output",
+	})
+	// EnvOktetoNormalizeLineEndings normalizes the line endings of the scripts of the manifest
+	EnvOktetoNormalizeLineEndings = newEnvVar(&EnvVar{
+		Name:        filesystem.NormalizeLineEndingsEnvVar,
+		Type:        BoolEnvVar,
+		Description: "convert the line endings of the files generated by okteto to LF (defaults to true on windows)",
+	})
+)
+
+// Lookup returns the value of the environment variable and if it is defined
+func (e *EnvVar) Lookup() (string, bool) {
+	return os.LookupEnv(e.Name)
+}
+
+// Value returns the value of the environment variable. If it is empty, it returns the value of the cli config or the default value
+func (e *EnvVar) Value() string {
+	value, _ := e.getValue()
+	return value
+}
+
+// Bool returns the value of a boolean environment variable. It returns false if the value is empty
+func (e *EnvVar) Bool() (bool, error) {
+	value := e.Value()
+	if value == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s is not a valid boolean: '%s'", e.Name, value)
+	}
+	return b, nil
+}
+
+// IsTrue returns the value of a boolean environment variable, or false if its value is not a valid boolean.
+// Invalid values are reported by ValidateEnvVars
+func (e *EnvVar) IsTrue() bool {
+	b, err := e.Bool()
+	if err != nil {
+		oktetoLog.Infof("%s", err)
+		return false
+	}
+	return b
+}
+
+// Int returns the value of an integer environment variable. It returns zero if the value is empty
+func (e *EnvVar) Int() (int, error) {
+	value := e.Value()
+	if value == "" {
+		return 0, nil
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s is not a valid integer: '%s'", e.Name, value)
+	}
+	return i, nil
+}
+
+// Duration returns the value of a duration environment variable. It returns zero if the value is empty
+func (e *EnvVar) Duration() (time.Duration, error) {
+	value := e.Value()
+	if value == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s is not a valid duration: '%s'", e.Name, value)
+	}
+	return d, nil
+}
+
+// validate checks that the value of the environment variable is valid for its type
+func (e *EnvVar) validate() error {
+	var err error
+	switch e.Type {
+	case BoolEnvVar:
+		_, err = e.Bool()
+	case IntEnvVar:
+		_, err = e.Int()
+	case DurationEnvVar:
+		_, err = e.Duration()
+	}
+	return err
+}
+
+func (e *EnvVar) getValue() (string, string) {
+	if value, ok := e.Lookup(); ok && value != "" {
+		return value, EnvSourceEnvironment
+	}
+	if e.cliConfig != nil {
+		if value := e.cliConfig(GetCLIConfig()); value != "" {
+			return value, EnvSourceCLIConfig
+		}
+	}
+	if e.Default != "" {
+		return e.Default, EnvSourceDefault
+	}
+	return "", EnvSourceUnset
+}
+
+// ValidateEnvVars returns a warning for every environment variable with an invalid value or that is deprecated
+func ValidateEnvVars() []string {
+	return validateEnvVars(envVars)
+}
+
+func validateEnvVars(vars []*EnvVar) []string {
+	warnings := []string{}
+	for _, e := range vars {
+		if _, ok := e.Lookup(); !ok {
+			continue
+		}
+		if e.Deprecated != "" {
+			warnings = append(warnings, fmt.Sprintf("%s is deprecated: %s", e.Name, e.Deprecated))
+		}
+		if err := e.validate(); err != nil {
+			warnings = append(warnings, err.Error())
+		}
+	}
+	return warnings
+}
+
+// ListEnvVars returns all the environment variables honored by the okteto cli sorted by name, with their current values
+func ListEnvVars() []EnvVarValue {
+	return listEnvVars(envVars)
+}
+
+func listEnvVars(vars []*EnvVar) []EnvVarValue {
+	result := make([]EnvVarValue, 0, len(vars))
+	for _, e := range vars {
+		value, source := e.getValue()
+		if e.Sensitive && value != "" {
+			value = "********"
+		}
+		result = append(result, EnvVarValue{
+			Name:        e.Name,
+			Type:        string(e.Type),
+			Value:       value,
+			Source:      source,
+			Description: e.Description,
+			Deprecated:  e.Deprecated,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
@@ -0,0 +1,118 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/log/io"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvVarAccessors(t *testing.T) {
+	currentCLIConfig = &CLIConfig{BuildkitTimeout: "10m"}
+	t.Cleanup(func() {
+		currentCLIConfig = nil
+	})
+
+	flag := &EnvVar{Name: "OKTETO_TEST_FLAG", Type: BoolEnvVar, Default: "true"}
+	b, err := flag.Bool()
+	require.NoError(t, err)
+	assert.True(t, b)
+	assert.True(t, flag.IsTrue())
+	t.Setenv(flag.Name, "false")
+	b, err = flag.Bool()
+	require.NoError(t, err)
+	assert.False(t, b)
+	assert.False(t, flag.IsTrue())
+	t.Setenv(flag.Name, "nope")
+	_, err = flag.Bool()
+	assert.EqualError(t, err, "OKTETO_TEST_FLAG is not a valid boolean: 'nope'")
+	assert.False(t, flag.IsTrue())
+
+	number := &EnvVar{Name: "OKTETO_TEST_NUMBER", Type: IntEnvVar}
+	i, err := number.Int()
+	require.NoError(t, err)
+	assert.Equal(t, 0, i)
+	t.Setenv(number.Name, "3")
+	i, err = number.Int()
+	require.NoError(t, err)
+	assert.Equal(t, 3, i)
+
+	timeout := &EnvVar{Name: "OKTETO_TEST_TIMEOUT", Type: DurationEnvVar, cliConfig: func(c *CLIConfig) string { return c.BuildkitTimeout }}
+	d, err := timeout.Duration()
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, d)
+	t.Setenv(timeout.Name, "1h")
+	d, err = timeout.Duration()
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, d)
+}
+
+func TestValidateEnvVars(t *testing.T) {
+	vars := []*EnvVar{
+		{Name: "OKTETO_TEST_OLD", Type: StringEnvVar, Deprecated: "use OKTETO_TEST_NEW instead"},
+		{Name: "OKTETO_TEST_NEW", Type: StringEnvVar},
+		{Name: "OKTETO_TEST_TIMEOUT", Type: DurationEnvVar, Default: "invalid"},
+		{Name: "OKTETO_TEST_RETRIES", Type: IntEnvVar},
+	}
+	assert.Empty(t, validateEnvVars(vars))
+
+	t.Setenv("OKTETO_TEST_OLD", "value")
+	t.Setenv("OKTETO_TEST_RETRIES", "many")
+	assert.Equal(t, []string{
+		"OKTETO_TEST_OLD is deprecated: use OKTETO_TEST_NEW instead",
+		"OKTETO_TEST_RETRIES is not a valid integer: 'many'",
+	}, validateEnvVars(vars))
+}
+
+func TestListEnvVars(t *testing.T) {
+	currentCLIConfig = &CLIConfig{BuildkitTimeout: "10m"}
+	t.Cleanup(func() {
+		currentCLIConfig = nil
+	})
+	t.Setenv("OKTETO_TEST_TOKEN", "secret")
+	t.Setenv("OKTETO_TEST_URL", "https://okteto.example.com")
+
+	vars := []*EnvVar{
+		{Name: "OKTETO_TEST_URL", Type: StringEnvVar},
+		{Name: "OKTETO_TEST_TOKEN", Type: StringEnvVar, Sensitive: true},
+		{Name: "OKTETO_TEST_TIMEOUT", Type: DurationEnvVar, cliConfig: func(c *CLIConfig) string { return c.BuildkitTimeout }},
+		{Name: "OKTETO_TEST_CHANNEL", Type: StringEnvVar, Default: "stable"},
+		{Name: "OKTETO_TEST_EMPTY", Type: StringEnvVar},
+		{Name: "OKTETO_TEST_SECRET", Type: StringEnvVar, Sensitive: true},
+	}
+	assert.Equal(t, []EnvVarValue{
+		{Name: "OKTETO_TEST_CHANNEL", Type: "string", Value: "stable", Source: EnvSourceDefault},
+		{Name: "OKTETO_TEST_EMPTY", Type: "string", Source: EnvSourceUnset},
+		{Name: "OKTETO_TEST_SECRET", Type: "string", Source: EnvSourceUnset},
+		{Name: "OKTETO_TEST_TIMEOUT", Type: "duration", Value: "10m", Source: EnvSourceCLIConfig},
+		{Name: "OKTETO_TEST_TOKEN", Type: "string", Value: "********", Source: EnvSourceEnvironment},
+		{Name: "OKTETO_TEST_URL", Type: "string", Value: "https://okteto.example.com", Source: EnvSourceEnvironment},
+	}, listEnvVars(vars))
+}
+
+func TestEnvVarsAreUnique(t *testing.T) {
+	names := map[string]bool{}
+	for _, e := range envVars {
+		assert.False(t, names[e.Name], "%s is registered twice", e.Name)
+		names[e.Name] = true
+	}
+}
+
+func TestDeprecatedEnvVarNames(t *testing.T) {
+	assert.Equal(t, io.OktetoDisableSpinnerEnvVar, EnvOktetoDisableSpinner.Name)
+}
@@ -0,0 +1,218 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// UpState represents the state of the up command
+type UpState string
+
+const (
+	// Activating up started
+	Activating UpState = "activating"
+	// Starting up started the dev pod creation
+	Starting UpState = "starting"
+	// Attaching up attaching volume
+	Attaching UpState = "attaching"
+	// Pulling  up pulling images
+	Pulling UpState = "pulling"
+	// StartingSync up preparing syncthing
+	StartingSync UpState = "startingSync"
+	// Synchronizing up is syncthing
+	Synchronizing UpState = "synchronizing"
+	// Ready up finished
+	Ready UpState = "ready"
+	// Failed up failed
+	Failed UpState = "failed"
+
+	stateFile string = "okteto.state"
+)
+
+// upStateOrder is the order of the states during the activation of a development container
+var upStateOrder = map[UpState]int{
+	Activating:    0,
+	Starting:      1,
+	Attaching:     2,
+	Pulling:       3,
+	StartingSync:  4,
+	Synchronizing: 5,
+	Ready:         6,
+}
+
+// StateInfo is the state of the up command persisted in the state file of a dev environment
+type StateInfo struct {
+	Timestamp time.Time `json:"timestamp"`
+	State     UpState   `json:"state"`
+	LastError string    `json:"lastError,omitempty"`
+	Retries   int       `json:"retries"`
+}
+
+// CanTransition returns if the up command can move from a state to another one.
+// The activation only moves forward, but it can be restarted or fail from any state
+func CanTransition(from, to UpState) bool {
+	if from == "" || to == Activating || to == Failed {
+		return true
+	}
+	fromOrder, ok := upStateOrder[from]
+	if !ok {
+		return false
+	}
+	toOrder, ok := upStateOrder[to]
+	if !ok {
+		return false
+	}
+	return toOrder >= fromOrder
+}
+
+func getStatePath(devName, devNamespace string) (string, error) {
+	if devNamespace == "" {
+		return "", fmt.Errorf("namespace is empty")
+	}
+
+	if devName == "" {
+		return "", fmt.Errorf("name is empty")
+	}
+
+	return filepath.Join(GetAppHome(devNamespace, devName), stateFile), nil
+}
+
+// UpdateStateFile updates the state file of a given dev environment
+func UpdateStateFile(devName, devNamespace string, state UpState) error {
+	s, err := getStatePath(devName, devNamespace)
+	if err != nil {
+		return fmt.Errorf("can't update state file, %w", err)
+	}
+
+	info, err := readStateInfo(s)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			oktetoLog.Infof("error reading state file: %s", err.Error())
+		}
+		info = &StateInfo{}
+	}
+	if !CanTransition(info.State, state) {
+		return fmt.Errorf("invalid state transition from '%s' to '%s'", info.State, state)
+	}
+	info.State = state
+
+	oktetoLog.Infof("updating file '%s'", s)
+	if err := writeStateInfo(s, info); err != nil {
+		return err
+	}
+	oktetoLog.Infof("file '%s' updated successfully", s)
+
+	return nil
+}
+
+// RecordStateError stores the error that made the up command retry the activation of a given dev environment
+func RecordStateError(devName, devNamespace string, stateErr error) error {
+	s, err := getStatePath(devName, devNamespace)
+	if err != nil {
+		return fmt.Errorf("can't update state file, %w", err)
+	}
+
+	info, err := readStateInfo(s)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			oktetoLog.Infof("error reading state file: %s", err.Error())
+		}
+		info = &StateInfo{}
+	}
+	info.Retries++
+	if stateErr != nil {
+		info.LastError = stateErr.Error()
+	}
+	return writeStateInfo(s, info)
+}
+
+func writeStateInfo(path string, info *StateInfo) error {
+	info.Timestamp = time.Now().UTC()
+	bytes, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, bytes, 0600); err != nil {
+		return fmt.Errorf("failed to update state file: %w", err)
+	}
+	return nil
+}
+
+func readStateInfo(path string) (*StateInfo, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseStateInfo(bytes)
+}
+
+// parseStateInfo parses the content of a state file. Previous versions of the CLI only stored the state name
+func parseStateInfo(bytes []byte) (*StateInfo, error) {
+	info := &StateInfo{}
+	if err := json.Unmarshal(bytes, info); err == nil {
+		return info, nil
+	}
+
+	state := UpState(strings.TrimSpace(string(bytes)))
+	if _, ok := upStateOrder[state]; !ok && state != Failed {
+		return nil, fmt.Errorf("unknown state '%s'", state)
+	}
+	info.State = state
+	return info, nil
+}
+
+// DeleteStateFile deletes the state file of a given dev environment
+func DeleteStateFile(devName, devNamespace string) error {
+	s, err := getStatePath(devName, devNamespace)
+	if err != nil {
+		return fmt.Errorf("can't delete state file, %w", err)
+	}
+	return os.Remove(s)
+}
+
+// GetStateInfo returns the state information of a given dev environment
+func GetStateInfo(devName, devNamespace string) (*StateInfo, error) {
+	s, err := getStatePath(devName, devNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("can't get state file, %w", err)
+	}
+
+	info, err := readStateInfo(s)
+	if err != nil {
+		oktetoLog.Infof("error reading state file: %s", err.Error())
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("development mode isn't enabled on your deployment"),
+			Hint: "Run 'okteto up' to enable it and try again",
+		}
+	}
+	return info, nil
+}
+
+// GetState returns the state of a given dev environment
+func GetState(devName, devNamespace string) (UpState, error) {
+	info, err := GetStateInfo(devName, devNamespace)
+	if err != nil {
+		return Failed, err
+	}
+	return info.State, nil
+}
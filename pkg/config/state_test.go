@@ -0,0 +1,97 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from     UpState
+		to       UpState
+		expected bool
+	}{
+		{from: "", to: Synchronizing, expected: true},
+		{from: Activating, to: Starting, expected: true},
+		{from: Starting, to: StartingSync, expected: true},
+		{from: Pulling, to: Pulling, expected: true},
+		{from: Ready, to: Activating, expected: true},
+		{from: Synchronizing, to: Failed, expected: true},
+		{from: Failed, to: Activating, expected: true},
+		{from: Ready, to: Synchronizing, expected: false},
+		{from: Failed, to: Ready, expected: false},
+		{from: Activating, to: UpState("unknown"), expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			assert.Equal(t, tt.expected, CanTransition(tt.from, tt.to))
+		})
+	}
+}
+
+func TestUpdateStateFile(t *testing.T) {
+	t.Setenv(constants.OktetoFolderEnvVar, t.TempDir())
+
+	_, err := GetState("api", "ns")
+	require.Error(t, err)
+
+	require.NoError(t, UpdateStateFile("api", "ns", Activating))
+	require.NoError(t, UpdateStateFile("api", "ns", Synchronizing))
+	require.NoError(t, RecordStateError("api", "ns", errors.New("lost connection")))
+	require.Error(t, UpdateStateFile("api", "ns", Starting))
+
+	info, err := GetStateInfo("api", "ns")
+	require.NoError(t, err)
+	assert.Equal(t, Synchronizing, info.State)
+	assert.Equal(t, "lost connection", info.LastError)
+	assert.Equal(t, 1, info.Retries)
+	assert.False(t, info.Timestamp.IsZero())
+
+	require.NoError(t, UpdateStateFile("api", "ns", Activating))
+	state, err := GetState("api", "ns")
+	require.NoError(t, err)
+	assert.Equal(t, Activating, state)
+
+	require.NoError(t, DeleteStateFile("api", "ns"))
+	_, err = GetState("api", "ns")
+	require.Error(t, err)
+}
+
+func TestUpdateStateFileWithoutName(t *testing.T) {
+	require.Error(t, UpdateStateFile("", "ns", Activating))
+	require.Error(t, UpdateStateFile("api", "", Activating))
+}
+
+func TestGetStateLegacyFormat(t *testing.T) {
+	t.Setenv(constants.OktetoFolderEnvVar, t.TempDir())
+
+	path := filepath.Join(GetAppHome("ns", "api"), stateFile)
+	require.NoError(t, os.WriteFile(path, []byte("ready"), 0600))
+
+	state, err := GetState("api", "ns")
+	require.NoError(t, err)
+	assert.Equal(t, Ready, state)
+
+	require.NoError(t, os.WriteFile(path, []byte("unknown"), 0600))
+	_, err = GetState("api", "ns")
+	require.Error(t, err)
+}
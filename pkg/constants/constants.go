@@ -137,4 +137,16 @@ const (
 
 	// EnvironmentLabelKeyPrefix represents the prefix for the preview and pipeline labels
 	EnvironmentLabelKeyPrefix = "label.okteto.com"
+
+	// OktetoUpdateChannelEnvVar defines the release channel used to update the cli: stable or beta
+	OktetoUpdateChannelEnvVar = "OKTETO_UPDATE_CHANNEL"
+
+	// OktetoUpdateCheckIntervalEnvVar defines how often the cli checks for new versions. Set it to 0 to disable the check
+	OktetoUpdateCheckIntervalEnvVar = "OKTETO_UPDATE_CHECK_INTERVAL"
+
+	// OktetoBuildkitTimeoutEnvVar defines the maximum duration of a build in the okteto builder
+	OktetoBuildkitTimeoutEnvVar = "OKTETO_BUILDKIT_TIMEOUT"
+
+	// OktetoDisableManifestOverrideEnvVar disables merging the okteto.override.yml file over the okteto manifest
+	OktetoDisableManifestOverrideEnvVar = "OKTETO_DISABLE_MANIFEST_OVERRIDE"
 )
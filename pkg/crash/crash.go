@@ -0,0 +1,182 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package crash generates the crash reports of the cli
+package crash
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/okteto/okteto/pkg/analytics"
+	"github.com/okteto/okteto/pkg/config"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	// maxLogSize is the maximum number of bytes of the log buffer included in the report
+	maxLogSize = 16 * 1024
+
+	issuesURL = "https://github.com/okteto/okteto/issues/new"
+)
+
+// sensitiveFlags are the flags whose value is never included in the report
+var sensitiveFlags = map[string]bool{
+	"--token": true,
+	"-t":      true,
+	"--var":   true,
+	"-v":      true,
+}
+
+// Report represents the information collected when the cli panics
+type Report struct {
+	Time        time.Time   `json:"time"`
+	Environment Environment `json:"environment"`
+	Panic       string      `json:"panic"`
+	Stack       string      `json:"stack"`
+	Logs        string      `json:"logs,omitempty"`
+	Args        []string    `json:"args"`
+}
+
+// Environment summarizes the environment where the cli was running
+type Environment struct {
+	Version      string   `json:"version"`
+	OS           string   `json:"os"`
+	Arch         string   `json:"arch"`
+	GoVersion    string   `json:"goVersion"`
+	OutputFormat string   `json:"outputFormat,omitempty"`
+	OktetoEnv    []string `json:"oktetoEnv,omitempty"`
+}
+
+// Recover handles a panic of the cli: it saves a crash report and exits. It must be deferred by the main function
+func Recover() {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	report := newReport(r, debug.Stack(), os.Args[1:])
+	path, err := report.save(config.GetCrashReportsDir())
+	if err != nil {
+		oktetoLog.Fail("okteto crashed unexpectedly: %s", report.Panic)
+		oktetoLog.Infof("failed to save crash report: %s", err)
+		os.Exit(1)
+	}
+	analytics.TrackCrash(getPanicFrame(report.Stack))
+
+	oktetoLog.Fail("okteto crashed unexpectedly: %s", report.Panic)
+	oktetoLog.Println(fmt.Sprintf("    A crash report has been saved to '%s'", path))
+	oktetoLog.Println(fmt.Sprintf("    Please attach it when opening an issue at %s", issuesURL))
+	os.Exit(1)
+}
+
+func newReport(recovered interface{}, stack []byte, args []string) *Report {
+	logs := oktetoLog.GetOutputBuffer().String()
+	if len(logs) > maxLogSize {
+		logs = logs[len(logs)-maxLogSize:]
+	}
+
+	return &Report{
+		Time: time.Now().UTC(),
+		Environment: Environment{
+			Version:      config.VersionString,
+			OS:           runtime.GOOS,
+			Arch:         runtime.GOARCH,
+			GoVersion:    runtime.Version(),
+			OutputFormat: oktetoLog.GetOutputFormat(),
+			OktetoEnv:    getOktetoEnvNames(os.Environ()),
+		},
+		Panic: redact(fmt.Sprintf("%v", recovered)),
+		Stack: redact(string(stack)),
+		Logs:  redact(logs),
+		Args:  redactArgs(args),
+	}
+}
+
+func (r *Report) save(dir string) (string, error) {
+	bytes, err := json.MarshalIndent(r, "", " ")
+	if err != nil {
+		return "", fmt.Errorf("failed to generate crash report: %w", err)
+	}
+	path := filepath.Join(dir, fmt.Sprintf("crash-%s.json", r.Time.Format("20060102-150405")))
+	if err := os.WriteFile(path, bytes, 0600); err != nil {
+		return "", fmt.Errorf("failed to write crash report: %w", err)
+	}
+	return path, nil
+}
+
+// redact removes the masked words and the home directory of the user
+func redact(s string) string {
+	s = oktetoLog.Redact(s)
+	if home := config.GetUserHomeDir(); home != "" {
+		s = strings.ReplaceAll(s, home, "~")
+	}
+	return s
+}
+
+// redactArgs removes the values of the flags that might contain secrets
+func redactArgs(args []string) []string {
+	result := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		name, _, hasValue := strings.Cut(args[i], "=")
+		if !sensitiveFlags[name] {
+			result = append(result, redact(args[i]))
+			continue
+		}
+		if hasValue {
+			result = append(result, name+"=***")
+			continue
+		}
+		result = append(result, name)
+		if i+1 < len(args) {
+			result = append(result, "***")
+			i++
+		}
+	}
+	return result
+}
+
+// getOktetoEnvNames returns the names of the okteto environment variables. Values are never included
+func getOktetoEnvNames(environ []string) []string {
+	result := []string{}
+	for _, e := range environ {
+		name, _, _ := strings.Cut(e, "=")
+		if strings.HasPrefix(name, "OKTETO_") {
+			result = append(result, name)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// getPanicFrame returns the function that panicked from a stack trace
+func getPanicFrame(stack string) string {
+	lines := strings.Split(stack, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, "panic(") && i+2 < len(lines) {
+			frame := lines[i+2]
+			if idx := strings.LastIndex(frame, "("); idx > 0 {
+				frame = frame[:idx]
+			}
+			return frame
+		}
+	}
+	return ""
+}
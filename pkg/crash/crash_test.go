@@ -0,0 +1,71 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crash
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/constants"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_redactArgs(t *testing.T) {
+	args := []string{"context", "use", "https://okteto.example.com", "--token", "secret", "--var=PASSWORD=secret", "-n", "ns"}
+	expected := []string{"context", "use", "https://okteto.example.com", "--token", "***", "--var=***", "-n", "ns"}
+	assert.Equal(t, expected, redactArgs(args))
+}
+
+func Test_getOktetoEnvNames(t *testing.T) {
+	environ := []string{"OKTETO_TOKEN=secret", "HOME=/home/user", "OKTETO_CONTEXT=https://okteto.example.com"}
+	assert.Equal(t, []string{"OKTETO_CONTEXT", "OKTETO_TOKEN"}, getOktetoEnvNames(environ))
+}
+
+func Test_getPanicFrame(t *testing.T) {
+	stack := `goroutine 1 [running]:
+runtime/debug.Stack()
+	/usr/local/go/src/runtime/debug/stack.go:24 +0x5e
+github.com/okteto/okteto/pkg/crash.Recover()
+	/okteto/pkg/crash/crash.go:70 +0x45
+panic({0x1, 0x2})
+	/usr/local/go/src/runtime/panic.go:884 +0x213
+github.com/okteto/okteto/cmd/up.(*upContext).activate(0xc000)
+	/okteto/cmd/up/activate.go:50 +0x25
+`
+	assert.Equal(t, "github.com/okteto/okteto/cmd/up.(*upContext).activate", getPanicFrame(stack))
+	assert.Equal(t, "", getPanicFrame("goroutine 1 [running]:"))
+}
+
+func TestReport(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv(constants.OktetoHomeEnvVar, home)
+	oktetoLog.AddMaskedWord("my-secret")
+
+	r := newReport(errors.New("failed to read my-secret"), []byte(home+"/project/main.go:10"), []string{"deploy"})
+	assert.Equal(t, "failed to read ***", r.Panic)
+	assert.Equal(t, "~/project/main.go:10", r.Stack)
+
+	path, err := r.save(home)
+	require.NoError(t, err)
+	bytes, err := os.ReadFile(path)
+	require.NoError(t, err)
+	result := &Report{}
+	require.NoError(t, json.Unmarshal(bytes, result))
+	assert.Equal(t, r.Panic, result.Panic)
+	assert.Equal(t, []string{"deploy"}, result.Args)
+}
@@ -51,7 +51,7 @@ func (d *Dependency) GetTimeout(defaultTimeout time.Duration) time.Duration {
 func (d *Dependency) ExpandVars(variables []string) error {
 	parser := parse.New("string", append(os.Environ(), variables...), &parse.Restrictions{})
 
-	expandedBranch, err := parser.Parse(d.Branch)
+	expandedBranch, err := parser.Parse(escapeOutputReferences(d.Branch))
 	if err != nil {
 		return fmt.Errorf("error expanding 'branch': %w", err)
 	}
@@ -59,7 +59,7 @@ func (d *Dependency) ExpandVars(variables []string) error {
 		d.Branch = expandedBranch
 	}
 
-	expandedRepository, err := parser.Parse(d.Repository)
+	expandedRepository, err := parser.Parse(escapeOutputReferences(d.Repository))
 	if err != nil {
 		return fmt.Errorf("error expanding 'repository': %w", err)
 	}
@@ -67,7 +67,7 @@ func (d *Dependency) ExpandVars(variables []string) error {
 		d.Repository = expandedRepository
 	}
 
-	expandedManifestPath, err := parser.Parse(d.ManifestPath)
+	expandedManifestPath, err := parser.Parse(escapeOutputReferences(d.ManifestPath))
 	if err != nil {
 		return fmt.Errorf("error expanding 'manifest': %w", err)
 	}
@@ -75,7 +75,7 @@ func (d *Dependency) ExpandVars(variables []string) error {
 		d.ManifestPath = expandedManifestPath
 	}
 
-	expandedNamespace, err := parser.Parse(d.Namespace)
+	expandedNamespace, err := parser.Parse(escapeOutputReferences(d.Namespace))
 	if err != nil {
 		return fmt.Errorf("error expanding 'namespace': %w", err)
 	}
@@ -93,7 +93,7 @@ func (d *Dependency) ExpandVars(variables []string) error {
 			v.Name = expandedVarName
 		}
 
-		expandedVarValue, err := parser.Parse(v.Value)
+		expandedVarValue, err := parser.Parse(escapeOutputReferences(v.Value))
 		if err != nil {
 			return fmt.Errorf("error expanding variable value: %w", err)
 		}
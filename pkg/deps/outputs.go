@@ -0,0 +1,160 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deps
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// outputReferenceRegex matches the references to the variables exported by a dependency: ${dependencies.<name>.<variable>}
+var outputReferenceRegex = regexp.MustCompile(`\$\{dependencies\.([a-zA-Z0-9_-]+)\.([a-zA-Z0-9_]+)\}`)
+
+// Outputs represents the variables exported by the dependencies through $OKTETO_ENV, indexed by dependency name
+type Outputs map[string]map[string]string
+
+// GetReferencedDependencies returns the names of the dependencies referenced in value
+func GetReferencedDependencies(value string) []string {
+	result := []string{}
+	for _, match := range outputReferenceRegex.FindAllStringSubmatch(value, -1) {
+		result = append(result, match[1])
+	}
+	return result
+}
+
+// Expand replaces the references to the dependency outputs in value.
+// Variable names are matched case insensitively, as $OKTETO_ENV variables are usually uppercase
+func (o Outputs) Expand(value string) (string, error) {
+	var err error
+	result := outputReferenceRegex.ReplaceAllStringFunc(value, func(ref string) string {
+		match := outputReferenceRegex.FindStringSubmatch(ref)
+		v, lookupErr := o.lookup(match[1], match[2])
+		if lookupErr != nil && err == nil {
+			err = lookupErr
+		}
+		return v
+	})
+	if err != nil {
+		return "", err
+	}
+	return result, nil
+}
+
+// escapeOutputReferences escapes the references to the dependency outputs so they are kept when the
+// field is expanded with the environment variables. They are resolved once the dependencies are deployed
+func escapeOutputReferences(value string) string {
+	return outputReferenceRegex.ReplaceAllStringFunc(value, func(ref string) string {
+		return "$" + ref
+	})
+}
+
+func (o Outputs) lookup(dependency, variable string) (string, error) {
+	vars, ok := o[dependency]
+	if !ok {
+		return "", fmt.Errorf("dependency '%s' is not deployed", dependency)
+	}
+	if v, ok := vars[variable]; ok {
+		return v, nil
+	}
+	for name, v := range vars {
+		if strings.EqualFold(name, variable) {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("dependency '%s' doesn't export the variable '%s'", dependency, variable)
+}
+
+// GetReferencedDependencies returns the names of the dependencies referenced in the dependency fields
+func (d *Dependency) GetReferencedDependencies() []string {
+	values := []string{d.Repository, d.ManifestPath, d.Branch, d.Namespace}
+	for _, v := range d.Variables {
+		values = append(values, v.Value)
+	}
+	result := []string{}
+	for _, value := range values {
+		result = append(result, GetReferencedDependencies(value)...)
+	}
+	return result
+}
+
+// ExpandOutputs replaces the references to the outputs of other dependencies in the dependency fields
+func (d *Dependency) ExpandOutputs(outputs Outputs) error {
+	var err error
+	if d.Repository, err = outputs.Expand(d.Repository); err != nil {
+		return fmt.Errorf("error expanding 'repository': %w", err)
+	}
+	if d.ManifestPath, err = outputs.Expand(d.ManifestPath); err != nil {
+		return fmt.Errorf("error expanding 'manifest': %w", err)
+	}
+	if d.Branch, err = outputs.Expand(d.Branch); err != nil {
+		return fmt.Errorf("error expanding 'branch': %w", err)
+	}
+	if d.Namespace, err = outputs.Expand(d.Namespace); err != nil {
+		return fmt.Errorf("error expanding 'namespace': %w", err)
+	}
+	for i := range d.Variables {
+		if d.Variables[i].Value, err = outputs.Expand(d.Variables[i].Value); err != nil {
+			return fmt.Errorf("error expanding variable '%s': %w", d.Variables[i].Name, err)
+		}
+	}
+	return nil
+}
+
+// GetDeployOrder returns the names of the dependencies sorted so each dependency is deployed after the dependencies it references
+func (md ManifestSection) GetDeployOrder() ([]string, error) {
+	names := make([]string, 0, len(md))
+	for name := range md {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := map[string]int{}
+	result := make([]string, 0, len(md))
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("dependencies have a circular reference: %s", strings.Join(append(path, name), " -> "))
+		}
+		state[name] = visiting
+		refs := md[name].GetReferencedDependencies()
+		sort.Strings(refs)
+		for _, ref := range refs {
+			if _, ok := md[ref]; !ok {
+				return fmt.Errorf("dependency '%s' references the undefined dependency '%s'", name, ref)
+			}
+			if err := visit(ref, append(path, name)); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		result = append(result, name)
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
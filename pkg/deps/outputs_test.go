@@ -0,0 +1,169 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deps
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/env"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputsExpand(t *testing.T) {
+	outputs := Outputs{
+		"api": {
+			"ENDPOINT": "https://api.okteto.dev",
+			"token":    "secret",
+		},
+	}
+	tests := []struct {
+		name        string
+		value       string
+		expected    string
+		expectedErr bool
+	}{
+		{
+			name:     "no references",
+			value:    "make deploy",
+			expected: "make deploy",
+		},
+		{
+			name:     "case insensitive variable",
+			value:    "curl ${dependencies.api.endpoint}/healthz",
+			expected: "curl https://api.okteto.dev/healthz",
+		},
+		{
+			name:     "several references",
+			value:    "${dependencies.api.ENDPOINT}?token=${dependencies.api.token}",
+			expected: "https://api.okteto.dev?token=secret",
+		},
+		{
+			name:        "dependency not deployed",
+			value:       "${dependencies.db.host}",
+			expectedErr: true,
+		},
+		{
+			name:        "variable not exported",
+			value:       "${dependencies.api.host}",
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := outputs.Expand(tt.value)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestDependencyExpandOutputs(t *testing.T) {
+	dep := &Dependency{
+		Repository: "https://github.com/okteto/frontend",
+		Branch:     "${dependencies.api.branch}",
+		Variables: env.Environment{
+			{Name: "API_URL", Value: "${dependencies.api.endpoint}/v1"},
+			{Name: "DEBUG", Value: "true"},
+		},
+	}
+	assert.Equal(t, []string{"api", "api"}, dep.GetReferencedDependencies())
+
+	err := dep.ExpandOutputs(Outputs{"api": {"ENDPOINT": "https://api", "BRANCH": "main"}})
+	require.NoError(t, err)
+	assert.Equal(t, &Dependency{
+		Repository: "https://github.com/okteto/frontend",
+		Branch:     "main",
+		Variables: env.Environment{
+			{Name: "API_URL", Value: "https://api/v1"},
+			{Name: "DEBUG", Value: "true"},
+		},
+	}, dep)
+}
+
+func TestGetDeployOrder(t *testing.T) {
+	tests := []struct {
+		dependencies ManifestSection
+		name         string
+		expected     []string
+		expectedErr  bool
+	}{
+		{
+			name: "no references are sorted by name",
+			dependencies: ManifestSection{
+				"b": &Dependency{},
+				"a": &Dependency{},
+			},
+			expected: []string{"a", "b"},
+		},
+		{
+			name: "referenced dependencies go first",
+			dependencies: ManifestSection{
+				"api": &Dependency{
+					Variables: env.Environment{{Name: "DB_HOST", Value: "${dependencies.db.host}"}},
+				},
+				"frontend": &Dependency{
+					Variables: env.Environment{{Name: "API_URL", Value: "${dependencies.api.endpoint}"}},
+				},
+				"db": &Dependency{},
+			},
+			expected: []string{"db", "api", "frontend"},
+		},
+		{
+			name: "undefined dependency",
+			dependencies: ManifestSection{
+				"api": &Dependency{
+					Variables: env.Environment{{Name: "DB_HOST", Value: "${dependencies.db.host}"}},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "circular reference",
+			dependencies: ManifestSection{
+				"a": &Dependency{Branch: "${dependencies.b.branch}"},
+				"b": &Dependency{Branch: "${dependencies.a.branch}"},
+			},
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.dependencies.GetDeployOrder()
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestExpandVarsKeepsDependencyReferences(t *testing.T) {
+	t.Setenv("BRANCH", "main")
+	dep := &Dependency{
+		Branch: "${BRANCH}",
+		Variables: env.Environment{
+			{Name: "API_URL", Value: "${dependencies.api.endpoint}"},
+		},
+	}
+	require.NoError(t, dep.ExpandVars(nil))
+	assert.Equal(t, "main", dep.Branch)
+	assert.Equal(t, "${dependencies.api.endpoint}", dep.Variables[0].Value)
+}
@@ -0,0 +1,167 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package discovery
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/dockerignore"
+	"github.com/moby/patternmatcher"
+	"github.com/okteto/okteto/pkg/filesystem"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/spf13/afero"
+)
+
+const (
+	// oktetoIgnoreFile lists the paths excluded when looking for okteto manifests, with the .dockerignore syntax
+	oktetoIgnoreFile = ".oktetoignore"
+
+	// maxManifestSearchDepth is the number of levels below the working directory where okteto manifests are searched
+	maxManifestSearchDepth = 3
+)
+
+// skippedManifestSearchDirs are the folders never inspected when looking for okteto manifests
+var skippedManifestSearchDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+}
+
+type manifestCandidate struct {
+	path       string
+	distance   int
+	descendant bool
+}
+
+// FindOktetoManifests returns the okteto manifests found around the working directory, closest first.
+// It looks in the working directory, its parents up to the root of the git repository and its subdirectories,
+// skipping hidden folders and the paths listed in the .oktetoignore file at the root of the repository
+func FindOktetoManifests(cwd string, fs afero.Fs) []string {
+	root := getRepositoryRoot(cwd, fs)
+	ignore := getOktetoIgnore(root, fs)
+
+	var candidates []manifestCandidate
+	dir := cwd
+	for distance := 0; ; distance++ {
+		if manifestPath, err := GetOktetoManifestPathWithFilesystem(dir, fs); err == nil {
+			candidates = append(candidates, manifestCandidate{path: manifestPath, distance: distance})
+		}
+		if dir == root {
+			break
+		}
+		dir = filepath.Dir(dir)
+	}
+
+	err := afero.Walk(fs, cwd, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			oktetoLog.Debugf("skipping '%s' looking for okteto manifests: %s", path, err)
+			return nil
+		}
+		if !info.IsDir() || path == cwd {
+			return nil
+		}
+		rel, err := filepath.Rel(cwd, path)
+		if err != nil {
+			return filepath.SkipDir
+		}
+		depth := len(strings.Split(rel, string(filepath.Separator)))
+		if depth > maxManifestSearchDepth || strings.HasPrefix(info.Name(), ".") || skippedManifestSearchDirs[info.Name()] {
+			return filepath.SkipDir
+		}
+		if isIgnored(ignore, root, path) {
+			return filepath.SkipDir
+		}
+		if manifestPath, err := GetOktetoManifestPathWithFilesystem(path, fs); err == nil {
+			candidates = append(candidates, manifestCandidate{path: manifestPath, distance: depth, descendant: true})
+		}
+		return nil
+	})
+	if err != nil {
+		oktetoLog.Infof("error looking for okteto manifests: %s", err)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		if candidates[i].descendant != candidates[j].descendant {
+			return !candidates[i].descendant
+		}
+		return candidates[i].path < candidates[j].path
+	})
+
+	result := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		result = append(result, c.path)
+	}
+	return result
+}
+
+// getRepositoryRoot returns the closest parent of the working directory containing a git repository,
+// or the working directory itself if it isn't inside a git repository
+func getRepositoryRoot(cwd string, fs afero.Fs) string {
+	dir := cwd
+	for {
+		if filesystem.FileExistsWithFilesystem(filepath.Join(dir, ".git"), fs) {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return cwd
+		}
+		dir = parent
+	}
+}
+
+func getOktetoIgnore(root string, fs afero.Fs) *patternmatcher.PatternMatcher {
+	f, err := fs.Open(filepath.Join(root, oktetoIgnoreFile))
+	if err != nil {
+		return nil
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			oktetoLog.Debugf("error closing file %s: %s", oktetoIgnoreFile, err)
+		}
+	}()
+
+	patterns, err := dockerignore.ReadAll(f)
+	if err != nil {
+		oktetoLog.Infof("error reading %s: %s", oktetoIgnoreFile, err)
+		return nil
+	}
+	pm, err := patternmatcher.New(patterns)
+	if err != nil {
+		oktetoLog.Infof("invalid patterns in %s: %s", oktetoIgnoreFile, err)
+		return nil
+	}
+	return pm
+}
+
+func isIgnored(pm *patternmatcher.PatternMatcher, root, path string) bool {
+	if pm == nil {
+		return false
+	}
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	ignored, err := pm.MatchesOrParentMatches(filepath.ToSlash(rel))
+	if err != nil {
+		oktetoLog.Debugf("error matching '%s' with %s: %s", rel, oktetoIgnoreFile, err)
+		return false
+	}
+	return ignored
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package discovery
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindOktetoManifests(t *testing.T) {
+	root := filepath.Join("/", "repo")
+	var tests = []struct {
+		name     string
+		cwd      string
+		files    []string
+		expected []string
+	}{
+		{
+			name:     "no manifests",
+			cwd:      root,
+			files:    []string{".git/HEAD", "README.md"},
+			expected: []string{},
+		},
+		{
+			name:  "manifests in subdirectories sorted by depth",
+			cwd:   root,
+			files: []string{".git/HEAD", "services/web/okteto.yml", "api/okteto.yaml", "services/worker/.okteto/okteto.yml"},
+			expected: []string{
+				filepath.Join(root, "api", "okteto.yaml"),
+				filepath.Join(root, "services", "web", "okteto.yml"),
+				filepath.Join(root, "services", "worker", ".okteto", "okteto.yml"),
+			},
+		},
+		{
+			name:  "parents up to the repository root before subdirectories",
+			cwd:   filepath.Join(root, "services", "api"),
+			files: []string{".git/HEAD", "okteto.yml", "services/okteto.yml", "services/api/src/okteto.yml"},
+			expected: []string{
+				filepath.Join(root, "services", "okteto.yml"),
+				filepath.Join(root, "services", "api", "src", "okteto.yml"),
+				filepath.Join(root, "okteto.yml"),
+			},
+		},
+		{
+			name:     "parents outside of the repository are ignored",
+			cwd:      filepath.Join(root, "app"),
+			files:    []string{"okteto.yml", "app/.git/HEAD"},
+			expected: []string{},
+		},
+		{
+			name:     "hidden, vendor and deep folders are skipped",
+			cwd:      root,
+			files:    []string{".git/HEAD", ".github/okteto.yml", "node_modules/lib/okteto.yml", "vendor/okteto.yml", "a/b/c/d/okteto.yml"},
+			expected: []string{},
+		},
+		{
+			name:     "paths in oktetoignore are skipped",
+			cwd:      filepath.Join(root, "services"),
+			files:    []string{".git/HEAD", ".oktetoignore", "services/legacy/okteto.yml", "services/web/okteto.yml"},
+			expected: []string{filepath.Join(root, "services", "web", "okteto.yml")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			require.NoError(t, fs.MkdirAll(tt.cwd, 0750))
+			for _, f := range tt.files {
+				content := ""
+				if f == oktetoIgnoreFile {
+					content = "services/legacy\n"
+				}
+				require.NoError(t, afero.WriteFile(fs, filepath.Join(root, f), []byte(content), 0600))
+			}
+
+			assert.Equal(t, tt.expected, FindOktetoManifests(tt.cwd, fs))
+		})
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package divert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/okteto/okteto/pkg/constants"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/format"
+	"github.com/okteto/okteto/pkg/k8s/configmaps"
+	"github.com/okteto/okteto/pkg/model"
+	"gopkg.in/yaml.v2"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// divertLabel identifies the configmaps storing the diverts managed by the divert commands
+	divertLabel = "dev.okteto.com/divert"
+
+	// divertConfigmapPrefix is the prefix of the configmaps storing the diverts
+	divertConfigmapPrefix = "okteto-divert-"
+
+	// divertDataField is the field of the configmap that stores the divert spec
+	divertDataField = "divert"
+)
+
+var (
+	// ErrDivertNotFound is returned when a divert doesn't exist in the namespace
+	ErrDivertNotFound = errors.New("divert not found")
+
+	// ErrDivertNamespaceRequired is returned when a divert doesn't define the namespace to divert
+	ErrDivertNamespaceRequired = errors.New("the namespace to divert is required")
+)
+
+// Divert represents a divert of a shared namespace into a developer namespace
+type Divert struct {
+	Name      string             `json:"name" yaml:"name"`
+	Namespace string             `json:"namespace" yaml:"namespace"`
+	Spec      model.DivertDeploy `json:"spec" yaml:"spec"`
+}
+
+type newDriverFunc func(m *model.Manifest, c kubernetes.Interface) (Driver, error)
+
+// Client manages diverts outside of the manifest deploy flow
+type Client struct {
+	k8s       kubernetes.Interface
+	newDriver newDriverFunc
+}
+
+// NewClient returns a new divert client
+func NewClient(c kubernetes.Interface) *Client {
+	return &Client{
+		k8s:       c,
+		newDriver: New,
+	}
+}
+
+// Create diverts the traffic and stores the divert so it can be listed and deleted later
+func (c *Client) Create(ctx context.Context, d *Divert) error {
+	if d.Spec.Namespace == "" {
+		return ErrDivertNamespaceRequired
+	}
+	if d.Spec.Driver == "" {
+		d.Spec.Driver = constants.OktetoDivertWeaverDriver
+	}
+	driver, err := c.newDriver(d.toManifest(), c.k8s)
+	if err != nil {
+		return err
+	}
+	if err := driver.Deploy(ctx); err != nil {
+		return fmt.Errorf("error diverting namespace '%s': %w", d.Spec.Namespace, err)
+	}
+
+	cmap, err := translateConfigMap(d)
+	if err != nil {
+		return err
+	}
+	return configmaps.Deploy(ctx, cmap, d.Namespace, c.k8s)
+}
+
+// List returns the diverts of a namespace
+func (c *Client) List(ctx context.Context, namespace string) ([]Divert, error) {
+	cmaps, err := configmaps.List(ctx, namespace, fmt.Sprintf("%s=true", divertLabel), c.k8s)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Divert, 0, len(cmaps))
+	for i := range cmaps {
+		d, err := translateDivert(&cmaps[i])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *d)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// Get returns a divert of a namespace
+func (c *Client) Get(ctx context.Context, name, namespace string) (*Divert, error) {
+	cmap, err := configmaps.Get(ctx, getConfigmapName(name), namespace, c.k8s)
+	if err != nil {
+		if oktetoErrors.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: '%s'", ErrDivertNotFound, name)
+		}
+		return nil, err
+	}
+	return translateDivert(cmap)
+}
+
+// Delete restores the diverted resources and removes the divert
+func (c *Client) Delete(ctx context.Context, name, namespace string) error {
+	d, err := c.Get(ctx, name, namespace)
+	if err != nil {
+		return err
+	}
+	driver, err := c.newDriver(d.toManifest(), c.k8s)
+	if err != nil {
+		return err
+	}
+	if err := driver.Destroy(ctx); err != nil {
+		return fmt.Errorf("error restoring namespace '%s': %w", d.Spec.Namespace, err)
+	}
+	return configmaps.Destroy(ctx, getConfigmapName(name), namespace, c.k8s)
+}
+
+func (d *Divert) toManifest() *model.Manifest {
+	spec := d.Spec
+	return &model.Manifest{
+		Name:      d.Name,
+		Namespace: d.Namespace,
+		Deploy: &model.DeployInfo{
+			Divert: &spec,
+		},
+	}
+}
+
+func getConfigmapName(name string) string {
+	return fmt.Sprintf("%s%s", divertConfigmapPrefix, format.ResourceK8sMetaString(name))
+}
+
+func translateConfigMap(d *Divert) (*apiv1.ConfigMap, error) {
+	bytes, err := yaml.Marshal(d)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing divert '%s': %w", d.Name, err)
+	}
+	return &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getConfigmapName(d.Name),
+			Namespace: d.Namespace,
+			Labels: map[string]string{
+				divertLabel: "true",
+			},
+		},
+		Data: map[string]string{
+			divertDataField: string(bytes),
+		},
+	}, nil
+}
+
+func translateDivert(cmap *apiv1.ConfigMap) (*Divert, error) {
+	d := &Divert{}
+	if err := yaml.Unmarshal([]byte(cmap.Data[divertDataField]), d); err != nil {
+		return nil, fmt.Errorf("error reading divert from configmap '%s': %w", cmap.Name, err)
+	}
+	return d, nil
+}
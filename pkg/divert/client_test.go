@@ -0,0 +1,101 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package divert
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	istioNetworkingV1beta1 "istio.io/api/networking/v1beta1"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type fakeDriver struct {
+	deployErr error
+	deployed  int
+	destroyed int
+}
+
+func (fd *fakeDriver) Deploy(_ context.Context) error {
+	fd.deployed++
+	return fd.deployErr
+}
+
+func (fd *fakeDriver) Destroy(_ context.Context) error {
+	fd.destroyed++
+	return nil
+}
+
+func (*fakeDriver) UpdatePod(spec apiv1.PodSpec) apiv1.PodSpec { return spec }
+
+func (*fakeDriver) UpdateVirtualService(_ *istioNetworkingV1beta1.VirtualService) {}
+
+func newFakeClient(driver *fakeDriver) *Client {
+	return &Client{
+		k8s: fake.NewSimpleClientset(),
+		newDriver: func(_ *model.Manifest, _ kubernetes.Interface) (Driver, error) {
+			return driver, nil
+		},
+	}
+}
+
+func TestClientCreateListDelete(t *testing.T) {
+	ctx := context.Background()
+	driver := &fakeDriver{}
+	c := newFakeClient(driver)
+
+	require.NoError(t, c.Create(ctx, &Divert{Name: "movies", Namespace: "cindy", Spec: model.DivertDeploy{Namespace: "staging"}}))
+	require.NoError(t, c.Create(ctx, &Divert{Name: "api", Namespace: "cindy", Spec: model.DivertDeploy{Namespace: "staging", Driver: constants.OktetoDivertIstioDriver}}))
+	assert.Equal(t, 2, driver.deployed)
+
+	diverts, err := c.List(ctx, "cindy")
+	require.NoError(t, err)
+	require.Len(t, diverts, 2)
+	assert.Equal(t, "api", diverts[0].Name)
+	assert.Equal(t, constants.OktetoDivertIstioDriver, diverts[0].Spec.Driver)
+	assert.Equal(t, "movies", diverts[1].Name)
+	assert.Equal(t, constants.OktetoDivertWeaverDriver, diverts[1].Spec.Driver)
+
+	diverts, err = c.List(ctx, "other")
+	require.NoError(t, err)
+	assert.Empty(t, diverts)
+
+	require.NoError(t, c.Delete(ctx, "movies", "cindy"))
+	assert.Equal(t, 1, driver.destroyed)
+
+	_, err = c.Get(ctx, "movies", "cindy")
+	assert.ErrorIs(t, err, ErrDivertNotFound)
+	assert.ErrorIs(t, c.Delete(ctx, "movies", "cindy"), ErrDivertNotFound)
+}
+
+func TestClientCreateErrors(t *testing.T) {
+	ctx := context.Background()
+	driver := &fakeDriver{deployErr: errors.New("boom")}
+	c := newFakeClient(driver)
+
+	assert.ErrorIs(t, c.Create(ctx, &Divert{Name: "movies", Namespace: "cindy"}), ErrDivertNamespaceRequired)
+	assert.Equal(t, 0, driver.deployed)
+
+	assert.Error(t, c.Create(ctx, &Divert{Name: "movies", Namespace: "cindy", Spec: model.DivertDeploy{Namespace: "staging"}}))
+	diverts, err := c.List(ctx, "cindy")
+	require.NoError(t, err)
+	assert.Empty(t, diverts)
+}
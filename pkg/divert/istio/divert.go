@@ -20,6 +20,7 @@ import (
 	"github.com/okteto/okteto/pkg/k8s/virtualservices"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/retry"
 	istioNetworkingV1beta1 "istio.io/api/networking/v1beta1"
 	istioclientset "istio.io/client-go/pkg/clientset/versioned"
 	apiv1 "k8s.io/api/core/v1"
@@ -89,31 +90,24 @@ func (d *Driver) Deploy(ctx context.Context) error {
 }
 
 func (d *Driver) Destroy(ctx context.Context) error {
-	var err error
 	for i := range d.divert.VirtualServices {
 		oktetoLog.Spinner(fmt.Sprintf("Restoring virtual service %s/%s...", d.divert.VirtualServices[i].Namespace, d.divert.VirtualServices[i].Name))
 		oktetoLog.StartSpinner()
 		defer oktetoLog.StopSpinner()
-		for retries := 0; retries < UPDATE_CONFLICT_RETRIES; retries++ {
-			vs, err := virtualservices.Get(ctx, d.divert.VirtualServices[i].Name, d.divert.VirtualServices[i].Namespace, d.istioClient)
+		vsName, vsNamespace := d.divert.VirtualServices[i].Name, d.divert.VirtualServices[i].Namespace
+		err := retry.Do(ctx, conflictRetryPolicy("restore virtual service"), func(ctx context.Context) error {
+			vs, err := virtualservices.Get(ctx, vsName, vsNamespace, d.istioClient)
 			if err != nil {
 				return err
 			}
 			restoredVS := d.restoreDivertVirtualService(vs)
-
-			err = virtualservices.Update(ctx, restoredVS, d.istioClient)
-			if err == nil {
-				oktetoLog.StopSpinner()
-				oktetoLog.Success("Virtual service '%s/%s' successfully restored", d.divert.VirtualServices[i].Namespace, d.divert.VirtualServices[i].Name)
-				break
-			}
-			if !k8sErrors.IsConflict(err) {
-				return err
-			}
-		}
+			return virtualservices.Update(ctx, restoredVS, d.istioClient)
+		})
 		if err != nil {
 			return err
 		}
+		oktetoLog.StopSpinner()
+		oktetoLog.Success("Virtual service '%s/%s' successfully restored", vsNamespace, vsName)
 	}
 	return nil
 
@@ -128,8 +122,7 @@ func (d *Driver) UpdateVirtualService(vs *istioNetworkingV1beta1.VirtualService)
 }
 
 func (d *Driver) retryTranslateDivertVirtualService(ctx context.Context, divertVS model.DivertVirtualService) error {
-	var err error
-	for retries := 0; retries < UPDATE_CONFLICT_RETRIES; retries++ {
+	return retry.Do(ctx, conflictRetryPolicy("divert virtual service"), func(ctx context.Context) error {
 		vs, err := virtualservices.Get(ctx, divertVS.Name, divertVS.Namespace, d.istioClient)
 		if err != nil {
 			return err
@@ -138,20 +131,12 @@ func (d *Driver) retryTranslateDivertVirtualService(ctx context.Context, divertV
 		if err != nil {
 			return err
 		}
-		err = virtualservices.Update(ctx, translatedVS, d.istioClient)
-		if err == nil {
-			return nil
-		}
-		if !k8sErrors.IsConflict(err) {
-			return err
-		}
-	}
-	return err
+		return virtualservices.Update(ctx, translatedVS, d.istioClient)
+	})
 }
 
 func (d *Driver) retryTranslateDivertHost(ctx context.Context, divertHost model.DivertHost) error {
-	var err error
-	for retries := 0; retries < UPDATE_CONFLICT_RETRIES; retries++ {
+	return retry.Do(ctx, conflictRetryPolicy("divert host"), func(ctx context.Context) error {
 		vs, err := virtualservices.Get(ctx, divertHost.VirtualService, divertHost.Namespace, d.istioClient)
 		if err != nil {
 			return err
@@ -161,14 +146,14 @@ func (d *Driver) retryTranslateDivertHost(ctx context.Context, divertHost model.
 		devVS, err := virtualservices.Get(ctx, divertHost.VirtualService, d.namespace, d.istioClient)
 		if k8sErrors.IsNotFound(err) {
 			err = virtualservices.Create(ctx, translatedVS, d.istioClient)
-			if err == nil {
-				return nil
-			}
-			if k8sErrors.IsAlreadyExists(err) {
+			if err == nil || k8sErrors.IsAlreadyExists(err) {
 				return nil
 			}
 			return err
 		}
+		if err != nil {
+			return err
+		}
 
 		if devVS.Labels[model.OktetoAutoCreateAnnotation] != "true" {
 			oktetoLog.Infof("Ignoring host '%s/%s', virtual service '%s/%s'", divertHost.Namespace, divertHost.VirtualService, d.namespace, divertHost.VirtualService)
@@ -176,13 +161,15 @@ func (d *Driver) retryTranslateDivertHost(ctx context.Context, divertHost model.
 		}
 
 		translatedVS.ResourceVersion = devVS.ResourceVersion
-		err = virtualservices.Update(ctx, translatedVS, d.istioClient)
-		if err == nil {
-			return nil
-		}
-		if !k8sErrors.IsConflict(err) {
-			return err
-		}
+		return virtualservices.Update(ctx, translatedVS, d.istioClient)
+	})
+}
+
+// conflictRetryPolicy retries the updates of virtual services modified by someone else in the meantime
+func conflictRetryPolicy(name string) retry.Policy {
+	return retry.Policy{
+		Name:        name,
+		Retryable:   retry.OnConflict,
+		MaxAttempts: UPDATE_CONFLICT_RETRIES,
 	}
-	return err
 }
@@ -0,0 +1,100 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"os"
+	"sort"
+)
+
+// Sources of a variable sorted from the highest to the lowest precedence
+const (
+	SourceFlag     = "flag"
+	SourceEnv      = "env"
+	SourceDotEnv   = "dotenv"
+	SourceManifest = "manifest"
+	SourcePlatform = "platform"
+)
+
+// ResolvedVar represents the value of a variable and the source it was taken from
+type ResolvedVar struct {
+	Name   string `json:"name" yaml:"name"`
+	Value  string `json:"value,omitempty" yaml:"value,omitempty"`
+	Source string `json:"source" yaml:"source"`
+}
+
+// Resolver resolves variables with the precedence: flags > env > .env file > manifest defaults > okteto platform variables
+type Resolver struct {
+	LookupEnv func(key string) (string, bool)
+	SetEnv    func(key, value string) error
+	DotEnv    map[string]string
+	Flags     []Var
+	Manifest  Environment
+	Platform  []Var
+}
+
+// NewResolver returns a resolver that reads and writes the process environment
+func NewResolver() *Resolver {
+	return &Resolver{
+		LookupEnv: os.LookupEnv,
+		SetEnv:    os.Setenv,
+		DotEnv:    map[string]string{},
+	}
+}
+
+// Resolve returns the value of every variable defined in any source sorted by name.
+// The .env file and the platform variables are exported to the environment when the context is loaded,
+// so an environment value equal to the value of a lower source is attributed to that source
+func (r *Resolver) Resolve() []ResolvedVar {
+	result := map[string]ResolvedVar{}
+	for _, v := range r.Platform {
+		result[v.Name] = ResolvedVar{Name: v.Name, Value: v.Value, Source: SourcePlatform}
+	}
+	for _, v := range r.Manifest {
+		result[v.Name] = ResolvedVar{Name: v.Name, Value: v.Value, Source: SourceManifest}
+	}
+	for name, value := range r.DotEnv {
+		result[name] = ResolvedVar{Name: name, Value: value, Source: SourceDotEnv}
+	}
+	for name, rv := range result {
+		if value, ok := r.LookupEnv(name); ok && value != rv.Value {
+			result[name] = ResolvedVar{Name: name, Value: value, Source: SourceEnv}
+		}
+	}
+	for _, v := range r.Flags {
+		result[v.Name] = ResolvedVar{Name: v.Name, Value: v.Value, Source: SourceFlag}
+	}
+
+	vars := make([]ResolvedVar, 0, len(result))
+	for _, rv := range result {
+		vars = append(vars, rv)
+	}
+	sort.Slice(vars, func(i, j int) bool {
+		return vars[i].Name < vars[j].Name
+	})
+	return vars
+}
+
+// ExportManifestDefaults exports the manifest variables that are not defined in the environment
+func (r *Resolver) ExportManifestDefaults() error {
+	for _, v := range r.Manifest {
+		if _, ok := r.LookupEnv(v.Name); ok {
+			continue
+		}
+		if err := r.SetEnv(v.Name, v.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolverPrecedence(t *testing.T) {
+	environ := map[string]string{
+		"FROM_ENV":    "env",
+		"FROM_DOTENV": "dotenv",
+		"PLATFORM":    "platform",
+	}
+	r := &Resolver{
+		LookupEnv: func(key string) (string, bool) {
+			v, ok := environ[key]
+			return v, ok
+		},
+		Flags: []Var{
+			{Name: "FROM_FLAG", Value: "flag"},
+		},
+		DotEnv: map[string]string{
+			"FROM_DOTENV": "dotenv",
+			"FROM_FLAG":   "dotenv",
+		},
+		Manifest: Environment{
+			{Name: "FROM_ENV", Value: "manifest"},
+			{Name: "FROM_MANIFEST", Value: "manifest"},
+		},
+		Platform: []Var{
+			{Name: "FROM_MANIFEST", Value: "platform"},
+			{Name: "PLATFORM", Value: "platform"},
+		},
+	}
+
+	expected := []ResolvedVar{
+		{Name: "FROM_DOTENV", Value: "dotenv", Source: SourceDotEnv},
+		{Name: "FROM_ENV", Value: "env", Source: SourceEnv},
+		{Name: "FROM_FLAG", Value: "flag", Source: SourceFlag},
+		{Name: "FROM_MANIFEST", Value: "manifest", Source: SourceManifest},
+		{Name: "PLATFORM", Value: "platform", Source: SourcePlatform},
+	}
+	assert.Equal(t, expected, r.Resolve())
+}
+
+func TestExportManifestDefaults(t *testing.T) {
+	environ := map[string]string{
+		"DEFINED": "env",
+	}
+	r := &Resolver{
+		LookupEnv: func(key string) (string, bool) {
+			v, ok := environ[key]
+			return v, ok
+		},
+		SetEnv: func(key, value string) error {
+			environ[key] = value
+			return nil
+		},
+		Manifest: Environment{
+			{Name: "DEFINED", Value: "manifest"},
+			{Name: "DEFAULT", Value: "manifest"},
+		},
+	}
+
+	require.NoError(t, r.ExportManifestDefaults())
+	assert.Equal(t, map[string]string{"DEFINED": "env", "DEFAULT": "manifest"}, environ)
+}
@@ -85,6 +85,9 @@ var (
 	// ErrInternalServerError is raised when an internal server error or similar is received
 	ErrInternalServerError = fmt.Errorf("internal server error, please try again")
 
+	// ErrAPIUnavailable is raised when the okteto API calls are short-circuited after consecutive failures
+	ErrAPIUnavailable = errors.New("the okteto API is not responding")
+
 	// ErrQuota is returned when there aren't enough resources to enable dev mode
 	ErrQuota = fmt.Errorf("quota exceeded, please free some resources and try again")
 
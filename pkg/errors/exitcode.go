@@ -0,0 +1,102 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors
+
+import (
+	"context"
+	"errors"
+)
+
+// Exit codes returned by the okteto cli so CI pipelines can branch on the class of the failure
+const (
+	// ExitCodeGeneric is returned for any error not included in the classes below
+	ExitCodeGeneric = 1
+
+	// ExitCodeConfig is returned when the manifest, the flags or the cli configuration are not valid
+	ExitCodeConfig = 2
+
+	// ExitCodeAuth is returned when the user is not logged in, the token expired or the context is not set
+	ExitCodeAuth = 3
+
+	// ExitCodeBuild is returned when an image fails to build
+	ExitCodeBuild = 4
+
+	// ExitCodeDeploy is returned when a deploy command fails
+	ExitCodeDeploy = 5
+
+	// ExitCodeSync is returned when the file synchronization of a development container fails
+	ExitCodeSync = 6
+
+	// ExitCodeTimeout is returned when an operation times out
+	ExitCodeTimeout = 7
+)
+
+// ExitCodeError sets the exit code of an error
+type ExitCodeError struct {
+	E    error
+	Code int
+}
+
+// Error returns the error message
+func (e ExitCodeError) Error() string {
+	return e.E.Error()
+}
+
+func (e ExitCodeError) Unwrap() error {
+	return e.E
+}
+
+// WithExitCode sets the exit code of an error. Errors already classified keep their exit code
+func WithExitCode(err error, code int) error {
+	if err == nil || GetExitCode(err) != ExitCodeGeneric {
+		return err
+	}
+	return ExitCodeError{E: err, Code: code}
+}
+
+// GetExitCode returns the exit code of an error
+func GetExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	var exitErr ExitCodeError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+
+	switch {
+	case isAny(err, ErrNotLoggedMsg, ErrTokenExpired, ErrCtxNotSet, ErrNotOktetoCluster, ErrTokenFlagNeeded, ErrTokenEnvVarNeeded, ErrInvalidLicense):
+		return ExitCodeAuth
+	case isAny(err, ErrInvalidManifest, ErrEmptyManifest, ErrNotManifestContentDetected, ErrCouldNotInferAnyManifest, ErrManifestNoDevSection,
+		ErrManifestFoundButNoDeployAndDependenciesCommands, ErrNamespaceNotMatching, ErrContextNotMatching, ErrBuiltInOktetoEnvVarSetFromCMD):
+		return ExitCodeConfig
+	case isAny(err, ErrUnknownSyncError, ErrNeedsResetSyncError, ErrInsufficientSpace, ErrBusySyncthing, ErrLostSyncthing):
+		return ExitCodeSync
+	case isAny(err, ErrDeployHasFailedCommand):
+		return ExitCodeDeploy
+	case isAny(err, ErrTimeout, context.DeadlineExceeded):
+		return ExitCodeTimeout
+	}
+	return ExitCodeGeneric
+}
+
+func isAny(err error, targets ...error) bool {
+	for _, target := range targets {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
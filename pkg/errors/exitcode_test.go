@@ -0,0 +1,57 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetExitCode(t *testing.T) {
+	tests := []struct {
+		err      error
+		name     string
+		expected int
+	}{
+		{name: "nil", err: nil, expected: 0},
+		{name: "generic", err: errors.New("boom"), expected: ExitCodeGeneric},
+		{name: "not-logged", err: NotLoggedError{Context: "https://okteto.example.com"}, expected: ExitCodeAuth},
+		{name: "token-expired", err: UserError{E: ErrTokenExpired}, expected: ExitCodeAuth},
+		{name: "invalid-manifest", err: fmt.Errorf("%w: line 1", ErrInvalidManifest), expected: ExitCodeConfig},
+		{name: "sync", err: ErrLostSyncthing, expected: ExitCodeSync},
+		{name: "deploy-command", err: UserError{E: ErrDeployHasFailedCommand}, expected: ExitCodeDeploy},
+		{name: "timeout", err: fmt.Errorf("waiting: %w", context.DeadlineExceeded), expected: ExitCodeTimeout},
+		{name: "explicit", err: UserError{E: ExitCodeError{E: errors.New("build failed"), Code: ExitCodeBuild}}, expected: ExitCodeBuild},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, GetExitCode(tt.err))
+		})
+	}
+}
+
+func TestWithExitCode(t *testing.T) {
+	assert.NoError(t, WithExitCode(nil, ExitCodeBuild))
+
+	err := WithExitCode(errors.New("build failed"), ExitCodeBuild)
+	assert.Equal(t, ExitCodeBuild, GetExitCode(err))
+
+	// the exit code of the build is kept when the deploy fails because of it
+	assert.Equal(t, ExitCodeBuild, GetExitCode(WithExitCode(err, ExitCodeDeploy)))
+	assert.Equal(t, ExitCodeConfig, GetExitCode(WithExitCode(ErrInvalidManifest, ExitCodeDeploy)))
+}
@@ -19,6 +19,7 @@ import (
 	"time"
 
 	"github.com/okteto/okteto/pkg/constants"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/externalresource/k8s"
 	"github.com/okteto/okteto/pkg/format"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
@@ -98,6 +99,44 @@ func (c *K8sControl) List(ctx context.Context, ns string, labelSelector string)
 	return result, nil
 }
 
+// Get returns the external resource 'name' of the namespace 'ns'
+func (c *K8sControl) Get(ctx context.Context, name, ns string) (*ExternalResource, error) {
+	k8sclient, err := c.ClientProvider(c.Cfg)
+	if err != nil {
+		return nil, fmt.Errorf("error providing external resource client: %w", err)
+	}
+
+	external, err := k8sclient.ExternalResources(ns).Get(ctx, format.ResourceK8sMetaString(name), metav1.GetOptions{})
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: external resource '%s'", oktetoErrors.ErrNotFound, name)
+		}
+		return nil, fmt.Errorf("error getting external resource '%s': %w", name, err)
+	}
+
+	result := translateK8sToExternal(*external)
+	return &result, nil
+}
+
+// Delete deletes the external resource 'name' of the namespace 'ns'
+func (c *K8sControl) Delete(ctx context.Context, name, ns string) error {
+	k8sclient, err := c.ClientProvider(c.Cfg)
+	if err != nil {
+		return fmt.Errorf("error providing external resource client: %w", err)
+	}
+
+	oktetoLog.Infof("deleting external resource CRD '%s'", name)
+	err = k8sclient.ExternalResources(ns).Delete(ctx, format.ResourceK8sMetaString(name), metav1.DeleteOptions{})
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return fmt.Errorf("%w: external resource '%s'", oktetoErrors.ErrNotFound, name)
+		}
+		return fmt.Errorf("error deleting external resource '%s': %w", name, err)
+	}
+	oktetoLog.Infof("deleted external resource CRD '%s'", name)
+	return nil
+}
+
 func translate(name, namespace string, externalResource *ExternalResource, now time.Time) *k8s.External {
 	var externalEndpointsSpec []k8s.Endpoint
 	for _, endpoint := range externalResource.Endpoints {
@@ -149,7 +188,13 @@ func translateK8sToExternal(er k8s.External) ExternalResource {
 	for _, ep := range er.Spec.Endpoints {
 		endpoints = append(endpoints, translateK8sToEndpoint(ep))
 	}
+	name := er.Spec.Name
+	if name == "" {
+		name = er.Name
+	}
 	return ExternalResource{
+		Name:      name,
+		Icon:      er.Spec.Icon,
 		Notes:     notes,
 		Endpoints: endpoints,
 	}
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/okteto/okteto/pkg/constants"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/externalresource/k8s"
 	"github.com/okteto/okteto/pkg/externalresource/k8s/fake"
 	"github.com/stretchr/testify/assert"
@@ -35,7 +36,7 @@ type fakeClientProvider struct {
 }
 
 type errs struct {
-	providerErr, getErr, createErr, updateErr, listErr error
+	providerErr, getErr, createErr, updateErr, listErr, deleteErr error
 }
 
 func (fcp *fakeClientProvider) provide(_ *rest.Config) (k8s.ExternalResourceV1Interface, error) {
@@ -48,6 +49,7 @@ func (fcp *fakeClientProvider) provide(_ *rest.Config) (k8s.ExternalResourceV1In
 		CreateErr: fcp.possibleErrs.createErr,
 		UpdateErr: fcp.possibleErrs.updateErr,
 		ListErr:   fcp.possibleErrs.listErr,
+		DeleteErr: fcp.possibleErrs.deleteErr,
 	}
 	return fake.NewFakeExternalResourceV1(possibleERErrors, fcp.objects...), nil
 }
@@ -313,6 +315,126 @@ func TestList(t *testing.T) {
 	}
 }
 
+func TestGet(t *testing.T) {
+	ctx := context.Background()
+	namespace := "testns"
+	external := &k8s.External{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "my-external",
+			Namespace: namespace,
+		},
+		Spec: k8s.ExternalResourceSpec{
+			Icon: "database",
+			Name: "my-external",
+			Endpoints: []k8s.Endpoint{
+				{
+					Name: "web",
+					Url:  "https://test.com",
+				},
+			},
+		},
+	}
+	var tt = []struct {
+		possibleErrs errs
+		expected     *ExternalResource
+		expectedErr  error
+		name         string
+		externalName string
+	}{
+		{
+			name:         "found",
+			externalName: "my external",
+			expected: &ExternalResource{
+				Name: "my-external",
+				Icon: "database",
+				Endpoints: []*ExternalEndpoint{
+					{
+						Name: "web",
+						Url:  "https://test.com",
+					},
+				},
+			},
+		},
+		{
+			name:         "not found",
+			externalName: "other",
+			expectedErr:  oktetoErrors.ErrNotFound,
+		},
+		{
+			name:         "get error",
+			externalName: "my-external",
+			expectedErr:  assert.AnError,
+			possibleErrs: errs{
+				getErr: assert.AnError,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := K8sControl{
+				ClientProvider: (&fakeClientProvider{
+					objects:      []runtime.Object{external},
+					possibleErrs: tc.possibleErrs,
+				}).provide,
+			}
+			result, err := ctrl.Get(ctx, tc.externalName, namespace)
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	ctx := context.Background()
+	namespace := "testns"
+	var tt = []struct {
+		possibleErrs errs
+		expectedErr  error
+		name         string
+		externalName string
+	}{
+		{
+			name:         "deleted",
+			externalName: "my-external",
+		},
+		{
+			name:         "not found",
+			externalName: "other",
+			expectedErr:  oktetoErrors.ErrNotFound,
+		},
+		{
+			name:         "delete error",
+			externalName: "my-external",
+			expectedErr:  assert.AnError,
+			possibleErrs: errs{
+				deleteErr: assert.AnError,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			provider := &fakeClientProvider{
+				objects: []runtime.Object{
+					&k8s.External{
+						ObjectMeta: v1.ObjectMeta{
+							Name:      "my-external",
+							Namespace: namespace,
+						},
+					},
+				},
+				possibleErrs: tc.possibleErrs,
+			}
+			ctrl := K8sControl{
+				ClientProvider: provider.provide,
+			}
+			err := ctrl.Delete(ctx, tc.externalName, namespace)
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
 func TestTranslate(t *testing.T) {
 	now := time.Now()
 	name := "non sanitized name"
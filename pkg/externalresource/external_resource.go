@@ -33,6 +33,7 @@ type ExternalResourceSection map[string]*ExternalResource
 
 // ExternalResource represents information on an external resource
 type ExternalResource struct {
+	Name      string `yaml:"-"`
 	Icon      string
 	Notes     *Notes
 	Endpoints []*ExternalEndpoint
@@ -24,9 +24,9 @@ import (
 
 // FakeExternalResource implements ExternalResourceInterface
 type FakeExternalResource struct {
-	getErr, createErr, updateErr, listErr error
-	Fake                                  *FakeExternalResourceV1
-	ns                                    string
+	getErr, createErr, updateErr, listErr, deleteErr error
+	Fake                                             *FakeExternalResourceV1
+	ns                                               string
 }
 
 var externalResourceResource = schema.GroupVersionResource{Group: k8sexternalresource.GroupName, Version: k8sexternalresource.GroupVersion, Resource: k8sexternalresource.ExternalResourceResource}
@@ -87,3 +87,13 @@ func (c *FakeExternalResource) List(ctx context.Context, opts metav1.ListOptions
 	}
 	return obj.(*k8sexternalresource.ExternalList), err
 }
+
+func (c *FakeExternalResource) Delete(_ context.Context, name string, _ metav1.DeleteOptions) error {
+	if c.deleteErr != nil {
+		return c.deleteErr
+	}
+
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(externalResourceResource, c.ns, name), &k8sexternalresource.External{})
+	return err
+}
@@ -29,7 +29,7 @@ type FakeExternalResourceV1 struct {
 }
 
 type PossibleERErrors struct {
-	GetErr, UpdateErr, CreateErr, ListErr error
+	GetErr, UpdateErr, CreateErr, ListErr, DeleteErr error
 }
 
 func NewFakeExternalResourceV1(errs PossibleERErrors, objects ...runtime.Object) *FakeExternalResourceV1 {
@@ -71,5 +71,6 @@ func (c *FakeExternalResourceV1) ExternalResources(namespace string) k8sexternal
 		createErr: c.possibleErrs.CreateErr,
 		updateErr: c.possibleErrs.UpdateErr,
 		listErr:   c.possibleErrs.ListErr,
+		deleteErr: c.possibleErrs.DeleteErr,
 	}
 }
@@ -41,6 +41,7 @@ type ExternalResourceInterface interface {
 	Get(ctx context.Context, name string, options metav1.GetOptions) (*External, error)
 	Create(ctx context.Context, external *External, options metav1.CreateOptions) (*External, error)
 	List(ctx context.Context, options metav1.ListOptions) (*ExternalList, error)
+	Delete(ctx context.Context, name string, options metav1.DeleteOptions) error
 }
 
 func (c *externalClient) Create(ctx context.Context, external *External, _ metav1.CreateOptions) (*External, error) {
@@ -93,3 +94,13 @@ func (c *externalClient) List(ctx context.Context, opts metav1.ListOptions) (*Ex
 		Into(&result)
 	return &result, err
 }
+
+func (c *externalClient) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.restClient.Delete().
+		Namespace(c.ns).
+		Resource(ExternalResourceResource).
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
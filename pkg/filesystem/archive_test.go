@@ -0,0 +1,83 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystem
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveAndExtractDirectory(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	src := filepath.Join("/", "src")
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(src, "okteto.yml"), []byte("deploy: []"), 0600))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(src, "chart", "Chart.yaml"), []byte("name: app"), 0600))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(src, ".git", "HEAD"), []byte("ref"), 0600))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(src, ".okteto", "state"), []byte("state"), 0600))
+
+	content, err := ArchiveDirectory(fs, src, nil)
+	require.NoError(t, err)
+
+	dst := filepath.Join("/", "dst")
+	require.NoError(t, ExtractArchive(fs, content, dst))
+
+	b, err := afero.ReadFile(fs, filepath.Join(dst, "okteto.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, "deploy: []", string(b))
+	b, err = afero.ReadFile(fs, filepath.Join(dst, "chart", "Chart.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "name: app", string(b))
+	assert.False(t, FileExistsWithFilesystem(filepath.Join(dst, ".git"), fs))
+	assert.False(t, FileExistsWithFilesystem(filepath.Join(dst, ".okteto"), fs))
+}
+
+func TestArchiveDirectoryExclude(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	src := filepath.Join("/", "src")
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(src, "okteto.yml"), []byte("deploy: []"), 0600))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(src, ".env"), []byte("TOKEN=secret"), 0600))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(src, "node_modules", "lib", "index.js"), []byte(""), 0600))
+
+	content, err := ArchiveDirectory(fs, src, func(rel string) (bool, error) {
+		return rel == ".env" || rel == "node_modules", nil
+	})
+	require.NoError(t, err)
+
+	dst := filepath.Join("/", "dst")
+	require.NoError(t, ExtractArchive(fs, content, dst))
+	assert.True(t, FileExistsWithFilesystem(filepath.Join(dst, "okteto.yml"), fs))
+	assert.False(t, FileExistsWithFilesystem(filepath.Join(dst, ".env"), fs))
+	assert.False(t, FileExistsWithFilesystem(filepath.Join(dst, "node_modules"), fs))
+}
+
+func TestExtractArchiveOutsideOfDestination(t *testing.T) {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: "../evil", Mode: 0600, Size: 4}))
+	_, err := tw.Write([]byte("evil"))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+
+	err = ExtractArchive(afero.NewMemMapFs(), buf.Bytes(), filepath.Join("/", "dst"))
+	assert.Error(t, err)
+}
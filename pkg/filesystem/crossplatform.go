@@ -0,0 +1,107 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystem
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"unicode"
+
+	"github.com/okteto/okteto/pkg/env"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/spf13/afero"
+)
+
+// NormalizeLineEndingsEnvVar defines if CRLF line endings are normalized to LF when hashing files and generating ignore files.
+// It defaults to true on windows
+const NormalizeLineEndingsEnvVar = "OKTETO_NORMALIZE_LINE_ENDINGS"
+
+// CrossPlatformFs wraps a filesystem so paths and file contents are handled the same way on every OS
+type CrossPlatformFs struct {
+	afero.Fs
+	// CaseInsensitive is true when the filesystem doesn't distinguish paths that only differ in case
+	CaseInsensitive bool
+	// NormalizeLineEndings is true when CRLF line endings are converted to LF when reading files
+	NormalizeLineEndings bool
+}
+
+// NewCrossPlatformFs returns a CrossPlatformFs detecting if dir lives in a case-insensitive filesystem
+func NewCrossPlatformFs(fs afero.Fs, dir string) *CrossPlatformFs {
+	return &CrossPlatformFs{
+		Fs:                   fs,
+		CaseInsensitive:      isCaseInsensitive(fs, dir),
+		NormalizeLineEndings: env.LoadBooleanOrDefault(NormalizeLineEndingsEnvVar, runtime.GOOS == "windows"),
+	}
+}
+
+// NormalizePath returns the path cleaned and using forward slashes as separator
+func (*CrossPlatformFs) NormalizePath(path string) string {
+	return filepath.ToSlash(filepath.Clean(path))
+}
+
+// NormalizeContent converts CRLF line endings to LF if line ending normalization is enabled
+func (c *CrossPlatformFs) NormalizeContent(content []byte) []byte {
+	if !c.NormalizeLineEndings {
+		return content
+	}
+	return bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+}
+
+// ReadFile reads a file normalizing its line endings if enabled
+func (c *CrossPlatformFs) ReadFile(path string) ([]byte, error) {
+	content, err := afero.ReadFile(c.Fs, path)
+	if err != nil {
+		return nil, err
+	}
+	return c.NormalizeContent(content), nil
+}
+
+// isCaseInsensitive checks if dir is reachable with the case of its name swapped.
+// If its name has no letters it falls back to the default of the OS
+func isCaseInsensitive(fs afero.Fs, dir string) bool {
+	defaultValue := runtime.GOOS == "windows" || runtime.GOOS == "darwin"
+
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return defaultValue
+	}
+	base := filepath.Base(abs)
+	swapped := swapCase(base)
+	if swapped == base {
+		return defaultValue
+	}
+
+	original, err := fs.Stat(abs)
+	if err != nil {
+		oktetoLog.Debugf("failed to check case-sensitivity of '%s': %s", abs, err)
+		return defaultValue
+	}
+	other, err := fs.Stat(filepath.Join(filepath.Dir(abs), swapped))
+	if err != nil {
+		return false
+	}
+	return os.SameFile(original, other)
+}
+
+func swapCase(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsUpper(r) {
+			return unicode.ToLower(r)
+		}
+		return unicode.ToUpper(r)
+	}, s)
+}
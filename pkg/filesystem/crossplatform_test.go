@@ -0,0 +1,81 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystem
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCrossPlatformFsNormalizePath(t *testing.T) {
+	c := &CrossPlatformFs{Fs: afero.NewMemMapFs()}
+	assert.Equal(t, "services/api/main.go", c.NormalizePath(filepath.Join("services", "api", "..", "api", "main.go")))
+}
+
+func TestCrossPlatformFsReadFile(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "file", []byte("a\r\nb\r\n"), 0600))
+
+	var tests = []struct {
+		name                 string
+		expected             string
+		normalizeLineEndings bool
+	}{
+		{
+			name:     "without normalization",
+			expected: "a\r\nb\r\n",
+		},
+		{
+			name:                 "with normalization",
+			normalizeLineEndings: true,
+			expected:             "a\nb\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &CrossPlatformFs{Fs: fs, NormalizeLineEndings: tt.normalizeLineEndings}
+			content, err := c.ReadFile("file")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(content))
+		})
+	}
+}
+
+func TestNewCrossPlatformFsLineEndings(t *testing.T) {
+	t.Setenv(NormalizeLineEndingsEnvVar, "")
+	assert.Equal(t, runtime.GOOS == "windows", NewCrossPlatformFs(afero.NewMemMapFs(), ".").NormalizeLineEndings)
+
+	t.Setenv(NormalizeLineEndingsEnvVar, "true")
+	assert.True(t, NewCrossPlatformFs(afero.NewMemMapFs(), ".").NormalizeLineEndings)
+
+	t.Setenv(NormalizeLineEndingsEnvVar, "false")
+	assert.False(t, NewCrossPlatformFs(afero.NewMemMapFs(), ".").NormalizeLineEndings)
+}
+
+func TestIsCaseInsensitive(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	dir := filepath.Join(t.TempDir(), "Project")
+	require.NoError(t, fs.MkdirAll(dir, 0700))
+	assert.False(t, isCaseInsensitive(fs, dir))
+
+	osDir := filepath.Join(t.TempDir(), "Project")
+	require.NoError(t, afero.NewOsFs().MkdirAll(osDir, 0700))
+	expected := runtime.GOOS == "windows" || runtime.GOOS == "darwin"
+	assert.Equal(t, expected, isCaseInsensitive(afero.NewOsFs(), osDir))
+}
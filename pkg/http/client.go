@@ -17,18 +17,18 @@ import (
 	"net/http"
 )
 
-// StrictSSLHTTPClient receives multiple *x509.Certificate and returns an *http.Client with a StrictSSLTransport
+// StrictSSLHTTPClient receives multiple *x509.Certificate and returns an *http.Client with the shared StrictSSLTransport of the options
 func StrictSSLHTTPClient(opts *SSLTransportOption) *http.Client {
-	transport := StrictSSLTransport(opts)
+	transport := SharedStrictSSLTransport(opts)
 
 	return &http.Client{
 		Transport: transport,
 	}
 }
 
-// InsecureHTTPClient returns an *http.Client with an InsecureTransport
+// InsecureHTTPClient returns an *http.Client with the shared InsecureTransport
 func InsecureHTTPClient() *http.Client {
-	transport := InsecureTransport()
+	transport := SharedInsecureTransport()
 
 	return &http.Client{
 		Transport: transport,
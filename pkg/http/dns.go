@@ -0,0 +1,260 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// dnsCacheTTL is the time the resolved addresses of a host are reused
+	dnsCacheTTL = time.Minute
+
+	// defaultFallbackDelay is the time to wait before racing the addresses of the other IP family, as net.Dialer does
+	defaultFallbackDelay = 300 * time.Millisecond
+
+	// minDialTimeout is the minimum time given to each address when the timeout of a dial is split among them
+	minDialTimeout = 2 * time.Second
+)
+
+// defaultDialer is the dialer of the transports of this package. It caches the DNS lookups shared by all of them
+var defaultDialer = newCachingDialer(&net.Dialer{
+	Timeout:   30 * time.Second,
+	KeepAlive: 30 * time.Second,
+}, net.DefaultResolver.LookupHost)
+
+type dnsEntry struct {
+	expiresAt time.Time
+	addrs     []string
+}
+
+// dnsCache keeps the addresses of the hosts resolved in the last dnsCacheTTL
+type dnsCache struct {
+	lookupHost func(ctx context.Context, host string) ([]string, error)
+	now        func() time.Time
+	entries    map[string]dnsEntry
+	mu         sync.Mutex
+}
+
+func (c *dnsCache) lookup(ctx context.Context, host string) ([]string, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[host]
+	c.mu.Unlock()
+	if ok && c.now().Before(entry.expiresAt) {
+		atomic.AddInt64(&stats.dnsCacheHits, 1)
+		return entry.addrs, nil
+	}
+
+	atomic.AddInt64(&stats.dnsLookups, 1)
+	addrs, err := c.lookupHost(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.entries[host] = dnsEntry{addrs: addrs, expiresAt: c.now().Add(dnsCacheTTL)}
+	c.mu.Unlock()
+	return addrs, nil
+}
+
+func (c *dnsCache) forget(host string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, host)
+}
+
+func isIPv4(addr string) bool {
+	ip := net.ParseIP(addr)
+	return ip != nil && ip.To4() != nil
+}
+
+// cachingDialer dials the addresses of a host resolved with the DNS cache
+type cachingDialer struct {
+	dialer *net.Dialer
+	cache  *dnsCache
+}
+
+func newCachingDialer(dialer *net.Dialer, lookupHost func(ctx context.Context, host string) ([]string, error)) *cachingDialer {
+	return &cachingDialer{
+		dialer: dialer,
+		cache: &dnsCache{
+			lookupHost: lookupHost,
+			now:        time.Now,
+			entries:    map[string]dnsEntry{},
+		},
+	}
+}
+
+// DialContext connects to the first reachable address of the host of addr. Like net.Dialer, the addresses of the
+// family of the first one are tried in order and, after the fallback delay, they race the addresses of the other family.
+// The host is resolved again on the next dial if none of its addresses are reachable
+func (d *cachingDialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil || net.ParseIP(host) != nil {
+		return d.dialer.DialContext(ctx, network, addr)
+	}
+
+	addrs, err := d.cache.lookup(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	addrs = filterAddrs(network, addrs)
+	if len(addrs) == 0 {
+		return nil, &net.DNSError{Err: "no suitable address found", Name: host}
+	}
+
+	if d.dialer.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d.dialer.Timeout)
+		defer cancel()
+	}
+	conn, err := d.dialParallel(ctx, network, port, addrs)
+	if err != nil {
+		d.cache.forget(host)
+		return nil, err
+	}
+	return conn, nil
+}
+
+// dialParallel races the addresses of the family of the first address against the addresses of the other family,
+// which start after the fallback delay. It returns the first connection established
+func (d *cachingDialer) dialParallel(ctx context.Context, network, port string, addrs []string) (net.Conn, error) {
+	primaries, fallbacks := partitionAddrs(addrs)
+	fallbackDelay := d.dialer.FallbackDelay
+	if fallbackDelay == 0 {
+		fallbackDelay = defaultFallbackDelay
+	}
+	if len(fallbacks) == 0 || fallbackDelay < 0 {
+		return d.dialSerial(ctx, network, port, addrs)
+	}
+
+	type dialResult struct {
+		conn    net.Conn
+		err     error
+		primary bool
+	}
+	results := make(chan dialResult)
+	returned := make(chan struct{})
+	defer close(returned)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	race := func(addrs []string, primary bool) {
+		conn, err := d.dialSerial(ctx, network, port, addrs)
+		select {
+		case results <- dialResult{conn: conn, err: err, primary: primary}:
+		case <-returned:
+			if conn != nil {
+				conn.Close()
+			}
+		}
+	}
+	go race(primaries, true)
+
+	fallbackTimer := time.NewTimer(fallbackDelay)
+	defer fallbackTimer.Stop()
+
+	var primaryErr error
+	pending := 2
+	for {
+		select {
+		case <-fallbackTimer.C:
+			go race(fallbacks, false)
+		case res := <-results:
+			if res.err == nil {
+				return res.conn, nil
+			}
+			pending--
+			if res.primary {
+				primaryErr = res.err
+				// the fallbacks don't have to wait for the delay once the primaries have failed
+				if fallbackTimer.Stop() {
+					fallbackTimer.Reset(0)
+				}
+			}
+			if pending == 0 {
+				if primaryErr != nil {
+					return nil, primaryErr
+				}
+				return nil, res.err
+			}
+		}
+	}
+}
+
+// dialSerial connects to the addresses in order. Each address gets a part of the remaining time of the dial,
+// so an unreachable address doesn't consume the whole timeout
+func (d *cachingDialer) dialSerial(ctx context.Context, network, port string, addrs []string) (net.Conn, error) {
+	var lastErr error
+	for i, a := range addrs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		dialCtx, cancel := ctx, context.CancelFunc(func() {})
+		if deadline, ok := ctx.Deadline(); ok {
+			dialCtx, cancel = context.WithDeadline(ctx, partialDeadline(time.Now(), deadline, len(addrs)-i))
+		}
+		conn, err := d.dialer.DialContext(dialCtx, network, net.JoinHostPort(a, port))
+		cancel()
+		if err == nil {
+			return conn, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// partialDeadline returns the deadline of one of the remaining addresses of a dial
+func partialDeadline(now, deadline time.Time, remaining int) time.Time {
+	timeRemaining := deadline.Sub(now)
+	timeout := timeRemaining / time.Duration(remaining)
+	if timeout < minDialTimeout {
+		if timeRemaining < minDialTimeout {
+			timeout = timeRemaining
+		} else {
+			timeout = minDialTimeout
+		}
+	}
+	return now.Add(timeout)
+}
+
+// partitionAddrs splits the addresses into the ones of the family of the first address and the rest, keeping their order
+func partitionAddrs(addrs []string) (primaries, fallbacks []string) {
+	for _, a := range addrs {
+		if isIPv4(a) == isIPv4(addrs[0]) {
+			primaries = append(primaries, a)
+		} else {
+			fallbacks = append(fallbacks, a)
+		}
+	}
+	return primaries, fallbacks
+}
+
+// filterAddrs returns the addresses that can be dialed in the network, like 'tcp4' or 'tcp6'
+func filterAddrs(network string, addrs []string) []string {
+	if !strings.HasSuffix(network, "4") && !strings.HasSuffix(network, "6") {
+		return addrs
+	}
+	result := []string{}
+	for _, a := range addrs {
+		if isIPv4(a) == strings.HasSuffix(network, "4") {
+			result = append(result, a)
+		}
+	}
+	return result
+}
@@ -0,0 +1,128 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDNSCacheLookup(t *testing.T) {
+	lookups := 0
+	now := time.Now()
+	cache := &dnsCache{
+		lookupHost: func(_ context.Context, _ string) ([]string, error) {
+			lookups++
+			return []string{"2001:db8::1", "192.0.2.1"}, nil
+		},
+		now:     func() time.Time { return now },
+		entries: map[string]dnsEntry{},
+	}
+
+	addrs, err := cache.lookup(context.Background(), "okteto.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2001:db8::1", "192.0.2.1"}, addrs)
+
+	_, err = cache.lookup(context.Background(), "okteto.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, 1, lookups)
+
+	now = now.Add(dnsCacheTTL)
+	_, err = cache.lookup(context.Background(), "okteto.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, 2, lookups)
+
+	cache.forget("okteto.example.com")
+	_, err = cache.lookup(context.Background(), "okteto.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, 3, lookups)
+}
+
+func TestDNSCacheLookupError(t *testing.T) {
+	cache := &dnsCache{
+		lookupHost: func(_ context.Context, _ string) ([]string, error) {
+			return nil, assert.AnError
+		},
+		now:     time.Now,
+		entries: map[string]dnsEntry{},
+	}
+	_, err := cache.lookup(context.Background(), "okteto.example.com")
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Empty(t, cache.entries)
+}
+
+func TestCachingDialer(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	_, port, err := net.SplitHostPort(l.Addr().String())
+	require.NoError(t, err)
+
+	d := newCachingDialer(&net.Dialer{Timeout: time.Second}, func(_ context.Context, host string) ([]string, error) {
+		if host == "okteto.example.com" {
+			return []string{"127.0.0.1"}, nil
+		}
+		return nil, errors.New("no such host")
+	})
+
+	conn, err := d.DialContext(context.Background(), "tcp", net.JoinHostPort("okteto.example.com", port))
+	require.NoError(t, err)
+	conn.Close()
+
+	conn, err = d.DialContext(context.Background(), "tcp", l.Addr().String())
+	require.NoError(t, err)
+	conn.Close()
+
+	_, err = d.DialContext(context.Background(), "tcp", net.JoinHostPort("unknown.example.com", port))
+	assert.Error(t, err)
+}
+
+func TestCachingDialerFallback(t *testing.T) {
+	l, err := net.Listen("tcp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	_, port, err := net.SplitHostPort(l.Addr().String())
+	require.NoError(t, err)
+
+	d := newCachingDialer(&net.Dialer{Timeout: time.Second, FallbackDelay: time.Minute}, func(_ context.Context, _ string) ([]string, error) {
+		return []string{"::1", "127.0.0.1"}, nil
+	})
+
+	conn, err := d.DialContext(context.Background(), "tcp", net.JoinHostPort("okteto.example.com", port))
+	require.NoError(t, err)
+	conn.Close()
+
+	_, err = d.DialContext(context.Background(), "tcp6", net.JoinHostPort("okteto.example.com", port))
+	assert.Error(t, err)
+}
+
+func TestPartialDeadline(t *testing.T) {
+	now := time.Now()
+	assert.Equal(t, now.Add(10*time.Second), partialDeadline(now, now.Add(30*time.Second), 3))
+	assert.Equal(t, now.Add(minDialTimeout), partialDeadline(now, now.Add(5*time.Second), 5))
+	assert.Equal(t, now.Add(time.Second), partialDeadline(now, now.Add(time.Second), 5))
+}
+
+func TestFilterAddrs(t *testing.T) {
+	addrs := []string{"2001:db8::1", "192.0.2.1"}
+	assert.Equal(t, addrs, filterAddrs("tcp", addrs))
+	assert.Equal(t, []string{"192.0.2.1"}, filterAddrs("tcp4", addrs))
+	assert.Equal(t, []string{"2001:db8::1"}, filterAddrs("tcp6", addrs))
+}
@@ -0,0 +1,133 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptrace"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// sharedTransports are the transports of the clients, by TLS configuration.
+// Clients with the same configuration share the pool of connections instead of dialing again on every call
+var (
+	sharedTransports   = map[string]http.RoundTripper{}
+	sharedTransportsMu sync.Mutex
+)
+
+// stats are the connection metrics of the shared transports
+var stats transportStats
+
+type transportStats struct {
+	requests          int64
+	newConnections    int64
+	reusedConnections int64
+	dnsLookups        int64
+	dnsCacheHits      int64
+}
+
+// Stats are the connection metrics of the shared transports since the start of the process
+type Stats struct {
+	Requests          int64
+	NewConnections    int64
+	ReusedConnections int64
+	DNSLookups        int64
+	DNSCacheHits      int64
+}
+
+func (s Stats) String() string {
+	return fmt.Sprintf("%d requests, %d new connections, %d reused connections, %d DNS lookups, %d DNS cache hits", s.Requests, s.NewConnections, s.ReusedConnections, s.DNSLookups, s.DNSCacheHits)
+}
+
+// GetStats returns the connection metrics of the shared transports
+func GetStats() Stats {
+	return Stats{
+		Requests:          atomic.LoadInt64(&stats.requests),
+		NewConnections:    atomic.LoadInt64(&stats.newConnections),
+		ReusedConnections: atomic.LoadInt64(&stats.reusedConnections),
+		DNSLookups:        atomic.LoadInt64(&stats.dnsLookups),
+		DNSCacheHits:      atomic.LoadInt64(&stats.dnsCacheHits),
+	}
+}
+
+// metricsTransport counts the requests and whether their connections were reused
+type metricsTransport struct {
+	base http.RoundTripper
+}
+
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt64(&stats.requests, 1)
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			if info.Reused {
+				atomic.AddInt64(&stats.reusedConnections, 1)
+				return
+			}
+			atomic.AddInt64(&stats.newConnections, 1)
+		},
+	}
+	return t.base.RoundTrip(req.WithContext(httptrace.WithClientTrace(req.Context(), trace)))
+}
+
+func getSharedTransport(key string, build func() *http.Transport) http.RoundTripper {
+	sharedTransportsMu.Lock()
+	defer sharedTransportsMu.Unlock()
+	if t, ok := sharedTransports[key]; ok {
+		return t
+	}
+	t := &metricsTransport{base: build()}
+	sharedTransports[key] = t
+	return t
+}
+
+// SharedStrictSSLTransport returns the StrictSSLTransport shared by the clients with the same options.
+// Options with a TLSDial function can't be compared, a new transport is returned for them
+func SharedStrictSSLTransport(opts *SSLTransportOption) http.RoundTripper {
+	if opts == nil {
+		opts = &SSLTransportOption{}
+	}
+	if opts.TLSDial != nil {
+		return StrictSSLTransport(opts)
+	}
+	return getSharedTransport(getStrictSSLTransportKey(opts), func() *http.Transport {
+		return StrictSSLTransport(opts)
+	})
+}
+
+// SharedDefaultTransport returns the DefaultTransport shared by the clients without TLS options
+func SharedDefaultTransport() http.RoundTripper {
+	return getSharedTransport("default", DefaultTransport)
+}
+
+// SharedInsecureTransport returns the InsecureTransport shared by all the clients
+func SharedInsecureTransport() http.RoundTripper {
+	return getSharedTransport("insecure", InsecureTransport)
+}
+
+func getStrictSSLTransportKey(opts *SSLTransportOption) string {
+	certs := []string{}
+	for _, cert := range opts.Certs {
+		if cert == nil {
+			continue
+		}
+		sum := sha256.Sum256(cert.Raw)
+		certs = append(certs, hex.EncodeToString(sum[:]))
+	}
+	return strings.Join([]string{"strict", opts.ServerName, strings.Join(opts.URLsToIntercept, ","), strings.Join(certs, ",")}, "|")
+}
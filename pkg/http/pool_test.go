@@ -0,0 +1,79 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSharedStrictSSLTransport(t *testing.T) {
+	opts := &SSLTransportOption{ServerName: "1.2.3.4:443", URLsToIntercept: []string{"https://okteto.example.com"}}
+	transport := SharedStrictSSLTransport(opts)
+	assert.Same(t, transport, SharedStrictSSLTransport(&SSLTransportOption{ServerName: "1.2.3.4:443", URLsToIntercept: []string{"https://okteto.example.com"}}))
+	assert.NotSame(t, transport, SharedStrictSSLTransport(&SSLTransportOption{}))
+
+	withCert := SharedStrictSSLTransport(&SSLTransportOption{Certs: []*x509.Certificate{{Raw: []byte("cert")}}})
+	assert.NotSame(t, withCert, SharedStrictSSLTransport(&SSLTransportOption{Certs: []*x509.Certificate{{Raw: []byte("other")}}}))
+
+	withDial := SharedStrictSSLTransport(&SSLTransportOption{TLSDial: DefaultTLSDial})
+	_, ok := withDial.(*http.Transport)
+	assert.True(t, ok)
+
+	assert.Same(t, SharedInsecureTransport(), SharedInsecureTransport())
+}
+
+func TestStrictSSLTransportDoesNotChangeTheSharedConfig(t *testing.T) {
+	var config *tls.Config
+	transport := StrictSSLTransport(&SSLTransportOption{
+		ServerName:      "1.2.3.4:443",
+		URLsToIntercept: []string{"https://okteto.example.com"},
+		TLSDial: func(_ string, _ string, c *tls.Config) (TLSConn, error) {
+			config = c
+			return nil, assert.AnError
+		},
+	})
+
+	_, err := transport.DialTLSContext(context.Background(), "tcp", "okteto.example.com:443")
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Equal(t, "okteto.example.com", config.ServerName)
+	assert.Empty(t, transport.TLSClientConfig.ServerName)
+}
+
+func TestMetricsTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	before := GetStats()
+	client := &http.Client{Transport: &metricsTransport{base: DefaultTransport()}}
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	after := GetStats()
+
+	assert.Equal(t, int64(3), after.Requests-before.Requests)
+	assert.Equal(t, int64(1), after.NewConnections-before.NewConnections)
+	assert.Equal(t, int64(2), after.ReusedConnections-before.ReusedConnections)
+}
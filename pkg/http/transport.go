@@ -24,7 +24,8 @@ import (
 )
 
 // DefaultTransport returns an *http.Transport lifted from http.DefaultTransport
-// Main differences vs empty &http.Client{} are http2 preference, min TLS version set to 1.2, timeouts and connection limits.
+// Main differences vs empty &http.Client{} are http2 preference, min TLS version set to 1.2, timeouts, connection limits and DNS caching.
+// Up to 20 idle connections per host are kept alive to be reused by the sequential calls to the same API or registry.
 //
 // dev: reason why not doing pointer cloning is because not safe after init():
 // - https://github.com/golang/go/issues/26013
@@ -32,14 +33,11 @@ import (
 // - https://github.com/kubernetes-retired/go-open-service-broker-client/pull/133
 func DefaultTransport() *http.Transport {
 	return &http.Transport{
-		Proxy: http.ProxyFromEnvironment,
-		DialContext: (&net.Dialer{
-			Timeout:   30 * time.Second,
-			KeepAlive: 30 * time.Second,
-			DualStack: true,
-		}).DialContext,
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           defaultDialer.DialContext,
 		ForceAttemptHTTP2:     true,
 		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   20,
 		IdleConnTimeout:       90 * time.Second,
 		TLSHandshakeTimeout:   10 * time.Second,
 		ExpectContinueTimeout: 1 * time.Second,
@@ -56,6 +54,24 @@ func DefaultTLSDial(network string, addr string, config *tls.Config) (TLSConn, e
 	return tls.Dial(network, addr, config)
 }
 
+// cachedTLSDial is the TLSDialFunc of the transports without TLSDial option. It resolves the host with the DNS cache
+func cachedTLSDial(ctx context.Context) TLSDialFunc {
+	return func(network string, addr string, config *tls.Config) (TLSConn, error) {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		conn, err := defaultDialer.DialContext(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+		if config.ServerName == "" {
+			config.ServerName = host
+		}
+		return tls.Client(conn, config), nil
+	}
+}
+
 type TLSConn interface {
 	net.Conn
 	Handshake() error
@@ -87,20 +103,22 @@ func StrictSSLTransport(opts *SSLTransportOption) *http.Transport {
 	transport.TLSClientConfig.RootCAs = pool
 
 	transport.DialTLSContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		// the config is cloned because the transport can be shared by several clients dialing at the same time
+		config := transport.TLSClientConfig.Clone()
 		if toIntercept.ShouldInterceptAddr(addr) && opts.ServerName != "" {
 			host, _, err := net.SplitHostPort(addr)
 			if err != nil {
 				return nil, err
 			}
-			transport.TLSClientConfig.ServerName = host
+			config.ServerName = host
 			addr = opts.ServerName
 		}
 
 		tlsDial := opts.TLSDial
 		if tlsDial == nil {
-			tlsDial = DefaultTLSDial
+			tlsDial = cachedTLSDial(ctx)
 		}
-		tlsConn, err := tlsDial("tcp", addr, transport.TLSClientConfig)
+		tlsConn, err := tlsDial("tcp", addr, config)
 		if err != nil {
 			return nil, fmt.Errorf("tcp dial failed for %s: %w", addr, err)
 		}
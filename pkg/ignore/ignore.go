@@ -0,0 +1,226 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ignore parses the project-level .oktetoignore file, shared by the file synchronization,
+// the build context hashing, the build context upload and the remote deploy context upload
+package ignore
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/moby/patternmatcher"
+	"github.com/spf13/afero"
+)
+
+// Filename is the name of the project-level ignore file
+const Filename = ".oktetoignore"
+
+// Rule is a pattern of the ignore file. Patterns follow the .dockerignore syntax and are relative to the project root
+type Rule struct {
+	matcher *patternmatcher.PatternMatcher
+	Pattern string
+	Line    int
+	Negated bool
+}
+
+// Ignore represents the rules of an ignore file
+type Ignore struct {
+	matcher *patternmatcher.PatternMatcher
+	Path    string
+	Rules   []Rule
+}
+
+// NewFromFile reads the ignore file at path. A missing file returns an Ignore without rules
+func NewFromFile(path string) (*Ignore, error) {
+	return NewFromFileWithFilesystem(path, afero.NewOsFs())
+}
+
+// NewFromFileWithFilesystem reads the ignore file at path using the given filesystem
+func NewFromFileWithFilesystem(path string, fs afero.Fs) (*Ignore, error) {
+	content, err := afero.ReadFile(fs, path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &Ignore{Path: path}, nil
+		}
+		return nil, fmt.Errorf("failed to read '%s': %w", path, err)
+	}
+	i, err := Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid '%s': %w", path, err)
+	}
+	i.Path = path
+	return i, nil
+}
+
+// Parse parses the content of an ignore file. Empty lines and lines starting with '#' are skipped
+func Parse(content []byte) (*Ignore, error) {
+	i := &Ignore{}
+	patterns := []string{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	line := 0
+	for scanner.Scan() {
+		line++
+		pattern := strings.TrimSpace(scanner.Text())
+		if pattern == "" || strings.HasPrefix(pattern, "#") {
+			continue
+		}
+
+		rule := Rule{Line: line}
+		if strings.HasPrefix(pattern, "!") {
+			rule.Negated = true
+			pattern = strings.TrimSpace(strings.TrimPrefix(pattern, "!"))
+		}
+		pattern = normalizePattern(pattern)
+		if pattern == "" {
+			continue
+		}
+		rule.Pattern = pattern
+
+		matcher, err := patternmatcher.New([]string{pattern})
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		rule.matcher = matcher
+		i.Rules = append(i.Rules, rule)
+		patterns = append(patterns, rule.String())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	matcher, err := patternmatcher.New(patterns)
+	if err != nil {
+		return nil, err
+	}
+	i.matcher = matcher
+	return i, nil
+}
+
+// normalizePattern removes the leading slash and cleans the pattern, as .dockerignore does
+func normalizePattern(pattern string) string {
+	pattern = filepath.ToSlash(pattern)
+	pattern = strings.TrimPrefix(pattern, "/")
+	if pattern == "" {
+		return ""
+	}
+	pattern = path.Clean(pattern)
+	if pattern == "." {
+		return ""
+	}
+	return pattern
+}
+
+// String returns the rule as written in a .dockerignore file
+func (r Rule) String() string {
+	if r.Negated {
+		return "!" + r.Pattern
+	}
+	return r.Pattern
+}
+
+// Patterns returns the rules in the .dockerignore syntax
+func (i *Ignore) Patterns() []string {
+	result := make([]string, 0, len(i.Rules))
+	for _, r := range i.Rules {
+		result = append(result, r.String())
+	}
+	return result
+}
+
+// Ignores returns if the file, relative to the project root, is excluded by the rules
+func (i *Ignore) Ignores(file string) (bool, error) {
+	if i == nil || i.matcher == nil || len(i.Rules) == 0 {
+		return false, nil
+	}
+	return i.matcher.MatchesOrParentMatches(filepath.FromSlash(normalizePattern(file)))
+}
+
+// Explain returns the last rule matching the file, which decides if it's excluded. It returns nil if no rule matches
+func (i *Ignore) Explain(file string) (*Rule, error) {
+	if i == nil {
+		return nil, nil
+	}
+	file = filepath.FromSlash(normalizePattern(file))
+	var result *Rule
+	for idx := range i.Rules {
+		matches, err := i.Rules[idx].matcher.MatchesOrParentMatches(file)
+		if err != nil {
+			return nil, err
+		}
+		if matches {
+			result = &i.Rules[idx]
+		}
+	}
+	return result, nil
+}
+
+// SyncthingPatterns returns the rules as .stignore patterns of a synchronized folder.
+// folder is the path of the synchronized folder relative to the project root.
+// Rules of files outside the folder are skipped
+func (i *Ignore) SyncthingPatterns(folder string) []string {
+	if i == nil {
+		return nil
+	}
+	result := []string{}
+	for _, r := range i.rulesRelativeTo(folder) {
+		pattern := r.Pattern
+		if !strings.HasPrefix(pattern, "**/") {
+			pattern = "/" + pattern
+		}
+		if r.Negated {
+			pattern = "!" + pattern
+		}
+		result = append(result, pattern)
+	}
+	return result
+}
+
+// ContextPatterns returns the rules as .dockerignore patterns of a build context.
+// folder is the path of the build context relative to the project root.
+// Rules of files outside the folder are skipped
+func (i *Ignore) ContextPatterns(folder string) []string {
+	if i == nil {
+		return nil
+	}
+	result := []string{}
+	for _, r := range i.rulesRelativeTo(folder) {
+		result = append(result, r.String())
+	}
+	return result
+}
+
+// rulesRelativeTo returns the rules that apply to the files of a folder, with patterns relative to the folder
+func (i *Ignore) rulesRelativeTo(folder string) []Rule {
+	folder = normalizePattern(folder)
+	result := []Rule{}
+	for _, r := range i.Rules {
+		switch {
+		case strings.HasPrefix(r.Pattern, "**/"):
+			// it applies to any directory
+		case folder == "":
+		case strings.HasPrefix(r.Pattern, folder+"/"):
+			r.Pattern = strings.TrimPrefix(r.Pattern, folder+"/")
+		default:
+			continue
+		}
+		result = append(result, r)
+	}
+	return result
+}
@@ -0,0 +1,121 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ignore
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testContent = `# dependencies
+node_modules
+/dist/
+*.log
+!important.log
+**/.cache
+api/tmp
+`
+
+func TestParse(t *testing.T) {
+	i, err := Parse([]byte(testContent))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"node_modules", "dist", "*.log", "!important.log", "**/.cache", "api/tmp"}, i.Patterns())
+	assert.Equal(t, 2, i.Rules[0].Line)
+	assert.True(t, i.Rules[3].Negated)
+}
+
+func TestIgnores(t *testing.T) {
+	i, err := Parse([]byte(testContent))
+	require.NoError(t, err)
+
+	tests := []struct {
+		file     string
+		expected bool
+	}{
+		{file: "node_modules/react/index.js", expected: true},
+		{file: "dist", expected: true},
+		{file: "app.log", expected: true},
+		{file: "important.log", expected: false},
+		{file: "api/.cache/file", expected: true},
+		{file: "api/tmp/file", expected: true},
+		{file: "api/main.go", expected: false},
+		{file: "./src/node_modules", expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			result, err := i.Ignores(tt.file)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestExplain(t *testing.T) {
+	i, err := Parse([]byte(testContent))
+	require.NoError(t, err)
+
+	rule, err := i.Explain("node_modules/react")
+	require.NoError(t, err)
+	require.NotNil(t, rule)
+	assert.Equal(t, "node_modules", rule.Pattern)
+	assert.Equal(t, 2, rule.Line)
+
+	rule, err = i.Explain("important.log")
+	require.NoError(t, err)
+	require.NotNil(t, rule)
+	assert.True(t, rule.Negated)
+
+	rule, err = i.Explain("main.go")
+	require.NoError(t, err)
+	assert.Nil(t, rule)
+}
+
+func TestSyncthingPatterns(t *testing.T) {
+	i, err := Parse([]byte(testContent))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"/node_modules", "/dist", "/*.log", "!/important.log", "**/.cache", "/api/tmp"}, i.SyncthingPatterns("."))
+	assert.Equal(t, []string{"**/.cache", "/tmp"}, i.SyncthingPatterns("api"))
+}
+
+func TestContextPatterns(t *testing.T) {
+	i, err := Parse([]byte(testContent))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"node_modules", "dist", "*.log", "!important.log", "**/.cache", "api/tmp"}, i.ContextPatterns("."))
+	assert.Equal(t, []string{"**/.cache", "tmp"}, i.ContextPatterns("api"))
+	assert.Equal(t, []string{"**/.cache"}, i.ContextPatterns("web"))
+
+	var empty *Ignore
+	assert.Nil(t, empty.ContextPatterns("."))
+}
+
+func TestNewFromFileWithFilesystem(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	i, err := NewFromFileWithFilesystem(Filename, fs)
+	require.NoError(t, err)
+	ignored, err := i.Ignores("node_modules")
+	require.NoError(t, err)
+	assert.False(t, ignored)
+
+	require.NoError(t, afero.WriteFile(fs, Filename, []byte(testContent), 0600))
+	i, err = NewFromFileWithFilesystem(Filename, fs)
+	require.NoError(t, err)
+	assert.Equal(t, Filename, i.Path)
+	assert.Len(t, i.Rules, 6)
+}
@@ -15,6 +15,7 @@ package apps
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -24,6 +25,7 @@ import (
 	"github.com/okteto/okteto/pkg/k8s/statefulsets"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/retry"
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
 )
@@ -68,44 +70,48 @@ func SetLastBuiltAnnotation(app App) {
 
 // GetRunningPodInLoop returns the dev pod for an app and loops until it success
 func GetRunningPodInLoop(ctx context.Context, dev *model.Dev, app App, c kubernetes.Interface) (*apiv1.Pod, error) {
-	ticker := time.NewTicker(500 * time.Millisecond)
-	start := time.Now()
-	to := start.Add(dev.Timeout.Resources)
+	policy := retry.Policy{
+		Name:            "running dev pod",
+		InitialInterval: 500 * time.Millisecond,
+		Budget:          dev.Timeout.Resources,
+		MinAttempts:     12,
+	}
 
-	for retries := 0; ; retries++ {
-		err := app.Refresh(ctx, c)
-		if err != nil {
-			return nil, err
+	var pod *apiv1.Pod
+	retries := 0
+	err := retry.Poll(ctx, policy, func(ctx context.Context) (bool, error) {
+		if err := app.Refresh(ctx, c); err != nil {
+			return false, err
 		}
-		if err = app.CheckConditionErrors(dev); err != nil {
-			return nil, err
+		if err := app.CheckConditionErrors(dev); err != nil {
+			return false, err
 		}
 
-		pod, err := app.GetRunningPod(ctx, c)
-
+		var err error
+		pod, err = app.GetRunningPod(ctx, c)
 		if err == nil {
-			return pod, nil
+			return true, nil
 		}
-
 		if !oktetoErrors.IsNotFound(err) {
-			return nil, err
+			return false, err
 		}
 
-		if time.Now().After(to) && retries > 10 {
-			return nil, oktetoErrors.ErrKubernetesLongTimeToCreateDevContainer
+		if retries%5 == 0 {
+			oktetoLog.Info("development container is not ready yet, will retry")
 		}
-
-		select {
-		case <-ticker.C:
-			if retries%5 == 0 {
-				oktetoLog.Info("development container is not ready yet, will retry")
-			}
-			continue
-		case <-ctx.Done():
+		retries++
+		return false, nil
+	})
+	switch {
+	case errors.Is(err, retry.ErrBudgetExhausted):
+		return nil, oktetoErrors.ErrKubernetesLongTimeToCreateDevContainer
+	case err != nil:
+		if ctx.Err() != nil {
 			oktetoLog.Debug("call to apps.GetRunningPodInLoop cancelled")
-			return nil, ctx.Err()
 		}
+		return nil, err
 	}
+	return pod, nil
 }
 
 // GetTranslations fills all the deployments pointed by a development container
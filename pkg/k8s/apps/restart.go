@@ -0,0 +1,89 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/wait"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// restartedAtAnnotation is the pod template annotation used to trigger a rolling restart, the same used by kubectl
+	restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+	rolloutPollInterval = 1 * time.Second
+)
+
+// Restart triggers a rolling restart of the pods of an app
+func Restart(ctx context.Context, app App, c kubernetes.Interface, now time.Time) error {
+	app.TemplateObjectMeta().Annotations[restartedAtAnnotation] = now.UTC().Format(time.RFC3339)
+	return app.Deploy(ctx, c)
+}
+
+// WaitForRollout waits until all the replicas of an app are running its latest revision
+func WaitForRollout(ctx context.Context, dev *model.Dev, app App, c kubernetes.Interface, timeout time.Duration) error {
+	err := wait.WaitFor(ctx, func(ctx context.Context) (bool, wait.Status, error) {
+		if err := app.Refresh(ctx, c); err != nil {
+			return false, wait.Status{}, err
+		}
+		if err := app.CheckConditionErrors(dev); err != nil {
+			return false, wait.Status{}, err
+		}
+		return isRolloutComplete(app), getRolloutStatus(app), nil
+	}, wait.Options{Interval: rolloutPollInterval, Timeout: timeout, Immediate: true})
+	if errors.Is(err, wait.ErrTimeout) {
+		return fmt.Errorf("%s '%s' didn't finish its rollout: %w", app.Kind(), app.ObjectMeta().Name, err)
+	}
+	return err
+}
+
+// getRolloutStatus returns the number of replicas of an app running its latest revision
+func getRolloutStatus(app App) wait.Status {
+	status := wait.Status{Message: "replicas updated", Total: int(app.Replicas())}
+	switch a := app.(type) {
+	case *DeploymentApp:
+		status.Completed = int(a.d.Status.UpdatedReplicas)
+	case *StatefulSetApp:
+		status.Completed = int(a.sfs.Status.UpdatedReplicas)
+	}
+	return status
+}
+
+func isRolloutComplete(app App) bool {
+	switch a := app.(type) {
+	case *DeploymentApp:
+		if a.d.Generation > a.d.Status.ObservedGeneration {
+			return false
+		}
+		replicas := a.Replicas()
+		return a.d.Status.UpdatedReplicas == replicas && a.d.Status.Replicas == replicas && a.d.Status.AvailableReplicas == replicas
+	case *StatefulSetApp:
+		if a.sfs.Generation > a.sfs.Status.ObservedGeneration {
+			return false
+		}
+		replicas := a.Replicas()
+		if a.sfs.Status.UpdatedReplicas != replicas || a.sfs.Status.ReadyReplicas != replicas {
+			return false
+		}
+		return a.sfs.Status.UpdateRevision == "" || a.sfs.Status.CurrentRevision == a.sfs.Status.UpdateRevision
+	default:
+		return true
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
+)
+
+func TestRestart(t *testing.T) {
+	ctx := context.Background()
+	d := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "api",
+			Namespace: "test",
+			UID:       "1",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: pointer.Int32(1),
+		},
+	}
+	c := fake.NewSimpleClientset(d)
+	now := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	app := &DeploymentApp{kind: okteto.Deployment, d: d.DeepCopy()}
+	require.NoError(t, Restart(ctx, app, c, now))
+
+	result, err := c.AppsV1().Deployments("test").Get(ctx, "api", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "2023-05-01T10:00:00Z", result.Spec.Template.Annotations[restartedAtAnnotation])
+}
+
+func TestIsRolloutComplete(t *testing.T) {
+	tests := []struct {
+		app      App
+		name     string
+		expected bool
+	}{
+		{
+			name: "deployment updated",
+			app: &DeploymentApp{d: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Spec:       appsv1.DeploymentSpec{Replicas: pointer.Int32(2)},
+				Status:     appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2},
+			}},
+			expected: true,
+		},
+		{
+			name: "deployment not observed",
+			app: &DeploymentApp{d: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Generation: 3},
+				Spec:       appsv1.DeploymentSpec{Replicas: pointer.Int32(2)},
+				Status:     appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2},
+			}},
+		},
+		{
+			name: "deployment with old replicas",
+			app: &DeploymentApp{d: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Spec:       appsv1.DeploymentSpec{Replicas: pointer.Int32(2)},
+				Status:     appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 1, AvailableReplicas: 2},
+			}},
+		},
+		{
+			name: "statefulset updated",
+			app: &StatefulSetApp{sfs: &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Spec:       appsv1.StatefulSetSpec{Replicas: pointer.Int32(1)},
+				Status:     appsv1.StatefulSetStatus{ObservedGeneration: 2, UpdatedReplicas: 1, ReadyReplicas: 1, CurrentRevision: "b", UpdateRevision: "b"},
+			}},
+			expected: true,
+		},
+		{
+			name: "statefulset updating",
+			app: &StatefulSetApp{sfs: &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Spec:       appsv1.StatefulSetSpec{Replicas: pointer.Int32(1)},
+				Status:     appsv1.StatefulSetStatus{ObservedGeneration: 2, UpdatedReplicas: 1, ReadyReplicas: 1, CurrentRevision: "a", UpdateRevision: "b"},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isRolloutComplete(tt.app))
+		})
+	}
+}
+
+func TestWaitForRolloutTimeout(t *testing.T) {
+	ctx := context.Background()
+	d := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "api",
+			Namespace:  "test",
+			Generation: 2,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: pointer.Int32(1),
+		},
+	}
+	c := fake.NewSimpleClientset(d)
+	app := &DeploymentApp{kind: okteto.Deployment, d: d.DeepCopy()}
+
+	err := WaitForRollout(ctx, &model.Dev{Name: "api"}, app, c, 10*time.Millisecond)
+	assert.ErrorContains(t, err, "didn't finish its rollout")
+}
@@ -543,6 +543,40 @@ func TranslateOktetoInitFromImageContainer(spec *apiv1.PodSpec, rule *model.Tran
 		command = fmt.Sprintf("%s && ( [ \"$(ls -A /init-volume/%d)\" ] || cp -R %s/. /init-volume/%d || true)", command, iVolume, mounPath, iVolume)
 		iVolume++
 	}
+	if rule.ResetShellHistory {
+		c.VolumeMounts = append(
+			c.VolumeMounts,
+			apiv1.VolumeMount{
+				Name:      rule.GetShellHistoryVolumeName(),
+				MountPath: "/init-history",
+				SubPath:   model.ShellHistorySubPath,
+			},
+		)
+		command = fmt.Sprintf("%s && find /init-history -mindepth 1 -delete", command)
+	}
+	dotfilesMounted := false
+	for _, v := range rule.Volumes {
+		if !strings.HasPrefix(v.SubPath, model.ShellDotfilesSubPath+"/") {
+			continue
+		}
+		if !dotfilesMounted {
+			c.VolumeMounts = append(
+				c.VolumeMounts,
+				apiv1.VolumeMount{
+					Name:      v.Name,
+					MountPath: "/init-dotfiles",
+					SubPath:   model.ShellDotfilesSubPath,
+				},
+			)
+			if rule.ResetShellHistory {
+				command = fmt.Sprintf("%s && find /init-dotfiles -mindepth 1 -delete", command)
+			}
+			dotfilesMounted = true
+		}
+		// dotfiles are initialized from the image, or as empty files so the subpath isn't created as a folder
+		target := path.Join("/init-dotfiles", strings.TrimPrefix(v.SubPath, model.ShellDotfilesSubPath+"/"))
+		command = fmt.Sprintf("%s && ( [ -e %s ] || ( mkdir -p %s && ( cp -a %s %s || touch %s ) ) )", command, target, path.Dir(target), v.MountPath, target, target)
+	}
 	command = fmt.Sprintf("%s && echo initialization completed.", command)
 
 	shOpts := "-c"
@@ -2148,3 +2148,58 @@ func Test_getDevName(t *testing.T) {
 		})
 	}
 }
+
+func TestTranslateOktetoInitFromImageContainerResetShellHistory(t *testing.T) {
+	rule := &model.TranslationRule{
+		PersistentVolume:  true,
+		ResetShellHistory: true,
+		Image:             "okteto/dev",
+		Volumes: []model.VolumeMount{
+			{
+				Name:      "dev-okteto",
+				MountPath: model.ShellHistoryMountPath,
+				SubPath:   model.ShellHistorySubPath,
+			},
+		},
+	}
+	spec := &apiv1.PodSpec{}
+	TranslateOktetoInitFromImageContainer(spec, rule)
+
+	require.Len(t, spec.InitContainers, 1)
+	c := spec.InitContainers[0]
+	assert.Equal(t, []apiv1.VolumeMount{
+		{
+			Name:      "dev-okteto",
+			MountPath: "/init-history",
+			SubPath:   model.ShellHistorySubPath,
+		},
+	}, c.VolumeMounts)
+	assert.Equal(t, "echo initializing... && find /init-history -mindepth 1 -delete && echo initialization completed.", c.Command[2])
+}
+
+func TestTranslateOktetoInitFromImageContainerDotfiles(t *testing.T) {
+	rule := &model.TranslationRule{
+		PersistentVolume: true,
+		Image:            "okteto/dev",
+		Volumes: []model.VolumeMount{
+			{
+				Name:      "dev-okteto",
+				MountPath: "/root/.gitconfig",
+				SubPath:   "okteto-dotfiles/root/.gitconfig",
+			},
+		},
+	}
+	spec := &apiv1.PodSpec{}
+	TranslateOktetoInitFromImageContainer(spec, rule)
+
+	require.Len(t, spec.InitContainers, 1)
+	c := spec.InitContainers[0]
+	assert.Equal(t, []apiv1.VolumeMount{
+		{
+			Name:      "dev-okteto",
+			MountPath: "/init-dotfiles",
+			SubPath:   model.ShellDotfilesSubPath,
+		},
+	}, c.VolumeMounts)
+	assert.Equal(t, "echo initializing... && ( [ -e /init-dotfiles/root/.gitconfig ] || ( mkdir -p /init-dotfiles/root && ( cp -a /root/.gitconfig /init-dotfiles/root/.gitconfig || touch /init-dotfiles/root/.gitconfig ) ) ) && echo initialization completed.", c.Command[2])
+}
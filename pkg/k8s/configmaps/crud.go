@@ -19,6 +19,7 @@ import (
 	"time"
 
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/labels"
 	"github.com/okteto/okteto/pkg/model"
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -76,6 +77,7 @@ func Destroy(ctx context.Context, name, namespace string, c kubernetes.Interface
 
 // Create creates a configmap in a space
 func Create(ctx context.Context, cf *apiv1.ConfigMap, namespace string, c kubernetes.Interface) error {
+	labels.ApplyPolicy(cf)
 	_, err := c.CoreV1().ConfigMaps(namespace).Create(ctx, cf, metav1.CreateOptions{})
 	if err != nil {
 		return err
@@ -84,6 +86,7 @@ func Create(ctx context.Context, cf *apiv1.ConfigMap, namespace string, c kubern
 }
 
 func update(ctx context.Context, cf *apiv1.ConfigMap, namespace string, c kubernetes.Interface) error {
+	labels.ApplyPolicy(cf)
 	_, err := c.CoreV1().ConfigMaps(namespace).Update(ctx, cf, metav1.UpdateOptions{})
 	if err != nil {
 		return err
@@ -207,6 +207,8 @@ func getResourceLimitError(errorMessage string, dev *model.Dev) error {
 // Deploy creates or updates a deployment
 func Deploy(ctx context.Context, d *appsv1.Deployment, c kubernetes.Interface) (*appsv1.Deployment, error) {
 	d.ResourceVersion = ""
+	labels.ApplyPolicy(d)
+	labels.ApplyPolicy(&d.Spec.Template)
 	result, err := c.AppsV1().Deployments(d.Namespace).Update(ctx, d, metav1.UpdateOptions{})
 	if err == nil {
 		return result, nil
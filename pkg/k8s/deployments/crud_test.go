@@ -19,7 +19,10 @@ import (
 	"testing"
 
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/labels"
 	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -479,3 +482,28 @@ func TestCheckConditionErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestDeployAppliesLabelsPolicy(t *testing.T) {
+	labels.AddPolicy(map[string]string{"cost-center": "dev"}, map[string]string{"owner": "team-a"})
+	defer labels.ResetPolicy()
+
+	ctx := context.Background()
+	c := fake.NewSimpleClientset()
+	d := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fake",
+			Namespace: "test",
+			Labels:    map[string]string{"cost-center": "prod"},
+		},
+	}
+
+	_, err := Deploy(ctx, d, c)
+	require.NoError(t, err)
+
+	result, err := c.AppsV1().Deployments("test").Get(ctx, "fake", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"cost-center": "prod"}, result.Labels)
+	assert.Equal(t, map[string]string{"owner": "team-a"}, result.Annotations)
+	assert.Equal(t, map[string]string{"cost-center": "dev"}, result.Spec.Template.Labels)
+	assert.Equal(t, map[string]string{"owner": "team-a"}, result.Spec.Template.Annotations)
+}
@@ -21,6 +21,7 @@ import (
 	"io"
 	"net/http"
 	"runtime"
+	"sync"
 	"time"
 
 	"github.com/okteto/okteto/pkg/k8s/labels"
@@ -44,9 +45,13 @@ type PortForwardManager struct {
 	services       map[string]struct{}
 	activeDev      *active
 	activeServices map[string]*active
+	activeProfiles []*active
 	restConfig     *rest.Config
 	iface          string
 	namespace      string
+	devPod         string
+	devNamespace   string
+	mu             sync.Mutex
 	stopped        bool
 }
 
@@ -94,6 +99,8 @@ func NewPortForwardManager(ctx context.Context, iface string, restConfig *rest.C
 
 // Add initializes a port forward
 func (p *PortForwardManager) Add(f forward.Forward) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	if _, ok := p.ports[f.Local]; ok {
 		return fmt.Errorf("port %d is listed multiple times, please check your configuration", f.Local)
 	}
@@ -132,32 +139,37 @@ func (*PortForwardManager) AddReverse(_ model.Reverse) error {
 
 // Start starts all the port forwarders to the development container
 func (p *PortForwardManager) Start(devPod, namespace string) error {
-	p.stopped = false
+	p.devPod = devPod
+	p.devNamespace = namespace
 	a, devPF, err := p.buildForwarderToDevPod(namespace, devPod)
 	if err != nil {
 		return fmt.Errorf("failed to k8s forward to development container: %w", err)
 	}
 
+	p.mu.Lock()
+	p.stopped = false
 	p.activeDev = a
+	p.activeServices = map[string]*active{}
+	p.mu.Unlock()
+	ready := a.readyChan
 	go func() {
 		err := devPF.ForwardPorts()
 		if err != nil {
 			oktetoLog.Infof("k8s forwarding to dev pod finished with errors: %s", err)
+			a.err = err
 			if !errors.Is(err, portforward.ErrLostConnectionToPod) {
-				p.activeDev.closeReady()
+				a.closeReady()
 			}
-			p.activeDev.err = err
 		}
 	}()
 
-	p.activeServices = map[string]*active{}
 	for svc := range p.services {
 		go p.forwardService(p.ctx, namespace, svc)
 	}
 
-	<-p.activeDev.readyChan
+	<-ready
 
-	if err := p.activeDev.error(); err != nil {
+	if err := a.error(); err != nil {
 		return err
 	}
 
@@ -165,8 +177,114 @@ func (p *PortForwardManager) Start(devPod, namespace string) error {
 	return nil
 }
 
+// StartForwards adds and starts new port forwards once the manager is running
+func (p *PortForwardManager) StartForwards(forwards []forward.Forward) error {
+	if !p.isRunning() {
+		return fmt.Errorf("k8s port forwards are not running")
+	}
+
+	devPorts := []string{}
+	added := []forward.Forward{}
+	newServices := map[string]map[int]forward.Forward{}
+	for _, f := range forwards {
+		if err := p.Add(f); err != nil {
+			p.remove(added)
+			return err
+		}
+		added = append(added, f)
+		if !f.Service {
+			devPorts = append(devPorts, fmt.Sprintf("%d:%d", f.Local, f.Remote))
+			continue
+		}
+		if _, ok := newServices[f.ServiceName]; !ok {
+			newServices[f.ServiceName] = map[int]forward.Forward{}
+		}
+		newServices[f.ServiceName][f.Local] = f
+	}
+
+	if len(devPorts) > 0 {
+		if err := p.forwardDevPorts(devPorts); err != nil {
+			// the ports are released so the forwards can be started again
+			p.remove(added)
+			return err
+		}
+	}
+
+	for svc, ports := range newServices {
+		go p.forwardServicePorts(p.ctx, p.devNamespace, svc, ports)
+	}
+	return nil
+}
+
+// forwardDevPorts starts an additional port forward to the development container
+func (p *PortForwardManager) forwardDevPorts(devPorts []string) error {
+	a, devPF, err := p.buildForwarder(p.devNamespace, p.devPod, devPorts)
+	if err != nil {
+		return fmt.Errorf("failed to k8s forward to development container: %w", err)
+	}
+	p.mu.Lock()
+	if p.stopped {
+		p.mu.Unlock()
+		return fmt.Errorf("k8s port forwards are not running")
+	}
+	p.activeProfiles = append(p.activeProfiles, a)
+	p.mu.Unlock()
+	ready := a.readyChan
+	go func() {
+		if err := devPF.ForwardPorts(); err != nil {
+			oktetoLog.Infof("k8s forwarding to dev pod finished with errors: %s", err)
+			a.err = err
+			if !errors.Is(err, portforward.ErrLostConnectionToPod) {
+				a.closeReady()
+			}
+		}
+	}()
+
+	<-ready
+	return a.error()
+}
+
+// remove deletes port forwards that failed to start
+func (p *PortForwardManager) remove(forwards []forward.Forward) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, f := range forwards {
+		delete(p.ports, f.Local)
+	}
+	for _, f := range forwards {
+		if !f.Service {
+			continue
+		}
+		inUse := false
+		for _, existing := range p.ports {
+			if existing.Service && existing.ServiceName == f.ServiceName {
+				inUse = true
+				break
+			}
+		}
+		if !inUse {
+			delete(p.services, f.ServiceName)
+		}
+	}
+}
+
+// isRunning returns if the forwards to the development container are started and not stopped yet
+func (p *PortForwardManager) isRunning() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.activeDev != nil && !p.stopped
+}
+
+func (p *PortForwardManager) isStopped() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.stopped
+}
+
 // Stop stops all the port forwarders
 func (p *PortForwardManager) Stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	p.stopped = true
 	p.activeDev.stop()
 
@@ -174,7 +292,12 @@ func (p *PortForwardManager) Stop() {
 		a.stop()
 	}
 
+	for _, a := range p.activeProfiles {
+		a.stop()
+	}
+
 	p.activeServices = nil
+	p.activeProfiles = nil
 	p.activeDev = nil
 	oktetoLog.Infof("stopped k8s forwarder")
 }
@@ -227,7 +350,7 @@ func (p *PortForwardManager) buildForwarder(namespace, pod string, ports []strin
 	return a, pf, nil
 }
 
-func (p *PortForwardManager) buildForwarderToService(ctx context.Context, namespace, service string) (*active, *portforward.PortForwarder, error) {
+func (p *PortForwardManager) buildForwarderToService(ctx context.Context, namespace, service string, forwards map[int]forward.Forward) (*active, *portforward.PortForwarder, error) {
 	svc, err := services.Get(ctx, service, namespace, p.client)
 	if err != nil {
 		return nil, nil, err
@@ -242,7 +365,7 @@ func (p *PortForwardManager) buildForwarderToService(ctx context.Context, namesp
 		return nil, nil, fmt.Errorf("failed to get pod mapped to service/%s: %w", svc.GetName(), err)
 	}
 
-	ports := getServicePorts(svc.GetName(), p.ports)
+	ports := getServicePorts(svc.GetName(), forwards)
 	return p.buildForwarder(pod.GetNamespace(), pod.GetName(), ports)
 }
 
@@ -278,15 +401,25 @@ func (p *PortForwardManager) buildDialer(namespace, pod string) (httpstream.Dial
 }
 
 func (p *PortForwardManager) forwardService(ctx context.Context, namespace, service string) {
+	p.mu.Lock()
+	forwards := make(map[int]forward.Forward, len(p.ports))
+	for local, f := range p.ports {
+		forwards[local] = f
+	}
+	p.mu.Unlock()
+	p.forwardServicePorts(ctx, namespace, service, forwards)
+}
+
+func (p *PortForwardManager) forwardServicePorts(ctx context.Context, namespace, service string, forwards map[int]forward.Forward) {
 	t := time.NewTicker(3 * time.Second)
 
 	for {
-		if p.stopped {
+		if p.isStopped() {
 			return
 		}
 
 		oktetoLog.Infof("k8s forwarding ports for service/%s", service)
-		a, pf, err := p.buildForwarderToService(ctx, namespace, service)
+		a, pf, err := p.buildForwarderToService(ctx, namespace, service, forwards)
 		if err != nil {
 			oktetoLog.Infof("failed to k8s forward ports to service/%s: %s", service, err)
 			<-t.C
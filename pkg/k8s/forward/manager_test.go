@@ -65,6 +65,13 @@ func TestStop(t *testing.T) {
 		},
 	}
 
+	pf.activeProfiles = []*active{
+		{
+			readyChan: make(chan struct{}, 1),
+			stopChan:  make(chan struct{}, 1),
+		},
+	}
+
 	pf.Stop()
 	if !pf.stopped {
 		t.Error("pf wasn't marked as stopped")
@@ -77,6 +84,21 @@ func TestStop(t *testing.T) {
 	if pf.activeServices != nil {
 		t.Error("pf.activeServices wasn't to nil")
 	}
+
+	if pf.activeProfiles != nil {
+		t.Error("pf.activeProfiles wasn't to nil")
+	}
+}
+
+func TestStartForwardsNotRunning(t *testing.T) {
+	pf := NewPortForwardManager(context.Background(), model.Localhost, nil, nil, "")
+	if err := pf.StartForwards([]forward.Forward{{Local: 10130, Remote: 1013}}); err == nil {
+		t.Fatal("forwards started before the manager")
+	}
+
+	if len(pf.ports) != 0 {
+		t.Fatalf("expected 0 ports but got %d", len(pf.ports))
+	}
 }
 
 func Test_active_stop(t *testing.T) {
@@ -184,3 +206,24 @@ func Test_getServicePorts(t *testing.T) {
 		})
 	}
 }
+
+func TestRemove(t *testing.T) {
+	pf := NewPortForwardManager(context.Background(), model.Localhost, nil, nil, "")
+	pf.ports[10140] = forward.Forward{Local: 10140, Remote: 80, Service: true, ServiceName: "api"}
+	pf.ports[10141] = forward.Forward{Local: 10141, Remote: 81, Service: true, ServiceName: "api"}
+	pf.ports[10142] = forward.Forward{Local: 10142, Remote: 82, Service: true, ServiceName: "db"}
+	pf.services["api"] = struct{}{}
+	pf.services["db"] = struct{}{}
+
+	pf.remove([]forward.Forward{pf.ports[10141], pf.ports[10142]})
+
+	if len(pf.ports) != 1 {
+		t.Fatalf("expected 1 port but got %d", len(pf.ports))
+	}
+	if _, ok := pf.services["api"]; !ok {
+		t.Error("service 'api' is still forwarded")
+	}
+	if _, ok := pf.services["db"]; ok {
+		t.Error("service 'db' wasn't removed")
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/labels"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	networkingv1 "k8s.io/api/networking/v1"
 	networkingv1beta1 "k8s.io/api/networking/v1beta1"
@@ -75,9 +76,11 @@ func (iClient *Client) Get(ctx context.Context, name, namespace string) (metav1.
 
 func (iClient *Client) Create(ctx context.Context, i *Ingress) error {
 	if iClient.isV1 {
+		labels.ApplyPolicy(i.V1)
 		_, err := iClient.c.NetworkingV1().Ingresses(i.V1.Namespace).Create(ctx, i.V1, metav1.CreateOptions{})
 		return err
 	}
+	labels.ApplyPolicy(i.V1Beta1)
 	_, err := iClient.c.NetworkingV1beta1().Ingresses(i.V1Beta1.Namespace).Create(ctx, i.V1Beta1, metav1.CreateOptions{})
 	return err
 }
@@ -85,9 +88,11 @@ func (iClient *Client) Create(ctx context.Context, i *Ingress) error {
 // Update updates a statefulset
 func (iClient *Client) Update(ctx context.Context, i *Ingress) error {
 	if iClient.isV1 {
+		labels.ApplyPolicy(i.V1)
 		_, err := iClient.c.NetworkingV1().Ingresses(i.V1.Namespace).Update(ctx, i.V1, metav1.UpdateOptions{})
 		return err
 	}
+	labels.ApplyPolicy(i.V1Beta1)
 	_, err := iClient.c.NetworkingV1beta1().Ingresses(i.V1Beta1.Namespace).Update(ctx, i.V1Beta1, metav1.UpdateOptions{})
 	return err
 }
@@ -18,6 +18,7 @@ import (
 	"fmt"
 
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/labels"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -25,6 +26,7 @@ import (
 )
 
 func Deploy(ctx context.Context, i *networkingv1.Ingress, c kubernetes.Interface) error {
+	labels.ApplyPolicy(i)
 	old, err := Get(ctx, i.Name, i.Namespace, c)
 	if err != nil && !oktetoErrors.IsNotFound(err) {
 		return fmt.Errorf("error getting kubernetes ingress: %w", err)
@@ -41,12 +41,14 @@ func Get(kubeconfigPaths []string) *clientcmdapi.Config {
 	if err != nil {
 		log.Fatalf("error accessing your KUBECONFIG file '%v': %v", kubeconfigPaths, err)
 	}
+	recordSnapshots(kubeconfigPaths)
 	return mergedConfig
 }
 
-// Write stores a kubeconfig file
+// Write stores a kubeconfig file. The file is locked while it is written, and the changes made by other processes
+// since it was read are preserved
 func Write(cfg *clientcmdapi.Config, kubeconfigPath string) error {
-	return clientcmd.WriteToFile(*cfg, kubeconfigPath)
+	return write(cfg, kubeconfigPath)
 }
 
 // CurrentContext returns the name of the current context
@@ -0,0 +1,258 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	// lockSuffix is the suffix of the lock file of a kubeconfig file. It is the same used by kubectl
+	lockSuffix = ".lock"
+
+	lockRetryInterval = 100 * time.Millisecond
+	lockTimeout       = 10 * time.Second
+
+	// staleLockAge is the age of a lock file left by a process that didn't release it
+	staleLockAge = 1 * time.Minute
+)
+
+var (
+	// errLockTimeout is returned when the lock of a kubeconfig file can't be acquired
+	errLockTimeout = errors.New("timeout waiting for the kubeconfig lock")
+
+	// snapshots keeps the content of every kubeconfig file the last time it was read or written by this process
+	snapshots   = map[string]*clientcmdapi.Config{}
+	snapshotsMu sync.Mutex
+)
+
+// recordSnapshots stores the content of the kubeconfig files to detect the changes made by other processes before writing them
+func recordSnapshots(kubeconfigPaths []string) {
+	for _, path := range kubeconfigPaths {
+		cfg, err := clientcmd.LoadFromFile(path)
+		if err != nil {
+			continue
+		}
+		setSnapshot(path, cfg)
+	}
+}
+
+func getSnapshot(path string) *clientcmdapi.Config {
+	snapshotsMu.Lock()
+	defer snapshotsMu.Unlock()
+	return snapshots[path]
+}
+
+func setSnapshot(path string, cfg *clientcmdapi.Config) {
+	snapshotsMu.Lock()
+	defer snapshotsMu.Unlock()
+	snapshots[path] = cfg
+}
+
+// write stores cfg in the kubeconfig file holding its lock.
+// If the file was modified by another process since it was read, the changes of cfg are merged into the current content of the file
+func write(cfg *clientcmdapi.Config, kubeconfigPath string) error {
+	unlock, err := lock(kubeconfigPath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	result := cfg
+	if base := getSnapshot(kubeconfigPath); base != nil {
+		current, err := clientcmd.LoadFromFile(kubeconfigPath)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to read the kubeconfig file '%s': %w", kubeconfigPath, err)
+		}
+		if current != nil && !equal(base, current) {
+			oktetoLog.Infof("kubeconfig file '%s' was modified by another process, merging changes", kubeconfigPath)
+			var conflicts []string
+			result, conflicts = merge(base, cfg, current)
+			if len(conflicts) > 0 {
+				oktetoLog.Warning("The kubeconfig file '%s' was modified by another process. Okteto changes override the ones made to: %v", kubeconfigPath, conflicts)
+			}
+		}
+	}
+
+	content, err := clientcmd.Write(*result)
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomically(kubeconfigPath, content); err != nil {
+		return err
+	}
+
+	written, err := clientcmd.Load(content)
+	if err != nil {
+		return err
+	}
+	setSnapshot(kubeconfigPath, written)
+	return nil
+}
+
+// lock creates the lock file of a kubeconfig file and returns the function to release it
+func lock(kubeconfigPath string) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(kubeconfigPath), 0755); err != nil {
+		return nil, err
+	}
+	lockPath := kubeconfigPath + lockSuffix
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL, 0600)
+		if err == nil {
+			if err := f.Close(); err != nil {
+				oktetoLog.Infof("failed to close lock file '%s': %s", lockPath, err)
+			}
+			return func() {
+				if err := os.Remove(lockPath); err != nil {
+					oktetoLog.Infof("failed to remove lock file '%s': %s", lockPath, err)
+				}
+			}, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to lock the kubeconfig file '%s': %w", kubeconfigPath, err)
+		}
+
+		if info, err := os.Stat(lockPath); err == nil && time.Since(info.ModTime()) > staleLockAge {
+			oktetoLog.Infof("removing stale lock file '%s'", lockPath)
+			if err := os.Remove(lockPath); err != nil && !os.IsNotExist(err) {
+				return nil, fmt.Errorf("failed to remove stale lock file '%s': %w", lockPath, err)
+			}
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("%w: remove the file '%s' if no other process is updating the kubeconfig file", errLockTimeout, lockPath)
+		}
+		time.Sleep(lockRetryInterval)
+	}
+}
+
+// writeFileAtomically replaces the content of the file so readers never see a partial kubeconfig
+func writeFileAtomically(path string, content []byte) error {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := os.Remove(tmp.Name()); err != nil && !os.IsNotExist(err) {
+			oktetoLog.Infof("failed to remove temporary kubeconfig '%s': %s", tmp.Name(), err)
+		}
+	}()
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	// the new file keeps the mode and the owner of the kubeconfig it replaces
+	mode := os.FileMode(0600)
+	info, err := os.Stat(path)
+	if err == nil {
+		mode = info.Mode().Perm()
+		if err := chown(tmp.Name(), info); err != nil {
+			oktetoLog.Infof("failed to keep the owner of kubeconfig '%s': %s", path, err)
+		}
+	}
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// merge applies the changes of ours from base on top of theirs, and returns the entries changed by both sides
+func merge(base, ours, theirs *clientcmdapi.Config) (*clientcmdapi.Config, []string) {
+	result := theirs.DeepCopy()
+	conflicts := []string{}
+	conflicts = append(conflicts, mergeEntries("cluster", base.Clusters, ours.Clusters, theirs.Clusters, result.Clusters)...)
+	conflicts = append(conflicts, mergeEntries("user", base.AuthInfos, ours.AuthInfos, theirs.AuthInfos, result.AuthInfos)...)
+	conflicts = append(conflicts, mergeEntries("context", base.Contexts, ours.Contexts, theirs.Contexts, result.Contexts)...)
+	conflicts = append(conflicts, mergeEntries("extension", base.Extensions, ours.Extensions, theirs.Extensions, result.Extensions)...)
+
+	if ours.CurrentContext != base.CurrentContext {
+		if theirs.CurrentContext != base.CurrentContext && theirs.CurrentContext != ours.CurrentContext {
+			conflicts = append(conflicts, "current-context")
+		}
+		result.CurrentContext = ours.CurrentContext
+	}
+	if !equal(ours.Preferences, base.Preferences) {
+		if !equal(theirs.Preferences, base.Preferences) && !equal(theirs.Preferences, ours.Preferences) {
+			conflicts = append(conflicts, "preferences")
+		}
+		result.Preferences = ours.Preferences
+	}
+	sort.Strings(conflicts)
+	return result, conflicts
+}
+
+// mergeEntries sets in result the entries added, modified or deleted by ours from base
+func mergeEntries[T any](kind string, base, ours, theirs, result map[string]T) []string {
+	conflicts := []string{}
+	names := map[string]bool{}
+	for name := range base {
+		names[name] = true
+	}
+	for name := range ours {
+		names[name] = true
+	}
+	for name := range names {
+		b, inBase := base[name]
+		o, inOurs := ours[name]
+		if inBase == inOurs && equal(b, o) {
+			continue
+		}
+		t, inTheirs := theirs[name]
+		theirsChanged := inBase != inTheirs || !equal(b, t)
+		sameChange := inOurs == inTheirs && equal(o, t)
+		if theirsChanged && !sameChange {
+			conflicts = append(conflicts, fmt.Sprintf("%s '%s'", kind, name))
+		}
+		if inOurs {
+			result[name] = o
+		} else {
+			delete(result, name)
+		}
+	}
+	return conflicts
+}
+
+// equal compares the serialized values, ignoring fields that are not stored in the kubeconfig file
+func equal(a, b interface{}) bool {
+	aBytes, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	bBytes, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(aBytes, bBytes)
+}
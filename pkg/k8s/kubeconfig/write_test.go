@@ -0,0 +1,184 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeconfig
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func newTestConfig(current string, contexts ...string) *clientcmdapi.Config {
+	cfg := clientcmdapi.NewConfig()
+	cfg.CurrentContext = current
+	for _, name := range contexts {
+		cfg.Contexts[name] = &clientcmdapi.Context{Cluster: name, AuthInfo: name, Namespace: "default"}
+		cfg.Clusters[name] = &clientcmdapi.Cluster{Server: "https://" + name}
+		cfg.AuthInfos[name] = &clientcmdapi.AuthInfo{Token: name}
+	}
+	return cfg
+}
+
+func TestWriteMergesConcurrentChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, clientcmd.WriteToFile(*newTestConfig("a", "a", "b"), path))
+
+	ours := Get([]string{path})
+
+	// another process adds a context and modifies an existing one
+	theirs, err := clientcmd.LoadFromFile(path)
+	require.NoError(t, err)
+	theirs.Contexts["c"] = &clientcmdapi.Context{Cluster: "a", AuthInfo: "a"}
+	theirs.Contexts["b"].Namespace = "theirs"
+	require.NoError(t, clientcmd.WriteToFile(*theirs, path))
+
+	ours.Contexts["a"].Namespace = "ours"
+	delete(ours.Contexts, "b")
+	ours.Contexts["d"] = &clientcmdapi.Context{Cluster: "a", AuthInfo: "a"}
+	ours.CurrentContext = "d"
+	require.NoError(t, Write(ours, path))
+
+	result, err := clientcmd.LoadFromFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "ours", result.Contexts["a"].Namespace)
+	assert.NotContains(t, result.Contexts, "b")
+	assert.Contains(t, result.Contexts, "c")
+	assert.Contains(t, result.Contexts, "d")
+	assert.Equal(t, "d", result.CurrentContext)
+
+	_, err = os.Stat(path + lockSuffix)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestWriteWithoutConcurrentChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, clientcmd.WriteToFile(*newTestConfig("a", "a", "b"), path))
+
+	cfg := Get([]string{path})
+	delete(cfg.Contexts, "b")
+	require.NoError(t, Write(cfg, path))
+
+	result, err := clientcmd.LoadFromFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, result.Contexts, "b")
+}
+
+func TestWriteKeepsMode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file modes are not supported on windows")
+	}
+	path := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, clientcmd.WriteToFile(*newTestConfig("a", "a"), path))
+	require.NoError(t, os.Chmod(path, 0640))
+
+	require.NoError(t, Write(newTestConfig("a", "a", "b"), path))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0640), info.Mode().Perm())
+}
+
+func TestWriteNewFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "config")
+	require.NoError(t, Write(newTestConfig("a", "a"), path))
+
+	result, err := clientcmd.LoadFromFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "a", result.CurrentContext)
+}
+
+func Test_merge(t *testing.T) {
+	base := newTestConfig("a", "a", "b")
+
+	ours := base.DeepCopy()
+	ours.Contexts["a"].Namespace = "ours"
+	ours.Contexts["b"].Namespace = "ours"
+	ours.CurrentContext = "b"
+
+	theirs := base.DeepCopy()
+	theirs.Contexts["b"].Namespace = "theirs"
+	theirs.AuthInfos["a"].Token = "theirs"
+	theirs.CurrentContext = "c"
+	theirs.Contexts["c"] = &clientcmdapi.Context{}
+
+	result, conflicts := merge(base, ours, theirs)
+	assert.Equal(t, []string{"context 'b'", "current-context"}, conflicts)
+	assert.Equal(t, "ours", result.Contexts["a"].Namespace)
+	assert.Equal(t, "ours", result.Contexts["b"].Namespace)
+	assert.Equal(t, "theirs", result.AuthInfos["a"].Token)
+	assert.Contains(t, result.Contexts, "c")
+	assert.Equal(t, "b", result.CurrentContext)
+
+	// the same change on both sides is not a conflict
+	theirs = ours.DeepCopy()
+	_, conflicts = merge(base, ours, theirs)
+	assert.Empty(t, conflicts)
+}
+
+func Test_lock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+
+	unlock, err := lock(path)
+	require.NoError(t, err)
+	assert.FileExists(t, path+lockSuffix)
+	unlock()
+	assert.NoFileExists(t, path+lockSuffix)
+
+	// a stale lock is removed
+	require.NoError(t, os.WriteFile(path+lockSuffix, nil, 0600))
+	old := time.Now().Add(-2 * staleLockAge)
+	require.NoError(t, os.Chtimes(path+lockSuffix, old, old))
+	unlock, err = lock(path)
+	require.NoError(t, err)
+	unlock()
+}
+
+func Test_lockWaitsForRelease(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+
+	unlock, err := lock(path)
+	require.NoError(t, err)
+
+	acquired := make(chan error, 1)
+	go func() {
+		unlockSecond, err := lock(path)
+		if err == nil {
+			unlockSecond()
+		}
+		acquired <- err
+	}()
+
+	select {
+	case err := <-acquired:
+		t.Fatalf("lock acquired while held: %v", err)
+	case <-time.After(3 * lockRetryInterval):
+	}
+	unlock()
+
+	select {
+	case err := <-acquired:
+		assert.False(t, errors.Is(err, errLockTimeout))
+		assert.NoError(t, err)
+	case <-time.After(lockTimeout):
+		t.Fatal("lock not acquired after release")
+	}
+}
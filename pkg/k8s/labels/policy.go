@@ -0,0 +1,76 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package labels
+
+import (
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// policy holds the labels and annotations added to every object created by okteto,
+// so cost-allocation and policy engines can track the ownership of those objects
+var policy = struct {
+	labels      map[string]string
+	annotations map[string]string
+	sync.RWMutex
+}{
+	labels:      map[string]string{},
+	annotations: map[string]string{},
+}
+
+// AddPolicy adds labels and annotations to the ones applied to every object created by okteto.
+// Values added later override the previous ones, so the manifest policy must be added after the context one
+func AddPolicy(labels, annotations map[string]string) {
+	policy.Lock()
+	defer policy.Unlock()
+	for k, v := range labels {
+		policy.labels[k] = v
+	}
+	for k, v := range annotations {
+		policy.annotations[k] = v
+	}
+}
+
+// ResetPolicy removes the labels and annotations applied to every object created by okteto
+func ResetPolicy() {
+	policy.Lock()
+	defer policy.Unlock()
+	policy.labels = map[string]string{}
+	policy.annotations = map[string]string{}
+}
+
+// ApplyPolicy adds the policy labels and annotations to an object. Values already set in the object are kept
+func ApplyPolicy(o metav1.Object) {
+	policy.RLock()
+	defer policy.RUnlock()
+	if len(policy.labels) > 0 {
+		o.SetLabels(mergeWithoutOverride(o.GetLabels(), policy.labels))
+	}
+	if len(policy.annotations) > 0 {
+		o.SetAnnotations(mergeWithoutOverride(o.GetAnnotations(), policy.annotations))
+	}
+}
+
+func mergeWithoutOverride(current, values map[string]string) map[string]string {
+	if current == nil {
+		current = map[string]string{}
+	}
+	for k, v := range values {
+		if _, ok := current[k]; !ok {
+			current[k] = v
+		}
+	}
+	return current
+}
@@ -0,0 +1,43 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package labels
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestApplyPolicy(t *testing.T) {
+	t.Cleanup(ResetPolicy)
+	AddPolicy(map[string]string{"team": "context", "cost-center": "1"}, map[string]string{"owner": "context"})
+	AddPolicy(map[string]string{"team": "manifest"}, nil)
+
+	om := &metav1.ObjectMeta{
+		Labels: map[string]string{"cost-center": "okteto"},
+	}
+	ApplyPolicy(om)
+
+	assert.Equal(t, map[string]string{"team": "manifest", "cost-center": "okteto"}, om.Labels)
+	assert.Equal(t, map[string]string{"owner": "context"}, om.Annotations)
+}
+
+func TestApplyEmptyPolicy(t *testing.T) {
+	ResetPolicy()
+	om := &metav1.ObjectMeta{}
+	ApplyPolicy(om)
+	assert.Nil(t, om.Labels)
+	assert.Nil(t, om.Annotations)
+}
@@ -16,6 +16,7 @@ package namespaces
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 
@@ -67,6 +68,12 @@ type DeleteAllOptions struct {
 	IncludeVolumes bool
 }
 
+// Resource represents a resource of a namespace
+type Resource struct {
+	Kind string `json:"kind" yaml:"kind"`
+	Name string `json:"name" yaml:"name"`
+}
+
 // Namespaces struct to interact with namespaces in k8s
 type Namespaces struct {
 	dynClient  dynamic.Interface
@@ -113,15 +120,7 @@ func NewNamespace(dynClient dynamic.Interface, discClient discovery.DiscoveryInt
 
 // DestroyWithLabel deletes all resources within a namespace
 func (n *Namespaces) DestroyWithLabel(ctx context.Context, ns string, opts DeleteAllOptions) error {
-	listOptions := metav1.ListOptions{
-		LabelSelector: opts.LabelSelector,
-	}
-
-	trip, err := NewTrip(n.restConfig, &Options{
-		Namespace:   ns,
-		Parallelism: parallelism,
-		List:        listOptions,
-	})
+	trip, err := n.newLabelTrip(ns, opts)
 	if err != nil {
 		return err
 	}
@@ -147,13 +146,7 @@ func (n *Namespaces) DestroyWithLabel(ctx context.Context, ns string, opts Delet
 			return err
 		}
 		gvk := obj.GetObjectKind().GroupVersionKind()
-		if isStorage(gvk.Kind) && !opts.IncludeVolumes {
-			oktetoLog.Debugf("skipping deletion of '%s' '%s' because of volume flag", gvk.Kind, m.GetName())
-			return nil
-		}
-
-		if m.GetAnnotations()[resourcePolicyAnnotation] == keepPolicy {
-			oktetoLog.Debugf("skipping deletion of %s '%s' because of policy annotation", gvk.Kind, m.GetName())
+		if skipDeletion(gvk.Kind, m, opts) {
 			return nil
 		}
 
@@ -184,18 +177,96 @@ func (n *Namespaces) DestroyWithLabel(ctx context.Context, ns string, opts Delet
 	}))
 }
 
+// ListWithLabel returns the resources within a namespace that DestroyWithLabel would delete, sorted by kind and name
+func (n *Namespaces) ListWithLabel(ctx context.Context, ns string, opts DeleteAllOptions) ([]Resource, error) {
+	trip, err := n.newLabelTrip(ns, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// See DestroyWithLabel
+	prevLevel := logrus.GetLevel()
+	logrus.SetLevel(logrus.ErrorLevel)
+	defer func() {
+		logrus.SetLevel(prevLevel)
+	}()
+
+	result := []Resource{}
+	err = trip.Wander(ctx, TravelerFunc(func(obj runtime.Object) error {
+		m, err := meta.Accessor(obj)
+		if err != nil {
+			return err
+		}
+		kind := obj.GetObjectKind().GroupVersionKind().Kind
+		if skipDeletion(kind, m, opts) {
+			return nil
+		}
+		result = append(result, Resource{Kind: kind, Name: m.GetName()})
+		return nil
+	}))
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Kind != result[j].Kind {
+			return result[i].Kind < result[j].Kind
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+func (n *Namespaces) newLabelTrip(ns string, opts DeleteAllOptions) (*Trip, error) {
+	return NewTrip(n.restConfig, &Options{
+		Namespace:   ns,
+		Parallelism: parallelism,
+		List: metav1.ListOptions{
+			LabelSelector: opts.LabelSelector,
+		},
+	})
+}
+
+// skipDeletion returns if a resource must be kept because of the volume flag or its policy annotation
+func skipDeletion(kind string, m metav1.Object, opts DeleteAllOptions) bool {
+	if isStorage(kind) && !opts.IncludeVolumes {
+		oktetoLog.Debugf("skipping deletion of '%s' '%s' because of volume flag", kind, m.GetName())
+		return true
+	}
+
+	if m.GetAnnotations()[resourcePolicyAnnotation] == keepPolicy {
+		oktetoLog.Debugf("skipping deletion of %s '%s' because of policy annotation", kind, m.GetName())
+		return true
+	}
+	return false
+}
+
 // DestroySFSVolumes This function deletes volumes for any statefulset that matches with opts.LabelSelector but it doesn't have any
 // dev.okteto.com/deployed-by label. This is to avoid to left PVCs behind when everything deployed with okteto deploy
 // command is deleted
 func (n *Namespaces) DestroySFSVolumes(ctx context.Context, ns string, opts DeleteAllOptions) error {
+	names, err := n.ListSFSVolumes(ctx, ns, opts)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if err := volumes.DestroyWithoutTimeout(ctx, name, ns, n.k8sClient); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListSFSVolumes returns the names of the volumes that DestroySFSVolumes would delete
+func (n *Namespaces) ListSFSVolumes(ctx context.Context, ns string, opts DeleteAllOptions) ([]string, error) {
+	result := []string{}
 	if !opts.IncludeVolumes {
-		return nil
+		return result, nil
 	}
 	var pvcNames []string
 
 	ssList, err := statefulsets.List(ctx, ns, opts.LabelSelector, n.k8sClient)
 	if err != nil {
-		return fmt.Errorf("error getting statefulsets: %w", err)
+		return nil, fmt.Errorf("error getting statefulsets: %w", err)
 	}
 	for _, ss := range ssList {
 		for _, pvcTemplate := range ss.Spec.VolumeClaimTemplates {
@@ -204,7 +275,7 @@ func (n *Namespaces) DestroySFSVolumes(ctx context.Context, ns string, opts Dele
 	}
 
 	if len(pvcNames) == 0 {
-		return nil
+		return result, nil
 	}
 
 	// We only need to delete all the volumes without deployed-by label. The ones with the label will be deleted by
@@ -215,12 +286,12 @@ func (n *Namespaces) DestroySFSVolumes(ctx context.Context, ns string, opts Dele
 		nil,
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	deployedByNotExistSelector := labels.NewSelector().Add(*deployedByNotExist).String()
 	vList, err := volumes.List(ctx, ns, deployedByNotExistSelector, n.k8sClient)
 	if err != nil {
-		return fmt.Errorf("error getting volumes: %w", err)
+		return nil, fmt.Errorf("error getting volumes: %w", err)
 	}
 	for _, v := range vList {
 		if v.Annotations[resourcePolicyAnnotation] == keepPolicy {
@@ -229,15 +300,13 @@ func (n *Namespaces) DestroySFSVolumes(ctx context.Context, ns string, opts Dele
 		}
 		for _, pvcName := range pvcNames {
 			if strings.HasPrefix(v.Name, pvcName) {
-				if err := volumes.DestroyWithoutTimeout(ctx, v.Name, ns, n.k8sClient); err != nil {
-					return err
-				}
+				result = append(result, v.Name)
 				break
 			}
 		}
 	}
 
-	return nil
+	return result, nil
 }
 
 // Below functions were added to remove "github.com/ibuildthecloud/finalizers" as a dependency
@@ -348,3 +348,53 @@ func TestDestroySFSVolumesIfNeeded(t *testing.T) {
 		})
 	}
 }
+
+func TestListSFSVolumes(t *testing.T) {
+	ns := "test"
+	c := fake.NewSimpleClientset(
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "sfs-1",
+				Namespace: ns,
+				Labels: map[string]string{
+					model.DeployedByLabel: "test-app",
+				},
+			},
+			Spec: appsv1.StatefulSetSpec{
+				VolumeClaimTemplates: []apiv1.PersistentVolumeClaim{
+					{ObjectMeta: metav1.ObjectMeta{Name: "pvc"}},
+				},
+			},
+		},
+		&apiv1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "pvc-sfs-1-0", Namespace: ns},
+		},
+		&apiv1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "pvc-sfs-1-1",
+				Namespace:   ns,
+				Annotations: map[string]string{resourcePolicyAnnotation: keepPolicy},
+			},
+		},
+		&apiv1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "mongodb", Namespace: ns},
+		},
+	)
+	n := &Namespaces{k8sClient: c}
+	opts := DeleteAllOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", model.DeployedByLabel, "test-app"),
+	}
+
+	names, err := n.ListSFSVolumes(context.Background(), ns, opts)
+	assert.NoError(t, err)
+	assert.Empty(t, names)
+
+	opts.IncludeVolumes = true
+	names, err = n.ListSFSVolumes(context.Background(), ns, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"pvc-sfs-1-0"}, names)
+
+	pvcList, err := c.CoreV1().PersistentVolumeClaims(ns).List(context.Background(), metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, pvcList.Items, 3)
+}
@@ -0,0 +1,127 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rbac
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const wildcard = "*"
+
+// Permission represents the verbs required on a resource type
+type Permission struct {
+	// Group is the api group of the resource, empty for the core group
+	Group string
+	// Resource is the plural name of the resource type, optionally with a subresource like "pods/portforward"
+	Resource string
+	Verbs    []string
+}
+
+// Check returns an error if the current user lacks any of the permissions in the namespace.
+// The check is best-effort: if the rules of the user can't be reviewed, it doesn't fail
+func Check(ctx context.Context, c kubernetes.Interface, namespace string, permissions []Permission) error {
+	if namespace == "" || len(permissions) == 0 {
+		return nil
+	}
+	review := &authorizationv1.SelfSubjectRulesReview{
+		Spec: authorizationv1.SelfSubjectRulesReviewSpec{Namespace: namespace},
+	}
+	result, err := c.AuthorizationV1().SelfSubjectRulesReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		oktetoLog.Infof("failed to review the permissions in namespace '%s': %s", namespace, err)
+		return nil
+	}
+	status := result.Status
+	if len(status.ResourceRules) == 0 && len(status.NonResourceRules) == 0 {
+		// every authenticated user has some rules, an empty review means the authorizer doesn't support it
+		oktetoLog.Infof("the permissions in namespace '%s' could not be reviewed", namespace)
+		return nil
+	}
+
+	missing := getMissingPermissions(status.ResourceRules, permissions)
+	if len(missing) == 0 {
+		return nil
+	}
+	if status.Incomplete {
+		// other authorizers might grant the permissions not listed in the review
+		oktetoLog.Infof("the permissions review in namespace '%s' is incomplete, missing permissions ignored: %s", namespace, strings.Join(missing, ", "))
+		return nil
+	}
+	return oktetoErrors.UserError{
+		E:    fmt.Errorf("you lack permission to %s in namespace '%s'", joinMissing(missing), namespace),
+		Hint: "Ask your cluster administrator to grant you these permissions or use a namespace where you have them",
+	}
+}
+
+// getMissingPermissions returns the verbs and resources of the permissions not granted by the rules, like "create deployments"
+func getMissingPermissions(rules []authorizationv1.ResourceRule, permissions []Permission) []string {
+	result := []string{}
+	for _, p := range permissions {
+		for _, verb := range p.Verbs {
+			if !isAllowed(rules, p.Group, p.Resource, verb) {
+				result = append(result, fmt.Sprintf("%s %s", verb, p.Resource))
+			}
+		}
+	}
+	return result
+}
+
+// isAllowed returns if any of the rules grants the verb on every resource of the type
+func isAllowed(rules []authorizationv1.ResourceRule, group, resource, verb string) bool {
+	for _, rule := range rules {
+		if len(rule.ResourceNames) > 0 {
+			// rules restricted to some resource names don't grant access to the resource type
+			continue
+		}
+		if matches(rule.Verbs, verb) && matches(rule.APIGroups, group) && matchesResource(rule.Resources, resource) {
+			return true
+		}
+	}
+	return false
+}
+
+func matches(values []string, value string) bool {
+	for _, v := range values {
+		if v == wildcard || v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesResource(resources []string, resource string) bool {
+	if matches(resources, resource) {
+		return true
+	}
+	// "*/scale" grants the scale subresource of every resource
+	if _, subresource, ok := strings.Cut(resource, "/"); ok {
+		return matches(resources, fmt.Sprintf("%s/%s", wildcard, subresource))
+	}
+	return false
+}
+
+func joinMissing(missing []string) string {
+	if len(missing) == 1 {
+		return missing[0]
+	}
+	return fmt.Sprintf("%s and %s", strings.Join(missing[:len(missing)-1], ", "), missing[len(missing)-1])
+}
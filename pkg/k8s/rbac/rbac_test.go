@@ -0,0 +1,119 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rbac
+
+import (
+	"context"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8sTesting "k8s.io/client-go/testing"
+)
+
+var deployPermissions = []Permission{
+	{Resource: "configmaps", Verbs: []string{"get", "create"}},
+	{Group: "apps", Resource: "deployments", Verbs: []string{"get", "create"}},
+}
+
+func fakeClientWithReview(status authorizationv1.SubjectRulesReviewStatus, err error) *fake.Clientset {
+	c := fake.NewSimpleClientset()
+	c.Fake.PrependReactor("create", "selfsubjectrulesreviews", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		if err != nil {
+			return true, nil, err
+		}
+		return true, &authorizationv1.SelfSubjectRulesReview{Status: status}, nil
+	})
+	return c
+}
+
+func TestCheck(t *testing.T) {
+	readOnly := authorizationv1.SubjectRulesReviewStatus{
+		ResourceRules: []authorizationv1.ResourceRule{
+			{Verbs: []string{"get", "create"}, APIGroups: []string{""}, Resources: []string{"configmaps"}},
+			{Verbs: []string{"get", "list"}, APIGroups: []string{"apps"}, Resources: []string{"deployments"}},
+		},
+	}
+	tests := []struct {
+		err         error
+		name        string
+		expectedErr string
+		status      authorizationv1.SubjectRulesReviewStatus
+	}{
+		{
+			name: "all permissions granted",
+			status: authorizationv1.SubjectRulesReviewStatus{
+				ResourceRules: []authorizationv1.ResourceRule{
+					{Verbs: []string{"*"}, APIGroups: []string{"", "apps"}, Resources: []string{"*"}},
+				},
+			},
+		},
+		{
+			name:        "missing permission",
+			status:      readOnly,
+			expectedErr: "you lack permission to create deployments in namespace 'test'",
+		},
+		{
+			name: "incomplete review",
+			status: authorizationv1.SubjectRulesReviewStatus{
+				ResourceRules: readOnly.ResourceRules,
+				Incomplete:    true,
+			},
+		},
+		{
+			name: "empty review",
+		},
+		{
+			name: "review not allowed",
+			err:  assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fakeClientWithReview(tt.status, tt.err)
+			err := Check(context.Background(), c, "test", deployPermissions)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var uErr oktetoErrors.UserError
+			require.ErrorAs(t, err, &uErr)
+			assert.EqualError(t, uErr.E, tt.expectedErr)
+		})
+	}
+}
+
+func TestGetMissingPermissions(t *testing.T) {
+	rules := []authorizationv1.ResourceRule{
+		{Verbs: []string{"get"}, APIGroups: []string{""}, Resources: []string{"configmaps"}},
+		{Verbs: []string{"create"}, APIGroups: []string{""}, Resources: []string{"configmaps"}, ResourceNames: []string{"app"}},
+		{Verbs: []string{"create"}, APIGroups: []string{"*"}, Resources: []string{"*/portforward"}},
+	}
+	permissions := []Permission{
+		{Resource: "configmaps", Verbs: []string{"get", "create"}},
+		{Resource: "pods/portforward", Verbs: []string{"create"}},
+		{Group: "apps", Resource: "deployments", Verbs: []string{"update"}},
+	}
+	assert.Equal(t, []string{"create configmaps", "update deployments"}, getMissingPermissions(rules, permissions))
+}
+
+func TestJoinMissing(t *testing.T) {
+	assert.Equal(t, "create deployments", joinMissing([]string{"create deployments"}))
+	assert.Equal(t, "create deployments and update services", joinMissing([]string{"create deployments", "update services"}))
+	assert.Equal(t, "get pods, create deployments and update services", joinMissing([]string{"get pods", "create deployments", "update services"}))
+}
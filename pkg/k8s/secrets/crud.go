@@ -20,6 +20,7 @@ import (
 	"strings"
 
 	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/k8s/labels"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/syncthing"
@@ -96,6 +97,7 @@ func Create(ctx context.Context, dev *model.Dev, c kubernetes.Interface, s *sync
 
 	}
 
+	labels.ApplyPolicy(data)
 	if sct.Name == "" {
 		_, err := c.CoreV1().Secrets(dev.Namespace).Create(ctx, data, metav1.CreateOptions{})
 		if err != nil {
@@ -19,6 +19,7 @@ import (
 	"strings"
 
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/labels"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	apiv1 "k8s.io/api/core/v1"
@@ -34,6 +35,7 @@ func CreateDev(ctx context.Context, dev *model.Dev, c kubernetes.Interface) erro
 
 // Deploy creates/updates a k8s service
 func Deploy(ctx context.Context, s *apiv1.Service, c kubernetes.Interface) error {
+	labels.ApplyPolicy(s)
 	old, err := Get(ctx, s.Name, s.Namespace, c)
 	if err != nil && !oktetoErrors.IsNotFound(err) {
 		return fmt.Errorf("error getting kubernetes service: %w", err)
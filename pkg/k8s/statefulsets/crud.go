@@ -22,6 +22,7 @@ import (
 
 	"github.com/okteto/okteto/pkg/constants"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/labels"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	appsv1 "k8s.io/api/apps/v1"
@@ -87,6 +88,8 @@ func Sandbox(dev *model.Dev) *appsv1.StatefulSet {
 // Deploy creates or updates a statefulset
 func Deploy(ctx context.Context, sfs *appsv1.StatefulSet, c kubernetes.Interface) (*appsv1.StatefulSet, error) {
 	sfs.ResourceVersion = ""
+	labels.ApplyPolicy(sfs)
+	labels.ApplyPolicy(&sfs.Spec.Template)
 	result, err := c.AppsV1().StatefulSets(sfs.Namespace).Update(ctx, sfs, metav1.UpdateOptions{})
 	if err == nil {
 		return result, nil
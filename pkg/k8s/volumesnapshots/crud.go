@@ -0,0 +1,348 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package volumesnapshots
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	apiv1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	// Group is the API group of the volume snapshots
+	Group = "snapshot.storage.k8s.io"
+
+	// Kind is the kind of the volume snapshots
+	Kind = "VolumeSnapshot"
+
+	// ContentKind is the kind of the volume snapshot contents
+	ContentKind = "VolumeSnapshotContent"
+
+	// SnapshotLabel is the label with the name of the snapshot a volume snapshot belongs to
+	SnapshotLabel = "dev.okteto.com/volume-snapshot"
+
+	// pvcAnnotation stores the persistent volume claim a volume snapshot was taken from, to recreate it on restore
+	pvcAnnotation = "dev.okteto.com/persistent-volume-claim"
+)
+
+var (
+	// GVR is the group version resource of the volume snapshots
+	GVR = schema.GroupVersionResource{Group: Group, Version: "v1", Resource: "volumesnapshots"}
+
+	// ContentGVR is the group version resource of the volume snapshot contents
+	ContentGVR = schema.GroupVersionResource{Group: Group, Version: "v1", Resource: "volumesnapshotcontents"}
+
+	// ErrNotSupported is returned when the cluster doesn't have the volume snapshot CRDs
+	ErrNotSupported = fmt.Errorf("the cluster doesn't support volume snapshots")
+)
+
+// VolumeSnapshot represents a snapshot of a persistent volume claim of a dev environment
+type VolumeSnapshot struct {
+	// PVC is the persistent volume claim the snapshot was taken from
+	PVC        *apiv1.PersistentVolumeClaim
+	Name       string
+	Namespace  string
+	Snapshot   string
+	Error      string
+	ReadyToUse bool
+}
+
+// Create takes a snapshot of a persistent volume claim. The snapshot belongs to the group of volume snapshots with the given name
+func Create(ctx context.Context, snapshot string, pvc *apiv1.PersistentVolumeClaim, className string, c dynamic.Interface) (*VolumeSnapshot, error) {
+	pvcTemplate, err := json.Marshal(getPVCTemplate(pvc))
+	if err != nil {
+		return nil, err
+	}
+
+	spec := map[string]interface{}{
+		"source": map[string]interface{}{
+			"persistentVolumeClaimName": pvc.Name,
+		},
+	}
+	if className != "" {
+		spec["volumeSnapshotClassName"] = className
+	}
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": GVR.GroupVersion().String(),
+			"kind":       Kind,
+			"metadata": map[string]interface{}{
+				"name":      GetName(snapshot, pvc.Name),
+				"namespace": pvc.Namespace,
+				"labels": map[string]interface{}{
+					SnapshotLabel: snapshot,
+				},
+				"annotations": map[string]interface{}{
+					pvcAnnotation: string(pvcTemplate),
+				},
+			},
+			"spec": spec,
+		},
+	}
+
+	oktetoLog.Infof("creating volume snapshot of '%s'", pvc.Name)
+	created, err := c.Resource(GVR).Namespace(pvc.Namespace).Create(ctx, obj, metav1.CreateOptions{})
+	if err != nil {
+		return nil, translateErr(err)
+	}
+	return fromUnstructured(created)
+}
+
+// Clone copies a volume snapshot into another namespace. The copy is bound to a new volume snapshot content
+// that points to the same snapshot of the storage backend, which is retained when the copy is deleted
+func Clone(ctx context.Context, vs *VolumeSnapshot, namespace string, c dynamic.Interface) (*VolumeSnapshot, error) {
+	src, err := c.Resource(GVR).Namespace(vs.Namespace).Get(ctx, vs.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, translateErr(err)
+	}
+	contentName, _, err := unstructured.NestedString(src.Object, "status", "boundVolumeSnapshotContentName")
+	if err != nil || contentName == "" {
+		return nil, fmt.Errorf("the volume snapshot of '%s' is not bound to a volume snapshot content", vs.PVC.Name)
+	}
+	content, err := c.Resource(ContentGVR).Get(ctx, contentName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the volume snapshot content of '%s': %w", vs.PVC.Name, err)
+	}
+	handle, _, err := unstructured.NestedString(content.Object, "status", "snapshotHandle")
+	if err != nil || handle == "" {
+		return nil, fmt.Errorf("the volume snapshot content of '%s' has no snapshot handle", vs.PVC.Name)
+	}
+	driver, _, err := unstructured.NestedString(content.Object, "spec", "driver")
+	if err != nil {
+		return nil, fmt.Errorf("invalid volume snapshot content '%s': %w", contentName, err)
+	}
+	className, _, err := unstructured.NestedString(content.Object, "spec", "volumeSnapshotClassName")
+	if err != nil {
+		return nil, fmt.Errorf("invalid volume snapshot content '%s': %w", contentName, err)
+	}
+
+	cloneContentName := fmt.Sprintf("%s-%s", namespace, vs.Name)
+	contentSpec := map[string]interface{}{
+		"deletionPolicy": "Retain",
+		"driver":         driver,
+		"source": map[string]interface{}{
+			"snapshotHandle": handle,
+		},
+		"volumeSnapshotRef": map[string]interface{}{
+			"name":      vs.Name,
+			"namespace": namespace,
+		},
+	}
+	spec := map[string]interface{}{
+		"source": map[string]interface{}{
+			"volumeSnapshotContentName": cloneContentName,
+		},
+	}
+	if className != "" {
+		contentSpec["volumeSnapshotClassName"] = className
+		spec["volumeSnapshotClassName"] = className
+	}
+	cloneContent := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": ContentGVR.GroupVersion().String(),
+			"kind":       ContentKind,
+			"metadata": map[string]interface{}{
+				"name": cloneContentName,
+				"labels": map[string]interface{}{
+					SnapshotLabel: vs.Snapshot,
+				},
+			},
+			"spec": contentSpec,
+		},
+	}
+	clone := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": GVR.GroupVersion().String(),
+			"kind":       Kind,
+			"metadata": map[string]interface{}{
+				"name":      vs.Name,
+				"namespace": namespace,
+				"labels": map[string]interface{}{
+					SnapshotLabel: vs.Snapshot,
+				},
+				"annotations": map[string]interface{}{
+					pvcAnnotation: src.GetAnnotations()[pvcAnnotation],
+				},
+			},
+			"spec": spec,
+		},
+	}
+
+	oktetoLog.Infof("cloning volume snapshot '%s' into namespace '%s'", vs.Name, namespace)
+	if _, err := c.Resource(ContentGVR).Create(ctx, cloneContent, metav1.CreateOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to create the volume snapshot content of '%s': %w", vs.PVC.Name, err)
+	}
+	created, err := c.Resource(GVR).Namespace(namespace).Create(ctx, clone, metav1.CreateOptions{})
+	if err != nil {
+		return nil, translateErr(err)
+	}
+	return fromUnstructured(created)
+}
+
+// List returns the volume snapshots of a snapshot
+func List(ctx context.Context, snapshot, namespace string, c dynamic.Interface) ([]*VolumeSnapshot, error) {
+	list, err := c.Resource(GVR).Namespace(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", SnapshotLabel, snapshot),
+	})
+	if err != nil {
+		return nil, translateErr(err)
+	}
+	result := []*VolumeSnapshot{}
+	for i := range list.Items {
+		vs, err := fromUnstructured(&list.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, vs)
+	}
+	return result, nil
+}
+
+// WaitUntilReady waits until every volume snapshot of a snapshot is ready to be restored
+func WaitUntilReady(ctx context.Context, snapshot, namespace string, timeout time.Duration, c dynamic.Interface) error {
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+	to := time.NewTimer(timeout)
+	defer to.Stop()
+
+	for {
+		vsList, err := List(ctx, snapshot, namespace, c)
+		if err != nil {
+			return err
+		}
+		ready := true
+		for _, vs := range vsList {
+			if vs.Error != "" {
+				return fmt.Errorf("volume snapshot of '%s' failed: %s", vs.PVC.Name, vs.Error)
+			}
+			if !vs.ReadyToUse {
+				ready = false
+			}
+		}
+		if ready {
+			return nil
+		}
+
+		select {
+		case <-to.C:
+			return fmt.Errorf("volume snapshots of '%s' weren't ready after %s", snapshot, timeout.String())
+		case <-ticker.C:
+			continue
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Destroy deletes the volume snapshots of a snapshot
+func Destroy(ctx context.Context, snapshot, namespace string, c dynamic.Interface) error {
+	err := c.Resource(GVR).Namespace(namespace).DeleteCollection(ctx, metav1.DeleteOptions{}, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", SnapshotLabel, snapshot),
+	})
+	if err != nil {
+		return translateErr(err)
+	}
+	return nil
+}
+
+// GetName returns the name of the volume snapshot of a persistent volume claim
+func GetName(snapshot, pvcName string) string {
+	return fmt.Sprintf("%s-%s", snapshot, pvcName)
+}
+
+// RestorePVC returns the persistent volume claim that restores the content of a volume snapshot
+func (vs *VolumeSnapshot) RestorePVC() *apiv1.PersistentVolumeClaim {
+	apiGroup := Group
+	pvc := vs.PVC.DeepCopy()
+	pvc.Namespace = vs.Namespace
+	pvc.Spec.DataSource = &apiv1.TypedLocalObjectReference{
+		APIGroup: &apiGroup,
+		Kind:     Kind,
+		Name:     vs.Name,
+	}
+	return pvc
+}
+
+// getPVCTemplate returns the fields of a persistent volume claim needed to recreate it
+func getPVCTemplate(pvc *apiv1.PersistentVolumeClaim) *apiv1.PersistentVolumeClaim {
+	result := &apiv1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        pvc.Name,
+			Labels:      pvc.Labels,
+			Annotations: map[string]string{},
+		},
+		Spec: apiv1.PersistentVolumeClaimSpec{
+			AccessModes:      pvc.Spec.AccessModes,
+			Resources:        pvc.Spec.Resources,
+			StorageClassName: pvc.Spec.StorageClassName,
+			VolumeMode:       pvc.Spec.VolumeMode,
+		},
+	}
+	// the annotations set by the control plane bind the claim to its current volume
+	for k, v := range pvc.Annotations {
+		switch k {
+		case "pv.kubernetes.io/bind-completed", "pv.kubernetes.io/bound-by-controller",
+			"volume.beta.kubernetes.io/storage-provisioner", "volume.kubernetes.io/storage-provisioner",
+			"volume.kubernetes.io/selected-node", "kubectl.kubernetes.io/last-applied-configuration":
+			continue
+		}
+		result.Annotations[k] = v
+	}
+	return result
+}
+
+func fromUnstructured(obj *unstructured.Unstructured) (*VolumeSnapshot, error) {
+	vs := &VolumeSnapshot{
+		Name:      obj.GetName(),
+		Namespace: obj.GetNamespace(),
+		Snapshot:  obj.GetLabels()[SnapshotLabel],
+	}
+	pvc := &apiv1.PersistentVolumeClaim{}
+	if err := json.Unmarshal([]byte(obj.GetAnnotations()[pvcAnnotation]), pvc); err != nil {
+		return nil, fmt.Errorf("invalid volume snapshot '%s': %w", obj.GetName(), err)
+	}
+	vs.PVC = pvc
+
+	ready, _, err := unstructured.NestedBool(obj.Object, "status", "readyToUse")
+	if err != nil {
+		oktetoLog.Infof("invalid status of volume snapshot '%s': %s", obj.GetName(), err)
+	}
+	vs.ReadyToUse = ready
+	message, _, err := unstructured.NestedString(obj.Object, "status", "error", "message")
+	if err != nil {
+		oktetoLog.Infof("invalid status of volume snapshot '%s': %s", obj.GetName(), err)
+	}
+	vs.Error = message
+	return vs, nil
+}
+
+func translateErr(err error) error {
+	if k8sErrors.IsNotFound(err) {
+		return oktetoErrors.UserError{
+			E:    ErrNotSupported,
+			Hint: "Ask your administrator to install the volume snapshot CRDs and a CSI driver that supports them",
+		}
+	}
+	return err
+}
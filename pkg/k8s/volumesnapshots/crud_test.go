@@ -0,0 +1,182 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package volumesnapshots
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func newFakeDynamicClient(objects ...runtime.Object) *dynamicfake.FakeDynamicClient {
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		GVR:        "VolumeSnapshotList",
+		ContentGVR: "VolumeSnapshotContentList",
+	}, objects...)
+}
+
+func newPVC() *apiv1.PersistentVolumeClaim {
+	storageClass := "standard"
+	return &apiv1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "data-db-0",
+			Namespace: "test",
+			Labels:    map[string]string{"app": "db"},
+			Annotations: map[string]string{
+				"meta.helm.sh/release-name":       "movies",
+				"pv.kubernetes.io/bind-completed": "yes",
+			},
+		},
+		Spec: apiv1.PersistentVolumeClaimSpec{
+			AccessModes: []apiv1.PersistentVolumeAccessMode{apiv1.ReadWriteOnce},
+			Resources: apiv1.ResourceRequirements{
+				Requests: apiv1.ResourceList{apiv1.ResourceStorage: resource.MustParse("1Gi")},
+			},
+			StorageClassName: &storageClass,
+			VolumeName:       "pvc-1234",
+		},
+	}
+}
+
+func TestCreateAndList(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeDynamicClient()
+
+	vs, err := Create(ctx, "movies-1", newPVC(), "csi-snapclass", c)
+	require.NoError(t, err)
+	assert.Equal(t, "movies-1-data-db-0", vs.Name)
+	assert.Equal(t, "movies-1", vs.Snapshot)
+	assert.False(t, vs.ReadyToUse)
+
+	obj, err := c.Resource(GVR).Namespace("test").Get(ctx, "movies-1-data-db-0", metav1.GetOptions{})
+	require.NoError(t, err)
+	source, _, err := unstructured.NestedString(obj.Object, "spec", "source", "persistentVolumeClaimName")
+	require.NoError(t, err)
+	assert.Equal(t, "data-db-0", source)
+	class, _, err := unstructured.NestedString(obj.Object, "spec", "volumeSnapshotClassName")
+	require.NoError(t, err)
+	assert.Equal(t, "csi-snapclass", class)
+
+	vsList, err := List(ctx, "movies-1", "test", c)
+	require.NoError(t, err)
+	require.Len(t, vsList, 1)
+	assert.Equal(t, "data-db-0", vsList[0].PVC.Name)
+	assert.Equal(t, map[string]string{"meta.helm.sh/release-name": "movies"}, vsList[0].PVC.Annotations)
+	assert.Empty(t, vsList[0].PVC.Spec.VolumeName)
+
+	vsList, err = List(ctx, "other", "test", c)
+	require.NoError(t, err)
+	assert.Empty(t, vsList)
+}
+
+func TestWaitUntilReady(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeDynamicClient()
+	_, err := Create(ctx, "movies-1", newPVC(), "", c)
+	require.NoError(t, err)
+
+	obj, err := c.Resource(GVR).Namespace("test").Get(ctx, "movies-1-data-db-0", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NoError(t, unstructured.SetNestedField(obj.Object, true, "status", "readyToUse"))
+	_, err = c.Resource(GVR).Namespace("test").Update(ctx, obj, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.NoError(t, WaitUntilReady(ctx, "movies-1", "test", time.Second, c))
+
+	require.NoError(t, unstructured.SetNestedField(obj.Object, "snapshot failed", "status", "error", "message"))
+	_, err = c.Resource(GVR).Namespace("test").Update(ctx, obj, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.ErrorContains(t, WaitUntilReady(ctx, "movies-1", "test", time.Second, c), "snapshot failed")
+}
+
+func TestRestorePVC(t *testing.T) {
+	vs := &VolumeSnapshot{
+		Name:      "movies-1-data-db-0",
+		Namespace: "test",
+		PVC:       getPVCTemplate(newPVC()),
+	}
+	pvc := vs.RestorePVC()
+	assert.Equal(t, "data-db-0", pvc.Name)
+	assert.Equal(t, "test", pvc.Namespace)
+	require.NotNil(t, pvc.Spec.DataSource)
+	assert.Equal(t, Group, *pvc.Spec.DataSource.APIGroup)
+	assert.Equal(t, Kind, pvc.Spec.DataSource.Kind)
+	assert.Equal(t, "movies-1-data-db-0", pvc.Spec.DataSource.Name)
+	assert.Equal(t, "standard", *pvc.Spec.StorageClassName)
+	assert.Nil(t, vs.PVC.Spec.DataSource)
+}
+
+func TestClone(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeDynamicClient()
+	vs, err := Create(ctx, "movies-1", newPVC(), "", c)
+	require.NoError(t, err)
+
+	_, err = Clone(ctx, vs, "copy", c)
+	assert.ErrorContains(t, err, "is not bound")
+
+	obj, err := c.Resource(GVR).Namespace("test").Get(ctx, vs.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NoError(t, unstructured.SetNestedField(obj.Object, "snapcontent-1", "status", "boundVolumeSnapshotContentName"))
+	_, err = c.Resource(GVR).Namespace("test").Update(ctx, obj, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	content := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": ContentGVR.GroupVersion().String(),
+			"kind":       ContentKind,
+			"metadata":   map[string]interface{}{"name": "snapcontent-1"},
+			"spec": map[string]interface{}{
+				"driver":                  "pd.csi.storage.gke.io",
+				"volumeSnapshotClassName": "csi-snapclass",
+			},
+			"status": map[string]interface{}{"snapshotHandle": "snapshot-1234"},
+		},
+	}
+	_, err = c.Resource(ContentGVR).Create(ctx, content, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	clone, err := Clone(ctx, vs, "copy", c)
+	require.NoError(t, err)
+	assert.Equal(t, "movies-1-data-db-0", clone.Name)
+	assert.Equal(t, "copy", clone.Namespace)
+	assert.Equal(t, "movies-1", clone.Snapshot)
+	assert.Equal(t, "data-db-0", clone.PVC.Name)
+
+	cloneObj, err := c.Resource(GVR).Namespace("copy").Get(ctx, clone.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	source, _, err := unstructured.NestedString(cloneObj.Object, "spec", "source", "volumeSnapshotContentName")
+	require.NoError(t, err)
+	assert.Equal(t, "copy-movies-1-data-db-0", source)
+
+	cloneContent, err := c.Resource(ContentGVR).Get(ctx, "copy-movies-1-data-db-0", metav1.GetOptions{})
+	require.NoError(t, err)
+	handle, _, err := unstructured.NestedString(cloneContent.Object, "spec", "source", "snapshotHandle")
+	require.NoError(t, err)
+	assert.Equal(t, "snapshot-1234", handle)
+	policy, _, err := unstructured.NestedString(cloneContent.Object, "spec", "deletionPolicy")
+	require.NoError(t, err)
+	assert.Equal(t, "Retain", policy)
+	refNamespace, _, err := unstructured.NestedString(cloneContent.Object, "spec", "volumeSnapshotRef", "namespace")
+	require.NoError(t, err)
+	assert.Equal(t, "copy", refNamespace)
+}
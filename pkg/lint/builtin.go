@@ -0,0 +1,138 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/registry"
+)
+
+// manifestImage is an image referenced by a manifest
+type manifestImage struct {
+	path  string
+	image string
+}
+
+// getManifestImages returns the images of the build, dev and compose sections of the manifest sorted by path.
+// Images with variables that couldn't be expanded are skipped
+func getManifestImages(manifest *model.Manifest) []manifestImage {
+	result := []manifestImage{}
+	add := func(path, image string) {
+		if image == "" || strings.Contains(image, "${") {
+			return
+		}
+		result = append(result, manifestImage{path: path, image: image})
+	}
+	for name, b := range manifest.Build {
+		if b != nil {
+			add(fmt.Sprintf("build.%s.image", name), b.Image)
+		}
+	}
+	for name, dev := range manifest.Dev {
+		if dev != nil && dev.Image != nil {
+			add(fmt.Sprintf("dev.%s.image", name), dev.Image.Name)
+		}
+	}
+	if manifest.Deploy != nil && manifest.Deploy.ComposeSection != nil && manifest.Deploy.ComposeSection.Stack != nil {
+		for name, svc := range manifest.Deploy.ComposeSection.Stack.Services {
+			if svc != nil {
+				add(fmt.Sprintf("services.%s.image", name), svc.Image)
+			}
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].path < result[j].path
+	})
+	return result
+}
+
+// getImageTag returns the tag of an image, "latest" if it has no tag
+func getImageTag(image string) string {
+	_, tag := registry.ImageCtrl{}.GetRepoNameAndTag(image)
+	return tag
+}
+
+func builtinRules() []Rule {
+	return []Rule{
+		latestTagRule{},
+		duplicatedForwardRule{},
+	}
+}
+
+// latestTagRule warns about images without a fixed tag
+type latestTagRule struct{}
+
+func (latestTagRule) Name() string {
+	return "latest-tag"
+}
+
+func (latestTagRule) Description() string {
+	return "images should use a fixed tag instead of 'latest'"
+}
+
+func (r latestTagRule) Check(manifest *model.Manifest) []Issue {
+	result := []Issue{}
+	for _, img := range getManifestImages(manifest) {
+		if getImageTag(img.image) == "latest" {
+			result = append(result, Issue{
+				Path:     img.path,
+				Message:  fmt.Sprintf("image '%s' uses the 'latest' tag", img.image),
+				Severity: SeverityWarning,
+			})
+		}
+	}
+	return result
+}
+
+// duplicatedForwardRule warns about local ports forwarded by several development containers
+type duplicatedForwardRule struct{}
+
+func (duplicatedForwardRule) Name() string {
+	return "duplicated-forward"
+}
+
+func (duplicatedForwardRule) Description() string {
+	return "development containers should not forward the same local port"
+}
+
+func (duplicatedForwardRule) Check(manifest *model.Manifest) []Issue {
+	owners := map[int][]string{}
+	for name, dev := range manifest.Dev {
+		if dev == nil {
+			continue
+		}
+		for _, f := range dev.Forward {
+			owners[f.Local] = append(owners[f.Local], name)
+		}
+	}
+	result := []Issue{}
+	for port, names := range owners {
+		if len(names) < 2 {
+			continue
+		}
+		sort.Strings(names)
+		for _, name := range names[1:] {
+			result = append(result, Issue{
+				Path:     fmt.Sprintf("dev.%s.forward", name),
+				Message:  fmt.Sprintf("local port %d is also forwarded by '%s'", port, names[0]),
+				Severity: SeverityWarning,
+			})
+		}
+	}
+	return result
+}
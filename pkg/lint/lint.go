@@ -0,0 +1,145 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lint checks okteto manifests against a set of rules.
+// Organizations can add their own rules by registering them in the default registry from their own build of the CLI,
+// or by declaring them in a rules file
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/okteto/okteto/pkg/model"
+)
+
+// Severity is the severity of the issues of a rule
+type Severity string
+
+const (
+	// SeverityError issues make the validation fail
+	SeverityError Severity = "error"
+	// SeverityWarning issues are reported but don't make the validation fail
+	SeverityWarning Severity = "warning"
+)
+
+// Issue is a problem found by a rule in a manifest
+type Issue struct {
+	Rule     string
+	Path     string
+	Message  string
+	Severity Severity
+}
+
+// Rule checks a manifest and returns the issues found
+type Rule interface {
+	Name() string
+	Description() string
+	Check(manifest *model.Manifest) []Issue
+}
+
+// Registry keeps the rules available to lint a manifest
+type Registry struct {
+	rules map[string]Rule
+	mu    sync.RWMutex
+}
+
+// NewRegistry returns an empty registry
+func NewRegistry() *Registry {
+	return &Registry{rules: map[string]Rule{}}
+}
+
+// Register adds a rule to the registry. Rule names must be unique
+func (r *Registry) Register(rule Rule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.rules[rule.Name()]; ok {
+		return fmt.Errorf("rule '%s' is already registered", rule.Name())
+	}
+	r.rules[rule.Name()] = rule
+	return nil
+}
+
+// Rules returns the rules of the registry sorted by name
+func (r *Registry) Rules() []Rule {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]Rule, 0, len(r.rules))
+	for _, rule := range r.rules {
+		result = append(result, rule)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name() < result[j].Name()
+	})
+	return result
+}
+
+var defaultRegistry = NewRegistry()
+
+func init() {
+	for _, rule := range builtinRules() {
+		MustRegister(rule)
+	}
+}
+
+// Register adds a rule to the default registry
+func Register(rule Rule) error {
+	return defaultRegistry.Register(rule)
+}
+
+// MustRegister adds a rule to the default registry and panics if it can't be registered.
+// It is meant to be called from the init function of the packages defining rules
+func MustRegister(rule Rule) {
+	if err := Register(rule); err != nil {
+		panic(err)
+	}
+}
+
+// DefaultRules returns the rules of the default registry
+func DefaultRules() []Rule {
+	return defaultRegistry.Rules()
+}
+
+// Run checks the manifest with the rules and returns the issues sorted by path
+func Run(manifest *model.Manifest, rules []Rule) []Issue {
+	result := []Issue{}
+	for _, rule := range rules {
+		for _, issue := range rule.Check(manifest) {
+			if issue.Rule == "" {
+				issue.Rule = rule.Name()
+			}
+			if issue.Severity == "" {
+				issue.Severity = SeverityError
+			}
+			result = append(result, issue)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Path != result[j].Path {
+			return result[i].Path < result[j].Path
+		}
+		return result[i].Rule < result[j].Rule
+	})
+	return result
+}
+
+// HasErrors returns if any of the issues has error severity
+func HasErrors(issues []Issue) bool {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
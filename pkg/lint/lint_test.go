@@ -0,0 +1,99 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRule struct {
+	name   string
+	issues []Issue
+}
+
+func (r fakeRule) Name() string {
+	return r.name
+}
+
+func (fakeRule) Description() string {
+	return "fake rule"
+}
+
+func (r fakeRule) Check(*model.Manifest) []Issue {
+	return r.issues
+}
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+	require.NoError(t, r.Register(fakeRule{name: "b"}))
+	require.NoError(t, r.Register(fakeRule{name: "a"}))
+	assert.Error(t, r.Register(fakeRule{name: "a"}))
+
+	names := []string{}
+	for _, rule := range r.Rules() {
+		names = append(names, rule.Name())
+	}
+	assert.Equal(t, []string{"a", "b"}, names)
+}
+
+func TestDefaultRules(t *testing.T) {
+	names := []string{}
+	for _, rule := range DefaultRules() {
+		names = append(names, rule.Name())
+	}
+	assert.Equal(t, []string{"duplicated-forward", "latest-tag"}, names)
+}
+
+func TestRun(t *testing.T) {
+	rules := []Rule{
+		fakeRule{name: "warn", issues: []Issue{{Path: "dev.b", Message: "b", Severity: SeverityWarning}}},
+		fakeRule{name: "fail", issues: []Issue{{Path: "dev.a", Message: "a"}}},
+	}
+	issues := Run(&model.Manifest{}, rules)
+	assert.Equal(t, []Issue{
+		{Rule: "fail", Path: "dev.a", Message: "a", Severity: SeverityError},
+		{Rule: "warn", Path: "dev.b", Message: "b", Severity: SeverityWarning},
+	}, issues)
+	assert.True(t, HasErrors(issues))
+	assert.False(t, HasErrors(issues[1:]))
+}
+
+func TestBuiltinRules(t *testing.T) {
+	manifest := &model.Manifest{
+		Build: build.ManifestBuild{
+			"api": &build.Info{Image: "registry.corp/api:1.0"},
+		},
+		Dev: model.ManifestDevs{
+			"api": &model.Dev{
+				Image:   &build.Info{Name: "okteto/golang"},
+				Forward: []forward.Forward{{Local: 8080, Remote: 8080}},
+			},
+			"web": &model.Dev{
+				Image:   &build.Info{Name: "${WEB_IMAGE}"},
+				Forward: []forward.Forward{{Local: 8080, Remote: 3000}},
+			},
+		},
+	}
+	issues := Run(manifest, builtinRules())
+	assert.Equal(t, []Issue{
+		{Rule: "latest-tag", Path: "dev.api.image", Message: "image 'okteto/golang' uses the 'latest' tag", Severity: SeverityWarning},
+		{Rule: "duplicated-forward", Path: "dev.web.forward", Message: "local port 8080 is also forwarded by 'api'", Severity: SeverityWarning},
+	}, issues)
+}
@@ -0,0 +1,130 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/model"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// rulesFile is a file declaring the custom rules of an organization
+type rulesFile struct {
+	Rules []declarativeRule `yaml:"rules"`
+}
+
+// declarativeRule is a rule defined in a rules file
+type declarativeRule struct {
+	Images       *imagesCondition `yaml:"images,omitempty"`
+	RuleName     string           `yaml:"name"`
+	Desc         string           `yaml:"description,omitempty"`
+	Message      string           `yaml:"message,omitempty"`
+	RuleSeverity Severity         `yaml:"severity,omitempty"`
+}
+
+// imagesCondition restricts the images referenced by the manifest
+type imagesCondition struct {
+	AllowedRegistries []string `yaml:"allowedRegistries,omitempty"`
+	DeniedTags        []string `yaml:"deniedTags,omitempty"`
+}
+
+// LoadRulesFile reads the rules declared in a rules file
+func LoadRulesFile(path string) ([]Rule, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the rules file: %w", err)
+	}
+	return parseRulesFile(b)
+}
+
+func parseRulesFile(b []byte) ([]Rule, error) {
+	file := &rulesFile{}
+	if err := yaml.UnmarshalStrict(b, file); err != nil {
+		return nil, fmt.Errorf("invalid rules file: %w", err)
+	}
+	result := []Rule{}
+	for i := range file.Rules {
+		rule := &file.Rules[i]
+		if rule.RuleName == "" {
+			return nil, fmt.Errorf("invalid rules file: every rule must have a name")
+		}
+		switch rule.RuleSeverity {
+		case "":
+			rule.RuleSeverity = SeverityError
+		case SeverityError, SeverityWarning:
+		default:
+			return nil, fmt.Errorf("invalid rules file: rule '%s' has an invalid severity '%s'. Supported values are: ['%s', '%s']", rule.RuleName, rule.RuleSeverity, SeverityError, SeverityWarning)
+		}
+		if rule.Images == nil || rule.Images.isEmpty() {
+			return nil, fmt.Errorf("invalid rules file: rule '%s' has no conditions", rule.RuleName)
+		}
+		result = append(result, rule)
+	}
+	return result, nil
+}
+
+func (r *declarativeRule) Name() string {
+	return r.RuleName
+}
+
+func (r *declarativeRule) Description() string {
+	return r.Desc
+}
+
+func (r *declarativeRule) Check(manifest *model.Manifest) []Issue {
+	result := []Issue{}
+	for _, img := range getManifestImages(manifest) {
+		msg := r.Images.check(img.image)
+		if msg == "" {
+			continue
+		}
+		if r.Message != "" {
+			msg = fmt.Sprintf("%s: %s", r.Message, msg)
+		}
+		result = append(result, Issue{Path: img.path, Message: msg, Severity: r.RuleSeverity})
+	}
+	return result
+}
+
+// isEmpty returns if the condition doesn't restrict any image
+func (c *imagesCondition) isEmpty() bool {
+	return len(c.AllowedRegistries) == 0 && len(c.DeniedTags) == 0
+}
+
+// check returns why the image doesn't meet the condition, or an empty string if it does
+func (c *imagesCondition) check(image string) string {
+	if len(c.AllowedRegistries) > 0 && !isFromRegistries(image, c.AllowedRegistries) {
+		return fmt.Sprintf("image '%s' is not from an allowed registry (%s)", image, strings.Join(c.AllowedRegistries, ", "))
+	}
+	tag := getImageTag(image)
+	for _, denied := range c.DeniedTags {
+		if tag == denied {
+			return fmt.Sprintf("image '%s' uses the denied tag '%s'", image, tag)
+		}
+	}
+	return ""
+}
+
+func isFromRegistries(image string, registries []string) bool {
+	for _, r := range registries {
+		r = strings.TrimSuffix(r, "/")
+		if strings.HasPrefix(image, r+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const corpRules = `rules:
+  - name: corp-registry
+    description: images must come from registry.corp
+    message: use the corporate registry
+    images:
+      allowedRegistries:
+        - registry.corp/
+  - name: no-dev-tag
+    severity: warning
+    images:
+      deniedTags:
+        - dev
+`
+
+func TestLoadRulesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(corpRules), 0600))
+	rules, err := LoadRulesFile(path)
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, "corp-registry", rules[0].Name())
+	assert.Equal(t, "images must come from registry.corp", rules[0].Description())
+
+	manifest := &model.Manifest{
+		Build: build.ManifestBuild{
+			"api": &build.Info{Image: "registry.corp/team/api:dev"},
+			"web": &build.Info{Image: "docker.io/web:1.0"},
+		},
+	}
+	assert.Equal(t, []Issue{
+		{Rule: "no-dev-tag", Path: "build.api.image", Message: "image 'registry.corp/team/api:dev' uses the denied tag 'dev'", Severity: SeverityWarning},
+		{Rule: "corp-registry", Path: "build.web.image", Message: "use the corporate registry: image 'docker.io/web:1.0' is not from an allowed registry (registry.corp/)", Severity: SeverityError},
+	}, Run(manifest, rules))
+}
+
+func TestParseRulesFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "unknown field", content: "rules:\n  - name: a\n    unknown: true\n"},
+		{name: "no name", content: "rules:\n  - images:\n      deniedTags: [latest]\n"},
+		{name: "no conditions", content: "rules:\n  - name: a\n"},
+		{name: "empty images condition", content: "rules:\n  - name: a\n    images: {}\n"},
+		{name: "invalid severity", content: "rules:\n  - name: a\n    severity: fatal\n    images:\n      deniedTags: [latest]\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseRulesFile([]byte(tt.content))
+			assert.Error(t, err)
+		})
+	}
+}
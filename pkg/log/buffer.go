@@ -0,0 +1,72 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// GetBufferedMessages returns the messages stored in the buffer of the logger. Lines that are not json messages are skipped
+func (l *Logger) GetBufferedMessages() ([]JSONMessage, error) {
+	messages := []JSONMessage{}
+	if l.buf == nil {
+		return messages, nil
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(l.buf.Bytes()))
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), l.buf.Len()+1)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var message JSONMessage
+		if err := json.Unmarshal(line, &message); err != nil {
+			l.out.Debugf("skipping invalid line in the output buffer: %s", err)
+			continue
+		}
+		messages = append(messages, message)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading the output buffer: %w", err)
+	}
+	return messages, nil
+}
+
+// GetStageBuffer returns the messages of the buffer that belong to a stage
+func (l *Logger) GetStageBuffer(stage string) ([]JSONMessage, error) {
+	messages, err := l.GetBufferedMessages()
+	if err != nil {
+		return nil, err
+	}
+	result := []JSONMessage{}
+	for _, message := range messages {
+		if message.Stage == stage {
+			result = append(result, message)
+		}
+	}
+	return result, nil
+}
+
+// GetBufferedMessages returns the messages stored in the buffer of the running command
+func GetBufferedMessages() ([]JSONMessage, error) {
+	return log.GetBufferedMessages()
+}
+
+// GetStageBuffer returns the messages of the running command that belong to a stage
+func GetStageBuffer(stage string) ([]JSONMessage, error) {
+	return log.GetStageBuffer(stage)
+}
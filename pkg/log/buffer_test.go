@@ -0,0 +1,75 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStageBuffer(t *testing.T) {
+	previous := log
+	t.Cleanup(func() {
+		SetLogger(previous)
+	})
+
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	var out bytes.Buffer
+	SetLogger(NewLogger(WithOutput(&out), WithFormat(JSONFormat), WithSpinnerDisabled(true), WithClock(func() time.Time { return now })))
+
+	SetStage("build")
+	AddToBuffer(InfoLevel, "building image")
+	SetStage("deploy")
+	AddToBuffer(InfoLevel, "deploying")
+	AddToBuffer(ErrorLevel, "deploy failed")
+
+	messages, err := GetBufferedMessages()
+	require.NoError(t, err)
+	assert.Len(t, messages, 3)
+
+	messages, err = GetStageBuffer("deploy")
+	require.NoError(t, err)
+	assert.Equal(t, []JSONMessage{
+		{Level: InfoLevel, Stage: "deploy", Message: "deploying", Timestamp: now.Unix()},
+		{Level: ErrorLevel, Stage: "deploy", Message: "deploy failed", Timestamp: now.Unix()},
+	}, messages)
+
+	messages, err = GetStageBuffer("destroy")
+	require.NoError(t, err)
+	assert.Empty(t, messages)
+}
+
+func TestGetBufferedMessagesInvalidLine(t *testing.T) {
+	l := NewLogger(WithSpinnerDisabled(true))
+	l.buf.WriteString("\nnot json\n{\"level\":\"info\",\"stage\":\"deploy\",\"message\":\"deploying\",\"timestamp\":1}\n")
+	messages, err := l.GetBufferedMessages()
+	require.NoError(t, err)
+	assert.Equal(t, []JSONMessage{{Level: InfoLevel, Stage: "deploy", Message: "deploying", Timestamp: 1}}, messages)
+}
+
+func TestGetBufferedMessagesSilentFormat(t *testing.T) {
+	l := NewLogger(WithFormat(SilentFormat), WithSpinnerDisabled(true))
+	l.stage = "deploy"
+	l.writer.AddToBuffer(InfoLevel, "first")
+	l.writer.AddToBuffer(InfoLevel, "second")
+	messages, err := l.GetBufferedMessages()
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Equal(t, "first", messages[0].Message)
+	assert.Equal(t, "second", messages[1].Message)
+}
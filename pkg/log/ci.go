@@ -0,0 +1,156 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// OktetoDisableCIOutputEnvVar if true the logs don't include the workflow commands of the CI provider
+	OktetoDisableCIOutputEnvVar = "OKTETO_DISABLE_CI_OUTPUT"
+
+	githubActionsEnvVar = "GITHUB_ACTIONS"
+	gitlabCIEnvVar      = "GITLAB_CI"
+
+	// GitHubCI is the CI provider for GitHub Actions
+	GitHubCI = "github"
+	// GitLabCI is the CI provider for GitLab CI
+	GitLabCI = "gitlab"
+)
+
+var (
+	gitlabSectionNameRegex = regexp.MustCompile("[^a-zA-Z0-9_.-]+")
+
+	githubCommandEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+)
+
+// stageWriter is implemented by the writers that mark the beginning and the end of each stage
+type stageWriter interface {
+	changeStage(previous, next string)
+}
+
+// CIWriter decorates a writer with the workflow commands of a CI provider:
+// stages are folded and errors and warnings are reported as annotations
+type CIWriter struct {
+	OktetoWriter
+	out      *logrus.Logger
+	buf      *bytes.Buffer
+	now      func() time.Time
+	logger   *Logger
+	provider string
+}
+
+// getCIProvider returns the CI provider running the command, if any
+func getCIProvider() string {
+	if loadBool(OktetoDisableCIOutputEnvVar) {
+		return ""
+	}
+	if loadBool(githubActionsEnvVar) {
+		return GitHubCI
+	}
+	if loadBool(gitlabCIEnvVar) {
+		return GitLabCI
+	}
+	return ""
+}
+
+// newCIWriter creates a new CIWriter
+func newCIWriter(writer OktetoWriter, provider string, l *Logger) *CIWriter {
+	return &CIWriter{
+		OktetoWriter: writer,
+		provider:     provider,
+		out:          l.out,
+		buf:          l.buf,
+		now:          l.now,
+		logger:       l,
+	}
+}
+
+// Fail prints a message as an error annotation
+func (w *CIWriter) Fail(format string, args ...interface{}) {
+	if w.provider != GitHubCI {
+		w.OktetoWriter.Fail(format, args...)
+		return
+	}
+	// the workflow command is the only line printed: GitHub shows its message in the log and as an annotation
+	msg := sprintf(format, args...)
+	fmt.Fprintf(w.out.Out, "::error::%s\n", githubCommandEscaper.Replace(msg))
+	if msg != "" {
+		msg = w.logger.convertToJSON(ErrorLevel, msg)
+		if msg != "" {
+			writeLine(w.buf, msg)
+		}
+	}
+}
+
+// Warning prints a message as a warning annotation
+func (w *CIWriter) Warning(format string, args ...interface{}) {
+	if w.provider != GitHubCI {
+		w.OktetoWriter.Warning(format, args...)
+		return
+	}
+	msg := sprintf(format, args...)
+	fmt.Fprintf(w.out.Out, "::warning::%s\n", githubCommandEscaper.Replace(msg))
+}
+
+// changeStage closes the section of the previous stage and opens the section of the next one
+func (w *CIWriter) changeStage(previous, next string) {
+	if previous == next {
+		return
+	}
+	if previous != "" {
+		w.endSection(previous)
+	}
+	if next != "" {
+		w.startSection(next)
+	}
+}
+
+func (w *CIWriter) startSection(stage string) {
+	switch w.provider {
+	case GitHubCI:
+		fmt.Fprintf(w.out.Out, "::group::%s\n", stage)
+	case GitLabCI:
+		fmt.Fprintf(w.out.Out, "\x1b[0Ksection_start:%d:%s\r\x1b[0K%s\n", w.now().Unix(), getGitlabSectionName(stage), stage)
+	}
+}
+
+func (w *CIWriter) endSection(stage string) {
+	switch w.provider {
+	case GitHubCI:
+		fmt.Fprintln(w.out.Out, "::endgroup::")
+	case GitLabCI:
+		fmt.Fprintf(w.out.Out, "\x1b[0Ksection_end:%d:%s\r\x1b[0K\n", w.now().Unix(), getGitlabSectionName(stage))
+	}
+}
+
+// sprintf formats the message only if there are arguments: the package functions pass messages already formatted
+func sprintf(format string, args ...interface{}) string {
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// getGitlabSectionName returns a section name with the characters accepted by GitLab
+func getGitlabSectionName(stage string) string {
+	return strings.Trim(gitlabSectionNameRegex.ReplaceAllString(strings.ToLower(stage), "_"), "_")
+}
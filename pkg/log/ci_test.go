@@ -0,0 +1,128 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_getCIProvider(t *testing.T) {
+	tests := []struct {
+		envs     map[string]string
+		name     string
+		expected string
+	}{
+		{
+			name:     "no ci",
+			envs:     map[string]string{},
+			expected: "",
+		},
+		{
+			name:     "github actions",
+			envs:     map[string]string{githubActionsEnvVar: "true"},
+			expected: GitHubCI,
+		},
+		{
+			name:     "gitlab ci",
+			envs:     map[string]string{gitlabCIEnvVar: "true"},
+			expected: GitLabCI,
+		},
+		{
+			name:     "disabled",
+			envs:     map[string]string{githubActionsEnvVar: "true", OktetoDisableCIOutputEnvVar: "true"},
+			expected: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(githubActionsEnvVar, "")
+			t.Setenv(gitlabCIEnvVar, "")
+			t.Setenv(OktetoDisableCIOutputEnvVar, "")
+			for k, v := range tt.envs {
+				t.Setenv(k, v)
+			}
+			assert.Equal(t, tt.expected, getCIProvider())
+		})
+	}
+}
+
+func newTestCIWriter(t *testing.T, provider string) (*CIWriter, *bytes.Buffer) {
+	t.Helper()
+	var b bytes.Buffer
+	previousOut := log.out.Out
+	previousWriter := log.writer
+	previousStage := log.stage
+	t.Cleanup(func() {
+		log.out.SetOutput(previousOut)
+		log.writer = previousWriter
+		log.stage = previousStage
+	})
+	log.out.SetOutput(&b)
+	log.stage = ""
+
+	w := newCIWriter(newPlainWriter(log), provider, log)
+	w.now = func() time.Time { return time.Unix(1700000000, 0) }
+	log.writer = w
+	return w, &b
+}
+
+func TestCIWriterGitHub(t *testing.T) {
+	_, b := newTestCIWriter(t, GitHubCI)
+
+	SetStage("Load manifest")
+	Warning("deprecated field")
+	SetStage("Deploy")
+	Fail("deploy failed: 100%%\nexit status 1")
+	SetStage("")
+
+	expected := "::group::Load manifest\n" +
+		"::warning::deprecated field\n" +
+		"::endgroup::\n" +
+		"::group::Deploy\n" +
+		"::error::deploy failed: 100%25%0Aexit status 1\n" +
+		"::endgroup::\n"
+	assert.Equal(t, expected, b.String())
+}
+
+func TestCIWriterGitHubPrintsFailOnce(t *testing.T) {
+	_, b := newTestCIWriter(t, GitHubCI)
+	previousLevel := log.out.GetLevel()
+	t.Cleanup(func() {
+		log.out.SetLevel(previousLevel)
+	})
+	log.out.SetLevel(logrus.InfoLevel)
+
+	Fail("deploy failed")
+	Warning("deprecated field")
+
+	assert.Equal(t, "::error::deploy failed\n::warning::deprecated field\n", b.String())
+}
+
+func TestCIWriterGitLab(t *testing.T) {
+	_, b := newTestCIWriter(t, GitLabCI)
+
+	SetStage("Deploying dependency api")
+	Fail("deploy failed")
+	SetStage("")
+
+	expected := "\x1b[0Ksection_start:1700000000:deploying_dependency_api\r\x1b[0KDeploying dependency api\n" +
+		"ERROR: deploy failed\n" +
+		"\x1b[0Ksection_end:1700000000:deploying_dependency_api\r\x1b[0K\n"
+	assert.Equal(t, expected, b.String())
+}
@@ -0,0 +1,257 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fake
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	// DebugLevel is the level of the events written by Debug and Debugf
+	DebugLevel = "debug"
+	// InfoLevel is the level of the events written by Info and Infof
+	InfoLevel = "info"
+	// ErrorLevel is the level of the events written by Error and Errorf
+	ErrorLevel = "error"
+	// FailLevel is the level of the events written by Fail
+	FailLevel = "fail"
+	// FatalLevel is the level of the events written by Fatalf
+	FatalLevel = "fatal"
+	// YellowLevel is the level of the events written by Yellow
+	YellowLevel = "yellow"
+	// GreenLevel is the level of the events written by Green
+	GreenLevel = "green"
+	// SuccessLevel is the level of the events written by Success
+	SuccessLevel = "success"
+	// InformationLevel is the level of the events written by Information
+	InformationLevel = "information"
+	// QuestionLevel is the level of the events written by Question
+	QuestionLevel = "question"
+	// WarningLevel is the level of the events written by Warning and FWarning
+	WarningLevel = "warning"
+	// HintLevel is the level of the events written by Hint
+	HintLevel = "hint"
+	// PrintLevel is the level of the events written by the Print family and Write
+	PrintLevel = "print"
+)
+
+// Event is a message captured by the SpyWriter
+type Event struct {
+	Level   string
+	Message string
+}
+
+// SpyWriter is an OktetoWriter that captures every message instead of writing it
+type SpyWriter struct {
+	// QuestionErr is returned by Question
+	QuestionErr error
+
+	events      []Event
+	interactive bool
+	mu          sync.Mutex
+}
+
+var _ oktetoLog.OktetoWriter = &SpyWriter{}
+
+// NewSpyWriter returns a SpyWriter with no captured events
+func NewSpyWriter() *SpyWriter {
+	return &SpyWriter{}
+}
+
+// SetInteractive sets the value returned by IsInteractive
+func (w *SpyWriter) SetInteractive(interactive bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.interactive = interactive
+}
+
+// Events returns the captured events in the order they were written
+func (w *SpyWriter) Events() []Event {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	result := make([]Event, len(w.events))
+	copy(result, w.events)
+	return result
+}
+
+// Messages returns the messages captured with the given level
+func (w *SpyWriter) Messages(level string) []string {
+	result := []string{}
+	for _, e := range w.Events() {
+		if e.Level == level {
+			result = append(result, e.Message)
+		}
+	}
+	return result
+}
+
+// Contains returns true if any captured message contains 'substr'
+func (w *SpyWriter) Contains(substr string) bool {
+	for _, e := range w.Events() {
+		if strings.Contains(e.Message, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Reset discards the captured events
+func (w *SpyWriter) Reset() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.events = nil
+}
+
+func (w *SpyWriter) record(level, msg string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.events = append(w.events, Event{Level: level, Message: msg})
+}
+
+// Debug captures a debug-level log
+func (w *SpyWriter) Debug(args ...interface{}) {
+	w.record(DebugLevel, fmt.Sprint(args...))
+}
+
+// Debugf captures a debug-level log with a format
+func (w *SpyWriter) Debugf(format string, args ...interface{}) {
+	w.record(DebugLevel, fmt.Sprintf(format, args...))
+}
+
+// Info captures a info-level log
+func (w *SpyWriter) Info(args ...interface{}) {
+	w.record(InfoLevel, fmt.Sprint(args...))
+}
+
+// Infof captures a info-level log with a format
+func (w *SpyWriter) Infof(format string, args ...interface{}) {
+	w.record(InfoLevel, fmt.Sprintf(format, args...))
+}
+
+// Error captures a error-level log
+func (w *SpyWriter) Error(args ...interface{}) {
+	w.record(ErrorLevel, fmt.Sprint(args...))
+}
+
+// Errorf captures a error-level log with a format
+func (w *SpyWriter) Errorf(format string, args ...interface{}) {
+	w.record(ErrorLevel, fmt.Sprintf(format, args...))
+}
+
+// Fail captures a fail message. It receives the message already formatted, like the rest of writers
+func (w *SpyWriter) Fail(format string, args ...interface{}) {
+	w.record(FailLevel, sprintf(format, args...))
+}
+
+// Fatalf captures a fatal message. Unlike the rest of writers, it doesn't exit the process
+func (w *SpyWriter) Fatalf(format string, args ...interface{}) {
+	w.record(FatalLevel, fmt.Sprintf(format, args...))
+}
+
+// Yellow captures a message printed in yellow
+func (w *SpyWriter) Yellow(format string, args ...interface{}) {
+	w.record(YellowLevel, fmt.Sprintf(format, args...))
+}
+
+// Green captures a message printed in green
+func (w *SpyWriter) Green(format string, args ...interface{}) {
+	w.record(GreenLevel, fmt.Sprintf(format, args...))
+}
+
+// Success captures a success message
+func (w *SpyWriter) Success(format string, args ...interface{}) {
+	w.record(SuccessLevel, fmt.Sprintf(format, args...))
+}
+
+// Information captures an information message
+func (w *SpyWriter) Information(format string, args ...interface{}) {
+	w.record(InformationLevel, fmt.Sprintf(format, args...))
+}
+
+// Question captures a question and returns QuestionErr
+func (w *SpyWriter) Question(format string, args ...interface{}) error {
+	w.record(QuestionLevel, fmt.Sprintf(format, args...))
+	return w.QuestionErr
+}
+
+// Warning captures a warning message
+func (w *SpyWriter) Warning(format string, args ...interface{}) {
+	w.record(WarningLevel, fmt.Sprintf(format, args...))
+}
+
+// FWarning captures a warning message. The message is not written to 'writer'
+func (w *SpyWriter) FWarning(_ io.Writer, format string, args ...interface{}) {
+	w.record(WarningLevel, fmt.Sprintf(format, args...))
+}
+
+// Hint captures a hint message
+func (w *SpyWriter) Hint(format string, args ...interface{}) {
+	w.record(HintLevel, fmt.Sprintf(format, args...))
+}
+
+// Println captures a line
+func (w *SpyWriter) Println(args ...interface{}) {
+	w.record(PrintLevel, fmt.Sprint(args...))
+}
+
+// FPrintln captures a line. The line is not written to 'writer'
+func (w *SpyWriter) FPrintln(_ io.Writer, args ...interface{}) {
+	w.record(PrintLevel, fmt.Sprint(args...))
+}
+
+// Print captures a message
+func (w *SpyWriter) Print(args ...interface{}) {
+	w.record(PrintLevel, fmt.Sprint(args...))
+}
+
+// Fprintf captures a message with a format. The message is not written to 'writer'
+func (w *SpyWriter) Fprintf(_ io.Writer, format string, a ...interface{}) {
+	w.record(PrintLevel, fmt.Sprintf(format, a...))
+}
+
+// Printf captures a message with a format
+func (w *SpyWriter) Printf(format string, a ...interface{}) {
+	w.record(PrintLevel, fmt.Sprintf(format, a...))
+}
+
+// IsInteractive returns the value set with SetInteractive
+func (w *SpyWriter) IsInteractive() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.interactive
+}
+
+// AddToBuffer captures a message with the given level
+func (w *SpyWriter) AddToBuffer(level, format string, a ...interface{}) {
+	w.record(level, fmt.Sprintf(format, a...))
+}
+
+// Write captures the bytes written as a message
+func (w *SpyWriter) Write(p []byte) (n int, err error) {
+	w.record(PrintLevel, string(p))
+	return len(p), nil
+}
+
+// sprintf only formats the message when there are arguments, as the messages received by Fail are already formatted
+func sprintf(format string, args ...interface{}) string {
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fake
+
+import (
+	"testing"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpyWriter(t *testing.T) {
+	w := NewSpyWriter()
+	previous := oktetoLog.GetOutputWriter()
+	oktetoLog.SetOutputWriter(w)
+	t.Cleanup(func() {
+		oktetoLog.SetOutputWriter(previous)
+	})
+
+	oktetoLog.Success("deployed %s", "api")
+	oktetoLog.Warning("using %d%% of the quota", 90)
+	oktetoLog.Fail("%s of the quota used", "100%")
+	oktetoLog.Information("done")
+
+	expected := []Event{
+		{Level: SuccessLevel, Message: "deployed api"},
+		{Level: WarningLevel, Message: "using 90% of the quota"},
+		{Level: FailLevel, Message: "100% of the quota used"},
+		{Level: InformationLevel, Message: "done"},
+	}
+	assert.Equal(t, expected, w.Events())
+	assert.Equal(t, []string{"using 90% of the quota"}, w.Messages(WarningLevel))
+	assert.True(t, w.Contains("quota used"))
+	assert.False(t, w.Contains("destroyed"))
+
+	w.Reset()
+	assert.Empty(t, w.Events())
+}
+
+func TestSpyWriterQuestion(t *testing.T) {
+	w := NewSpyWriter()
+	w.QuestionErr = assert.AnError
+
+	err := w.Question("are you sure?")
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Equal(t, []string{"are you sure?"}, w.Messages(QuestionLevel))
+}
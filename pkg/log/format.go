@@ -13,7 +13,10 @@
 
 package log
 
-import "io"
+import (
+	"bytes"
+	"io"
+)
 
 // OktetoWriter implements the interface of the writers
 type OktetoWriter interface {
@@ -61,25 +64,42 @@ const (
 	SilentFormat string = "silent"
 )
 
-func (l *logger) getWriter(format string) OktetoWriter {
+func (l *Logger) getWriter(format string) OktetoWriter {
 	switch format {
 	case TTYFormat:
 		l.outputMode = TTYFormat
-		return newTTYWriter(l.out, l.file)
+		return l.withCIProvider(newTTYWriter(l))
 	case PlainFormat:
 		l.outputMode = PlainFormat
-		return newPlainWriter(l.out, l.file)
+		return l.withCIProvider(newPlainWriter(l))
 	case JSONFormat:
 		l.outputMode = JSONFormat
-		l.out.SetFormatter(&JSONLogFormat{})
-		return newJSONWriter(l.out, l.file)
+		l.out.SetFormatter(&JSONLogFormat{logger: l})
+		return newJSONWriter(l)
 	case SilentFormat:
 		l.outputMode = SilentFormat
-		return newSilentWriter(l.out, l.file)
+		return newSilentWriter(l)
 	default:
 		Debugf("could not load %s. Callback to 'tty'", format)
 		l.outputMode = TTYFormat
-		return newTTYWriter(l.out, l.file)
+		return l.withCIProvider(newTTYWriter(l))
 	}
 
 }
+
+// writeLine appends a json line to the buffer of a writer. Writers built without buffer discard it
+func writeLine(buf *bytes.Buffer, line string) {
+	if buf == nil {
+		return
+	}
+	buf.WriteString(line)
+	buf.WriteString("\n")
+}
+
+// withCIProvider decorates the writer with the workflow commands of the CI provider running the command, if any
+func (l *Logger) withCIProvider(writer OktetoWriter) OktetoWriter {
+	if provider := getCIProvider(); provider != "" {
+		return newCIWriter(writer, provider, l)
+	}
+	return writer
+}
@@ -0,0 +1,338 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	invocationIndexFile = "index.jsonl"
+	invocationLogExt    = ".log"
+
+	// maxInvocationLogs is the number of command runs whose logs are kept
+	maxInvocationLogs = 50
+
+	redactedValue = "***"
+)
+
+var (
+	// ErrInvocationNotFound is raised when there is no log for a command run
+	ErrInvocationNotFound = errors.New("command run not found")
+
+	// sensitiveFlags are the flags whose values are redacted from the index
+	sensitiveFlags = []string{"token", "password", "secret", "key"}
+
+	// variableFlags are the flags with KEY=VALUE values whose values are redacted from the index
+	variableFlags = map[string]bool{
+		"--var": true,
+		"-v":    true,
+		"--env": true,
+		"-e":    true,
+	}
+
+	current *invocationLog
+)
+
+// Invocation is the index entry of a command run
+type Invocation struct {
+	StartedAt time.Time     `json:"startedAt" yaml:"startedAt"`
+	ID        string        `json:"id" yaml:"id"`
+	Command   string        `json:"command" yaml:"command"`
+	Version   string        `json:"version" yaml:"version"`
+	LogFile   string        `json:"logFile" yaml:"logFile"`
+	Args      []string      `json:"args" yaml:"args"`
+	Duration  time.Duration `json:"duration" yaml:"duration"`
+	ExitCode  int           `json:"exitCode" yaml:"exitCode"`
+}
+
+// OutputName returns the id of the invocation for the 'name' output format
+func (i Invocation) OutputName() string {
+	return i.ID
+}
+
+type invocationLog struct {
+	file       *os.File
+	dir        string
+	invocation Invocation
+}
+
+// StartInvocationLog writes the logs of the current command run to its own file in dir
+func StartInvocationLog(dir, version, command string, args []string) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	now := time.Now()
+	id := fmt.Sprintf("%s-%d", now.Format("20060102-150405"), os.Getpid())
+	logPath := filepath.Join(dir, id+invocationLogExt)
+	f, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", logPath, err)
+	}
+
+	fileLogger := logrus.New()
+	fileLogger.SetFormatter(&logrus.TextFormatter{
+		DisableColors: true,
+		FullTimestamp: true,
+	})
+	fileLogger.SetOutput(f)
+	fileLogger.SetLevel(logrus.DebugLevel)
+	log.file = fileLogger.WithFields(logrus.Fields{"action": id, "version": version})
+
+	current = &invocationLog{
+		file: f,
+		dir:  dir,
+		invocation: Invocation{
+			ID:        id,
+			Command:   command,
+			Args:      RedactArgs(args),
+			Version:   version,
+			LogFile:   logPath,
+			StartedAt: now,
+		},
+	}
+
+	if err := pruneInvocationLogs(dir, maxInvocationLogs); err != nil {
+		Infof("failed to prune command logs: %s", err)
+	}
+	return nil
+}
+
+// FinishInvocationLog closes the log of the current command run and adds it to the index
+func FinishInvocationLog(exitCode int, err error) {
+	if current == nil {
+		return
+	}
+	defer func() {
+		current = nil
+	}()
+	if err != nil {
+		log.file.Errorf("command failed with exit code %d: %s", exitCode, err)
+	}
+	log.file = nil
+	if err := current.file.Close(); err != nil {
+		Infof("failed to close %s: %s", current.invocation.LogFile, err)
+	}
+
+	current.invocation.ExitCode = exitCode
+	current.invocation.Duration = time.Since(current.invocation.StartedAt)
+	if err := appendInvocation(current.dir, current.invocation); err != nil {
+		Infof("failed to update the command logs index: %s", err)
+	}
+}
+
+// GetInvocationLogPath returns the log file of the current command run, or an empty string if it's not logged
+func GetInvocationLogPath() string {
+	if current == nil {
+		return ""
+	}
+	return current.invocation.LogFile
+}
+
+// ListInvocations returns the logged command runs in dir, the most recent first
+func ListInvocations(dir string) ([]Invocation, error) {
+	f, err := os.Open(filepath.Join(dir, invocationIndexFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Invocation{}, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	result := []Invocation{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var i Invocation
+		if err := json.Unmarshal(scanner.Bytes(), &i); err != nil {
+			// entries can be corrupted by concurrent writes, skip them
+			continue
+		}
+		if _, err := os.Stat(i.LogFile); err != nil {
+			continue
+		}
+		result = append(result, i)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].StartedAt.After(result[j].StartedAt)
+	})
+	return result, nil
+}
+
+// GetInvocation returns a logged command run by its ID. "last" returns the most recent one
+func GetInvocation(dir, id string) (*Invocation, error) {
+	invocations, err := ListInvocations(dir)
+	if err != nil {
+		return nil, err
+	}
+	for i := range invocations {
+		if id == "last" || invocations[i].ID == id {
+			return &invocations[i], nil
+		}
+	}
+	return nil, fmt.Errorf("'%s': %w", id, ErrInvocationNotFound)
+}
+
+// CleanInvocationLogs removes the logs and the index of all the command runs in dir except the current one
+func CleanInvocationLogs(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	for _, e := range entries {
+		p := filepath.Join(dir, e.Name())
+		if filepath.Ext(e.Name()) != invocationLogExt || p == GetInvocationLogPath() {
+			continue
+		}
+		if err := os.Remove(p); err != nil {
+			return err
+		}
+	}
+	if err := os.Remove(filepath.Join(dir, invocationIndexFile)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// RedactArgs hides the values of the flags that might contain credentials
+func RedactArgs(args []string) []string {
+	result := make([]string, len(args))
+	redactNext := false
+	redactNextValue := false
+	for i, arg := range args {
+		switch {
+		case redactNext:
+			result[i] = redactedValue
+		case redactNextValue:
+			result[i] = redactVariable(arg)
+		default:
+			result[i] = redactArg(arg)
+		}
+		redactNext = false
+		redactNextValue = false
+		if strings.HasPrefix(arg, "-") && !strings.Contains(arg, "=") {
+			redactNext = isSensitiveFlag(arg)
+			redactNextValue = variableFlags[arg]
+		}
+	}
+	return result
+}
+
+func redactArg(arg string) string {
+	if !strings.HasPrefix(arg, "-") {
+		return arg
+	}
+	flag, value, found := strings.Cut(arg, "=")
+	if !found {
+		return arg
+	}
+	if isSensitiveFlag(flag) {
+		return fmt.Sprintf("%s=%s", flag, redactedValue)
+	}
+	if variableFlags[flag] {
+		return fmt.Sprintf("%s=%s", flag, redactVariable(value))
+	}
+	return arg
+}
+
+func redactVariable(value string) string {
+	name, _, found := strings.Cut(value, "=")
+	if !found {
+		return value
+	}
+	return fmt.Sprintf("%s=%s", name, redactedValue)
+}
+
+func isSensitiveFlag(flag string) bool {
+	flag = strings.ToLower(strings.TrimLeft(flag, "-"))
+	for _, s := range sensitiveFlags {
+		if strings.Contains(flag, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func appendInvocation(dir string, i Invocation) error {
+	bytes, err := json.Marshal(i)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(filepath.Join(dir, invocationIndexFile), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(bytes, '\n'))
+	return err
+}
+
+// pruneInvocationLogs removes the oldest logs in dir, keeping the given number of them
+func pruneInvocationLogs(dir string, keep int) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	logs := []string{}
+	for _, e := range entries {
+		if !e.IsDir() && filepath.Ext(e.Name()) == invocationLogExt {
+			logs = append(logs, e.Name())
+		}
+	}
+	if len(logs) <= keep {
+		return nil
+	}
+	// log names start with their timestamp, so they are sorted from the oldest to the newest
+	sort.Strings(logs)
+	for _, name := range logs[:len(logs)-keep] {
+		if err := os.Remove(filepath.Join(dir, name)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return compactInvocationIndex(dir)
+}
+
+// compactInvocationIndex removes the index entries whose logs don't exist anymore
+func compactInvocationIndex(dir string) error {
+	invocations, err := ListInvocations(dir)
+	if err != nil {
+		return err
+	}
+	var sb strings.Builder
+	for i := len(invocations) - 1; i >= 0; i-- {
+		bytes, err := json.Marshal(invocations[i])
+		if err != nil {
+			return err
+		}
+		sb.Write(bytes)
+		sb.WriteByte('\n')
+	}
+	return os.WriteFile(filepath.Join(dir, invocationIndexFile), []byte(sb.String()), 0600)
+}
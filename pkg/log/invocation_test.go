@@ -0,0 +1,139 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvocationLog(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, StartInvocationLog(dir, "1.0.0", "okteto up", []string{"up", "--token", "secret-token"}))
+	logPath := GetInvocationLogPath()
+	assert.Equal(t, dir, filepath.Dir(logPath))
+
+	log.file.Info("message in the command log")
+	FinishInvocationLog(1, errors.New("failed"))
+	assert.Empty(t, GetInvocationLogPath())
+	assert.Nil(t, log.file)
+
+	content, err := os.ReadFile(logPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "message in the command log")
+	assert.Contains(t, string(content), "command failed with exit code 1: failed")
+
+	invocations, err := ListInvocations(dir)
+	require.NoError(t, err)
+	require.Len(t, invocations, 1)
+	assert.Equal(t, "okteto up", invocations[0].Command)
+	assert.Equal(t, []string{"up", "--token", "***"}, invocations[0].Args)
+	assert.Equal(t, 1, invocations[0].ExitCode)
+	assert.Equal(t, logPath, invocations[0].LogFile)
+
+	last, err := GetInvocation(dir, "last")
+	require.NoError(t, err)
+	assert.Equal(t, invocations[0].ID, last.ID)
+
+	_, err = GetInvocation(dir, "unknown")
+	assert.ErrorIs(t, err, ErrInvocationNotFound)
+
+	require.NoError(t, CleanInvocationLogs(dir))
+	invocations, err = ListInvocations(dir)
+	require.NoError(t, err)
+	assert.Empty(t, invocations)
+	assert.NoFileExists(t, logPath)
+}
+
+func TestListInvocationsSkipsCorruptedEntries(t *testing.T) {
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "20230101-100000-1.log")
+	require.NoError(t, os.WriteFile(logPath, nil, 0600))
+	require.NoError(t, appendInvocation(dir, Invocation{ID: "20230101-100000-1", LogFile: logPath}))
+	require.NoError(t, appendInvocation(dir, Invocation{ID: "removed", LogFile: filepath.Join(dir, "removed.log")}))
+
+	f, err := os.OpenFile(filepath.Join(dir, invocationIndexFile), os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = f.WriteString("{corrupted\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	invocations, err := ListInvocations(dir)
+	require.NoError(t, err)
+	require.Len(t, invocations, 1)
+	assert.Equal(t, "20230101-100000-1", invocations[0].ID)
+}
+
+func TestPruneInvocationLogs(t *testing.T) {
+	dir := t.TempDir()
+	for i := 0; i < 5; i++ {
+		id := fmt.Sprintf("20230101-10000%d-1", i)
+		logPath := filepath.Join(dir, id+invocationLogExt)
+		require.NoError(t, os.WriteFile(logPath, nil, 0600))
+		require.NoError(t, appendInvocation(dir, Invocation{ID: id, LogFile: logPath}))
+	}
+
+	require.NoError(t, pruneInvocationLogs(dir, 3))
+
+	assert.NoFileExists(t, filepath.Join(dir, "20230101-100000-1.log"))
+	assert.NoFileExists(t, filepath.Join(dir, "20230101-100001-1.log"))
+	assert.FileExists(t, filepath.Join(dir, "20230101-100004-1.log"))
+	invocations, err := ListInvocations(dir)
+	require.NoError(t, err)
+	assert.Len(t, invocations, 3)
+
+	content, err := os.ReadFile(filepath.Join(dir, invocationIndexFile))
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "20230101-100000-1")
+}
+
+func TestRedactArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected []string
+	}{
+		{
+			name:     "no sensitive flags",
+			args:     []string{"deploy", "--name", "movies", "-n", "test"},
+			expected: []string{"deploy", "--name", "movies", "-n", "test"},
+		},
+		{
+			name:     "token flag",
+			args:     []string{"context", "use", "https://okteto.example.com", "--token", "abc", "-l", "debug"},
+			expected: []string{"context", "use", "https://okteto.example.com", "--token", "***", "-l", "debug"},
+		},
+		{
+			name:     "token flag with equal",
+			args:     []string{"context", "--token=abc"},
+			expected: []string{"context", "--token=***"},
+		},
+		{
+			name:     "variables",
+			args:     []string{"deploy", "--var", "PASSWORD=abc", "-v=KEY=value", "-e", "NAME"},
+			expected: []string{"deploy", "--var", "PASSWORD=***", "-v=KEY=***", "-e", "NAME"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, RedactArgs(tt.args))
+		})
+	}
+}
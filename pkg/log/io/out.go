@@ -130,6 +130,21 @@ func (l *OutputController) Success(format string, args ...any) {
 	fmt.Fprint(l.out, string(bytes))
 }
 
+// Warning prints a warning message to the user
+func (l *OutputController) Warning(format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	msg = l.decorator.Warning(msg)
+	bytes, err := l.formatter.format(msg)
+	if err != nil {
+		return
+	}
+	if l.spinner != nil && l.spinner.isActive() {
+		l.spinner.Stop()
+		defer l.Spinner(l.spinner.getMessage()).Start()
+	}
+	fmt.Fprint(l.out, string(bytes))
+}
+
 // SetStage sets the stage of the logger if it's json
 func (l *OutputController) SetStage(stage string) {
 	if v, ok := l.formatter.(*jsonFormatter); ok {
@@ -157,6 +172,21 @@ func (l *OutputController) Spinner(msg string) OktetoSpinner {
 	return l.spinner
 }
 
+// ProgressBar returns a progress bar of a size in bytes
+func (l *OutputController) ProgressBar(msg string, total int64) OktetoProgressBar {
+	if l.spinner != nil && l.spinner.isActive() {
+		l.spinner.Stop()
+	}
+
+	disableSpinner := env.LoadBoolean(OktetoDisableSpinnerEnvVar)
+
+	_, isTTY := l.formatter.(*ttyFormatter)
+	if isTTY && !disableSpinner {
+		return newTTYProgressBar(l.out, msg, total)
+	}
+	return newNoProgressBar(l, msg, total)
+}
+
 // Write logs into the buffer but does not print anything
 func (l *OutputController) Write(p []byte) (n int, err error) {
 	msg := string(p)
@@ -0,0 +1,111 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package io
+
+import (
+	"fmt"
+	"io"
+	"sync/atomic"
+
+	"github.com/cheggaaa/pb/v3"
+	"github.com/docker/go-units"
+)
+
+// OktetoProgressBar is the interface for the progress bars
+type OktetoProgressBar interface {
+	Start()
+	Add(n int)
+	Finish()
+}
+
+// ttyProgressBar is the progress bar for the tty
+type ttyProgressBar struct {
+	bar *pb.ProgressBar
+}
+
+// newTTYProgressBar creates a new ttyProgressBar of a size in bytes
+func newTTYProgressBar(out io.Writer, message string, total int64) *ttyProgressBar {
+	bar := pb.New64(total)
+	bar.SetWriter(out)
+	bar.SetTemplate(pb.Simple)
+	bar.Set(pb.Bytes, true)
+	bar.Set("prefix", ucFirst(message))
+	return &ttyProgressBar{bar: bar}
+}
+
+// Start starts the progress bar
+func (p *ttyProgressBar) Start() {
+	p.bar.Start()
+}
+
+// Add increases the progress by n bytes
+func (p *ttyProgressBar) Add(n int) {
+	p.bar.Add(n)
+}
+
+// Finish stops the progress bar
+func (p *ttyProgressBar) Finish() {
+	p.bar.Finish()
+}
+
+// noProgressBar is the progress bar for the no tty modes. It prints a line when it starts and when it finishes
+type noProgressBar struct {
+	out     *OutputController
+	message string
+	total   int64
+	current int64
+}
+
+// newNoProgressBar creates a new noProgressBar of a size in bytes
+func newNoProgressBar(out *OutputController, message string, total int64) *noProgressBar {
+	return &noProgressBar{
+		out:     out,
+		message: ucFirst(message),
+		total:   total,
+	}
+}
+
+// Start prints the message of the progress bar
+func (p *noProgressBar) Start() {
+	p.out.Println(fmt.Sprintf("%s (%s)", p.message, units.BytesSize(float64(p.total))))
+}
+
+// Add increases the progress by n bytes
+func (p *noProgressBar) Add(n int) {
+	atomic.AddInt64(&p.current, int64(n))
+}
+
+// Finish prints the progress reached
+func (p *noProgressBar) Finish() {
+	p.out.Println(fmt.Sprintf("%s: %s / %s", p.message, units.BytesSize(float64(atomic.LoadInt64(&p.current))), units.BytesSize(float64(p.total))))
+}
+
+// progressReader increases a progress bar with the bytes read
+type progressReader struct {
+	r   io.Reader
+	bar OktetoProgressBar
+}
+
+// NewProgressReader returns a reader that increases the progress bar with the bytes read from r
+func NewProgressReader(r io.Reader, bar OktetoProgressBar) io.Reader {
+	return &progressReader{r: r, bar: bar}
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 {
+		r.bar.Add(n)
+	}
+	return n, err
+}
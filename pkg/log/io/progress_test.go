@@ -0,0 +1,64 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package io
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressBar(t *testing.T) {
+	buffer := bytes.NewBuffer([]byte{})
+	l := newOutputController(buffer)
+
+	require.IsType(t, &ttyProgressBar{}, l.ProgressBar("uploading", 10))
+
+	t.Setenv(OktetoDisableSpinnerEnvVar, "true")
+	require.IsType(t, &noProgressBar{}, l.ProgressBar("uploading", 10))
+
+	t.Setenv(OktetoDisableSpinnerEnvVar, "false")
+	l.SetOutputFormat("plain")
+	require.IsType(t, &noProgressBar{}, l.ProgressBar("uploading", 10))
+}
+
+func TestNoProgressBar(t *testing.T) {
+	buffer := bytes.NewBuffer([]byte{})
+	l := newOutputController(buffer)
+	l.SetOutputFormat("plain")
+
+	bar := l.ProgressBar("uploading build context", 2048)
+	bar.Start()
+	n, err := io.Copy(io.Discard, NewProgressReader(strings.NewReader(strings.Repeat("a", 1024)), bar))
+	require.NoError(t, err)
+	require.Equal(t, int64(1024), n)
+	bar.Finish()
+
+	require.Equal(t, "Uploading build context (2KiB)\nUploading build context: 1KiB / 2KiB\n", buffer.String())
+}
+
+func TestTTYProgressBar(t *testing.T) {
+	buffer := bytes.NewBuffer([]byte{})
+	bar := newTTYProgressBar(buffer, "uploading build context", 1024)
+	bar.Start()
+	_, err := io.Copy(io.Discard, NewProgressReader(strings.NewReader(strings.Repeat("a", 1024)), bar))
+	require.NoError(t, err)
+	bar.Finish()
+
+	require.Contains(t, buffer.String(), "Uploading build context")
+	require.Contains(t, buffer.String(), "100.00%")
+}
@@ -25,6 +25,8 @@ import (
 
 const (
 	// OktetoDisableSpinnerEnvVar if true spinner is disabled
+	//
+	// Deprecated: use config.EnvOktetoDisableSpinner.Name. This package can't import config, so the name is repeated here
 	OktetoDisableSpinnerEnvVar = "OKTETO_DISABLE_SPINNER"
 
 	// spinnerAndWhitespaceCharCount is the number of characters that the spinner and the whitespace take
@@ -14,11 +14,11 @@
 package log
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
-	"time"
 	"unicode"
 
 	"github.com/sirupsen/logrus"
@@ -26,11 +26,14 @@ import (
 
 // JSONWriter writes into a JSON terminal
 type JSONWriter struct {
-	out  *logrus.Logger
-	file *logrus.Entry
+	out    *logrus.Logger
+	file   *logrus.Entry
+	buf    *bytes.Buffer
+	logger *Logger
 }
 
-type jsonMessage struct {
+// JSONMessage represents a line of the json output and of the output buffer
+type JSONMessage struct {
 	Level     string `json:"level"`
 	Stage     string `json:"stage"`
 	Message   string `json:"message"`
@@ -43,18 +46,24 @@ type JSONLogFormat struct {
 	Stage     string `json:"stage"`
 	Message   string `json:"message"`
 	Timestamp int64  `json:"timestamp"`
+
+	logger *Logger
 }
 
 // Format formats the message
-func (*JSONLogFormat) Format(entry *logrus.Entry) ([]byte, error) {
+func (f *JSONLogFormat) Format(entry *logrus.Entry) ([]byte, error) {
+	logger := f.logger
+	if logger == nil {
+		logger = log
+	}
 	level := strings.ToLower(entry.Level.String())
 	if entry.Level == logrus.WarnLevel {
 		level = "info"
 	}
-	outputJSON := &jsonMessage{
+	outputJSON := &JSONMessage{
 		Level:     level,
-		Timestamp: time.Now().Unix(),
-		Stage:     log.stage,
+		Timestamp: logger.now().Unix(),
+		Stage:     logger.stage,
 		Message:   entry.Message,
 	}
 	messageJSON, err := json.Marshal(outputJSON)
@@ -66,105 +75,107 @@ func (*JSONLogFormat) Format(entry *logrus.Entry) ([]byte, error) {
 }
 
 // newJSONWriter creates a new JSONWriter
-func newJSONWriter(out *logrus.Logger, file *logrus.Entry) *JSONWriter {
+func newJSONWriter(l *Logger) *JSONWriter {
 	return &JSONWriter{
-		out:  out,
-		file: file,
+		out:    l.out,
+		file:   l.file,
+		buf:    l.buf,
+		logger: l,
 	}
 }
 
 // Debug writes a debug-level log
 func (w *JSONWriter) Debug(args ...interface{}) {
 	w.out.Debug(args...)
-	if log.file != nil {
-		log.file.Debug(args...)
+	if w.file != nil {
+		w.file.Debug(args...)
 	}
 }
 
 // Debugf writes a debug-level log with a format
-func (*JSONWriter) Debugf(format string, args ...interface{}) {
-	log.out.Debugf(format, args...)
-	if log.file != nil {
-		log.file.Debugf(format, args...)
+func (w *JSONWriter) Debugf(format string, args ...interface{}) {
+	w.out.Debugf(format, args...)
+	if w.file != nil {
+		w.file.Debugf(format, args...)
 	}
 }
 
 // Info writes a info-level log
-func (*JSONWriter) Info(args ...interface{}) {
-	log.out.Info(args...)
-	if log.file != nil {
-		log.file.Info(args...)
+func (w *JSONWriter) Info(args ...interface{}) {
+	w.out.Info(args...)
+	if w.file != nil {
+		w.file.Info(args...)
 	}
 }
 
 // Infof writes a info-level log with a format
-func (*JSONWriter) Infof(format string, args ...interface{}) {
-	log.out.Infof(format, args...)
-	if log.file != nil {
-		log.file.Infof(format, args...)
+func (w *JSONWriter) Infof(format string, args ...interface{}) {
+	w.out.Infof(format, args...)
+	if w.file != nil {
+		w.file.Infof(format, args...)
 	}
 }
 
 // Error writes a error-level log
-func (*JSONWriter) Error(args ...interface{}) {
-	log.out.Error(args...)
-	if log.file != nil {
-		log.file.Error(args...)
+func (w *JSONWriter) Error(args ...interface{}) {
+	w.out.Error(args...)
+	if w.file != nil {
+		w.file.Error(args...)
 	}
 }
 
 // Errorf writes a error-level log with a format
-func (*JSONWriter) Errorf(format string, args ...interface{}) {
-	log.out.Errorf(format, args...)
-	if log.file != nil {
-		log.file.Errorf(format, args...)
+func (w *JSONWriter) Errorf(format string, args ...interface{}) {
+	w.out.Errorf(format, args...)
+	if w.file != nil {
+		w.file.Errorf(format, args...)
 	}
 }
 
 // Fatalf writes a error-level log with a format
-func (*JSONWriter) Fatalf(format string, args ...interface{}) {
-	if log.file != nil {
-		log.file.Errorf(format, args...)
+func (w *JSONWriter) Fatalf(format string, args ...interface{}) {
+	if w.file != nil {
+		w.file.Errorf(format, args...)
 	}
 
-	log.out.Fatalf(format, args...)
+	w.out.Fatalf(format, args...)
 }
 
 // Green writes a line in green
 func (w *JSONWriter) Green(format string, args ...interface{}) {
-	log.out.Infof(format, args...)
+	w.out.Infof(format, args...)
 	w.FPrintln(w.out.Out, fmt.Sprintf(format, args...))
 }
 
 // Yellow writes a line in yellow
 func (w *JSONWriter) Yellow(format string, args ...interface{}) {
-	log.out.Infof(format, args...)
+	w.out.Infof(format, args...)
 	w.FPrintln(w.out.Out, fmt.Sprintf(format, args...))
 }
 
 // Success prints a message with the success symbol first, and the text in green
 func (w *JSONWriter) Success(format string, args ...interface{}) {
-	log.out.Infof(format, args...)
+	w.out.Infof(format, args...)
 	w.FPrintln(w.out.Out, fmt.Sprintf("%s %s", successSymbol, fmt.Sprintf(format, args...)))
 }
 
 // Information prints a message with the information symbol first, and the text in blue
 func (w *JSONWriter) Information(format string, args ...interface{}) {
-	log.out.Infof(format, args...)
+	w.out.Infof(format, args...)
 	w.FPrintln(w.out.Out, fmt.Sprintf("%s %s", informationSymbol, fmt.Sprintf(format, args...)))
 }
 
 // Question prints a message with the question symbol first, and the text in magenta
-func (*JSONWriter) Question(_ string, _ ...interface{}) error {
+func (w *JSONWriter) Question(_ string, _ ...interface{}) error {
 	return fmt.Errorf("can't ask questions on json mode")
 }
 
 // Warning prints a message with the warning symbol first, and the text in yellow
 func (w *JSONWriter) Warning(format string, args ...interface{}) {
-	log.out.Infof(format, args...)
+	w.out.Infof(format, args...)
 	msg := fmt.Sprintf("%s %s", warningSymbol, fmt.Sprintf(format, args...))
 	if msg != "" {
-		msg := convertToJSON("warn", log.stage, msg)
+		msg := w.logger.convertToJSON("warn", msg)
 		if msg != "" {
 			fmt.Fprintln(w.out.Out, msg)
 		}
@@ -172,11 +183,11 @@ func (w *JSONWriter) Warning(format string, args ...interface{}) {
 }
 
 // FWarning prints a message with the warning symbol first, and the text in yellow
-func (*JSONWriter) FWarning(writer io.Writer, format string, args ...interface{}) {
-	log.out.Infof(format, args...)
+func (w *JSONWriter) FWarning(writer io.Writer, format string, args ...interface{}) {
+	w.out.Infof(format, args...)
 	msg := fmt.Sprintf("%s %s", warningSymbol, fmt.Sprintf(format, args...))
 	if msg != "" {
-		msg := convertToJSON("warn", log.stage, msg)
+		msg := w.logger.convertToJSON("warn", msg)
 		if msg != "" {
 			fmt.Fprintln(writer, msg)
 		}
@@ -185,22 +196,21 @@ func (*JSONWriter) FWarning(writer io.Writer, format string, args ...interface{}
 
 // Hint prints a message with the text in blue
 func (w *JSONWriter) Hint(format string, args ...interface{}) {
-	log.out.Infof(format, args...)
+	w.out.Infof(format, args...)
 	w.FPrintln(w.out.Out, fmt.Sprintf(format, args...))
 }
 
 // Fail prints a message with the error symbol first, and the text in red
 func (w *JSONWriter) Fail(format string, args ...interface{}) {
-	log.out.Infof(format, args...)
+	w.out.Infof(format, args...)
 	msg := fmt.Sprintf("%s %s", errorSymbol, fmt.Sprintf(format, args...))
 	if msg != "" {
-		if log.stage == "" {
-			log.stage = "Internal server error"
+		if w.logger.stage == "" {
+			w.logger.stage = "Internal server error"
 		}
-		msg = convertToJSON(ErrorLevel, log.stage, msg)
+		msg = w.logger.convertToJSON(ErrorLevel, msg)
 		if msg != "" {
-			log.buf.WriteString(msg)
-			log.buf.WriteString("\n")
+			writeLine(w.buf, msg)
 			fmt.Fprintln(w.out.Out, msg)
 		}
 	}
@@ -219,10 +229,9 @@ func (w *JSONWriter) Fprintf(writer io.Writer, format string, a ...interface{})
 		return
 	}
 	if msg != "" && writer == w.out.Out {
-		msg = convertToJSON(InfoLevel, log.stage, msg)
+		msg = w.logger.convertToJSON(InfoLevel, msg)
 		if msg != "" {
-			log.buf.WriteString(msg)
-			log.buf.WriteString("\n")
+			writeLine(w.buf, msg)
 		}
 		fmt.Fprint(writer, msg)
 	}
@@ -233,10 +242,9 @@ func (w *JSONWriter) Fprintf(writer io.Writer, format string, a ...interface{})
 func (w *JSONWriter) FPrintln(writer io.Writer, args ...interface{}) {
 	msg := fmt.Sprint(args...)
 	if msg != "" && writer == w.out.Out {
-		msg = convertToJSON(InfoLevel, log.stage, msg)
+		msg = w.logger.convertToJSON(InfoLevel, msg)
 		if msg != "" {
-			log.buf.WriteString(msg)
-			log.buf.WriteString("\n")
+			writeLine(w.buf, msg)
 			fmt.Fprintln(writer, msg)
 		}
 
@@ -245,10 +253,9 @@ func (w *JSONWriter) FPrintln(writer io.Writer, args ...interface{}) {
 
 // Print writes a line with colors
 func (w *JSONWriter) Print(args ...interface{}) {
-	msg := convertToJSON(InfoLevel, log.stage, fmt.Sprint(args...))
+	msg := w.logger.convertToJSON(InfoLevel, fmt.Sprint(args...))
 	if msg != "" {
-		log.buf.WriteString(msg)
-		log.buf.WriteString("\n")
+		writeLine(w.buf, msg)
 		fmt.Fprint(w.out.Out, msg)
 	}
 
@@ -260,20 +267,21 @@ func (w *JSONWriter) Printf(format string, a ...interface{}) {
 }
 
 // IsInteractive checks if the writer is interactive
-func (*JSONWriter) IsInteractive() bool {
+func (w *JSONWriter) IsInteractive() bool {
 	return false
 }
 
-func convertToJSON(level, stage, message string) string {
+// convertToJSON returns the message as a json line of the current stage of the logger
+func (l *Logger) convertToJSON(level, message string) string {
 	message = strings.TrimRightFunc(message, unicode.IsSpace)
-	if stage == "" || message == "" {
+	if l.stage == "" || message == "" {
 		return ""
 	}
-	messageStruct := jsonMessage{
+	messageStruct := JSONMessage{
 		Level:     level,
 		Message:   ansiRegex.ReplaceAllString(message, ""),
-		Stage:     stage,
-		Timestamp: time.Now().Unix(),
+		Stage:     l.stage,
+		Timestamp: l.now().Unix(),
 	}
 	messageJSON, err := json.Marshal(messageStruct)
 	if err != nil {
@@ -286,10 +294,9 @@ func convertToJSON(level, stage, message string) string {
 // AddToBuffer logs into the buffer and writes to stdout if its a json writer
 func (w *JSONWriter) AddToBuffer(level, format string, a ...interface{}) {
 	msg := fmt.Sprintf(format, a...)
-	msg = convertToJSON(level, log.stage, msg)
+	msg = w.logger.convertToJSON(level, msg)
 	if msg != "" {
-		log.buf.WriteString(msg)
-		log.buf.WriteString("\n")
+		writeLine(w.buf, msg)
 		fmt.Fprintln(w.out.Out, msg)
 	}
 }
@@ -297,7 +304,7 @@ func (w *JSONWriter) AddToBuffer(level, format string, a ...interface{}) {
 // Write logs into the buffer but does not print anything
 func (w *JSONWriter) Write(p []byte) (n int, err error) {
 	msg := string(p)
-	msg = convertToJSON(InfoLevel, log.stage, msg)
+	msg = w.logger.convertToJSON(InfoLevel, msg)
 	if msg != "" {
 		if _, err := w.out.Out.Write([]byte("")); err != nil {
 			return 0, err
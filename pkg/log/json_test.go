@@ -16,6 +16,7 @@ package log
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -31,7 +32,7 @@ func Test_ConvertToJson(t *testing.T) {
 		level    string
 		message  string
 		err      error
-		expected jsonMessage
+		expected JSONMessage
 	}{
 		{
 			name:    "empty stage",
@@ -39,7 +40,7 @@ func Test_ConvertToJson(t *testing.T) {
 			stage:   "",
 			message: "foobar",
 			err:     &json.SyntaxError{},
-			expected: jsonMessage{
+			expected: JSONMessage{
 				Timestamp: mockedTimestamp,
 			},
 		},
@@ -49,7 +50,7 @@ func Test_ConvertToJson(t *testing.T) {
 			stage:   defaultStage,
 			message: "",
 			err:     &json.SyntaxError{},
-			expected: jsonMessage{
+			expected: JSONMessage{
 				Timestamp: mockedTimestamp,
 			},
 		},
@@ -58,7 +59,7 @@ func Test_ConvertToJson(t *testing.T) {
 			level:   defaultLevel,
 			stage:   defaultStage,
 			message: "foobar",
-			expected: jsonMessage{
+			expected: JSONMessage{
 				Level:     defaultLevel,
 				Stage:     defaultStage,
 				Message:   "foobar",
@@ -69,7 +70,7 @@ func Test_ConvertToJson(t *testing.T) {
 			level:   defaultLevel,
 			stage:   defaultStage,
 			message: " \t\nsome indented line",
-			expected: jsonMessage{
+			expected: JSONMessage{
 				Level:     defaultLevel,
 				Stage:     defaultStage,
 				Message:   " \t\nsome indented line",
@@ -80,7 +81,7 @@ func Test_ConvertToJson(t *testing.T) {
 			level:   defaultLevel,
 			stage:   defaultStage,
 			message: "  some indented line \t\n",
-			expected: jsonMessage{
+			expected: JSONMessage{
 				Level:     defaultLevel,
 				Stage:     defaultStage,
 				Message:   "  some indented line",
@@ -91,8 +92,9 @@ func Test_ConvertToJson(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := convertToJSON(tt.level, tt.stage, tt.message)
-			var resultJSON jsonMessage
+			l := &Logger{stage: tt.stage, now: time.Now}
+			s := l.convertToJSON(tt.level, tt.message)
+			var resultJSON JSONMessage
 			err := json.Unmarshal([]byte(s), &resultJSON)
 			if err != nil {
 				assert.ErrorAs(t, err, &tt.err)
@@ -18,16 +18,14 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"path/filepath"
 	"runtime"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
-	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
-	lumberjack "gopkg.in/natefinch/lumberjack.v2"
 )
 
 var (
@@ -64,7 +62,8 @@ var (
 	DebugLevel = "debug"
 )
 
-type logger struct {
+// Logger writes the messages of the okteto commands. Use NewLogger to build one and SetLogger to make it the logger of the package
+type Logger struct {
 	writer OktetoWriter
 	out    *logrus.Logger
 	file   *logrus.Entry
@@ -72,15 +71,18 @@ type logger struct {
 	buf      *bytes.Buffer
 	replacer *strings.Replacer
 	spinner  *spinnerLogger
+	now      func() time.Time
 
 	stage      string
 	outputMode string
 
 	maskedWords []string
 	isMasked    bool
+
+	spinnerDisabled bool
 }
 
-var log = &logger{
+var log = &Logger{
 	out: logrus.New(),
 }
 
@@ -93,42 +95,7 @@ func init() {
 
 // Init configures the logger for the package to use.
 func Init(level logrus.Level) {
-	log.out.SetOutput(os.Stdout)
-	log.out.SetLevel(level)
-	log.writer = log.getWriter(TTYFormat)
-	log.maskedWords = []string{}
-	log.buf = &bytes.Buffer{}
-	log.spinner = &spinnerLogger{
-		sp:             newSpinner(),
-		spinnerSupport: !loadBool(OktetoDisableSpinnerEnvVar) && IsInteractive(),
-	}
-}
-
-// ConfigureFileLogger configures the file to write
-func ConfigureFileLogger(dir, version string) {
-	fileLogger := logrus.New()
-	fileLogger.SetFormatter(&logrus.TextFormatter{
-		DisableColors: true,
-		FullTimestamp: true,
-	})
-
-	logPath := filepath.Join(dir, "okteto.log")
-	rolling := getRollingLog(logPath)
-	fileLogger.SetOutput(rolling)
-	fileLogger.SetLevel(logrus.DebugLevel)
-
-	actionID := uuid.New().String()
-	log.file = fileLogger.WithFields(logrus.Fields{"action": actionID, "version": version})
-}
-
-func getRollingLog(path string) io.Writer {
-	return &lumberjack.Logger{
-		Filename:   path,
-		MaxSize:    1, // megabytes
-		MaxBackups: 10,
-		MaxAge:     28, // days
-		Compress:   true,
-	}
+	SetLogger(NewLogger(WithLevel(level)))
 }
 
 // SetLevel sets the level of the main logger
@@ -155,15 +122,16 @@ func GetOutput() io.Writer {
 	return log.out.Out
 }
 
-// SetOutput sets the log output
+// SetOutput sets the log output, the spinner is displayed in the same output
 func SetOutput(output io.Writer) {
 	log.out.SetOutput(output)
+	log.spinner.sp.Writer = output
 }
 
 // SetOutputFormat sets the output format
 func SetOutputFormat(format string) {
 	log.writer = log.getWriter(format)
-	log.spinner.spinnerSupport = !loadBool(OktetoDisableSpinnerEnvVar) && IsInteractive()
+	log.spinner.spinnerSupport = log.hasSpinnerSupport()
 }
 
 // GetOutputWriter sets the output format
@@ -171,8 +139,17 @@ func GetOutputWriter() OktetoWriter {
 	return log.writer
 }
 
+// SetOutputWriter replaces the writer of the logger. It is meant to plug in test doubles like the ones in pkg/log/fake
+func SetOutputWriter(w OktetoWriter) {
+	log.writer = w
+	log.spinner.spinnerSupport = log.hasSpinnerSupport()
+}
+
 // SetStage sets the stage of the logger
 func SetStage(stage string) {
+	if w, ok := log.writer.(stageWriter); ok {
+		w.changeStage(log.stage, stage)
+	}
 	log.stage = stage
 }
 
@@ -236,6 +213,11 @@ func RedString(format string, args ...interface{}) string {
 	return redString(format, args...)
 }
 
+// GreenString returns a string in green
+func GreenString(format string, args ...interface{}) string {
+	return greenString(format, args...)
+}
+
 // BlueBackgroundString returns a string in a blue background
 func BlueBackgroundString(format string, args ...interface{}) string {
 	return blueString(format, args...)
@@ -313,23 +295,36 @@ func IsInteractive() bool {
 
 // AddMaskedWord adds a new word to be redacted
 func AddMaskedWord(word string) {
-	if strings.TrimSpace(word) != "" {
-		log.maskedWords = append(log.maskedWords, word)
+	if strings.TrimSpace(word) == "" {
+		return
+	}
+	log.maskedWords = append(log.maskedWords, word)
+	if log.isMasked {
+		log.replacer = newMaskReplacer(log.maskedWords)
 	}
 }
 
 // EnableMasking starts redacting all variables
 func EnableMasking() {
 	log.isMasked = true
-	sort.Slice(log.maskedWords, func(i, j int) bool {
-		return len(log.maskedWords[i]) > len(log.maskedWords[j])
+	log.replacer = newMaskReplacer(log.maskedWords)
+}
+
+func newMaskReplacer(words []string) *strings.Replacer {
+	sort.Slice(words, func(i, j int) bool {
+		return len(words[i]) > len(words[j])
 	})
 	oldnew := []string{}
-	for _, maskWord := range log.maskedWords {
+	for _, maskWord := range words {
 		oldnew = append(oldnew, maskWord)
 		oldnew = append(oldnew, "***")
 	}
-	log.replacer = strings.NewReplacer(oldnew...)
+	return strings.NewReplacer(oldnew...)
+}
+
+// Redact replaces the masked words of a message even if masking is disabled
+func Redact(message string) string {
+	return newMaskReplacer(log.maskedWords).Replace(message)
 }
 
 // DisableMasking will stop showing secrets and vars
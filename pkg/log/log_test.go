@@ -104,3 +104,22 @@ func TestSetOutputFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestAddMaskedWordWhileMasking(t *testing.T) {
+	previousWords := log.maskedWords
+	previousMasked := log.isMasked
+	previousReplacer := log.replacer
+	t.Cleanup(func() {
+		log.maskedWords = previousWords
+		log.isMasked = previousMasked
+		log.replacer = previousReplacer
+	})
+	log.maskedWords = []string{}
+
+	EnableMasking()
+	AddMaskedWord("my-secret-value")
+	assert.Equal(t, "token: ***", redactMessage("token: my-secret-value"))
+
+	DisableMasking()
+	assert.Equal(t, "token: my-secret-value", redactMessage("token: my-secret-value"))
+}
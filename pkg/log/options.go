@@ -0,0 +1,132 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// spinnerDisabled is the default of the loggers created without WithSpinnerDisabled
+var spinnerDisabled bool
+
+// Option configures a logger built by NewLogger
+type Option func(*loggerOptions)
+
+type loggerOptions struct {
+	output         io.Writer
+	file           *logrus.Entry
+	now            func() time.Time
+	disableSpinner *bool
+	format         string
+	level          logrus.Level
+}
+
+// WithOutput sets the writer where the messages are written. Defaults to stdout
+func WithOutput(w io.Writer) Option {
+	return func(o *loggerOptions) {
+		o.output = w
+	}
+}
+
+// WithFormat sets the format of the messages: tty, plain, json or silent. Defaults to tty
+func WithFormat(format string) Option {
+	return func(o *loggerOptions) {
+		o.format = format
+	}
+}
+
+// WithLevel sets the level of the messages written to the output. Defaults to warn
+func WithLevel(level logrus.Level) Option {
+	return func(o *loggerOptions) {
+		o.level = level
+	}
+}
+
+// WithFile writes every message to a file logger too, regardless of the level of the output
+func WithFile(file *logrus.Entry) Option {
+	return func(o *loggerOptions) {
+		o.file = file
+	}
+}
+
+// WithSpinnerDisabled disables the spinner. Defaults to the value set with SetSpinnerDisabled
+func WithSpinnerDisabled(disabled bool) Option {
+	return func(o *loggerOptions) {
+		o.disableSpinner = &disabled
+	}
+}
+
+// WithClock sets the function returning the time of the messages that include it, like the json ones
+func WithClock(now func() time.Time) Option {
+	return func(o *loggerOptions) {
+		o.now = now
+	}
+}
+
+// NewLogger returns a logger configured with the given options
+func NewLogger(opts ...Option) *Logger {
+	options := &loggerOptions{
+		output: os.Stdout,
+		format: TTYFormat,
+		level:  logrus.WarnLevel,
+		now:    time.Now,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if options.disableSpinner == nil {
+		disabled := spinnerDisabled
+		options.disableSpinner = &disabled
+	}
+
+	out := logrus.New()
+	out.SetOutput(options.output)
+	out.SetLevel(options.level)
+	l := &Logger{
+		out:             out,
+		file:            options.file,
+		now:             options.now,
+		maskedWords:     []string{},
+		buf:             &bytes.Buffer{},
+		spinnerDisabled: *options.disableSpinner,
+	}
+	l.writer = l.getWriter(options.format)
+	l.spinner = &spinnerLogger{
+		sp:             newSpinner(),
+		spinnerSupport: l.hasSpinnerSupport(),
+	}
+	return l
+}
+
+// SetSpinnerDisabled disables the spinner of the logger of the package and of the loggers created afterwards without WithSpinnerDisabled
+func SetSpinnerDisabled(disabled bool) {
+	spinnerDisabled = disabled
+	log.spinnerDisabled = disabled
+	log.spinner.spinnerSupport = log.hasSpinnerSupport()
+}
+
+// SetLogger replaces the logger used by the functions of the package
+func SetLogger(l *Logger) {
+	log = l
+}
+
+// hasSpinnerSupport returns if the spinner can be shown with the current writer
+func (l *Logger) hasSpinnerSupport() bool {
+	return !l.spinnerDisabled && l.writer.IsInteractive()
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLogger(t *testing.T) {
+	SetSpinnerDisabled(true)
+	t.Cleanup(func() {
+		SetSpinnerDisabled(false)
+	})
+	l := NewLogger()
+	assert.IsType(t, &TTYWriter{}, l.writer)
+	assert.Equal(t, logrus.WarnLevel, l.out.GetLevel())
+	assert.True(t, l.spinnerDisabled)
+	assert.False(t, l.spinner.spinnerSupport)
+
+	l = NewLogger(WithSpinnerDisabled(false), WithFormat(PlainFormat), WithLevel(logrus.DebugLevel))
+	assert.IsType(t, &PlainWriter{}, l.writer)
+	assert.Equal(t, logrus.DebugLevel, l.out.GetLevel())
+	assert.False(t, l.spinnerDisabled)
+}
+
+func TestNewLoggerWithOptions(t *testing.T) {
+	previous := log
+	t.Cleanup(func() {
+		SetLogger(previous)
+	})
+
+	var buf bytes.Buffer
+	now := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	SetLogger(NewLogger(
+		WithOutput(&buf),
+		WithFormat(JSONFormat),
+		WithLevel(logrus.InfoLevel),
+		WithClock(func() time.Time { return now }),
+		WithSpinnerDisabled(true),
+	))
+	SetStage("build")
+	Information("image built")
+
+	assert.Contains(t, buf.String(), `{"level":"info","stage":"build","message":"image built","timestamp":1696154400}`)
+}
+
+func TestNewLoggerWritesWithItsOwnOptions(t *testing.T) {
+	var out, file bytes.Buffer
+	fileLogger := logrus.New()
+	fileLogger.SetOutput(&file)
+	now := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+
+	l := NewLogger(
+		WithOutput(&out),
+		WithFormat(JSONFormat),
+		WithLevel(logrus.InfoLevel),
+		WithFile(logrus.NewEntry(fileLogger)),
+		WithClock(func() time.Time { return now }),
+		WithSpinnerDisabled(true),
+	)
+	l.stage = "build"
+	l.writer.Infof("image %s", "built")
+
+	assert.Equal(t, "{\"level\":\"info\",\"stage\":\"build\",\"message\":\"image built\",\"timestamp\":1696154400}\n", out.String())
+	assert.Contains(t, file.String(), "image built")
+	assert.NotSame(t, l, log)
+}
@@ -14,6 +14,7 @@
 package log
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 
@@ -22,134 +23,137 @@ import (
 
 // PlainWriter writes into a plain terminal
 type PlainWriter struct {
-	out  *logrus.Logger
-	file *logrus.Entry
+	out    *logrus.Logger
+	file   *logrus.Entry
+	buf    *bytes.Buffer
+	logger *Logger
 }
 
 // newPlainWriter creates a new plainWriter
-func newPlainWriter(out *logrus.Logger, file *logrus.Entry) *PlainWriter {
+func newPlainWriter(l *Logger) *PlainWriter {
 	return &PlainWriter{
-		out:  out,
-		file: file,
+		out:    l.out,
+		file:   l.file,
+		buf:    l.buf,
+		logger: l,
 	}
 }
 
 // Debug writes a debug-level log
 func (w *PlainWriter) Debug(args ...interface{}) {
 	w.out.Debug(args...)
-	if log.file != nil {
-		log.file.Debug(args...)
+	if w.file != nil {
+		w.file.Debug(args...)
 	}
 }
 
 // Debugf writes a debug-level log with a format
-func (*PlainWriter) Debugf(format string, args ...interface{}) {
-	log.out.Debugf(format, args...)
-	if log.file != nil {
-		log.file.Debugf(format, args...)
+func (w *PlainWriter) Debugf(format string, args ...interface{}) {
+	w.out.Debugf(format, args...)
+	if w.file != nil {
+		w.file.Debugf(format, args...)
 	}
 }
 
 // Info writes a info-level log
-func (*PlainWriter) Info(args ...interface{}) {
-	log.out.Info(args...)
-	if log.file != nil {
-		log.file.Info(args...)
+func (w *PlainWriter) Info(args ...interface{}) {
+	w.out.Info(args...)
+	if w.file != nil {
+		w.file.Info(args...)
 	}
 }
 
 // Infof writes a info-level log with a format
-func (*PlainWriter) Infof(format string, args ...interface{}) {
-	log.out.Infof(format, args...)
-	if log.file != nil {
-		log.file.Infof(format, args...)
+func (w *PlainWriter) Infof(format string, args ...interface{}) {
+	w.out.Infof(format, args...)
+	if w.file != nil {
+		w.file.Infof(format, args...)
 	}
 }
 
 // Error writes a error-level log
-func (*PlainWriter) Error(args ...interface{}) {
-	log.out.Error(args...)
-	if log.file != nil {
-		log.file.Error(args...)
+func (w *PlainWriter) Error(args ...interface{}) {
+	w.out.Error(args...)
+	if w.file != nil {
+		w.file.Error(args...)
 	}
 }
 
 // Errorf writes a error-level log with a format
-func (*PlainWriter) Errorf(format string, args ...interface{}) {
-	log.out.Errorf(format, args...)
-	if log.file != nil {
-		log.file.Errorf(format, args...)
+func (w *PlainWriter) Errorf(format string, args ...interface{}) {
+	w.out.Errorf(format, args...)
+	if w.file != nil {
+		w.file.Errorf(format, args...)
 	}
 }
 
 // Fatalf writes a error-level log with a format
-func (*PlainWriter) Fatalf(format string, args ...interface{}) {
-	if log.file != nil {
-		log.file.Errorf(format, args...)
+func (w *PlainWriter) Fatalf(format string, args ...interface{}) {
+	if w.file != nil {
+		w.file.Errorf(format, args...)
 	}
 
-	log.out.Fatalf(format, args...)
+	w.out.Fatalf(format, args...)
 }
 
 // Green writes a line in green
 func (w *PlainWriter) Green(format string, args ...interface{}) {
-	log.out.Infof(format, args...)
+	w.out.Infof(format, args...)
 	w.FPrintln(w.out.Out, fmt.Sprintf(format, args...))
 }
 
 // Yellow writes a line in yellow
 func (w *PlainWriter) Yellow(format string, args ...interface{}) {
-	log.out.Infof(format, args...)
+	w.out.Infof(format, args...)
 	w.FPrintln(w.out.Out, fmt.Sprintf(format, args...))
 }
 
 // Success prints a message with the success symbol first, and the text in green
 func (w *PlainWriter) Success(format string, args ...interface{}) {
-	log.out.Infof(format, args...)
+	w.out.Infof(format, args...)
 	w.Fprintf(w.out.Out, "SUCCESS: %s\n", fmt.Sprintf(format, args...))
 }
 
 // Information prints a message with the information symbol first, and the text in blue
 func (w *PlainWriter) Information(format string, args ...interface{}) {
-	log.out.Infof(format, args...)
+	w.out.Infof(format, args...)
 	w.Fprintf(w.out.Out, "INFO: %s\n", fmt.Sprintf(format, args...))
 }
 
 // Question prints a message with the question symbol first, and the text in magenta
 func (w *PlainWriter) Question(format string, args ...interface{}) error {
-	log.out.Infof(format, args...)
+	w.out.Infof(format, args...)
 	w.Fprintf(w.out.Out, "%s %s", questionSymbol, fmt.Sprintf(format, args...))
 	return nil
 }
 
 // Warning prints a message with the warning symbol first, and the text in yellow
 func (w *PlainWriter) Warning(format string, args ...interface{}) {
-	log.out.Infof(format, args...)
+	w.out.Infof(format, args...)
 	w.Fprintf(w.out.Out, "WARNING: %s\n", fmt.Sprintf(format, args...))
 }
 
 // FWarning prints a message with the warning symbol first, and the text in yellow into an specific writer
 func (w *PlainWriter) FWarning(writer io.Writer, format string, args ...interface{}) {
-	log.out.Infof(format, args...)
+	w.out.Infof(format, args...)
 	w.Fprintf(writer, "WARNING: %s\n", fmt.Sprintf(format, args...))
 }
 
 // Hint prints a message with the text in blue
 func (w *PlainWriter) Hint(format string, args ...interface{}) {
-	log.out.Infof(format, args...)
+	w.out.Infof(format, args...)
 	w.Fprintf(w.out.Out, "%s\n", fmt.Sprintf(format, args...))
 }
 
 // Fail prints a message with the error symbol first, and the text in red
 func (w *PlainWriter) Fail(format string, args ...interface{}) {
 	msg := fmt.Sprintf(format, args...)
-	log.out.Info(msg)
+	w.out.Info(msg)
 	w.Fprintf(w.out.Out, "ERROR: %s\n", fmt.Sprintf(format, args...))
 	if msg != "" {
-		msg = convertToJSON(ErrorLevel, log.stage, msg)
+		msg = w.logger.convertToJSON(ErrorLevel, msg)
 		if msg != "" {
-			log.buf.WriteString(msg)
-			log.buf.WriteString("\n")
+			writeLine(w.buf, msg)
 		}
 	}
 }
@@ -157,13 +161,12 @@ func (w *PlainWriter) Fail(format string, args ...interface{}) {
 // Println writes a line with colors
 func (w *PlainWriter) Println(args ...interface{}) {
 	msg := fmt.Sprint(args...)
-	log.out.Info(msg)
+	w.out.Info(msg)
 	w.FPrintln(w.out.Out, args...)
 	if msg != "" {
-		msg = convertToJSON(InfoLevel, log.stage, msg)
+		msg = w.logger.convertToJSON(InfoLevel, msg)
 		if msg != "" {
-			log.buf.WriteString(msg)
-			log.buf.WriteString("\n")
+			writeLine(w.buf, msg)
 		}
 	}
 }
@@ -173,9 +176,8 @@ func (w *PlainWriter) Fprintf(writer io.Writer, format string, a ...interface{})
 	msg := fmt.Sprintf(format, a...)
 	fmt.Fprint(writer, msg)
 	if msg != "" && writer == w.out.Out {
-		msg = convertToJSON(InfoLevel, log.stage, msg)
-		log.buf.WriteString(msg)
-		log.buf.WriteString("\n")
+		msg = w.logger.convertToJSON(InfoLevel, msg)
+		writeLine(w.buf, msg)
 	}
 }
 
@@ -184,10 +186,9 @@ func (w *PlainWriter) FPrintln(writer io.Writer, args ...interface{}) {
 	msg := fmt.Sprint(args...)
 	fmt.Fprintln(writer, args...)
 	if msg != "" && writer == w.out.Out {
-		msg = convertToJSON(InfoLevel, log.stage, msg)
+		msg = w.logger.convertToJSON(InfoLevel, msg)
 		if msg != "" {
-			log.buf.WriteString(msg)
-			log.buf.WriteString("\n")
+			writeLine(w.buf, msg)
 		}
 	}
 }
@@ -197,10 +198,9 @@ func (w *PlainWriter) Print(args ...interface{}) {
 	msg := fmt.Sprint(args...)
 	fmt.Fprint(w.out.Out, args...)
 	if msg != "" {
-		msg = convertToJSON(InfoLevel, log.stage, msg)
+		msg = w.logger.convertToJSON(InfoLevel, msg)
 		if msg != "" {
-			log.buf.WriteString(msg)
-			log.buf.WriteString("\n")
+			writeLine(w.buf, msg)
 		}
 	}
 }
@@ -211,18 +211,17 @@ func (w *PlainWriter) Printf(format string, a ...interface{}) {
 }
 
 // IsInteractive checks if the writer is interactive
-func (*PlainWriter) IsInteractive() bool {
+func (w *PlainWriter) IsInteractive() bool {
 	return false
 }
 
 // AddToBuffer logs into the buffer but does not print anything
-func (*PlainWriter) AddToBuffer(level, format string, a ...interface{}) {
+func (w *PlainWriter) AddToBuffer(level, format string, a ...interface{}) {
 	msg := fmt.Sprintf(format, a...)
 	if msg != "" {
-		msg = convertToJSON(level, log.stage, msg)
+		msg = w.logger.convertToJSON(level, msg)
 		if msg != "" {
-			log.buf.WriteString(msg)
-			log.buf.WriteString("\n")
+			writeLine(w.buf, msg)
 		}
 	}
 }
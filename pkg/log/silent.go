@@ -26,65 +26,69 @@ type SilentWriter struct {
 	out    *logrus.Logger
 	buffer io.ReadWriter
 	file   *logrus.Entry
+	buf    *bytes.Buffer
+	logger *Logger
 }
 
 // newSilentWriter creates a new SilentWriter
-func newSilentWriter(out *logrus.Logger, file *logrus.Entry) *SilentWriter {
+func newSilentWriter(l *Logger) *SilentWriter {
 	return &SilentWriter{
-		out:    out,
+		out:    l.out,
 		buffer: bytes.NewBuffer(nil),
-		file:   file,
+		file:   l.file,
+		buf:    l.buf,
+		logger: l,
 	}
 }
 
 // Debug writes a debug-level log
 func (w *SilentWriter) Debug(args ...interface{}) {
-	if log.file != nil {
-		log.file.Debug(args...)
+	if w.file != nil {
+		w.file.Debug(args...)
 	}
 }
 
 // Debugf writes a debug-level log with a format
 func (w *SilentWriter) Debugf(format string, args ...interface{}) {
-	if log.file != nil {
-		log.file.Debugf(format, args...)
+	if w.file != nil {
+		w.file.Debugf(format, args...)
 	}
 }
 
 // Info writes a info-level log
 func (w *SilentWriter) Info(args ...interface{}) {
-	if log.file != nil {
-		log.file.Info(args...)
+	if w.file != nil {
+		w.file.Info(args...)
 	}
 }
 
 // Infof writes a info-level log with a format
-func (*SilentWriter) Infof(format string, args ...interface{}) {
-	if log.file != nil {
-		log.file.Infof(format, args...)
+func (w *SilentWriter) Infof(format string, args ...interface{}) {
+	if w.file != nil {
+		w.file.Infof(format, args...)
 	}
 }
 
 // Error writes a error-level log
 func (w *SilentWriter) Error(args ...interface{}) {
 	w.out.Error(args...)
-	if log.file != nil {
-		log.file.Error(args...)
+	if w.file != nil {
+		w.file.Error(args...)
 	}
 }
 
 // Errorf writes a error-level log with a format
 func (w *SilentWriter) Errorf(format string, args ...interface{}) {
 	w.out.Errorf(format, args...)
-	if log.file != nil {
-		log.file.Errorf(format, args...)
+	if w.file != nil {
+		w.file.Errorf(format, args...)
 	}
 }
 
 // Fatalf writes a error-level log with a format
 func (w *SilentWriter) Fatalf(format string, args ...interface{}) {
-	if log.file != nil {
-		log.file.Errorf(format, args...)
+	if w.file != nil {
+		w.file.Errorf(format, args...)
 	}
 
 	w.out.Fatalf(format, args...)
@@ -134,14 +138,14 @@ func (w *SilentWriter) Hint(format string, args ...interface{}) {
 // Fail prints a message with the error symbol first, and the text in red
 func (w *SilentWriter) Fail(format string, args ...interface{}) {
 	msg := fmt.Sprintf(format, args...)
-	log.out.Info(msg)
+	w.out.Info(msg)
 	w.Fprintf(w.out.Out, "ERROR: %s\n", fmt.Sprintf(format, args...))
 }
 
 // Println writes a line with colors
 func (w *SilentWriter) Println(args ...interface{}) {
 	msg := fmt.Sprint(args...)
-	log.out.Info(msg)
+	w.out.Info(msg)
 	w.FPrintln(w.buffer, args...)
 }
 
@@ -169,14 +173,16 @@ func (w *SilentWriter) Printf(format string, a ...interface{}) {
 }
 
 // IsInteractive checks if the writer is interactive
-func (*SilentWriter) IsInteractive() bool {
+func (w *SilentWriter) IsInteractive() bool {
 	return false
 }
 
 // AddToBuffer logs into the buffer but does not print anything
-func (*SilentWriter) AddToBuffer(level, format string, a ...interface{}) {
-	msg := fmt.Sprintf(format, a...)
-	log.buf.Write([]byte(msg))
+func (w *SilentWriter) AddToBuffer(level, format string, a ...interface{}) {
+	msg := w.logger.convertToJSON(level, fmt.Sprintf(format, a...))
+	if msg != "" {
+		writeLine(w.buf, msg)
+	}
 }
 
 // Write logs into the buffer but does not print anything
@@ -24,11 +24,6 @@ import (
 	"golang.org/x/term"
 )
 
-const (
-	// OktetoDisableSpinnerEnvVar if true spinner is disabled
-	OktetoDisableSpinnerEnvVar = "OKTETO_DISABLE_SPINNER"
-)
-
 type spinnerLogger struct {
 	sp             *sp.Spinner
 	spinnerSupport bool
@@ -38,21 +33,21 @@ type spinnerLogger struct {
 // hold is used within the TTYWritter to pause the spinner to display the log
 // if the spinner is Active (running) it will stop
 func (sl *spinnerLogger) hold() {
-	if !sl.sp.Active() {
+	if sl == nil || !sl.sp.Active() {
 		return
 	}
 	sl.onHold = true
-	StopSpinner()
+	sl.stop()
 }
 
 // unhold is used within the TTYWritter to restart the spinner after display the log.
 // If the spinner is onHold (previously Active) this will start the spinning running again
 func (sl *spinnerLogger) unhold() {
-	if !sl.onHold {
+	if sl == nil || !sl.onHold {
 		return
 	}
 	sl.onHold = false
-	StartSpinner()
+	sl.start()
 }
 
 func newSpinner() *sp.Spinner {
@@ -81,10 +76,7 @@ func Spinner(text string) {
 // StartSpinner starts to run the spinner if enabled or Println if not
 func StartSpinner() {
 	if log.spinner.spinnerSupport {
-		if log.spinner.sp.FinalMSG == "" {
-			log.spinner.sp.FinalMSG = log.spinner.sp.Suffix
-		}
-		log.spinner.sp.Start()
+		log.spinner.start()
 	} else {
 		Println(strings.TrimSpace(log.spinner.sp.Suffix))
 	}
@@ -92,11 +84,27 @@ func StartSpinner() {
 
 // StopSpinner deletes FinalMSG and stops the running of the spinner
 func StopSpinner() {
-	if log.spinner.sp.FinalMSG != "" {
-		log.spinner.sp.FinalMSG = ""
+	log.spinner.stop()
+}
+
+// start runs the spinner, keeping its suffix as the final message
+func (sl *spinnerLogger) start() {
+	if !sl.spinnerSupport {
+		return
 	}
-	if log.spinner.spinnerSupport {
-		log.spinner.sp.Stop()
+	if sl.sp.FinalMSG == "" {
+		sl.sp.FinalMSG = sl.sp.Suffix
+	}
+	sl.sp.Start()
+}
+
+// stop deletes FinalMSG and stops the running of the spinner
+func (sl *spinnerLogger) stop() {
+	if sl.sp.FinalMSG != "" {
+		sl.sp.FinalMSG = ""
+	}
+	if sl.spinnerSupport {
+		sl.sp.Stop()
 	}
 }
 
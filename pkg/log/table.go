@@ -0,0 +1,240 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+	"gopkg.in/yaml.v2"
+)
+
+// Alignment is the alignment of the cells of a table column
+type Alignment int
+
+const (
+	// AlignLeft aligns the cells to the left
+	AlignLeft Alignment = iota
+	// AlignRight aligns the cells to the right
+	AlignRight
+)
+
+const (
+	tableColumnSeparator = "  "
+	tableMinColumnWidth  = 5
+	tableEllipsis        = "…"
+)
+
+// Column is a column of a table
+type Column struct {
+	// Header is the title of the column
+	Header string
+	// Key is the field name used by the json and yaml outputs, defaults to the lowercase header
+	Key string
+	// Align is the alignment of the cells
+	Align Alignment
+	// NoTruncate prevents the column from being truncated to fit the terminal width
+	NoTruncate bool
+}
+
+// Table renders rows as aligned columns, truncated to the terminal width
+type Table struct {
+	columns []Column
+	rows    [][]string
+
+	// width is the maximum width of the rendered lines, zero means unlimited
+	width int
+}
+
+// NewTable creates a table with the given columns
+func NewTable(columns ...Column) *Table {
+	return &Table{columns: columns}
+}
+
+// AddRow adds a row to the table, missing cells are rendered empty
+func (t *Table) AddRow(cells ...string) {
+	row := make([]string, len(t.columns))
+	copy(row, cells)
+	t.rows = append(t.rows, row)
+}
+
+// Len returns the number of rows of the table
+func (t *Table) Len() int {
+	return len(t.rows)
+}
+
+// SetWidth sets the maximum width of the rendered lines, zero means unlimited
+func (t *Table) SetWidth(width int) {
+	t.width = width
+}
+
+// Render writes the table in the given output format. An empty format renders the columns
+func (t *Table) Render(w io.Writer, output string) error {
+	switch output {
+	case "json":
+		bytes, err := json.MarshalIndent(t.records(), "", " ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(bytes))
+		return err
+	case "yaml":
+		records := []yaml.MapSlice{}
+		for _, row := range t.rows {
+			record := yaml.MapSlice{}
+			for i, column := range t.columns {
+				record = append(record, yaml.MapItem{Key: column.key(), Value: row[i]})
+			}
+			records = append(records, record)
+		}
+		bytes, err := yaml.Marshal(records)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprint(w, string(bytes))
+		return err
+	case "":
+		width := t.width
+		if width == 0 {
+			width = getTerminalWidth(w)
+		}
+		_, err := fmt.Fprint(w, t.renderColumns(width))
+		return err
+	default:
+		return fmt.Errorf("output format '%s' is not supported. Supported values are: ['json', 'yaml']", output)
+	}
+}
+
+func (c Column) key() string {
+	if c.Key != "" {
+		return c.Key
+	}
+	return strings.ReplaceAll(strings.ToLower(c.Header), " ", "_")
+}
+
+func (t *Table) records() []map[string]string {
+	result := []map[string]string{}
+	for _, row := range t.rows {
+		record := map[string]string{}
+		for i, column := range t.columns {
+			record[column.key()] = row[i]
+		}
+		result = append(result, record)
+	}
+	return result
+}
+
+func (t *Table) renderColumns(maxWidth int) string {
+	widths := t.getColumnWidths(maxWidth)
+
+	var sb strings.Builder
+	t.renderLine(&sb, t.headers(), widths)
+	for _, row := range t.rows {
+		t.renderLine(&sb, row, widths)
+	}
+	return sb.String()
+}
+
+func (t *Table) headers() []string {
+	result := make([]string, len(t.columns))
+	for i, column := range t.columns {
+		result[i] = column.Header
+	}
+	return result
+}
+
+func (t *Table) renderLine(sb *strings.Builder, cells []string, widths []int) {
+	for i, cell := range cells {
+		cell = truncate(cell, widths[i])
+		padding := strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell))
+		isLast := i == len(cells)-1
+		switch {
+		case t.columns[i].Align == AlignRight:
+			sb.WriteString(padding + cell)
+		case isLast:
+			sb.WriteString(cell)
+		default:
+			sb.WriteString(cell + padding)
+		}
+		if !isLast {
+			sb.WriteString(tableColumnSeparator)
+		}
+	}
+	sb.WriteString("\n")
+}
+
+// getColumnWidths returns the width of each column, shrinking the widest columns until the lines fit in 'maxWidth'
+func (t *Table) getColumnWidths(maxWidth int) []int {
+	widths := make([]int, len(t.columns))
+	for i, column := range t.columns {
+		widths[i] = utf8.RuneCountInString(column.Header)
+		for _, row := range t.rows {
+			if l := utf8.RuneCountInString(row[i]); l > widths[i] {
+				widths[i] = l
+			}
+		}
+	}
+	if maxWidth <= 0 {
+		return widths
+	}
+
+	total := len(tableColumnSeparator) * (len(widths) - 1)
+	for _, w := range widths {
+		total += w
+	}
+	for total > maxWidth {
+		widest := -1
+		for i, column := range t.columns {
+			if column.NoTruncate || widths[i] <= tableMinColumnWidth {
+				continue
+			}
+			if widest == -1 || widths[i] > widths[widest] {
+				widest = i
+			}
+		}
+		if widest == -1 {
+			break
+		}
+		widths[widest]--
+		total--
+	}
+	return widths
+}
+
+func truncate(value string, width int) string {
+	if utf8.RuneCountInString(value) <= width {
+		return value
+	}
+	runes := []rune(value)
+	return string(runes[:width-1]) + tableEllipsis
+}
+
+// getTerminalWidth returns the width of the terminal of 'w' or zero if it isn't a terminal
+func getTerminalWidth(w io.Writer) int {
+	f, ok := w.(*os.File)
+	if !ok || !term.IsTerminal(int(f.Fd())) {
+		return 0
+	}
+	width, _, err := term.GetSize(int(f.Fd()))
+	if err != nil {
+		Infof("failed to get terminal size: %s", err)
+		return 0
+	}
+	return width
+}
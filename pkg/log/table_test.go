@@ -0,0 +1,140 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTable() *Table {
+	table := NewTable(
+		Column{Header: "Name"},
+		Column{Header: "Replicas", Align: AlignRight},
+		Column{Header: "Endpoint URL"},
+	)
+	table.AddRow("api", "1", "https://api-cindy.okteto.example.com")
+	table.AddRow("frontend", "10", "https://frontend-cindy.okteto.example.com")
+	table.AddRow("db")
+	return table
+}
+
+func setTestOutputMode(t *testing.T, mode string) {
+	t.Helper()
+	previous := log.outputMode
+	t.Cleanup(func() { log.outputMode = previous })
+	log.outputMode = mode
+}
+
+func TestTableRender(t *testing.T) {
+	setTestOutputMode(t, TTYFormat)
+	tests := []struct {
+		name     string
+		output   string
+		expected string
+		width    int
+	}{
+		{
+			name:   "columns",
+			output: "",
+			expected: `Name      Replicas  Endpoint URL
+api              1  https://api-cindy.okteto.example.com
+frontend        10  https://frontend-cindy.okteto.example.com
+db                  
+`,
+		},
+		{
+			name:   "truncated to width",
+			output: "",
+			width:  40,
+			expected: `Name      Replicas  Endpoint URL
+api              1  https://api-cindy.o…
+frontend        10  https://frontend-ci…
+db                  
+`,
+		},
+		{
+			name:   "json",
+			output: "json",
+			expected: `[
+ {
+  "endpoint_url": "https://api-cindy.okteto.example.com",
+  "name": "api",
+  "replicas": "1"
+ },
+ {
+  "endpoint_url": "https://frontend-cindy.okteto.example.com",
+  "name": "frontend",
+  "replicas": "10"
+ },
+ {
+  "endpoint_url": "",
+  "name": "db",
+  "replicas": ""
+ }
+]
+`,
+		},
+		{
+			name:   "yaml",
+			output: "yaml",
+			expected: `- name: api
+  replicas: "1"
+  endpoint_url: https://api-cindy.okteto.example.com
+- name: frontend
+  replicas: "10"
+  endpoint_url: https://frontend-cindy.okteto.example.com
+- name: db
+  replicas: ""
+  endpoint_url: ""
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := newTestTable()
+			table.SetWidth(tt.width)
+			var b bytes.Buffer
+			require.NoError(t, table.Render(&b, tt.output))
+			assert.Equal(t, tt.expected, b.String())
+		})
+	}
+}
+
+func TestTableRenderWithJSONLogs(t *testing.T) {
+	setTestOutputMode(t, JSONFormat)
+
+	table := NewTable(Column{Header: "Namespace"}, Column{Header: "Status"})
+	table.AddRow("cindy", "Active")
+	var b bytes.Buffer
+	require.NoError(t, table.Render(&b, ""))
+	assert.Equal(t, "Namespace  Status\ncindy      Active\n", b.String())
+}
+
+func TestTableRenderInvalidOutput(t *testing.T) {
+	assert.Error(t, newTestTable().Render(&bytes.Buffer{}, "xml"))
+}
+
+func TestTableNoTruncate(t *testing.T) {
+	setTestOutputMode(t, TTYFormat)
+	table := NewTable(Column{Header: "URL", NoTruncate: true})
+	table.AddRow("https://okteto.example.com")
+	table.SetWidth(10)
+	var b bytes.Buffer
+	require.NoError(t, table.Render(&b, ""))
+	assert.Equal(t, "URL\nhttps://okteto.example.com\n", b.String())
+}
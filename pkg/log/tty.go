@@ -14,6 +14,7 @@
 package log
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"regexp"
@@ -28,162 +29,165 @@ var ansiRegex = regexp.MustCompile(ansi)
 
 // TTYWriter writes into a tty terminal
 type TTYWriter struct {
-	out  *logrus.Logger
-	file *logrus.Entry
+	out    *logrus.Logger
+	file   *logrus.Entry
+	buf    *bytes.Buffer
+	logger *Logger
 }
 
 // newTTYWriter creates a new ttyWriter
-func newTTYWriter(out *logrus.Logger, file *logrus.Entry) *TTYWriter {
+func newTTYWriter(l *Logger) *TTYWriter {
 	return &TTYWriter{
-		out:  out,
-		file: file,
+		out:    l.out,
+		file:   l.file,
+		buf:    l.buf,
+		logger: l,
 	}
 }
 
 // Debug writes a debug-level log
 func (w *TTYWriter) Debug(args ...interface{}) {
 	w.out.Debug(args...)
-	if log.file != nil {
-		log.file.Debug(args...)
+	if w.file != nil {
+		w.file.Debug(args...)
 	}
 }
 
 // Debugf writes a debug-level log with a format
-func (*TTYWriter) Debugf(format string, args ...interface{}) {
-	log.out.Debugf(format, args...)
-	if log.file != nil {
-		log.file.Debugf(format, args...)
+func (w *TTYWriter) Debugf(format string, args ...interface{}) {
+	w.out.Debugf(format, args...)
+	if w.file != nil {
+		w.file.Debugf(format, args...)
 	}
 }
 
 // Info writes a info-level log
-func (*TTYWriter) Info(args ...interface{}) {
-	log.out.Info(args...)
-	if log.file != nil {
-		log.file.Info(args...)
+func (w *TTYWriter) Info(args ...interface{}) {
+	w.out.Info(args...)
+	if w.file != nil {
+		w.file.Info(args...)
 	}
 }
 
 // Infof writes a info-level log with a format
-func (*TTYWriter) Infof(format string, args ...interface{}) {
-	log.out.Infof(format, args...)
-	if log.file != nil {
-		log.file.Infof(format, args...)
+func (w *TTYWriter) Infof(format string, args ...interface{}) {
+	w.out.Infof(format, args...)
+	if w.file != nil {
+		w.file.Infof(format, args...)
 	}
 }
 
 // Error writes a error-level log
-func (*TTYWriter) Error(args ...interface{}) {
-	log.out.Error(args...)
-	if log.file != nil {
-		log.file.Error(args...)
+func (w *TTYWriter) Error(args ...interface{}) {
+	w.out.Error(args...)
+	if w.file != nil {
+		w.file.Error(args...)
 	}
 }
 
 // Errorf writes a error-level log with a format
-func (*TTYWriter) Errorf(format string, args ...interface{}) {
-	log.out.Errorf(format, args...)
-	if log.file != nil {
-		log.file.Errorf(format, args...)
+func (w *TTYWriter) Errorf(format string, args ...interface{}) {
+	w.out.Errorf(format, args...)
+	if w.file != nil {
+		w.file.Errorf(format, args...)
 	}
 }
 
 // Fatalf writes a error-level log with a format
-func (*TTYWriter) Fatalf(format string, args ...interface{}) {
-	if log.file != nil {
-		log.file.Errorf(format, args...)
+func (w *TTYWriter) Fatalf(format string, args ...interface{}) {
+	if w.file != nil {
+		w.file.Errorf(format, args...)
 	}
 
-	log.out.Fatalf(format, args...)
+	w.out.Fatalf(format, args...)
 }
 
 // Green writes a line in green
 func (w *TTYWriter) Green(format string, args ...interface{}) {
-	log.out.Infof(format, args...)
-	log.spinner.hold()
+	w.out.Infof(format, args...)
+	w.logger.spinner.hold()
 	w.FPrintln(w.out.Out, greenString(format, args...))
-	log.spinner.unhold()
+	w.logger.spinner.unhold()
 }
 
 // Yellow writes a line in yellow
 func (w *TTYWriter) Yellow(format string, args ...interface{}) {
-	log.out.Infof(format, args...)
-	log.spinner.hold()
+	w.out.Infof(format, args...)
+	w.logger.spinner.hold()
 	w.FPrintln(w.out.Out, yellowString(format, args...))
-	log.spinner.unhold()
+	w.logger.spinner.unhold()
 }
 
 // Success prints a message with the success symbol first, and the text in green
 func (w *TTYWriter) Success(format string, args ...interface{}) {
-	log.out.Infof(format, args...)
-	log.spinner.hold()
+	w.out.Infof(format, args...)
+	w.logger.spinner.hold()
 	w.Fprintf(w.out.Out, "%s %s\n", coloredSuccessSymbol, greenString(format, args...))
-	log.spinner.unhold()
+	w.logger.spinner.unhold()
 }
 
 // Information prints a message with the information symbol first, and the text in blue
 func (w *TTYWriter) Information(format string, args ...interface{}) {
-	log.out.Infof(format, args...)
-	log.spinner.hold()
+	w.out.Infof(format, args...)
+	w.logger.spinner.hold()
 	w.Fprintf(w.out.Out, "%s %s\n", coloredInformationSymbol, blueString(format, args...))
-	log.spinner.unhold()
+	w.logger.spinner.unhold()
 }
 
 // Question prints a message with the question symbol first, and the text in magenta
 func (w *TTYWriter) Question(format string, args ...interface{}) error {
-	log.out.Infof(format, args...)
-	log.spinner.hold()
+	w.out.Infof(format, args...)
+	w.logger.spinner.hold()
 	w.Fprintf(w.out.Out, "%s %s", coloredQuestionSymbol, color.MagentaString(format, args...))
-	log.spinner.unhold()
+	w.logger.spinner.unhold()
 	return nil
 }
 
 // Warning prints a message with the warning symbol first, and the text in yellow
 func (w *TTYWriter) Warning(format string, args ...interface{}) {
-	log.out.Infof(format, args...)
-	log.spinner.hold()
+	w.out.Infof(format, args...)
+	w.logger.spinner.hold()
 	w.Fprintf(w.out.Out, "%s %s\n", coloredWarningSymbol, yellowString(format, args...))
-	log.spinner.unhold()
+	w.logger.spinner.unhold()
 }
 
 // FWarning prints a message with the warning symbol first, and the text in yellow into an specific writer
 func (w *TTYWriter) FWarning(writer io.Writer, format string, args ...interface{}) {
-	log.out.Infof(format, args...)
-	log.spinner.hold()
+	w.out.Infof(format, args...)
+	w.logger.spinner.hold()
 	w.Fprintf(writer, "%s %s\n", coloredWarningSymbol, yellowString(format, args...))
-	log.spinner.unhold()
+	w.logger.spinner.unhold()
 }
 
 // Hint prints a message with the text in blue
 func (w *TTYWriter) Hint(format string, args ...interface{}) {
-	log.out.Infof(format, args...)
-	log.spinner.hold()
+	w.out.Infof(format, args...)
+	w.logger.spinner.hold()
 	w.Fprintf(w.out.Out, "%s\n", blueString(format, args...))
-	log.spinner.unhold()
+	w.logger.spinner.unhold()
 }
 
 // Fail prints a message with the error symbol first, and the text in red
 func (w *TTYWriter) Fail(format string, args ...interface{}) {
 	msg := fmt.Sprintf(format, args...)
-	log.out.Info(msg)
-	log.spinner.hold()
+	w.out.Info(msg)
+	w.logger.spinner.hold()
 	w.Fprintf(w.out.Out, "%s %s\n", coloredErrorSymbol, redString(format, args...))
-	log.spinner.unhold()
+	w.logger.spinner.unhold()
 	if msg != "" {
-		msg = convertToJSON(ErrorLevel, log.stage, msg)
+		msg = w.logger.convertToJSON(ErrorLevel, msg)
 		if msg != "" {
-			log.buf.WriteString(msg)
-			log.buf.WriteString("\n")
+			writeLine(w.buf, msg)
 		}
 	}
 }
 
 // Println writes a line with colors
 func (w *TTYWriter) Println(args ...interface{}) {
-	log.out.Info(args...)
-	log.spinner.hold()
+	w.out.Info(args...)
+	w.logger.spinner.hold()
 	w.FPrintln(w.out.Out, args...)
-	log.spinner.unhold()
+	w.logger.spinner.unhold()
 }
 
 // Fprintf prints a line with format
@@ -191,10 +195,9 @@ func (w *TTYWriter) Fprintf(writer io.Writer, format string, a ...interface{}) {
 	msg := fmt.Sprintf(format, a...)
 	fmt.Fprint(writer, msg)
 	if msg != "" && writer == w.out.Out {
-		msg = convertToJSON(InfoLevel, log.stage, msg)
+		msg = w.logger.convertToJSON(InfoLevel, msg)
 		if msg != "" {
-			log.buf.WriteString(msg)
-			log.buf.WriteString("\n")
+			writeLine(w.buf, msg)
 		}
 	}
 
@@ -205,10 +208,9 @@ func (w *TTYWriter) FPrintln(writer io.Writer, args ...interface{}) {
 	msg := fmt.Sprint(args...)
 	fmt.Fprintln(writer, msg)
 	if msg != "" && writer == w.out.Out {
-		msg = convertToJSON(InfoLevel, log.stage, msg)
+		msg = w.logger.convertToJSON(InfoLevel, msg)
 		if msg != "" {
-			log.buf.WriteString(msg)
-			log.buf.WriteString("\n")
+			writeLine(w.buf, msg)
 		}
 	}
 
@@ -219,10 +221,9 @@ func (w *TTYWriter) Print(args ...interface{}) {
 	msg := fmt.Sprint(args...)
 	fmt.Fprint(w.out.Out, args...)
 	if msg != "" {
-		msg = convertToJSON(ErrorLevel, log.stage, msg)
+		msg = w.logger.convertToJSON(ErrorLevel, msg)
 		if msg != "" {
-			log.buf.WriteString(msg)
-			log.buf.WriteString("\n")
+			writeLine(w.buf, msg)
 		}
 	}
 
@@ -230,24 +231,23 @@ func (w *TTYWriter) Print(args ...interface{}) {
 
 // Printf writes a line with format
 func (w *TTYWriter) Printf(format string, a ...interface{}) {
-	log.spinner.hold()
+	w.logger.spinner.hold()
 	w.Fprintf(w.out.Out, format, a...)
-	log.spinner.unhold()
+	w.logger.spinner.unhold()
 }
 
 // IsInteractive checks if the writer is interactive
-func (*TTYWriter) IsInteractive() bool {
+func (w *TTYWriter) IsInteractive() bool {
 	return true
 }
 
 // AddToBuffer logs into the buffer but does not print anything
-func (*TTYWriter) AddToBuffer(level, format string, a ...interface{}) {
+func (w *TTYWriter) AddToBuffer(level, format string, a ...interface{}) {
 	msg := fmt.Sprintf(format, a...)
 	if msg != "" {
-		msg = convertToJSON(level, log.stage, msg)
+		msg = w.logger.convertToJSON(level, msg)
 		if msg != "" {
-			log.buf.WriteString(msg)
-			log.buf.WriteString("\n")
+			writeLine(w.buf, msg)
 		}
 	}
 }
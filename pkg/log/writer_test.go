@@ -0,0 +1,128 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+// callWriterMethods calls every method of the writer except Fatalf, which exits the process
+func callWriterMethods(t *testing.T, w OktetoWriter) {
+	t.Helper()
+	var other bytes.Buffer
+	assert.NotPanics(t, func() {
+		w.Debug("debug")
+		w.Debugf("debug %s", "f")
+		w.Info("info")
+		w.Infof("info %s", "f")
+		w.Error("error")
+		w.Errorf("error %s", "f")
+		w.Fail("fail %s", "f")
+		w.Yellow("yellow %s", "f")
+		w.Green("green %s", "f")
+		w.Success("success %s", "f")
+		w.Information("information %s", "f")
+		w.Warning("warning %s", "f")
+		w.FWarning(&other, "fwarning %s", "f")
+		w.Hint("hint %s", "f")
+		w.Println("println")
+		w.FPrintln(w, "fprintln")
+		w.Print("print")
+		w.Fprintf(w, "fprintf %s\n", "f")
+		w.Printf("printf %s\n", "f")
+		w.AddToBuffer(InfoLevel, "buffered %s", "f")
+		w.IsInteractive()
+		_, err := w.Write([]byte("write\n"))
+		assert.NoError(t, err)
+	})
+}
+
+func TestWritersBuffer(t *testing.T) {
+	previous := log
+	t.Cleanup(func() {
+		SetLogger(previous)
+	})
+
+	var tests = []struct {
+		newWriter func(l *Logger) OktetoWriter
+		name      string
+	}{
+		{
+			name: "tty",
+			newWriter: func(l *Logger) OktetoWriter {
+				return newTTYWriter(l)
+			},
+		},
+		{
+			name: "plain",
+			newWriter: func(l *Logger) OktetoWriter {
+				return newPlainWriter(l)
+			},
+		},
+		{
+			name: "json",
+			newWriter: func(l *Logger) OktetoWriter {
+				return newJSONWriter(l)
+			},
+		},
+		{
+			name: "silent",
+			newWriter: func(l *Logger) OktetoWriter {
+				return newSilentWriter(l)
+			},
+		},
+		{
+			name: "ci",
+			newWriter: func(l *Logger) OktetoWriter {
+				return newCIWriter(newPlainWriter(l), GitHubCI, l)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			SetLogger(NewLogger(WithOutput(&out), WithLevel(logrus.DebugLevel), WithSpinnerDisabled(true)))
+			SetStage("test")
+
+			// writers built without buffer must not panic
+			log.writer = tt.newWriter(&Logger{out: log.out, now: log.now, stage: log.stage})
+			callWriterMethods(t, log.writer)
+
+			// writers built with the buffer of the logger write to it
+			log.writer = tt.newWriter(log)
+			callWriterMethods(t, log.writer)
+			assert.Contains(t, GetOutputBuffer().String(), "buffered f")
+		})
+	}
+}
+
+func TestNewLoggerInjectsBuffer(t *testing.T) {
+	previous := log
+	t.Cleanup(func() {
+		SetLogger(previous)
+	})
+
+	var out bytes.Buffer
+	SetLogger(NewLogger(WithOutput(&out), WithSpinnerDisabled(true)))
+	SetStage("test")
+	for _, format := range []string{TTYFormat, PlainFormat, JSONFormat, SilentFormat} {
+		SetOutputFormat(format)
+		AddToBuffer(InfoLevel, "message from %s", format)
+		assert.Contains(t, GetOutputBuffer().String(), "message from "+format)
+	}
+}
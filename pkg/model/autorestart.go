@@ -0,0 +1,49 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// defaultAutorestartDebounce is the time to wait for more changes before running the autorestart command
+const defaultAutorestartDebounce = 1 * time.Second
+
+// Autorestart defines the command run by 'okteto restart --watch' in the development container every time the synchronization completes
+type Autorestart struct {
+	Command  Command       `json:"command,omitempty" yaml:"command,omitempty"`
+	Debounce time.Duration `json:"debounce,omitempty" yaml:"debounce,omitempty"`
+}
+
+// GetDebounce returns the time to wait for more synchronized changes before running the command
+func (a *Autorestart) GetDebounce() time.Duration {
+	if a.Debounce == 0 {
+		return defaultAutorestartDebounce
+	}
+	return a.Debounce
+}
+
+func (a *Autorestart) validate() error {
+	if a == nil {
+		return nil
+	}
+	if len(a.Command.Values) == 0 {
+		return fmt.Errorf("'autorestart.command' is required")
+	}
+	if a.Debounce < 0 {
+		return fmt.Errorf("'autorestart.debounce' must be >= 0")
+	}
+	return nil
+}
@@ -136,6 +136,12 @@ const (
 	DefaultSyncthingRescanInterval = 300
 	// RemoteSubPath subpath in the development container persistent volume for the remote data
 	RemoteSubPath = "okteto-remote"
+	// ShellHistoryMountPath shell history volume mount path
+	ShellHistoryMountPath = "/var/okteto/bashrc"
+	// ShellHistorySubPath subpath in the development container persistent volume for the shell history
+	ShellHistorySubPath = "okteto-bash-history"
+	// ShellDotfilesSubPath subpath in the development container persistent volume for the persisted dotfiles
+	ShellDotfilesSubPath = "okteto-dotfiles"
 	// OktetoAutoCreateAnnotation indicates if the deployment was auto generated by okteto up
 	OktetoAutoCreateAnnotation = "dev.okteto.com/auto-create"
 	// OktetoRestartAnnotation indicates the dev pod must be recreated to pull the latest version of its image
@@ -191,6 +197,9 @@ const (
 	// OktetoNamespaceEnvVar defines the namespace the user is using
 	OktetoNamespaceEnvVar = "OKTETO_NAMESPACE"
 
+	// ComposeProfilesEnvVar defines the compose profiles enabled when deploying a compose file
+	ComposeProfilesEnvVar = "COMPOSE_PROFILES"
+
 	// OktetoDomainEnvVar defines the domain the user is using
 	OktetoDomainEnvVar = "OKTETO_DOMAIN"
 
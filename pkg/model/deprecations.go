@@ -0,0 +1,56 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Deprecation represents a deprecated feature used by a manifest
+type Deprecation struct {
+	Field       string `json:"field" yaml:"field"`
+	Replacement string `json:"replacement" yaml:"replacement"`
+}
+
+// GetDeprecations returns the deprecated features used by the dev containers of the manifest
+func (m *Manifest) GetDeprecations() []Deprecation {
+	result := []Deprecation{}
+	for name, dev := range m.Dev {
+		if dev.Healthchecks {
+			result = append(result, Deprecation{Field: fmt.Sprintf("dev.%s.healthchecks", name), Replacement: "probes"})
+		}
+		if len(dev.Labels) > 0 {
+			result = append(result, Deprecation{Field: fmt.Sprintf("dev.%s.labels", name), Replacement: "selector"})
+		}
+		if len(dev.Annotations) > 0 {
+			result = append(result, Deprecation{Field: fmt.Sprintf("dev.%s.annotations", name), Replacement: "metadata.annotations"})
+		}
+		if dev.Image != nil && (dev.Image.Context != "" || dev.Image.Dockerfile != "") {
+			result = append(result, Deprecation{Field: fmt.Sprintf("dev.%s.image", name), Replacement: "build"})
+		}
+		for _, s := range dev.Services {
+			if len(s.Labels) > 0 {
+				result = append(result, Deprecation{Field: fmt.Sprintf("dev.%s.services.%s.labels", name, s.Name), Replacement: "selector"})
+			}
+			if len(s.Annotations) > 0 {
+				result = append(result, Deprecation{Field: fmt.Sprintf("dev.%s.services.%s.annotations", name, s.Name), Replacement: "metadata.annotations"})
+			}
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Field < result[j].Field
+	})
+	return result
+}
@@ -0,0 +1,48 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/build"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDeprecations(t *testing.T) {
+	m := &Manifest{
+		Dev: ManifestDevs{
+			"api": &Dev{
+				Healthchecks: true,
+				Image:        &build.Info{Context: "api"},
+				Services: []*Dev{
+					{Name: "worker", Labels: Labels{"app": "worker"}},
+				},
+			},
+			"frontend": &Dev{
+				Annotations: Annotations{"key": "value"},
+				Image:       &build.Info{Name: "okteto/frontend"},
+			},
+		},
+	}
+
+	expected := []Deprecation{
+		{Field: "dev.api.healthchecks", Replacement: "probes"},
+		{Field: "dev.api.image", Replacement: "build"},
+		{Field: "dev.api.services.worker.labels", Replacement: "selector"},
+		{Field: "dev.frontend.annotations", Replacement: "metadata.annotations"},
+	}
+	assert.Equal(t, expected, m.GetDeprecations())
+	assert.Empty(t, (&Manifest{}).GetDeprecations())
+}
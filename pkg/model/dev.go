@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
 	"sort"
@@ -28,6 +29,7 @@ import (
 	"github.com/compose-spec/godotenv"
 	"github.com/google/uuid"
 	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/constants"
 	"github.com/okteto/okteto/pkg/env"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
@@ -64,6 +66,9 @@ type Dev struct {
 	Image                *build.Info           `json:"image,omitempty" yaml:"image,omitempty"`
 	Push                 *build.Info           `json:"-" yaml:"push,omitempty"`
 	Lifecycle            *Lifecycle            `json:"lifecycle,omitempty" yaml:"lifecycle,omitempty"`
+	ReadinessGate        *ReadinessGate        `json:"readinessGate,omitempty" yaml:"readinessGate,omitempty"`
+	ShellHistory         *ShellHistory         `json:"shellHistory,omitempty" yaml:"shellHistory,omitempty"`
+	Autorestart          *Autorestart          `json:"autorestart,omitempty" yaml:"autorestart,omitempty"`
 	Replicas             *int                  `json:"replicas,omitempty" yaml:"replicas,omitempty"`
 	InitContainer        InitContainer         `json:"initContainer,omitempty" yaml:"initContainer,omitempty"`
 	Workdir              string                `json:"workdir,omitempty" yaml:"workdir,omitempty"`
@@ -82,6 +87,7 @@ type Dev struct {
 	Tolerations     []apiv1.Toleration `json:"tolerations,omitempty" yaml:"tolerations,omitempty"`
 	Command         Command            `json:"command,omitempty" yaml:"command,omitempty"`
 	Forward         []forward.Forward  `json:"forward,omitempty" yaml:"forward,omitempty"`
+	ForwardProfiles ForwardProfiles    `json:"forwardProfiles,omitempty" yaml:"forwardProfiles,omitempty"`
 	Reverse         []Reverse          `json:"reverse,omitempty" yaml:"reverse,omitempty"`
 	ExternalVolumes []ExternalVolume   `json:"externalVolumes,omitempty" yaml:"externalVolumes,omitempty"`
 	Secrets         []Secret           `json:"secrets,omitempty" yaml:"secrets,omitempty"`
@@ -96,8 +102,10 @@ type Dev struct {
 	SSHServerPort   int                `json:"sshServerPort,omitempty" yaml:"sshServerPort,omitempty"`
 
 	EmptyImage    bool `json:"-" yaml:"-"`
+	FreshHistory  bool `json:"-" yaml:"-"`
 	InitFromImage bool `json:"initFromImage,omitempty" yaml:"initFromImage,omitempty"`
 	Autocreate    bool `json:"autocreate,omitempty" yaml:"autocreate,omitempty"`
+	Open          bool `json:"open,omitempty" yaml:"open,omitempty"`
 	Healthchecks  bool `json:"healthchecks,omitempty" yaml:"healthchecks,omitempty"` // Deprecated field
 }
 
@@ -126,12 +134,19 @@ type Volume struct {
 
 // Sync represents a sync info in the development container
 type Sync struct {
-	LocalPath      string       `json:"-" yaml:"-"`
-	RemotePath     string       `json:"-" yaml:"-"`
-	Folders        []SyncFolder `json:"folders,omitempty" yaml:"folders,omitempty"`
-	RescanInterval int          `json:"rescanInterval,omitempty" yaml:"rescanInterval,omitempty"`
-	Compression    bool         `json:"compression" yaml:"compression"`
-	Verbose        bool         `json:"verbose" yaml:"verbose"`
+	LargeFiles     *SyncLargeFiles `json:"largeFiles,omitempty" yaml:"largeFiles,omitempty"`
+	LocalPath      string          `json:"-" yaml:"-"`
+	RemotePath     string          `json:"-" yaml:"-"`
+	Folders        []SyncFolder    `json:"folders,omitempty" yaml:"folders,omitempty"`
+	RescanInterval int             `json:"rescanInterval,omitempty" yaml:"rescanInterval,omitempty"`
+	Compression    bool            `json:"compression" yaml:"compression"`
+	Verbose        bool            `json:"verbose" yaml:"verbose"`
+}
+
+// SyncLargeFiles configures the detection of large files in the synchronized folders
+type SyncLargeFiles struct {
+	Threshold  *Quantity `json:"threshold,omitempty" yaml:"threshold,omitempty"`
+	AutoIgnore []string  `json:"autoIgnore,omitempty" yaml:"autoIgnore,omitempty"`
 }
 
 // SyncFolder represents a sync folder in the development container
@@ -216,6 +231,16 @@ type Probes struct {
 	Startup   bool `json:"startup,omitempty" yaml:"startup,omitempty"`
 }
 
+// ReadinessGate defines the conditions the development container must meet before 'okteto up' starts the synchronization
+type ReadinessGate struct {
+	// Command is executed in the development container until it succeeds
+	Command Command `json:"command,omitempty" yaml:"command,omitempty"`
+	// Timeout is the maximum time to wait for the conditions
+	Timeout time.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	// Probes waits for the readiness and startup probes of the development container
+	Probes bool `json:"probes,omitempty" yaml:"probes,omitempty"`
+}
+
 // Lifecycle defines the lifecycle for containers
 type Lifecycle struct {
 	PostStart bool `json:"postStart,omitempty" yaml:"postStart,omitempty"`
@@ -241,6 +266,11 @@ func Get(devPath string) (*Manifest, error) {
 		return nil, err
 	}
 
+	b, err = resolveManifestExtends(devPath, b)
+	if err != nil {
+		return nil, err
+	}
+
 	manifest, err := Read(b)
 	if err != nil {
 		return nil, err
@@ -472,10 +502,10 @@ func (dev *Dev) SetDefaults() error {
 
 	dev.setRunAsUserDefaults(dev)
 
-	if os.Getenv(OktetoRescanIntervalEnvVar) != "" {
-		rescanInterval, err := strconv.Atoi(os.Getenv(OktetoRescanIntervalEnvVar))
+	if config.EnvOktetoRescanInterval.Value() != "" {
+		rescanInterval, err := config.EnvOktetoRescanInterval.Int()
 		if err != nil {
-			return fmt.Errorf("cannot parse 'OKTETO_RESCAN_INTERVAL' into an integer: %w", err)
+			return err
 		}
 		dev.Sync.RescanInterval = rescanInterval
 	} else if dev.Sync.RescanInterval == 0 {
@@ -660,6 +690,26 @@ func (dev *Dev) Validate() error {
 		return err
 	}
 
+	if err := dev.ShellHistory.validate(); err != nil {
+		return err
+	}
+
+	if err := dev.validateForwardProfiles(); err != nil {
+		return err
+	}
+
+	if err := dev.validateReverse(); err != nil {
+		return err
+	}
+
+	if err := dev.validateReadinessGate(); err != nil {
+		return err
+	}
+
+	if err := dev.Autorestart.validate(); err != nil {
+		return err
+	}
+
 	if _, err := resource.ParseQuantity(dev.PersistentVolumeSize()); err != nil {
 		return fmt.Errorf("'persistentVolume.size' is not valid. A sample value would be '10Gi'")
 	}
@@ -695,6 +745,19 @@ func (dev *Dev) PreparePathsAndExpandEnvFiles(manifestPath string) error {
 	return nil
 }
 
+func (dev *Dev) validateReadinessGate() error {
+	if dev.ReadinessGate == nil {
+		return nil
+	}
+	if !dev.ReadinessGate.Probes && len(dev.ReadinessGate.Command.Values) == 0 {
+		return fmt.Errorf("'readinessGate' must define 'probes' or 'command'")
+	}
+	if dev.ReadinessGate.Timeout < 0 {
+		return fmt.Errorf("'readinessGate.timeout' must be >= 0")
+	}
+	return nil
+}
+
 func (dev *Dev) validateSync() error {
 	for _, folder := range dev.Sync.Folders {
 		validPath, err := os.Stat(folder.LocalPath)
@@ -721,7 +784,7 @@ func (dev *Dev) validateSync() error {
 		}
 
 	}
-	return nil
+	return dev.Sync.LargeFiles.validate()
 }
 
 func validatePullPolicy(pullPolicy apiv1.PullPolicy) error {
@@ -1011,7 +1074,9 @@ func (dev *Dev) ToTranslationRule(main *Dev, reset bool) *TranslationRule {
 				},
 			)
 		}
-		enableHistoryVolume(rule, main)
+		if main.IsShellHistoryEnabled() {
+			enableHistoryVolume(rule, main)
+		}
 	}
 
 	for _, v := range dev.ExternalVolumes {
@@ -1032,9 +1097,11 @@ func enableHistoryVolume(rule *TranslationRule, main *Dev) {
 	rule.Volumes = append(rule.Volumes,
 		VolumeMount{
 			Name:      main.GetVolumeName(),
-			MountPath: "/var/okteto/bashrc",
-			SubPath:   "okteto-bash-history",
+			MountPath: ShellHistoryMountPath,
+			SubPath:   ShellHistorySubPath,
 		})
+	rule.Volumes = append(rule.Volumes, main.ShellHistory.getDotfilesVolumes(main.GetVolumeName())...)
+	rule.ResetShellHistory = main.FreshHistory
 
 	rule.Environment = append(rule.Environment,
 		env.Var{
@@ -1051,7 +1118,7 @@ func enableHistoryVolume(rule *TranslationRule, main *Dev) {
 		},
 		env.Var{
 			Name:  "HISTFILE",
-			Value: "/var/okteto/bashrc/.bash_history",
+			Value: path.Join(ShellHistoryMountPath, ".bash_history"),
 		},
 		env.Var{
 			Name:  "BASHOPTS",
@@ -1061,6 +1128,7 @@ func enableHistoryVolume(rule *TranslationRule, main *Dev) {
 			Name:  "PROMPT_COMMAND",
 			Value: "history -a ; history -c ; history -r",
 		})
+	rule.Environment = append(rule.Environment, main.ShellHistory.getToolsEnvironment()...)
 }
 
 func areProbesEnabled(probes *Probes) bool {
@@ -1091,7 +1159,7 @@ func (dev *Dev) RemoteModeEnabled() bool {
 		return true
 	}
 
-	if v, ok := os.LookupEnv(OktetoExecuteSSHEnvVar); ok && v == "false" {
+	if v, ok := config.EnvOktetoExecuteSSH.Lookup(); ok && v == "false" {
 		return false
 	}
 	return true
@@ -1111,17 +1179,10 @@ func (s *Secret) GetFileName() string {
 func GetTimeout() (time.Duration, error) {
 	defaultTimeout := (60 * time.Second)
 
-	t := os.Getenv(OktetoTimeoutEnvVar)
-	if t == "" {
+	if config.EnvOktetoTimeout.Value() == "" {
 		return defaultTimeout, nil
 	}
-
-	parsed, err := time.ParseDuration(t)
-	if err != nil {
-		return 0, fmt.Errorf("OKTETO_TIMEOUT is not a valid duration: %s", t)
-	}
-
-	return parsed, nil
+	return config.EnvOktetoTimeout.Duration()
 }
 
 func (dev *Dev) translateDeprecatedMetadataFields() {
@@ -1205,6 +1266,18 @@ func (service *Dev) validateForExtraFields() error {
 	if service.Forward != nil {
 		return fmt.Errorf(errorMessage, "forward")
 	}
+	if service.ForwardProfiles != nil {
+		return fmt.Errorf(errorMessage, "forwardProfiles")
+	}
+	if service.ReadinessGate != nil {
+		return fmt.Errorf(errorMessage, "readinessGate")
+	}
+	if service.Autorestart != nil {
+		return fmt.Errorf(errorMessage, "autorestart")
+	}
+	if service.Open {
+		return fmt.Errorf(errorMessage, "open")
+	}
 	if service.Reverse != nil {
 		return fmt.Errorf(errorMessage, "reverse")
 	}
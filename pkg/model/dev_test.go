@@ -791,6 +791,48 @@ func Test_validate(t *testing.T) {
         size: 10Gi`),
 			expectErr: false,
 		},
+		{
+			name: "readiness-gate-command",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      readinessGate:
+        command: pg_isready -h postgres
+        timeout: 2m`),
+			expectErr: false,
+		},
+		{
+			name: "readiness-gate-without-conditions",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      readinessGate:
+        timeout: 2m`),
+			expectErr: true,
+		},
+		{
+			name: "autorestart",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      autorestart:
+        command: go build -o app && ./app
+        debounce: 2s`),
+			expectErr: false,
+		},
+		{
+			name: "autorestart-without-command",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      autorestart:
+        debounce: 2s`),
+			expectErr: true,
+		},
 		{
 			name: "volumes-mount-path-/",
 			manifest: []byte(`
@@ -1254,6 +1296,22 @@ func Test_validateForExtraFields(t *testing.T) {
 			name:  "healthchecks",
 			value: "healthchecks: true",
 		},
+		{
+			name: "readinessGate",
+			value: `readinessGate:
+               probes: true`,
+		},
+		{
+			name: "autorestart",
+			value: `autorestart:
+               command: go run main.go`,
+		},
+		{
+			name: "forwardProfiles",
+			value: `forwardProfiles:
+               debug:
+                 - 2345:2345`,
+		},
 		{
 			name: "probes",
 			value: `probes:
@@ -1329,6 +1387,10 @@ func Test_validateForExtraFields(t *testing.T) {
 			name:  "initFromImage",
 			value: "initFromImage: true",
 		},
+		{
+			name:  "open",
+			value: "open: true",
+		},
 		{
 			name: "timeout",
 			value: `timeout:
@@ -0,0 +1,67 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/okteto/okteto/pkg/model/forward"
+)
+
+// ForwardProfiles represents named groups of port forwards that can be enabled while 'okteto up' is running
+type ForwardProfiles map[string][]forward.Forward
+
+// Get returns the forwards of the profile 'name'
+func (fp ForwardProfiles) Get(name string) ([]forward.Forward, error) {
+	forwards, ok := fp[name]
+	if !ok {
+		return nil, fmt.Errorf("forward profile '%s' is not defined in your okteto manifest", name)
+	}
+	return forwards, nil
+}
+
+// Names returns the sorted names of the profiles
+func (fp ForwardProfiles) Names() []string {
+	names := make([]string, 0, len(fp))
+	for name := range fp {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (dev *Dev) validateForwardProfiles() error {
+	usedBy := map[int]string{}
+	for _, f := range dev.Forward {
+		usedBy[f.Local] = "'forward'"
+	}
+
+	for _, name := range dev.ForwardProfiles.Names() {
+		if ValidKubeNameRegex.MatchString(name) {
+			return fmt.Errorf("forward profile name '%s' is not valid. It must contain only lowercase alphanumeric characters or '-'", name)
+		}
+		forwards := dev.ForwardProfiles[name]
+		if len(forwards) == 0 {
+			return fmt.Errorf("forward profile '%s' must define at least one port", name)
+		}
+		for _, f := range forwards {
+			if used, ok := usedBy[f.Local]; ok {
+				return fmt.Errorf("port %d of forward profile '%s' is already used in %s", f.Local, name, used)
+			}
+			usedBy[f.Local] = fmt.Sprintf("forward profile '%s'", name)
+		}
+	}
+	return nil
+}
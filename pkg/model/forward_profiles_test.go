@@ -0,0 +1,111 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestForwardProfilesUnmarshal(t *testing.T) {
+	manifest := []byte(`
+forward:
+  - 8080:80
+forwardProfiles:
+  debug:
+    - 2345:2345
+  db:
+    - 5432:postgres:5432`)
+
+	dev := &Dev{}
+	require.NoError(t, yaml.Unmarshal(manifest, dev))
+
+	expected := ForwardProfiles{
+		"debug": []forward.Forward{{Local: 2345, Remote: 2345}},
+		"db":    []forward.Forward{{Local: 5432, Remote: 5432, Service: true, ServiceName: "postgres"}},
+	}
+	assert.Equal(t, expected, dev.ForwardProfiles)
+	assert.Equal(t, []string{"db", "debug"}, dev.ForwardProfiles.Names())
+
+	forwards, err := dev.ForwardProfiles.Get("debug")
+	assert.NoError(t, err)
+	assert.Equal(t, []forward.Forward{{Local: 2345, Remote: 2345}}, forwards)
+
+	_, err = dev.ForwardProfiles.Get("unknown")
+	assert.Error(t, err)
+}
+
+func TestValidateForwardProfiles(t *testing.T) {
+	tests := []struct {
+		profiles  ForwardProfiles
+		name      string
+		expectErr bool
+	}{
+		{
+			name: "valid profiles",
+			profiles: ForwardProfiles{
+				"debug": []forward.Forward{{Local: 2345, Remote: 2345}},
+				"db":    []forward.Forward{{Local: 5432, Remote: 5432}},
+			},
+		},
+		{
+			name: "port used in forward",
+			profiles: ForwardProfiles{
+				"debug": []forward.Forward{{Local: 8080, Remote: 2345}},
+			},
+			expectErr: true,
+		},
+		{
+			name: "port used in other profile",
+			profiles: ForwardProfiles{
+				"debug": []forward.Forward{{Local: 2345, Remote: 2345}},
+				"db":    []forward.Forward{{Local: 2345, Remote: 5432}},
+			},
+			expectErr: true,
+		},
+		{
+			name: "empty profile",
+			profiles: ForwardProfiles{
+				"debug": []forward.Forward{},
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid name",
+			profiles: ForwardProfiles{
+				"Debug_Ports": []forward.Forward{{Local: 2345, Remote: 2345}},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dev := &Dev{
+				Forward:         []forward.Forward{{Local: 8080, Remote: 80}},
+				ForwardProfiles: tt.profiles,
+			}
+			err := dev.validateForwardProfiles()
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// HelmRelease represents a helm release installed by the deploy commands of a development environment.
+// Releases declared in the deploy section are uninstalled by 'okteto destroy' even if they can't be detected
+type HelmRelease struct {
+	Name string `json:"name" yaml:"name"`
+	// Namespace is the namespace of the release. Empty means the namespace of the development environment
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	// Timeout is the maximum time to uninstall the release. Zero means the helm default
+	Timeout time.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+}
+
+func validateHelmReleases(releases []HelmRelease) error {
+	names := map[string]bool{}
+	for _, release := range releases {
+		if release.Name == "" {
+			return fmt.Errorf("'deploy.helmReleases': every helm release must have a name")
+		}
+		if names[release.Name] {
+			return fmt.Errorf("'deploy.helmReleases': helm release '%s' is declared more than once", release.Name)
+		}
+		names[release.Name] = true
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+const (
+	// PreDeployHook runs before the deploy commands
+	PreDeployHook = "pre-deploy"
+
+	// PostDeployHook runs after the dev environment is successfully deployed
+	PostDeployHook = "post-deploy"
+
+	// PreUpHook runs before the development container is activated
+	PreUpHook = "pre-up"
+
+	// PostUpHook runs once the development container is ready and the files are synchronized
+	PostUpHook = "post-up"
+
+	// PreDestroyHook runs before the destroy commands
+	PreDestroyHook = "pre-destroy"
+)
+
+// Hooks represents the commands executed around the lifecycle of a dev environment
+type Hooks struct {
+	PreDeploy  []DeployCommand `json:"pre-deploy,omitempty" yaml:"pre-deploy,omitempty"`
+	PostDeploy []DeployCommand `json:"post-deploy,omitempty" yaml:"post-deploy,omitempty"`
+	PreUp      []DeployCommand `json:"pre-up,omitempty" yaml:"pre-up,omitempty"`
+	PostUp     []DeployCommand `json:"post-up,omitempty" yaml:"post-up,omitempty"`
+	PreDestroy []DeployCommand `json:"pre-destroy,omitempty" yaml:"pre-destroy,omitempty"`
+}
+
+// GetCommands returns the commands of the given hook
+func (h *Hooks) GetCommands(hook string) []DeployCommand {
+	if h == nil {
+		return nil
+	}
+	switch hook {
+	case PreDeployHook:
+		return h.PreDeploy
+	case PostDeployHook:
+		return h.PostDeploy
+	case PreUpHook:
+		return h.PreUp
+	case PostUpHook:
+		return h.PostUp
+	case PreDestroyHook:
+		return h.PreDestroy
+	default:
+		return nil
+	}
+}
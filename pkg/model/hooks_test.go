@@ -0,0 +1,57 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManifestHooksUnmarshalling(t *testing.T) {
+	manifest, err := Read([]byte(`deploy:
+  - kubectl apply -f k8s.yml
+hooks:
+  pre-deploy:
+    - make migrate
+  post-deploy:
+    - name: smoke tests
+      command: make smoke
+  pre-destroy:
+    - make backup
+`))
+	require.NoError(t, err)
+
+	expected := &Hooks{
+		PreDeploy: []DeployCommand{
+			{Name: "make migrate", Command: "make migrate"},
+		},
+		PostDeploy: []DeployCommand{
+			{Name: "smoke tests", Command: "make smoke"},
+		},
+		PreDestroy: []DeployCommand{
+			{Name: "make backup", Command: "make backup"},
+		},
+	}
+	assert.Equal(t, expected, manifest.Hooks)
+	assert.Equal(t, expected.PostDeploy, manifest.Hooks.GetCommands(PostDeployHook))
+	assert.Empty(t, manifest.Hooks.GetCommands(PreUpHook))
+	assert.Empty(t, manifest.Hooks.GetCommands("unknown"))
+}
+
+func TestHooksGetCommandsNil(t *testing.T) {
+	var h *Hooks
+	assert.Nil(t, h.GetCommands(PreDeployHook))
+}
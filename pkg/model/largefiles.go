@@ -0,0 +1,106 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+)
+
+const (
+	// DefaultLargeFileThreshold is the size above which a synchronized file is reported as large
+	DefaultLargeFileThreshold int64 = 50 * 1024 * 1024
+)
+
+// largeFileCategories are the kinds of large files that can be ignored automatically, by extension
+var largeFileCategories = map[string][]string{
+	"archives":  {".zip", ".tar", ".tgz", ".gz", ".bz2", ".xz", ".7z", ".rar"},
+	"binaries":  {".exe", ".dll", ".so", ".dylib", ".jar", ".war", ".wasm", ".bin"},
+	"databases": {".db", ".sqlite", ".sqlite3", ".sql", ".dump"},
+	"images":    {".iso", ".img", ".dmg", ".vmdk", ".qcow2"},
+	"media":     {".mp4", ".mov", ".avi", ".mkv", ".mp3", ".wav", ".psd"},
+}
+
+// LargeFileCategories returns the categories accepted by 'sync.largeFiles.autoIgnore'
+func LargeFileCategories() []string {
+	result := make([]string, 0, len(largeFileCategories))
+	for category := range largeFileCategories {
+		result = append(result, category)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// GetLargeFileCategory returns the category of a file based on its extension, or an empty string if it has none
+func GetLargeFileCategory(file string) string {
+	ext := strings.ToLower(filepath.Ext(file))
+	if ext == "" {
+		return ""
+	}
+	for category, extensions := range largeFileCategories {
+		for _, e := range extensions {
+			if e == ext {
+				return category
+			}
+		}
+	}
+	return ""
+}
+
+// GetThreshold returns the size in bytes above which a synchronized file is reported as large
+func (l *SyncLargeFiles) GetThreshold() int64 {
+	if l == nil || l.Threshold == nil {
+		return DefaultLargeFileThreshold
+	}
+	return l.Threshold.Value.Value()
+}
+
+// IsAutoIgnored returns if a large file has to be excluded from the synchronization
+func (l *SyncLargeFiles) IsAutoIgnored(file string) bool {
+	if l == nil {
+		return false
+	}
+	category := GetLargeFileCategory(file)
+	if category == "" {
+		return false
+	}
+	for _, c := range l.AutoIgnore {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *SyncLargeFiles) validate() error {
+	if l == nil {
+		return nil
+	}
+	if l.Threshold != nil && l.Threshold.Value.Sign() <= 0 {
+		return fmt.Errorf("'sync.largeFiles.threshold' must be greater than 0")
+	}
+	for _, category := range l.AutoIgnore {
+		if _, ok := largeFileCategories[category]; !ok {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("'%s' is not a valid category for 'sync.largeFiles.autoIgnore'", category),
+				Hint: fmt.Sprintf("Valid categories are: %s", strings.Join(LargeFileCategories(), ", ")),
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestSyncLargeFilesUnmarshal(t *testing.T) {
+	manifest := []byte(`
+folders:
+  - .:/app
+largeFiles:
+  threshold: 10Mi
+  autoIgnore:
+    - archives
+    - media
+`)
+	sync := Sync{}
+	assert.NoError(t, yaml.Unmarshal(manifest, &sync))
+	assert.Equal(t, int64(10*1024*1024), sync.LargeFiles.GetThreshold())
+	assert.True(t, sync.LargeFiles.IsAutoIgnored("dist/app.TAR.GZ"))
+	assert.True(t, sync.LargeFiles.IsAutoIgnored("video.mp4"))
+	assert.False(t, sync.LargeFiles.IsAutoIgnored("data/dump.sql"))
+	assert.NoError(t, sync.LargeFiles.validate())
+}
+
+func TestSyncLargeFilesDefaults(t *testing.T) {
+	var l *SyncLargeFiles
+	assert.Equal(t, DefaultLargeFileThreshold, l.GetThreshold())
+	assert.False(t, l.IsAutoIgnored("app.zip"))
+	assert.NoError(t, l.validate())
+}
+
+func TestSyncLargeFilesValidate(t *testing.T) {
+	l := &SyncLargeFiles{AutoIgnore: []string{"archives", "videos"}}
+	assert.ErrorContains(t, l.validate(), "'videos' is not a valid category")
+
+	l = &SyncLargeFiles{}
+	assert.NoError(t, yaml.Unmarshal([]byte("threshold: 0"), l))
+	assert.ErrorContains(t, l.validate(), "must be greater than 0")
+}
@@ -37,6 +37,7 @@ import (
 	"github.com/spf13/afero"
 	yaml "gopkg.in/yaml.v2"
 	yaml3 "gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 // Archetype represents the type of manifest
@@ -98,6 +99,7 @@ type Manifest struct {
 	Namespace     string                                   `json:"namespace,omitempty" yaml:"namespace,omitempty"`
 	Context       string                                   `json:"context,omitempty" yaml:"context,omitempty"`
 	Icon          string                                   `json:"icon,omitempty" yaml:"icon,omitempty"`
+	Metadata      *Metadata                                `json:"metadata,omitempty" yaml:"metadata,omitempty"`
 	ManifestPath  string                                   `json:"-" yaml:"-"`
 	Deploy        *DeployInfo                              `json:"deploy,omitempty" yaml:"deploy,omitempty"`
 	Dev           ManifestDevs                             `json:"dev,omitempty" yaml:"dev,omitempty"`
@@ -106,6 +108,10 @@ type Manifest struct {
 	Dependencies  deps.ManifestSection                     `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
 	GlobalForward []forward.GlobalForward                  `json:"forward,omitempty" yaml:"forward,omitempty"`
 	External      externalresource.ExternalResourceSection `json:"external,omitempty" yaml:"external,omitempty"`
+	Hooks         *Hooks                                   `json:"hooks,omitempty" yaml:"hooks,omitempty"`
+	Variables     env.Environment                          `json:"variables,omitempty" yaml:"variables,omitempty"`
+	Test          ManifestTests                            `json:"test,omitempty" yaml:"test,omitempty"`
+	Registries    ManifestRegistries                       `json:"registries,omitempty" yaml:"registries,omitempty"`
 
 	Type     Archetype `json:"-" yaml:"-"`
 	Manifest []byte    `json:"-" yaml:"-"`
@@ -181,7 +187,8 @@ type DeployInfo struct {
 	Divert         *DivertDeploy       `json:"divert,omitempty" yaml:"divert,omitempty"`
 	Image          string              `json:"image,omitempty" yaml:"image,omitempty"`
 	Commands       []DeployCommand     `json:"commands,omitempty" yaml:"commands,omitempty"`
-	Remote         bool                `json:"remote,omitempty" yaml:"remote,omitempty"`
+	Remote         *RemoteDeploy       `json:"remote,omitempty" yaml:"remote,omitempty"`
+	HelmReleases   []HelmRelease       `json:"helmReleases,omitempty" yaml:"helmReleases,omitempty"`
 }
 
 // DestroyInfo represents what must be destroyed for the app
@@ -628,6 +635,11 @@ func getOktetoManifest(devPath string) (*Manifest, error) {
 		return nil, fmt.Errorf("%s: %w", oktetoErrors.ErrInvalidManifest, oktetoErrors.ErrEmptyManifest)
 	}
 
+	b, err = resolveManifestExtends(devPath, b)
+	if err != nil {
+		return nil, err
+	}
+
 	manifest, err := Read(b)
 	if err != nil {
 		if errors.Is(err, oktetoErrors.ErrNotManifestContentDetected) {
@@ -657,6 +669,12 @@ func Read(bytes []byte) (*Manifest, error) {
 	manifest := NewManifest()
 
 	if bytes != nil {
+		if err := checkManifestExtendsResolved(bytes); err != nil {
+			return nil, err
+		}
+		if err := exportVariableDefaults(bytes); err != nil {
+			return nil, err
+		}
 		if err := yaml.UnmarshalStrict(bytes, manifest); err != nil {
 			if err := yaml.Unmarshal(bytes, manifest); err == nil {
 				if reflect.DeepEqual(manifest, NewManifest()) {
@@ -693,9 +711,45 @@ func (m *Manifest) validate() error {
 	if err := m.Build.Validate(); err != nil {
 		return err
 	}
+	if err := m.Test.Validate(); err != nil {
+		return err
+	}
+	if err := m.Registries.Validate(); err != nil {
+		return err
+	}
+	if m.Deploy != nil {
+		if err := m.Deploy.Remote.validate(); err != nil {
+			return err
+		}
+	}
+	if err := m.Metadata.validate(); err != nil {
+		return err
+	}
 	return m.validateDivert()
 }
 
+// validate checks that the metadata labels and annotations follow the kubernetes syntax,
+// as they are added to every object created by okteto
+func (md *Metadata) validate() error {
+	if md == nil {
+		return nil
+	}
+	for k, v := range md.Labels {
+		if errs := validation.IsQualifiedName(k); len(errs) > 0 {
+			return fmt.Errorf("metadata.labels key '%s' is not valid: %s", k, strings.Join(errs, ", "))
+		}
+		if errs := validation.IsValidLabelValue(v); len(errs) > 0 {
+			return fmt.Errorf("metadata.labels value '%s' of '%s' is not valid: %s", v, k, strings.Join(errs, ", "))
+		}
+	}
+	for k := range md.Annotations {
+		if errs := validation.IsQualifiedName(k); len(errs) > 0 {
+			return fmt.Errorf("metadata.annotations key '%s' is not valid: %s", k, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
 func (s *Secret) validate() error {
 	if s.LocalPath == "" || s.RemotePath == "" {
 		return fmt.Errorf("secrets must follow the syntax 'LOCAL_PATH:REMOTE_PATH:MODE'")
@@ -965,9 +1019,33 @@ func (manifest *Manifest) ExpandEnvVars() error {
 		}
 	}
 
+	for _, test := range manifest.Test {
+		if test == nil {
+			continue
+		}
+		if err := test.expandEnvVars(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// exportVariableDefaults exports the default values of the manifest variables that are not defined by flags, the environment or the .env file.
+// It runs before the manifest is unmarshalled because the environment variables are expanded while unmarshalling
+func exportVariableDefaults(bytes []byte) error {
+	raw := struct {
+		Variables env.Environment `yaml:"variables,omitempty"`
+	}{}
+	if err := yaml.Unmarshal(bytes, &raw); err != nil {
+		// the error is returned by the unmarshal of the whole manifest
+		return nil
+	}
+	r := env.NewResolver()
+	r.Manifest = raw.Variables
+	return r.ExportManifestDefaults()
+}
+
 // InferFromStack infers data from a stackfile
 func (m *Manifest) InferFromStack(cwd string) (*Manifest, error) {
 	for svcName, svcInfo := range m.Deploy.ComposeSection.Stack.Services {
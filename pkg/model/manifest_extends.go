@@ -0,0 +1,276 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/config"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/filesystem"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	yaml3 "gopkg.in/yaml.v3"
+)
+
+const (
+	manifestExtendsField = "extends"
+	manifestIncludeField = "include"
+
+	// manifestOverrideSuffix is added to the name of the manifest to get the name of its personal override file: okteto.yml -> okteto.override.yml
+	manifestOverrideSuffix = ".override"
+)
+
+var (
+	// ErrManifestExtendsNotResolved is raised when a manifest declaring 'extends' or 'include' is not read from a file
+	ErrManifestExtendsNotResolved = errors.New("'extends' and 'include' are only supported in okteto manifest files")
+)
+
+// manifestReference is a manifest file referenced by 'extends' or 'include'
+type manifestReference struct {
+	file     string
+	optional bool
+}
+
+// manifestExtendsResolver composes a manifest with the manifests it extends and includes.
+//
+// The manifests listed in 'extends' are the base of the manifest, and the manifests listed in 'include' are overlays applied on top of it.
+// Both are merged in the order they are declared following these rules:
+//   - maps are merged key by key
+//   - scalars and sequences of the overlay replace the ones of the base
+//   - a null value in the overlay removes the key from the base
+//
+// Paths in 'extends' and 'include' are relative to the manifest declaring them.
+// The rest of paths, like build contexts, are always relative to the main manifest
+type manifestExtendsResolver struct {
+	visiting map[string]bool
+}
+
+// resolveManifestExtends returns the manifest in 'path' composed with the manifests it extends and includes
+func resolveManifestExtends(path string, file []byte) ([]byte, error) {
+	doc := yaml3.Node{}
+	if err := yaml3.Unmarshal(file, &doc); err != nil {
+		// the manifest parser returns a friendlier error
+		return file, nil
+	}
+	if len(doc.Content) == 0 {
+		return file, nil
+	}
+	override := getManifestOverridePath(path)
+	if !hasManifestExtends(doc.Content[0]) && override == "" {
+		return file, nil
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	r := &manifestExtendsResolver{visiting: map[string]bool{}}
+	manifest, err := r.resolve(absPath, doc.Content[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w:\n%w", oktetoErrors.ErrInvalidManifest, err)
+	}
+	if override != "" {
+		overlay, err := r.load(absPath, manifestReference{file: override, optional: true})
+		if err != nil {
+			return nil, fmt.Errorf("%w:\n%w", oktetoErrors.ErrInvalidManifest, err)
+		}
+		if overlay != nil {
+			oktetoLog.Infof("merging '%s' over '%s'", override, path)
+			manifest = mergeManifestNodes(manifest, overlay)
+		}
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	encoder := yaml3.NewEncoder(buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(manifest); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// GetManifestOverridePath returns the path of the personal override file of the manifest in 'path', even if it doesn't exist
+func GetManifestOverridePath(path string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + manifestOverrideSuffix + ext
+}
+
+// IsManifestOverrideDisabled returns true if the personal override files must be ignored
+func IsManifestOverrideDisabled() bool {
+	disabled, _ := config.EnvOktetoDisableManifestOverride.Bool()
+	return disabled
+}
+
+// getManifestOverridePath returns the override file to merge over the manifest in 'path', or empty if there is none
+func getManifestOverridePath(path string) string {
+	if IsManifestOverrideDisabled() {
+		return ""
+	}
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	if strings.HasSuffix(name, manifestOverrideSuffix) {
+		return ""
+	}
+	override := GetManifestOverridePath(path)
+	if !filesystem.FileExists(override) {
+		return ""
+	}
+	return filepath.Base(override)
+}
+
+// checkManifestExtendsResolved returns an error if the manifest still declares 'extends' or 'include'
+func checkManifestExtendsResolved(file []byte) error {
+	doc := yaml3.Node{}
+	if err := yaml3.Unmarshal(file, &doc); err != nil || len(doc.Content) == 0 {
+		return nil
+	}
+	if hasManifestExtends(doc.Content[0]) {
+		return fmt.Errorf("%w: %w", oktetoErrors.ErrInvalidManifest, ErrManifestExtendsNotResolved)
+	}
+	return nil
+}
+
+func hasManifestExtends(node *yaml3.Node) bool {
+	return getMappingValue(node, manifestExtendsField) != nil || getMappingValue(node, manifestIncludeField) != nil
+}
+
+// resolve returns the manifest 'node' of the file 'path' merged with its base manifests and overlays
+func (r *manifestExtendsResolver) resolve(path string, node *yaml3.Node) (*yaml3.Node, error) {
+	if node.Kind != yaml3.MappingNode {
+		return nil, fmt.Errorf("'%s' is not a valid okteto manifest", path)
+	}
+	if r.visiting[path] {
+		return nil, fmt.Errorf("circular reference to '%s'", path)
+	}
+	r.visiting[path] = true
+	defer delete(r.visiting, path)
+
+	extends, err := getManifestReferences(manifestExtendsField, removeMappingValue(node, manifestExtendsField))
+	if err != nil {
+		return nil, err
+	}
+	include, err := getManifestReferences(manifestIncludeField, removeMappingValue(node, manifestIncludeField))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &yaml3.Node{Kind: yaml3.MappingNode, Tag: "!!map"}
+	for _, ref := range extends {
+		base, err := r.load(path, ref)
+		if err != nil {
+			return nil, err
+		}
+		if base != nil {
+			result = mergeManifestNodes(result, base)
+		}
+	}
+	result = mergeManifestNodes(result, node)
+	for _, ref := range include {
+		overlay, err := r.load(path, ref)
+		if err != nil {
+			return nil, err
+		}
+		if overlay != nil {
+			result = mergeManifestNodes(result, overlay)
+		}
+	}
+	return result, nil
+}
+
+// load reads and resolves a manifest referenced from the manifest in 'from'. It returns nil for missing optional manifests
+func (r *manifestExtendsResolver) load(from string, ref manifestReference) (*yaml3.Node, error) {
+	path := ref.file
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(from), path)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) && ref.optional {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading '%s': %w", ref.file, err)
+	}
+	doc := yaml3.Node{}
+	if err := yaml3.Unmarshal(b, &doc); err != nil {
+		return nil, fmt.Errorf("error parsing '%s': %w", ref.file, err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+	return r.resolve(path, doc.Content[0])
+}
+
+// getManifestReferences parses the value of 'extends' or 'include': a file, or a list of files or {file, optional} maps
+func getManifestReferences(field string, node *yaml3.Node) ([]manifestReference, error) {
+	if node == nil {
+		return nil, nil
+	}
+	items := []*yaml3.Node{node}
+	if node.Kind == yaml3.SequenceNode {
+		items = node.Content
+	}
+
+	result := []manifestReference{}
+	for _, item := range items {
+		ref := manifestReference{}
+		switch item.Kind {
+		case yaml3.ScalarNode:
+			ref.file = item.Value
+		case yaml3.MappingNode:
+			if v := getMappingValue(item, "file"); v != nil {
+				ref.file = v.Value
+			}
+			if v := getMappingValue(item, "optional"); v != nil {
+				if err := v.Decode(&ref.optional); err != nil {
+					return nil, fmt.Errorf("%s.optional must be a boolean", field)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("%s must be a file, or a list of files", field)
+		}
+		if ref.file == "" {
+			return nil, fmt.Errorf("%s: file can't be empty", field)
+		}
+		result = append(result, ref)
+	}
+	return result, nil
+}
+
+// mergeManifestNodes merges 'overlay' into 'base': maps are merged, sequences and scalars are replaced and null values remove the key
+func mergeManifestNodes(base, overlay *yaml3.Node) *yaml3.Node {
+	if base.Kind != yaml3.MappingNode || overlay.Kind != yaml3.MappingNode {
+		return overlay
+	}
+	for i := 0; i+1 < len(overlay.Content); i += 2 {
+		key := overlay.Content[i]
+		value := overlay.Content[i+1]
+		baseValue := getMappingValue(base, key.Value)
+		switch {
+		case value.Kind == yaml3.ScalarNode && value.Tag == "!!null":
+			removeMappingValue(base, key.Value)
+		case baseValue == nil:
+			base.Content = append(base.Content, key, value)
+		case baseValue.Kind == yaml3.MappingNode && value.Kind == yaml3.MappingNode:
+			setMappingValue(base, key.Value, mergeManifestNodes(baseValue, value))
+		default:
+			setMappingValue(base, key.Value, value)
+		}
+	}
+	return base
+}
@@ -0,0 +1,245 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/constants"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeManifestFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func Test_getOktetoManifestWithExtendsAndInclude(t *testing.T) {
+	dir := t.TempDir()
+	writeManifestFile(t, dir, "base/okteto.base.yml", `
+build:
+  api:
+    context: api
+deploy:
+  - helm upgrade --install movies chart
+dev:
+  api:
+    command: bash
+    environment:
+      LOG_LEVEL: info
+      DEBUG: "false"
+    sync:
+      - .:/usr/src/app
+`)
+	writeManifestFile(t, dir, "okteto.cindy.yml", `
+dev:
+  api:
+    environment:
+      DEBUG: "true"
+    sync:
+      - api:/usr/src/app
+`)
+	manifestPath := writeManifestFile(t, dir, "okteto.yml", `
+extends: base/okteto.base.yml
+include:
+  - okteto.cindy.yml
+  - file: okteto.local.yml
+    optional: true
+name: movies
+deploy:
+  - okteto build
+  - helm upgrade --install movies chart
+`)
+
+	manifest, err := getOktetoManifest(manifestPath)
+	require.NoError(t, err)
+
+	assert.Equal(t, "movies", manifest.Name)
+	require.Contains(t, manifest.Build, "api")
+	require.Len(t, manifest.Deploy.Commands, 2)
+	assert.Equal(t, "okteto build", manifest.Deploy.Commands[0].Command)
+
+	api := manifest.Dev["api"]
+	require.NotNil(t, api)
+	assert.Equal(t, []string{"bash"}, api.Command.Values)
+	assert.ElementsMatch(t, []string{"LOG_LEVEL=info", "DEBUG=true"}, environmentToStrings(api.Environment))
+	require.Len(t, api.Sync.Folders, 1)
+	assert.Equal(t, "/usr/src/app", api.Sync.Folders[0].RemotePath)
+	assert.Equal(t, "api", api.Sync.Folders[0].LocalPath)
+}
+
+func Test_resolveManifestExtendsNullRemovesKey(t *testing.T) {
+	dir := t.TempDir()
+	writeManifestFile(t, dir, "okteto.base.yml", `
+name: movies
+icon: database
+dev:
+  api:
+    command: bash
+  worker:
+    command: bash
+`)
+	manifestPath := writeManifestFile(t, dir, "okteto.yml", `
+extends: [okteto.base.yml]
+icon: null
+dev:
+  worker: null
+`)
+	b, err := os.ReadFile(manifestPath)
+	require.NoError(t, err)
+
+	result, err := resolveManifestExtends(manifestPath, b)
+	require.NoError(t, err)
+	assert.Equal(t, "name: movies\ndev:\n  api:\n    command: bash\n", string(result))
+}
+
+func Test_resolveManifestExtendsErrors(t *testing.T) {
+	tests := []struct {
+		files       map[string]string
+		name        string
+		expectedErr string
+	}{
+		{
+			name: "circular reference",
+			files: map[string]string{
+				"okteto.yml":      "extends: okteto.base.yml\nname: movies",
+				"okteto.base.yml": "extends: okteto.yml\nicon: database",
+			},
+			expectedErr: "circular reference",
+		},
+		{
+			name: "missing file",
+			files: map[string]string{
+				"okteto.yml": "include: okteto.missing.yml\nname: movies",
+			},
+			expectedErr: "error reading 'okteto.missing.yml'",
+		},
+		{
+			name: "invalid reference",
+			files: map[string]string{
+				"okteto.yml": "extends:\n  - optional: true\nname: movies",
+			},
+			expectedErr: "extends: file can't be empty",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				writeManifestFile(t, dir, name, content)
+			}
+			manifestPath := filepath.Join(dir, "okteto.yml")
+			b, err := os.ReadFile(manifestPath)
+			require.NoError(t, err)
+
+			_, err = resolveManifestExtends(manifestPath, b)
+			assert.ErrorIs(t, err, oktetoErrors.ErrInvalidManifest)
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func Test_resolveManifestExtendsWithoutExtends(t *testing.T) {
+	b := []byte("# comment\nname: movies\n")
+	result, err := resolveManifestExtends("okteto.yml", b)
+	require.NoError(t, err)
+	assert.Equal(t, b, result)
+}
+
+func Test_ReadWithUnresolvedExtends(t *testing.T) {
+	_, err := Read([]byte("extends: okteto.base.yml\nname: movies"))
+	assert.ErrorIs(t, err, ErrManifestExtendsNotResolved)
+}
+
+func Test_getOktetoManifestWithOverride(t *testing.T) {
+	manifest := `
+name: movies
+deploy:
+  - helm upgrade --install movies chart
+dev:
+  api:
+    command: bash
+    sync:
+      - .:/usr/src/app
+    forward:
+      - 8080:8080
+`
+	override := `
+dev:
+  api:
+    sync:
+      - api:/usr/src/app
+    forward:
+      - 8080:8080
+      - 9229:9229
+`
+	tests := []struct {
+		name             string
+		override         string
+		disabled         string
+		expectedSync     string
+		expectedForwards int
+	}{
+		{
+			name:             "override-merged",
+			override:         override,
+			expectedSync:     "api",
+			expectedForwards: 2,
+		},
+		{
+			name:             "override-disabled",
+			override:         override,
+			disabled:         "true",
+			expectedSync:     ".",
+			expectedForwards: 1,
+		},
+		{
+			name:             "no-override",
+			expectedSync:     ".",
+			expectedForwards: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(constants.OktetoDisableManifestOverrideEnvVar, tt.disabled)
+			dir := t.TempDir()
+			manifestPath := writeManifestFile(t, dir, "okteto.yml", manifest)
+			if tt.override != "" {
+				writeManifestFile(t, dir, "okteto.override.yml", tt.override)
+			}
+
+			result, err := getOktetoManifest(manifestPath)
+			require.NoError(t, err)
+
+			api := result.Dev["api"]
+			require.NotNil(t, api)
+			assert.Equal(t, "bash", api.Command.Values[0])
+			require.Len(t, api.Sync.Folders, 1)
+			assert.Equal(t, tt.expectedSync, api.Sync.Folders[0].LocalPath)
+			assert.Len(t, api.Forward, tt.expectedForwards)
+		})
+	}
+}
+
+func Test_GetManifestOverridePath(t *testing.T) {
+	assert.Equal(t, filepath.Join("app", "okteto.override.yml"), GetManifestOverridePath(filepath.Join("app", "okteto.yml")))
+	assert.Equal(t, "okteto.override.yaml", GetManifestOverridePath("okteto.yaml"))
+}
@@ -19,6 +19,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/agext/levenshtein"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/suggest"
 	"sigs.k8s.io/kustomize/kyaml/yaml"
@@ -39,27 +40,25 @@ func getManifestSuggestionRules(manifestSchema interface{}) []*suggest.Rule {
 	}
 
 	// add levenshtein rules to suggest similar field names
-	manifestKeys := getStructKeys(manifestSchema)
+	manifestKeys := getManifestKeys(manifestSchema)
 	manifestKeys["model.manifestRaw"] = manifestKeys["model.Manifest"]
 	manifestKeys["build.buildInfoRaw"] = manifestKeys["build.BuildInfo"]
 	manifestKeys["model.DevRC"] = manifestKeys["model.Dev"]
 	manifestKeys["model.devType"] = manifestKeys["model.Dev"]
 
 	for structName, structKeywords := range manifestKeys {
-		for _, keyword := range structKeywords {
-			// example: line 5: field contest not found in type build.buildInfoRaw
-			// (.*?): this excludes eerything before the keyword "field"
-			// (\w+): this captures the keyword we want to calculate the levenshtein distance with
-			// (in type|into): this ensures to match all variations of the error message
-			// (.*?): this excludes everything after the message that we want to find
-			pattern := fmt.Sprintf(`(.*?)field (\w+) not found (in type|into) %s(.*?)`, structName)
-
-			// keywordInGroup is the index of the capturing group that contains the actual mistyped keyword
-			// set to 2 because index 0 is the whole sentence and index 1 is "line 5"
-			keywordInGroup := 2
-			rule := suggest.NewLevenshteinRule(pattern, keyword, keywordInGroup)
-			rules = append(rules, rule)
-		}
+		// example: line 5: field contest not found in type build.buildInfoRaw
+		// (.*?): this excludes eerything before the keyword "field"
+		// (\w+): this captures the keyword we want to calculate the levenshtein distance with
+		// (in type|into): this ensures to match all variations of the error message
+		// \b: this ensures that model.Manifest doesn't match model.ManifestBuild
+		// (.*?): this excludes everything after the message that we want to find
+		pattern := fmt.Sprintf(`(.*?)field (\w+) not found (in type|into) %s\b(.*?)`, regexp.QuoteMeta(structName))
+
+		// keywordInGroup is the index of the capturing group that contains the actual mistyped keyword
+		// set to 2 because index 0 is the whole sentence and index 1 is "line 5"
+		keywordInGroup := 2
+		rules = append(rules, closestKeywordRule(pattern, structKeywords, keywordInGroup))
 	}
 
 	rules = append(rules,
@@ -140,6 +139,59 @@ func indentNumLines() *suggest.Rule {
 	return suggest.NewRule(condition, transformation)
 }
 
+// closestKeywordRule suggests the closest keyword to every mistyped keyword matched by the pattern.
+// Only one keyword is suggested even if the mistyped one is close to several of them, like 'contest' to 'context' and 'test'
+func closestKeywordRule(pattern string, keywords []string, keywordInGroup int) *suggest.Rule {
+	re := regexp.MustCompile(pattern)
+
+	condition := func(e error) bool {
+		for _, match := range re.FindAllStringSubmatch(e.Error(), -1) {
+			if getClosestKeyword(match[keywordInGroup], keywords) != "" {
+				return true
+			}
+		}
+		return false
+	}
+
+	transformation := func(e error) error {
+		errorMsg := e.Error()
+		for _, match := range re.FindAllStringSubmatch(errorMsg, -1) {
+			closest := getClosestKeyword(match[keywordInGroup], keywords)
+			if closest == "" {
+				continue
+			}
+			// match[0] is the whole string that matched the regex
+			suggestion := fmt.Sprintf("%s. Did you mean \"%s\"?", match[0], closest)
+			errorMsg = strings.Replace(errorMsg, match[0], suggestion, 1)
+		}
+		return errors.New(errorMsg)
+	}
+
+	return suggest.NewRule(condition, transformation)
+}
+
+// getClosestKeyword returns the keyword with the lowest levenshtein distance to word, or an empty string if none is close enough.
+// The thresholds are the ones of suggest.NewLevenshteinRule
+func getClosestKeyword(word string, keywords []string) string {
+	closest := ""
+	closestDistance := 0
+	for _, keyword := range keywords {
+		threshold := 3
+		if len(keyword) <= threshold {
+			threshold = 1
+		}
+		distance := levenshtein.Distance(keyword, word, nil)
+		if distance > threshold {
+			continue
+		}
+		if closest == "" || distance < closestDistance {
+			closest = keyword
+			closestDistance = distance
+		}
+	}
+	return closest
+}
+
 // fieldsNotExistingRule replaces "not found" fields which are unknown to the Okteto manifest specification
 func fieldsNotExistingRule() *suggest.Rule {
 	pattern := `field (\w+) not found`
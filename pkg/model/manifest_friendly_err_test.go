@@ -74,6 +74,13 @@ yaml: some random error
 			input: errors.New("yaml: unmarshal errors:\n  line 4: field contest not found in type model.manifestRaw"),
 			expected: `your okteto manifest is not valid, please check the following errors:
      - line 4: field 'contest' is not a property of the okteto manifest. Did you mean "context"?
+    Check out the okteto manifest docs at: https://www.okteto.com/docs/reference/manifest`,
+		},
+		{
+			name:  "suggestion for manifest compose keys",
+			input: errors.New("yaml: unmarshal errors:\n  line 1: field incude not found in type model.manifestRaw"),
+			expected: `your okteto manifest is not valid, please check the following errors:
+     - line 1: field 'incude' is not a property of the okteto manifest. Did you mean "include"?
     Check out the okteto manifest docs at: https://www.okteto.com/docs/reference/manifest`,
 		},
 	}
@@ -85,3 +92,10 @@ yaml: some random error
 		})
 	}
 }
+
+func TestGetClosestKeyword(t *testing.T) {
+	keywords := []string{"test", "context", "deploy"}
+	assert.Equal(t, "context", getClosestKeyword("contest", keywords))
+	assert.Equal(t, "test", getClosestKeyword("tset", keywords))
+	assert.Equal(t, "", getClosestKeyword("unknown", keywords))
+}
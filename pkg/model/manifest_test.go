@@ -335,6 +335,50 @@ func Test_validateDivert(t *testing.T) {
 	}
 }
 
+func TestMetadataValidate(t *testing.T) {
+	tests := []struct {
+		metadata    *Metadata
+		name        string
+		expectedErr bool
+	}{
+		{
+			name: "nil metadata",
+		},
+		{
+			name: "valid metadata",
+			metadata: &Metadata{
+				Labels:      Labels{"okteto.com/team": "platform", "cost-center": ""},
+				Annotations: Annotations{"okteto.com/owner": "Platform team <platform@okteto.com>"},
+			},
+		},
+		{
+			name:        "invalid label key",
+			metadata:    &Metadata{Labels: Labels{"cost center": "1"}},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid label value",
+			metadata:    &Metadata{Labels: Labels{"team": "platform team"}},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid annotation key",
+			metadata:    &Metadata{Annotations: Annotations{"-owner": "platform"}},
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.metadata.validate()
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func Test_validateManifestBuild(t *testing.T) {
 	tests := []struct {
 		buildSection build.ManifestBuild
@@ -1417,7 +1461,7 @@ func TestRead(t *testing.T) {
 					Endpoints: nil,
 					Image:     "",
 					Commands:  nil,
-					Remote:    false,
+					Remote:    nil,
 				},
 				Dev: ManifestDevs{},
 				Destroy: &DestroyInfo{
@@ -1447,7 +1491,7 @@ func TestRead(t *testing.T) {
 					Endpoints: nil,
 					Image:     "",
 					Commands:  nil,
-					Remote:    false,
+					Remote:    nil,
 				},
 				Dev: ManifestDevs{},
 				Destroy: &DestroyInfo{
@@ -1610,7 +1654,7 @@ func TestRead(t *testing.T) {
 					},
 					Image:    "",
 					Commands: nil,
-					Remote:   false,
+					Remote:   nil,
 				},
 				Dev: ManifestDevs{},
 				Destroy: &DestroyInfo{
@@ -1684,3 +1728,28 @@ func TestPathExistsAndDirError(t *testing.T) {
 		})
 	}
 }
+
+func TestReadExportsVariableDefaultsBeforeExpanding(t *testing.T) {
+	t.Cleanup(func() {
+		os.Unsetenv("OKTETO_TEST_VARIABLE_TAG")
+	})
+	manifest := []byte(`
+variables:
+  OKTETO_TEST_VARIABLE_TAG: "1.0"
+  OKTETO_TEST_VARIABLE_DEFINED: default
+dev:
+  api:
+    image: okteto/api:${OKTETO_TEST_VARIABLE_TAG}
+    command: bash
+    environment:
+      DEFINED: ${OKTETO_TEST_VARIABLE_DEFINED}
+    sync:
+      - .:/app
+`)
+	t.Setenv("OKTETO_TEST_VARIABLE_DEFINED", "env")
+
+	m, err := Read(manifest)
+	require.NoError(t, err)
+	assert.Equal(t, "okteto/api:1.0", m.Dev["api"].Image.Name)
+	assert.Equal(t, env.Environment{{Name: "DEFINED", Value: "env"}}, m.Dev["api"].Environment)
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// RegistryProviderECR exchanges the AWS credentials of the user for a token of Amazon ECR
+	RegistryProviderECR = "ecr"
+
+	// RegistryProviderGCR exchanges the gcloud credentials of the user for a token of Google Container/Artifact Registry
+	RegistryProviderGCR = "gcr"
+
+	// RegistryProviderACR exchanges the Azure credentials of the user for a token of Azure Container Registry
+	RegistryProviderACR = "acr"
+)
+
+// ManifestRegistries defines the private external registries the builds authenticate against
+type ManifestRegistries []RegistryLogin
+
+// RegistryLogin defines how to get short-lived credentials for a private external registry
+type RegistryLogin struct {
+	Server   string `json:"server,omitempty" yaml:"server,omitempty"`
+	Provider string `json:"provider,omitempty" yaml:"provider,omitempty"`
+	Helper   string `json:"helper,omitempty" yaml:"helper,omitempty"`
+}
+
+// GetProvider returns the provider of the registry, inferring it from the server when it is not defined
+func (r RegistryLogin) GetProvider() string {
+	if r.Provider != "" || r.Helper != "" {
+		return r.Provider
+	}
+	host := strings.ToLower(r.Server)
+	switch {
+	case strings.Contains(host, ".dkr.ecr.") && strings.HasSuffix(host, ".amazonaws.com"):
+		return RegistryProviderECR
+	case strings.HasSuffix(host, ".azurecr.io"):
+		return RegistryProviderACR
+	case host == "gcr.io" || strings.HasSuffix(host, ".gcr.io") || strings.HasSuffix(host, "-docker.pkg.dev"):
+		return RegistryProviderGCR
+	}
+	return ""
+}
+
+// Validate validates the registries section of the manifest
+func (mr ManifestRegistries) Validate() error {
+	servers := map[string]bool{}
+	for i, r := range mr {
+		if r.Server == "" {
+			return fmt.Errorf("the field 'registries[%d].server' is mandatory", i)
+		}
+		if servers[r.Server] {
+			return fmt.Errorf("registry '%s' is defined more than once", r.Server)
+		}
+		servers[r.Server] = true
+		if r.Provider != "" && r.Helper != "" {
+			return fmt.Errorf("registry '%s' can't define both 'provider' and 'helper'", r.Server)
+		}
+		switch r.GetProvider() {
+		case RegistryProviderECR, RegistryProviderGCR, RegistryProviderACR:
+		case "":
+			if r.Helper == "" {
+				return fmt.Errorf("registry '%s' must define a 'provider' (%s, %s or %s) or a docker credential 'helper'", r.Server, RegistryProviderECR, RegistryProviderGCR, RegistryProviderACR)
+			}
+		default:
+			return fmt.Errorf("registry '%s' has an unsupported provider '%s'. Supported providers: %s, %s, %s", r.Server, r.Provider, RegistryProviderECR, RegistryProviderGCR, RegistryProviderACR)
+		}
+	}
+	return nil
+}
+
+// Get returns the registry of a server
+func (mr ManifestRegistries) Get(server string) (RegistryLogin, bool) {
+	for _, r := range mr {
+		if r.Server == server {
+			return r, true
+		}
+	}
+	return RegistryLogin{}, false
+}
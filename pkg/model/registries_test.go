@@ -0,0 +1,120 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestRegistryLoginGetProvider(t *testing.T) {
+	tests := []struct {
+		name     string
+		registry RegistryLogin
+		expected string
+	}{
+		{name: "ecr", registry: RegistryLogin{Server: "123456789012.dkr.ecr.us-east-1.amazonaws.com"}, expected: RegistryProviderECR},
+		{name: "acr", registry: RegistryLogin{Server: "myregistry.azurecr.io"}, expected: RegistryProviderACR},
+		{name: "gcr", registry: RegistryLogin{Server: "gcr.io"}, expected: RegistryProviderGCR},
+		{name: "regional gcr", registry: RegistryLogin{Server: "eu.gcr.io"}, expected: RegistryProviderGCR},
+		{name: "artifact registry", registry: RegistryLogin{Server: "europe-west1-docker.pkg.dev"}, expected: RegistryProviderGCR},
+		{name: "explicit provider", registry: RegistryLogin{Server: "registry.example.com", Provider: RegistryProviderECR}, expected: RegistryProviderECR},
+		{name: "helper", registry: RegistryLogin{Server: "gcr.io", Helper: "gcloud"}, expected: ""},
+		{name: "unknown", registry: RegistryLogin{Server: "registry.example.com"}, expected: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.registry.GetProvider())
+		})
+	}
+}
+
+func TestManifestRegistriesValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		registries ManifestRegistries
+		expectErr  bool
+	}{
+		{
+			name:       "empty",
+			registries: ManifestRegistries{},
+		},
+		{
+			name: "valid",
+			registries: ManifestRegistries{
+				{Server: "123456789012.dkr.ecr.us-east-1.amazonaws.com"},
+				{Server: "registry.example.com", Helper: "pass"},
+				{Server: "registry.acme.com", Provider: RegistryProviderACR},
+			},
+		},
+		{
+			name:       "missing server",
+			registries: ManifestRegistries{{Provider: RegistryProviderECR}},
+			expectErr:  true,
+		},
+		{
+			name:       "duplicated server",
+			registries: ManifestRegistries{{Server: "gcr.io"}, {Server: "gcr.io"}},
+			expectErr:  true,
+		},
+		{
+			name:       "provider and helper",
+			registries: ManifestRegistries{{Server: "gcr.io", Provider: RegistryProviderGCR, Helper: "gcloud"}},
+			expectErr:  true,
+		},
+		{
+			name:       "unknown provider",
+			registries: ManifestRegistries{{Server: "registry.example.com", Provider: "docker"}},
+			expectErr:  true,
+		},
+		{
+			name:       "provider not inferred",
+			registries: ManifestRegistries{{Server: "registry.example.com"}},
+			expectErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.registries.Validate()
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestManifestRegistriesUnmarshal(t *testing.T) {
+	b := []byte(`registries:
+  - server: 123456789012.dkr.ecr.us-east-1.amazonaws.com
+  - server: registry.example.com
+    helper: pass
+`)
+	manifest := &Manifest{}
+	require.NoError(t, yaml.UnmarshalStrict(b, manifest))
+	assert.Equal(t, ManifestRegistries{
+		{Server: "123456789012.dkr.ecr.us-east-1.amazonaws.com"},
+		{Server: "registry.example.com", Helper: "pass"},
+	}, manifest.Registries)
+
+	r, ok := manifest.Registries.Get("registry.example.com")
+	assert.True(t, ok)
+	assert.Equal(t, "pass", r.Helper)
+	_, ok = manifest.Registries.Get("gcr.io")
+	assert.False(t, ok)
+}
@@ -0,0 +1,114 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// RemoteDeploy represents the execution environment of the deploy commands when they run in the cluster
+type RemoteDeploy struct {
+	Resources      ResourceRequirements `json:"resources,omitempty" yaml:"resources,omitempty"`
+	Tolerations    []apiv1.Toleration   `json:"tolerations,omitempty" yaml:"tolerations,omitempty"`
+	Image          string               `json:"image,omitempty" yaml:"image,omitempty"`
+	ServiceAccount string               `json:"serviceAccount,omitempty" yaml:"serviceAccount,omitempty"`
+	Enabled        bool                 `json:"-" yaml:"-"`
+}
+
+// IsEnabled returns true if the deploy commands must run in the cluster
+func (r *RemoteDeploy) IsEnabled() bool {
+	return r != nil && r.Enabled
+}
+
+// HasExecutionSettings returns true if the resources, tolerations or service account of the remote deploy are set
+func (r *RemoteDeploy) HasExecutionSettings() bool {
+	if r == nil {
+		return false
+	}
+	return len(r.Resources.Limits) > 0 || len(r.Resources.Requests) > 0 || len(r.Tolerations) > 0 || r.ServiceAccount != ""
+}
+
+// UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
+func (r *RemoteDeploy) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var enabled bool
+	if err := unmarshal(&enabled); err == nil {
+		*r = RemoteDeploy{Enabled: enabled}
+		return nil
+	}
+
+	type remoteDeployRaw RemoteDeploy // This is necessary to prevent recursion
+	var raw remoteDeployRaw
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+	*r = RemoteDeploy(raw)
+	r.Enabled = true
+	return nil
+}
+
+// MarshalYAML Implements the marshaler interface of the yaml pkg.
+func (r *RemoteDeploy) MarshalYAML() (interface{}, error) {
+	if r.Image == "" && !r.HasExecutionSettings() {
+		return r.Enabled, nil
+	}
+	type remoteDeployRaw RemoteDeploy
+	return remoteDeployRaw(*r), nil
+}
+
+func (r *RemoteDeploy) validate() error {
+	if r == nil {
+		return nil
+	}
+	for resourceName, limit := range r.Resources.Limits {
+		if request, ok := r.Resources.Requests[resourceName]; ok && request.Cmp(limit) > 0 {
+			return fmt.Errorf("deploy.remote.resources: the request of '%s' can't be greater than its limit", resourceName)
+		}
+	}
+	for i, toleration := range r.Tolerations {
+		if err := validateToleration(toleration); err != nil {
+			return fmt.Errorf("deploy.remote.tolerations[%d]: %w", i, err)
+		}
+	}
+	if r.ServiceAccount != "" {
+		if errs := validation.IsDNS1123Subdomain(r.ServiceAccount); len(errs) > 0 {
+			return fmt.Errorf("deploy.remote.serviceAccount '%s' is not valid: %s", r.ServiceAccount, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
+func validateToleration(toleration apiv1.Toleration) error {
+	switch toleration.Operator {
+	case "", apiv1.TolerationOpEqual:
+	case apiv1.TolerationOpExists:
+		if toleration.Value != "" {
+			return fmt.Errorf("value must be empty when operator is '%s'", apiv1.TolerationOpExists)
+		}
+	default:
+		return fmt.Errorf("operator '%s' is not supported. Use '%s' or '%s'", toleration.Operator, apiv1.TolerationOpEqual, apiv1.TolerationOpExists)
+	}
+	if toleration.Key == "" && toleration.Operator != apiv1.TolerationOpExists {
+		return fmt.Errorf("operator must be '%s' when key is empty", apiv1.TolerationOpExists)
+	}
+	switch toleration.Effect {
+	case "", apiv1.TaintEffectNoSchedule, apiv1.TaintEffectPreferNoSchedule, apiv1.TaintEffectNoExecute:
+	default:
+		return fmt.Errorf("effect '%s' is not supported. Use '%s', '%s' or '%s'", toleration.Effect, apiv1.TaintEffectNoSchedule, apiv1.TaintEffectPreferNoSchedule, apiv1.TaintEffectNoExecute)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestDeployInfoRemoteUnmarshal(t *testing.T) {
+	tests := []struct {
+		expected      *RemoteDeploy
+		name          string
+		manifest      string
+		expectedImage string
+		expectedErr   bool
+	}{
+		{
+			name:     "bool",
+			manifest: "commands: [make]\nremote: true",
+			expected: &RemoteDeploy{Enabled: true},
+		},
+		{
+			name:     "disabled",
+			manifest: "commands: [make]\nremote: false",
+			expected: &RemoteDeploy{Enabled: false},
+		},
+		{
+			name: "execution-settings",
+			manifest: `commands: [make]
+remote:
+  image: okteto/runner:1.0
+  serviceAccount: deployer
+  resources:
+    requests:
+      cpu: 500m
+    limits:
+      memory: 1Gi
+  tolerations:
+    - key: pool
+      operator: Equal
+      value: ci
+      effect: NoSchedule`,
+			expected: &RemoteDeploy{
+				Enabled:        true,
+				Image:          "okteto/runner:1.0",
+				ServiceAccount: "deployer",
+				Resources: ResourceRequirements{
+					Requests: ResourceList{apiv1.ResourceCPU: resource.MustParse("500m")},
+					Limits:   ResourceList{apiv1.ResourceMemory: resource.MustParse("1Gi")},
+				},
+				Tolerations: []apiv1.Toleration{
+					{Key: "pool", Operator: apiv1.TolerationOpEqual, Value: "ci", Effect: apiv1.TaintEffectNoSchedule},
+				},
+			},
+			expectedImage: "okteto/runner:1.0",
+		},
+		{
+			name:        "image-conflict",
+			manifest:    "commands: [make]\nimage: okteto/runner:2.0\nremote:\n  image: okteto/runner:1.0",
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deploy := &DeployInfo{}
+			err := yaml.Unmarshal([]byte(tt.manifest), deploy)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, deploy.Remote)
+			assert.Equal(t, tt.expectedImage, deploy.Image)
+		})
+	}
+}
+
+func TestRemoteDeployMarshal(t *testing.T) {
+	b, err := yaml.Marshal(&RemoteDeploy{Enabled: true})
+	require.NoError(t, err)
+	assert.Equal(t, "true\n", string(b))
+
+	b, err = yaml.Marshal(&RemoteDeploy{Enabled: true, ServiceAccount: "deployer"})
+	require.NoError(t, err)
+	assert.Equal(t, "serviceAccount: deployer\n", string(b))
+}
+
+func TestRemoteDeployValidate(t *testing.T) {
+	tests := []struct {
+		remote      *RemoteDeploy
+		name        string
+		expectedErr bool
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "valid",
+			remote: &RemoteDeploy{
+				ServiceAccount: "deployer",
+				Resources: ResourceRequirements{
+					Requests: ResourceList{apiv1.ResourceCPU: resource.MustParse("500m")},
+					Limits:   ResourceList{apiv1.ResourceCPU: resource.MustParse("1")},
+				},
+				Tolerations: []apiv1.Toleration{{Operator: apiv1.TolerationOpExists}},
+			},
+		},
+		{
+			name: "request-greater-than-limit",
+			remote: &RemoteDeploy{
+				Resources: ResourceRequirements{
+					Requests: ResourceList{apiv1.ResourceCPU: resource.MustParse("2")},
+					Limits:   ResourceList{apiv1.ResourceCPU: resource.MustParse("1")},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid-service-account",
+			remote:      &RemoteDeploy{ServiceAccount: "Deployer_SA"},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid-operator",
+			remote:      &RemoteDeploy{Tolerations: []apiv1.Toleration{{Key: "pool", Operator: "In"}}},
+			expectedErr: true,
+		},
+		{
+			name:        "exists-with-value",
+			remote:      &RemoteDeploy{Tolerations: []apiv1.Toleration{{Key: "pool", Operator: apiv1.TolerationOpExists, Value: "ci"}}},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid-effect",
+			remote:      &RemoteDeploy{Tolerations: []apiv1.Toleration{{Key: "pool", Value: "ci", Effect: "Never"}}},
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.remote.validate()
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "fmt"
+
+const maxPort = 65535
+
+// validateReverse validates that the reverse forwards can be started in the development container
+func (dev *Dev) validateReverse() error {
+	forwardPorts := map[int]bool{}
+	for _, f := range dev.Forward {
+		forwardPorts[f.Local] = true
+	}
+
+	localPorts := map[int]bool{}
+	remotePorts := map[int]bool{}
+	for _, r := range dev.Reverse {
+		if r.Remote <= 0 || r.Remote > maxPort {
+			return fmt.Errorf("reverse '%d:%d' is not valid: remote port must be between 1 and %d", r.Remote, r.Local, maxPort)
+		}
+		if r.Local <= 0 || r.Local > maxPort {
+			return fmt.Errorf("reverse '%d:%d' is not valid: local port must be between 1 and %d", r.Remote, r.Local, maxPort)
+		}
+		if dev.SSHServerPort > 0 && r.Remote == dev.SSHServerPort {
+			return fmt.Errorf("reverse '%d:%d' is not valid: remote port %d is used by the SSH server of the development container", r.Remote, r.Local, r.Remote)
+		}
+		if remotePorts[r.Remote] {
+			return fmt.Errorf("remote port %d is listed multiple times, please check your reverse forwards configuration", r.Remote)
+		}
+		if localPorts[r.Local] {
+			return fmt.Errorf("port %d is listed multiple times, please check your reverse forwards configuration", r.Local)
+		}
+		if forwardPorts[r.Local] {
+			return fmt.Errorf("port %d of reverse '%d:%d' is already used in 'forward'", r.Local, r.Remote, r.Local)
+		}
+		remotePorts[r.Remote] = true
+		localPorts[r.Local] = true
+	}
+	return nil
+}
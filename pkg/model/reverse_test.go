@@ -0,0 +1,80 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateReverse(t *testing.T) {
+	tests := []struct {
+		name      string
+		reverse   []Reverse
+		expectErr bool
+	}{
+		{
+			name:    "valid reverse",
+			reverse: []Reverse{{Remote: 9000, Local: 9000}, {Remote: 9001, Local: 5005}},
+		},
+		{
+			name:      "invalid remote port",
+			reverse:   []Reverse{{Remote: 0, Local: 9000}},
+			expectErr: true,
+		},
+		{
+			name:      "invalid local port",
+			reverse:   []Reverse{{Remote: 9000, Local: 70000}},
+			expectErr: true,
+		},
+		{
+			name:      "remote port used by the ssh server",
+			reverse:   []Reverse{{Remote: 2222, Local: 9000}},
+			expectErr: true,
+		},
+		{
+			name:      "duplicated remote port",
+			reverse:   []Reverse{{Remote: 9000, Local: 9000}, {Remote: 9000, Local: 9001}},
+			expectErr: true,
+		},
+		{
+			name:      "duplicated local port",
+			reverse:   []Reverse{{Remote: 9000, Local: 9000}, {Remote: 9001, Local: 9000}},
+			expectErr: true,
+		},
+		{
+			name:      "local port used in forward",
+			reverse:   []Reverse{{Remote: 9000, Local: 8080}},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dev := &Dev{
+				Forward:       []forward.Forward{{Local: 8080, Remote: 80}},
+				Reverse:       tt.reverse,
+				SSHServerPort: 2222,
+			}
+			err := dev.validateReverse()
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
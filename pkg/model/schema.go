@@ -18,6 +18,19 @@ import (
 	"strings"
 )
 
+// manifestComposeKeys are the keys of the okteto manifest resolved when the manifest file is loaded,
+// so they are not part of the Manifest struct
+var manifestComposeKeys = []string{manifestExtendsField, manifestIncludeField}
+
+// getManifestKeys returns the keys of an okteto manifest schema, including the keys resolved when the manifest file is loaded
+func getManifestKeys(manifestSchema interface{}) map[string][]string {
+	keys := getStructKeys(manifestSchema)
+	if _, ok := keys["model.Manifest"]; ok {
+		keys["model.Manifest"] = mergeAndSortUnique(keys["model.Manifest"], manifestComposeKeys)
+	}
+	return keys
+}
+
 func mergeAndSortUnique(slice1, slice2 []string) []string {
 	uniqueMap := make(map[string]struct{})
 	var result []string
@@ -187,31 +187,41 @@ func Test_getStructKeys(t *testing.T) {
 				"forward.Forward":            {"labels", "name", "localPort", "remotePort"},
 				"forward.GlobalForward":      {"labels", "name", "localPort", "remotePort"},
 				"build.Info":                 {"secrets", "name", "context", "dockerfile", "target", "image", "cache_from", "export_cache", "depends_on"},
+				"build.ScanInfo":             {"failOn"},
 				"build.VolumeMounts":         {"local_path", "remote_path"},
+				"model.Autorestart":          {"debounce"},
 				"model.Capabilities":         {"add", "drop"},
 				"model.ComposeInfo":          {"file", "services"},
 				"model.DeployCommand":        {"name", "command"},
-				"model.DeployInfo":           {"endpoints", "image", "remote"},
+				"model.DeployInfo":           {"endpoints", "image"},
 				"model.DestroyInfo":          {"image", "remote"},
-				"model.Dev":                  {"selector", "annotations", "labels", "nodeSelector", "replicas", "workdir", "name", "context", "namespace", "container", "serviceAccount", "interface", "mode", "imagePullPolicy", "envFiles", "services", "remote", "sshServerPort", "initFromImage", "autocreate", "healthchecks"},
+				"model.Dev":                  {"selector", "annotations", "labels", "nodeSelector", "replicas", "workdir", "name", "context", "namespace", "container", "serviceAccount", "interface", "mode", "imagePullPolicy", "forwardProfiles", "envFiles", "services", "remote", "sshServerPort", "initFromImage", "autocreate", "open", "healthchecks"},
 				"model.DivertDeploy":         {"driver", "namespace", "service", "deployment", "port"},
 				"model.DivertHost":           {"virtualService", "namespace"},
 				"model.DivertVirtualService": {"name", "namespace", "routes"},
 				"model.HTTPHealtcheck":       {"path", "port"},
 				"model.HealthCheck":          {"test", "interval", "timeout", "retries", "start_period", "disable", "x-okteto-liveness", "x-okteto-readiness"},
+				"model.HelmRelease":          {"name", "namespace", "timeout"},
 				"model.InitContainer":        {"image"},
 				"model.Lifecycle":            {"postStart", "postStop"},
-				"model.Manifest":             {"name", "namespace", "context", "icon", "dev", "build", "dependencies", "external"},
+				"model.Artifact":             {"path", "destination"},
+				"model.Manifest":             {"name", "namespace", "context", "icon", "dev", "build", "dependencies", "external", "test"},
 				"model.Metadata":             {"labels", "annotations"},
 				"model.PersistentVolumeInfo": {"storageClass", "size", "enabled"},
 				"model.Probes":               {"liveness", "readiness", "startup"},
+				"model.ReadinessGate":        {"timeout", "probes"},
+				"model.RegistryLogin":        {"server", "provider", "helper"},
+				"model.RemoteDeploy":         {"image", "serviceAccount"},
 				"model.ResourceRequirements": {"limits", "requests"},
 				"model.SecurityContext":      {"runAsUser", "runAsGroup", "fsGroup", "runAsNonRoot", "allowPrivilegeEscalation"},
 				"model.Service":              {"labels", "x-node-selector", "depends_on", "workdir", "image", "restart", "cap_add", "cap_drop", "env_file", "annotations", "stop_grace_period", "replicas", "max_attempts", "public"},
+				"model.ShellHistory":         {"enabled", "tools", "dotfiles"},
 				"model.Stack":                {"volumes", "services", "endpoints", "name", "namespace", "context"},
 				"model.StackSecurityContext": {"runAsUser", "runAsGroup"},
 				"model.StorageResource":      {"class"},
 				"model.Sync":                 {"rescanInterval", "compression", "verbose"},
+				"model.SyncLargeFiles":       {"autoIgnore"},
+				"model.Test":                 {"image", "context", "caches"},
 				"model.Timeout":              {"default", "resources"},
 				"model.VolumeSpec":           {"labels", "annotations", "class"},
 			},
@@ -224,3 +234,13 @@ func Test_getStructKeys(t *testing.T) {
 		})
 	}
 }
+
+func Test_getManifestKeys(t *testing.T) {
+	keys := getManifestKeys(Manifest{})
+	assert.Equal(t, []string{"name", "namespace", "context", "icon", "dev", "build", "dependencies", "external", "test", "extends", "include"}, keys["model.Manifest"])
+	assert.Equal(t, getStructKeys(Manifest{})["model.Dev"], keys["model.Dev"])
+
+	assert.Equal(t, map[string][]string{"_": {"field1"}}, getManifestKeys(struct {
+		field1 string `yaml:"field1"`
+	}{}))
+}
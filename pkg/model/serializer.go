@@ -52,6 +52,7 @@ var (
 )
 
 type syncRaw struct {
+	LargeFiles     *SyncLargeFiles `json:"largeFiles,omitempty" yaml:"largeFiles,omitempty"`
 	LocalPath      string
 	RemotePath     string
 	Folders        []SyncFolder `json:"folders,omitempty" yaml:"folders,omitempty"`
@@ -253,12 +254,13 @@ func (sync *Sync) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	sync.Verbose = rawSync.Verbose
 	sync.RescanInterval = rawSync.RescanInterval
 	sync.Folders = rawSync.Folders
+	sync.LargeFiles = rawSync.LargeFiles
 	return nil
 }
 
 // MarshalYAML Implements the marshaler interface of the yaml pkg.
 func (sync Sync) MarshalYAML() (interface{}, error) {
-	if !sync.Compression && sync.RescanInterval == DefaultSyncthingRescanInterval {
+	if !sync.Compression && sync.RescanInterval == DefaultSyncthingRescanInterval && sync.LargeFiles == nil {
 		return sync.Folders, nil
 	}
 	return syncRaw(sync), nil
@@ -751,6 +753,7 @@ type manifestRaw struct {
 	Namespace     string                                   `json:"namespace,omitempty" yaml:"namespace,omitempty"`
 	Context       string                                   `json:"context,omitempty" yaml:"context,omitempty"`
 	Icon          string                                   `json:"icon,omitempty" yaml:"icon,omitempty"`
+	Metadata      *Metadata                                `json:"metadata,omitempty" yaml:"metadata,omitempty"`
 	Deploy        *DeployInfo                              `json:"deploy,omitempty" yaml:"deploy,omitempty"`
 	Dev           ManifestDevs                             `json:"dev,omitempty" yaml:"dev,omitempty"`
 	Destroy       *DestroyInfo                             `json:"destroy,omitempty" yaml:"destroy,omitempty"`
@@ -758,6 +761,10 @@ type manifestRaw struct {
 	Dependencies  deps.ManifestSection                     `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
 	GlobalForward []forward.GlobalForward                  `json:"forward,omitempty" yaml:"forward,omitempty"`
 	External      externalresource.ExternalResourceSection `json:"external,omitempty" yaml:"external,omitempty"`
+	Hooks         *Hooks                                   `json:"hooks,omitempty" yaml:"hooks,omitempty"`
+	Variables     env.Environment                          `json:"variables,omitempty" yaml:"variables,omitempty"`
+	Test          ManifestTests                            `json:"test,omitempty" yaml:"test,omitempty"`
+	Registries    ManifestRegistries                       `json:"registries,omitempty" yaml:"registries,omitempty"`
 
 	DeprecatedDevs []string `yaml:"devs"`
 }
@@ -787,6 +794,7 @@ func (m *Manifest) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	m.Destroy = manifest.Destroy
 	m.Dev = manifest.Dev
 	m.Icon = manifest.Icon
+	m.Metadata = manifest.Metadata
 	m.Build = manifest.Build
 	m.Namespace = manifest.Namespace
 	m.Context = manifest.Context
@@ -795,6 +803,10 @@ func (m *Manifest) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	m.Name = manifest.Name
 	m.GlobalForward = manifest.GlobalForward
 	m.External = manifest.External
+	m.Hooks = manifest.Hooks
+	m.Variables = manifest.Variables
+	m.Test = manifest.Test
+	m.Registries = manifest.Registries
 
 	err = m.SanitizeSvcNames()
 	if err != nil {
@@ -869,8 +881,17 @@ func (d *DeployInfo) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	if err != nil {
 		return err
 	}
+	if err := validateHelmReleases(deploy.HelmReleases); err != nil {
+		return err
+	}
 
 	*d = DeployInfo(deploy)
+	if d.Remote != nil && d.Remote.Image != "" {
+		if d.Image != "" && d.Image != d.Remote.Image {
+			return fmt.Errorf("'deploy.image' and 'deploy.remote.image' can't be set at the same time")
+		}
+		d.Image = d.Remote.Image
+	}
 	return nil
 }
 
@@ -1000,7 +1021,7 @@ func (d *ManifestDevs) UnmarshalYAML(unmarshal func(interface{}) error) error {
 }
 
 func isManifestFieldNotFound(err error) bool {
-	manifestFields := []string{"devs", "dev", "name", "icon", "variables", "deploy", "destroy", "build", "namespace", "context", "dependencies"}
+	manifestFields := []string{"devs", "dev", "name", "icon", "variables", "deploy", "destroy", "build", "namespace", "context", "dependencies", "hooks", "test", "metadata", "extends", "include", "registries"}
 	for _, field := range manifestFields {
 		if strings.Contains(err.Error(), fmt.Sprintf("field %s not found", field)) {
 			return true
@@ -2416,6 +2416,47 @@ compose:
 			},
 			isErrorExpected: true,
 		},
+		{
+			name: "helm releases",
+			deployInfoManifest: []byte(`commands:
+- helm upgrade --install movies chart
+helmReleases:
+- name: movies
+  timeout: 5m
+- name: monitoring
+  namespace: observability`),
+			expected: &DeployInfo{
+				Commands: []DeployCommand{
+					{
+						Name:    "helm upgrade --install movies chart",
+						Command: "helm upgrade --install movies chart",
+					},
+				},
+				HelmReleases: []HelmRelease{
+					{Name: "movies", Timeout: 5 * time.Minute},
+					{Name: "monitoring", Namespace: "observability"},
+				},
+			},
+		},
+		{
+			name: "duplicated helm releases",
+			deployInfoManifest: []byte(`helmReleases:
+- name: movies
+- name: movies`),
+			expected: &DeployInfo{
+				Commands: []DeployCommand{},
+			},
+			isErrorExpected: true,
+		},
+		{
+			name: "helm release without name",
+			deployInfoManifest: []byte(`helmReleases:
+- namespace: movies`),
+			expected: &DeployInfo{
+				Commands: []DeployCommand{},
+			},
+			isErrorExpected: true,
+		},
 	}
 
 	for _, tt := range tests {
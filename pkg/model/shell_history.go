@@ -0,0 +1,107 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/env"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+)
+
+// ShellHistory configures the shell history persisted in the development container volume
+type ShellHistory struct {
+	Enabled  *bool    `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	Tools    []string `json:"tools,omitempty" yaml:"tools,omitempty"`
+	Dotfiles []string `json:"dotfiles,omitempty" yaml:"dotfiles,omitempty"`
+}
+
+// shellHistoryTools are the environment variables pointing the history file of each tool to the persistent volume
+var shellHistoryTools = map[string]env.Var{
+	"mysql":  {Name: "MYSQL_HISTFILE", Value: path.Join(ShellHistoryMountPath, ".mysql_history")},
+	"node":   {Name: "NODE_REPL_HISTORY", Value: path.Join(ShellHistoryMountPath, ".node_repl_history")},
+	"psql":   {Name: "PSQL_HISTORY", Value: path.Join(ShellHistoryMountPath, ".psql_history")},
+	"python": {Name: "PYTHON_HISTORY", Value: path.Join(ShellHistoryMountPath, ".python_history")},
+	"redis":  {Name: "REDISCLI_HISTFILE", Value: path.Join(ShellHistoryMountPath, ".rediscli_history")},
+	"sqlite": {Name: "SQLITE_HISTORY", Value: path.Join(ShellHistoryMountPath, ".sqlite_history")},
+}
+
+// IsShellHistoryEnabled returns if the shell history is persisted across development container restarts. It's enabled by default
+func (dev *Dev) IsShellHistoryEnabled() bool {
+	if dev.ShellHistory == nil || dev.ShellHistory.Enabled == nil {
+		return true
+	}
+	return *dev.ShellHistory.Enabled
+}
+
+// getToolsEnvironment returns the environment variables to persist the history of the tools in the manifest
+func (h *ShellHistory) getToolsEnvironment() env.Environment {
+	result := env.Environment{}
+	if h == nil {
+		return result
+	}
+	for _, tool := range h.Tools {
+		if v, ok := shellHistoryTools[tool]; ok {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// getDotfilesVolumes returns the volume mounts persisting the dotfiles of the manifest in the development container volume
+func (h *ShellHistory) getDotfilesVolumes(volumeName string) []VolumeMount {
+	result := []VolumeMount{}
+	if h == nil {
+		return result
+	}
+	for _, dotfile := range h.Dotfiles {
+		result = append(result, VolumeMount{
+			Name:      volumeName,
+			MountPath: path.Clean(dotfile),
+			SubPath:   path.Join(ShellDotfilesSubPath, strings.TrimPrefix(path.Clean(dotfile), "/")),
+		})
+	}
+	return result
+}
+
+func (h *ShellHistory) validate() error {
+	if h == nil {
+		return nil
+	}
+	for _, dotfile := range h.Dotfiles {
+		if !path.IsAbs(dotfile) || path.Clean(dotfile) == "/" {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("'%s' is not a valid path for 'shellHistory.dotfiles'", dotfile),
+				Hint: "Dotfiles must be absolute paths of the development container, like '/root/.gitconfig'",
+			}
+		}
+	}
+	for _, tool := range h.Tools {
+		if _, ok := shellHistoryTools[tool]; !ok {
+			tools := make([]string, 0, len(shellHistoryTools))
+			for t := range shellHistoryTools {
+				tools = append(tools, t)
+			}
+			sort.Strings(tools)
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("'%s' is not a valid tool for 'shellHistory.tools'", tool),
+				Hint: fmt.Sprintf("Valid tools are: %s", strings.Join(tools, ", ")),
+			}
+		}
+	}
+	return nil
+}
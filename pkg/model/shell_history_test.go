@@ -0,0 +1,62 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/env"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/pointer"
+)
+
+func TestIsShellHistoryEnabled(t *testing.T) {
+	assert.True(t, (&Dev{}).IsShellHistoryEnabled())
+	assert.True(t, (&Dev{ShellHistory: &ShellHistory{}}).IsShellHistoryEnabled())
+	assert.False(t, (&Dev{ShellHistory: &ShellHistory{Enabled: pointer.Bool(false)}}).IsShellHistoryEnabled())
+}
+
+func TestShellHistoryValidate(t *testing.T) {
+	assert.NoError(t, (*ShellHistory)(nil).validate())
+	assert.NoError(t, (&ShellHistory{Tools: []string{"psql", "node"}}).validate())
+	assert.ErrorContains(t, (&ShellHistory{Tools: []string{"psql", "irb"}}).validate(), "'irb' is not a valid tool")
+	assert.NoError(t, (&ShellHistory{Dotfiles: []string{"/root/.gitconfig"}}).validate())
+	assert.Error(t, (&ShellHistory{Dotfiles: []string{".gitconfig"}}).validate())
+	assert.Error(t, (&ShellHistory{Dotfiles: []string{"/"}}).validate())
+}
+
+func TestShellHistoryTranslation(t *testing.T) {
+	dev := &Dev{
+		Name:                 "dev",
+		Image:                &build.Info{Name: "okteto/dev"},
+		PersistentVolumeInfo: &PersistentVolumeInfo{Enabled: true},
+		ShellHistory:         &ShellHistory{Tools: []string{"psql"}, Dotfiles: []string{"/root/.gitconfig"}},
+		FreshHistory:         true,
+	}
+	rule := dev.ToTranslationRule(dev, false)
+
+	assert.True(t, rule.ResetShellHistory)
+	assert.Equal(t, "dev-okteto", rule.GetShellHistoryVolumeName())
+	assert.Contains(t, rule.Environment, env.Var{Name: "HISTFILE", Value: "/var/okteto/bashrc/.bash_history"})
+	assert.Contains(t, rule.Environment, env.Var{Name: "PSQL_HISTORY", Value: "/var/okteto/bashrc/.psql_history"})
+	assert.Contains(t, rule.Volumes, VolumeMount{Name: "dev-okteto", MountPath: "/root/.gitconfig", SubPath: "okteto-dotfiles/root/.gitconfig"})
+
+	dev.ShellHistory.Enabled = pointer.Bool(false)
+	rule = dev.ToTranslationRule(dev, false)
+	require.False(t, rule.ResetShellHistory)
+	assert.Empty(t, rule.GetShellHistoryVolumeName())
+	assert.NotContains(t, rule.Environment, env.Var{Name: "HISTFILE", Value: "/var/okteto/bashrc/.bash_history"})
+}
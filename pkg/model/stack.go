@@ -25,6 +25,7 @@ import (
 
 	"github.com/compose-spec/godotenv"
 	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/constants"
 	"github.com/okteto/okteto/pkg/discovery"
 	"github.com/okteto/okteto/pkg/env"
@@ -249,7 +250,7 @@ func GetStackFromPath(name, stackPath string, isCompose bool) (*Stack, error) {
 	}
 	stackPath = GetManifestPathFromWorkdir(stackPath, stackWorkingDir)
 
-	s, err := ReadStack(b, isCompose)
+	s, err := readStack(b, isCompose, filepath.Dir(stackPath))
 	if err != nil {
 		return nil, err
 	}
@@ -305,7 +306,7 @@ func getStackName(name, stackPath, actualStackName string) (string, error) {
 		return name, nil
 	}
 	if actualStackName == "" {
-		nameEnvVar := os.Getenv(constants.OktetoNameEnvVar)
+		nameEnvVar := config.EnvOktetoName.Value()
 		if nameEnvVar != "" {
 			// this name could be not sanitized when running at pipeline installer
 			return nameEnvVar, nil
@@ -340,6 +341,11 @@ func getValidNameFromGitRepo(folder string) (string, error) {
 
 // ReadStack reads an okteto stack
 func ReadStack(bytes []byte, isCompose bool) (*Stack, error) {
+	return readStack(bytes, isCompose, "")
+}
+
+// readStack reads an okteto stack located at 'dir'
+func readStack(bytes []byte, isCompose bool, dir string) (*Stack, error) {
 	s := &Stack{
 		Manifest:  bytes,
 		IsCompose: isCompose,
@@ -348,6 +354,10 @@ func ReadStack(bytes []byte, isCompose bool) (*Stack, error) {
 	if err != nil {
 		return nil, err
 	}
+	expandedManifest, err = resolveStackExtends(expandedManifest, dir)
+	if err != nil {
+		return nil, err
+	}
 
 	if err := yaml.UnmarshalStrict(expandedManifest, s); err != nil {
 		if strings.HasPrefix(err.Error(), "yaml: unmarshal errors:") {
@@ -0,0 +1,269 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	yaml3 "gopkg.in/yaml.v3"
+)
+
+// composeReplacedSequences are the service fields whose value is replaced instead of merged when a service extends another one
+var composeReplacedSequences = map[string]bool{
+	"command":    true,
+	"entrypoint": true,
+	"test":       true,
+}
+
+// extendsResolver merges the services declaring `extends` with the services they extend
+type extendsResolver struct {
+	services map[string]*yaml3.Node
+	resolved map[string]*yaml3.Node
+	visiting map[string]bool
+	// dir is the directory of the main manifest, relative files extended by it are resolved from there
+	dir string
+}
+
+// resolveStackExtends returns the stack manifest located at 'dir' with the `extends` of every service resolved
+func resolveStackExtends(file []byte, dir string) ([]byte, error) {
+	doc := yaml3.Node{}
+	if err := yaml3.Unmarshal(file, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return file, nil
+	}
+
+	services := getMappingValue(doc.Content[0], "services")
+	if services == nil || services.Kind != yaml3.MappingNode {
+		return file, nil
+	}
+
+	r := &extendsResolver{
+		services: map[string]*yaml3.Node{"": services},
+		resolved: map[string]*yaml3.Node{},
+		visiting: map[string]bool{},
+		dir:      dir,
+	}
+	for i := 0; i+1 < len(services.Content); i += 2 {
+		resolvedSvc, err := r.resolve("", services.Content[i].Value)
+		if err != nil {
+			return nil, err
+		}
+		services.Content[i+1] = resolvedSvc
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	encoder := yaml3.NewEncoder(buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc.Content[0]); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// resolve returns the service 'svcName' defined in 'file' merged with the services it extends
+func (r *extendsResolver) resolve(file, svcName string) (*yaml3.Node, error) {
+	key := fmt.Sprintf("%s:%s", file, svcName)
+	if svc, ok := r.resolved[key]; ok {
+		return svc, nil
+	}
+	if r.visiting[key] {
+		return nil, fmt.Errorf("invalid compose manifest: services[%s].extends: circular reference", svcName)
+	}
+	r.visiting[key] = true
+	defer delete(r.visiting, key)
+
+	services, err := r.getServices(file)
+	if err != nil {
+		return nil, err
+	}
+	svc := getMappingValue(services, svcName)
+	if svc == nil {
+		if file == "" {
+			return nil, fmt.Errorf("invalid compose manifest: service '%s' is not defined", svcName)
+		}
+		return nil, fmt.Errorf("invalid compose manifest: service '%s' is not defined in '%s'", svcName, file)
+	}
+
+	extends := removeMappingValue(svc, "extends")
+	if extends == nil {
+		r.resolved[key] = svc
+		return svc, nil
+	}
+
+	baseFile, baseSvcName, err := getExtendsReference(svcName, extends)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case baseFile == "":
+		baseFile = file
+	case filepath.IsAbs(baseFile):
+	case file == "":
+		baseFile = filepath.Join(r.dir, baseFile)
+	default:
+		baseFile = filepath.Join(filepath.Dir(file), baseFile)
+	}
+
+	base, err := r.resolve(baseFile, baseSvcName)
+	if err != nil {
+		return nil, err
+	}
+	merged := mergeServiceNodes(copyNode(base), svc)
+	r.resolved[key] = merged
+	return merged, nil
+}
+
+// getServices returns the services node of a compose file, the main manifest is identified by an empty path
+func (r *extendsResolver) getServices(file string) (*yaml3.Node, error) {
+	if services, ok := r.services[file]; ok {
+		return services, nil
+	}
+
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("invalid compose manifest: error reading extended file '%s': %w", file, err)
+	}
+	b, err = ExpandStackEnvs(b)
+	if err != nil {
+		return nil, err
+	}
+	doc := yaml3.Node{}
+	if err := yaml3.Unmarshal(b, &doc); err != nil {
+		return nil, fmt.Errorf("invalid compose manifest: error parsing extended file '%s': %w", file, err)
+	}
+
+	var services *yaml3.Node
+	if len(doc.Content) > 0 {
+		services = getMappingValue(doc.Content[0], "services")
+	}
+	if services == nil || services.Kind != yaml3.MappingNode {
+		services = &yaml3.Node{Kind: yaml3.MappingNode}
+	}
+	r.services[file] = services
+	return services, nil
+}
+
+// getExtendsReference returns the file and the service referenced by an `extends` node
+func getExtendsReference(svcName string, extends *yaml3.Node) (string, string, error) {
+	switch extends.Kind {
+	case yaml3.ScalarNode:
+		if extends.Value == "" {
+			return "", "", fmt.Errorf("invalid compose manifest: services[%s].extends can't be empty", svcName)
+		}
+		return "", extends.Value, nil
+	case yaml3.MappingNode:
+		var file, service string
+		if v := getMappingValue(extends, "file"); v != nil {
+			file = v.Value
+		}
+		if v := getMappingValue(extends, "service"); v != nil {
+			service = v.Value
+		}
+		if service == "" {
+			return "", "", fmt.Errorf("invalid compose manifest: services[%s].extends.service is required", svcName)
+		}
+		return file, service, nil
+	default:
+		return "", "", fmt.Errorf("invalid compose manifest: services[%s].extends must be a string or a map", svcName)
+	}
+}
+
+// mergeServiceNodes merges 'override' into 'base': maps are merged, sequences are appended and scalars are replaced
+func mergeServiceNodes(base, override *yaml3.Node) *yaml3.Node {
+	if base.Kind != yaml3.MappingNode || override.Kind != yaml3.MappingNode {
+		return override
+	}
+	for i := 0; i+1 < len(override.Content); i += 2 {
+		key := override.Content[i]
+		value := override.Content[i+1]
+		baseValue := getMappingValue(base, key.Value)
+		switch {
+		case baseValue == nil:
+			base.Content = append(base.Content, key, value)
+		case baseValue.Kind == yaml3.MappingNode && value.Kind == yaml3.MappingNode:
+			setMappingValue(base, key.Value, mergeServiceNodes(baseValue, value))
+		case baseValue.Kind == yaml3.SequenceNode && value.Kind == yaml3.SequenceNode && !composeReplacedSequences[key.Value]:
+			setMappingValue(base, key.Value, mergeSequenceNodes(baseValue, value))
+		default:
+			setMappingValue(base, key.Value, value)
+		}
+	}
+	return base
+}
+
+// mergeSequenceNodes appends to 'base' the items of 'override' not already included
+func mergeSequenceNodes(base, override *yaml3.Node) *yaml3.Node {
+	for _, item := range override.Content {
+		found := false
+		for _, baseItem := range base.Content {
+			if item.Kind == yaml3.ScalarNode && baseItem.Kind == yaml3.ScalarNode && item.Value == baseItem.Value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			base.Content = append(base.Content, item)
+		}
+	}
+	return base
+}
+
+func getMappingValue(node *yaml3.Node, key string) *yaml3.Node {
+	if node == nil || node.Kind != yaml3.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func setMappingValue(node *yaml3.Node, key string, value *yaml3.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1] = value
+			return
+		}
+	}
+}
+
+func removeMappingValue(node *yaml3.Node, key string) *yaml3.Node {
+	if node.Kind != yaml3.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			value := node.Content[i+1]
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return value
+		}
+	}
+	return nil
+}
+
+func copyNode(node *yaml3.Node) *yaml3.Node {
+	result := *node
+	result.Content = make([]*yaml3.Node, len(node.Content))
+	for i, child := range node.Content {
+		result.Content[i] = copyNode(child)
+	}
+	return &result
+}
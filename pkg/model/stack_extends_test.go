@@ -0,0 +1,243 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/env"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ReadStackWithExtends(t *testing.T) {
+	manifest := []byte(`
+services:
+  base:
+    image: okteto/base
+    environment:
+      A: a
+      B: b
+    ports:
+      - 8080
+    command: ["sleep", "infinity"]
+  api:
+    extends: base
+    environment:
+      B: override
+    ports:
+      - 9090
+    command: ["./api"]
+  worker:
+    extends:
+      service: api
+    image: okteto/worker`)
+
+	s, err := ReadStack(manifest, true)
+	require.NoError(t, err)
+
+	api := s.Services["api"]
+	assert.Equal(t, "okteto/base", api.Image)
+	assert.ElementsMatch(t, []string{"A=a", "B=override"}, environmentToStrings(api.Environment))
+	assert.Len(t, api.Ports, 2)
+	assert.Equal(t, Command{Values: []string{"./api"}}, api.Command)
+
+	worker := s.Services["worker"]
+	assert.Equal(t, "okteto/worker", worker.Image)
+	assert.Equal(t, Command{Values: []string{"./api"}}, worker.Command)
+
+	assert.Equal(t, "okteto/base", s.Services["base"].Image)
+	assert.NotContains(t, s.Warnings.NotSupportedFields, "services[api].extends")
+}
+
+func Test_ReadStackWithExtendsFromFile(t *testing.T) {
+	dir := t.TempDir()
+	common := filepath.Join(dir, "common.yml")
+	require.NoError(t, os.WriteFile(common, []byte(`
+services:
+  web:
+    image: okteto/web
+    environment:
+      COMMON: "true"
+  unused:
+    image: okteto/unused`), 0600))
+
+	manifest := []byte(fmt.Sprintf(`
+services:
+  frontend:
+    extends:
+      file: %s
+      service: web
+    environment:
+      NAME: frontend`, common))
+
+	s, err := ReadStack(manifest, true)
+	require.NoError(t, err)
+	require.Len(t, s.Services, 1)
+	assert.Equal(t, "okteto/web", s.Services["frontend"].Image)
+	assert.ElementsMatch(t, []string{"COMMON=true", "NAME=frontend"}, environmentToStrings(s.Services["frontend"].Environment))
+}
+
+func Test_GetStackFromPathWithRelativeExtends(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), ".okteto")
+	require.NoError(t, os.MkdirAll(dir, 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "common.yml"), []byte(`
+services:
+  web:
+    image: okteto/web`), 0600))
+	stackPath := filepath.Join(dir, "docker-compose.yml")
+	require.NoError(t, os.WriteFile(stackPath, []byte(`
+services:
+  frontend:
+    extends:
+      file: common.yml
+      service: web`), 0600))
+
+	s, err := GetStackFromPath("test", stackPath, true)
+	require.NoError(t, err)
+	assert.Equal(t, "okteto/web", s.Services["frontend"].Image)
+}
+
+func Test_ReadStackWithExtendsErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+	}{
+		{
+			name: "circular reference",
+			manifest: `
+services:
+  a:
+    extends: b
+  b:
+    extends: a`,
+		},
+		{
+			name: "undefined service",
+			manifest: `
+services:
+  a:
+    image: okteto/a
+    extends: b`,
+		},
+		{
+			name: "missing service",
+			manifest: `
+services:
+  a:
+    image: okteto/a
+    extends:
+      file: common.yml`,
+		},
+		{
+			name: "missing file",
+			manifest: `
+services:
+  a:
+    extends:
+      file: does-not-exist.yml
+      service: b`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadStack([]byte(tt.manifest), true)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_ReadStackWithProfiles(t *testing.T) {
+	manifest := []byte(`
+services:
+  api:
+    image: okteto/api
+  debug:
+    image: okteto/debug
+    profiles: ["debug"]
+  monitoring:
+    image: okteto/monitoring
+    profiles: ["ops", "monitoring"]`)
+
+	tests := []struct {
+		name     string
+		profiles string
+		expected []string
+	}{
+		{
+			name:     "no profiles",
+			expected: []string{"api"},
+		},
+		{
+			name:     "one profile",
+			profiles: "debug",
+			expected: []string{"api", "debug"},
+		},
+		{
+			name:     "several profiles",
+			profiles: "debug, monitoring",
+			expected: []string{"api", "debug", "monitoring"},
+		},
+		{
+			name:     "all profiles",
+			profiles: "*",
+			expected: []string{"api", "debug", "monitoring"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(ComposeProfilesEnvVar, tt.profiles)
+			s, err := ReadStack(manifest, true)
+			require.NoError(t, err)
+
+			names := []string{}
+			for name := range s.Services {
+				names = append(names, name)
+			}
+			assert.ElementsMatch(t, tt.expected, names)
+			assert.Empty(t, s.Warnings.NotSupportedFields)
+		})
+	}
+}
+
+func Test_ReadStackWithProfilesRemovesInactiveDependencies(t *testing.T) {
+	t.Setenv(ComposeProfilesEnvVar, "")
+	manifest := []byte(`
+services:
+  api:
+    image: okteto/api
+    depends_on:
+      - db
+      - debug
+  db:
+    image: okteto/db
+  debug:
+    image: okteto/debug
+    profiles: ["debug"]`)
+
+	s, err := ReadStack(manifest, true)
+	require.NoError(t, err)
+	assert.Equal(t, DependsOn{"db": DependsOnConditionSpec{Condition: DependsOnServiceRunning}}, s.Services["api"].DependsOn)
+}
+
+func environmentToStrings(environment env.Environment) []string {
+	result := []string{}
+	for _, e := range environment {
+		result = append(result, fmt.Sprintf("%s=%s", e.Name, e.Value))
+	}
+	return result
+}
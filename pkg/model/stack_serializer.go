@@ -26,6 +26,7 @@ import (
 	"github.com/kballard/go-shellquote"
 	"github.com/okteto/okteto/pkg/build"
 	"github.com/okteto/okteto/pkg/cache"
+	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/env"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/filesystem"
@@ -85,7 +86,7 @@ type ServiceRaw struct {
 	ReadOnly                 *WarningType           `yaml:"read_only,omitempty"`
 	PullPolicy               *WarningType           `yaml:"pull_policy,omitempty"`
 	ContainerName            *WarningType           `yaml:"container_name,omitempty"`
-	Profiles                 *WarningType           `yaml:"profiles,omitempty"`
+	Profiles                 []string               `yaml:"profiles,omitempty"`
 	Scale                    *int32                 `yaml:"scale"`
 	StopGracePeriodSneakCase *RawMessage            `yaml:"stop_grace_period,omitempty"`
 	StopGracePeriod          *RawMessage            `yaml:"stopGracePeriod,omitempty"`
@@ -115,7 +116,6 @@ type ServiceRaw struct {
 	DnsOpt                   *WarningType           `yaml:"dns_opt,omitempty"`
 	DnsSearch                *WarningType           `yaml:"dns_search,omitempty"`
 	DomainName               *WarningType           `yaml:"domainname,omitempty"`
-	Extends                  *WarningType           `yaml:"extends,omitempty"`
 	ExternalLinks            *WarningType           `yaml:"external_links,omitempty"`
 	ExtraHosts               *WarningType           `yaml:"extra_hosts,omitempty"`
 	GroupAdd                 *WarningType           `yaml:"group_add,omitempty"`
@@ -299,7 +299,14 @@ func (s *Stack) UnmarshalYAML(unmarshal func(interface{}) error) error {
 
 	sanitizedServicesNames := make(map[string]string)
 	s.Services = make(map[string]*Service)
+	activeProfiles := getActiveComposeProfiles()
+	inactiveServices := map[string]bool{}
 	for svcName, svcRaw := range stackRaw.Services {
+		if !isServiceProfileActive(svcRaw.Profiles, activeProfiles) {
+			inactiveServices[sanitizeName(svcName)] = true
+			delete(stackRaw.Services, svcName)
+			continue
+		}
 		if shouldBeSanitized(svcName) {
 			newName := sanitizeName(svcName)
 			sanitizedServicesNames[svcName] = newName
@@ -311,6 +318,8 @@ func (s *Stack) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		}
 	}
 
+	removeInactiveDependencies(s.Services, inactiveServices)
+
 	s.Warnings.NotSupportedFields = getNotSupportedFields(&stackRaw)
 	s.Warnings.SanitizedServices = sanitizedServicesNames
 	s.Warnings.VolumeMountWarnings = make([]string, 0)
@@ -1260,6 +1269,48 @@ func sanitizeName(name string) string {
 	return name
 }
 
+// getActiveComposeProfiles returns the compose profiles enabled by COMPOSE_PROFILES
+func getActiveComposeProfiles() []string {
+	result := []string{}
+	for _, profile := range strings.Split(config.EnvComposeProfiles.Value(), ",") {
+		profile = strings.TrimSpace(profile)
+		if profile != "" {
+			result = append(result, profile)
+		}
+	}
+	return result
+}
+
+// isServiceProfileActive returns if a service must be deployed: services without profiles are always enabled,
+// services with profiles are only enabled if any of them is active
+func isServiceProfileActive(svcProfiles, activeProfiles []string) bool {
+	if len(svcProfiles) == 0 {
+		return true
+	}
+	for _, active := range activeProfiles {
+		if active == "*" {
+			return true
+		}
+		for _, profile := range svcProfiles {
+			if profile == active {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// removeInactiveDependencies removes from depends_on the services disabled by their profiles
+func removeInactiveDependencies(services map[string]*Service, inactiveServices map[string]bool) {
+	for _, svc := range services {
+		for name := range svc.DependsOn {
+			if inactiveServices[name] {
+				delete(svc.DependsOn, name)
+			}
+		}
+	}
+}
+
 func getNotSupportedFields(s *StackRaw) []string {
 	notSupportedFields := make([]string, 0)
 	notSupportedFields = append(notSupportedFields, getTopLevelNotSupportedFields(s)...)
@@ -1345,9 +1396,6 @@ func getServiceNotSupportedFields(svcName string, svcInfo *ServiceRaw) []string
 	if svcInfo.DomainName != nil {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].domainname", svcName))
 	}
-	if svcInfo.Extends != nil {
-		notSupported = append(notSupported, fmt.Sprintf("services[%s].extends", svcName))
-	}
 	if svcInfo.ExternalLinks != nil {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].external_links", svcName))
 	}
@@ -1408,9 +1456,6 @@ func getServiceNotSupportedFields(svcName string, svcInfo *ServiceRaw) []string
 	if svcInfo.Privileged != nil {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].privileged", svcName))
 	}
-	if svcInfo.Profiles != nil {
-		notSupported = append(notSupported, fmt.Sprintf("services[%s].profiles", svcName))
-	}
 	if svcInfo.PullPolicy != nil {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].pull_policy", svcName))
 	}
@@ -0,0 +1,113 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/env"
+)
+
+// ManifestTests defines all the test section
+type ManifestTests map[string]*Test
+
+// Test represents a test suite executed remotely by 'okteto test'
+type Test struct {
+	Image     string          `json:"image,omitempty" yaml:"image,omitempty"`
+	Context   string          `json:"context,omitempty" yaml:"context,omitempty"`
+	Commands  []DeployCommand `json:"commands,omitempty" yaml:"commands,omitempty"`
+	Caches    []string        `json:"caches,omitempty" yaml:"caches,omitempty"`
+	Artifacts []Artifact      `json:"artifacts,omitempty" yaml:"artifacts,omitempty"`
+}
+
+// Artifact represents a file or folder generated by a test suite that is copied back to the local filesystem
+type Artifact struct {
+	Path        string `json:"path,omitempty" yaml:"path,omitempty"`
+	Destination string `json:"destination,omitempty" yaml:"destination,omitempty"`
+}
+
+// Names returns the sorted names of the test suites
+func (mt ManifestTests) Names() []string {
+	names := make([]string, 0, len(mt))
+	for name := range mt {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Validate validates the test section of the manifest
+func (mt ManifestTests) Validate() error {
+	for _, name := range mt.Names() {
+		test := mt[name]
+		if test == nil {
+			return fmt.Errorf("test suite '%s' is empty", name)
+		}
+		if len(test.Commands) == 0 {
+			return fmt.Errorf("test suite '%s' must define at least one command", name)
+		}
+		for _, cache := range test.Caches {
+			if !filepath.IsAbs(cache) {
+				return fmt.Errorf("cache '%s' of test suite '%s' must be an absolute path", cache, name)
+			}
+		}
+		for _, artifact := range test.Artifacts {
+			if artifact.Path == "" {
+				return fmt.Errorf("artifacts of test suite '%s' must define a path", name)
+			}
+			if filepath.IsAbs(artifact.Path) || strings.HasPrefix(filepath.Clean(artifact.Path), "..") {
+				return fmt.Errorf("artifact '%s' of test suite '%s' must be a path relative to the test context", artifact.Path, name)
+			}
+			if filepath.IsAbs(artifact.Destination) || strings.HasPrefix(filepath.Clean(artifact.Destination), "..") {
+				return fmt.Errorf("artifact destination '%s' of test suite '%s' must be a path relative to the manifest folder", artifact.Destination, name)
+			}
+		}
+	}
+	return nil
+}
+
+func (t *Test) expandEnvVars() error {
+	var err error
+	t.Image, err = env.ExpandEnvIfNotEmpty(t.Image)
+	if err != nil {
+		return err
+	}
+	t.Context, err = env.ExpandEnvIfNotEmpty(t.Context)
+	return err
+}
+
+// UnmarshalYAML implements the Unmarshaler interface of the yaml pkg. An artifact can be defined as a path
+func (a *Artifact) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var path string
+	if err := unmarshal(&path); err == nil {
+		a.Path = path
+		a.Destination = path
+		return nil
+	}
+
+	// prevent recursion
+	type artifact Artifact
+	var extendedArtifact artifact
+	if err := unmarshal(&extendedArtifact); err != nil {
+		return err
+	}
+	*a = Artifact(extendedArtifact)
+	if a.Destination == "" {
+		a.Destination = a.Path
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestTestUnmarshal(t *testing.T) {
+	manifest := []byte(`test:
+  unit:
+    image: golang:1.21
+    context: api
+    commands:
+    - go test ./...
+    - name: coverage
+      command: go tool cover -func=cover.out
+    caches:
+    - /root/.cache/go-build
+    artifacts:
+    - report.xml
+    - path: coverage/cover.html
+      destination: reports/cover.html
+`)
+	m := &Manifest{}
+	require.NoError(t, yaml.UnmarshalStrict(manifest, m))
+
+	expected := ManifestTests{
+		"unit": &Test{
+			Image:   "golang:1.21",
+			Context: "api",
+			Commands: []DeployCommand{
+				{Name: "go test ./...", Command: "go test ./..."},
+				{Name: "coverage", Command: "go tool cover -func=cover.out"},
+			},
+			Caches: []string{"/root/.cache/go-build"},
+			Artifacts: []Artifact{
+				{Path: "report.xml", Destination: "report.xml"},
+				{Path: "coverage/cover.html", Destination: "reports/cover.html"},
+			},
+		},
+	}
+	assert.Equal(t, expected, m.Test)
+}
+
+func TestManifestTestsValidate(t *testing.T) {
+	tests := []struct {
+		tests       ManifestTests
+		name        string
+		expectedErr bool
+	}{
+		{
+			name: "valid",
+			tests: ManifestTests{
+				"unit": &Test{
+					Commands:  []DeployCommand{{Command: "make test"}},
+					Caches:    []string{"/root/.cache"},
+					Artifacts: []Artifact{{Path: "report.xml", Destination: "reports/report.xml"}},
+				},
+			},
+		},
+		{
+			name:        "empty suite",
+			tests:       ManifestTests{"unit": nil},
+			expectedErr: true,
+		},
+		{
+			name:        "no commands",
+			tests:       ManifestTests{"unit": &Test{}},
+			expectedErr: true,
+		},
+		{
+			name: "relative cache",
+			tests: ManifestTests{
+				"unit": &Test{
+					Commands: []DeployCommand{{Command: "make test"}},
+					Caches:   []string{".cache"},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "absolute artifact",
+			tests: ManifestTests{
+				"unit": &Test{
+					Commands:  []DeployCommand{{Command: "make test"}},
+					Artifacts: []Artifact{{Path: "/report.xml", Destination: "report.xml"}},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "artifact destination outside the manifest folder",
+			tests: ManifestTests{
+				"unit": &Test{
+					Commands:  []DeployCommand{{Command: "make test"}},
+					Artifacts: []Artifact{{Path: "report.xml", Destination: "../report.xml"}},
+				},
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.tests.Validate()
+			if tt.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -43,6 +43,7 @@ type TranslationRule struct {
 	Volumes           []VolumeMount        `json:"volumes,omitempty"`
 	Healthchecks      bool                 `json:"healthchecks" yaml:"healthchecks"`
 	PersistentVolume  bool                 `json:"persistentVolume" yaml:"persistentVolume"`
+	ResetShellHistory bool                 `json:"resetShellHistory,omitempty" yaml:"resetShellHistory,omitempty"`
 }
 
 // IsMainDevContainer returns true if the translation rule applies to the main dev container of the okteto manifest
@@ -50,6 +51,16 @@ func (r *TranslationRule) IsMainDevContainer() bool {
 	return r.OktetoBinImageTag != ""
 }
 
+// GetShellHistoryVolumeName returns the name of the volume persisting the shell history
+func (r *TranslationRule) GetShellHistoryVolumeName() string {
+	for _, v := range r.Volumes {
+		if v.SubPath == ShellHistorySubPath {
+			return v.Name
+		}
+	}
+	return ""
+}
+
 // VolumeMount represents a volume mount
 type VolumeMount struct {
 	Name      string `json:"name,omitempty"`
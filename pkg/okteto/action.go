@@ -36,6 +36,13 @@ type getActionQueryStruct struct {
 	Action actionStruct `graphql:"action(name: $name, space: $space)"`
 }
 
+type getActionVariables struct {
+	Name  graphql.String `graphql:"name"`
+	Space graphql.String `graphql:"space"`
+}
+
+var getActionOperation = newQuery[getActionVariables, getActionQueryStruct]("action")
+
 type actionStruct struct {
 	Id     graphql.String
 	Name   graphql.String
@@ -45,13 +52,11 @@ type actionStruct struct {
 // GetAction gets a installer job given its name
 func (c *pipelineClient) GetAction(ctx context.Context, name, namespace string) (*types.Action, error) {
 	oktetoLog.Infof("getting action '%s' on %s", name, namespace)
-	queryStruct := getActionQueryStruct{}
-	variables := map[string]interface{}{
-		"name":  graphql.String(name),
-		"space": graphql.String(namespace),
+	variables := getActionVariables{
+		Name:  graphql.String(name),
+		Space: graphql.String(namespace),
 	}
-
-	err := query(ctx, &queryStruct, variables, c.client)
+	queryStruct, err := getActionOperation.Do(ctx, c.client, variables)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get action '%s': %w", name, err)
 	}
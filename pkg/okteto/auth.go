@@ -65,6 +65,16 @@ type deprecatedAuthMutationStruct struct {
 	Response deprecatedUserMutation `graphql:"auth(code: $code, source: $source)"`
 }
 
+type authVariables struct {
+	Code   graphql.String `graphql:"code"`
+	Source graphql.String `graphql:"source"`
+}
+
+var (
+	authOperation           = newMutation[authVariables, authMutationStruct]("auth")
+	deprecatedAuthOperation = newMutation[authVariables, deprecatedAuthMutationStruct]("auth")
+)
+
 type userMutation struct {
 	Id              graphql.String
 	Name            graphql.String
@@ -125,14 +135,7 @@ func (c *OktetoClient) Auth(ctx context.Context, code string) (*types.User, erro
 }
 
 func (c *OktetoClient) authUser(ctx context.Context, code string) (*types.User, error) {
-	var mutation authMutationStruct
-
-	queryVariables := map[string]interface{}{
-		"code":   graphql.String(code),
-		"source": graphql.String(cliSource),
-	}
-
-	err := mutate(ctx, &mutation, queryVariables, c.client)
+	mutation, err := authOperation.Do(ctx, c.client, authVariables{Code: graphql.String(code), Source: graphql.String(cliSource)})
 	if err != nil {
 		if strings.Contains(err.Error(), "Cannot query field \"globalNamespace\" on type \"me\"") {
 			return c.deprecatedAuthUser(ctx, code)
@@ -166,13 +169,7 @@ func (c *OktetoClient) authUser(ctx context.Context, code string) (*types.User,
 
 // TODO: Remove this code when okteto char 0.10.8 is deprecated
 func (c *OktetoClient) deprecatedAuthUser(ctx context.Context, code string) (*types.User, error) {
-	var mutation deprecatedAuthMutationStruct
-	queryVariables := map[string]interface{}{
-		"code":   graphql.String(code),
-		"source": graphql.String(cliSource),
-	}
-
-	err := mutate(ctx, &mutation, queryVariables, c.client)
+	mutation, err := deprecatedAuthOperation.Do(ctx, c.client, authVariables{Code: graphql.String(code), Source: graphql.String(cliSource)})
 	if err != nil {
 		return nil, err
 	}
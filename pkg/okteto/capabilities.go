@@ -0,0 +1,190 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/okteto/okteto/pkg/config"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/types"
+)
+
+// Capability is a feature of the okteto API that is not available in every okteto version
+type Capability string
+
+const (
+	// KubetokenCapability is the support for dynamic kubernetes tokens
+	KubetokenCapability Capability = "kubetoken"
+
+	// DivertCapability is the support for diverting traffic between namespaces
+	DivertCapability Capability = "divert"
+
+	// ExternalResourcesCapability is the support for external resources
+	ExternalResourcesCapability Capability = "externalResources"
+
+	// capabilitiesCacheTTL is how long the capabilities of a context are cached before querying the okteto API again
+	capabilitiesCacheTTL = 24 * time.Hour
+)
+
+type capabilitiesState struct {
+	FetchedAt     time.Time `json:"fetchedAt"`
+	ServerVersion string    `json:"serverVersion"`
+	Capabilities  []string  `json:"capabilities"`
+}
+
+// CapabilityNotSupportedError is returned when the okteto API of a context doesn't support a capability
+type CapabilityNotSupportedError struct {
+	Capability    Capability
+	ServerVersion string
+}
+
+func (e CapabilityNotSupportedError) Error() string {
+	if e.ServerVersion == "" {
+		return fmt.Sprintf("your Okteto instance doesn't support %s", e.Capability)
+	}
+	return fmt.Sprintf("your Okteto version (%s) doesn't support %s", e.ServerVersion, e.Capability)
+}
+
+// CapabilityChecker checks the features supported by the okteto API of a context.
+// The capabilities advertised by the server are cached in the okteto folder until the TTL expires or the server version changes
+type CapabilityChecker struct {
+	userClient types.UserInterface
+	now        func() time.Time
+	path       string
+	namespace  string
+}
+
+// NewCapabilityChecker returns the capability checker of the okteto API of the current context
+func NewCapabilityChecker(userClient types.UserInterface, namespace string) *CapabilityChecker {
+	return &CapabilityChecker{
+		userClient: userClient,
+		namespace:  namespace,
+		now:        time.Now,
+	}
+}
+
+// Check returns a CapabilityNotSupportedError if the okteto API of the context doesn't support the capability.
+// If the server doesn't advertise its capabilities the capability is considered supported, and the API will return its own error
+func (cc *CapabilityChecker) Check(ctx context.Context, contextName string, capability Capability) error {
+	state, ok := cc.getState(ctx, contextName)
+	if !ok || state.Capabilities == nil {
+		return nil
+	}
+	if slices.Contains(state.Capabilities, string(capability)) {
+		return nil
+	}
+
+	return oktetoErrors.UserError{
+		E: CapabilityNotSupportedError{
+			Capability:    capability,
+			ServerVersion: state.ServerVersion,
+		},
+		Hint: fmt.Sprintf("Ask your administrator to upgrade Okteto to a version supporting %s", capability),
+	}
+}
+
+// Update caches the capabilities of the cluster metadata, so the cache is invalidated as soon as the server version changes
+func (cc *CapabilityChecker) Update(contextName string, metadata types.ClusterMetadata) {
+	states := cc.load()
+	contextName = strings.TrimSuffix(contextName, "/")
+	if state, ok := states[contextName]; ok && state.ServerVersion == metadata.ServerVersion && slices.Equal(state.Capabilities, metadata.Capabilities) {
+		return
+	}
+	states[contextName] = capabilitiesState{
+		ServerVersion: metadata.ServerVersion,
+		Capabilities:  metadata.Capabilities,
+		FetchedAt:     cc.now(),
+	}
+	cc.save(states)
+}
+
+// getState returns the capabilities of the context, querying the okteto API if the cached value expired
+func (cc *CapabilityChecker) getState(ctx context.Context, contextName string) (capabilitiesState, bool) {
+	states := cc.load()
+	contextName = strings.TrimSuffix(contextName, "/")
+	if state, ok := states[contextName]; ok && cc.now().Sub(state.FetchedAt) < capabilitiesCacheTTL {
+		return state, true
+	}
+
+	if cc.userClient == nil {
+		return capabilitiesState{}, false
+	}
+	metadata, err := cc.userClient.GetClusterMetadata(ctx, cc.namespace)
+	if err != nil {
+		oktetoLog.Infof("failed to get the okteto server capabilities: %s", err)
+		return capabilitiesState{}, false
+	}
+
+	state := capabilitiesState{
+		ServerVersion: metadata.ServerVersion,
+		Capabilities:  metadata.Capabilities,
+		FetchedAt:     cc.now(),
+	}
+	states[contextName] = state
+	cc.save(states)
+	return state, true
+}
+
+// getPath returns the path of the cache file, resolved lazily to not create the okteto folder until it's needed
+func (cc *CapabilityChecker) getPath() string {
+	if cc.path == "" {
+		return config.GetAPICapabilitiesPath()
+	}
+	return cc.path
+}
+
+func (cc *CapabilityChecker) load() map[string]capabilitiesState {
+	states := map[string]capabilitiesState{}
+	b, err := os.ReadFile(cc.getPath())
+	if err != nil {
+		return states
+	}
+	if err := json.Unmarshal(b, &states); err != nil {
+		oktetoLog.Infof("failed to read the okteto API capabilities cache: %s", err)
+		return map[string]capabilitiesState{}
+	}
+	return states
+}
+
+func (cc *CapabilityChecker) save(states map[string]capabilitiesState) {
+	b, err := json.Marshal(states)
+	if err != nil {
+		oktetoLog.Infof("failed to generate the okteto API capabilities cache: %s", err)
+		return
+	}
+	if err := os.WriteFile(cc.getPath(), b, 0600); err != nil {
+		oktetoLog.Infof("failed to write the okteto API capabilities cache: %s", err)
+	}
+}
+
+// CheckCapability checks if the okteto API of the current context supports a capability
+func CheckCapability(ctx context.Context, capability Capability) error {
+	if Context().IsOffline {
+		return nil
+	}
+	c, err := NewOktetoClient()
+	if err != nil {
+		oktetoLog.Infof("failed to create okteto client to check %s support: %s", capability, err)
+		return nil
+	}
+	return NewCapabilityChecker(c.User(), Context().Namespace).Check(ctx, Context().Name, capability)
+}
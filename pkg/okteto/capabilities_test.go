@@ -0,0 +1,139 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeMetadataClient struct {
+	types.UserInterface
+	err      error
+	metadata types.ClusterMetadata
+	calls    int
+}
+
+func (c *fakeMetadataClient) GetClusterMetadata(_ context.Context, _ string) (types.ClusterMetadata, error) {
+	c.calls++
+	return c.metadata, c.err
+}
+
+func TestCapabilityChecker(t *testing.T) {
+	var tests = []struct {
+		err           error
+		name          string
+		serverVersion string
+		capabilities  []string
+		capability    Capability
+		expectedErr   bool
+	}{
+		{
+			name:          "supported",
+			serverVersion: "1.12.0",
+			capabilities:  []string{"kubetoken", "divert"},
+			capability:    KubetokenCapability,
+		},
+		{
+			name:          "not supported",
+			serverVersion: "1.11.3",
+			capabilities:  []string{"divert"},
+			capability:    KubetokenCapability,
+			expectedErr:   true,
+		},
+		{
+			name:          "capabilities not advertised",
+			serverVersion: "1.11.3",
+			capability:    KubetokenCapability,
+		},
+		{
+			name:       "metadata error",
+			err:        errors.New("not-authorized"),
+			capability: DivertCapability,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cc := &CapabilityChecker{
+				userClient: &fakeMetadataClient{metadata: types.ClusterMetadata{ServerVersion: tt.serverVersion, Capabilities: tt.capabilities}, err: tt.err},
+				now:        time.Now,
+				path:       filepath.Join(t.TempDir(), "api-capabilities.json"),
+			}
+			err := cc.Check(context.Background(), "https://okteto.example.com", tt.capability)
+			if !tt.expectedErr {
+				assert.NoError(t, err)
+				return
+			}
+			var uErr oktetoErrors.UserError
+			assert.ErrorAs(t, err, &uErr)
+			assert.EqualError(t, err, "your Okteto version (1.11.3) doesn't support kubetoken")
+			assert.Equal(t, "Ask your administrator to upgrade Okteto to a version supporting kubetoken", uErr.Hint)
+		})
+	}
+}
+
+func TestCapabilityCheckerCache(t *testing.T) {
+	now := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	client := &fakeMetadataClient{metadata: types.ClusterMetadata{ServerVersion: "1.9.0", Capabilities: []string{"externalResources"}}}
+	cc := &CapabilityChecker{
+		userClient: client,
+		now:        func() time.Time { return now },
+		path:       filepath.Join(t.TempDir(), "api-capabilities.json"),
+	}
+	ctx := context.Background()
+	name := "https://okteto.example.com"
+
+	assert.Error(t, cc.Check(ctx, name, DivertCapability))
+	assert.NoError(t, cc.Check(ctx, name+"/", ExternalResourcesCapability))
+	assert.Equal(t, 1, client.calls)
+
+	// the capabilities are queried again once the cache expires
+	client.metadata = types.ClusterMetadata{ServerVersion: "1.10.0", Capabilities: []string{"divert"}}
+	now = now.Add(capabilitiesCacheTTL)
+	assert.NoError(t, cc.Check(ctx, name, DivertCapability))
+	assert.Equal(t, 2, client.calls)
+
+	// a failed query is not cached
+	client.err = errors.New("connection refused")
+	assert.NoError(t, cc.Check(ctx, "https://other.example.com", DivertCapability))
+	assert.NoError(t, cc.Check(ctx, "https://other.example.com", DivertCapability))
+	assert.Equal(t, 4, client.calls)
+}
+
+func TestCapabilityCheckerUpdate(t *testing.T) {
+	now := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	client := &fakeMetadataClient{}
+	cc := &CapabilityChecker{
+		userClient: client,
+		now:        func() time.Time { return now },
+		path:       filepath.Join(t.TempDir(), "api-capabilities.json"),
+	}
+	ctx := context.Background()
+	name := "https://okteto.example.com"
+
+	cc.Update(name, types.ClusterMetadata{ServerVersion: "1.9.0", Capabilities: []string{}})
+	assert.Error(t, cc.Check(ctx, name, DivertCapability))
+
+	// a new server version invalidates the cached capabilities before the TTL expires
+	cc.Update(name, types.ClusterMetadata{ServerVersion: "1.10.0", Capabilities: []string{"divert"}})
+	assert.NoError(t, cc.Check(ctx, name, DivertCapability))
+	assert.Equal(t, 0, client.calls)
+}
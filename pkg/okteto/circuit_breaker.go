@@ -0,0 +1,118 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/okteto/okteto/pkg/config"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	// circuitBreakerThreshold is the number of consecutive API failures that open the circuit
+	circuitBreakerThreshold = 3
+
+	// circuitBreakerCooldown is how long the circuit stays open before the API is called again
+	circuitBreakerCooldown = 2 * time.Minute
+)
+
+type circuitState struct {
+	OpenedAt time.Time `json:"openedAt,omitempty"`
+	Failures int       `json:"failures"`
+}
+
+// CircuitBreaker short-circuits the calls to the okteto API of a context after consecutive failures.
+// The state is stored in the okteto folder so it's shared by every okteto command
+type CircuitBreaker struct {
+	now  func() time.Time
+	path string
+}
+
+// NewCircuitBreaker returns the circuit breaker of the okteto API
+func NewCircuitBreaker() *CircuitBreaker {
+	return &CircuitBreaker{
+		now: time.Now,
+	}
+}
+
+// Allow returns if the API of the context can be called.
+// Once the cooldown expires, the next call is allowed to probe if the API is back
+func (cb *CircuitBreaker) Allow(name string) bool {
+	state := cb.load()[strings.TrimSuffix(name, "/")]
+	if state.Failures < circuitBreakerThreshold {
+		return true
+	}
+	return cb.now().Sub(state.OpenedAt) >= circuitBreakerCooldown
+}
+
+// RecordSuccess closes the circuit of the context
+func (cb *CircuitBreaker) RecordSuccess(name string) {
+	states := cb.load()
+	name = strings.TrimSuffix(name, "/")
+	if _, ok := states[name]; !ok {
+		return
+	}
+	delete(states, name)
+	cb.save(states)
+}
+
+// RecordFailure counts a failed call to the API of the context, opening the circuit when the threshold is reached
+func (cb *CircuitBreaker) RecordFailure(name string) {
+	states := cb.load()
+	name = strings.TrimSuffix(name, "/")
+	state := states[name]
+	state.Failures++
+	if state.Failures >= circuitBreakerThreshold {
+		state.OpenedAt = cb.now()
+		oktetoLog.Infof("okteto API circuit opened for '%s' after %d consecutive failures", name, state.Failures)
+	}
+	states[name] = state
+	cb.save(states)
+}
+
+// getPath returns the path of the state file, resolved lazily to not create the okteto folder until it's needed
+func (cb *CircuitBreaker) getPath() string {
+	if cb.path == "" {
+		return config.GetAPICircuitBreakerPath()
+	}
+	return cb.path
+}
+
+func (cb *CircuitBreaker) load() map[string]circuitState {
+	states := map[string]circuitState{}
+	b, err := os.ReadFile(cb.getPath())
+	if err != nil {
+		return states
+	}
+	if err := json.Unmarshal(b, &states); err != nil {
+		oktetoLog.Infof("failed to read the okteto API circuit breaker state: %s", err)
+		return map[string]circuitState{}
+	}
+	return states
+}
+
+func (cb *CircuitBreaker) save(states map[string]circuitState) {
+	b, err := json.Marshal(states)
+	if err != nil {
+		oktetoLog.Infof("failed to generate the okteto API circuit breaker state: %s", err)
+		return
+	}
+	if err := os.WriteFile(cb.getPath(), b, 0600); err != nil {
+		oktetoLog.Infof("failed to write the okteto API circuit breaker state: %s", err)
+	}
+}
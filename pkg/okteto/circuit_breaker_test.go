@@ -0,0 +1,62 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	cb := &CircuitBreaker{
+		now:  func() time.Time { return now },
+		path: filepath.Join(t.TempDir(), "api-circuit-breaker.json"),
+	}
+	name := "https://okteto.example.com"
+
+	for i := 0; i < circuitBreakerThreshold-1; i++ {
+		cb.RecordFailure(name)
+		assert.True(t, cb.Allow(name))
+	}
+
+	cb.RecordFailure(name)
+	assert.False(t, cb.Allow(name))
+	assert.False(t, cb.Allow(name+"/"))
+	assert.True(t, cb.Allow("https://other.example.com"))
+
+	// after the cooldown the API is probed again
+	now = now.Add(circuitBreakerCooldown)
+	assert.True(t, cb.Allow(name))
+
+	// a failed probe opens the circuit again
+	cb.RecordFailure(name)
+	assert.False(t, cb.Allow(name))
+
+	now = now.Add(circuitBreakerCooldown)
+	cb.RecordSuccess(name)
+	assert.True(t, cb.Allow(name))
+	assert.Empty(t, cb.load())
+}
+
+func TestCircuitBreakerCorruptedState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api-circuit-breaker.json")
+	assert.NoError(t, os.WriteFile(path, []byte("not-json"), 0600))
+	cb := &CircuitBreaker{now: time.Now, path: path}
+	assert.True(t, cb.Allow("https://okteto.example.com"))
+}
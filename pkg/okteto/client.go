@@ -21,11 +21,10 @@ import (
 	"net"
 	"net/http"
 	"net/url"
-	"os"
 	"strings"
 	"sync"
 
-	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/config"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	oktetoHttp "github.com/okteto/okteto/pkg/http"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
@@ -45,6 +44,8 @@ type OktetoClient struct {
 	stream    types.StreamInterface
 	kubetoken types.KubetokenInterface
 	endpoint  types.EndpointClientInterface
+	device    types.DeviceInterface
+	session   types.SessionInterface
 }
 
 type OktetoClientProvider struct{}
@@ -152,11 +153,6 @@ func newOktetoHttpClient(contextName, token, oktetoUrlPath string) (*http.Client
 		return nil, "", err
 	}
 
-	src := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: token,
-			TokenType: "Bearer"},
-	)
-
 	sslTransportOption := &oktetoHttp.SSLTransportOption{}
 
 	if serverName != "" {
@@ -173,9 +169,9 @@ func newOktetoHttpClient(contextName, token, oktetoUrlPath string) (*http.Client
 		ctxHttpClient = oktetoHttp.StrictSSLHTTPClient(sslTransportOption)
 	}
 
-	ctx := contextWithOauth2HttpClient(context.Background(), ctxHttpClient)
-
-	httpClient := oauth2.NewClient(ctx, src)
+	httpClient := &http.Client{
+		Transport: newTokenRefreshTransport(ctxHttpClient.Transport, contextName, token, RefreshToken),
+	}
 
 	return httpClient, u, err
 }
@@ -189,11 +185,6 @@ func newOktetoHttpClientStateless(contextName, token, cert, oktetoUrlPath string
 		return nil, "", err
 	}
 
-	src := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: token,
-			TokenType: "Bearer"},
-	)
-
 	sslTransportOption := &oktetoHttp.SSLTransportOption{}
 
 	if serverName != "" {
@@ -210,9 +201,9 @@ func newOktetoHttpClientStateless(contextName, token, cert, oktetoUrlPath string
 		ctxHttpClient = oktetoHttp.StrictSSLHTTPClient(sslTransportOption)
 	}
 
-	ctx := contextWithOauth2HttpClient(context.Background(), ctxHttpClient)
-
-	httpClient := oauth2.NewClient(ctx, src)
+	httpClient := &http.Client{
+		Transport: newTokenRefreshTransport(ctxHttpClient.Transport, contextName, token, RefreshToken),
+	}
 
 	return httpClient, u, err
 }
@@ -224,11 +215,6 @@ func NewOktetoClientFromUrlAndToken(url, token string) (*OktetoClient, error) {
 		return nil, fmt.Errorf("could not create okteto client: %w", err)
 	}
 
-	src := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: token,
-			TokenType: "Bearer"},
-	)
-
 	sslTransportOption := &oktetoHttp.SSLTransportOption{}
 
 	if serverName != "" {
@@ -245,9 +231,10 @@ func NewOktetoClientFromUrlAndToken(url, token string) (*OktetoClient, error) {
 		ctxHttpClient = oktetoHttp.StrictSSLHTTPClient(sslTransportOption)
 	}
 
-	ctx := contextWithOauth2HttpClient(context.Background(), ctxHttpClient)
-
-	httpClient := oauth2.NewClient(ctx, src)
+	// okteto contexts are named after the url of the okteto instance
+	httpClient := &http.Client{
+		Transport: newTokenRefreshTransport(ctxHttpClient.Transport, url, token, RefreshToken),
+	}
 
 	return newOktetoClientFromGraphqlClient(u, httpClient)
 }
@@ -275,9 +262,10 @@ func NewOktetoClientFromUrl(url string) (*OktetoClient, error) {
 		ctxHttpClient = oktetoHttp.StrictSSLHTTPClient(sslTransportOption)
 	}
 
-	ctx := contextWithOauth2HttpClient(context.Background(), ctxHttpClient)
-
-	httpClient := oauth2.NewClient(ctx, nil)
+	// requests are sent without token until the API rejects them and the token of the context is found
+	httpClient := &http.Client{
+		Transport: newTokenRefreshTransport(ctxHttpClient.Transport, url, "", refreshStoredToken),
+	}
 
 	return newOktetoClientFromGraphqlClient(u, httpClient)
 }
@@ -302,6 +290,8 @@ func newOktetoClientFromGraphqlClient(url string, httpClient *http.Client) (*Okt
 	c.stream = newStreamClient(httpClient)
 	c.kubetoken = newKubeTokenClient(httpClient)
 	c.endpoint = newEndpointClient(c.client)
+	c.device = newDeviceClient(httpClient)
+	c.session = newSessionClient(httpClient)
 	return c, nil
 }
 
@@ -401,7 +391,7 @@ func isAPITransientErr(err error) bool {
 
 // InDevContainer returns true if running in an okteto dev container
 func InDevContainer() bool {
-	if v, ok := os.LookupEnv(constants.OktetoNameEnvVar); ok && v != "" {
+	if v, ok := config.EnvOktetoName.Lookup(); ok && v != "" {
 		return true
 	}
 
@@ -464,6 +454,16 @@ func (c *OktetoClient) Endpoint() types.EndpointClientInterface {
 	return c.endpoint
 }
 
+// Device retrieves the device authorization client
+func (c *OktetoClient) Device() types.DeviceInterface {
+	return c.device
+}
+
+// Session retrieves the client of the session of the user
+func (c *OktetoClient) Session() types.SessionInterface {
+	return c.session
+}
+
 func SetInsecureSkipTLSVerifyPolicy(isInsecure bool) {
 	oktetoLog.Debugf("insecure mode: %t", isInsecure)
 	if isInsecure {
@@ -64,6 +64,8 @@ var (
 // OktetoContext contains the information related to an okteto context
 type OktetoContext struct {
 	Cfg                *clientcmdapi.Config `json:"-" yaml:"-"`
+	Labels             map[string]string    `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Annotations        map[string]string    `json:"annotations,omitempty" yaml:"annotations,omitempty"`
 	Name               string               `json:"name" yaml:"name,omitempty"`
 	UserID             string               `json:"id,omitempty" yaml:"id,omitempty"`
 	Username           string               `json:"username,omitempty" yaml:"username,omitempty"`
@@ -73,6 +75,7 @@ type OktetoContext struct {
 	Registry           string               `json:"registry,omitempty" yaml:"registry,omitempty"`
 	Certificate        string               `json:"certificate,omitempty" yaml:"certificate,omitempty"`
 	PersonalNamespace  string               `json:"personalNamespace,omitempty" yaml:"personalNamespace,omitempty"`
+	DevCatalog         string               `json:"devCatalog,omitempty" yaml:"devCatalog,omitempty"`
 	GlobalNamespace    string               `json:"-" yaml:"-"`
 	ClusterType        string               `json:"-" yaml:"-"`
 	CompanyName        string               `json:"-" yaml:"-"`
@@ -81,6 +84,7 @@ type OktetoContext struct {
 	IsInsecure         bool                 `json:"-" yaml:"-"`
 	Analytics          bool                 `json:"-" yaml:"-"`
 	IsTrial            bool                 `json:"-" yaml:"-"`
+	IsOffline          bool                 `json:"-" yaml:"-"`
 }
 
 // OktetoContextViewer contains info to show
@@ -308,12 +312,18 @@ func AddOktetoContext(name string, u *types.User, namespace, personalNamespace s
 
 func AddKubernetesContext(name, namespace, buildkitURL string) {
 	CurrentStore = ContextStore()
-	CurrentStore.Contexts[name] = &OktetoContext{
+	okCtx := &OktetoContext{
 		Name:      name,
 		Namespace: namespace,
 		Builder:   buildkitURL,
 		Analytics: true,
 	}
+	if previous, ok := CurrentStore.Contexts[name]; ok && previous != nil {
+		okCtx.Labels = previous.Labels
+		okCtx.Annotations = previous.Annotations
+		okCtx.DevCatalog = previous.DevCatalog
+	}
+	CurrentStore.Contexts[name] = okCtx
 	CurrentStore.CurrentContext = name
 }
 
@@ -359,7 +369,7 @@ func (*ContextConfigWriter) Write() error {
 func AddOktetoCredentialsToCfg(cfg *clientcmdapi.Config, cred *types.Credential, namespace, userName string, oktetoContext OktetoContext) error {
 	// If the context is being initialized within the execution of `okteto deploy` deploy command it should not
 	// write the Okteto credentials into the kubeconfig. It would overwrite the proxy settings
-	if os.Getenv(constants.OktetoSkipConfigCredentialsUpdate) == "true" {
+	if config.EnvOktetoSkipConfigCredentialsUpdate.Value() == "true" {
 		return nil
 	}
 
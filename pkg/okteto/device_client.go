@@ -0,0 +1,153 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/types"
+)
+
+const (
+	// deviceCodePathTemplate (baseURL)
+	deviceCodePathTemplate = "%s/auth/device/code"
+	// deviceTokenPathTemplate (baseURL)
+	deviceTokenPathTemplate = "%s/auth/device/token"
+)
+
+var (
+	// ErrAuthorizationPending is returned while the user has not completed the device authorization
+	ErrAuthorizationPending = errors.New("authorization pending")
+
+	// ErrSlowDown is returned when the device authorization is polled too often
+	ErrSlowDown = errors.New("polling too fast")
+
+	// ErrDeviceCodeExpired is returned when the device code expired before the user completed the authorization
+	ErrDeviceCodeExpired = errors.New("the device code expired")
+
+	// ErrAccessDenied is returned when the user denied the device authorization
+	ErrAccessDenied = errors.New("the authorization request was denied")
+)
+
+type deviceClient struct {
+	httpClient *http.Client
+}
+
+// deviceTokenResponse is the response of the device token endpoint
+type deviceTokenResponse struct {
+	Code  string `json:"code"`
+	Error string `json:"error"`
+}
+
+func newDeviceClient(httpClient *http.Client) *deviceClient {
+	return &deviceClient{
+		httpClient: httpClient,
+	}
+}
+
+// RequestCode starts a device authorization request
+func (c *deviceClient) RequestCode(ctx context.Context, baseURL string) (*types.DeviceCode, error) {
+	body, err := c.post(ctx, fmt.Sprintf(deviceCodePathTemplate, baseURL), nil)
+	if err != nil {
+		return nil, fmt.Errorf("RequestCode %w", err)
+	}
+
+	code := &types.DeviceCode{}
+	if err := json.Unmarshal(body, code); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal device code response: %w", err)
+	}
+	if code.DeviceCode == "" || code.UserCode == "" {
+		return nil, fmt.Errorf("RequestCode %w: the response doesn't include a device code", errStatus)
+	}
+	return code, nil
+}
+
+// PollCode returns the authorization code of a device authorization request once the user completes it
+func (c *deviceClient) PollCode(ctx context.Context, baseURL, deviceCode string) (string, error) {
+	payload, err := json.Marshal(map[string]string{"device_code": deviceCode})
+	if err != nil {
+		return "", err
+	}
+	body, err := c.post(ctx, fmt.Sprintf(deviceTokenPathTemplate, baseURL), payload)
+	if err != nil && !errors.Is(err, errStatus) {
+		return "", fmt.Errorf("PollCode %w", err)
+	}
+
+	response := deviceTokenResponse{}
+	if jsonErr := json.Unmarshal(body, &response); jsonErr != nil {
+		if err != nil {
+			return "", fmt.Errorf("PollCode %w", err)
+		}
+		return "", fmt.Errorf("failed to unmarshal device token response: %w", jsonErr)
+	}
+
+	switch response.Error {
+	case "":
+	case "authorization_pending":
+		return "", ErrAuthorizationPending
+	case "slow_down":
+		return "", ErrSlowDown
+	case "expired_token":
+		return "", ErrDeviceCodeExpired
+	case "access_denied":
+		return "", ErrAccessDenied
+	default:
+		return "", fmt.Errorf("PollCode %w: %s", errStatus, response.Error)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("PollCode %w", err)
+	}
+	if response.Code == "" {
+		return "", fmt.Errorf("PollCode %w: the response doesn't include an authorization code", errStatus)
+	}
+	return response.Code, nil
+}
+
+// post sends a request to the endpoint and returns the body of the response, also on status errors
+func (c *deviceClient) post(ctx context.Context, endpoint string, payload []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errRequest, err)
+	}
+
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			oktetoLog.Infof("could not close the body: %s", err)
+		}
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read device authorization response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return body, fmt.Errorf("%w: %s", errStatus, resp.Status)
+	}
+	return body, nil
+}
@@ -0,0 +1,163 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RequestCode(t *testing.T) {
+	tests := []struct {
+		httpFakeHandler http.Handler
+		expectedErr     error
+		expected        *types.DeviceCode
+		name            string
+	}{
+		{
+			name: "error request not success",
+			httpFakeHandler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			}),
+			expectedErr: errStatus,
+		},
+		{
+			name: "error response without codes",
+			httpFakeHandler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("{}"))
+			}),
+			expectedErr: errStatus,
+		},
+		{
+			name: "success response",
+			httpFakeHandler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/auth/device/code" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.Write([]byte(`{"device_code":"device","user_code":"ABCD-1234","verification_uri":"https://okteto.example.com/device","expires_in":600,"interval":5}`))
+			}),
+			expected: &types.DeviceCode{
+				DeviceCode:      "device",
+				UserCode:        "ABCD-1234",
+				VerificationURI: "https://okteto.example.com/device",
+				ExpiresIn:       600,
+				Interval:        5,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeHttpServer := httptest.NewServer(tt.httpFakeHandler)
+			defer fakeHttpServer.Close()
+
+			fakeDeviceClient := newDeviceClient(fakeHttpServer.Client())
+
+			got, err := fakeDeviceClient.RequestCode(context.Background(), fakeHttpServer.URL)
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func Test_PollCode(t *testing.T) {
+	tests := []struct {
+		expectedErr  error
+		name         string
+		response     string
+		expectedCode string
+		status       int
+	}{
+		{
+			name:         "authorized",
+			status:       http.StatusOK,
+			response:     `{"code":"authorization-code"}`,
+			expectedCode: "authorization-code",
+		},
+		{
+			name:        "authorization pending",
+			status:      http.StatusBadRequest,
+			response:    `{"error":"authorization_pending"}`,
+			expectedErr: ErrAuthorizationPending,
+		},
+		{
+			name:        "slow down",
+			status:      http.StatusBadRequest,
+			response:    `{"error":"slow_down"}`,
+			expectedErr: ErrSlowDown,
+		},
+		{
+			name:        "expired",
+			status:      http.StatusBadRequest,
+			response:    `{"error":"expired_token"}`,
+			expectedErr: ErrDeviceCodeExpired,
+		},
+		{
+			name:        "access denied",
+			status:      http.StatusBadRequest,
+			response:    `{"error":"access_denied"}`,
+			expectedErr: ErrAccessDenied,
+		},
+		{
+			name:        "unknown error",
+			status:      http.StatusBadRequest,
+			response:    `{"error":"invalid_grant"}`,
+			expectedErr: errStatus,
+		},
+		{
+			name:        "server error without body",
+			status:      http.StatusInternalServerError,
+			expectedErr: errStatus,
+		},
+		{
+			name:        "success without code",
+			status:      http.StatusOK,
+			response:    `{}`,
+			expectedErr: errStatus,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeHttpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body := map[string]string{}
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["device_code"] != "device" {
+					w.WriteHeader(http.StatusUnprocessableEntity)
+					return
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.response))
+			}))
+			defer fakeHttpServer.Close()
+
+			fakeDeviceClient := newDeviceClient(fakeHttpServer.Client())
+
+			got, err := fakeDeviceClient.PollCode(context.Background(), fakeHttpServer.URL, "device")
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, got)
+		})
+	}
+}
@@ -51,14 +51,11 @@ type SpaceQuery struct {
 	Response Space `graphql:"space(id: $id)"`
 }
 
+var listEndpointsOperation = newQuery[idVariables, SpaceQuery]("space")
+
 func (c *endpointClient) List(ctx context.Context, ns, deployedBy string) ([]string, error) {
 	oktetoLog.Infof("listing endpoints in '%s' namespace deployed by %s", ns, deployedBy)
-	var queryStruct SpaceQuery
-	variables := map[string]interface{}{
-		"id": graphql.String(ns),
-	}
-
-	err := query(ctx, &queryStruct, variables, c.client)
+	queryStruct, err := listEndpointsOperation.Do(ctx, c.client, idVariables{ID: graphql.String(ns)})
 	if err != nil {
 		return nil, err
 	}
@@ -11,7 +11,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package client
+package fake
 
 import (
 	"context"
@@ -25,6 +25,9 @@ type FakeNamespaceClient struct {
 	err        error
 	namespaces []types.Namespace
 
+	// DestroySchedules are the destroy schedules of the namespaces, by name
+	DestroySchedules map[string]types.DestroySchedule
+
 	// WakeCalls is the number of times Wake was called
 	WakeCalls int
 }
@@ -85,3 +88,24 @@ func (c *FakeNamespaceClient) Wake(_ context.Context, _ string) error {
 	c.WakeCalls++
 	return nil
 }
+
+// SetDestroySchedule sets the destroy schedule of a namespace
+func (c *FakeNamespaceClient) SetDestroySchedule(_ context.Context, namespace string, schedule types.DestroySchedule) error {
+	if c.err != nil {
+		return c.err
+	}
+	if c.DestroySchedules == nil {
+		c.DestroySchedules = map[string]types.DestroySchedule{}
+	}
+	c.DestroySchedules[namespace] = schedule
+	return nil
+}
+
+// GetDestroySchedule returns the destroy schedule of a namespace
+func (c *FakeNamespaceClient) GetDestroySchedule(_ context.Context, namespace string) (*types.DestroySchedule, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	schedule := c.DestroySchedules[namespace]
+	return &schedule, nil
+}
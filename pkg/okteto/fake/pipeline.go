@@ -11,7 +11,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package client
+package fake
 
 import (
 	"context"
@@ -31,12 +31,19 @@ type FakePipelineResponses struct {
 	ResourceErr error
 	WaitErr     error
 	DestroyErr  error
+	StatusErr   error
+	ActionErr   error
+
+	// StatusResponses are returned by consecutive calls to GetStatus. The last one is repeated once all of them have been returned
+	StatusResponses []*types.PipelineStatus
 
 	DeployResponse  *types.GitDeployResponse
 	DestroyResponse *types.GitDeployResponse
+	LastAction      *types.Action
 	ResourcesMap    map[string]string
 	DeployOpts      types.PipelineDeployOptions
 	CallCount       int
+	StatusCallCount int
 }
 
 // NewFakePipelineClient creates a pipeline client to use in tests
@@ -77,3 +84,24 @@ func (*FakePipelineClient) GetByName(_ context.Context, _, _ string) (*types.Git
 func (fc *FakePipelineClient) WaitForActionProgressing(_ context.Context, _, _, _ string, _ time.Duration) error {
 	return fc.responses.WaitErr
 }
+
+// GetStatus returns the status of a pipeline and its resources
+func (fc *FakePipelineClient) GetStatus(_ context.Context, _, _ string) (*types.PipelineStatus, error) {
+	fc.responses.StatusCallCount++
+	if fc.responses.StatusErr != nil {
+		return nil, fc.responses.StatusErr
+	}
+	if len(fc.responses.StatusResponses) == 0 {
+		return nil, nil
+	}
+	idx := fc.responses.StatusCallCount - 1
+	if idx >= len(fc.responses.StatusResponses) {
+		idx = len(fc.responses.StatusResponses) - 1
+	}
+	return fc.responses.StatusResponses[idx], nil
+}
+
+// GetLastAction returns the last action of a pipeline
+func (fc *FakePipelineClient) GetLastAction(_ context.Context, _, _ string) (*types.Action, error) {
+	return fc.responses.LastAction, fc.responses.ActionErr
+}
@@ -11,11 +11,12 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package client
+package fake
 
 import (
 	"context"
 
+	"github.com/okteto/okteto/pkg/repository"
 	"github.com/okteto/okteto/pkg/types"
 )
 
@@ -45,13 +46,13 @@ func NewFakePreviewClient(response *FakePreviewResponse) *FakePreviewsClient {
 	}
 }
 
-// List list namespaces
-func (c *FakePreviewsClient) List(_ context.Context, _ []string) ([]types.Preview, error) {
+// List list previews
+func (c *FakePreviewsClient) List(_ context.Context, _ types.PreviewListOptions) ([]types.Preview, error) {
 	return c.response.PreviewList, c.response.ErrList
 }
 
 // DeployPreview deploys a preview
-func (c *FakePreviewsClient) DeployPreview(_ context.Context, _, _, _, _, _, _ string, _ []types.Variable, _ []string) (*types.PreviewResponse, error) {
+func (c *FakePreviewsClient) DeployPreview(_ context.Context, _, _, _, _, _, _ string, _ []types.Variable, _ []string, _ *repository.CommitInfo) (*types.PreviewResponse, error) {
 	return c.response.Preview, c.response.ErrDeployPreview
 }
 
@@ -11,10 +11,13 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package client
+package fake
 
 import (
 	"context"
+	"time"
+
+	"github.com/okteto/okteto/pkg/types"
 )
 
 // FakeStreamClient mocks the stream client interface
@@ -24,7 +27,8 @@ type FakeStreamClient struct {
 
 // FakeStreamResponse mocks the stream response
 type FakeStreamResponse struct {
-	StreamErr error
+	StreamErr   error
+	AuditEvents []types.AuditEvent
 }
 
 // NewFakeStreamClient returns a new fake stream client
@@ -43,3 +47,11 @@ func (c *FakeStreamClient) PipelineLogs(_ context.Context, _, _, _ string) error
 func (c *FakeStreamClient) DestroyAllLogs(_ context.Context, _ string) error {
 	return c.response.StreamErr
 }
+
+// AuditEvents sends the fake audit events to the handler
+func (c *FakeStreamClient) AuditEvents(_ context.Context, _ time.Time, handler func(types.AuditEvent)) error {
+	for _, e := range c.response.AuditEvents {
+		handler(e)
+	}
+	return c.response.StreamErr
+}
@@ -0,0 +1,164 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package okteto
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/shurcooL/graphql"
+)
+
+// variableRegex matches the variables referenced by the graphql tags of a query or mutation struct
+var variableRegex = regexp.MustCompile(`\$(\w+)`)
+
+// operations are all the operations declared with newQuery or newMutation
+var operations []operationChecker
+
+// operationChecker validates the declaration of an operation
+type operationChecker interface {
+	check() error
+}
+
+// operation is a typed query or mutation of the okteto API. The document is built from the graphql tags of R,
+// and the variables from the fields of V tagged with their names. Operations are declared once, so the calls to
+// the API can't use a variable that the document doesn't reference, and check validates each declaration
+type operation[V any, R any] struct {
+	name     string
+	mutation bool
+}
+
+// newQuery returns a typed query of the okteto API
+func newQuery[V any, R any](name string) operation[V, R] {
+	o := operation[V, R]{name: name}
+	operations = append(operations, o)
+	return o
+}
+
+// newMutation returns a typed mutation of the okteto API
+func newMutation[V any, R any](name string) operation[V, R] {
+	o := operation[V, R]{name: name, mutation: true}
+	operations = append(operations, o)
+	return o
+}
+
+// Do runs the operation with the client and returns its response
+func (o operation[V, R]) Do(ctx context.Context, client graphqlClientInterface, vars V) (*R, error) {
+	response := new(R)
+	variables := getOperationVariables(vars)
+	var err error
+	if o.mutation {
+		err = mutate(ctx, response, variables, client)
+	} else {
+		err = query(ctx, response, variables, client)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// check returns an error if the variables of the operation don't match the ones referenced by its document
+func (o operation[V, R]) check() error {
+	expected := getDocumentVariables(reflect.TypeOf(new(R)).Elem())
+	got := map[string]bool{}
+	for name := range getOperationVariables(*new(V)) {
+		got[name] = true
+	}
+	missing := []string{}
+	for name := range expected {
+		if !got[name] {
+			missing = append(missing, name)
+		}
+	}
+	unused := []string{}
+	for name := range got {
+		if !expected[name] {
+			unused = append(unused, name)
+		}
+	}
+	if len(missing) == 0 && len(unused) == 0 {
+		return nil
+	}
+	sort.Strings(missing)
+	sort.Strings(unused)
+	return fmt.Errorf("operation '%s': missing variables [%s], unused variables [%s]", o.name, strings.Join(missing, ", "), strings.Join(unused, ", "))
+}
+
+// noVariables are the variables of the operations without arguments
+type noVariables struct{}
+
+// idVariables are the variables of the operations on a namespace by its id
+type idVariables struct {
+	ID graphql.String `graphql:"id"`
+}
+
+// spaceVariables are the variables of the operations on a namespace by its name
+type spaceVariables struct {
+	Space graphql.String `graphql:"space"`
+}
+
+// getOperationVariables returns the variables of an operation from the fields of its variables struct, by their graphql tag.
+// The fields of embedded structs are variables of the operation too
+func getOperationVariables(vars interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	collectOperationVariables(reflect.ValueOf(vars), result)
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+func collectOperationVariables(v reflect.Value, result map[string]interface{}) {
+	if v.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if field.Anonymous {
+			collectOperationVariables(v.Field(i), result)
+			continue
+		}
+		if name := field.Tag.Get("graphql"); name != "" {
+			result[name] = v.Field(i).Interface()
+		}
+	}
+}
+
+// getDocumentVariables returns the variables referenced by the graphql tags of a query or mutation struct
+func getDocumentVariables(t reflect.Type) map[string]bool {
+	result := map[string]bool{}
+	collectDocumentVariables(t, result, map[reflect.Type]bool{})
+	return result
+}
+
+func collectDocumentVariables(t reflect.Type, result map[string]bool, visited map[reflect.Type]bool) {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || visited[t] {
+		return
+	}
+	visited[t] = true
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		for _, match := range variableRegex.FindAllStringSubmatch(field.Tag.Get("graphql"), -1) {
+			result[match[1]] = true
+		}
+		collectDocumentVariables(field.Type, result, visited)
+	}
+}
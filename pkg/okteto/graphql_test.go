@@ -0,0 +1,80 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"testing"
+
+	"github.com/shurcooL/graphql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperationsVariables(t *testing.T) {
+	assert.NotEmpty(t, operations)
+	for _, o := range operations {
+		assert.NoError(t, o.check())
+	}
+}
+
+type fakeOperationQuery struct {
+	Space struct {
+		Id graphql.String
+	} `graphql:"space(id: $id)"`
+}
+
+func TestOperationCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		operation   operationChecker
+		expectedErr string
+	}{
+		{
+			name:      "matching variables",
+			operation: operation[idVariables, fakeOperationQuery]{name: "space"},
+		},
+		{
+			name:        "missing variables",
+			operation:   operation[noVariables, fakeOperationQuery]{name: "space"},
+			expectedErr: "operation 'space': missing variables [id], unused variables []",
+		},
+		{
+			name:        "unused variables",
+			operation:   operation[spaceVariables, fakeOperationQuery]{name: "space"},
+			expectedErr: "operation 'space': missing variables [id], unused variables [space]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.operation.check()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestGetOperationVariables(t *testing.T) {
+	vars := deployPipelineWithLabelsVariables{
+		deployPipelineVariables: deployPipelineVariables{
+			Name: graphql.String("test"),
+		},
+		Labels: labelList{"label"},
+	}
+	result := getOperationVariables(vars)
+	assert.Equal(t, graphql.String("test"), result["name"])
+	assert.Equal(t, labelList{"label"}, result["labels"])
+	assert.Nil(t, getOperationVariables(noVariables{}))
+}
@@ -16,10 +16,10 @@ package okteto
 import (
 	"errors"
 	"net/http"
-	"os"
 	"sync"
 	"time"
 
+	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/k8s/ingresses"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"k8s.io/client-go/discovery"
@@ -38,11 +38,6 @@ var (
 	ErrK8sUnauthorised = errors.New("k8s unauthorized error")
 )
 
-const (
-	// oktetoKubernetesTimeoutEnvVar defines the timeout for kubernetes operations
-	oktetoKubernetesTimeoutEnvVar = "OKTETO_KUBERNETES_TIMEOUT"
-)
-
 type K8sClientProvider interface {
 	Provide(clientApiConfig *clientcmdapi.Config) (kubernetes.Interface, *rest.Config, error)
 }
@@ -96,14 +91,13 @@ func (*K8sClient) GetIngressClient() (*ingresses.Client, error) {
 func GetKubernetesTimeout() time.Duration {
 	tOnce.Do(func() {
 		timeout = 0 * time.Second
-		t, ok := os.LookupEnv(oktetoKubernetesTimeoutEnvVar)
-		if !ok {
+		if _, ok := config.EnvOktetoKubernetesTimeout.Lookup(); !ok {
 			return
 		}
 
-		parsed, err := time.ParseDuration(t)
+		parsed, err := config.EnvOktetoKubernetesTimeout.Duration()
 		if err != nil {
-			oktetoLog.Infof("'%s' is not a valid duration, ignoring", t)
+			oktetoLog.Infof("%s, ignoring", err)
 			return
 		}
 
@@ -15,7 +15,11 @@ package okteto
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/shurcooL/graphql"
 )
@@ -30,6 +34,9 @@ const (
 	ErrorStatus       = "error"
 )
 
+// ErrDestroyScheduleNotSupported is returned when the Okteto instance can't destroy namespaces automatically
+var ErrDestroyScheduleNotSupported = fmt.Errorf("the automatic destruction of namespaces is not supported by your Okteto instance")
+
 var TransitionStatus = map[string]bool{
 	BootingStatus:     true,
 	ProgressingStatus: true,
@@ -67,6 +74,21 @@ type sleepNamespaceMutation struct {
 	Response namespaceID `graphql:"sleepSpace(space: $space)"`
 }
 
+type setDestroyScheduleMutation struct {
+	Response namespaceID `graphql:"setSpaceDestroySchedule(space: $space, ttl: $ttl, schedule: $schedule)"`
+}
+
+type getDestroyScheduleQuery struct {
+	Response namespaceDestroySchedule `graphql:"space(id: $id)"`
+}
+
+// namespaceDestroySchedule is the destroy schedule of a namespace. DestroyTtl is expressed in seconds and DestroyAt in RFC3339
+type namespaceDestroySchedule struct {
+	DestroySchedule graphql.String
+	DestroyAt       graphql.String
+	DestroyTtl      graphql.Int
+}
+
 type namespaceStatus struct {
 	Id     graphql.String
 	Status graphql.String
@@ -76,17 +98,45 @@ type namespaceID struct {
 	Id graphql.String
 }
 
+type createNamespaceVariables struct {
+	Name graphql.String `graphql:"name"`
+}
+
+type addMembersVariables struct {
+	ID      graphql.String   `graphql:"id"`
+	Members []graphql.String `graphql:"members"`
+}
+
+type namespaceDestroyAllVariables struct {
+	ID             graphql.String  `graphql:"id"`
+	IncludeVolumes graphql.Boolean `graphql:"includeVolumes"`
+}
+
+type setDestroyScheduleVariables struct {
+	Space    graphql.String `graphql:"space"`
+	TTL      graphql.Int    `graphql:"ttl"`
+	Schedule graphql.String `graphql:"schedule"`
+}
+
+var (
+	createNamespaceOperation     = newMutation[createNamespaceVariables, createNamespaceMutation]("createSpace")
+	listNamespacesOperation      = newQuery[noVariables, listNamespacesQuery]("spaces")
+	addMembersOperation          = newMutation[addMembersVariables, addMembersMutation]("updateSpace")
+	deleteNamespaceOperation     = newMutation[idVariables, deleteNamespaceMutation]("deleteSpace")
+	sleepNamespaceOperation      = newMutation[spaceVariables, sleepNamespaceMutation]("sleepSpace")
+	namespaceDestroyAllOperation = newMutation[namespaceDestroyAllVariables, namespaceDestroyAllMutation]("destroyAllInSpace")
+	wakeNamespaceOperation       = newMutation[spaceVariables, wakeNamespaceMutation]("wakeSpace")
+	setDestroyScheduleOperation  = newMutation[setDestroyScheduleVariables, setDestroyScheduleMutation]("setSpaceDestroySchedule")
+	getDestroyScheduleOperation  = newQuery[idVariables, getDestroyScheduleQuery]("space")
+)
+
 func newNamespaceClient(client graphqlClientInterface) *namespaceClient {
 	return &namespaceClient{client: client}
 }
 
 // Create creates a namespace
 func (c *namespaceClient) Create(ctx context.Context, namespace string) (string, error) {
-	var mutation createNamespaceMutation
-	variables := map[string]interface{}{
-		"name": graphql.String(namespace),
-	}
-	err := mutate(ctx, &mutation, variables, c.client)
+	mutation, err := createNamespaceOperation.Do(ctx, c.client, createNamespaceVariables{Name: graphql.String(namespace)})
 	if err != nil {
 		return "", err
 	}
@@ -96,9 +146,7 @@ func (c *namespaceClient) Create(ctx context.Context, namespace string) (string,
 
 // List lists the namespaces
 func (c *namespaceClient) List(ctx context.Context) ([]types.Namespace, error) {
-	var queryStruct listNamespacesQuery
-
-	err := query(ctx, &queryStruct, nil, c.client)
+	queryStruct, err := listNamespacesOperation.Do(ctx, c.client, noVariables{})
 	if err != nil {
 		return nil, err
 	}
@@ -116,17 +164,11 @@ func (c *namespaceClient) List(ctx context.Context) ([]types.Namespace, error) {
 
 // AddMembers adds members to a namespace
 func (c *namespaceClient) AddMembers(ctx context.Context, namespace string, members []string) error {
-	var mutation addMembersMutation
-
 	membersVariable := make([]graphql.String, 0)
 	for _, m := range members {
 		membersVariable = append(membersVariable, graphql.String(m))
 	}
-	variables := map[string]interface{}{
-		"id":      graphql.String(namespace),
-		"members": membersVariable,
-	}
-	err := mutate(ctx, &mutation, variables, c.client)
+	_, err := addMembersOperation.Do(ctx, c.client, addMembersVariables{ID: graphql.String(namespace), Members: membersVariable})
 	if err != nil {
 		return err
 	}
@@ -136,11 +178,7 @@ func (c *namespaceClient) AddMembers(ctx context.Context, namespace string, memb
 
 // Delete deletes a namespace
 func (c *namespaceClient) Delete(ctx context.Context, namespace string) error {
-	var mutation deleteNamespaceMutation
-	variables := map[string]interface{}{
-		"id": graphql.String(namespace),
-	}
-	err := mutate(ctx, &mutation, variables, c.client)
+	_, err := deleteNamespaceOperation.Do(ctx, c.client, idVariables{ID: graphql.String(namespace)})
 	if err != nil {
 		return err
 	}
@@ -150,11 +188,7 @@ func (c *namespaceClient) Delete(ctx context.Context, namespace string) error {
 
 // Sleep sleeps a namespace
 func (c *namespaceClient) Sleep(ctx context.Context, namespace string) error {
-	var mutation sleepNamespaceMutation
-	variables := map[string]interface{}{
-		"space": graphql.String(namespace),
-	}
-	err := mutate(ctx, &mutation, variables, c.client)
+	_, err := sleepNamespaceOperation.Do(ctx, c.client, spaceVariables{Space: graphql.String(namespace)})
 	if err != nil {
 		return err
 	}
@@ -164,13 +198,12 @@ func (c *namespaceClient) Sleep(ctx context.Context, namespace string) error {
 
 // DestroyAll deletes a namespace
 func (c *namespaceClient) DestroyAll(ctx context.Context, namespace string, destroyVolumes bool) error {
-	var mutation namespaceDestroyAllMutation
 	// includingVolumes so everything is cleaned up by default with this cmd
-	variables := map[string]interface{}{
-		"id":             graphql.String(namespace),
-		"includeVolumes": graphql.Boolean(destroyVolumes),
+	variables := namespaceDestroyAllVariables{
+		ID:             graphql.String(namespace),
+		IncludeVolumes: graphql.Boolean(destroyVolumes),
 	}
-	err := mutate(ctx, &mutation, variables, c.client)
+	_, err := namespaceDestroyAllOperation.Do(ctx, c.client, variables)
 	if err != nil {
 		return err
 	}
@@ -180,14 +213,53 @@ func (c *namespaceClient) DestroyAll(ctx context.Context, namespace string, dest
 
 // Wake wakes a namespace
 func (c *namespaceClient) Wake(ctx context.Context, namespace string) error {
-	var mutation wakeNamespaceMutation
-	variables := map[string]interface{}{
-		"space": graphql.String(namespace),
-	}
-	err := mutate(ctx, &mutation, variables, c.client)
+	_, err := wakeNamespaceOperation.Do(ctx, c.client, spaceVariables{Space: graphql.String(namespace)})
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// SetDestroySchedule sets when the garbage collector of Okteto destroys a namespace. An empty schedule removes it
+func (c *namespaceClient) SetDestroySchedule(ctx context.Context, namespace string, schedule types.DestroySchedule) error {
+	variables := setDestroyScheduleVariables{
+		Space:    graphql.String(namespace),
+		TTL:      graphql.Int(int(schedule.TTL.Seconds())),
+		Schedule: graphql.String(schedule.Schedule),
+	}
+	_, err := setDestroyScheduleOperation.Do(ctx, c.client, variables)
+	if err != nil {
+		return translateDestroyScheduleErr(err)
+	}
+
+	return nil
+}
+
+// GetDestroySchedule returns when the garbage collector of Okteto destroys a namespace
+func (c *namespaceClient) GetDestroySchedule(ctx context.Context, namespace string) (*types.DestroySchedule, error) {
+	queryStruct, err := getDestroyScheduleOperation.Do(ctx, c.client, idVariables{ID: graphql.String(namespace)})
+	if err != nil {
+		return nil, translateDestroyScheduleErr(err)
+	}
+
+	result := &types.DestroySchedule{
+		Schedule: string(queryStruct.Response.DestroySchedule),
+		TTL:      time.Duration(queryStruct.Response.DestroyTtl) * time.Second,
+	}
+	if queryStruct.Response.DestroyAt != "" {
+		destroyAt, err := time.Parse(time.RFC3339, string(queryStruct.Response.DestroyAt))
+		if err != nil {
+			return nil, fmt.Errorf("invalid destroy time '%s': %w", queryStruct.Response.DestroyAt, err)
+		}
+		result.DestroyAt = &destroyAt
+	}
+	return result, nil
+}
+
+func translateDestroyScheduleErr(err error) error {
+	if strings.Contains(err.Error(), "Cannot query field") || strings.Contains(err.Error(), "Unknown argument") {
+		return oktetoErrors.UserError{E: ErrDestroyScheduleNotSupported, Hint: "Please upgrade to the latest version or ask your administrator"}
+	}
+	return err
+}
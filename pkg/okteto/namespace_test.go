@@ -15,7 +15,9 @@ package okteto
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/stretchr/testify/assert"
@@ -409,3 +411,117 @@ func TestSleepNamespace(t *testing.T) {
 		})
 	}
 }
+
+func TestSetDestroySchedule(t *testing.T) {
+	testCases := []struct {
+		expectedErr error
+		client      *fakeGraphQLClient
+		name        string
+	}{
+		{
+			name: "error in graphql",
+			client: &fakeGraphQLClient{
+				err: assert.AnError,
+			},
+			expectedErr: assert.AnError,
+		},
+		{
+			name: "not supported",
+			client: &fakeGraphQLClient{
+				err: errors.New(`Cannot query field "setSpaceDestroySchedule" on type "Mutation"`),
+			},
+			expectedErr: ErrDestroyScheduleNotSupported,
+		},
+		{
+			name: "success",
+			client: &fakeGraphQLClient{
+				mutationResult: &setDestroyScheduleMutation{
+					Response: namespaceID{
+						Id: "test",
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			nc := &namespaceClient{
+				client: tc.client,
+			}
+			err := nc.SetDestroySchedule(context.Background(), "test", types.DestroySchedule{TTL: 72 * time.Hour})
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestGetDestroySchedule(t *testing.T) {
+	destroyAt := time.Date(2023, 10, 18, 10, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		expectedErr error
+		client      *fakeGraphQLClient
+		expected    *types.DestroySchedule
+		name        string
+	}{
+		{
+			name: "error in graphql",
+			client: &fakeGraphQLClient{
+				err: assert.AnError,
+			},
+			expectedErr: assert.AnError,
+		},
+		{
+			name: "not supported",
+			client: &fakeGraphQLClient{
+				err: errors.New(`Cannot query field "destroyTtl" on type "Space"`),
+			},
+			expectedErr: ErrDestroyScheduleNotSupported,
+		},
+		{
+			name: "no schedule",
+			client: &fakeGraphQLClient{
+				queryResult: &getDestroyScheduleQuery{},
+			},
+			expected: &types.DestroySchedule{},
+		},
+		{
+			name: "ttl",
+			client: &fakeGraphQLClient{
+				queryResult: &getDestroyScheduleQuery{
+					Response: namespaceDestroySchedule{
+						DestroyTtl: 259200,
+						DestroyAt:  "2023-10-18T10:00:00Z",
+					},
+				},
+			},
+			expected: &types.DestroySchedule{
+				TTL:       72 * time.Hour,
+				DestroyAt: &destroyAt,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			nc := &namespaceClient{
+				client: tc.client,
+			}
+			schedule, err := nc.GetDestroySchedule(context.Background(), "test")
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expected, schedule)
+		})
+	}
+}
+
+func TestGetDestroyScheduleInvalidDestroyTime(t *testing.T) {
+	nc := &namespaceClient{
+		client: &fakeGraphQLClient{
+			queryResult: &getDestroyScheduleQuery{
+				Response: namespaceDestroySchedule{
+					DestroySchedule: "@daily",
+					DestroyAt:       "tomorrow",
+				},
+			},
+		},
+	}
+	_, err := nc.GetDestroySchedule(context.Background(), "test")
+	assert.ErrorContains(t, err, "invalid destroy time 'tomorrow'")
+}
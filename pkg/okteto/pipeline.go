@@ -23,6 +23,7 @@ import (
 	"github.com/okteto/okteto/pkg/config"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/repository"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/shurcooL/graphql"
 )
@@ -60,6 +61,22 @@ type deployPipelineMutationWithLabels struct {
 	Response deployPipelineResponse `graphql:"deployGitRepository(name: $name, repository: $repository, space: $space, branch: $branch, variables: $variables, filename: $filename, labels: $labels)"`
 }
 
+type deployPipelineMutationWithCommit struct {
+	Response deployPipelineResponse `graphql:"deployGitRepository(name: $name, repository: $repository, space: $space, branch: $branch, variables: $variables, filename: $filename, commit: $commit)"`
+}
+
+type deployPipelineMutationWithLabelsAndCommit struct {
+	Response deployPipelineResponse `graphql:"deployGitRepository(name: $name, repository: $repository, space: $space, branch: $branch, variables: $variables, filename: $filename, labels: $labels, commit: $commit)"`
+}
+
+// CommitInput is the commit an environment is deployed from, shown by the UI
+type CommitInput struct {
+	Sha       graphql.String `json:"sha"`
+	Author    graphql.String `json:"author"`
+	Message   graphql.String `json:"message"`
+	Timestamp graphql.String `json:"timestamp"`
+}
+
 type getPipelineByNameQuery struct {
 	Response getPipelineByNameResponse `graphql:"space(id: $id)"`
 }
@@ -80,6 +97,14 @@ type deprecatedDestroyPipelineWithoutVolumesMutation struct {
 	Response deprecatedDestroyPipelineResponse `graphql:"destroyGitRepository(name: $name, space: $space)"`
 }
 
+type getPipelineLastActionQuery struct {
+	Response getPipelineLastActionResponse `graphql:"space(id: $id)"`
+}
+
+type getPipelineLastActionWithoutActorQuery struct {
+	Response getPipelineLastActionWithoutActorResponse `graphql:"space(id: $id)"`
+}
+
 type getPipelineResources struct {
 	Response previewResourcesStatus `graphql:"space(id: $id)"`
 }
@@ -100,6 +125,31 @@ type getPipelineByNameResponse struct {
 	GitDeploys []gitDeployInfoIdNameStatus
 }
 
+type getPipelineLastActionResponse struct {
+	GitDeploys []gitDeployInfoWithLastAction
+}
+
+type gitDeployInfoWithLastAction struct {
+	Name   graphql.String
+	Action actionWithActorStruct
+}
+
+type getPipelineLastActionWithoutActorResponse struct {
+	GitDeploys []gitDeployInfoWithLastActionWithoutActor
+}
+
+type gitDeployInfoWithLastActionWithoutActor struct {
+	Name   graphql.String
+	Action actionStruct
+}
+
+type actionWithActorStruct struct {
+	Id     graphql.String
+	Name   graphql.String
+	Status graphql.String
+	Actor  graphql.String
+}
+
 type gitDeployInfoIdNameStatus struct {
 	Id     graphql.String
 	Name   graphql.String
@@ -115,30 +165,74 @@ type deprecatedDestroyPipelineResponse struct {
 	GitDeploy gitDeployInfoWithRepoInfo
 }
 
+type deployPipelineVariables struct {
+	Name       graphql.String  `graphql:"name"`
+	Repository graphql.String  `graphql:"repository"`
+	Space      graphql.String  `graphql:"space"`
+	Branch     graphql.String  `graphql:"branch"`
+	Variables  []InputVariable `graphql:"variables"`
+	Filename   graphql.String  `graphql:"filename"`
+}
+
+type deployPipelineWithLabelsVariables struct {
+	deployPipelineVariables
+	Labels labelList `graphql:"labels"`
+}
+
+type deployPipelineWithCommitVariables struct {
+	deployPipelineVariables
+	Commit *CommitInput `graphql:"commit"`
+}
+
+// deployPipelineWithLabelsAndCommitVariables are the variables of all the deploy mutations.
+// The labels and the commit are only sent when they are set
+type deployPipelineWithLabelsAndCommitVariables struct {
+	deployPipelineVariables
+	Labels labelList    `graphql:"labels"`
+	Commit *CommitInput `graphql:"commit"`
+}
+
+type destroyPipelineVariables struct {
+	Name  graphql.String `graphql:"name"`
+	Space graphql.String `graphql:"space"`
+}
+
+type destroyPipelineWithVolumesVariables struct {
+	destroyPipelineVariables
+	DestroyVolumes graphql.Boolean `graphql:"destroyVolumes"`
+}
+
+var (
+	deployPipelineOperation                          = newMutation[deployPipelineVariables, deployPipelineMutation]("deployGitRepository")
+	deployPipelineWithLabelsOperation                = newMutation[deployPipelineWithLabelsVariables, deployPipelineMutationWithLabels]("deployGitRepository")
+	deployPipelineWithCommitOperation                = newMutation[deployPipelineWithCommitVariables, deployPipelineMutationWithCommit]("deployGitRepository")
+	deployPipelineWithLabelsAndCommitOperation       = newMutation[deployPipelineWithLabelsAndCommitVariables, deployPipelineMutationWithLabelsAndCommit]("deployGitRepository")
+	getPipelineByNameOperation                       = newQuery[idVariables, getPipelineByNameQuery]("space")
+	getPipelineLastActionOperation                   = newQuery[idVariables, getPipelineLastActionQuery]("space")
+	getPipelineLastActionWithoutActorOperation       = newQuery[idVariables, getPipelineLastActionWithoutActorQuery]("space")
+	getPipelineResourcesOperation                    = newQuery[idVariables, getPipelineResources]("space")
+	destroyPipelineWithVolumesOperation              = newMutation[destroyPipelineWithVolumesVariables, destroyPipelineWithVolumesMutation]("destroyGitRepository")
+	destroyPipelineWithoutVolumesOperation           = newMutation[destroyPipelineVariables, destroyPipelineWithoutVolumesMutation]("destroyGitRepository")
+	deprecatedDestroyPipelineWithVolumesOperation    = newMutation[destroyPipelineWithVolumesVariables, deprecatedDestroyPipelineWithVolumesMutation]("destroyGitRepository")
+	deprecatedDestroyPipelineWithoutVolumesOperation = newMutation[destroyPipelineVariables, deprecatedDestroyPipelineWithoutVolumesMutation]("destroyGitRepository")
+)
+
 // Deploy creates a pipeline
 func (c *pipelineClient) Deploy(ctx context.Context, opts types.PipelineDeployOptions) (*types.GitDeployResponse, error) {
 	oktetoLog.Infof("deploying pipeline '%s' mutation on %s", opts.Name, opts.Namespace)
 
 	mutationVariables := c.getDeployVariables(opts)
-	var response deployPipelineResponse
-	if len(opts.Labels) == 0 {
-		mutationStruct := &deployPipelineMutation{}
-		err := mutate(ctx, mutationStruct, mutationVariables, c.client)
-		if err != nil {
-			return nil, fmt.Errorf("failed to deploy pipeline: %w", err)
-		}
-		response = mutationStruct.Response
-	} else {
-		mutationStruct := &deployPipelineMutationWithLabels{}
-		err := mutate(ctx, mutationStruct, mutationVariables, c.client)
-
-		if err != nil {
-			if strings.Contains(err.Error(), "Unknown argument \"labels\" on field \"deployGitRepository\" of type \"Mutation\"") {
-				return nil, oktetoErrors.UserError{E: ErrDeployPipelineLabelsFeatureNotSupported, Hint: "Please upgrade to the latest version or ask your administrator"}
-			}
-			return nil, fmt.Errorf("failed to deploy pipeline: %w", err)
+	response, err := c.deploy(ctx, mutationVariables)
+	if err != nil && isCommitNotSupportedError(err) {
+		oktetoLog.Infof("the okteto instance doesn't support the commit of the deploy: %s", err)
+		mutationVariables.Commit = nil
+		response, err = c.deploy(ctx, mutationVariables)
+	}
+	if err != nil {
+		if strings.Contains(err.Error(), "Unknown argument \"labels\" on field \"deployGitRepository\" of type \"Mutation\"") {
+			return nil, oktetoErrors.UserError{E: ErrDeployPipelineLabelsFeatureNotSupported, Hint: "Please upgrade to the latest version or ask your administrator"}
 		}
-		response = mutationStruct.Response
+		return nil, fmt.Errorf("failed to deploy pipeline: %w", err)
 	}
 
 	gitDeployResponse := &types.GitDeployResponse{
@@ -157,7 +251,45 @@ func (c *pipelineClient) Deploy(ctx context.Context, opts types.PipelineDeployOp
 	return gitDeployResponse, nil
 }
 
-func (c *pipelineClient) getDeployVariables(opts types.PipelineDeployOptions) map[string]interface{} {
+// deploy runs the deploy mutation with the arguments of the variables
+func (c *pipelineClient) deploy(ctx context.Context, variables deployPipelineWithLabelsAndCommitVariables) (deployPipelineResponse, error) {
+	hasLabels := len(variables.Labels) > 0
+	hasCommit := variables.Commit != nil
+	switch {
+	case hasLabels && hasCommit:
+		mutationStruct, err := deployPipelineWithLabelsAndCommitOperation.Do(ctx, c.client, variables)
+		if err != nil {
+			return deployPipelineResponse{}, err
+		}
+		return mutationStruct.Response, nil
+	case hasLabels:
+		mutationStruct, err := deployPipelineWithLabelsOperation.Do(ctx, c.client, deployPipelineWithLabelsVariables{
+			deployPipelineVariables: variables.deployPipelineVariables,
+			Labels:                  variables.Labels,
+		})
+		if err != nil {
+			return deployPipelineResponse{}, err
+		}
+		return mutationStruct.Response, nil
+	case hasCommit:
+		mutationStruct, err := deployPipelineWithCommitOperation.Do(ctx, c.client, deployPipelineWithCommitVariables{
+			deployPipelineVariables: variables.deployPipelineVariables,
+			Commit:                  variables.Commit,
+		})
+		if err != nil {
+			return deployPipelineResponse{}, err
+		}
+		return mutationStruct.Response, nil
+	default:
+		mutationStruct, err := deployPipelineOperation.Do(ctx, c.client, variables.deployPipelineVariables)
+		if err != nil {
+			return deployPipelineResponse{}, err
+		}
+		return mutationStruct.Response, nil
+	}
+}
+
+func (c *pipelineClient) getDeployVariables(opts types.PipelineDeployOptions) deployPipelineWithLabelsAndCommitVariables {
 	variablesVariable := make([]InputVariable, 0)
 	for _, v := range opts.Variables {
 		variablesVariable = append(variablesVariable, InputVariable{
@@ -178,13 +310,15 @@ func (c *pipelineClient) getDeployVariables(opts types.PipelineDeployOptions) ma
 			Value: graphql.String(origin),
 		})
 	}
-	vars := map[string]interface{}{
-		"name":       graphql.String(opts.Name),
-		"space":      graphql.String(opts.Namespace),
-		"repository": graphql.String(opts.Repository),
-		"branch":     graphql.String(opts.Branch),
-		"variables":  variablesVariable,
-		"filename":   graphql.String(opts.Filename),
+	vars := deployPipelineWithLabelsAndCommitVariables{
+		deployPipelineVariables: deployPipelineVariables{
+			Name:       graphql.String(opts.Name),
+			Space:      graphql.String(opts.Namespace),
+			Repository: graphql.String(opts.Repository),
+			Branch:     graphql.String(opts.Branch),
+			Variables:  variablesVariable,
+			Filename:   graphql.String(opts.Filename),
+		},
 	}
 
 	if len(opts.Labels) > 0 {
@@ -192,19 +326,36 @@ func (c *pipelineClient) getDeployVariables(opts types.PipelineDeployOptions) ma
 		for _, l := range opts.Labels {
 			labelsVariable = append(labelsVariable, graphql.String(l))
 		}
-		vars["labels"] = labelsVariable
+		vars.Labels = labelsVariable
+	}
+	if opts.Commit != nil {
+		vars.Commit = newCommitInput(opts.Commit)
 	}
 	return vars
 }
 
+// newCommitInput returns the commit argument of the deploy mutations
+func newCommitInput(commit *repository.CommitInfo) *CommitInput {
+	input := &CommitInput{
+		Sha:     graphql.String(commit.Sha),
+		Author:  graphql.String(commit.Author),
+		Message: graphql.String(commit.Message),
+	}
+	if !commit.Timestamp.IsZero() {
+		input.Timestamp = graphql.String(commit.Timestamp.UTC().Format(time.RFC3339))
+	}
+	return input
+}
+
+// isCommitNotSupportedError returns if the deploy failed because the okteto instance doesn't support the commit argument
+func isCommitNotSupportedError(err error) bool {
+	return strings.Contains(err.Error(), "Unknown argument \"commit\"") || strings.Contains(err.Error(), "Unknown type \"CommitInput\"")
+}
+
 // GetByName gets a pipeline given its name
 func (c *pipelineClient) GetByName(ctx context.Context, name, namespace string) (*types.GitDeploy, error) {
 	oktetoLog.Infof("getting pipeline '%s' in namespace '%s'", name, namespace)
-	var queryStruct getPipelineByNameQuery
-	variables := map[string]interface{}{
-		"id": graphql.String(namespace),
-	}
-	err := query(ctx, &queryStruct, variables, c.client)
+	queryStruct, err := getPipelineByNameOperation.Do(ctx, c.client, idVariables{ID: graphql.String(namespace)})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get pipeline: %w", err)
 	}
@@ -221,19 +372,99 @@ func (c *pipelineClient) GetByName(ctx context.Context, name, namespace string)
 	return nil, oktetoErrors.ErrNotFound
 }
 
+// GetStatus returns the deploy status of a pipeline and the status of its resources
+func (c *pipelineClient) GetStatus(ctx context.Context, name, namespace string) (*types.PipelineStatus, error) {
+	gitDeploy, err := c.GetByName(ctx, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	resources, err := c.GetResourcesStatus(ctx, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.PipelineStatus{
+		Name:      gitDeploy.Name,
+		Status:    gitDeploy.Status,
+		Resources: resources,
+	}, nil
+}
+
+// GetLastAction returns the last action run on a pipeline and the user that triggered it.
+// The actor is empty if the okteto instance doesn't expose it, and it returns nil if the okteto instance doesn't expose the actions of the pipelines
+func (c *pipelineClient) GetLastAction(ctx context.Context, name, namespace string) (*types.Action, error) {
+	oktetoLog.Infof("getting last action of pipeline '%s' in namespace '%s'", name, namespace)
+	queryStruct, err := getPipelineLastActionOperation.Do(ctx, c.client, idVariables{ID: graphql.String(namespace)})
+	if err != nil {
+		if strings.Contains(err.Error(), "Cannot query field \"actor\"") {
+			oktetoLog.Infof("actor of actions not supported: %s", err)
+			return c.getLastActionWithoutActor(ctx, name, namespace)
+		}
+		if strings.Contains(err.Error(), "Cannot query field") {
+			oktetoLog.Infof("last action of pipelines not supported: %s", err)
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get last action of pipeline: %w", err)
+	}
+
+	for _, gitDeploy := range queryStruct.Response.GitDeploys {
+		if string(gitDeploy.Name) != name {
+			continue
+		}
+		if gitDeploy.Action.Name == "" {
+			return nil, nil
+		}
+		return &types.Action{
+			ID:     string(gitDeploy.Action.Id),
+			Name:   string(gitDeploy.Action.Name),
+			Status: string(gitDeploy.Action.Status),
+			Actor:  string(gitDeploy.Action.Actor),
+		}, nil
+	}
+	return nil, oktetoErrors.ErrNotFound
+}
+
+// getLastActionWithoutActor returns the last action run on a pipeline for okteto instances that don't expose the actor of the actions
+func (c *pipelineClient) getLastActionWithoutActor(ctx context.Context, name, namespace string) (*types.Action, error) {
+	queryStruct, err := getPipelineLastActionWithoutActorOperation.Do(ctx, c.client, idVariables{ID: graphql.String(namespace)})
+	if err != nil {
+		if strings.Contains(err.Error(), "Cannot query field") {
+			oktetoLog.Infof("last action of pipelines not supported: %s", err)
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get last action of pipeline: %w", err)
+	}
+
+	for _, gitDeploy := range queryStruct.Response.GitDeploys {
+		if string(gitDeploy.Name) != name {
+			continue
+		}
+		if gitDeploy.Action.Name == "" {
+			return nil, nil
+		}
+		return &types.Action{
+			ID:     string(gitDeploy.Action.Id),
+			Name:   string(gitDeploy.Action.Name),
+			Status: string(gitDeploy.Action.Status),
+		}, nil
+	}
+	return nil, oktetoErrors.ErrNotFound
+}
+
 // Destroy destroys a pipeline
 func (c *pipelineClient) Destroy(ctx context.Context, name, namespace string, destroyVolumes bool) (*types.GitDeployResponse, error) {
 	oktetoLog.Infof("destroy pipeline: %s/%s", namespace, name)
 	gitDeployResponse := &types.GitDeployResponse{}
 	if destroyVolumes {
-		var mutation destroyPipelineWithVolumesMutation
-
-		queryVariables := map[string]interface{}{
-			"name":           graphql.String(name),
-			"destroyVolumes": graphql.Boolean(destroyVolumes),
-			"space":          graphql.String(namespace),
+		queryVariables := destroyPipelineWithVolumesVariables{
+			destroyPipelineVariables: destroyPipelineVariables{
+				Name:  graphql.String(name),
+				Space: graphql.String(namespace),
+			},
+			DestroyVolumes: graphql.Boolean(destroyVolumes),
 		}
-		err := mutate(ctx, &mutation, queryVariables, c.client)
+		mutation, err := destroyPipelineWithVolumesOperation.Do(ctx, c.client, queryVariables)
 		if err != nil {
 			if strings.Contains(err.Error(), "Cannot query field \"action\" on type \"GitDeploy\"") {
 				return c.deprecatedDestroy(ctx, name, namespace, destroyVolumes)
@@ -252,12 +483,11 @@ func (c *pipelineClient) Destroy(ctx context.Context, name, namespace string, de
 			Status:     string(mutation.Response.GitDeploy.Status),
 		}
 	} else {
-		var mutation destroyPipelineWithoutVolumesMutation
-		queryVariables := map[string]interface{}{
-			"name":  graphql.String(name),
-			"space": graphql.String(Context().Namespace),
+		queryVariables := destroyPipelineVariables{
+			Name:  graphql.String(name),
+			Space: graphql.String(Context().Namespace),
 		}
-		err := mutate(ctx, &mutation, queryVariables, c.client)
+		mutation, err := destroyPipelineWithoutVolumesOperation.Do(ctx, c.client, queryVariables)
 		if err != nil {
 			if strings.Contains(err.Error(), "Cannot query field \"action\" on type \"GitDeploy\"") {
 				return c.deprecatedDestroy(ctx, name, namespace, destroyVolumes)
@@ -286,14 +516,14 @@ func (c *pipelineClient) deprecatedDestroy(ctx context.Context, name, namespace
 	oktetoLog.Infof("destroy pipeline: %s/%s", namespace, name)
 	gitDeployResponse := &types.GitDeployResponse{}
 	if destroyVolumes {
-		var mutation deprecatedDestroyPipelineWithVolumesMutation
-
-		queryVariables := map[string]interface{}{
-			"name":           graphql.String(name),
-			"destroyVolumes": graphql.Boolean(destroyVolumes),
-			"space":          graphql.String(namespace),
+		queryVariables := destroyPipelineWithVolumesVariables{
+			destroyPipelineVariables: destroyPipelineVariables{
+				Name:  graphql.String(name),
+				Space: graphql.String(namespace),
+			},
+			DestroyVolumes: graphql.Boolean(destroyVolumes),
 		}
-		err := mutate(ctx, &mutation, queryVariables, c.client)
+		mutation, err := deprecatedDestroyPipelineWithVolumesOperation.Do(ctx, c.client, queryVariables)
 		if err != nil {
 			return nil, fmt.Errorf("failed to deploy pipeline: %w", err)
 		}
@@ -302,12 +532,11 @@ func (c *pipelineClient) deprecatedDestroy(ctx context.Context, name, namespace
 			Status: string(mutation.Response.GitDeploy.Status),
 		}
 	} else {
-		var mutation deprecatedDestroyPipelineWithoutVolumesMutation
-		queryVariables := map[string]interface{}{
-			"name":  graphql.String(name),
-			"space": graphql.String(namespace),
+		queryVariables := destroyPipelineVariables{
+			Name:  graphql.String(name),
+			Space: graphql.String(namespace),
 		}
-		err := mutate(ctx, &mutation, queryVariables, c.client)
+		mutation, err := deprecatedDestroyPipelineWithoutVolumesOperation.Do(ctx, c.client, queryVariables)
 		if err != nil {
 			return nil, fmt.Errorf("failed to deploy pipeline: %w", err)
 		}
@@ -324,12 +553,8 @@ func (c *pipelineClient) deprecatedDestroy(ctx context.Context, name, namespace
 // GetResourcesStatus returns the status of deployments statefulsets and jobs
 func (c *pipelineClient) GetResourcesStatus(ctx context.Context, name, namespace string) (map[string]string, error) {
 	oktetoLog.Infof("get resource status started for pipeline: %s/%s", namespace, name)
-	var queryStruct getPipelineResources
-	variables := map[string]interface{}{
-		"id": graphql.String(namespace),
-	}
-
-	if err := query(ctx, &queryStruct, variables, c.client); err != nil {
+	queryStruct, err := getPipelineResourcesOperation.Do(ctx, c.client, idVariables{ID: graphql.String(namespace)})
+	if err != nil {
 		if oktetoErrors.IsNotFound(err) {
 			okClient, err := NewOktetoClientFromUrlAndToken(c.url, Context().Token)
 			if err != nil {
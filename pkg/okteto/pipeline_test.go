@@ -18,8 +18,10 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/repository"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -251,6 +253,70 @@ func TestDeployPipeline(t *testing.T) {
 	}
 }
 
+func TestNewCommitInput(t *testing.T) {
+	commit := &repository.CommitInfo{
+		Sha:       "f1e2d3c4",
+		Author:    "Cindy Lopez",
+		Message:   "Add movies endpoint",
+		Timestamp: time.Date(2023, time.October, 10, 12, 0, 0, 0, time.FixedZone("CEST", 2*60*60)),
+	}
+	assert.Equal(t, &CommitInput{
+		Sha:       "f1e2d3c4",
+		Author:    "Cindy Lopez",
+		Message:   "Add movies endpoint",
+		Timestamp: "2023-10-10T10:00:00Z",
+	}, newCommitInput(commit))
+
+	assert.Equal(t, &CommitInput{Sha: "f1e2d3c4"}, newCommitInput(&repository.CommitInfo{Sha: "f1e2d3c4"}))
+}
+
+// fakeCommitGraphQLClient records if every mutation includes the commit argument
+type fakeCommitGraphQLClient struct {
+	fakeGraphQLMultipleCallsClient
+	withCommit []bool
+}
+
+func (fc *fakeCommitGraphQLClient) Mutate(ctx context.Context, m interface{}, vars map[string]interface{}) error {
+	_, ok := vars["commit"]
+	fc.withCommit = append(fc.withCommit, ok)
+	return fc.fakeGraphQLMultipleCallsClient.Mutate(ctx, m, vars)
+}
+
+func TestDeployPipelineWithCommit(t *testing.T) {
+	response := deployPipelineResponse{
+		Action:    actionStruct{Id: "test", Name: "test", Status: ProgressingStatus},
+		GitDeploy: gitDeployInfoWithRepoInfo{Id: "test", Name: "test", Status: ProgressingStatus, Repository: "my-repo"},
+	}
+	opts := types.PipelineDeployOptions{
+		Name:   "test",
+		Commit: &repository.CommitInfo{Sha: "f1e2d3c4", Author: "Cindy Lopez"},
+	}
+
+	client := &fakeCommitGraphQLClient{
+		fakeGraphQLMultipleCallsClient: fakeGraphQLMultipleCallsClient{
+			mutationResult: []interface{}{&deployPipelineMutationWithCommit{Response: response}},
+		},
+	}
+	pc := pipelineClient{client: client}
+	result, err := pc.Deploy(context.Background(), opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "test", result.GitDeploy.ID)
+	assert.Equal(t, []bool{true}, client.withCommit)
+
+	// okteto instances without the commit argument deploy without it
+	client = &fakeCommitGraphQLClient{
+		fakeGraphQLMultipleCallsClient: fakeGraphQLMultipleCallsClient{
+			errs:           []error{errors.New("Unknown argument \"commit\" on field \"deployGitRepository\" of type \"Mutation\""), nil},
+			mutationResult: []interface{}{nil, &deployPipelineMutation{Response: response}},
+		},
+	}
+	pc = pipelineClient{client: client}
+	result, err = pc.Deploy(context.Background(), opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "test", result.GitDeploy.ID)
+	assert.Equal(t, []bool{true, false}, client.withCommit)
+}
+
 func TestGetPipelineByName(t *testing.T) {
 	type input struct {
 		client *fakeGraphQLClient
@@ -860,6 +926,186 @@ func TestGetPipelineResourcesStatus(t *testing.T) {
 	}
 }
 
+// fakeSequenceGraphQLClient answers each query with the next client of the sequence
+type fakeSequenceGraphQLClient struct {
+	clients []*fakeGraphQLClient
+	calls   int
+}
+
+func (fc *fakeSequenceGraphQLClient) Query(ctx context.Context, q interface{}, vars map[string]interface{}) error {
+	c := fc.clients[fc.calls]
+	fc.calls++
+	return c.Query(ctx, q, vars)
+}
+
+func (fc *fakeSequenceGraphQLClient) Mutate(ctx context.Context, m interface{}, vars map[string]interface{}) error {
+	return assert.AnError
+}
+
+func TestGetPipelineStatus(t *testing.T) {
+	byName := &fakeGraphQLClient{
+		queryResult: &getPipelineByNameQuery{
+			Response: getPipelineByNameResponse{
+				GitDeploys: []gitDeployInfoIdNameStatus{
+					{
+						Id:     "1",
+						Name:   "api",
+						Status: "deployed",
+					},
+				},
+			},
+		},
+	}
+	resources := &fakeGraphQLClient{
+		queryResult: &getPipelineResources{
+			Response: previewResourcesStatus{
+				Deployments: []resourceInfo{
+					{
+						Name:       "api",
+						Status:     "running",
+						DeployedBy: "api",
+					},
+					{
+						Name:       "frontend",
+						Status:     "running",
+						DeployedBy: "frontend",
+					},
+				},
+				Statefulsets: []resourceInfo{
+					{
+						Name:       "db",
+						Status:     "booting",
+						DeployedBy: "api",
+					},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		expected    *types.PipelineStatus
+		expectedErr error
+		name        string
+		clients     []*fakeGraphQLClient
+	}{
+		{
+			name:    "deployed",
+			clients: []*fakeGraphQLClient{byName, resources},
+			expected: &types.PipelineStatus{
+				Name:   "api",
+				Status: "deployed",
+				Resources: map[string]string{
+					"deployment/api": "running",
+					"statefulset/db": "booting",
+				},
+			},
+		},
+		{
+			name:        "not found",
+			clients:     []*fakeGraphQLClient{{queryResult: &getPipelineByNameQuery{}}},
+			expectedErr: oktetoErrors.ErrNotFound,
+		},
+		{
+			name:        "error getting resources",
+			clients:     []*fakeGraphQLClient{byName, {err: assert.AnError}},
+			expectedErr: assert.AnError,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pc := pipelineClient{
+				client: &fakeSequenceGraphQLClient{clients: tc.clients},
+			}
+			response, err := pc.GetStatus(context.Background(), "api", "test")
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expected, response)
+		})
+	}
+}
+
+func TestGetPipelineLastAction(t *testing.T) {
+	response := &getPipelineLastActionQuery{
+		Response: getPipelineLastActionResponse{
+			GitDeploys: []gitDeployInfoWithLastAction{
+				{
+					Name: "frontend",
+				},
+				{
+					Name: "api",
+					Action: actionWithActorStruct{
+						Id:     "1",
+						Name:   "cli",
+						Status: "success",
+						Actor:  "cindy",
+					},
+				},
+			},
+		},
+	}
+	testCases := []struct {
+		expected    *types.Action
+		expectedErr error
+		client      graphqlClientInterface
+		name        string
+		pipeline    string
+	}{
+		{
+			name:     "found",
+			pipeline: "api",
+			client:   &fakeGraphQLClient{queryResult: response},
+			expected: &types.Action{ID: "1", Name: "cli", Status: "success", Actor: "cindy"},
+		},
+		{
+			name:     "without actions",
+			pipeline: "frontend",
+			client:   &fakeGraphQLClient{queryResult: response},
+		},
+		{
+			name:        "not found",
+			pipeline:    "db",
+			client:      &fakeGraphQLClient{queryResult: response},
+			expectedErr: oktetoErrors.ErrNotFound,
+		},
+		{
+			name:     "not supported",
+			pipeline: "api",
+			client:   &fakeGraphQLClient{err: errors.New(`Cannot query field "action" on type "GitDeploy"`)},
+		},
+		{
+			name:     "actor not supported",
+			pipeline: "api",
+			client: &fakeGraphQLMultipleCallsClient{
+				errs: []error{errors.New(`Cannot query field "actor" on type "Action"`), nil},
+				queryResults: []interface{}{
+					nil,
+					&getPipelineLastActionWithoutActorQuery{
+						Response: getPipelineLastActionWithoutActorResponse{
+							GitDeploys: []gitDeployInfoWithLastActionWithoutActor{
+								{Name: "api", Action: actionStruct{Id: "1", Name: "cli", Status: "success"}},
+							},
+						},
+					},
+				},
+			},
+			expected: &types.Action{ID: "1", Name: "cli", Status: "success"},
+		},
+		{
+			name:        "error",
+			pipeline:    "api",
+			client:      &fakeGraphQLClient{err: assert.AnError},
+			expectedErr: assert.AnError,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pc := pipelineClient{client: tc.client}
+			action, err := pc.GetLastAction(context.Background(), tc.pipeline, "test")
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expected, action)
+		})
+	}
+}
+
 func Test_getResourceFullName(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -20,6 +20,8 @@ import (
 
 	"github.com/okteto/okteto/pkg/config"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/repository"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/shurcooL/graphql"
 )
@@ -29,9 +31,18 @@ var (
 
     Consider removing the "--label" flag, or please upgrade to the latest version.
 
+    For more information and upgrade instructions, please visit our docs at https://www.okteto.com/docs or contact your system administrator.`)
+
+	ErrPreviewFiltersNotSupported = fmt.Errorf(`Filtering and sorting preview environments requires a more recent version of Okteto.
+
+    Consider removing the "--owner", "--mine", "--branch", "--status", "--sort" and "--limit" flags, or please upgrade to the latest version.
+
     For more information and upgrade instructions, please visit our docs at https://www.okteto.com/docs or contact your system administrator.`)
 )
 
+// previewsPageSize is the number of preview environments requested on every page of a filtered list
+const previewsPageSize = 100
+
 type previewClient struct {
 	client             graphqlClientInterface
 	namespaceValidator namespaceValidator
@@ -65,16 +76,16 @@ type deployPreviewMutation struct {
 	Response deployPreviewResponse `graphql:"deployPreview(name: $name, scope: $scope, repository: $repository, branch: $branch, sourceUrl: $sourceURL, variables: $variables, filename: $filename)"`
 }
 
-func (d *deployPreviewMutation) response() deployPreviewResponse {
-	return d.Response
-}
-
 type deployPreviewMutationWithLabels struct {
 	Response deployPreviewResponse `graphql:"deployPreview(name: $name, scope: $scope, repository: $repository, branch: $branch, sourceUrl: $sourceURL, variables: $variables, filename: $filename, labels: $labels)"`
 }
 
-func (d *deployPreviewMutationWithLabels) response() deployPreviewResponse {
-	return d.Response
+type deployPreviewMutationWithCommit struct {
+	Response deployPreviewResponse `graphql:"deployPreview(name: $name, scope: $scope, repository: $repository, branch: $branch, sourceUrl: $sourceURL, variables: $variables, filename: $filename, commit: $commit)"`
+}
+
+type deployPreviewMutationWithLabelsAndCommit struct {
+	Response deployPreviewResponse `graphql:"deployPreview(name: $name, scope: $scope, repository: $repository, branch: $branch, sourceUrl: $sourceURL, variables: $variables, filename: $filename, labels: $labels, commit: $commit)"`
 }
 
 type destroyPreviewMutation struct {
@@ -85,6 +96,10 @@ type listPreviewQuery struct {
 	Response []previewEnv `graphql:"previews(labels: $labels)"`
 }
 
+type listPreviewQueryWithFilters struct {
+	Response []filteredPreviewEnv `graphql:"previews(labels: $labels, owner: $owner, branch: $branch, status: $status, sortBy: $sortBy, limit: $limit, offset: $offset)"`
+}
+
 type listPreviewQueryDeprecated struct {
 	Response []deprecatedPreviewEnv `graphql:"previews"`
 }
@@ -132,6 +147,16 @@ type endpointURL struct {
 	Url graphql.String
 }
 
+type filteredPreviewEnv struct {
+	Id            graphql.String
+	Scope         graphql.String
+	Owner         graphql.String
+	Branch        graphql.String
+	Status        graphql.String
+	PreviewLabels []graphql.String
+	Sleeping      graphql.Boolean
+}
+
 type deprecatedPreviewEnv struct {
 	Id       graphql.String
 	Scope    graphql.String
@@ -154,33 +179,79 @@ type previewIDStruct struct {
 	Id graphql.String
 }
 
+type deployPreviewVariables struct {
+	Name       graphql.String  `graphql:"name"`
+	Scope      PreviewScope    `graphql:"scope"`
+	Repository graphql.String  `graphql:"repository"`
+	Branch     graphql.String  `graphql:"branch"`
+	SourceURL  graphql.String  `graphql:"sourceURL"`
+	Variables  []InputVariable `graphql:"variables"`
+	Filename   graphql.String  `graphql:"filename"`
+}
+
+type deployPreviewWithLabelsVariables struct {
+	deployPreviewVariables
+	Labels labelList `graphql:"labels"`
+}
+
+type deployPreviewWithCommitVariables struct {
+	deployPreviewVariables
+	Commit *CommitInput `graphql:"commit"`
+}
+
+// deployPreviewWithLabelsAndCommitVariables are the variables of all the deploy mutations.
+// The labels and the commit are only sent when they are set
+type deployPreviewWithLabelsAndCommitVariables struct {
+	deployPreviewVariables
+	Labels labelList    `graphql:"labels"`
+	Commit *CommitInput `graphql:"commit"`
+}
+
+type listPreviewVariables struct {
+	Labels labelList `graphql:"labels"`
+}
+
+type listPreviewWithFiltersVariables struct {
+	Labels labelList      `graphql:"labels"`
+	Owner  graphql.String `graphql:"owner"`
+	Branch graphql.String `graphql:"branch"`
+	Status graphql.String `graphql:"status"`
+	SortBy graphql.String `graphql:"sortBy"`
+	Limit  graphql.Int    `graphql:"limit"`
+	Offset graphql.Int    `graphql:"offset"`
+}
+
+var (
+	deployPreviewOperation                    = newMutation[deployPreviewVariables, deployPreviewMutation]("deployPreview")
+	deployPreviewWithLabelsOperation          = newMutation[deployPreviewWithLabelsVariables, deployPreviewMutationWithLabels]("deployPreview")
+	deployPreviewWithCommitOperation          = newMutation[deployPreviewWithCommitVariables, deployPreviewMutationWithCommit]("deployPreview")
+	deployPreviewWithLabelsAndCommitOperation = newMutation[deployPreviewWithLabelsAndCommitVariables, deployPreviewMutationWithLabelsAndCommit]("deployPreview")
+	destroyPreviewOperation                   = newMutation[idVariables, destroyPreviewMutation]("destroyPreview")
+	listPreviewOperation                      = newQuery[listPreviewVariables, listPreviewQuery]("previews")
+	listPreviewWithFiltersOperation           = newQuery[listPreviewWithFiltersVariables, listPreviewQueryWithFilters]("previews")
+	deprecatedListPreviewOperation            = newQuery[noVariables, listPreviewQueryDeprecated]("previews")
+	listPreviewEndpointsOperation             = newQuery[idVariables, listPreviewEndpoints]("preview")
+	getPreviewResourcesOperation              = newQuery[idVariables, getPreviewResources]("preview")
+)
+
 // DeployPreview creates a preview environment
-func (c *previewClient) DeployPreview(ctx context.Context, name, scope, repository, branch, sourceUrl, filename string, variables []types.Variable, labels []string) (*types.PreviewResponse, error) {
+func (c *previewClient) DeployPreview(ctx context.Context, name, scope, repository, branch, sourceUrl, filename string, variables []types.Variable, labels []string, commit *repository.CommitInfo) (*types.PreviewResponse, error) {
 	if err := c.namespaceValidator.validate(name, previewEnvObject); err != nil {
 		return nil, err
 	}
 
-	mutationVariables := c.getDeployVariables(name, scope, repository, branch, sourceUrl, filename, variables, labels)
-	var response deployPreviewResponse
-	if len(labels) == 0 {
-		mutationStruct := &deployPreviewMutation{}
-		err := mutate(ctx, mutationStruct, mutationVariables, c.client)
-		if err != nil {
-			return nil, c.translateErr(err, name)
-		}
-		response = mutationStruct.response()
-	} else {
-		mutationStruct := &deployPreviewMutationWithLabels{}
-		err := mutate(ctx, mutationStruct, mutationVariables, c.client)
-
-		if err != nil {
-			if strings.Contains(err.Error(), "Unknown argument \"labels\" on field \"deployPreview\" of type \"Mutation\"") {
-				return nil, oktetoErrors.UserError{E: ErrLabelsFeatureNotSupported, Hint: "Please upgrade to the latest version or ask your administrator"}
-			}
-
-			return nil, c.translateErr(err, name)
+	mutationVariables := c.getDeployVariables(name, scope, repository, branch, sourceUrl, filename, variables, labels, commit)
+	response, err := c.deploy(ctx, mutationVariables)
+	if err != nil && isCommitNotSupportedError(err) {
+		oktetoLog.Infof("the okteto instance doesn't support the commit of the deploy: %s", err)
+		mutationVariables.Commit = nil
+		response, err = c.deploy(ctx, mutationVariables)
+	}
+	if err != nil {
+		if strings.Contains(err.Error(), "Unknown argument \"labels\" on field \"deployPreview\" of type \"Mutation\"") {
+			return nil, oktetoErrors.UserError{E: ErrLabelsFeatureNotSupported, Hint: "Please upgrade to the latest version or ask your administrator"}
 		}
-		response = mutationStruct.response()
+		return nil, c.translateErr(err, name)
 	}
 
 	previewResponse := &types.PreviewResponse{}
@@ -195,7 +266,45 @@ func (c *previewClient) DeployPreview(ctx context.Context, name, scope, reposito
 	return previewResponse, nil
 }
 
-func (*previewClient) getDeployVariables(name, scope, repository, branch, sourceUrl, filename string, variables []types.Variable, labels []string) map[string]interface{} {
+// deploy runs the deploy mutation with the arguments of the variables
+func (c *previewClient) deploy(ctx context.Context, variables deployPreviewWithLabelsAndCommitVariables) (deployPreviewResponse, error) {
+	hasLabels := len(variables.Labels) > 0
+	hasCommit := variables.Commit != nil
+	switch {
+	case hasLabels && hasCommit:
+		mutationStruct, err := deployPreviewWithLabelsAndCommitOperation.Do(ctx, c.client, variables)
+		if err != nil {
+			return deployPreviewResponse{}, err
+		}
+		return mutationStruct.Response, nil
+	case hasLabels:
+		mutationStruct, err := deployPreviewWithLabelsOperation.Do(ctx, c.client, deployPreviewWithLabelsVariables{
+			deployPreviewVariables: variables.deployPreviewVariables,
+			Labels:                 variables.Labels,
+		})
+		if err != nil {
+			return deployPreviewResponse{}, err
+		}
+		return mutationStruct.Response, nil
+	case hasCommit:
+		mutationStruct, err := deployPreviewWithCommitOperation.Do(ctx, c.client, deployPreviewWithCommitVariables{
+			deployPreviewVariables: variables.deployPreviewVariables,
+			Commit:                 variables.Commit,
+		})
+		if err != nil {
+			return deployPreviewResponse{}, err
+		}
+		return mutationStruct.Response, nil
+	default:
+		mutationStruct, err := deployPreviewOperation.Do(ctx, c.client, variables.deployPreviewVariables)
+		if err != nil {
+			return deployPreviewResponse{}, err
+		}
+		return mutationStruct.Response, nil
+	}
+}
+
+func (*previewClient) getDeployVariables(name, scope, repository, branch, sourceUrl, filename string, variables []types.Variable, labels []string, commit *repository.CommitInfo) deployPreviewWithLabelsAndCommitVariables {
 	variablesVariable := make([]InputVariable, 0)
 	for _, v := range variables {
 		variablesVariable = append(variablesVariable, InputVariable{
@@ -216,14 +325,16 @@ func (*previewClient) getDeployVariables(name, scope, repository, branch, source
 			Value: graphql.String(origin),
 		})
 	}
-	vars := map[string]interface{}{
-		"name":       graphql.String(name),
-		"scope":      PreviewScope(scope),
-		"repository": graphql.String(repository),
-		"branch":     graphql.String(branch),
-		"sourceURL":  graphql.String(sourceUrl),
-		"variables":  variablesVariable,
-		"filename":   graphql.String(filename),
+	vars := deployPreviewWithLabelsAndCommitVariables{
+		deployPreviewVariables: deployPreviewVariables{
+			Name:       graphql.String(name),
+			Scope:      PreviewScope(scope),
+			Repository: graphql.String(repository),
+			Branch:     graphql.String(branch),
+			SourceURL:  graphql.String(sourceUrl),
+			Variables:  variablesVariable,
+			Filename:   graphql.String(filename),
+		},
 	}
 
 	if len(labels) > 0 {
@@ -231,36 +342,29 @@ func (*previewClient) getDeployVariables(name, scope, repository, branch, source
 		for _, l := range labels {
 			labelsVariable = append(labelsVariable, graphql.String(l))
 		}
-		vars["labels"] = labelsVariable
+		vars.Labels = labelsVariable
+	}
+	if commit != nil {
+		vars.Commit = newCommitInput(commit)
 	}
 	return vars
 }
 
 // DestroyPreview destroy a preview environment
 func (c *previewClient) Destroy(ctx context.Context, name string) error {
-	mutationStruct := destroyPreviewMutation{}
-	variables := map[string]interface{}{
-		"id": graphql.String(name),
-	}
-
-	err := mutate(ctx, &mutationStruct, variables, c.client)
+	_, err := destroyPreviewOperation.Do(ctx, c.client, idVariables{ID: graphql.String(name)})
 	return err
 }
 
 // List lists preview environments
-func (c *previewClient) List(ctx context.Context, labels []string) ([]types.Preview, error) {
-	queryStruct := listPreviewQuery{}
-
-	variables := map[string]interface{}{}
-	labelsVariable := make(labelList, 0)
-	for _, l := range labels {
-		labelsVariable = append(labelsVariable, graphql.String(l))
+func (c *previewClient) List(ctx context.Context, opts types.PreviewListOptions) ([]types.Preview, error) {
+	if hasPreviewFilters(opts) {
+		return c.listWithFilters(ctx, opts)
 	}
-	variables["labels"] = labelsVariable
-	err := query(ctx, &queryStruct, variables, c.client)
+	queryStruct, err := listPreviewOperation.Do(ctx, c.client, listPreviewVariables{Labels: getLabelsVariable(opts.Labels)})
 	if err != nil {
 		if strings.Contains(err.Error(), "Unknown argument \"labels\" on field \"previews\" of type \"Query\"") {
-			if len(labels) > 0 {
+			if len(opts.Labels) > 0 {
 				return nil, oktetoErrors.UserError{E: ErrLabelsFeatureNotSupported, Hint: "Please upgrade to the latest version or ask your administrator"}
 			}
 			return c.deprecatedList(ctx)
@@ -285,10 +389,69 @@ func (c *previewClient) List(ctx context.Context, labels []string) ([]types.Prev
 	return result, nil
 }
 
+// hasPreviewFilters returns if the options require the server-side filtering and sorting of preview environments
+func hasPreviewFilters(opts types.PreviewListOptions) bool {
+	return opts.Owner != "" || opts.Branch != "" || opts.Status != "" || opts.SortBy != "" || opts.Limit > 0
+}
+
+func getLabelsVariable(labels []string) labelList {
+	result := make(labelList, 0)
+	for _, l := range labels {
+		result = append(result, graphql.String(l))
+	}
+	return result
+}
+
+// listWithFilters requests the preview environments page by page until the server has no more results or the limit is reached
+func (c *previewClient) listWithFilters(ctx context.Context, opts types.PreviewListOptions) ([]types.Preview, error) {
+	result := make([]types.Preview, 0)
+	for {
+		pageSize := previewsPageSize
+		if opts.Limit > 0 && opts.Limit-len(result) < pageSize {
+			pageSize = opts.Limit - len(result)
+		}
+		variables := listPreviewWithFiltersVariables{
+			Labels: getLabelsVariable(opts.Labels),
+			Owner:  graphql.String(opts.Owner),
+			Branch: graphql.String(opts.Branch),
+			Status: graphql.String(opts.Status),
+			SortBy: graphql.String(opts.SortBy),
+			Limit:  graphql.Int(pageSize),
+			Offset: graphql.Int(len(result)),
+		}
+		queryStruct, err := listPreviewWithFiltersOperation.Do(ctx, c.client, variables)
+		if err != nil {
+			if strings.Contains(err.Error(), "Unknown argument") && strings.Contains(err.Error(), "on field \"previews\"") {
+				return nil, oktetoErrors.UserError{E: ErrPreviewFiltersNotSupported, Hint: "Please upgrade to the latest version or ask your administrator"}
+			}
+			return nil, err
+		}
+
+		for _, previewEnv := range queryStruct.Response {
+			labels := make([]string, 0)
+			for _, l := range previewEnv.PreviewLabels {
+				labels = append(labels, string(l))
+			}
+			result = append(result, types.Preview{
+				ID:            string(previewEnv.Id),
+				Scope:         string(previewEnv.Scope),
+				Owner:         string(previewEnv.Owner),
+				Branch:        string(previewEnv.Branch),
+				Status:        string(previewEnv.Status),
+				Sleeping:      bool(previewEnv.Sleeping),
+				PreviewLabels: labels,
+			})
+		}
+
+		if len(queryStruct.Response) < pageSize || (opts.Limit > 0 && len(result) >= opts.Limit) {
+			return result, nil
+		}
+	}
+}
+
 // TODO: Remove it when all charts are updated to 1.9
 func (c *previewClient) deprecatedList(ctx context.Context) ([]types.Preview, error) {
-	queryStruct := listPreviewQueryDeprecated{}
-	err := query(ctx, &queryStruct, nil, c.client)
+	queryStruct, err := deprecatedListPreviewOperation.Do(ctx, c.client, noVariables{})
 	if err != nil {
 		return nil, err
 	}
@@ -306,13 +469,8 @@ func (c *previewClient) deprecatedList(ctx context.Context) ([]types.Preview, er
 
 // ListEndpoints lists all the endpoints from a preview environment
 func (c *previewClient) ListEndpoints(ctx context.Context, previewName string) ([]types.Endpoint, error) {
-	queryStruct := listPreviewEndpoints{}
-	variables := map[string]interface{}{
-		"id": graphql.String(previewName),
-	}
 	endpoints := make([]types.Endpoint, 0)
-
-	err := query(ctx, &queryStruct, variables, c.client)
+	queryStruct, err := listPreviewEndpointsOperation.Do(ctx, c.client, idVariables{ID: graphql.String(previewName)})
 	if err != nil {
 		return nil, err
 	}
@@ -354,12 +512,7 @@ func (c *previewClient) ListEndpoints(ctx context.Context, previewName string) (
 }
 
 func (c *previewClient) GetResourcesStatus(ctx context.Context, previewName, devName string) (map[string]string, error) {
-	queryStruct := getPreviewResources{}
-	variables := map[string]interface{}{
-		"id": graphql.String(previewName),
-	}
-
-	err := query(ctx, &queryStruct, variables, c.client)
+	queryStruct, err := getPreviewResourcesOperation.Do(ctx, c.client, idVariables{ID: graphql.String(previewName)})
 	if err != nil {
 		return nil, err
 	}
@@ -224,7 +224,7 @@ func TestDeployPreview(t *testing.T) {
 				client:             tc.input.client,
 				namespaceValidator: newNamespaceValidator(),
 			}
-			response, err := pc.DeployPreview(context.Background(), tc.input.name, "", "", "", "", "", tc.input.variables, tc.input.labels)
+			response, err := pc.DeployPreview(context.Background(), tc.input.name, "", "", "", "", "", tc.input.variables, tc.input.labels, nil)
 			assert.ErrorIs(t, err, tc.expected.err)
 			assert.Equal(t, tc.expected.response, response)
 		})
@@ -406,13 +406,97 @@ func TestListPreview(t *testing.T) {
 			pc := previewClient{
 				client: tc.input.client,
 			}
-			response, err := pc.List(context.Background(), tc.input.labels)
+			response, err := pc.List(context.Background(), types.PreviewListOptions{Labels: tc.input.labels})
 			assert.ErrorIs(t, err, tc.expected.err)
 			assert.Equal(t, tc.expected.response, response)
 		})
 	}
 }
 
+func TestListPreviewWithFilters(t *testing.T) {
+	testCases := []struct {
+		expectedErr error
+		client      *fakeGraphQLClient
+		name        string
+		expected    []types.Preview
+		opts        types.PreviewListOptions
+	}{
+		{
+			name: "filtered by owner",
+			opts: types.PreviewListOptions{Owner: "cindy", SortBy: "name"},
+			client: &fakeGraphQLClient{
+				queryResult: &listPreviewQueryWithFilters{
+					Response: []filteredPreviewEnv{
+						{
+							Id:            "test",
+							Scope:         "personal",
+							Owner:         "cindy",
+							Branch:        "main",
+							Status:        "Success",
+							PreviewLabels: []graphql.String{"value"},
+						},
+					},
+				},
+			},
+			expected: []types.Preview{
+				{
+					ID:            "test",
+					Scope:         "personal",
+					Owner:         "cindy",
+					Branch:        "main",
+					Status:        "Success",
+					PreviewLabels: []string{"value"},
+				},
+			},
+		},
+		{
+			name: "stops when the limit is reached",
+			opts: types.PreviewListOptions{Limit: 1},
+			client: &fakeGraphQLClient{
+				queryResult: &listPreviewQueryWithFilters{
+					Response: []filteredPreviewEnv{
+						{
+							Id: "test",
+						},
+					},
+				},
+			},
+			expected: []types.Preview{
+				{
+					ID:            "test",
+					PreviewLabels: []string{},
+				},
+			},
+		},
+		{
+			name: "error",
+			opts: types.PreviewListOptions{Branch: "main"},
+			client: &fakeGraphQLClient{
+				err: assert.AnError,
+			},
+			expectedErr: assert.AnError,
+		},
+		{
+			name: "error on a non supported version",
+			opts: types.PreviewListOptions{Status: "Success"},
+			client: &fakeGraphQLClient{
+				err: errors.New("Unknown argument \"owner\" on field \"previews\" of type \"Query\""),
+			},
+			expectedErr: ErrPreviewFiltersNotSupported,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pc := previewClient{
+				client: tc.client,
+			}
+			response, err := pc.List(context.Background(), tc.opts)
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expected, response)
+		})
+	}
+}
+
 func TestDeprecatedListPreview(t *testing.T) {
 	type input struct {
 		client *fakeGraphQLClient
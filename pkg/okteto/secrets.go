@@ -119,6 +119,27 @@ type metadataQueryItem struct {
 	Value graphql.String
 }
 
+type credVariables struct {
+	Cred graphql.String `graphql:"cred"`
+}
+
+type metadataVariables struct {
+	Namespace graphql.String `graphql:"namespace"`
+}
+
+type registryCredentialsVariables struct {
+	RegHost graphql.String `graphql:"regHost"`
+}
+
+var (
+	getContextOperation             = newQuery[credVariables, getContextQuery]("getContext")
+	getDeprecatedContextOperation   = newQuery[credVariables, getDeprecatedContextQuery]("getContext")
+	getSecretsOperation             = newQuery[noVariables, getSecretsQuery]("getGitDeploySecrets")
+	getContextFileOperation         = newQuery[noVariables, getContextFileQuery]("contextFile")
+	getMetadataOperation            = newQuery[metadataVariables, metadataQuery]("metadata")
+	getRegistryCredentialsOperation = newQuery[registryCredentialsVariables, getRegistryCredentialsQuery]("registryCredentials")
+)
+
 type contextFileJSON struct {
 	Contexts map[string]struct {
 		Certificate string `yaml:"certificate"`
@@ -127,11 +148,7 @@ type contextFileJSON struct {
 
 // GetSecrets returns the secrets from Okteto API
 func (c *userClient) GetContext(ctx context.Context, ns string) (*types.UserContext, error) {
-	var queryStruct getContextQuery
-	variables := map[string]interface{}{
-		"cred": graphql.String(ns),
-	}
-	err := query(ctx, &queryStruct, variables, c.client)
+	queryStruct, err := getContextOperation.Do(ctx, c.client, credVariables{Cred: graphql.String(ns)})
 	if err != nil {
 		if strings.Contains(err.Error(), "Cannot query field \"globalNamespace\" on type \"me\"") {
 			return c.deprecatedGetUserContext(ctx)
@@ -183,8 +200,7 @@ func (c *userClient) GetContext(ctx context.Context, ns string) (*types.UserCont
 
 // GetSecrets returns the secrets from Okteto API
 func (c *userClient) GetUserSecrets(ctx context.Context) ([]types.Secret, error) {
-	var queryStruct getSecretsQuery
-	err := query(ctx, &queryStruct, nil, c.client)
+	queryStruct, err := getSecretsOperation.Do(ctx, c.client, noVariables{})
 	if err != nil {
 		return nil, err
 	}
@@ -204,11 +220,7 @@ func (c *userClient) GetUserSecrets(ctx context.Context) ([]types.Secret, error)
 
 // TODO: Remove this code when users are in okteto chart > 0.10.8
 func (c *userClient) deprecatedGetUserContext(ctx context.Context) (*types.UserContext, error) {
-	var queryStruct getDeprecatedContextQuery
-	variables := map[string]interface{}{
-		"cred": graphql.String(""),
-	}
-	err := query(ctx, &queryStruct, variables, c.client)
+	queryStruct, err := getDeprecatedContextOperation.Do(ctx, c.client, credVariables{Cred: graphql.String("")})
 	if err != nil {
 		return nil, err
 	}
@@ -249,8 +261,8 @@ func (c *userClient) deprecatedGetUserContext(ctx context.Context) (*types.UserC
 }
 
 func (c *userClient) GetClusterCertificate(ctx context.Context, cluster, ns string) ([]byte, error) {
-	var queryStruct getContextFileQuery
-	if err := query(ctx, &queryStruct, nil, c.client); err != nil {
+	queryStruct, err := getContextFileOperation.Do(ctx, c.client, noVariables{})
+	if err != nil {
 		return nil, err
 	}
 
@@ -282,12 +294,7 @@ func (c *userClient) GetClusterCertificate(ctx context.Context, cluster, ns stri
 
 // GetClusterMetadata returns the metadata with the cluster configuration
 func (c *userClient) GetClusterMetadata(ctx context.Context, ns string) (types.ClusterMetadata, error) {
-	var queryStruct metadataQuery
-	vars := map[string]interface{}{
-		"namespace": graphql.String(ns),
-	}
-
-	err := query(ctx, &queryStruct, vars, c.client)
+	queryStruct, err := getMetadataOperation.Do(ctx, c.client, metadataVariables{Namespace: graphql.String(ns)})
 
 	if err != nil {
 		if strings.Contains(err.Error(), "Cannot query field \"metadata\" on type \"Query\"") {
@@ -325,6 +332,17 @@ func (c *userClient) GetClusterMetadata(ctx context.Context, ns string) (types.C
 			metadata.BuildKitInternalIP = string(v.Value)
 		case "publicDomain":
 			metadata.PublicDomain = string(v.Value)
+		case "version":
+			metadata.ServerVersion = string(v.Value)
+		case "minimumCLIVersion":
+			metadata.MinimumCLIVersion = string(v.Value)
+		case "capabilities":
+			metadata.Capabilities = []string{}
+			for _, capability := range strings.Split(string(v.Value), ",") {
+				if capability = strings.TrimSpace(capability); capability != "" {
+					metadata.Capabilities = append(metadata.Capabilities, capability)
+				}
+			}
 		}
 	}
 	if metadata.PipelineRunnerImage == "" {
@@ -334,11 +352,7 @@ func (c *userClient) GetClusterMetadata(ctx context.Context, ns string) (types.C
 }
 
 func (c *userClient) GetRegistryCredentials(ctx context.Context, host string) (dockertypes.AuthConfig, error) {
-	var queryStruct getRegistryCredentialsQuery
-	vars := map[string]interface{}{
-		"regHost": graphql.String(host),
-	}
-	err := query(ctx, &queryStruct, vars, c.client)
+	queryStruct, err := getRegistryCredentialsOperation.Do(ctx, c.client, registryCredentialsVariables{RegHost: graphql.String(host)})
 
 	if err != nil {
 		if strings.Contains(err.Error(), "Cannot query field \"registryCredentials\" on type \"Query\"") {
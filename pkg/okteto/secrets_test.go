@@ -478,6 +478,18 @@ func TestGetClusterMetadata(t *testing.T) {
 								Name:  "publicDomain",
 								Value: "test.okteto.com",
 							},
+							{
+								Name:  "version",
+								Value: "1.12.0",
+							},
+							{
+								Name:  "minimumCLIVersion",
+								Value: "2.14.0",
+							},
+							{
+								Name:  "capabilities",
+								Value: "kubetoken, divert",
+							},
 						},
 					},
 				},
@@ -489,6 +501,9 @@ func TestGetClusterMetadata(t *testing.T) {
 					PipelineRunnerImage: "installer-runner-image",
 					BuildKitInternalIP:  "10.10.10.10",
 					PublicDomain:        "test.okteto.com",
+					ServerVersion:       "1.12.0",
+					MinimumCLIVersion:   "2.14.0",
+					Capabilities:        []string{"kubetoken", "divert"},
 				},
 			},
 		},
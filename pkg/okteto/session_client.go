@@ -0,0 +1,65 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	// revokeTokenPathTemplate (baseURL)
+	revokeTokenPathTemplate = "%s/auth/token/revoke"
+)
+
+type sessionClient struct {
+	httpClient *http.Client
+}
+
+func newSessionClient(httpClient *http.Client) *sessionClient {
+	return &sessionClient{
+		httpClient: httpClient,
+	}
+}
+
+// RevokeToken invalidates the token used by the client
+func (c *sessionClient) RevokeToken(ctx context.Context, baseURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf(revokeTokenPathTemplate, baseURL), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("RevokeToken %w: %w", errRequest, err)
+	}
+
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			oktetoLog.Infof("could not close the body: %s", err)
+		}
+	}()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	case http.StatusUnauthorized:
+		return fmt.Errorf("RevokeToken %w", errUnauthorized)
+	default:
+		return fmt.Errorf("RevokeToken %w: %s", errStatus, resp.Status)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RevokeToken(t *testing.T) {
+	tests := []struct {
+		expectedErr error
+		name        string
+		status      int
+	}{
+		{
+			name:   "revoked",
+			status: http.StatusNoContent,
+		},
+		{
+			name:        "unauthorized",
+			status:      http.StatusUnauthorized,
+			expectedErr: errUnauthorized,
+		},
+		{
+			name:        "error request not success",
+			status:      http.StatusInternalServerError,
+			expectedErr: errStatus,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeHttpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/auth/token/revoke" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer fakeHttpServer.Close()
+
+			fakeSessionClient := newSessionClient(fakeHttpServer.Client())
+
+			err := fakeSessionClient.RevokeToken(context.Background(), fakeHttpServer.URL)
+			assert.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
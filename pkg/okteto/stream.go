@@ -19,9 +19,11 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/stream"
+	"github.com/okteto/okteto/pkg/types"
 )
 
 var (
@@ -29,6 +31,8 @@ var (
 	gitDeployUrlTemplate = "%s/sse/logs/%s/gitdeploy/%s?action=%s"
 	// destroyAllUrlTempleate (baseURL, namespace)
 	destroyAllUrlTempleate = "%s/sse/logs/%s/destroy-all"
+	// auditUrlTemplate (baseURL, since)
+	auditUrlTemplate = "%s/sse/audit?since=%s"
 )
 
 type streamClient struct {
@@ -47,6 +51,11 @@ type destroyAllLogFormat struct {
 	Line string `json:"line"`
 }
 
+type auditEventFormat struct {
+	types.AuditEvent
+	Done bool `json:"done"`
+}
+
 // PipelineLogs retrieves logs from the pipeline provided and prints them, returns error
 func (c *streamClient) PipelineLogs(ctx context.Context, name, namespace, actionName string) error {
 	streamURL := fmt.Sprintf(gitDeployUrlTemplate, Context().Name, namespace, name, actionName)
@@ -121,3 +130,37 @@ func handlerDestroyAllLog(line string) bool {
 	}
 	return false
 }
+
+// AuditEvents retrieves the audit events of the okteto instance since the given time and sends them to the handler
+func (c *streamClient) AuditEvents(ctx context.Context, since time.Time, handler func(types.AuditEvent)) error {
+	// Context().Name represents baseURL for SSE subscription endpoints
+	streamURL := fmt.Sprintf(auditUrlTemplate, Context().Name, url.QueryEscape(since.UTC().Format(time.RFC3339)))
+	u, err := url.Parse(streamURL)
+	if err != nil {
+		return err
+	}
+	return stream.GetLogsFromURL(ctx, c.client, u.String(), func(line string) bool {
+		return handleAuditEventLine(line, handler)
+	})
+}
+
+// handleAuditEventLine sends the events unmarshalled from line to the handler
+// returns true when the server sends the done event to break the scanner
+func handleAuditEventLine(line string, handler func(types.AuditEvent)) bool {
+	events := []auditEventFormat{}
+	if err := json.Unmarshal([]byte(line), &events); err != nil {
+		event := auditEventFormat{}
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			oktetoLog.Infof("error unmarshalling auditEventFormat: %v", err)
+			return false
+		}
+		events = []auditEventFormat{event}
+	}
+	for _, e := range events {
+		if e.Done {
+			return true
+		}
+		handler(e.AuditEvent)
+	}
+	return false
+}
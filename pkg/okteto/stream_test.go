@@ -0,0 +1,39 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_handleAuditEventLine(t *testing.T) {
+	events := []types.AuditEvent{}
+	handler := func(e types.AuditEvent) {
+		events = append(events, e)
+	}
+
+	assert.False(t, handleAuditEventLine(`{"user":"cindy","action":"deploy"}`, handler))
+	assert.False(t, handleAuditEventLine(`[{"user":"john","action":"sleep"}]`, handler))
+	assert.False(t, handleAuditEventLine("not-json", handler))
+	assert.True(t, handleAuditEventLine(`[{"user":"john","action":"wake"},{"done":true}]`, handler))
+
+	assert.Equal(t, []types.AuditEvent{
+		{User: "cindy", Action: "deploy"},
+		{User: "john", Action: "sleep"},
+		{User: "john", Action: "wake"},
+	}, events)
+}
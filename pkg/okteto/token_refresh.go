@@ -0,0 +1,189 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// TokenRefresher returns a valid token for the okteto context 'contextName' after 'expiredToken' was rejected by the API
+type TokenRefresher func(ctx context.Context, contextName, expiredToken string) (string, error)
+
+var (
+	interactiveTokenRefresher TokenRefresher
+	refreshTokenMutex         sync.Mutex
+)
+
+// SetInteractiveTokenRefresher registers the function used to get a new token when the one stored in the okteto context is also rejected.
+// It is only called in interactive terminals, where the user can be asked to log in again
+func SetInteractiveTokenRefresher(r TokenRefresher) {
+	refreshTokenMutex.Lock()
+	defer refreshTokenMutex.Unlock()
+	interactiveTokenRefresher = r
+}
+
+// RefreshToken gets a new token for the okteto context 'contextName' after 'expiredToken' was rejected by the API.
+// It first looks for a newer token in the okteto context store, which may have been updated by another okteto command.
+// Otherwise, and only in interactive terminals, it asks the user to log in again and persists the new token
+func RefreshToken(ctx context.Context, contextName, expiredToken string) (string, error) {
+	refreshTokenMutex.Lock()
+	defer refreshTokenMutex.Unlock()
+
+	okCtx, ok := ContextStore().Contexts[contextName]
+	if !ok {
+		return "", fmt.Errorf("%s context doesn't exists", contextName)
+	}
+	if token := getStoredToken(okCtx, contextName, expiredToken); token != "" {
+		return token, nil
+	}
+
+	if interactiveTokenRefresher == nil || !oktetoLog.IsInteractive() {
+		return "", fmt.Errorf("the token of context '%s' is no longer valid", contextName)
+	}
+
+	token, err := interactiveTokenRefresher(ctx, contextName, expiredToken)
+	if err != nil {
+		return "", err
+	}
+	okCtx.Token = token
+	if err := NewContextConfigWriter().Write(); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// refreshStoredToken gets a new token for the okteto context 'contextName' only from the okteto context store.
+// It is used by the clients of the login flow, which must not ask the user to log in again
+func refreshStoredToken(_ context.Context, contextName, expiredToken string) (string, error) {
+	refreshTokenMutex.Lock()
+	defer refreshTokenMutex.Unlock()
+
+	okCtx, ok := ContextStore().Contexts[contextName]
+	if !ok {
+		return "", fmt.Errorf("%s context doesn't exists", contextName)
+	}
+	if token := getStoredToken(okCtx, contextName, expiredToken); token != "" {
+		return token, nil
+	}
+	return "", fmt.Errorf("the token of context '%s' is no longer valid", contextName)
+}
+
+// getStoredToken returns the token of the okteto context if it is newer than 'expiredToken', updating the context with the token persisted by other okteto commands
+func getStoredToken(okCtx *OktetoContext, contextName, expiredToken string) string {
+	// another request already refreshed the token
+	if okCtx.Token != "" && okCtx.Token != expiredToken {
+		return okCtx.Token
+	}
+
+	if ContextExists() {
+		if storedCtx, ok := GetContextStoreFromStorePath().Contexts[contextName]; ok && storedCtx.Token != "" && storedCtx.Token != expiredToken {
+			oktetoLog.Infof("using the token stored for context '%s'", contextName)
+			okCtx.Token = storedCtx.Token
+			return okCtx.Token
+		}
+	}
+	return ""
+}
+
+// tokenRefreshTransport authenticates the requests with the token of an okteto context.
+// When a request is rejected with a 401 status code, it refreshes the token and retries the request once
+type tokenRefreshTransport struct {
+	rt          http.RoundTripper
+	refresh     TokenRefresher
+	contextName string
+
+	mu    sync.Mutex
+	token string
+}
+
+// newTokenRefreshTransport implements the RoundTripper interface
+func newTokenRefreshTransport(rt http.RoundTripper, contextName, token string, refresh TokenRefresher) *tokenRefreshTransport {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return &tokenRefreshTransport{
+		rt:          rt,
+		refresh:     refresh,
+		contextName: contextName,
+		token:       token,
+	}
+}
+
+// RoundTrip sends the request and retries it once with a new token if it was unauthorized
+func (t *tokenRefreshTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token := t.getToken()
+	resp, err := t.rt.RoundTrip(withBearerToken(req, token))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	// requests whose body can't be read again are not retried
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return resp, nil
+	}
+
+	newToken, err := t.refresh(req.Context(), t.contextName, token)
+	if err != nil {
+		oktetoLog.Infof("failed to refresh the token of context '%s': %s", t.contextName, err)
+		return resp, nil
+	}
+	if newToken == "" || newToken == token {
+		return resp, nil
+	}
+	t.setToken(newToken)
+
+	retry := withBearerToken(req, newToken)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+		retry.Body = body
+	}
+
+	if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+		oktetoLog.Infof("failed to read unauthorized response: %s", err)
+	}
+	if err := resp.Body.Close(); err != nil {
+		oktetoLog.Infof("failed to close unauthorized response: %s", err)
+	}
+	return t.rt.RoundTrip(retry)
+}
+
+func (t *tokenRefreshTransport) getToken() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.token
+}
+
+func (t *tokenRefreshTransport) setToken(token string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.token = token
+}
+
+// withBearerToken returns a copy of the request authenticated with 'token', if any
+func withBearerToken(req *http.Request, token string) *http.Request {
+	r := req.Clone(req.Context())
+	if token != "" {
+		r.Header.Set("Authorization", "Bearer "+token)
+	}
+	return r
+}
@@ -0,0 +1,225 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTokenServer(t *testing.T, validToken string, bodies *[]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		*bodies = append(*bodies, string(b))
+		if r.Header.Get("Authorization") != "Bearer "+validToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestTokenRefreshTransport(t *testing.T) {
+	tests := []struct {
+		refreshErr         error
+		name               string
+		refreshedToken     string
+		expectedBodies     []string
+		expectedStatusCode int
+		expectedRefreshes  int
+	}{
+		{
+			name:               "token is valid",
+			refreshedToken:     "new-token",
+			expectedStatusCode: http.StatusOK,
+			expectedBodies:     []string{"query"},
+		},
+		{
+			name:               "token is refreshed",
+			refreshedToken:     "valid",
+			expectedStatusCode: http.StatusOK,
+			expectedRefreshes:  1,
+			expectedBodies:     []string{"query", "query"},
+		},
+		{
+			name:               "refreshed token is also invalid",
+			refreshedToken:     "still-invalid",
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedRefreshes:  1,
+			expectedBodies:     []string{"query", "query"},
+		},
+		{
+			name:               "token can't be refreshed",
+			refreshErr:         assert.AnError,
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedRefreshes:  1,
+			expectedBodies:     []string{"query"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bodies := []string{}
+			validToken := "valid"
+			initialToken := "expired"
+			if tt.expectedRefreshes == 0 {
+				initialToken = validToken
+			}
+			server := newTokenServer(t, validToken, &bodies)
+
+			refreshes := 0
+			refresh := func(_ context.Context, contextName, expiredToken string) (string, error) {
+				refreshes++
+				assert.Equal(t, "https://okteto.example.com", contextName)
+				assert.Equal(t, initialToken, expiredToken)
+				return tt.refreshedToken, tt.refreshErr
+			}
+			client := &http.Client{
+				Transport: newTokenRefreshTransport(nil, "https://okteto.example.com", initialToken, refresh),
+			}
+
+			resp, err := client.Post(server.URL, "application/json", strings.NewReader("query"))
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+
+			assert.Equal(t, tt.expectedStatusCode, resp.StatusCode)
+			assert.Equal(t, tt.expectedRefreshes, refreshes)
+			assert.Equal(t, tt.expectedBodies, bodies)
+		})
+	}
+}
+
+func TestTokenRefreshTransportKeepsRefreshedToken(t *testing.T) {
+	bodies := []string{}
+	server := newTokenServer(t, "valid", &bodies)
+
+	refreshes := 0
+	refresh := func(_ context.Context, _, _ string) (string, error) {
+		refreshes++
+		return "valid", nil
+	}
+	client := &http.Client{
+		Transport: newTokenRefreshTransport(nil, "https://okteto.example.com", "expired", refresh),
+	}
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+	assert.Equal(t, 1, refreshes)
+}
+
+func TestRefreshTokenAlreadyRefreshed(t *testing.T) {
+	CurrentStore = &OktetoContextStore{
+		CurrentContext: "https://okteto.example.com",
+		Contexts: map[string]*OktetoContext{
+			"https://okteto.example.com": {
+				Name:  "https://okteto.example.com",
+				Token: "new-token",
+			},
+		},
+	}
+	t.Cleanup(func() {
+		CurrentStore = nil
+	})
+
+	token, err := RefreshToken(context.Background(), "https://okteto.example.com", "expired")
+	require.NoError(t, err)
+	assert.Equal(t, "new-token", token)
+
+	_, err = RefreshToken(context.Background(), "https://unknown.example.com", "expired")
+	assert.Error(t, err)
+}
+
+func TestClientsRefreshToken(t *testing.T) {
+	tests := []struct {
+		newClient       func() (*OktetoClient, error)
+		name            string
+		expectedContext string
+		expectedToken   string
+	}{
+		{
+			name: "url and token",
+			newClient: func() (*OktetoClient, error) {
+				return NewOktetoClientFromUrlAndToken("https://okteto.example.com", "token")
+			},
+			expectedContext: "https://okteto.example.com",
+			expectedToken:   "token",
+		},
+		{
+			name: "url",
+			newClient: func() (*OktetoClient, error) {
+				return NewOktetoClientFromUrl("https://okteto.example.com")
+			},
+			expectedContext: "https://okteto.example.com",
+		},
+		{
+			name: "stateless",
+			newClient: func() (*OktetoClient, error) {
+				return NewOktetoClientStateless(&OktetoClientCfg{
+					CtxName: "https://okteto.example.com",
+					Token:   "token",
+				})
+			},
+			expectedContext: "https://okteto.example.com",
+			expectedToken:   "token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := tt.newClient()
+			require.NoError(t, err)
+			transport, ok := c.kubetoken.(*kubeTokenClient).httpClient.Transport.(*tokenRefreshTransport)
+			require.True(t, ok)
+			assert.Equal(t, tt.expectedContext, transport.contextName)
+			assert.Equal(t, tt.expectedToken, transport.getToken())
+		})
+	}
+}
+
+func TestRefreshStoredToken(t *testing.T) {
+	CurrentStore = &OktetoContextStore{
+		CurrentContext: "https://okteto.example.com",
+		Contexts: map[string]*OktetoContext{
+			"https://okteto.example.com": {
+				Name: "https://okteto.example.com",
+			},
+		},
+	}
+	t.Cleanup(func() {
+		CurrentStore = nil
+	})
+	t.Setenv(constants.OktetoFolderEnvVar, t.TempDir())
+
+	_, err := refreshStoredToken(context.Background(), "https://okteto.example.com", "")
+	assert.Error(t, err)
+
+	CurrentStore.Contexts["https://okteto.example.com"].Token = "token"
+	token, err := refreshStoredToken(context.Background(), "https://okteto.example.com", "")
+	require.NoError(t, err)
+	assert.Equal(t, "token", token)
+}
@@ -0,0 +1,260 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package output writes the result of the commands in the structured output formats of the '--output' flag
+package output
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// JSON writes the objects as indented json
+	JSON = "json"
+
+	// YAML writes the objects as yaml
+	YAML = "yaml"
+
+	// Name writes the name of every object in a line
+	Name = "name"
+
+	// goTemplatePrefix is the prefix of the output format that executes an inline go template
+	goTemplatePrefix = "go-template="
+
+	// goTemplateFilePrefix is the prefix of the output format that executes the go template of a file
+	goTemplateFilePrefix = "go-template-file="
+
+	flagUsage = "output format. One of: ['json', 'yaml', 'name', 'go-template=<template>', 'go-template-file=<path>']"
+)
+
+// ErrInvalidFormat is returned when the output format is not supported
+var ErrInvalidFormat = errors.New("output format is not supported")
+
+// Formatter writes objects in a structured output format
+type Formatter interface {
+	Format(w io.Writer, obj interface{}) error
+}
+
+// Option customizes the json and yaml formats of a command, so it keeps the output it had before using this package
+type Option func(*options)
+
+type options struct {
+	yamlMarshal func(interface{}) ([]byte, error)
+	jsonIndent  string
+}
+
+// WithJSONIndent sets the indent of the json format
+func WithJSONIndent(indent string) Option {
+	return func(o *options) {
+		o.jsonIndent = indent
+	}
+}
+
+// WithYAMLMarshal sets the function that marshals the objects in the yaml format
+func WithYAMLMarshal(marshal func(interface{}) ([]byte, error)) Option {
+	return func(o *options) {
+		o.yamlMarshal = marshal
+	}
+}
+
+// Named is implemented by the objects whose name is not their 'name' field
+type Named interface {
+	OutputName() string
+}
+
+// AddFlag registers the '--output' flag of a command
+func AddFlag(cmd *cobra.Command, output *string) {
+	AddFlagWithDefault(cmd, output, "")
+}
+
+// AddFlagWithDefault registers the '--output' flag of a command with a default output format
+func AddFlagWithDefault(cmd *cobra.Command, output *string, defaultValue string) {
+	cmd.Flags().StringVarP(output, "output", "o", defaultValue, flagUsage)
+}
+
+// NewFormatter returns the formatter of an output format. It returns nil for the empty format, which is the default output of each command
+func NewFormatter(output string, opts ...Option) (Formatter, error) {
+	o := &options{
+		jsonIndent:  " ",
+		yamlMarshal: yaml.Marshal,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	switch {
+	case output == "":
+		return nil, nil
+	case output == JSON:
+		return jsonFormatter{indent: o.jsonIndent}, nil
+	case output == YAML:
+		return yamlFormatter{marshal: o.yamlMarshal}, nil
+	case output == Name:
+		return nameFormatter{}, nil
+	case strings.HasPrefix(output, goTemplatePrefix):
+		return newTemplateFormatter(strings.TrimPrefix(output, goTemplatePrefix))
+	case strings.HasPrefix(output, goTemplateFilePrefix):
+		path := strings.TrimPrefix(output, goTemplateFilePrefix)
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the template file '%s': %w", path, err)
+		}
+		return newTemplateFormatter(string(b))
+	default:
+		return nil, fmt.Errorf("%w: '%s'. Supported values are: ['json', 'yaml', 'name', 'go-template=<template>', 'go-template-file=<path>']", ErrInvalidFormat, output)
+	}
+}
+
+// Validate returns an error if the output format is not supported
+func Validate(output string) error {
+	_, err := NewFormatter(output)
+	return err
+}
+
+// Print writes obj in the output format. The default output of the command is written by printDefault
+func Print(w io.Writer, output string, obj interface{}, printDefault func(w io.Writer) error, opts ...Option) error {
+	formatter, err := NewFormatter(output, opts...)
+	if err != nil {
+		return err
+	}
+	if formatter == nil {
+		return printDefault(w)
+	}
+	return formatter.Format(w, obj)
+}
+
+type jsonFormatter struct {
+	indent string
+}
+
+// Format writes obj as indented json. Empty lists are written as '[]'
+func (f jsonFormatter) Format(w io.Writer, obj interface{}) error {
+	if v := reflect.ValueOf(obj); v.Kind() == reflect.Slice && v.IsNil() {
+		obj = []interface{}{}
+	}
+	bytes, err := json.MarshalIndent(obj, "", f.indent)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(bytes))
+	return err
+}
+
+type yamlFormatter struct {
+	marshal func(interface{}) ([]byte, error)
+}
+
+// Format writes obj as yaml
+func (f yamlFormatter) Format(w io.Writer, obj interface{}) error {
+	bytes, err := f.marshal(obj)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(w, string(bytes))
+	return err
+}
+
+type nameFormatter struct{}
+
+// Format writes the name of obj, or the name of every item if obj is a list.
+// The name is returned by OutputName, or it is the 'name' or 'metadata.name' field of the json representation of the object
+func (nameFormatter) Format(w io.Writer, obj interface{}) error {
+	items := []interface{}{obj}
+	if v := reflect.ValueOf(obj); v.Kind() == reflect.Slice {
+		items = make([]interface{}, v.Len())
+		for i := range items {
+			items[i] = v.Index(i).Interface()
+		}
+	}
+	for _, item := range items {
+		name, err := getName(item)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(w, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getName(item interface{}) (string, error) {
+	if named, ok := item.(Named); ok {
+		return named.OutputName(), nil
+	}
+	generic, err := toGeneric(item)
+	if err != nil {
+		return "", err
+	}
+	fields, ok := generic.(map[string]interface{})
+	if ok {
+		if name, ok := fields["name"].(string); ok {
+			return name, nil
+		}
+		if metadata, ok := fields["metadata"].(map[string]interface{}); ok {
+			if name, ok := metadata["name"].(string); ok {
+				return name, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("the output format '%s' is not supported by this command", Name)
+}
+
+type templateFormatter struct {
+	tmpl *template.Template
+}
+
+func newTemplateFormatter(text string) (*templateFormatter, error) {
+	tmpl, err := template.New("output").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid go template: %w", err)
+	}
+	return &templateFormatter{tmpl: tmpl}, nil
+}
+
+// Format executes the template with the json representation of obj, so the fields are referenced by their json names
+func (f *templateFormatter) Format(w io.Writer, obj interface{}) error {
+	generic, err := toGeneric(obj)
+	if err != nil {
+		return err
+	}
+	if err := f.tmpl.Execute(w, generic); err != nil {
+		return fmt.Errorf("failed to execute the go template: %w", err)
+	}
+	return nil
+}
+
+// toGeneric returns the json representation of obj as maps and lists
+func toGeneric(obj interface{}) (interface{}, error) {
+	bytes, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var result interface{}
+	if err := json.Unmarshal(bytes, &result); err != nil {
+		return nil, err
+	}
+	if result == nil {
+		result = []interface{}{}
+	}
+	return result, nil
+}
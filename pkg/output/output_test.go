@@ -0,0 +1,165 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type item struct {
+	Name   string   `json:"name" yaml:"name"`
+	Labels []string `json:"labels" yaml:"labels"`
+}
+
+type namedItem struct {
+	ID string `json:"id" yaml:"id"`
+}
+
+func (n namedItem) OutputName() string {
+	return n.ID
+}
+
+type metadata struct {
+	Name string `json:"name"`
+}
+
+type resource struct {
+	Metadata metadata `json:"metadata"`
+}
+
+func TestPrint(t *testing.T) {
+	items := []item{{Name: "a", Labels: []string{"x"}}, {Name: "b"}}
+	templateFile := filepath.Join(t.TempDir(), "template")
+	require.NoError(t, os.WriteFile(templateFile, []byte(`{{ range . }}{{ .name }};{{ end }}`), 0600))
+
+	tests := []struct {
+		obj      interface{}
+		name     string
+		output   string
+		expected string
+	}{
+		{
+			name:     "default",
+			output:   "",
+			obj:      items,
+			expected: "default\n",
+		},
+		{
+			name:     "json",
+			output:   JSON,
+			obj:      items,
+			expected: "[\n {\n  \"name\": \"a\",\n  \"labels\": [\n   \"x\"\n  ]\n },\n {\n  \"name\": \"b\",\n  \"labels\": null\n }\n]\n",
+		},
+		{
+			name:     "json empty list",
+			output:   JSON,
+			obj:      []item(nil),
+			expected: "[]\n",
+		},
+		{
+			name:     "yaml",
+			output:   YAML,
+			obj:      items,
+			expected: "- name: a\n  labels:\n  - x\n- name: b\n  labels: []\n",
+		},
+		{
+			name:     "name",
+			output:   Name,
+			obj:      items,
+			expected: "a\nb\n",
+		},
+		{
+			name:     "name of a single object",
+			output:   Name,
+			obj:      item{Name: "a"},
+			expected: "a\n",
+		},
+		{
+			name:     "name with OutputName",
+			output:   Name,
+			obj:      []namedItem{{ID: "id"}},
+			expected: "id\n",
+		},
+		{
+			name:     "name from metadata",
+			output:   Name,
+			obj:      []resource{{Metadata: metadata{Name: "divert"}}},
+			expected: "divert\n",
+		},
+		{
+			name:     "go template",
+			output:   "go-template={{ range . }}{{ .name }}:{{ len .labels }} {{ end }}",
+			obj:      []item{{Name: "a", Labels: []string{"x"}}},
+			expected: "a:1 ",
+		},
+		{
+			name:     "go template file",
+			output:   "go-template-file=" + templateFile,
+			obj:      items,
+			expected: "a;b;",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			err := Print(&b, tt.output, tt.obj, func(w io.Writer) error {
+				_, err := fmt.Fprintln(w, "default")
+				return err
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, b.String())
+		})
+	}
+}
+
+func TestPrintWithOptions(t *testing.T) {
+	printDefault := func(io.Writer) error { return nil }
+	marshal := func(interface{}) ([]byte, error) { return []byte("custom\n"), nil }
+
+	var b bytes.Buffer
+	require.NoError(t, Print(&b, JSON, item{Name: "a"}, printDefault, WithJSONIndent("\t")))
+	assert.Equal(t, "{\n\t\"name\": \"a\",\n\t\"labels\": null\n}\n", b.String())
+
+	b.Reset()
+	require.NoError(t, Print(&b, YAML, item{Name: "a"}, printDefault, WithYAMLMarshal(marshal)))
+	assert.Equal(t, "custom\n", b.String())
+}
+
+func TestPrintErrors(t *testing.T) {
+	var b bytes.Buffer
+	printDefault := func(io.Writer) error { return nil }
+
+	assert.ErrorIs(t, Print(&b, "xml", nil, printDefault), ErrInvalidFormat)
+	assert.ErrorContains(t, Print(&b, "go-template={{ .name", nil, printDefault), "invalid go template")
+	assert.ErrorContains(t, Print(&b, "go-template-file=/does/not/exist", nil, printDefault), "failed to read the template file")
+	assert.ErrorContains(t, Print(&b, Name, []string{"a"}, printDefault), "not supported by this command")
+}
+
+func TestAddFlag(t *testing.T) {
+	var output string
+	cmd := &cobra.Command{}
+	AddFlagWithDefault(cmd, &output, JSON)
+	require.NoError(t, cmd.ParseFlags([]string{"-o", "name"}))
+	assert.Equal(t, Name, output)
+	assert.Equal(t, JSON, cmd.Flags().Lookup("output").DefValue)
+}
@@ -0,0 +1,187 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/partial"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+)
+
+const (
+	// ManifestArtifactScheme is the prefix of the references to okteto manifests packaged as OCI artifacts
+	ManifestArtifactScheme = "oci://"
+
+	manifestConfigMediaType types.MediaType = "application/vnd.okteto.manifest.config.v1+json"
+	manifestLayerMediaType  types.MediaType = "application/vnd.okteto.manifest.content.v1.tar+gzip"
+
+	// manifestPathAnnotation is the path of the okteto manifest inside the artifact content
+	manifestPathAnnotation = "dev.okteto.manifest.path"
+)
+
+// ManifestArtifact is an okteto manifest and the files of its folder packaged as an OCI artifact
+type ManifestArtifact struct {
+	// ManifestPath is the path of the okteto manifest relative to the root of the content
+	ManifestPath string
+	// Content is a gzipped tarball with the folder of the okteto manifest
+	Content []byte
+}
+
+// IsManifestArtifact returns true if the reference points to an okteto manifest packaged as an OCI artifact
+func IsManifestArtifact(reference string) bool {
+	return strings.HasPrefix(reference, ManifestArtifactScheme)
+}
+
+// PushManifestArtifact pushes the artifact to the registry and returns the reference with its digest
+func (or OktetoRegistry) PushManifestArtifact(reference string, artifact *ManifestArtifact) (string, error) {
+	ref, err := or.parseArtifactReference(reference)
+	if err != nil {
+		return "", err
+	}
+
+	img, err := newManifestArtifactImage(artifact)
+	if err != nil {
+		return "", fmt.Errorf("failed to package the manifest: %w", err)
+	}
+
+	if err := or.client.Write(ref, img); err != nil {
+		return "", fmt.Errorf("failed to push the manifest to '%s': %w", ref.Name(), err)
+	}
+	digest, err := img.Digest()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s@%s", ref.Context().Name(), digest), nil
+}
+
+// PullManifestArtifact returns the okteto manifest artifact of the reference
+func (or OktetoRegistry) PullManifestArtifact(reference string) (*ManifestArtifact, error) {
+	ref, err := or.parseArtifactReference(reference)
+	if err != nil {
+		return nil, err
+	}
+
+	descriptor, err := or.client.GetDescriptor(ref.Name())
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull the manifest from '%s': %w", ref.Name(), err)
+	}
+	img, err := descriptor.Image()
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull the manifest from '%s': %w", ref.Name(), err)
+	}
+	manifest, err := img.Manifest()
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull the manifest from '%s': %w", ref.Name(), err)
+	}
+	if manifest.Config.MediaType != manifestConfigMediaType || len(manifest.Layers) != 1 {
+		return nil, fmt.Errorf("'%s' is not an okteto manifest pushed by 'okteto manifest push'", ref.Name())
+	}
+
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull the manifest from '%s': %w", ref.Name(), err)
+	}
+	rc, err := layers[0].Compressed()
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull the manifest from '%s': %w", ref.Name(), err)
+	}
+	defer rc.Close()
+	content, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull the manifest from '%s': %w", ref.Name(), err)
+	}
+
+	return &ManifestArtifact{
+		ManifestPath: manifest.Annotations[manifestPathAnnotation],
+		Content:      content,
+	}, nil
+}
+
+// manifestArtifactImage is the OCI image manifest of an okteto manifest artifact: an empty config and a single layer with the content
+type manifestArtifactImage struct {
+	config   v1.Layer
+	content  v1.Layer
+	manifest []byte
+}
+
+func newManifestArtifactImage(artifact *ManifestArtifact) (v1.Image, error) {
+	img := &manifestArtifactImage{
+		config:  static.NewLayer([]byte("{}"), manifestConfigMediaType),
+		content: static.NewLayer(artifact.Content, manifestLayerMediaType),
+	}
+	configDesc, err := partial.Descriptor(img.config)
+	if err != nil {
+		return nil, err
+	}
+	contentDesc, err := partial.Descriptor(img.content)
+	if err != nil {
+		return nil, err
+	}
+	img.manifest, err = json.Marshal(&v1.Manifest{
+		SchemaVersion: 2,
+		MediaType:     types.OCIManifestSchema1,
+		Config:        *configDesc,
+		Layers:        []v1.Descriptor{*contentDesc},
+		Annotations:   map[string]string{manifestPathAnnotation: artifact.ManifestPath},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return partial.CompressedToImage(img)
+}
+
+// RawConfigFile implements partial.CompressedImageCore
+func (*manifestArtifactImage) RawConfigFile() ([]byte, error) {
+	return []byte("{}"), nil
+}
+
+// MediaType implements partial.CompressedImageCore
+func (*manifestArtifactImage) MediaType() (types.MediaType, error) {
+	return types.OCIManifestSchema1, nil
+}
+
+// RawManifest implements partial.CompressedImageCore
+func (img *manifestArtifactImage) RawManifest() ([]byte, error) {
+	return img.manifest, nil
+}
+
+// LayerByDigest implements partial.CompressedImageCore
+func (img *manifestArtifactImage) LayerByDigest(h v1.Hash) (partial.CompressedLayer, error) {
+	for _, l := range []v1.Layer{img.content, img.config} {
+		digest, err := l.Digest()
+		if err != nil {
+			return nil, err
+		}
+		if digest == h {
+			return l, nil
+		}
+	}
+	return nil, fmt.Errorf("blob %s not found", h)
+}
+
+func (or OktetoRegistry) parseArtifactReference(reference string) (name.Reference, error) {
+	reference = strings.TrimPrefix(reference, ManifestArtifactScheme)
+	ref, err := name.ParseReference(or.imageCtrl.expandImageRegistries(reference))
+	if err != nil {
+		return nil, fmt.Errorf("invalid manifest reference '%s': %w", reference, err)
+	}
+	return ref, nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	ggcrRegistry "github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	oktetoHttp "github.com/okteto/okteto/pkg/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsManifestArtifact(t *testing.T) {
+	assert.True(t, IsManifestArtifact("oci://okteto.dev/my-app:1.0"))
+	assert.False(t, IsManifestArtifact("okteto.dev/my-app:1.0"))
+	assert.False(t, IsManifestArtifact("api"))
+}
+
+func TestPushAndPullManifestArtifact(t *testing.T) {
+	server := httptest.NewServer(ggcrRegistry.New())
+	defer server.Close()
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	or := OktetoRegistry{
+		client: client{
+			config:  fakeClientConfig{},
+			get:     remote.Get,
+			write:   remote.Write,
+			tlsDial: oktetoHttp.DefaultTLSDial,
+		},
+		imageCtrl: NewImageCtrl(fakeImageConfig{}),
+	}
+
+	artifact := &ManifestArtifact{
+		ManifestPath: "okteto.yml",
+		Content:      []byte("content"),
+	}
+	reference := u.Host + "/my-app:1.0"
+	pushed, err := or.PushManifestArtifact(ManifestArtifactScheme+reference, artifact)
+	require.NoError(t, err)
+	assert.Contains(t, pushed, u.Host+"/my-app@sha256:")
+
+	pulled, err := or.PullManifestArtifact(ManifestArtifactScheme + reference)
+	require.NoError(t, err)
+	assert.Equal(t, artifact, pulled)
+
+	pulled, err = or.PullManifestArtifact(pushed)
+	require.NoError(t, err)
+	assert.Equal(t, artifact, pulled)
+}
+
+func TestPullManifestArtifactNotAnArtifact(t *testing.T) {
+	server := httptest.NewServer(ggcrRegistry.New())
+	defer server.Close()
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	or := OktetoRegistry{
+		client: client{
+			config:  fakeClientConfig{},
+			get:     remote.Get,
+			write:   remote.Write,
+			tlsDial: oktetoHttp.DefaultTLSDial,
+		},
+		imageCtrl: NewImageCtrl(fakeImageConfig{}),
+	}
+
+	_, err = or.PullManifestArtifact(u.Host + "/missing:1.0")
+	assert.Error(t, err)
+}
@@ -69,18 +69,20 @@ func (oh oktetoHelper) Get(_ string) (string, string, error) {
 
 // client operates with the registry API
 type client struct {
-	config  ClientConfigInterface
-	get     func(ref name.Reference, options ...remote.Option) (*remote.Descriptor, error)
-	write   func(ref name.Reference, image v1.Image, options ...remote.Option) error
+	config ClientConfigInterface
+	get    func(ref name.Reference, options ...remote.Option) (*remote.Descriptor, error)
+	write  func(ref name.Reference, image v1.Image, options ...remote.Option) error
+	// tlsDial dials the TLS connections to the registry. The transports shared with the other clients are used when it's nil
 	tlsDial oktetoHttp.TLSDialFunc
+	// limiter limits the transfer rate of the responses of the registry when it is set
+	limiter *rateLimiter
 }
 
 func newOktetoRegistryClient(config ClientConfigInterface) client {
 	return client{
-		config:  config,
-		get:     remote.Get,
-		write:   remote.Write,
-		tlsDial: oktetoHttp.DefaultTLSDial,
+		config: config,
+		get:    remote.Get,
+		write:  remote.Write,
 	}
 }
 
@@ -203,13 +205,17 @@ func (c client) getTransport() http.RoundTripper {
 		}
 	}
 
-	transport := oktetoHttp.StrictSSLTransport(sslTransportOption)
-
+	var transport http.RoundTripper
 	if c.config.IsInsecureSkipTLSVerifyPolicy() {
-		transport = oktetoHttp.InsecureTransport()
-	} else if cert, err := c.config.GetContextCertificate(); err == nil {
-		sslTransportOption.Certs = []*x509.Certificate{cert}
-		transport = oktetoHttp.StrictSSLTransport(sslTransportOption)
+		transport = oktetoHttp.SharedInsecureTransport()
+	} else {
+		if cert, err := c.config.GetContextCertificate(); err == nil {
+			sslTransportOption.Certs = []*x509.Certificate{cert}
+		}
+		transport = oktetoHttp.SharedStrictSSLTransport(sslTransportOption)
+	}
+	if c.limiter != nil {
+		return &rateLimitedTransport{base: transport, limiter: c.limiter}
 	}
 	return transport
 }
@@ -0,0 +1,203 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package login
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+const (
+	ecrUsername = "AWS"
+	gcrUsername = "oauth2accesstoken"
+	acrUsername = "00000000-0000-0000-0000-000000000000"
+
+	// the tokens are valid for a longer period, but they are renewed before they expire
+	ecrTokenTTL    = 11 * time.Hour
+	gcrTokenTTL    = 50 * time.Minute
+	acrTokenTTL    = 2*time.Hour + 50*time.Minute
+	helperTokenTTL = 30 * time.Minute
+
+	credentialHelperPrefix = "docker-credential-"
+)
+
+// providerCLIs are the CLIs used to get the token of each provider
+var providerCLIs = map[string]struct {
+	binary string
+	docs   string
+}{
+	model.RegistryProviderECR: {binary: "aws", docs: "https://docs.aws.amazon.com/cli/latest/userguide/getting-started-install.html"},
+	model.RegistryProviderGCR: {binary: "gcloud", docs: "https://cloud.google.com/sdk/docs/install"},
+	model.RegistryProviderACR: {binary: "az", docs: "https://learn.microsoft.com/cli/azure/install-azure-cli"},
+}
+
+// Exchanger exchanges the cloud credentials of the user for short-lived registry credentials
+type Exchanger struct {
+	lookPath func(file string) (string, error)
+	run      func(ctx context.Context, stdin, name string, args ...string) ([]byte, error)
+	now      func() time.Time
+}
+
+// NewExchanger returns an exchanger that runs the CLIs of the cloud providers
+func NewExchanger() *Exchanger {
+	return &Exchanger{
+		lookPath: exec.LookPath,
+		run:      runCommand,
+		now:      time.Now,
+	}
+}
+
+// Exchange returns the credentials of a registry using its provider or docker credential helper
+func (e *Exchanger) Exchange(ctx context.Context, r model.RegistryLogin) (*Credentials, error) {
+	if r.Helper != "" {
+		return e.exchangeHelper(ctx, r)
+	}
+	provider := r.GetProvider()
+	cli, ok := providerCLIs[provider]
+	if !ok {
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("could not infer the provider of the registry '%s'", r.Server),
+			Hint: fmt.Sprintf("Set the provider (%s, %s or %s) or the docker credential helper of the registry", model.RegistryProviderECR, model.RegistryProviderGCR, model.RegistryProviderACR),
+		}
+	}
+	bin, err := e.lookPath(cli.binary)
+	if err != nil {
+		oktetoLog.Infof("failed to find %s: %s", cli.binary, err)
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("the '%s' CLI is required to log in to '%s'", cli.binary, r.Server),
+			Hint: fmt.Sprintf("Install it following the instructions at %s", cli.docs),
+		}
+	}
+
+	var args []string
+	var username string
+	var ttl time.Duration
+	switch provider {
+	case model.RegistryProviderECR:
+		region, err := getECRRegion(r.Server)
+		if err != nil {
+			return nil, err
+		}
+		args = []string{"ecr", "get-login-password", "--region", region}
+		username = ecrUsername
+		ttl = ecrTokenTTL
+	case model.RegistryProviderGCR:
+		args = []string{"auth", "print-access-token"}
+		username = gcrUsername
+		ttl = gcrTokenTTL
+	case model.RegistryProviderACR:
+		args = []string{"acr", "login", "--name", getACRName(r.Server), "--expose-token", "--output", "tsv", "--query", "accessToken"}
+		username = acrUsername
+		ttl = acrTokenTTL
+	}
+
+	out, err := e.run(ctx, "", bin, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get a token for '%s' from %s: %w", r.Server, cli.binary, err)
+	}
+	token := strings.TrimSpace(string(out))
+	if token == "" {
+		return nil, fmt.Errorf("failed to get a token for '%s': %s returned an empty token", r.Server, cli.binary)
+	}
+	return &Credentials{
+		Server:    r.Server,
+		Username:  username,
+		Secret:    token,
+		ExpiresAt: e.now().Add(ttl),
+	}, nil
+}
+
+// helperCredentials is the output of the 'get' command of the docker credential helpers
+type helperCredentials struct {
+	Username string `json:"Username"`
+	Secret   string `json:"Secret"`
+}
+
+func (e *Exchanger) exchangeHelper(ctx context.Context, r model.RegistryLogin) (*Credentials, error) {
+	binary := credentialHelperPrefix + r.Helper
+	bin, err := e.lookPath(binary)
+	if err != nil {
+		oktetoLog.Infof("failed to find %s: %s", binary, err)
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("the docker credential helper '%s' is not installed", binary),
+			Hint: fmt.Sprintf("Install '%s' and make sure it is available in your PATH", binary),
+		}
+	}
+	out, err := e.run(ctx, r.Server, bin, "get")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the credentials of '%s' from %s: %w", r.Server, binary, err)
+	}
+	creds := &helperCredentials{}
+	if err := json.Unmarshal(out, creds); err != nil {
+		return nil, fmt.Errorf("invalid response of %s: %w", binary, err)
+	}
+	if creds.Secret == "" {
+		return nil, fmt.Errorf("%s returned no credentials for '%s'", binary, r.Server)
+	}
+	return &Credentials{
+		Server:    r.Server,
+		Username:  creds.Username,
+		Secret:    creds.Secret,
+		ExpiresAt: e.now().Add(helperTokenTTL),
+	}, nil
+}
+
+// getECRRegion returns the region of a registry like '123456789012.dkr.ecr.us-east-1.amazonaws.com'
+func getECRRegion(server string) (string, error) {
+	parts := strings.Split(getHost(server), ".")
+	for i, part := range parts {
+		if part == "ecr" && i > 0 && parts[i-1] == "dkr" && i+1 < len(parts) {
+			return parts[i+1], nil
+		}
+	}
+	return "", fmt.Errorf("could not get the region of the ECR registry '%s'", server)
+}
+
+// getACRName returns the name of a registry like 'myregistry.azurecr.io'
+func getACRName(server string) string {
+	return strings.Split(getHost(server), ".")[0]
+}
+
+func getHost(server string) string {
+	host := strings.TrimPrefix(strings.TrimPrefix(server, "https://"), "http://")
+	return strings.SplitN(host, "/", 2)[0]
+}
+
+func runCommand(ctx context.Context, stdin, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	if stdin != "" {
+		cmd.Stdin = strings.NewReader(stdin)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && stderr.Len() > 0 {
+			return nil, fmt.Errorf("%w: %s", err, bytes.TrimSpace(stderr.Bytes()))
+		}
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,168 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package login
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCommand struct {
+	stdin string
+	name  string
+	args  []string
+}
+
+func newFakeExchanger(out string, runErr error, commands *[]fakeCommand) *Exchanger {
+	return &Exchanger{
+		lookPath: func(file string) (string, error) {
+			return "/usr/bin/" + file, nil
+		},
+		run: func(_ context.Context, stdin, name string, args ...string) ([]byte, error) {
+			*commands = append(*commands, fakeCommand{stdin: stdin, name: name, args: args})
+			return []byte(out), runErr
+		},
+		now: func() time.Time {
+			return time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+		},
+	}
+}
+
+func TestExchange(t *testing.T) {
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name            string
+		registry        model.RegistryLogin
+		out             string
+		expectedCommand fakeCommand
+		expected        *Credentials
+	}{
+		{
+			name:     "ecr",
+			registry: model.RegistryLogin{Server: "123456789012.dkr.ecr.eu-west-1.amazonaws.com"},
+			out:      "ecr-token\n",
+			expectedCommand: fakeCommand{
+				name: "/usr/bin/aws",
+				args: []string{"ecr", "get-login-password", "--region", "eu-west-1"},
+			},
+			expected: &Credentials{Server: "123456789012.dkr.ecr.eu-west-1.amazonaws.com", Username: "AWS", Secret: "ecr-token", ExpiresAt: now.Add(ecrTokenTTL)},
+		},
+		{
+			name:     "gcr",
+			registry: model.RegistryLogin{Server: "europe-west1-docker.pkg.dev"},
+			out:      "gcr-token",
+			expectedCommand: fakeCommand{
+				name: "/usr/bin/gcloud",
+				args: []string{"auth", "print-access-token"},
+			},
+			expected: &Credentials{Server: "europe-west1-docker.pkg.dev", Username: "oauth2accesstoken", Secret: "gcr-token", ExpiresAt: now.Add(gcrTokenTTL)},
+		},
+		{
+			name:     "acr",
+			registry: model.RegistryLogin{Server: "myregistry.azurecr.io"},
+			out:      "acr-token",
+			expectedCommand: fakeCommand{
+				name: "/usr/bin/az",
+				args: []string{"acr", "login", "--name", "myregistry", "--expose-token", "--output", "tsv", "--query", "accessToken"},
+			},
+			expected: &Credentials{Server: "myregistry.azurecr.io", Username: "00000000-0000-0000-0000-000000000000", Secret: "acr-token", ExpiresAt: now.Add(acrTokenTTL)},
+		},
+		{
+			name:     "helper",
+			registry: model.RegistryLogin{Server: "registry.example.com", Helper: "pass"},
+			out:      `{"ServerURL":"registry.example.com","Username":"user","Secret":"password"}`,
+			expectedCommand: fakeCommand{
+				stdin: "registry.example.com",
+				name:  "/usr/bin/docker-credential-pass",
+				args:  []string{"get"},
+			},
+			expected: &Credentials{Server: "registry.example.com", Username: "user", Secret: "password", ExpiresAt: now.Add(helperTokenTTL)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commands := []fakeCommand{}
+			e := newFakeExchanger(tt.out, nil, &commands)
+			creds, err := e.Exchange(context.Background(), tt.registry)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, creds)
+			assert.Equal(t, []fakeCommand{tt.expectedCommand}, commands)
+		})
+	}
+}
+
+func TestExchangeErrors(t *testing.T) {
+	t.Run("cli not installed", func(t *testing.T) {
+		commands := []fakeCommand{}
+		e := newFakeExchanger("", nil, &commands)
+		e.lookPath = func(string) (string, error) {
+			return "", errors.New("not found")
+		}
+		_, err := e.Exchange(context.Background(), model.RegistryLogin{Server: "gcr.io"})
+		assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+		assert.Empty(t, commands)
+	})
+
+	t.Run("cli fails", func(t *testing.T) {
+		commands := []fakeCommand{}
+		e := newFakeExchanger("", errors.New("expired session"), &commands)
+		_, err := e.Exchange(context.Background(), model.RegistryLogin{Server: "gcr.io"})
+		assert.ErrorContains(t, err, "expired session")
+	})
+
+	t.Run("empty token", func(t *testing.T) {
+		commands := []fakeCommand{}
+		e := newFakeExchanger("\n", nil, &commands)
+		_, err := e.Exchange(context.Background(), model.RegistryLogin{Server: "gcr.io"})
+		assert.Error(t, err)
+	})
+
+	t.Run("ecr without region", func(t *testing.T) {
+		commands := []fakeCommand{}
+		e := newFakeExchanger("token", nil, &commands)
+		_, err := e.Exchange(context.Background(), model.RegistryLogin{Server: "registry.example.com", Provider: model.RegistryProviderECR})
+		assert.Error(t, err)
+		assert.Empty(t, commands)
+	})
+
+	t.Run("unknown provider", func(t *testing.T) {
+		commands := []fakeCommand{}
+		e := newFakeExchanger("token", nil, &commands)
+		_, err := e.Exchange(context.Background(), model.RegistryLogin{Server: "registry.example.com"})
+		assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+	})
+
+	t.Run("helper without credentials", func(t *testing.T) {
+		commands := []fakeCommand{}
+		e := newFakeExchanger(`{"Username":"","Secret":""}`, nil, &commands)
+		_, err := e.Exchange(context.Background(), model.RegistryLogin{Server: "registry.example.com", Helper: "pass"})
+		assert.Error(t, err)
+	})
+}
+
+func TestGetECRRegion(t *testing.T) {
+	region, err := getECRRegion("https://123456789012.dkr.ecr.us-east-2.amazonaws.com/v2/")
+	require.NoError(t, err)
+	assert.Equal(t, "us-east-2", region)
+
+	_, err = getECRRegion("public.ecr.aws")
+	assert.Error(t, err)
+}
@@ -0,0 +1,103 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package login gets short-lived credentials of private external registries and stores them
+// to authenticate the builds against those registries
+package login
+
+import (
+	"context"
+
+	"github.com/docker/cli/cli/config/configfile"
+	"github.com/docker/cli/cli/config/types"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+type exchanger interface {
+	Exchange(ctx context.Context, r model.RegistryLogin) (*Credentials, error)
+}
+
+// Manager logs in to external registries reusing the stored credentials while they are valid
+type Manager struct {
+	exchanger exchanger
+	store     *Store
+}
+
+// NewManager returns a manager that stores the credentials in the okteto home folder
+func NewManager() *Manager {
+	return &Manager{
+		exchanger: NewExchanger(),
+		store:     NewStore(),
+	}
+}
+
+// Login returns the credentials of a registry. New credentials are requested if the stored ones are expired or force is true
+func (m *Manager) Login(ctx context.Context, r model.RegistryLogin, force bool) (*Credentials, error) {
+	if !force {
+		if creds, ok := m.store.Get(r.Server); ok {
+			oktetoLog.Infof("using stored credentials for '%s'", r.Server)
+			return creds, nil
+		}
+	}
+	creds, err := m.exchanger.Exchange(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.store.Save(creds); err != nil {
+		return nil, err
+	}
+	return creds, nil
+}
+
+// LoginAll logs in to every registry
+func (m *Manager) LoginAll(ctx context.Context, registries model.ManifestRegistries) error {
+	for _, r := range registries {
+		if _, err := m.Login(ctx, r, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Logout removes the stored credentials of a registry. It returns false if there were no credentials
+func (m *Manager) Logout(server string) (bool, error) {
+	return m.store.Delete(server)
+}
+
+// AddToDockerConfig adds the stored credentials to a docker config so builds authenticate with them.
+// They take precedence over the credential helpers configured for the same registries
+func AddToDockerConfig(cfg *configfile.ConfigFile) {
+	addToDockerConfig(cfg, NewStore().List())
+}
+
+func addToDockerConfig(cfg *configfile.ConfigFile, creds []Credentials) {
+	if len(creds) == 0 {
+		return
+	}
+	if cfg.AuthConfigs == nil {
+		cfg.AuthConfigs = map[string]types.AuthConfig{}
+	}
+	if cfg.CredentialHelpers == nil {
+		cfg.CredentialHelpers = map[string]string{}
+	}
+	for _, c := range creds {
+		cfg.AuthConfigs[c.Server] = types.AuthConfig{
+			ServerAddress: c.Server,
+			Username:      c.Username,
+			Password:      c.Secret,
+		}
+		// an empty helper makes the docker config read the credentials of the server from AuthConfigs
+		cfg.CredentialHelpers[getHost(c.Server)] = ""
+	}
+}
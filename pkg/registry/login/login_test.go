@@ -0,0 +1,89 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package login
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/docker/cli/cli/config/configfile"
+	"github.com/docker/cli/cli/config/types"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeExchanger struct {
+	exchanged []string
+	ttl       time.Duration
+}
+
+func (fe *fakeExchanger) Exchange(_ context.Context, r model.RegistryLogin) (*Credentials, error) {
+	fe.exchanged = append(fe.exchanged, r.Server)
+	return &Credentials{
+		Server:    r.Server,
+		Username:  "user",
+		Secret:    "token",
+		ExpiresAt: time.Now().Add(fe.ttl),
+	}, nil
+}
+
+func TestManagerLogin(t *testing.T) {
+	ctx := context.Background()
+	fe := &fakeExchanger{ttl: time.Hour}
+	m := &Manager{
+		exchanger: fe,
+		store:     newTestStore(t, time.Now()),
+	}
+	m.store.now = time.Now
+
+	registries := model.ManifestRegistries{{Server: "gcr.io"}, {Server: "myregistry.azurecr.io"}}
+	require.NoError(t, m.LoginAll(ctx, registries))
+	assert.Equal(t, []string{"gcr.io", "myregistry.azurecr.io"}, fe.exchanged)
+
+	// valid credentials are reused
+	require.NoError(t, m.LoginAll(ctx, registries))
+	assert.Len(t, fe.exchanged, 2)
+
+	_, err := m.Login(ctx, registries[0], true)
+	require.NoError(t, err)
+	assert.Len(t, fe.exchanged, 3)
+
+	deleted, err := m.Logout("gcr.io")
+	require.NoError(t, err)
+	assert.True(t, deleted)
+	_, err = m.Login(ctx, registries[0], false)
+	require.NoError(t, err)
+	assert.Len(t, fe.exchanged, 4)
+}
+
+func TestAddToDockerConfig(t *testing.T) {
+	cfg := configfile.New("config.json")
+	cfg.CredentialsStore = "desktop"
+	cfg.CredentialHelpers = map[string]string{"123456789012.dkr.ecr.us-east-1.amazonaws.com": "ecr-login"}
+
+	addToDockerConfig(cfg, []Credentials{
+		{Server: "123456789012.dkr.ecr.us-east-1.amazonaws.com", Username: "AWS", Secret: "ecr-token"},
+		{Server: "https://myregistry.azurecr.io", Username: "user", Secret: "acr-token"},
+	})
+
+	ac, err := cfg.GetAuthConfig("123456789012.dkr.ecr.us-east-1.amazonaws.com")
+	require.NoError(t, err)
+	assert.Equal(t, "ecr-token", ac.Password)
+
+	ac, err = cfg.GetAuthConfig("myregistry.azurecr.io")
+	require.NoError(t, err)
+	assert.Equal(t, types.AuthConfig{ServerAddress: "https://myregistry.azurecr.io", Username: "user", Password: "acr-token"}, ac)
+}
@@ -0,0 +1,132 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package login
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/okteto/okteto/pkg/config"
+)
+
+// renewBefore is the margin to consider credentials expired before they actually expire
+const renewBefore = 5 * time.Minute
+
+// Credentials are the short-lived credentials of a registry
+type Credentials struct {
+	ExpiresAt time.Time `json:"expiresAt"`
+	Server    string    `json:"server"`
+	Username  string    `json:"username"`
+	Secret    string    `json:"secret"`
+}
+
+// Store keeps the credentials of the external registries in a file only readable by the user
+type Store struct {
+	now  func() time.Time
+	path string
+}
+
+// NewStore returns the store of the okteto home folder
+func NewStore() *Store {
+	return &Store{
+		path: config.GetRegistryCredentialsPath(),
+		now:  time.Now,
+	}
+}
+
+func (s *Store) isValid(c Credentials) bool {
+	return s.now().Add(renewBefore).Before(c.ExpiresAt)
+}
+
+// Get returns the credentials of a registry if they are not expired
+func (s *Store) Get(server string) (*Credentials, bool) {
+	for _, c := range s.List() {
+		if c.Server == server {
+			creds := c
+			return &creds, true
+		}
+	}
+	return nil, false
+}
+
+// List returns the credentials that are not expired, sorted by server
+func (s *Store) List() []Credentials {
+	all, err := s.read()
+	if err != nil {
+		return []Credentials{}
+	}
+	result := []Credentials{}
+	for _, c := range all {
+		if s.isValid(c) {
+			result = append(result, c)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Server < result[j].Server
+	})
+	return result
+}
+
+// Save stores the credentials of a registry and removes the expired ones
+func (s *Store) Save(creds *Credentials) error {
+	all := map[string]Credentials{}
+	for _, c := range s.List() {
+		all[c.Server] = c
+	}
+	all[creds.Server] = *creds
+	return s.write(all)
+}
+
+// Delete removes the credentials of a registry. It returns false if there were no credentials
+func (s *Store) Delete(server string) (bool, error) {
+	all, err := s.read()
+	if err != nil {
+		return false, err
+	}
+	if _, ok := all[server]; !ok {
+		return false, nil
+	}
+	delete(all, server)
+	return true, s.write(all)
+}
+
+func (s *Store) read() (map[string]Credentials, error) {
+	all := map[string]Credentials{}
+	b, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return all, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &all); err != nil {
+		return nil, fmt.Errorf("failed to parse '%s': %w", s.path, err)
+	}
+	return all, nil
+}
+
+func (s *Store) write(all map[string]Credentials) error {
+	b, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(s.path, b, 0600); err != nil {
+		return fmt.Errorf("failed to write '%s': %w", s.path, err)
+	}
+	// the file may exist with broader permissions
+	return os.Chmod(s.path, 0600)
+}
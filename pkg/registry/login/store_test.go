@@ -0,0 +1,81 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package login
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStore(t *testing.T, now time.Time) *Store {
+	return &Store{
+		path: filepath.Join(t.TempDir(), "registry-credentials.json"),
+		now: func() time.Time {
+			return now
+		},
+	}
+}
+
+func TestStore(t *testing.T) {
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	s := newTestStore(t, now)
+
+	_, ok := s.Get("gcr.io")
+	assert.False(t, ok)
+
+	require.NoError(t, s.Save(&Credentials{Server: "gcr.io", Username: "oauth2accesstoken", Secret: "token", ExpiresAt: now.Add(time.Hour)}))
+	require.NoError(t, s.Save(&Credentials{Server: "myregistry.azurecr.io", Username: "user", Secret: "about-to-expire", ExpiresAt: now.Add(time.Minute)}))
+
+	info, err := os.Stat(s.path)
+	require.NoError(t, err)
+	if os.PathSeparator == '/' {
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	}
+
+	creds, ok := s.Get("gcr.io")
+	require.True(t, ok)
+	assert.Equal(t, "token", creds.Secret)
+
+	// credentials about to expire must be renewed
+	_, ok = s.Get("myregistry.azurecr.io")
+	assert.False(t, ok)
+	assert.Len(t, s.List(), 1)
+
+	deleted, err := s.Delete("gcr.io")
+	require.NoError(t, err)
+	assert.True(t, deleted)
+	_, ok = s.Get("gcr.io")
+	assert.False(t, ok)
+
+	deleted, err = s.Delete("gcr.io")
+	require.NoError(t, err)
+	assert.False(t, deleted)
+}
+
+func TestStoreSaveRemovesExpired(t *testing.T) {
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	s := newTestStore(t, now)
+	require.NoError(t, s.Save(&Credentials{Server: "gcr.io", Secret: "old", ExpiresAt: now.Add(-time.Hour)}))
+	require.NoError(t, s.Save(&Credentials{Server: "eu.gcr.io", Secret: "new", ExpiresAt: now.Add(time.Hour)}))
+
+	all, err := s.read()
+	require.NoError(t, err)
+	assert.Len(t, all, 1)
+	assert.Contains(t, all, "eu.gcr.io")
+}
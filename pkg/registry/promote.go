@@ -0,0 +1,246 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// PromotePlan describes the copy of an image from the registry of an okteto context to the registry of another one
+type PromotePlan struct {
+	// Source is the promoted image with its digest
+	Source string
+	// Destination is the tag of the image in the destination registry
+	Destination string
+	Blobs       []PromoteBlob
+	isIndex     bool
+}
+
+// PromoteBlob is a layer or config blob of the promoted image
+type PromoteBlob struct {
+	Digest string
+	Size   int64
+	// Exists is true when the blob is already in the destination registry, usually copied by a previous interrupted promotion
+	Exists bool
+}
+
+// Pending returns the number of blobs to copy and their size
+func (p *PromotePlan) Pending() (int, int64) {
+	count := 0
+	var size int64
+	for _, b := range p.Blobs {
+		if b.Exists {
+			continue
+		}
+		count++
+		size += b.Size
+	}
+	return count, size
+}
+
+// Promoter copies images between the registries of two okteto contexts.
+// The blobs already copied to the destination registry are skipped, so an interrupted promotion resumes where it stopped
+type Promoter struct {
+	source            OktetoRegistry
+	destination       OktetoRegistry
+	sourceClient      client
+	destinationClient client
+}
+
+// NewPromoter returns a promoter between two registries. bytesPerSecond limits the transfer rate when it is greater than 0
+func NewPromoter(source, destination configInterface, bytesPerSecond int64) *Promoter {
+	sourceClient := newOktetoRegistryClient(source)
+	if bytesPerSecond > 0 {
+		sourceClient.limiter = newRateLimiter(bytesPerSecond)
+	}
+	destinationClient := newOktetoRegistryClient(destination)
+	return &Promoter{
+		source:            OktetoRegistry{client: sourceClient, imageCtrl: NewImageCtrl(source), config: source},
+		destination:       OktetoRegistry{client: destinationClient, imageCtrl: NewImageCtrl(destination), config: destination},
+		sourceClient:      sourceClient,
+		destinationClient: destinationClient,
+	}
+}
+
+// GetDestinationImage returns the image of the destination namespace with the same repository and tag as an image of the source namespace
+func (p *Promoter) GetDestinationImage(sourceImage string) (string, error) {
+	expanded := p.source.imageCtrl.expandImageRegistries(sourceImage)
+	prefix := fmt.Sprintf("%s/%s/", p.source.config.GetRegistryURL(), p.source.config.GetNamespace())
+	if p.source.config.GetRegistryURL() == "" || !strings.HasPrefix(expanded, prefix) {
+		return "", fmt.Errorf("'%s' is not an image of the okteto registry of namespace '%s'", sourceImage, p.source.config.GetNamespace())
+	}
+	return fmt.Sprintf("%s/%s/%s", p.destination.config.GetRegistryURL(), p.destination.config.GetNamespace(), strings.TrimPrefix(expanded, prefix)), nil
+}
+
+// Plan resolves the digest of the source image and checks which of its blobs are already in the destination registry
+func (p *Promoter) Plan(ctx context.Context, sourceImage, destinationImage string) (*PromotePlan, error) {
+	srcRef, err := name.ParseReference(p.source.imageCtrl.expandImageRegistries(sourceImage))
+	if err != nil {
+		return nil, fmt.Errorf("invalid image '%s': %w", sourceImage, err)
+	}
+	dstRef, err := name.ParseReference(p.destination.imageCtrl.expandImageRegistries(destinationImage))
+	if err != nil {
+		return nil, fmt.Errorf("invalid image '%s': %w", destinationImage, err)
+	}
+
+	descriptor, err := p.sourceClient.GetDescriptor(srcRef.Name())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get '%s': %w", sourceImage, err)
+	}
+	plan := &PromotePlan{
+		Source:      fmt.Sprintf("%s@%s", srcRef.Context().Name(), descriptor.Digest),
+		Destination: dstRef.Name(),
+		isIndex:     descriptor.MediaType.IsIndex(),
+	}
+
+	images := []v1.Image{}
+	if plan.isIndex {
+		idx, err := descriptor.ImageIndex()
+		if err != nil {
+			return nil, err
+		}
+		manifest, err := idx.IndexManifest()
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range manifest.Manifests {
+			if !m.MediaType.IsImage() {
+				continue
+			}
+			img, err := idx.Image(m.Digest)
+			if err != nil {
+				return nil, err
+			}
+			images = append(images, img)
+		}
+	} else {
+		img, err := descriptor.Image()
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, img)
+	}
+
+	seen := map[v1.Hash]bool{}
+	for _, img := range images {
+		blobs, err := getImageBlobs(img)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the layers of '%s': %w", sourceImage, err)
+		}
+		for _, b := range blobs {
+			if seen[b.Digest] {
+				continue
+			}
+			seen[b.Digest] = true
+			exists, err := p.blobExists(ctx, dstRef, b.Digest)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check the layers of '%s': %w", dstRef.Name(), err)
+			}
+			plan.Blobs = append(plan.Blobs, PromoteBlob{Digest: b.Digest.String(), Size: b.Size, Exists: exists})
+		}
+	}
+	return plan, nil
+}
+
+// Promote copies the image of a plan to the destination registry.
+// The progress channel is closed when it returns, as remote.Write does, also when it fails before writing
+func (p *Promoter) Promote(ctx context.Context, plan *PromotePlan, progress chan<- v1.Update) error {
+	dstRef, err := name.ParseReference(plan.Destination)
+	if err != nil {
+		closeProgress(progress)
+		return err
+	}
+	descriptor, err := p.sourceClient.GetDescriptor(plan.Source)
+	if err != nil {
+		closeProgress(progress)
+		return fmt.Errorf("failed to get '%s': %w", plan.Source, err)
+	}
+
+	options := append(p.destinationClient.getOptions(dstRef), remote.WithContext(ctx))
+	if progress != nil {
+		options = append(options, remote.WithProgress(progress))
+	}
+	if plan.isIndex {
+		idx, err := descriptor.ImageIndex()
+		if err != nil {
+			closeProgress(progress)
+			return err
+		}
+		err = remote.WriteIndex(dstRef, idx, options...)
+		if err != nil {
+			return fmt.Errorf("failed to copy '%s' to '%s': %w", plan.Source, plan.Destination, err)
+		}
+		return nil
+	}
+	img, err := descriptor.Image()
+	if err != nil {
+		closeProgress(progress)
+		return err
+	}
+	if err := p.destinationClient.write(dstRef, img, options...); err != nil {
+		return fmt.Errorf("failed to copy '%s' to '%s': %w", plan.Source, plan.Destination, err)
+	}
+	return nil
+}
+
+// closeProgress closes the progress channel of a promotion that failed before writing the image
+func closeProgress(progress chan<- v1.Update) {
+	if progress != nil {
+		close(progress)
+	}
+}
+
+func (p *Promoter) blobExists(ctx context.Context, ref name.Reference, h v1.Hash) (bool, error) {
+	options := append(p.destinationClient.getOptions(ref), remote.WithContext(ctx))
+	layer, err := remote.Layer(ref.Context().Digest(h.String()), options...)
+	if err != nil {
+		return false, err
+	}
+	checker, ok := layer.(interface{ Exists() (bool, error) })
+	if !ok {
+		return false, nil
+	}
+	exists, err := checker.Exists()
+	if err != nil {
+		// the repository doesn't exist until the first promotion
+		oktetoLog.Infof("failed to check blob '%s': %s", h, err)
+		return false, nil
+	}
+	return exists, nil
+}
+
+type imageBlob struct {
+	Digest v1.Hash
+	Size   int64
+}
+
+// getImageBlobs returns the config and layers of an image
+func getImageBlobs(img v1.Image) ([]imageBlob, error) {
+	manifest, err := img.Manifest()
+	if err != nil {
+		return nil, err
+	}
+	result := []imageBlob{{Digest: manifest.Config.Digest, Size: manifest.Config.Size}}
+	for _, l := range manifest.Layers {
+		result = append(result, imageBlob{Digest: l.Digest, Size: l.Size})
+	}
+	return result, nil
+}
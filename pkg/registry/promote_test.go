@@ -0,0 +1,246 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	ggcrRegistry "github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/partial"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRegistry(t *testing.T) string {
+	server := httptest.NewServer(ggcrRegistry.New())
+	t.Cleanup(server.Close)
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	return u.Host
+}
+
+// testImage is an image whose layers are the given contents
+type testImage struct {
+	config   []byte
+	manifest []byte
+	layers   []v1.Layer
+}
+
+func newTestImage(t *testing.T, contents ...string) v1.Image {
+	img := &testImage{config: []byte(fmt.Sprintf(`{"architecture":"amd64","os":"linux","comment":%q}`, contents))}
+	configDigest, _, err := v1.SHA256(bytes.NewReader(img.config))
+	require.NoError(t, err)
+	manifest := v1.Manifest{
+		SchemaVersion: 2,
+		MediaType:     types.DockerManifestSchema2,
+		Config:        v1.Descriptor{MediaType: types.DockerConfigJSON, Size: int64(len(img.config)), Digest: configDigest},
+	}
+	for _, content := range contents {
+		layer := static.NewLayer([]byte(content), types.DockerLayer)
+		digest, err := layer.Digest()
+		require.NoError(t, err)
+		img.layers = append(img.layers, layer)
+		manifest.Layers = append(manifest.Layers, v1.Descriptor{MediaType: types.DockerLayer, Size: int64(len(content)), Digest: digest})
+	}
+	img.manifest, err = json.Marshal(manifest)
+	require.NoError(t, err)
+	result, err := partial.CompressedToImage(img)
+	require.NoError(t, err)
+	return result
+}
+
+func (i *testImage) RawConfigFile() ([]byte, error) { return i.config, nil }
+
+func (*testImage) MediaType() (types.MediaType, error) { return types.DockerManifestSchema2, nil }
+
+func (i *testImage) RawManifest() ([]byte, error) { return i.manifest, nil }
+
+func (i *testImage) LayerByDigest(h v1.Hash) (partial.CompressedLayer, error) {
+	for _, layer := range i.layers {
+		if digest, _ := layer.Digest(); digest == h {
+			return layer, nil
+		}
+	}
+	return nil, fmt.Errorf("layer %s not found", h)
+}
+
+// testIndex is a multi-platform index of test images
+type testIndex struct {
+	images   map[v1.Hash]v1.Image
+	manifest []byte
+}
+
+func newTestIndex(t *testing.T, images ...v1.Image) v1.ImageIndex {
+	idx := &testIndex{images: map[v1.Hash]v1.Image{}}
+	manifest := v1.IndexManifest{SchemaVersion: 2, MediaType: types.OCIImageIndex}
+	for _, img := range images {
+		digest, err := img.Digest()
+		require.NoError(t, err)
+		size, err := img.Size()
+		require.NoError(t, err)
+		idx.images[digest] = img
+		manifest.Manifests = append(manifest.Manifests, v1.Descriptor{MediaType: types.DockerManifestSchema2, Size: size, Digest: digest})
+	}
+	var err error
+	idx.manifest, err = json.Marshal(manifest)
+	require.NoError(t, err)
+	return idx
+}
+
+func (*testIndex) MediaType() (types.MediaType, error) { return types.OCIImageIndex, nil }
+
+func (i *testIndex) Digest() (v1.Hash, error) {
+	digest, _, err := v1.SHA256(bytes.NewReader(i.manifest))
+	return digest, err
+}
+
+func (i *testIndex) Size() (int64, error) { return int64(len(i.manifest)), nil }
+
+func (i *testIndex) IndexManifest() (*v1.IndexManifest, error) {
+	return v1.ParseIndexManifest(bytes.NewReader(i.manifest))
+}
+
+func (i *testIndex) RawManifest() ([]byte, error) { return i.manifest, nil }
+
+func (i *testIndex) Image(h v1.Hash) (v1.Image, error) {
+	img, ok := i.images[h]
+	if !ok {
+		return nil, fmt.Errorf("image %s not found", h)
+	}
+	return img, nil
+}
+
+func (*testIndex) ImageIndex(h v1.Hash) (v1.ImageIndex, error) {
+	return nil, fmt.Errorf("index %s not found", h)
+}
+
+func TestPromote(t *testing.T) {
+	ctx := context.Background()
+	sourceRegistry := newTestRegistry(t)
+	destinationRegistry := newTestRegistry(t)
+
+	img := newTestImage(t, "layer one", "layer two")
+	digest, err := img.Digest()
+	require.NoError(t, err)
+	sourceRef, err := name.ParseReference(fmt.Sprintf("%s/preview/api:sha", sourceRegistry))
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(sourceRef, img))
+
+	// a layer copied by a previous interrupted promotion
+	layers, err := img.Layers()
+	require.NoError(t, err)
+	destinationRepo, err := name.NewRepository(fmt.Sprintf("%s/staging/api", destinationRegistry))
+	require.NoError(t, err)
+	require.NoError(t, remote.WriteLayer(destinationRepo, layers[0]))
+
+	p := NewPromoter(
+		FakeConfig{IsOktetoClusterCfg: true, RegistryURL: sourceRegistry, Namespace: "preview"},
+		FakeConfig{IsOktetoClusterCfg: true, RegistryURL: destinationRegistry, Namespace: "staging"},
+		0,
+	)
+
+	destination, err := p.GetDestinationImage("okteto.dev/api:sha")
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("%s/staging/api:sha", destinationRegistry), destination)
+
+	plan, err := p.Plan(ctx, "okteto.dev/api:sha", destination)
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("%s/preview/api@%s", sourceRegistry, digest), plan.Source)
+	assert.Equal(t, destination, plan.Destination)
+	assert.Len(t, plan.Blobs, 3)
+	pending, size := plan.Pending()
+	assert.Equal(t, 2, pending)
+	assert.Equal(t, int64(len("layer two"))+plan.Blobs[0].Size, size)
+
+	require.NoError(t, p.Promote(ctx, plan, nil))
+
+	destinationRef, err := name.ParseReference(destination)
+	require.NoError(t, err)
+	promoted, err := remote.Head(destinationRef)
+	require.NoError(t, err)
+	assert.Equal(t, digest, promoted.Digest)
+
+	plan, err = p.Plan(ctx, "okteto.dev/api:sha", destination)
+	require.NoError(t, err)
+	pending, _ = plan.Pending()
+	assert.Equal(t, 0, pending)
+}
+
+func TestPromoteIndex(t *testing.T) {
+	ctx := context.Background()
+	sourceRegistry := newTestRegistry(t)
+	destinationRegistry := newTestRegistry(t)
+
+	idx := newTestIndex(t, newTestImage(t, "amd64 layer"), newTestImage(t, "arm64 layer"))
+	digest, err := idx.Digest()
+	require.NoError(t, err)
+	sourceImage := fmt.Sprintf("%s/preview/api:multiarch", sourceRegistry)
+	sourceRef, err := name.ParseReference(sourceImage)
+	require.NoError(t, err)
+	require.NoError(t, remote.WriteIndex(sourceRef, idx))
+
+	p := NewPromoter(
+		FakeConfig{RegistryURL: sourceRegistry, Namespace: "preview"},
+		FakeConfig{RegistryURL: destinationRegistry, Namespace: "staging"},
+		1024*1024,
+	)
+	destination := fmt.Sprintf("%s/staging/api:multiarch", destinationRegistry)
+	plan, err := p.Plan(ctx, sourceImage, destination)
+	require.NoError(t, err)
+	assert.Len(t, plan.Blobs, 4)
+
+	require.NoError(t, p.Promote(ctx, plan, nil))
+	destinationRef, err := name.ParseReference(destination)
+	require.NoError(t, err)
+	promoted, err := remote.Head(destinationRef)
+	require.NoError(t, err)
+	assert.Equal(t, digest, promoted.Digest)
+}
+
+func TestPromoteClosesProgressOnError(t *testing.T) {
+	p := NewPromoter(FakeConfig{Namespace: "preview"}, FakeConfig{Namespace: "staging"}, 0)
+	progress := make(chan v1.Update, 1)
+	err := p.Promote(context.Background(), &PromotePlan{Source: "okteto.dev/api:1.0", Destination: "INVALID:reference:"}, progress)
+	assert.Error(t, err)
+	_, open := <-progress
+	assert.False(t, open)
+}
+
+func TestGetDestinationImageNotOktetoRegistry(t *testing.T) {
+	p := NewPromoter(
+		FakeConfig{IsOktetoClusterCfg: true, RegistryURL: "registry.preview.okteto.dev", Namespace: "preview"},
+		FakeConfig{IsOktetoClusterCfg: true, RegistryURL: "registry.staging.okteto.dev", Namespace: "staging"},
+		0,
+	)
+	_, err := p.GetDestinationImage("docker.io/okteto/api:1.0")
+	assert.Error(t, err)
+
+	_, err = p.GetDestinationImage("registry.preview.okteto.dev/other/api:1.0")
+	assert.Error(t, err)
+
+	destination, err := p.GetDestinationImage("okteto.global/api:1.0")
+	assert.Error(t, err)
+	assert.Empty(t, destination)
+}
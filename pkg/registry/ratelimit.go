@@ -0,0 +1,86 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// rateLimiter limits the number of bytes per second read by all the readers sharing it
+type rateLimiter struct {
+	start          time.Time
+	now            func() time.Time
+	sleep          func(time.Duration)
+	bytesPerSecond int64
+	transferred    int64
+	mu             sync.Mutex
+}
+
+func newRateLimiter(bytesPerSecond int64) *rateLimiter {
+	return &rateLimiter{
+		bytesPerSecond: bytesPerSecond,
+		now:            time.Now,
+		sleep:          time.Sleep,
+	}
+}
+
+// wait blocks until reading n more bytes doesn't exceed the rate limit
+func (l *rateLimiter) wait(n int) {
+	l.mu.Lock()
+	if l.start.IsZero() {
+		l.start = l.now()
+	}
+	l.transferred += int64(n)
+	expected := time.Duration(float64(l.transferred) / float64(l.bytesPerSecond) * float64(time.Second))
+	elapsed := l.now().Sub(l.start)
+	l.mu.Unlock()
+	if expected > elapsed {
+		l.sleep(expected - elapsed)
+	}
+}
+
+// rateLimitedTransport limits the bytes per second of the bodies of the responses
+type rateLimitedTransport struct {
+	base    http.RoundTripper
+	limiter *rateLimiter
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.Body == nil {
+		return resp, err
+	}
+	resp.Body = &rateLimitedReader{rc: resp.Body, limiter: t.limiter}
+	return resp, nil
+}
+
+type rateLimitedReader struct {
+	rc      io.ReadCloser
+	limiter *rateLimiter
+}
+
+func (r *rateLimitedReader) Read(p []byte) (int, error) {
+	n, err := r.rc.Read(p)
+	if n > 0 {
+		r.limiter.wait(n)
+	}
+	return n, err
+}
+
+func (r *rateLimitedReader) Close() error {
+	return r.rc.Close()
+}
@@ -0,0 +1,46 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitedReader(t *testing.T) {
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	slept := time.Duration(0)
+	limiter := &rateLimiter{
+		bytesPerSecond: 100,
+		now: func() time.Time {
+			return now
+		},
+		sleep: func(d time.Duration) {
+			slept += d
+			now = now.Add(d)
+		},
+	}
+	r := &rateLimitedReader{rc: io.NopCloser(bytes.NewReader(make([]byte, 250))), limiter: limiter}
+
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Len(t, b, 250)
+	assert.Equal(t, 2500*time.Millisecond, slept)
+	require.NoError(t, r.Close())
+}
@@ -20,6 +20,7 @@ import (
 	"path/filepath"
 
 	"github.com/okteto/okteto/pkg/discovery"
+	"github.com/okteto/okteto/pkg/ignore"
 	"github.com/spf13/afero"
 )
 
@@ -57,6 +58,15 @@ func CreateDockerignoreFileWithFilesystem(cwd, tmpDir, manifestPathFlag string,
 	if string(dockerignoreContent) != "" {
 		content = string(dockerignoreContent) + "\n"
 	}
+
+	// the rules of the .oktetoignore file also apply to the remote deploy context
+	oktetoIgnore, err := ignore.NewFromFileWithFilesystem(filepath.Join(cwd, ignore.Filename), fs)
+	if err != nil {
+		return err
+	}
+	for _, pattern := range oktetoIgnore.Patterns() {
+		content = content + pattern + "\n"
+	}
 	if currentOktetoManifestFileName != "" {
 		content = content + fmt.Sprintf("!%s", currentOktetoManifestFileName) + "\n"
 	}
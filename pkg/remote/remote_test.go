@@ -98,3 +98,20 @@ func TestCreateDockerignoreFileWithFilesystem(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateDockerignoreFileWithOktetoIgnore(t *testing.T) {
+	wd := "/test/"
+	tempDir := t.TempDir()
+	fs := afero.NewMemMapFs()
+
+	assert.NoError(t, fs.MkdirAll(wd, 0755))
+	assert.NoError(t, afero.WriteFile(fs, filepath.Join(wd, ".oktetodeployignore"), []byte("secrets"), 0644))
+	assert.NoError(t, afero.WriteFile(fs, filepath.Join(wd, ".oktetoignore"), []byte("# deps\nnode_modules\n/dist/\n"), 0644))
+	assert.NoError(t, afero.WriteFile(fs, filepath.Join(wd, "okteto.yaml"), []byte("hola"), 0644))
+
+	err := CreateDockerignoreFileWithFilesystem(wd, tempDir, "", fs)
+	assert.NoError(t, err)
+	b, err := afero.ReadFile(fs, filepath.Join(tempDir, ".dockerignore"))
+	assert.NoError(t, err)
+	assert.Equal(t, "secrets\nnode_modules\ndist\n!okteto.yaml\n", string(b))
+}
@@ -20,16 +20,21 @@ import (
 	"fmt"
 	"path/filepath"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/okteto/okteto/pkg/filesystem"
+	"github.com/okteto/okteto/pkg/ignore"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/spf13/afero"
 )
 
+// diffHeaderPrefix is the prefix of the line starting the diff of each file
+const diffHeaderPrefix = "diff --git a/"
+
 var (
 	errNotCleanRepo    = errors.New("repository is not clean")
 	errTimeoutExceeded = errors.New("timeout exceeded")
@@ -38,7 +43,7 @@ var (
 
 type gitRepoController struct {
 	repoGetter repositoryGetterInterface
-	fs         afero.Fs
+	fs         *filesystem.CrossPlatformFs
 	path       string
 }
 
@@ -46,7 +51,7 @@ func newGitRepoController(path string) gitRepoController {
 	return gitRepoController{
 		repoGetter: gitRepositoryGetter{},
 		path:       path,
-		fs:         afero.NewOsFs(),
+		fs:         filesystem.NewCrossPlatformFs(afero.NewOsFs(), path),
 	}
 }
 
@@ -128,6 +133,37 @@ func (r gitRepoController) getSHA() (string, error) {
 	return head.Hash().String(), nil
 }
 
+// getCommitInfo returns the metadata of the last commit of the repository
+func (r gitRepoController) getCommitInfo() (CommitInfo, error) {
+	repo, err := r.repoGetter.get(r.path)
+	if err != nil {
+		return CommitInfo{}, fmt.Errorf("failed to analyze git repo: %w", err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return CommitInfo{}, fmt.Errorf("failed to analyze git repo: %w", err)
+	}
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return CommitInfo{}, fmt.Errorf("failed to get commit '%s': %w", head.Hash().String(), err)
+	}
+	info := newCommitInfo(commit)
+	info.Sha = head.Hash().String()
+	return info, nil
+}
+
+func newCommitInfo(commit *object.Commit) CommitInfo {
+	message := strings.TrimSpace(commit.Message)
+	if idx := strings.Index(message, "\n"); idx >= 0 {
+		message = strings.TrimSpace(message[:idx])
+	}
+	return CommitInfo{
+		Author:    commit.Author.Name,
+		Message:   message,
+		Timestamp: commit.Author.When,
+	}
+}
+
 type commitResponse struct {
 	err    error
 	commit string
@@ -201,6 +237,13 @@ func (r gitRepoController) GetDiffHash(contextDir string) (string, error) {
 		return "", fmt.Errorf("failed to analyze git repo: %w", err)
 	}
 
+	// files excluded by the .oktetoignore file don't change the build context hash
+	oktetoIgnore, err := ignore.NewFromFileWithFilesystem(filepath.Join(r.path, ignore.Filename), r.fs)
+	if err != nil {
+		oktetoLog.Infof("ignoring '%s': %s", ignore.Filename, err)
+		oktetoIgnore = nil
+	}
+
 	// go func that cancels the context after the timeout
 	go func() {
 		time.Sleep(timeout)
@@ -238,7 +281,7 @@ func (r gitRepoController) GetDiffHash(contextDir string) (string, error) {
 			return
 		}
 
-		untrackedFilesContent, err := r.getUntrackedContent(untrackedFiles)
+		untrackedFilesContent, err := r.getUntrackedContent(filterIgnoredFiles(untrackedFiles, oktetoIgnore))
 		select {
 		case <-timeoutCh:
 		case untrackedFilesCh <- untrackedFilesResponse{
@@ -259,19 +302,64 @@ func (r gitRepoController) GetDiffHash(contextDir string) (string, error) {
 		return "", untrackedFilesResponse.err
 	}
 
-	diffHash := sha256.Sum256([]byte(fmt.Sprintf("%s%s", diffResponse.diff, untrackedFilesResponse.untrackedFilesDiff)))
+	// line endings are normalized so checkouts with CRLF produce the same hash as the rest of platforms
+	diff := r.fs.NormalizeContent([]byte(filterIgnoredDiff(diffResponse.diff, oktetoIgnore)))
+	diffHash := sha256.Sum256([]byte(fmt.Sprintf("%s%s", diff, untrackedFilesResponse.untrackedFilesDiff)))
 	return fmt.Sprintf("%x", diffHash), nil
 }
 
+// filterIgnoredFiles returns the files not excluded by the ignore rules
+func filterIgnoredFiles(files []string, oktetoIgnore *ignore.Ignore) []string {
+	result := make([]string, 0, len(files))
+	for _, file := range files {
+		ignored, err := oktetoIgnore.Ignores(file)
+		if err != nil {
+			oktetoLog.Infof("failed to check if '%s' is ignored: %s", file, err)
+		}
+		if ignored {
+			continue
+		}
+		result = append(result, file)
+	}
+	return result
+}
+
+// filterIgnoredDiff removes from a git diff the sections of the files excluded by the ignore rules
+func filterIgnoredDiff(diff string, oktetoIgnore *ignore.Ignore) string {
+	if oktetoIgnore == nil || len(oktetoIgnore.Rules) == 0 {
+		return diff
+	}
+
+	var b strings.Builder
+	skip := false
+	for _, line := range strings.SplitAfter(diff, "\n") {
+		if strings.HasPrefix(line, diffHeaderPrefix) {
+			file := strings.TrimPrefix(strings.TrimSpace(line), diffHeaderPrefix)
+			if idx := strings.Index(file, " b/"); idx >= 0 {
+				file = file[:idx]
+			}
+			ignored, err := oktetoIgnore.Ignores(file)
+			if err != nil {
+				oktetoLog.Infof("failed to check if '%s' is ignored: %s", file, err)
+			}
+			skip = ignored
+		}
+		if !skip {
+			b.WriteString(line)
+		}
+	}
+	return b.String()
+}
+
 func (r gitRepoController) getUntrackedContent(files []string) (string, error) {
 	totalContent := ""
 	for _, file := range files {
 		absPath := filepath.Join(r.path, file)
-		content, err := afero.ReadFile(r.fs, absPath)
+		content, err := r.fs.ReadFile(absPath)
 		if err != nil {
 			return "", fmt.Errorf("failed to read file '%s': %w", absPath, err)
 		}
-		totalContent += fmt.Sprintf("%s:%s\n", file, content)
+		totalContent += fmt.Sprintf("%s:%s\n", r.fs.NormalizePath(file), content)
 	}
 	return totalContent, nil
 }
@@ -319,6 +407,10 @@ func (ogr oktetoGitRepository) Head() (*plumbing.Reference, error) {
 	return ogr.repo.Head()
 }
 
+func (ogr oktetoGitRepository) CommitObject(h plumbing.Hash) (*object.Commit, error) {
+	return ogr.repo.CommitObject(h)
+}
+
 func (ogr oktetoGitRepository) Log(o *git.LogOptions) (object.CommitIter, error) {
 	return ogr.repo.Log(o)
 }
@@ -365,6 +457,7 @@ type gitRepositoryInterface interface {
 	Worktree() (gitWorktreeInterface, error)
 	Head() (*plumbing.Reference, error)
 	Log(o *git.LogOptions) (object.CommitIter, error)
+	CommitObject(h plumbing.Hash) (*object.Commit, error)
 	GetLatestCommit(ctx context.Context, repoPath, dirpath string, localGit LocalGitInterface) (string, error)
 	GetDiff(ctx context.Context, repoPath, dirpath string, localGit LocalGitInterface) (string, error)
 	calculateUntrackedFiles(ctx context.Context, contextDir string) ([]string, error)
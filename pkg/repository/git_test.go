@@ -18,11 +18,16 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/okteto/okteto/pkg/filesystem"
+	"github.com/okteto/okteto/pkg/ignore"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIsClean(t *testing.T) {
@@ -300,6 +305,70 @@ func TestGetSHA(t *testing.T) {
 	}
 }
 
+func TestGetCommitInfo(t *testing.T) {
+	when := time.Date(2023, time.October, 10, 12, 0, 0, 0, time.UTC)
+	sha := "f1e2d3c4b5a69788123456789012345678900abc"
+	repoWithCommit := &fakeRepository{
+		head: plumbing.NewHashReference("test", plumbing.NewHash(sha)),
+		commitObject: &object.Commit{
+			Author:  object.Signature{Name: "Cindy Lopez", Email: "cindy@okteto.com", When: when},
+			Message: "Add movies endpoint\n\nThe endpoint returns the list of movies\n",
+		},
+	}
+
+	var tests = []struct {
+		repositoryGetter *fakeRepositoryGetter
+		expectedErr      error
+		name             string
+		expected         CommitInfo
+	}{
+		{
+			name: "commit info of the head",
+			repositoryGetter: &fakeRepositoryGetter{
+				repository: []*fakeRepository{repoWithCommit},
+			},
+			expected: CommitInfo{
+				Sha:       sha,
+				Author:    "Cindy Lopez",
+				Message:   "Add movies endpoint",
+				Timestamp: when,
+			},
+		},
+		{
+			name: "error getting the commit",
+			repositoryGetter: &fakeRepositoryGetter{
+				repository: []*fakeRepository{
+					{
+						head: plumbing.NewHashReference("test", plumbing.NewHash("test")),
+						err:  assert.AnError,
+					},
+				},
+			},
+			expectedErr: assert.AnError,
+		},
+		{
+			name: "error analyzing the repository",
+			repositoryGetter: &fakeRepositoryGetter{
+				repository: []*fakeRepository{},
+				err:        []error{assert.AnError},
+			},
+			expectedErr: assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := Repository{
+				control: gitRepoController{
+					repoGetter: tt.repositoryGetter,
+				},
+			}
+			info, err := repo.GetCommitInfo()
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expected, info)
+		})
+	}
+}
+
 func TestGetLatestDirCommit(t *testing.T) {
 	type config struct {
 		repositoryGetter *fakeRepositoryGetter
@@ -426,6 +495,7 @@ func TestGetDiffHash(t *testing.T) {
 			repo := Repository{
 				control: gitRepoController{
 					repoGetter: tt.config.repositoryGetter,
+					fs:         &filesystem.CrossPlatformFs{Fs: afero.NewMemMapFs()},
 				},
 			}
 			commit, err := repo.GetDiffHash(tt.buildContext)
@@ -570,7 +640,7 @@ func TestGetUntrackedContent(t *testing.T) {
 			},
 			output: output{
 				expectedErr:      nil,
-				untrackedContent: fmt.Sprintf("%s:test\n", testFile1),
+				untrackedContent: fmt.Sprintf("%s:test\n", filepath.ToSlash(testFile1)),
 			},
 		},
 		{
@@ -595,7 +665,7 @@ func TestGetUntrackedContent(t *testing.T) {
 			},
 			output: output{
 				expectedErr:      nil,
-				untrackedContent: fmt.Sprintf("%s:test\n%s:test2\n", testFile1, testFile2),
+				untrackedContent: fmt.Sprintf("%s:test\n%s:test2\n", filepath.ToSlash(testFile1), filepath.ToSlash(testFile2)),
 			},
 		},
 	}
@@ -604,7 +674,7 @@ func TestGetUntrackedContent(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			fs := tt.input.mockFs()
 			gitRepoController := gitRepoController{
-				fs: fs,
+				fs: &filesystem.CrossPlatformFs{Fs: fs},
 			}
 			content, err := gitRepoController.getUntrackedContent(tt.input.files)
 			assert.Equal(t, tt.output.untrackedContent, content)
@@ -612,3 +682,53 @@ func TestGetUntrackedContent(t *testing.T) {
 		})
 	}
 }
+
+func TestGetUntrackedContentWithLineEndingsNormalization(t *testing.T) {
+	testFile := filepath.Join("services", "api", "test.go")
+	fs := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(fs, testFile, []byte("line1\r\nline2\r\n"), 0644))
+
+	crlf := gitRepoController{
+		fs: &filesystem.CrossPlatformFs{Fs: fs, NormalizeLineEndings: true},
+	}
+	crlfContent, err := crlf.getUntrackedContent([]string{testFile})
+	assert.NoError(t, err)
+
+	lfFs := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(lfFs, testFile, []byte("line1\nline2\n"), 0644))
+	lf := gitRepoController{
+		fs: &filesystem.CrossPlatformFs{Fs: lfFs, NormalizeLineEndings: true},
+	}
+	lfContent, err := lf.getUntrackedContent([]string{testFile})
+	assert.NoError(t, err)
+
+	assert.Equal(t, lfContent, crlfContent)
+	assert.Equal(t, "services/api/test.go:line1\nline2\n\n", crlfContent)
+}
+
+func TestFilterIgnored(t *testing.T) {
+	oktetoIgnore, err := ignore.Parse([]byte("node_modules\n*.log\n"))
+	require.NoError(t, err)
+
+	files := []string{"main.go", "node_modules/react/index.js", "debug.log"}
+	assert.Equal(t, []string{"main.go"}, filterIgnoredFiles(files, oktetoIgnore))
+	assert.Equal(t, files, filterIgnoredFiles(files, nil))
+
+	diff := `diff --git a/main.go b/main.go
+index 1..2 100644
+--- a/main.go
++++ b/main.go
++fmt.Println("hello")
+diff --git a/debug.log b/debug.log
+index 3..4 100644
++error
+`
+	expected := `diff --git a/main.go b/main.go
+index 1..2 100644
+--- a/main.go
++++ b/main.go
++fmt.Println("hello")
+`
+	assert.Equal(t, expected, filterIgnoredDiff(diff, oktetoIgnore))
+	assert.Equal(t, diff, filterIgnoredDiff(diff, nil))
+}
@@ -36,6 +36,10 @@ func (or oktetoRemoteRepoController) getSHA() (string, error) {
 	return or.gitCommit, nil
 }
 
+func (or oktetoRemoteRepoController) getCommitInfo() (CommitInfo, error) {
+	return CommitInfo{}, fmt.Errorf("not-implemented")
+}
+
 func (or oktetoRemoteRepoController) GetLatestDirCommit(string) (string, error) {
 	return "", fmt.Errorf("not-implemented")
 }
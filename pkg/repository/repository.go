@@ -16,10 +16,10 @@ package repository
 import (
 	"context"
 	"net/url"
-	"os"
 	"strings"
+	"time"
 
-	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/config"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	giturls "github.com/whilp/git-urls"
 )
@@ -36,10 +36,20 @@ type Repository struct {
 type repositoryInterface interface {
 	isClean(ctx context.Context) (bool, error)
 	getSHA() (string, error)
+	getCommitInfo() (CommitInfo, error)
 	GetLatestDirCommit(string) (string, error)
 	GetDiffHash(string) (string, error)
 }
 
+// CommitInfo is the metadata of a commit of the repository
+type CommitInfo struct {
+	Timestamp time.Time
+	Sha       string
+	Author    string
+	// Message is the first line of the commit message
+	Message string
+}
+
 type repositoryURL struct {
 	url.URL
 }
@@ -68,8 +78,8 @@ func NewRepository(path string) Repository {
 
 	var controller repositoryInterface = newGitRepoController(path)
 	// check if we are inside a remote deploy
-	if v := os.Getenv(constants.OktetoDeployRemote); v != "" {
-		sha := os.Getenv(constants.OktetoGitCommitEnvVar)
+	if v, ok := config.EnvOktetoDeployRemote.Lookup(); ok && v != "" {
+		sha := config.EnvOktetoGitCommit.Value()
 		controller = newOktetoRemoteRepoController(sha)
 	}
 	return Repository{
@@ -89,6 +99,11 @@ func (r Repository) GetSHA() (string, error) {
 	return r.control.getSHA()
 }
 
+// GetCommitInfo returns the sha, author, message and timestamp of the last commit of the repository
+func (r Repository) GetCommitInfo() (CommitInfo, error) {
+	return r.control.getCommitInfo()
+}
+
 // IsEqual checks if another repository is the same from the one calling the function
 func (r Repository) IsEqual(otherRepo Repository) bool {
 	if r.url == nil || otherRepo.url == nil {
@@ -44,6 +44,7 @@ type fakeRepository struct {
 	err          error
 	worktree     *fakeWorktree
 	head         *plumbing.Reference
+	commitObject *object.Commit
 	commit       string
 	diff         string
 	failInCommit bool
@@ -59,6 +60,10 @@ func (fr fakeRepository) Head() (*plumbing.Reference, error) {
 	return fr.head, fr.err
 }
 
+func (fr fakeRepository) CommitObject(plumbing.Hash) (*object.Commit, error) {
+	return fr.commitObject, fr.err
+}
+
 func (fr fakeRepository) GetLatestCommit(context.Context, string, string, LocalGitInterface) (string, error) {
 	return fr.commit, fr.err
 }
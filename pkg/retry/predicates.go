@@ -0,0 +1,72 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"errors"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Always retries every error
+func Always(error) bool {
+	return true
+}
+
+// OnTransient retries the errors caused by temporary network issues
+func OnTransient(err error) bool {
+	return oktetoErrors.IsTransient(err)
+}
+
+// OnConflict retries the kubernetes update conflicts
+func OnConflict(err error) bool {
+	return k8sErrors.IsConflict(err)
+}
+
+// OnNotFound retries the errors of resources that are not created yet
+func OnNotFound(err error) bool {
+	return oktetoErrors.IsNotFound(err)
+}
+
+// OnErrors retries the errors matching any of the given ones
+func OnErrors(targets ...error) Predicate {
+	return func(err error) bool {
+		for _, target := range targets {
+			if errors.Is(err, target) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not retries the errors not retried by a predicate
+func Not(p Predicate) Predicate {
+	return func(err error) bool {
+		return !p(err)
+	}
+}
+
+// Any retries the errors retried by any of the given predicates
+func Any(predicates ...Predicate) Predicate {
+	return func(err error) bool {
+		for _, p := range predicates {
+			if p(err) {
+				return true
+			}
+		}
+		return false
+	}
+}
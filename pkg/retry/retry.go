@@ -0,0 +1,229 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package retry implements the retry policies shared by the okteto commands:
+// constant and exponential backoffs with jitter, attempt and time budgets and error predicates
+package retry
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// DefaultJitter is the jitter used to avoid many clients retrying an operation at the same time
+const DefaultJitter = 0.2
+
+// ErrBudgetExhausted is returned by Poll when the condition is not met within the policy budget
+var ErrBudgetExhausted = errors.New("retry budget exhausted")
+
+// Predicate decides if an error must be retried
+type Predicate func(error) bool
+
+// permanentError is an error that is never retried
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent marks an error as not retryable whatever the policy is. Do returns the original error
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return permanentError{err: err}
+}
+
+// Policy defines how an operation is retried
+type Policy struct {
+	// Retryable decides if an error must be retried. Every error is retried when it's nil
+	Retryable Predicate
+
+	// Name identifies the operation in the debug metrics
+	Name string
+
+	// InitialInterval is the time to wait after the first failed attempt
+	InitialInterval time.Duration
+
+	// MaxInterval caps the time to wait between attempts. There is no cap when it's zero
+	MaxInterval time.Duration
+
+	// Budget is the maximum time spent retrying. There is no limit when it's zero
+	Budget time.Duration
+
+	// Multiplier increases the interval on each attempt. Intervals are constant when it's lower or equal than 1
+	Multiplier float64
+
+	// Jitter randomizes each interval by up to this fraction of it
+	Jitter float64
+
+	// MaxAttempts is the maximum number of attempts, including the first one. There is no limit when it's zero
+	MaxAttempts int
+
+	// MinAttempts is the number of attempts made even if the budget is exhausted
+	MinAttempts int
+}
+
+// Constant returns a policy that waits the same interval between attempts
+func Constant(name string, interval time.Duration, maxAttempts int) Policy {
+	return Policy{
+		Name:            name,
+		InitialInterval: interval,
+		MaxAttempts:     maxAttempts,
+	}
+}
+
+// Exponential returns a policy that doubles the interval between attempts up to a maximum
+func Exponential(name string, initial, max time.Duration) Policy {
+	return Policy{
+		Name:            name,
+		InitialInterval: initial,
+		MaxInterval:     max,
+		Multiplier:      2,
+	}
+}
+
+// WithJitter returns a copy of the policy that randomizes each interval by up to the given fraction of it
+func (p Policy) WithJitter(jitter float64) Policy {
+	p.Jitter = jitter
+	return p
+}
+
+// Interval returns the time to wait after a given failed attempt, starting at zero
+func (p Policy) Interval(attempt int) time.Duration {
+	wait := p.InitialInterval
+	if p.Multiplier > 1 {
+		for i := 0; i < attempt && (p.MaxInterval == 0 || wait < p.MaxInterval); i++ {
+			wait = time.Duration(float64(wait) * p.Multiplier)
+		}
+	}
+	if p.MaxInterval > 0 && wait > p.MaxInterval {
+		wait = p.MaxInterval
+	}
+	if p.Jitter > 0 {
+		delta := float64(wait) * p.Jitter
+		// #nosec G404 jitter doesn't need a secure random generator
+		wait = time.Duration(float64(wait) - delta + rand.Float64()*2*delta)
+	}
+	return wait
+}
+
+func (p Policy) isRetryable(err error) bool {
+	var permanent permanentError
+	if errors.As(err, &permanent) {
+		return false
+	}
+	if p.Retryable == nil {
+		return true
+	}
+	return p.Retryable(err)
+}
+
+// exhausted returns if no more attempts can be made after a given number of attempts
+func (p Policy) exhausted(attempts int, start time.Time) bool {
+	if p.MaxAttempts > 0 && attempts >= p.MaxAttempts {
+		return true
+	}
+	if p.Budget > 0 && time.Since(start) >= p.Budget {
+		return attempts >= p.MinAttempts
+	}
+	return false
+}
+
+// Do runs an operation until it succeeds, its error is not retryable, the policy is exhausted or the context is done.
+// It returns the error of the last attempt
+func Do(ctx context.Context, p Policy, op func(context.Context) error) error {
+	start := time.Now()
+	attempts := 0
+	var err error
+	for {
+		attempts++
+		err = op(ctx)
+		if err == nil {
+			logMetrics(p, attempts, start, "success")
+			return nil
+		}
+		if !p.isRetryable(err) {
+			logMetrics(p, attempts, start, "not retryable")
+			var permanent permanentError
+			if errors.As(err, &permanent) {
+				return permanent.err
+			}
+			return err
+		}
+		if p.exhausted(attempts, start) {
+			logMetrics(p, attempts, start, "exhausted")
+			return err
+		}
+		oktetoLog.Debugf("retrying %s after attempt %d: %s", p.Name, attempts, err)
+		if ctxErr := wait(ctx, p.Interval(attempts-1)); ctxErr != nil {
+			logMetrics(p, attempts, start, "canceled")
+			return ctxErr
+		}
+	}
+}
+
+// Poll checks a condition until it's met, it returns an error or the policy is exhausted.
+// It returns ErrBudgetExhausted when the condition is not met in time
+func Poll(ctx context.Context, p Policy, condition func(context.Context) (bool, error)) error {
+	start := time.Now()
+	attempts := 0
+	for {
+		attempts++
+		done, err := condition(ctx)
+		if err != nil {
+			logMetrics(p, attempts, start, "failed")
+			return err
+		}
+		if done {
+			logMetrics(p, attempts, start, "success")
+			return nil
+		}
+		if p.exhausted(attempts, start) {
+			logMetrics(p, attempts, start, "exhausted")
+			return ErrBudgetExhausted
+		}
+		if ctxErr := wait(ctx, p.Interval(attempts-1)); ctxErr != nil {
+			logMetrics(p, attempts, start, "canceled")
+			return ctxErr
+		}
+	}
+}
+
+func wait(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func logMetrics(p Policy, attempts int, start time.Time, result string) {
+	if attempts <= 1 && result == "success" {
+		return
+	}
+	oktetoLog.Debugf("retry metrics: operation=%s attempts=%d elapsed=%s result=%s", p.Name, attempts, time.Since(start).Round(time.Millisecond), result)
+}
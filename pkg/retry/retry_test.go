@@ -0,0 +1,172 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var errTest = errors.New("test error")
+
+func TestInterval(t *testing.T) {
+	p := Exponential("test", time.Second, 30*time.Second)
+	expected := []time.Duration{1, 2, 4, 8, 16, 30, 30}
+	for i, e := range expected {
+		assert.Equal(t, e*time.Second, p.Interval(i))
+	}
+
+	c := Constant("test", 200*time.Millisecond, 3)
+	assert.Equal(t, 200*time.Millisecond, c.Interval(0))
+	assert.Equal(t, 200*time.Millisecond, c.Interval(5))
+}
+
+func TestWithJitter(t *testing.T) {
+	p := Constant("test", 100*time.Millisecond, 0)
+	jittered := p.WithJitter(0.5)
+	assert.Equal(t, 0.5, jittered.Jitter)
+	assert.Equal(t, float64(0), p.Jitter)
+}
+
+func TestIntervalJitter(t *testing.T) {
+	p := Constant("test", 100*time.Millisecond, 0)
+	p.Jitter = 0.5
+	for i := 0; i < 20; i++ {
+		wait := p.Interval(i)
+		assert.GreaterOrEqual(t, wait, 50*time.Millisecond)
+		assert.LessOrEqual(t, wait, 150*time.Millisecond)
+	}
+}
+
+func TestDo(t *testing.T) {
+	tests := []struct {
+		expected      error
+		policy        Policy
+		name          string
+		errs          []error
+		expectedCalls int
+	}{
+		{
+			name:          "success on first attempt",
+			policy:        Constant("test", time.Millisecond, 3),
+			errs:          []error{nil},
+			expectedCalls: 1,
+		},
+		{
+			name:          "success after retries",
+			policy:        Constant("test", time.Millisecond, 3),
+			errs:          []error{errTest, errTest, nil},
+			expectedCalls: 3,
+		},
+		{
+			name:          "max attempts exceeded",
+			policy:        Constant("test", time.Millisecond, 3),
+			errs:          []error{errTest, errTest, errTest, nil},
+			expected:      errTest,
+			expectedCalls: 3,
+		},
+		{
+			name: "not retryable error",
+			policy: Policy{
+				InitialInterval: time.Millisecond,
+				MaxAttempts:     3,
+				Retryable:       OnConflict,
+			},
+			errs:          []error{errTest, nil},
+			expected:      errTest,
+			expectedCalls: 1,
+		},
+		{
+			name:          "permanent error",
+			policy:        Constant("test", time.Millisecond, 3),
+			errs:          []error{Permanent(errTest), nil},
+			expected:      errTest,
+			expectedCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			err := Do(context.Background(), tt.policy, func(context.Context) error {
+				err := tt.errs[calls]
+				calls++
+				return err
+			})
+			assert.Equal(t, tt.expected, err)
+			assert.Equal(t, tt.expectedCalls, calls)
+		})
+	}
+}
+
+func TestDoContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := Do(ctx, Constant("test", time.Hour, 0), func(context.Context) error {
+		return errTest
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestPoll(t *testing.T) {
+	calls := 0
+	err := Poll(context.Background(), Constant("test", time.Millisecond, 5), func(context.Context) (bool, error) {
+		calls++
+		return calls == 3, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+
+	err = Poll(context.Background(), Constant("test", time.Millisecond, 2), func(context.Context) (bool, error) {
+		return false, nil
+	})
+	assert.ErrorIs(t, err, ErrBudgetExhausted)
+
+	err = Poll(context.Background(), Constant("test", time.Millisecond, 2), func(context.Context) (bool, error) {
+		return false, errTest
+	})
+	assert.ErrorIs(t, err, errTest)
+}
+
+func TestPollMinAttempts(t *testing.T) {
+	p := Policy{
+		InitialInterval: time.Millisecond,
+		Budget:          time.Nanosecond,
+		MinAttempts:     4,
+	}
+	calls := 0
+	err := Poll(context.Background(), p, func(context.Context) (bool, error) {
+		calls++
+		return false, nil
+	})
+	assert.ErrorIs(t, err, ErrBudgetExhausted)
+	assert.Equal(t, 4, calls)
+}
+
+func TestPredicates(t *testing.T) {
+	conflict := k8sErrors.NewConflict(schema.GroupResource{Resource: "deployments"}, "test", errTest)
+
+	assert.True(t, Always(errTest))
+	assert.True(t, OnConflict(conflict))
+	assert.False(t, OnConflict(errTest))
+	assert.True(t, OnErrors(errTest)(errors.Join(errors.New("wrapped"), errTest)))
+	assert.False(t, Not(Always)(errTest))
+	assert.True(t, Any(OnConflict, OnErrors(errTest))(errTest))
+	assert.False(t, Any(OnConflict, OnNotFound)(errTest))
+}
@@ -0,0 +1,136 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scan
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Severity is the severity of a vulnerability
+type Severity string
+
+const (
+	// SeverityUnknown is the severity of the vulnerabilities not classified yet
+	SeverityUnknown Severity = "UNKNOWN"
+
+	// SeverityLow is the low severity
+	SeverityLow Severity = "LOW"
+
+	// SeverityMedium is the medium severity
+	SeverityMedium Severity = "MEDIUM"
+
+	// SeverityHigh is the high severity
+	SeverityHigh Severity = "HIGH"
+
+	// SeverityCritical is the critical severity
+	SeverityCritical Severity = "CRITICAL"
+)
+
+// severities are sorted from the lowest to the highest
+var severities = []Severity{SeverityUnknown, SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical}
+
+// ParseSeverity returns the severity of a value like 'high' or 'CRITICAL'
+func ParseSeverity(value string) (Severity, error) {
+	s := Severity(strings.ToUpper(strings.TrimSpace(value)))
+	for _, severity := range severities {
+		if s == severity {
+			return s, nil
+		}
+	}
+	names := []string{}
+	for _, severity := range severities {
+		names = append(names, strings.ToLower(string(severity)))
+	}
+	return "", fmt.Errorf("invalid severity '%s': must be one of %s", value, strings.Join(names, ", "))
+}
+
+func (s Severity) rank() int {
+	for i, severity := range severities {
+		if s == severity {
+			return i
+		}
+	}
+	return 0
+}
+
+// Target is the image to scan. Archive takes precedence over Image when both are set
+type Target struct {
+	// Image is the reference of an image pushed to a registry
+	Image string
+	// Archive is the path of a docker archive or OCI layout directory
+	Archive string
+}
+
+// String returns the name of the target shown to the user
+func (t Target) String() string {
+	if t.Archive != "" {
+		return t.Archive
+	}
+	return t.Image
+}
+
+// Vulnerability represents a vulnerability found in a package of the image
+type Vulnerability struct {
+	ID               string
+	Package          string
+	InstalledVersion string
+	FixedVersion     string
+	Title            string
+	Severity         Severity
+}
+
+// Report is the result of scanning an image
+type Report struct {
+	Vulnerabilities []Vulnerability
+}
+
+// AtLeast returns the vulnerabilities with the given severity or higher, sorted by severity
+func (r *Report) AtLeast(min Severity) []Vulnerability {
+	result := []Vulnerability{}
+	for _, v := range r.Vulnerabilities {
+		if v.Severity.rank() >= min.rank() {
+			result = append(result, v)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Severity != result[j].Severity {
+			return result[i].Severity.rank() > result[j].Severity.rank()
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// Summary returns the number of vulnerabilities of each severity like 'CRITICAL: 1, HIGH: 3'
+func (r *Report) Summary() string {
+	counts := map[Severity]int{}
+	for _, v := range r.Vulnerabilities {
+		counts[v.Severity]++
+	}
+	parts := []string{}
+	for i := len(severities) - 1; i >= 0; i-- {
+		if counts[severities[i]] > 0 {
+			parts = append(parts, fmt.Sprintf("%s: %d", severities[i], counts[severities[i]]))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Scanner scans an image for vulnerabilities
+type Scanner interface {
+	Scan(ctx context.Context, target Target) (*Report, error)
+}
@@ -0,0 +1,55 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSeverity(t *testing.T) {
+	s, err := ParseSeverity(" high")
+	require.NoError(t, err)
+	assert.Equal(t, SeverityHigh, s)
+
+	s, err = ParseSeverity("CRITICAL")
+	require.NoError(t, err)
+	assert.Equal(t, SeverityCritical, s)
+
+	_, err = ParseSeverity("severe")
+	assert.Error(t, err)
+}
+
+func TestReport(t *testing.T) {
+	report := &Report{
+		Vulnerabilities: []Vulnerability{
+			{ID: "CVE-3", Severity: SeverityLow},
+			{ID: "CVE-2", Severity: SeverityHigh},
+			{ID: "CVE-1", Severity: SeverityCritical},
+			{ID: "CVE-4", Severity: SeverityHigh},
+		},
+	}
+
+	expected := []Vulnerability{
+		{ID: "CVE-1", Severity: SeverityCritical},
+		{ID: "CVE-2", Severity: SeverityHigh},
+		{ID: "CVE-4", Severity: SeverityHigh},
+	}
+	assert.Equal(t, expected, report.AtLeast(SeverityHigh))
+	assert.Len(t, report.AtLeast(SeverityCritical), 1)
+	assert.Len(t, report.AtLeast(SeverityUnknown), 4)
+	assert.Equal(t, "CRITICAL: 1, HIGH: 2, LOW: 1", report.Summary())
+}
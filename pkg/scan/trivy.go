@@ -0,0 +1,123 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scan
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+const trivyBinary = "trivy"
+
+var errTrivyNotFound = oktetoErrors.UserError{
+	E:    fmt.Errorf("the vulnerability scanner 'trivy' is not installed"),
+	Hint: "Install it following the instructions at https://aquasecurity.github.io/trivy/latest/getting-started/installation/",
+}
+
+// Trivy scans images with the trivy CLI. Registry credentials are read from the docker config
+type Trivy struct {
+	lookPath func(file string) (string, error)
+	run      func(ctx context.Context, name string, args ...string) ([]byte, error)
+}
+
+// NewTrivy returns a scanner that runs the trivy CLI
+func NewTrivy() *Trivy {
+	return &Trivy{
+		lookPath: exec.LookPath,
+		run:      runCommand,
+	}
+}
+
+// trivyReport is the json output of 'trivy image'
+type trivyReport struct {
+	Results []struct {
+		Target          string `json:"Target"`
+		Vulnerabilities []struct {
+			VulnerabilityID  string `json:"VulnerabilityID"`
+			PkgName          string `json:"PkgName"`
+			InstalledVersion string `json:"InstalledVersion"`
+			FixedVersion     string `json:"FixedVersion"`
+			Title            string `json:"Title"`
+			Severity         string `json:"Severity"`
+		} `json:"Vulnerabilities"`
+	} `json:"Results"`
+}
+
+// Scan runs 'trivy image' on the target and returns its vulnerabilities
+func (t *Trivy) Scan(ctx context.Context, target Target) (*Report, error) {
+	bin, err := t.lookPath(trivyBinary)
+	if err != nil {
+		oktetoLog.Infof("failed to find trivy: %s", err)
+		return nil, errTrivyNotFound
+	}
+
+	args := []string{"image", "--quiet", "--format", "json"}
+	if target.Archive != "" {
+		args = append(args, "--input", target.Archive)
+	} else {
+		args = append(args, target.Image)
+	}
+	out, err := t.run(ctx, bin, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan '%s': %w", target, err)
+	}
+	return parseTrivyReport(out)
+}
+
+func parseTrivyReport(out []byte) (*Report, error) {
+	raw := &trivyReport{}
+	if err := json.Unmarshal(out, raw); err != nil {
+		return nil, fmt.Errorf("invalid trivy report: %w", err)
+	}
+	report := &Report{Vulnerabilities: []Vulnerability{}}
+	for _, result := range raw.Results {
+		for _, v := range result.Vulnerabilities {
+			severity, err := ParseSeverity(v.Severity)
+			if err != nil {
+				severity = SeverityUnknown
+			}
+			report.Vulnerabilities = append(report.Vulnerabilities, Vulnerability{
+				ID:               v.VulnerabilityID,
+				Package:          v.PkgName,
+				InstalledVersion: v.InstalledVersion,
+				FixedVersion:     v.FixedVersion,
+				Title:            v.Title,
+				Severity:         severity,
+			})
+		}
+	}
+	return report, nil
+}
+
+func runCommand(ctx context.Context, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && stderr.Len() > 0 {
+			return nil, fmt.Errorf("%w: %s", err, bytes.TrimSpace(stderr.Bytes()))
+		}
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scan
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const trivyOutput = `{
+  "Results": [
+    {
+      "Target": "okteto.dev/api (debian 11.6)",
+      "Vulnerabilities": [
+        {"VulnerabilityID": "CVE-2023-0286", "PkgName": "openssl", "InstalledVersion": "1.1.1n", "FixedVersion": "1.1.1t", "Severity": "HIGH"},
+        {"VulnerabilityID": "CVE-2023-0001", "PkgName": "zlib", "InstalledVersion": "1.2.11", "Severity": "NEGLIGIBLE"}
+      ]
+    },
+    {
+      "Target": "app/package-lock.json"
+    }
+  ]
+}`
+
+func TestTrivyScan(t *testing.T) {
+	tests := []struct {
+		name         string
+		target       Target
+		expectedArgs []string
+	}{
+		{
+			name:         "image",
+			target:       Target{Image: "okteto.dev/api:1.0"},
+			expectedArgs: []string{"image", "--quiet", "--format", "json", "okteto.dev/api:1.0"},
+		},
+		{
+			name:         "archive",
+			target:       Target{Image: "okteto.dev/api:1.0", Archive: "image.tar"},
+			expectedArgs: []string{"image", "--quiet", "--format", "json", "--input", "image.tar"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var args []string
+			trivy := &Trivy{
+				lookPath: func(string) (string, error) { return "/usr/local/bin/trivy", nil },
+				run: func(_ context.Context, _ string, a ...string) ([]byte, error) {
+					args = a
+					return []byte(trivyOutput), nil
+				},
+			}
+			report, err := trivy.Scan(context.Background(), tt.target)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedArgs, args)
+			expected := []Vulnerability{
+				{ID: "CVE-2023-0286", Package: "openssl", InstalledVersion: "1.1.1n", FixedVersion: "1.1.1t", Severity: SeverityHigh},
+				{ID: "CVE-2023-0001", Package: "zlib", InstalledVersion: "1.2.11", Severity: SeverityUnknown},
+			}
+			assert.Equal(t, expected, report.Vulnerabilities)
+		})
+	}
+}
+
+func TestTrivyNotInstalled(t *testing.T) {
+	trivy := &Trivy{
+		lookPath: func(string) (string, error) { return "", errors.New("not found") },
+	}
+	_, err := trivy.Scan(context.Background(), Target{Image: "okteto.dev/api:1.0"})
+	assert.ErrorIs(t, err, errTrivyNotFound)
+}
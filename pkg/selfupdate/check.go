@@ -0,0 +1,75 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selfupdate
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/okteto/okteto/pkg/config"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// defaultCheckInterval is how often the cli checks for new versions by default
+const defaultCheckInterval = 24 * time.Hour
+
+type checkState struct {
+	LastCheck time.Time `json:"lastCheck"`
+}
+
+// ShouldCheck returns if the cli should check for new versions, and records the check if so
+func ShouldCheck(now time.Time) bool {
+	return shouldCheck(now, config.GetUpdateCheckPath())
+}
+
+func shouldCheck(now time.Time, path string) bool {
+	interval := getCheckInterval()
+	if interval <= 0 {
+		return false
+	}
+
+	state := &checkState{}
+	if b, err := os.ReadFile(path); err == nil {
+		if err := json.Unmarshal(b, state); err != nil {
+			oktetoLog.Infof("failed to read update check state: %s", err)
+		}
+	}
+	if now.Sub(state.LastCheck) < interval {
+		return false
+	}
+
+	state.LastCheck = now
+	b, err := json.Marshal(state)
+	if err != nil {
+		oktetoLog.Infof("failed to generate update check state: %s", err)
+		return true
+	}
+	if err := os.WriteFile(path, b, 0600); err != nil {
+		oktetoLog.Infof("failed to write update check state: %s", err)
+	}
+	return true
+}
+
+func getCheckInterval() time.Duration {
+	if v, ok := config.EnvOktetoUpdateCheckInterval.Lookup(); !ok || v == "" {
+		return defaultCheckInterval
+	}
+	interval, err := config.EnvOktetoUpdateCheckInterval.Duration()
+	if err != nil {
+		oktetoLog.Infof("%s, using the default interval", err)
+		return defaultCheckInterval
+	}
+	return interval
+}
@@ -0,0 +1,38 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selfupdate
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_shouldCheck(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "update-check.json")
+	now := time.Now()
+
+	assert.True(t, shouldCheck(now, path))
+	assert.False(t, shouldCheck(now.Add(time.Hour), path))
+	assert.True(t, shouldCheck(now.Add(25*time.Hour), path))
+
+	t.Setenv(constants.OktetoUpdateCheckIntervalEnvVar, "1m")
+	assert.True(t, shouldCheck(now.Add(26*time.Hour), path))
+
+	t.Setenv(constants.OktetoUpdateCheckIntervalEnvVar, "0")
+	assert.False(t, shouldCheck(now.Add(48*time.Hour), path))
+}
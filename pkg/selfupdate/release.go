@@ -0,0 +1,109 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package selfupdate updates the okteto binary to the latest release of a channel
+package selfupdate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/google/go-github/github"
+	"github.com/okteto/okteto/pkg/config"
+)
+
+const (
+	// StableChannel includes only the stable releases
+	StableChannel = "stable"
+
+	// BetaChannel includes the stable releases and the pre-releases
+	BetaChannel = "beta"
+)
+
+var (
+	// ErrInvalidChannel is returned when the channel is not stable or beta
+	ErrInvalidChannel = errors.New("invalid update channel: supported values are 'stable' and 'beta'")
+
+	errNoRelease = errors.New("failed to find latest release")
+)
+
+// Release represents an okteto release
+type Release struct {
+	Version *semver.Version
+	Tag     string
+}
+
+type listReleasesFunc func(ctx context.Context) ([]*github.RepositoryRelease, error)
+
+// GetChannel returns the update channel defined by the user. It defaults to the stable channel
+func GetChannel() string {
+	if channel, ok := config.EnvOktetoUpdateChannel.Lookup(); ok && channel != "" {
+		return channel
+	}
+	return StableChannel
+}
+
+// ValidateChannel checks that the channel is supported
+func ValidateChannel(channel string) error {
+	if channel != StableChannel && channel != BetaChannel {
+		return fmt.Errorf("%w: '%s'", ErrInvalidChannel, channel)
+	}
+	return nil
+}
+
+// GetLatestRelease returns the latest okteto release of a channel
+func GetLatestRelease(ctx context.Context, channel string) (*Release, error) {
+	return getLatestRelease(ctx, channel, listGithubReleases)
+}
+
+func getLatestRelease(ctx context.Context, channel string, list listReleasesFunc) (*Release, error) {
+	if err := ValidateChannel(channel); err != nil {
+		return nil, err
+	}
+	releases, err := list(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *Release
+	for _, r := range releases {
+		if r.GetDraft() {
+			continue
+		}
+		if r.GetPrerelease() && channel != BetaChannel {
+			continue
+		}
+		v, err := semver.NewVersion(r.GetTagName())
+		if err != nil {
+			continue
+		}
+		if latest == nil || v.GreaterThan(latest.Version) {
+			latest = &Release{Version: v, Tag: r.GetTagName()}
+		}
+	}
+	if latest == nil {
+		return nil, errNoRelease
+	}
+	return latest, nil
+}
+
+func listGithubReleases(ctx context.Context) ([]*github.RepositoryRelease, error) {
+	client := github.NewClient(nil)
+	releases, _, err := client.Repositories.ListReleases(ctx, "okteto", "okteto", &github.ListOptions{PerPage: 20})
+	if err != nil {
+		return nil, fmt.Errorf("fail to get releases from github: %w", err)
+	}
+	return releases, nil
+}
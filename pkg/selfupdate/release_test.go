@@ -0,0 +1,70 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selfupdate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-github/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRelease(tag string, prerelease, draft bool) *github.RepositoryRelease {
+	return &github.RepositoryRelease{TagName: &tag, Prerelease: &prerelease, Draft: &draft}
+}
+
+func Test_getLatestRelease(t *testing.T) {
+	releases := []*github.RepositoryRelease{
+		newRelease("2.16.0-beta.1", true, false),
+		newRelease("2.17.0", false, true),
+		newRelease("2.15.3", false, false),
+		newRelease("2.15.2", false, false),
+		newRelease("not-a-version", false, false),
+	}
+	list := func(context.Context) ([]*github.RepositoryRelease, error) {
+		return releases, nil
+	}
+
+	tests := []struct {
+		name     string
+		channel  string
+		expected string
+	}{
+		{name: "stable", channel: StableChannel, expected: "2.15.3"},
+		{name: "beta", channel: BetaChannel, expected: "2.16.0-beta.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := getLatestRelease(context.Background(), tt.channel, list)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, r.Tag)
+		})
+	}
+
+	_, err := getLatestRelease(context.Background(), "nightly", list)
+	assert.ErrorIs(t, err, ErrInvalidChannel)
+
+	_, err = getLatestRelease(context.Background(), StableChannel, func(context.Context) ([]*github.RepositoryRelease, error) {
+		return []*github.RepositoryRelease{newRelease("2.16.0-beta.1", true, false)}, nil
+	})
+	assert.ErrorIs(t, err, errNoRelease)
+
+	_, err = getLatestRelease(context.Background(), StableChannel, func(context.Context) ([]*github.RepositoryRelease, error) {
+		return nil, assert.AnError
+	})
+	assert.True(t, errors.Is(err, assert.AnError))
+}
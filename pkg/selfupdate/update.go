@@ -0,0 +1,203 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selfupdate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	okHttp "github.com/okteto/okteto/pkg/http"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	releasesURL = "https://github.com/okteto/okteto/releases/download"
+
+	// oldBinarySuffix is the suffix of the replaced binary on Windows, where a running binary can't be removed
+	oldBinarySuffix = ".old"
+
+	newBinarySuffix = ".new"
+)
+
+var errChecksumMismatch = errors.New("checksum mismatch")
+
+// Updater downloads and installs okteto releases
+type Updater struct {
+	client  *http.Client
+	baseURL string
+	goos    string
+	goarch  string
+}
+
+// NewUpdater returns an updater for the current platform
+func NewUpdater() *Updater {
+	return &Updater{
+		client:  &http.Client{Timeout: 5 * time.Minute, Transport: okHttp.SharedDefaultTransport()},
+		baseURL: releasesURL,
+		goos:    runtime.GOOS,
+		goarch:  runtime.GOARCH,
+	}
+}
+
+// GetBinaryPath returns the path of the running okteto binary
+func GetBinaryPath() (string, error) {
+	p, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("failed to get the path of the okteto binary: %w", err)
+	}
+	return filepath.EvalSymlinks(p)
+}
+
+// Update downloads the release, verifies its checksum and replaces the binary
+func (u *Updater) Update(ctx context.Context, r *Release, binPath string) error {
+	artifact, err := getArtifactName(u.goos, u.goarch)
+	if err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s/%s/%s", u.baseURL, r.Tag, artifact)
+
+	expected, err := u.getChecksum(ctx, url+".sha256")
+	if err != nil {
+		return err
+	}
+
+	newPath := binPath + newBinarySuffix
+	if err := u.download(ctx, url, newPath, expected); err != nil {
+		if err := os.Remove(newPath); err != nil && !os.IsNotExist(err) {
+			oktetoLog.Infof("failed to remove '%s': %s", newPath, err)
+		}
+		return err
+	}
+	return replaceBinary(newPath, binPath, u.goos)
+}
+
+func (u *Updater) get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download '%s': %w", url, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to download '%s': unexpected status code %d", url, resp.StatusCode)
+	}
+	return resp, nil
+}
+
+// getChecksum returns the sha256 published for an artifact
+func (u *Updater) getChecksum(ctx context.Context, url string) (string, error) {
+	resp, err := u.get(ctx, url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read checksum: %w", err)
+	}
+	fields := strings.Fields(string(b))
+	if len(fields) == 0 {
+		return "", fmt.Errorf("checksum '%s' is empty", url)
+	}
+	return strings.ToLower(fields[0]), nil
+}
+
+// download writes the artifact to path, failing if its sha256 doesn't match the expected one
+func (u *Updater) download(ctx context.Context, url, path, expected string) error {
+	resp, err := u.get(ctx, url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create '%s': %w", path, err)
+	}
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(f, h), resp.Body); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to download '%s': %w", url, err)
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	if got := hex.EncodeToString(h.Sum(nil)); got != expected {
+		return fmt.Errorf("%w: expected %s, got %s", errChecksumMismatch, expected, got)
+	}
+	return nil
+}
+
+// replaceBinary replaces dst with src. On Windows the running binary can't be overwritten, but it can be renamed
+func replaceBinary(src, dst, goos string) error {
+	if goos != "windows" {
+		if err := os.Rename(src, dst); err != nil {
+			return fmt.Errorf("failed to replace '%s': %w", dst, err)
+		}
+		return nil
+	}
+
+	old := dst + oldBinarySuffix
+	if err := os.Remove(old); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove '%s': %w", old, err)
+	}
+	if err := os.Rename(dst, old); err != nil {
+		return fmt.Errorf("failed to replace '%s': %w", dst, err)
+	}
+	if err := os.Rename(src, dst); err != nil {
+		if rErr := os.Rename(old, dst); rErr != nil {
+			oktetoLog.Infof("failed to restore '%s': %s", dst, rErr)
+		}
+		return fmt.Errorf("failed to replace '%s': %w", dst, err)
+	}
+	return nil
+}
+
+// CleanupOldBinary removes the binary replaced by a previous update on Windows
+func CleanupOldBinary(binPath string) {
+	if err := os.Remove(binPath + oldBinarySuffix); err != nil && !os.IsNotExist(err) {
+		oktetoLog.Infof("failed to remove old okteto binary: %s", err)
+	}
+}
+
+func getArtifactName(goos, goarch string) (string, error) {
+	switch goos {
+	case "windows":
+		return "okteto.exe", nil
+	case "darwin", "linux":
+		arch := goarch
+		if goarch == "amd64" {
+			arch = "x86_64"
+		} else if goarch != "arm64" {
+			return "", fmt.Errorf("architecture '%s' is not supported by the update command", goarch)
+		}
+		return fmt.Sprintf("okteto-%s-%s", strings.ToUpper(goos[:1])+goos[1:], arch), nil
+	default:
+		return "", fmt.Errorf("operating system '%s' is not supported by the update command", goos)
+	}
+}
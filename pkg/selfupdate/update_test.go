@@ -0,0 +1,114 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selfupdate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T, binary, checksum string) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/2.16.0/okteto-Linux-x86_64", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, binary)
+	})
+	mux.HandleFunc("/2.16.0/okteto-Linux-x86_64.sha256", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, "%s  okteto-Linux-x86_64\n", checksum)
+	})
+	s := httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestUpdate(t *testing.T) {
+	sum := sha256.Sum256([]byte("new-binary"))
+	release := &Release{Version: semver.MustParse("2.16.0"), Tag: "2.16.0"}
+
+	tests := []struct {
+		expectedErr error
+		name        string
+		checksum    string
+		expected    string
+	}{
+		{name: "valid-checksum", checksum: hex.EncodeToString(sum[:]), expected: "new-binary"},
+		{name: "invalid-checksum", checksum: "abcd", expected: "old-binary", expectedErr: errChecksumMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t, "new-binary", tt.checksum)
+			binPath := filepath.Join(t.TempDir(), "okteto")
+			require.NoError(t, os.WriteFile(binPath, []byte("old-binary"), 0755))
+
+			u := &Updater{client: s.Client(), baseURL: s.URL, goos: "linux", goarch: "amd64"}
+			err := u.Update(context.Background(), release, binPath)
+			assert.ErrorIs(t, err, tt.expectedErr)
+
+			b, err := os.ReadFile(binPath)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(b))
+			assert.NoFileExists(t, binPath+newBinarySuffix)
+		})
+	}
+}
+
+func Test_replaceBinaryWindows(t *testing.T) {
+	dir := t.TempDir()
+	dst := filepath.Join(dir, "okteto.exe")
+	src := dst + newBinarySuffix
+	require.NoError(t, os.WriteFile(dst, []byte("old"), 0755))
+	require.NoError(t, os.WriteFile(src, []byte("new"), 0755))
+
+	require.NoError(t, replaceBinary(src, dst, "windows"))
+	b, err := os.ReadFile(dst)
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(b))
+	assert.FileExists(t, dst+oldBinarySuffix)
+
+	CleanupOldBinary(dst)
+	assert.NoFileExists(t, dst+oldBinarySuffix)
+}
+
+func Test_getArtifactName(t *testing.T) {
+	tests := []struct {
+		goos     string
+		goarch   string
+		expected string
+		wantErr  bool
+	}{
+		{goos: "darwin", goarch: "arm64", expected: "okteto-Darwin-arm64"},
+		{goos: "linux", goarch: "amd64", expected: "okteto-Linux-x86_64"},
+		{goos: "windows", goarch: "amd64", expected: "okteto.exe"},
+		{goos: "linux", goarch: "386", wantErr: true},
+		{goos: "plan9", goarch: "amd64", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.goos+"-"+tt.goarch, func(t *testing.T) {
+			name, err := getArtifactName(tt.goos, tt.goarch)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.expected, name)
+		})
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	"github.com/okteto/okteto/pkg/k8s/deployments"
+	"github.com/okteto/okteto/pkg/k8s/pods"
+	"github.com/okteto/okteto/pkg/k8s/statefulsets"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/registry"
+	"gopkg.in/yaml.v2"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// currentVersion is the version of the snapshot file format
+	currentVersion = 1
+
+	// DeploymentKind is the kind of the deployments stored in a snapshot
+	DeploymentKind = "Deployment"
+	// StatefulSetKind is the kind of the statefulsets stored in a snapshot
+	StatefulSetKind = "StatefulSet"
+)
+
+// Snapshot represents the state of a dev environment at a given point in time
+type Snapshot struct {
+	CreatedAt  time.Time  `yaml:"createdAt"`
+	Name       string     `yaml:"name"`
+	Namespace  string     `yaml:"namespace"`
+	Repository string     `yaml:"repository,omitempty"`
+	Branch     string     `yaml:"branch,omitempty"`
+	Commit     string     `yaml:"commit,omitempty"`
+	Filename   string     `yaml:"filename,omitempty"`
+	Manifest   string     `yaml:"manifest,omitempty"`
+	Variables  []string   `yaml:"variables,omitempty"`
+	Resources  []Resource `yaml:"resources,omitempty"`
+	Version    int        `yaml:"version"`
+}
+
+// Resource represents a workload deployed by the dev environment
+type Resource struct {
+	// Images maps each container of the workload to its image pinned by digest
+	Images          map[string]string `yaml:"images,omitempty"`
+	Kind            string            `yaml:"kind"`
+	Name            string            `yaml:"name"`
+	ResourceVersion string            `yaml:"resourceVersion"`
+	Generation      int64             `yaml:"generation"`
+}
+
+// Take captures the state of the dev environment deployed with the given name
+func Take(ctx context.Context, name, namespace string, c kubernetes.Interface) (*Snapshot, error) {
+	data, err := pipeline.GetConfigmapData(ctx, name, namespace, c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dev environment '%s': %w", name, err)
+	}
+
+	s := &Snapshot{
+		Version:    currentVersion,
+		CreatedAt:  time.Now().UTC(),
+		Name:       name,
+		Namespace:  namespace,
+		Repository: data.Repository,
+		Branch:     data.Branch,
+		Commit:     data.Commit,
+		Filename:   data.Filename,
+		Manifest:   string(data.Manifest),
+		Variables:  data.Variables,
+	}
+
+	dList, err := pipeline.ListDeployments(ctx, name, namespace, c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments of '%s': %w", name, err)
+	}
+	for i := range dList {
+		d := dList[i]
+		images, err := getPinnedImages(ctx, namespace, d.Spec.Selector.MatchLabels, d.Spec.Template.Spec.Containers, c)
+		if err != nil {
+			return nil, err
+		}
+		s.Resources = append(s.Resources, Resource{
+			Kind:            DeploymentKind,
+			Name:            d.Name,
+			ResourceVersion: d.ResourceVersion,
+			Generation:      d.Generation,
+			Images:          images,
+		})
+	}
+
+	sfsList, err := pipeline.ListStatefulsets(ctx, name, namespace, c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets of '%s': %w", name, err)
+	}
+	for i := range sfsList {
+		sfs := sfsList[i]
+		images, err := getPinnedImages(ctx, namespace, sfs.Spec.Selector.MatchLabels, sfs.Spec.Template.Spec.Containers, c)
+		if err != nil {
+			return nil, err
+		}
+		s.Resources = append(s.Resources, Resource{
+			Kind:            StatefulSetKind,
+			Name:            sfs.Name,
+			ResourceVersion: sfs.ResourceVersion,
+			Generation:      sfs.Generation,
+			Images:          images,
+		})
+	}
+
+	sort.Slice(s.Resources, func(i, j int) bool {
+		if s.Resources[i].Kind != s.Resources[j].Kind {
+			return s.Resources[i].Kind < s.Resources[j].Kind
+		}
+		return s.Resources[i].Name < s.Resources[j].Name
+	})
+	return s, nil
+}
+
+// getPinnedImages returns the image of each container pinned to the digest its pods are running.
+// Containers without a running pod keep the image of the workload spec
+func getPinnedImages(ctx context.Context, namespace string, selector map[string]string, containers []apiv1.Container, c kubernetes.Interface) (map[string]string, error) {
+	digests := map[string]string{}
+	if len(selector) > 0 {
+		podList, err := pods.ListBySelector(ctx, namespace, selector, c)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pods: %w", err)
+		}
+		for _, p := range podList {
+			for _, status := range p.Status.ContainerStatuses {
+				if _, ok := digests[status.Name]; ok {
+					continue
+				}
+				if i := strings.LastIndex(status.ImageID, "@"); i != -1 {
+					digests[status.Name] = status.ImageID[i+1:]
+				}
+			}
+		}
+	}
+
+	images := map[string]string{}
+	for _, container := range containers {
+		digest, ok := digests[container.Name]
+		if !ok {
+			oktetoLog.Infof("no digest found for container '%s', using image '%s'", container.Name, container.Image)
+			images[container.Name] = container.Image
+			continue
+		}
+		repo, _ := registry.ImageCtrl{}.GetRepoNameAndTag(container.Image)
+		images[container.Name] = fmt.Sprintf("%s@%s", repo, digest)
+	}
+	return images, nil
+}
+
+// Write stores the snapshot in the given path
+func Write(s *Snapshot, path string) error {
+	bytes, err := yaml.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+	// the variables of the dev environment might contain sensitive values
+	if err := os.WriteFile(path, bytes, 0600); err != nil {
+		return fmt.Errorf("failed to write snapshot to '%s': %w", path, err)
+	}
+	return nil
+}
+
+// Read loads the snapshot stored in the given path
+func Read(path string) (*Snapshot, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot '%s': %w", path, err)
+	}
+	s := &Snapshot{}
+	if err := yaml.UnmarshalStrict(bytes, s); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot '%s': %w", path, err)
+	}
+	if s.Version != currentVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d", s.Version)
+	}
+	if s.Name == "" {
+		return nil, fmt.Errorf("invalid snapshot '%s': name is empty", path)
+	}
+	return s, nil
+}
+
+// PinImages updates the workloads of the snapshot to run the images stored in it
+func PinImages(ctx context.Context, s *Snapshot, c kubernetes.Interface) error {
+	for _, r := range s.Resources {
+		switch r.Kind {
+		case DeploymentKind:
+			d, err := deployments.Get(ctx, r.Name, s.Namespace, c)
+			if err != nil {
+				return fmt.Errorf("failed to get deployment '%s': %w", r.Name, err)
+			}
+			if !pinContainers(d.Spec.Template.Spec.Containers, r.Images) {
+				continue
+			}
+			if _, err := deployments.Deploy(ctx, d, c); err != nil {
+				return fmt.Errorf("failed to update deployment '%s': %w", r.Name, err)
+			}
+		case StatefulSetKind:
+			sfs, err := statefulsets.Get(ctx, r.Name, s.Namespace, c)
+			if err != nil {
+				return fmt.Errorf("failed to get statefulset '%s': %w", r.Name, err)
+			}
+			if !pinContainers(sfs.Spec.Template.Spec.Containers, r.Images) {
+				continue
+			}
+			if _, err := statefulsets.Deploy(ctx, sfs, c); err != nil {
+				return fmt.Errorf("failed to update statefulset '%s': %w", r.Name, err)
+			}
+		default:
+			oktetoLog.Infof("skipping resource '%s' of unknown kind '%s'", r.Name, r.Kind)
+		}
+	}
+	return nil
+}
+
+// pinContainers sets the images of the containers and returns if any of them changed
+func pinContainers(containers []apiv1.Container, images map[string]string) bool {
+	changed := false
+	for i := range containers {
+		image, ok := images[containers[i].Name]
+		if !ok || containers[i].Image == image {
+			continue
+		}
+		containers[i].Image = image
+		changed = true
+	}
+	return changed
+}
@@ -0,0 +1,154 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newDeployment(name, image string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       "test",
+			ResourceVersion: "42",
+			Generation:      3,
+			Labels:          map[string]string{model.DeployedByLabel: "movies"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": name}},
+			Template: apiv1.PodTemplateSpec{
+				Spec: apiv1.PodSpec{
+					Containers: []apiv1.Container{{Name: name, Image: image}},
+				},
+			},
+		},
+	}
+}
+
+func newPod(name, app, imageID string) *apiv1.Pod {
+	return &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test",
+			Labels:    map[string]string{"app": app},
+		},
+		Status: apiv1.PodStatus{
+			ContainerStatuses: []apiv1.ContainerStatus{{Name: app, ImageID: imageID}},
+		},
+	}
+}
+
+func newConfigmap(ctx context.Context, t *testing.T) *apiv1.ConfigMap {
+	c := fake.NewSimpleClientset()
+	cmap, err := pipeline.TranslateConfigMapAndDeploy(ctx, &pipeline.CfgData{
+		Name:       "movies",
+		Namespace:  "test",
+		Status:     pipeline.DeployedStatus,
+		Repository: "https://github.com/okteto/movies",
+		Branch:     "main",
+		Commit:     "1a2b3c4",
+		Filename:   "okteto.yml",
+		Manifest:   []byte("deploy:\n  - kubectl apply -f k8s"),
+		Variables:  []string{"A=1"},
+	}, c)
+	require.NoError(t, err)
+	return cmap
+}
+
+func TestTake(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset(
+		newConfigmap(ctx, t),
+		newDeployment("api", "okteto.dev/api:1.0"),
+		newDeployment("frontend", "nginx"),
+		newPod("api-1", "api", "docker-pullable://okteto.dev/api@sha256:abc"),
+	)
+
+	s, err := Take(ctx, "movies", "test", c)
+	require.NoError(t, err)
+	assert.Equal(t, currentVersion, s.Version)
+	assert.Equal(t, "https://github.com/okteto/movies", s.Repository)
+	assert.Equal(t, "main", s.Branch)
+	assert.Equal(t, "1a2b3c4", s.Commit)
+	assert.Equal(t, "okteto.yml", s.Filename)
+	assert.Equal(t, "deploy:\n  - kubectl apply -f k8s", s.Manifest)
+	assert.Equal(t, []string{"A=1"}, s.Variables)
+	assert.Equal(t, []Resource{
+		{Kind: DeploymentKind, Name: "api", ResourceVersion: "42", Generation: 3, Images: map[string]string{"api": "okteto.dev/api@sha256:abc"}},
+		{Kind: DeploymentKind, Name: "frontend", ResourceVersion: "42", Generation: 3, Images: map[string]string{"frontend": "nginx"}},
+	}, s.Resources)
+}
+
+func TestTakeNotFound(t *testing.T) {
+	_, err := Take(context.Background(), "movies", "test", fake.NewSimpleClientset())
+	assert.Error(t, err)
+}
+
+func TestWriteAndRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.yml")
+	s := &Snapshot{
+		Version:   currentVersion,
+		Name:      "movies",
+		Namespace: "test",
+		Variables: []string{"A=1"},
+		Resources: []Resource{{Kind: DeploymentKind, Name: "api", Images: map[string]string{"api": "okteto.dev/api@sha256:abc"}}},
+	}
+	require.NoError(t, Write(s, path))
+
+	result, err := Read(path)
+	require.NoError(t, err)
+	assert.Equal(t, s.Name, result.Name)
+	assert.Equal(t, s.Variables, result.Variables)
+	assert.Equal(t, s.Resources, result.Resources)
+
+	s.Version = 2
+	require.NoError(t, Write(s, path))
+	_, err = Read(path)
+	assert.Error(t, err)
+}
+
+func TestPinImages(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset(newDeployment("api", "okteto.dev/api:1.0"))
+	s := &Snapshot{
+		Namespace: "test",
+		Resources: []Resource{{Kind: DeploymentKind, Name: "api", Images: map[string]string{"api": "okteto.dev/api@sha256:abc"}}},
+	}
+
+	require.NoError(t, PinImages(ctx, s, c))
+
+	d, err := c.AppsV1().Deployments("test").Get(ctx, "api", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "okteto.dev/api@sha256:abc", d.Spec.Template.Spec.Containers[0].Image)
+}
+
+func TestPinImagesNotFound(t *testing.T) {
+	s := &Snapshot{
+		Namespace: "test",
+		Resources: []Resource{{Kind: StatefulSetKind, Name: "db"}},
+	}
+	assert.Error(t, PinImages(context.Background(), s, fake.NewSimpleClientset()))
+}
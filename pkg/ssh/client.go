@@ -19,8 +19,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/okteto/okteto/pkg/config"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
-	"github.com/okteto/okteto/pkg/model"
 	"golang.org/x/crypto/ssh"
 )
 
@@ -46,14 +46,13 @@ func getPrivateKey() (ssh.Signer, error) {
 func getOktetoSSHTimeout() time.Duration {
 	tOnce.Do(func() {
 		timeout = 10 * time.Second
-		t, ok := os.LookupEnv(model.OktetoSSHTimeoutEnvVar)
-		if !ok {
+		if _, ok := config.EnvOktetoSSHTimeout.Lookup(); !ok {
 			return
 		}
 
-		parsed, err := time.ParseDuration(t)
+		parsed, err := config.EnvOktetoSSHTimeout.Duration()
 		if err != nil {
-			oktetoLog.Infof("'%s' is not a valid duration, ignoring", t)
+			oktetoLog.Infof("%s, ignoring", err)
 			return
 		}
 
@@ -29,6 +29,7 @@ type forward struct {
 	pool          *pool
 	localAddress  string
 	remoteAddress string
+	lastError     string
 	lock          sync.Mutex
 	c             bool
 }
@@ -43,6 +44,28 @@ func (f *forward) setConnected() {
 	f.lock.Lock()
 	defer f.lock.Unlock()
 	f.c = true
+	f.lastError = ""
+}
+
+// setError marks the forward as disconnected because of err
+func (f *forward) setError(err error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.c = false
+	f.lastError = err.Error()
+}
+
+// setLastError records a connection error that doesn't stop the forward
+func (f *forward) setLastError(err error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.lastError = err.Error()
+}
+
+func (f *forward) health() (bool, string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.c, f.lastError
 }
 
 func (f *forward) setDisconnected() {
@@ -55,6 +78,7 @@ func (f *forward) start(ctx context.Context) {
 	localListener, err := net.Listen("tcp", f.localAddress)
 	if err != nil {
 		oktetoLog.Infof("%s -> failed to listen: %s", f.String(), err)
+		f.setError(fmt.Errorf("failed to listen: %w", err))
 		return
 	}
 
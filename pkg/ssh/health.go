@@ -0,0 +1,143 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssh
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/okteto/okteto/pkg/config"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	// ForwardKind is the kind of the forwards from a local port to the development container
+	ForwardKind = "forward"
+
+	// GlobalForwardKind is the kind of the forwards defined in the global forward section of the manifest
+	GlobalForwardKind = "global"
+
+	// ReverseKind is the kind of the forwards from the development container to a local port
+	ReverseKind = "reverse"
+
+	forwardsHealthFile = "okteto.forwards"
+)
+
+// ForwardStatus represents the health of a forward
+type ForwardStatus struct {
+	Kind      string `json:"kind"`
+	Local     string `json:"local"`
+	Remote    string `json:"remote"`
+	LastError string `json:"lastError,omitempty"`
+	Connected bool   `json:"connected"`
+}
+
+// ForwardsHealth is the health of the forwards of a development container
+type ForwardsHealth struct {
+	UpdatedAt time.Time       `json:"updatedAt"`
+	Forwards  []ForwardStatus `json:"forwards"`
+}
+
+func newForwardStatus(kind string, f *forward) ForwardStatus {
+	connected, lastError := f.health()
+	return ForwardStatus{
+		Kind:      kind,
+		Local:     f.localAddress,
+		Remote:    f.remoteAddress,
+		Connected: connected,
+		LastError: lastError,
+	}
+}
+
+// Status returns the health of every forward handled by the manager
+func (fm *ForwardManager) Status() []ForwardStatus {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+
+	result := []ForwardStatus{}
+	for _, f := range fm.forwards {
+		result = append(result, newForwardStatus(ForwardKind, f))
+	}
+	for _, f := range fm.globalForwards {
+		result = append(result, newForwardStatus(GlobalForwardKind, f))
+	}
+	for _, r := range fm.reverses {
+		result = append(result, newForwardStatus(ReverseKind, &r.forward))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Kind != result[j].Kind {
+			return result[i].Kind < result[j].Kind
+		}
+		return result[i].Local < result[j].Local
+	})
+	return result
+}
+
+// GetForwardsHealthPath returns the path of the file with the health of the forwards of a development container
+func GetForwardsHealthPath(namespace, devName string) string {
+	return filepath.Join(config.GetAppHome(namespace, devName), forwardsHealthFile)
+}
+
+// Supervise writes the health of the forwards into path every interval until the context is cancelled
+func (fm *ForwardManager) Supervise(ctx context.Context, path string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	defer func() {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			oktetoLog.Infof("failed to delete forwards health file: %s", err)
+		}
+	}()
+
+	for {
+		if err := writeForwardsHealth(path, &ForwardsHealth{UpdatedAt: time.Now(), Forwards: fm.Status()}); err != nil {
+			oktetoLog.Infof("failed to write forwards health file: %s", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func writeForwardsHealth(path string, health *ForwardsHealth) error {
+	b, err := json.Marshal(health)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0600)
+}
+
+// ReadForwardsHealth returns the health of the forwards stored in path
+func ReadForwardsHealth(path string) (*ForwardsHealth, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("forwards health not found: %w", err)
+		}
+		return nil, err
+	}
+	health := &ForwardsHealth{}
+	if err := json.Unmarshal(b, health); err != nil {
+		return nil, fmt.Errorf("invalid forwards health file: %w", err)
+	}
+	return health, nil
+}
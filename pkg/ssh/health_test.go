@@ -0,0 +1,85 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssh
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForwardManagerStatus(t *testing.T) {
+	fm := &ForwardManager{
+		forwards: map[int]*forward{
+			8080: {localAddress: "localhost:8080", remoteAddress: "0.0.0.0:8080", c: true},
+		},
+		globalForwards: map[int]*forward{
+			5432: {localAddress: "localhost:5432", remoteAddress: "postgres:5432"},
+		},
+		reverses: map[int]*reverse{
+			9229: {forward{localAddress: "localhost:9229", remoteAddress: "0.0.0.0:9229"}},
+		},
+	}
+	fm.reverses[9229].setError(errors.New("failed to listen on remote address"))
+
+	expected := []ForwardStatus{
+		{Kind: ForwardKind, Local: "localhost:8080", Remote: "0.0.0.0:8080", Connected: true},
+		{Kind: GlobalForwardKind, Local: "localhost:5432", Remote: "postgres:5432"},
+		{Kind: ReverseKind, Local: "localhost:9229", Remote: "0.0.0.0:9229", LastError: "failed to listen on remote address"},
+	}
+	assert.Equal(t, expected, fm.Status())
+
+	fm.reverses[9229].setConnected()
+	status := fm.Status()
+	assert.True(t, status[2].Connected)
+	assert.Empty(t, status[2].LastError)
+}
+
+func TestSupervise(t *testing.T) {
+	path := filepath.Join(t.TempDir(), forwardsHealthFile)
+	fm := &ForwardManager{
+		forwards: map[int]*forward{
+			8080: {localAddress: "localhost:8080", remoteAddress: "0.0.0.0:8080", c: true},
+		},
+		globalForwards: map[int]*forward{},
+		reverses:       map[int]*reverse{},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		fm.Supervise(ctx, path, 10*time.Millisecond)
+		close(done)
+	}()
+
+	require.Eventually(t, func() bool {
+		health, err := ReadForwardsHealth(path)
+		return err == nil && len(health.Forwards) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	cancel()
+	<-done
+	_, err := ReadForwardsHealth(path)
+	assert.Error(t, err)
+}
+
+func TestReadForwardsHealthNotFound(t *testing.T) {
+	_, err := ReadForwardsHealth(filepath.Join(t.TempDir(), forwardsHealthFile))
+	assert.Error(t, err)
+}
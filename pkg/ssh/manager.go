@@ -19,6 +19,7 @@ import (
 	"net"
 	"runtime"
 	"strconv"
+	"sync"
 	"time"
 
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
@@ -44,6 +45,7 @@ type ForwardManager struct {
 	pf              *k8sForward.PortForwardManager
 	pool            *pool
 	namespace       string
+	mu              sync.Mutex
 }
 
 // NewForwardManager returns a newly initialized instance of ForwardManager
@@ -185,6 +187,27 @@ func (fm *ForwardManager) Start(devPod, namespace string) error {
 	return nil
 }
 
+// StartForwards adds and starts new forwards once the manager is running
+func (fm *ForwardManager) StartForwards(forwards []forwardModel.Forward) error {
+	if fm.pool == nil {
+		return fmt.Errorf("SSH forward manager is not running")
+	}
+
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+
+	for _, f := range forwards {
+		if err := fm.Add(f); err != nil {
+			return err
+		}
+		ff := fm.forwards[f.Local]
+		ff.pool = fm.pool
+		go ff.start(fm.ctx)
+	}
+
+	return nil
+}
+
 // Stop sends a stop signal to all the connections
 func (fm *ForwardManager) Stop() {
 
@@ -46,44 +46,71 @@ func (fm *ForwardManager) AddReverse(f model.Reverse) error {
 	return nil
 }
 
+const (
+	reverseMinBackoff = 1 * time.Second
+	reverseMaxBackoff = 30 * time.Second
+)
+
+// start supervises the reverse forward: the remote listener is created again with an exponential backoff
+// every time it fails, until the context is cancelled
 func (r *reverse) start(ctx context.Context) {
+	backoff := reverseMinBackoff
+	for {
+		listened, err := r.serve(ctx)
+		if ctx.Err() != nil {
+			r.setDisconnected()
+			oktetoLog.Infof("%s -> done", r.String())
+			return
+		}
+		if listened {
+			backoff = reverseMinBackoff
+		}
+
+		r.setError(err)
+		oktetoLog.Infof("%s -> %s, retrying in %s", r.String(), err, backoff)
+		select {
+		case <-ctx.Done():
+			r.setDisconnected()
+			oktetoLog.Infof("%s -> done", r.String())
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > reverseMaxBackoff {
+			backoff = reverseMaxBackoff
+		}
+	}
+}
+
+// serve listens on the remote address and handles connections until the listener fails.
+// It returns if the remote listener was created
+func (r *reverse) serve(ctx context.Context) (bool, error) {
 	remoteListener, err := r.pool.getListener(r.remoteAddress)
 	if err != nil {
-		oktetoLog.Infof("%s -> failed to listen on remote address: %v", r.String(), err)
-		return
+		return false, fmt.Errorf("failed to listen on remote address: %w", err)
 	}
-	defer func() {
-		if err := remoteListener.Close(); err != nil {
-			oktetoLog.Debugf("Error closing remote listener '%s': %s", r.String(), err)
-		}
-	}()
 
+	done := make(chan struct{})
+	defer close(done)
 	go func() {
-		<-ctx.Done()
-		r.setDisconnected()
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
 		if err := remoteListener.Close(); err != nil {
-			oktetoLog.Infof("%s -> failed to close: %s", r.String(), err)
+			oktetoLog.Debugf("Error closing remote listener '%s': %s", r.String(), err)
 		}
-
-		oktetoLog.Infof("%s -> done", r.String())
 	}()
 
-	tick := time.NewTicker(500 * time.Millisecond)
+	r.setConnected()
 	for {
-		r.setConnected()
 		remoteConn, err := remoteListener.Accept()
 		if err != nil {
-			if !r.connected() {
-				return
-			}
-
-			oktetoLog.Infof("%s -> failed to accept connection: %v", r.String(), err)
-			<-tick.C
-			continue
+			return true, fmt.Errorf("failed to accept connection: %w", err)
 		}
 
 		go r.handle(ctx, remoteConn)
-
 	}
 }
 
@@ -98,6 +125,7 @@ func (r *reverse) handle(ctx context.Context, remote net.Conn) {
 	local, err := getConn(ctx, r.localAddress, defaultRetries)
 	if err != nil {
 		oktetoLog.Infof("%s -> failed to listen on local address: %v", r.String(), err)
+		r.setLastError(fmt.Errorf("failed to connect to local address: %w", err))
 		return
 	}
 
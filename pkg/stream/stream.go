@@ -31,6 +31,16 @@ const (
 	dataHeader       = "data: "
 )
 
+// StatusError is returned when the server responds to the stream request with an unexpected status code
+type StatusError struct {
+	Status     string
+	StatusCode int
+}
+
+func (e StatusError) Error() string {
+	return fmt.Sprintf("response from request: %s", e.Status)
+}
+
 func nextRetrySchedule(attempts int) time.Duration {
 	delaySecs := int64(math.Floor((math.Pow(2, float64(attempts)) - 1) * 0.5))
 	return time.Duration(delaySecs) * time.Second
@@ -58,8 +68,9 @@ func requestWithRetry(c *http.Client, url string) (*http.Response, error) {
 			return resp, nil
 		}
 
+		resp.Body.Close()
 		if resp.StatusCode != http.StatusInternalServerError {
-			return nil, fmt.Errorf("response from request: %s", resp.Status)
+			return nil, StatusError{Status: resp.Status, StatusCode: resp.StatusCode}
 		}
 
 		if attempts >= maxRetryAttempts {
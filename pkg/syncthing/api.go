@@ -23,7 +23,9 @@ import (
 	"strings"
 	"time"
 
+	okHttp "github.com/okteto/okteto/pkg/http"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/retry"
 )
 
 type addAPIKeyTransport struct {
@@ -46,38 +48,34 @@ func (akt *addAPIKeyTransport) RoundTrip(req *http.Request) (*http.Response, err
 func NewAPIClient() *http.Client {
 	return &http.Client{
 		Timeout:   60 * time.Second,
-		Transport: &addAPIKeyTransport{http.DefaultTransport},
+		Transport: &addAPIKeyTransport{okHttp.SharedDefaultTransport()},
 	}
 }
 
 // APICall calls the syncthing API and returns the parsed json or an error
 func (s *Syncthing) APICall(ctx context.Context, url, method string, code int, params map[string]string, local bool, body []byte, readBody bool, maxRetries int) ([]byte, error) {
-	retries := 0
-	ticker := time.NewTicker(200 * time.Millisecond)
-	for {
-		select {
-		case <-ticker.C:
-			result, err := s.callWithRetry(ctx, url, method, code, params, local, body, readBody)
-			if err == nil {
-				return result, nil
-			}
-
-			if strings.Contains(err.Error(), "connection refused") {
-				oktetoLog.Infof("syncthing is not ready, retrying local=%t", local)
-			} else {
-				oktetoLog.Infof("retrying syncthing call[%s] local=%t: %s", url, local, err.Error())
-			}
-
-			if retries >= maxRetries {
-				return nil, err
-			}
-			retries++
-
-		case <-ctx.Done():
+	var result []byte
+	policy := retry.Constant("syncthing "+url, 200*time.Millisecond, maxRetries+1).WithJitter(retry.DefaultJitter)
+	err := retry.Do(ctx, policy, func(ctx context.Context) error {
+		var err error
+		result, err = s.callWithRetry(ctx, url, method, code, params, local, body, readBody)
+		if err == nil {
+			return nil
+		}
+		if strings.Contains(err.Error(), "connection refused") {
+			oktetoLog.Infof("syncthing is not ready, retrying local=%t", local)
+		} else {
+			oktetoLog.Infof("retrying syncthing call[%s] local=%t: %s", url, local, err.Error())
+		}
+		return err
+	})
+	if err != nil {
+		if ctx.Err() != nil {
 			oktetoLog.Infof("call to syncthing.APICall %s canceled", url)
-			return nil, ctx.Err()
 		}
+		return nil, err
 	}
+	return result, nil
 }
 
 func (s *Syncthing) callWithRetry(ctx context.Context, url, method string, code int, params map[string]string, local bool, body []byte, readBody bool) ([]byte, error) {
@@ -0,0 +1,129 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncthing
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/okteto/okteto/pkg/config"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	// HistorySampleInterval is the time between two samples of the synchronization status
+	HistorySampleInterval = 10 * time.Second
+
+	// historyMaxSamples keeps two hours of samples in the history file
+	historyMaxSamples = 720
+
+	// HistoryRetention is the time window kept in the history file
+	HistoryRetention = historyMaxSamples * HistorySampleInterval
+)
+
+// HistorySample is a sample of the synchronization status persisted in the history file
+type HistorySample struct {
+	Time              time.Time `json:"time"`
+	Error             string    `json:"error,omitempty"`
+	Progress          float64   `json:"progress"`
+	InBytesPerSecond  float64   `json:"inBytesPerSecond"`
+	OutBytesPerSecond float64   `json:"outBytesPerSecond"`
+	PullErrors        int64     `json:"pullErrors"`
+}
+
+// GetTransferredBytes returns the total bytes received and sent by the syncthing instance
+func (s *Syncthing) GetTransferredBytes(ctx context.Context, local bool) (int64, int64, error) {
+	connections := &Connections{}
+	body, err := s.APICall(ctx, "rest/system/connections", "GET", http.StatusOK, nil, local, nil, true, maxRetries)
+	if err != nil {
+		oktetoLog.Infof("error getting connections: %s", err.Error())
+		if strings.Contains(err.Error(), "Client.Timeout") {
+			return 0, 0, oktetoErrors.ErrBusySyncthing
+		}
+		return 0, 0, oktetoErrors.ErrLostSyncthing
+	}
+	if err := json.Unmarshal(body, connections); err != nil {
+		oktetoLog.Infof("error unmarshalling connections: %s", err.Error())
+		return 0, 0, oktetoErrors.ErrLostSyncthing
+	}
+	return connections.Total.InBytesTotal, connections.Total.OutBytesTotal, nil
+}
+
+// GetHistoryFile returns the path to the file storing the synchronization status samples
+func GetHistoryFile(namespace, name string) string {
+	return filepath.Join(config.GetAppHome(namespace, name), "syncthing.history")
+}
+
+// AppendHistorySample adds a sample to the history file, dropping the oldest samples to keep the file bounded
+func AppendHistorySample(path string, sample HistorySample) error {
+	samples, err := LoadHistory(path)
+	if err != nil {
+		oktetoLog.Infof("discarding invalid history file '%s': %s", path, err)
+		samples = nil
+	}
+	samples = append(samples, sample)
+	if len(samples) > historyMaxSamples {
+		samples = samples[len(samples)-historyMaxSamples:]
+	}
+
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	for _, s := range samples {
+		if err := encoder.Encode(s); err != nil {
+			return err
+		}
+	}
+
+	tmp := fmt.Sprintf("%s.tmp", path)
+	if err := os.WriteFile(tmp, buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("failed to write history file: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// LoadHistory returns the samples of the history file, oldest first. A missing file returns no samples
+func LoadHistory(path string) ([]HistorySample, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	samples := []HistorySample{}
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		sample := HistorySample{}
+		if err := json.Unmarshal(line, &sample); err != nil {
+			return nil, err
+		}
+		samples = append(samples, sample)
+	}
+	return samples, scanner.Err()
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncthing
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadHistoryMissingFile(t *testing.T) {
+	samples, err := LoadHistory(filepath.Join(t.TempDir(), "syncthing.history"))
+	require.NoError(t, err)
+	assert.Empty(t, samples)
+}
+
+func TestAppendHistorySample(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "syncthing.history")
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < historyMaxSamples+5; i++ {
+		sample := HistorySample{
+			Time:     start.Add(time.Duration(i) * HistorySampleInterval),
+			Progress: float64(i % 100),
+		}
+		require.NoError(t, AppendHistorySample(path, sample))
+	}
+
+	samples, err := LoadHistory(path)
+	require.NoError(t, err)
+	require.Len(t, samples, historyMaxSamples)
+	assert.Equal(t, start.Add(5*HistorySampleInterval), samples[0].Time)
+	assert.Equal(t, start.Add(time.Duration(historyMaxSamples+4)*HistorySampleInterval), samples[len(samples)-1].Time)
+}
+
+func TestAppendHistorySampleInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "syncthing.history")
+	require.NoError(t, os.WriteFile(path, []byte("not json\n"), 0600))
+
+	sample := HistorySample{Time: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), Error: "lost"}
+	require.NoError(t, AppendHistorySample(path, sample))
+
+	samples, err := LoadHistory(path)
+	require.NoError(t, err)
+	assert.Equal(t, []HistorySample{sample}, samples)
+}
@@ -14,121 +14,366 @@
 package syncthing
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/clearsign"
 	getter "github.com/hashicorp/go-getter"
+	"github.com/okteto/okteto/pkg/config"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/filesystem"
+	okHttp "github.com/okteto/okteto/pkg/http"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
-	"github.com/okteto/okteto/pkg/model"
 )
 
 const (
 	syncthingVersion          = "1.27.1"
 	syncthingVersionStringNew = 3
 	syncthingVersionStringOld = 2
+
+	// checksumsFile is the file of a syncthing release with the sha256 of its artifacts, signed by the syncthing release key
+	checksumsFile = "sha256sum.txt.asc"
+
+	downloadTimeout = 5 * time.Minute
 )
 
 var (
-	versionRegex       = regexp.MustCompile(`syncthing v(\d+\.\d+\.\d+)(-rc\.[0-9])?.*`)
-	downloadURLFormats = map[string]string{
-		"linux":       "https://github.com/syncthing/syncthing/releases/download/v%[1]s/syncthing-linux-amd64-v%[1]s.tar.gz",
-		"arm":         "https://github.com/syncthing/syncthing/releases/download/v%[1]s/syncthing-linux-arm-v%[1]s.tar.gz",
-		"arm64":       "https://github.com/syncthing/syncthing/releases/download/v%[1]s/syncthing-linux-arm64-v%[1]s.tar.gz",
-		"darwinArm64": "https://github.com/syncthing/syncthing/releases/download/v%[1]s/syncthing-macos-arm64-v%[1]s.zip",
-		"darwin":      "https://github.com/syncthing/syncthing/releases/download/v%[1]s/syncthing-macos-amd64-v%[1]s.zip",
-		"windows":     "https://github.com/syncthing/syncthing/releases/download/v%[1]s/syncthing-windows-amd64-v%[1]s.zip",
+	versionRegex    = regexp.MustCompile(`syncthing v(\d+\.\d+\.\d+)(-rc\.[0-9])?.*`)
+	artifactFormats = map[string]string{
+		"linux":       "syncthing-linux-amd64-v%[1]s.tar.gz",
+		"arm":         "syncthing-linux-arm-v%[1]s.tar.gz",
+		"arm64":       "syncthing-linux-arm64-v%[1]s.tar.gz",
+		"darwinArm64": "syncthing-macos-arm64-v%[1]s.zip",
+		"darwin":      "syncthing-macos-amd64-v%[1]s.zip",
+		"windows":     "syncthing-windows-amd64-v%[1]s.zip",
 	}
+
+	errChecksumMismatch = errors.New("checksum mismatch")
+
+	downloadHint = "Check your network connection. If you are behind a proxy, set the HTTPS_PROXY environment variable. You can also set OKTETO_SYNCTHING_MIRROR to download syncthing from a mirror"
 )
 
-// Install installs syncthing locally
+// Installer downloads, verifies and caches the syncthing binaries
+type Installer struct {
+	client     *http.Client
+	mirror     string
+	signingKey string
+	binDir     string
+	goos       string
+	goarch     string
+}
+
+// NewInstaller returns an installer for the current platform. Downloads go through the proxy defined in the environment
+func NewInstaller() *Installer {
+	return &Installer{
+		client:     &http.Client{Timeout: downloadTimeout, Transport: okHttp.SharedDefaultTransport()},
+		mirror:     strings.TrimSuffix(config.EnvOktetoSyncthingMirror.Value(), "/"),
+		signingKey: config.EnvOktetoSyncthingSigningKey.Value(),
+		binDir:     getCacheDir(),
+		goos:       runtime.GOOS,
+		goarch:     runtime.GOARCH,
+	}
+}
+
+// Install installs the minimum version of syncthing in the okteto binaries cache
 func Install(p getter.ProgressTracker) error {
-	oktetoLog.Infof("installing syncthing for %s/%s", runtime.GOOS, runtime.GOARCH)
+	if _, err := NewInstaller().Install(context.Background(), GetMinimumVersion().String(), p); err != nil {
+		return err
+	}
+
+	if legacy := getLegacyInstallPath(); filesystem.FileExists(legacy) {
+		if err := os.Remove(legacy); err != nil {
+			oktetoLog.Infof("failed to delete %s: %s", legacy, err)
+		}
+	}
+	return nil
+}
 
-	minimum := GetMinimumVersion()
-	downloadURL, err := GetDownloadURL(runtime.GOOS, runtime.GOARCH, minimum.String())
+// Install downloads a syncthing release, verifies its checksum and extracts the binary into the cache. It returns the path of the binary
+func (i *Installer) Install(ctx context.Context, version string, p getter.ProgressTracker) (string, error) {
+	oktetoLog.Infof("installing syncthing %s for %s/%s", version, i.goos, i.goarch)
+
+	artifact, err := getArtifactName(i.goos, i.goarch, version)
 	if err != nil {
-		return err
+		return "", oktetoErrors.UserError{
+			E:    err,
+			Hint: fmt.Sprintf("Install syncthing %s manually in '%s'", version, i.getBinaryPath(version)),
+		}
 	}
+	url := fmt.Sprintf("%s/v%s/%s", i.mirror, version, artifact)
 
-	opts := []getter.ClientOption{}
-	if p != nil {
-		opts = []getter.ClientOption{getter.WithProgress(p)}
+	checksums, err := i.getChecksums(ctx, fmt.Sprintf("%s/v%s/%s", i.mirror, version, checksumsFile))
+	if err != nil {
+		return "", err
+	}
+	expected, ok := checksums[artifact]
+	if !ok {
+		return "", oktetoErrors.UserError{
+			E:    fmt.Errorf("the checksums of syncthing %s don't include '%s'", version, artifact),
+			Hint: "Check that OKTETO_SYNCTHING_MIRROR points to a mirror of the syncthing releases",
+		}
 	}
 
-	dir, err := os.MkdirTemp("", "")
+	binPath := i.getBinaryPath(version)
+	dir := filepath.Dir(binPath)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create '%s': %w", dir, err)
+	}
+	archive, err := os.CreateTemp(dir, "download-")
 	if err != nil {
-		return fmt.Errorf("failed to create temp download dir")
+		return "", fmt.Errorf("failed to create temp download file: %w", err)
 	}
+	defer func() {
+		if err := os.Remove(archive.Name()); err != nil && !os.IsNotExist(err) {
+			oktetoLog.Infof("failed to remove '%s': %s", archive.Name(), err)
+		}
+	}()
 
-	client := &getter.Client{
-		Src:     downloadURL,
-		Dst:     dir,
-		Mode:    getter.ClientModeDir,
-		Options: opts,
+	err = i.download(ctx, url, archive, expected, p)
+	if closeErr := archive.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		if errors.Is(err, errChecksumMismatch) {
+			return "", oktetoErrors.UserError{
+				E:    fmt.Errorf("the syncthing download from '%s' is corrupted: %w", url, err),
+				Hint: "Try again. If the problem persists, check the mirror defined by OKTETO_SYNCTHING_MIRROR",
+			}
+		}
+		return "", err
 	}
 
-	defer os.RemoveAll(dir)
+	if err := extractBinary(archive.Name(), artifact, getBinaryPathInDownload("", url, i.goos), binPath); err != nil {
+		return "", err
+	}
+
+	oktetoLog.Infof("downloaded syncthing %s to %s", version, binPath)
+	return binPath, nil
+}
 
-	if err := client.Get(); err != nil {
-		return fmt.Errorf("failed to download syncthing from %s: %w", client.Src, err)
+// getBinaryPath returns the path of a version of syncthing in the cache
+func (i *Installer) getBinaryPath(version string) string {
+	return filepath.Join(i.binDir, version, getBinaryNameFor(i.goos))
+}
+
+func (i *Installer) get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("failed to download '%s': %w", url, err),
+			Hint: downloadHint,
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("failed to download '%s': unexpected status code %d", url, resp.StatusCode),
+			Hint: downloadHint,
+		}
 	}
+	return resp, nil
+}
 
-	i := getInstallPath()
-	b := getBinaryPathInDownload(dir, downloadURL)
+// getChecksums returns the sha256 of the artifacts of a release. If a signing key is configured, the checksums must be signed by it
+func (i *Installer) getChecksums(ctx context.Context, url string) (map[string]string, error) {
+	resp, err := i.get(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read '%s': %w", url, err)
+	}
 
-	if _, err := os.Stat(b); err != nil {
-		return fmt.Errorf("%s didn't include the syncthing binary: %w", downloadURL, err)
+	content := b
+	block, _ := clearsign.Decode(b)
+	if block != nil {
+		content = block.Plaintext
+	}
+	if i.signingKey != "" {
+		if err := verifySignature(block, i.signingKey); err != nil {
+			return nil, oktetoErrors.UserError{
+				E:    fmt.Errorf("failed to verify the signature of '%s': %w", url, err),
+				Hint: "Check that OKTETO_SYNCTHING_SIGNING_KEY is the key that signs the syncthing releases of your mirror",
+			}
+		}
 	}
+	return parseChecksums(content), nil
+}
 
-	// skipcq GSC-G302 syncthing is a binary so it needs exec permissions
-	if err := os.Chmod(b, 0700); err != nil {
-		return fmt.Errorf("failed to set permissions to %s: %w", b, err)
+// verifySignature checks that a clearsigned block is signed by the armored public key stored in keyPath
+func verifySignature(block *clearsign.Block, keyPath string) error {
+	if block == nil {
+		return fmt.Errorf("the checksums are not signed")
+	}
+	key, err := os.ReadFile(keyPath)
+	if err != nil {
+		return fmt.Errorf("failed to read the signing key: %w", err)
 	}
+	keyring, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(key))
+	if err != nil {
+		return fmt.Errorf("failed to parse the signing key: %w", err)
+	}
+	_, err = openpgp.CheckDetachedSignature(keyring, bytes.NewReader(block.Bytes), block.ArmoredSignature.Body, nil)
+	return err
+}
 
-	if filesystem.FileExists(i) {
-		if err := os.Remove(i); err != nil {
-			oktetoLog.Infof("failed to delete %s, will try to overwrite: %s", i, err)
+// parseChecksums returns the checksums of a 'sha256sum' output by file name
+func parseChecksums(content []byte) map[string]string {
+	result := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
 		}
+		result[strings.TrimPrefix(fields[1], "*")] = strings.ToLower(fields[0])
 	}
+	return result
+}
 
-	if err := filesystem.CopyFile(b, i); err != nil {
-		return fmt.Errorf("failed to write %s: %w", i, err)
+// download writes the artifact to w, failing if its sha256 doesn't match the expected one
+func (i *Installer) download(ctx context.Context, url string, w io.Writer, expected string, p getter.ProgressTracker) error {
+	resp, err := i.get(ctx, url)
+	if err != nil {
+		return err
+	}
+	body := resp.Body
+	if p != nil {
+		body = p.TrackProgress(url, 0, resp.ContentLength, body)
+	}
+	defer body.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(w, h), body); err != nil {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("failed to download '%s': %w", url, err),
+			Hint: downloadHint,
+		}
 	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != expected {
+		return fmt.Errorf("%w: expected %s, got %s", errChecksumMismatch, expected, got)
+	}
+	return nil
+}
 
-	oktetoLog.Infof("downloaded syncthing %s to %s", syncthingVersion, i)
+// extractBinary copies the file 'name' of the tar.gz or zip archive of an artifact into dst with exec permissions
+func extractBinary(archive, artifact, name, dst string) error {
+	tmp := dst + ".tmp"
+	var err error
+	if strings.HasSuffix(artifact, ".zip") {
+		err = extractFromZip(archive, name, tmp)
+	} else {
+		err = extractFromTarGz(archive, name, tmp)
+	}
+	if err != nil {
+		if err := os.Remove(tmp); err != nil && !os.IsNotExist(err) {
+			oktetoLog.Infof("failed to remove '%s': %s", tmp, err)
+		}
+		return err
+	}
+	if err := os.Rename(tmp, dst); err != nil {
+		return fmt.Errorf("failed to write '%s': %w", dst, err)
+	}
 	return nil
 }
 
-// IsInstalled returns true if syncthing is installed
-func IsInstalled() bool {
-	_, err := os.Stat(getInstallPath())
-	return !os.IsNotExist(err)
+func extractFromTarGz(archive, name, dst string) error {
+	f, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("failed to read the syncthing archive: %w", err)
+	}
+	tr := tar.NewReader(gz)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return fmt.Errorf("the syncthing archive doesn't include '%s'", name)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read the syncthing archive: %w", err)
+		}
+		if h.Typeflag == tar.TypeReg && filepath.Clean(filepath.FromSlash(h.Name)) == name {
+			return writeBinary(tr, dst)
+		}
+	}
 }
 
-// ShouldUpgrade returns true if syncthing should be upgraded
-func ShouldUpgrade() bool {
-	if !IsInstalled() {
-		return true
+func extractFromZip(archive, name, dst string) error {
+	r, err := zip.OpenReader(archive)
+	if err != nil {
+		return fmt.Errorf("failed to read the syncthing archive: %w", err)
 	}
-	current := getInstalledVersion()
-	if current == nil {
-		return true
+	defer r.Close()
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() || filepath.Clean(filepath.FromSlash(f.Name)) != name {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return fmt.Errorf("failed to read the syncthing archive: %w", err)
+		}
+		defer rc.Close()
+		return writeBinary(rc, dst)
 	}
+	return fmt.Errorf("the syncthing archive doesn't include '%s'", name)
+}
 
-	minimum := GetMinimumVersion()
+func writeBinary(r io.Reader, dst string) error {
+	// skipcq GSC-G302 syncthing is a binary so it needs exec permissions
+	f, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0700)
+	if err != nil {
+		return fmt.Errorf("failed to create '%s': %w", dst, err)
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write '%s': %w", dst, err)
+	}
+	return f.Close()
+}
 
-	return minimum.GreaterThan(current)
+// IsInstalled returns true if a syncthing binary is available
+func IsInstalled() bool {
+	_, err := getBinaryPath()
+	return err == nil
+}
+
+// ShouldUpgrade returns true if the minimum version of syncthing is not in the cache
+func ShouldUpgrade() bool {
+	return !filesystem.FileExists(getInstallPath())
 }
 
 func GetMinimumVersion() *semver.Version {
-	v := os.Getenv(model.SyncthingVersionEnvVar)
+	v := config.EnvOktetoSyncthingVersion.Value()
 	if v == "" {
 		v = syncthingVersion
 	}
@@ -136,6 +381,68 @@ func GetMinimumVersion() *semver.Version {
 	return semver.MustParse(v)
 }
 
+// getBinaryPath returns the syncthing binary to run: the minimum version if it's installed, or the latest version in the cache otherwise.
+// Binaries installed by previous versions of okteto in the okteto folder are used as a last resort
+func getBinaryPath() (string, error) {
+	if p := getInstallPath(); filesystem.FileExists(p) {
+		return p, nil
+	}
+	if versions := getCachedVersions(getCacheDir()); len(versions) > 0 {
+		return getVersionBinaryPath(getCacheDir(), versions[len(versions)-1].Original()), nil
+	}
+	if p := getLegacyInstallPath(); filesystem.FileExists(p) {
+		return p, nil
+	}
+	return "", newNotInstalledError()
+}
+
+// newNotInstalledError returns the error shown when there is no syncthing binary to run
+func newNotInstalledError() error {
+	return oktetoErrors.UserError{
+		E:    fmt.Errorf("syncthing is not installed"),
+		Hint: fmt.Sprintf("Run 'okteto up' with network access to install it, or copy the syncthing %s binary to '%s'", GetMinimumVersion().String(), getInstallPath()),
+	}
+}
+
+// getCachedVersions returns the versions of the syncthing binaries in the cache sorted from oldest to latest
+func getCachedVersions(cacheDir string) []*semver.Version {
+	entries, err := os.ReadDir(cacheDir)
+	if err != nil {
+		return nil
+	}
+	result := []*semver.Version{}
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		v, err := semver.NewVersion(e.Name())
+		if err != nil || !filesystem.FileExists(getVersionBinaryPath(cacheDir, e.Name())) {
+			continue
+		}
+		result = append(result, v)
+	}
+	sort.Sort(semver.Collection(result))
+	return result
+}
+
+func getCacheDir() string {
+	return filepath.Join(config.GetBinFolder(), "syncthing")
+}
+
+// getInstallPath returns the path of the minimum version of syncthing in the cache
+func getInstallPath() string {
+	return getVersionBinaryPath(getCacheDir(), GetMinimumVersion().String())
+}
+
+// getLegacyInstallPath returns the path where previous versions of okteto installed syncthing
+func getLegacyInstallPath() string {
+	return filepath.Join(config.GetOktetoHome(), getBinaryName())
+}
+
+func getVersionBinaryPath(cacheDir, version string) string {
+	return filepath.Join(cacheDir, version, getBinaryName())
+}
+
 func getInstalledVersion() *semver.Version {
 	cmd := exec.Command(getInstallPath(), "--version")
 	output, err := cmd.Output()
@@ -176,34 +483,44 @@ func parseVersionFromOutput(output []byte) (*semver.Version, error) {
 
 // GetDownloadURL returns the url of the syncthing package for the OS and ARCH
 func GetDownloadURL(os, arch, version string) (string, error) {
+	artifact, err := getArtifactName(os, arch, version)
+	if err != nil {
+		return "", err
+	}
+	mirror := strings.TrimSuffix(config.EnvOktetoSyncthingMirror.Value(), "/")
+	return fmt.Sprintf("%s/v%s/%s", mirror, version, artifact), nil
+}
+
+// getArtifactName returns the name of the syncthing package for the OS and ARCH
+func getArtifactName(os, arch, version string) (string, error) {
 	switch os {
 	case "linux":
 		switch arch {
 		case "arm":
-			return fmt.Sprintf(downloadURLFormats["arm"], version), nil
+			return fmt.Sprintf(artifactFormats["arm"], version), nil
 		case "arm64":
-			return fmt.Sprintf(downloadURLFormats["arm64"], version), nil
+			return fmt.Sprintf(artifactFormats["arm64"], version), nil
 		case "amd64":
-			return fmt.Sprintf(downloadURLFormats["linux"], version), nil
+			return fmt.Sprintf(artifactFormats["linux"], version), nil
 		}
 	case "darwin":
 		switch arch {
 		case "arm64":
-			return fmt.Sprintf(downloadURLFormats["darwinArm64"], version), nil
+			return fmt.Sprintf(artifactFormats["darwinArm64"], version), nil
 		default:
-			return fmt.Sprintf(downloadURLFormats["darwin"], version), nil
+			return fmt.Sprintf(artifactFormats["darwin"], version), nil
 
 		}
 	case "windows":
-		return fmt.Sprintf(downloadURLFormats[os], version), nil
+		return fmt.Sprintf(artifactFormats[os], version), nil
 	}
 
 	return "", fmt.Errorf("%s-%s is not a supported platform", os, arch)
 }
 
-func getBinaryPathInDownload(dir, url string) string {
+func getBinaryPathInDownload(dir, url, goos string) string {
 	_, f := filepath.Split(url)
 	f = strings.TrimSuffix(f, ".tar.gz")
 	f = strings.TrimSuffix(f, ".zip")
-	return filepath.Join(dir, f, getBinaryName())
+	return filepath.Join(dir, f, getBinaryNameFor(goos))
 }
@@ -14,14 +14,30 @@
 package syncthing
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
 
 	"github.com/Masterminds/semver/v3"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/clearsign"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestInstall(t *testing.T) {
@@ -106,7 +122,7 @@ func Test_getBinaryPathInDownload(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			p := getBinaryPathInDownload("dir", u)
+			p := getBinaryPathInDownload("dir", u, runtime.GOOS)
 
 			if !strings.Contains(p, version) {
 				t.Errorf("got %s, expected to include %s", p, version)
@@ -208,3 +224,201 @@ func TestGetMinimumVersion(t *testing.T) {
 		})
 	}
 }
+
+func newTarGz(t *testing.T, name string, content []byte) []byte {
+	t.Helper()
+	var b bytes.Buffer
+	gz := gzip.NewWriter(&b)
+	tw := tar.NewWriter(gz)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+	_, err := tw.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return b.Bytes()
+}
+
+func newZip(t *testing.T, name string, content []byte) []byte {
+	t.Helper()
+	var b bytes.Buffer
+	zw := zip.NewWriter(&b)
+	w, err := zw.Create(name)
+	require.NoError(t, err)
+	_, err = w.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	return b.Bytes()
+}
+
+func sha256sum(b []byte) string {
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
+
+func newMirror(t *testing.T, files map[string][]byte) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, ok := files[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := w.Write(b)
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newTestInstaller(srv *httptest.Server, goos, goarch, binDir string) *Installer {
+	return &Installer{
+		client: srv.Client(),
+		mirror: srv.URL,
+		binDir: binDir,
+		goos:   goos,
+		goarch: goarch,
+	}
+}
+
+func TestInstallerInstall(t *testing.T) {
+	binary := []byte("syncthing binary")
+	linux := newTarGz(t, "syncthing-linux-amd64-v1.2.3/syncthing", binary)
+	windows := newZip(t, "syncthing-windows-amd64-v1.2.3/syncthing.exe", binary)
+	checksums := fmt.Sprintf("%s  syncthing-linux-amd64-v1.2.3.tar.gz\n%s  syncthing-windows-amd64-v1.2.3.zip\n", sha256sum(linux), sha256sum(windows))
+	srv := newMirror(t, map[string][]byte{
+		"/v1.2.3/sha256sum.txt.asc":                   []byte(checksums),
+		"/v1.2.3/syncthing-linux-amd64-v1.2.3.tar.gz": linux,
+		"/v1.2.3/syncthing-windows-amd64-v1.2.3.zip":  windows,
+	})
+
+	tests := []struct {
+		goos   string
+		goarch string
+	}{
+		{goos: "linux", goarch: "amd64"},
+		{goos: "windows", goarch: "amd64"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.goos, func(t *testing.T) {
+			dir := t.TempDir()
+			path, err := newTestInstaller(srv, tt.goos, tt.goarch, dir).Install(context.Background(), "1.2.3", nil)
+			require.NoError(t, err)
+			assert.Equal(t, filepath.Join(dir, "1.2.3", getBinaryNameFor(tt.goos)), path)
+
+			b, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, binary, b)
+
+			entries, err := os.ReadDir(filepath.Dir(path))
+			require.NoError(t, err)
+			assert.Len(t, entries, 1)
+		})
+	}
+}
+
+func TestInstallerInstallErrors(t *testing.T) {
+	linux := newTarGz(t, "syncthing-linux-amd64-v1.2.3/syncthing", []byte("syncthing binary"))
+	srv := newMirror(t, map[string][]byte{
+		"/v1.2.3/sha256sum.txt.asc":                   []byte(fmt.Sprintf("%s  syncthing-linux-amd64-v1.2.3.tar.gz\n", sha256sum([]byte("other")))),
+		"/v1.2.3/syncthing-linux-amd64-v1.2.3.tar.gz": linux,
+	})
+
+	_, err := newTestInstaller(srv, "linux", "amd64", t.TempDir()).Install(context.Background(), "1.2.3", nil)
+	assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+	assert.ErrorContains(t, err, errChecksumMismatch.Error())
+
+	_, err = newTestInstaller(srv, "linux", "arm64", t.TempDir()).Install(context.Background(), "1.2.3", nil)
+	assert.ErrorContains(t, err, "don't include 'syncthing-linux-arm64-v1.2.3.tar.gz'")
+
+	_, err = newTestInstaller(srv, "linux", "amd64", t.TempDir()).Install(context.Background(), "1.2.4", nil)
+	assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+	assert.ErrorContains(t, err, "unexpected status code 404")
+
+	_, err = newTestInstaller(srv, "solaris", "amd64", t.TempDir()).Install(context.Background(), "1.2.3", nil)
+	assert.ErrorContains(t, err, "not a supported platform")
+}
+
+func TestInstallerSignedChecksums(t *testing.T) {
+	newKey := func(t *testing.T) (*openpgp.Entity, string) {
+		t.Helper()
+		entity, err := openpgp.NewEntity("release", "", "release@example.com", nil)
+		require.NoError(t, err)
+		var b bytes.Buffer
+		w, err := armor.Encode(&b, openpgp.PublicKeyType, nil)
+		require.NoError(t, err)
+		require.NoError(t, entity.Serialize(w))
+		require.NoError(t, w.Close())
+		path := filepath.Join(t.TempDir(), "key.asc")
+		require.NoError(t, os.WriteFile(path, b.Bytes(), 0600))
+		return entity, path
+	}
+	signer, signerKey := newKey(t)
+	_, otherKey := newKey(t)
+
+	linux := newTarGz(t, "syncthing-linux-amd64-v1.2.3/syncthing", []byte("syncthing binary"))
+	var signed bytes.Buffer
+	w, err := clearsign.Encode(&signed, signer.PrivateKey, nil)
+	require.NoError(t, err)
+	_, err = fmt.Fprintf(w, "%s  syncthing-linux-amd64-v1.2.3.tar.gz\n", sha256sum(linux))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	srv := newMirror(t, map[string][]byte{
+		"/v1.2.3/sha256sum.txt.asc":                   signed.Bytes(),
+		"/v1.2.3/syncthing-linux-amd64-v1.2.3.tar.gz": linux,
+	})
+
+	i := newTestInstaller(srv, "linux", "amd64", t.TempDir())
+	_, err = i.Install(context.Background(), "1.2.3", nil)
+	require.NoError(t, err)
+
+	i.signingKey = signerKey
+	_, err = i.Install(context.Background(), "1.2.3", nil)
+	require.NoError(t, err)
+
+	i.signingKey = otherKey
+	_, err = i.Install(context.Background(), "1.2.3", nil)
+	assert.ErrorContains(t, err, "failed to verify the signature")
+}
+
+func Test_parseChecksums(t *testing.T) {
+	content := []byte("ABC123  syncthing-linux-amd64-v1.2.3.tar.gz\ndef456 *syncthing-windows-amd64-v1.2.3.zip\n\ninvalid line here\n")
+	assert.Equal(t, map[string]string{
+		"syncthing-linux-amd64-v1.2.3.tar.gz": "abc123",
+		"syncthing-windows-amd64-v1.2.3.zip":  "def456",
+	}, parseChecksums(content))
+}
+
+func Test_getBinaryPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("OKTETO_FOLDER", home)
+	t.Setenv(model.SyncthingVersionEnvVar, "1.2.3")
+
+	_, err := getBinaryPath()
+	assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+	assert.False(t, IsInstalled())
+	assert.True(t, ShouldUpgrade())
+
+	install := func(path string) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		require.NoError(t, os.WriteFile(path, []byte("syncthing"), 0600))
+	}
+
+	install(getLegacyInstallPath())
+	path, err := getBinaryPath()
+	require.NoError(t, err)
+	assert.Equal(t, getLegacyInstallPath(), path)
+
+	install(getVersionBinaryPath(getCacheDir(), "1.0.0"))
+	install(getVersionBinaryPath(getCacheDir(), "1.1.0"))
+	require.NoError(t, os.MkdirAll(filepath.Join(getCacheDir(), "1.9.0"), 0700))
+	path, err = getBinaryPath()
+	require.NoError(t, err)
+	assert.Equal(t, getVersionBinaryPath(getCacheDir(), "1.1.0"), path)
+	assert.True(t, ShouldUpgrade())
+
+	install(getVersionBinaryPath(getCacheDir(), "1.2.3"))
+	path, err = getBinaryPath()
+	require.NoError(t, err)
+	assert.Equal(t, getVersionBinaryPath(getCacheDir(), "1.2.3"), path)
+	assert.False(t, ShouldUpgrade())
+}
@@ -17,6 +17,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -33,8 +34,10 @@ import (
 	"github.com/google/uuid"
 	"github.com/okteto/okteto/pkg/config"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/filesystem"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/retry"
 	"github.com/shirou/gopsutil/process"
 	"golang.org/x/crypto/bcrypt"
 	yaml "gopkg.in/yaml.v2"
@@ -163,14 +166,31 @@ type ItemEvent struct {
 	GlobalId int                                        `json:"globalID"`
 }
 
+// SyncEvent represents a LocalIndexUpdated or FolderCompletion event in syncthing.
+type SyncEvent struct {
+	Type string        `json:"type"`
+	Data DataSyncEvent `json:"data"`
+	ID   int           `json:"id"`
+}
+
+// DataSyncEvent represents data of a LocalIndexUpdated or FolderCompletion event in syncthing.
+type DataSyncEvent struct {
+	Folder     string  `json:"folder"`
+	Device     string  `json:"device"`
+	Completion float64 `json:"completion"`
+}
+
 // Connections represents syncthing connections.
 type Connections struct {
 	Connections map[string]Connection `json:"connections"`
+	Total       Connection            `json:"total"`
 }
 
 // Connection represents syncthing connection.
 type Connection struct {
-	Connected bool `json:"connected"`
+	InBytesTotal  int64 `json:"inBytesTotal"`
+	OutBytesTotal int64 `json:"outBytesTotal"`
+	Connected     bool  `json:"connected"`
 }
 
 // DownloadProgressData represents an the information about a DownloadProgress event
@@ -180,7 +200,11 @@ type DownloadProgressData struct {
 
 // New constructs a new Syncthing.
 func New(dev *model.Dev) (*Syncthing, error) {
-	fullPath := getInstallPath()
+	fullPath, err := getBinaryPath()
+	if err != nil {
+		oktetoLog.Infof("syncthing binary not found: %s", err)
+		fullPath = getInstallPath()
+	}
 	remotePort, err := model.GetAvailablePort(dev.Interface)
 	if err != nil {
 		return nil, err
@@ -300,6 +324,10 @@ func (s *Syncthing) Run() error {
 		return err
 	}
 
+	if !filesystem.FileExists(s.binPath) {
+		return newNotInstalledError()
+	}
+
 	if s.ResetDatabase {
 		cmd := exec.Command(s.binPath, "-home", s.Home, "-reset-database")
 		output, err := cmd.CombinedOutput()
@@ -336,29 +364,32 @@ func (s *Syncthing) Run() error {
 
 // WaitForPing waits for syncthing to be ready
 func (s *Syncthing) WaitForPing(ctx context.Context, local bool) error {
-	ticker := time.NewTicker(300 * time.Millisecond)
-	to := time.Now().Add(s.timeout)
-
 	oktetoLog.Infof("waiting for syncthing local=%t to be ready", local)
-	for retries := 0; ; retries++ {
-		select {
-		case <-ticker.C:
-			if s.Ping(ctx, local) {
-				return nil
-			}
-			if retries%5 == 0 {
-				oktetoLog.Infof("syncthing local=%t is not ready yet", local)
-			}
-
-			if time.Now().After(to) && retries > 10 {
-				return fmt.Errorf("syncthing local=%t didn't respond after %s", local, s.timeout.String())
-			}
-
-		case <-ctx.Done():
-			oktetoLog.Infof("syncthing.WaitForPing cancelled local=%t", local)
-			return ctx.Err()
+	policy := retry.Policy{
+		Name:            "syncthing ping",
+		InitialInterval: 300 * time.Millisecond,
+		Budget:          s.timeout,
+		MinAttempts:     12,
+	}
+	retries := 0
+	err := retry.Poll(ctx, policy, func(ctx context.Context) (bool, error) {
+		if s.Ping(ctx, local) {
+			return true, nil
+		}
+		if retries%5 == 0 {
+			oktetoLog.Infof("syncthing local=%t is not ready yet", local)
 		}
+		retries++
+		return false, nil
+	})
+	switch {
+	case errors.Is(err, retry.ErrBudgetExhausted):
+		return fmt.Errorf("syncthing local=%t didn't respond after %s", local, s.timeout.String())
+	case err != nil:
+		oktetoLog.Infof("syncthing.WaitForPing cancelled local=%t", local)
+		return err
 	}
+	return nil
 }
 
 // Ping checks if syncthing is available
@@ -404,44 +435,44 @@ func (s *Syncthing) IsAllOverwritten() bool {
 
 // WaitForConnected waits for local and remote syncthing to be connected
 func (s *Syncthing) WaitForConnected(ctx context.Context) error {
-	ticker := time.NewTicker(100 * time.Millisecond)
 	oktetoLog.Info("waiting for remote device to be connected")
-	to := time.Now().Add(s.timeout)
-	for retries := 0; ; retries++ {
+	policy := retry.Policy{
+		Name:            "syncthing connection",
+		InitialInterval: 100 * time.Millisecond,
+		Budget:          s.timeout,
+		MinAttempts:     12,
+	}
+	err := retry.Poll(ctx, policy, func(ctx context.Context) (bool, error) {
 		connections := &Connections{}
 		body, err := s.APICall(ctx, "rest/system/connections", "GET", http.StatusOK, nil, true, nil, true, maxRetries)
 		if err != nil {
+			if ctx.Err() != nil {
+				return false, ctx.Err()
+			}
 			oktetoLog.Infof("error getting connections: %s", err.Error())
 			if strings.Contains(err.Error(), "Client.Timeout") {
-				return oktetoErrors.ErrBusySyncthing
+				return false, oktetoErrors.ErrBusySyncthing
 			}
-			return oktetoErrors.ErrLostSyncthing
+			return false, oktetoErrors.ErrLostSyncthing
 		}
-		err = json.Unmarshal(body, connections)
-		if err != nil {
+		if err := json.Unmarshal(body, connections); err != nil {
 			oktetoLog.Infof("error unmarshalling connections: %s", err.Error())
-			return oktetoErrors.ErrLostSyncthing
-		}
-
-		if connection, ok := connections.Connections[DefaultRemoteDeviceID]; ok {
-			if connection.Connected {
-				return nil
-			}
+			return false, oktetoErrors.ErrLostSyncthing
 		}
 
-		if time.Now().After(to) && retries > 10 {
-			oktetoLog.Infof("remote syncthing connection not completed after %s, please try again", s.timeout.String())
-			return oktetoErrors.ErrLostSyncthing
-		}
-
-		select {
-		case <-ticker.C:
-			continue
-		case <-ctx.Done():
-			oktetoLog.Info("call to syncthing.WaitForConnected canceled")
-			return ctx.Err()
+		if connection, ok := connections.Connections[DefaultRemoteDeviceID]; ok && connection.Connected {
+			return true, nil
 		}
+		return false, nil
+	})
+	switch {
+	case errors.Is(err, retry.ErrBudgetExhausted):
+		oktetoLog.Infof("remote syncthing connection not completed after %s, please try again", s.timeout.String())
+		return oktetoErrors.ErrLostSyncthing
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		oktetoLog.Info("call to syncthing.WaitForConnected canceled")
 	}
+	return err
 }
 
 // WaitForScanning waits for syncthing to finish initial scanning
@@ -744,6 +775,27 @@ func (s *Syncthing) GetFolderErrors(ctx context.Context, local bool) error {
 	return fmt.Errorf("%s: %s", folderErrors.Data.Errors[0].Path, errMsg)
 }
 
+// GetSyncEvents waits for the local changes and completion events of the local syncthing after the event 'since'
+func (s *Syncthing) GetSyncEvents(ctx context.Context, since int, timeout time.Duration) ([]SyncEvent, error) {
+	params := map[string]string{
+		"since":   strconv.Itoa(since),
+		"timeout": strconv.Itoa(int(timeout.Seconds())),
+		"events":  "LocalIndexUpdated,FolderCompletion",
+	}
+	body, err := s.APICall(ctx, "rest/events", "GET", http.StatusOK, params, true, nil, true, maxRetries)
+	if err != nil {
+		oktetoLog.Infof("error getting sync events: %s", err.Error())
+		return nil, oktetoErrors.ErrLostSyncthing
+	}
+
+	events := []SyncEvent{}
+	if err := json.Unmarshal(body, &events); err != nil {
+		oktetoLog.Infof("error unmarshalling events: %s", err.Error())
+		return nil, oktetoErrors.ErrLostSyncthing
+	}
+	return events, nil
+}
+
 // GetInSynchronizationFile the files syncthing
 func (s *Syncthing) GetInSynchronizationFile(ctx context.Context) string {
 	events := []ItemEvent{}
@@ -956,12 +1008,12 @@ func isDirEmpty(path string) (bool, error) {
 	return false, err // Either not empty or error, suits both cases
 }
 
-func getInstallPath() string {
-	return filepath.Join(config.GetOktetoHome(), getBinaryName())
+func getBinaryName() string {
+	return getBinaryNameFor(runtime.GOOS)
 }
 
-func getBinaryName() string {
-	if runtime.GOOS == "windows" {
+func getBinaryNameFor(goos string) string {
+	if goos == "windows" {
 		return "syncthing.exe"
 	}
 
@@ -23,4 +23,6 @@ type Action struct {
 	ID     string `json:"id"`
 	Name   string `json:"name"`
 	Status string `json:"status"`
+	// Actor is the user that triggered the action, if known
+	Actor string `json:"actor,omitempty"`
 }
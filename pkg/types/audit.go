@@ -0,0 +1,25 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "time"
+
+// AuditEvent represents an action done by a user in an Okteto instance
+type AuditEvent struct {
+	Time      time.Time `json:"time" yaml:"time"`
+	User      string    `json:"user" yaml:"user"`
+	Namespace string    `json:"namespace" yaml:"namespace"`
+	Action    string    `json:"action" yaml:"action"`
+	Resource  string    `json:"resource" yaml:"resource"`
+}
@@ -27,6 +27,14 @@ type BuildSshSession struct {
 	Target string
 }
 
+// BuildOutput is the local destination of an image that is exported instead of pushed to the registry
+type BuildOutput struct {
+	// Type is the format of the exported image: 'docker' or 'oci'
+	Type string
+	// Dest is the path of the docker archive or the OCI layout directory
+	Dest string
+}
+
 type HostMap struct {
 	Hostname string
 	IP       string
@@ -34,7 +42,9 @@ type HostMap struct {
 
 // BuildOptions define the options available for build
 type BuildOptions struct {
-	Manifest    *model.Manifest
+	Manifest *model.Manifest
+	// Output exports the image to a local archive instead of pushing it to the registry
+	Output      *BuildOutput
 	File        string
 	OutputMode  string
 	Path        string
@@ -50,11 +60,23 @@ type BuildOptions struct {
 	ExportCache []string
 	// CommandArgs comes from the user input on the command
 	CommandArgs []string
+	// LocalOutputPath exports the filesystem of the built target to this local folder
+	LocalOutputPath string
+	// ScanFailOn is the minimum severity of the vulnerabilities that fail the build
+	ScanFailOn string
 
 	SshSessions []BuildSshSession
 	ExtraHosts  []HostMap
+	// RemoteDeploy configures the runner of the deploy commands when the build runs a remote deploy
+	RemoteDeploy *model.RemoteDeploy
 
 	BuildToGlobal bool
 	NoCache       bool
 	EnableStages  bool
+	// Explain prints the inputs used by smart builds to decide whether an image is rebuilt
+	Explain bool
+	// Lock writes the digests of the built images to the lock file
+	Lock bool
+	// Scan scans the built image for vulnerabilities
+	Scan bool
 }
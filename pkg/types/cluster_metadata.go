@@ -20,6 +20,9 @@ type ClusterMetadata struct {
 	BuildKitInternalIP  string
 	PublicDomain        string
 	CompanyName         string
+	ServerVersion       string
+	MinimumCLIVersion   string
+	Capabilities        []string
 	Certificate         []byte
 	IsTrialLicense      bool
 }
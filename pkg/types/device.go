@@ -0,0 +1,29 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// DeviceCode represents the codes of a device authorization request
+type DeviceCode struct {
+	// DeviceCode identifies the request when polling for the token
+	DeviceCode string `json:"device_code"`
+	// UserCode is the code the user enters in the verification page
+	UserCode        string `json:"user_code"`
+	VerificationURI string `json:"verification_uri"`
+	// VerificationURIComplete is the verification page with the user code already filled in
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	// ExpiresIn is the lifetime in seconds of the codes
+	ExpiresIn int `json:"expires_in"`
+	// Interval is the minimum number of seconds between polling requests
+	Interval int `json:"interval"`
+}
@@ -13,6 +13,8 @@
 
 package types
 
+import "github.com/okteto/okteto/pkg/repository"
+
 // PipelineDeployOptions represents the options to deploy a pipeline
 type PipelineDeployOptions struct {
 	Name       string
@@ -22,6 +24,8 @@ type PipelineDeployOptions struct {
 	Variables  []Variable
 	Namespace  string
 	Labels     []string
+	// Commit is the metadata of the commit being deployed, if known
+	Commit *repository.CommitInfo
 }
 
 // SpaceBody top body answer
@@ -43,6 +47,14 @@ type GitDeploy struct {
 	Status     string `json:"status"`
 }
 
+// PipelineStatus represents the deploy status of a pipeline and the status of the resources it deployed
+type PipelineStatus struct {
+	// Resources maps the full name of each resource, e.g. 'deployment/api', to its status
+	Resources map[string]string
+	Name      string
+	Status    string
+}
+
 // Space represents the contents of an Okteto Cloud space
 type Space struct {
 	GitDeploys   []GitDeploy   `json:"gitDeploys"`
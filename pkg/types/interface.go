@@ -18,6 +18,7 @@ import (
 	"time"
 
 	dockertypes "github.com/docker/cli/cli/config/types"
+	"github.com/okteto/okteto/pkg/repository"
 )
 
 // OktetoInterface represents the client that connects to the backend to create API calls
@@ -48,12 +49,14 @@ type NamespaceInterface interface {
 	Sleep(ctx context.Context, namespace string) error
 	DestroyAll(ctx context.Context, namespace string, destroyVolumes bool) error
 	Wake(ctx context.Context, namespace string) error
+	SetDestroySchedule(ctx context.Context, namespace string, schedule DestroySchedule) error
+	GetDestroySchedule(ctx context.Context, namespace string) (*DestroySchedule, error)
 }
 
 // PreviewInterface represents the client that connects to the preview functions
 type PreviewInterface interface {
-	List(ctx context.Context, labels []string) ([]Preview, error)
-	DeployPreview(ctx context.Context, name, scope, repository, branch, sourceUrl, filename string, variables []Variable, labels []string) (*PreviewResponse, error)
+	List(ctx context.Context, opts PreviewListOptions) ([]Preview, error)
+	DeployPreview(ctx context.Context, name, scope, repository, branch, sourceUrl, filename string, variables []Variable, labels []string, commit *repository.CommitInfo) (*PreviewResponse, error)
 	GetResourcesStatus(ctx context.Context, previewName, devName string) (map[string]string, error)
 	Destroy(ctx context.Context, previewName string) error
 	ListEndpoints(ctx context.Context, previewName string) ([]Endpoint, error)
@@ -66,6 +69,8 @@ type PipelineInterface interface {
 	Destroy(ctx context.Context, name, namespace string, destroyVolumes bool) (*GitDeployResponse, error)
 	GetResourcesStatus(ctx context.Context, name, namespace string) (map[string]string, error)
 	GetByName(ctx context.Context, name, namespace string) (*GitDeploy, error)
+	GetStatus(ctx context.Context, name, namespace string) (*PipelineStatus, error)
+	GetLastAction(ctx context.Context, name, namespace string) (*Action, error)
 	WaitForActionProgressing(ctx context.Context, pipelineName, namespace, actionName string, timeout time.Duration) error
 }
 
@@ -78,6 +83,7 @@ type OktetoClientProvider interface {
 type StreamInterface interface {
 	PipelineLogs(ctx context.Context, name, namespace, actionName string) error
 	DestroyAllLogs(ctx context.Context, namespace string) error
+	AuditEvents(ctx context.Context, since time.Time, handler func(AuditEvent)) error
 }
 
 // KubetokenInterface represents the kubetoken client
@@ -86,6 +92,17 @@ type KubetokenInterface interface {
 	CheckService(baseURL, namespace string) error
 }
 
+// DeviceInterface represents the device authorization client
+type DeviceInterface interface {
+	RequestCode(ctx context.Context, baseURL string) (*DeviceCode, error)
+	PollCode(ctx context.Context, baseURL, deviceCode string) (string, error)
+}
+
+// SessionInterface represents the client of the session of the user
+type SessionInterface interface {
+	RevokeToken(ctx context.Context, baseURL string) error
+}
+
 // EndpointClientInterface represents the endpoint client
 type EndpointClientInterface interface {
 	List(ctx context.Context, ns, label string) ([]string, error)
@@ -13,9 +13,20 @@
 
 package types
 
+import "time"
+
 // Namespace represents an Okteto k8s namespace
 type Namespace struct {
 	ID       string `json:"id" yaml:"id"`
 	Status   string `json:"status" yaml:"status"`
 	Sleeping bool   `json:"sleeping" yaml:"sleeping"`
 }
+
+// DestroySchedule represents when the garbage collector of Okteto destroys a namespace.
+// TTL destroys the namespace once the duration has elapsed, Schedule destroys it periodically following a cron expression.
+// DestroyAt is the next time the namespace will be destroyed, if known
+type DestroySchedule struct {
+	DestroyAt *time.Time    `json:"destroyAt,omitempty" yaml:"destroyAt,omitempty"`
+	Schedule  string        `json:"schedule,omitempty" yaml:"schedule,omitempty"`
+	TTL       time.Duration `json:"ttl,omitempty" yaml:"ttl,omitempty"`
+}
@@ -17,6 +17,9 @@ package types
 type Preview struct {
 	ID            string        `json:"id" yaml:"id"`
 	Scope         string        `json:"scope" yaml:"scope"`
+	Owner         string        `json:"owner,omitempty" yaml:"owner,omitempty"`
+	Branch        string        `json:"branch,omitempty" yaml:"branch,omitempty"`
+	Status        string        `json:"status,omitempty" yaml:"status,omitempty"`
 	GitDeploys    []GitDeploy   `json:"gitDeploys"`
 	Statefulsets  []Statefulset `json:"statefulsets"`
 	Deployments   []Deployment  `json:"deployments"`
@@ -24,6 +27,17 @@ type Preview struct {
 	Sleeping      bool          `json:"sleeping" yaml:"sleeping"`
 }
 
+// PreviewListOptions are the filters and the sorting applied by the server when listing preview environments.
+// Limit is the maximum number of preview environments returned, 0 means all of them
+type PreviewListOptions struct {
+	Owner  string
+	Branch string
+	Status string
+	SortBy string
+	Labels []string
+	Limit  int
+}
+
 // PreviewResponse represents the response of a deployPreview
 type PreviewResponse struct {
 	Action  *Action  `json:"action" yaml:"action"`
@@ -53,3 +67,8 @@ type Endpoint struct {
 	URL     string `json:"url"`
 	Private bool   `json:"private"`
 }
+
+// OutputName returns the url of the endpoint for the 'name' output format
+func (e Endpoint) OutputName() string {
+	return e.URL
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vcs
+
+import (
+	"context"
+	"fmt"
+)
+
+type bitbucketProvider struct {
+	client client
+}
+
+type bitbucketStatus struct {
+	State       string `json:"state"`
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	URL         string `json:"url,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type bitbucketContent struct {
+	Raw string `json:"raw"`
+}
+
+type bitbucketComment struct {
+	Content bitbucketContent `json:"content"`
+}
+
+var bitbucketStates = map[State]string{
+	StatePending: "INPROGRESS",
+	StateSuccess: "SUCCESSFUL",
+	StateFailure: "FAILED",
+}
+
+func (*bitbucketProvider) Name() string {
+	return Bitbucket
+}
+
+func (*bitbucketProvider) PullRequestURL(pr PullRequest) (string, error) {
+	u, err := parseRepositoryURL(pr.Repository)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("https://%s/%s/pull-requests/%d", u.host, u.path, pr.Number), nil
+}
+
+func (b *bitbucketProvider) PublishStatus(ctx context.Context, pr PullRequest, status Status) error {
+	u, err := parseRepositoryURL(pr.Repository)
+	if err != nil {
+		return err
+	}
+	body := bitbucketStatus{
+		State:       bitbucketStates[status.State],
+		Key:         statusContext,
+		Name:        statusContext,
+		URL:         status.TargetURL,
+		Description: status.Description,
+	}
+	return b.client.post(ctx, fmt.Sprintf("/repositories/%s/commit/%s/statuses/build", u.path, pr.SHA), body)
+}
+
+func (b *bitbucketProvider) PublishComment(ctx context.Context, pr PullRequest, body string) error {
+	u, err := parseRepositoryURL(pr.Repository)
+	if err != nil {
+		return err
+	}
+	comment := bitbucketComment{Content: bitbucketContent{Raw: body}}
+	return b.client.post(ctx, fmt.Sprintf("/repositories/%s/pullrequests/%d/comments", u.path, pr.Number), comment)
+}
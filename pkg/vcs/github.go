@@ -0,0 +1,78 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vcs
+
+import (
+	"context"
+	"fmt"
+)
+
+const githubHost = "github.com"
+
+type githubProvider struct {
+	client client
+}
+
+type githubStatus struct {
+	State       string `json:"state"`
+	TargetURL   string `json:"target_url,omitempty"`
+	Description string `json:"description,omitempty"`
+	Context     string `json:"context"`
+}
+
+type githubComment struct {
+	Body string `json:"body"`
+}
+
+// getGitHubAPIURL returns the API URL for github.com and GitHub Enterprise servers
+func getGitHubAPIURL(host string) string {
+	if host == githubHost {
+		return "https://api.github.com"
+	}
+	return fmt.Sprintf("https://%s/api/v3", host)
+}
+
+func (*githubProvider) Name() string {
+	return GitHub
+}
+
+func (*githubProvider) PullRequestURL(pr PullRequest) (string, error) {
+	u, err := parseRepositoryURL(pr.Repository)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("https://%s/%s/pull/%d", u.host, u.path, pr.Number), nil
+}
+
+func (g *githubProvider) PublishStatus(ctx context.Context, pr PullRequest, status Status) error {
+	u, err := parseRepositoryURL(pr.Repository)
+	if err != nil {
+		return err
+	}
+	body := githubStatus{
+		State:       string(status.State),
+		TargetURL:   status.TargetURL,
+		Description: status.Description,
+		Context:     statusContext,
+	}
+	return g.client.post(ctx, fmt.Sprintf("/repos/%s/statuses/%s", u.path, pr.SHA), body)
+}
+
+func (g *githubProvider) PublishComment(ctx context.Context, pr PullRequest, body string) error {
+	u, err := parseRepositoryURL(pr.Repository)
+	if err != nil {
+		return err
+	}
+	return g.client.post(ctx, fmt.Sprintf("/repos/%s/issues/%d/comments", u.path, pr.Number), githubComment{Body: body})
+}
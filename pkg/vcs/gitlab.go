@@ -0,0 +1,87 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vcs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type gitlabProvider struct {
+	client client
+}
+
+type gitlabStatus struct {
+	State       string `json:"state"`
+	TargetURL   string `json:"target_url,omitempty"`
+	Description string `json:"description,omitempty"`
+	Name        string `json:"name"`
+}
+
+type gitlabNote struct {
+	Body string `json:"body"`
+}
+
+var gitlabStates = map[State]string{
+	StatePending: "running",
+	StateSuccess: "success",
+	StateFailure: "failed",
+}
+
+func setGitLabAuth(req *http.Request, token string) {
+	req.Header.Set("PRIVATE-TOKEN", token)
+}
+
+func (*gitlabProvider) Name() string {
+	return GitLab
+}
+
+func (*gitlabProvider) PullRequestURL(pr PullRequest) (string, error) {
+	u, err := parseRepositoryURL(pr.Repository)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("https://%s/%s/-/merge_requests/%d", u.host, u.path, pr.Number), nil
+}
+
+func (g *gitlabProvider) PublishStatus(ctx context.Context, pr PullRequest, status Status) error {
+	u, err := parseRepositoryURL(pr.Repository)
+	if err != nil {
+		return err
+	}
+	body := gitlabStatus{
+		State:       gitlabStates[status.State],
+		TargetURL:   status.TargetURL,
+		Description: status.Description,
+		Name:        statusContext,
+	}
+	return g.authClient().post(ctx, fmt.Sprintf("/projects/%s/statuses/%s", url.PathEscape(u.path), pr.SHA), body)
+}
+
+func (g *gitlabProvider) PublishComment(ctx context.Context, pr PullRequest, body string) error {
+	u, err := parseRepositoryURL(pr.Repository)
+	if err != nil {
+		return err
+	}
+	return g.authClient().post(ctx, fmt.Sprintf("/projects/%s/merge_requests/%d/notes", url.PathEscape(u.path), pr.Number), gitlabNote{Body: body})
+}
+
+// authClient returns the client using the GitLab authentication header
+func (g *gitlabProvider) authClient() client {
+	c := g.client
+	c.setAuth = setGitLabAuth
+	return c
+}
@@ -0,0 +1,232 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vcs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/okteto/okteto/pkg/config"
+	okHttp "github.com/okteto/okteto/pkg/http"
+	giturls "github.com/whilp/git-urls"
+)
+
+const (
+	// GitHub is the provider name for GitHub and GitHub Enterprise repositories
+	GitHub = "github"
+
+	// GitLab is the provider name for GitLab repositories
+	GitLab = "gitlab"
+
+	// Bitbucket is the provider name for Bitbucket Cloud repositories
+	Bitbucket = "bitbucket"
+
+	// TokenEnvVar defines the token used to publish to any VCS provider
+	TokenEnvVar = "OKTETO_VCS_TOKEN"
+
+	// statusContext is the name of the commit statuses published by okteto
+	statusContext = "okteto/preview"
+
+	requestTimeout = 30 * time.Second
+)
+
+var (
+	// ErrUnknownProvider is raised when the VCS provider can't be inferred or is not supported
+	ErrUnknownProvider = errors.New("unknown VCS provider. Accepted values are ['github', 'gitlab', 'bitbucket']")
+
+	// ErrMissingToken is raised when there is no token to authenticate against the VCS provider
+	ErrMissingToken = errors.New("VCS token not found")
+
+	providerTokenEnvVars = map[string]*config.EnvVar{
+		GitHub:    config.EnvGithubToken,
+		GitLab:    config.EnvGitlabToken,
+		Bitbucket: config.EnvBitbucketToken,
+	}
+)
+
+// State is the state of a commit status
+type State string
+
+const (
+	// StatePending is the state of a preview environment being deployed
+	StatePending State = "pending"
+
+	// StateSuccess is the state of a preview environment successfully deployed
+	StateSuccess State = "success"
+
+	// StateFailure is the state of a preview environment that failed to deploy
+	StateFailure State = "failure"
+)
+
+// PullRequest identifies the pull/merge request a preview environment is deployed for
+type PullRequest struct {
+	// Repository is the URL of the repository
+	Repository string
+	SHA        string
+	Number     int
+}
+
+// Status is the commit status published for a preview environment
+type Status struct {
+	State       State
+	Description string
+	TargetURL   string
+}
+
+// Provider publishes information about preview environments to a VCS provider
+type Provider interface {
+	// Name returns the name of the provider
+	Name() string
+
+	// PullRequestURL returns the web URL of a pull/merge request
+	PullRequestURL(pr PullRequest) (string, error)
+
+	// PublishStatus sets a commit status on the SHA of a pull/merge request
+	PublishStatus(ctx context.Context, pr PullRequest, status Status) error
+
+	// PublishComment adds a comment to a pull/merge request
+	PublishComment(ctx context.Context, pr PullRequest, body string) error
+}
+
+// repositoryURL is the parsed URL of a repository
+type repositoryURL struct {
+	host string
+	// path is the repository path without the .git suffix, for example "okteto/okteto"
+	path string
+}
+
+func parseRepositoryURL(repository string) (repositoryURL, error) {
+	u, err := giturls.Parse(repository)
+	if err != nil {
+		return repositoryURL{}, fmt.Errorf("failed to parse repository URL '%s': %w", repository, err)
+	}
+	path := strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git")
+	if u.Hostname() == "" || !strings.Contains(path, "/") {
+		return repositoryURL{}, fmt.Errorf("invalid repository URL '%s'", repository)
+	}
+	return repositoryURL{
+		host: u.Hostname(),
+		path: path,
+	}, nil
+}
+
+// DetectProvider infers the VCS provider from the URL of a repository
+func DetectProvider(repository string) (string, error) {
+	u, err := parseRepositoryURL(repository)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case strings.Contains(u.host, GitHub):
+		return GitHub, nil
+	case strings.Contains(u.host, GitLab):
+		return GitLab, nil
+	case strings.Contains(u.host, Bitbucket):
+		return Bitbucket, nil
+	}
+	return "", ErrUnknownProvider
+}
+
+// GetToken returns the token to authenticate against a VCS provider from the environment
+func GetToken(provider string) (string, error) {
+	if token := config.EnvOktetoVCSToken.Value(); token != "" {
+		return token, nil
+	}
+	providerToken, ok := providerTokenEnvVars[provider]
+	if !ok {
+		return "", fmt.Errorf("%w: set the %s environment variable", ErrMissingToken, TokenEnvVar)
+	}
+	if token := providerToken.Value(); token != "" {
+		return token, nil
+	}
+	return "", fmt.Errorf("%w: set the %s or %s environment variables", ErrMissingToken, TokenEnvVar, providerToken.Name)
+}
+
+// NewProvider returns the provider for a given name. The API URL is inferred from the repository when apiURL is empty
+func NewProvider(name, repository, token, apiURL string) (Provider, error) {
+	u, err := parseRepositoryURL(repository)
+	if err != nil {
+		return nil, err
+	}
+	c := client{
+		http:  &http.Client{Timeout: requestTimeout, Transport: okHttp.SharedDefaultTransport()},
+		token: token,
+	}
+	switch name {
+	case GitHub:
+		if apiURL == "" {
+			apiURL = getGitHubAPIURL(u.host)
+		}
+		c.baseURL = strings.TrimSuffix(apiURL, "/")
+		return &githubProvider{client: c}, nil
+	case GitLab:
+		if apiURL == "" {
+			apiURL = fmt.Sprintf("https://%s/api/v4", u.host)
+		}
+		c.baseURL = strings.TrimSuffix(apiURL, "/")
+		return &gitlabProvider{client: c}, nil
+	case Bitbucket:
+		if apiURL == "" {
+			apiURL = "https://api.bitbucket.org/2.0"
+		}
+		c.baseURL = strings.TrimSuffix(apiURL, "/")
+		return &bitbucketProvider{client: c}, nil
+	}
+	return nil, ErrUnknownProvider
+}
+
+// client sends authenticated requests to the API of a VCS provider
+type client struct {
+	http    *http.Client
+	setAuth func(*http.Request, string)
+	baseURL string
+	token   string
+}
+
+func (c client) post(ctx context.Context, path string, body interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if c.setAuth != nil {
+		c.setAuth(req, c.token)
+	} else {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("request to '%s' failed with status %d: %s", path, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
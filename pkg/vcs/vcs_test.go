@@ -0,0 +1,186 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vcs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectProvider(t *testing.T) {
+	tests := []struct {
+		repository string
+		expected   string
+		expectErr  bool
+	}{
+		{repository: "https://github.com/okteto/movies.git", expected: GitHub},
+		{repository: "git@github.com:okteto/movies.git", expected: GitHub},
+		{repository: "https://gitlab.example.com/group/movies", expected: GitLab},
+		{repository: "https://bitbucket.org/okteto/movies", expected: Bitbucket},
+		{repository: "https://git.example.com/okteto/movies", expectErr: true},
+		{repository: "https://github.com", expectErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.repository, func(t *testing.T) {
+			provider, err := DetectProvider(tt.repository)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, provider)
+		})
+	}
+}
+
+func TestGetToken(t *testing.T) {
+	t.Setenv(TokenEnvVar, "")
+	t.Setenv("GITHUB_TOKEN", "github-token")
+	t.Setenv("GITLAB_TOKEN", "")
+
+	token, err := GetToken(GitHub)
+	assert.NoError(t, err)
+	assert.Equal(t, "github-token", token)
+
+	_, err = GetToken(GitLab)
+	assert.ErrorIs(t, err, ErrMissingToken)
+
+	t.Setenv(TokenEnvVar, "okteto-token")
+	token, err = GetToken(GitLab)
+	assert.NoError(t, err)
+	assert.Equal(t, "okteto-token", token)
+}
+
+func TestNewProviderUnknown(t *testing.T) {
+	_, err := NewProvider("svn", "https://github.com/okteto/movies", "token", "")
+	assert.ErrorIs(t, err, ErrUnknownProvider)
+}
+
+type request struct {
+	body   map[string]interface{}
+	header http.Header
+	path   string
+}
+
+func newTestServer(t *testing.T, requests *[]request) *httptest.Server {
+	t.Helper()
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]interface{}{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		*requests = append(*requests, request{path: r.URL.EscapedPath(), header: r.Header, body: body})
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestProviders(t *testing.T) {
+	pr := PullRequest{
+		SHA:    "abc123",
+		Number: 7,
+	}
+	status := Status{
+		State:     StateSuccess,
+		TargetURL: "https://okteto.example.com/previews/pr-7",
+	}
+	tests := []struct {
+		name             string
+		provider         string
+		repository       string
+		expectedPRURL    string
+		expectedStatus   string
+		expectedComment  string
+		expectedState    string
+		expectedAuthName string
+		expectedAuth     string
+	}{
+		{
+			name:             "github",
+			provider:         GitHub,
+			repository:       "https://github.com/okteto/movies.git",
+			expectedPRURL:    "https://github.com/okteto/movies/pull/7",
+			expectedStatus:   "/repos/okteto/movies/statuses/abc123",
+			expectedComment:  "/repos/okteto/movies/issues/7/comments",
+			expectedState:    "success",
+			expectedAuthName: "Authorization",
+			expectedAuth:     "Bearer token",
+		},
+		{
+			name:             "gitlab",
+			provider:         GitLab,
+			repository:       "https://gitlab.com/group/movies.git",
+			expectedPRURL:    "https://gitlab.com/group/movies/-/merge_requests/7",
+			expectedStatus:   "/projects/group%2Fmovies/statuses/abc123",
+			expectedComment:  "/projects/group%2Fmovies/merge_requests/7/notes",
+			expectedState:    "success",
+			expectedAuthName: "PRIVATE-TOKEN",
+			expectedAuth:     "token",
+		},
+		{
+			name:             "bitbucket",
+			provider:         Bitbucket,
+			repository:       "https://bitbucket.org/okteto/movies.git",
+			expectedPRURL:    "https://bitbucket.org/okteto/movies/pull-requests/7",
+			expectedStatus:   "/repositories/okteto/movies/commit/abc123/statuses/build",
+			expectedComment:  "/repositories/okteto/movies/pullrequests/7/comments",
+			expectedState:    "SUCCESSFUL",
+			expectedAuthName: "Authorization",
+			expectedAuth:     "Bearer token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []request
+			s := newTestServer(t, &requests)
+
+			p, err := NewProvider(tt.provider, tt.repository, "token", s.URL)
+			require.NoError(t, err)
+			assert.Equal(t, tt.provider, p.Name())
+
+			pr := pr
+			pr.Repository = tt.repository
+			prURL, err := p.PullRequestURL(pr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedPRURL, prURL)
+
+			require.NoError(t, p.PublishStatus(context.Background(), pr, status))
+			require.NoError(t, p.PublishComment(context.Background(), pr, "preview deployed"))
+
+			require.Len(t, requests, 2)
+			assert.Equal(t, tt.expectedStatus, requests[0].path)
+			assert.Equal(t, tt.expectedState, requests[0].body["state"])
+			assert.Equal(t, tt.expectedAuth, requests[0].header.Get(tt.expectedAuthName))
+			assert.Equal(t, tt.expectedComment, requests[1].path)
+		})
+	}
+}
+
+func TestPublishError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("forbidden"))
+	}))
+	defer s.Close()
+
+	p, err := NewProvider(GitHub, "https://github.com/okteto/movies", "token", s.URL)
+	require.NoError(t, err)
+	err = p.PublishComment(context.Background(), PullRequest{Repository: "https://github.com/okteto/movies", Number: 1}, "body")
+	assert.ErrorContains(t, err, "status 403: forbidden")
+}
@@ -0,0 +1,179 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wait
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+)
+
+// watchRetryInterval is the time to wait before watching the events again when the watch fails
+const watchRetryInterval = 2 * time.Second
+
+// eventsProgress streams the kubernetes events of a namespace that happen while the operation is waited for
+type eventsProgress struct {
+	start     time.Time
+	c         kubernetes.Interface
+	seen      map[string]int32
+	cancel    context.CancelFunc
+	done      chan struct{}
+	namespace string
+	mu        sync.Mutex
+}
+
+// NewEventsProgress returns a progress that shows the warning events of a namespace raised while waiting.
+// The rest of events are only written to the log file
+func NewEventsProgress(c kubernetes.Interface, namespace string) Progress {
+	return &eventsProgress{
+		c:         c,
+		namespace: namespace,
+		seen:      map[string]int32{},
+	}
+}
+
+// Start watches the events of the namespace in the background until the progress is stopped
+func (p *eventsProgress) Start() {
+	// kubernetes event timestamps have second precision
+	p.start = time.Now().Truncate(time.Second)
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	p.done = make(chan struct{})
+	go func() {
+		defer close(p.done)
+		p.watch(ctx)
+	}()
+}
+
+func (*eventsProgress) Update(context.Context, Status) {}
+
+func (p *eventsProgress) Stop() {
+	if p.cancel == nil {
+		return
+	}
+	p.cancel()
+	<-p.done
+	p.cancel = nil
+}
+
+// watch reports the events of the namespace from the current resource version, watching again from the last
+// received resource version when the watch is closed by the server
+func (p *eventsProgress) watch(ctx context.Context) {
+	resourceVersion := ""
+	for {
+		if resourceVersion == "" {
+			events, err := p.c.CoreV1().Events(p.namespace).List(ctx, metav1.ListOptions{Limit: 1})
+			if err != nil {
+				oktetoLog.Infof("failed to list events of namespace '%s': %s", p.namespace, err)
+				if !sleep(ctx, watchRetryInterval) {
+					return
+				}
+				continue
+			}
+			resourceVersion = events.ResourceVersion
+		}
+
+		w, err := p.c.CoreV1().Events(p.namespace).Watch(ctx, metav1.ListOptions{ResourceVersion: resourceVersion})
+		if err != nil {
+			oktetoLog.Infof("failed to watch events of namespace '%s': %s", p.namespace, err)
+			resourceVersion = ""
+			if !sleep(ctx, watchRetryInterval) {
+				return
+			}
+			continue
+		}
+		resourceVersion = p.consume(ctx, w, resourceVersion)
+		w.Stop()
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// consume reports the events received by a watch until it is closed and returns the last resource version received.
+// It returns an empty resource version if the watch failed and the events must be listed again
+func (p *eventsProgress) consume(ctx context.Context, w watch.Interface, resourceVersion string) string {
+	for {
+		select {
+		case <-ctx.Done():
+			return resourceVersion
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return resourceVersion
+			}
+			if event.Type == watch.Error {
+				oktetoLog.Infof("error watching events of namespace '%s': %v", p.namespace, event.Object)
+				return ""
+			}
+			e, ok := event.Object.(*apiv1.Event)
+			if !ok {
+				continue
+			}
+			resourceVersion = e.ResourceVersion
+			if event.Type == watch.Added || event.Type == watch.Modified {
+				p.report(e)
+			}
+		}
+	}
+}
+
+// report shows an event if it was raised while waiting and it wasn't shown before
+func (p *eventsProgress) report(e *apiv1.Event) {
+	if getEventTime(e).Before(p.start) {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	key := string(e.UID)
+	if count, ok := p.seen[key]; ok && count == e.Count {
+		return
+	}
+	p.seen[key] = e.Count
+	message := fmt.Sprintf("%s/%s: %s", e.InvolvedObject.Kind, e.InvolvedObject.Name, e.Message)
+	if e.Type == apiv1.EventTypeWarning {
+		oktetoLog.Warning(message)
+		return
+	}
+	oktetoLog.Infof("event %s: %s", e.Reason, message)
+}
+
+// sleep waits for the given duration and returns false if the context is cancelled before
+func sleep(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
+
+func getEventTime(e *apiv1.Event) time.Time {
+	switch {
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	default:
+		return e.CreationTimestamp.Time
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wait
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newEvent(name string, ts time.Time, count int32) *apiv1.Event {
+	return &apiv1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test",
+			UID:       types.UID("uid-" + name),
+		},
+		InvolvedObject: apiv1.ObjectReference{Kind: "Pod", Name: "api"},
+		Type:           apiv1.EventTypeWarning,
+		Reason:         "BackOff",
+		Message:        "Back-off restarting failed container",
+		LastTimestamp:  metav1.NewTime(ts),
+		Count:          count,
+	}
+}
+
+func TestEventsProgress(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset(newEvent("old", time.Now().Add(-time.Hour), 1))
+	p := NewEventsProgress(c, "test").(*eventsProgress)
+	p.Start()
+	defer p.Stop()
+
+	seen := func() map[string]int32 {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		result := map[string]int32{}
+		for k, v := range p.seen {
+			result[k] = v
+		}
+		return result
+	}
+
+	// the fake client only sends the events created after the watch starts
+	require.Eventually(t, func() bool {
+		return len(c.Actions()) >= 2
+	}, time.Second, 10*time.Millisecond)
+
+	_, err := c.CoreV1().Events("test").Create(ctx, newEvent("stale", time.Now().Add(-time.Hour), 1), metav1.CreateOptions{})
+	require.NoError(t, err)
+	e, err := c.CoreV1().Events("test").Create(ctx, newEvent("new", time.Now().Add(time.Second), 1), metav1.CreateOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual(map[string]int32{"uid-new": 1}, seen())
+	}, time.Second, 10*time.Millisecond)
+
+	e.Count = 2
+	_, err = c.CoreV1().Events("test").Update(ctx, e, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual(map[string]int32{"uid-new": 2}, seen())
+	}, time.Second, 10*time.Millisecond)
+
+	// the events are listed once to get the resource version to watch from
+	lists := 0
+	for _, action := range c.Actions() {
+		if action.GetVerb() == "list" {
+			lists++
+		}
+	}
+	assert.Equal(t, 1, lists)
+}
+
+func TestEventsProgressStopWithoutStart(t *testing.T) {
+	p := NewEventsProgress(fake.NewSimpleClientset(), "test")
+	p.Stop()
+}
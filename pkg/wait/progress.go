@@ -0,0 +1,107 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wait
+
+import (
+	"context"
+	"fmt"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// Progress renders the status of a long operation while it is waited for
+type Progress interface {
+	Start()
+	Update(ctx context.Context, status Status)
+	Stop()
+}
+
+type noProgress struct{}
+
+func (noProgress) Start() {}
+
+func (noProgress) Update(context.Context, Status) {}
+
+func (noProgress) Stop() {}
+
+// spinnerProgress renders the status of the operation as the text of the spinner
+type spinnerProgress struct {
+	format  func(message string, status Status) string
+	message string
+}
+
+// NewSpinnerProgress returns a progress that shows a spinner with the message and the last status of the operation
+func NewSpinnerProgress(message string) Progress {
+	return &spinnerProgress{
+		message: message,
+		format: func(message string, status Status) string {
+			if s := status.String(); s != "" {
+				return fmt.Sprintf("%s: %s", message, s)
+			}
+			return message
+		},
+	}
+}
+
+// NewPercentageProgress returns a progress that shows a spinner with the message and the percentage of completed work of the operation
+func NewPercentageProgress(message string) Progress {
+	return &spinnerProgress{
+		message: message,
+		format: func(message string, status Status) string {
+			if status.Total == 0 {
+				return message
+			}
+			return fmt.Sprintf("%s (%d%%)", message, status.Completed*100/status.Total)
+		},
+	}
+}
+
+func (p *spinnerProgress) Start() {
+	oktetoLog.Spinner(p.message)
+	oktetoLog.StartSpinner()
+}
+
+func (p *spinnerProgress) Update(_ context.Context, status Status) {
+	oktetoLog.Spinner(p.format(p.message, status))
+}
+
+func (*spinnerProgress) Stop() {
+	oktetoLog.StopSpinner()
+}
+
+// multiProgress renders the status of the operation with several progresses
+type multiProgress []Progress
+
+// NewMultiProgress returns a progress that renders the status of the operation with all the given progresses
+func NewMultiProgress(progresses ...Progress) Progress {
+	return multiProgress(progresses)
+}
+
+func (m multiProgress) Start() {
+	for _, p := range m {
+		p.Start()
+	}
+}
+
+func (m multiProgress) Update(ctx context.Context, status Status) {
+	for _, p := range m {
+		p.Update(ctx, status)
+	}
+}
+
+func (m multiProgress) Stop() {
+	for _, p := range m {
+		p.Stop()
+	}
+}
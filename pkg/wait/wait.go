@@ -0,0 +1,129 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const defaultInterval = 1 * time.Second
+
+// ErrTimeout is the error returned when a condition is not met before the timeout
+var ErrTimeout = errors.New("timed out")
+
+// Status is the state of a long operation observed by a condition
+type Status struct {
+	// Message describes the observed state
+	Message string
+	// Completed and Total are the units of work of the operation, used to render its percentage
+	Completed int
+	Total     int
+}
+
+// String returns the message of the status followed by its units of work, if any
+func (s Status) String() string {
+	if s.Total == 0 {
+		return s.Message
+	}
+	if s.Message == "" {
+		return fmt.Sprintf("%d/%d", s.Completed, s.Total)
+	}
+	return fmt.Sprintf("%s (%d/%d)", s.Message, s.Completed, s.Total)
+}
+
+// Condition checks the state of a long operation. It returns true when the operation is done and the observed status
+type Condition func(ctx context.Context) (bool, Status, error)
+
+// Options configures how a condition is waited for
+type Options struct {
+	// Progress renders the status of the operation while waiting. Nothing is rendered if it is nil
+	Progress Progress
+	// Interval is the time between checks of the condition
+	Interval time.Duration
+	// Timeout is the maximum time to wait for the condition. Zero means no timeout
+	Timeout time.Duration
+	// Immediate checks the condition before waiting for the first interval
+	Immediate bool
+}
+
+// TimeoutError is returned when a condition is not met before the timeout. It carries the last observed status
+type TimeoutError struct {
+	Last    Status
+	Timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	last := e.Last.String()
+	if last == "" {
+		return fmt.Sprintf("timed out after %s", e.Timeout)
+	}
+	return fmt.Sprintf("timed out after %s, last status: %s", e.Timeout, last)
+}
+
+// Is allows to check timeouts with errors.Is(err, ErrTimeout)
+func (*TimeoutError) Is(target error) bool {
+	return target == ErrTimeout
+}
+
+// WaitFor checks a condition every interval until it is done, the condition fails, the context is cancelled or the timeout expires
+func WaitFor(ctx context.Context, condition Condition, opts Options) error {
+	interval := opts.Interval
+	if interval == 0 {
+		interval = defaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var timeout <-chan time.Time
+	if opts.Timeout > 0 {
+		to := time.NewTimer(opts.Timeout)
+		defer to.Stop()
+		timeout = to.C
+	}
+
+	progress := opts.Progress
+	if progress == nil {
+		progress = noProgress{}
+	}
+	progress.Start()
+	defer progress.Stop()
+
+	last := Status{}
+	check := opts.Immediate
+	for {
+		if check {
+			done, status, err := condition(ctx)
+			if err != nil {
+				return err
+			}
+			if done {
+				return nil
+			}
+			last = status
+			progress.Update(ctx, status)
+		}
+		check = true
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout:
+			return &TimeoutError{Last: last, Timeout: opts.Timeout}
+		case <-ticker.C:
+		}
+	}
+}
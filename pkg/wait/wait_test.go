@@ -0,0 +1,89 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wait
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeProgress struct {
+	updates []Status
+	started bool
+	stopped bool
+}
+
+func (p *fakeProgress) Start() { p.started = true }
+
+func (p *fakeProgress) Update(_ context.Context, status Status) {
+	p.updates = append(p.updates, status)
+}
+
+func (p *fakeProgress) Stop() { p.stopped = true }
+
+func TestWaitFor(t *testing.T) {
+	progress := &fakeProgress{}
+	checks := 0
+	err := WaitFor(context.Background(), func(context.Context) (bool, Status, error) {
+		checks++
+		return checks == 3, Status{Message: "deploying", Completed: checks, Total: 3}, nil
+	}, Options{Interval: time.Millisecond, Immediate: true, Progress: progress})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, checks)
+	assert.True(t, progress.started)
+	assert.True(t, progress.stopped)
+	assert.Equal(t, []Status{{Message: "deploying", Completed: 1, Total: 3}, {Message: "deploying", Completed: 2, Total: 3}}, progress.updates)
+}
+
+func TestWaitForTimeout(t *testing.T) {
+	err := WaitFor(context.Background(), func(context.Context) (bool, Status, error) {
+		return false, Status{Message: "pending", Completed: 1, Total: 2}, nil
+	}, Options{Interval: time.Hour, Timeout: 10 * time.Millisecond, Immediate: true})
+
+	assert.ErrorIs(t, err, ErrTimeout)
+	timeoutErr := &TimeoutError{}
+	require.ErrorAs(t, err, &timeoutErr)
+	assert.Equal(t, Status{Message: "pending", Completed: 1, Total: 2}, timeoutErr.Last)
+	assert.Equal(t, "timed out after 10ms, last status: pending (1/2)", err.Error())
+}
+
+func TestWaitForConditionError(t *testing.T) {
+	errCondition := errors.New("deployment failed")
+	err := WaitFor(context.Background(), func(context.Context) (bool, Status, error) {
+		return false, Status{}, errCondition
+	}, Options{Interval: time.Millisecond})
+	assert.ErrorIs(t, err, errCondition)
+}
+
+func TestWaitForContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := WaitFor(ctx, func(context.Context) (bool, Status, error) {
+		return false, Status{}, nil
+	}, Options{Interval: time.Hour, Immediate: true})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestStatusString(t *testing.T) {
+	assert.Equal(t, "", Status{}.String())
+	assert.Equal(t, "pending", Status{Message: "pending"}.String())
+	assert.Equal(t, "1/2", Status{Completed: 1, Total: 2}.String())
+	assert.Equal(t, "pending (1/2)", Status{Message: "pending", Completed: 1, Total: 2}.String())
+}